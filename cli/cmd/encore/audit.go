@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/logrusorgru/aurora/v3"
+	"github.com/spf13/cobra"
+
+	"encore.dev/appruntime/exported/auditlog"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Commands for querying the audit log of your local app",
+}
+
+func init() {
+	var (
+		query  daemonpb.ListAuditLogRequest
+		since  time.Duration
+		limit  int
+		asJSON bool
+	)
+	listCmd := &cobra.Command{
+		Use:     "list [--action=name] [--user=id] [--target=name] [--since=24h]",
+		Short:   "Lists the events recorded in the audit log, newest first",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			query.AppRoot, _ = determineAppRoot()
+			query.Limit = int32(limit)
+			if since > 0 {
+				query.SinceUnixSecs = time.Now().Add(-since).Unix()
+			}
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.ListAuditLog(ctx, &query)
+			if err != nil {
+				fatal(err)
+			}
+
+			entries := make([]*auditlog.Entry, 0, len(resp.Entries))
+			for _, data := range resp.Entries {
+				var e auditlog.Entry
+				if err := json.Unmarshal(data, &e); err != nil {
+					fatal(err)
+				}
+				entries = append(entries, &e)
+			}
+
+			if asJSON {
+				out, _ := json.MarshalIndent(entries, "", "  ")
+				fmt.Println(string(out))
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			_, _ = fmt.Fprint(w, "SEQ\tTIME\tACTOR\tACTION\tTARGET\tENDPOINT\n")
+			for _, e := range entries {
+				actor := e.Actor.UserID
+				if actor == "" {
+					actor = "-"
+				}
+				endpoint := "-"
+				if e.Service != "" && e.Endpoint != "" {
+					endpoint = e.Service + "." + e.Endpoint
+				}
+				_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", e.Seq,
+					e.Time.Local().Format(time.DateTime), actor, e.Action, e.Target, endpoint)
+			}
+			_ = w.Flush()
+		},
+	}
+	listCmd.Flags().StringVar(&query.Action, "action", "", "Only list events with the given action")
+	listCmd.Flags().StringVar(&query.UserId, "user", "", "Only list events performed by the given user id")
+	listCmd.Flags().StringVar(&query.Target, "target", "", "Only list events performed on the given target")
+	listCmd.Flags().DurationVar(&since, "since", 0, "Only list events recorded within the given duration, like 24h")
+	listCmd.Flags().IntVarP(&limit, "limit", "n", 100, "The maximum number of events to list")
+	listCmd.Flags().BoolVar(&asJSON, "json", false, "Output the events as JSON")
+	auditCmd.AddCommand(listCmd)
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies that the audit log has not been tampered with",
+		Long: `Verifies the hash chain of the audit log, where each event includes
+the hash of the event before it. Modifying, removing or reordering recorded
+events breaks the chain. The command exits with a non-zero status if it is broken.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			appRoot, _ := determineAppRoot()
+			daemon := setupDaemon(ctx)
+			resp, err := daemon.VerifyAuditLog(ctx, &daemonpb.VerifyAuditLogRequest{AppRoot: appRoot})
+			if err != nil {
+				fatal(err)
+			}
+			if resp.BrokenSeq > 0 {
+				fmt.Fprintf(os.Stderr, "%s audit log is broken at event %d: %s\n",
+					aurora.Red("error:"), resp.BrokenSeq, resp.BrokenReason)
+				os.Exit(1)
+			}
+			fmt.Printf("%s verified %d audit log events\n", aurora.Green("✔"), resp.EntriesVerified)
+		},
+	}
+	auditCmd.AddCommand(verifyCmd)
+
+	rootCmd.AddCommand(auditCmd)
+}
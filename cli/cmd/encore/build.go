@@ -0,0 +1,684 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/composegen"
+	"encr.dev/pkg/dockerbuild"
+	"encr.dev/pkg/infraconf"
+	"encr.dev/pkg/k8sgen"
+	"encr.dev/pkg/migrategen"
+	"encr.dev/pkg/obsgen"
+	"encr.dev/pkg/tfgen"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Builds artifacts for self-hosting your app",
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+}
+
+// Docker command
+func init() {
+	var (
+		healthCheck bool
+		infraConfig string
+		infraTags   []string
+	)
+	p := ejectParams{
+		CgoEnabled: os.Getenv("CGO_ENABLED") == "1",
+	}
+	dockerCmd := &cobra.Command{
+		Use:   "docker [IMAGE_TAG] [--tag=IMAGE_TAG...] [--platform=linux/amd64,linux/arm64] [--push] [--oci-layout=dir] [--per-service] [--offline] [--infra-config=file]",
+		Short: "Builds a Docker image of your app",
+		Long: `Builds a Docker image of your app, for one or more platforms.
+
+By default the image is built for linux/amd64 and saved to the local Docker daemon.
+Specify several platforms, such as --platform=linux/amd64,linux/arm64, to build a
+multi-platform image, cross-compiling the app for each platform. As the local
+Docker daemon can't store multi-platform images, they must be pushed to a
+registry with --push, or written to a directory in the OCI image layout format
+with --oci-layout.
+
+With --per-service, one image is built for each service and gateway instead,
+hosting only that service or gateway. Each image is tagged by appending its name
+to the repository of IMAGE_TAG: "registry/app:v1" becomes "registry/app-svc:v1".
+Use --manifest to write a JSON manifest of the built images and what they host.
+
+The images are labeled with the commit of the app, the Encore version and a hash
+of the app's API. Pushed images and images written to an OCI layout also get an
+SPDX software bill of materials of the app attached as an attestation, unless
+--sbom=false is given.
+
+With --offline, the image is built without network access, from a pre-populated
+Go module cache (--mod-cache, defaulting to GOMODCACHE), the Encore Go toolchain
+(--goroot) and runtimes (--runtimes), the prebuilt binaries the image needs
+(--binaries) and a base image in the local Docker daemon. Before building, all
+of them are checked and any missing artifacts are reported.
+
+The image can be given several tags with --tag, which can be repeated. Tags can
+contain the placeholders {version}, the app's commit shortened and suffixed with
+"-dirty" if there are uncommitted changes, {commit}, the full commit, and {date},
+the date of the build, as in --tag=registry.internal/app:{version}.
+
+Images are pushed with the credentials of the Docker config. ECR registries can
+also be pushed to with AWS credentials in the environment, or with the role in
+AWS_ROLE_ARN assumed with an OIDC token, such as that of a GitHub Actions
+workflow. GCR and Artifact Registry registries can be pushed to with the Google
+credentials in GOOGLE_APPLICATION_CREDENTIALS, including workload identity
+federation. Use --digest-file to write the digests of the pushed images, and
+--sign-key to sign them with a cosign key, decrypted with COSIGN_PASSWORD.
+
+By default the binary of a Go app includes the drivers of every infrastructure
+provider the Encore runtime supports. Give the infra config of the environment the
+image is deployed to with --infra-config, in the format described by
+'encore infra export --help', to leave out the drivers it doesn't use, like the
+AWS and GCP SDKs of an app using NSQ and Prometheus. This shrinks the binary, but
+the image can then only run with infra configs using the same providers.
+
+The base image, the user to run as, the health check and additional CA
+certificates can also be configured in the "build.docker" section of the
+encore.app file, which the flags take precedence over.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			tags := append(args, p.Tags...)
+			if len(tags) == 0 {
+				fatal("no image tag specified: give IMAGE_TAG or --tag")
+			}
+			p.AppRoot, _ = determineAppRoot()
+			file, err := appfile.ParseFile(filepath.Join(p.AppRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+			docker := file.Build.Docker
+			if !cmd.Flag("base").Changed {
+				if docker.BaseImage != "" {
+					p.BaseImg = docker.BaseImage
+				} else if file.Lang == appfile.LangTS {
+					p.BaseImg = "node:latest"
+				}
+			}
+			if !cmd.Flag("cgo").Changed {
+				p.CgoEnabled = file.Build.CgoEnabled
+			}
+			if !cmd.Flag("user").Changed {
+				p.User = docker.User
+			}
+			if healthCheck || docker.HealthCheck != nil {
+				p.HealthCheck = dockerHealthCheck(docker.HealthCheck)
+			}
+			for i, path := range p.CACerts {
+				if p.CACerts[i], err = filepath.Abs(path); err != nil {
+					fatal(err)
+				}
+			}
+			for _, path := range docker.CACerts {
+				p.CACerts = append(p.CACerts, filepath.Join(p.AppRoot, path))
+			}
+			for _, path := range []*string{&p.Manifest, &p.GoRoot, &p.RuntimesPath, &p.BinariesPath, &p.ModCache, &p.DigestFile, &p.SignKey} {
+				if *path != "" {
+					if *path, err = filepath.Abs(*path); err != nil {
+						fatal(err)
+					}
+				}
+			}
+
+			if infraConfig != "" {
+				if file.Lang != appfile.LangGo {
+					fatal("--infra-config is only supported for Go apps")
+				}
+				p.InfraConfig, err = infraconf.Load(infraConfig, file.Lang, infraTags)
+				if err != nil {
+					fatal(err)
+				}
+			} else if len(infraTags) > 0 {
+				fatal("--infra-tag needs --infra-config")
+			}
+
+			if len(p.Platforms) == 0 {
+				fatal("no platforms specified")
+			} else if len(p.Platforms) > 1 && !p.Push && p.OCILayout == "" {
+				fatal("multi-platform images can't be saved to the local Docker daemon: use --push or --oci-layout")
+			}
+			if (p.DigestFile != "" || p.SignKey != "") && !p.Push {
+				fatal("--digest-file and --sign-key need --push")
+			}
+			p.Goos, p.Goarch, _ = strings.Cut(p.Platforms[0], "/")
+			p.ImageTag, p.Tags = tags[0], tags[1:]
+			dockerEject(p)
+		},
+	}
+
+	dockerCmd.Flags().StringArrayVar(&p.Tags, "tag", nil, "A tag for the image, which may contain {version}, {commit} and {date} (can be repeated)")
+	dockerCmd.Flags().StringSliceVar(&p.Platforms, "platform", []string{"linux/amd64"}, "The platforms to build the image for, in os/arch format")
+	dockerCmd.Flags().BoolVarP(&p.Push, "push", "p", false, "Push the image to the remote repository")
+	dockerCmd.Flags().StringVar(&p.OCILayout, "oci-layout", "", "Write the image to the directory in the OCI image layout format")
+	_ = dockerCmd.MarkFlagDirname("oci-layout")
+	dockerCmd.Flags().StringVar(&p.BaseImg, "base", "scratch", "The base image to build from, such as gcr.io/distroless/static")
+	dockerCmd.Flags().StringVar(&p.User, "user", "", "The user to run the app as, in user[:group] format (e.g. 65532:65532)")
+	dockerCmd.Flags().BoolVar(&healthCheck, "healthcheck", false, "Add a health check probing the app's health endpoint")
+	dockerCmd.Flags().StringArrayVar(&p.CACerts, "ca-cert", nil, "A PEM file of additional CA certificates to trust (can be repeated)")
+	dockerCmd.Flags().BoolVar(&p.CgoEnabled, "cgo", false, "Enable cgo")
+	dockerCmd.Flags().BoolVar(&p.PerService, "per-service", false, "Build one image per service and gateway")
+	dockerCmd.Flags().StringVar(&p.Manifest, "manifest", "", "Write a JSON manifest of the built images to the file")
+	_ = dockerCmd.MarkFlagFilename("manifest", "json")
+	dockerCmd.Flags().BoolVar(&p.SBOM, "sbom", true, "Attach a software bill of materials to pushed and OCI layout images")
+	dockerCmd.Flags().StringVar(&p.DigestFile, "digest-file", "", "Write the digest references of the pushed images to the file")
+	dockerCmd.Flags().StringVar(&p.SignKey, "sign-key", "", "Sign the pushed images with the cosign private key")
+	_ = dockerCmd.MarkFlagFilename("sign-key", "key", "pem")
+	dockerCmd.Flags().BoolVar(&p.Offline, "offline", false, "Build without network access, reporting any missing artifacts")
+	dockerCmd.Flags().StringVar(&p.GoRoot, "goroot", "", "The Encore Go toolchain to build with (defaults to ENCORE_GOROOT)")
+	_ = dockerCmd.MarkFlagDirname("goroot")
+	dockerCmd.Flags().StringVar(&p.RuntimesPath, "runtimes", "", "The Encore runtimes to build with (defaults to ENCORE_RUNTIMES_PATH)")
+	_ = dockerCmd.MarkFlagDirname("runtimes")
+	dockerCmd.Flags().StringVar(&p.BinariesPath, "binaries", "", "A directory of prebuilt binaries, in <os>-<arch> subdirectories")
+	_ = dockerCmd.MarkFlagDirname("binaries")
+	dockerCmd.Flags().StringVar(&p.ModCache, "mod-cache", "", "The Go module cache to build with (defaults to GOMODCACHE)")
+	_ = dockerCmd.MarkFlagDirname("mod-cache")
+	dockerCmd.Flags().StringVar(&infraConfig, "infra-config", "", "Leave out the infrastructure drivers the infra config doesn't use")
+	_ = dockerCmd.MarkFlagFilename("infra-config", "json", "yaml", "yml", "cue")
+	dockerCmd.Flags().StringArrayVar(&infraTags, "infra-tag", nil, "Set the value of a CUE infra config's @tag attribute (key=value)")
+	buildCmd.AddCommand(dockerCmd)
+}
+
+// dockerHealthCheck converts the health check configuration of the app file,
+// which has already been validated, into its protobuf representation.
+func dockerHealthCheck(hc *appfile.DockerHealthCheck) *daemonpb.DockerHealthCheck {
+	if hc == nil {
+		return &daemonpb.DockerHealthCheck{}
+	}
+	seconds := func(s string) int32 {
+		d, _ := time.ParseDuration(s)
+		return int32(d / time.Second)
+	}
+	return &daemonpb.DockerHealthCheck{
+		IntervalSeconds:    seconds(hc.Interval),
+		TimeoutSeconds:     seconds(hc.Timeout),
+		StartPeriodSeconds: seconds(hc.StartPeriod),
+		Retries:            int32(hc.Retries),
+	}
+}
+
+// Compose command
+func init() {
+	var (
+		output string
+		params composegen.Params
+	)
+	composeCmd := &cobra.Command{
+		Use:   "compose IMAGE_TAG [--output=dir] [--port=8080] [--base-url=url]",
+		Short: "Generates a Docker Compose configuration for self-hosting your app",
+		Long: `Generates a Docker Compose configuration for self-hosting your app,
+running its Docker image (as built by 'encore build docker IMAGE_TAG') together
+with Postgres, Redis and NSQ for the databases, caches and Pub/Sub topics it uses.
+
+The runtime configuration wiring the app to the infrastructure is written to
+infra-config.json, and embedded in docker-compose.yaml. The Postgres password and
+the values of the app's secrets are read from the .env file, which is created with
+a random password if it doesn't exist, and is never overwritten.
+
+Regenerate the configuration when the app's infrastructure changes.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			file, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			params.Meta = parseAppMeta(ctx)
+			params.AppSlug = composeProjectName(file.ID, appRoot)
+			params.Lang = file.Lang
+			params.Image = args[0]
+			params.CORS = file.GlobalCORS
+			params.DeployedAt = time.Now()
+
+			// Keep using the Postgres password of the existing env file, as it's
+			// the password of the existing database.
+			envPath := filepath.Join(output, composegen.EnvFile)
+			envData, err := os.ReadFile(envPath)
+			if err != nil && !os.IsNotExist(err) {
+				fatal(err)
+			}
+			params.PostgresPassword = envValue(envData, composegen.PostgresPasswordEnv)
+			if params.PostgresPassword == "" {
+				params.PostgresPassword = randomPassword()
+			}
+
+			files, err := composegen.Generate(params)
+			if err != nil {
+				fatal(err)
+			}
+
+			if err := os.MkdirAll(output, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				perm := os.FileMode(0644)
+				if name == composegen.EnvFile {
+					if envData != nil {
+						continue
+					}
+					perm = 0600
+				}
+				if err := os.WriteFile(filepath.Join(output, name), data, perm); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote the Docker Compose configuration to %s\n", filepath.Join(output, composegen.ComposeFile))
+			if envData == nil {
+				fmt.Fprintf(os.Stderr, "set the values of the app's secrets, if any, in %s\n", envPath)
+			}
+		},
+	}
+
+	composeCmd.Flags().StringVarP(&output, "output", "o", ".", "The directory to write the configuration to")
+	_ = composeCmd.MarkFlagDirname("output")
+	composeCmd.Flags().IntVar(&params.Port, "port", 8080, "The port on the host to serve the app on")
+	composeCmd.Flags().StringVar(&params.BaseURL, "base-url", "", "The URL the app is reachable at (defaults to http://localhost:<port>)")
+	buildCmd.AddCommand(composeCmd)
+}
+
+// Kubernetes command
+func init() {
+	var (
+		output        string
+		infraConfig   string
+		infraTags     []string
+		imageManifest string
+		params        k8sgen.Params
+	)
+	migrate := cmdutil.Oneof{
+		Value:   "job",
+		Allowed: []string{"job", "init-container"},
+		Flag:    "migrate",
+		Desc:    "How to apply the database migrations",
+	}
+	k8sCmd := &cobra.Command{
+		Use:   "k8s [IMAGE_TAG] --infra-config=file [--images=manifest] [--output=dir] [--namespace=ns]",
+		Short: "Generates Kubernetes manifests for self-hosting your app",
+		Long: `Generates Kubernetes manifests for self-hosting your app, running its Docker image
+(as built by 'encore build docker IMAGE_TAG') with a Deployment and a Service for
+each service and gateway, so they can be scaled independently.
+
+The infrastructure the app uses is described by the infra config, in the format
+generated by 'encore build compose', which is used as the base of the runtime
+configuration of each Deployment. It can also be written in YAML or CUE, as
+described by 'encore infra export --help'. The runtime configurations are stored in a
+Secret, and the values of the app's secrets are read from the <app>-secrets Secret,
+which must be created separately. A Job applying the migrations of each database
+is generated as well, or with --migrate=init-container, an init container of each
+Deployment of a service using the database. The migrations are stored in ConfigMaps,
+unless a migration image built from the output of 'encore build migrations' is
+given with --migration-image.
+
+To run the per-service images built by 'encore build docker --per-service', pass
+the manifest written by its --manifest flag with --images. Services and gateways
+missing from the manifest run IMAGE_TAG.
+
+The manifests are listed in a kustomization, so they can be applied with
+'kubectl apply -k <dir>'. Regenerate them when the app changes.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 && imageManifest == "" {
+				fatal("specify the image to run, or an image manifest with --images")
+			}
+			appRoot, _ := determineAppRoot()
+			file, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+			params.InfraConfig, err = infraconf.Load(infraConfig, file.Lang, infraTags)
+			if err != nil {
+				fatal(err)
+			}
+			if imageManifest != "" {
+				if params.Images, err = readImageManifest(imageManifest); err != nil {
+					fatal(err)
+				}
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			params.Meta = parseAppMeta(ctx)
+			params.AppSlug = composeProjectName(file.ID, appRoot)
+			params.Lang = file.Lang
+			if len(args) > 0 {
+				params.Image = args[0]
+			}
+			params.Migrations = readMigrations(appRoot, params.Meta)
+			params.MigrateInInitContainers = migrate.Value == "init-container"
+
+			files, err := k8sgen.Generate(params)
+			if err != nil {
+				fatal(err)
+			}
+			if err := os.MkdirAll(output, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				if err := os.WriteFile(filepath.Join(output, name), data, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote the Kubernetes manifests to %s\n", output)
+		},
+	}
+
+	k8sCmd.Flags().StringVar(&infraConfig, "infra-config", "infra-config.json", "The infra config describing the app's infrastructure")
+	_ = k8sCmd.MarkFlagFilename("infra-config", "json", "yaml", "yml", "cue")
+	k8sCmd.Flags().StringArrayVarP(&infraTags, "tag", "t", nil, "Set the value of a CUE infra config's @tag attribute (key=value)")
+	k8sCmd.Flags().StringVar(&imageManifest, "images", "", "The image manifest written by 'encore build docker --manifest'")
+	_ = k8sCmd.MarkFlagFilename("images", "json")
+	k8sCmd.Flags().StringVarP(&output, "output", "o", "k8s", "The directory to write the manifests to")
+	_ = k8sCmd.MarkFlagDirname("output")
+	k8sCmd.Flags().StringVarP(&params.Namespace, "namespace", "n", "", "The namespace to deploy the app to")
+	k8sCmd.Flags().IntVar(&params.Replicas, "replicas", 1, "The number of replicas of each service and gateway")
+	k8sCmd.Flags().StringVar(&params.CPURequest, "cpu", "100m", "The CPU requested for each replica")
+	k8sCmd.Flags().StringVar(&params.MemoryRequest, "memory", "128Mi", "The memory requested for each replica")
+	k8sCmd.Flags().StringVar(&params.MigrationImage, "migration-image", "", "The migration runner image built from the output of 'encore build migrations'")
+	migrate.AddFlag(k8sCmd)
+	buildCmd.AddCommand(k8sCmd)
+}
+
+// Migrations command
+func init() {
+	var output string
+	migrationsCmd := &cobra.Command{
+		Use:   "migrations [--output=dir]",
+		Short: "Generates a runner applying your app's database migrations",
+		Long: `Generates a migration runner for applying the migrations of your app's databases
+as an explicit deploy step, with its own logs and failure handling, before deploying
+a new version of the app.
+
+The output is the build context of a Docker image: the migrations of each database,
+a run-migrations.sh script applying them with golang-migrate, and a Dockerfile.
+Build and push it with:
+
+    docker build -t MY-MIGRATIONS-IMAGE:TAG <dir> && docker push MY-MIGRATIONS-IMAGE:TAG
+
+The runner reads the URL of each database from DATABASE_URL_<NAME>, such as
+DATABASE_URL_ORDERS, and applies the migrations of all databases unless given the
+names of some as arguments. It stops at the first failure, exiting with status 1.
+Pass the image to 'encore build k8s --migration-image' to apply the migrations
+with it in a Job or init containers.
+
+Regenerate the runner when the app's migrations change.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			md := parseAppMeta(ctx)
+			files, err := migrategen.Generate(migrategen.Params{
+				Meta:       md,
+				Migrations: readMigrations(appRoot, md),
+			})
+			if err != nil {
+				fatal(err)
+			}
+
+			// Remove the previous migrations, so deleted migrations don't linger.
+			if err := os.RemoveAll(filepath.Join(output, migrategen.MigrationsDir)); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				dst := filepath.Join(output, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+					fatal(err)
+				}
+				mode := os.FileMode(0644)
+				if name == migrategen.Script {
+					mode = 0755
+				}
+				if err := os.WriteFile(dst, data, mode); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote the migration runner to %s\n", output)
+		},
+	}
+
+	migrationsCmd.Flags().StringVarP(&output, "output", "o", "migrations-runner", "The directory to write the migration runner to")
+	_ = migrationsCmd.MarkFlagDirname("output")
+	buildCmd.AddCommand(migrationsCmd)
+}
+
+func init() {
+	var (
+		output      string
+		infraConfig string
+		infraTags   []string
+	)
+	observabilityCmd := &cobra.Command{
+		Use:   "observability --infra-config=file [--output=dir]",
+		Short: "Generates monitoring configs for your app's metrics",
+		Long: `Generates the configuration wiring your self-hosted app into a monitoring stack,
+based on the metrics settings of the infra config and the metrics the app defines.
+
+The app pushes its metrics with Prometheus remote write, so the infra config must
+set metrics.prometheus.RemoteWriteURL. The output holds:
+
+    encore-rules.yaml     Prometheus recording rules for the request rate and error
+                          ratio of each endpoint and the rate of the app's counters
+    prometheus.yaml       a Prometheus config loading the rules
+    otel-collector.yaml   an OpenTelemetry Collector pipeline reading the app's metrics
+                          from Prometheus and exporting them with OTLP to $OTLP_ENDPOINT
+
+prometheus.yaml and otel-collector.yaml are only generated when the remote write
+URL is a Prometheus server's /api/v1/write endpoint. Only Go apps export metrics.
+
+Regenerate the configs when the app's metrics change.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			file, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+			infra, err := infraconf.Load(infraConfig, file.Lang, infraTags)
+			if err != nil {
+				fatal(err)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			files, err := obsgen.Generate(obsgen.Params{
+				Meta:        parseAppMeta(ctx),
+				AppSlug:     composeProjectName(file.ID, appRoot),
+				Lang:        file.Lang,
+				InfraConfig: infra,
+			})
+			if err != nil {
+				fatal(err)
+			}
+			if err := os.MkdirAll(output, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				if err := os.WriteFile(filepath.Join(output, name), data, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote the monitoring configs to %s\n", output)
+		},
+	}
+
+	observabilityCmd.Flags().StringVar(&infraConfig, "infra-config", "infra-config.json", "The infra config describing the app's infrastructure")
+	_ = observabilityCmd.MarkFlagFilename("infra-config", "json", "yaml", "yml", "cue")
+	observabilityCmd.Flags().StringArrayVarP(&infraTags, "tag", "t", nil, "Set the value of a CUE infra config's @tag attribute (key=value)")
+	observabilityCmd.Flags().StringVarP(&output, "output", "o", "observability", "The directory to write the configs to")
+	_ = observabilityCmd.MarkFlagDirname("output")
+	buildCmd.AddCommand(observabilityCmd)
+}
+
+// readMigrations reads the migration files of the app's databases,
+// keyed by the database name and then the file name.
+func readMigrations(appRoot string, md *meta.Data) map[string]map[string][]byte {
+	migrations := make(map[string]map[string][]byte)
+	for _, db := range md.SqlDatabases {
+		files := make(map[string][]byte)
+		for _, m := range db.Migrations {
+			data, err := os.ReadFile(filepath.Join(appRoot, filepath.FromSlash(db.GetMigrationRelPath()), m.Filename))
+			if err != nil {
+				fatal(err)
+			}
+			files[m.Filename] = data
+		}
+		migrations[db.Name] = files
+	}
+	return migrations
+}
+
+// readImageManifest reads the manifest written by "encore build docker --manifest",
+// returning the image of each service and gateway.
+func readImageManifest(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest dockerbuild.ImageManifestFile
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse image manifest: %w", err)
+	}
+	images := make(map[string]string)
+	for _, m := range manifest.Manifests {
+		for _, name := range append(slices.Clone(m.BundledServices), m.BundledGateways...) {
+			images[name] = m.Reference
+		}
+	}
+	return images, nil
+}
+
+// Terraform command
+func init() {
+	var (
+		output string
+		cloud  string
+		params tfgen.Params
+	)
+	tfCmd := &cobra.Command{
+		Use:   "terraform --cloud=aws|gcp [--output=dir]",
+		Short: "Generates Terraform provisioning your app's infrastructure",
+		Long: `Generates a Terraform configuration provisioning the infrastructure your app
+uses on AWS or GCP: a Postgres instance for its databases, a Redis instance for
+each cache cluster, Pub/Sub topics and subscriptions, and schedules calling the
+endpoints of its cron jobs.
+
+The configuration outputs the runtime configuration of the app as infra_config,
+in the format generated by 'encore build compose', which can be passed to
+'encore build k8s' or used directly:
+
+    terraform output -raw infra_config > infra-config.json
+
+Regenerate the configuration when the app's infrastructure changes.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			file, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			params.Meta = parseAppMeta(ctx)
+			params.AppSlug = composeProjectName(file.ID, appRoot)
+			params.Lang = file.Lang
+			params.Cloud = tfgen.Cloud(cloud)
+			params.CORS = file.GlobalCORS
+			params.DeployedAt = time.Now()
+
+			files, err := tfgen.Generate(params)
+			if err != nil {
+				fatal(err)
+			}
+			if err := os.MkdirAll(output, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				if err := os.WriteFile(filepath.Join(output, name), data, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote the Terraform configuration to %s\n", output)
+		},
+	}
+
+	tfCmd.Flags().StringVar(&cloud, "cloud", "", "The cloud to provision the infrastructure on (aws or gcp)")
+	_ = tfCmd.MarkFlagRequired("cloud")
+	_ = tfCmd.RegisterFlagCompletionFunc("cloud", cmdutil.AutoCompleteFromStaticList(
+		"aws\tRDS, ElastiCache, SNS/SQS and EventBridge",
+		"gcp\tCloud SQL, Memorystore, Pub/Sub and Cloud Scheduler",
+	))
+	tfCmd.Flags().StringVarP(&output, "output", "o", "terraform", "The directory to write the configuration to")
+	_ = tfCmd.MarkFlagDirname("output")
+	buildCmd.AddCommand(tfCmd)
+}
+
+// composeProjectName returns the name of the Compose project of the app,
+// which must consist of lowercase letters, digits, dashes and underscores.
+func composeProjectName(slug, appRoot string) string {
+	if slug == "" {
+		slug = filepath.Base(appRoot)
+	}
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, slug)
+	return strings.TrimLeft(name, "-_")
+}
+
+// envValue returns the value of the variable in the env file, or "" if it's not set.
+func envValue(data []byte, key string) string {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(strings.TrimSpace(sc.Text()), "=")
+		if ok && strings.TrimSpace(k) == key {
+			return strings.Trim(strings.TrimSpace(v), `"'`)
+		}
+	}
+	return ""
+}
+
+func randomPassword() string {
+	var b [16]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		fatal(err)
+	}
+	return hex.EncodeToString(b[:])
+}
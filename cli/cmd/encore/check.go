@@ -19,6 +19,13 @@ var (
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Checks your application for compile-time errors using Encore's compiler.",
+	Long: `Checks your application for compile-time errors using Encore's compiler.
+
+It also reports string literals that look like hardcoded credentials, such as
+AWS access keys, private keys and connection strings with passwords, so they're
+caught before they're committed. Store them as secrets instead, or add a
+//encore:nosecret comment to the line (or the line above it) if the value
+isn't actually a credential.`,
 
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -6,7 +6,6 @@ import (
 	"net"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
@@ -16,6 +15,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"encr.dev/internal/version"
+	"encr.dev/pkg/daemonclient"
 	"encr.dev/pkg/xos"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
@@ -99,11 +99,7 @@ func StopDaemon() {
 
 // daemonSockPath reports the path to the Encore daemon unix socket.
 func daemonSockPath() (string, error) {
-	cacheDir, err := os.UserCacheDir()
-	if err != nil {
-		return "", fmt.Errorf("could not determine cache dir: %v", err)
-	}
-	return filepath.Join(cacheDir, "encore", "encored.sock"), nil
+	return daemonclient.SocketPath()
 }
 
 // StartDaemonInBackground starts the Encore daemon in the background.
@@ -9,6 +9,7 @@ import (
 
 	"encr.dev/cli/cmd/encore/cmdutil"
 	daemonpkg "encr.dev/cli/cmd/encore/daemon"
+	"encr.dev/internal/conf"
 	"encr.dev/internal/env"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
@@ -34,6 +35,9 @@ func init() {
 	rootCmd.AddCommand(daemonCmd)
 	daemonCmd.Flags().BoolVarP(&daemonizeForeground, "foreground", "f", false, "Start the daemon in the foreground")
 	daemonCmd.AddCommand(daemonEnvCmd)
+	daemonCmd.AddCommand(daemonGCCmd)
+	daemonCmd.AddCommand(daemonNotificationsCmd)
+	daemonCmd.AddCommand(daemonAPITokenCmd)
 }
 
 func setupDaemon(ctx context.Context) daemonpb.DaemonClient {
@@ -50,3 +54,89 @@ var daemonEnvCmd = &cobra.Command{
 		}
 	},
 }
+
+var daemonGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Garbage collects traces, build caches and logs stored by the daemon",
+	Long: `Garbage collects traces, build caches and logs stored by the daemon.
+
+The daemon does this automatically in the background according to the
+retention policy in its configuration, but running it manually also
+compacts the daemon database to return the freed space to the file system.`,
+	Args: cobra.NoArgs,
+	Run: func(cc *cobra.Command, args []string) {
+		ctx := context.Background()
+		daemon := setupDaemon(ctx)
+		resp, err := daemon.GC(ctx, &daemonpb.GCRequest{})
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Printf("Deleted %d traces.\n", resp.TracesDeleted)
+		fmt.Printf("Removed %d stale build directories.\n", resp.BuildDirsRemoved)
+		if resp.LogRotated {
+			fmt.Println("Rotated the daemon log file.")
+		}
+		fmt.Printf("Reclaimed %.1f MiB of disk space.\n", float64(resp.BytesReclaimed)/(1<<20))
+	},
+}
+
+var daemonNotificationsCmd = &cobra.Command{
+	Use:   "notifications [on|off]",
+	Short: "Enables or disables desktop notifications about local build and run failures",
+	Long: `Enables or disables desktop notifications about local build and run failures.
+
+When enabled, the daemon shows a desktop notification when a rebuild
+triggered by a file change fails, a service crashes, or a cron job fails.
+Without arguments it reports whether notifications are enabled.`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"on", "off"},
+	Run: func(cc *cobra.Command, args []string) {
+		cfg, err := conf.LoadDaemonConfig()
+		if err != nil {
+			fatal(err)
+		}
+		if len(args) == 0 {
+			if cfg.Notifications {
+				fmt.Println("Desktop notifications are enabled.")
+			} else {
+				fmt.Println("Desktop notifications are disabled.")
+			}
+			return
+		}
+
+		switch args[0] {
+		case "on":
+			cfg.Notifications = true
+		case "off":
+			cfg.Notifications = false
+		default:
+			fatalf("invalid argument %q: must be 'on' or 'off'", args[0])
+		}
+		if err := conf.WriteDaemonConfig(cfg); err != nil {
+			fatal(err)
+		}
+		if cfg.Notifications {
+			fmt.Println("Desktop notifications enabled.")
+		} else {
+			fmt.Println("Desktop notifications disabled.")
+		}
+	},
+}
+
+var daemonAPITokenCmd = &cobra.Command{
+	Use:   "api-token",
+	Short: "Prints the access token for the REST API of the local development dashboard",
+	Long: `Prints the access token for the REST API of the local development dashboard.
+
+The REST API exposes the traces, logs and metadata of your apps under
+/api/v1 on the dashboard address, for use by custom tooling.
+Pass the token as a bearer token in the Authorization header.`,
+	Args: cobra.NoArgs,
+	Run: func(cc *cobra.Command, args []string) {
+		token, err := conf.DashAPIToken()
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Println(token)
+	},
+}
@@ -3,8 +3,6 @@ package daemon
 import (
 	"context"
 	"database/sql"
-	"embed"
-	_ "embed" // for go:embed
 	"fmt"
 	"io"
 	"io/fs"
@@ -20,10 +18,6 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/cockroachdb/errors"
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database"
-	"github.com/golang-migrate/migrate/v4/database/sqlite3"
-	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/mattn/go-sqlite3" // for "sqlite3" driver
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -34,11 +28,21 @@ import (
 
 	"encr.dev/cli/daemon"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/audit"
+	"encr.dev/cli/daemon/collections"
+	"encr.dev/cli/daemon/crons"
 	"encr.dev/cli/daemon/dash"
 	"encr.dev/cli/daemon/engine"
 	"encr.dev/cli/daemon/engine/trace2"
 	"encr.dev/cli/daemon/engine/trace2/sqlite"
+	"encr.dev/cli/daemon/gc"
+	"encr.dev/cli/daemon/mcp"
+	"encr.dev/cli/daemon/metrics"
+	"encr.dev/cli/daemon/mgmtdb"
 	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/notify"
+	"encr.dev/cli/daemon/ports"
+	"encr.dev/cli/daemon/redis"
 	"encr.dev/cli/daemon/run"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/daemon/sqldb"
@@ -95,18 +99,27 @@ type Daemon struct {
 	DBProxy  *retryingTCPListener
 	Dash     *retryingTCPListener
 	Debug    *retryingTCPListener
+	MCP      *retryingTCPListener
 	EncoreDB *sql.DB
 
 	Apps       *apps.Manager
 	Secret     *secret.Manager
 	RunMgr     *run.Manager
 	NS         *namespace.Manager
+	Ports      *ports.Manager
 	ClusterMgr *sqldb.ClusterManager
 	Trace      trace2.Store
+	Metrics    *metrics.Store
+	Audit      *audit.Store
+	TestAudit  *audit.Store
+	GC         *gc.Collector
 	Server     *daemon.Server
 
 	dev bool // whether we're in development mode
 
+	// dashSecret is the secret required to access the dashboard, or "" if none is required.
+	dashSecret string
+
 	// exit is a channel that shuts down the daemon when sent on.
 	// A nil error indicates graceful exit.
 	exit chan<- error
@@ -116,18 +129,26 @@ type Daemon struct {
 }
 
 func (d *Daemon) init(ctx context.Context) {
+	cfg, err := conf.LoadDaemonConfig()
+	if err != nil {
+		fatal(err)
+	}
+
 	d.Daemon = d.listenDaemonSocket()
-	d.Dash = d.listenTCPRetry("dashboard", 9400)
+	dashHost, dashSecret, dashToken := dashAuth(cfg.Dash)
+	d.Dash = d.listenTCPRetryOnHost("dashboard", dashHost, 9400)
+	d.dashSecret = dashSecret
 	d.DBProxy = d.listenTCPRetry("dbproxy", 9500)
 	d.Runtime = d.listenTCPRetry("runtime", 9600)
 	d.Debug = d.listenTCPRetry("debug", 9700)
+	d.MCP = d.listenTCPRetry("mcp", mcp.DefaultPort)
 	d.EncoreDB = d.openDB()
 
 	d.Apps = apps.NewManager(d.EncoreDB)
 	d.close = append(d.close, d.Apps)
 
-	// If ENCORE_SQLDB_HOST is set, use the external cluster instead of
-	// creating our own docker container cluster.
+	// If ENCORE_SQLDB_HOST is set or an external server is configured,
+	// use the external cluster instead of creating our own docker container cluster.
 	var sqldbDriver sqldb.Driver = &docker.Driver{}
 	if host := os.Getenv("ENCORE_SQLDB_HOST"); host != "" {
 		sqldbDriver = &external.Driver{
@@ -137,26 +158,76 @@ func (d *Daemon) init(ctx context.Context) {
 			SuperuserPassword: os.Getenv("ENCORE_SQLDB_PASSWORD"),
 		}
 		log.Info().Msgf("using external postgres cluster: %s", host)
+	} else if cfg.SQLDB != nil && cfg.SQLDB.ConnString != "" {
+		drv, err := external.ParseConnString(cfg.SQLDB.ConnString)
+		if err != nil {
+			fatalf("invalid sqldb configuration in daemon config: %v", err)
+		}
+		sqldbDriver = drv
+		log.Info().Msgf("using external postgres cluster: %s", drv.Host)
 	}
 
 	d.NS = namespace.NewManager(d.EncoreDB)
+	d.Ports = ports.NewManager(d.EncoreDB)
 	d.ClusterMgr = sqldb.NewClusterManager(sqldbDriver, d.Apps, d.NS)
 
-	d.Trace = sqlite.New(ctx, d.EncoreDB)
+	traceStore := sqlite.New(ctx, d.EncoreDB)
+	d.Trace = traceStore
+	d.Metrics = metrics.NewStore(metrics.DefaultRetention)
+	d.Metrics.ListenTraces(traceStore)
+	d.Audit = audit.NewStore(d.EncoreDB)
+	// Tests record their audit events in memory, keeping them out
+	// of the audit log of the app's local environment.
+	d.TestAudit = audit.NewStore(d.openMemoryDB())
 	d.Secret = secret.New()
 	d.RunMgr = &run.Manager{
 		RuntimePort: d.Runtime.Port(),
 		DBProxyPort: d.DBProxy.Port(),
 		DashPort:    d.Dash.Port(),
+		DashToken:   dashToken,
 		Secret:      d.Secret,
 		ClusterMgr:  d.ClusterMgr,
 	}
 
+	// Use an external Redis server for caches, if configured.
+	if cfg.Redis != nil && cfg.Redis.URL != "" {
+		ext, err := redis.ParseExternal(cfg.Redis.URL)
+		if err != nil {
+			fatalf("invalid redis configuration in daemon config: %v", err)
+		}
+		d.RunMgr.ExternalRedis = ext
+		d.NS.RegisterDeletionHandler(ext)
+		log.Info().Msg("using external redis server")
+	}
+
+	if conf.Offline() {
+		log.Info().Msg("offline mode enabled: not connecting to the encore platform")
+	}
+
+	retention, err := gc.ParsePolicy(cfg.Retention)
+	if err != nil {
+		fatalf("invalid retention configuration in daemon config: %v", err)
+	}
+	d.GC = &gc.Collector{
+		Policy:        retention,
+		DB:            d.EncoreDB,
+		Traces:        traceStore,
+		BuildCacheDir: buildCacheDir(),
+		LogPath:       env.EncoreDaemonLogPath(),
+		IsRunning: func(appID string) bool {
+			return d.RunMgr.FindRunByAppID(appID) != nil
+		},
+	}
+	d.GC.Start(ctx)
+
 	// Register namespace deletion handlers.
 	d.NS.RegisterDeletionHandler(d.ClusterMgr)
 	d.NS.RegisterDeletionHandler(d.RunMgr)
+	d.NS.RegisterDeletionHandler(d.Ports)
+
+	notify.New(d.RunMgr, d.Trace)
 
-	d.Server = daemon.New(d.Apps, d.RunMgr, d.ClusterMgr, d.Secret, d.NS)
+	d.Server = daemon.New(d.Apps, d.RunMgr, d.ClusterMgr, d.Secret, d.NS, d.Ports, d.GC, d.Audit, d.MCP.Port())
 }
 
 func (d *Daemon) serve() {
@@ -165,6 +236,17 @@ func (d *Daemon) serve() {
 	go d.serveDBProxy()
 	go d.serveDash()
 	go d.serveDebug()
+	go d.serveMCP()
+}
+
+// buildCacheDir reports the directory where apps' build caches are stored,
+// or "" if it cannot be determined.
+func buildCacheDir() string {
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(userCacheDir, "encore-build")
 }
 
 // listenDaemonSocket listens on the encored.sock UNIX socket
@@ -233,7 +315,7 @@ func (d *Daemon) serveDaemon() {
 func (d *Daemon) serveRuntime() {
 	log.Info().Stringer("addr", d.Runtime.Addr()).Msg("serving runtime")
 	rec := trace2.NewRecorder(d.Trace)
-	srv := engine.NewServer(d.RunMgr, rec)
+	srv := engine.NewServer(d.RunMgr, rec, d.Metrics, d.Audit, d.TestAudit)
 	d.exit <- http.Serve(d.Runtime, srv)
 }
 
@@ -244,8 +326,22 @@ func (d *Daemon) serveDBProxy() {
 
 func (d *Daemon) serveDash() {
 	log.Info().Stringer("addr", d.Dash.Addr()).Msg("serving dash")
-	srv := dash.NewServer(d.Apps, d.RunMgr, d.Trace, d.Dash.Port())
-	d.exit <- http.Serve(d.Dash, srv)
+	srv := dash.NewServer(d.Apps, d.RunMgr, d.NS, d.ClusterMgr, collections.NewManager(d.EncoreDB), crons.NewHistory(d.EncoreDB), d.Trace, d.Metrics, d.Audit, d.Dash.Port())
+	var h http.Handler = srv
+	if d.dashSecret != "" {
+		h = dash.RequireAuth(d.dashSecret, srv)
+	}
+
+	// The REST API authenticates with its own token,
+	// or with the dashboard secret if there is one.
+	apiToken, err := conf.DashAPIToken()
+	if err != nil {
+		log.Error().Err(err).Msg("could not get dash api token, disabling the REST API")
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/api/", srv.API(apiToken, d.dashSecret))
+	mux.Handle("/", h)
+	d.exit <- http.Serve(d.Dash, mux)
 }
 
 func (d *Daemon) serveDebug() {
@@ -260,14 +356,48 @@ func (d *Daemon) serveDebug() {
 	d.exit <- http.Serve(d.Debug, mux)
 }
 
+func (d *Daemon) serveMCP() {
+	log.Info().Stringer("addr", d.MCP.Addr()).Msg("serving mcp")
+	srv := mcp.NewServer(d.Apps, d.RunMgr, d.NS, d.ClusterMgr, d.Trace)
+	d.exit <- http.Serve(d.MCP, srv)
+}
+
 // listenTCPRetry listens for TCP connections on the given port, retrying
 // in the background if it's already in use.
 func (d *Daemon) listenTCPRetry(component string, port int) *retryingTCPListener {
-	ln := listenLocalhostTCP(component, port)
+	return d.listenTCPRetryOnHost(component, "127.0.0.1", port)
+}
+
+// listenTCPRetryOnHost is like listenTCPRetry but listens on the given host.
+func (d *Daemon) listenTCPRetryOnHost(component, host string, port int) *retryingTCPListener {
+	ln := listenTCPRetry(component, host, port)
 	d.closeOnExit(ln)
 	return ln
 }
 
+// dashAuth reports the host the dashboard should listen on, and the secret required
+// to access it, if any. If a token is generated it's returned so that it can be
+// included in the dashboard URLs the daemon prints.
+func dashAuth(cfg *conf.DashConfig) (host, secret, token string) {
+	host = "127.0.0.1"
+	if cfg != nil && cfg.Host != "" {
+		host = cfg.Host
+	}
+	if cfg != nil && cfg.Password != "" {
+		return host, cfg.Password, ""
+	}
+	if dash.IsLoopback(host) {
+		return host, "", ""
+	}
+
+	token, err := dash.GenerateToken()
+	if err != nil {
+		fatalf("could not generate dashboard access token: %v", err)
+	}
+	log.Warn().Str("host", host).Msg("dashboard listens on a non-loopback address; requiring an access token")
+	return host, token, token
+}
+
 // listenTCP listens for TCP connections on a random port on localhost.
 // If the daemon is in development mode it always listens on devPort instead.
 func (d *Daemon) listenTCP(devPort int) *net.TCPListener {
@@ -308,7 +438,7 @@ func (d *Daemon) openDB() *sql.DB {
 	}
 
 	// Initialize db schema
-	if err := d.runDBMigrations(db); err != nil {
+	if err := mgmtdb.Migrate(db); err != nil {
 		fatalf("unable to migrate management database: %v", err)
 	}
 	d.closeOnExit(db)
@@ -316,64 +446,22 @@ func (d *Daemon) openDB() *sql.DB {
 	return db
 }
 
-//go:embed migrations
-var dbMigrations embed.FS
-
-func (d *Daemon) runDBMigrations(db *sql.DB) error {
-	{
-		// Convert old-style schema definition to golang-migrate, if necessary.
-		var isLegacy bool
-		err := db.QueryRow(`
-			SELECT COUNT(*) > 0 FROM pragma_table_info('schema_migrations') WHERE name = 'dummy'
-		`).Scan(&isLegacy)
-		if err != nil {
-			return err
-		} else if isLegacy {
-			_, _ = db.Exec("DROP TABLE schema_migrations;")
-		}
-	}
-
-	src, err := iofs.New(dbMigrations, "migrations")
-	if err != nil {
-		return fmt.Errorf("read db migrations: %v", err)
-	}
-	instance, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+// openMemoryDB opens an in-memory database with the same schema as the management database.
+func (d *Daemon) openMemoryDB() *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
-		return fmt.Errorf("initialize migration instance: %v", err)
-	}
-	m, err := migrate.NewWithInstance("iofs", src, "encore", instance)
-	if err != nil {
-		return fmt.Errorf("setup migrate instance: %v", err)
+		fatal(err)
 	}
+	// Each connection has its own in-memory database.
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
 
-	err = m.Up()
-	if errors.Is(err, migrate.ErrNoChange) {
-		return nil
+	if err := mgmtdb.Migrate(db); err != nil {
+		fatalf("unable to migrate in-memory database: %v", err)
 	}
-
-	// If we have a dirty migration, reset the dirty flag and try again.
-	// This is safe since all migrations run inside transactions.
-	var dirty migrate.ErrDirty
-	if errors.As(err, &dirty) {
-		// Find the version that preceded the dirty version so
-		// we can force the migration to that version and then
-		// re-apply the migration.
-		var prevVer uint
-		prevVer, err = src.Prev(uint(dirty.Version))
-		targetVer := int(prevVer)
-		if errors.Is(err, fs.ErrNotExist) {
-			// No previous migration exists
-			targetVer = database.NilVersion
-		} else if err != nil {
-			return errors.Wrap(err, "failed to find previous version")
-		}
-
-		if err = m.Force(targetVer); err == nil {
-			err = m.Up()
-		}
-	}
-
-	return err
+	d.closeOnExit(db)
+	return db
 }
 
 // detectSocketClose polls for the unix socket at socketPath to be removed
@@ -472,6 +560,7 @@ func redirectLogOutput() error {
 // and the port still being in use momentarily.
 type retryingTCPListener struct {
 	component string
+	host      string
 	port      int
 	ctx       context.Context
 	cancel    func() // call to cancel ctx
@@ -483,10 +572,11 @@ type retryingTCPListener struct {
 	listenErr     error
 }
 
-func listenLocalhostTCP(component string, port int) *retryingTCPListener {
+func listenTCPRetry(component, host string, port int) *retryingTCPListener {
 	ctx, cancel := context.WithCancel(context.Background())
 	ln := &retryingTCPListener{
 		component:     component,
+		host:          host,
 		port:          port,
 		ctx:           ctx,
 		cancel:        cancel,
@@ -521,7 +611,11 @@ func (ln *retryingTCPListener) Close() error {
 }
 
 func (ln *retryingTCPListener) Addr() net.Addr {
-	return &net.TCPAddr{IP: net.IP{127, 0, 0, 1}, Port: ln.port}
+	ip := net.ParseIP(ln.host)
+	if ip == nil {
+		ip = net.IP{127, 0, 0, 1}
+	}
+	return &net.TCPAddr{IP: ip, Port: ln.port}
 }
 
 func (ln *retryingTCPListener) Port() int {
@@ -532,7 +626,7 @@ func (ln *retryingTCPListener) listen() {
 	defer close(ln.doneListening)
 
 	logger := log.With().Str("component", ln.component).Int("port", ln.port).Logger()
-	addr := "127.0.0.1:" + strconv.Itoa(ln.port)
+	addr := net.JoinHostPort(ln.host, strconv.Itoa(ln.port))
 
 	b := backoff.NewExponentialBackOff()
 	b.InitialInterval = 50 * time.Millisecond
@@ -16,6 +16,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/daemon/sqldb/docker"
+	"encr.dev/cli/internal/containers"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -125,23 +126,27 @@ when using tools like Prisma.
 		}
 
 		// If we have the psql binary, use that.
-		// Otherwise fall back to docker.
+		// Otherwise fall back to running it in a container.
 		var cmd *exec.Cmd
 		if p, err := exec.LookPath("psql"); err == nil {
 			cmd = exec.Command(p, resp.Dsn)
 		} else {
-			fmt.Fprintln(os.Stderr, "encore: no 'psql' executable found in $PATH; using docker to run 'psql' instead.\n\nNote: install psql to hide this message.")
+			rt, err := containers.Detect(ctx)
+			if err != nil {
+				fatal(err)
+			}
+			fmt.Fprintf(os.Stderr, "encore: no 'psql' executable found in $PATH; using %s to run 'psql' instead.\n\nNote: install psql to hide this message.\n", rt.Name())
 			dsn := resp.Dsn
 
 			if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
-				// Docker for {Mac, Windows}'s networking setup requires
-				// using "host.docker.internal" instead of "localhost"
+				// Container runtimes on {Mac, Windows} run in a VM whose networking setup
+				// requires using the runtime's host address instead of "localhost"
 				for _, rep := range []string{"localhost", "127.0.0.1"} {
-					dsn = strings.Replace(dsn, rep, "host.docker.internal", -1)
+					dsn = strings.Replace(dsn, rep, rt.HostAddress(), -1)
 				}
 			}
 
-			cmd = exec.Command("docker", "run", "-it", "--rm", "--network=host", docker.Image, "psql", dsn)
+			cmd = exec.Command(rt.Binary(), "run", "-it", "--rm", "--network=host", docker.Image, "psql", dsn)
 		}
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
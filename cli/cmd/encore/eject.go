@@ -61,6 +61,29 @@ type ejectParams struct {
 	Goos       string
 	Goarch     string
 	CgoEnabled bool
+	Platforms  []string
+	OCILayout  string
+	User       string
+	CACerts    []string
+	PerService bool
+	Manifest   string
+	SBOM       bool
+	Tags       []string // more tags, besides ImageTag
+	DigestFile string
+	SignKey    string
+
+	// InfraConfig is the infra config of the environment the image is
+	// deployed to, used to leave out the drivers it doesn't use.
+	InfraConfig []byte
+
+	// Offline builds without network access, from local artifacts.
+	Offline      bool
+	GoRoot       string
+	RuntimesPath string
+	BinariesPath string
+	ModCache     string
+
+	HealthCheck *daemonpb.DockerHealthCheck
 }
 
 func dockerEject(p ejectParams) {
@@ -75,20 +98,44 @@ func dockerEject(p ejectParams) {
 
 	daemon := setupDaemon(ctx)
 	params := &daemonpb.DockerExportParams{
-		BaseImageTag: p.BaseImg,
+		BaseImageTag:   p.BaseImg,
+		Platforms:      p.Platforms,
+		OciLayoutPath:  p.OCILayout,
+		User:           p.User,
+		Healthcheck:    p.HealthCheck,
+		CaCertPaths:    p.CACerts,
+		PerService:     p.PerService,
+		ManifestPath:   p.Manifest,
+		Sbom:           p.SBOM,
+		Offline:        p.Offline,
+		GoRoot:         p.GoRoot,
+		RuntimesPath:   p.RuntimesPath,
+		BinariesPath:   p.BinariesPath,
+		AdditionalTags: p.Tags,
+		DigestFile:     p.DigestFile,
+		SigningKeyPath: p.SignKey,
+		InfraConfig:    p.InfraConfig,
+	}
+	if p.OCILayout != "" {
+		params.OciLayoutRefName = p.ImageTag
 	}
 	if p.Push {
 		params.PushDestinationTag = p.ImageTag
-	} else {
+	} else if p.OCILayout == "" {
 		params.LocalDaemonTag = p.ImageTag
 	}
 
+	environ := os.Environ()
+	if p.ModCache != "" {
+		environ = append(environ, "GOMODCACHE="+p.ModCache)
+	}
+
 	stream, err := daemon.Export(ctx, &daemonpb.ExportRequest{
 		AppRoot:    p.AppRoot,
 		CgoEnabled: p.CgoEnabled,
 		Goos:       p.Goos,
 		Goarch:     p.Goarch,
-		Environ:    os.Environ(),
+		Environ:    environ,
 		Format: &daemonpb.ExportRequest_Docker{
 			Docker: params,
 		},
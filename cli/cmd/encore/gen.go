@@ -4,13 +4,26 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/logrusorgru/aurora/v3"
 	"github.com/spf13/cobra"
+	"golang.org/x/mod/modfile"
+	"google.golang.org/protobuf/proto"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/internal/flowdiagram"
 	"encr.dev/internal/clientgen"
+	"encr.dev/internal/clientgen/clientgenconfig"
+	"encr.dev/internal/clientgen/jsonschema"
+	"encr.dev/internal/clientgen/protogen"
+	"encr.dev/internal/servergen"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 func init() {
@@ -28,10 +41,13 @@ func init() {
 		excludedServices     []string
 		endpointTags         []string
 		excludedEndpointTags []string
+		openAPIAnnotations   string
+		reactQuery           bool
+		configPath           string
 	)
 
 	genClientCmd := &cobra.Command{
-		Use:   "client [<app-id>] [--env=<name>] [--services=foo,bar] [--excluded-services=baz,qux] [--tags=cache,mobile] [--excluded-tags=internal]",
+		Use:   "client [<app-id>] [--env=<name>] [--services=foo,bar] [--excluded-services=baz,qux] [--tags=cache,mobile] [--excluded-tags=internal] [--config=file]",
 		Short: "Generates an API client for your app",
 		Long: `Generates an API client for your app.
 
@@ -42,15 +58,35 @@ Supported language codes are:
   typescript: A TypeScript client using the Fetch API
   javascript: A JavaScript client using the Fetch API
   go: A Go client using net/http"
+  csharp: A C# client using HttpClient and System.Text.Json
+  dart: A Dart/Flutter client using package:http and json_serializable
   openapi: An OpenAPI specification (EXPERIMENTAL)
 
 By default all services with a non-private API endpoint are included.
 To further narrow down the services to generate, use the '--services' flag.
+
+OpenAPI specifications can be annotated with examples and vendor extensions
+by passing a JSON file with '--openapi-annotations'.
+
+TypeScript clients can include TanStack Query (React Query) hooks
+for calling the endpoints by passing '--react-query'.
+
+When neither '--output' nor '--lang' is given, the clients described by
+the encore-clientgen.yaml file in the app root (or the file given with
+'--config') are generated, so everyone generates the same clients.
 `,
 		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			if output == "" && lang == "" {
-				fatal("specify at least one of --output or --lang.")
+			if output == "" && lang == "" && configPath == "" {
+				if appRoot, _, err := cmdutil.MaybeAppRoot(); err == nil {
+					path := filepath.Join(appRoot, clientgenconfig.FileName)
+					if _, err := os.Stat(path); err == nil {
+						configPath = path
+					}
+				}
+				if configPath == "" {
+					fatal("specify at least one of --output or --lang, or describe the clients to generate in " + clientgenconfig.FileName + ".")
+				}
 			}
 
 			// Determine the app id, either from the argument or from the current directory.
@@ -61,6 +97,14 @@ To further narrow down the services to generate, use the '--services' flag.
 				appID = args[0]
 			}
 
+			if configPath != "" {
+				if output != "" || lang != "" {
+					fatal("--config cannot be combined with --output or --lang.")
+				}
+				genClientsFromConfig(appID, configPath)
+				return
+			}
+
 			if lang == "" {
 				var ok bool
 				l, ok := clientgen.Detect(output)
@@ -72,11 +116,27 @@ To further narrow down the services to generate, use the '--services' flag.
 				// Validate the user input for the language
 				l, err := clientgen.GetLang(lang)
 				if err != nil {
-					fatal(fmt.Sprintf("%s: supported langauges are `typescript`, `javascript`, and `go`", err))
+					fatal(fmt.Sprintf("%s: supported langauges are `typescript`, `javascript`, `go`, `csharp`, and `dart`", err))
 				}
 				lang = string(l)
 			}
 
+			var annotations []byte
+			if openAPIAnnotations != "" {
+				if lang != string(clientgen.LangOpenAPI) {
+					fatal("--openapi-annotations can only be used when generating an OpenAPI specification")
+				}
+				data, err := os.ReadFile(openAPIAnnotations)
+				if err != nil {
+					fatal(fmt.Sprintf("unable to read openapi annotations: %v", err))
+				}
+				annotations = data
+			}
+
+			if reactQuery && lang != string(clientgen.LangTypeScript) {
+				fatal("--react-query can only be used when generating a TypeScript client")
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 			defer cancel()
 
@@ -93,10 +153,14 @@ To further narrow down the services to generate, use the '--services' flag.
 				ExcludedServices:     excludedServices,
 				EndpointTags:         endpointTags,
 				ExcludedEndpointTags: excludedEndpointTags,
+				OpenapiAnnotations:   annotations,
+				ReactQuery:           reactQuery,
+				PackageName:          clientPackageName(lang, "", output),
 			})
 			if err != nil {
 				fatal(err)
 			}
+			printGenWarnings(resp.Warnings)
 
 			if output == "" {
 				_, _ = os.Stdout.Write(resp.Code)
@@ -136,19 +200,267 @@ which may require the user-facing wrapper code to be manually generated.`,
 		},
 	}
 
+	diagramFormat := cmdutil.Oneof{
+		Value:     "svg",
+		Allowed:   []string{"svg", "png", "mermaid"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+	var (
+		diagramOutput string
+		diagramOpts   flowdiagram.Options
+	)
+	genDiagramCmd := &cobra.Command{
+		Use:   "diagram [--format=svg|png|mermaid] [--output=file] [--collapse-infra] [--highlight=service]",
+		Short: "Generates an architecture diagram of your app",
+		Long: `Generates an architecture diagram of your app, like the Flow view
+of the local development dashboard shows, for embedding in design docs.
+
+The format defaults to the extension of the output file. Rendering PNG
+requires rsvg-convert, resvg or ImageMagick to be installed.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("format") {
+				switch filepath.Ext(diagramOutput) {
+				case ".png":
+					diagramFormat.Value = "png"
+				case ".mmd", ".mermaid", ".md":
+					diagramFormat.Value = "mermaid"
+				}
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			md := parseAppMeta(ctx)
+			g, err := flowdiagram.Build(md, diagramOpts)
+			if err != nil {
+				fatal(err)
+			}
+			var out []byte
+			switch diagramFormat.Value {
+			case "mermaid":
+				out = []byte(flowdiagram.Mermaid(g))
+			case "png":
+				if out, err = flowdiagram.PNG(flowdiagram.SVG(g)); err != nil {
+					fatal(err)
+				}
+			default:
+				out = flowdiagram.SVG(g)
+			}
+
+			if diagramOutput == "" {
+				_, _ = os.Stdout.Write(out)
+			} else if err := os.WriteFile(diagramOutput, out, 0644); err != nil {
+				fatal(err)
+			}
+		},
+	}
+
+	schemaFormat := cmdutil.Oneof{
+		Value:     "jsonschema",
+		Allowed:   []string{"jsonschema"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Schema format",
+	}
+	var schemaOutput string
+	genSchemaCmd := &cobra.Command{
+		Use:   "schema --output=dir [--format=jsonschema]",
+		Short: "Generates schemas for your app's API types",
+		Long: `Generates schemas for the types used by your app's APIs,
+for use with schema registries and contract-testing tools.
+
+A JSON Schema document is written for each named type, such as
+"users.User.json". Documents reference each other using relative $refs,
+and properties are named as they are encoded on the wire.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if schemaOutput == "" {
+				fatal("specify the directory to write the schemas to with --output.")
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			md := parseAppMeta(ctx)
+			docs, err := jsonschema.Generate(md)
+			if err != nil {
+				fatal(err)
+			}
+
+			if err := os.MkdirAll(schemaOutput, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range docs {
+				if err := os.WriteFile(filepath.Join(schemaOutput, name), data, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote %d schemas to %s\n", len(docs), schemaOutput)
+		},
+	}
+
+	var (
+		protoOutput string
+		protoOpts   protogen.Options
+	)
+	genProtoCmd := &cobra.Command{
+		Use:   "proto --output=dir [--package=name] [--http-annotations]",
+		Short: "Generates Protocol Buffers definitions of your app's API",
+		Long: `Generates Protocol Buffers definitions of your app's API,
+for use with gRPC gateways and protobuf-based code generation.
+
+A .proto file is written for each service with public or authenticated
+endpoints, defining the service, its endpoints and the messages they use.
+Fields use their names on the wire as their JSON names.
+
+Use '--http-annotations' to annotate the endpoints with their HTTP methods
+and paths using google.api.http options, which requires google/api/annotations.proto.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if protoOutput == "" {
+				fatal("specify the directory to write the definitions to with --output.")
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			md := parseAppMeta(ctx)
+			files, err := protogen.Generate(md, protoOpts)
+			if err != nil {
+				fatal(err)
+			}
+
+			if err := os.MkdirAll(protoOutput, 0755); err != nil {
+				fatal(err)
+			}
+			for name, data := range files {
+				if err := os.WriteFile(filepath.Join(protoOutput, name), data, 0644); err != nil {
+					fatal(err)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "wrote %d proto files to %s\n", len(files), protoOutput)
+		},
+	}
+
+	var (
+		serverSpec   string
+		serverOutput string
+		serverOpts   servergen.Options
+	)
+	genServerCmd := &cobra.Command{
+		Use:   "server --from=spec [--output=dir] [--service=name]",
+		Short: "Scaffolds services from an OpenAPI specification",
+		Long: `Scaffolds services from an OpenAPI specification, in YAML or JSON,
+for migrating an existing API contract to Encore.
+
+The operations are grouped into services by their first tag, and the operations
+without tags are put in the service given by --service. Each service gets a file
+with an endpoint stub for each operation, which returns an Unimplemented error
+until implemented, and a file with the request and response types they use.
+Schemas used by several services are put in a shared "apitypes" package, and if
+any operation requires authentication an auth handler stub is generated.
+
+The files are written to the app root, or the directory given by --output.
+Existing files are never overwritten. The parts of the specification which
+can't be represented in Encore are reported as warnings.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if serverSpec == "" {
+				fatal("specify the OpenAPI specification to scaffold the services from with --from.")
+			}
+			if serverOutput == "" {
+				serverOutput, _ = determineAppRoot()
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+
+			// Import the shared types using the module path of the app.
+			if data, err := os.ReadFile(filepath.Join(serverOutput, "go.mod")); err == nil {
+				serverOpts.ModulePath = modfile.ModulePath(data)
+			}
+			if serverOpts.ModulePath == "" {
+				fatal("could not determine the module path of the app: " + serverOutput + " has no go.mod file with a module path.")
+			}
+
+			spec, err := servergen.Load(ctx, serverSpec)
+			if err != nil {
+				fatal(err)
+			}
+			files, warnings, err := servergen.Generate(spec, serverOpts)
+			if err != nil {
+				fatal(err)
+			}
+
+			names := make([]string, 0, len(files))
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				path := filepath.Join(serverOutput, filepath.FromSlash(name))
+				if _, err := os.Stat(path); err == nil {
+					fatalf("%s already exists; move it out of the way or use --output to write to another directory.", path)
+				}
+			}
+			for _, name := range names {
+				path := filepath.Join(serverOutput, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					fatal(err)
+				}
+				if err := os.WriteFile(path, files[name], 0644); err != nil {
+					fatal(err)
+				}
+			}
+			for _, w := range warnings {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+			}
+			fmt.Fprintf(os.Stderr, "wrote %d files to %s\n", len(files), serverOutput)
+		},
+	}
+
 	genCmd.AddCommand(genClientCmd)
 	genCmd.AddCommand(genWrappersCmd)
+	genCmd.AddCommand(genDiagramCmd)
+	genCmd.AddCommand(genSchemaCmd)
+	genCmd.AddCommand(genProtoCmd)
+	genCmd.AddCommand(genServerCmd)
 
-	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", and \"openapi\" are supported)")
+	genServerCmd.Flags().StringVar(&serverSpec, "from", "", "The OpenAPI specification to scaffold the services from")
+	_ = genServerCmd.MarkFlagFilename("from", "yaml", "yml", "json")
+	genServerCmd.Flags().StringVarP(&serverOutput, "output", "o", "", "The directory to write the services to (defaults to the app root)")
+	_ = genServerCmd.MarkFlagDirname("output")
+	genServerCmd.Flags().StringVar(&serverOpts.DefaultService, "service", "api", "The service of the operations without tags")
+
+	genProtoCmd.Flags().StringVarP(&protoOutput, "output", "o", "", "The directory to write the definitions to")
+	_ = genProtoCmd.MarkFlagDirname("output")
+	genProtoCmd.Flags().StringVar(&protoOpts.Package, "package", "encore", "The prefix of the generated proto package names")
+	genProtoCmd.Flags().BoolVar(&protoOpts.HTTPAnnotations, "http-annotations", false, "Annotate endpoints with their HTTP methods and paths")
+
+	schemaFormat.AddFlag(genSchemaCmd)
+	genSchemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "The directory to write the schemas to")
+	_ = genSchemaCmd.MarkFlagDirname("output")
+
+	diagramFormat.AddFlag(genDiagramCmd)
+	genDiagramCmd.Flags().StringVarP(&diagramOutput, "output", "o", "", "The filename to write the diagram to (defaults to stdout)")
+	genDiagramCmd.Flags().BoolVar(&diagramOpts.CollapseInfra, "collapse-infra", false, "List infrastructure within the services instead of as separate nodes")
+	genDiagramCmd.Flags().StringVar(&diagramOpts.Highlight, "highlight", "", "The name of a service to highlight along with its dependencies")
+
+	genClientCmd.Flags().StringVarP(&lang, "lang", "l", "", "The language to generate code for (\"typescript\", \"javascript\", \"go\", \"csharp\", \"dart\", and \"openapi\" are supported)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("lang", cmdutil.AutoCompleteFromStaticList(
 		"typescript\tA TypeScript client using the in-browser Fetch API",
 		"javascript\tA JavaScript client using the in-browser Fetch API",
 		"go\tA Go client using net/http",
+		"csharp\tA C# client using HttpClient and System.Text.Json",
+		"dart\tA Dart/Flutter client using package:http and json_serializable",
 		"openapi\tAn OpenAPI specification",
 	))
 
 	genClientCmd.Flags().StringVarP(&output, "output", "o", "", "The filename to write the generated client code to")
-	_ = genClientCmd.MarkFlagFilename("output", "go", "ts", "tsx", "js", "jsx")
+	_ = genClientCmd.MarkFlagFilename("output", "go", "ts", "tsx", "js", "jsx", "cs", "dart")
 
 	genClientCmd.Flags().StringVarP(&envName, "env", "e", "", "The environment to fetch the API for (defaults to the primary environment)")
 	_ = genClientCmd.RegisterFlagCompletionFunc("env", cmdutil.AutoCompleteEnvSlug)
@@ -158,4 +470,102 @@ which may require the user-facing wrapper code to be manually generated.`,
 	genClientCmd.Flags().StringSliceVarP(&endpointTags, "tags", "t", nil, "The names of endpoint tags to include in the output")
 	genClientCmd.Flags().
 		StringSliceVar(&excludedEndpointTags, "excluded-tags", nil, "The names of endpoint tags to exclude in the output")
+	genClientCmd.Flags().StringVar(&openAPIAnnotations, "openapi-annotations", "", "A JSON file with examples and vendor extensions to embed in the OpenAPI specification")
+	_ = genClientCmd.MarkFlagFilename("openapi-annotations", "json")
+	genClientCmd.Flags().BoolVar(&reactQuery, "react-query", false, "Generate TanStack Query (React Query) hooks in TypeScript clients")
+	genClientCmd.Flags().StringVar(&configPath, "config", "", "The client generation config file describing the clients to generate (defaults to "+clientgenconfig.FileName+" in the app root)")
+	_ = genClientCmd.MarkFlagFilename("config", "yaml", "yml")
+}
+
+// genClientsFromConfig generates the clients described by the config file at path.
+func genClientsFromConfig(appID, path string) {
+	cfg, err := clientgenconfig.Load(path)
+	if err != nil {
+		fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(cfg.Targets))*15*time.Second)
+	defer cancel()
+	daemon := setupDaemon(ctx)
+
+	for _, t := range cfg.Targets {
+		var annotations []byte
+		if t.OpenAPIAnnotations != "" {
+			if annotations, err = os.ReadFile(t.OpenAPIAnnotations); err != nil {
+				fatal(fmt.Sprintf("unable to read openapi annotations: %v", err))
+			}
+		}
+		services := t.Services
+		if len(services) == 0 {
+			services = []string{"*"}
+		}
+
+		resp, err := daemon.GenClient(ctx, &daemonpb.GenClientRequest{
+			AppId:                appID,
+			EnvName:              cfg.EnvName(t),
+			Lang:                 t.Lang,
+			Services:             services,
+			ExcludedServices:     t.ExcludedServices,
+			EndpointTags:         t.Tags,
+			ExcludedEndpointTags: t.ExcludedTags,
+			OpenapiAnnotations:   annotations,
+			ReactQuery:           t.ReactQuery,
+			PackageName:          clientPackageName(t.Lang, t.Package, t.Output),
+			ServiceNames:         t.ServiceNames,
+		})
+		if err != nil {
+			fatal(fmt.Sprintf("generate %s: %v", t.Output, err))
+		}
+		printGenWarnings(resp.Warnings)
+		if err := os.MkdirAll(filepath.Dir(t.Output), 0755); err != nil {
+			fatal(err)
+		}
+		if err := os.WriteFile(t.Output, resp.Code, 0644); err != nil {
+			fatal(err)
+		}
+		fmt.Fprintf(os.Stderr, "generated %s client: %s\n", t.Lang, t.Output)
+	}
+}
+
+// printGenWarnings prints the warnings reported when generating a client.
+func printGenWarnings(warnings []string) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "%s %s\n", aurora.Yellow("warning:"), w)
+	}
+}
+
+// clientPackageName returns the package name to generate a client with.
+// Dart clients refer to the code json_serializable generates from them by file name,
+// so for them it's the name of the output file.
+func clientPackageName(lang, pkg, output string) string {
+	if lang == string(clientgen.LangDart) && output != "" {
+		return strings.TrimSuffix(filepath.Base(output), filepath.Ext(output))
+	}
+	return pkg
+}
+
+// parseAppMeta parses the app in the current directory and returns its metadata.
+func parseAppMeta(ctx context.Context) *meta.Data {
+	appRoot, wd := determineAppRoot()
+	return parseAppMetaAt(ctx, appRoot, wd)
+}
+
+// parseAppMetaAt is like parseAppMeta but parses the app at appRoot,
+// with wd being the working directory relative to it.
+func parseAppMetaAt(ctx context.Context, appRoot, wd string) *meta.Data {
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    appRoot,
+		WorkingDir: wd,
+		Environ:    os.Environ(),
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		fatal(err)
+	}
+	var md meta.Data
+	if err := proto.Unmarshal(resp.Meta, &md); err != nil {
+		fatal(err)
+	}
+	return &md
 }
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/logrusorgru/aurora/v3"
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/infraconf"
+	"encr.dev/pkg/infravalidate"
+)
+
+var infraCmd = &cobra.Command{
+	Use:   "infra",
+	Short: "Commands for managing the infrastructure of self-hosted apps",
+}
+
+func init() {
+	var (
+		against string
+		tags    []string
+	)
+	format := cmdutil.Oneof{
+		Value:     "text",
+		Allowed:   []string{"text", "json"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+
+	validateCmd := &cobra.Command{
+		Use:   "validate INFRA_CONFIG [--against=dir]",
+		Short: "Validates an infra config against the app's declared resources",
+		Long: `Validates an infra config, like the infra-config.json generated by
+'encore build compose', against the resources declared by the app: its databases,
+caches, Pub/Sub topics and subscriptions, gateways, services and secrets.
+
+The infra config can be written in JSON, YAML or CUE, as described by
+'encore infra export --help'. YAML and CUE configs are checked against the
+schema of the runtime configuration before validating them.
+
+Each problem is reported with a code and the path of the offending value.
+Errors break the app at runtime, while warnings are likely mistakes.
+The command exits with a non-zero status if there are any errors.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, err := filepath.Abs(against)
+			if err != nil {
+				fatal(err)
+			}
+			file, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+			data, err := infraconf.Load(args[0], file.Lang, tags)
+			if err != nil {
+				fatal(err)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer cancel()
+			md := parseAppMetaAt(ctx, appRoot, ".")
+			diags := infravalidate.Validate(md, file.Lang, data)
+
+			switch format.Value {
+			case "json":
+				if diags == nil {
+					diags = []infravalidate.Diagnostic{}
+				}
+				out, _ := json.MarshalIndent(diags, "", "  ")
+				fmt.Println(string(out))
+			default:
+				printInfraDiagnostics(args[0], diags)
+			}
+			if infravalidate.HasErrors(diags) {
+				os.Exit(1)
+			}
+		},
+	}
+
+	validateCmd.Flags().StringVar(&against, "against", ".", "The root directory of the app to validate the infra config against")
+	_ = validateCmd.MarkFlagDirname("against")
+	validateCmd.Flags().StringArrayVarP(&tags, "tag", "t", nil, "Set the value of a CUE infra config's @tag attribute (key=value)")
+	format.AddFlag(validateCmd)
+	infraCmd.AddCommand(validateCmd)
+
+	var output string
+	exportCmd := &cobra.Command{
+		Use:   "export INFRA_CONFIG [--output=file] [--tag=key=value]...",
+		Short: "Converts an infra config to the JSON format read by the app",
+		Long: `Converts an infra config written in YAML or CUE to the JSON format read by
+the app's runtime, for example to set ENCORE_RUNTIME_CONFIG of a deployment.
+The format of the infra config is determined by its extension: .json, .yaml,
+.yml or .cue.
+
+YAML and CUE configs are checked against the schema of the runtime
+configuration, which fills in the defaults of settings that aren't set.
+Fields the runtime doesn't know are reported as errors.
+
+CUE configs can hold the settings of several environments, picking the
+values of an environment with @tag attributes set with --tag:
+
+    _env: "staging" | "production" @tag(env)
+    env_name: _env
+    sql_servers: [{host: "db-\(_env).internal:5432"}]
+
+    encore infra export infra.cue --tag env=staging
+
+Fields starting with an underscore are hidden, and left out of the output.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			appRoot, _ := determineAppRoot()
+			file, err := appfile.ParseFile(filepath.Join(appRoot, appfile.Name))
+			if err != nil {
+				fatal(err)
+			}
+			data, err := infraconf.Load(args[0], file.Lang, tags)
+			if err != nil {
+				fatal(err)
+			}
+			if data, err = infraconf.Indent(data); err != nil {
+				fatal(err)
+			}
+			if output == "" {
+				_, _ = os.Stdout.Write(data)
+				return
+			}
+			if err := os.WriteFile(output, data, 0600); err != nil {
+				fatal(err)
+			}
+			fmt.Fprintf(os.Stderr, "wrote the infra config to %s\n", output)
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "The file to write the infra config to, instead of stdout")
+	_ = exportCmd.MarkFlagFilename("output", "json")
+	exportCmd.Flags().StringArrayVarP(&tags, "tag", "t", nil, "Set the value of a CUE infra config's @tag attribute (key=value)")
+	infraCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(infraCmd)
+}
+
+func printInfraDiagnostics(filename string, diags []infravalidate.Diagnostic) {
+	var errs, warnings int
+	for _, d := range diags {
+		label := fmt.Sprintf("%s[%s]", d.Severity, d.Code)
+		if d.Severity == infravalidate.Error {
+			errs++
+			fmt.Fprint(os.Stderr, aurora.Red(label))
+		} else {
+			warnings++
+			fmt.Fprint(os.Stderr, aurora.Yellow(label))
+		}
+		if d.Path != "" {
+			fmt.Fprintf(os.Stderr, " %s", d.Path)
+		}
+		fmt.Fprintf(os.Stderr, ": %s\n", d.Message)
+	}
+
+	if len(diags) == 0 {
+		fmt.Fprintln(os.Stderr, aurora.Green(filename+" is valid"))
+	} else {
+		fmt.Fprintf(os.Stderr, "\n%s: %d error(s), %d warning(s)\n", filename, errs, warnings)
+	}
+}
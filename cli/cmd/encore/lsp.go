@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Runs the Encore language server",
+	Long: `Runs the Encore language server, communicating over stdin and stdout.
+
+The language server provides Encore-specific diagnostics, hover documentation
+and go-to-definition for API endpoints and infrastructure resources.
+Configure your editor to run "encore lsp" from within the app directory,
+alongside the regular language server for your app's language.`,
+
+	Args:                  cobra.NoArgs,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		appRoot, _ := determineAppRoot()
+		runLanguageServer(appRoot)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
+
+func runLanguageServer(appRoot string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	daemon := setupDaemon(ctx)
+	stream, err := daemon.LanguageServer(ctx)
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	err = stream.Send(&daemonpb.LanguageServerMessage{
+		AppRoot: appRoot,
+		Environ: os.Environ(),
+	})
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+
+	// Forward stdin to the language server.
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				data := append([]byte(nil), buf[:n]...)
+				if err := stream.Send(&daemonpb.LanguageServerMessage{Data: data}); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				_ = stream.CloseSend()
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
+			cmdutil.Fatal(err)
+		}
+		if _, err := os.Stdout.Write(msg.Data); err != nil {
+			return
+		}
+	}
+}
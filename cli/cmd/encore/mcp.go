@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "MCP (Model Context Protocol) commands",
+	Long: `The Encore daemon serves a Model Context Protocol (MCP) server that gives
+AI coding assistants access to your app's service catalog, recent traces
+and logs, and read-only access to its local databases.`,
+}
+
+var mcpStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Prints the URL of the MCP server for the app",
+
+	Args:                  cobra.NoArgs,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		url := mcpURL()
+		fmt.Fprintf(os.Stdout, "MCP server: %s\n\n", url)
+		fmt.Fprintf(os.Stdout, "Configure your AI tool to connect to the URL above using the Streamable HTTP transport.\n"+
+			"For tools that only support the stdio transport, configure the command \"encore mcp run\"\n"+
+			"to run from within the app directory instead.\n")
+	},
+}
+
+var mcpRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Runs the MCP server for the app, communicating over stdin and stdout",
+
+	Args:                  cobra.NoArgs,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := proxyMCP(mcpURL(), os.Stdin, os.Stdout); err != nil {
+			cmdutil.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	mcpCmd.AddCommand(mcpStartCmd)
+	mcpCmd.AddCommand(mcpRunCmd)
+	rootCmd.AddCommand(mcpCmd)
+}
+
+// mcpURL returns the URL of the daemon's MCP server for the current app.
+func mcpURL() string {
+	appRoot, _ := determineAppRoot()
+	ctx := context.Background()
+	daemon := setupDaemon(ctx)
+	resp, err := daemon.MCPConnect(ctx, &daemonpb.MCPConnectRequest{AppRoot: appRoot})
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	return resp.Url
+}
+
+// proxyMCP implements the MCP stdio transport by forwarding each
+// newline-delimited message read from r to the MCP server at url,
+// and writing any response as a line to w.
+func proxyMCP(url string, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4<<20)
+	for scanner.Scan() {
+		msg := bytes.TrimSpace(scanner.Bytes())
+		if len(msg) == 0 {
+			continue
+		}
+		resp, err := http.Post(url, "application/json", bytes.NewReader(msg))
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusAccepted:
+			// Notifications don't have a response.
+		case resp.StatusCode != http.StatusOK:
+			return fmt.Errorf("mcp server responded with %s: %s", resp.Status, bytes.TrimSpace(body))
+		default:
+			if _, err := w.Write(append(bytes.TrimSpace(body), '\n')); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 
 	"github.com/logrusorgru/aurora/v3"
 	"github.com/spf13/cobra"
@@ -33,11 +34,13 @@ var (
 		Desc:        "Compile for debugging (disables some optimizations)",
 		TypeDesc:    "string",
 	}
-	watch    bool
-	listen   string
-	port     uint
-	jsonLogs bool
-	browser  = cmdutil.Oneof{
+	watch       bool
+	listen      string
+	port        uint
+	autoPort    bool
+	jsonLogs    bool
+	secretFlags []string
+	browser     = cmdutil.Oneof{
 		Value:     "auto",
 		Allowed:   []string{"auto", "never", "always"},
 		Flag:      "browser",
@@ -45,6 +48,14 @@ var (
 		Desc:      "Whether to open the local development dashboard in the browser on startup",
 		TypeDesc:  "string",
 	}
+	jsRuntime = cmdutil.Oneof{
+		Value:     "",
+		Allowed:   []string{"node", "bun", "deno"},
+		Flag:      "runtime",
+		FlagShort: "", // no short flag
+		Desc:      "JavaScript runtime to run TypeScript apps with (defaults to node)",
+		TypeDesc:  "string",
+	}
 )
 
 func init() {
@@ -59,6 +70,9 @@ func init() {
 			if !cmd.Flag("watch").Changed && debug.Value != "" {
 				watch = false
 			}
+			// If the user didn't explicitly choose a port, let the daemon
+			// pick the port reserved for the app.
+			autoPort = !cmd.Flag("port").Changed
 			runApp(appRoot, wd)
 		},
 	}
@@ -70,12 +84,14 @@ func init() {
 	runCmd.Flags().StringVar(&listen, "listen", "", "Address to listen on (for example \"0.0.0.0:4000\")")
 	runCmd.Flags().UintVarP(&port, "port", "p", 4000, "Port to listen on")
 	runCmd.Flags().BoolVar(&jsonLogs, "json", false, "Display logs in JSON format")
+	runCmd.Flags().StringArrayVar(&secretFlags, "secret", nil, "Secret value to use if not set on the Encore Platform, as KEY=VALUE (can be repeated)")
 	runCmd.Flags().StringVarP(&nsName, "namespace", "n", "", "Namespace to use (defaults to active namespace)")
 	runCmd.Flags().BoolVar(&color, "color", isTerm, "Whether to display colorized output")
 	runCmd.Flags().BoolVar(&noColor, "no-color", false, "Equivalent to --color=false")
 	runCmd.Flags().MarkHidden("no-color")
 	debug.AddFlag(runCmd)
 	browser.AddFlag(runCmd)
+	jsRuntime.AddFlag(runCmd)
 }
 
 // runApp runs the app.
@@ -93,6 +109,7 @@ func runApp(appRoot, wd string) {
 	} else if _, _, err := net.SplitHostPort(listen); err == nil {
 		// If --listen is given with a port, use that directly and ignore --port.
 		listenAddr = listen
+		autoPort = false
 	} else {
 		// Otherwise use --listen as the host and --port as the port.
 		listenAddr = net.JoinHostPort(listen, strconv.Itoa(int(port)))
@@ -116,6 +133,25 @@ func runApp(appRoot, wd string) {
 		debugMode = daemonpb.RunRequest_DEBUG_BREAK
 	}
 
+	jsRuntimeMode := daemonpb.RunRequest_JS_RUNTIME_DEFAULT
+	switch jsRuntime.Value {
+	case "node":
+		jsRuntimeMode = daemonpb.RunRequest_JS_RUNTIME_NODE
+	case "bun":
+		jsRuntimeMode = daemonpb.RunRequest_JS_RUNTIME_BUN
+	case "deno":
+		jsRuntimeMode = daemonpb.RunRequest_JS_RUNTIME_DENO
+	}
+
+	secretValues := make(map[string]string, len(secretFlags))
+	for _, s := range secretFlags {
+		key, val, ok := strings.Cut(s, "=")
+		if !ok || key == "" {
+			fatalf("invalid --secret %q: expected KEY=VALUE", s)
+		}
+		secretValues[key] = val
+	}
+
 	daemon := setupDaemon(ctx)
 	stream, err := daemon.Run(ctx, &daemonpb.RunRequest{
 		AppRoot:    appRoot,
@@ -123,10 +159,13 @@ func runApp(appRoot, wd string) {
 		Watch:      watch,
 		WorkingDir: wd,
 		ListenAddr: listenAddr,
+		AutoPort:   autoPort,
 		Environ:    os.Environ(),
 		TraceFile:  root.TraceFile,
 		Namespace:  nonZeroPtr(nsName),
 		Browser:    browserMode,
+		JsRuntime:  jsRuntimeMode,
+		Secrets:    secretValues,
 	})
 	if err != nil {
 		fatal(err)
@@ -0,0 +1,284 @@
+package secrets
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/internal/platform"
+	"encr.dev/cli/internal/platform/gql"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var (
+	auditFormat = cmdutil.Oneof{
+		Value:     "table",
+		Allowed:   []string{"table", "json"},
+		Flag:      "format",
+		FlagShort: "f",
+		Desc:      "Output format",
+	}
+	auditAllow []string
+)
+
+var auditSecretCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audits the app's secrets against their usage and values",
+	Long: `
+Audits the app's secrets by cross-referencing the secrets declared in the
+code, the services reading them, and the values set in each environment.
+
+It reports:
+
+  - unused: secrets with values set that the app doesn't declare
+  - unexpected-service: secrets read by more than one service, or by every
+    service because they're declared outside of a service, unless allowed
+    with --allow
+  - missing-value: declared secrets without a value for an environment
+
+Exits with status 1 if there are any findings, to use it in CI.
+`,
+
+	Example: `
+Auditing the app's secrets:
+
+	$ encore secret audit
+
+Allowing the StripeKey secret to be read by the billing and checkout services,
+and reporting the findings as JSON:
+
+	$ encore secret audit --allow StripeKey=billing,checkout --format json`,
+	Args:                  cobra.NoArgs,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		allowed := make(map[string][]string)
+		for _, a := range auditAllow {
+			key, svcs, ok := strings.Cut(a, "=")
+			if !ok || key == "" || svcs == "" {
+				cmdutil.Fatalf("invalid --allow %q: want <secret>=<service>[,<service>...]", a)
+			}
+			allowed[key] = append(allowed[key], strings.Split(svcs, ",")...)
+		}
+		auditSecrets(allowed)
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(auditSecretCmd)
+	auditFormat.AddFlag(auditSecretCmd)
+	auditSecretCmd.Flags().StringArrayVar(&auditAllow, "allow", nil, "services allowed to read a secret, as <secret>=<service>[,<service>...] (repeatable)")
+}
+
+// The kinds of audit findings.
+const (
+	findingUnused            = "unused"
+	findingUnexpectedService = "unexpected-service"
+	findingMissingValue      = "missing-value"
+)
+
+// auditFinding is a problem with a secret found by an audit.
+type auditFinding struct {
+	Kind     string   `json:"kind"`
+	Secret   string   `json:"secret"`
+	Services []string `json:"services,omitempty"` // for unexpected-service findings
+	Env      string   `json:"env,omitempty"`      // for missing-value findings
+	Message  string   `json:"message"`
+}
+
+func auditSecrets(allowed map[string][]string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	appSlug := cmdutil.AppSlug()
+	md := appMeta(ctx)
+
+	secrets, err := platform.ListSecretGroups(ctx, appSlug, nil)
+	if err != nil {
+		cmdutil.Fatalf("unable to list secrets: %v", err)
+	}
+	envs, err := platform.ListEnvs(ctx, appSlug)
+	if err != nil {
+		cmdutil.Fatalf("unable to list environments: %v", err)
+	}
+
+	findings := auditFindings(md, secrets, envs, allowed)
+	switch auditFormat.Value {
+	case "json":
+		if findings == nil {
+			findings = []auditFinding{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			cmdutil.Fatal(err)
+		}
+	default:
+		if len(findings) == 0 {
+			fmt.Println("No problems found.")
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		_, _ = fmt.Fprint(w, "Secret Key\tFinding\tDetails\t\n")
+		for _, f := range findings {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t\n", f.Secret, f.Kind, f.Message)
+		}
+		_ = w.Flush()
+	}
+	if len(findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// appMeta parses the app in the working directory and returns its metadata.
+func appMeta(ctx context.Context) *meta.Data {
+	appRoot, relPath := cmdutil.AppRoot()
+	daemon := cmdutil.ConnectDaemon(ctx)
+	resp, err := daemon.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    appRoot,
+		WorkingDir: relPath,
+		Environ:    os.Environ(),
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		cmdutil.Fatalf("unable to parse app: %v", err)
+	}
+	var md meta.Data
+	if err := proto.Unmarshal(resp.Meta, &md); err != nil {
+		cmdutil.Fatalf("unable to parse app metadata: %v", err)
+	}
+	return &md
+}
+
+// auditFindings cross-references the secrets declared in md with the services
+// reading them and the secret values set for the app's environments.
+// Secrets may be read by the services in allowed, keyed by secret.
+func auditFindings(md *meta.Data, secrets []*gql.Secret, envs []*platform.Env, allowed map[string][]string) []auditFinding {
+	var allSvcs []string
+	for _, svc := range md.Svcs {
+		allSvcs = append(allSvcs, svc.Name)
+	}
+	slices.Sort(allSvcs)
+
+	// Determine the services reading each secret. Secrets declared
+	// outside of a service are provided to every service.
+	readers := make(map[string][]string)
+	for _, pkg := range md.Pkgs {
+		for _, key := range pkg.Secrets {
+			if pkg.ServiceName != "" {
+				readers[key] = append(readers[key], pkg.ServiceName)
+			} else {
+				readers[key] = append(readers[key], allSvcs...)
+			}
+		}
+	}
+	for key, svcs := range readers {
+		slices.Sort(svcs)
+		readers[key] = slices.Compact(svcs)
+	}
+
+	var findings []auditFinding
+	for _, s := range secrets {
+		if _, declared := readers[s.Key]; !declared && getSecretEnvDesc(s.Groups).hasAny {
+			findings = append(findings, auditFinding{
+				Kind:    findingUnused,
+				Secret:  s.Key,
+				Message: "has values but is not declared by the app",
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(readers))
+	for key := range readers {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		svcs := readers[key]
+		var unexpected []string
+		if allow, ok := allowed[key]; ok {
+			for _, svc := range svcs {
+				if !slices.Contains(allow, svc) {
+					unexpected = append(unexpected, svc)
+				}
+			}
+		} else if len(svcs) > 1 {
+			unexpected = svcs
+		}
+		if len(unexpected) > 0 {
+			findings = append(findings, auditFinding{
+				Kind:     findingUnexpectedService,
+				Secret:   key,
+				Services: unexpected,
+				Message:  "read by " + strings.Join(unexpected, ", "),
+			})
+		}
+	}
+
+	// Check the values of each environment, along with local development
+	// and preview environments, which come and go.
+	type auditEnv struct {
+		name, id, envType string
+	}
+	checkEnvs := []auditEnv{{name: "local", envType: "local"}, {name: "preview", envType: "preview"}}
+	for _, env := range envs {
+		if t := envTypeAliases[env.Type]; t != "preview" {
+			checkEnvs = append(checkEnvs, auditEnv{name: env.Slug, id: env.ID, envType: t})
+		}
+	}
+	groups := make(map[string][]*gql.SecretGroup)
+	for _, s := range secrets {
+		groups[s.Key] = append(groups[s.Key], s.Groups...)
+	}
+	for _, key := range keys {
+		for _, env := range checkEnvs {
+			if !hasValueFor(groups[key], env.id, env.envType) {
+				findings = append(findings, auditFinding{
+					Kind:    findingMissingValue,
+					Secret:  key,
+					Env:     env.name,
+					Message: "no value for " + env.name,
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b auditFinding) int {
+		return cmp.Compare(a.Secret, b.Secret)
+	})
+	return findings
+}
+
+// hasValueFor reports whether any of the secret groups provides
+// a value for the environment with the given id and type.
+func hasValueFor(groups []*gql.SecretGroup, envID, envType string) bool {
+	for _, g := range groups {
+		if g.ArchivedAt != nil {
+			continue
+		}
+		for _, sel := range g.Selector {
+			switch sel := sel.(type) {
+			case *gql.SecretSelectorEnvType:
+				if sel.Kind == envType {
+					return true
+				}
+			case *gql.SecretSelectorSpecificEnv:
+				if envID != "" && sel.Env != nil && sel.Env.ID == envID {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package secrets
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/internal/platform"
+	"encr.dev/cli/internal/platform/gql"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestAuditFindings(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs: []*meta.Service{{Name: "billing"}, {Name: "checkout"}, {Name: "email"}},
+		Pkgs: []*meta.Package{
+			{RelPath: "billing", ServiceName: "billing", Secrets: []string{"StripeKey"}},
+			{RelPath: "checkout", ServiceName: "checkout", Secrets: []string{"StripeKey"}},
+			{RelPath: "email", ServiceName: "email", Secrets: []string{"SendgridKey"}},
+			{RelPath: "pkg/tracing", Secrets: []string{"HoneycombKey"}},
+		},
+	}
+	envs := []*platform.Env{
+		{ID: "env-prod", Slug: "prod", Type: "production"},
+		{ID: "env-staging", Slug: "staging", Type: "development"},
+		{ID: "env-pr", Slug: "pr:12", Type: "preview"},
+	}
+	archived := time.Now()
+	secrets := []*gql.Secret{
+		{Key: "StripeKey", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "production"}}},
+			{Selector: []gql.SecretSelector{
+				&gql.SecretSelectorEnvType{Kind: "local"},
+				&gql.SecretSelectorEnvType{Kind: "preview"},
+				&gql.SecretSelectorSpecificEnv{Env: &gql.Env{ID: "env-staging"}},
+			}},
+		}},
+		{Key: "SendgridKey", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{
+				&gql.SecretSelectorEnvType{Kind: "production"},
+				&gql.SecretSelectorEnvType{Kind: "development"},
+				&gql.SecretSelectorEnvType{Kind: "local"},
+			}},
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "preview"}}, ArchivedAt: &archived},
+		}},
+		{Key: "HoneycombKey", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{
+				&gql.SecretSelectorEnvType{Kind: "production"},
+				&gql.SecretSelectorEnvType{Kind: "development"},
+				&gql.SecretSelectorEnvType{Kind: "preview"},
+				&gql.SecretSelectorEnvType{Kind: "local"},
+			}},
+		}},
+		{Key: "OldKey", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "production"}}},
+		}},
+		{Key: "ArchivedKey", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "production"}}, ArchivedAt: &archived},
+		}},
+	}
+
+	findings := auditFindings(md, secrets, envs, map[string][]string{"StripeKey": {"billing", "checkout"}})
+	c.Assert(findings, qt.DeepEquals, []auditFinding{
+		{Kind: findingUnexpectedService, Secret: "HoneycombKey", Services: []string{"billing", "checkout", "email"}, Message: "read by billing, checkout, email"},
+		{Kind: findingUnused, Secret: "OldKey", Message: "has values but is not declared by the app"},
+		{Kind: findingMissingValue, Secret: "SendgridKey", Env: "preview", Message: "no value for preview"},
+	})
+
+	// Without allowing it, a secret read by several services is reported.
+	findings = auditFindings(md, secrets, envs, nil)
+	c.Assert(findings, qt.HasLen, 4)
+	c.Assert(findings[3], qt.DeepEquals, auditFinding{
+		Kind: findingUnexpectedService, Secret: "StripeKey", Services: []string{"billing", "checkout"}, Message: "read by billing, checkout",
+	})
+}
@@ -0,0 +1,419 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/internal/platform"
+	"encr.dev/cli/internal/platform/gql"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+var editSecretCmd = &cobra.Command{
+	Use:   "edit --type <types>",
+	Short: "Edits the secret values of one or more environments in an editor",
+	Long: `
+Edits the secret values of one or more environment types or environments
+at once, as a YAML document opened in your editor ($VISUAL or $EDITOR).
+
+Secret values can't be read back once set, so the values that are already
+set are shown as null, except for local development values. Leave them as
+null to keep them. Set a value to update it, add a key to create a secret,
+or remove a key to remove its value for the selected environments.
+
+All changes are validated before any of them are applied. If applying
+a change fails, the changes applied before it are rolled back.
+`,
+
+	Example: `
+Editing the secret values used for development and preview environments:
+
+	$ encore secret edit --type dev,preview`,
+	Args:                  cobra.NoArgs,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		editSecrets()
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(editSecretCmd)
+	editSecretCmd.Flags().StringSliceVarP(&secretEnvs.envTypes, "type", "t", nil, "environment type(s) to edit (comma-separated list)")
+	editSecretCmd.Flags().StringSliceVarP(&secretEnvs.envNames, "env", "e", nil, "environment name(s) to edit (comma-separated list)")
+}
+
+func editSecrets() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	appRoot, _ := cmdutil.AppRoot()
+	appSlug := cmdutil.AppSlug()
+	sel := secretEnvs.ParseSelector(ctx, appSlug)
+
+	app, err := platform.GetApp(ctx, appSlug)
+	if err != nil {
+		cmdutil.Fatalf("unable to lookup app %s: %v", appSlug, err)
+	}
+	secrets, err := platform.ListSecretGroups(ctx, app.Slug, nil)
+	if err != nil {
+		cmdutil.Fatalf("unable to list secrets: %v", err)
+	}
+
+	// Values are write-only, except for local development.
+	orig := make(map[string]*string)
+	for _, s := range secrets {
+		orig[s.Key] = nil
+	}
+	if len(sel) == 1 && sel[0].String() == "type:local" {
+		values, err := platform.GetLocalSecretValues(ctx, app.Slug, false)
+		if err != nil {
+			cmdutil.Fatalf("unable to get local secret values: %v", err)
+		}
+		for key, val := range values {
+			orig[key] = &val
+		}
+	}
+	hasValue := func(key string) bool {
+		return selectedGroup(secrets, key, sel) != nil
+	}
+
+	var envs []string
+	for _, s := range sel {
+		if s, ok := s.(*gql.SecretSelectorEnvType); ok {
+			envs = append(envs, s.Kind)
+		}
+	}
+	envs = append(envs, secretEnvs.envNames...)
+	doc := renderEditDocument(envs, orig, hasValue)
+	edited, err := parseEditDocument(editDocument(doc))
+	if err != nil {
+		cmdutil.Fatalf("invalid secrets document: %v", err)
+	}
+
+	changes, err := planSecretEdits(secrets, sel, diffSecretEdits(orig, edited, hasValue))
+	if err != nil {
+		cmdutil.Fatal(err)
+	} else if len(changes) == 0 {
+		fmt.Println("No changes made.")
+		return
+	}
+
+	// Apply the changes with a fresh timeout, as editing takes a while.
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	err = applySecretChanges(changes,
+		func(c secretChange) error { return c.apply(ctx, app.ID, sel) },
+		func(c secretChange) error { return c.undo(ctx, app.Slug, sel) },
+	)
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	for _, c := range changes {
+		fmt.Printf("Successfully %s secret value for %s.\n", c.verbPast(), c.key)
+	}
+
+	daemon := cmdutil.ConnectDaemon(ctx)
+	if _, err := daemon.SecretsRefresh(ctx, &daemonpb.SecretsRefreshRequest{AppRoot: appRoot}); err != nil {
+		fmt.Fprintln(os.Stderr, "warning: failed to refresh secrets, skipping:", err)
+	}
+}
+
+// renderEditDocument renders the YAML document to edit the secret values in.
+// Values that are unknown are rendered as null.
+func renderEditDocument(envs []string, values map[string]*string, hasValue func(key string) bool) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Secret values for: %s\n", strings.Join(envs, ", "))
+	buf.WriteString(`#
+# Values that are already set can't be shown, and are listed as null.
+# Leave them as null to keep them. Set a value to update it, add a key
+# to create a secret, or remove a key to remove its value.
+
+`)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if !hasValue(key) {
+			buf.WriteString("# not set\n")
+		}
+		data, err := yaml.Marshal(map[string]*string{key: values[key]})
+		if err != nil {
+			cmdutil.Fatal(err)
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+// editDocument opens the document in the user's editor and returns the edited document.
+// The file is removed afterwards, to not leave secret values on disk.
+func editDocument(doc []byte) []byte {
+	f, err := os.CreateTemp("", "encore-secrets-*.yaml")
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(doc); err != nil {
+		cmdutil.Fatal(err)
+	} else if err := f.Close(); err != nil {
+		cmdutil.Fatal(err)
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], f.Name())...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		cmdutil.Fatalf("unable to run editor %s: %v", editor, err)
+	}
+
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	return edited
+}
+
+// parseEditDocument parses the edited YAML document into secret values,
+// where null values are unknown.
+func parseEditDocument(doc []byte) (map[string]*string, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(doc, &raw); err != nil {
+		return nil, err
+	}
+	values := make(map[string]*string, len(raw))
+	for key, val := range raw {
+		var s string
+		switch val := val.(type) {
+		case nil:
+			values[key] = nil
+			continue
+		case string:
+			s = val
+		case bool:
+			s = strconv.FormatBool(val)
+		case float64:
+			s = strconv.FormatFloat(val, 'f', -1, 64)
+		default:
+			return nil, fmt.Errorf("the value of %s must be a string", key)
+		}
+		values[key] = &s
+	}
+	return values, nil
+}
+
+// secretEdits are the edits made to the secret values.
+type secretEdits struct {
+	set    map[string]string
+	remove []string
+}
+
+// diffSecretEdits computes the edits made to the original values.
+// Keys without a value for the selected environments can't be removed.
+func diffSecretEdits(orig, edited map[string]*string, hasValue func(key string) bool) secretEdits {
+	edits := secretEdits{set: make(map[string]string)}
+	for key, val := range edited {
+		if val == nil {
+			continue
+		}
+		if prev := orig[key]; prev == nil || *prev != *val {
+			edits.set[key] = *val
+		}
+	}
+	for key := range orig {
+		if _, ok := edited[key]; !ok && hasValue(key) {
+			edits.remove = append(edits.remove, key)
+		}
+	}
+	slices.Sort(edits.remove)
+	return edits
+}
+
+// secretChange is a change to make to a secret.
+type secretChange struct {
+	key   string
+	value string
+	group *gql.SecretGroup // existing group to update or archive, if any
+
+	archive bool
+}
+
+// planSecretEdits validates the edits and plans the changes to make,
+// so no change is made unless all of them can be.
+func planSecretEdits(secrets []*gql.Secret, sel []gql.SecretSelector, edits secretEdits) ([]secretChange, error) {
+	// Only consider the groups that aren't archived.
+	active := make([]*gql.Secret, 0, len(secrets))
+	for _, s := range secrets {
+		groups := slices.DeleteFunc(slices.Clone(s.Groups), func(g *gql.SecretGroup) bool { return g.ArchivedAt != nil })
+		active = append(active, &gql.Secret{Key: s.Key, Groups: groups})
+	}
+
+	var changes []secretChange
+	keys := make([]string, 0, len(edits.set))
+	for key := range edits.set {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if g := findMatchingSecretGroup(active, key, sel); g != nil {
+			changes = append(changes, secretChange{key: key, value: edits.set[key], group: g})
+		} else if g := selectedGroup(active, key, sel); g != nil {
+			return nil, errors.Newf("cannot set %s: its value is shared with other environments (secret group %s); "+
+				"use 'encore secret set' for each environment instead", key, g.ID)
+		} else {
+			changes = append(changes, secretChange{key: key, value: edits.set[key]})
+		}
+	}
+
+	for _, key := range edits.remove {
+		g := findMatchingSecretGroup(active, key, sel)
+		if g == nil {
+			g = selectedGroup(active, key, sel)
+			return nil, errors.Newf("cannot remove %s: its value is shared with other environments (secret group %s); "+
+				"use 'encore secret archive' instead", key, g.ID)
+		}
+		changes = append(changes, secretChange{key: key, group: g, archive: true})
+	}
+	return changes, nil
+}
+
+// selectedGroup returns the non-archived group providing the secret's
+// value for any of the selected environments, if any.
+func selectedGroup(secrets []*gql.Secret, key string, sel []gql.SecretSelector) *gql.SecretGroup {
+	for _, s := range secrets {
+		if s.Key != key {
+			continue
+		}
+		for _, g := range s.Groups {
+			if g.ArchivedAt != nil {
+				continue
+			}
+			for _, gs := range g.Selector {
+				if slices.ContainsFunc(sel, func(s gql.SecretSelector) bool { return s.String() == gs.String() }) {
+					return g
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// applySecretChanges applies the changes in order. If a change fails, the
+// changes applied before it are undone in reverse order, so either all of
+// the changes are applied or none of them are.
+func applySecretChanges(changes []secretChange, apply, undo func(c secretChange) error) error {
+	for i, c := range changes {
+		err := apply(c)
+		if err == nil {
+			continue
+		}
+		err = errors.Wrapf(err, "unable to %s secret %s", c.verb(), c.key)
+		for j := i - 1; j >= 0; j-- {
+			if uerr := undo(changes[j]); uerr != nil {
+				return errors.Newf("%v; rolling back the change to secret %s failed: %v (%d of %d changes remain applied)",
+					err, changes[j].key, uerr, j+1, len(changes))
+			}
+		}
+		return errors.Newf("%v (rolled back all changes)", err)
+	}
+	return nil
+}
+
+// apply makes the change. Values are updated by replacing their group with a new one,
+// rather than by adding a version to it, so the previous value can be restored by undo.
+func (c secretChange) apply(ctx context.Context, appID string, sel []gql.SecretSelector) error {
+	var desc string
+	if c.group != nil {
+		archived := true
+		if err := platform.UpdateSecretGroup(ctx, platform.UpdateSecretGroupParams{ID: c.group.ID, Archived: &archived}); err != nil {
+			return err
+		} else if c.archive {
+			return nil
+		}
+		desc = c.group.Description
+	}
+
+	err := platform.CreateSecretGroup(ctx, platform.CreateSecretGroupParams{
+		AppID:          appID,
+		Key:            c.key,
+		PlaintextValue: c.value,
+		Selector:       sel,
+		Description:    desc,
+	})
+	if err != nil && c.group != nil {
+		// Restore the group being replaced.
+		archived := false
+		if uerr := platform.UpdateSecretGroup(ctx, platform.UpdateSecretGroupParams{ID: c.group.ID, Archived: &archived}); uerr != nil {
+			return errors.Newf("%v; restoring secret group %s failed: %v", err, c.group.ID, uerr)
+		}
+	}
+	return err
+}
+
+// undo reverts the change made by apply.
+func (c secretChange) undo(ctx context.Context, appSlug string, sel []gql.SecretSelector) error {
+	if !c.archive {
+		// Archive the group apply created.
+		secrets, err := platform.ListSecretGroups(ctx, appSlug, []string{c.key})
+		if err != nil {
+			return err
+		}
+		for _, s := range secrets {
+			s.Groups = slices.DeleteFunc(s.Groups, func(g *gql.SecretGroup) bool { return g.ArchivedAt != nil })
+		}
+		created := findMatchingSecretGroup(secrets, c.key, sel)
+		if created == nil {
+			return errors.New("the created secret group was not found")
+		}
+		archived := true
+		if err := platform.UpdateSecretGroup(ctx, platform.UpdateSecretGroupParams{ID: created.ID, Archived: &archived}); err != nil {
+			return err
+		}
+	}
+	if c.group != nil {
+		archived := false
+		return platform.UpdateSecretGroup(ctx, platform.UpdateSecretGroupParams{ID: c.group.ID, Archived: &archived})
+	}
+	return nil
+}
+
+func (c secretChange) verb() string {
+	switch {
+	case c.archive:
+		return "remove"
+	case c.group != nil:
+		return "update"
+	default:
+		return "create"
+	}
+}
+
+func (c secretChange) verbPast() string {
+	return strings.TrimSuffix(c.verb(), "e") + "ed"
+}
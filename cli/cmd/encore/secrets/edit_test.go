@@ -0,0 +1,129 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+
+	"encr.dev/cli/internal/platform/gql"
+)
+
+func TestEditDocument(t *testing.T) {
+	c := qt.New(t)
+	editsEquals := qt.CmpEquals(cmp.AllowUnexported(secretEdits{}))
+	local := "local-value"
+	orig := map[string]*string{"StripeKey": nil, "SendgridKey": nil, "LocalKey": &local}
+	hasValue := func(key string) bool { return key != "SendgridKey" }
+
+	doc := renderEditDocument([]string{"development", "preview"}, orig, hasValue)
+	got, err := parseEditDocument(doc)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, orig)
+	c.Assert(diffSecretEdits(orig, got, hasValue), editsEquals, secretEdits{set: map[string]string{}})
+
+	edited, err := parseEditDocument([]byte(`
+# comments are ignored
+StripeKey: sk_test_123
+LocalKey: local-value
+Port: 8080
+Multiline: |
+  line one
+  line two
+`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(diffSecretEdits(orig, edited, hasValue), editsEquals, secretEdits{
+		set: map[string]string{
+			"StripeKey": "sk_test_123",
+			"Port":      "8080",
+			"Multiline": "line one\nline two\n",
+		},
+		// SendgridKey was removed, but has no value to remove.
+	})
+
+	_, err = parseEditDocument([]byte("Nested:\n  key: value\n"))
+	c.Assert(err, qt.ErrorMatches, "the value of Nested must be a string")
+}
+
+func TestPlanSecretEdits(t *testing.T) {
+	c := qt.New(t)
+	devType := &gql.SecretSelectorEnvType{Kind: "development"}
+	previewType := &gql.SecretSelectorEnvType{Kind: "preview"}
+	prodType := &gql.SecretSelectorEnvType{Kind: "production"}
+	sel := []gql.SecretSelector{devType, previewType}
+
+	archived := time.Now()
+	exact := &gql.SecretGroup{ID: "secgrp_exact", Selector: []gql.SecretSelector{previewType, devType}}
+	shared := &gql.SecretGroup{ID: "secgrp_shared", Selector: []gql.SecretSelector{devType, prodType}}
+	secrets := []*gql.Secret{
+		{Key: "Exact", Groups: []*gql.SecretGroup{
+			{ID: "secgrp_old", Selector: sel, ArchivedAt: &archived},
+			exact,
+		}},
+		{Key: "Shared", Groups: []*gql.SecretGroup{shared}},
+		{Key: "ProdOnly", Groups: []*gql.SecretGroup{{ID: "secgrp_prod", Selector: []gql.SecretSelector{prodType}}}},
+	}
+
+	changes, err := planSecretEdits(secrets, sel, secretEdits{
+		set:    map[string]string{"Exact": "a", "ProdOnly": "b", "New": "c"},
+		remove: []string{"Exact"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changes, qt.CmpEquals(cmp.AllowUnexported(secretChange{})), []secretChange{
+		{key: "Exact", value: "a", group: exact},
+		{key: "New", value: "c"},
+		{key: "ProdOnly", value: "b"},
+		{key: "Exact", group: exact, archive: true},
+	})
+	c.Assert(changes[0].verbPast(), qt.Equals, "updated")
+	c.Assert(changes[1].verbPast(), qt.Equals, "created")
+	c.Assert(changes[3].verbPast(), qt.Equals, "removed")
+
+	// Values shared with environments that aren't selected can't be edited.
+	_, err = planSecretEdits(secrets, sel, secretEdits{set: map[string]string{"Shared": "x"}})
+	c.Assert(err, qt.ErrorMatches, "cannot set Shared: its value is shared with other environments.*")
+	_, err = planSecretEdits(secrets, sel, secretEdits{remove: []string{"Shared"}})
+	c.Assert(err, qt.ErrorMatches, "cannot remove Shared: its value is shared with other environments.*")
+}
+
+func TestApplySecretChanges(t *testing.T) {
+	c := qt.New(t)
+	changes := []secretChange{{key: "A"}, {key: "B"}, {key: "C"}}
+
+	var log []string
+	apply := func(fail string) func(secretChange) error {
+		return func(ch secretChange) error {
+			if ch.key == fail {
+				return errors.New("conflict")
+			}
+			log = append(log, "apply "+ch.key)
+			return nil
+		}
+	}
+	undo := func(fail string) func(secretChange) error {
+		return func(ch secretChange) error {
+			if ch.key == fail {
+				return errors.New("unavailable")
+			}
+			log = append(log, "undo "+ch.key)
+			return nil
+		}
+	}
+
+	c.Assert(applySecretChanges(changes, apply(""), undo("")), qt.IsNil)
+	c.Assert(log, qt.DeepEquals, []string{"apply A", "apply B", "apply C"})
+
+	// A failing change rolls back the changes applied before it.
+	log = nil
+	err := applySecretChanges(changes, apply("C"), undo(""))
+	c.Assert(err, qt.ErrorMatches, `unable to create secret C: conflict \(rolled back all changes\)`)
+	c.Assert(log, qt.DeepEquals, []string{"apply A", "apply B", "undo B", "undo A"})
+
+	// Changes that can't be rolled back are reported.
+	log = nil
+	err = applySecretChanges(changes, apply("C"), undo("A"))
+	c.Assert(err, qt.ErrorMatches, `unable to create secret C: conflict; rolling back the change to secret A failed: unavailable \(1 of 3 changes remain applied\)`)
+	c.Assert(log, qt.DeepEquals, []string{"apply A", "apply B", "undo B"})
+}
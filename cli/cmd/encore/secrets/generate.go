@@ -0,0 +1,129 @@
+package secrets
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/daemon/secret"
+	"encr.dev/cli/internal/platform"
+	"encr.dev/cli/internal/platform/gql"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+var generateSecretCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generates values for preview environments of secrets tagged encore:\"generate\"",
+	Long: `
+Generates values for preview environments of the secrets tagged
+encore:"generate" that have no value for them yet.
+
+Each secret gets a random value of its declared shape, stored for the
+'pr' environment type so every preview environment can use it. Secrets
+that already have a value for preview environments are left as-is.
+
+Local development generates its values on its own, and production
+environments always require the secrets to be set explicitly.
+`,
+
+	Example: `
+Generating the missing preview values:
+
+	$ encore secret generate
+	Successfully created secret value for SessionKey.`,
+	Args:                  cobra.NoArgs,
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		generateSecrets()
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(generateSecretCmd)
+}
+
+// previewSelector selects the values used by every preview environment.
+var previewSelector = []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "preview"}}
+
+func generateSecrets() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	appSlug := cmdutil.AppSlug()
+	md := appMeta(ctx)
+
+	secrets, err := platform.ListSecretGroups(ctx, appSlug, nil)
+	if err != nil {
+		cmdutil.Fatalf("unable to list secrets: %v", err)
+	}
+
+	missing := missingPreviewSecrets(md, secrets)
+	if len(missing) == 0 {
+		fmt.Println("All generated secrets have a value for preview environments.")
+		return
+	}
+
+	app, err := platform.GetApp(ctx, appSlug)
+	if err != nil {
+		cmdutil.Fatalf("unable to lookup app %s: %v", appSlug, err)
+	}
+	for _, s := range missing {
+		val, err := secret.GenerateValue(s.Shape)
+		if err != nil {
+			cmdutil.Fatalf("unable to generate secret %s: %v", s.Key, err)
+		}
+		if isListSecret(md, s.Key) {
+			val = listValue(val)
+		}
+		err = platform.CreateSecretGroup(ctx, platform.CreateSecretGroupParams{
+			AppID:          app.ID,
+			Key:            s.Key,
+			PlaintextValue: val,
+			Selector:       previewSelector,
+			Description:    "Generated for preview environments",
+		})
+		if err != nil {
+			cmdutil.Fatalf("unable to create secret %s: %v", s.Key, err)
+		}
+		fmt.Printf("Successfully created secret value for %s.\n", s.Key)
+	}
+}
+
+// missingPreviewSecrets returns the secrets in md tagged encore:"generate"
+// that have no value for preview environments, sorted by key.
+func missingPreviewSecrets(md *meta.Data, secrets []*gql.Secret) []*meta.GeneratedSecret {
+	groups := make(map[string][]*gql.SecretGroup)
+	for _, s := range secrets {
+		groups[s.Key] = append(groups[s.Key], s.Groups...)
+	}
+
+	var missing []*meta.GeneratedSecret
+	for _, pkg := range md.Pkgs {
+		for _, s := range pkg.GeneratedSecrets {
+			if !hasValueFor(groups[s.Key], "", "preview") {
+				missing = append(missing, s)
+			}
+		}
+	}
+	slices.SortFunc(missing, func(a, b *meta.GeneratedSecret) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return slices.CompactFunc(missing, func(a, b *meta.GeneratedSecret) bool {
+		return a.Key == b.Key
+	})
+}
+
+// isListSecret reports whether md declares the secret key as a []string.
+func isListSecret(md *meta.Data, key string) bool {
+	for _, pkg := range md.Pkgs {
+		if slices.Contains(pkg.ListSecrets, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/internal/platform/gql"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMissingPreviewSecrets(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Pkgs: []*meta.Package{
+			{RelPath: "auth", GeneratedSecrets: []*meta.GeneratedSecret{
+				{Key: "SessionKey"},
+				{Key: "WebhookSecret", Shape: meta.GeneratedSecret_HEX},
+			}},
+			{RelPath: "billing", GeneratedSecrets: []*meta.GeneratedSecret{
+				{Key: "SigningKey", Shape: meta.GeneratedSecret_BYTES},
+				{Key: "SessionKey"},
+			}},
+		},
+	}
+	archived := time.Now()
+	secrets := []*gql.Secret{
+		{Key: "WebhookSecret", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "preview"}}},
+		}},
+		{Key: "SigningKey", Groups: []*gql.SecretGroup{
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "production"}}},
+			{Selector: []gql.SecretSelector{&gql.SecretSelectorEnvType{Kind: "preview"}}, ArchivedAt: &archived},
+		}},
+	}
+
+	var keys []string
+	for _, s := range missingPreviewSecrets(md, secrets) {
+		keys = append(keys, s.Key)
+	}
+	c.Assert(keys, qt.DeepEquals, []string{"SessionKey", "SigningKey"})
+}
@@ -0,0 +1,166 @@
+package secrets
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"encr.dev/cli/cmd/encore/cmdutil"
+)
+
+var rotateSecretCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotates a secret without downtime",
+	Long: `
+Rotates a secret in three steps, so the app keeps working while the
+systems using the secret switch over to its new value.
+
+Only secrets declared as []string in Go apps can be rotated. Their values
+are stored as JSON lists, with the current value first.
+
+  1. start stores both the current and the next value,
+     so the app can accept either of them.
+  2. promote makes the next value the current one, keeping the previous
+     value around for anything still using it.
+  3. retire stores the new value on its own, ending the rotation.
+
+Secret values are write-only, so each step asks for the values to store.
+`,
+
+	Example: `
+Rotating the production API key:
+
+	$ encore secret rotate start --type prod APIKey
+	Enter current secret value: ...
+	Enter next secret value: ...
+
+	$ encore secret rotate promote --type prod APIKey
+	Enter current secret value: ...
+	Enter next secret value: ...
+
+	$ encore secret rotate retire --type prod APIKey
+	Enter secret value: ...`,
+}
+
+var rotateStartCmd = &cobra.Command{
+	Use:   "start --type <types> <secret-name>",
+	Short: "Starts rotating a secret, storing its current and next values",
+	Long: `
+Starts rotating a secret, storing its current and next values.
+
+The secret gets both values, with the current value first.
+`,
+	Args:                  cobra.ExactArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		requireListSecret(args[0])
+		values := readSecretValues("current", "next")
+		storeSecret(args[0], listValue(values...))
+	},
+}
+
+var rotatePromoteCmd = &cobra.Command{
+	Use:   "promote --type <types> <secret-name>",
+	Short: "Promotes the next value of a secret being rotated to its current value",
+	Long: `
+Promotes the next value of a secret being rotated to its current value.
+
+The previous value is kept as the second value, for anything still using it.
+`,
+	Args:                  cobra.ExactArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		requireListSecret(args[0])
+		values := readSecretValues("current", "next")
+		storeSecret(args[0], listValue(values[1], values[0]))
+	},
+}
+
+var rotateRetireCmd = &cobra.Command{
+	Use:   "retire --type <types> <secret-name>",
+	Short: "Retires the previous value of a secret, ending its rotation",
+	Long: `
+Retires the previous value of a secret, ending its rotation.
+
+Enter the value that was promoted. It's stored as the secret's only value.
+`,
+	Args:                  cobra.ExactArgs(1),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		requireListSecret(args[0])
+		storeSecret(args[0], listValue(readSecretValue()))
+	},
+}
+
+func init() {
+	secretCmd.AddCommand(rotateSecretCmd)
+	rotateSecretCmd.AddCommand(rotateStartCmd, rotatePromoteCmd, rotateRetireCmd)
+	rotateSecretCmd.PersistentFlags().StringSliceVarP(&secretEnvs.envTypes, "type", "t", nil, "environment type(s) to rotate for (comma-separated list)")
+	rotateSecretCmd.PersistentFlags().StringSliceVarP(&secretEnvs.envNames, "env", "e", nil, "environment name(s) to rotate for (comma-separated list)")
+}
+
+// requireListSecret exits with an error unless the app declares
+// the secret key as a []string, which is what holds several values.
+func requireListSecret(key string) {
+	requireGoApp("secret rotations")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for _, pkg := range appMeta(ctx).Pkgs {
+		if slices.Contains(pkg.ListSecrets, key) {
+			return
+		}
+	}
+	cmdutil.Fatalf("secret %s must be declared as []string to be rotated", key)
+}
+
+// listValue returns the value of a []string secret storing
+// the given values, with the current value first.
+func listValue(values ...string) string {
+	data, err := json.Marshal(values)
+	if err != nil {
+		cmdutil.Fatal(err)
+	}
+	return string(data)
+}
+
+// readSecretValues reads the named secret values from the user.
+// If it's a terminal it prompts for each value in turn,
+// otherwise it reads one value per line from stdin.
+func readSecretValues(names ...string) []string {
+	values := make([]string, len(names))
+	fd := syscall.Stdin
+	if terminal.IsTerminal(int(fd)) {
+		for i, name := range names {
+			fmt.Fprintf(os.Stderr, "Enter %s secret value: ", name)
+			data, err := terminal.ReadPassword(int(fd))
+			if err != nil {
+				cmdutil.Fatal(err)
+			}
+			values[i] = string(data)
+			fmt.Fprintln(os.Stderr)
+		}
+		return values
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for i, name := range names {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				cmdutil.Fatal(err)
+			}
+			cmdutil.Fatalf("missing %s secret value: want one value per line", name)
+		}
+		values[i] = strings.TrimRight(scanner.Text(), "\r")
+	}
+	return values
+}
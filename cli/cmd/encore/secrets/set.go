@@ -3,6 +3,7 @@ package secrets
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,8 +19,11 @@ import (
 	"golang.org/x/crypto/ssh/terminal"
 
 	"encr.dev/cli/cmd/encore/cmdutil"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/internal/platform"
 	"encr.dev/cli/internal/platform/gql"
+	"encr.dev/pkg/appfile"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
@@ -30,6 +34,16 @@ var setSecretCmd = &cobra.Command{
 Sets a secret value for one or more environment types.
 
 The valid environment types are 'prod', 'dev', 'pr' and 'local'.
+
+With --local the value is instead written to the local override file
+(.secrets.local.cue) in the app root, without contacting the Encore Platform.
+Combine it with --namespace to only override the value when running in
+that infrastructure namespace.
+
+With --from-file the value is read from the given file instead, and stored
+base64-encoded so binary files like certificates and keystores are preserved.
+It's meant for secrets declared with type []byte, which the runtime decodes.
+Binary secrets are only supported for Go apps.
 `,
 
 	Example: `
@@ -44,7 +58,18 @@ Piping a secret from a file:
 	$ encore secret set --type dev,local,pr MySecret < my-secret.txt
 	Successfully created secret value for MySecret.
 
-Note that this strips trailing newlines from the secret value.`,
+Note that this strips trailing newlines from the secret value.
+
+Setting a binary secret from a file, as-is:
+
+	$ encore secret set --type prod --from-file cert.p12 ClientCert
+	Successfully created secret value for ClientCert.
+
+Overriding a secret locally when running in the feature-x namespace:
+
+	$ encore secret set --local --namespace feature-x StripeKey
+	Enter secret value: ...
+	Successfully set local override for StripeKey in namespace feature-x.`,
 	Args:                  cobra.ExactArgs(1),
 	DisableFlagsInUseLine: true,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -52,7 +77,12 @@ Note that this strips trailing newlines from the secret value.`,
 	},
 }
 
-var secretEnvs secretEnvSelector
+var (
+	secretEnvs  secretEnvSelector
+	setLocal    bool
+	setNSName   string
+	setFromFile string
+)
 
 type secretEnvSelector struct {
 	devFlag  bool
@@ -67,13 +97,27 @@ func init() {
 	setSecretCmd.Flags().BoolVarP(&secretEnvs.prodFlag, "prod", "p", false, "To set the secret for production use")
 	setSecretCmd.Flags().StringSliceVarP(&secretEnvs.envTypes, "type", "t", nil, "environment type(s) to set for (comma-separated list)")
 	setSecretCmd.Flags().StringSliceVarP(&secretEnvs.envNames, "env", "e", nil, "environment name(s) to set for (comma-separated list)")
+	setSecretCmd.Flags().BoolVar(&setLocal, "local", false, "set a local override in .secrets.local.cue instead")
+	setSecretCmd.Flags().StringVarP(&setNSName, "namespace", "n", "", "namespace to set the local override for (requires --local)")
+	setSecretCmd.Flags().StringVar(&setFromFile, "from-file", "", "read the secret value from the given file, stored base64-encoded")
 	_ = setSecretCmd.Flags().MarkHidden("dev")
 	_ = setSecretCmd.Flags().MarkHidden("prod")
 }
 
 func setSecret(key string) {
-	plaintextValue := readSecretValue()
+	if setLocal {
+		setLocalOverride(key)
+		return
+	} else if setNSName != "" {
+		cmdutil.Fatal("--namespace can only be used together with --local")
+	}
+
+	storeSecret(key, secretValue())
+}
 
+// storeSecret stores the secret value for the environments selected
+// by --type/--env, updating the matching secret group if there is one.
+func storeSecret(key, plaintextValue string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -134,6 +178,27 @@ func setSecret(key string) {
 	fmt.Printf("Successfully created secret value for %s.\n", key)
 }
 
+// setLocalOverride sets the local override of the secret key,
+// for the namespace given by --namespace if any.
+func setLocalOverride(key string) {
+	if secretEnvs.devFlag || secretEnvs.prodFlag || len(secretEnvs.envTypes) > 0 || len(secretEnvs.envNames) > 0 {
+		cmdutil.Fatal("cannot combine --local with --type/--env (or --dev/--prod)")
+	}
+	plaintextValue := secretValue()
+
+	appRoot, _ := cmdutil.AppRoot()
+	ns := namespace.Name(setNSName)
+	if err := secret.SetLocalOverride(appRoot, ns, key, plaintextValue); err != nil {
+		cmdutil.Fatalf("unable to set local override: %v", err)
+	}
+
+	if ns != "" {
+		fmt.Printf("Successfully set local override for %s in namespace %s.\n", key, ns)
+	} else {
+		fmt.Printf("Successfully set local override for %s.\n", key)
+	}
+}
+
 func (s secretEnvSelector) ParseSelector(ctx context.Context, appSlug string) []gql.SecretSelector {
 	if s.devFlag && s.prodFlag {
 		cmdutil.Fatal("cannot specify both --dev and --prod")
@@ -165,22 +230,8 @@ func (s secretEnvSelector) ParseSelector(ctx context.Context, appSlug string) []
 	} else {
 		// Parse env types and env names
 		seenTypes := make(map[string]bool)
-		validTypes := map[string]string{
-			// Actual names
-			"development": "development",
-			"production":  "production",
-			"preview":     "preview",
-			"local":       "local",
-
-			// Aliases
-			"dev":       "development",
-			"prod":      "production",
-			"pr":        "preview",
-			"ephemeral": "preview",
-		}
-
 		for _, t := range s.envTypes {
-			val, ok := validTypes[t]
+			val, ok := envTypeAliases[t]
 			if !ok {
 				cmdutil.Fatalf("invalid environment type %q", t)
 			}
@@ -204,6 +255,51 @@ func (s secretEnvSelector) ParseSelector(ctx context.Context, appSlug string) []
 	return sel
 }
 
+// envTypeAliases maps the accepted environment type names
+// to the environment types secret values are selected by.
+var envTypeAliases = map[string]string{
+	// Actual names
+	"development": "development",
+	"production":  "production",
+	"preview":     "preview",
+	"local":       "local",
+
+	// Aliases
+	"dev":       "development",
+	"prod":      "production",
+	"pr":        "preview",
+	"ephemeral": "preview",
+}
+
+// secretValue returns the secret value to set: the base64-encoded contents
+// of the --from-file file, if given, and otherwise the value read from the user.
+//
+// Files are stored base64-encoded for secrets declared as []byte,
+// which the Go runtime decodes based on the declared type.
+func secretValue() string {
+	if setFromFile == "" {
+		return readSecretValue()
+	}
+	requireGoApp("binary secrets")
+	data, err := os.ReadFile(setFromFile)
+	if err != nil {
+		cmdutil.Fatalf("unable to read secret file: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// requireGoApp exits with an error unless the app is a Go app,
+// for the secret features only the Go runtime supports.
+func requireGoApp(feature string) {
+	appRoot, _ := cmdutil.AppRoot()
+	lang, err := appfile.AppLang(appRoot)
+	if err != nil {
+		cmdutil.Fatalf("unable to read app file: %v", err)
+	} else if lang != appfile.LangGo {
+		cmdutil.Fatalf("%s are only supported for Go apps", feature)
+	}
+}
+
 // readSecretValue reads the secret value from the user.
 // If it's a terminal it becomes an interactive prompt,
 // otherwise it reads from stdin.
@@ -29,9 +29,13 @@ var testCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		var (
 			traceFile    string
+			coverSummary string
+			shard        string
 			codegenDebug bool
 			prepareOnly  bool
 			noColor      bool
+			integration  bool
+			updateGolden bool
 		)
 		// Support specific args but otherwise let all args be passed on to "go test"
 		for i := 0; i < len(args); i++ {
@@ -39,6 +43,13 @@ var testCmd = &cobra.Command{
 			if arg == "-h" || arg == "--help" {
 				_ = cmd.Help()
 				return
+			} else if arg == "-args" || arg == "--args" {
+				// The remaining arguments are passed to the test binaries as-is.
+				break
+			} else if arg == "-update" || arg == "--update" {
+				updateGolden = true
+				args = slices.Delete(args, i, i+1)
+				i--
 			} else if arg == "--trace" || strings.HasPrefix(arg, "--trace=") {
 				// Drop this argument always.
 				args = slices.Delete(args, i, i+1)
@@ -56,6 +67,28 @@ var testCmd = &cobra.Command{
 						i--
 					}
 				}
+			} else if arg == "--coverage-summary" || strings.HasPrefix(arg, "--coverage-summary=") {
+				args = slices.Delete(args, i, i+1)
+				i--
+
+				if _, value, ok := strings.Cut(arg, "="); ok {
+					coverSummary = value
+				} else if i+1 < len(args) {
+					coverSummary = args[i+1]
+					args = slices.Delete(args, i+1, i+2)
+				}
+			} else if arg == "--shard" || strings.HasPrefix(arg, "--shard=") {
+				args = slices.Delete(args, i, i+1)
+				i--
+
+				if _, value, ok := strings.Cut(arg, "="); ok {
+					shard = value
+				} else if i+1 < len(args) {
+					shard = args[i+1]
+					args = slices.Delete(args, i+1, i+2)
+				} else {
+					fatal("--shard requires a value, like --shard 3/8")
+				}
 			} else if arg == "--codegen-debug" {
 				codegenDebug = true
 				args = slices.Delete(args, i, i+1)
@@ -68,15 +101,28 @@ var testCmd = &cobra.Command{
 				noColor = true
 				args = slices.Delete(args, i, i+1)
 				i--
+			} else if arg == "--integration" {
+				integration = true
+				args = slices.Delete(args, i, i+1)
+				i--
 			}
 		}
 
+		if integration && shard != "" {
+			fatal("--shard cannot be combined with --integration")
+		}
+
+		environ := os.Environ()
+		if updateGolden {
+			environ = append(environ, "ENCORE_GOLDEN_UPDATE=1")
+		}
+
 		appRoot, relPath := determineAppRoot()
-		runTests(appRoot, relPath, args, traceFile, codegenDebug, prepareOnly, noColor)
+		runTests(environ, appRoot, relPath, args, traceFile, coverSummary, shard, codegenDebug, prepareOnly, noColor, integration)
 	},
 }
 
-func runTests(appRoot, testDir string, args []string, traceFile string, codegenDebug, prepareOnly, noColor bool) {
+func runTests(environ []string, appRoot, testDir string, args []string, traceFile, coverSummary, shard string, codegenDebug, prepareOnly, noColor, integration bool) {
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
 
@@ -96,11 +142,17 @@ func runTests(appRoot, testDir string, args []string, traceFile string, codegenD
 	// Is this a node package?
 	packageJsonPath := filepath.Join(appRoot, "package.json")
 	if _, err := os.Stat(packageJsonPath); err == nil || prepareOnly {
+		if integration {
+			fatal("--integration is only supported for Go applications")
+		} else if shard != "" {
+			fatal("--shard is only supported for Go applications")
+		}
+
 		spec, err := daemon.TestSpec(ctx, &daemonpb.TestSpecRequest{
 			AppRoot:    appRoot,
 			WorkingDir: testDir,
 			Args:       args,
-			Environ:    os.Environ(),
+			Environ:    environ,
 		})
 		if status.Code(err) == codes.NotFound {
 			fatal("application does not define any tests.\nNote: Add a 'test' script command to package.json to run tests.")
@@ -133,12 +185,15 @@ func runTests(appRoot, testDir string, args []string, traceFile string, codegenD
 	}
 
 	stream, err := daemon.Test(ctx, &daemonpb.TestRequest{
-		AppRoot:      appRoot,
-		WorkingDir:   testDir,
-		Args:         args,
-		Environ:      os.Environ(),
-		TraceFile:    nonZeroPtr(traceFile),
-		CodegenDebug: codegenDebug,
+		AppRoot:         appRoot,
+		WorkingDir:      testDir,
+		Args:            args,
+		Environ:         environ,
+		TraceFile:       nonZeroPtr(traceFile),
+		CodegenDebug:    codegenDebug,
+		Integration:     integration,
+		CoverageSummary: nonZeroPtr(coverSummary),
+		Shard:           nonZeroPtr(shard),
 	})
 	if err != nil {
 		fatal(err)
@@ -156,6 +211,10 @@ func init() {
 	testCmd.Flags().Bool("prepare", false, "Prepare for running tests (without running them)")
 	testCmd.Flags().String("trace", "", "Specifies a trace file to write trace information about the parse and compilation process to.")
 	testCmd.Flags().Bool("no-color", false, "Disable colorized output")
+	testCmd.Flags().String("coverage-summary", "", "Write the per-service coverage summary to the given file as JSON (requires -coverprofile)")
+	testCmd.Flags().Bool("update", false, "Update the golden files compared against with et.Golden")
+	testCmd.Flags().Bool("integration", false, "Boot the complete app with its own infrastructure and run the test packages against it")
+	testCmd.Flags().String("shard", "", "Only run the test packages in the given shard, like 3/8, balanced by service and historical test durations")
 
 }
 
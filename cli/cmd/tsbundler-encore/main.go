@@ -19,11 +19,17 @@ var (
 	entryPoints      []string
 	specifiedEngines []string
 	// replacementFile  string
-	outDir   string
-	bundle   bool
-	minify   bool
-	help     bool
-	logLevel int
+	outDir     string
+	bundle     bool
+	minify     bool
+	keepNames  bool
+	sourceMap  string
+	packages   string
+	external   []string
+	noExternal []string
+	format     string
+	help       bool
+	logLevel   int
 )
 
 // main is the entry point for the tsbundler-encore command.
@@ -39,6 +45,12 @@ func main() {
 	flag.StringVar(&outDir, "outdir", "dist", "Output directory")
 	flag.BoolVar(&bundle, "bundle", true, "Bundle all dependencies")
 	flag.BoolVar(&minify, "minify", false, "Minify output (default false)")
+	flag.BoolVar(&keepNames, "keep-names", true, "Preserve function and class names when minifying, for readable stack traces")
+	flag.StringVar(&sourceMap, "sourcemap", "linked", "Source map mode: linked, external, inline or none")
+	flag.StringVar(&packages, "packages", "external", "How to handle npm packages: external (load from node_modules) or bundle")
+	flag.StringArrayVar(&external, "external", nil, "Package to keep external when bundling packages (can be specified multiple times)")
+	flag.StringArrayVar(&noExternal, "no-external", nil, "Package to bundle when packages are external (can be specified multiple times)")
+	flag.StringVar(&format, "format", "esm", "Output format: esm (.mjs files), cjs (.cjs files) or both")
 	flag.StringArrayVar(&specifiedEngines, "engine", []string{"node:21"}, "Target engine")
 	flag.CountVarP(&logLevel, "verbose", "v", "Increase logging level (can be specified multiple times)")
 	flag.BoolVarP(&help, "help", "h", false, "Print help")
@@ -54,6 +66,9 @@ func main() {
 	// Validate input (note: these functions will exit on error)
 	validateEntrypointParams()
 	engines := readEngines()
+	sourceMapMode := readSourceMap()
+	packagesMode := readPackages()
+	formats := readFormats()
 	// replacements := readReplacementMapping()
 
 	// Create our transformer plugin
@@ -88,6 +103,15 @@ func main() {
 //
 // https://encore.dev`
 
+	plugins := []api.Plugin{
+		// rewritePlugin,
+	}
+	if packagesMode == api.PackagesExternal {
+		// The plugin keeps packages external instead, to be able to bundle workspace packages.
+		packagesMode = api.PackagesDefault
+		plugins = append(plugins, externalPackagesPlugin(noExternal))
+	}
+
 	outBase := ""
 	if len(entryPoints) == 1 {
 		// If there's a single entrypoint, use its directory as the outbase
@@ -95,22 +119,18 @@ func main() {
 		outBase = filepath.Dir(filepath.Dir(entryPoints[0]))
 	}
 
-	// Trigger esbuild
-	result := api.Build(api.BuildOptions{
+	opts := api.BuildOptions{
 		// Setup base settings
-		LogLevel:  api.LogLevelWarning - api.LogLevel(logLevel),
-		Banner:    map[string]string{"js": banner},
-		Charset:   api.CharsetUTF8,
-		Sourcemap: api.SourceMapLinked,
-		Packages:  api.PackagesExternal,
-		Plugins:   []api.Plugin{
-			// rewritePlugin,
-		},
+		LogLevel:    api.LogLevelWarning - api.LogLevel(logLevel),
+		Charset:     api.CharsetUTF8,
+		Sourcemap:   sourceMapMode,
+		Packages:    packagesMode,
+		External:    external,
+		Plugins:     plugins,
 		TreeShaking: api.TreeShakingTrue,
 
 		// Set our build target
 		Platform: api.PlatformNode,
-		Format:   api.FormatESModule,
 		Target:   api.ES2022,
 		Engines:  engines,
 
@@ -118,6 +138,7 @@ func main() {
 		MinifyWhitespace:  minify,
 		MinifySyntax:      minify,
 		MinifyIdentifiers: minify,
+		KeepNames:         minify && keepNames,
 
 		// Pass in what we want to build
 		EntryNames:  "[dir]/[name]",
@@ -126,16 +147,30 @@ func main() {
 		Outdir:      outDir,
 		Outbase:     outBase,
 		Write:       true, // Write to outdir
-		OutExtension: map[string]string{
-			".js": ".mjs",
-		},
-		Define: map[string]string{
+	}
+
+	// Trigger esbuild, once per output format
+	for _, f := range formats {
+		opts.Format = f
+		opts.Banner = map[string]string{"js": banner}
+		opts.Define = map[string]string{
 			"ENCORE_DROP_TESTS": "true",
-		},
-	})
+		}
+		switch f {
+		case api.FormatESModule:
+			opts.OutExtension = map[string]string{".js": ".mjs"}
+		case api.FormatCommonJS:
+			opts.OutExtension = map[string]string{".js": ".cjs"}
+
+			// import.meta isn't available in CommonJS, so derive its url from the file name.
+			opts.Banner["js"] += "\n" + `var __encore_import_meta_url = require("url").pathToFileURL(__filename).href;`
+			opts.Define["import.meta.url"] = "__encore_import_meta_url"
+		}
 
-	if len(result.Errors) > 0 {
-		os.Exit(1)
+		result := api.Build(opts)
+		if len(result.Errors) > 0 {
+			os.Exit(1)
+		}
 	}
 }
 
@@ -295,3 +330,64 @@ func readEngines() []api.Engine {
 
 	return engines
 }
+
+// readSourceMap reads the source map mode from the specified flag.
+func readSourceMap() api.SourceMap {
+	switch strings.ToLower(strings.TrimSpace(sourceMap)) {
+	case "linked":
+		return api.SourceMapLinked
+	case "external":
+		return api.SourceMapExternal
+	case "inline":
+		return api.SourceMapInline
+	case "none":
+		return api.SourceMapNone
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Error: unknown source map mode %s\n\n", sourceMap)
+		printHelp()
+		os.Exit(1)
+		return api.SourceMapNone
+	}
+}
+
+// readPackages reads how to handle npm packages from the specified flag.
+func readPackages() api.Packages {
+	switch strings.ToLower(strings.TrimSpace(packages)) {
+	case "external":
+		if len(external) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: --external can only be used with --packages=bundle\n\n")
+			printHelp()
+			os.Exit(1)
+		}
+		return api.PackagesExternal
+	case "bundle":
+		if len(noExternal) > 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "Error: --no-external can only be used with --packages=external\n\n")
+			printHelp()
+			os.Exit(1)
+		}
+		return api.PackagesDefault
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Error: unknown packages mode %s\n\n", packages)
+		printHelp()
+		os.Exit(1)
+		return api.PackagesDefault
+	}
+}
+
+// readFormats reads the output formats to emit from the specified flag.
+func readFormats() []api.Format {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "esm":
+		return []api.Format{api.FormatESModule}
+	case "cjs":
+		return []api.Format{api.FormatCommonJS}
+	case "both":
+		return []api.Format{api.FormatESModule, api.FormatCommonJS}
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Error: unknown output format %s\n\n", format)
+		printHelp()
+		os.Exit(1)
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/evanw/esbuild/pkg/api"
+)
+
+// resolvingPackage marks the resolutions made by externalPackagesPlugin itself.
+type resolvingPackage struct{}
+
+// externalPackagesPlugin keeps npm packages external, like esbuild's
+// "packages: external" mode, except for workspace packages and the
+// packages in bundled.
+//
+// Workspace packages are packages in the same monorepo as the app, which
+// package managers link into node_modules with a symlink to their source directory.
+// They're bundled, since they're commonly written in TypeScript and not built
+// before being imported, and aren't available when running the bundle elsewhere.
+func externalPackagesPlugin(bundled []string) api.Plugin {
+	return api.Plugin{
+		Name: "encore-external-packages",
+		Setup: func(build api.PluginBuild) {
+			// Matches imports that aren't relative paths, as recommended by esbuild.
+			build.OnResolve(api.OnResolveOptions{Filter: `^[^./]|^\.[^./]|^\.\.[^/]`},
+				func(args api.OnResolveArgs) (api.OnResolveResult, error) {
+					if args.Kind == api.ResolveEntryPoint || filepath.IsAbs(args.Path) {
+						return api.OnResolveResult{}, nil
+					} else if _, ok := args.PluginData.(resolvingPackage); ok {
+						return api.OnResolveResult{}, nil
+					} else if slices.Contains(bundled, packageName(args.Path)) {
+						return api.OnResolveResult{}, nil
+					}
+
+					// Resolve the import to tell tsconfig path aliases and workspace packages,
+					// which esbuild resolves to their real location, apart from installed packages.
+					res := build.Resolve(args.Path, api.ResolveOptions{
+						Importer:   args.Importer,
+						ResolveDir: args.ResolveDir,
+						Kind:       args.Kind,
+						Namespace:  args.Namespace,
+						PluginData: resolvingPackage{},
+					})
+					if len(res.Errors) == 0 && !res.External && !inNodeModules(res.Path) {
+						sideEffects := api.SideEffectsTrue
+						if !res.SideEffects {
+							sideEffects = api.SideEffectsFalse
+						}
+						return api.OnResolveResult{Path: res.Path, Namespace: res.Namespace, SideEffects: sideEffects}, nil
+					}
+					return api.OnResolveResult{Path: args.Path, External: true}, nil
+				})
+		},
+	}
+}
+
+// inNodeModules reports whether path is inside a node_modules directory.
+func inNodeModules(path string) bool {
+	return slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "node_modules")
+}
+
+// packageName returns the name of the package imported by the import path,
+// like "@scope/pkg" for "@scope/pkg/sub/path".
+func packageName(path string) string {
+	parts := strings.SplitN(path, "/", 3)
+	if strings.HasPrefix(path, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+	return parts[0]
+}
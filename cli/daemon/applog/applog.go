@@ -0,0 +1,249 @@
+// Package applog keeps the recent log output of running apps,
+// parsed into structured entries that can be filtered and correlated
+// with the traces of the requests that emitted them.
+package applog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is a log level.
+type Level string
+
+const (
+	Trace Level = "trace"
+	Debug Level = "debug"
+	Info  Level = "info"
+	Warn  Level = "warn"
+	Error Level = "error"
+	Fatal Level = "fatal"
+)
+
+var levelOrder = map[Level]int{Trace: 0, Debug: 1, Info: 2, Warn: 3, Error: 4, Fatal: 5}
+
+// parseLevel parses the level of a log line,
+// accepting the names used by the different runtimes.
+func parseLevel(s string) Level {
+	switch l := Level(strings.ToLower(s)); l {
+	case "warning":
+		return Warn
+	case "critical", "panic":
+		return Fatal
+	default:
+		return l
+	}
+}
+
+// Entry is a log entry.
+type Entry struct {
+	// Seq is the sequence number of the entry, which increases
+	// with every entry written by the app.
+	Seq     int64     `json:"seq"`
+	Time    time.Time `json:"time"`
+	Level   Level     `json:"level,omitempty"`
+	Message string    `json:"message"`
+
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	TraceID  string `json:"trace_id,omitempty"`
+	SpanID   string `json:"span_id,omitempty"`
+
+	// Fields are the remaining fields of structured log lines.
+	Fields map[string]json.RawMessage `json:"fields,omitempty"`
+}
+
+// Parse parses a line of log output. Lines that aren't structured
+// are kept as the message of an entry without a level.
+func Parse(line []byte, now time.Time) *Entry {
+	e := &Entry{Time: now}
+	line = bytes.TrimRight(line, "\r\n")
+
+	var fields map[string]json.RawMessage
+	if len(line) == 0 || line[0] != '{' || json.Unmarshal(line, &fields) != nil {
+		e.Message = string(line)
+		return e
+	}
+
+	str := func(key string) string {
+		raw, ok := fields[key]
+		if !ok {
+			return ""
+		}
+		delete(fields, key)
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			return string(raw)
+		}
+		return s
+	}
+
+	e.Level = parseLevel(str("level"))
+	e.Message = str("message")
+	e.Service = str("service")
+	e.Endpoint = str("endpoint")
+	e.TraceID = str("trace_id")
+	e.SpanID = str("span_id")
+	if t, err := time.Parse(time.RFC3339Nano, str("time")); err == nil {
+		e.Time = t
+	}
+	if len(fields) > 0 {
+		e.Fields = fields
+	}
+	return e
+}
+
+// Filter selects log entries. Empty fields match all entries.
+type Filter struct {
+	Service  string
+	Endpoint string
+	TraceID  string
+	SpanID   string
+
+	// MinLevel is the lowest level to include.
+	// Entries without a level are only included when it's empty.
+	MinLevel Level
+
+	// Search is a case-insensitive substring the message must contain.
+	Search string
+
+	// AfterSeq only includes entries written after the given entry,
+	// for tailing the logs.
+	AfterSeq int64
+
+	// Since and Until bound the time of the entries.
+	Since, Until time.Time
+}
+
+// Matches reports whether the entry matches the filter.
+func (f *Filter) Matches(e *Entry) bool {
+	switch {
+	case f.Service != "" && e.Service != f.Service,
+		f.Endpoint != "" && e.Endpoint != f.Endpoint,
+		f.TraceID != "" && e.TraceID != f.TraceID,
+		f.SpanID != "" && e.SpanID != f.SpanID,
+		e.Seq <= f.AfterSeq,
+		!f.Since.IsZero() && e.Time.Before(f.Since),
+		!f.Until.IsZero() && e.Time.After(f.Until):
+		return false
+	}
+	if f.MinLevel != "" {
+		lvl, ok := levelOrder[e.Level]
+		if !ok || lvl < levelOrder[f.MinLevel] {
+			return false
+		}
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(e.Message), strings.ToLower(f.Search)) {
+		return false
+	}
+	return true
+}
+
+// DefaultCapacity is the number of entries kept for each app by default.
+const DefaultCapacity = 10000
+
+// Buffer keeps the most recent log entries of each app.
+type Buffer struct {
+	capacity int
+
+	mu   sync.Mutex
+	apps map[string]*appLog
+}
+
+type appLog struct {
+	entries []*Entry // ring buffer
+	next    int      // index of the next entry to write
+	seq     int64
+	partial map[string][]byte // incomplete lines by stream
+}
+
+// NewBuffer creates a buffer keeping up to capacity entries for each app.
+func NewBuffer(capacity int) *Buffer {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Buffer{capacity: capacity, apps: make(map[string]*appLog)}
+}
+
+// Write adds the output written by an app to the given stream,
+// like a process' stdout, which may contain several lines or part of one.
+// It reports the entries added for the complete lines.
+func (b *Buffer) Write(appID, stream string, out []byte) []*Entry {
+	now := time.Now()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	a := b.apps[appID]
+	if a == nil {
+		a = &appLog{partial: make(map[string][]byte)}
+		b.apps[appID] = a
+	}
+
+	var added []*Entry
+	data := out
+	if p := a.partial[stream]; len(p) > 0 {
+		data = append(p, out...)
+	}
+	for {
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			break
+		}
+		if line := data[:idx]; len(bytes.TrimSpace(line)) > 0 {
+			e := Parse(line, now)
+			b.add(a, e)
+			added = append(added, e)
+		}
+		data = data[idx+1:]
+	}
+	// Copy the remainder since out can't be retained.
+	a.partial[stream] = append([]byte(nil), data...)
+	return added
+}
+
+func (b *Buffer) add(a *appLog, e *Entry) {
+	a.seq++
+	e.Seq = a.seq
+	if len(a.entries) < b.capacity {
+		a.entries = append(a.entries, e)
+		return
+	}
+	a.entries[a.next] = e
+	a.next = (a.next + 1) % b.capacity
+}
+
+// List lists the most recent entries of an app matching the filter,
+// up to limit entries, in the order they were written.
+func (b *Buffer) List(appID string, f *Filter, limit int) []*Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := []*Entry{}
+	a := b.apps[appID]
+	if a == nil {
+		return result
+	}
+
+	// Walk from the newest entry backwards.
+	n := len(a.entries)
+	for i := 0; i < n && (limit <= 0 || len(result) < limit); i++ {
+		e := a.entries[(a.next-1-i+2*n)%n]
+		if f.Matches(e) {
+			result = append(result, e)
+		}
+	}
+	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+		result[i], result[j] = result[j], result[i]
+	}
+	return result
+}
+
+// Clear removes the entries of an app.
+func (b *Buffer) Clear(appID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.apps, appID)
+}
@@ -0,0 +1,79 @@
+package applog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParse(t *testing.T) {
+	c := qt.New(t)
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	e := Parse([]byte(`{"level":"WARNING","time":"2024-03-10T11:59:58Z","message":"slow query","service":"orders","endpoint":"Get","trace_id":"t1","span_id":"s1","rows":3}`+"\n"), now)
+	c.Assert(e, qt.DeepEquals, &Entry{
+		Time:     time.Date(2024, 3, 10, 11, 59, 58, 0, time.UTC),
+		Level:    Warn,
+		Message:  "slow query",
+		Service:  "orders",
+		Endpoint: "Get",
+		TraceID:  "t1",
+		SpanID:   "s1",
+		Fields:   map[string]json.RawMessage{"rows": json.RawMessage("3")},
+	})
+
+	// Log lines written by the TypeScript runtime.
+	e = Parse([]byte(`{"level":"info","time":"2024-03-10T11:59:59Z","message":"hello","caller":"greeting","service":"greeting","endpoint":"hello","trace_id":"ntnpgchs2ahr4etbuenr6ht2ddd4q8dr","span_id":"g3sj6eho0ebmk"}`), now)
+	c.Assert(e, qt.DeepEquals, &Entry{
+		Time:     time.Date(2024, 3, 10, 11, 59, 59, 0, time.UTC),
+		Level:    Info,
+		Message:  "hello",
+		Service:  "greeting",
+		Endpoint: "hello",
+		TraceID:  "ntnpgchs2ahr4etbuenr6ht2ddd4q8dr",
+		SpanID:   "g3sj6eho0ebmk",
+		Fields:   map[string]json.RawMessage{"caller": json.RawMessage(`"greeting"`)},
+	})
+
+	e = Parse([]byte("panic: something went wrong"), now)
+	c.Assert(e, qt.DeepEquals, &Entry{Time: now, Message: "panic: something went wrong"})
+}
+
+func TestBuffer(t *testing.T) {
+	c := qt.New(t)
+	b := NewBuffer(3)
+
+	// Lines can be split across writes.
+	added := b.Write("app", "stdout", []byte(`{"level":"info","message":"one","trace_id":"t1","span_id":"s1"}`+"\n"+`{"level":"debug",`))
+	c.Assert(added, qt.HasLen, 1)
+	added = b.Write("app", "stdout", []byte(`"message":"two","trace_id":"t1","span_id":"s2"}`+"\n"))
+	c.Assert(added, qt.HasLen, 1)
+	c.Assert(added[0].Message, qt.Equals, "two")
+	b.Write("app", "stderr", []byte("plain output\n"))
+
+	messages := func(entries []*Entry) []string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Message)
+		}
+		return out
+	}
+	c.Assert(messages(b.List("app", &Filter{}, 0)), qt.DeepEquals, []string{"one", "two", "plain output"})
+	c.Assert(messages(b.List("app", &Filter{}, 2)), qt.DeepEquals, []string{"two", "plain output"})
+	c.Assert(messages(b.List("app", &Filter{MinLevel: Info}, 0)), qt.DeepEquals, []string{"one"})
+	c.Assert(messages(b.List("app", &Filter{TraceID: "t1", SpanID: "s2"}, 0)), qt.DeepEquals, []string{"two"})
+	c.Assert(messages(b.List("app", &Filter{Search: "PLAIN"}, 0)), qt.DeepEquals, []string{"plain output"})
+	c.Assert(messages(b.List("app", &Filter{AfterSeq: 2}, 0)), qt.DeepEquals, []string{"plain output"})
+	c.Assert(b.List("other", &Filter{}, 0), qt.HasLen, 0)
+
+	// The oldest entries are dropped once the buffer is full.
+	b.Write("app", "stdout", []byte("four\nfive\n"))
+	entries := b.List("app", &Filter{}, 0)
+	c.Assert(messages(entries), qt.DeepEquals, []string{"plain output", "four", "five"})
+	c.Assert(entries[2].Seq, qt.Equals, int64(5))
+
+	b.Clear("app")
+	c.Assert(b.List("app", &Filter{}, 0), qt.HasLen, 0)
+}
@@ -367,6 +367,15 @@ func (i *Instance) ProcessPerService() bool {
 	return appFile.Build.Docker.ProcessPerService
 }
 
+// HotReload reports whether the app opted in to being hot reloaded.
+func (i *Instance) HotReload() bool {
+	appFile, err := appfile.ParseFile(filepath.Join(i.root, appfile.Name))
+	if err != nil {
+		return false
+	}
+	return appFile.Watch.HotReload
+}
+
 // GlobalCORS returns the CORS configuration for the app which
 // will be applied against all API gateways into the app
 func (i *Instance) GlobalCORS() (appfile.CORS, error) {
@@ -384,6 +393,12 @@ func (i *Instance) GlobalCORS() (appfile.CORS, error) {
 
 }
 
+// AuditLogRetention returns how long the app's audit log entries are kept,
+// or 0 if they are kept forever.
+func (i *Instance) AuditLogRetention() (time.Duration, error) {
+	return appfile.AuditLogRetention(i.root)
+}
+
 func (i *Instance) Watch(fn WatchFunc) (WatchSubscriptionID, error) {
 	if err := i.beginWatch(); err != nil {
 		return 0, err
@@ -405,7 +420,7 @@ func (i *Instance) Unwatch(id WatchSubscriptionID) {
 
 func (i *Instance) beginWatch() error {
 	return i.setupWatch.Do(func() error {
-		watch, err := watcher.New(i.PlatformOrLocalID())
+		watch, err := watcher.NewWithOptions(i.PlatformOrLocalID(), i.watchOptions())
 		if err != nil {
 			return errors.Wrap(err, "unable to create watcher")
 		}
@@ -449,6 +464,31 @@ func (i *Instance) beginWatch() error {
 	})
 }
 
+// watchOptions returns the options for watching the app,
+// as configured in the app file.
+func (i *Instance) watchOptions() watcher.Options {
+	appFile, err := appfile.ParseFile(filepath.Join(i.root, appfile.Name))
+	if err != nil {
+		log.Warn().Err(err).Str("app", i.root).Msg("unable to parse app file, using default watch options")
+		return watcher.Options{}
+	}
+
+	var opts watcher.Options
+	switch appFile.Watch.Mode {
+	case appfile.WatchNotify:
+		opts.Mode = watcher.ModeNotify
+	case appfile.WatchPoll:
+		opts.Mode = watcher.ModePoll
+	default:
+		opts.Mode = watcher.ModeAuto
+	}
+	if appFile.Watch.PollInterval != "" {
+		// The interval is validated when parsing the app file.
+		opts.PollInterval, _ = time.ParseDuration(appFile.Watch.PollInterval)
+	}
+	return opts
+}
+
 // CachePath returns the path to the cache directory for this app.
 // It creates the directory if it does not exist.
 func (i *Instance) CachePath() (string, error) {
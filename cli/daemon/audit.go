@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encore.dev/appruntime/exported/auditlog"
+	"encr.dev/cli/daemon/audit"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// ListAuditLog lists the entries in the audit log of an app run locally.
+func (s *Server) ListAuditLog(ctx context.Context, req *daemonpb.ListAuditLogRequest) (*daemonpb.ListAuditLogResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	q := &audit.Query{
+		UserID: req.UserId,
+		Action: req.Action,
+		Target: req.Target,
+		Limit:  int(req.Limit),
+	}
+	if req.SinceUnixSecs > 0 {
+		q.Since = time.Unix(req.SinceUnixSecs, 0)
+	}
+	entries, err := s.audit.List(ctx, app.PlatformOrLocalID(), q)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &daemonpb.ListAuditLogResponse{}
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		resp.Entries = append(resp.Entries, data)
+	}
+	return resp, nil
+}
+
+// VerifyAuditLog verifies the hash chain of the audit log of an app run locally.
+func (s *Server) VerifyAuditLog(ctx context.Context, req *daemonpb.VerifyAuditLogRequest) (*daemonpb.VerifyAuditLogResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	n, err := s.audit.Verify(ctx, app.PlatformOrLocalID())
+	resp := &daemonpb.VerifyAuditLogResponse{EntriesVerified: int32(n)}
+	var verr *auditlog.VerifyError
+	if errors.As(err, &verr) {
+		resp.BrokenSeq, resp.BrokenReason = verr.Seq, verr.Reason
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return resp, nil
+}
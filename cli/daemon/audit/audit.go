@@ -0,0 +1,210 @@
+// Package audit stores the audit logs of apps running locally,
+// with the events they record using encore.dev/audit.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encore.dev/appruntime/exported/auditlog"
+)
+
+// NewStore creates a new audit log store.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Store stores the audit logs of apps.
+type Store struct {
+	db *sql.DB
+
+	// mu serializes appends, since each entry is chained to the one before it.
+	mu sync.Mutex
+}
+
+// Append appends an entry to the app's audit log, setting its sequence number and hashes.
+// If retention is positive, entries older than it are removed from the log.
+func (s *Store) Append(ctx context.Context, appID string, e *auditlog.Entry, retention time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "append audit entry")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var prev *auditlog.Entry
+	var prevData string
+	err = tx.QueryRowContext(ctx, `
+		SELECT data FROM audit_entry WHERE app_id = ? ORDER BY seq DESC LIMIT 1
+	`, appID).Scan(&prevData)
+	if err == nil {
+		prev = new(auditlog.Entry)
+		if err := json.Unmarshal([]byte(prevData), prev); err != nil {
+			return errors.Wrap(err, "append audit entry: decode previous entry")
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "append audit entry")
+	}
+
+	e.Time = e.Time.UTC()
+	if err := e.Chain(prev); err != nil {
+		return errors.Wrap(err, "append audit entry")
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "append audit entry")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_entry (app_id, seq, id, time, actor_user_id, action, target, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, appID, e.Seq, e.ID, e.Time, e.Actor.UserID, e.Action, e.Target, string(data))
+	if err != nil {
+		return errors.Wrap(err, "append audit entry")
+	}
+
+	if retention > 0 {
+		if err := prune(ctx, tx, appID, time.Now().Add(-retention), e.Seq); err != nil {
+			return errors.Wrap(err, "prune audit entries")
+		}
+	}
+	return errors.Wrap(tx.Commit(), "append audit entry")
+}
+
+// prune removes the entries from the start of the app's audit log up to the
+// last entry recorded before cutoff, keeping at least the entry with sequence
+// number keepSeq. It records the last removed entry as the log's checkpoint.
+func prune(ctx context.Context, tx *sql.Tx, appID string, cutoff time.Time, keepSeq int64) error {
+	var lastData string
+	err := tx.QueryRowContext(ctx, `
+		SELECT data FROM audit_entry WHERE app_id = ? AND time < ? AND seq < ?
+		ORDER BY seq DESC LIMIT 1
+	`, appID, cutoff.UTC(), keepSeq).Scan(&lastData)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var last auditlog.Entry
+	if err := json.Unmarshal([]byte(lastData), &last); err != nil {
+		return errors.Wrap(err, "decode audit entry")
+	}
+
+	// Remove a prefix of the log so the remaining entries are contiguous.
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM audit_entry WHERE app_id = ? AND seq <= ?
+	`, appID, last.Seq); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_checkpoint (app_id, seq, hash)
+		VALUES (?, ?, ?)
+		ON CONFLICT (app_id) DO UPDATE
+		SET seq = excluded.seq, hash = excluded.hash
+	`, appID, last.Seq, last.Hash)
+	return err
+}
+
+// Query filters the entries to list. Empty fields match all entries.
+type Query struct {
+	UserID string
+	Action string
+	Target string
+	Since  time.Time
+	Until  time.Time
+	Limit  int // defaults to 100
+}
+
+// List lists the entries in the app's audit log matching the query, newest first.
+func (s *Store) List(ctx context.Context, appID string, q *Query) ([]*auditlog.Entry, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	until := q.Until
+	if until.IsZero() {
+		until = time.Now().Add(time.Hour)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM audit_entry
+		WHERE app_id = ?
+			AND (? = '' OR actor_user_id = ?)
+			AND (? = '' OR action = ?)
+			AND (? = '' OR target = ?)
+			AND time >= ? AND time < ?
+		ORDER BY seq DESC
+		LIMIT ?
+	`, appID, q.UserID, q.UserID, q.Action, q.Action, q.Target, q.Target,
+		q.Since.UTC(), until.UTC(), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "list audit entries")
+	}
+	defer func() { _ = rows.Close() }()
+
+	res := []*auditlog.Entry{}
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "list audit entries")
+		}
+		res = append(res, e)
+	}
+	return res, errors.Wrap(rows.Err(), "list audit entries")
+}
+
+// Verify verifies the hash chain of the app's audit log, and reports
+// the number of entries verified. If the chain is broken it reports
+// a *auditlog.VerifyError describing where.
+func (s *Store) Verify(ctx context.Context, appID string) (int, error) {
+	var checkpoint *auditlog.Checkpoint
+	var cp auditlog.Checkpoint
+	err := s.db.QueryRowContext(ctx, `
+		SELECT seq, hash FROM audit_checkpoint WHERE app_id = ?
+	`, appID).Scan(&cp.Seq, &cp.Hash)
+	if err == nil {
+		checkpoint = &cp
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.Wrap(err, "verify audit log")
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT data FROM audit_entry WHERE app_id = ? ORDER BY seq ASC
+	`, appID)
+	if err != nil {
+		return 0, errors.Wrap(err, "verify audit log")
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []*auditlog.Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return 0, errors.Wrap(err, "verify audit log")
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "verify audit log")
+	}
+	return len(entries), auditlog.Verify(checkpoint, entries)
+}
+
+func scanEntry(rows *sql.Rows) (*auditlog.Entry, error) {
+	var data string
+	if err := rows.Scan(&data); err != nil {
+		return nil, err
+	}
+	var e auditlog.Entry
+	if err := json.Unmarshal([]byte(data), &e); err != nil {
+		return nil, errors.Wrap(err, "decode audit entry")
+	}
+	return &e, nil
+}
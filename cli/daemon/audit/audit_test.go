@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/auditlog"
+
+	"encr.dev/cli/daemon/mgmtdb/mgmtdbtest"
+)
+
+func newTestStore(c *qt.C) (*Store, *sql.DB) {
+	db := mgmtdbtest.New(c)
+	return NewStore(db), db
+}
+
+func TestStore(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	s, db := newTestStore(c)
+
+	now := time.Now()
+	for i, e := range []*auditlog.Entry{
+		{ID: "1", Time: now.Add(-2 * time.Hour), Actor: auditlog.Actor{UserID: "alice"}, Action: "invoice.create", Target: "invoice/1"},
+		{ID: "2", Time: now.Add(-time.Hour), Actor: auditlog.Actor{UserID: "bob"}, Action: "invoice.update", Target: "invoice/1"},
+		{ID: "3", Time: now, Actor: auditlog.Actor{UserID: "alice"}, Action: "invoice.create", Target: "invoice/2"},
+	} {
+		c.Assert(s.Append(ctx, "app", e, 0), qt.IsNil)
+		c.Assert(e.Seq, qt.Equals, int64(i+1))
+		c.Assert(e.Hash, qt.Not(qt.Equals), "")
+	}
+
+	all, err := s.List(ctx, "app", &Query{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(all, qt.HasLen, 3)
+	c.Assert(all[0].ID, qt.Equals, "3") // newest first
+
+	list, err := s.List(ctx, "app", &Query{UserID: "alice", Action: "invoice.create"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 2)
+	list, err = s.List(ctx, "app", &Query{Target: "invoice/1", Since: now.Add(-90 * time.Minute)})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].ID, qt.Equals, "2")
+	list, err = s.List(ctx, "other", &Query{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 0)
+
+	n, err := s.Verify(ctx, "app")
+	c.Assert(err, qt.IsNil)
+	c.Assert(n, qt.Equals, 3)
+
+	// Tampering with an entry is detected.
+	_, err = db.Exec(`UPDATE audit_entry SET data = replace(data, 'bob', 'mallory') WHERE seq = 2`)
+	c.Assert(err, qt.IsNil)
+	_, err = s.Verify(ctx, "app")
+	var verr *auditlog.VerifyError
+	c.Assert(errors.As(err, &verr), qt.IsTrue)
+	c.Assert(verr.Seq, qt.Equals, int64(2))
+}
+
+func TestRetention(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	s, db := newTestStore(c)
+
+	now := time.Now()
+	c.Assert(s.Append(ctx, "app", &auditlog.Entry{ID: "old", Time: now.Add(-48 * time.Hour), Action: "a"}, 0), qt.IsNil)
+	c.Assert(s.Append(ctx, "app", &auditlog.Entry{ID: "old2", Time: now.Add(-36 * time.Hour), Action: "a"}, 0), qt.IsNil)
+	c.Assert(s.Append(ctx, "app", &auditlog.Entry{ID: "new", Time: now.Add(-time.Hour), Action: "a"}, 0), qt.IsNil)
+	c.Assert(s.Append(ctx, "app", &auditlog.Entry{ID: "newest", Time: now, Action: "a"}, 24*time.Hour), qt.IsNil)
+
+	list, err := s.List(ctx, "app", &Query{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 2)
+	c.Assert(list[1].ID, qt.Equals, "new")
+
+	// The remaining entries still verify, anchored by the checkpoint.
+	n, err := s.Verify(ctx, "app")
+	c.Assert(err, qt.IsNil)
+	c.Assert(n, qt.Equals, 2)
+
+	// Removing the oldest remaining entry is detected.
+	_, err = db.Exec(`DELETE FROM audit_entry WHERE seq = 3`)
+	c.Assert(err, qt.IsNil)
+	_, err = s.Verify(ctx, "app")
+	var verr *auditlog.VerifyError
+	c.Assert(errors.As(err, &verr), qt.IsTrue)
+	c.Assert(verr.Seq, qt.Equals, int64(3))
+}
@@ -23,6 +23,8 @@ func (s *Server) Check(req *daemonpb.CheckRequest, stream daemonpb.Daemon_CheckS
 		CodegenDebug: req.CodegenDebug,
 		Environ:      req.Environ,
 		Tests:        req.ParseTests,
+
+		DetectHardcodedSecrets: true,
 	})
 
 	exitCode := 0
@@ -0,0 +1,149 @@
+// Package collections manages the named request collections saved
+// in the API explorer of the local development dashboard.
+//
+// Collections are persisted per app in the daemon database, and can be
+// exported to a file for sharing and imported again, or imported from
+// curl commands and Postman collections.
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/xid"
+)
+
+// Request is a saved API request.
+type Request struct {
+	Name string `json:"name"`
+
+	// Service and Endpoint identify the endpoint the request is for,
+	// if known. Imported requests only have a method and path.
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	Method  string            `json:"method"`
+	Path    string            `json:"path"` // including any query string
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// Collection is a named collection of requests.
+type Collection struct {
+	ID        string     `json:"id"`
+	AppID     string     `json:"app_id"`
+	Name      string     `json:"name"`
+	Requests  []*Request `json:"requests"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// NewManager creates a new collection manager.
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{db: db}
+}
+
+// Manager manages request collections.
+type Manager struct {
+	db *sql.DB
+}
+
+// List lists the collections of an app, ordered by name.
+func (m *Manager) List(ctx context.Context, appID string) ([]*Collection, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT id, app_id, name, requests, created_at, updated_at
+		FROM request_collection
+		WHERE app_id = ?
+		ORDER BY name ASC
+	`, appID)
+	if err != nil {
+		return nil, errors.Wrap(err, "list collections")
+	}
+	defer rows.Close()
+
+	res := []*Collection{}
+	for rows.Next() {
+		c, err := scanCollection(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, errors.Wrap(rows.Err(), "list collections")
+}
+
+// Get returns the collection with the given id.
+// It reports sql.ErrNoRows if there is no such collection.
+func (m *Manager) Get(ctx context.Context, appID, id string) (*Collection, error) {
+	row := m.db.QueryRowContext(ctx, `
+		SELECT id, app_id, name, requests, created_at, updated_at
+		FROM request_collection
+		WHERE app_id = ? AND id = ?
+	`, appID, id)
+	return scanCollection(row)
+}
+
+// Save creates or updates a collection.
+// If c.ID is empty a new collection is created and c.ID is set.
+func (m *Manager) Save(ctx context.Context, c *Collection) error {
+	if c.Name == "" {
+		return errors.New("collection name is required")
+	}
+	if c.Requests == nil {
+		c.Requests = []*Request{}
+	}
+	reqs, err := json.Marshal(c.Requests)
+	if err != nil {
+		return errors.Wrap(err, "marshal requests")
+	}
+
+	now := time.Now()
+	if c.ID == "" {
+		c.ID = xid.NewWithTime(now).String()
+		c.CreatedAt = now
+	}
+	c.UpdatedAt = now
+
+	res, err := m.db.ExecContext(ctx, `
+		INSERT INTO request_collection (id, app_id, name, requests, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET name = excluded.name, requests = excluded.requests, updated_at = excluded.updated_at
+		WHERE request_collection.app_id = excluded.app_id
+	`, c.ID, c.AppID, c.Name, string(reqs), c.CreatedAt, c.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "save collection")
+	}
+	// The upsert doesn't touch collections of other apps with the same id.
+	if n, err := res.RowsAffected(); err != nil {
+		return errors.Wrap(err, "save collection")
+	} else if n == 0 {
+		return errors.Newf("save collection: collection %s belongs to another app", c.ID)
+	}
+	return nil
+}
+
+// Delete deletes a collection.
+func (m *Manager) Delete(ctx context.Context, appID, id string) error {
+	_, err := m.db.ExecContext(ctx, `
+		DELETE FROM request_collection WHERE app_id = ? AND id = ?
+	`, appID, id)
+	return errors.Wrap(err, "delete collection")
+}
+
+func scanCollection(row interface{ Scan(...any) error }) (*Collection, error) {
+	var (
+		c    Collection
+		reqs string
+	)
+	if err := row.Scan(&c.ID, &c.AppID, &c.Name, &reqs, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := json.Unmarshal([]byte(reqs), &c.Requests); err != nil {
+		return nil, errors.Wrap(err, "unmarshal requests")
+	}
+	return &c, nil
+}
@@ -0,0 +1,153 @@
+package collections
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/mgmtdb/mgmtdbtest"
+)
+
+func newTestManager(c *qt.C) *Manager {
+	return NewManager(mgmtdbtest.New(c))
+}
+
+func TestManager(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	m := newTestManager(c)
+
+	coll := &Collection{AppID: "app", Name: "Users", Requests: []*Request{
+		{Name: "Get user", Method: "GET", Path: "/users/1"},
+	}}
+	c.Assert(m.Save(ctx, coll), qt.IsNil)
+	c.Assert(coll.ID, qt.Not(qt.Equals), "")
+
+	// Update the collection.
+	coll.Requests = append(coll.Requests, &Request{Name: "Create user", Method: "POST", Path: "/users", Body: `{"name":"x"}`})
+	c.Assert(m.Save(ctx, coll), qt.IsNil)
+
+	got, err := m.Get(ctx, "app", coll.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Name, qt.Equals, "Users")
+	c.Assert(got.Requests, qt.HasLen, 2)
+	c.Assert(got.Requests[1].Body, qt.Equals, `{"name":"x"}`)
+
+	// Collections are scoped to their app.
+	list, err := m.List(ctx, "other")
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 0)
+	_, err = m.Get(ctx, "other", coll.ID)
+	c.Assert(errors.Is(err, sql.ErrNoRows), qt.IsTrue)
+
+	// Another app can't overwrite the collection by reusing its id.
+	err = m.Save(ctx, &Collection{ID: coll.ID, AppID: "other", Name: "Hijack"})
+	c.Assert(err, qt.ErrorMatches, "save collection: collection .* belongs to another app")
+	got, err = m.Get(ctx, "app", coll.ID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Name, qt.Equals, "Users")
+
+	c.Assert(m.Delete(ctx, "app", coll.ID), qt.IsNil)
+	list, err = m.List(ctx, "app")
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 0)
+}
+
+func TestExportImport(t *testing.T) {
+	c := qt.New(t)
+	orig := &Collection{ID: "id", AppID: "app", Name: "Users", Requests: []*Request{
+		{Name: "Get user", Service: "user", Endpoint: "Get", Method: "GET", Path: "/users/1", Headers: map[string]string{"X-Foo": "bar"}},
+	}}
+	data, err := Export(orig)
+	c.Assert(err, qt.IsNil)
+
+	got, err := Import(data)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.ID, qt.Equals, "")
+	c.Assert(got.Name, qt.Equals, "Users")
+	c.Assert(got.Requests, qt.DeepEquals, orig.Requests)
+
+	_, err = Import([]byte(`{"encore_collection": 99}`))
+	c.Assert(err, qt.ErrorMatches, "unsupported collection version 99.*")
+	_, err = Import([]byte(`{"foo": 1}`))
+	c.Assert(err, qt.ErrorMatches, "unrecognized collection format.*")
+}
+
+func TestImportPostman(t *testing.T) {
+	c := qt.New(t)
+	data := []byte(`{
+		"info": {"name": "My API", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+		"item": [
+			{"name": "Users", "item": [
+				{"name": "Create", "request": {
+					"method": "post",
+					"header": [{"key": "Content-Type", "value": "application/json"}, {"key": "X-Off", "value": "1", "disabled": true}],
+					"url": {"raw": "{{baseUrl}}/users?dry=true"},
+					"body": {"mode": "raw", "raw": "{\"name\":\"x\"}"}
+				}}
+			]},
+			{"name": "Health", "request": {"method": "GET", "url": "http://localhost:4000/health"}},
+			{"name": "Version", "request": "{{baseUrl}}/version"}
+		]
+	}`)
+	got, err := Import(data)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Name, qt.Equals, "My API")
+	c.Assert(got.Requests, qt.DeepEquals, []*Request{
+		{Name: "Users / Create", Method: "POST", Path: "/users?dry=true", Headers: map[string]string{"Content-Type": "application/json"}, Body: `{"name":"x"}`},
+		{Name: "Health", Method: "GET", Path: "/health"},
+		{Name: "Version", Method: "GET", Path: "/version"},
+	})
+}
+
+func TestParseCurl(t *testing.T) {
+	tests := []struct {
+		cmd     string
+		want    *Request
+		wantErr string
+	}{
+		{
+			cmd:  `curl http://localhost:4000/hello/world`,
+			want: &Request{Name: "GET /hello/world", Method: "GET", Path: "/hello/world"},
+		},
+		{
+			cmd: `curl -X PUT 'http://localhost:4000/users/1' \
+  -H 'Content-Type: application/json' \
+  --data-raw '{"name": "it'\''s me"}' --compressed`,
+			want: &Request{
+				Name: "PUT /users/1", Method: "PUT", Path: "/users/1",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"name": "it's me"}`,
+			},
+		},
+		{
+			cmd: `curl --json "{\"a\":1}" --url=localhost:4000/items`,
+			want: &Request{
+				Name: "POST /items", Method: "POST", Path: "/items",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    `{"a":1}`,
+			},
+		},
+		{
+			cmd:  `curl -G -d q=foo -d limit=10 "http://localhost:4000/search?x=1"`,
+			want: &Request{Name: "GET /search?x=1&q=foo&limit=10", Method: "GET", Path: "/search?x=1&q=foo&limit=10"},
+		},
+		{cmd: `wget http://localhost`, wantErr: "not a curl command"},
+		{cmd: `curl -H`, wantErr: "missing value for -H"},
+		{cmd: `curl 'http://localhost`, wantErr: "unterminated quote in command"},
+	}
+
+	c := qt.New(t)
+	for _, test := range tests {
+		got, err := ParseCurl(test.cmd)
+		if test.wantErr != "" {
+			c.Assert(err, qt.ErrorMatches, test.wantErr, qt.Commentf("cmd: %s", test.cmd))
+			continue
+		}
+		c.Assert(err, qt.IsNil, qt.Commentf("cmd: %s", test.cmd))
+		c.Assert(got, qt.DeepEquals, test.want, qt.Commentf("cmd: %s", test.cmd))
+	}
+}
@@ -0,0 +1,362 @@
+package collections
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// exportVersion is the version of the export file format.
+const exportVersion = 1
+
+// exportFile is the format of exported collections.
+type exportFile struct {
+	EncoreCollection int        `json:"encore_collection"` // the format version
+	Name             string     `json:"name"`
+	Requests         []*Request `json:"requests"`
+}
+
+// Export exports a collection to a file that can be shared and imported again.
+func Export(c *Collection) ([]byte, error) {
+	return json.MarshalIndent(&exportFile{
+		EncoreCollection: exportVersion,
+		Name:             c.Name,
+		Requests:         c.Requests,
+	}, "", "  ")
+}
+
+// Import parses an exported collection or a Postman collection (v2.0 or v2.1).
+// The returned collection has not been saved.
+func Import(data []byte) (*Collection, error) {
+	var probe struct {
+		EncoreCollection int `json:"encore_collection"`
+		Info             *struct {
+			Schema string `json:"schema"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, errors.Wrap(err, "invalid collection file")
+	}
+
+	switch {
+	case probe.EncoreCollection > 0:
+		if probe.EncoreCollection > exportVersion {
+			return nil, errors.Newf("unsupported collection version %d; upgrade Encore to import it", probe.EncoreCollection)
+		}
+		var f exportFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, errors.Wrap(err, "invalid collection file")
+		}
+		if f.Requests == nil {
+			f.Requests = []*Request{}
+		}
+		return &Collection{Name: f.Name, Requests: f.Requests}, nil
+
+	case probe.Info != nil && strings.Contains(probe.Info.Schema, "postman"):
+		return importPostman(data)
+
+	default:
+		return nil, errors.New("unrecognized collection format: expected an exported Encore or Postman collection")
+	}
+}
+
+type postmanCollection struct {
+	Info struct {
+		Name string `json:"name"`
+	} `json:"info"`
+	Item []*postmanItem `json:"item"`
+}
+
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []*postmanItem  `json:"item"` // set for folders
+	Request *postmanRequest `json:"request"`
+}
+
+type postmanRequest struct {
+	Method string `json:"method"`
+	Header []struct {
+		Key      string `json:"key"`
+		Value    string `json:"value"`
+		Disabled bool   `json:"disabled"`
+	} `json:"header"`
+	URL  json.RawMessage `json:"url"` // either a string or an object with a "raw" field
+	Body *struct {
+		Mode string `json:"mode"`
+		Raw  string `json:"raw"`
+	} `json:"body"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// A request can also be given as just a URL, which is requested with GET.
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*r = postmanRequest{Method: "GET", URL: data}
+		return nil
+	}
+	type plain postmanRequest
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+func importPostman(data []byte) (*Collection, error) {
+	var pc postmanCollection
+	if err := json.Unmarshal(data, &pc); err != nil {
+		return nil, errors.Wrap(err, "invalid postman collection")
+	}
+
+	c := &Collection{Name: pc.Info.Name, Requests: []*Request{}}
+	var walk func(items []*postmanItem, prefix string) error
+	walk = func(items []*postmanItem, prefix string) error {
+		for _, it := range items {
+			if it.Request == nil {
+				if err := walk(it.Item, prefix+it.Name+" / "); err != nil {
+					return err
+				}
+				continue
+			}
+
+			r := &Request{Name: prefix + it.Name, Method: strings.ToUpper(it.Request.Method)}
+			if r.Method == "" {
+				r.Method = "GET"
+			}
+			rawURL, err := postmanURL(it.Request.URL)
+			if err != nil {
+				return errors.Wrapf(err, "request %q", r.Name)
+			}
+			r.Path = requestPath(rawURL)
+			for _, h := range it.Request.Header {
+				if !h.Disabled && h.Key != "" {
+					if r.Headers == nil {
+						r.Headers = make(map[string]string)
+					}
+					r.Headers[h.Key] = h.Value
+				}
+			}
+			if b := it.Request.Body; b != nil && b.Mode == "raw" {
+				r.Body = b.Raw
+			}
+			c.Requests = append(c.Requests, r)
+		}
+		return nil
+	}
+	if err := walk(pc.Item, ""); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// postmanURL returns the raw URL of a Postman request URL,
+// which is either a string or an object.
+func postmanURL(data json.RawMessage) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s, nil
+	}
+	var obj struct {
+		Raw string `json:"raw"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", errors.Wrap(err, "invalid url")
+	}
+	return obj.Raw, nil
+}
+
+// postmanVarPrefix matches a leading Postman variable like "{{baseUrl}}".
+var postmanVarPrefix = regexp.MustCompile(`^\{\{[^}]*\}\}`)
+
+// requestPath returns the path and query of a URL, dropping the scheme and host
+// since requests are always sent to the locally running app.
+func requestPath(rawURL string) string {
+	rawURL = postmanVarPrefix.ReplaceAllString(strings.TrimSpace(rawURL), "")
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		rawURL = u.RequestURI()
+	} else if !strings.HasPrefix(rawURL, "/") {
+		// A host without a scheme, like "localhost:4000/foo".
+		if _, rest, ok := strings.Cut(rawURL, "/"); ok {
+			rawURL = "/" + rest
+		} else {
+			rawURL = "/"
+		}
+	}
+	if rawURL == "" {
+		rawURL = "/"
+	}
+	return rawURL
+}
+
+// ParseCurl parses a curl command into a request.
+func ParseCurl(cmd string) (*Request, error) {
+	args, err := splitShellWords(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, errors.New("not a curl command")
+	}
+
+	r := &Request{}
+	var (
+		rawURL  string
+		getData bool
+	)
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		next := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", errors.Newf("missing value for %s", arg)
+			}
+			i++
+			return args[i], nil
+		}
+
+		// Support the "--flag=value" form.
+		if strings.HasPrefix(arg, "--") {
+			if name, val, ok := strings.Cut(arg, "="); ok {
+				arg = name
+				args = append(args[:i+1], append([]string{val}, args[i+1:]...)...)
+			}
+		}
+
+		switch arg {
+		case "-X", "--request":
+			m, err := next()
+			if err != nil {
+				return nil, err
+			}
+			r.Method = strings.ToUpper(m)
+		case "-H", "--header":
+			h, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if key, val, ok := strings.Cut(h, ":"); ok {
+				if r.Headers == nil {
+					r.Headers = make(map[string]string)
+				}
+				r.Headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+			}
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii", "--json":
+			d, err := next()
+			if err != nil {
+				return nil, err
+			}
+			if r.Body != "" {
+				r.Body += "&"
+			}
+			r.Body += d
+			if arg == "--json" {
+				if r.Headers == nil {
+					r.Headers = make(map[string]string)
+				}
+				r.Headers["Content-Type"] = "application/json"
+			}
+		case "-G", "--get":
+			getData = true
+		case "--url":
+			if rawURL, err = next(); err != nil {
+				return nil, err
+			}
+		case "-u", "--user", "-A", "--user-agent", "-e", "--referer", "-b", "--cookie", "-o", "--output", "-m", "--max-time":
+			// Flags with values that don't affect the request itself.
+			if _, err := next(); err != nil {
+				return nil, err
+			}
+		default:
+			if !strings.HasPrefix(arg, "-") && rawURL == "" {
+				rawURL = arg
+			}
+			// Ignore other flags like --compressed, -s, -v and -L.
+		}
+	}
+
+	if rawURL == "" {
+		return nil, errors.New("no URL in curl command")
+	}
+	r.Path = requestPath(rawURL)
+	if getData && r.Body != "" {
+		sep := "?"
+		if strings.Contains(r.Path, "?") {
+			sep = "&"
+		}
+		r.Path += sep + r.Body
+		r.Body = ""
+	}
+	if r.Method == "" {
+		r.Method = "GET"
+		if r.Body != "" {
+			r.Method = "POST"
+		}
+	}
+	r.Name = r.Method + " " + r.Path
+	return r, nil
+}
+
+// splitShellWords splits a command line into words,
+// handling quotes, escapes and line continuations like a POSIX shell.
+func splitShellWords(s string) ([]string, error) {
+	var (
+		words   []string
+		cur     strings.Builder
+		inWord  bool
+		quote   rune // the current quote character, or 0
+		escaped bool
+	)
+	for _, ch := range s {
+		switch {
+		case escaped:
+			escaped = false
+			if ch == '\n' {
+				continue // a backslash-newline is a line continuation
+			}
+			if quote == '"' && !strings.ContainsRune("$`\"\\", ch) {
+				// Within double quotes, backslashes only escape some characters.
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(ch)
+			inWord = true
+		case quote == '\'':
+			if ch == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(ch)
+			}
+		case quote == '"':
+			switch ch {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				cur.WriteRune(ch)
+			}
+		case ch == '\\':
+			escaped = true
+		case ch == '\'' || ch == '"':
+			quote = ch
+			inWord = true
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(ch)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote in command")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
@@ -3,15 +3,13 @@ package daemon
 import (
 	"io"
 	"net"
-	"os"
-	"runtime"
 	"strconv"
 	"strings"
-	"syscall"
 
 	"github.com/logrusorgru/aurora/v3"
 
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/jsonsample"
 	"encr.dev/cli/internal/onboarding"
 	"encr.dev/pkg/errlist"
 	meta "encr.dev/proto/encore/parser/meta/v1"
@@ -60,6 +58,9 @@ func (s *Server) OnError(r *run.Run, err *errlist.List) {
 	}
 }
 
+// OnCrash implements run.EventListener.
+func (s *Server) OnCrash(r *run.Run, process string, err error) {}
+
 func showFirstRunExperience(run *run.Run, md *meta.Data, stdout io.Writer) {
 	if state, err := onboarding.Load(); err == nil {
 		if !state.FirstRun.IsSet() {
@@ -115,7 +116,7 @@ func genCurlCommand(run *run.Run, md *meta.Data, rpc *meta.RPC) string {
 	case "GET", "HEAD", "DELETE":
 		// doesn't use HTTP body payloads
 	default:
-		payload = genSchema(md, rpc.RequestSchema)
+		payload = jsonsample.Generate(md, rpc.RequestSchema)
 	}
 
 	var segments []string
@@ -158,21 +159,3 @@ func genCurlCommand(run *run.Run, md *meta.Data, rpc *meta.RPC) string {
 	}
 	return strings.Join(parts, "")
 }
-
-// errIsAddrInUse reports whether the error is due to the address already being in use.
-func errIsAddrInUse(err error) bool {
-	if opErr, ok := err.(*net.OpError); ok {
-		if syscallErr, ok := opErr.Err.(*os.SyscallError); ok {
-			if errno, ok := syscallErr.Err.(syscall.Errno); ok {
-				const WSAEADDRINUSE = 10048
-				switch {
-				case errno == syscall.EADDRINUSE:
-					return true
-				case runtime.GOOS == "windows" && errno == WSAEADDRINUSE:
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
@@ -0,0 +1,186 @@
+// Package crons lets the local development dashboard list an app's
+// cron jobs, trigger them on demand and keep a history of their executions.
+//
+// Cron jobs don't run on their schedule when developing locally,
+// so the next run times are those the job would have when deployed.
+package crons
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	cronparser "github.com/robfig/cron/v3"
+	"github.com/rs/xid"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Job is a cron job.
+type Job struct {
+	ID       string      `json:"id"`
+	Title    string      `json:"title"`
+	Doc      string      `json:"doc,omitempty"`
+	Schedule string      `json:"schedule"` // "every:<minutes>" or "schedule:<cron expression>"
+	Service  string      `json:"service"`
+	Endpoint string      `json:"endpoint"`
+	Path     string      `json:"path"`
+	NextRuns []time.Time `json:"next_runs"`
+}
+
+// Jobs returns the cron jobs of an app, with their next n run times after now.
+func Jobs(md *meta.Data, now time.Time, n int) []*Job {
+	jobs := []*Job{} // prevent marshalling as null
+	for _, cj := range md.CronJobs {
+		job := &Job{
+			ID:       cj.Id,
+			Title:    cj.Title,
+			Doc:      strings.TrimSpace(cj.GetDoc()),
+			Schedule: cj.Schedule,
+			NextRuns: []time.Time{},
+		}
+		if svc, rpc := findEndpoint(md, cj.Endpoint); rpc != nil {
+			job.Service, job.Endpoint, job.Path = svc.Name, rpc.Name, rpcPath(rpc)
+		}
+		if runs, err := NextRuns(cj.Schedule, now, n); err == nil {
+			job.NextRuns = runs
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// FindJob finds the cron job with the given id.
+// If it cannot be found it reports nil.
+func FindJob(md *meta.Data, id string) *Job {
+	for _, job := range Jobs(md, time.Now(), 0) {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// findEndpoint finds the service and endpoint a cron job calls.
+func findEndpoint(md *meta.Data, ep *meta.QualifiedName) (*meta.Service, *meta.RPC) {
+	if ep == nil {
+		return nil, nil
+	}
+	for _, svc := range md.Svcs {
+		if ep.Pkg != svc.RelPath && !strings.HasPrefix(ep.Pkg, svc.RelPath+"/") {
+			continue
+		}
+		for _, rpc := range svc.Rpcs {
+			if rpc.Name == ep.Name {
+				return svc, rpc
+			}
+		}
+	}
+	return nil, nil
+}
+
+// rpcPath returns the path of an endpoint.
+// Cron job endpoints have no path parameters.
+func rpcPath(rpc *meta.RPC) string {
+	var b strings.Builder
+	for _, seg := range rpc.Path.GetSegments() {
+		b.WriteByte('/')
+		b.WriteString(seg.Value)
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+// scheduleParser parses cron expressions like the Encore parser does.
+var scheduleParser = cronparser.NewParser(cronparser.Minute | cronparser.Hour | cronparser.Dom | cronparser.Month | cronparser.Dow)
+
+// NextRuns returns the next n times a job with the given schedule runs after from.
+// Schedules are in UTC, and "every" schedules are aligned to midnight UTC.
+func NextRuns(schedule string, from time.Time, n int) ([]time.Time, error) {
+	from = from.UTC()
+	runs := make([]time.Time, 0, n)
+
+	switch kind, val, _ := strings.Cut(schedule, ":"); kind {
+	case "every":
+		minutes, err := strconv.Atoi(val)
+		if err != nil || minutes <= 0 {
+			return nil, errors.Newf("invalid schedule %q", schedule)
+		}
+		every := time.Duration(minutes) * time.Minute
+		midnight := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+		next := midnight.Add(from.Sub(midnight).Truncate(every) + every)
+		for len(runs) < n {
+			runs = append(runs, next)
+			next = next.Add(every)
+			if next.Day() != runs[len(runs)-1].Day() {
+				// Start over from midnight each day.
+				next = time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+			}
+		}
+
+	case "schedule":
+		sched, err := scheduleParser.Parse(val)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid schedule %q", schedule)
+		}
+		next := from
+		for len(runs) < n {
+			next = sched.Next(next)
+			if next.IsZero() {
+				break
+			}
+			runs = append(runs, next)
+		}
+
+	default:
+		return nil, errors.Newf("invalid schedule %q", schedule)
+	}
+	return runs, nil
+}
+
+// Trigger runs a cron job by calling its endpoint on the app listening on baseURL,
+// the same way it's called when deployed.
+//
+// The returned execution reports whether the job failed, and is not saved.
+// An error is only returned if the job could not be triggered.
+func Trigger(ctx context.Context, baseURL, appID string, job *Job) (*Execution, error) {
+	if job.Path == "" {
+		return nil, errors.Newf("cron job %q has no endpoint", job.ID)
+	}
+
+	start := time.Now()
+	exec := &Execution{
+		ID:        xid.NewWithTime(start).String(),
+		AppID:     appID,
+		JobID:     job.ID,
+		StartedAt: start,
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+job.Path, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	req.Header.Set("X-Encore-Cron-Execution", exec.ID)
+
+	resp, err := http.DefaultClient.Do(req)
+	exec.Duration = time.Since(start)
+	if err != nil {
+		exec.Error = err.Error()
+		return exec, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	exec.StatusCode = resp.StatusCode
+	exec.TraceID = resp.Header.Get("X-Encore-Trace-Id")
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		exec.Error = fmt.Sprintf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return exec, nil
+}
@@ -0,0 +1,112 @@
+package crons
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/mgmtdb/mgmtdbtest"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestNextRuns(t *testing.T) {
+	c := qt.New(t)
+	from := time.Date(2024, 3, 10, 23, 20, 30, 0, time.UTC)
+	at := func(day, hour, min int) time.Time { return time.Date(2024, 3, day, hour, min, 0, 0, time.UTC) }
+
+	runs, err := NextRuns("every:30", from, 3)
+	c.Assert(err, qt.IsNil)
+	c.Assert(runs, qt.DeepEquals, []time.Time{at(10, 23, 30), at(11, 0, 0), at(11, 0, 30)})
+
+	runs, err = NextRuns("every:1440", from, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(runs, qt.DeepEquals, []time.Time{at(11, 0, 0), at(12, 0, 0)})
+
+	runs, err = NextRuns("schedule:0 9 * * 1", from, 2) // Mondays at 09:00
+	c.Assert(err, qt.IsNil)
+	c.Assert(runs, qt.DeepEquals, []time.Time{at(11, 9, 0), at(18, 9, 0)})
+
+	_, err = NextRuns("schedule:not a schedule", from, 1)
+	c.Assert(err, qt.ErrorMatches, `invalid schedule .*`)
+	_, err = NextRuns("every:0", from, 1)
+	c.Assert(err, qt.ErrorMatches, `invalid schedule "every:0"`)
+}
+
+func TestJobs(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs: []*meta.Service{{
+			Name:    "email",
+			RelPath: "email",
+			Rpcs: []*meta.RPC{{
+				Name: "SendDigest",
+				Path: &meta.Path{Segments: []*meta.PathSegment{{Value: "email.SendDigest"}}},
+			}},
+		}},
+		CronJobs: []*meta.CronJob{{
+			Id:       "send-digest",
+			Title:    "Send digest",
+			Schedule: "every:60",
+			Endpoint: &meta.QualifiedName{Pkg: "email/digest", Name: "SendDigest"},
+		}},
+	}
+
+	jobs := Jobs(md, time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC), 2)
+	c.Assert(jobs, qt.HasLen, 1)
+	c.Assert(jobs[0].Service, qt.Equals, "email")
+	c.Assert(jobs[0].Endpoint, qt.Equals, "SendDigest")
+	c.Assert(jobs[0].Path, qt.Equals, "/email.SendDigest")
+	c.Assert(jobs[0].NextRuns, qt.HasLen, 2)
+	c.Assert(FindJob(md, "send-digest"), qt.IsNotNil)
+	c.Assert(FindJob(md, "unknown"), qt.IsNil)
+}
+
+func TestTriggerAndHistory(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var gotExecID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		gotExecID = req.Header.Get("X-Encore-Cron-Execution")
+		w.Header().Set("X-Encore-Trace-Id", "trace-"+req.URL.Path[1:])
+		if req.URL.Path == "/fail" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	h := NewHistory(mgmtdbtest.New(c))
+
+	ok, err := Trigger(ctx, srv.URL, "app", &Job{ID: "ok", Path: "/ok"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotExecID, qt.Equals, ok.ID)
+	c.Assert(ok.StatusCode, qt.Equals, 200)
+	c.Assert(ok.Error, qt.Equals, "")
+	c.Assert(ok.TraceID, qt.Equals, "trace-ok")
+	c.Assert(h.Record(ctx, ok), qt.IsNil)
+
+	failed, err := Trigger(ctx, srv.URL, "app", &Job{ID: "fail", Path: "/fail"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(failed.StatusCode, qt.Equals, 500)
+	c.Assert(failed.Error, qt.Equals, "500 Internal Server Error: boom")
+	c.Assert(h.Record(ctx, failed), qt.IsNil)
+
+	all, err := h.List(ctx, "app", "", 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(all, qt.HasLen, 2)
+	c.Assert(all[0].ID, qt.Equals, failed.ID) // newest first
+
+	list, err := h.List(ctx, "app", "ok", 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 1)
+	c.Assert(list[0].TraceID, qt.Equals, "trace-ok")
+	c.Assert(list[0].Duration, qt.Equals, ok.Duration)
+
+	list, err = h.List(ctx, "other", "", 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(list, qt.HasLen, 0)
+}
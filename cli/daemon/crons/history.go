@@ -0,0 +1,70 @@
+package crons
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Execution is an execution of a cron job.
+type Execution struct {
+	ID         string        `json:"id"`
+	AppID      string        `json:"app_id"`
+	JobID      string        `json:"job_id"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration_nanos"`
+	StatusCode int           `json:"status_code"`     // 0 if the request failed
+	Error      string        `json:"error,omitempty"` // empty if the job succeeded
+	TraceID    string        `json:"trace_id,omitempty"`
+}
+
+// NewHistory creates a new execution history.
+func NewHistory(db *sql.DB) *History {
+	return &History{db: db}
+}
+
+// History stores the executions of cron jobs.
+type History struct {
+	db *sql.DB
+}
+
+// Record saves an execution.
+func (h *History) Record(ctx context.Context, e *Execution) error {
+	_, err := h.db.ExecContext(ctx, `
+		INSERT INTO cron_execution (id, app_id, job_id, started_at, duration_nanos, status_code, error, trace_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, e.ID, e.AppID, e.JobID, e.StartedAt, int64(e.Duration), e.StatusCode, e.Error, e.TraceID)
+	return errors.Wrap(err, "record cron execution")
+}
+
+// List lists the most recent executions of a job, newest first.
+// If jobID is empty it lists the executions of all the app's jobs.
+func (h *History) List(ctx context.Context, appID, jobID string, limit int) ([]*Execution, error) {
+	rows, err := h.db.QueryContext(ctx, `
+		SELECT id, app_id, job_id, started_at, duration_nanos, status_code, error, trace_id
+		FROM cron_execution
+		WHERE app_id = ? AND (? = '' OR job_id = ?)
+		ORDER BY started_at DESC
+		LIMIT ?
+	`, appID, jobID, jobID, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "list cron executions")
+	}
+	defer rows.Close()
+
+	res := []*Execution{}
+	for rows.Next() {
+		var (
+			e        Execution
+			duration int64
+		)
+		if err := rows.Scan(&e.ID, &e.AppID, &e.JobID, &e.StartedAt, &duration, &e.StatusCode, &e.Error, &e.TraceID); err != nil {
+			return nil, errors.Wrap(err, "list cron executions")
+		}
+		e.Duration = time.Duration(duration)
+		res = append(res, &e)
+	}
+	return res, errors.Wrap(rows.Err(), "list cron executions")
+}
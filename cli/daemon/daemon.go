@@ -19,7 +19,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/audit"
+	"encr.dev/cli/daemon/gc"
 	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/ports"
 	"encr.dev/cli/daemon/run"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/daemon/sqldb"
@@ -27,6 +30,8 @@ import (
 	"encr.dev/cli/internal/update"
 	"encr.dev/internal/clientgen"
 	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/internal/clientgen/openapi"
+	"encr.dev/internal/conf"
 	"encr.dev/internal/version"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
@@ -40,11 +45,16 @@ var _ daemonpb.DaemonServer = (*Server)(nil)
 
 // Server implements daemonpb.DaemonServer.
 type Server struct {
-	apps *apps.Manager
-	mgr  *run.Manager
-	cm   *sqldb.ClusterManager
-	sm   *secret.Manager
-	ns   *namespace.Manager
+	apps  *apps.Manager
+	mgr   *run.Manager
+	cm    *sqldb.ClusterManager
+	sm    *secret.Manager
+	ns    *namespace.Manager
+	ports *ports.Manager
+	gc    *gc.Collector
+	audit *audit.Store
+
+	mcpPort int // port the MCP server listens on
 
 	mu      sync.Mutex
 	streams map[string]*streamLog // run id -> stream
@@ -59,13 +69,17 @@ type Server struct {
 }
 
 // New creates a new Server.
-func New(appsMgr *apps.Manager, mgr *run.Manager, cm *sqldb.ClusterManager, sm *secret.Manager, ns *namespace.Manager) *Server {
+func New(appsMgr *apps.Manager, mgr *run.Manager, cm *sqldb.ClusterManager, sm *secret.Manager, ns *namespace.Manager, ports *ports.Manager, gc *gc.Collector, audit *audit.Store, mcpPort int) *Server {
 	srv := &Server{
 		apps:    appsMgr,
 		mgr:     mgr,
 		cm:      cm,
 		sm:      sm,
 		ns:      ns,
+		ports:   ports,
+		gc:      gc,
+		audit:   audit,
+		mcpPort: mcpPort,
 		streams: make(map[string]*streamLog),
 
 		appDebouncers: make(map[*apps.Instance]*regenerateCodeDebouncer),
@@ -141,11 +155,27 @@ func (s *Server) GenClient(ctx context.Context, params *daemonpb.GenClientReques
 
 	servicesToGenerate := clientgentypes.NewServiceSet(md, params.Services, params.ExcludedServices)
 	tagSet := clientgentypes.NewTagSet(params.EndpointTags, params.ExcludedEndpointTags)
-	code, err := clientgen.Client(lang, params.AppId, md, servicesToGenerate, tagSet)
+	opts := clientgen.ClientOptions{
+		ReactQuery:   params.ReactQuery,
+		PackageName:  params.PackageName,
+		ServiceNames: params.ServiceNames,
+	}
+	if params.OpenapiAnnotations != nil {
+		ann, err := openapi.ParseAnnotations(params.OpenapiAnnotations)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts.OpenAPIAnnotations = ann
+	}
+
+	code, err := clientgen.Client(lang, params.AppId, md, servicesToGenerate, tagSet, opts)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	return &daemonpb.GenClientResponse{Code: code}, nil
+	return &daemonpb.GenClientResponse{
+		Code:     code,
+		Warnings: clientgen.Warnings(md, servicesToGenerate, tagSet),
+	}, nil
 }
 
 func (s *Server) SecretsRefresh(ctx context.Context, req *daemonpb.SecretsRefreshRequest) (*daemonpb.SecretsRefreshResponse, error) {
@@ -174,6 +204,9 @@ func (s *Server) Version(context.Context, *empty.Empty) (*daemonpb.VersionRespon
 // If there is a new version it returns it as a semver string.
 func (s *Server) availableUpdate() *update.LatestVersion {
 	check := func() *update.LatestVersion {
+		if conf.Offline() {
+			return nil
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		ver, err := update.Check(ctx)
@@ -0,0 +1,68 @@
+package dash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"encore.dev/appruntime/exported/auditlog"
+	"encr.dev/cli/daemon/audit"
+)
+
+// auditVerification is the result of verifying an app's audit log.
+type auditVerification struct {
+	EntriesVerified int    `json:"entries_verified"`
+	Valid           bool   `json:"valid"`
+	BrokenSeq       int64  `json:"broken_seq,omitempty"`
+	BrokenReason    string `json:"broken_reason,omitempty"`
+}
+
+// handleAudit handles the audit log requests of the dashboard.
+func (h *handler) handleAudit(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "audit/list":
+		var params struct {
+			AppID  string    `json:"app_id"`
+			UserID string    `json:"user_id"`
+			Action string    `json:"action"`
+			Target string    `json:"target"`
+			Since  time.Time `json:"since"`
+			Until  time.Time `json:"until"`
+			Limit  int       `json:"limit"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.audit.List(ctx, params.AppID, &audit.Query{
+			UserID: params.UserID,
+			Action: params.Action,
+			Target: params.Target,
+			Since:  params.Since,
+			Until:  params.Until,
+			Limit:  params.Limit,
+		})
+
+	case "audit/verify":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.verifyAudit(ctx, params.AppID)
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
+
+func (h *handler) verifyAudit(ctx context.Context, appID string) (*auditVerification, error) {
+	n, err := h.audit.Verify(ctx, appID)
+	res := &auditVerification{EntriesVerified: n, Valid: err == nil}
+	var verr *auditlog.VerifyError
+	if errors.As(err, &verr) {
+		res.BrokenSeq, res.BrokenReason = verr.Seq, verr.Reason
+	} else if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
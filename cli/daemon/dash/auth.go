@@ -0,0 +1,82 @@
+package dash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// authCookie is the cookie holding the access token once a browser has authenticated.
+const authCookie = "encore_dash_token"
+
+// IsLoopback reports whether host only accepts connections from the local machine.
+func IsLoopback(host string) bool {
+	if host == "" || host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// GenerateToken generates a random access token.
+func GenerateToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// RequireAuth returns a handler that requires the secret to access h.
+//
+// The secret can be provided as a "token" query parameter, which stores it in
+// a cookie for subsequent requests, as a bearer token, or as the password
+// of HTTP basic authentication, which makes browsers prompt for it.
+func RequireAuth(secret string, h http.Handler) http.Handler {
+	valid := func(s string) bool {
+		return s != "" && subtle.ConstantTimeCompare([]byte(s), []byte(secret)) == 1
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if c, err := req.Cookie(authCookie); err == nil && valid(c.Value) {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		if token := req.URL.Query().Get("token"); valid(token) {
+			http.SetCookie(w, &http.Cookie{
+				Name:     authCookie,
+				Value:    token,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+			if req.Method == http.MethodGet {
+				// Redirect to remove the token from the URL.
+				u := *req.URL
+				q := u.Query()
+				q.Del("token")
+				u.RawQuery = q.Encode()
+				http.Redirect(w, req, u.String(), http.StatusFound)
+				return
+			}
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok && valid(token) {
+			h.ServeHTTP(w, req)
+			return
+		}
+		if _, password, ok := req.BasicAuth(); ok && valid(password) {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		w.Header().Set("WWW-Authenticate", `Basic realm="Encore Development Dashboard", charset="UTF-8"`)
+		http.Error(w, "unauthorized: open the dashboard using the URL printed by 'encore run', or enter the dashboard password", http.StatusUnauthorized)
+	})
+}
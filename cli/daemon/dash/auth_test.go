@@ -0,0 +1,63 @@
+package dash
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestRequireAuth(t *testing.T) {
+	c := qt.New(t)
+	h := RequireAuth("secret", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	do := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	// Unauthenticated requests are rejected.
+	w := do(httptest.NewRequest("GET", "/app", nil))
+	c.Assert(w.Code, qt.Equals, http.StatusUnauthorized)
+	c.Assert(w.Header().Get("WWW-Authenticate"), qt.Matches, `Basic .*`)
+	w = do(httptest.NewRequest("GET", "/app?token=wrong", nil))
+	c.Assert(w.Code, qt.Equals, http.StatusUnauthorized)
+
+	// The token in the URL is exchanged for a cookie.
+	w = do(httptest.NewRequest("GET", "/app?token=secret&x=1", nil))
+	c.Assert(w.Code, qt.Equals, http.StatusFound)
+	c.Assert(w.Header().Get("Location"), qt.Equals, "/app?x=1")
+	cookies := w.Result().Cookies()
+	c.Assert(cookies, qt.HasLen, 1)
+	c.Assert(cookies[0].HttpOnly, qt.IsTrue)
+
+	req := httptest.NewRequest("GET", "/app", nil)
+	req.AddCookie(cookies[0])
+	c.Assert(do(req).Body.String(), qt.Equals, "ok")
+
+	req = httptest.NewRequest("GET", "/__encore", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	c.Assert(do(req).Body.String(), qt.Equals, "ok")
+
+	req = httptest.NewRequest("GET", "/app", nil)
+	req.SetBasicAuth("anyone", "secret")
+	c.Assert(do(req).Body.String(), qt.Equals, "ok")
+}
+
+func TestIsLoopback(t *testing.T) {
+	c := qt.New(t)
+	for host, want := range map[string]bool{
+		"":          true,
+		"localhost": true,
+		"127.0.0.1": true,
+		"::1":       true,
+		"0.0.0.0":   false,
+		"::":        false,
+		"10.0.0.5":  false,
+	} {
+		c.Assert(IsLoopback(host), qt.Equals, want, qt.Commentf("host %q", host))
+	}
+}
@@ -0,0 +1,100 @@
+package dash
+
+import (
+	"context"
+	"fmt"
+
+	"encr.dev/cli/daemon/collections"
+)
+
+// handleCollections handles the request collection requests of the dashboard.
+func (h *handler) handleCollections(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	if h.coll == nil {
+		return nil, fmt.Errorf("request collections are not available")
+	}
+
+	switch method {
+	case "collections/list":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.coll.List(ctx, params.AppID)
+
+	case "collections/save":
+		var c collections.Collection
+		if err := unmarshal(&c); err != nil {
+			return nil, err
+		}
+		if err := h.coll.Save(ctx, &c); err != nil {
+			return nil, err
+		}
+		return &c, nil
+
+	case "collections/delete":
+		var params struct {
+			AppID string `json:"app_id"`
+			ID    string `json:"id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return "ok", h.coll.Delete(ctx, params.AppID, params.ID)
+
+	case "collections/export":
+		var params struct {
+			AppID string `json:"app_id"`
+			ID    string `json:"id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		c, err := h.coll.Get(ctx, params.AppID, params.ID)
+		if err != nil {
+			return nil, err
+		}
+		data, err := collections.Export(c)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"filename": c.Name + ".encore-collection.json", "data": string(data)}, nil
+
+	case "collections/import":
+		// Imports an exported or Postman collection as a new collection.
+		var params struct {
+			AppID string `json:"app_id"`
+			Data  string `json:"data"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		c, err := collections.Import([]byte(params.Data))
+		if err != nil {
+			return nil, err
+		}
+		c.AppID = params.AppID
+		if c.Name == "" {
+			c.Name = "Imported collection"
+		}
+		if err := h.coll.Save(ctx, c); err != nil {
+			return nil, err
+		}
+		return c, nil
+
+	case "collections/parse-curl":
+		// Parses a curl command into a request, which the dashboard
+		// can then add to a collection.
+		var params struct {
+			Command string `json:"command"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return collections.ParseCurl(params.Command)
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
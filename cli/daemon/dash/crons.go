@@ -0,0 +1,75 @@
+package dash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"encr.dev/cli/daemon/crons"
+)
+
+// handleCrons handles the cron job requests of the dashboard.
+func (h *handler) handleCrons(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "crons/list":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		md, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return nil, err
+		}
+		return crons.Jobs(md, time.Now(), 5), nil
+
+	case "crons/trigger":
+		var params struct {
+			AppID string `json:"app_id"`
+			JobID string `json:"job_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		run := h.run.FindRunByAppID(params.AppID)
+		if run == nil || run.ProcGroup() == nil {
+			return nil, fmt.Errorf("app not running")
+		}
+		job := crons.FindJob(run.ProcGroup().Meta, params.JobID)
+		if job == nil {
+			return nil, fmt.Errorf("cron job %q not found", params.JobID)
+		}
+
+		exec, err := crons.Trigger(ctx, "http://"+run.ListenAddr, params.AppID, job)
+		if err != nil {
+			return nil, err
+		}
+		if h.cronHistory != nil {
+			if err := h.cronHistory.Record(ctx, exec); err != nil {
+				return nil, err
+			}
+		}
+		return exec, nil
+
+	case "crons/history":
+		var params struct {
+			AppID string `json:"app_id"`
+			JobID string `json:"job_id"` // optional
+			Limit int    `json:"limit"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		if h.cronHistory == nil {
+			return []*crons.Execution{}, nil
+		}
+		if params.Limit <= 0 || params.Limit > 500 {
+			params.Limit = 100
+		}
+		return h.cronHistory.List(ctx, params.AppID, params.JobID, params.Limit)
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
@@ -20,10 +20,18 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/tailscale/hujson"
 
+	"encr.dev/cli/daemon/applog"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/audit"
+	"encr.dev/cli/daemon/collections"
+	"encr.dev/cli/daemon/crons"
 	"encr.dev/cli/daemon/dash/ai"
 	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/engine/trace2/tracecompare"
+	"encr.dev/cli/daemon/metrics"
+	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/cli/internal/browser"
 	"encr.dev/cli/internal/jsonrpc2"
 	"encr.dev/cli/internal/onboarding"
@@ -40,8 +48,22 @@ type handler struct {
 	rpc  jsonrpc2.Conn
 	apps *apps.Manager
 	run  *run.Manager
+	ns   *namespace.Manager
+	cm   *sqldb.ClusterManager
+	coll *collections.Manager
 	ai   *ai.Manager
-	tr   trace2.Store
+
+	cronHistory *crons.History
+	logs        *applog.Buffer
+	metrics     *metrics.Store
+	audit       *audit.Store
+	diag        *diagnosticsStore
+	tr          trace2.Store
+
+	// streams are the streaming endpoint connections opened by the client,
+	// and streamCtx is the context for notifying the client about them.
+	streams   *apiStreams
+	streamCtx context.Context
 }
 
 func (h *handler) GetMeta(appID string) (*meta.Data, error) {
@@ -194,16 +216,50 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 			AppID      string `json:"app_id"`
 			MessageID  string `json:"message_id"`
 			TestTraces *bool  `json:"test_traces,omitempty"`
+
+			// Optional filters for searching traces.
+			Service       string    `json:"service"`
+			Endpoint      string    `json:"endpoint"`
+			StatusCode    int       `json:"status_code"`
+			IsError       *bool     `json:"is_error,omitempty"`
+			ErrorCode     string    `json:"error_code"`
+			UserID        string    `json:"user_id"`
+			MinDurationMs float64   `json:"min_duration_ms"`
+			MaxDurationMs float64   `json:"max_duration_ms"`
+			StartTime     time.Time `json:"start_time"`
+			EndTime       time.Time `json:"end_time"`
+			SortBy        string    `json:"sort_by"` // "started_at" (the default) or "duration"
+			SortAscending bool      `json:"sort_ascending"`
+			Limit         int       `json:"limit"`
+			Offset        int       `json:"offset"`
 		}
 		if err := unmarshal(&params); err != nil {
 			return reply(ctx, nil, err)
 		}
+		if params.Limit <= 0 {
+			params.Limit = 100
+		} else if params.Limit > 1000 {
+			params.Limit = 1000
+		}
 
 		query := &trace2.Query{
-			AppID:      params.AppID,
-			TestFilter: params.TestTraces,
-			MessageID:  params.MessageID,
-			Limit:      100,
+			AppID:       params.AppID,
+			TestFilter:  params.TestTraces,
+			MessageID:   params.MessageID,
+			Service:     params.Service,
+			Endpoint:    params.Endpoint,
+			StatusCode:  params.StatusCode,
+			IsError:     params.IsError,
+			ErrorCode:   params.ErrorCode,
+			UserID:      params.UserID,
+			MinDurNanos: uint64(params.MinDurationMs * float64(time.Millisecond)),
+			MaxDurNanos: uint64(params.MaxDurationMs * float64(time.Millisecond)),
+			StartTime:   params.StartTime,
+			EndTime:     params.EndTime,
+			SortBy:      trace2.SortField(params.SortBy),
+			SortAsc:     params.SortAscending,
+			Limit:       params.Limit,
+			Offset:      params.Offset,
 		}
 		var list []*tracepb2.SpanSummary
 		iter := func(s *tracepb2.SpanSummary) bool {
@@ -237,6 +293,34 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 		}
 		return reply(ctx, events, err)
 
+	case "traces/compare":
+		telemetry.Send("traces.compare")
+		var params struct {
+			AppID  string `json:"app_id"`
+			TraceA string `json:"trace_a"`
+			TraceB string `json:"trace_b"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return reply(ctx, nil, err)
+		}
+
+		var summaries [2]*tracecompare.Summary
+		for i, traceID := range [2]string{params.TraceA, params.TraceB} {
+			var events []*tracepb2.TraceEvent
+			iter := func(ev *tracepb2.TraceEvent) bool {
+				events = append(events, ev)
+				return true
+			}
+			if err := h.tr.Get(ctx, params.AppID, traceID, iter); err != nil {
+				return reply(ctx, nil, err)
+			}
+			summaries[i] = tracecompare.Summarize(traceID, events)
+		}
+		if a, b := summaries[0], summaries[1]; a.Root != b.Root {
+			return reply(ctx, nil, fmt.Errorf("cannot compare traces of different endpoints (%s and %s)", a.Root, b.Root))
+		}
+		return reply(ctx, tracecompare.Compare(summaries[0], summaries[1]), nil)
+
 	case "status":
 		var params struct {
 			AppID string
@@ -265,6 +349,54 @@ func (h *handler) Handle(ctx context.Context, reply jsonrpc2.Replier, r jsonrpc2
 
 		return reply(ctx, status, nil)
 
+	case "db/list", "db/tables", "db/rows", "db/query":
+		telemetry.Send("db.browse", map[string]interface{}{"method": r.Method()})
+		resp, err := h.handleDB(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "pubsub/topics", "pubsub/publish":
+		telemetry.Send("pubsub.dash", map[string]interface{}{"method": r.Method()})
+		resp, err := h.handlePubSub(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "collections/list", "collections/save", "collections/delete",
+		"collections/export", "collections/import", "collections/parse-curl":
+		resp, err := h.handleCollections(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "crons/list", "crons/trigger", "crons/history":
+		resp, err := h.handleCrons(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "flow/export":
+		resp, err := h.handleFlow(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "traces/replay":
+		telemetry.Send("traces.replay")
+		resp, err := h.handleReplay(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "logs/list", "logs/span", "logs/clear":
+		resp, err := h.handleLogs(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "diagnostics/get":
+		resp, err := h.handleDiagnostics(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "metrics/requests", "metrics/list", "metrics/series", "metrics/clear":
+		resp, err := h.handleMetrics(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "audit/list", "audit/verify":
+		resp, err := h.handleAudit(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
+	case "stream/open", "stream/send", "stream/messages", "stream/reconnect", "stream/close":
+		resp, err := h.handleStream(ctx, r.Method(), unmarshal)
+		return reply(ctx, resp, err)
+
 	case "api-call":
 		telemetry.Send("api.call")
 		var params apiCallParams
@@ -528,6 +660,10 @@ type apiCallParams struct {
 	AuthPayload   []byte `json:"auth_payload,omitempty"`
 	AuthToken     string `json:"auth_token,omitempty"`
 	CorrelationID string `json:"correlation_id,omitempty"`
+
+	// Headers are additional headers to send, like the ones
+	// of a request replayed from a trace.
+	Headers map[string]string `json:"headers,omitempty"`
 }
 
 func (h *handler) apiCall(ctx context.Context, reply jsonrpc2.Replier, p *apiCallParams) error {
@@ -553,6 +689,11 @@ func (h *handler) apiCall(ctx context.Context, reply jsonrpc2.Replier, p *apiCal
 	if p.CorrelationID != "" {
 		req.Header.Set("X-Correlation-ID", p.CorrelationID)
 	}
+	for name, value := range p.Headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
@@ -634,14 +775,14 @@ func (s *Server) OnStart(r *run.Run) {
 	// Open the browser if needed.
 	browserMode := r.Params.Browser
 	if browserMode == run.BrowserModeAlways || (browserMode == run.BrowserModeAuto && !s.hasClients()) {
-		u := fmt.Sprintf("http://localhost:%d/%s", s.dashPort, r.App.PlatformOrLocalID())
-		browser.Open(u)
+		browser.Open(s.run.DashURL(r.App.PlatformOrLocalID()))
 	}
 
 	s.notify(&notification{
 		Method: "process/start",
 		Params: status,
 	})
+	s.setDiagnostics(r, diagOK, nil)
 }
 
 func (s *Server) OnCompileStart(r *run.Run) {
@@ -657,6 +798,7 @@ func (s *Server) OnCompileStart(r *run.Run) {
 		Method: "process/compile-start",
 		Params: status,
 	})
+	s.setDiagnostics(r, diagCompiling, nil)
 }
 
 // OnReload notifies active websocket clients about the reloaded run.
@@ -671,10 +813,13 @@ func (s *Server) OnReload(r *run.Run) {
 		Method: "process/reload",
 		Params: status,
 	})
+	s.setDiagnostics(r, diagOK, nil)
 }
 
 // OnStop notifies active websocket clients about the stopped run.
 func (s *Server) OnStop(r *run.Run) {
+	s.diag.clear(r.App.PlatformOrLocalID())
+
 	status, err := buildAppStatus(r.App, nil)
 	if err != nil {
 		log.Error().Err(err).Msg("dash: could not build app status")
@@ -689,12 +834,12 @@ func (s *Server) OnStop(r *run.Run) {
 
 // OnStdout forwards the output to active websocket clients.
 func (s *Server) OnStdout(r *run.Run, out []byte) {
-	s.onOutput(r, out)
+	s.onOutput(r, "stdout", out)
 }
 
 // OnStderr forwards the output to active websocket clients.
 func (s *Server) OnStderr(r *run.Run, out []byte) {
-	s.onOutput(r, out)
+	s.onOutput(r, "stderr", out)
 }
 
 func (s *Server) OnError(r *run.Run, err *errlist.List) {
@@ -716,20 +861,37 @@ func (s *Server) OnError(r *run.Run, err *errlist.List) {
 		Method: "process/compile-error",
 		Params: status,
 	})
+	s.setDiagnostics(r, diagError, err)
 }
 
-func (s *Server) onOutput(r *run.Run, out []byte) {
+// OnCrash implements run.EventListener.
+func (s *Server) OnCrash(r *run.Run, process string, err error) {}
+
+func (s *Server) onOutput(r *run.Run, stream string, out []byte) {
+	appID := r.App.PlatformOrLocalID()
+	entries := s.logs.Write(appID, stream, out)
+
 	// Copy to a new slice since we cannot retain it after the call ends, and notify is async.
 	out2 := make([]byte, len(out))
 	copy(out2, out)
 	s.notify(&notification{
 		Method: "process/output",
 		Params: map[string]interface{}{
-			"appID":  r.App.PlatformOrLocalID(),
+			"appID":  appID,
 			"pid":    r.ID,
 			"output": out2,
 		},
 	})
+
+	if len(entries) > 0 {
+		s.notify(&notification{
+			Method: "logs/new",
+			Params: map[string]any{
+				"app_id":  appID,
+				"entries": entries,
+			},
+		})
+	}
 }
 
 // findRPC finds the RPC with the given service and endpoint name.
@@ -774,19 +936,8 @@ func prepareRequest(ctx context.Context, baseURL string, md *meta.Data, p *apiCa
 		}
 	}
 
-	// Add auth encoding, if any
-	if h := md.AuthHandler; h != nil {
-		auth, err := encoding.DescribeAuth(md, h.Params, nil)
-		if err != nil {
-			return nil, fmt.Errorf("describe auth: %v", err)
-		}
-		if auth.LegacyTokenFormat {
-			reqSpec.Header.Set("Authorization", "Bearer "+p.AuthToken)
-		} else {
-			if err := addToRequest(reqSpec, p.AuthPayload, auth.ParameterEncodingMapByName()); err != nil {
-				return nil, fmt.Errorf("encode auth params: %v", err)
-			}
-		}
+	if err := addAuthToRequest(reqSpec, md, p); err != nil {
+		return nil, err
 	}
 
 	var body io.Reader = nil
@@ -816,6 +967,26 @@ func prepareRequest(ctx context.Context, baseURL string, md *meta.Data, p *apiCa
 	return req, nil
 }
 
+// addAuthToRequest adds the auth parameters of p to the request, if the app has an auth handler.
+func addAuthToRequest(reqSpec *httpRequestSpec, md *meta.Data, p *apiCallParams) error {
+	h := md.AuthHandler
+	if h == nil {
+		return nil
+	}
+	auth, err := encoding.DescribeAuth(md, h.Params, nil)
+	if err != nil {
+		return fmt.Errorf("describe auth: %v", err)
+	}
+	if auth.LegacyTokenFormat {
+		reqSpec.Header.Set("Authorization", "Bearer "+p.AuthToken)
+	} else {
+		if err := addToRequest(reqSpec, p.AuthPayload, auth.ParameterEncodingMapByName()); err != nil {
+			return fmt.Errorf("encode auth params: %v", err)
+		}
+	}
+	return nil
+}
+
 func handleResponse(md *meta.Data, p *apiCallParams, headers http.Header, body []byte) []byte {
 	rpc := findRPC(md, p.Service, p.Endpoint)
 	if rpc == nil {
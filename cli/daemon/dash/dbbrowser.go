@@ -0,0 +1,275 @@
+package dash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/sqldb"
+)
+
+const (
+	// defaultPageSize and maxPageSize bound the number of rows
+	// returned per page when browsing a table.
+	defaultPageSize = 50
+	maxPageSize     = 500
+
+	// maxQueryRows is the maximum number of rows returned by an ad-hoc query.
+	maxQueryRows = 1000
+
+	// dbQueryTimeout is the maximum time a database browser query may run for.
+	dbQueryTimeout = 30 * time.Second
+)
+
+// dbParams identifies a database of an app's local database cluster.
+type dbParams struct {
+	AppID     string `json:"app_id"`
+	Namespace string `json:"namespace"` // if empty, the active namespace
+	Database  string `json:"database"`
+}
+
+type dbColumn struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+}
+
+type dbResult struct {
+	Columns      []dbColumn `json:"columns"`
+	Rows         [][]any    `json:"rows"`
+	Truncated    bool       `json:"truncated,omitempty"`
+	RowsAffected int64      `json:"rows_affected"`
+	DurationMs   float64    `json:"duration_ms"`
+}
+
+// handleDB handles the database browser requests of the dashboard.
+func (h *handler) handleDB(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "db/list":
+		var params dbParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		md, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return nil, err
+		}
+		dbs := []string{} // prevent marshalling as null
+		for _, db := range md.GetSqlDatabases() {
+			dbs = append(dbs, db.Name)
+		}
+		return dbs, nil
+
+	case "db/tables":
+		var params dbParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.dbQuery(ctx, params, true, maxQueryRows, `
+			SELECT t.table_schema AS schema, t.table_name AS name, c.reltuples::bigint AS estimated_rows
+			FROM information_schema.tables t
+			LEFT JOIN pg_catalog.pg_namespace n ON n.nspname = t.table_schema
+			LEFT JOIN pg_catalog.pg_class c ON c.relnamespace = n.oid AND c.relname = t.table_name
+			WHERE t.table_type = 'BASE TABLE' AND t.table_schema NOT IN ('pg_catalog', 'information_schema')
+			ORDER BY 1, 2
+		`)
+
+	case "db/rows":
+		var params struct {
+			dbParams
+			Schema  string `json:"schema"`
+			Table   string `json:"table"`
+			OrderBy string `json:"order_by"`
+			Desc    bool   `json:"desc"`
+			Offset  int    `json:"offset"`
+			Limit   int    `json:"limit"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		} else if params.Table == "" {
+			return nil, fmt.Errorf("missing table")
+		}
+		if params.Schema == "" {
+			params.Schema = "public"
+		}
+		limit := params.Limit
+		if limit <= 0 {
+			limit = defaultPageSize
+		} else if limit > maxPageSize {
+			limit = maxPageSize
+		}
+
+		query := "SELECT * FROM " + pgx.Identifier{params.Schema, params.Table}.Sanitize()
+		if params.OrderBy != "" {
+			query += " ORDER BY " + pgx.Identifier{params.OrderBy}.Sanitize()
+			if params.Desc {
+				query += " DESC"
+			}
+		}
+		query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, max(params.Offset, 0))
+
+		res, err := h.dbQuery(ctx, params.dbParams, true, limit, query)
+		if err != nil {
+			return nil, err
+		}
+		count, err := h.dbQuery(ctx, params.dbParams, true, 1,
+			"SELECT count(*) FROM "+pgx.Identifier{params.Schema, params.Table}.Sanitize())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"columns": res.Columns,
+			"rows":    res.Rows,
+			"total":   count.Rows[0][0],
+			"offset":  max(params.Offset, 0),
+			"limit":   limit,
+		}, nil
+
+	case "db/query":
+		var params struct {
+			dbParams
+			Query    string `json:"query"`
+			ReadOnly bool   `json:"read_only"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		} else if params.Query == "" {
+			return nil, fmt.Errorf("missing query")
+		}
+		return h.dbQuery(ctx, params.dbParams, params.ReadOnly, maxQueryRows, params.Query)
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
+
+// dbConnect connects to a database of an app's local database cluster.
+// The cluster must already be running.
+func (h *handler) dbConnect(ctx context.Context, params dbParams) (*pgx.Conn, error) {
+	if h.ns == nil || h.cm == nil {
+		return nil, fmt.Errorf("the database browser is not available")
+	}
+	app, err := h.apps.FindLatestByPlatformOrLocalID(params.AppID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ns *namespace.Namespace
+	if params.Namespace == "" {
+		ns, err = h.ns.GetActive(ctx, app)
+	} else {
+		ns, err = h.ns.GetByName(ctx, app, namespace.Name(params.Namespace))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, ok := h.cm.Get(sqldb.GetClusterID(app, sqldb.Run, ns))
+	if !ok {
+		return nil, fmt.Errorf("the database cluster for namespace %q is not running; start the app with 'encore run'", ns.Name)
+	}
+	db, ok := cluster.GetDB(params.Database)
+	if !ok {
+		return nil, fmt.Errorf("database %q not found", params.Database)
+	}
+	info, err := cluster.Info(ctx)
+	if err != nil {
+		return nil, err
+	} else if info.Status != sqldb.Running {
+		return nil, fmt.Errorf("the database cluster for namespace %q is not running", ns.Name)
+	}
+	role, ok := info.Encore.First(sqldb.RoleSuperuser)
+	if !ok {
+		return nil, fmt.Errorf("unable to find a role to connect to the database with")
+	}
+	return pgx.Connect(ctx, info.ConnURI(db.ApplicationCloudName(), role))
+}
+
+// dbQuery runs query against a database and returns up to maxRows rows.
+// If readOnly is true the query runs in a read-only transaction.
+func (h *handler) dbQuery(ctx context.Context, params dbParams, readOnly bool, maxRows int, query string) (*dbResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbQueryTimeout)
+	defer cancel()
+
+	conn, err := h.dbConnect(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	opts := pgx.TxOptions{}
+	if readOnly {
+		opts.AccessMode = pgx.ReadOnly
+	}
+	tx, err := conn.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	start := time.Now()
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dbResult{Columns: []dbColumn{}, Rows: [][]any{}}
+	typeMap := conn.TypeMap()
+	for _, fd := range rows.FieldDescriptions() {
+		col := dbColumn{Name: fd.Name}
+		if typ, ok := typeMap.TypeForOID(fd.DataTypeOID); ok {
+			col.DataType = typ.Name
+		}
+		res.Columns = append(res.Columns, col)
+	}
+	for rows.Next() {
+		if len(res.Rows) == maxRows {
+			res.Truncated = true
+			break
+		}
+		vals, err := rows.Values()
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		for i, v := range vals {
+			vals[i] = sqlValue(v)
+		}
+		res.Rows = append(res.Rows, vals)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	res.RowsAffected = rows.CommandTag().RowsAffected()
+	res.DurationMs = float64(time.Since(start)) / float64(time.Millisecond)
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// sqlValue converts a value decoded by pgx into a value
+// that's displayed sensibly when marshalled as JSON.
+func sqlValue(v any) any {
+	switch v := v.(type) {
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return v // base64-encoded
+	case json.Marshaler:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,21 @@
+package dash
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSQLValue(t *testing.T) {
+	c := qt.New(t)
+	now := time.Now()
+	id := [16]byte{0xbe, 0x23, 0xa2, 0x1f, 0xd1, 0x2c, 0x43, 0x2c, 0x91, 0xec, 0xfb, 0x8a, 0x52, 0xe2, 0x39, 0x67}
+
+	c.Assert(sqlValue(id), qt.Equals, "be23a21f-d12c-432c-91ec-fb8a52e23967")
+	c.Assert(sqlValue([]byte("hello")), qt.Equals, "hello")
+	c.Assert(sqlValue([]byte{0xff, 0xfe}), qt.DeepEquals, []byte{0xff, 0xfe})
+	c.Assert(sqlValue(now), qt.Equals, now)
+	c.Assert(sqlValue(int64(5)), qt.Equals, int64(5))
+	c.Assert(sqlValue(nil), qt.IsNil)
+}
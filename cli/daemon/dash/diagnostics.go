@@ -0,0 +1,289 @@
+package dash
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/run"
+	"encr.dev/pkg/errlist"
+)
+
+// Diagnostics statuses.
+const (
+	diagCompiling = "compiling"
+	diagError     = "error"
+	diagOK        = "ok"
+)
+
+// diagnosticsReport describes the result of the latest build of a running app.
+type diagnosticsReport struct {
+	AppID       string        `json:"app_id"`
+	Status      string        `json:"status"` // "compiling", "error" or "ok"
+	Diagnostics []*diagnostic `json:"diagnostics"`
+	// Rendered is the error as printed in the terminal.
+	Rendered string    `json:"rendered,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// diagnostic is a compile error.
+type diagnostic struct {
+	Code      int             `json:"code,omitempty"`
+	Title     string          `json:"title"`
+	Summary   string          `json:"summary"`
+	Detail    string          `json:"detail,omitempty"`
+	Locations []*diagLocation `json:"locations"`
+}
+
+// diagLocation is a location in the source code that a diagnostic refers to.
+// File, StartLine and StartCol can be passed to "editors/open" to open the
+// location in the user's editor.
+type diagLocation struct {
+	Type      string `json:"type"` // "error", "warning" or "help"
+	Text      string `json:"text,omitempty"`
+	File      string `json:"file"` // relative to the app root, if within it
+	StartLine int    `json:"start_line"`
+	StartCol  int    `json:"start_col"`
+	EndLine   int    `json:"end_line"`
+	EndCol    int    `json:"end_col"`
+}
+
+// newDiagnostics converts a list of compile errors into diagnostics.
+// The error locations must already be relative to the app root.
+func newDiagnostics(errs *errlist.List) []*diagnostic {
+	diags := []*diagnostic{}
+	for _, e := range errs.List {
+		d := &diagnostic{
+			Code:      e.Params.Code,
+			Title:     e.Params.Title,
+			Summary:   e.Params.Summary,
+			Detail:    e.Params.Detail,
+			Locations: []*diagLocation{},
+		}
+		for _, loc := range e.Params.Locations {
+			if loc.File == nil {
+				continue
+			}
+			d.Locations = append(d.Locations, &diagLocation{
+				Type:      loc.Type.String(),
+				Text:      loc.Text,
+				File:      loc.File.RelPath,
+				StartLine: loc.Start.Line,
+				StartCol:  loc.Start.Col,
+				EndLine:   loc.End.Line,
+				EndCol:    loc.End.Col,
+			})
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// diagnosticsStore keeps the latest diagnostics of running apps.
+type diagnosticsStore struct {
+	mu      sync.Mutex
+	reports map[string]*diagnosticsReport // by app id
+	subs    map[*diagSubscriber]struct{}
+}
+
+func newDiagnosticsStore() *diagnosticsStore {
+	return &diagnosticsStore{
+		reports: make(map[string]*diagnosticsReport),
+		subs:    make(map[*diagSubscriber]struct{}),
+	}
+}
+
+// diagSubscriber receives the diagnostics of apps as they change.
+// Reports are never dropped: if the subscriber falls behind, only
+// the latest report of each app is kept until it catches up.
+type diagSubscriber struct {
+	wake chan struct{} // signaled when there are pending reports
+
+	mu      sync.Mutex
+	pending []*diagnosticsReport // at most one per app, in order of change
+}
+
+// subscribe returns a subscriber receiving diagnostics as they change,
+// which must be unsubscribed when done.
+func (d *diagnosticsStore) subscribe() *diagSubscriber {
+	sub := &diagSubscriber{wake: make(chan struct{}, 1)}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subs[sub] = struct{}{}
+	return sub
+}
+
+func (d *diagnosticsStore) unsubscribe(sub *diagSubscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.subs, sub)
+}
+
+func (sub *diagSubscriber) push(r *diagnosticsReport) {
+	sub.mu.Lock()
+	sub.pending = slices.DeleteFunc(sub.pending, func(p *diagnosticsReport) bool { return p.AppID == r.AppID })
+	sub.pending = append(sub.pending, r)
+	sub.mu.Unlock()
+
+	select {
+	case sub.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the pending reports, and clears them.
+func (sub *diagSubscriber) next() []*diagnosticsReport {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	pending := sub.pending
+	sub.pending = nil
+	return pending
+}
+
+// get returns the latest diagnostics of an app, or nil if the app isn't running.
+func (d *diagnosticsStore) get(appID string) *diagnosticsReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.reports[appID]
+}
+
+// list returns the latest diagnostics of all running apps.
+func (d *diagnosticsStore) list() []*diagnosticsReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	list := make([]*diagnosticsReport, 0, len(d.reports))
+	for _, r := range d.reports {
+		list = append(list, r)
+	}
+	return list
+}
+
+func (d *diagnosticsStore) set(r *diagnosticsReport) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reports[r.AppID] = r
+	for sub := range d.subs {
+		sub.push(r)
+	}
+}
+
+func (d *diagnosticsStore) clear(appID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.reports, appID)
+}
+
+// setDiagnostics records the latest diagnostics of a run
+// and notifies clients about them.
+func (s *Server) setDiagnostics(r *run.Run, status string, errs *errlist.List) {
+	report := &diagnosticsReport{
+		AppID:       r.App.PlatformOrLocalID(),
+		Status:      status,
+		Diagnostics: []*diagnostic{},
+		Time:        time.Now(),
+	}
+	if errs != nil {
+		report.Diagnostics = newDiagnostics(errs)
+		report.Rendered = errs.Error()
+	}
+	s.diag.set(report)
+
+	s.notify(&notification{
+		Method: "diagnostics/update",
+		Params: report,
+	})
+}
+
+// handleDiagnostics handles the requests for compile diagnostics.
+func (h *handler) handleDiagnostics(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "diagnostics/get":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.diag.get(params.AppID), nil
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
+
+// sseKeepAlive is how often comments are sent on idle
+// event streams to keep the connection open.
+const sseKeepAlive = 15 * time.Second
+
+// DiagnosticsEvents streams the compile diagnostics of running apps as server-sent events,
+// as they are rebuilt. The "app_id" query parameter limits the stream to a single app.
+//
+// The latest diagnostics are sent when the stream is opened,
+// followed by a "diagnostics" event each time they change.
+func (s *Server) DiagnosticsEvents(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	appID := req.URL.Query().Get("app_id")
+
+	// Subscribe to the store rather than as a dash client, so no updates are
+	// dropped and the stream doesn't count as an open dashboard.
+	sub := s.diag.subscribe()
+	defer s.diag.unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(report *diagnosticsReport) bool {
+		data, err := protoEncoder.Marshal(report)
+		if err != nil {
+			log.Error().Err(err).Msg("dash: could not marshal diagnostics")
+			return true
+		}
+		if _, err := fmt.Fprintf(w, "event: diagnostics\ndata: %s\n\n", data); err != nil {
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	for _, report := range s.diag.list() {
+		if appID != "" && report.AppID != appID {
+			continue
+		}
+		if !send(report) {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-sub.wake:
+			for _, report := range sub.next() {
+				if appID != "" && report.AppID != appID {
+					continue
+				}
+				if !send(report) {
+					return
+				}
+			}
+		}
+	}
+}
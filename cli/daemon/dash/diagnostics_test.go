@@ -0,0 +1,90 @@
+package dash
+
+import (
+	"bufio"
+	"context"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/pkg/errinsrc/srcerrors"
+	"encr.dev/pkg/errlist"
+)
+
+func TestDiagnostics(t *testing.T) {
+	c := qt.New(t)
+	root := t.TempDir()
+	file := filepath.Join(root, "svc", "svc.go")
+	c.Assert(os.MkdirAll(filepath.Dir(file), 0755), qt.IsNil)
+	c.Assert(os.WriteFile(file, []byte("package svc\n\nfunc Foo() {\n\tbar()\n}\n"), 0644), qt.IsNil)
+
+	errs := errlist.New(nil)
+	errs.Report(srcerrors.GenericError(token.Position{Filename: file, Line: 4, Column: 2}, "undefined: bar"))
+	errs.MakeRelative(root, "")
+
+	s := &Server{diag: newDiagnosticsStore(), clients: make(map[chan<- *notification]struct{})}
+	srv := httptest.NewServer(http.HandlerFunc(s.DiagnosticsEvents))
+	defer srv.Close()
+
+	r := &run.Run{App: apps.NewInstance(root, "app", "")}
+	s.setDiagnostics(r, diagCompiling, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"?app_id=app", nil)
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, qt.IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.Header.Get("Content-Type"), qt.Equals, "text/event-stream")
+
+	events := bufio.NewScanner(resp.Body)
+	next := func() string {
+		for events.Scan() {
+			if data, ok := strings.CutPrefix(events.Text(), "data: "); ok {
+				return data
+			}
+		}
+		c.Fatal("event stream closed")
+		return ""
+	}
+
+	// The current diagnostics are sent when connecting.
+	c.Assert(next(), qt.Contains, `"status":"compiling"`)
+
+	s.setDiagnostics(&run.Run{App: apps.NewInstance(root, "other", "")}, diagOK, nil)
+	s.setDiagnostics(r, diagError, errs)
+	data := next()
+	c.Assert(data, qt.Contains, `"app_id":"app","status":"error"`)
+	c.Assert(data, qt.Contains, `"summary":"undefined: bar"`)
+	c.Assert(data, qt.Contains, `"file":"svc/svc.go","start_line":4,"start_col":2`)
+
+	// Event streams don't count as open dashboards.
+	c.Assert(s.hasClients(), qt.IsFalse)
+
+	// The latest diagnostics are delivered even when they change faster than they're sent.
+	for i := 0; i < 100; i++ {
+		s.setDiagnostics(r, diagCompiling, nil)
+	}
+	s.setDiagnostics(r, diagOK, nil)
+	for !strings.Contains(next(), `"status":"ok"`) {
+	}
+
+	report := s.diag.get("app")
+	c.Assert(report.Status, qt.Equals, diagOK)
+	s.setDiagnostics(r, diagError, errs)
+	report = s.diag.get("app")
+	c.Assert(report.Diagnostics, qt.HasLen, 1)
+	c.Assert(report.Diagnostics[0].Locations, qt.HasLen, 1)
+	c.Assert(report.Diagnostics[0].Locations[0].Type, qt.Equals, "error")
+
+	s.diag.clear("app")
+	c.Assert(s.diag.get("app"), qt.IsNil)
+}
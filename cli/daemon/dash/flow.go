@@ -0,0 +1,56 @@
+package dash
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"encr.dev/cli/internal/flowdiagram"
+)
+
+// handleFlow handles the Flow diagram requests of the dashboard.
+func (h *handler) handleFlow(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "flow/export":
+		var params struct {
+			AppID         string `json:"app_id"`
+			Format        string `json:"format"` // "svg", "png" or "mermaid"
+			CollapseInfra bool   `json:"collapse_infra"`
+			Highlight     string `json:"highlight"` // service name
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		md, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return nil, err
+		}
+		g, err := flowdiagram.Build(md, flowdiagram.Options{
+			CollapseInfra: params.CollapseInfra,
+			Highlight:     params.Highlight,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		type exported struct {
+			Format string `json:"format"`
+			Data   string `json:"data"` // base64-encoded for png
+		}
+		switch params.Format {
+		case "", "svg":
+			return exported{Format: "svg", Data: string(flowdiagram.SVG(g))}, nil
+		case "mermaid":
+			return exported{Format: "mermaid", Data: flowdiagram.Mermaid(g)}, nil
+		case "png":
+			png, err := flowdiagram.PNG(flowdiagram.SVG(g))
+			if err != nil {
+				return nil, err
+			}
+			return exported{Format: "png", Data: base64.StdEncoding.EncodeToString(png)}, nil
+		default:
+			return nil, fmt.Errorf("unsupported format %q", params.Format)
+		}
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
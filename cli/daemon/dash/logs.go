@@ -0,0 +1,75 @@
+package dash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"encr.dev/cli/daemon/applog"
+)
+
+// handleLogs handles the log requests of the dashboard.
+func (h *handler) handleLogs(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "logs/list":
+		var params struct {
+			AppID    string    `json:"app_id"`
+			Service  string    `json:"service"`
+			Endpoint string    `json:"endpoint"`
+			TraceID  string    `json:"trace_id"`
+			SpanID   string    `json:"span_id"`
+			Level    string    `json:"level"` // minimum level
+			Search   string    `json:"search"`
+			AfterSeq int64     `json:"after_seq"`
+			Since    time.Time `json:"since"`
+			Until    time.Time `json:"until"`
+			Limit    int       `json:"limit"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		if params.Limit <= 0 || params.Limit > applog.DefaultCapacity {
+			params.Limit = 1000
+		}
+		return h.logs.List(params.AppID, &applog.Filter{
+			Service:  params.Service,
+			Endpoint: params.Endpoint,
+			TraceID:  params.TraceID,
+			SpanID:   params.SpanID,
+			MinLevel: applog.Level(params.Level),
+			Search:   params.Search,
+			AfterSeq: params.AfterSeq,
+			Since:    params.Since,
+			Until:    params.Until,
+		}, params.Limit), nil
+
+	case "logs/span":
+		// Lists the log lines emitted within a span of a trace.
+		var params struct {
+			AppID   string `json:"app_id"`
+			TraceID string `json:"trace_id"`
+			SpanID  string `json:"span_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		if params.TraceID == "" || params.SpanID == "" {
+			return nil, fmt.Errorf("trace_id and span_id are required")
+		}
+		return h.logs.List(params.AppID, &applog.Filter{
+			TraceID: params.TraceID,
+			SpanID:  params.SpanID,
+		}, 0), nil
+
+	case "logs/clear":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		h.logs.Clear(params.AppID)
+		return "ok", nil
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
@@ -0,0 +1,71 @@
+package dash
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"encr.dev/cli/daemon/metrics"
+)
+
+// handleMetrics handles the metrics requests of the dashboard.
+func (h *handler) handleMetrics(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "metrics/requests":
+		// Returns the request rate, error rate and latencies over time.
+		var params struct {
+			AppID    string    `json:"app_id"`
+			Service  string    `json:"service"`
+			Endpoint string    `json:"endpoint"`
+			Since    time.Time `json:"since"`
+			Until    time.Time `json:"until"`
+			StepSecs int       `json:"step_secs"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.metrics.Requests(params.AppID, &metrics.RequestQuery{
+			Service:  params.Service,
+			Endpoint: params.Endpoint,
+			Since:    params.Since,
+			Until:    params.Until,
+			Step:     time.Duration(params.StepSecs) * time.Second,
+		}), nil
+
+	case "metrics/list":
+		// Lists the custom metrics reported by the app.
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		return h.metrics.List(params.AppID), nil
+
+	case "metrics/series":
+		var params struct {
+			AppID  string            `json:"app_id"`
+			Name   string            `json:"name"`
+			Labels map[string]string `json:"labels"`
+			Since  time.Time         `json:"since"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		if params.Name == "" {
+			return nil, fmt.Errorf("name is required")
+		}
+		return h.metrics.Series(params.AppID, params.Name, params.Labels, params.Since), nil
+
+	case "metrics/clear":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		h.metrics.Clear(params.AppID)
+		return "ok", nil
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
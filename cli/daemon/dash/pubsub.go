@@ -0,0 +1,100 @@
+package dash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"encr.dev/cli/internal/jsonsample"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+type pubsubTopic struct {
+	Name          string          `json:"name"`
+	Doc           string          `json:"doc,omitempty"`
+	Subscriptions []string        `json:"subscriptions"`
+	Example       json.RawMessage `json:"example,omitempty"` // sample message matching the schema
+}
+
+// handlePubSub handles the Pub/Sub requests of the dashboard.
+func (h *handler) handlePubSub(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "pubsub/topics":
+		var params struct {
+			AppID string `json:"app_id"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		md, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return nil, err
+		}
+
+		topics := []pubsubTopic{} // prevent marshalling as null
+		for _, t := range md.GetPubsubTopics() {
+			topic := pubsubTopic{
+				Name:          t.Name,
+				Doc:           t.GetDoc(),
+				Subscriptions: []string{},
+			}
+			for _, sub := range t.Subscriptions {
+				topic.Subscriptions = append(topic.Subscriptions, sub.Name)
+			}
+			if ex := jsonsample.Generate(md, t.MessageType); len(ex) > 0 {
+				topic.Example = ex
+			}
+			topics = append(topics, topic)
+		}
+		return topics, nil
+
+	case "pubsub/publish":
+		var params struct {
+			AppID      string            `json:"app_id"`
+			Topic      string            `json:"topic"`
+			Message    json.RawMessage   `json:"message"`
+			Attributes map[string]string `json:"attributes"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		} else if len(params.Message) == 0 {
+			return nil, fmt.Errorf("missing message")
+		} else if !json.Valid(params.Message) {
+			return nil, fmt.Errorf("the message is not valid JSON")
+		}
+
+		runInstance := h.run.FindRunByAppID(params.AppID)
+		if runInstance == nil || runInstance.ProcGroup() == nil {
+			return nil, fmt.Errorf("the app is not running")
+		}
+		if findTopic(runInstance.ProcGroup().Meta, params.Topic) == nil {
+			return nil, fmt.Errorf("topic %q not found", params.Topic)
+		}
+		nsq := runInstance.ResourceManager.GetPubSub()
+		if nsq == nil {
+			return nil, fmt.Errorf("the Pub/Sub server is not running")
+		}
+
+		msgID, err := nsq.Publish(runInstance.App.Lang(), params.Topic, params.Attributes, params.Message)
+		if err != nil {
+			return nil, err
+		}
+		// The traces of the resulting subscription deliveries can be
+		// found by listing traces with this message id.
+		return map[string]string{"message_id": msgID}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
+
+// findTopic finds the topic with the given name.
+// If it cannot be found it reports nil.
+func findTopic(md *meta.Data, name string) *meta.PubSubTopic {
+	for _, t := range md.GetPubsubTopics() {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package dash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"encr.dev/parser/encoding"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// replayRequest is a request reconstructed from a trace, in the format
+// accepted by the "api-call" method so it can be edited and sent again.
+type replayRequest struct {
+	Service     string            `json:"service"`
+	Endpoint    string            `json:"endpoint"`
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	Payload     []byte            `json:"payload"`
+	AuthPayload []byte            `json:"auth_payload,omitempty"`
+	AuthToken   string            `json:"auth_token,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+
+	// TraceID and SpanID identify the original request.
+	TraceID string `json:"trace_id"`
+	SpanID  string `json:"span_id"`
+}
+
+// handleReplay handles the requests for replaying requests from traces.
+func (h *handler) handleReplay(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	switch method {
+	case "traces/replay":
+		var params struct {
+			AppID   string `json:"app_id"`
+			TraceID string `json:"trace_id"`
+			SpanID  string `json:"span_id"` // optional; defaults to the root request
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		var spanID uint64
+		if params.SpanID != "" {
+			id, err := strconv.ParseUint(params.SpanID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid span id %q", params.SpanID)
+			}
+			spanID = id
+		}
+
+		md, err := h.GetMeta(params.AppID)
+		if err != nil {
+			return nil, err
+		}
+		var events []*tracepb2.TraceEvent
+		iter := func(ev *tracepb2.TraceEvent) bool {
+			events = append(events, ev)
+			return true
+		}
+		if err := h.tr.Get(ctx, params.AppID, params.TraceID, iter); err != nil {
+			return nil, err
+		}
+		req, err := buildReplay(md, events, spanID)
+		if err != nil {
+			return nil, err
+		}
+		req.TraceID = params.TraceID
+		return req, nil
+	}
+	return nil, fmt.Errorf("unknown method %q", method)
+}
+
+// skipReplayHeaders are the request headers that are set when sending
+// a request, or that relate to the original request, and are not replayed.
+var skipReplayHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Connection":        true,
+	"Accept-Encoding":   true,
+	"Transfer-Encoding": true,
+	"Traceparent":       true,
+	"Tracestate":        true,
+	"X-Correlation-Id":  true,
+	"X-Request-Id":      true,
+}
+
+// buildReplay reconstructs the request handled by a span of a trace,
+// or of the root request of the trace if spanID is zero, so it can
+// be sent to the current version of the app.
+func buildReplay(md *meta.Data, events []*tracepb2.TraceEvent, spanID uint64) (*replayRequest, error) {
+	var (
+		req     *tracepb2.RequestSpanStart
+		reqSpan uint64
+		auth    *tracepb2.AuthSpanStart
+	)
+	for _, ev := range events {
+		start := ev.GetSpanStart()
+		if start == nil {
+			continue
+		}
+		if a := start.GetAuth(); a != nil && auth == nil {
+			auth = a
+		}
+		r := start.GetRequest()
+		switch {
+		case spanID != 0 && ev.SpanId == spanID:
+			if r == nil {
+				return nil, fmt.Errorf("only API requests can be replayed")
+			}
+			req, reqSpan = r, ev.SpanId
+		case spanID == 0 && r != nil && start.ParentSpanId == nil && req == nil:
+			req, reqSpan = r, ev.SpanId
+		}
+	}
+	if req == nil {
+		return nil, fmt.Errorf("no API request found in trace")
+	}
+
+	rpc := findRPC(md, req.ServiceName, req.EndpointName)
+	if rpc == nil {
+		return nil, fmt.Errorf("endpoint %s.%s no longer exists", req.ServiceName, req.EndpointName)
+	}
+
+	r := &replayRequest{
+		Service:  req.ServiceName,
+		Endpoint: req.EndpointName,
+		Method:   req.HttpMethod,
+		Path:     req.Path,
+		Payload:  req.RequestPayload,
+		SpanID:   strconv.FormatUint(reqSpan, 10),
+	}
+
+	// Headers and cookies that are encoded from the payload are left out.
+	paramHeaders := make(map[string]bool)
+	usesCookies := false
+	if enc, err := encoding.DescribeRPC(md, rpc, nil); err == nil {
+		if reqEnc := enc.RequestEncodingForMethod(req.HttpMethod); reqEnc != nil {
+			for _, p := range reqEnc.HeaderParameters {
+				paramHeaders[http.CanonicalHeaderKey(p.WireFormat)] = true
+			}
+		}
+	}
+
+	if md.AuthHandler != nil && auth != nil && len(auth.AuthPayload) > 0 {
+		authEnc, err := encoding.DescribeAuth(md, md.AuthHandler.Params, nil)
+		if err != nil {
+			return nil, fmt.Errorf("describe auth: %v", err)
+		}
+		if authEnc.LegacyTokenFormat {
+			var token string
+			if err := json.Unmarshal(auth.AuthPayload, &token); err == nil {
+				r.AuthToken = token
+			}
+			paramHeaders["Authorization"] = true
+		} else {
+			r.AuthPayload = auth.AuthPayload
+			for _, p := range authEnc.HeaderParameters {
+				paramHeaders[http.CanonicalHeaderKey(p.WireFormat)] = true
+			}
+			usesCookies = len(authEnc.CookieParameters) > 0
+		}
+	}
+	skip := func(name string) bool {
+		name = http.CanonicalHeaderKey(name)
+		return skipReplayHeaders[name] || paramHeaders[name] ||
+			strings.HasPrefix(name, "X-Encore-") || (usesCookies && name == "Cookie") ||
+			(len(r.Payload) == 0 && name == "Content-Type")
+	}
+
+	for name, value := range req.RequestHeaders {
+		if !skip(name) {
+			if r.Headers == nil {
+				r.Headers = make(map[string]string)
+			}
+			r.Headers[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+	return r, nil
+}
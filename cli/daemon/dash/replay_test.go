@@ -0,0 +1,75 @@
+package dash
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestBuildReplay(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs: []*meta.Service{{
+			Name: "orders",
+			Rpcs: []*meta.RPC{
+				{ServiceName: "orders", Name: "Place", HttpMethods: []string{"POST"}, AccessType: meta.RPC_PUBLIC},
+				{ServiceName: "orders", Name: "Get", HttpMethods: []string{"GET"}, AccessType: meta.RPC_PUBLIC},
+			},
+		}},
+	}
+
+	parent := uint64(1)
+	events := []*tracepb2.TraceEvent{
+		{SpanId: 1, Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+			Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+				ServiceName:    "orders",
+				EndpointName:   "Place",
+				HttpMethod:     "POST",
+				Path:           "/orders",
+				RequestPayload: []byte(`{"item":"book"}`),
+				RequestHeaders: map[string]string{
+					"Content-Type":    "application/json",
+					"Content-Length":  "15",
+					"X-Encore-Meta":   "internal",
+					"Accept-Language": "sv",
+				},
+			}},
+		}}},
+		{SpanId: 2, Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+			ParentSpanId: &parent,
+			Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+				ServiceName:    "orders",
+				EndpointName:   "Get",
+				HttpMethod:     "GET",
+				Path:           "/orders/5",
+				RequestHeaders: map[string]string{"Content-Type": "application/json"},
+			}},
+		}}},
+	}
+
+	// The root request is replayed by default.
+	r, err := buildReplay(md, events, 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(r, qt.DeepEquals, &replayRequest{
+		Service:  "orders",
+		Endpoint: "Place",
+		Method:   "POST",
+		Path:     "/orders",
+		Payload:  []byte(`{"item":"book"}`),
+		Headers:  map[string]string{"Content-Type": "application/json", "Accept-Language": "sv"},
+		SpanID:   "1",
+	})
+
+	r, err = buildReplay(md, events, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(r.Endpoint, qt.Equals, "Get")
+	c.Assert(r.Path, qt.Equals, "/orders/5")
+	c.Assert(r.Headers, qt.IsNil)
+
+	md.Svcs[0].Rpcs = md.Svcs[0].Rpcs[1:]
+	_, err = buildReplay(md, events, 0)
+	c.Assert(err, qt.ErrorMatches, `endpoint orders.Place no longer exists`)
+}
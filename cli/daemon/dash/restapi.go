@@ -0,0 +1,383 @@
+package dash
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/applog"
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/audit"
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/metrics"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// API returns the handler for the REST API of the dashboard, which exposes
+// the traces, logs and metadata of apps under /api/v1 for use by custom tooling.
+// Requests must authenticate with one of the given tokens as a bearer token.
+//
+// Unlike the WebSocket API used by the dashboard itself, the REST API is
+// versioned: changes to /api/v1 are backwards compatible.
+func (s *Server) API(tokens ...string) http.Handler {
+	a := &restAPI{
+		h: &handler{apps: s.apps, run: s.run, tr: s.tr, logs: s.logs, metrics: s.metrics, audit: s.audit},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/apps", a.listApps)
+	mux.HandleFunc("GET /api/v1/apps/{app}/metadata", a.getMetadata)
+	mux.HandleFunc("GET /api/v1/apps/{app}/endpoints", a.listEndpoints)
+	mux.HandleFunc("GET /api/v1/apps/{app}/traces", a.listTraces)
+	mux.HandleFunc("GET /api/v1/apps/{app}/traces/{trace}", a.getTrace)
+	mux.HandleFunc("GET /api/v1/apps/{app}/logs", a.listLogs)
+	mux.HandleFunc("GET /api/v1/apps/{app}/metrics", a.listMetrics)
+	mux.HandleFunc("GET /api/v1/apps/{app}/metrics/requests", a.requestMetrics)
+	mux.HandleFunc("GET /api/v1/apps/{app}/metrics/series/{name}", a.metricSeries)
+	mux.HandleFunc("GET /api/v1/apps/{app}/audit", a.listAudit)
+	mux.HandleFunc("GET /api/v1/apps/{app}/audit/verify", a.verifyAudit)
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, req *http.Request) {
+		writeAPIError(w, http.StatusNotFound, "not_found", "unknown API endpoint")
+	})
+
+	valid := func(s string) bool {
+		for _, t := range tokens {
+			if t != "" && subtle.ConstantTimeCompare([]byte(s), []byte(t)) == 1 {
+				return true
+			}
+		}
+		return false
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); !ok || !valid(token) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="Encore Development Dashboard"`)
+			writeAPIError(w, http.StatusUnauthorized, "unauthenticated",
+				"missing or invalid bearer token; see 'encore daemon api-token'")
+			return
+		}
+		mux.ServeHTTP(w, req)
+	})
+}
+
+type restAPI struct {
+	h *handler
+}
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeAPIError(w http.ResponseWriter, status int, code, msg string) {
+	writeAPIResponse(w, status, apiError{Code: code, Message: msg})
+}
+
+func writeAPIResponse(w http.ResponseWriter, status int, v any) {
+	data, err := protoEncoder.Marshal(v)
+	if err != nil {
+		log.Error().Err(err).Msg("dash: could not marshal api response")
+		status = http.StatusInternalServerError
+		data = []byte(`{"code":"internal","message":"could not marshal response"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// meta gets the metadata of the app in the request,
+// writing an error response if it's not available.
+func (a *restAPI) meta(w http.ResponseWriter, req *http.Request) (*meta.Data, bool) {
+	md, err := a.h.GetMeta(req.PathValue("app"))
+	if errors.Is(err, apps.ErrNotFound) || (err == nil && md == nil) {
+		writeAPIError(w, http.StatusNotFound, "not_found", "app not found or not yet built")
+		return nil, false
+	} else if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+		return nil, false
+	}
+	return md, true
+}
+
+func (a *restAPI) listApps(w http.ResponseWriter, req *http.Request) {
+	type app struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		AppRoot string `json:"app_root"`
+		Running bool   `json:"running"`
+	}
+	all, err := a.h.apps.List()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	list := []app{}
+	for _, inst := range all {
+		list = append(list, app{
+			ID:      inst.PlatformOrLocalID(),
+			Name:    inst.Name(),
+			AppRoot: inst.Root(),
+			Running: a.h.run.FindRunByAppID(inst.PlatformOrLocalID()) != nil,
+		})
+	}
+	slices.SortFunc(list, func(a, b app) int { return strings.Compare(a.Name, b.Name) })
+	writeAPIResponse(w, http.StatusOK, list)
+}
+
+func (a *restAPI) getMetadata(w http.ResponseWriter, req *http.Request) {
+	if md, ok := a.meta(w, req); ok {
+		writeAPIResponse(w, http.StatusOK, md)
+	}
+}
+
+// apiEndpoint describes an endpoint in the API catalog.
+type apiEndpoint struct {
+	Service  string   `json:"service"`
+	Endpoint string   `json:"endpoint"`
+	Access   string   `json:"access"` // "public", "auth" or "private"
+	Methods  []string `json:"methods"`
+	Path     string   `json:"path"`
+	Raw      bool     `json:"raw"`
+	Doc      string   `json:"doc,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+func (a *restAPI) listEndpoints(w http.ResponseWriter, req *http.Request) {
+	md, ok := a.meta(w, req)
+	if !ok {
+		return
+	}
+	list := []apiEndpoint{}
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			ep := apiEndpoint{
+				Service:  svc.Name,
+				Endpoint: rpc.Name,
+				Access:   strings.ToLower(rpc.AccessType.String()),
+				Methods:  rpc.HttpMethods,
+				Path:     rpc.Path.Pattern(),
+				Raw:      rpc.Proto == meta.RPC_RAW,
+				Doc:      rpc.GetDoc(),
+			}
+			for _, t := range rpc.Tags {
+				ep.Tags = append(ep.Tags, t.GetValue())
+			}
+			list = append(list, ep)
+		}
+	}
+	writeAPIResponse(w, http.StatusOK, list)
+}
+
+// queryParser parses query string parameters, keeping the first error.
+type queryParser struct {
+	q   url.Values
+	err error
+}
+
+func (p *queryParser) int(name string) int {
+	s := p.q.Get(name)
+	if s == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s: %q", name, s)
+	}
+	return n
+}
+
+func (p *queryParser) float(name string) float64 {
+	s := p.q.Get(name)
+	if s == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s: %q", name, s)
+	}
+	return f
+}
+
+func (p *queryParser) bool(name string) *bool {
+	s := p.q.Get(name)
+	if s == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s: %q", name, s)
+	}
+	return &b
+}
+
+func (p *queryParser) time(name string) time.Time {
+	s := p.q.Get(name)
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s: %q (must be in RFC 3339 format)", name, s)
+	}
+	return t
+}
+
+func (a *restAPI) listTraces(w http.ResponseWriter, req *http.Request) {
+	p := &queryParser{q: req.URL.Query()}
+	query := &trace2.Query{
+		AppID:       req.PathValue("app"),
+		TestFilter:  p.bool("test_traces"),
+		Service:     p.q.Get("service"),
+		Endpoint:    p.q.Get("endpoint"),
+		StatusCode:  p.int("status_code"),
+		IsError:     p.bool("is_error"),
+		ErrorCode:   p.q.Get("error_code"),
+		UserID:      p.q.Get("user_id"),
+		MinDurNanos: uint64(p.float("min_duration_ms") * float64(time.Millisecond)),
+		MaxDurNanos: uint64(p.float("max_duration_ms") * float64(time.Millisecond)),
+		StartTime:   p.time("start_time"),
+		EndTime:     p.time("end_time"),
+		SortBy:      trace2.SortField(p.q.Get("sort_by")),
+		SortAsc:     p.q.Get("order") == "asc",
+		Limit:       p.int("limit"),
+		Offset:      p.int("offset"),
+	}
+	if p.err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_argument", p.err.Error())
+		return
+	}
+	if query.Limit <= 0 {
+		query.Limit = 100
+	} else if query.Limit > 1000 {
+		query.Limit = 1000
+	}
+
+	list := []*tracepb2.SpanSummary{}
+	err := a.h.tr.List(req.Context(), query, func(s *tracepb2.SpanSummary) bool {
+		list = append(list, s)
+		return true
+	})
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	writeAPIResponse(w, http.StatusOK, list)
+}
+
+func (a *restAPI) getTrace(w http.ResponseWriter, req *http.Request) {
+	events := []*tracepb2.TraceEvent{}
+	err := a.h.tr.Get(req.Context(), req.PathValue("app"), req.PathValue("trace"), func(ev *tracepb2.TraceEvent) bool {
+		events = append(events, ev)
+		return true
+	})
+	if errors.Is(err, trace2.ErrNotFound) || (err == nil && len(events) == 0) {
+		writeAPIError(w, http.StatusNotFound, "not_found", "trace not found")
+		return
+	} else if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	writeAPIResponse(w, http.StatusOK, events)
+}
+
+func (a *restAPI) listLogs(w http.ResponseWriter, req *http.Request) {
+	p := &queryParser{q: req.URL.Query()}
+	filter := &applog.Filter{
+		Service:  p.q.Get("service"),
+		Endpoint: p.q.Get("endpoint"),
+		TraceID:  p.q.Get("trace_id"),
+		SpanID:   p.q.Get("span_id"),
+		MinLevel: applog.Level(p.q.Get("level")),
+		Search:   p.q.Get("search"),
+		AfterSeq: int64(p.int("after_seq")),
+		Since:    p.time("since"),
+		Until:    p.time("until"),
+	}
+	limit := p.int("limit")
+	if p.err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_argument", p.err.Error())
+		return
+	}
+	if limit <= 0 || limit > applog.DefaultCapacity {
+		limit = 1000
+	}
+	writeAPIResponse(w, http.StatusOK, a.h.logs.List(req.PathValue("app"), filter, limit))
+}
+
+func (a *restAPI) listMetrics(w http.ResponseWriter, req *http.Request) {
+	writeAPIResponse(w, http.StatusOK, a.h.metrics.List(req.PathValue("app")))
+}
+
+func (a *restAPI) requestMetrics(w http.ResponseWriter, req *http.Request) {
+	p := &queryParser{q: req.URL.Query()}
+	query := &metrics.RequestQuery{
+		Service:  p.q.Get("service"),
+		Endpoint: p.q.Get("endpoint"),
+		Since:    p.time("since"),
+		Until:    p.time("until"),
+		Step:     time.Duration(p.int("step_secs")) * time.Second,
+	}
+	if p.err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_argument", p.err.Error())
+		return
+	}
+	writeAPIResponse(w, http.StatusOK, a.h.metrics.Requests(req.PathValue("app"), query))
+}
+
+// metricSeries returns the series of a custom metric.
+// Series can be filtered by label with "label=key:value" query parameters.
+func (a *restAPI) metricSeries(w http.ResponseWriter, req *http.Request) {
+	p := &queryParser{q: req.URL.Query()}
+	since := p.time("since")
+	labels := make(map[string]string)
+	for _, l := range p.q["label"] {
+		key, value, ok := strings.Cut(l, ":")
+		if !ok && p.err == nil {
+			p.err = fmt.Errorf("invalid label: %q (must be in key:value format)", l)
+		}
+		labels[key] = value
+	}
+	if p.err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_argument", p.err.Error())
+		return
+	}
+	writeAPIResponse(w, http.StatusOK, a.h.metrics.Series(req.PathValue("app"), req.PathValue("name"), labels, since))
+}
+
+func (a *restAPI) listAudit(w http.ResponseWriter, req *http.Request) {
+	p := &queryParser{q: req.URL.Query()}
+	query := &audit.Query{
+		UserID: p.q.Get("user_id"),
+		Action: p.q.Get("action"),
+		Target: p.q.Get("target"),
+		Since:  p.time("since"),
+		Until:  p.time("until"),
+		Limit:  p.int("limit"),
+	}
+	if p.err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_argument", p.err.Error())
+		return
+	}
+	entries, err := a.h.audit.List(req.Context(), req.PathValue("app"), query)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	writeAPIResponse(w, http.StatusOK, entries)
+}
+
+func (a *restAPI) verifyAudit(w http.ResponseWriter, req *http.Request) {
+	res, err := a.h.verifyAudit(req.Context(), req.PathValue("app"))
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "internal", err.Error())
+		return
+	}
+	writeAPIResponse(w, http.StatusOK, res)
+}
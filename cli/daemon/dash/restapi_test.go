@@ -0,0 +1,104 @@
+package dash
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/applog"
+	"encr.dev/cli/daemon/engine/trace2"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// fakeTraceStore is a trace2.Store serving a fixed set of traces.
+type fakeTraceStore struct {
+	trace2.Store
+	spans  []*tracepb2.SpanSummary
+	events map[string][]*tracepb2.TraceEvent
+
+	lastQuery *trace2.Query
+}
+
+func (s *fakeTraceStore) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntryIterator) error {
+	s.lastQuery = q
+	for _, sp := range s.spans {
+		if !iter(sp) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *fakeTraceStore) Get(ctx context.Context, appID, traceID string, iter trace2.EventIterator) error {
+	evs, ok := s.events[traceID]
+	if !ok {
+		return trace2.ErrNotFound
+	}
+	for _, ev := range evs {
+		iter(ev)
+	}
+	return nil
+}
+
+func TestRESTAPI(t *testing.T) {
+	c := qt.New(t)
+	tr := &fakeTraceStore{
+		spans:  []*tracepb2.SpanSummary{{TraceId: "t1", SpanId: "s1"}},
+		events: map[string][]*tracepb2.TraceEvent{"t1": {{SpanId: 1, EventId: 1}}},
+	}
+	s := &Server{tr: tr, logs: applog.NewBuffer(10)}
+	s.logs.Write("app", "stdout", []byte(`{"level":"info","message":"hello","service":"orders"}`+"\n"+`{"level":"debug","message":"details"}`+"\n"))
+	srv := httptest.NewServer(s.API("token"))
+	defer srv.Close()
+
+	get := func(path, token string) (int, string) {
+		req, _ := http.NewRequest("GET", srv.URL+path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		c.Assert(err, qt.IsNil)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("/api/v1/apps/app/logs?level=info", "")
+	c.Assert(status, qt.Equals, http.StatusUnauthorized)
+	c.Assert(body, qt.Contains, `"code":"unauthenticated"`)
+	status, _ = get("/api/v1/apps/app/logs?level=info", "wrong")
+	c.Assert(status, qt.Equals, http.StatusUnauthorized)
+
+	status, body = get("/api/v1/apps/app/logs?level=info", "token")
+	c.Assert(status, qt.Equals, http.StatusOK)
+	var entries []*applog.Entry
+	c.Assert(json.Unmarshal([]byte(body), &entries), qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+	c.Assert(entries[0].Message, qt.Equals, "hello")
+
+	status, body = get("/api/v1/apps/app/traces?service=orders&min_duration_ms=1.5&order=asc", "token")
+	c.Assert(status, qt.Equals, http.StatusOK)
+	c.Assert(body, qt.Contains, `"trace_id":"t1"`)
+	c.Assert(tr.lastQuery.Service, qt.Equals, "orders")
+	c.Assert(tr.lastQuery.MinDurNanos, qt.Equals, uint64(1_500_000))
+	c.Assert(tr.lastQuery.SortAsc, qt.IsTrue)
+	c.Assert(tr.lastQuery.Limit, qt.Equals, 100)
+
+	status, body = get("/api/v1/apps/app/traces?limit=many", "token")
+	c.Assert(status, qt.Equals, http.StatusBadRequest)
+	c.Assert(body, qt.Contains, `invalid limit: \"many\"`)
+
+	status, body = get("/api/v1/apps/app/traces/t1", "token")
+	c.Assert(status, qt.Equals, http.StatusOK)
+	c.Assert(body, qt.Contains, `"event_id":"1"`)
+	status, _ = get("/api/v1/apps/app/traces/t2", "token")
+	c.Assert(status, qt.Equals, http.StatusNotFound)
+
+	status, _ = get("/api/v2/apps", "token")
+	c.Assert(status, qt.Equals, http.StatusNotFound)
+}
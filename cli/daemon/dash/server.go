@@ -11,12 +11,19 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog/log"
 
+	"encr.dev/cli/daemon/applog"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/audit"
+	"encr.dev/cli/daemon/collections"
+	"encr.dev/cli/daemon/crons"
 	"encr.dev/cli/daemon/dash/ai"
 	"encr.dev/cli/daemon/dash/apiproxy"
 	"encr.dev/cli/daemon/dash/dashproxy"
 	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/metrics"
+	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/cli/internal/jsonrpc2"
 	"encr.dev/internal/conf"
 	"encr.dev/pkg/fns"
@@ -27,7 +34,7 @@ var upgrader = websocket.Upgrader{
 }
 
 // NewServer starts a new server and returns it.
-func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, tr trace2.Store, dashPort int) *Server {
+func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, ns *namespace.Manager, cm *sqldb.ClusterManager, coll *collections.Manager, cronHistory *crons.History, tr trace2.Store, metrics *metrics.Store, audit *audit.Store, dashPort int) *Server {
 	proxy, err := dashproxy.New(conf.DevDashURL)
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not create dash proxy")
@@ -45,7 +52,15 @@ func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, tr trace2.Store, dash
 		apiProxy: apiProxy,
 		apps:     appsMgr,
 		run:      runMgr,
+		ns:       ns,
+		cm:       cm,
+		coll:     coll,
+		cronHist: cronHistory,
+		logs:     applog.NewBuffer(applog.DefaultCapacity),
 		tr:       tr,
+		metrics:  metrics,
+		audit:    audit,
+		diag:     newDiagnosticsStore(),
 		dashPort: dashPort,
 		traceCh:  make(chan trace2.NewSpanEvent, 10),
 		clients:  make(map[chan<- *notification]struct{}),
@@ -64,7 +79,15 @@ type Server struct {
 	apiProxy *httputil.ReverseProxy
 	apps     *apps.Manager
 	run      *run.Manager
+	ns       *namespace.Manager
+	cm       *sqldb.ClusterManager
+	coll     *collections.Manager
+	cronHist *crons.History
+	logs     *applog.Buffer
 	tr       trace2.Store
+	metrics  *metrics.Store
+	audit    *audit.Store
+	diag     *diagnosticsStore
 	dashPort int
 	traceCh  chan trace2.NewSpanEvent
 	ai       *ai.Manager
@@ -77,6 +100,8 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
 	case "/__encore":
 		s.WebSocket(w, req)
+	case "/__encore/diagnostics":
+		s.DiagnosticsEvents(w, req)
 	case "/__graphql":
 		s.apiProxy.ServeHTTP(w, req)
 	default:
@@ -96,7 +121,9 @@ func (s *Server) WebSocket(w http.ResponseWriter, req *http.Request) {
 
 	stream := &wsStream{c: c}
 	conn := jsonrpc2.NewConn(stream)
-	handler := &handler{rpc: conn, apps: s.apps, run: s.run, tr: s.tr, ai: s.ai}
+	handler := &handler{rpc: conn, apps: s.apps, run: s.run, ns: s.ns, cm: s.cm, coll: s.coll, tr: s.tr, ai: s.ai, cronHistory: s.cronHist,
+		logs: s.logs, metrics: s.metrics, audit: s.audit, diag: s.diag, streams: &apiStreams{}, streamCtx: req.Context()}
+	defer handler.streams.closeAll()
 	conn.Go(req.Context(), handler.Handle)
 
 	ch := make(chan *notification, 20)
@@ -0,0 +1,373 @@
+package dash
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"github.com/tailscale/hujson"
+
+	"encr.dev/cli/internal/jsonsample"
+	"encr.dev/parser/encoding"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// maxStreamLog is the maximum number of messages kept in the log of a stream.
+const maxStreamLog = 1000
+
+// streamMessage is an entry in the message log of a stream.
+type streamMessage struct {
+	Time time.Time `json:"time"`
+
+	// Direction is "out" for messages sent to the endpoint,
+	// "in" for messages received from it, and "event" for
+	// connection events like the stream being opened or closed.
+	Direction string          `json:"direction"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Event     string          `json:"event,omitempty"` // "open", "reconnect" or "close"
+	Error     string          `json:"error,omitempty"`
+}
+
+// apiStream is a connection to a streaming endpoint, driven from the dashboard.
+type apiStream struct {
+	id     string
+	params *apiCallParams
+	url    string
+	header http.Header
+
+	// notify is called with notifications about the stream.
+	notify func(method string, params any)
+
+	mu     sync.Mutex
+	conn   *websocket.Conn // nil when closed
+	log    []*streamMessage
+	closed bool // closed by the dashboard
+}
+
+// connect (re)connects the stream.
+func (s *apiStream) connect(ctx context.Context, event string) error {
+	dialer := &websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, resp, err := dialer.DialContext(ctx, s.url, s.header)
+	if err != nil {
+		if resp != nil {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			_ = resp.Body.Close()
+			err = fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+		return fmt.Errorf("could not connect to stream: %v", err)
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = conn.Close()
+		return fmt.Errorf("stream closed")
+	}
+	prev := s.conn
+	s.conn = conn
+	msg := s.record(&streamMessage{Direction: "event", Event: event})
+	s.mu.Unlock()
+
+	if prev != nil {
+		_ = prev.Close()
+	}
+	s.notify("stream/message", map[string]any{"stream_id": s.id, "message": msg})
+	go s.readLoop(conn)
+	return nil
+}
+
+// readLoop reads messages from conn until it is closed.
+func (s *apiStream) readLoop(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			s.mu.Lock()
+			if s.conn != conn {
+				// The connection was replaced or closed by the dashboard.
+				s.mu.Unlock()
+				return
+			}
+			s.conn = nil
+			msg := &streamMessage{Direction: "event", Event: "close"}
+			if ce, ok := err.(*websocket.CloseError); !ok || (ce.Code != websocket.CloseNormalClosure && ce.Code != websocket.CloseGoingAway) {
+				msg.Error = err.Error()
+			}
+			s.record(msg)
+			s.mu.Unlock()
+
+			_ = conn.Close()
+			s.notify("stream/message", map[string]any{"stream_id": s.id, "message": msg})
+			return
+		}
+
+		if !json.Valid(data) {
+			// Encode non-JSON messages as a JSON string.
+			data, _ = json.Marshal(string(data))
+		}
+		s.mu.Lock()
+		msg := s.record(&streamMessage{Direction: "in", Data: data})
+		s.mu.Unlock()
+		s.notify("stream/message", map[string]any{"stream_id": s.id, "message": msg})
+	}
+}
+
+// send sends a message on the stream.
+func (s *apiStream) send(data json.RawMessage) (*streamMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil, fmt.Errorf("stream is not connected")
+	}
+	if err := s.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return nil, fmt.Errorf("could not send message: %v", err)
+	}
+	return s.record(&streamMessage{Direction: "out", Data: data}), nil
+}
+
+// close aborts the stream.
+func (s *apiStream) close() {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.closed = true
+	s.mu.Unlock()
+
+	if conn != nil {
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		_ = conn.Close()
+	}
+}
+
+// messages returns a copy of the message log.
+func (s *apiStream) messages() []*streamMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*streamMessage{}, s.log...)
+}
+
+// record adds msg to the log. It must be called with s.mu held.
+func (s *apiStream) record(msg *streamMessage) *streamMessage {
+	msg.Time = time.Now()
+	s.log = append(s.log, msg)
+	if n := len(s.log) - maxStreamLog; n > 0 {
+		s.log = append(s.log[:0], s.log[n:]...)
+	}
+	return msg
+}
+
+// apiStreams tracks the streams opened by a dashboard client.
+type apiStreams struct {
+	mu      sync.Mutex
+	streams map[string]*apiStream
+}
+
+func (ss *apiStreams) get(id string) (*apiStream, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if s := ss.streams[id]; s != nil {
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown stream %q", id)
+}
+
+func (ss *apiStreams) add(s *apiStream) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.streams == nil {
+		ss.streams = make(map[string]*apiStream)
+	}
+	ss.streams[s.id] = s
+}
+
+func (ss *apiStreams) remove(id string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	delete(ss.streams, id)
+}
+
+// closeAll closes all streams. It's called when the dashboard client disconnects.
+func (ss *apiStreams) closeAll() {
+	ss.mu.Lock()
+	streams := ss.streams
+	ss.streams = nil
+	ss.mu.Unlock()
+	for _, s := range streams {
+		s.close()
+	}
+}
+
+// handleStream handles the requests for driving streaming endpoints from the API explorer.
+func (h *handler) handleStream(ctx context.Context, method string, unmarshal func(any) error) (any, error) {
+	type streamParams struct {
+		StreamID string `json:"stream_id"`
+	}
+
+	switch method {
+	case "stream/open":
+		var params apiCallParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		run := h.run.FindRunByAppID(params.AppID)
+		if run == nil || run.ProcGroup() == nil {
+			return nil, fmt.Errorf("app not running")
+		}
+		md := run.ProcGroup().Meta
+		rpc := findRPC(md, params.Service, params.Endpoint)
+		if rpc == nil {
+			return nil, fmt.Errorf("unknown service/endpoint: %s/%s", params.Service, params.Endpoint)
+		} else if !rpc.StreamingRequest && !rpc.StreamingResponse {
+			return nil, fmt.Errorf("%s.%s is not a streaming endpoint", params.Service, params.Endpoint)
+		}
+
+		url, header, err := prepareStreamRequest("ws://"+run.ListenAddr, md, rpc, &params)
+		if err != nil {
+			return nil, err
+		}
+		s := &apiStream{
+			id:     xid.New().String(),
+			params: &params,
+			url:    url,
+			header: header,
+			notify: func(method string, params any) {
+				// The dashboard's context outlives the request's.
+				if err := h.rpc.Notify(h.streamCtx, method, params); err != nil {
+					log.Debug().Err(err).Msg("dash: could not send stream notification")
+				}
+			},
+		}
+		if err := s.connect(ctx, "open"); err != nil {
+			return nil, err
+		}
+		h.streams.add(s)
+
+		resp := map[string]any{
+			"stream_id":          s.id,
+			"streaming_request":  rpc.StreamingRequest,
+			"streaming_response": rpc.StreamingResponse,
+			"messages":           s.messages(),
+		}
+		if rpc.StreamingRequest {
+			// Provide a sample message for the dashboard to pre-fill the message editor with.
+			if ex := jsonsample.Generate(md, rpc.RequestSchema); len(ex) > 0 {
+				resp["message_example"] = ex
+			}
+		}
+		return resp, nil
+
+	case "stream/send":
+		var params struct {
+			StreamID string `json:"stream_id"`
+			Message  []byte `json:"message"`
+		}
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		s, err := h.streams.get(params.StreamID)
+		if err != nil {
+			return nil, err
+		}
+		if run := h.run.FindRunByAppID(s.params.AppID); run != nil && run.ProcGroup() != nil {
+			if rpc := findRPC(run.ProcGroup().Meta, s.params.Service, s.params.Endpoint); rpc != nil && !rpc.StreamingRequest {
+				return nil, fmt.Errorf("%s.%s does not accept messages", s.params.Service, s.params.Endpoint)
+			}
+		}
+		data, err := standardizeMessage(params.Message)
+		if err != nil {
+			return nil, err
+		}
+		return s.send(data)
+
+	case "stream/messages":
+		var params streamParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		s, err := h.streams.get(params.StreamID)
+		if err != nil {
+			return nil, err
+		}
+		return s.messages(), nil
+
+	case "stream/reconnect":
+		var params streamParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		s, err := h.streams.get(params.StreamID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.connect(ctx, "reconnect"); err != nil {
+			return nil, err
+		}
+		return s.messages(), nil
+
+	case "stream/close":
+		var params streamParams
+		if err := unmarshal(&params); err != nil {
+			return nil, err
+		}
+		s, err := h.streams.get(params.StreamID)
+		if err != nil {
+			return nil, err
+		}
+		s.close()
+		h.streams.remove(s.id)
+		return s.messages(), nil
+
+	default:
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+}
+
+// prepareStreamRequest returns the URL and headers for connecting to the streaming endpoint rpc,
+// encoding the handshake and auth parameters of p.
+func prepareStreamRequest(baseURL string, md *meta.Data, rpc *meta.RPC, p *apiCallParams) (string, http.Header, error) {
+	reqSpec := newHTTPRequestSpec()
+	if rpc.HandshakeSchema != nil && len(p.Payload) > 0 {
+		encs, err := encoding.DescribeRequest(md, rpc.HandshakeSchema, nil, "GET")
+		if err != nil {
+			return "", nil, fmt.Errorf("describe handshake: %v", err)
+		}
+		if err := addToRequest(reqSpec, p.Payload, encs[0].ParameterEncodingMapByName()); err != nil {
+			return "", nil, fmt.Errorf("encode handshake params: %v", err)
+		}
+	}
+	if err := addAuthToRequest(reqSpec, md, p); err != nil {
+		return "", nil, err
+	}
+
+	url := baseURL + p.Path
+	if len(reqSpec.Query) > 0 {
+		url += "?" + reqSpec.Query.Encode()
+	}
+	for _, c := range reqSpec.Cookies {
+		reqSpec.Header.Add("Cookie", c.String())
+	}
+	return url, reqSpec.Header, nil
+}
+
+// standardizeMessage converts a message written in the dashboard,
+// which may contain comments and trailing commas, to standard JSON.
+func standardizeMessage(msg []byte) (json.RawMessage, error) {
+	if len(msg) == 0 {
+		return nil, fmt.Errorf("missing message")
+	}
+	v, err := hujson.Parse(msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %v", err)
+	}
+	v.Standardize()
+	v.Minimize()
+	return v.Pack(), nil
+}
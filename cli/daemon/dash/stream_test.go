@@ -0,0 +1,96 @@
+package dash
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/gorilla/websocket"
+)
+
+func TestAPIStream(t *testing.T) {
+	c := qt.New(t)
+
+	// Echo server that closes the connection when it receives "bye".
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteJSON(map[string]string{"hello": req.Header.Get("X-Name")})
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == `"bye"` {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			_ = conn.WriteMessage(websocket.TextMessage, data)
+		}
+	}))
+	defer srv.Close()
+
+	notified := make(chan *streamMessage, 10)
+	s := &apiStream{
+		id:     "test",
+		url:    "ws" + strings.TrimPrefix(srv.URL, "http"),
+		header: http.Header{"X-Name": []string{"encore"}},
+		notify: func(method string, params any) {
+			notified <- params.(map[string]any)["message"].(*streamMessage)
+		},
+	}
+	next := func() *streamMessage {
+		select {
+		case msg := <-notified:
+			return msg
+		case <-time.After(5 * time.Second):
+			c.Fatal("timed out waiting for stream message")
+			return nil
+		}
+	}
+
+	c.Assert(s.connect(context.Background(), "open"), qt.IsNil)
+	c.Assert(next().Event, qt.Equals, "open")
+	c.Assert(string(next().Data), qt.Equals, `{"hello":"encore"}`+"\n")
+
+	msg, err := standardizeMessage([]byte(`{"a": 1, /* comment */ }`))
+	c.Assert(err, qt.IsNil)
+	sent, err := s.send(msg)
+	c.Assert(err, qt.IsNil)
+	c.Assert(sent.Direction, qt.Equals, "out")
+	c.Assert(string(sent.Data), qt.Equals, `{"a":1}`)
+	got := next()
+	c.Assert(got.Direction, qt.Equals, "in")
+	c.Assert(string(got.Data), qt.Equals, `{"a":1}`)
+
+	// The server closing the stream is recorded without an error.
+	_, err = s.send([]byte(`"bye"`))
+	c.Assert(err, qt.IsNil)
+	closed := next()
+	c.Assert(closed.Event, qt.Equals, "close")
+	c.Assert(closed.Error, qt.Equals, "")
+	_, err = s.send(msg)
+	c.Assert(err, qt.ErrorMatches, "stream is not connected")
+
+	// Reconnecting keeps the message log.
+	c.Assert(s.connect(context.Background(), "reconnect"), qt.IsNil)
+	c.Assert(next().Event, qt.Equals, "reconnect")
+	next() // hello message
+	c.Assert(s.messages(), qt.HasLen, 8)
+
+	// Aborting the stream doesn't report a close event.
+	s.close()
+	c.Assert(s.connect(context.Background(), "reconnect"), qt.ErrorMatches, "stream closed")
+	select {
+	case msg := <-notified:
+		c.Fatalf("unexpected message after close: %+v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
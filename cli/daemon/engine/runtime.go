@@ -2,24 +2,35 @@ package engine
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
+	"encore.dev/appruntime/exported/auditlog"
 	tracemodel "encore.dev/appruntime/exported/trace2"
+	"encr.dev/cli/daemon/audit"
 	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/metrics"
 	"encr.dev/cli/daemon/run"
 )
 
 type server struct {
-	runMgr *run.Manager
-	rec    *trace2.Recorder
+	runMgr  *run.Manager
+	rec     *trace2.Recorder
+	metrics *metrics.Store
+	audit   *audit.Store
+
+	// testAudit stores the audit logs recorded by tests,
+	// separately from those of the apps' local environments.
+	testAudit *audit.Store
 }
 
-func NewServer(runMgr *run.Manager, rec *trace2.Recorder) http.Handler {
-	s := &server{runMgr: runMgr, rec: rec}
+func NewServer(runMgr *run.Manager, rec *trace2.Recorder, metrics *metrics.Store, audit, testAudit *audit.Store) http.Handler {
+	s := &server{runMgr: runMgr, rec: rec, metrics: metrics, audit: audit, testAudit: testAudit}
 	return s
 }
 
@@ -28,6 +39,10 @@ func (s *server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	switch req.URL.Path {
 	case "/trace":
 		s.RecordTrace(w, req)
+	case "/metrics":
+		s.RecordMetrics(w, req)
+	case "/audit":
+		s.RecordAudit(w, req)
 	default:
 		http.Error(w, "Not Found", http.StatusNotFound)
 	}
@@ -47,6 +62,75 @@ func (s *server) RecordTrace(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// RecordMetrics records metrics sent by an app using the Prometheus remote-write protocol.
+func (s *server) RecordMetrics(w http.ResponseWriter, req *http.Request) {
+	appID := req.URL.Query().Get("app_id")
+	if appID == "" {
+		http.Error(w, "missing app_id query parameter", http.StatusBadRequest)
+		return
+	}
+	s.metrics.ServeRemoteWrite(w, req, appID)
+}
+
+// RecordAudit appends an entry to an app's audit log,
+// and responds with the entry as stored.
+func (s *server) RecordAudit(w http.ResponseWriter, req *http.Request) {
+	appID, err := s.appID(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var retention time.Duration
+	if secs := req.Header.Get("X-Encore-Audit-Retention"); secs != "" {
+		n, err := strconv.ParseInt(secs, 10, 64)
+		if err != nil {
+			http.Error(w, "bad X-Encore-Audit-Retention header", http.StatusBadRequest)
+			return
+		}
+		retention = time.Duration(n) * time.Second
+	}
+
+	var e auditlog.Entry
+	if err := json.NewDecoder(req.Body).Decode(&e); err != nil {
+		http.Error(w, "unable to parse audit entry: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	store := s.audit
+	if req.Header.Get("X-Encore-Env-ID") == "test" {
+		store = s.testAudit
+	}
+	if err := store.Append(req.Context(), appID, &e, retention); err != nil {
+		http.Error(w, "unable to record audit entry: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&e)
+}
+
+// appID reports the id of the app that sent the request,
+// from the environment or app id headers.
+func (s *server) appID(req *http.Request) (string, error) {
+	pid := req.Header.Get("X-Encore-Env-ID")
+	if pid == "test" {
+		appID := req.Header.Get("X-Encore-App-ID")
+		if appID == "" {
+			return "", errors.New("missing X-Encore-App-ID header")
+		}
+		return appID, nil
+	}
+
+	if pid == "" {
+		return "", errors.New("missing X-Encore-Env-ID header")
+	}
+	proc := s.runMgr.FindProc(pid)
+	if proc == nil {
+		return "", errors.Newf("process %q is not running", pid)
+	}
+	return proc.Run.App.PlatformOrLocalID(), nil
+}
+
 func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err error) {
 	// Parse trace version
 	traceVersion := req.Header.Get("X-Encore-Trace-Version")
@@ -56,23 +140,11 @@ func (s *server) parseTraceData(req *http.Request) (d trace2.RecordData, err err
 	}
 	d.TraceVersion = tracemodel.Version(version)
 
-	pid := req.Header.Get("X-Encore-Env-ID")
-	if pid == "test" {
-		appID := req.Header.Get("X-Encore-App-ID")
-		if appID == "" {
-			return d, errors.New("missing X-Encore-App-ID header")
-		}
-		d.Meta = &trace2.Meta{AppID: appID}
-	} else {
-		if pid == "" {
-			return d, errors.New("missing X-Encore-Env-ID header")
-		}
-		proc := s.runMgr.FindProc(pid)
-		if proc == nil {
-			return d, errors.Newf("process %q is not running", pid)
-		}
-		d.Meta = &trace2.Meta{AppID: proc.Run.App.PlatformOrLocalID()}
+	appID, err := s.appID(req)
+	if err != nil {
+		return d, err
 	}
+	d.Meta = &trace2.Meta{AppID: appID}
 
 	// Parse time anchor
 	timeAnchor := req.Header.Get("X-Encore-Trace-TimeAnchor")
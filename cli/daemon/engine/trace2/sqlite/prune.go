@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+
+	"encr.dev/pkg/fns"
+)
+
+// pruneBatchSize is the maximum number of traces to delete in a single query.
+const pruneBatchSize = 1000
+
+// PruneExcess deletes the oldest traces of each app that has more than
+// maxTraces traces, keeping the maxTraces most recent ones.
+// It reports the number of traces deleted.
+func (s *Store) PruneExcess(ctx context.Context, maxTraces int) (deleted int, err error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT app_id FROM trace_event GROUP BY app_id HAVING COUNT(distinct trace_id) > ?", maxTraces)
+	if err != nil {
+		return 0, errors.Wrap(err, "get app ids")
+	}
+	appIDs, err := scanRows[string](rows)
+	if err != nil {
+		return 0, errors.Wrap(err, "scan app ids")
+	}
+
+	for _, appID := range appIDs {
+		// Find the first event of the oldest trace to keep.
+		var firstKept int64
+		err := s.db.QueryRowContext(ctx, `
+			WITH latest_events AS (
+				SELECT trace_id, min(id) as id FROM trace_event WHERE app_id = ? GROUP BY 1 ORDER BY 2 DESC LIMIT ?
+			) SELECT min(id) FROM latest_events;
+		`, appID, maxTraces).Scan(&firstKept)
+		if err != nil {
+			return deleted, errors.Wrap(err, "get oldest trace to keep")
+		}
+
+		for {
+			rows, err := s.db.QueryContext(ctx, `
+				SELECT trace_id FROM trace_event WHERE app_id = ?
+				GROUP BY trace_id HAVING min(id) < ? LIMIT ?
+			`, appID, firstKept, pruneBatchSize)
+			if err != nil {
+				return deleted, errors.Wrap(err, "get old trace ids")
+			}
+			traceIDs, err := scanRows[string](rows)
+			if err != nil {
+				return deleted, errors.Wrap(err, "scan old trace ids")
+			} else if len(traceIDs) == 0 {
+				break
+			}
+			if err := s.deleteTraces(ctx, traceIDs); err != nil {
+				return deleted, err
+			}
+			deleted += len(traceIDs)
+		}
+	}
+	return deleted, nil
+}
+
+// PruneOlderThan deletes the traces whose most recent span
+// started before cutoff. It reports the number of traces deleted.
+func (s *Store) PruneOlderThan(ctx context.Context, cutoff time.Time) (deleted int, err error) {
+	for {
+		rows, err := s.db.QueryContext(ctx, `
+			SELECT trace_id FROM trace_span_index
+			GROUP BY trace_id HAVING max(started_at) < ? LIMIT ?
+		`, cutoff.UnixNano(), pruneBatchSize)
+		if err != nil {
+			return deleted, errors.Wrap(err, "get expired trace ids")
+		}
+		traceIDs, err := scanRows[string](rows)
+		if err != nil {
+			return deleted, errors.Wrap(err, "scan expired trace ids")
+		} else if len(traceIDs) == 0 {
+			return deleted, nil
+		}
+		if err := s.deleteTraces(ctx, traceIDs); err != nil {
+			return deleted, err
+		}
+		deleted += len(traceIDs)
+	}
+}
+
+// PruneToSize deletes the oldest traces until the total size of
+// the stored trace events is at most maxBytes.
+// It reports the number of traces deleted.
+func (s *Store) PruneToSize(ctx context.Context, maxBytes int64) (deleted int, err error) {
+	size, err := s.Size(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for size > maxBytes {
+		rows, err := s.db.QueryContext(ctx, `
+			SELECT trace_id, sum(length(event_data)) FROM trace_event
+			GROUP BY trace_id ORDER BY min(id) LIMIT ?
+		`, pruneBatchSize)
+		if err != nil {
+			return deleted, errors.Wrap(err, "get oldest traces")
+		}
+
+		var traceIDs []string
+		for rows.Next() && size > maxBytes {
+			var (
+				traceID   string
+				traceSize int64
+			)
+			if err := rows.Scan(&traceID, &traceSize); err != nil {
+				fns.CloseIgnore(rows)
+				return deleted, errors.Wrap(err, "scan oldest traces")
+			}
+			traceIDs = append(traceIDs, traceID)
+			size -= traceSize
+		}
+		err = rows.Err()
+		fns.CloseIgnore(rows)
+		if err != nil {
+			return deleted, errors.Wrap(err, "iterate oldest traces")
+		} else if len(traceIDs) == 0 {
+			break
+		}
+
+		if err := s.deleteTraces(ctx, traceIDs); err != nil {
+			return deleted, err
+		}
+		deleted += len(traceIDs)
+	}
+	return deleted, nil
+}
+
+// Size reports the total size of the stored trace events, in bytes.
+func (s *Store) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := s.db.QueryRowContext(ctx, "SELECT coalesce(sum(length(event_data)), 0) FROM trace_event").Scan(&size)
+	return size, errors.Wrap(err, "get trace size")
+}
+
+// deleteTraces deletes the events and spans of the given traces.
+func (s *Store) deleteTraces(ctx context.Context, traceIDs []string) error {
+	idArgs := strings.Join(fns.Map(traceIDs, pq.QuoteLiteral), ",")
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM trace_event WHERE trace_id IN ("+idArgs+")"); err != nil {
+		return errors.Wrap(err, "delete trace events")
+	}
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM trace_span_index WHERE trace_id IN ("+idArgs+")"); err != nil {
+		return errors.Wrap(err, "delete trace spans")
+	}
+	return nil
+}
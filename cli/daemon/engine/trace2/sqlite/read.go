@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -27,30 +28,81 @@ func (s *Store) List(ctx context.Context, q *trace2.Query, iter trace2.ListEntry
 	}
 
 	extraWhereClause := ""
+	addFilter := func(cond string, arg any) {
+		args = append(args, arg)
+		extraWhereClause += " AND " + strings.ReplaceAll(cond, "?", "$"+strconv.Itoa(len(args)))
+	}
 
 	if q.MessageID != "" {
-		args = append(args, q.MessageID)
-		extraWhereClause += " AND message_id = $" + strconv.Itoa(len(args))
+		addFilter("message_id = ?", q.MessageID)
+	}
+	if q.Service != "" {
+		addFilter("service_name = ?", q.Service)
+	}
+	if q.Endpoint != "" {
+		addFilter("endpoint_name = ?", q.Endpoint)
+	}
+	if q.Topic != "" {
+		addFilter("topic_name = ?", q.Topic)
+	}
+	if q.Subscription != "" {
+		addFilter("subscription_name = ?", q.Subscription)
+	}
+	if q.TraceID != "" {
+		addFilter("trace_id = ?", q.TraceID)
+	}
+	if q.UserID != "" {
+		addFilter("user_id = ?", q.UserID)
+	}
+	if q.StatusCode != 0 {
+		addFilter("http_status_code = ?", q.StatusCode)
+	}
+	if q.ErrorCode != "" {
+		addFilter("error_code = ?", q.ErrorCode)
+	}
+	if q.IsError != nil {
+		addFilter("is_error = ?", *q.IsError)
+	}
+	if !q.StartTime.IsZero() {
+		addFilter("started_at >= ?", q.StartTime.UnixNano())
+	}
+	if !q.EndTime.IsZero() {
+		addFilter("started_at < ?", q.EndTime.UnixNano())
+	}
+	if q.MinDurNanos > 0 {
+		addFilter("duration_nanos >= ?", q.MinDurNanos)
+	}
+	if q.MaxDurNanos > 0 {
+		addFilter("duration_nanos <= ?", q.MaxDurNanos)
 	}
 
 	// If we're filter for tests / not tests, add the extra where clause
 	if q.TestFilter != nil {
-		args = append(args, tracepb2.SpanSummary_TEST)
 		if *q.TestFilter {
-			extraWhereClause += " AND span_type = $" + strconv.Itoa(len(args))
+			addFilter("span_type = ?", tracepb2.SpanSummary_TEST)
 		} else {
-			extraWhereClause += " AND span_type != $" + strconv.Itoa(len(args))
+			addFilter("span_type != ?", tracepb2.SpanSummary_TEST)
 		}
 	}
 
+	orderBy := "started_at"
+	if q.SortBy == trace2.SortByDuration {
+		orderBy = "duration_nanos"
+	}
+	if q.SortAsc {
+		orderBy += " ASC"
+	} else {
+		orderBy += " DESC"
+	}
+
 	rows, err := s.db.QueryContext(ctx, `
 		SELECT
 		    trace_id, span_id, started_at, span_type, is_root, service_name, endpoint_name,
 		    topic_name, subscription_name, message_id, is_error, test_skipped, duration_nanos, src_file, src_line
 		FROM trace_span_index
 		WHERE app_id = $1 AND has_response AND is_root AND span_type != $2 `+extraWhereClause+`
-		ORDER BY started_at DESC
-		LIMIT `+strconv.Itoa(limit)+`
+		ORDER BY `+orderBy+`
+		LIMIT `+strconv.Itoa(limit)+` OFFSET `+strconv.Itoa(max(q.Offset, 0))+`
 	`, args...)
 	if err != nil {
 		return errors.Wrap(err, "query traces")
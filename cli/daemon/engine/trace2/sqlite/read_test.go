@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/mgmtdb/mgmtdbtest"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func newTestStore(c *qt.C) *Store {
+	return New(context.Background(), mgmtdbtest.New(c))
+}
+
+type testRequest struct {
+	traceID  uint64
+	endpoint string
+	uid      string
+	status   uint32
+	errCode  string
+	duration time.Duration
+}
+
+func writeRequest(c *qt.C, s *Store, start time.Time, r testRequest) {
+	traceID := &tracepb2.TraceID{Low: r.traceID}
+	end := &tracepb2.SpanEnd{
+		DurationNanos: uint64(r.duration),
+		Data: &tracepb2.SpanEnd_Request{Request: &tracepb2.RequestSpanEnd{
+			ServiceName:    "svc",
+			EndpointName:   r.endpoint,
+			HttpStatusCode: r.status,
+		}},
+	}
+	if r.errCode != "" {
+		end.Error = &tracepb2.Error{Msg: "boom"}
+		end.GetRequest().ResponsePayload = []byte(`{"code":"` + r.errCode + `","message":"boom"}`)
+	}
+
+	err := s.WriteEvents(context.Background(), &trace2.Meta{AppID: "app"}, []*tracepb2.TraceEvent{
+		{
+			TraceId:   traceID,
+			SpanId:    1,
+			EventTime: timestamppb.New(start),
+			Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+				Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{
+					ServiceName:  "svc",
+					EndpointName: r.endpoint,
+					Uid:          &r.uid,
+				}},
+			}},
+		},
+		{
+			TraceId:   traceID,
+			SpanId:    1,
+			EventTime: timestamppb.New(start.Add(r.duration)),
+			Event:     &tracepb2.TraceEvent_SpanEnd{SpanEnd: end},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+}
+
+func TestList(t *testing.T) {
+	c := qt.New(t)
+	s := newTestStore(c)
+
+	now := time.Now()
+	reqs := []testRequest{
+		{traceID: 1, endpoint: "Get", uid: "alice", status: 200, duration: 5 * time.Millisecond},
+		{traceID: 2, endpoint: "Get", uid: "bob", status: 404, errCode: "not_found", duration: 1 * time.Millisecond},
+		{traceID: 3, endpoint: "List", uid: "alice", status: 200, duration: 50 * time.Millisecond},
+		{traceID: 4, endpoint: "List", uid: "bob", status: 500, errCode: "internal", duration: 20 * time.Millisecond},
+	}
+	for i, r := range reqs {
+		writeRequest(c, s, now.Add(time.Duration(i)*time.Second), r)
+	}
+
+	list := func(q trace2.Query) []string {
+		q.AppID = "app"
+		var ids []string
+		err := s.List(context.Background(), &q, func(sp *tracepb2.SpanSummary) bool {
+			ids = append(ids, sp.GetEndpointName()+"/"+sp.TraceId)
+			return true
+		})
+		c.Assert(err, qt.IsNil)
+		return ids
+	}
+	id := func(endpoint string, traceID uint64) string {
+		return endpoint + "/" + encodeTraceID(&tracepb2.TraceID{Low: traceID})
+	}
+
+	isError := true
+	c.Assert(list(trace2.Query{}), qt.DeepEquals, []string{id("List", 4), id("List", 3), id("Get", 2), id("Get", 1)})
+	c.Assert(list(trace2.Query{Endpoint: "Get"}), qt.DeepEquals, []string{id("Get", 2), id("Get", 1)})
+	c.Assert(list(trace2.Query{UserID: "alice"}), qt.DeepEquals, []string{id("List", 3), id("Get", 1)})
+	c.Assert(list(trace2.Query{StatusCode: 404}), qt.DeepEquals, []string{id("Get", 2)})
+	c.Assert(list(trace2.Query{ErrorCode: "internal"}), qt.DeepEquals, []string{id("List", 4)})
+	c.Assert(list(trace2.Query{IsError: &isError}), qt.DeepEquals, []string{id("List", 4), id("Get", 2)})
+	c.Assert(list(trace2.Query{MinDurNanos: uint64(10 * time.Millisecond)}), qt.DeepEquals, []string{id("List", 4), id("List", 3)})
+	c.Assert(list(trace2.Query{StartTime: now.Add(time.Second), EndTime: now.Add(3 * time.Second)}), qt.DeepEquals,
+		[]string{id("List", 3), id("Get", 2)})
+
+	// Sorting and pagination.
+	c.Assert(list(trace2.Query{SortBy: trace2.SortByDuration, Limit: 2}), qt.DeepEquals, []string{id("List", 3), id("List", 4)})
+	c.Assert(list(trace2.Query{SortBy: trace2.SortByDuration, Limit: 2, Offset: 2}), qt.DeepEquals, []string{id("Get", 1), id("Get", 2)})
+	c.Assert(list(trace2.Query{SortAsc: true, Limit: 1}), qt.DeepEquals, []string{id("Get", 1)})
+}
@@ -5,27 +5,22 @@ import (
 	"database/sql"
 	"encoding/base32"
 	"encoding/binary"
+	"encoding/json"
 	"net/http"
-	"strings"
-	"time"
 
 	"github.com/cockroachdb/errors"
-	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"encr.dev/cli/daemon/engine/trace2"
-	"encr.dev/pkg/fns"
 	tracepbcli "encr.dev/proto/encore/engine/trace2"
 )
 
 // New creates a new store backed by the given db.
 func New(ctx context.Context, db *sql.DB) *Store {
-	s := &Store{
+	return &Store{
 		db: db,
 	}
-	s.startCleaner(ctx, 1*time.Minute, 500, 100, 10000)
-	return s
 }
 
 type Store struct {
@@ -49,76 +44,6 @@ func scanRows[T any](rows *sql.Rows) ([]T, error) {
 	return out, nil
 }
 
-func (s *Store) startCleaner(ctx context.Context, freq time.Duration, triggerAt, eventsToKeep, batchSize int) {
-	go func() {
-		for {
-			timer := time.NewTimer(freq)
-			select {
-			case <-ctx.Done():
-				return
-			case <-timer.C:
-				log.Info().Msg("initiating trace event cleanup sweep")
-				rows, err := s.db.QueryContext(ctx, "SELECT app_id FROM trace_event GROUP BY app_id HAVING COUNT(distinct trace_id) > ?", triggerAt)
-				if err != nil {
-					log.Error().Err(err).Msg("failed to get app ids")
-					continue
-				}
-				appIDs, err := scanRows[string](rows)
-				if err != nil {
-					log.Error().Err(err).Msg("failed to scan app ids")
-					continue
-				}
-				for _, appID := range appIDs {
-					row := s.db.QueryRowContext(ctx, `
-						WITH latest_events AS (
-							SELECT trace_id, min(id) as id FROM trace_event WHERE app_id = ? GROUP BY 1 ORDER BY 2 DESC LIMIT ?
-						) SELECT min(id) FROM latest_events;
-					`, appID, eventsToKeep)
-					var traceID int64
-					err := row.Scan(&traceID)
-					if err != nil {
-						log.Error().Err(err).Msg("failed to get trace id")
-						continue
-					}
-					rows, err := s.db.QueryContext(ctx, "SELECT DISTINCT trace_id FROM trace_event WHERE app_id = ? AND id < ? ORDER BY id DESC LIMIT ?", appID, traceID, batchSize)
-					if err != nil {
-						log.Error().Err(err).Msg("failed to get old trace ids")
-						continue
-					}
-					traceIDs, err := scanRows[string](rows)
-					if len(traceIDs) == 0 {
-						continue
-					}
-					idArgs := strings.Join(fns.Map(traceIDs, pq.QuoteLiteral), ",")
-					res, err := s.db.ExecContext(ctx, "DELETE FROM trace_event WHERE app_id = ? AND trace_id IN ("+idArgs+")", appID)
-					if err != nil {
-						log.Error().Err(err).Msg("failed to delete old trace events")
-						continue
-					}
-					rowCount, err := res.RowsAffected()
-					if err != nil {
-						log.Error().Err(err).Msg("failed to get rows affected")
-						continue
-					}
-					log.Info().Str("app_id", appID).Int64("deleted", rowCount).Msg("cleaned up old trace events")
-					res, err = s.db.ExecContext(ctx, "DELETE FROM trace_span_index WHERE app_id = ? AND trace_id IN ("+idArgs+")", appID)
-					if err != nil {
-						log.Error().Err(err).Msg("failed to delete old trace spans")
-						continue
-					}
-					rowCount, err = res.RowsAffected()
-					if err != nil {
-						log.Error().Err(err).Msg("failed to get rows affected")
-						continue
-					}
-					log.Info().Str("app_id", appID).Int64("deleted", rowCount).Msg("cleaned up old trace spans")
-				}
-			}
-		}
-
-	}()
-}
-
 func (s *Store) Listen(ch chan<- trace2.NewSpanEvent) {
 	s.listeners = append(s.listeners, ch)
 }
@@ -177,16 +102,18 @@ func (s *Store) updateSpanStartIndex(ctx context.Context, meta *trace2.Meta, ev
 		extRequestID := req.RequestHeaders[http.CanonicalHeaderKey("X-Request-ID")]
 		_, err := s.db.ExecContext(ctx, `
 			INSERT INTO trace_span_index (
-				app_id, trace_id, span_id, span_type, started_at, is_root, service_name, endpoint_name, external_request_id, has_response, test_skipped
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, false, false)
+				app_id, trace_id, span_id, span_type, started_at, is_root, service_name, endpoint_name, external_request_id,
+				user_id, has_response, test_skipped
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, false, false)
 			ON CONFLICT (trace_id, span_id) DO UPDATE SET
 				is_root = excluded.is_root,
 				service_name = excluded.service_name,
 				endpoint_name = excluded.endpoint_name,
-				external_request_id = excluded.external_request_id
+				external_request_id = excluded.external_request_id,
+				user_id = excluded.user_id
 		`, meta.AppID, encodeTraceID(ev.TraceId), encodeSpanID(ev.SpanId),
 			tracepbcli.SpanSummary_REQUEST, ev.EventTime.AsTime().UnixNano(),
-			isRoot, req.ServiceName, req.EndpointName, extRequestID)
+			isRoot, req.ServiceName, req.EndpointName, extRequestID, req.Uid)
 		if err != nil {
 			return errors.Wrap(err, "insert trace span event")
 		}
@@ -267,17 +194,23 @@ func (s *Store) updateSpanEndIndex(ctx context.Context, meta *trace2.Meta, ev *t
 	}()
 
 	if req := end.GetRequest(); req != nil {
+		var errCode *string
+		if end.Error != nil {
+			errCode = errorCode(req.ResponsePayload)
+		}
 		_, err := s.db.ExecContext(ctx, `
 			INSERT INTO trace_span_index (
-				app_id, trace_id, span_id, span_type, has_response, is_error, duration_nanos
-			) VALUES (?, ?, ?, ?, ?, ?, ?)
+				app_id, trace_id, span_id, span_type, has_response, is_error, duration_nanos, http_status_code, error_code
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 			ON CONFLICT (trace_id, span_id) DO UPDATE SET
 				has_response = excluded.has_response,
 				is_error = excluded.is_error,
-				duration_nanos = excluded.duration_nanos
+				duration_nanos = excluded.duration_nanos,
+				http_status_code = excluded.http_status_code,
+				error_code = excluded.error_code
 		`, meta.AppID, traceID, spanID,
 			tracepbcli.SpanSummary_REQUEST, true,
-			end.Error != nil, end.DurationNanos)
+			end.Error != nil, end.DurationNanos, req.HttpStatusCode, errCode)
 		if err != nil {
 			return errors.Wrap(err, "insert trace span event")
 		}
@@ -343,6 +276,18 @@ func (s *Store) updateSpanEndIndex(ctx context.Context, meta *trace2.Meta, ev *t
 	return nil
 }
 
+// errorCode returns the Encore error code of an error response payload, like "not_found",
+// or nil if the payload isn't an Encore error.
+func errorCode(payload []byte) *string {
+	var resp struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(payload, &resp); err != nil || resp.Code == "" {
+		return nil
+	}
+	return &resp.Code
+}
+
 var (
 	binBE = binary.BigEndian
 	binLE = binary.LittleEndian
@@ -33,9 +33,27 @@ type Query struct {
 	// If MaxDurMicros is 0 it defaults to no limit.
 	MinDurNanos, MaxDurNanos uint64
 
-	Limit int // if 0 defaults to 100.
+	UserID     string // the authenticated user
+	StatusCode int    // HTTP status code; 0 means any
+	ErrorCode  string // Encore error code, like "not_found"
+
+	// SortBy is what to sort by, in descending order unless SortAsc is set.
+	// If empty it defaults to SortByStartTime.
+	SortBy  SortField
+	SortAsc bool
+
+	Limit  int // if 0 defaults to 100.
+	Offset int // number of traces to skip, for pagination
 }
 
+// SortField is a field traces can be sorted by.
+type SortField string
+
+const (
+	SortByStartTime SortField = "started_at"
+	SortByDuration  SortField = "duration"
+)
+
 type Tag struct {
 	Key   string
 	Value string
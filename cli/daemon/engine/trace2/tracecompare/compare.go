@@ -0,0 +1,274 @@
+// Package tracecompare compares two traces of the same endpoint,
+// to quantify the effect of a change or compare a slow request
+// against a fast baseline.
+package tracecompare
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// Span is a span within a trace.
+type Span struct {
+	ID            uint64 `json:"id"`
+	Kind          string `json:"kind"` // "request", "auth", "pubsub_message" or "test"
+	Name          string `json:"name"` // e.g. "service.Endpoint"
+	OffsetNanos   int64  `json:"offset_nanos"`
+	DurationNanos uint64 `json:"duration_nanos"`
+	IsError       bool   `json:"is_error"`
+}
+
+// Operation is an operation performed within a span,
+// like a database query or a call to another service.
+type Operation struct {
+	SpanID        uint64 `json:"span_id"`
+	Kind          string `json:"kind"` // "rpc", "db_query", "http", "pubsub_publish" or "cache"
+	Name          string `json:"name"` // e.g. the query or the called endpoint
+	OffsetNanos   int64  `json:"offset_nanos"`
+	DurationNanos int64  `json:"duration_nanos"`
+	IsError       bool   `json:"is_error"`
+}
+
+// Summary summarizes a trace.
+type Summary struct {
+	TraceID       string       `json:"trace_id"`
+	Root          string       `json:"root"` // name of the root span
+	DurationNanos uint64       `json:"duration_nanos"`
+	Spans         []*Span      `json:"spans"`
+	Operations    []*Operation `json:"operations"`
+}
+
+// Summarize summarizes the trace with the given events.
+func Summarize(traceID string, events []*tracepb2.TraceEvent) *Summary {
+	events = append([]*tracepb2.TraceEvent(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].EventTime.AsTime().Before(events[j].EventTime.AsTime())
+	})
+
+	s := &Summary{TraceID: traceID, Spans: []*Span{}, Operations: []*Operation{}}
+	if len(events) == 0 {
+		return s
+	}
+	start := events[0].EventTime.AsTime()
+	offset := func(t time.Time) int64 { return int64(t.Sub(start)) }
+
+	spans := make(map[uint64]*Span)
+	ops := make(map[uint64]*Operation) // start event id -> operation
+	var root *Span
+	for _, ev := range events {
+		at := ev.EventTime.AsTime()
+		switch e := ev.Event.(type) {
+		case *tracepb2.TraceEvent_SpanStart:
+			sp := &Span{ID: ev.SpanId, OffsetNanos: offset(at)}
+			switch d := e.SpanStart.Data.(type) {
+			case *tracepb2.SpanStart_Request:
+				sp.Kind, sp.Name = "request", d.Request.ServiceName+"."+d.Request.EndpointName
+			case *tracepb2.SpanStart_Auth:
+				sp.Kind, sp.Name = "auth", d.Auth.ServiceName+"."+d.Auth.EndpointName
+			case *tracepb2.SpanStart_PubsubMessage:
+				sp.Kind, sp.Name = "pubsub_message", d.PubsubMessage.TopicName+"."+d.PubsubMessage.SubscriptionName
+			case *tracepb2.SpanStart_Test:
+				sp.Kind, sp.Name = "test", d.Test.ServiceName+"."+d.Test.TestName
+			}
+			spans[ev.SpanId] = sp
+			s.Spans = append(s.Spans, sp)
+			if root == nil && e.SpanStart.ParentSpanId == nil {
+				root = sp
+			}
+
+		case *tracepb2.TraceEvent_SpanEnd:
+			if sp := spans[ev.SpanId]; sp != nil {
+				sp.DurationNanos = e.SpanEnd.DurationNanos
+				sp.IsError = e.SpanEnd.Error != nil
+			}
+
+		case *tracepb2.TraceEvent_SpanEvent:
+			if op := newOperation(e.SpanEvent); op != nil {
+				op.SpanID, op.OffsetNanos = ev.SpanId, offset(at)
+				ops[ev.EventId] = op
+				s.Operations = append(s.Operations, op)
+			} else if id := e.SpanEvent.CorrelationEventId; id != nil {
+				if op := ops[*id]; op != nil {
+					op.DurationNanos = offset(at) - op.OffsetNanos
+					op.IsError = operationFailed(e.SpanEvent)
+				}
+			}
+		}
+	}
+
+	if root == nil && len(s.Spans) > 0 {
+		root = s.Spans[0]
+	}
+	if root != nil {
+		s.Root = root.Name
+		s.DurationNanos = root.DurationNanos
+	}
+	return s
+}
+
+// newOperation returns the operation started by ev,
+// or nil if ev doesn't start an operation.
+func newOperation(ev *tracepb2.SpanEvent) *Operation {
+	switch d := ev.Data.(type) {
+	case *tracepb2.SpanEvent_RpcCallStart:
+		return &Operation{Kind: "rpc", Name: d.RpcCallStart.TargetServiceName + "." + d.RpcCallStart.TargetEndpointName}
+	case *tracepb2.SpanEvent_DbQueryStart:
+		return &Operation{Kind: "db_query", Name: normalizeQuery(d.DbQueryStart.Query)}
+	case *tracepb2.SpanEvent_HttpCallStart:
+		url, _, _ := strings.Cut(d.HttpCallStart.Url, "?")
+		return &Operation{Kind: "http", Name: d.HttpCallStart.Method + " " + url}
+	case *tracepb2.SpanEvent_PubsubPublishStart:
+		return &Operation{Kind: "pubsub_publish", Name: d.PubsubPublishStart.Topic}
+	case *tracepb2.SpanEvent_CacheCallStart:
+		return &Operation{Kind: "cache", Name: d.CacheCallStart.Operation}
+	}
+	return nil
+}
+
+// operationFailed reports whether the operation end event ev reports an error.
+func operationFailed(ev *tracepb2.SpanEvent) bool {
+	switch d := ev.Data.(type) {
+	case *tracepb2.SpanEvent_RpcCallEnd:
+		return d.RpcCallEnd.Err != nil
+	case *tracepb2.SpanEvent_DbQueryEnd:
+		return d.DbQueryEnd.Err != nil
+	case *tracepb2.SpanEvent_HttpCallEnd:
+		return d.HttpCallEnd.Err != nil
+	case *tracepb2.SpanEvent_PubsubPublishEnd:
+		return d.PubsubPublishEnd.Err != nil
+	case *tracepb2.SpanEvent_CacheCallEnd:
+		return d.CacheCallEnd.Err != nil
+	}
+	return false
+}
+
+// normalizeQuery collapses whitespace in a query so that
+// the same query formatted differently is grouped together.
+func normalizeQuery(q string) string {
+	return strings.Join(strings.Fields(q), " ")
+}
+
+// SpanDiff compares the nth span with the same kind and name in both traces.
+// A or B is nil if the span only occurred in one of the traces.
+type SpanDiff struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Occurrence int    `json:"occurrence"` // zero-based
+	A          *Span  `json:"a"`
+	B          *Span  `json:"b"`
+	DeltaNanos int64  `json:"delta_nanos"` // B's duration minus A's
+}
+
+// OperationDiff compares the operations of a kind and name across both traces.
+type OperationDiff struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	CountA      int    `json:"count_a"`
+	CountB      int    `json:"count_b"`
+	TotalNanosA int64  `json:"total_nanos_a"`
+	TotalNanosB int64  `json:"total_nanos_b"`
+	DeltaNanos  int64  `json:"delta_nanos"` // B's total minus A's
+}
+
+// Comparison is the comparison of two traces.
+type Comparison struct {
+	A          *Summary         `json:"a"`
+	B          *Summary         `json:"b"`
+	DeltaNanos int64            `json:"delta_nanos"` // B's duration minus A's
+	Spans      []*SpanDiff      `json:"spans"`
+	Operations []*OperationDiff `json:"operations"`
+}
+
+// Compare compares the traces a and b.
+// Differences are reported as b relative to a, so a is typically the baseline.
+func Compare(a, b *Summary) *Comparison {
+	c := &Comparison{
+		A:          a,
+		B:          b,
+		DeltaNanos: int64(b.DurationNanos) - int64(a.DurationNanos),
+		Spans:      []*SpanDiff{},
+		Operations: []*OperationDiff{},
+	}
+
+	// Match spans by kind, name and occurrence, in the order they started.
+	type spanKey struct {
+		kind, name string
+		n          int
+	}
+	var keys []spanKey
+	matched := make(map[spanKey]*SpanDiff)
+	addSpans := func(spans []*Span, isA bool) {
+		seen := make(map[[2]string]int)
+		for _, sp := range spans {
+			n := seen[[2]string{sp.Kind, sp.Name}]
+			seen[[2]string{sp.Kind, sp.Name}]++
+			key := spanKey{sp.Kind, sp.Name, n}
+			d := matched[key]
+			if d == nil {
+				d = &SpanDiff{Kind: sp.Kind, Name: sp.Name, Occurrence: n}
+				matched[key] = d
+				keys = append(keys, key)
+			}
+			if isA {
+				d.A = sp
+			} else {
+				d.B = sp
+			}
+		}
+	}
+	addSpans(a.Spans, true)
+	addSpans(b.Spans, false)
+	for _, key := range keys {
+		d := matched[key]
+		if d.A != nil && d.B != nil {
+			d.DeltaNanos = int64(d.B.DurationNanos) - int64(d.A.DurationNanos)
+		}
+		c.Spans = append(c.Spans, d)
+	}
+
+	// Group operations by kind and name.
+	type opKey struct{ kind, name string }
+	var opKeys []opKey
+	ops := make(map[opKey]*OperationDiff)
+	addOps := func(list []*Operation, isA bool) {
+		for _, op := range list {
+			key := opKey{op.Kind, op.Name}
+			d := ops[key]
+			if d == nil {
+				d = &OperationDiff{Kind: op.Kind, Name: op.Name}
+				ops[key] = d
+				opKeys = append(opKeys, key)
+			}
+			if isA {
+				d.CountA++
+				d.TotalNanosA += op.DurationNanos
+			} else {
+				d.CountB++
+				d.TotalNanosB += op.DurationNanos
+			}
+		}
+	}
+	addOps(a.Operations, true)
+	addOps(b.Operations, false)
+	for _, key := range opKeys {
+		d := ops[key]
+		d.DeltaNanos = d.TotalNanosB - d.TotalNanosA
+		c.Operations = append(c.Operations, d)
+	}
+
+	// Show the biggest differences first.
+	sort.SliceStable(c.Operations, func(i, j int) bool {
+		return abs(c.Operations[i].DeltaNanos) > abs(c.Operations[j].DeltaNanos)
+	})
+	return c
+}
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
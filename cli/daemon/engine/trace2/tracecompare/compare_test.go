@@ -0,0 +1,73 @@
+package tracecompare
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// traceEvents returns the events of a request to svc.Get that makes
+// the given number of database queries, each taking queryDur.
+func traceEvents(queries int, queryDur time.Duration) []*tracepb2.TraceEvent {
+	start := time.Unix(1700000000, 0)
+	at := func(d time.Duration) *timestamppb.Timestamp { return timestamppb.New(start.Add(d)) }
+
+	events := []*tracepb2.TraceEvent{{
+		SpanId: 1, EventId: 1, EventTime: at(0),
+		Event: &tracepb2.TraceEvent_SpanStart{SpanStart: &tracepb2.SpanStart{
+			Data: &tracepb2.SpanStart_Request{Request: &tracepb2.RequestSpanStart{ServiceName: "svc", EndpointName: "Get"}},
+		}},
+	}}
+	now := time.Millisecond
+	for i := 0; i < queries; i++ {
+		startID := uint64(len(events) + 1)
+		events = append(events, &tracepb2.TraceEvent{
+			SpanId: 1, EventId: startID, EventTime: at(now),
+			Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+				Data: &tracepb2.SpanEvent_DbQueryStart{DbQueryStart: &tracepb2.DBQueryStart{Query: "SELECT *\n  FROM users"}},
+			}},
+		}, &tracepb2.TraceEvent{
+			SpanId: 1, EventId: startID + 1, EventTime: at(now + queryDur),
+			Event: &tracepb2.TraceEvent_SpanEvent{SpanEvent: &tracepb2.SpanEvent{
+				CorrelationEventId: &startID,
+				Data:               &tracepb2.SpanEvent_DbQueryEnd{DbQueryEnd: &tracepb2.DBQueryEnd{}},
+			}},
+		})
+		now += queryDur
+	}
+	events = append(events, &tracepb2.TraceEvent{
+		SpanId: 1, EventId: uint64(len(events) + 1), EventTime: at(now),
+		Event: &tracepb2.TraceEvent_SpanEnd{SpanEnd: &tracepb2.SpanEnd{DurationNanos: uint64(now)}},
+	})
+	return events
+}
+
+func TestCompare(t *testing.T) {
+	c := qt.New(t)
+	fast := Summarize("fast", traceEvents(1, 2*time.Millisecond))
+	slow := Summarize("slow", traceEvents(3, 5*time.Millisecond))
+
+	c.Assert(fast.Root, qt.Equals, "svc.Get")
+	c.Assert(fast.DurationNanos, qt.Equals, uint64(3*time.Millisecond))
+	c.Assert(slow.Operations, qt.HasLen, 3)
+	c.Assert(slow.Operations[0].Name, qt.Equals, "SELECT * FROM users")
+	c.Assert(slow.Operations[0].DurationNanos, qt.Equals, int64(5*time.Millisecond))
+
+	cmp := Compare(fast, slow)
+	c.Assert(cmp.DeltaNanos, qt.Equals, int64(13*time.Millisecond))
+	c.Assert(cmp.Spans, qt.HasLen, 1)
+	c.Assert(cmp.Spans[0].DeltaNanos, qt.Equals, int64(13*time.Millisecond))
+	c.Assert(cmp.Operations, qt.DeepEquals, []*OperationDiff{{
+		Kind:        "db_query",
+		Name:        "SELECT * FROM users",
+		CountA:      1,
+		CountB:      3,
+		TotalNanosA: int64(2 * time.Millisecond),
+		TotalNanosB: int64(15 * time.Millisecond),
+		DeltaNanos:  int64(13 * time.Millisecond),
+	}})
+}
@@ -2,6 +2,12 @@ package export
 
 import (
 	"context"
+	"crypto"
+	"encoding/json"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -10,20 +16,26 @@ import (
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/daemon"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/rs/zerolog"
 
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/internal/env"
+	"encr.dev/internal/version"
 	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
 	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/dockerbuild"
 	"encr.dev/pkg/fns"
+	"encr.dev/pkg/infraconf"
 	"encr.dev/pkg/option"
+	"encr.dev/pkg/paths"
 	"encr.dev/pkg/vcs"
 	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 // Docker exports the app as a docker image.
@@ -33,24 +45,455 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		return false, errors.Newf("unsupported format: %T", req.Format)
 	}
 
+	platforms, err := parsePlatforms(req, params)
+	if err != nil {
+		return false, err
+	}
+	if len(platforms) > 1 && params.LocalDaemonTag != "" {
+		return false, errors.New("multi-platform images cannot be saved to the local docker daemon; push them or write them as an OCI layout instead")
+	}
+	if err := expandTags(params, app.Root(), time.Now()); err != nil {
+		return false, err
+	}
+	push := pushOptions{keychain: registryKeychain(req.Environ)}
+	if params.SigningKeyPath != "" {
+		if params.PushDestinationTag == "" {
+			return false, errors.New("only pushed images can be signed")
+		}
+		data, err := os.ReadFile(params.SigningKeyPath)
+		if err != nil {
+			return false, errors.Wrap(err, "read signing key")
+		}
+		push.signer, err = dockerbuild.LoadSigningKey(data, []byte(environMap(req.Environ)["COSIGN_PASSWORD"]))
+		if err != nil {
+			return false, errors.Wrap(err, "load signing key")
+		}
+	}
+	if params.Offline {
+		log.Info().Msg("checking the artifacts needed to build offline")
+		if missing := checkOffline(ctx, app, req, platforms); len(missing) > 0 {
+			return false, &missingArtifactsError{Missing: missing}
+		}
+	}
+
+	var (
+		targets []imageTarget
+		addenda [][]mutate.IndexAddendum // the images of each target, for each platform
+		specs   []*dockerbuild.ImageSpec // the image spec of each target
+	)
+	for _, p := range platforms {
+		compiled, err := compileApp(ctx, app, req, p, log)
+		if err != nil {
+			return false, err
+		}
+		if targets == nil {
+			targets = imageTargets(compiled.meta, params.PerService)
+			if len(targets) == 0 {
+				compiled.cleanup()
+				return false, errors.New("the app has no services or gateways to build images for")
+			}
+			addenda = make([][]mutate.IndexAddendum, len(targets))
+			specs = make([]*dockerbuild.ImageSpec, len(targets))
+		}
+
+		for i, target := range targets {
+			img, spec, err := buildImage(ctx, compiled, params, target, log)
+			if err != nil {
+				compiled.cleanup()
+				return false, err
+			}
+			specs[i] = spec
+			addenda[i] = append(addenda[i], mutate.IndexAddendum{
+				Add: img,
+				Descriptor: v1.Descriptor{
+					Platform: &v1.Platform{OS: p.OS, Architecture: p.Architecture, Variant: p.Variant},
+				},
+			})
+			if compiled.sbom != nil {
+				att, err := dockerbuild.SBOMAttestation(img, compiled.sbom)
+				if err != nil {
+					compiled.cleanup()
+					return false, errors.Wrap(err, "attach sbom")
+				}
+				addenda[i] = append(addenda[i], att)
+			}
+		}
+		compiled.cleanup()
+	}
+
+	manifest := &dockerbuild.ImageManifestFile{}
+	for i, target := range targets {
+		ref, ok := saveImage(ctx, params, push, target, addenda[i], platforms, log)
+		if !ok {
+			return false, nil
+		}
+		manifest.Manifests = append(manifest.Manifests, &dockerbuild.ImageManifest{
+			Reference:       ref,
+			BundledServices: specs[i].BundledServices,
+			BundledGateways: specs[i].BundledGateways,
+			FeatureFlags:    specs[i].FeatureFlags,
+		})
+	}
+
+	if params.DigestFile != "" {
+		var refs strings.Builder
+		for _, m := range manifest.Manifests {
+			refs.WriteString(m.Reference + "\n")
+		}
+		if err := os.WriteFile(params.DigestFile, []byte(refs.String()), 0644); err != nil {
+			log.Error().Err(err).Msg("unable to write image digests")
+			return false, nil
+		}
+		log.Info().Msgf("wrote image digests to %s", params.DigestFile)
+	}
+
+	if params.ManifestPath != "" {
+		data, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return false, errors.WithStack(err)
+		}
+		if err := os.WriteFile(params.ManifestPath, append(data, '\n'), 0644); err != nil {
+			log.Error().Err(err).Msg("unable to write image manifest")
+			return false, nil
+		}
+		log.Info().Msgf("wrote image manifest to %s", params.ManifestPath)
+	}
+
+	return true, nil
+}
+
+// imageTarget is an image to build.
+type imageTarget struct {
+	// Name is the name of the service or gateway the image hosts,
+	// or empty if the image hosts the whole app.
+	Name string
+
+	// Services and Gateways are what the image hosts. If both are
+	// empty the image hosts the whole app.
+	Services []string
+	Gateways []string
+}
+
+// imageTargets returns the images to build for the app: a single image
+// hosting the whole app, or an image for each service and gateway.
+func imageTargets(md *meta.Data, perService bool) []imageTarget {
+	if !perService {
+		return []imageTarget{{}}
+	}
+	var targets []imageTarget
+	for _, svc := range md.Svcs {
+		targets = append(targets, imageTarget{Name: svc.Name, Services: []string{svc.Name}})
+	}
+	for _, gw := range md.Gateways {
+		targets = append(targets, imageTarget{Name: gw.EncoreName, Gateways: []string{gw.EncoreName}})
+	}
+	return targets
+}
+
+// Tag returns the tag of the target's image, given the tag of the app's image.
+// For services and gateways "-<name>" is appended to the repository of the tag.
+func (t imageTarget) Tag(appTag string) string {
+	if t.Name == "" {
+		return appTag
+	}
+	repo, version := appTag, ""
+	if i := strings.LastIndex(appTag, ":"); i > strings.LastIndex(appTag, "/") {
+		repo, version = appTag[:i], appTag[i:]
+	}
+	return repo + "-" + t.Name + version
+}
+
+// tagPlaceholderRegex matches the placeholders of image tags.
+var tagPlaceholderRegex = regexp.MustCompile(`\{[^{}]*\}`)
+
+// expandTags expands the placeholders in the tags of the image:
+// {version}, the app's commit shortened, suffixed by "-dirty" if it has
+// uncommitted changes, {commit}, the full commit, and {date}, the build date.
+func expandTags(params *daemonpb.DockerExportParams, appRoot string, now time.Time) error {
+	var (
+		rev     vcs.Status
+		haveRev bool
+		err     error
+	)
+	expand := func(tag string) string {
+		return tagPlaceholderRegex.ReplaceAllStringFunc(tag, func(placeholder string) string {
+			switch placeholder {
+			case "{date}":
+				return now.UTC().Format("20060102")
+			case "{version}", "{commit}":
+				if !haveRev {
+					rev, haveRev = vcs.GetRevision(appRoot), true
+				}
+				if rev.Revision == "" {
+					err = errors.Newf("invalid image tag %q: %s needs the app to be in a version control repository", tag, placeholder)
+					return placeholder
+				}
+				if placeholder == "{commit}" {
+					return rev.Revision
+				}
+				version := rev.Revision[:min(len(rev.Revision), 12)]
+				if rev.Uncommitted {
+					version += "-dirty"
+				}
+				return version
+			default:
+				err = errors.Newf("invalid image tag %q: unknown placeholder %s, use {version}, {commit} or {date}", tag, placeholder)
+				return placeholder
+			}
+		})
+	}
+
+	params.LocalDaemonTag = expand(params.LocalDaemonTag)
+	params.PushDestinationTag = expand(params.PushDestinationTag)
+	params.OciLayoutRefName = expand(params.OciLayoutRefName)
+	for i, tag := range params.AdditionalTags {
+		params.AdditionalTags[i] = expand(tag)
+	}
+	return err
+}
+
+// pushOptions configure how images are pushed.
+type pushOptions struct {
+	// keychain authenticates to registries.
+	keychain authn.Keychain
+
+	// signer, if non-nil, signs the pushed images.
+	signer crypto.Signer
+}
+
+// saveImage saves the images of a target, built for each platform, to the local
+// docker daemon, a docker registry and an OCI layout, as requested by params.
+// It reports the reference of the saved image, and whether it succeeded.
+func saveImage(ctx context.Context, params *daemonpb.DockerExportParams, push pushOptions, target imageTarget, addenda []mutate.IndexAddendum, platforms []v1.Platform, log zerolog.Logger) (ref string, ok bool) {
+	img := addenda[0].Add.(v1.Image)
+	index := mutate.AppendManifests(empty.Index, addenda...)
+	if target.Name != "" {
+		log = log.With().Str("image", target.Name).Logger()
+	}
+
+	if params.LocalDaemonTag != "" {
+		ref = target.Tag(params.LocalDaemonTag)
+		tag, err := name.NewTag(ref, name.WeakValidation)
+		if err != nil {
+			log.Error().Err(err).Msg("invalid image tag")
+			return "", false
+		}
+		log.Info().Msg("saving image to local docker daemon")
+
+		_, err = daemon.Write(tag, img, daemon.WithUnbufferedOpener())
+		if err != nil {
+			log.Error().Err(err).Msg("unable to save docker image")
+			return "", false
+		}
+		log.Info().Msg("successfully saved local docker image")
+
+		for _, extra := range params.AdditionalTags {
+			extraTag, err := name.NewTag(target.Tag(extra), name.WeakValidation)
+			if err != nil {
+				log.Error().Err(err).Msg("invalid image tag")
+				return "", false
+			}
+			if err := daemon.Tag(tag, extraTag); err != nil {
+				log.Error().Err(err).Msgf("unable to tag docker image as %s", extraTag)
+				return "", false
+			}
+		}
+	}
+
+	if params.PushDestinationTag != "" {
+		var (
+			digest v1.Hash
+			err    error
+		)
+		if len(addenda) > 1 {
+			digest, err = index.Digest()
+		} else {
+			digest, err = img.Digest()
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("unable to compute image digest")
+			return "", false
+		}
+
+		signed := make(map[string]bool) // the repositories the image is signed in
+		for i, t := range append([]string{params.PushDestinationTag}, params.AdditionalTags...) {
+			tag, err := name.NewTag(target.Tag(t), name.WeakValidation)
+			if err != nil {
+				log.Error().Err(err).Msg("invalid image tag")
+				return "", false
+			}
+			log.Info().Msgf("pushing image to docker registry as %s", tag)
+			if len(addenda) > 1 {
+				err = pushDockerIndex(ctx, log, push.keychain, index, tag)
+			} else {
+				err = pushDockerImage(ctx, log, push.keychain, img, tag)
+			}
+			if err != nil {
+				log.Error().Err(err).Msg("unable to push docker image")
+				return "", false
+			}
+			digestRef := tag.Context().Digest(digest.String()).String()
+			if i == 0 {
+				ref = digestRef
+			}
+			log.Info().Msgf("pushed image %s", digestRef)
+
+			if repo := tag.Context(); push.signer != nil && !signed[repo.String()] {
+				sig, err := dockerbuild.SignatureImage(push.signer, repo, digest)
+				if err == nil {
+					err = pushDockerImage(ctx, log, push.keychain, sig, dockerbuild.SignatureTag(repo, digest))
+				}
+				if err != nil {
+					log.Error().Err(err).Msg("unable to sign docker image")
+					return "", false
+				}
+				signed[repo.String()] = true
+				log.Info().Msgf("signed image %s", digestRef)
+			}
+		}
+	}
+
+	if params.OciLayoutPath != "" {
+		log.Info().Msgf("writing image to OCI layout %s", params.OciLayoutPath)
+		var refName string
+		if params.OciLayoutRefName != "" {
+			refName = target.Tag(params.OciLayoutRefName)
+			if ref == "" {
+				ref = refName
+			}
+		}
+		var layoutIndex v1.ImageIndex
+		if len(addenda) > 1 {
+			layoutIndex = index
+		}
+		if err := writeOCILayout(params.OciLayoutPath, refName, img, layoutIndex, platforms); err != nil {
+			log.Error().Err(err).Msg("unable to write OCI layout")
+			return "", false
+		}
+		log.Info().Msg("successfully wrote OCI layout")
+	}
+
+	return ref, true
+}
+
+// ociRefNameAnnotation is the annotation naming an image in an OCI layout.
+const ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+// writeOCILayout writes the image to the OCI layout at path, annotated with
+// refName if it's non-empty, creating the layout if it doesn't exist. If index is
+// non-nil, as for multi-platform images and images with attestations, it's written
+// instead of img as a nested image index, so the layout can hold several images.
+func writeOCILayout(path, refName string, img v1.Image, index v1.ImageIndex, platforms []v1.Platform) error {
+	lp, err := layout.FromPath(path)
+	if err != nil {
+		lp, err = layout.Write(path, empty.Index)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	var opts []layout.Option
+	if refName != "" {
+		opts = append(opts, layout.WithAnnotations(map[string]string{
+			ociRefNameAnnotation: refName,
+		}))
+	}
+	if index != nil {
+		return errors.WithStack(lp.AppendIndex(index, opts...))
+	}
+
+	opts = append(opts, layout.WithPlatform(platforms[0]))
+	return errors.WithStack(lp.AppendImage(img, opts...))
+}
+
+// parsePlatforms returns the platforms to build the image for.
+func parsePlatforms(req *daemonpb.ExportRequest, params *daemonpb.DockerExportParams) ([]v1.Platform, error) {
+	if len(params.Platforms) == 0 {
+		return []v1.Platform{{OS: req.Goos, Architecture: req.Goarch}}, nil
+	}
+
+	platforms := make([]v1.Platform, 0, len(params.Platforms))
+	seen := make(map[string]bool)
+	for _, s := range params.Platforms {
+		p, err := v1.ParsePlatform(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid platform %q", s)
+		} else if p.OS == "" || p.Architecture == "" {
+			return nil, errors.Newf("invalid platform %q: must be in the format os/arch", s)
+		}
+		if seen[p.String()] {
+			continue
+		}
+		seen[p.String()] = true
+		platforms = append(platforms, *p)
+	}
+	return platforms, nil
+}
+
+// compiledApp is the app compiled for a platform, along with
+// the platform-specific files to add to its images.
+type compiledApp struct {
+	app      *apps.Instance
+	platform v1.Platform
+	meta     *meta.Data
+	result   *builder.CompileResult
+
+	// buildInfo describes the build, and is recorded in the images.
+	buildInfo dockerbuild.BuildInfo
+
+	// sbom is the software bill of materials to attach to the images, if any.
+	sbom *dockerbuild.SBOM
+
+	crossNodeRuntime option.Option[dockerbuild.HostPath]
+	healthCheck      option.Option[dockerbuild.HealthCheck]
+	supervisorPath   option.Option[dockerbuild.HostPath]
+	baseImage        option.Option[v1.Image]
+
+	// cleanup removes the temporary files of the compiled app.
+	cleanup func()
+}
+
+// compileApp compiles the app for the given platform.
+func compileApp(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest, platform v1.Platform, log zerolog.Logger) (compiled *compiledApp, err error) {
+	params := req.GetDocker()
 	expSet, err := app.Experiments(req.Environ)
 	if err != nil {
-		return false, errors.Wrap(err, "get experimental features")
+		return nil, errors.Wrap(err, "get experimental features")
 	}
 
+	goos, goarch := platform.OS, platform.Architecture
 	vcsRevision := vcs.GetRevision(app.Root())
+	environ := req.Environ
+	if params.Offline {
+		environ = append(slices.Clip(environ), offlineEnviron...)
+	}
 	buildInfo := builder.BuildInfo{
 		BuildTags:          []string{"timetzdata"},
 		CgoEnabled:         req.CgoEnabled,
 		StaticLink:         true,
 		DebugMode:          builder.DebugModeDisabled,
-		Environ:            req.Environ,
-		GOOS:               req.Goos,
-		GOARCH:             req.Goarch,
+		Environ:            environ,
+		GOOS:               goos,
+		GOARCH:             goarch,
 		KeepOutput:         false,
 		Revision:           vcsRevision.Revision,
 		UncommittedChanges: vcsRevision.Uncommitted,
 	}
+	if len(params.InfraConfig) > 0 && app.Lang() == appfile.LangGo {
+		// Leave out the drivers of infrastructure the environment doesn't use.
+		tags, err := infraconf.DriverBuildTags(params.InfraConfig)
+		if err != nil {
+			return nil, err
+		}
+		buildInfo.BuildTags = append(buildInfo.BuildTags, tags...)
+		log.Info().Strs("tags", tags).Msg("leaving out unused infrastructure drivers")
+	}
+	if params.GoRoot != "" {
+		buildInfo.GoRoot = option.Some(paths.RootedFSPath(params.GoRoot, "."))
+	}
+	if params.RuntimesPath != "" {
+		buildInfo.EncoreRuntimes = option.Some(paths.FS(params.RuntimesPath))
+	}
 
 	bld := builderimpl.Resolve(app.Lang(), expSet)
 	defer fns.CloseIgnore(bld)
@@ -62,11 +505,11 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		ParseTests:  false,
 	})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	if err := app.CacheMetadata(parse.Meta); err != nil {
 		log.Info().Err(err).Msg("failed to cache metadata")
-		return false, errors.Wrap(err, "cache metadata")
+		return nil, errors.Wrap(err, "cache metadata")
 	}
 
 	// Validate the service configs.
@@ -81,10 +524,10 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 		},
 	})
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	log.Info().Msgf("compiling Encore application for %s/%s", req.Goos, req.Goarch)
+	log.Info().Msgf("compiling Encore application for %s/%s", goos, goarch)
 	result, err := bld.Compile(ctx, builder.CompileParams{
 		Build:       buildInfo,
 		App:         app,
@@ -96,88 +539,131 @@ func Docker(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest
 
 	if err != nil {
 		log.Info().Err(err).Msg("compilation failed")
-		return false, errors.Wrap(err, "compilation failed")
+		return nil, errors.Wrap(err, "compilation failed")
+	}
+
+	compiled = &compiledApp{
+		app:      app,
+		platform: platform,
+		meta:     parse.Meta,
+		result:   result,
+		buildInfo: dockerbuild.BuildInfo{
+			EncoreCompiler: version.Version,
+			AppCommit: dockerbuild.CommitInfo{
+				Revision:    vcsRevision.Revision,
+				Uncommitted: vcsRevision.Uncommitted,
+			},
+			APIHash: dockerbuild.APIHash(parse.Meta),
+		},
+		cleanup: func() {},
+	}
+
+	// Attestations can only be stored in image indexes, which the local docker daemon can't store.
+	if params.Sbom && (params.PushDestinationTag != "" || params.OciLayoutPath != "") {
+		sbom, err := dockerbuild.GenerateSBOM(app.PlatformOrLocalID(), result, time.Now(), "encore-"+version.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "generate sbom")
+		}
+		compiled.sbom = sbom
 	}
 
-	var crossNodeRuntime option.Option[dockerbuild.HostPath]
 	if app.Lang() == appfile.LangTS && buildInfo.IsCrossBuild() {
-		binary, err := downloadBinary(req.Goos, req.Goarch, "encore-runtime.node", log)
+		binary, err := prebuiltBinary(params, goos, goarch, "encore-runtime.node", log)
 		if err != nil {
-			return false, errors.Wrap(err, "download runtime binaries")
+			return nil, errors.Wrap(err, "download runtime binaries")
 		}
-		crossNodeRuntime = option.Some(binary)
+		compiled.crossNodeRuntime = option.Some(binary)
 	}
 
+	if hc := params.Healthcheck; hc != nil {
+		probeDir, err := os.MkdirTemp("", "encore-healthcheck")
+		if err != nil {
+			return nil, errors.Wrap(err, "create temp dir")
+		}
+		compiled.cleanup = func() { _ = os.RemoveAll(probeDir) }
+
+		log.Info().Msgf("building health probe for %s/%s", goos, goarch)
+		goroot, ok := goRoot(params)
+		if !ok {
+			goroot = env.EncoreGoRoot()
+		}
+		probe, err := dockerbuild.BuildHealthProbe(ctx, goroot, goos, goarch, dockerbuild.HostPath(probeDir))
+		if err != nil {
+			compiled.cleanup()
+			return nil, errors.Wrap(err, "build health probe")
+		}
+		compiled.healthCheck = option.Some(dockerbuild.HealthCheck{
+			ProbePath:   probe,
+			Interval:    time.Duration(hc.IntervalSeconds) * time.Second,
+			Timeout:     time.Duration(hc.TimeoutSeconds) * time.Second,
+			StartPeriod: time.Duration(hc.StartPeriodSeconds) * time.Second,
+			Retries:     int(hc.Retries),
+		})
+	}
+	return compiled, nil
+}
+
+// buildImage builds the docker image of the target from the compiled app,
+// returning it along with its spec.
+func buildImage(ctx context.Context, compiled *compiledApp, params *daemonpb.DockerExportParams, target imageTarget, log zerolog.Logger) (v1.Image, *dockerbuild.ImageSpec, error) {
+	runtimes, ok := runtimesPath(params)
+	if !ok {
+		runtimes = env.EncoreRuntimesPath()
+	}
 	spec, err := dockerbuild.Describe(dockerbuild.DescribeConfig{
-		Meta:              parse.Meta,
-		Compile:           result,
+		Meta:              compiled.meta,
+		Compile:           compiled.result,
 		BundleSource:      option.Option[dockerbuild.BundleSourceSpec]{},
 		DockerBaseImage:   option.AsOptional(params.BaseImageTag),
-		Runtimes:          dockerbuild.HostPath(env.EncoreRuntimesPath()),
-		NodeRuntime:       crossNodeRuntime,
-		ProcessPerService: app.ProcessPerService(),
+		Runtimes:          dockerbuild.HostPath(runtimes),
+		NodeRuntime:       compiled.crossNodeRuntime,
+		BuildInfo:         compiled.buildInfo,
+		ProcessPerService: compiled.app.ProcessPerService(),
+		HostedServices:    target.Services,
+		HostedGateways:    target.Gateways,
 	})
 	if err != nil {
-		return false, errors.Wrap(err, "describe docker image")
+		return nil, nil, errors.Wrap(err, "describe docker image")
 	}
 
+	// The base image and supervisor are the same for all images of the platform.
 	var baseImgOverride option.Option[v1.Image]
 	if params.BaseImageTag != "" {
-		baseImg, err := resolveBaseImage(ctx, log, params, spec)
-		if err != nil {
-			return false, errors.Wrap(err, "resolve base image")
+		if !compiled.baseImage.Present() {
+			baseImg, err := resolveBaseImage(ctx, log, params, spec)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "resolve base image")
+			}
+			compiled.baseImage = option.Some(baseImg)
 		}
-		baseImgOverride = option.Some(baseImg)
+		baseImgOverride = compiled.baseImage
 	}
 
 	var supervisorPath option.Option[dockerbuild.HostPath]
 	if spec.Supervisor.Present() {
-		binary, err := downloadBinary(req.Goos, req.Goarch, "supervisor-encore", log)
-		if err != nil {
-			return false, errors.Wrap(err, "download supervisor binaries")
+		if !compiled.supervisorPath.Present() {
+			binary, err := prebuiltBinary(params, spec.OS, spec.Arch, "supervisor-encore", log)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "download supervisor binaries")
+			}
+			compiled.supervisorPath = option.Some(binary)
 		}
-		supervisorPath = option.Some(binary)
+		supervisorPath = compiled.supervisorPath
 	}
+
 	img, err := dockerbuild.BuildImage(ctx, spec, dockerbuild.ImageBuildConfig{
 		BuildTime:         time.Now(),
 		BaseImageOverride: baseImgOverride,
 		AddCACerts:        option.Some[dockerbuild.ImagePath](""),
+		ExtraCACerts:      fns.Map(params.CaCertPaths, func(p string) dockerbuild.HostPath { return dockerbuild.HostPath(p) }),
 		SupervisorPath:    supervisorPath,
+		User:              option.AsOptional(params.User),
+		HealthCheck:       compiled.healthCheck,
 	})
 	if err != nil {
-		return false, errors.Wrap(err, "build docker image")
-	}
-
-	if params.LocalDaemonTag != "" {
-		tag, err := name.NewTag(params.LocalDaemonTag, name.WeakValidation)
-		if err != nil {
-			log.Error().Err(err).Msg("invalid image tag")
-			return false, nil
-		}
-		log.Info().Msg("saving image to local docker daemon")
-
-		_, err = daemon.Write(tag, img, daemon.WithUnbufferedOpener())
-		if err != nil {
-			log.Error().Err(err).Msg("unable to save docker image")
-			return false, nil
-		}
-		log.Info().Msg("successfully saved local docker image")
+		return nil, nil, errors.Wrap(err, "build docker image")
 	}
-
-	if params.PushDestinationTag != "" {
-		tag, err := name.NewTag(params.PushDestinationTag, name.WeakValidation)
-		if err != nil {
-			log.Error().Err(err).Msg("invalid image tag")
-			return false, nil
-		}
-		log.Info().Msg("pushing image to docker registry")
-		if err := pushDockerImage(ctx, log, img, tag); err != nil {
-			log.Error().Err(err).Msg("unable to push docker image")
-			return false, nil
-		}
-	}
-
-	return true, nil
+	return img, spec, nil
 }
 
 func resolveBaseImage(ctx context.Context, log zerolog.Logger, p *daemonpb.DockerExportParams, spec *dockerbuild.ImageSpec) (v1.Image, error) {
@@ -201,6 +687,9 @@ func resolveBaseImage(ctx context.Context, log zerolog.Logger, p *daemonpb.Docke
 			fetchRemote = file.OS != spec.OS || file.Architecture != spec.Arch
 		}
 	}
+	if fetchRemote && p.Offline {
+		return nil, errors.Newf("base image %s for %s/%s not found in the local docker daemon, and the build is offline", baseImgTag, spec.OS, spec.Arch)
+	}
 	if fetchRemote {
 		log.Info().Msg("could not get image from local daemon, fetching it remotely")
 		keychain := authn.DefaultKeychain
@@ -227,12 +716,20 @@ func resolveBaseImage(ctx context.Context, log zerolog.Logger, p *daemonpb.Docke
 	return img, nil
 }
 
-func pushDockerImage(ctx context.Context, log zerolog.Logger, img v1.Image, destination name.Tag) error {
+func pushDockerImage(ctx context.Context, log zerolog.Logger, keychain authn.Keychain, img v1.Image, destination name.Tag) error {
 	log.Info().Msg("pushing docker image to container registry")
-	keychain := authn.DefaultKeychain
 	if err := remote.Write(destination, img, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)); err != nil {
 		return errors.WithStack(err)
 	}
 	log.Info().Msg("successfully pushed docker image")
 	return nil
 }
+
+func pushDockerIndex(ctx context.Context, log zerolog.Logger, keychain authn.Keychain, index v1.ImageIndex, destination name.Tag) error {
+	log.Info().Msg("pushing multi-platform docker image to container registry")
+	if err := remote.WriteIndex(destination, index, remote.WithAuthFromKeychain(keychain), remote.WithContext(ctx)); err != nil {
+		return errors.WithStack(err)
+	}
+	log.Info().Msg("successfully pushed docker image")
+	return nil
+}
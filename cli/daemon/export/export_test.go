@@ -0,0 +1,222 @@
+package export
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/rs/zerolog"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestParsePlatforms(t *testing.T) {
+	c := qt.New(t)
+	req := &daemonpb.ExportRequest{Goos: "linux", Goarch: "arm64"}
+
+	got, err := parsePlatforms(req, &daemonpb.DockerExportParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, []v1.Platform{{OS: "linux", Architecture: "arm64"}})
+
+	got, err = parsePlatforms(req, &daemonpb.DockerExportParams{
+		Platforms: []string{"linux/amd64", "linux/arm64", "linux/amd64"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	})
+
+	_, err = parsePlatforms(req, &daemonpb.DockerExportParams{Platforms: []string{"linux"}})
+	c.Assert(err, qt.ErrorMatches, `invalid platform "linux": must be in the format os/arch`)
+}
+
+func TestWriteOCILayout(t *testing.T) {
+	c := qt.New(t)
+	platforms := []v1.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	}
+	var addenda []mutate.IndexAddendum
+	for _, p := range platforms {
+		img, err := random.Image(64, 1)
+		c.Assert(err, qt.IsNil)
+		addenda = append(addenda, mutate.IndexAddendum{
+			Add:        img,
+			Descriptor: v1.Descriptor{Platform: &p},
+		})
+	}
+	index := mutate.AppendManifests(empty.Index, addenda...)
+
+	path := t.TempDir()
+	c.Assert(writeOCILayout(path, "example.com/app:v1", addenda[0].Add.(v1.Image), index, platforms), qt.IsNil)
+
+	lp, err := layout.FromPath(path)
+	c.Assert(err, qt.IsNil)
+	root, err := lp.ImageIndex()
+	c.Assert(err, qt.IsNil)
+	manifest, err := root.IndexManifest()
+	c.Assert(err, qt.IsNil)
+	c.Assert(manifest.Manifests, qt.HasLen, 1)
+	c.Assert(manifest.Manifests[0].Annotations[ociRefNameAnnotation], qt.Equals, "example.com/app:v1")
+
+	nested, err := root.ImageIndex(manifest.Manifests[0].Digest)
+	c.Assert(err, qt.IsNil)
+	nestedManifest, err := nested.IndexManifest()
+	c.Assert(err, qt.IsNil)
+	c.Assert(nestedManifest.Manifests, qt.HasLen, 2)
+	for i, desc := range nestedManifest.Manifests {
+		c.Assert(*desc.Platform, qt.DeepEquals, platforms[i])
+	}
+
+	// Writing a single-platform image appends it to the existing layout.
+	c.Assert(writeOCILayout(path, "example.com/app:v2", addenda[1].Add.(v1.Image), nil, platforms[1:]), qt.IsNil)
+	root, err = lp.ImageIndex()
+	c.Assert(err, qt.IsNil)
+	manifest, err = root.IndexManifest()
+	c.Assert(err, qt.IsNil)
+	c.Assert(manifest.Manifests, qt.HasLen, 2)
+	c.Assert(*manifest.Manifests[1].Platform, qt.DeepEquals, platforms[1])
+}
+
+func TestImageTargets(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs:     []*meta.Service{{Name: "orders"}, {Name: "emails"}},
+		Gateways: []*meta.Gateway{{EncoreName: "api-gateway"}},
+	}
+
+	c.Assert(imageTargets(md, false), qt.DeepEquals, []imageTarget{{}})
+	c.Assert(imageTargets(md, true), qt.DeepEquals, []imageTarget{
+		{Name: "orders", Services: []string{"orders"}},
+		{Name: "emails", Services: []string{"emails"}},
+		{Name: "api-gateway", Gateways: []string{"api-gateway"}},
+	})
+}
+
+func TestImageTargetTag(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		target imageTarget
+		appTag string
+		want   string
+	}{
+		{imageTarget{}, "registry.example.com/shop:v1", "registry.example.com/shop:v1"},
+		{imageTarget{Name: "orders"}, "registry.example.com/shop:v1", "registry.example.com/shop-orders:v1"},
+		{imageTarget{Name: "orders"}, "localhost:5000/shop", "localhost:5000/shop-orders"},
+		{imageTarget{Name: "api-gateway"}, "shop:latest", "shop-api-gateway:latest"},
+	}
+	for _, test := range tests {
+		c.Assert(test.target.Tag(test.appTag), qt.Equals, test.want)
+	}
+}
+
+func TestMissingModules(t *testing.T) {
+	c := qt.New(t)
+	goroot := runtime.GOROOT()
+	runtimeDir, err := filepath.Abs("../../../runtimes/go")
+	c.Assert(err, qt.IsNil)
+
+	appRoot := c.TempDir()
+	c.Assert(os.WriteFile(filepath.Join(appRoot, "go.mod"), []byte("module app\n\ngo 1.22\n"), 0644), qt.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(appRoot, "app.go"), []byte("package app\n\nimport _ \"encore.dev/rlog\"\n"), 0644), qt.IsNil)
+
+	// An empty module cache has none of the runtime's dependencies.
+	emptyCache := c.TempDir()
+	missing, err := missingModules(context.Background(), appRoot, goroot, runtimeDir, []string{"GOMODCACHE=" + emptyCache})
+	c.Assert(err, qt.IsNil)
+	var names []string
+	for _, m := range missing {
+		names = append(names, m.What)
+	}
+	c.Assert(strings.Join(names, "\n"), qt.Contains, "the Go module github.com/rs/zerolog@")
+}
+
+func TestMissingArtifactsError(t *testing.T) {
+	c := qt.New(t)
+	err := &missingArtifactsError{Missing: []missingArtifact{
+		{"the Encore Go toolchain", "/opt/encore-go"},
+		{"the linux/arm64 supervisor-encore binary", "/bin/linux-arm64/supervisor-encore"},
+	}}
+	c.Assert(err.Error(), qt.Equals, `the offline build is missing 2 artifact(s):
+  - the Encore Go toolchain (looked in /opt/encore-go)
+  - the linux/arm64 supervisor-encore binary (looked in /bin/linux-arm64/supervisor-encore)`)
+}
+
+func TestExpandTags(t *testing.T) {
+	c := qt.New(t)
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	params := &daemonpb.DockerExportParams{
+		PushDestinationTag: "registry.internal/app:{date}",
+		AdditionalTags:     []string{"registry.internal/app:latest"},
+	}
+	c.Assert(expandTags(params, c.TempDir(), now), qt.IsNil)
+	c.Assert(params.PushDestinationTag, qt.Equals, "registry.internal/app:20240304")
+	c.Assert(params.AdditionalTags, qt.DeepEquals, []string{"registry.internal/app:latest"})
+
+	// The commit can't be determined outside a repository.
+	params = &daemonpb.DockerExportParams{LocalDaemonTag: "app:{version}"}
+	c.Assert(expandTags(params, c.TempDir(), now), qt.ErrorMatches,
+		`invalid image tag "app:{version}": {version} needs the app to be in a version control repository`)
+
+	params = &daemonpb.DockerExportParams{LocalDaemonTag: "app:{branch}"}
+	c.Assert(expandTags(params, c.TempDir(), now), qt.ErrorMatches,
+		`invalid image tag "app:{branch}": unknown placeholder {branch}.*`)
+}
+
+func TestSaveImagePush(t *testing.T) {
+	c := qt.New(t)
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	img, err := random.Image(64, 1)
+	c.Assert(err, qt.IsNil)
+	digest, err := img.Digest()
+	c.Assert(err, qt.IsNil)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, qt.IsNil)
+
+	params := &daemonpb.DockerExportParams{
+		PushDestinationTag: host + "/shop:v1",
+		AdditionalTags:     []string{host + "/shop:latest"},
+	}
+	push := pushOptions{keychain: authn.DefaultKeychain, signer: key}
+	addenda := []mutate.IndexAddendum{{Add: img}}
+	ref, ok := saveImage(context.Background(), params, push, imageTarget{}, addenda, nil, zerolog.Nop())
+	c.Assert(ok, qt.IsTrue)
+	c.Assert(ref, qt.Equals, host+"/shop@"+digest.String())
+
+	// The image is pushed with each tag, and signed once.
+	for _, tag := range []string{"v1", "latest", "sha256-" + digest.Hex + ".sig"} {
+		ref, err := name.ParseReference(host + "/shop:" + tag)
+		c.Assert(err, qt.IsNil)
+		desc, err := remote.Head(ref)
+		c.Assert(err, qt.IsNil)
+		if !strings.HasSuffix(tag, ".sig") {
+			c.Assert(desc.Digest, qt.Equals, digest)
+		}
+	}
+}
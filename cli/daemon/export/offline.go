@@ -0,0 +1,289 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	"github.com/rs/zerolog"
+	"golang.org/x/mod/modfile"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/internal/conf"
+	"encr.dev/internal/env"
+	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/dockerbuild"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// offlineEnviron is the environment of offline builds, making the go command
+// use the module cache only. The checksum database is disabled as well, as it
+// can't be reached; the cached modules were verified when they were downloaded.
+var offlineEnviron = []string{
+	"GOPROXY=off",
+	"GOSUMDB=off",
+	"GOFLAGS=-mod=mod",
+}
+
+// missingArtifact is an artifact an offline build needs that isn't available.
+type missingArtifact struct {
+	// What describes the artifact, and Where it was looked for.
+	What, Where string
+}
+
+func (a missingArtifact) String() string {
+	return a.What + " (looked in " + a.Where + ")"
+}
+
+// missingArtifactsError reports the artifacts an offline build is missing.
+type missingArtifactsError struct {
+	Missing []missingArtifact
+}
+
+func (e *missingArtifactsError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "the offline build is missing %d artifact(s):", len(e.Missing))
+	for _, a := range e.Missing {
+		b.WriteString("\n  - " + a.String())
+	}
+	return b.String()
+}
+
+// goRoot returns the Encore Go toolchain to build with, if any.
+func goRoot(params *daemonpb.DockerExportParams) (string, bool) {
+	if params.GoRoot != "" {
+		return params.GoRoot, true
+	}
+	return env.OptEncoreGoRoot().Get()
+}
+
+// runtimesPath returns the Encore runtimes to build with, if any.
+func runtimesPath(params *daemonpb.DockerExportParams) (string, bool) {
+	if params.RuntimesPath != "" {
+		return params.RuntimesPath, true
+	}
+	return env.OptEncoreRuntimesPath().Get()
+}
+
+// checkOffline checks that the artifacts needed to build the app's images
+// are available without network access, returning those that aren't.
+//
+// The supervisor is only checked for apps running a process per service,
+// as whether other apps need it is only known once they're compiled.
+func checkOffline(ctx context.Context, app *apps.Instance, req *daemonpb.ExportRequest, platforms []v1.Platform) []missingArtifact {
+	params := req.GetDocker()
+	var missing []missingArtifact
+
+	goroot, hasGoRoot := goRoot(params)
+	if !hasGoRoot {
+		missing = append(missing, missingArtifact{"the Encore Go toolchain", "--goroot and ENCORE_GOROOT, which are unset"})
+	} else if !exists(filepath.Join(goroot, "bin", "go"+exeSuffix())) {
+		missing = append(missing, missingArtifact{"the Encore Go toolchain", goroot})
+		hasGoRoot = false
+	}
+
+	runtimes, hasRuntimes := runtimesPath(params)
+	if !hasRuntimes {
+		missing = append(missing, missingArtifact{"the Encore runtimes", "--runtimes and ENCORE_RUNTIMES_PATH, which are unset"})
+	} else {
+		var dirs []string
+		if app.Lang() == appfile.LangTS {
+			dirs = []string{filepath.Join("js", "encore.dev"), filepath.Join("js", "encore-runtime.node")}
+		} else {
+			dirs = []string{"go"}
+		}
+		for _, dir := range dirs {
+			if !exists(filepath.Join(runtimes, dir)) {
+				missing = append(missing, missingArtifact{"the Encore runtime " + filepath.ToSlash(dir), runtimes})
+				hasRuntimes = false
+			}
+		}
+	}
+
+	if app.Lang() == appfile.LangGo && hasGoRoot && hasRuntimes {
+		modules, err := missingModules(ctx, app.Root(), goroot, filepath.Join(runtimes, "go"), req.Environ)
+		if err != nil {
+			missing = append(missing, missingArtifact{"the app's Go modules: " + err.Error(), "the module cache"})
+		}
+		missing = append(missing, modules...)
+	}
+
+	for _, p := range platforms {
+		var binaries []string
+		if app.Lang() == appfile.LangTS && (p.OS != runtime.GOOS || p.Architecture != runtime.GOARCH) {
+			binaries = append(binaries, "encore-runtime.node")
+		}
+		if app.ProcessPerService() {
+			binaries = append(binaries, "supervisor-encore")
+		}
+		for _, binary := range binaries {
+			if _, err := findBinary(params.BinariesPath, p.OS, p.Architecture, binary); err != nil {
+				missing = append(missing, missingArtifact{
+					What:  fmt.Sprintf("the %s/%s %s binary", p.OS, p.Architecture, binary),
+					Where: strings.Join(binaryPaths(params.BinariesPath, p.OS, p.Architecture, binary), ", "),
+				})
+			}
+		}
+	}
+
+	if base := params.BaseImageTag; base != "" && base != "scratch" {
+		for _, p := range platforms {
+			if err := checkLocalImage(base, p); err != nil {
+				missing = append(missing, missingArtifact{
+					What:  fmt.Sprintf("the %s/%s base image %s", p.OS, p.Architecture, base),
+					Where: "the local Docker daemon: " + err.Error(),
+				})
+			}
+		}
+	}
+	return missing
+}
+
+// missingModules returns the Go modules in the app's module graph that aren't
+// in the module cache, by downloading them with the module proxy disabled.
+// The app's go.mod is amended with the Encore runtime like when building it.
+func missingModules(ctx context.Context, appRoot, goroot, runtimeDir string, environ []string) ([]missingArtifact, error) {
+	modData, err := os.ReadFile(filepath.Join(appRoot, "go.mod"))
+	if err != nil {
+		return nil, errors.Wrap(err, "read go.mod")
+	}
+	mod, err := modfile.Parse("go.mod", modData, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse go.mod")
+	}
+	if err := mod.AddRequire("encore.dev", "v0.0.0"); err != nil {
+		return nil, errors.Wrap(err, "require encore.dev")
+	}
+	if err := mod.AddReplace("encore.dev", "", runtimeDir, ""); err != nil {
+		return nil, errors.Wrap(err, "replace encore.dev")
+	}
+	mod.Cleanup()
+
+	tmpDir, err := os.MkdirTemp("", "encore-offline")
+	if err != nil {
+		return nil, errors.Wrap(err, "create temp dir")
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	modPath := filepath.Join(tmpDir, "go.mod")
+	if err := os.WriteFile(modPath, modfile.Format(mod.Syntax), 0644); err != nil {
+		return nil, errors.Wrap(err, "write go.mod")
+	}
+	if sum, err := os.ReadFile(filepath.Join(appRoot, "go.sum")); err == nil {
+		if err := os.WriteFile(filepath.Join(tmpDir, "go.sum"), sum, 0644); err != nil {
+			return nil, errors.Wrap(err, "write go.sum")
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, filepath.Join(goroot, "bin", "go"+exeSuffix()), "mod", "download", "-json", "-modfile="+modPath, "all")
+	cmd.Dir = appRoot
+	cmd.Env = append(append(os.Environ(), environ...), offlineEnviron...)
+	// -modfile can't be used in workspace mode, and the app's own go.mod is what's built.
+	cmd.Env = append(cmd.Env, "GOROOT="+goroot, "GOTOOLCHAIN=local", "GO111MODULE=on", "GOWORK=off")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, runErr := cmd.Output()
+
+	var missing []missingArtifact
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var m struct {
+			Path, Version, Error string
+		}
+		if err := dec.Decode(&m); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "parse go mod download output")
+		}
+		if m.Error != "" {
+			missing = append(missing, missingArtifact{"the Go module " + m.Path + "@" + m.Version, "the module cache"})
+		}
+	}
+	if runErr != nil && len(missing) == 0 {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = runErr.Error()
+		}
+		return nil, errors.New(msg)
+	}
+	return missing, nil
+}
+
+// checkLocalImage checks that the local Docker daemon has the image for the platform.
+func checkLocalImage(ref string, p v1.Platform) error {
+	imgRef, err := name.ParseReference(ref)
+	if err != nil {
+		return err
+	}
+	img, err := daemon.Image(imgRef)
+	if err != nil {
+		return err
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return err
+	}
+	if cfg.OS != p.OS || cfg.Architecture != p.Architecture {
+		return errors.Newf("it's for %s/%s", cfg.OS, cfg.Architecture)
+	}
+	return nil
+}
+
+// binaryPaths returns the paths a prebuilt binary is looked for at, in order:
+// the binaries directory, if any, and the download cache.
+func binaryPaths(binariesPath, goos, goarch, binary string) []string {
+	var paths []string
+	if binariesPath != "" {
+		paths = append(paths, filepath.Join(binariesPath, goos+"-"+goarch, binary))
+	}
+	if cacheDir, err := conf.CacheDir(); err == nil {
+		paths = append(paths, filepath.Join(cacheDir, "bin", version.Version, goos, goarch, binary))
+	}
+	return paths
+}
+
+// prebuiltBinary returns the path to a prebuilt binary, preferring the binaries
+// directory and the download cache, and downloading it unless the build is offline.
+func prebuiltBinary(params *daemonpb.DockerExportParams, goos, goarch, binary string, log zerolog.Logger) (dockerbuild.HostPath, error) {
+	if params.BinariesPath != "" || params.Offline {
+		path, err := findBinary(params.BinariesPath, goos, goarch, binary)
+		if err == nil || params.Offline {
+			return path, err
+		}
+	}
+	return downloadBinary(goos, goarch, binary, log)
+}
+
+// findBinary finds a prebuilt binary without downloading it.
+func findBinary(binariesPath, goos, goarch, binary string) (dockerbuild.HostPath, error) {
+	paths := binaryPaths(binariesPath, goos, goarch, binary)
+	for _, path := range paths {
+		if exists(path) {
+			return dockerbuild.HostPath(path), nil
+		}
+	}
+	return "", errors.Newf("%s/%s %s not found at %s", goos, goarch, binary, strings.Join(paths, ", "))
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func exeSuffix() string {
+	if runtime.GOOS == "windows" {
+		return ".exe"
+	}
+	return ""
+}
@@ -0,0 +1,375 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"golang.org/x/oauth2/google"
+)
+
+// registryKeychain returns the keychain authenticating to registries for an
+// export run with the given environment, the environment of the CLI.
+//
+// ECR registries are authenticated with AWS credentials, either set in the
+// environment or exchanged for an OIDC token, such as that of a GitHub Actions
+// workflow or an EKS service account, with the role in AWS_ROLE_ARN. GCR and
+// Artifact Registry registries are authenticated with the Google credentials
+// in GOOGLE_APPLICATION_CREDENTIALS, including workload identity federation
+// configs exchanging an OIDC token. Otherwise, and for other registries,
+// the credentials of the Docker config are used.
+func registryKeychain(environ []string) authn.Keychain {
+	env := environMap(environ)
+	return authn.NewMultiKeychain(
+		&ecrKeychain{env: env, tokens: make(map[string]authn.Authenticator)},
+		&gcrKeychain{env: env},
+		authn.DefaultKeychain,
+	)
+}
+
+// environMap returns the environment as a map, later entries taking precedence.
+func environMap(environ []string) map[string]string {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env
+}
+
+// httpClient is the client used to exchange credentials.
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// ecrHostRegex matches the hosts of ECR registries, capturing the region.
+var ecrHostRegex = regexp.MustCompile(`^\d{12}\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(\.cn)?$`)
+
+// ecrKeychain authenticates to ECR registries with ECR authorization tokens.
+type ecrKeychain struct {
+	env map[string]string
+
+	mu     sync.Mutex
+	tokens map[string]authn.Authenticator // by registry
+}
+
+func (k *ecrKeychain) Resolve(res authn.Resource) (authn.Authenticator, error) {
+	m := ecrHostRegex.FindStringSubmatch(res.RegistryStr())
+	if m == nil {
+		return authn.Anonymous, nil
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if auth, ok := k.tokens[res.RegistryStr()]; ok {
+		return auth, nil
+	}
+
+	ctx := context.Background()
+	region, domain := m[1], "amazonaws.com"+m[2]
+	creds, ok, err := awsCredentials(ctx, k.env, region, domain)
+	if err != nil {
+		return nil, errors.Wrapf(err, "authenticate to %s", res.RegistryStr())
+	} else if !ok {
+		return authn.Anonymous, nil
+	}
+	username, password, err := ecrAuthorizationToken(ctx, creds, "https://api.ecr."+region+"."+domain+"/", region)
+	if err != nil {
+		return nil, errors.Wrapf(err, "authenticate to %s", res.RegistryStr())
+	}
+	auth := authn.FromConfig(authn.AuthConfig{Username: username, Password: password})
+	k.tokens[res.RegistryStr()] = auth
+	return auth, nil
+}
+
+// awsCreds are AWS credentials.
+type awsCreds struct {
+	AccessKeyID, SecretAccessKey, SessionToken string
+}
+
+// awsCredentials returns the AWS credentials set in the environment, or those
+// of the role in AWS_ROLE_ARN assumed with an OIDC token. It reports false if
+// the environment has neither.
+func awsCredentials(ctx context.Context, env map[string]string, region, domain string) (creds awsCreds, ok bool, err error) {
+	if env["AWS_ACCESS_KEY_ID"] != "" && env["AWS_SECRET_ACCESS_KEY"] != "" {
+		return awsCreds{env["AWS_ACCESS_KEY_ID"], env["AWS_SECRET_ACCESS_KEY"], env["AWS_SESSION_TOKEN"]}, true, nil
+	}
+	role := env["AWS_ROLE_ARN"]
+	if role == "" {
+		return awsCreds{}, false, nil
+	}
+	token, ok, err := oidcToken(ctx, env, env["AWS_WEB_IDENTITY_TOKEN_FILE"], "sts.amazonaws.com")
+	if err != nil {
+		return awsCreds{}, false, err
+	} else if !ok {
+		return awsCreds{}, false, errors.New("AWS_ROLE_ARN is set, but there's no OIDC token to assume it with: set AWS_WEB_IDENTITY_TOKEN_FILE")
+	}
+	sessionName := env["AWS_ROLE_SESSION_NAME"]
+	if sessionName == "" {
+		sessionName = "encore-build"
+	}
+	creds, err = assumeRoleWithWebIdentity(ctx, "https://sts."+region+"."+domain+"/", role, sessionName, token)
+	return creds, err == nil, err
+}
+
+// oidcToken returns the OIDC token in the file, if any, or the token
+// of the GitHub Actions workflow for the audience when running in one.
+func oidcToken(ctx context.Context, env map[string]string, file, audience string) (token string, ok bool, err error) {
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", false, errors.Wrap(err, "read oidc token")
+		}
+		return strings.TrimSpace(string(data)), true, nil
+	}
+
+	reqURL, reqToken := env["ACTIONS_ID_TOKEN_REQUEST_URL"], env["ACTIONS_ID_TOKEN_REQUEST_TOKEN"]
+	if reqURL == "" || reqToken == "" {
+		return "", false, nil
+	}
+	u, err := url.Parse(reqURL)
+	if err != nil {
+		return "", false, errors.Wrap(err, "parse github actions oidc token url")
+	}
+	q := u.Query()
+	q.Set("audience", audience)
+	u.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return "", false, errors.WithStack(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+reqToken)
+	var resp struct {
+		Value string `json:"value"`
+	}
+	if err := doJSON(req, &resp); err != nil {
+		return "", false, errors.Wrap(err, "get github actions oidc token")
+	}
+	return resp.Value, true, nil
+}
+
+// assumeRoleWithWebIdentity exchanges the OIDC token for the credentials
+// of the role, with the STS endpoint.
+func assumeRoleWithWebIdentity(ctx context.Context, endpoint, role, sessionName, token string) (awsCreds, error) {
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {role},
+		"RoleSessionName":  {sessionName},
+		"WebIdentityToken": {token},
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return awsCreds{}, errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	body, err := do(req)
+	if err != nil {
+		return awsCreds{}, errors.Wrapf(err, "assume role %s", role)
+	}
+
+	var resp struct {
+		Credentials struct {
+			AccessKeyId     string
+			SecretAccessKey string
+			SessionToken    string
+		} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+	}
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return awsCreds{}, errors.Wrap(err, "parse sts response")
+	}
+	c := resp.Credentials
+	return awsCreds{c.AccessKeyId, c.SecretAccessKey, c.SessionToken}, nil
+}
+
+// ecrAuthorizationToken gets an authorization token for the ECR registries
+// of the account, returning the username and password it encodes.
+func ecrAuthorizationToken(ctx context.Context, creds awsCreds, endpoint, region string) (username, password string, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader("{}"))
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken")
+	signAWSRequest(req, []byte("{}"), creds, region, "ecr", time.Now())
+	var resp struct {
+		AuthorizationData []struct {
+			AuthorizationToken string `json:"authorizationToken"`
+		} `json:"authorizationData"`
+	}
+	if err := doJSON(req, &resp); err != nil {
+		return "", "", errors.Wrap(err, "get ecr authorization token")
+	}
+	if len(resp.AuthorizationData) == 0 {
+		return "", "", errors.New("get ecr authorization token: no token returned")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(resp.AuthorizationData[0].AuthorizationToken)
+	if err != nil {
+		return "", "", errors.Wrap(err, "decode ecr authorization token")
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", errors.New("invalid ecr authorization token")
+	}
+	return username, password, nil
+}
+
+// signAWSRequest signs the request with AWS Signature Version 4.
+func signAWSRequest(req *http.Request, body []byte, creds awsCreds, region, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		vals := query[k]
+		sort.Strings(vals)
+		for _, v := range vals {
+			params = append(params, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.Join(params, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, s := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// awsEscape escapes s as AWS signatures expect, leaving only unreserved characters.
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// gcrHostRegex matches the hosts of GCR and Artifact Registry registries.
+var gcrHostRegex = regexp.MustCompile(`^(?:[a-z]+\.)?gcr\.io$|^[a-z0-9-]+-docker\.pkg\.dev$`)
+
+// gcrScope is the OAuth scope of access tokens for GCR and Artifact Registry.
+const gcrScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// gcrKeychain authenticates to GCR and Artifact Registry registries with
+// access tokens of the Google credentials in GOOGLE_APPLICATION_CREDENTIALS.
+type gcrKeychain struct {
+	env map[string]string
+
+	once  sync.Once
+	creds *google.Credentials
+	err   error
+}
+
+func (k *gcrKeychain) Resolve(res authn.Resource) (authn.Authenticator, error) {
+	file := k.env["GOOGLE_APPLICATION_CREDENTIALS"]
+	if !gcrHostRegex.MatchString(res.RegistryStr()) || file == "" {
+		return authn.Anonymous, nil
+	}
+	k.once.Do(func() {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			k.err = errors.Wrap(err, "read google credentials")
+			return
+		}
+		k.creds, k.err = google.CredentialsFromJSON(context.Background(), data, gcrScope)
+		if k.err != nil {
+			k.err = errors.Wrap(k.err, "parse google credentials")
+		}
+	})
+	if k.err != nil {
+		return nil, k.err
+	}
+	token, err := k.creds.TokenSource.Token()
+	if err != nil {
+		return nil, errors.Wrapf(err, "authenticate to %s", res.RegistryStr())
+	}
+	return authn.FromConfig(authn.AuthConfig{Username: "oauth2accesstoken", Password: token.AccessToken}), nil
+}
+
+// doJSON sends the request and decodes the JSON response into dst.
+func doJSON(req *http.Request, dst any) error {
+	body, err := do(req)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(json.Unmarshal(body, dst), "parse response")
+}
+
+// do sends the request, returning the response body
+// or an error including it if the request failed.
+func do(req *http.Request) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("%s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return body, nil
+}
@@ -0,0 +1,101 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// TestSignAWSRequest tests the signature of the example in the AWS documentation.
+func TestSignAWSRequest(t *testing.T) {
+	c := qt.New(t)
+	req, err := http.NewRequest("GET", "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	c.Assert(err, qt.IsNil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	creds := awsCreds{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signAWSRequest(req, nil, creds, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	c.Assert(req.Header.Get("X-Amz-Date"), qt.Equals, "20150830T123600Z")
+	c.Assert(req.Header.Get("Authorization"), qt.Equals, "AWS4-HMAC-SHA256 "+
+		"Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7")
+}
+
+func TestECRAuthentication(t *testing.T) {
+	c := qt.New(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/oidc":
+			c.Check(r.Header.Get("Authorization"), qt.Equals, "Bearer request-token")
+			c.Check(r.URL.Query().Get("audience"), qt.Equals, "sts.amazonaws.com")
+			_, _ = fmt.Fprint(w, `{"value": "oidc-token"}`)
+		case r.URL.Path == "/sts":
+			c.Check(r.FormValue("Action"), qt.Equals, "AssumeRoleWithWebIdentity")
+			c.Check(r.FormValue("RoleArn"), qt.Equals, "arn:aws:iam::123456789012:role/ci")
+			c.Check(r.FormValue("WebIdentityToken"), qt.Equals, "oidc-token")
+			_, _ = fmt.Fprint(w, `<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>
+<AccessKeyId>ASIAEXAMPLE</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>
+</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`)
+		case r.URL.Path == "/ecr":
+			body, _ := io.ReadAll(r.Body)
+			c.Check(string(body), qt.Equals, "{}")
+			c.Check(r.Header.Get("X-Amz-Target"), qt.Equals, "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken")
+			c.Check(r.Header.Get("X-Amz-Security-Token"), qt.Equals, "session")
+			c.Check(strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=ASIAEXAMPLE/"), qt.IsTrue)
+			token := base64.StdEncoding.EncodeToString([]byte("AWS:ecr-password"))
+			_, _ = fmt.Fprintf(w, `{"authorizationData": [{"authorizationToken": %q}]}`, token)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	env := map[string]string{
+		"AWS_ROLE_ARN":                   "arn:aws:iam::123456789012:role/ci",
+		"ACTIONS_ID_TOKEN_REQUEST_URL":   srv.URL + "/oidc?api-version=2.0",
+		"ACTIONS_ID_TOKEN_REQUEST_TOKEN": "request-token",
+	}
+	ctx := context.Background()
+	token, ok, err := oidcToken(ctx, env, "", "sts.amazonaws.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.IsTrue)
+	creds, err := assumeRoleWithWebIdentity(ctx, srv.URL+"/sts", env["AWS_ROLE_ARN"], "encore-build", token)
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.Equals, awsCreds{"ASIAEXAMPLE", "secret", "session"})
+	username, password, err := ecrAuthorizationToken(ctx, creds, srv.URL+"/ecr", "eu-west-1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(username, qt.Equals, "AWS")
+	c.Assert(password, qt.Equals, "ecr-password")
+}
+
+func TestRegistryKeychain(t *testing.T) {
+	c := qt.New(t)
+	c.Setenv("DOCKER_CONFIG", c.TempDir())
+	kc := registryKeychain(nil)
+
+	// Without credentials in the environment, ECR and GCR fall back to the Docker config.
+	for _, reg := range []string{"123456789012.dkr.ecr.eu-west-1.amazonaws.com", "gcr.io", "europe-docker.pkg.dev", "registry.internal"} {
+		r, err := name.NewRegistry(reg)
+		c.Assert(err, qt.IsNil)
+		auth, err := kc.Resolve(r)
+		c.Assert(err, qt.IsNil)
+		c.Assert(auth, qt.Equals, authn.Anonymous)
+	}
+
+	c.Assert(ecrHostRegex.FindStringSubmatch("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn"), qt.DeepEquals,
+		[]string{"123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn", "cn-north-1", ".cn"})
+	c.Assert(ecrHostRegex.MatchString("public.ecr.aws"), qt.IsFalse)
+	c.Assert(gcrHostRegex.MatchString("eu.gcr.io"), qt.IsTrue)
+	c.Assert(gcrHostRegex.MatchString("gcr.io.evil.com"), qt.IsFalse)
+}
@@ -0,0 +1,21 @@
+package daemon
+
+import (
+	"context"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// GC garbage collects the data stored by the daemon.
+func (s *Server) GC(ctx context.Context, req *daemonpb.GCRequest) (*daemonpb.GCResponse, error) {
+	rep, err := s.gc.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonpb.GCResponse{
+		TracesDeleted:    int32(rep.TracesDeleted),
+		BuildDirsRemoved: int32(rep.BuildDirsRemoved),
+		LogRotated:       rep.LogRotated,
+		BytesReclaimed:   rep.BytesReclaimed,
+	}, nil
+}
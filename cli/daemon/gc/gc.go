@@ -0,0 +1,352 @@
+// Package gc garbage collects the data the daemon accumulates over time:
+// traces, build caches and logs.
+package gc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/engine/trace2/sqlite"
+	"encr.dev/internal/conf"
+)
+
+// Policy describes how long to retain data.
+type Policy struct {
+	TraceMaxAge      time.Duration
+	TracesPerApp     int
+	TraceMaxSize     int64 // in bytes
+	BuildCacheMaxAge time.Duration
+	LogMaxSize       int64 // in bytes
+}
+
+// DefaultPolicy is the default retention policy.
+var DefaultPolicy = Policy{
+	TraceMaxAge:      7 * 24 * time.Hour,
+	TracesPerApp:     500,
+	TraceMaxSize:     1024 << 20,
+	BuildCacheMaxAge: 30 * 24 * time.Hour,
+	LogMaxSize:       100 << 20,
+}
+
+// ParsePolicy parses the retention configuration,
+// using the defaults for any unset values.
+func ParsePolicy(cfg *conf.Retention) (Policy, error) {
+	p := DefaultPolicy
+	if cfg == nil {
+		return p, nil
+	}
+
+	parseDur := func(field, val string, dst *time.Duration) error {
+		if val == "" {
+			return nil
+		}
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid %s %q: must be a positive duration like \"72h\"", field, val)
+		}
+		*dst = d
+		return nil
+	}
+	if err := parseDur("trace_max_age", cfg.TraceMaxAge, &p.TraceMaxAge); err != nil {
+		return p, err
+	}
+	if err := parseDur("build_cache_max_age", cfg.BuildCacheMaxAge, &p.BuildCacheMaxAge); err != nil {
+		return p, err
+	}
+	if cfg.TracesPerApp > 0 {
+		p.TracesPerApp = cfg.TracesPerApp
+	}
+	if cfg.TraceMaxSizeMB > 0 {
+		p.TraceMaxSize = int64(cfg.TraceMaxSizeMB) << 20
+	}
+	if cfg.LogMaxSizeMB > 0 {
+		p.LogMaxSize = int64(cfg.LogMaxSizeMB) << 20
+	}
+	return p, nil
+}
+
+// Collector garbage collects daemon data according to a policy.
+type Collector struct {
+	Policy Policy
+
+	// DB is the daemon database, and Traces the trace store within it.
+	DB     *sql.DB
+	Traces *sqlite.Store
+
+	// BuildCacheDir is the directory containing the per-app build caches.
+	BuildCacheDir string
+
+	// LogPath is the path to the daemon log file.
+	LogPath string
+
+	// IsRunning reports whether the app with the given id is running,
+	// in which case its build cache is kept.
+	IsRunning func(appID string) bool
+
+	mu sync.Mutex // serializes collections
+}
+
+// Report describes the result of a collection.
+type Report struct {
+	TracesDeleted     int
+	BuildDirsRemoved  int
+	LogRotated        bool
+	BytesReclaimed    int64
+	DatabaseCompacted bool
+}
+
+// Start periodically collects garbage until ctx is canceled.
+// Traces are pruned every minute, and files every hour.
+func (c *Collector) Start(ctx context.Context) {
+	go func() {
+		traceTicker := time.NewTicker(1 * time.Minute)
+		defer traceTicker.Stop()
+		fileTicker := time.NewTicker(1 * time.Hour)
+		defer fileTicker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-traceTicker.C:
+				rep := &Report{}
+				c.mu.Lock()
+				err := c.pruneTraces(ctx, rep)
+				c.mu.Unlock()
+				if err != nil {
+					log.Error().Err(err).Msg("gc: failed to prune traces")
+				} else if rep.TracesDeleted > 0 {
+					log.Info().Int("deleted", rep.TracesDeleted).Msg("gc: pruned traces")
+				}
+			case <-fileTicker.C:
+				rep := &Report{}
+				c.mu.Lock()
+				c.collectFiles(rep)
+				c.mu.Unlock()
+				if rep.BytesReclaimed > 0 {
+					log.Info().Int64("bytes", rep.BytesReclaimed).Msg("gc: reclaimed disk space")
+				}
+			}
+		}
+	}()
+}
+
+// Collect collects all garbage now, and compacts the daemon
+// database to reclaim the space freed by deleted traces.
+func (c *Collector) Collect(ctx context.Context) (*Report, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rep := &Report{}
+	if err := c.pruneTraces(ctx, rep); err != nil {
+		return nil, err
+	}
+	c.collectFiles(rep)
+
+	if c.DB != nil {
+		before, err := c.dbSize(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := c.DB.ExecContext(ctx, "VACUUM"); err != nil {
+			return nil, errors.Wrap(err, "compact database")
+		}
+		after, err := c.dbSize(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rep.DatabaseCompacted = true
+		if before > after {
+			rep.BytesReclaimed += before - after
+		}
+	}
+	return rep, nil
+}
+
+func (c *Collector) pruneTraces(ctx context.Context, rep *Report) error {
+	if c.Traces == nil {
+		return nil
+	}
+	p := c.Policy
+	if p.TraceMaxAge > 0 {
+		n, err := c.Traces.PruneOlderThan(ctx, time.Now().Add(-p.TraceMaxAge))
+		rep.TracesDeleted += n
+		if err != nil {
+			return err
+		}
+	}
+	if p.TracesPerApp > 0 {
+		n, err := c.Traces.PruneExcess(ctx, p.TracesPerApp)
+		rep.TracesDeleted += n
+		if err != nil {
+			return err
+		}
+	}
+	if p.TraceMaxSize > 0 {
+		n, err := c.Traces.PruneToSize(ctx, p.TraceMaxSize)
+		rep.TracesDeleted += n
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectFiles removes stale build caches and rotates the log file.
+// Errors are logged rather than returned since they only affect
+// individual files.
+func (c *Collector) collectFiles(rep *Report) {
+	if c.BuildCacheDir != "" && c.Policy.BuildCacheMaxAge > 0 {
+		c.removeStaleBuildDirs(rep)
+	}
+	if c.LogPath != "" && c.Policy.LogMaxSize > 0 {
+		rotated, reclaimed, err := rotateLog(c.LogPath, c.Policy.LogMaxSize)
+		if err != nil {
+			log.Error().Err(err).Msg("gc: failed to rotate log file")
+		}
+		rep.LogRotated = rotated
+		rep.BytesReclaimed += reclaimed
+	}
+}
+
+// removeStaleBuildDirs removes the build caches of apps that
+// haven't been built within the max age, and temporary build
+// directories left behind by builds that didn't clean up.
+func (c *Collector) removeStaleBuildDirs(rep *Report) {
+	cutoff := time.Now().Add(-c.Policy.BuildCacheMaxAge)
+
+	remove := func(dir string) {
+		size := dirSize(dir)
+		if err := os.RemoveAll(dir); err != nil {
+			log.Error().Err(err).Str("dir", dir).Msg("gc: failed to remove build directory")
+			return
+		}
+		rep.BuildDirsRemoved++
+		rep.BytesReclaimed += size
+	}
+
+	// The build cache is laid out as <BuildCacheDir>/<app id>/<build mode>.
+	appDirs, _ := os.ReadDir(c.BuildCacheDir)
+	for _, appDir := range appDirs {
+		if !appDir.IsDir() {
+			continue
+		}
+		appID := appDir.Name()
+		if c.IsRunning != nil && c.IsRunning(appID) {
+			continue
+		}
+		path := filepath.Join(c.BuildCacheDir, appID)
+		if lastModified(path).Before(cutoff) {
+			remove(path)
+		}
+	}
+
+	tmpDirs, _ := os.ReadDir(os.TempDir())
+	for _, tmp := range tmpDirs {
+		if tmp.IsDir() && strings.HasPrefix(tmp.Name(), "encore-build") {
+			path := filepath.Join(os.TempDir(), tmp.Name())
+			if lastModified(path).Before(cutoff) {
+				remove(path)
+			}
+		}
+	}
+}
+
+// lastModified reports the most recent modification time of dir
+// and the entries up to two levels below it, which is where builds
+// write their output.
+func lastModified(dir string) time.Time {
+	var latest time.Time
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		if d.IsDir() && path != dir {
+			if rel, err := filepath.Rel(dir, path); err == nil && strings.Count(rel, string(filepath.Separator)) >= 1 {
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	return latest
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// rotateLog moves the contents of the log file at path to path.1 if it's
+// larger than maxSize, replacing any previously rotated log.
+// It reports whether the log was rotated, and the number of bytes reclaimed
+// by replacing the previously rotated log.
+//
+// The log is copied and truncated rather than renamed, since the daemon
+// keeps the file open for appending.
+func rotateLog(path string, maxSize int64) (rotated bool, reclaimed int64, err error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, 0, nil
+	} else if err != nil {
+		return false, 0, err
+	} else if info.Size() <= maxSize {
+		return false, 0, nil
+	}
+
+	var prevSize int64
+	if prev, err := os.Stat(path + ".1"); err == nil {
+		prevSize = prev.Size()
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() { _ = src.Close() }()
+	dst, err := os.OpenFile(path+".1", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return false, 0, err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return false, 0, err
+	} else if err := dst.Close(); err != nil {
+		return false, 0, err
+	}
+	if err := os.Truncate(path, 0); err != nil {
+		return false, 0, err
+	}
+	return true, prevSize, nil
+}
+
+func (c *Collector) dbSize(ctx context.Context) (int64, error) {
+	var pages, pageSize int64
+	if err := c.DB.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, errors.Wrap(err, "get database size")
+	}
+	if err := c.DB.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, errors.Wrap(err, "get database size")
+	}
+	return pages * pageSize, nil
+}
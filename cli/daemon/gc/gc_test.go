@@ -0,0 +1,90 @@
+package gc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/internal/conf"
+)
+
+func TestParsePolicy(t *testing.T) {
+	c := qt.New(t)
+
+	p, err := ParsePolicy(nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(p, qt.Equals, DefaultPolicy)
+
+	p, err = ParsePolicy(&conf.Retention{TraceMaxAge: "24h", TracesPerApp: 10, LogMaxSizeMB: 5})
+	c.Assert(err, qt.IsNil)
+	c.Assert(p.TraceMaxAge, qt.Equals, 24*time.Hour)
+	c.Assert(p.TracesPerApp, qt.Equals, 10)
+	c.Assert(p.LogMaxSize, qt.Equals, int64(5<<20))
+	c.Assert(p.BuildCacheMaxAge, qt.Equals, DefaultPolicy.BuildCacheMaxAge)
+
+	_, err = ParsePolicy(&conf.Retention{BuildCacheMaxAge: "30 days"})
+	c.Assert(err, qt.ErrorMatches, `invalid build_cache_max_age "30 days".*`)
+}
+
+func TestRotateLog(t *testing.T) {
+	c := qt.New(t)
+	path := filepath.Join(t.TempDir(), "daemon.log")
+	c.Assert(os.WriteFile(path+".1", []byte("old"), 0600), qt.IsNil)
+	c.Assert(os.WriteFile(path, []byte("hello"), 0600), qt.IsNil)
+
+	// Below the max size nothing happens.
+	rotated, _, err := rotateLog(path, 10)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rotated, qt.IsFalse)
+
+	rotated, reclaimed, err := rotateLog(path, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rotated, qt.IsTrue)
+	c.Assert(reclaimed, qt.Equals, int64(len("old")))
+
+	data, err := os.ReadFile(path + ".1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "hello")
+	data, err = os.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(data), qt.Equals, "")
+}
+
+func TestRemoveStaleBuildDirs(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", t.TempDir()) // don't touch the real temporary build directories
+	old := time.Now().Add(-48 * time.Hour)
+
+	mkdir := func(appID string, modTime time.Time) {
+		path := filepath.Join(dir, appID, "run")
+		c.Assert(os.MkdirAll(path, 0755), qt.IsNil)
+		c.Assert(os.WriteFile(filepath.Join(path, "main.go"), []byte("package main"), 0644), qt.IsNil)
+		for _, p := range []string{filepath.Join(path, "main.go"), path, filepath.Dir(path)} {
+			c.Assert(os.Chtimes(p, modTime, modTime), qt.IsNil)
+		}
+	}
+	mkdir("stale", old)
+	mkdir("running", old)
+	mkdir("fresh", time.Now())
+
+	col := &Collector{
+		Policy:        Policy{BuildCacheMaxAge: 24 * time.Hour},
+		BuildCacheDir: dir,
+		IsRunning:     func(appID string) bool { return appID == "running" },
+	}
+	rep := &Report{}
+	col.removeStaleBuildDirs(rep)
+
+	c.Assert(rep.BuildDirsRemoved, qt.Equals, 1)
+	c.Assert(rep.BytesReclaimed, qt.Equals, int64(len("package main")))
+	_, err := os.Stat(filepath.Join(dir, "stale"))
+	c.Assert(os.IsNotExist(err), qt.IsTrue)
+	for _, appID := range []string{"running", "fresh"} {
+		_, err := os.Stat(filepath.Join(dir, appID))
+		c.Assert(err, qt.IsNil)
+	}
+}
@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"context"
+	"net"
+
+	"encr.dev/cli/daemon/lsp"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/pkg/fns"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// LanguageServer serves the Encore language server for an app.
+func (s *Server) LanguageServer(stream daemonpb.Daemon_LanguageServerServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	app, err := s.apps.Track(first.AppRoot)
+	if err != nil {
+		return err
+	}
+
+	// Bridge the gRPC stream to the language server using an in-memory connection.
+	client, server := net.Pipe()
+	defer fns.CloseIgnore(server)
+
+	// Forward the editor's input to the language server.
+	go func() {
+		defer fns.CloseIgnore(client)
+		data := first.Data
+		for {
+			if len(data) > 0 {
+				if _, err := client.Write(data); err != nil {
+					return
+				}
+			}
+			msg, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			data = msg.Data
+		}
+	}()
+
+	// Forward the language server's output to the editor.
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := client.Read(buf)
+			if n > 0 {
+				data := append([]byte(nil), buf[:n]...)
+				if err := stream.Send(&daemonpb.LanguageServerMessage{Data: data}); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	srv := lsp.New(app, func(ctx context.Context) (*meta.Data, error) {
+		return s.mgr.Parse(ctx, run.CheckParams{
+			App:        app,
+			WorkingDir: ".",
+			Environ:    first.Environ,
+		})
+	})
+	return srv.Serve(stream.Context(), server)
+}
@@ -0,0 +1,187 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/pkg/errinsrc"
+	"encr.dev/pkg/errlist"
+)
+
+// requestAnalysis requests the app to be re-analyzed.
+// Multiple requests made while an analysis is running are coalesced.
+func (s *Server) requestAnalysis() {
+	select {
+	case s.analyze <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Server) analyzeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.analyze:
+			s.runAnalysis(ctx)
+		}
+	}
+}
+
+// runAnalysis parses the app and publishes the resulting diagnostics.
+func (s *Server) runAnalysis(ctx context.Context) {
+	diags := make(map[string][]Diagnostic)
+
+	md, err := s.parse(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		list := errlist.Convert(err)
+		if list == nil {
+			s.showMessage(ctx, MessageError, "encore: "+err.Error())
+		} else {
+			for _, e := range list.List {
+				if !s.addErrDiagnostics(diags, e) {
+					s.showMessage(ctx, MessageError, "encore: "+e.Title()+": "+e.Params.Summary)
+				}
+			}
+		}
+	} else {
+		idx := buildIndex(s.app.Root(), md)
+		for _, d := range idx.decls {
+			if msg := idx.unusedMessage(d); msg != "" {
+				diags[d.file] = append(diags[d.file], Diagnostic{
+					Range:    d.nameRange,
+					Severity: SeverityWarning,
+					Source:   "encore",
+					Message:  msg,
+				})
+			}
+		}
+		s.mu.Lock()
+		s.idx = idx
+		s.mu.Unlock()
+	}
+
+	s.publish(ctx, diags)
+}
+
+// addErrDiagnostics adds diagnostics for the source locations of e.
+// It reports false if e has no source locations.
+func (s *Server) addErrDiagnostics(diags map[string][]Diagnostic, e *errinsrc.ErrInSrc) bool {
+	msg := e.Title()
+	if e.Params.Summary != "" {
+		msg += ": " + e.Params.Summary
+	}
+	if e.Params.Detail != "" {
+		msg += "\n\n" + e.Params.Detail
+	}
+
+	added := false
+	for _, loc := range e.Params.Locations {
+		if loc.File == nil || loc.File.FullPath == "" {
+			continue
+		}
+		content := loc.File.Contents
+		if content == nil {
+			var err error
+			if content, err = os.ReadFile(loc.File.FullPath); err != nil {
+				continue
+			}
+		}
+
+		d := Diagnostic{
+			Range: Range{
+				Start: lineColPosition(content, loc.Start.Line, loc.Start.Col),
+				End:   lineColPosition(content, loc.End.Line, loc.End.Col),
+			},
+			Source: "encore",
+		}
+		switch loc.Type.String() {
+		case "error":
+			d.Severity, d.Message = SeverityError, msg
+		case "warning":
+			d.Severity, d.Message = SeverityWarning, msg
+		default:
+			d.Severity, d.Message = SeverityHint, e.Title()
+		}
+		if loc.Text != "" {
+			d.Message += "\n" + loc.Text
+		}
+		diags[loc.File.FullPath] = append(diags[loc.File.FullPath], d)
+		added = true
+	}
+	return added
+}
+
+// unusedMessage returns a warning message if the resource declared by d is unused,
+// or "" otherwise.
+func (idx *index) unusedMessage(d *decl) string {
+	switch {
+	case d.topic != nil && d.kind == topicDecl:
+		if len(d.topic.Subscriptions) == 0 && len(d.topic.Publishers) == 0 {
+			return fmt.Sprintf("Pub/Sub topic %q is never used.", d.name)
+		} else if len(d.topic.Subscriptions) == 0 {
+			return fmt.Sprintf("Pub/Sub topic %q has no subscriptions; messages published to it are discarded.", d.name)
+		}
+	case d.cluster != nil:
+		if len(d.cluster.Keyspaces) == 0 {
+			return fmt.Sprintf("Cache cluster %q has no keyspaces.", d.name)
+		}
+	case d.db != nil:
+		if len(idx.databaseServices(d.name)) == 0 {
+			return fmt.Sprintf("SQL database %q is not used by any service.", d.name)
+		}
+	}
+	return ""
+}
+
+// publish publishes diags, and clears the diagnostics of
+// files that no longer have any.
+func (s *Server) publish(ctx context.Context, diags map[string][]Diagnostic) {
+	s.mu.Lock()
+	var paths []string
+	for path := range s.published {
+		if _, ok := diags[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	s.published = make(map[string]bool, len(diags))
+	for path := range diags {
+		s.published[path] = true
+		paths = append(paths, path)
+	}
+	s.mu.Unlock()
+
+	slices.Sort(paths)
+	for _, path := range paths {
+		d := diags[path]
+		if d == nil {
+			d = []Diagnostic{} // the diagnostics field is required
+		}
+		err := s.rpc.Notify(ctx, "textDocument/publishDiagnostics", PublishDiagnosticsParams{
+			URI:         s.uri(path),
+			Diagnostics: d,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("lsp: could not publish diagnostics")
+			return
+		}
+	}
+}
+
+func (s *Server) showMessage(ctx context.Context, typ MessageType, msg string) {
+	err := s.rpc.Notify(ctx, "window/showMessage", ShowMessageParams{
+		Type:    typ,
+		Message: strings.TrimSpace(msg),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("lsp: could not show message")
+	}
+}
@@ -0,0 +1,205 @@
+package lsp
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// hover returns the hover documentation for the resource at the
+// given position, or nil if there is none.
+func (s *Server) hover(params TextDocumentPositionParams) *Hover {
+	d, rng := s.resolve(params)
+	if d == nil {
+		return nil
+	}
+	return &Hover{
+		Contents: MarkupContent{Kind: Markdown, Value: d.markdown(s.index())},
+		Range:    rng,
+	}
+}
+
+// definition returns the location of the declaration of the
+// resource at the given position, or nil if there is none.
+func (s *Server) definition(params TextDocumentPositionParams) []Location {
+	d, _ := s.resolve(params)
+	if d == nil {
+		return nil
+	}
+	return []Location{{URI: s.uri(d.file), Range: d.nameRange}}
+}
+
+// resolve resolves the resource referenced at the given position.
+// It also reports the range of the reference, if known.
+func (s *Server) resolve(params TextDocumentPositionParams) (*decl, *Range) {
+	idx := s.index()
+	if idx == nil {
+		return nil, nil
+	}
+	path, err := params.TextDocument.URI.Path()
+	if err != nil || filepath.Ext(path) != ".go" {
+		return nil, nil
+	}
+	relPath, ok := idx.relPath(filepath.Dir(path))
+	if !ok {
+		return nil, nil
+	}
+	content, err := s.contents(path)
+	if err != nil {
+		return nil, nil
+	}
+
+	// Parse the file as it currently is in the editor.
+	// The file may be partially invalid while being edited,
+	// in which case we use whatever the parser could make sense of.
+	fset := token.NewFileSet()
+	f, _ := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+	if f == nil {
+		return nil, nil
+	}
+	tf := fset.File(f.Pos())
+	offset := positionOffset(content, params.Position)
+	if offset > tf.Size() {
+		return nil, nil
+	}
+
+	nodes := identPath(f, tf, offset)
+	if len(nodes) > 0 {
+		if id, ok := nodes[0].(*ast.Ident); ok {
+			// Determine the package the identifier belongs to:
+			// either the imported package it's selected from,
+			// or the package of the file itself.
+			pkgPath := idx.importPath(relPath)
+			if len(nodes) > 1 {
+				if sel, ok := nodes[1].(*ast.SelectorExpr); ok && sel.Sel == id {
+					x, ok := sel.X.(*ast.Ident)
+					if !ok {
+						return nil, nil
+					}
+					if pkgPath = idx.resolveImport(f, x.Name); pkgPath == "" {
+						return nil, nil
+					}
+				}
+			}
+			if d := idx.lookup(pkgPath, id.Name); d != nil {
+				rng := offsetRange(content, tf.Offset(id.Pos()), tf.Offset(id.End()))
+				return d, &rng
+			}
+		}
+	}
+
+	// Fall back to anonymous declarations, like "var _ = pubsub.NewSubscription(...)".
+	return idx.declAt(path, offset), nil
+}
+
+// identPath returns the path of AST nodes enclosing the identifier at
+// offset, with the identifier first. The cursor may be placed either
+// before or right after the identifier. If there is no identifier at
+// offset it returns nil.
+func identPath(f *ast.File, tf *token.File, offset int) []ast.Node {
+	for _, interval := range [][2]int{{offset, offset + 1}, {offset - 1, offset}} {
+		start, end := interval[0], interval[1]
+		if start < 0 || end > tf.Size() {
+			continue
+		}
+		nodes, _ := astutil.PathEnclosingInterval(f, tf.Pos(start), tf.Pos(end))
+		if len(nodes) > 0 {
+			if _, ok := nodes[0].(*ast.Ident); ok {
+				return nodes
+			}
+		}
+	}
+	return nil
+}
+
+// markdown renders the documentation for the resource declared by d.
+func (d *decl) markdown(idx *index) string {
+	var b strings.Builder
+	doc := ""
+	switch d.kind {
+	case endpointDecl:
+		rpc := d.rpc
+		fmt.Fprintf(&b, "**API endpoint** `%s.%s`\n\n", rpc.ServiceName, rpc.Name)
+		fmt.Fprintf(&b, "`%s %s`", strings.Join(rpc.HttpMethods, ", "), rpc.Path.Pattern())
+		switch rpc.AccessType {
+		case meta.RPC_PUBLIC:
+			b.WriteString(" · public")
+		case meta.RPC_AUTH:
+			b.WriteString(" · requires authentication")
+		case meta.RPC_PRIVATE:
+			b.WriteString(" · private")
+		}
+		if rpc.Proto == meta.RPC_RAW {
+			b.WriteString(" · raw")
+		}
+		b.WriteString("\n")
+		doc = rpc.GetDoc()
+
+	case topicDecl:
+		fmt.Fprintf(&b, "**Pub/Sub topic** `%s`\n", d.name)
+		if t := d.topic; t != nil {
+			b.WriteString("\n")
+			switch t.DeliveryGuarantee {
+			case meta.PubSubTopic_AT_LEAST_ONCE:
+				b.WriteString("Delivery: at-least-once\n\n")
+			case meta.PubSubTopic_EXACTLY_ONCE:
+				b.WriteString("Delivery: exactly-once\n\n")
+			}
+			if t.OrderingKey != "" {
+				fmt.Fprintf(&b, "Ordered by: `%s`\n\n", t.OrderingKey)
+			}
+			var publishers []string
+			for _, p := range t.Publishers {
+				publishers = append(publishers, p.ServiceName)
+			}
+			fmt.Fprintf(&b, "Published by: %s\n\n", listOrNone(publishers))
+			var subs []string
+			for _, sub := range t.Subscriptions {
+				subs = append(subs, fmt.Sprintf("`%s` (%s)", sub.Name, sub.ServiceName))
+			}
+			fmt.Fprintf(&b, "Subscriptions: %s\n", listOrNone(subs))
+			doc = t.GetDoc()
+		}
+
+	case subscriptionDecl:
+		fmt.Fprintf(&b, "**Pub/Sub subscription** `%s`\n", d.name)
+		if d.topic != nil {
+			fmt.Fprintf(&b, "\nTopic: `%s`\n\nService: %s\n", d.topic.Name, d.svc)
+		}
+
+	case cacheClusterDecl:
+		fmt.Fprintf(&b, "**Cache cluster** `%s`\n", d.name)
+		if c := d.cluster; c != nil {
+			fmt.Fprintf(&b, "\nEviction policy: `%s`\n\nKeyspaces: %d\n", c.EvictionPolicy, len(c.Keyspaces))
+			doc = c.Doc
+		}
+
+	case databaseDecl:
+		fmt.Fprintf(&b, "**SQL database** `%s`\n", d.name)
+		if db := d.db; db != nil {
+			fmt.Fprintf(&b, "\nUsed by: %s\n", listOrNone(idx.databaseServices(d.name)))
+			doc = db.GetDoc()
+		}
+	}
+
+	if doc = strings.TrimSpace(doc); doc != "" {
+		b.WriteString("\n---\n\n")
+		b.WriteString(doc)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,321 @@
+package lsp
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+type declKind int
+
+const (
+	endpointDecl declKind = iota
+	topicDecl
+	subscriptionDecl
+	cacheClusterDecl
+	databaseDecl
+)
+
+// constructors maps the Encore resource constructors to the kind
+// of resource they declare, and the index of the resource name argument.
+var constructors = map[string]struct {
+	kind    declKind
+	nameArg int
+}{
+	"encore.dev/pubsub.NewTopic":           {topicDecl, 0},
+	"encore.dev/pubsub.NewSubscription":    {subscriptionDecl, 1},
+	"encore.dev/storage/cache.NewCluster":  {cacheClusterDecl, 0},
+	"encore.dev/storage/sqldb.NewDatabase": {databaseDecl, 0},
+}
+
+// decl is the declaration of an Encore resource in the app's source code.
+type decl struct {
+	kind    declKind
+	name    string // resource name
+	pkgPath string // import path of the declaring package
+	ident   string // declared identifier, or "" for declarations like "var _ = ..."
+
+	file       string // absolute path to the file
+	start, end int    // byte offsets of the whole declaration
+	nameRange  Range  // range of the declared identifier or name argument
+
+	// The resource metadata, depending on kind.
+	svc     string // service name, for endpoints and subscriptions
+	rpc     *meta.RPC
+	topic   *meta.PubSubTopic
+	sub     *meta.PubSubTopic_Subscription
+	cluster *meta.CacheCluster
+	db      *meta.SQLDatabase
+}
+
+// index describes where the Encore resources of an app are declared.
+type index struct {
+	root  string
+	md    *meta.Data
+	decls []*decl
+}
+
+// buildIndex indexes the resource declarations in the packages
+// described by md. Files that cannot be parsed are skipped.
+func buildIndex(root string, md *meta.Data) *index {
+	idx := &index{root: root, md: md}
+	fset := token.NewFileSet()
+	for _, pkg := range md.Pkgs {
+		dir := filepath.Join(root, filepath.FromSlash(pkg.RelPath))
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			path := filepath.Join(dir, name)
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			f, err := parser.ParseFile(fset, path, content, parser.SkipObjectResolution)
+			if err != nil {
+				continue
+			}
+			idx.indexFile(fset, pkg, path, content, f)
+		}
+	}
+	return idx
+}
+
+func (idx *index) indexFile(fset *token.FileSet, pkg *meta.Package, path string, content []byte, f *ast.File) {
+	tf := fset.File(f.Pos())
+	pkgPath := idx.importPath(pkg.RelPath)
+	rng := func(node ast.Node) Range {
+		return offsetRange(content, tf.Offset(node.Pos()), tf.Offset(node.End()))
+	}
+
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.FuncDecl:
+			rpc := idx.findRPC(pkg.ServiceName, pkgPath, filepath.Base(path), d.Name.Name)
+			if rpc == nil {
+				continue
+			}
+			idx.decls = append(idx.decls, &decl{
+				kind:      endpointDecl,
+				name:      rpc.Name,
+				pkgPath:   pkgPath,
+				ident:     d.Name.Name,
+				file:      path,
+				start:     tf.Offset(d.Pos()),
+				end:       tf.Offset(d.End()),
+				nameRange: rng(d.Name),
+				svc:       rpc.ServiceName,
+				rpc:       rpc,
+			})
+
+		case *ast.GenDecl:
+			if d.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range d.Specs {
+				spec := spec.(*ast.ValueSpec)
+				for i, val := range spec.Values {
+					call, ok := val.(*ast.CallExpr)
+					if !ok {
+						continue
+					}
+					c, ok := constructors[idx.qualifiedName(f, call.Fun)]
+					if !ok || len(call.Args) <= c.nameArg {
+						continue
+					}
+					lit, ok := call.Args[c.nameArg].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					name, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						continue
+					}
+
+					dd := &decl{
+						kind:      c.kind,
+						name:      name,
+						pkgPath:   pkgPath,
+						file:      path,
+						start:     tf.Offset(spec.Pos()),
+						end:       tf.Offset(spec.End()),
+						nameRange: rng(lit),
+						svc:       pkg.ServiceName,
+					}
+					if i < len(spec.Names) && spec.Names[i].Name != "_" {
+						dd.ident = spec.Names[i].Name
+						dd.nameRange = rng(spec.Names[i])
+					}
+					idx.resolveMeta(dd)
+					idx.decls = append(idx.decls, dd)
+				}
+			}
+		}
+	}
+}
+
+// resolveMeta sets the resource metadata for d.
+func (idx *index) resolveMeta(d *decl) {
+	switch d.kind {
+	case topicDecl:
+		for _, t := range idx.md.PubsubTopics {
+			if t.Name == d.name {
+				d.topic = t
+			}
+		}
+	case subscriptionDecl:
+		for _, t := range idx.md.PubsubTopics {
+			for _, s := range t.Subscriptions {
+				if s.Name == d.name && s.ServiceName == d.svc {
+					d.topic, d.sub = t, s
+				}
+			}
+		}
+	case cacheClusterDecl:
+		for _, c := range idx.md.CacheClusters {
+			if c.Name == d.name {
+				d.cluster = c
+			}
+		}
+	case databaseDecl:
+		for _, db := range idx.md.SqlDatabases {
+			if db.Name == d.name {
+				d.db = db
+			}
+		}
+	}
+}
+
+// findRPC finds the RPC with the given name declared in the given file.
+func (idx *index) findRPC(svcName, pkgPath, filename, name string) *meta.RPC {
+	for _, svc := range idx.md.Svcs {
+		if svc.Name != svcName {
+			continue
+		}
+		for _, rpc := range svc.Rpcs {
+			if rpc.Name == name && rpc.Loc.GetPkgPath() == pkgPath && rpc.Loc.GetFilename() == filename {
+				return rpc
+			}
+		}
+	}
+	return nil
+}
+
+// importPath returns the import path of the app package with the given relative path.
+func (idx *index) importPath(relPath string) string {
+	if relPath == "." || relPath == "" {
+		return idx.md.ModulePath
+	}
+	return path.Join(idx.md.ModulePath, relPath)
+}
+
+// relPath returns the package path relative to the app root for the
+// package in the given directory, or false if it's outside the app.
+func (idx *index) relPath(dir string) (string, bool) {
+	rel, err := filepath.Rel(idx.root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// qualifiedName returns the qualified name ("import/path.Name") of
+// the package-level object referenced by expr, or "" if it's not
+// a reference to an imported object.
+func (idx *index) qualifiedName(f *ast.File, expr ast.Expr) string {
+	// Unwrap generic instantiations like pubsub.NewTopic[*Msg].
+	switch x := expr.(type) {
+	case *ast.IndexExpr:
+		expr = x.X
+	case *ast.IndexListExpr:
+		expr = x.X
+	}
+
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if importPath := idx.resolveImport(f, pkgIdent.Name); importPath != "" {
+		return importPath + "." + sel.Sel.Name
+	}
+	return ""
+}
+
+// resolveImport returns the import path of the package imported
+// by f under the given name, or "" if there is no such import.
+func (idx *index) resolveImport(f *ast.File, name string) string {
+	for _, imp := range f.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return importPath
+			}
+		} else if idx.packageName(importPath) == name {
+			return importPath
+		}
+	}
+	return ""
+}
+
+// packageName returns the package name for the given import path.
+// For packages outside the app it assumes the conventional name.
+func (idx *index) packageName(importPath string) string {
+	for _, pkg := range idx.md.Pkgs {
+		if idx.importPath(pkg.RelPath) == importPath {
+			return pkg.Name
+		}
+	}
+	return path.Base(importPath)
+}
+
+// lookup finds the declaration of the identifier ident in the package pkgPath.
+func (idx *index) lookup(pkgPath, ident string) *decl {
+	for _, d := range idx.decls {
+		if d.pkgPath == pkgPath && d.ident == ident {
+			return d
+		}
+	}
+	return nil
+}
+
+// declAt finds the resource variable declaration containing
+// the given offset in the given file.
+func (idx *index) declAt(file string, offset int) *decl {
+	for _, d := range idx.decls {
+		if d.kind != endpointDecl && d.file == file && d.start <= offset && offset < d.end {
+			return d
+		}
+	}
+	return nil
+}
+
+// databaseServices returns the names of the services using the given database.
+func (idx *index) databaseServices(dbName string) []string {
+	var svcs []string
+	for _, svc := range idx.md.Svcs {
+		if slices.Contains(svc.Databases, dbName) {
+			svcs = append(svcs, svc.Name)
+		}
+	}
+	return svcs
+}
@@ -0,0 +1,144 @@
+package lsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestPositions(t *testing.T) {
+	c := qt.New(t)
+	content := []byte("package foo\n\n// héllo 😀 world\nvar x = 1\n")
+
+	// The text preceding "world" on its line is 15 bytes,
+	// but only 12 UTF-16 code units.
+	worldOffset := strings.Index(string(content), "world")
+	pos := offsetPosition(content, worldOffset)
+	c.Assert(pos, qt.Equals, Position{Line: 2, Character: 12})
+	c.Assert(positionOffset(content, pos), qt.Equals, worldOffset)
+	c.Assert(lineColPosition(content, 3, 15+1), qt.Equals, pos)
+
+	// Positions past the end of a line are clamped.
+	c.Assert(positionOffset(content, Position{Line: 0, Character: 100}), qt.Equals, len("package foo"))
+	c.Assert(positionOffset(content, Position{Line: 100}), qt.Equals, len(content))
+}
+
+func TestURI(t *testing.T) {
+	if filepath.Separator != '/' {
+		t.Skip("unix paths only")
+	}
+	c := qt.New(t)
+	uri := pathURI("/path/to/my app/foo.go")
+	c.Assert(uri, qt.Equals, DocumentURI("file:///path/to/my%20app/foo.go"))
+	path, err := uri.Path()
+	c.Assert(err, qt.IsNil)
+	c.Assert(path, qt.Equals, "/path/to/my app/foo.go")
+}
+
+func TestResolve(t *testing.T) {
+	c := qt.New(t)
+	root := c.TempDir()
+	writeFile(c, root, "svc/svc.go", `package svc
+
+import (
+	"context"
+
+	"encore.dev/pubsub"
+)
+
+type Event struct{}
+
+var Events = pubsub.NewTopic[*Event]("events", pubsub.TopicConfig{})
+
+//encore:api public method=GET path=/hello/:name
+func Hello(ctx context.Context, name string) error { return nil }
+`)
+	writeFile(c, root, "other/other.go", `package other
+
+import (
+	"context"
+
+	"example.com/app/svc"
+)
+
+func Call(ctx context.Context) error {
+	_ = svc.Events
+	return svc.Hello(ctx, "x")
+}
+`)
+
+	md := &meta.Data{
+		ModulePath: "example.com/app",
+		Pkgs: []*meta.Package{
+			{RelPath: "svc", Name: "svc", ServiceName: "svc"},
+			{RelPath: "other", Name: "other"},
+		},
+		Svcs: []*meta.Service{{
+			Name:    "svc",
+			RelPath: "svc",
+			Rpcs: []*meta.RPC{{
+				Name:        "Hello",
+				ServiceName: "svc",
+				AccessType:  meta.RPC_PUBLIC,
+				HttpMethods: []string{"GET"},
+				Loc:         &schema.Loc{PkgPath: "example.com/app/svc", Filename: "svc.go"},
+				Path: &meta.Path{Segments: []*meta.PathSegment{
+					{Type: meta.PathSegment_LITERAL, Value: "hello"},
+					{Type: meta.PathSegment_PARAM, Value: "name"},
+				}},
+			}},
+		}},
+		PubsubTopics: []*meta.PubSubTopic{{Name: "events"}},
+	}
+
+	s := New(nil, nil)
+	s.idx = buildIndex(root, md)
+	c.Assert(s.idx.decls, qt.HasLen, 2)
+
+	otherURI := pathURI(filepath.Join(root, "other", "other.go"))
+	svcPath := filepath.Join(root, "svc", "svc.go")
+
+	// Hovering the endpoint call shows the endpoint docs.
+	hover := s.hover(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: otherURI},
+		Position:     Position{Line: 10, Character: 13},
+	})
+	c.Assert(hover, qt.IsNotNil)
+	c.Assert(hover.Contents.Value, qt.Contains, "`GET /hello/:name` · public")
+	c.Assert(*hover.Range, qt.Equals, Range{Start: Position{10, 12}, End: Position{10, 17}})
+
+	// Go-to-definition on the call jumps to the endpoint.
+	locs := s.definition(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: otherURI},
+		Position:     Position{Line: 10, Character: 13},
+	})
+	c.Assert(locs, qt.DeepEquals, []Location{{
+		URI:   pathURI(svcPath),
+		Range: Range{Start: Position{13, 5}, End: Position{13, 10}},
+	}})
+
+	// Hovering the topic reference shows the topic docs.
+	hover = s.hover(TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: otherURI},
+		Position:     Position{Line: 9, Character: 10},
+	})
+	c.Assert(hover, qt.IsNotNil)
+	c.Assert(hover.Contents.Value, qt.Contains, "**Pub/Sub topic** `events`")
+
+	// The topic is flagged as unused.
+	topic := s.idx.lookup("example.com/app/svc", "Events")
+	c.Assert(topic, qt.IsNotNil)
+	c.Assert(s.idx.unusedMessage(topic), qt.Equals, `Pub/Sub topic "events" is never used.`)
+}
+
+func writeFile(c *qt.C, root, path, content string) {
+	path = filepath.Join(root, filepath.FromSlash(path))
+	c.Assert(os.MkdirAll(filepath.Dir(path), 0755), qt.IsNil)
+	c.Assert(os.WriteFile(path, []byte(content), 0644), qt.IsNil)
+}
@@ -0,0 +1,95 @@
+package lsp
+
+import (
+	"bytes"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+)
+
+// offsetPosition converts a byte offset in content to a position.
+func offsetPosition(content []byte, offset int) Position {
+	offset = min(max(offset, 0), len(content))
+	lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+	return Position{
+		Line:      bytes.Count(content[:offset], []byte{'\n'}),
+		Character: utf16Len(content[lineStart:offset]),
+	}
+}
+
+// positionOffset converts a position to a byte offset in content.
+// Positions past the end of a line or the content are clamped.
+func positionOffset(content []byte, pos Position) int {
+	offset := 0
+	for i := 0; i < pos.Line; i++ {
+		idx := bytes.IndexByte(content[offset:], '\n')
+		if idx < 0 {
+			return len(content)
+		}
+		offset += idx + 1
+	}
+
+	for units := 0; units < pos.Character && offset < len(content) && content[offset] != '\n'; {
+		r, size := utf8.DecodeRune(content[offset:])
+		units += utf16RuneLen(r)
+		offset += size
+	}
+	return offset
+}
+
+// lineColPosition converts a one-based line and byte column,
+// as reported by the Go parser, to a position.
+func lineColPosition(content []byte, line, col int) Position {
+	offset := positionOffset(content, Position{Line: line - 1})
+	return offsetPosition(content, offset+max(col-1, 0))
+}
+
+func offsetRange(content []byte, start, end int) Range {
+	return Range{Start: offsetPosition(content, start), End: offsetPosition(content, end)}
+}
+
+func utf16Len(b []byte) (n int) {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		n += utf16RuneLen(r)
+		b = b[size:]
+	}
+	return n
+}
+
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// pathURI returns the file URI for the given absolute path.
+func pathURI(path string) DocumentURI {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		// Windows paths like "C:/foo" are written as "file:///C:/foo".
+		path = "/" + path
+	}
+	return DocumentURI((&url.URL{Scheme: "file", Path: path}).String())
+}
+
+// Path returns the file path the URI refers to.
+func (u DocumentURI) Path() (string, error) {
+	parsed, err := url.Parse(string(u))
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid document uri %q", u)
+	} else if parsed.Scheme != "file" {
+		return "", errors.Newf("unsupported document uri %q", u)
+	}
+
+	path := parsed.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	return filepath.Clean(filepath.FromSlash(path)), nil
+}
@@ -0,0 +1,205 @@
+// Package lsp implements the Encore language server.
+//
+// It complements the regular language server for the app's language with
+// Encore-specific diagnostics (parse errors, invalid directives and unused
+// infrastructure), hover documentation for API endpoints and infrastructure
+// resources, and go-to-definition for API calls.
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/internal/jsonrpc2"
+	"encr.dev/internal/version"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// ParseFunc parses the app, reporting its metadata.
+type ParseFunc func(ctx context.Context) (*meta.Data, error)
+
+// Server is a language server session for a single app.
+type Server struct {
+	app   *apps.Instance
+	parse ParseFunc
+	rpc   jsonrpc2.Conn
+
+	// analyze is signalled to re-analyze the app.
+	analyze chan struct{}
+
+	mu        sync.Mutex
+	docs      map[string]*document // open documents, keyed by path
+	idx       *index               // most recent index; nil until the app has parsed successfully
+	published map[string]bool      // paths with published diagnostics
+	exited    bool
+}
+
+type document struct {
+	uri     DocumentURI
+	content []byte
+}
+
+// New creates a new language server session for app.
+func New(app *apps.Instance, parse ParseFunc) *Server {
+	return &Server{
+		app:       app,
+		parse:     parse,
+		analyze:   make(chan struct{}, 1),
+		docs:      make(map[string]*document),
+		published: make(map[string]bool),
+	}
+}
+
+// Serve serves the language server protocol over conn until
+// the client exits or the connection is closed.
+func (s *Server) Serve(ctx context.Context, conn net.Conn) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.rpc = jsonrpc2.NewConn(jsonrpc2.NewHeaderStream(conn))
+	s.rpc.Go(ctx, s.handle)
+	go s.analyzeLoop(ctx)
+
+	select {
+	case <-s.rpc.Done():
+	case <-ctx.Done():
+		_ = s.rpc.Close()
+		<-s.rpc.Done()
+	}
+
+	s.mu.Lock()
+	exited := s.exited
+	s.mu.Unlock()
+	if exited {
+		return nil
+	}
+	return s.rpc.Err()
+}
+
+func (s *Server) handle(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+	switch req.Method() {
+	case "initialize":
+		return reply(ctx, InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync: TextDocumentSyncOptions{
+					OpenClose: true,
+					Change:    SyncFull,
+					Save:      &SaveOptions{},
+				},
+				HoverProvider:      true,
+				DefinitionProvider: true,
+			},
+			ServerInfo: ServerInfo{Name: "encore", Version: version.Version},
+		}, nil)
+
+	case "initialized", "workspace/didChangeWatchedFiles":
+		s.requestAnalysis()
+		return reply(ctx, nil, nil)
+
+	case "shutdown":
+		return reply(ctx, nil, nil)
+
+	case "exit":
+		s.mu.Lock()
+		s.exited = true
+		s.mu.Unlock()
+		return s.rpc.Close()
+
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		s.setDocument(params.TextDocument.URI, []byte(params.TextDocument.Text))
+		return reply(ctx, nil, nil)
+
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.setDocument(params.TextDocument.URI, []byte(params.ContentChanges[n-1].Text))
+		}
+		return reply(ctx, nil, nil)
+
+	case "textDocument/didSave":
+		// Diagnostics are based on the files on disk, so re-analyze on save.
+		s.requestAnalysis()
+		return reply(ctx, nil, nil)
+
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		if path, err := params.TextDocument.URI.Path(); err == nil {
+			s.mu.Lock()
+			delete(s.docs, path)
+			s.mu.Unlock()
+		}
+		return reply(ctx, nil, nil)
+
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		return reply(ctx, s.hover(params), nil)
+
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, err)
+		}
+		return reply(ctx, s.definition(params), nil)
+
+	default:
+		return jsonrpc2.MethodNotFound(ctx, reply, req)
+	}
+}
+
+func (s *Server) setDocument(uri DocumentURI, content []byte) {
+	path, err := uri.Path()
+	if err != nil {
+		log.Error().Err(err).Msg("lsp: invalid document")
+		return
+	}
+	s.mu.Lock()
+	s.docs[path] = &document{uri: uri, content: content}
+	s.mu.Unlock()
+}
+
+// contents returns the contents of the file at path, preferring the
+// contents of the open document over the file on disk.
+func (s *Server) contents(path string) ([]byte, error) {
+	s.mu.Lock()
+	doc := s.docs[path]
+	s.mu.Unlock()
+	if doc != nil {
+		return doc.content, nil
+	}
+	return os.ReadFile(path)
+}
+
+// uri returns the URI to report for the file at path.
+func (s *Server) uri(path string) DocumentURI {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if doc := s.docs[path]; doc != nil {
+		return doc.uri
+	}
+	return pathURI(path)
+}
+
+func (s *Server) index() *index {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.idx
+}
@@ -0,0 +1,19 @@
+package daemon
+
+import (
+	"context"
+
+	"encr.dev/cli/daemon/mcp"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// MCPConnect returns the URL of the MCP server for an app.
+func (s *Server) MCPConnect(ctx context.Context, req *daemonpb.MCPConnectRequest) (*daemonpb.MCPConnectResponse, error) {
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonpb.MCPConnectResponse{
+		Url: mcp.URL(s.mcpPort, app.PlatformOrLocalID()),
+	}, nil
+}
@@ -0,0 +1,216 @@
+// Package mcp implements a Model Context Protocol (MCP) server that
+// exposes Encore apps to AI tools: the service catalog, recent traces,
+// and read-only access to the app's local databases.
+//
+// It implements the Streamable HTTP transport without server-initiated
+// messages; see https://modelcontextprotocol.io/specification/2025-03-26/basic/transports.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/jsonrpc2"
+	"encr.dev/internal/version"
+)
+
+// DefaultPort is the port the daemon serves MCP on.
+const DefaultPort = 9900
+
+// protocolVersion is the latest MCP protocol version the server supports.
+const protocolVersion = "2025-03-26"
+
+// maxRequestSize is the maximum size of a request body.
+const maxRequestSize = 4 << 20
+
+// Server is the http.Handler for serving MCP.
+type Server struct {
+	apps *apps.Manager
+	run  *run.Manager
+	ns   *namespace.Manager
+	cm   *sqldb.ClusterManager
+	tr   trace2.Store
+}
+
+// NewServer creates a new MCP server.
+func NewServer(appsMgr *apps.Manager, runMgr *run.Manager, ns *namespace.Manager, cm *sqldb.ClusterManager, tr trace2.Store) *Server {
+	return &Server{
+		apps: appsMgr,
+		run:  runMgr,
+		ns:   ns,
+		cm:   cm,
+		tr:   tr,
+	}
+}
+
+// URL returns the URL for connecting to the MCP server for the given app.
+func URL(port int, appID string) string {
+	return fmt.Sprintf("http://localhost:%d/mcp?app=%s", port, url.QueryEscape(appID))
+}
+
+// ServeHTTP serves MCP requests on "/mcp?app=<app id>".
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/mcp" {
+		http.NotFound(w, req)
+		return
+	} else if !isLocalOrigin(req.Header.Get("Origin")) {
+		// Protect against DNS rebinding attacks from websites running in a browser.
+		http.Error(w, "forbidden origin", http.StatusForbidden)
+		return
+	} else if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	app, err := s.apps.FindLatestByPlatformOrLocalID(req.URL.Query().Get("app"))
+	if err != nil {
+		http.Error(w, "unknown app: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg, err := jsonrpc2.DecodeMessage(body)
+	if err != nil {
+		http.Error(w, "invalid JSON-RPC message: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	call, ok := msg.(*jsonrpc2.Call)
+	if !ok {
+		// Notifications and responses don't have a response.
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	result, err := s.handle(req.Context(), app, call.Method(), call.Params())
+	resp, err := jsonrpc2.NewResponse(call.ID(), result, err)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Error().Err(err).Msg("mcp: could not write response")
+	}
+}
+
+func (s *Server) handle(ctx context.Context, app *apps.Instance, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("%w: %v", jsonrpc2.ErrInvalidParams, err)
+		}
+		return map[string]any{
+			"protocolVersion": negotiateVersion(p.ProtocolVersion),
+			"capabilities": map[string]any{
+				"tools": map[string]any{},
+			},
+			"serverInfo": map[string]any{
+				"name":    "encore",
+				"version": version.Version,
+			},
+			"instructions": fmt.Sprintf("This server provides information about the Encore application %q, "+
+				"as it runs locally with 'encore run'.", app.Name()),
+		}, nil
+
+	case "ping":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		list := make([]map[string]any, 0, len(tools))
+		for _, t := range tools {
+			list = append(list, map[string]any{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.InputSchema,
+			})
+		}
+		return map[string]any{"tools": list}, nil
+
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, fmt.Errorf("%w: %v", jsonrpc2.ErrInvalidParams, err)
+		}
+		t, ok := findTool(p.Name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown tool %q", jsonrpc2.ErrInvalidParams, p.Name)
+		}
+		if len(p.Arguments) == 0 {
+			p.Arguments = json.RawMessage("{}")
+		}
+
+		// Tool errors are reported as part of the result,
+		// so the model can see them and self-correct.
+		res, err := t.Call(ctx, s, app, p.Arguments)
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return toolResult(string(data), false), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %q", jsonrpc2.ErrMethodNotFound, method)
+	}
+}
+
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// negotiateVersion returns the protocol version to use
+// given the version requested by the client.
+func negotiateVersion(requested string) string {
+	switch requested {
+	case "2024-11-05", "2025-03-26":
+		return requested
+	default:
+		return protocolVersion
+	}
+}
+
+// isLocalOrigin reports whether the given Origin header
+// is empty or refers to the local machine.
+func isLocalOrigin(origin string) bool {
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/internal/jsonrpc2"
+)
+
+func TestHandle(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	app := apps.NewInstance("/path/to/app", "local-id", "my-app")
+	s := &Server{}
+
+	call := func(method, params string) (map[string]any, error) {
+		res, err := s.handle(ctx, app, method, json.RawMessage(params))
+		if err != nil {
+			return nil, err
+		}
+		// Round-trip through JSON to inspect the result like a client would.
+		data, err := json.Marshal(res)
+		c.Assert(err, qt.IsNil)
+		var out map[string]any
+		c.Assert(json.Unmarshal(data, &out), qt.IsNil)
+		return out, nil
+	}
+
+	res, err := call("initialize", `{"protocolVersion": "2024-11-05"}`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res["protocolVersion"], qt.Equals, "2024-11-05")
+	c.Assert(res["serverInfo"].(map[string]any)["name"], qt.Equals, "encore")
+
+	res, err = call("initialize", `{"protocolVersion": "1999-01-01"}`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res["protocolVersion"], qt.Equals, protocolVersion)
+
+	res, err = call("tools/list", `{}`)
+	c.Assert(err, qt.IsNil)
+	var names []string
+	for _, t := range res["tools"].([]any) {
+		names = append(names, t.(map[string]any)["name"].(string))
+	}
+	c.Assert(names, qt.DeepEquals, []string{"get_services", "get_traces", "get_trace", "query_database"})
+
+	// Tool errors are reported in the result.
+	res, err = call("tools/call", `{"name": "get_trace", "arguments": {}}`)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res["isError"], qt.IsTrue)
+	c.Assert(res["content"].([]any)[0].(map[string]any)["text"], qt.Equals, "trace_id is required")
+
+	_, err = call("tools/call", `{"name": "unknown"}`)
+	c.Assert(errors.Is(err, jsonrpc2.ErrInvalidParams), qt.IsTrue)
+
+	_, err = call("resources/list", `{}`)
+	c.Assert(errors.Is(err, jsonrpc2.ErrMethodNotFound), qt.IsTrue)
+}
+
+func TestIsLocalOrigin(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(isLocalOrigin(""), qt.IsTrue)
+	c.Assert(isLocalOrigin("http://localhost:3000"), qt.IsTrue)
+	c.Assert(isLocalOrigin("http://127.0.0.1"), qt.IsTrue)
+	c.Assert(isLocalOrigin("http://[::1]:8080"), qt.IsTrue)
+	c.Assert(isLocalOrigin("https://example.com"), qt.IsFalse)
+	c.Assert(isLocalOrigin("http://localhost.example.com"), qt.IsFalse)
+}
@@ -0,0 +1,384 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/sqldb"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// tool is a tool the MCP server exposes.
+type tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any
+
+	// Call calls the tool with the given JSON-encoded arguments.
+	// The result is encoded as JSON and returned to the client.
+	Call func(ctx context.Context, s *Server, app *apps.Instance, args json.RawMessage) (any, error)
+}
+
+var tools = []*tool{
+	{
+		Name: "get_services",
+		Description: "Returns the services of the Encore app with their API endpoints, " +
+			"along with the infrastructure resources (databases, Pub/Sub topics, caches and cron jobs) the app uses.",
+		InputSchema: objectSchema(nil),
+		Call:        getServices,
+	},
+	{
+		Name: "get_traces",
+		Description: "Lists the most recent traces of requests to the locally running Encore app, " +
+			"newest first. Use get_trace to fetch the details of a trace.",
+		InputSchema: objectSchema(map[string]any{
+			"service":     prop("string", "Only include traces for this service."),
+			"endpoint":    prop("string", "Only include traces for this endpoint. Requires service."),
+			"errors_only": prop("boolean", "Only include traces that resulted in an error."),
+			"limit":       prop("integer", "The maximum number of traces to return (default 20, max 100)."),
+		}),
+		Call: getTraces,
+	},
+	{
+		Name: "get_trace",
+		Description: "Returns the events of a trace, including the spans, API calls, database queries, " +
+			"Pub/Sub messages and log messages that were recorded as part of it.",
+		InputSchema: objectSchema(map[string]any{
+			"trace_id": prop("string", "The id of the trace, as returned by get_traces."),
+		}, "trace_id"),
+		Call: getTrace,
+	},
+	{
+		Name: "query_database",
+		Description: "Runs a read-only SQL query against one of the app's local databases " +
+			"and returns the resulting rows (at most 100). The app must have been started with 'encore run'.",
+		InputSchema: objectSchema(map[string]any{
+			"database": prop("string", "The name of the database, as returned by get_services."),
+			"query":    prop("string", "The SQL query to run. The query runs in a read-only transaction."),
+		}, "database", "query"),
+		Call: queryDatabase,
+	},
+}
+
+func findTool(name string) (*tool, bool) {
+	for _, t := range tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func objectSchema(props map[string]any, required ...string) map[string]any {
+	if props == nil {
+		props = map[string]any{}
+	}
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func prop(typ, desc string) map[string]any {
+	return map[string]any{"type": typ, "description": desc}
+}
+
+// getMeta returns the metadata for the app, preferring the
+// metadata of the running app over the cached metadata.
+func (s *Server) getMeta(app *apps.Instance) (*meta.Data, error) {
+	if r := s.run.FindRunByAppID(app.PlatformOrLocalID()); r != nil {
+		if pg := r.ProcGroup(); pg != nil {
+			return pg.Meta, nil
+		}
+	}
+	md, err := app.CachedMetadata()
+	if err != nil {
+		return nil, err
+	} else if md == nil {
+		return nil, fmt.Errorf("the app has not been parsed yet; start it with 'encore run'")
+	}
+	return md, nil
+}
+
+type serviceInfo struct {
+	Name      string         `json:"name"`
+	RelPath   string         `json:"rel_path"`
+	Endpoints []endpointInfo `json:"endpoints"`
+	Databases []string       `json:"databases,omitempty"`
+}
+
+type endpointInfo struct {
+	Name    string   `json:"name"`
+	Access  string   `json:"access"`
+	Methods []string `json:"methods"`
+	Path    string   `json:"path"`
+	Raw     bool     `json:"raw,omitempty"`
+	Doc     string   `json:"doc,omitempty"`
+}
+
+type topicInfo struct {
+	Name          string   `json:"name"`
+	Publishers    []string `json:"publishers"`
+	Subscriptions []string `json:"subscriptions"`
+}
+
+type cronJobInfo struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Schedule string `json:"schedule"`
+	Endpoint string `json:"endpoint"`
+}
+
+func getServices(ctx context.Context, s *Server, app *apps.Instance, args json.RawMessage) (any, error) {
+	md, err := s.getMeta(app)
+	if err != nil {
+		return nil, err
+	}
+
+	svcs := make([]serviceInfo, 0, len(md.Svcs))
+	for _, svc := range md.Svcs {
+		info := serviceInfo{Name: svc.Name, RelPath: svc.RelPath, Endpoints: []endpointInfo{}}
+		for _, rpc := range svc.Rpcs {
+			info.Endpoints = append(info.Endpoints, endpointInfo{
+				Name:    rpc.Name,
+				Access:  strings.ToLower(rpc.AccessType.String()),
+				Methods: rpc.HttpMethods,
+				Path:    rpc.Path.Pattern(),
+				Raw:     rpc.Proto == meta.RPC_RAW,
+				Doc:     strings.TrimSpace(rpc.GetDoc()),
+			})
+		}
+		info.Databases = svc.Databases
+		svcs = append(svcs, info)
+	}
+
+	dbs := make([]string, 0, len(md.SqlDatabases))
+	for _, db := range md.SqlDatabases {
+		dbs = append(dbs, db.Name)
+	}
+
+	topics := make([]topicInfo, 0, len(md.PubsubTopics))
+	for _, t := range md.PubsubTopics {
+		info := topicInfo{Name: t.Name, Publishers: []string{}, Subscriptions: []string{}}
+		for _, p := range t.Publishers {
+			info.Publishers = append(info.Publishers, p.ServiceName)
+		}
+		for _, sub := range t.Subscriptions {
+			info.Subscriptions = append(info.Subscriptions, sub.ServiceName+"."+sub.Name)
+		}
+		topics = append(topics, info)
+	}
+
+	caches := make([]string, 0, len(md.CacheClusters))
+	for _, c := range md.CacheClusters {
+		caches = append(caches, c.Name)
+	}
+
+	crons := make([]cronJobInfo, 0, len(md.CronJobs))
+	for _, job := range md.CronJobs {
+		crons = append(crons, cronJobInfo{
+			ID:       job.Id,
+			Title:    job.Title,
+			Schedule: job.Schedule,
+			Endpoint: job.Endpoint.GetPkg() + "." + job.Endpoint.GetName(),
+		})
+	}
+
+	return map[string]any{
+		"app":           app.Name(),
+		"services":      svcs,
+		"databases":     dbs,
+		"pubsub_topics": topics,
+		"caches":        caches,
+		"cron_jobs":     crons,
+	}, nil
+}
+
+type traceSummary struct {
+	TraceID    string  `json:"trace_id"`
+	Service    string  `json:"service,omitempty"`
+	Endpoint   string  `json:"endpoint,omitempty"`
+	Type       string  `json:"type"`
+	StartedAt  string  `json:"started_at"`
+	DurationMs float64 `json:"duration_ms"`
+	IsError    bool    `json:"is_error"`
+}
+
+func getTraces(ctx context.Context, s *Server, app *apps.Instance, args json.RawMessage) (any, error) {
+	var params struct {
+		Service    string `json:"service"`
+		Endpoint   string `json:"endpoint"`
+		ErrorsOnly bool   `json:"errors_only"`
+		Limit      int    `json:"limit"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	}
+	if params.Limit <= 0 {
+		params.Limit = 20
+	} else if params.Limit > 100 {
+		params.Limit = 100
+	}
+
+	noTests := false
+	query := &trace2.Query{
+		AppID:      app.PlatformOrLocalID(),
+		Service:    params.Service,
+		Endpoint:   params.Endpoint,
+		TestFilter: &noTests,
+		Limit:      params.Limit,
+	}
+	if params.ErrorsOnly {
+		isError := true
+		query.IsError = &isError
+	}
+
+	traces := []traceSummary{}
+	err := s.tr.List(ctx, query, func(span *tracepb2.SpanSummary) bool {
+		sum := traceSummary{
+			TraceID:    span.TraceId,
+			Service:    span.GetServiceName(),
+			Endpoint:   span.GetEndpointName(),
+			Type:       strings.ToLower(span.Type.String()),
+			StartedAt:  span.StartedAt.AsTime().Format(time.RFC3339Nano),
+			IsError:    span.IsError,
+			DurationMs: float64(span.DurationNanos) / float64(time.Millisecond),
+		}
+		traces = append(traces, sum)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return traces, nil
+}
+
+func getTrace(ctx context.Context, s *Server, app *apps.Instance, args json.RawMessage) (any, error) {
+	var params struct {
+		TraceID string `json:"trace_id"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	} else if params.TraceID == "" {
+		return nil, fmt.Errorf("trace_id is required")
+	}
+
+	opts := protojson.MarshalOptions{UseProtoNames: true}
+	var events []json.RawMessage
+	var marshalErr error
+	err := s.tr.Get(ctx, app.PlatformOrLocalID(), params.TraceID, func(ev *tracepb2.TraceEvent) bool {
+		data, err := opts.Marshal(ev)
+		if err != nil {
+			marshalErr = err
+			return false
+		}
+		events = append(events, data)
+		return true
+	})
+	if err == nil {
+		err = marshalErr
+	}
+	if err != nil {
+		return nil, err
+	} else if len(events) == 0 {
+		return nil, fmt.Errorf("trace %q not found", params.TraceID)
+	}
+	return map[string]any{"trace_id": params.TraceID, "events": events}, nil
+}
+
+// maxRows is the maximum number of rows query_database returns.
+const maxRows = 100
+
+func queryDatabase(ctx context.Context, s *Server, app *apps.Instance, args json.RawMessage) (any, error) {
+	var params struct {
+		Database string `json:"database"`
+		Query    string `json:"query"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, err
+	} else if params.Database == "" || params.Query == "" {
+		return nil, fmt.Errorf("database and query are required")
+	}
+
+	ns, err := s.ns.GetActive(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+	cluster, ok := s.cm.Get(sqldb.GetClusterID(app, sqldb.Run, ns))
+	if !ok {
+		return nil, fmt.Errorf("the database cluster is not running; start the app with 'encore run'")
+	}
+	db, ok := cluster.GetDB(params.Database)
+	if !ok {
+		return nil, fmt.Errorf("database %q not found", params.Database)
+	}
+	info, err := cluster.Info(ctx)
+	if err != nil {
+		return nil, err
+	} else if info.Status != sqldb.Running {
+		return nil, fmt.Errorf("the database cluster is not running")
+	}
+	role, ok := info.Encore.First(sqldb.RoleRead, sqldb.RoleSuperuser)
+	if !ok {
+		return nil, fmt.Errorf("unable to find a role to connect to the database with")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	conn, err := pgx.Connect(ctx, info.ConnURI(db.ApplicationCloudName(), role))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	// Run the query in a read-only transaction that's always rolled back,
+	// so it can't modify the database even when connecting as a superuser.
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	rows, err := tx.Query(ctx, params.Query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for _, fd := range rows.FieldDescriptions() {
+		columns = append(columns, fd.Name)
+	}
+	result := [][]any{}
+	truncated := false
+	for rows.Next() {
+		if len(result) == maxRows {
+			truncated = true
+			break
+		}
+		vals, err := rows.Values()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, vals)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"columns":   columns,
+		"rows":      result,
+		"truncated": truncated,
+	}, nil
+}
@@ -0,0 +1,446 @@
+// Package metrics keeps short-term time series of the metrics of running apps,
+// so the local development dashboard can chart request rates, latencies and
+// custom metrics while the app is being exercised, such as during a load test.
+//
+// Request statistics are derived from the traces of the requests the app handles,
+// while custom metrics (and Go runtime metrics) are received from the app
+// using the Prometheus remote-write protocol.
+package metrics
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+// DefaultRetention is how long metrics are kept by default.
+const DefaultRetention = 30 * time.Minute
+
+// latencyBuckets are the upper bounds of the request latency histogram buckets.
+var latencyBuckets = [...]time.Duration{
+	1 * time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// Store stores the recent metrics of apps, keyed by app id.
+// It is safe for concurrent use.
+type Store struct {
+	retention time.Duration
+	now       func() time.Time // for testing
+
+	mu   sync.Mutex
+	apps map[string]*appMetrics
+}
+
+// NewStore returns a new store keeping metrics for the given retention period.
+func NewStore(retention time.Duration) *Store {
+	return &Store{
+		retention: retention,
+		now:       time.Now,
+		apps:      make(map[string]*appMetrics),
+	}
+}
+
+type appMetrics struct {
+	// requests are the request statistics per endpoint, keyed by
+	// the unix second the requests started.
+	requests map[endpointKey]map[int64]*requestBucket
+
+	// series are the custom metric series, keyed by seriesKey.
+	series map[string]*Series
+}
+
+type endpointKey struct {
+	service, endpoint string
+}
+
+type requestBucket struct {
+	count, errors int
+	total         time.Duration
+	hist          [len(latencyBuckets) + 1]int // the last bucket is +Inf
+}
+
+func (b *requestBucket) add(o *requestBucket) {
+	b.count += o.count
+	b.errors += o.errors
+	b.total += o.total
+	for i, n := range o.hist {
+		b.hist[i] += n
+	}
+}
+
+// quantile estimates the q-quantile of the request latencies,
+// interpolating linearly within the histogram bucket it falls in.
+func (b *requestBucket) quantile(q float64) time.Duration {
+	if b.count == 0 {
+		return 0
+	}
+	rank := q * float64(b.count)
+	var seen float64
+	for i, n := range b.hist {
+		if n == 0 {
+			continue
+		}
+		if seen+float64(n) >= rank {
+			if i == len(latencyBuckets) {
+				// There's no upper bound for the last bucket.
+				return latencyBuckets[i-1]
+			}
+			var lower time.Duration
+			if i > 0 {
+				lower = latencyBuckets[i-1]
+			}
+			frac := (rank - seen) / float64(n)
+			return lower + time.Duration(frac*float64(latencyBuckets[i]-lower))
+		}
+		seen += float64(n)
+	}
+	return latencyBuckets[len(latencyBuckets)-1]
+}
+
+// app returns the metrics for the given app, creating them if necessary.
+// s.mu must be held.
+func (s *Store) app(appID string) *appMetrics {
+	a, ok := s.apps[appID]
+	if !ok {
+		a = &appMetrics{
+			requests: make(map[endpointKey]map[int64]*requestBucket),
+			series:   make(map[string]*Series),
+		}
+		s.apps[appID] = a
+	}
+	return a
+}
+
+// ListenTraces records the request statistics of the
+// requests completed by apps, as they are written to tr.
+func (s *Store) ListenTraces(tr trace2.Store) {
+	ch := make(chan trace2.NewSpanEvent, 100)
+	tr.Listen(ch)
+	go func() {
+		for ev := range ch {
+			if !ev.TestTrace {
+				s.RecordSpan(ev.AppID, ev.Span)
+			}
+		}
+	}()
+}
+
+// RecordSpan records a completed span in the request statistics.
+// Spans other than API requests are ignored.
+func (s *Store) RecordSpan(appID string, sp *tracepb2.SpanSummary) {
+	if sp.Type != tracepb2.SpanSummary_REQUEST || sp.StartedAt == nil {
+		return
+	}
+	start := sp.StartedAt.AsTime()
+	now := s.now()
+	if start.Before(now.Add(-s.retention)) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := s.app(appID)
+	key := endpointKey{service: sp.ServiceName, endpoint: sp.GetEndpointName()}
+	buckets, ok := a.requests[key]
+	if !ok {
+		buckets = make(map[int64]*requestBucket)
+		a.requests[key] = buckets
+	}
+	sec := start.Unix()
+	b, ok := buckets[sec]
+	if !ok {
+		b = &requestBucket{}
+		buckets[sec] = b
+		// Expire old buckets as new ones are added.
+		cutoff := now.Add(-s.retention).Unix()
+		for t := range buckets {
+			if t < cutoff {
+				delete(buckets, t)
+			}
+		}
+	}
+
+	dur := time.Duration(sp.DurationNanos)
+	b.count++
+	b.total += dur
+	if sp.IsError {
+		b.errors++
+	}
+	idx, _ := slices.BinarySearch(latencyBuckets[:], dur)
+	b.hist[idx]++
+}
+
+// RequestQuery describes which request statistics to return.
+type RequestQuery struct {
+	// Service and Endpoint filter the requests to include.
+	// If empty, requests to all services and endpoints are included.
+	Service  string
+	Endpoint string
+
+	// Since and Until is the time range to return statistics for.
+	// They default to the last five minutes.
+	Since, Until time.Time
+
+	// Step is the interval between data points.
+	// If zero it's chosen to return at most 120 data points.
+	Step time.Duration
+}
+
+// maxRequestPoints is the maximum number of data points returned by Requests.
+// Longer steps are used for queries that would return more.
+const maxRequestPoints = 1000
+
+// RequestPoint is a data point of request statistics,
+// covering the requests started in the step starting at Time.
+type RequestPoint struct {
+	Time      time.Time `json:"time"`
+	Requests  int       `json:"requests"`
+	Errors    int       `json:"errors"`
+	Rate      float64   `json:"rate"`       // requests per second
+	ErrorRate float64   `json:"error_rate"` // fraction of requests that failed
+	AvgMs     float64   `json:"avg_ms"`
+	P50Ms     float64   `json:"p50_ms"`
+	P90Ms     float64   `json:"p90_ms"`
+	P99Ms     float64   `json:"p99_ms"`
+}
+
+// Requests returns the request statistics of an app over time,
+// with one data point per step in the queried time range.
+func (s *Store) Requests(appID string, q *RequestQuery) []*RequestPoint {
+	// Only metrics within the retention period are kept,
+	// so limit the range to it.
+	now := s.now()
+	until := q.Until
+	if until.IsZero() || until.After(now) {
+		until = now
+	}
+	since := q.Since
+	if since.IsZero() {
+		since = until.Add(-5 * time.Minute)
+	}
+	if oldest := now.Add(-s.retention); since.Before(oldest) {
+		since = oldest
+	}
+	step := q.Step
+	if step <= 0 {
+		step = max(time.Second, (until.Sub(since)/120 + time.Second - 1).Truncate(time.Second))
+	} else if minStep := until.Sub(since) / (maxRequestPoints - 1); step < minStep {
+		// Aligning since to the step can add a point, hence maxRequestPoints-1.
+		step = (minStep + time.Second).Truncate(time.Second)
+	}
+	step = min(step, s.retention)
+	since = since.Truncate(step)
+	if !until.After(since) {
+		return []*RequestPoint{}
+	}
+
+	n := int((until.Sub(since) + step - 1) / step)
+	steps := make([]requestBucket, n)
+
+	s.mu.Lock()
+	if a, ok := s.apps[appID]; ok {
+		for key, buckets := range a.requests {
+			if (q.Service != "" && key.service != q.Service) || (q.Endpoint != "" && key.endpoint != q.Endpoint) {
+				continue
+			}
+			for sec, b := range buckets {
+				t := time.Unix(sec, 0)
+				if t.Before(since) || !t.Before(until) {
+					continue
+				}
+				steps[int(t.Sub(since)/step)].add(b)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	points := make([]*RequestPoint, n)
+	for i := range steps {
+		b := &steps[i]
+		p := &RequestPoint{
+			Time:     since.Add(time.Duration(i) * step),
+			Requests: b.count,
+			Errors:   b.errors,
+			Rate:     float64(b.count) / step.Seconds(),
+		}
+		if b.count > 0 {
+			p.ErrorRate = float64(b.errors) / float64(b.count)
+			p.AvgMs = ms(b.total / time.Duration(b.count))
+			p.P50Ms = ms(b.quantile(0.5))
+			p.P90Ms = ms(b.quantile(0.9))
+			p.P99Ms = ms(b.quantile(0.99))
+		}
+		points[i] = p
+	}
+	return points
+}
+
+func ms(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+// Series is a time series of a custom metric.
+type Series struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels"`
+	Points []Point           `json:"points"`
+}
+
+// Point is a data point of a time series.
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// seriesKey returns the key identifying the series with the given name and labels.
+func seriesKey(name string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteByte('\x00')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+	}
+	return b.String()
+}
+
+// AddPoint adds a data point to the series with the given name and labels.
+func (s *Store) AddPoint(appID, name string, labels map[string]string, p Point) {
+	now := s.now()
+	cutoff := now.Add(-s.retention)
+	if p.Time.Before(cutoff) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := s.app(appID)
+	key := seriesKey(name, labels)
+	ser, ok := a.series[key]
+	if !ok {
+		ser = &Series{Name: name, Labels: labels}
+		a.series[key] = ser
+	}
+
+	// Points are usually added in order; insert them in place otherwise.
+	idx := len(ser.Points)
+	for idx > 0 && ser.Points[idx-1].Time.After(p.Time) {
+		idx--
+	}
+	ser.Points = slices.Insert(ser.Points, idx, p)
+
+	// Expire old points.
+	expired := 0
+	for expired < len(ser.Points) && ser.Points[expired].Time.Before(cutoff) {
+		expired++
+	}
+	if expired > 0 {
+		ser.Points = slices.Delete(ser.Points, 0, expired)
+	}
+}
+
+// MetricInfo describes a custom metric.
+type MetricInfo struct {
+	Name   string   `json:"name"`
+	Series int      `json:"series"` // number of series
+	Labels []string `json:"labels"` // label names used by the series
+}
+
+// List lists the custom metrics of an app, sorted by name.
+func (s *Store) List(appID string) []*MetricInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byName := make(map[string]*MetricInfo)
+	list := []*MetricInfo{}
+	if a, ok := s.apps[appID]; ok {
+		for _, ser := range a.series {
+			info, ok := byName[ser.Name]
+			if !ok {
+				info = &MetricInfo{Name: ser.Name, Labels: []string{}}
+				byName[ser.Name] = info
+				list = append(list, info)
+			}
+			info.Series++
+			for k := range ser.Labels {
+				if !slices.Contains(info.Labels, k) {
+					info.Labels = append(info.Labels, k)
+				}
+			}
+		}
+	}
+	for _, info := range list {
+		sort.Strings(info.Labels)
+	}
+	slices.SortFunc(list, func(a, b *MetricInfo) int { return strings.Compare(a.Name, b.Name) })
+	return list
+}
+
+// Series returns the series of the custom metric with the given name
+// that have all the given labels, with the data points since the given time.
+func (s *Store) Series(appID, name string, labels map[string]string, since time.Time) []*Series {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []*Series{}
+	a, ok := s.apps[appID]
+	if !ok {
+		return list
+	}
+Outer:
+	for _, ser := range a.series {
+		if ser.Name != name {
+			continue
+		}
+		for k, v := range labels {
+			if ser.Labels[k] != v {
+				continue Outer
+			}
+		}
+		idx, _ := slices.BinarySearchFunc(ser.Points, since, func(p Point, t time.Time) int {
+			return p.Time.Compare(t)
+		})
+		list = append(list, &Series{
+			Name:   ser.Name,
+			Labels: ser.Labels,
+			Points: slices.Clone(ser.Points[idx:]),
+		})
+	}
+	slices.SortFunc(list, func(a, b *Series) int {
+		return strings.Compare(seriesKey(a.Name, a.Labels), seriesKey(b.Name, b.Labels))
+	})
+	return list
+}
+
+// Clear removes all metrics for an app.
+func (s *Store) Clear(appID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.apps, appID)
+}
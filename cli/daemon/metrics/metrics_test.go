@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"encore.dev/appruntime/infrasdk/metrics/prometheus/prompb"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+)
+
+func TestRequests(t *testing.T) {
+	c := qt.New(t)
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	s := NewStore(time.Hour)
+	s.now = func() time.Time { return now }
+
+	span := func(offset time.Duration, endpoint string, dur time.Duration, isError bool) *tracepb2.SpanSummary {
+		return &tracepb2.SpanSummary{
+			Type:          tracepb2.SpanSummary_REQUEST,
+			StartedAt:     timestamppb.New(now.Add(offset)),
+			DurationNanos: uint64(dur),
+			ServiceName:   "orders",
+			EndpointName:  &endpoint,
+			IsError:       isError,
+		}
+	}
+	for i := 0; i < 9; i++ {
+		s.RecordSpan("app", span(-50*time.Second, "Get", 3*time.Millisecond, false))
+	}
+	s.RecordSpan("app", span(-45*time.Second, "Get", 2*time.Second, true))
+	s.RecordSpan("app", span(-5*time.Second, "Place", 40*time.Millisecond, false))
+	s.RecordSpan("app", span(-2*time.Hour, "Place", time.Millisecond, false)) // expired
+	s.RecordSpan("app", &tracepb2.SpanSummary{Type: tracepb2.SpanSummary_PUBSUB_MESSAGE, StartedAt: timestamppb.New(now)})
+
+	points := s.Requests("app", &RequestQuery{Since: now.Add(-time.Minute), Step: 20 * time.Second})
+	c.Assert(points, qt.HasLen, 3)
+	c.Assert(points[0].Time, qt.Equals, now.Add(-time.Minute))
+	c.Assert(points[0].Requests, qt.Equals, 10)
+	c.Assert(points[0].Errors, qt.Equals, 1)
+	c.Assert(points[0].Rate, qt.Equals, 0.5)
+	c.Assert(points[0].ErrorRate, qt.Equals, 0.1)
+	c.Assert(points[0].AvgMs, qt.Equals, 202.7)
+	c.Assert(points[0].P50Ms, qt.Equals, 3.667)
+	c.Assert(points[0].P99Ms, qt.Equals, 2350.0)
+	c.Assert(points[1].Requests, qt.Equals, 0)
+	c.Assert(points[2].Requests, qt.Equals, 1)
+
+	points = s.Requests("app", &RequestQuery{Endpoint: "Place", Since: now.Add(-time.Minute), Step: 20 * time.Second})
+	c.Assert(points[0].Requests, qt.Equals, 0)
+	c.Assert(points[2].Requests, qt.Equals, 1)
+	c.Assert(points[2].P90Ms, qt.Equals, 47.5)
+
+	// The step defaults to whole seconds, for at most 120 data points.
+	c.Assert(s.Requests("app", &RequestQuery{}), qt.HasLen, 100)
+	c.Assert(s.Requests("other", &RequestQuery{Step: time.Minute})[0].Requests, qt.Equals, 0)
+
+	// Queries are limited to the retention period, and to a maximum number of data points.
+	points = s.Requests("app", &RequestQuery{Since: time.Unix(0, 0), Until: now.Add(1000 * time.Hour), Step: time.Second})
+	c.Assert(len(points) <= maxRequestPoints, qt.IsTrue)
+	c.Assert(points[0].Time.Before(now.Add(-time.Hour)), qt.IsFalse)
+	c.Assert(points[len(points)-1].Time.After(now), qt.IsFalse)
+}
+
+func TestRemoteWrite(t *testing.T) {
+	c := qt.New(t)
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	s := NewStore(time.Hour)
+	s.now = func() time.Time { return now }
+
+	series := func(name, svc string, values ...float64) *prompb.TimeSeries {
+		ts := &prompb.TimeSeries{Labels: []*prompb.Label{
+			{Name: "__name__", Value: name},
+			{Name: "service", Value: svc},
+		}}
+		for i, v := range values {
+			ts.Samples = append(ts.Samples, &prompb.Sample{
+				Value:     v,
+				Timestamp: now.Add(time.Duration(i-len(values)) * time.Second).UnixMilli(),
+			})
+		}
+		return ts
+	}
+	data, err := proto.Marshal(&prompb.WriteRequest{Timeseries: []*prompb.TimeSeries{
+		series("orders_placed", "orders", 1, 3, 7),
+		series("orders_placed", "billing", 2),
+		series("e_sys_goroutines", "", 12),
+	}})
+	c.Assert(err, qt.IsNil)
+
+	req := httptest.NewRequest("POST", "/metrics", bytes.NewReader(snappy.Encode(nil, data)))
+	req.Header.Set("Content-Encoding", "snappy")
+	w := httptest.NewRecorder()
+	s.ServeRemoteWrite(w, req, "app")
+	c.Assert(w.Code, qt.Equals, http.StatusNoContent)
+
+	req = httptest.NewRequest("POST", "/metrics", bytes.NewReader([]byte("garbage")))
+	req.Header.Set("Content-Encoding", "snappy")
+	w = httptest.NewRecorder()
+	s.ServeRemoteWrite(w, req, "app")
+	c.Assert(w.Code, qt.Equals, http.StatusBadRequest)
+
+	c.Assert(s.List("app"), qt.DeepEquals, []*MetricInfo{
+		{Name: "e_sys_goroutines", Series: 1, Labels: []string{"service"}},
+		{Name: "orders_placed", Series: 2, Labels: []string{"service"}},
+	})
+
+	got := s.Series("app", "orders_placed", map[string]string{"service": "orders"}, now.Add(-2*time.Second))
+	c.Assert(got, qt.HasLen, 1)
+	c.Assert(got[0].Points, qt.DeepEquals, []Point{
+		{Time: now.Add(-2 * time.Second), Value: 3},
+		{Time: now.Add(-time.Second), Value: 7},
+	})
+	c.Assert(s.Series("app", "orders_placed", nil, time.Time{}), qt.HasLen, 2)
+
+	// Points that arrive out of order are kept sorted.
+	s.AddPoint("app", "orders_placed", map[string]string{"service": "billing"}, Point{Time: now.Add(-5 * time.Second), Value: 1})
+	got = s.Series("app", "orders_placed", map[string]string{"service": "billing"}, time.Time{})
+	c.Assert(got[0].Points[0].Value, qt.Equals, 1.0)
+
+	s.Clear("app")
+	c.Assert(s.List("app"), qt.HasLen, 0)
+}
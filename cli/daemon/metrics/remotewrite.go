@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/proto"
+
+	"encore.dev/appruntime/infrasdk/metrics/prometheus/prompb"
+)
+
+// maxRemoteWriteSize is the maximum size of a remote-write request body.
+const maxRemoteWriteSize = 32 << 20
+
+// ServeRemoteWrite serves a Prometheus remote-write request from the given app,
+// adding the received samples to the store.
+func (s *Store) ServeRemoteWrite(w http.ResponseWriter, req *http.Request, appID string) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	wr, err := decodeRemoteWrite(req)
+	if err != nil {
+		http.Error(w, "unable to parse remote-write request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.Write(appID, wr.Timeseries)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeRemoteWrite(req *http.Request) (*prompb.WriteRequest, error) {
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxRemoteWriteSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "read body")
+	}
+	if req.Header.Get("Content-Encoding") == "snappy" {
+		data, err = snappy.Decode(nil, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "decompress body")
+		}
+	}
+	var wr prompb.WriteRequest
+	if err := proto.Unmarshal(data, &wr); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return &wr, nil
+}
+
+// Write adds the samples of the given time series to the store.
+// The metric name is read from the "__name__" label.
+func (s *Store) Write(appID string, series []*prompb.TimeSeries) {
+	for _, ts := range series {
+		var name string
+		labels := make(map[string]string, len(ts.Labels))
+		for _, l := range ts.Labels {
+			if l.Name == "__name__" {
+				name = l.Value
+			} else {
+				labels[l.Name] = l.Value
+			}
+		}
+		if name == "" {
+			continue
+		}
+		for _, sample := range ts.Samples {
+			s.AddPoint(appID, name, labels, Point{
+				Time:  time.UnixMilli(sample.Timestamp),
+				Value: sample.Value,
+			})
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Package mgmtdb defines the schema of the daemon's management database,
+// the SQLite database where the daemon persists its state.
+package mgmtdb
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed migrations
+var dbMigrations embed.FS
+
+// Migrate applies the schema migrations to the management database.
+func Migrate(db *sql.DB) error {
+	{
+		// Convert old-style schema definition to golang-migrate, if necessary.
+		var isLegacy bool
+		err := db.QueryRow(`
+			SELECT COUNT(*) > 0 FROM pragma_table_info('schema_migrations') WHERE name = 'dummy'
+		`).Scan(&isLegacy)
+		if err != nil {
+			return err
+		} else if isLegacy {
+			_, _ = db.Exec("DROP TABLE schema_migrations;")
+		}
+	}
+
+	src, err := iofs.New(dbMigrations, "migrations")
+	if err != nil {
+		return fmt.Errorf("read db migrations: %v", err)
+	}
+	instance, err := sqlite3.WithInstance(db, &sqlite3.Config{})
+	if err != nil {
+		return fmt.Errorf("initialize migration instance: %v", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "encore", instance)
+	if err != nil {
+		return fmt.Errorf("setup migrate instance: %v", err)
+	}
+
+	err = m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	// If we have a dirty migration, reset the dirty flag and try again.
+	// This is safe since all migrations run inside transactions.
+	var dirty migrate.ErrDirty
+	if errors.As(err, &dirty) {
+		// Find the version that preceded the dirty version so
+		// we can force the migration to that version and then
+		// re-apply the migration.
+		var prevVer uint
+		prevVer, err = src.Prev(uint(dirty.Version))
+		targetVer := int(prevVer)
+		if errors.Is(err, fs.ErrNotExist) {
+			// No previous migration exists
+			targetVer = database.NilVersion
+		} else if err != nil {
+			return errors.Wrap(err, "failed to find previous version")
+		}
+
+		if err = m.Force(targetVer); err == nil {
+			err = m.Up()
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,30 @@
+// Package mgmtdbtest provides an in-memory management database for tests.
+package mgmtdbtest
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3" // for "sqlite3" driver
+
+	"encr.dev/cli/daemon/mgmtdb"
+)
+
+// New returns a new, migrated in-memory management database,
+// which is closed when the test ends.
+func New(t testing.TB) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// Each connection to an in-memory database gets a database of its own.
+	db.SetMaxOpenConns(1)
+
+	if err := mgmtdb.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
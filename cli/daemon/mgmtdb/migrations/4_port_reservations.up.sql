@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS port_reservation (
+    app_id TEXT NOT NULL, -- platform_id or local_id
+    namespace_id TEXT NOT NULL,
+    name TEXT NOT NULL, -- what the port is used for, e.g. "api"
+    port INTEGER NOT NULL,
+    reserved_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (app_id, namespace_id, name),
+    UNIQUE (port)
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS request_collection (
+    id TEXT PRIMARY KEY,
+    app_id TEXT NOT NULL, -- platform_id or local_id
+    name TEXT NOT NULL,
+    requests TEXT NOT NULL, -- JSON-encoded list of requests
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS request_collection_app_id ON request_collection (app_id);
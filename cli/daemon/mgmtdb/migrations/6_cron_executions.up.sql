@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS cron_execution (
+    id TEXT PRIMARY KEY,
+    app_id TEXT NOT NULL, -- platform_id or local_id
+    job_id TEXT NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    duration_nanos INTEGER NOT NULL,
+    status_code INTEGER NOT NULL, -- 0 if the request failed
+    error TEXT NOT NULL,
+    trace_id TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS cron_execution_app_job ON cron_execution (app_id, job_id, started_at);
@@ -0,0 +1,7 @@
+ALTER TABLE trace_span_index ADD COLUMN http_status_code INTEGER NULL;
+ALTER TABLE trace_span_index ADD COLUMN error_code TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS trace_span_index_started_at ON trace_span_index (app_id, started_at);
+CREATE INDEX IF NOT EXISTS trace_span_index_endpoint ON trace_span_index (app_id, service_name, endpoint_name, started_at);
+CREATE INDEX IF NOT EXISTS trace_span_index_duration ON trace_span_index (app_id, duration_nanos);
+CREATE INDEX IF NOT EXISTS trace_span_index_user_id ON trace_span_index (app_id, user_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS audit_entry (
+    app_id TEXT NOT NULL, -- platform_id or local_id
+    seq INTEGER NOT NULL,
+    id TEXT NOT NULL,
+    time TIMESTAMP NOT NULL,
+    actor_user_id TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target TEXT NOT NULL,
+    data TEXT NOT NULL, -- the JSON-encoded entry, as hashed
+    PRIMARY KEY (app_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS audit_entry_time ON audit_entry (app_id, time);
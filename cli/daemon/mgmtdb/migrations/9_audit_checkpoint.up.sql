@@ -0,0 +1,7 @@
+-- The last entry removed from the start of an app's audit log by the retention policy,
+-- which anchors the hash chain of the remaining entries.
+CREATE TABLE IF NOT EXISTS audit_checkpoint (
+    app_id TEXT NOT NULL PRIMARY KEY, -- platform_id or local_id
+    seq INTEGER NOT NULL,
+    hash TEXT NOT NULL
+);
@@ -0,0 +1,164 @@
+// Package notify shows desktop notifications when locally running apps
+// break, so developers working in another window notice immediately.
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/engine/trace2"
+	"encr.dev/cli/daemon/run"
+	"encr.dev/cli/internal/desktop"
+	"encr.dev/internal/conf"
+	"encr.dev/pkg/errlist"
+	tracepb2 "encr.dev/proto/encore/engine/trace2"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// throttle is the minimum time between identical notifications,
+// to avoid flooding the user when something fails repeatedly.
+const throttle = 30 * time.Second
+
+// Notifier shows desktop notifications when a watched rebuild fails,
+// a service crashes or a cron job fails.
+//
+// Notifications are opt-in; see conf.DaemonConfig.Notifications.
+type Notifier struct {
+	mgr     *run.Manager
+	traceCh chan trace2.NewSpanEvent
+
+	enabled func() bool
+	send    func(title, message string) error
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time // notification key -> when it was last sent
+}
+
+// New creates a new Notifier listening for events from mgr and tr.
+func New(mgr *run.Manager, tr trace2.Store) *Notifier {
+	n := &Notifier{
+		mgr:      mgr,
+		traceCh:  make(chan trace2.NewSpanEvent, 10),
+		enabled:  enabledInConfig,
+		send:     desktop.Notify,
+		lastSent: make(map[string]time.Time),
+	}
+	mgr.AddListener(n)
+	tr.Listen(n.traceCh)
+	go n.listenTraces()
+	return n
+}
+
+// enabledInConfig reports whether notifications are enabled in the daemon config.
+// The config is read each time so that changes take effect without a restart.
+func enabledInConfig() bool {
+	cfg, err := conf.LoadDaemonConfig()
+	return err == nil && cfg.Notifications
+}
+
+var _ run.EventListener = (*Notifier)(nil)
+
+// OnError notifies that a rebuild failed.
+func (n *Notifier) OnError(r *run.Run, err *errlist.List) {
+	if err == nil || err.Len() == 0 {
+		return
+	}
+	msg := err.List[0].Params.Title
+	if err.Len() > 1 {
+		msg += fmt.Sprintf(" (and %d more errors)", err.Len()-1)
+	}
+	n.notify(r.App.PlatformOrLocalID()+"/build", r.App.Name()+": build failed", msg)
+}
+
+// OnCrash notifies that a process exited unexpectedly.
+func (n *Notifier) OnCrash(r *run.Run, process string, err error) {
+	n.notify(r.App.PlatformOrLocalID()+"/crash/"+process,
+		r.App.Name()+": "+process+" crashed",
+		fmt.Sprintf("The %s process exited unexpectedly: %v", process, err))
+}
+
+func (n *Notifier) OnStart(r *run.Run)              {}
+func (n *Notifier) OnCompileStart(r *run.Run)       {}
+func (n *Notifier) OnReload(r *run.Run)             {}
+func (n *Notifier) OnStop(r *run.Run)               {}
+func (n *Notifier) OnStdout(r *run.Run, out []byte) {}
+func (n *Notifier) OnStderr(r *run.Run, out []byte) {}
+
+// listenTraces notifies about failed cron job executions.
+func (n *Notifier) listenTraces() {
+	for ev := range n.traceCh {
+		sp := ev.Span
+		if ev.TestTrace || sp.Type != tracepb2.SpanSummary_REQUEST || !sp.IsRoot || !sp.IsError || sp.EndpointName == nil {
+			continue
+		}
+		r := n.mgr.FindRunByAppID(ev.AppID)
+		if r == nil {
+			continue
+		}
+		pg := r.ProcGroup()
+		if pg == nil {
+			continue
+		}
+		if job := findCronJob(pg.Meta, sp.ServiceName, *sp.EndpointName); job != nil {
+			n.notify(ev.AppID+"/cron/"+job.Id,
+				r.App.Name()+": cron job failed",
+				fmt.Sprintf("The cron job %q (%s.%s) returned an error.", job.Title, sp.ServiceName, *sp.EndpointName))
+		}
+	}
+}
+
+// findCronJob finds the cron job that calls the given endpoint.
+// If there is none it reports nil.
+func findCronJob(md *meta.Data, service, endpoint string) *meta.CronJob {
+	if md == nil {
+		return nil
+	}
+	var relPath string
+	for _, svc := range md.Svcs {
+		if svc.Name == service {
+			relPath = svc.RelPath
+			break
+		}
+	}
+	if relPath == "" {
+		return nil
+	}
+
+	for _, job := range md.CronJobs {
+		ep := job.Endpoint
+		if ep == nil || ep.Name != endpoint {
+			continue
+		}
+		if ep.Pkg == relPath || strings.HasPrefix(ep.Pkg, relPath+"/") {
+			return job
+		}
+	}
+	return nil
+}
+
+// notify shows a notification, unless notifications are disabled
+// or a notification with the same key was recently shown.
+func (n *Notifier) notify(key, title, message string) {
+	if !n.enabled() {
+		return
+	}
+
+	n.mu.Lock()
+	now := time.Now()
+	if last, ok := n.lastSent[key]; ok && now.Sub(last) < throttle {
+		n.mu.Unlock()
+		return
+	}
+	n.lastSent[key] = now
+	n.mu.Unlock()
+
+	go func() {
+		if err := n.send(title, message); err != nil {
+			log.Debug().Err(err).Msg("unable to show desktop notification")
+		}
+	}()
+}
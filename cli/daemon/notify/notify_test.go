@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestFindCronJob(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "billing", RelPath: "billing"},
+			{Name: "email", RelPath: "email"},
+		},
+		CronJobs: []*meta.CronJob{
+			{Id: "charge", Endpoint: &meta.QualifiedName{Pkg: "billing", Name: "Charge"}},
+			{Id: "digest", Endpoint: &meta.QualifiedName{Pkg: "email/digest", Name: "Send"}},
+		},
+	}
+
+	c.Assert(findCronJob(md, "billing", "Charge").GetId(), qt.Equals, "charge")
+	c.Assert(findCronJob(md, "email", "Send").GetId(), qt.Equals, "digest")
+	c.Assert(findCronJob(md, "billing", "Refund"), qt.IsNil)
+	c.Assert(findCronJob(md, "email", "Charge"), qt.IsNil)
+	c.Assert(findCronJob(nil, "billing", "Charge"), qt.IsNil)
+}
+
+func TestNotifyThrottle(t *testing.T) {
+	c := qt.New(t)
+	sent := make(chan string, 10)
+	enabled := true
+	n := &Notifier{
+		enabled:  func() bool { return enabled },
+		send:     func(title, message string) error { sent <- title; return nil },
+		lastSent: make(map[string]time.Time),
+	}
+
+	n.notify("app/build", "build failed", "")
+	n.notify("app/build", "build failed", "")
+	n.notify("app/crash/foo", "foo crashed", "")
+	enabled = false
+	n.notify("app/crash/bar", "bar crashed", "")
+
+	got := map[string]bool{<-sent: true, <-sent: true}
+	c.Assert(got, qt.DeepEquals, map[string]bool{"build failed": true, "foo crashed": true})
+	select {
+	case title := <-sent:
+		c.Fatalf("unexpected notification %q", title)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,47 @@
+package ports
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// Process describes a process holding a port.
+type Process struct {
+	PID  int
+	Name string // may be empty if unknown
+}
+
+func (p *Process) String() string {
+	if p.Name == "" {
+		return fmt.Sprintf("process %d", p.PID)
+	}
+	return fmt.Sprintf("%s (pid %d)", p.Name, p.PID)
+}
+
+// FindHolder attempts to find the process listening on the given TCP port.
+// It reports nil if the holder could not be determined, which is common
+// when the process is owned by another user.
+func FindHolder(port int) (*Process, error) {
+	return findHolder(port)
+}
+
+// IsAddrInUse reports whether the error is due to the address already being in use.
+func IsAddrInUse(err error) bool {
+	if opErr, ok := err.(*net.OpError); ok {
+		if syscallErr, ok := opErr.Err.(*os.SyscallError); ok {
+			if errno, ok := syscallErr.Err.(syscall.Errno); ok {
+				const WSAEADDRINUSE = 10048
+				switch {
+				case errno == syscall.EADDRINUSE:
+					return true
+				case runtime.GOOS == "windows" && errno == WSAEADDRINUSE:
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+//go:build !windows
+// +build !windows
+
+package ports
+
+import (
+	"bufio"
+	"bytes"
+	"os/exec"
+	"strconv"
+)
+
+// findHolder uses lsof to find the process listening on the port.
+func findHolder(port int) (*Process, error) {
+	out, err := exec.Command("lsof", "-nP", "-iTCP:"+strconv.Itoa(port), "-sTCP:LISTEN", "-Fpc").Output()
+	if err != nil {
+		// lsof exits with status 1 when nothing matched.
+		return nil, nil
+	}
+	return parseLsof(out), nil
+}
+
+// parseLsof parses the output of "lsof -Fpc", which consists of
+// lines prefixed with 'p' (pid) and 'c' (command name).
+func parseLsof(out []byte) *Process {
+	var proc *Process
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		if len(line) < 2 {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			if proc != nil {
+				// We only report the first process.
+				return proc
+			}
+			pid, err := strconv.Atoi(line[1:])
+			if err != nil {
+				return nil
+			}
+			proc = &Process{PID: pid}
+		case 'c':
+			if proc != nil {
+				proc.Name = line[1:]
+			}
+		}
+	}
+	return proc
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+// +build !windows
+
+package ports
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseLsof(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(parseLsof([]byte("p123\ncnode\np456\ncother\n")), qt.DeepEquals, &Process{PID: 123, Name: "node"})
+	c.Assert(parseLsof(nil), qt.IsNil)
+}
@@ -0,0 +1,49 @@
+//go:build windows
+// +build windows
+
+package ports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// findHolder uses netstat and tasklist to find the process listening on the port.
+func findHolder(port int) (*Process, error) {
+	out, err := exec.Command("netstat", "-ano", "-p", "tcp").Output()
+	if err != nil {
+		return nil, nil
+	}
+
+	suffix := ":" + strconv.Itoa(port)
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		// Proto  Local Address  Foreign Address  State  PID
+		fields := strings.Fields(sc.Text())
+		if len(fields) != 5 || fields[3] != "LISTENING" || !strings.HasSuffix(fields[1], suffix) {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[4])
+		if err != nil {
+			return nil, nil
+		}
+		return &Process{PID: pid, Name: processName(pid)}, nil
+	}
+	return nil, nil
+}
+
+func processName(pid int) string {
+	out, err := exec.Command("tasklist", "/FI", "PID eq "+strconv.Itoa(pid), "/FO", "CSV", "/NH").Output()
+	if err != nil {
+		return ""
+	}
+	rec, err := csv.NewReader(bytes.NewReader(out)).Read()
+	if err != nil || len(rec) == 0 {
+		return ""
+	}
+	return rec[0]
+}
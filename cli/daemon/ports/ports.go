@@ -0,0 +1,251 @@
+// Package ports manages the local ports used by running Encore applications.
+//
+// Ports are reserved per app and namespace and the assignments are persisted
+// in the daemon database, so that an app keeps running on the same port
+// across restarts even when several apps are run side by side.
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+)
+
+// Name identifies what a reserved port is used for.
+type Name string
+
+const (
+	// API is the port the app's API gateway listens on.
+	API Name = "api"
+)
+
+// maxSearch is the number of ports to try when searching for an available port.
+const maxSearch = 100
+
+// ErrInUse is reported when a port is already in use.
+var ErrInUse = errors.New("port already in use")
+
+// NewManager creates a new port manager.
+func NewManager(db *sql.DB) *Manager {
+	return &Manager{db: db}
+}
+
+// Manager manages port reservations.
+type Manager struct {
+	db *sql.DB
+}
+
+// Reservation describes a port reserved for a given app and namespace.
+type Reservation struct {
+	AppID       string
+	NamespaceID namespace.ID
+	Name        Name
+	Port        int
+	ReservedAt  time.Time
+}
+
+// ConflictError is returned when a port is in use by another process.
+type ConflictError struct {
+	Host string
+	Port int
+
+	// Holder is the process holding the port, if it could be determined.
+	Holder *Process
+
+	// Reservation is the reservation for the port, if any.
+	// It indicates which app most recently ran on the port.
+	Reservation *Reservation
+}
+
+func (e *ConflictError) Error() string {
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+	switch {
+	case e.Holder != nil:
+		return fmt.Sprintf("%s is in use by %s", addr, e.Holder)
+	case e.Reservation != nil:
+		return fmt.Sprintf("%s is in use (last reserved by app %s)", addr, e.Reservation.AppID)
+	default:
+		return fmt.Sprintf("%s is in use by another process", addr)
+	}
+}
+
+func (e *ConflictError) Unwrap() error { return ErrInUse }
+
+// ReserveParams are the parameters for Reserve.
+type ReserveParams struct {
+	App  *apps.Instance
+	NS   *namespace.Namespace
+	Name Name
+
+	// Host is the host to listen on.
+	Host string
+
+	// Preferred is the port to use if there is no existing reservation.
+	Preferred int
+
+	// AutoSelect, if true, causes an alternative port to be chosen if
+	// the reserved or preferred port is in use. If false a *ConflictError
+	// is returned instead.
+	AutoSelect bool
+}
+
+// Reserve reserves a port for the given app and namespace and starts listening on it.
+//
+// If p.AutoSelect is true and the app and namespace already has a reservation,
+// that port is tried first. Otherwise p.Preferred is used.
+// The returned listener is listening on the reserved port.
+func (m *Manager) Reserve(ctx context.Context, p ReserveParams) (ln net.Listener, port int, err error) {
+	appID := p.App.PlatformOrLocalID()
+	candidate := p.Preferred
+	if p.AutoSelect {
+		if existing, err := m.Get(ctx, p.App, p.NS, p.Name); err == nil {
+			candidate = existing.Port
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, err
+		}
+	}
+
+	for port := candidate; port < candidate+maxSearch && port <= 65535; port++ {
+		// When auto-selecting, skip ports reserved for other apps or namespaces
+		// so that their assignments remain stable. An explicitly requested port
+		// takes over the reservation if the port is available.
+		if p.AutoSelect {
+			if other, err := m.getByPort(ctx, port); err != nil {
+				return nil, 0, err
+			} else if other != nil && (other.AppID != appID || other.NamespaceID != p.NS.ID || other.Name != p.Name) {
+				continue
+			}
+		}
+
+		ln, err := net.Listen("tcp", net.JoinHostPort(p.Host, strconv.Itoa(port)))
+		if err != nil {
+			if !IsAddrInUse(err) {
+				return nil, 0, errors.Wrap(err, "listen")
+			} else if !p.AutoSelect {
+				return nil, 0, m.conflict(ctx, p.Host, port)
+			}
+			continue
+		}
+
+		if err := m.save(ctx, appID, p.NS.ID, p.Name, port); err != nil {
+			_ = ln.Close()
+			return nil, 0, err
+		}
+		return ln, port, nil
+	}
+
+	return nil, 0, errors.Newf("no available port found in range %d-%d", candidate, candidate+maxSearch-1)
+}
+
+// Get returns the reservation for the given app, namespace and name.
+// It reports sql.ErrNoRows if there is no such reservation.
+func (m *Manager) Get(ctx context.Context, app *apps.Instance, ns *namespace.Namespace, name Name) (*Reservation, error) {
+	var r Reservation
+	err := m.db.QueryRowContext(ctx, `
+		SELECT app_id, namespace_id, name, port, reserved_at
+		FROM port_reservation
+		WHERE app_id = ? AND namespace_id = ? AND name = ?
+	`, app.PlatformOrLocalID(), ns.ID, name).Scan(&r.AppID, &r.NamespaceID, &r.Name, &r.Port, &r.ReservedAt)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &r, nil
+}
+
+// List lists all port reservations for the given app.
+func (m *Manager) List(ctx context.Context, app *apps.Instance) ([]*Reservation, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT app_id, namespace_id, name, port, reserved_at
+		FROM port_reservation
+		WHERE app_id = ?
+		ORDER BY port ASC
+	`, app.PlatformOrLocalID())
+	if err != nil {
+		return nil, errors.Wrap(err, "list port reservations")
+	}
+	defer rows.Close()
+
+	var res []*Reservation
+	for rows.Next() {
+		var r Reservation
+		if err := rows.Scan(&r.AppID, &r.NamespaceID, &r.Name, &r.Port, &r.ReservedAt); err != nil {
+			return nil, errors.Wrap(err, "scan port reservation")
+		}
+		res = append(res, &r)
+	}
+	return res, errors.Wrap(rows.Err(), "list port reservations")
+}
+
+func (m *Manager) getByPort(ctx context.Context, port int) (*Reservation, error) {
+	var r Reservation
+	err := m.db.QueryRowContext(ctx, `
+		SELECT app_id, namespace_id, name, port, reserved_at
+		FROM port_reservation
+		WHERE port = ?
+	`, port).Scan(&r.AppID, &r.NamespaceID, &r.Name, &r.Port, &r.ReservedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "get port reservation")
+	}
+	return &r, nil
+}
+
+func (m *Manager) save(ctx context.Context, appID string, nsID namespace.ID, name Name, port int) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer tx.Rollback() // committed explicitly on success
+
+	// Remove any stale reservation of the port; we're actively listening on it
+	// so whoever reserved it previously is no longer using it.
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM port_reservation
+		WHERE port = ? AND NOT (app_id = ? AND namespace_id = ? AND name = ?)
+	`, port, appID, nsID, name)
+	if err != nil {
+		return errors.Wrap(err, "reserve port")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO port_reservation (app_id, namespace_id, name, port, reserved_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (app_id, namespace_id, name) DO UPDATE
+		SET port = excluded.port, reserved_at = excluded.reserved_at
+	`, appID, nsID, name, port, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "reserve port")
+	}
+	return errors.Wrap(tx.Commit(), "reserve port")
+}
+
+// conflict returns a *ConflictError describing who is using the given port.
+func (m *Manager) conflict(ctx context.Context, host string, port int) error {
+	holder, _ := FindHolder(port)
+	res, _ := m.getByPort(ctx, port)
+	return &ConflictError{Host: host, Port: port, Holder: holder, Reservation: res}
+}
+
+// CanDeleteNamespace implements namespace.DeletionHandler.
+func (m *Manager) CanDeleteNamespace(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) error {
+	return nil
+}
+
+// DeleteNamespace implements namespace.DeletionHandler.
+// It releases all port reservations held by the namespace.
+func (m *Manager) DeleteNamespace(ctx context.Context, app *apps.Instance, ns *namespace.Namespace) error {
+	_, err := m.db.ExecContext(ctx, `
+		DELETE FROM port_reservation
+		WHERE app_id = ? AND namespace_id = ?
+	`, app.PlatformOrLocalID(), ns.ID)
+	return errors.Wrap(err, "release port reservations")
+}
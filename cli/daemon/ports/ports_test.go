@@ -0,0 +1,73 @@
+package ports
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/mgmtdb/mgmtdbtest"
+	"encr.dev/cli/daemon/namespace"
+)
+
+func newTestManager(c *qt.C) *Manager {
+	return NewManager(mgmtdbtest.New(c))
+}
+
+// freePort returns a port that is currently available.
+func freePort(c *qt.C) int {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestReserve(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	mgr := newTestManager(c)
+
+	appA := apps.NewInstance(c.TempDir(), "app-a", "")
+	appB := apps.NewInstance(c.TempDir(), "app-b", "")
+	ns := &namespace.Namespace{ID: "ns", Name: "default"}
+	port := freePort(c)
+
+	// App A reserves the preferred port.
+	ln, got, err := mgr.Reserve(ctx, ReserveParams{App: appA, NS: ns, Name: API, Host: "127.0.0.1", Preferred: port, AutoSelect: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.Equals, port)
+
+	// App B should get a different port since A is holding it.
+	lnB, gotB, err := mgr.Reserve(ctx, ReserveParams{App: appB, NS: ns, Name: API, Host: "127.0.0.1", Preferred: port, AutoSelect: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotB, qt.Not(qt.Equals), port)
+	_ = lnB.Close()
+
+	// Without auto-selection we get a conflict error.
+	_, _, err = mgr.Reserve(ctx, ReserveParams{App: appB, NS: ns, Name: API, Host: "127.0.0.1", Preferred: port})
+	var conflict *ConflictError
+	c.Assert(err, qt.ErrorAs, &conflict)
+	c.Assert(conflict.Port, qt.Equals, port)
+	c.Assert(conflict.Reservation, qt.IsNotNil)
+	c.Assert(conflict.Reservation.AppID, qt.Equals, "app-a")
+	c.Assert(err, qt.ErrorIs, ErrInUse)
+
+	// Once A stops, A gets its reserved port back and B keeps its own.
+	_ = ln.Close()
+	ln, got, err = mgr.Reserve(ctx, ReserveParams{App: appA, NS: ns, Name: API, Host: "127.0.0.1", Preferred: 1, AutoSelect: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.Equals, port)
+	_ = ln.Close()
+
+	res, err := mgr.Get(ctx, appB, ns, API)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Port, qt.Equals, gotB)
+
+	// Deleting the namespace releases the reservations.
+	c.Assert(mgr.DeleteNamespace(ctx, appA, ns), qt.IsNil)
+	_, err = mgr.Get(ctx, appA, ns, API)
+	c.Assert(err, qt.ErrorIs, sql.ErrNoRows)
+}
@@ -1,15 +1,19 @@
 package pubsub
 
 import (
+	"encoding/json"
 	"os"
 	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/nsqio/go-nsq"
 	"github.com/nsqio/nsq/nsqd"
+	"github.com/rs/xid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go4.org/syncutil"
+
+	"encr.dev/pkg/appfile"
 )
 
 type NSQDaemon struct {
@@ -78,6 +82,54 @@ func (n *NSQDaemon) Start() error {
 	})
 }
 
+// messageWrapper is the data structure for an NSQ message of Go apps.
+// It must be synchronized with the nsq/topic.go file in the runtime.
+type messageWrapper struct {
+	ID         string
+	Attributes map[string]string
+	Data       json.RawMessage
+}
+
+// encodedMessage is the data structure for an NSQ message of TypeScript apps.
+// It must be synchronized with EncodedMessage in runtimes/core/src/pubsub/nsq/topic.rs.
+type encodedMessage struct {
+	ID    string            `json:"id"`
+	Body  json.RawMessage   `json:"body"`
+	Attrs map[string]string `json:"attrs"`
+}
+
+// Publish publishes a message with the given JSON payload to a topic of an app
+// written in lang, in the same way as the app itself. It returns the message id.
+func (n *NSQDaemon) Publish(lang appfile.Lang, topic string, attrs map[string]string, data json.RawMessage) (msgID string, err error) {
+	if n.nsqd == nil {
+		return "", errors.New("nsqd not started")
+	}
+	if attrs == nil {
+		attrs = map[string]string{}
+	}
+
+	msgID = xid.New().String()
+	var msg any = &messageWrapper{ID: msgID, Attributes: attrs, Data: data}
+	if lang == appfile.LangTS {
+		msg = &encodedMessage{ID: msgID, Body: data, Attrs: attrs}
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal message")
+	}
+
+	p, err := nsq.NewProducer(n.Addr(), nsq.NewConfig())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create nsq producer")
+	}
+	defer p.Stop()
+	p.SetLogger(&logAdapter{"nsq producer"}, nsq.LogLevelWarning)
+	if err := p.Publish(topic, body); err != nil {
+		return "", errors.Wrap(err, "failed to publish message")
+	}
+	return msgID, nil
+}
+
 func (n *NSQDaemon) Stop() {
 	if n.nsqd != nil {
 		n.nsqd.Exit()
@@ -0,0 +1,54 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/nsqio/go-nsq"
+
+	"encr.dev/pkg/appfile"
+)
+
+func TestNSQDaemon_Publish(t *testing.T) {
+	c := qt.New(t)
+	n := &NSQDaemon{}
+	c.Assert(n.Start(), qt.IsNil)
+	defer n.Stop()
+
+	received := make(chan *nsq.Message, 1)
+	consumer, err := nsq.NewConsumer("orders", "test", nsq.NewConfig())
+	c.Assert(err, qt.IsNil)
+	consumer.SetLoggerLevel(nsq.LogLevelMax)
+	consumer.AddHandler(nsq.HandlerFunc(func(m *nsq.Message) error {
+		received <- m
+		return nil
+	}))
+	c.Assert(consumer.ConnectToNSQD(n.Addr()), qt.IsNil)
+	defer consumer.Stop()
+
+	receive := func() []byte {
+		select {
+		case m := <-received:
+			return m.Body
+		case <-time.After(10 * time.Second):
+			c.Fatal("timed out waiting for message")
+			return nil
+		}
+	}
+
+	msgID, err := n.Publish(appfile.LangGo, "orders", map[string]string{"source": "dash"}, json.RawMessage(`{"id":1}`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(msgID, qt.Not(qt.Equals), "")
+	var got messageWrapper
+	c.Assert(json.Unmarshal(receive(), &got), qt.IsNil)
+	c.Assert(got.ID, qt.Equals, msgID)
+	c.Assert(got.Attributes, qt.DeepEquals, map[string]string{"source": "dash"})
+	c.Assert(string(got.Data), qt.Equals, `{"id":1}`)
+
+	// TypeScript apps use a different encoding, and attributes default to an empty object.
+	msgID, err = n.Publish(appfile.LangTS, "orders", nil, json.RawMessage(`{"id":2}`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(receive()), qt.Equals, `{"id":"`+msgID+`","body":{"id":2},"attrs":{}}`)
+}
@@ -1,17 +1,26 @@
 package daemon
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/logrusorgru/aurora/v3"
 
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/cli/daemon/ports"
 	"encr.dev/cli/daemon/run"
+	"encr.dev/internal/conf"
 	"encr.dev/internal/optracker"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/builder"
 	"encr.dev/pkg/fns"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
@@ -43,11 +52,38 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	if listenAddr == "" {
 		listenAddr = ":4000"
 	}
-	ln, err := net.Listen("tcp", listenAddr)
+
+	app, err := s.apps.Track(req.AppRoot)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve app: %v"), err))
+		sendExit(1)
+		return nil
+	}
+
+	if req.JsRuntime != daemonpb.RunRequest_JS_RUNTIME_DEFAULT && app.Lang() != appfile.LangTS {
+		_, _ = fmt.Fprintln(stderr, aurora.Red("--runtime is only supported for TypeScript apps"))
+		sendExit(1)
+		return nil
+	}
+
+	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
 	if err != nil {
-		if errIsAddrInUse(err) {
+		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve namespace: %v"), err))
+		sendExit(1)
+		return nil
+	}
+
+	ln, listenAddr, err := s.listenRun(ctx, app, ns, listenAddr, req.AutoPort)
+	if err != nil {
+		var conflict *ports.ConflictError
+		switch {
+		case errors.As(err, &conflict) && conflict.Holder != nil:
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to run on %s - port is already in use by %s"), listenAddr, conflict.Holder))
+		case errors.As(err, &conflict) && conflict.Reservation != nil:
+			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to run on %s - port is already in use (last used by app %s)"), listenAddr, conflict.Reservation.AppID))
+		case errors.As(err, &conflict):
 			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to run on %s - port is already in use"), listenAddr))
-		} else {
+		default:
 			_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("Failed to run on %s - %v"), listenAddr, err))
 		}
 
@@ -69,20 +105,6 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	}
 	defer fns.CloseIgnore(ln)
 
-	app, err := s.apps.Track(req.AppRoot)
-	if err != nil {
-		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve app: %v"), err))
-		sendExit(1)
-		return nil
-	}
-
-	ns, err := s.namespaceOrActive(ctx, app, req.Namespace)
-	if err != nil {
-		_, _ = fmt.Fprintln(stderr, aurora.Sprintf(aurora.Red("failed to resolve namespace: %v"), err))
-		sendExit(1)
-		return nil
-	}
-
 	ops := optracker.New(stderr, stream)
 	defer ops.AllDone() // Kill the tracker when we exit this function
 
@@ -115,9 +137,9 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 
 	// If the listen addr contains no interface, render it as "localhost:port"
 	// instead of just ":port".
-	displayListenAddr := req.ListenAddr
+	displayListenAddr := listenAddr
 	if strings.HasPrefix(listenAddr, ":") {
-		displayListenAddr = "localhost" + req.ListenAddr
+		displayListenAddr = "localhost" + listenAddr
 	}
 
 	runInstance, err := s.mgr.Start(ctx, run.StartParams{
@@ -131,6 +153,8 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 		OpsTracker: ops,
 		Browser:    run.BrowserModeFromProto(req.Browser),
 		Debug:      run.DebugModeFromProto(req.DebugMode),
+		JSRuntime:  run.JSRuntimeFromProto(req.JsRuntime),
+		Secrets:    req.Secrets,
 	})
 	if err != nil {
 		s.mu.Unlock()
@@ -156,11 +180,19 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	_, _ = fmt.Fprintf(stderr, "  Encore development server running!\n\n")
 
 	_, _ = fmt.Fprintf(stderr, "  Your API is running at:     %s\n", aurora.Cyan("http://"+runInstance.ListenAddr))
-	_, _ = fmt.Fprintf(stderr, "  Development Dashboard URL:  %s\n", aurora.Cyan(fmt.Sprintf(
-		"http://localhost:%d/%s", s.mgr.DashPort, app.PlatformOrLocalID())))
+	_, _ = fmt.Fprintf(stderr, "  Development Dashboard URL:  %s\n", aurora.Cyan(s.mgr.DashURL(app.PlatformOrLocalID())))
 	if ns := runInstance.NS; !ns.Active || ns.Name != "default" {
 		_, _ = fmt.Fprintf(stderr, "  Namespace:                  %s\n", aurora.Cyan(ns.Name))
 	}
+	if conf.Offline() {
+		_, _ = fmt.Fprintf(stderr, "  Offline mode:               %s\n", aurora.Yellow("enabled"))
+		for _, msg := range s.offlineLimitations(app) {
+			_, _ = fmt.Fprintf(stderr, "    %s\n", aurora.Faint("- "+msg))
+		}
+	}
+	if rt := run.JSRuntimeFromProto(req.JsRuntime); rt != "" && rt != builder.JSRuntimeNode {
+		_, _ = fmt.Fprintf(stderr, "  JavaScript runtime:         %s\n", aurora.Cyan(rt))
+	}
 	if req.DebugMode == daemonpb.RunRequest_DEBUG_ENABLED {
 		// Print the pid for debugging. Currently we only support this if we have a default gateway.
 		if gw, ok := runInstance.ProcGroup().Gateways["api-gateway"]; ok {
@@ -217,3 +249,52 @@ func (s *Server) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer)
 	s.mu.Unlock()
 	return nil
 }
+
+// listenRun listens on the address to run the app on.
+//
+// If autoPort is true the port reserved for the app and namespace is used,
+// falling back to the port in listenAddr and then to any available port.
+// Otherwise the port in listenAddr is reserved and used as-is, and a
+// *ports.ConflictError is returned if it's already in use.
+//
+// It returns the address actually being listened on.
+func (s *Server) listenRun(ctx context.Context, app *apps.Instance, ns *namespace.Namespace, listenAddr string, autoPort bool) (net.Listener, string, error) {
+	host, portStr, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return nil, listenAddr, errors.Wrap(err, "invalid listen address")
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, listenAddr, errors.Wrap(err, "invalid listen address")
+	}
+
+	ln, port, err := s.ports.Reserve(ctx, ports.ReserveParams{
+		App:        app,
+		NS:         ns,
+		Name:       ports.API,
+		Host:       host,
+		Preferred:  port,
+		AutoSelect: autoPort,
+	})
+	if err != nil {
+		return nil, listenAddr, err
+	}
+	return ln, net.JoinHostPort(host, strconv.Itoa(port)), nil
+}
+
+// offlineLimitations describes the functionality that is degraded
+// for app when running in offline mode.
+func (s *Server) offlineLimitations(app *apps.Instance) []string {
+	var msgs []string
+	if app.PlatformID() != "" {
+		if synced, ok := s.sm.Cached(app); ok {
+			msgs = append(msgs, fmt.Sprintf("Secrets: using values cached %s", synced.Format(time.DateTime)))
+		} else {
+			msgs = append(msgs, "Secrets: none cached, only local overrides (.secrets.local.cue) are available")
+		}
+	}
+	msgs = append(msgs,
+		"Commands that use the Encore Platform (like deploying and connecting to cloud databases) are unavailable",
+		"Update checks and telemetry are disabled")
+	return msgs
+}
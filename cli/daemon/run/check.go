@@ -6,12 +6,14 @@ import (
 
 	"github.com/cockroachdb/errors"
 
+	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
 	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/fns"
 	"encr.dev/pkg/vcs"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 )
 
 type CheckParams struct {
@@ -32,6 +34,10 @@ type CheckParams struct {
 
 	// Tests specifies whether to parse and codegen for tests as well.
 	Tests bool
+
+	// DetectHardcodedSecrets specifies whether to report string literals
+	// that look like credentials as errors.
+	DetectHardcodedSecrets bool
 }
 
 // Check checks the app for errors.
@@ -44,8 +50,49 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 
 	// TODO: We should check that all secret keys are defined as well.
 
+	buildInfo := checkBuildInfo(p)
+	bld := builderimpl.Resolve(p.App.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	parse, err := parseAndValidate(ctx, bld, p, buildInfo, expSet)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := bld.Compile(ctx, builder.CompileParams{
+		Build:       buildInfo,
+		App:         p.App,
+		Parse:       parse,
+		OpTracker:   nil, // TODO
+		Experiments: expSet,
+		WorkingDir:  p.WorkingDir,
+	})
+
+	if result != nil && len(result.Outputs) > 0 {
+		buildDir = result.Outputs[0].GetArtifactDir().ToIO()
+	}
+	return buildDir, err
+}
+
+// Parse parses the app and validates its service configs,
+// without compiling it. It reports the parsed app metadata.
+func (mgr *Manager) Parse(ctx context.Context, p CheckParams) (*meta.Data, error) {
+	expSet, err := p.App.Experiments(p.Environ)
+	if err != nil {
+		return nil, err
+	}
+
+	bld := builderimpl.Resolve(p.App.Lang(), expSet)
+	defer fns.CloseIgnore(bld)
+	parse, err := parseAndValidate(ctx, bld, p, checkBuildInfo(p), expSet)
+	if err != nil {
+		return nil, err
+	}
+	return parse.Meta, nil
+}
+
+func checkBuildInfo(p CheckParams) builder.BuildInfo {
 	vcsRevision := vcs.GetRevision(p.App.Root())
-	buildInfo := builder.BuildInfo{
+	return builder.BuildInfo{
 		BuildTags:          builder.LocalBuildTags,
 		CgoEnabled:         true,
 		StaticLink:         false,
@@ -57,21 +104,24 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 		Revision:           vcsRevision.Revision,
 		UncommittedChanges: vcsRevision.Uncommitted,
 	}
+}
 
-	bld := builderimpl.Resolve(p.App.Lang(), expSet)
-	defer fns.CloseIgnore(bld)
+// parseAndValidate parses the app and validates the service configs.
+func parseAndValidate(ctx context.Context, bld builder.Impl, p CheckParams, buildInfo builder.BuildInfo, expSet *experiments.Set) (*builder.ParseResult, error) {
 	parse, err := bld.Parse(ctx, builder.ParseParams{
 		Build:       buildInfo,
 		App:         p.App,
 		Experiments: expSet,
 		WorkingDir:  p.WorkingDir,
 		ParseTests:  p.Tests,
+
+		DetectHardcodedSecrets: p.DetectHardcodedSecrets,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if err := p.App.CacheMetadata(parse.Meta); err != nil {
-		return "", errors.Wrap(err, "cache metadata")
+		return nil, errors.Wrap(err, "cache metadata")
 	}
 
 	// Validate the service configs.
@@ -86,20 +136,7 @@ func (mgr *Manager) Check(ctx context.Context, p CheckParams) (buildDir string,
 		},
 	})
 	if err != nil {
-		return "", err
-	}
-
-	result, err := bld.Compile(ctx, builder.CompileParams{
-		Build:       buildInfo,
-		App:         p.App,
-		Parse:       parse,
-		OpTracker:   nil, // TODO
-		Experiments: expSet,
-		WorkingDir:  p.WorkingDir,
-	})
-
-	if result != nil && len(result.Outputs) > 0 {
-		buildDir = result.Outputs[0].GetArtifactDir().ToIO()
+		return nil, err
 	}
-	return buildDir, err
+	return parse, nil
 }
@@ -0,0 +1,73 @@
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/coverage"
+	"encr.dev/pkg/paths"
+)
+
+// processCoverage removes the code generated by Encore from the coverage profile
+// written by "go test", and reports the coverage of each of the app's services.
+// The summary is written as a table to w and, if summaryFile is set, as JSON to summaryFile.
+func processCoverage(params TestParams, workingDir paths.FS, profileFile, summaryFile string, w io.Writer) error {
+	md, err := params.App.CachedMetadata()
+	if err != nil {
+		return errors.Wrap(err, "coverage: load app metadata")
+	}
+
+	profileFile = workingDir.Resolve(profileFile).ToIO()
+	profile, err := os.ReadFile(profileFile)
+	if errors.Is(err, os.ErrNotExist) {
+		// No profile was written, like when the tests failed to build.
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "coverage: read profile")
+	}
+
+	filtered, summary, err := coverage.Process(md, profile)
+	if err != nil {
+		return errors.Wrap(err, "coverage")
+	}
+	if err := os.WriteFile(profileFile, filtered, 0644); err != nil {
+		return errors.Wrap(err, "coverage: write profile")
+	}
+
+	_, _ = fmt.Fprintln(w, "\nCoverage by service (excluding generated code):")
+	if err := summary.WriteTable(w); err != nil {
+		return err
+	}
+
+	if summaryFile != "" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "coverage: marshal summary")
+		}
+		if err := os.WriteFile(workingDir.Resolve(summaryFile).ToIO(), append(data, '\n'), 0644); err != nil {
+			return errors.Wrap(err, "coverage: write summary")
+		}
+	}
+	return nil
+}
+
+// coverProfileArg returns the file given to "go test" with -coverprofile, if any.
+func coverProfileArg(args []string) string {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || (name != "coverprofile" && name != "test.coverprofile") {
+			continue
+		}
+		if hasValue {
+			return value
+		} else if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -58,12 +58,17 @@ type ExecScriptParams struct {
 
 // ExecScript executes the script.
 func (mgr *Manager) ExecScript(ctx context.Context, p ExecScriptParams) (err error) {
+	p.Environ, err = prepareNode(ctx, p.App, "", p.Environ)
+	if err != nil {
+		return err
+	}
+
 	expSet, err := p.App.Experiments(p.Environ)
 	if err != nil {
 		return err
 	}
 
-	rm := infra.NewResourceManager(p.App, mgr.ClusterMgr, p.NS, p.Environ, mgr.DBProxyPort, false)
+	rm := infra.NewResourceManager(p.App, mgr.ClusterMgr, mgr.ExternalRedis, p.NS, p.Environ, mgr.DBProxyPort, false)
 	defer rm.StopAll()
 
 	tracker := p.OpTracker
@@ -113,7 +118,11 @@ func (mgr *Manager) ExecScript(ctx context.Context, p ExecScriptParams) (err err
 	var secrets map[string]string
 	if usesSecrets(parse.Meta) {
 		jobs.Go("Fetching application secrets", true, 150*time.Millisecond, func(ctx context.Context) error {
-			data, err := mgr.Secret.Load(p.App).Get(ctx, expSet)
+			data, err := mgr.Secret.Load(p.App, p.NS).Get(ctx, expSet)
+			if err != nil {
+				return err
+			}
+			data, err = mgr.Secret.ApplyGenerated(p.App, parse.Meta, data)
 			if err != nil {
 				return err
 			}
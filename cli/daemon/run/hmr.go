@@ -0,0 +1,225 @@
+package run
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/builder"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// hotReloadTimeout is how long to wait for the app to hot reload
+// before falling back to restarting it.
+const hotReloadTimeout = 10 * time.Second
+
+// hotReloader hot reloads the code of a running TypeScript app,
+// swapping in the new handler implementations without restarting the process.
+//
+// It communicates with the JS runtime over a pair of pipes passed to the process,
+// sending a JSON command per line and reading a JSON reply per line.
+type hotReloader struct {
+	cmd builder.Cmd // the command the process was started with
+
+	mu      sync.Mutex    // serializes reloads
+	w       *os.File      // the daemon end of the command pipe
+	r       *bufio.Reader // reads replies from rf
+	rf      *os.File      // the daemon end of the reply pipe
+	childFs []*os.File    // the child's ends of the pipes, until it has started
+}
+
+// supportsHotReload reports whether apps in r can be hot reloaded.
+//
+// It's only supported for TypeScript apps running with Node.js that opted in
+// with the hot_reload watch setting, since reloading evaluates the app's modules
+// again, and not on Windows since it relies on passing pipes to the process.
+func supportsHotReload(r *Run) bool {
+	if r.App.Lang() != appfile.LangTS || !r.App.HotReload() || runtime.GOOS == "windows" {
+		return false
+	}
+	js := r.Params.JSRuntime
+	return js == "" || js == builder.JSRuntimeNode
+}
+
+// newHotReloader creates a hot reloader for the process running spec
+// and configures cmd to pass it the pipes.
+func newHotReloader(cmd *exec.Cmd, spec builder.Cmd) (*hotReloader, error) {
+	cmdR, cmdW, err := os.Pipe()
+	if err != nil {
+		return nil, errors.Wrap(err, "create hot reload pipe")
+	}
+	replyR, replyW, err := os.Pipe()
+	if err != nil {
+		_ = cmdR.Close()
+		_ = cmdW.Close()
+		return nil, errors.Wrap(err, "create hot reload pipe")
+	}
+
+	// The extra files start at fd 3 in the child process.
+	fd := 3 + len(cmd.ExtraFiles)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, cmdR, replyW)
+	cmd.Env = append(cmd.Env, fmt.Sprintf("ENCORE_HMR_FDS=%d,%d", fd, fd+1))
+
+	return &hotReloader{
+		cmd:     spec,
+		w:       cmdW,
+		r:       bufio.NewReader(replyR),
+		rf:      replyR,
+		childFs: []*os.File{cmdR, replyW},
+	}, nil
+}
+
+// started is called when the process has started,
+// to close the child's ends of the pipes in the daemon.
+func (h *hotReloader) started() {
+	for _, f := range h.childFs {
+		_ = f.Close()
+	}
+	h.childFs = nil
+}
+
+// close closes the pipes.
+func (h *hotReloader) close() {
+	h.started()
+	_ = h.w.Close()
+	_ = h.rf.Close()
+}
+
+type hotReloadReply struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// reload tells the process to hot reload the app,
+// and waits for it to report the outcome.
+func (h *hotReloader) reload(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, hotReloadTimeout)
+	defer cancel()
+
+	if _, err := h.w.Write([]byte(`{"type":"reload"}` + "\n")); err != nil {
+		return errors.Wrap(err, "send hot reload command")
+	}
+
+	type result struct {
+		line []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		line, err := h.r.ReadBytes('\n')
+		done <- result{line, err}
+	}()
+
+	var res result
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		// The reply can't be matched to its command anymore,
+		// so the process has to be restarted.
+		return errors.Wrap(ctx.Err(), "wait for hot reload")
+	}
+	if res.err != nil {
+		return errors.Wrap(res.err, "read hot reload reply")
+	}
+
+	var reply hotReloadReply
+	if err := json.Unmarshal(res.line, &reply); err != nil {
+		return errors.Wrap(err, "parse hot reload reply")
+	} else if !reply.OK {
+		return errors.Newf("hot reload failed: %s", reply.Error)
+	}
+	return nil
+}
+
+// HotReload tries to apply a new build of the app to the running process,
+// instead of starting a new process. It reports whether it succeeded.
+//
+// This is only possible when the app's process can be hot reloaded and
+// nothing but the implementation of the app has changed,
+// so the running process is configured the same way as a new one would be.
+func (pg *ProcGroup) HotReload(ctx context.Context, params *StartProcGroupParams) bool {
+	if !isSingleProc(params.Outputs) || len(pg.allProcesses) != 1 {
+		return false
+	}
+	h := pg.allProcesses[0].hmr
+	if h == nil {
+		return false
+	}
+
+	output := params.Outputs[0]
+	spec := output.GetEntrypoints()[0].Cmd.Expand(output.GetArtifactDir())
+	if !slices.Equal(spec.Command, h.cmd.Command) || !slices.Equal(spec.Env, h.cmd.Env) ||
+		!maps.Equal(params.Secrets, pg.ConfigGen.DefinedSecrets) ||
+		!maps.Equal(params.ServiceConfigs, pg.ConfigGen.SvcConfigs) ||
+		!sameAppStructure(pg.Meta, params.Meta) {
+		return false
+	}
+
+	if err := h.reload(ctx); err != nil {
+		pg.log.Info().Err(err).Msg("unable to hot reload, restarting instead")
+		return false
+	}
+	return true
+}
+
+// sameAppStructure reports whether a and b describe the same app,
+// ignoring where in the source code things are defined.
+func sameAppStructure(a, b *meta.Data) bool {
+	return proto.Equal(withoutSourceLocations(a), withoutSourceLocations(b))
+}
+
+// withoutSourceLocations returns a copy of md with all source locations cleared.
+func withoutSourceLocations(md *meta.Data) *meta.Data {
+	md = proto.Clone(md).(*meta.Data)
+	for _, pkg := range md.Pkgs {
+		pkg.TraceNodes = nil
+	}
+	clearLocs(md.ProtoReflect())
+	return md
+}
+
+var locDescriptor = (&schema.Loc{}).ProtoReflect().Descriptor()
+
+func clearLocs(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil {
+			return true
+		}
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					clearLocs(v.Message())
+					return true
+				})
+			}
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				clearLocs(list.Get(i).Message())
+			}
+		case fd.Message().FullName() == locDescriptor.FullName():
+			m.Clear(fd)
+		default:
+			clearLocs(v.Message())
+		}
+		return true
+	})
+}
@@ -0,0 +1,39 @@
+package run
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestSameAppStructure(t *testing.T) {
+	c := qt.New(t)
+
+	md := func(line int32, path string) *meta.Data {
+		return &meta.Data{
+			Svcs: []*meta.Service{{
+				Name: "svc",
+				Rpcs: []*meta.RPC{{
+					Name: "Hello",
+					Path: &meta.Path{Segments: []*meta.PathSegment{{Value: path}}},
+					Loc:  &schema.Loc{PkgPath: "svc", SrcLineStart: line},
+				}},
+			}},
+			Decls: []*schema.Decl{{
+				Name: "Params",
+				Loc:  &schema.Loc{PkgPath: "svc", SrcLineStart: line + 10},
+			}},
+		}
+	}
+
+	c.Assert(sameAppStructure(md(1, "hello"), md(5, "hello")), qt.IsTrue)
+	c.Assert(sameAppStructure(md(1, "hello"), md(1, "hi")), qt.IsFalse)
+
+	// The source locations of the originals are kept.
+	a := md(1, "hello")
+	sameAppStructure(a, md(5, "hello"))
+	c.Assert(a.Svcs[0].Rpcs[0].Loc.SrcLineStart, qt.Equals, int32(1))
+}
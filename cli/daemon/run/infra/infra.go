@@ -46,6 +46,7 @@ type ResourceManager struct {
 	app         *apps.Instance
 	dbProxyPort int
 	sqlMgr      *sqldb.ClusterManager
+	redis       *redis.External
 	ns          *namespace.Namespace
 	environ     environ.Environ
 	log         zerolog.Logger
@@ -55,11 +56,15 @@ type ResourceManager struct {
 	servers map[Type]Resource
 }
 
-func NewResourceManager(app *apps.Instance, sqlMgr *sqldb.ClusterManager, ns *namespace.Namespace, environ environ.Environ, dbProxyPort int, forTests bool) *ResourceManager {
+// NewResourceManager creates a new ResourceManager.
+// If externalRedis is non-nil it is used for cache clusters
+// instead of starting an in-memory Redis server.
+func NewResourceManager(app *apps.Instance, sqlMgr *sqldb.ClusterManager, externalRedis *redis.External, ns *namespace.Namespace, environ environ.Environ, dbProxyPort int, forTests bool) *ResourceManager {
 	return &ResourceManager{
 		app:         app,
 		dbProxyPort: dbProxyPort,
 		sqlMgr:      sqlMgr,
+		redis:       externalRedis,
 		ns:          ns,
 		environ:     environ,
 		forTests:    forTests,
@@ -96,8 +101,12 @@ func (rm *ResourceManager) StartRequiredServices(a *optracker.AsyncBuildJobs, md
 		a.Go("Starting PubSub daemon", true, 250*time.Millisecond, rm.StartPubSub)
 	}
 
-	if redis.IsUsed(md) && rm.GetRedis() == nil {
-		a.Go("Starting Redis server", true, 250*time.Millisecond, rm.StartRedis)
+	if redis.IsUsed(md) && !rm.isStarted(Cache) {
+		if rm.redis != nil {
+			a.Go("Connecting to Redis server", true, 250*time.Millisecond, rm.StartRedis)
+		} else {
+			a.Go("Starting Redis server", true, 250*time.Millisecond, rm.StartRedis)
+		}
 	}
 }
 
@@ -126,8 +135,27 @@ func (rm *ResourceManager) GetPubSub() *pubsub.NSQDaemon {
 	return nil
 }
 
-// StartRedis starts a Redis server.
+// isStarted reports whether a resource of the given type has been started.
+func (rm *ResourceManager) isStarted(typ Type) bool {
+	rm.mutex.Lock()
+	defer rm.mutex.Unlock()
+	_, ok := rm.servers[typ]
+	return ok
+}
+
+// StartRedis starts a Redis server, or connects to the
+// external Redis server if one is configured.
 func (rm *ResourceManager) StartRedis(ctx context.Context) error {
+	if rm.redis != nil {
+		if err := rm.redis.Ping(ctx); err != nil {
+			return err
+		}
+		rm.mutex.Lock()
+		rm.servers[Cache] = rm.redis
+		rm.mutex.Unlock()
+		return nil
+	}
+
 	srv := redis.New()
 	err := srv.Start()
 	if err != nil {
@@ -140,17 +168,44 @@ func (rm *ResourceManager) StartRedis(ctx context.Context) error {
 	return nil
 }
 
-// GetRedis returns the Redis server if it is running otherwise it returns nil
+// GetRedis returns the in-memory Redis server if it is running otherwise it returns nil.
+// It also returns nil when an external Redis server is used.
 func (rm *ResourceManager) GetRedis() *redis.Server {
 	rm.mutex.Lock()
 	defer rm.mutex.Unlock()
 
 	if srv, found := rm.servers[Cache]; found {
-		return srv.(*redis.Server)
+		srv, _ := srv.(*redis.Server)
+		return srv
 	}
 	return nil
 }
 
+// redisConfig returns the Redis server configuration and the
+// database configuration for the given cache cluster.
+// It reports false if Redis has not been started.
+func (rm *ResourceManager) redisConfig(cluster *meta.CacheCluster) (config.RedisServer, config.RedisDatabase, bool) {
+	rm.mutex.Lock()
+	srv := rm.servers[Cache]
+	rm.mutex.Unlock()
+
+	switch srv := srv.(type) {
+	case *redis.Server:
+		return config.RedisServer{Host: srv.Addr()}, config.RedisDatabase{
+			EncoreName: cluster.Name,
+			KeyPrefix:  cluster.Name + "/",
+		}, true
+	case *redis.External:
+		return srv.ServerConfig(), config.RedisDatabase{
+			EncoreName: cluster.Name,
+			Database:   srv.Database(),
+			KeyPrefix:  srv.KeyPrefix(rm.ns, rm.forTests, cluster.Name),
+		}, true
+	default:
+		return config.RedisServer{}, config.RedisDatabase{}, false
+	}
+}
+
 func (rm *ResourceManager) StartSQLCluster(a *optracker.AsyncBuildJobs, md *meta.Data) func(ctx context.Context) error {
 	return func(ctx context.Context) error {
 		// This can be the case in tests.
@@ -298,20 +353,15 @@ func (rm *ResourceManager) UpdateConfig(cfg *config.Runtime, md *meta.Data, dbPr
 		}
 	}
 
-	if redis := rm.GetRedis(); redis != nil {
-		srv := &config.RedisServer{
-			Host: redis.Addr(),
-		}
+	if rm.isStarted(Cache) && len(md.CacheClusters) > 0 {
+		srv, _, _ := rm.redisConfig(md.CacheClusters[0])
 		serverID := len(cfg.RedisServers)
-		cfg.RedisServers = append(cfg.RedisServers, srv)
+		cfg.RedisServers = append(cfg.RedisServers, &srv)
 
 		for _, cluster := range md.CacheClusters {
-			cfg.RedisDatabases = append(cfg.RedisDatabases, &config.RedisDatabase{
-				ServerID:   serverID,
-				Database:   0,
-				EncoreName: cluster.Name,
-				KeyPrefix:  cluster.Name + "/",
-			})
+			_, db, _ := rm.redisConfig(cluster)
+			db.ServerID = serverID
+			cfg.RedisDatabases = append(cfg.RedisDatabases, &db)
 		}
 	}
 
@@ -392,19 +442,9 @@ func (rm *ResourceManager) PubSubSubscriptionConfig(_ *meta.PubSubTopic, sub *me
 
 // RedisConfig returns the Redis server and database configuration for the given database.
 func (rm *ResourceManager) RedisConfig(redis *meta.CacheCluster) (config.RedisServer, config.RedisDatabase, error) {
-	server := rm.GetRedis()
-	if server == nil {
+	srvCfg, dbCfg, ok := rm.redisConfig(redis)
+	if !ok {
 		return config.RedisServer{}, config.RedisDatabase{}, errors.New("no Redis server found")
 	}
-
-	srvCfg := config.RedisServer{
-		Host: server.Addr(),
-	}
-
-	dbCfg := config.RedisDatabase{
-		EncoreName: redis.Name,
-		KeyPrefix:  redis.Name + "/",
-	}
-
 	return srvCfg, dbCfg, nil
 }
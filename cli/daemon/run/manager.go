@@ -2,6 +2,7 @@ package run
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 	encore "encore.dev"
 	"encore.dev/appruntime/exported/config"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/redis"
 	"encr.dev/cli/daemon/run/infra"
 	"encr.dev/cli/daemon/secret"
 	"encr.dev/cli/daemon/sqldb"
@@ -21,17 +23,31 @@ import (
 
 // Manager manages the set of running applications.
 type Manager struct {
-	RuntimePort int // port for Encore runtime
-	DBProxyPort int // port for sqldb proxy
-	DashPort    int // port for dev dashboard
+	RuntimePort int    // port for Encore runtime
+	DBProxyPort int    // port for sqldb proxy
+	DashPort    int    // port for dev dashboard
+	DashToken   string // access token to include in dev dashboard URLs, if any
 	Secret      *secret.Manager
 	ClusterMgr  *sqldb.ClusterManager
 
+	// ExternalRedis, if non-nil, is the Redis server to use for
+	// cache clusters instead of an in-memory server.
+	ExternalRedis *redis.External
+
 	listeners []EventListener
 	mu        sync.Mutex
 	runs      map[string]*Run // id -> run
 }
 
+// DashURL returns the URL of the dev dashboard for the given app.
+func (mgr *Manager) DashURL(appID string) string {
+	u := fmt.Sprintf("http://localhost:%d/%s", mgr.DashPort, appID)
+	if mgr.DashToken != "" {
+		u += "?token=" + url.QueryEscape(mgr.DashToken)
+	}
+	return u
+}
+
 // EventListener is the interface for listening to events
 // about running apps.
 type EventListener interface {
@@ -49,6 +65,9 @@ type EventListener interface {
 	OnStderr(r *Run, out []byte)
 	// OnError is called when a run encounters an error.
 	OnError(r *Run, err *errlist.List)
+	// OnCrash is called when a process of a run exits unexpectedly.
+	// The process is identified by its service or gateway name.
+	OnCrash(r *Run, process string, err error)
 }
 
 // FindProc finds the proc with the given id.
@@ -123,6 +142,12 @@ func (mgr *Manager) RunError(r *Run, err *errlist.List) {
 	}
 }
 
+func (mgr *Manager) RunCrash(r *Run, process string, err error) {
+	for _, ln := range mgr.listeners {
+		ln.OnCrash(r, process, err)
+	}
+}
+
 type parseAppParams struct {
 	App           *apps.Instance
 	Environ       []string
@@ -0,0 +1,61 @@
+package run
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/nodejs"
+)
+
+// prepareNode makes sure the TypeScript app can be run with Node.js,
+// and returns the environment to build and run it with.
+//
+// If the app pins a Node.js version with "build.node_version" in encore.app,
+// Encore's managed installation of it is put first on the PATH, installing it if needed.
+// Otherwise the node binary on the PATH is used. Either way the version is checked
+// against the versions supported by Encore and the app's "engines.node" field,
+// failing early instead of with errors loading the runtime's native module.
+func prepareNode(ctx context.Context, app *apps.Instance, js builder.JSRuntime, environ []string) ([]string, error) {
+	if app.Lang() != appfile.LangTS || (js != "" && js != builder.JSRuntimeNode) {
+		return environ, nil
+	}
+
+	pinned, err := appfile.NodeVersion(app.Root())
+	if err != nil {
+		return nil, err
+	}
+
+	var inst *nodejs.Installation
+	if pinned != "" {
+		v, err := nodejs.ParseVersion(pinned)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid \"build.node_version\" in encore.app")
+		}
+		if inst, err = nodejs.Managed(ctx, v); err != nil {
+			return nil, err
+		}
+		environ = nodejs.WithBinDir(environ, filepath.Dir(inst.Path))
+	} else if inst, err = nodejs.Detect(ctx, environ); err != nil {
+		return nil, err
+	}
+
+	if err := nodejs.Check(inst.Version, app.Root()); err != nil {
+		var verr *nodejs.VersionError
+		if errors.As(err, &verr) {
+			verr.Pinned = pinned != ""
+		}
+		return nil, err
+	}
+
+	if !nodejs.Tested(inst.Version) {
+		log.Warn().Str("version", inst.Version.String()).Ints("tested_majors", nodejs.TestedMajors).
+			Msg("running with a Node.js version Encore is not tested against")
+	}
+	return environ, nil
+}
@@ -222,6 +222,7 @@ func (pg *ProcGroup) newProc(processName string, listenAddr netip.AddrPort) (*Pr
 	}
 
 	p := &Proc{
+		name:       processName,
 		group:      pg,
 		log:        pg.log.With().Str("proc", processName).Logger(),
 		listenAddr: listenAddr,
@@ -259,6 +260,12 @@ func (pg *ProcGroup) NewAllInOneProc(spec builder.Cmd, listenAddr netip.AddrPort
 
 	p.cmd = cmd
 
+	if supportsHotReload(pg.Run) {
+		if p.hmr, err = newHotReloader(cmd, spec); err != nil {
+			return err
+		}
+	}
+
 	// Assign all the gateways to this process.
 	for _, gw := range pg.Meta.Gateways {
 		pg.Gateways[gw.EncoreName] = p
@@ -351,10 +358,12 @@ func (pg *ProcGroup) Warnings() (rtn []warning) {
 
 // Proc represents a single Encore process running within a [ProcGroup].
 type Proc struct {
+	name  string         // The service or gateway name of the process
 	group *ProcGroup     // The group this process belongs to
 	log   zerolog.Logger // The logger for this process
 	exit  chan struct{}  // closed when the process has exited
 	cmd   *exec.Cmd      // The command for this specific process
+	hmr   *hotReloader   // The hot reloader for the process, if supported
 
 	listenAddr netip.AddrPort         // The port the HTTP server of the process should listen on
 	httpProxy  *httputil.ReverseProxy // The reverse proxy for the HTTP server of the process
@@ -384,8 +393,14 @@ func (p *Proc) start() error {
 	}
 
 	if err := p.cmd.Start(); err != nil {
+		if p.hmr != nil {
+			p.hmr.close()
+		}
 		return errors.Wrap(err, "could not start process")
 	}
+	if p.hmr != nil {
+		p.hmr.started()
+	}
 	p.log.Info().Str("addr", p.listenAddr.String()).Msg("process started")
 	p.group.runningProcs++
 
@@ -398,8 +413,14 @@ func (p *Proc) start() error {
 
 		// Wait for the process to exit.
 		err := p.cmd.Wait()
+		if p.hmr != nil {
+			p.hmr.close()
+		}
 		if err != nil && p.group.ctx.Err() == nil {
 			p.log.Error().Err(err).Msg("process exited with error")
+			if r := p.group.Run; r != nil && r.Mgr != nil {
+				r.Mgr.RunCrash(r, p.name, err)
+			}
 		} else {
 			p.log.Info().Msg("process exited successfully")
 		}
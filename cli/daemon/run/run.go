@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"net/netip"
+	"net/url"
 	"runtime"
 	"slices"
 	"sort"
@@ -96,6 +97,14 @@ type StartParams struct {
 
 	// Debug specifies to compile the application for debugging.
 	Debug builder.DebugMode
+
+	// JSRuntime specifies the JavaScript runtime to run TypeScript apps with.
+	// If empty it defaults to Node.js.
+	JSRuntime builder.JSRuntime
+
+	// Secrets are secret values given on the command line, for secrets
+	// without a value set on the Encore Platform.
+	Secrets map[string]string
 }
 
 // BrowserMode specifies how to open the browser when starting 'encore run'.
@@ -133,11 +142,29 @@ func DebugModeFromProto(d daemonpb.RunRequest_DebugMode) builder.DebugMode {
 	}
 }
 
+func JSRuntimeFromProto(r daemonpb.RunRequest_JSRuntime) builder.JSRuntime {
+	switch r {
+	case daemonpb.RunRequest_JS_RUNTIME_NODE:
+		return builder.JSRuntimeNode
+	case daemonpb.RunRequest_JS_RUNTIME_BUN:
+		return builder.JSRuntimeBun
+	case daemonpb.RunRequest_JS_RUNTIME_DENO:
+		return builder.JSRuntimeDeno
+	default:
+		return ""
+	}
+}
+
 // Start starts the application.
 // Its lifetime is bounded by ctx.
 func (mgr *Manager) Start(ctx context.Context, params StartParams) (run *Run, err error) {
 	logger := log.With().Str("app_id", params.App.PlatformOrLocalID()).Logger()
 
+	params.Environ, err = prepareNode(ctx, params.App, params.JSRuntime, params.Environ)
+	if err != nil {
+		return nil, err
+	}
+
 	svcProxy, err := svcproxy.New(ctx, logger)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create service proxy")
@@ -147,13 +174,13 @@ func (mgr *Manager) Start(ctx context.Context, params StartParams) (run *Run, er
 		ID:              GenID(),
 		App:             params.App,
 		NS:              params.NS,
-		ResourceManager: infra.NewResourceManager(params.App, mgr.ClusterMgr, params.NS, params.Environ, mgr.DBProxyPort, false),
+		ResourceManager: infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.ExternalRedis, params.NS, params.Environ, mgr.DBProxyPort, false),
 		ListenAddr:      params.ListenAddr,
 		SvcProxy:        svcProxy,
 		log:             logger,
 		Mgr:             mgr,
 		Params:          &params,
-		secrets:         mgr.Secret.Load(params.App),
+		secrets:         mgr.Secret.Load(params.App, params.NS).WithFlagValues(params.Secrets),
 		ctx:             ctx,
 		exited:          make(chan struct{}),
 		started:         make(chan struct{}),
@@ -198,6 +225,14 @@ func (r *Run) Close() {
 	r.ResourceManager.StopAll()
 }
 
+// closeBuilder closes the builder, stopping the parser
+// it keeps running between reloads.
+func (r *Run) closeBuilder() {
+	if r.Builder != nil {
+		_ = r.Builder.Close()
+	}
+}
+
 // RunLogger is the interface for listening to run logs.
 // The log methods are called for each logline on stdout and stderr respectively.
 type RunLogger interface {
@@ -250,6 +285,7 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 			// This is closed below when err == nil,
 			// so handle the other cases.
 			close(r.started)
+			r.closeBuilder()
 			close(r.exited)
 		}
 	}()
@@ -299,6 +335,7 @@ func (r *Run) start(ln net.Listener, tracker *optracker.OpTracker) (err error) {
 				for _, ln := range r.Mgr.listeners {
 					ln.OnStop(r)
 				}
+				r.closeBuilder()
 				close(r.exited)
 				return
 			}
@@ -343,6 +380,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		CgoEnabled:         true,
 		StaticLink:         false,
 		DebugMode:          r.Params.Debug,
+		JSRuntime:          r.Params.JSRuntime,
 		Environ:            r.Params.Environ,
 		GOOS:               runtime.GOOS,
 		GOARCH:             runtime.GOARCH,
@@ -367,6 +405,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		Experiments: expSet,
 		WorkingDir:  r.Params.WorkingDir,
 		ParseTests:  false,
+		Incremental: true,
 	})
 	if err != nil {
 		tracker.Fail(parseOp, err)
@@ -415,6 +454,10 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 			if err != nil {
 				return err
 			}
+			data, err = r.Mgr.Secret.ApplyGenerated(r.App, parse.Meta, data)
+			if err != nil {
+				return err
+			}
 			secrets = data.Values
 			return nil
 		})
@@ -430,7 +473,7 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 	}
 
 	startOp := tracker.Add("Starting Encore application", start)
-	newProcess, err := r.StartProcGroup(&StartProcGroupParams{
+	procParams := &StartProcGroupParams{
 		Ctx:            ctx,
 		Outputs:        build.Outputs,
 		Meta:           parse.Meta,
@@ -441,7 +484,20 @@ func (r *Run) buildAndStart(ctx context.Context, tracker *optracker.OpTracker, i
 		WorkingDir:     r.Params.WorkingDir,
 		IsReload:       isReload,
 		Experiments:    expSet,
-	})
+	}
+
+	// If only the implementation of the app changed,
+	// swap it into the running process instead of restarting it.
+	if isReload {
+		if prev, ok := r.proc.Load().(*ProcGroup); ok && prev.HotReload(ctx, procParams) {
+			// The running process is kept, so the new proc context isn't needed.
+			cancelProcCtx()
+			tracker.Done(startOp, 50*time.Millisecond)
+			return nil
+		}
+	}
+
+	newProcess, err := r.StartProcGroup(procParams)
 	if err != nil {
 		tracker.Fail(startOp, err)
 		return err
@@ -519,18 +575,21 @@ func (r *Run) StartProcGroup(params *StartProcGroupParams) (p *ProcGroup, err er
 		Run:     r,
 		AuthKey: authKey,
 		ConfigGen: &RuntimeConfigGenerator{
-			app:            r.App,
-			infraManager:   r.ResourceManager,
-			md:             params.Meta,
-			AppID:          option.Some(r.ID),
-			EnvID:          option.Some(pid),
-			TraceEndpoint:  option.Some(fmt.Sprintf("http://localhost:%d/trace", r.Mgr.RuntimePort)),
-			AuthKey:        authKey,
-			Gateways:       gateways,
-			DefinedSecrets: params.Secrets,
-			SvcConfigs:     params.ServiceConfigs,
-			DeployID:       option.Some(fmt.Sprintf("run_%s", xid.New().String())),
-			IncludeMetaEnv: r.Builder.NeedsMeta(),
+			app:           r.App,
+			infraManager:  r.ResourceManager,
+			md:            params.Meta,
+			AppID:         option.Some(r.ID),
+			EnvID:         option.Some(pid),
+			TraceEndpoint: option.Some(fmt.Sprintf("http://localhost:%d/trace", r.Mgr.RuntimePort)),
+			MetricsEndpoint: option.Some(fmt.Sprintf("http://localhost:%d/metrics?app_id=%s",
+				r.Mgr.RuntimePort, url.QueryEscape(r.App.PlatformOrLocalID()))),
+			AuditLogEndpoint: option.Some(fmt.Sprintf("http://localhost:%d/audit", r.Mgr.RuntimePort)),
+			AuthKey:          authKey,
+			Gateways:         gateways,
+			DefinedSecrets:   params.Secrets,
+			SvcConfigs:       params.ServiceConfigs,
+			DeployID:         option.Some(fmt.Sprintf("run_%s", xid.New().String())),
+			IncludeMetaEnv:   r.Builder.NeedsMeta(),
 		},
 		Experiments: params.Experiments,
 		Meta:        params.Meta,
@@ -47,6 +47,7 @@ type RuntimeConfigGenerator struct {
 		PlatformID() string
 		PlatformOrLocalID() string
 		GlobalCORS() (appfile.CORS, error)
+		AuditLogRetention() (time.Duration, error)
 	}
 
 	// The infra manager to use
@@ -66,9 +67,15 @@ type RuntimeConfigGenerator struct {
 	EnvType       option.Option[runtimev1.Environment_Type]
 	EnvCloud      option.Option[runtimev1.Environment_Cloud]
 	TraceEndpoint option.Option[string]
-	DeployID      option.Option[string]
-	Gateways      map[string]GatewayConfig
-	AuthKey       config.EncoreAuthKey
+	// MetricsEndpoint is the Prometheus remote-write endpoint
+	// the app sends its metrics to, if any.
+	MetricsEndpoint option.Option[string]
+	// AuditLogEndpoint is the endpoint the app records
+	// its audit events to, if any.
+	AuditLogEndpoint option.Option[string]
+	DeployID         option.Option[string]
+	Gateways         map[string]GatewayConfig
+	AuthKey          config.EncoreAuthKey
 
 	// Whether to include the metadata as an environment variable.
 	IncludeMetaEnv bool
@@ -129,6 +136,34 @@ func (g *RuntimeConfigGenerator) initialize() error {
 			})
 		}
 
+		if metricsEndpoint, ok := g.MetricsEndpoint.Get(); ok {
+			g.conf.MetricsProvider(&runtimev1.MetricsProvider{
+				Rid:                newRid(),
+				CollectionInterval: durationpb.New(5 * time.Second),
+				Provider: &runtimev1.MetricsProvider_PromRemoteWrite{
+					PromRemoteWrite: &runtimev1.MetricsProvider_PrometheusRemoteWrite{
+						RemoteWriteUrl: toSecret([]byte(metricsEndpoint)),
+					},
+				},
+			})
+		}
+
+		if auditEndpoint, ok := g.AuditLogEndpoint.Get(); ok {
+			prov := &runtimev1.AuditLogProvider{
+				Rid: newRid(),
+				Provider: &runtimev1.AuditLogProvider_Encore{
+					Encore: &runtimev1.AuditLogProvider_EncoreAuditLogProvider{AuditEndpoint: auditEndpoint},
+				},
+			}
+			retention, err := g.app.AuditLogRetention()
+			if err != nil {
+				return errors.Wrap(err, "failed to read audit log retention")
+			} else if retention > 0 {
+				prov.Retention = durationpb.New(retention)
+			}
+			g.conf.AuditLogProvider(prov)
+		}
+
 		g.conf.AuthMethods([]*runtimev1.ServiceAuth{
 			{
 				AuthMethod: &runtimev1.ServiceAuth_EncoreAuth_{
@@ -0,0 +1,316 @@
+package run
+
+import (
+	"cmp"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/pkg/paths"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Shard identifies a subset of the test packages to run,
+// for splitting the tests of an app across several CI jobs.
+type Shard struct {
+	Index int // 1-based index of the shard
+	Count int // total number of shards
+}
+
+// ParseShard parses a shard in the format "index/count", like "3/8".
+func ParseShard(s string) (Shard, error) {
+	idx, count, ok := strings.Cut(s, "/")
+	if !ok {
+		return Shard{}, fmt.Errorf("invalid shard %q: expected the format index/count, like 3/8", s)
+	}
+	i, err1 := strconv.Atoi(idx)
+	n, err2 := strconv.Atoi(count)
+	if err1 != nil || err2 != nil {
+		return Shard{}, fmt.Errorf("invalid shard %q: expected the format index/count, like 3/8", s)
+	}
+	sh := Shard{Index: i, Count: n}
+	return sh, sh.Validate()
+}
+
+// Validate reports whether the shard is valid.
+func (s Shard) Validate() error {
+	if s.Count < 1 || s.Index < 1 || s.Index > s.Count {
+		return fmt.Errorf("invalid shard %d/%d: the index must be between 1 and the number of shards", s.Index, s.Count)
+	}
+	return nil
+}
+
+func (s Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// errEmptyShard is returned when a shard has no test packages to run,
+// which happens when there are more shards than units of test packages.
+var errEmptyShard = errors.New("no test packages in shard")
+
+// shardTestArgs rewrites the "go test" arguments to only test the packages in the given shard.
+//
+// The packages matching the package patterns in args are partitioned into units that are
+// assigned to shards as a whole: all the packages of a service form a unit, so the service's
+// infrastructure is only set up in one shard, and each package outside of a service forms its own unit.
+// The units are balanced across the shards by their historical test durations.
+// Every shard computes the same partition, given the same app and timings,
+// so each package is tested in exactly one shard.
+func shardTestArgs(md *meta.Data, appRoot, workingDir paths.FS, args []string, shard Shard, timings *testTimings) ([]string, error) {
+	pkgIdx, patterns := packageArgs(args)
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	// Convert the patterns to import path patterns.
+	for i, p := range patterns {
+		if p == "." || p == ".." || strings.HasPrefix(p, "./") || strings.HasPrefix(p, "../") || filepath.IsAbs(p) {
+			rel, err := filepath.Rel(appRoot.ToIO(), workingDir.Join(filepath.FromSlash(p)).ToIO())
+			if err != nil || !filepath.IsLocal(rel) && rel != "." {
+				return nil, fmt.Errorf("package pattern %q is outside the app", p)
+			}
+			p = path.Join(md.ModulePath, filepath.ToSlash(rel))
+		}
+		patterns[i] = p
+	}
+
+	pkgs, err := testPackages(appRoot, md.ModulePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "find test packages")
+	}
+	pkgs = slices.DeleteFunc(pkgs, func(pkg string) bool {
+		return !slices.ContainsFunc(patterns, func(p string) bool { return matchPackage(p, pkg) })
+	})
+
+	units := testUnits(md, pkgs)
+	selected := assignShard(units, shard, timings)
+	if len(selected) == 0 {
+		return nil, errEmptyShard
+	}
+
+	// Replace the package patterns with the selected packages,
+	// where the first pattern was given.
+	insertAt := len(args)
+	if len(pkgIdx) > 0 {
+		insertAt = pkgIdx[0]
+	} else if i := slices.IndexFunc(args, func(a string) bool { return a == "-args" || a == "--args" }); i >= 0 {
+		insertAt = i
+	}
+	out := make([]string, 0, len(args)+len(selected))
+	for i, arg := range args {
+		if i == insertAt {
+			out = append(out, selected...)
+		}
+		if !slices.Contains(pkgIdx, i) {
+			out = append(out, arg)
+		}
+	}
+	if insertAt == len(args) {
+		out = append(out, selected...)
+	}
+	return out, nil
+}
+
+// testFlagsWithValue are the "go test" flags that take a value,
+// when not given as -flag=value.
+var testFlagsWithValue = map[string]bool{
+	// Build flags
+	"C": true, "asmflags": true, "buildmode": true, "compiler": true, "exec": true, "gccgoflags": true,
+	"gcflags": true, "installsuffix": true, "ldflags": true, "mod": true, "modfile": true, "o": true,
+	"overlay": true, "p": true, "pgo": true, "pkgdir": true, "tags": true, "toolexec": true, "vet": true,
+	"covermode": true, "coverpkg": true,
+
+	// Test flags
+	"bench": true, "benchtime": true, "blockprofile": true, "blockprofilerate": true, "count": true,
+	"coverprofile": true, "cpu": true, "cpuprofile": true, "fuzz": true, "fuzzminimizetime": true,
+	"fuzztime": true, "list": true, "memprofile": true, "memprofilerate": true, "mutexprofile": true,
+	"mutexprofilefraction": true, "outputdir": true, "parallel": true, "run": true, "shuffle": true,
+	"skip": true, "timeout": true, "trace": true,
+}
+
+// packageArgs returns the indices and values of the package patterns in the "go test" arguments.
+func packageArgs(args []string) (indices []int, patterns []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-args" || arg == "--args" {
+			break
+		} else if name, ok := strings.CutPrefix(arg, "-"); ok {
+			name = strings.TrimPrefix(name, "-")
+			if !strings.Contains(name, "=") && testFlagsWithValue[strings.TrimPrefix(name, "test.")] {
+				i++ // skip the value
+			}
+			continue
+		}
+		indices = append(indices, i)
+		patterns = append(patterns, arg)
+	}
+	return indices, patterns
+}
+
+// matchPackage reports whether the import path matches the package pattern,
+// where "..." matches any string, like for the go command.
+func matchPackage(pattern, pkg string) bool {
+	if !strings.Contains(pattern, "...") {
+		return pattern == pkg
+	}
+	re := regexp.QuoteMeta(pattern)
+	re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+	// "foo/..." matches "foo" itself as well.
+	if strings.HasSuffix(re, `/.*`) {
+		re = strings.TrimSuffix(re, `/.*`) + `(/.*)?`
+	}
+	return regexp.MustCompile(`^` + re + `$`).MatchString(pkg)
+}
+
+// testPackages returns the import paths of the app's packages that have test files.
+// Like the go command, it skips directories that begin with "." or "_", testdata and vendor directories
+// and directories containing other modules.
+func testPackages(appRoot paths.FS, modulePath string) ([]string, error) {
+	root := appRoot.ToIO()
+	seen := make(map[string]bool)
+	var pkgs []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if p != root {
+				if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" || name == "node_modules" {
+					return filepath.SkipDir
+				}
+				if _, err := os.Stat(filepath.Join(p, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
+		if strings.HasSuffix(d.Name(), "_test.go") && !strings.HasPrefix(d.Name(), ".") && !strings.HasPrefix(d.Name(), "_") {
+			rel, err := filepath.Rel(root, filepath.Dir(p))
+			if err != nil {
+				return err
+			}
+			pkg := path.Join(modulePath, filepath.ToSlash(rel))
+			if !seen[pkg] {
+				seen[pkg] = true
+				pkgs = append(pkgs, pkg)
+			}
+		}
+		return nil
+	})
+	slices.Sort(pkgs)
+	return pkgs, err
+}
+
+// testUnit is a group of test packages that are assigned to the same shard.
+type testUnit struct {
+	name string   // the service name, or the import path for packages outside of services
+	pkgs []string // import paths
+}
+
+// testUnits groups the packages into units: one per service, and one per package outside of services.
+func testUnits(md *meta.Data, pkgs []string) []*testUnit {
+	bySvc := make(map[string]*testUnit)
+	var units []*testUnit
+	for _, pkg := range pkgs {
+		rel := strings.TrimPrefix(strings.TrimPrefix(pkg, md.ModulePath), "/")
+		if rel == "" {
+			rel = "."
+		}
+
+		// Find the service the package belongs to, preferring the most specific one.
+		var svc *meta.Service
+		for _, s := range md.Svcs {
+			if s.RelPath == rel || s.RelPath == "." || strings.HasPrefix(rel, s.RelPath+"/") {
+				if svc == nil || len(s.RelPath) > len(svc.RelPath) || svc.RelPath == "." {
+					svc = s
+				}
+			}
+		}
+
+		if svc == nil {
+			units = append(units, &testUnit{name: pkg, pkgs: []string{pkg}})
+		} else if u, ok := bySvc[svc.Name]; ok {
+			u.pkgs = append(u.pkgs, pkg)
+		} else {
+			u := &testUnit{name: svc.Name, pkgs: []string{pkg}}
+			bySvc[svc.Name] = u
+			units = append(units, u)
+		}
+	}
+	return units
+}
+
+// assignShard balances the units across the shards by their estimated durations,
+// and returns the packages of the units assigned to the given shard.
+//
+// It assigns the units from the longest to the shortest to the shard with the
+// shortest total duration so far, which is deterministic given the same inputs.
+func assignShard(units []*testUnit, shard Shard, timings *testTimings) []string {
+	// Estimate the duration of packages without timings as the average of the others,
+	// so the units are balanced by package count when there are no timings at all.
+	var known time.Duration
+	var numKnown int
+	for _, u := range units {
+		for _, pkg := range u.pkgs {
+			if d, ok := timings.Get(pkg); ok {
+				known += d
+				numKnown++
+			}
+		}
+	}
+	estimate := time.Second
+	if numKnown > 0 {
+		estimate = max(known/time.Duration(numKnown), time.Millisecond)
+	}
+
+	type weighted struct {
+		unit *testUnit
+		dur  time.Duration
+	}
+	ws := make([]weighted, len(units))
+	for i, u := range units {
+		ws[i].unit = u
+		for _, pkg := range u.pkgs {
+			if d, ok := timings.Get(pkg); ok {
+				ws[i].dur += d
+			} else {
+				ws[i].dur += estimate
+			}
+		}
+	}
+	slices.SortFunc(ws, func(a, b weighted) int {
+		if c := cmp.Compare(b.dur, a.dur); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.unit.name, b.unit.name)
+	})
+
+	totals := make([]time.Duration, shard.Count)
+	var selected []string
+	for _, w := range ws {
+		// Pick the first shard with the shortest total.
+		idx := 0
+		for i, t := range totals {
+			if t < totals[idx] {
+				idx = i
+			}
+		}
+		totals[idx] += w.dur
+		if idx == shard.Index-1 {
+			selected = append(selected, w.unit.pkgs...)
+		}
+	}
+	slices.Sort(selected)
+	return selected
+}
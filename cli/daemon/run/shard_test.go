@@ -0,0 +1,131 @@
+package run
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/paths"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestParseShard(t *testing.T) {
+	c := qt.New(t)
+
+	sh, err := ParseShard("3/8")
+	c.Assert(err, qt.IsNil)
+	c.Assert(sh, qt.Equals, Shard{Index: 3, Count: 8})
+
+	for _, s := range []string{"3", "a/8", "0/8", "9/8", "1/0"} {
+		_, err := ParseShard(s)
+		c.Assert(err, qt.IsNotNil, qt.Commentf("shard %q", s))
+	}
+}
+
+func TestPackageArgs(t *testing.T) {
+	c := qt.New(t)
+
+	idx, pkgs := packageArgs([]string{"-v", "-run", "TestFoo", "./...", "-count=1", "./bar", "-args", "./baz"})
+	c.Assert(idx, qt.DeepEquals, []int{3, 5})
+	c.Assert(pkgs, qt.DeepEquals, []string{"./...", "./bar"})
+}
+
+func TestMatchPackage(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(matchPackage("app/foo", "app/foo"), qt.IsTrue)
+	c.Assert(matchPackage("app/foo", "app/foo/bar"), qt.IsFalse)
+	c.Assert(matchPackage("app/foo/...", "app/foo"), qt.IsTrue)
+	c.Assert(matchPackage("app/foo/...", "app/foo/bar"), qt.IsTrue)
+	c.Assert(matchPackage("app/foo/...", "app/foobar"), qt.IsFalse)
+	c.Assert(matchPackage("app/...", "app"), qt.IsTrue)
+}
+
+func TestShardTestArgs(t *testing.T) {
+	c := qt.New(t)
+
+	root := c.TempDir()
+	for _, dir := range []string{".", "orders", "orders/internal", "users", "pkg/util", "pkg/db", "cmd/tool", "testdata/x", ".hidden", "nested"} {
+		c.Assert(os.MkdirAll(filepath.Join(root, dir), 0755), qt.IsNil)
+		c.Assert(os.WriteFile(filepath.Join(root, dir, "x_test.go"), nil, 0644), qt.IsNil)
+	}
+	c.Assert(os.WriteFile(filepath.Join(root, "nested", "go.mod"), nil, 0644), qt.IsNil)
+	c.Assert(os.MkdirAll(filepath.Join(root, "notests"), 0755), qt.IsNil)
+
+	md := &meta.Data{
+		ModulePath: "example.com/app",
+		Svcs: []*meta.Service{
+			{Name: "orders", RelPath: "orders"},
+			{Name: "users", RelPath: "users"},
+		},
+	}
+	appRoot := paths.RootedFSPath(root, ".")
+
+	all := []string{
+		"example.com/app", "example.com/app/cmd/tool", "example.com/app/orders",
+		"example.com/app/orders/internal", "example.com/app/pkg/db", "example.com/app/pkg/util", "example.com/app/users",
+	}
+	timings := &testTimings{Pkgs: map[string]time.Duration{
+		"example.com/app/orders":          10 * time.Second,
+		"example.com/app/orders/internal": 5 * time.Second,
+		"example.com/app/users":           8 * time.Second,
+		"example.com/app/pkg/db":          6 * time.Second,
+	}}
+
+	// Every package is run in exactly one shard, with the packages of a service in the same shard.
+	for _, count := range []int{1, 2, 3, 10} {
+		var got []string
+		for i := 1; i <= count; i++ {
+			args, err := shardTestArgs(md, appRoot, appRoot, []string{"-v", "./...", "-args", "-x"}, Shard{Index: i, Count: count}, timings)
+			if err == errEmptyShard {
+				continue
+			}
+			c.Assert(err, qt.IsNil)
+			c.Assert(args[0], qt.Equals, "-v")
+			c.Assert(args[len(args)-2:], qt.DeepEquals, []string{"-args", "-x"})
+			pkgs := args[1 : len(args)-2]
+			c.Assert(slices.Contains(pkgs, "example.com/app/orders"), qt.Equals, slices.Contains(pkgs, "example.com/app/orders/internal"))
+			got = append(got, pkgs...)
+		}
+		slices.Sort(got)
+		c.Assert(got, qt.DeepEquals, all, qt.Commentf("%d shards", count))
+	}
+
+	// The shards are balanced by duration, estimating packages without timings
+	// as the average of the others (7.25s), for 28.25s and 22.5s in total.
+	args, err := shardTestArgs(md, appRoot, appRoot, []string{"./..."}, Shard{Index: 1, Count: 2}, timings)
+	c.Assert(err, qt.IsNil)
+	c.Assert(args, qt.DeepEquals, []string{
+		"example.com/app/cmd/tool", "example.com/app/orders", "example.com/app/orders/internal", "example.com/app/pkg/db",
+	})
+
+	// Patterns are relative to the working directory.
+	args, err = shardTestArgs(md, appRoot, appRoot.Join("pkg"), []string{"./...", "-count=1"}, Shard{Index: 1, Count: 1}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(args, qt.DeepEquals, []string{"example.com/app/pkg/db", "example.com/app/pkg/util", "-count=1"})
+
+	_, err = shardTestArgs(md, appRoot, appRoot, []string{"./notests"}, Shard{Index: 1, Count: 1}, nil)
+	c.Assert(err, qt.Equals, errEmptyShard)
+}
+
+func TestTimingRecorder(t *testing.T) {
+	c := qt.New(t)
+
+	timings := &testTimings{Pkgs: map[string]time.Duration{"example.com/cached": time.Second}}
+	r := &timingRecorder{w: io.Discard, timings: timings}
+	_, _ = io.WriteString(r, "ok  \texample.com/a\t1.500s\tcoverage: 50.0% of statements\nFAIL\texample.com/b\t0.2")
+	_, _ = io.WriteString(r, "50s\nok  \texample.com/cached\t(cached)\n?   \texample.com/c\t[no test files]\n")
+	_, _ = io.WriteString(r, `{"Action":"pass","Package":"example.com/d","Test":"TestX","Elapsed":9}`+"\n")
+	_, _ = io.WriteString(r, `{"Action":"pass","Package":"example.com/d","Elapsed":3}`+"\n")
+
+	c.Assert(timings.Pkgs, qt.DeepEquals, map[string]time.Duration{
+		"example.com/a":      1500 * time.Millisecond,
+		"example.com/b":      250 * time.Millisecond,
+		"example.com/cached": time.Second,
+		"example.com/d":      3 * time.Second,
+	})
+}
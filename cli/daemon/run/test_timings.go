@@ -0,0 +1,146 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/xos"
+)
+
+// testTimings are the durations of the most recent test runs of an app's packages,
+// used to balance test shards.
+type testTimings struct {
+	mu   sync.Mutex
+	Pkgs map[string]time.Duration `json:"pkgs"` // import path -> duration
+}
+
+// testTimingsFile returns the path to the file storing the test timings of the app.
+func testTimingsFile(app *apps.Instance) (string, error) {
+	cacheDir, err := app.CachePath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "test_timings.json"), nil
+}
+
+// loadTestTimings loads the test timings of the app.
+// It returns empty timings if none have been recorded.
+func loadTestTimings(app *apps.Instance) (*testTimings, error) {
+	t := &testTimings{Pkgs: make(map[string]time.Duration)}
+	file, err := testTimingsFile(app)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read test timings")
+	}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, errors.Wrap(err, "parse test timings")
+	}
+	if t.Pkgs == nil {
+		t.Pkgs = make(map[string]time.Duration)
+	}
+	return t, nil
+}
+
+// save saves the test timings of the app.
+func (t *testTimings) save(app *apps.Instance) error {
+	file, err := testTimingsFile(app)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t, "", "  ")
+	t.mu.Unlock()
+	if err != nil {
+		return errors.Wrap(err, "marshal test timings")
+	}
+	return errors.Wrap(xos.WriteFile(file, data, 0644), "write test timings")
+}
+
+// Get returns the recorded duration of the package's tests.
+// It is safe to call on nil timings.
+func (t *testTimings) Get(pkg string) (time.Duration, bool) {
+	if t == nil {
+		return 0, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	d, ok := t.Pkgs[pkg]
+	return d, ok
+}
+
+// Set records the duration of the package's tests.
+func (t *testTimings) Set(pkg string, d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Pkgs[pkg] = d
+}
+
+// timingRecorder is an io.Writer that passes "go test" output through to w,
+// recording the duration of each package's tests, from both the plain
+// and the JSON output formats. Cached results, which report no duration, are not recorded.
+type timingRecorder struct {
+	w       io.Writer
+	timings *testTimings
+	buf     []byte // incomplete line
+}
+
+func (r *timingRecorder) Write(p []byte) (int, error) {
+	r.buf = append(r.buf, p...)
+	for {
+		idx := bytes.IndexByte(r.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		r.parseLine(r.buf[:idx])
+		r.buf = r.buf[idx+1:]
+	}
+	return r.w.Write(p)
+}
+
+// parseLine records the package duration reported by the line, if any.
+func (r *timingRecorder) parseLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) > 0 && line[0] == '{' {
+		// Test events written with -json.
+		var ev struct {
+			Action  string
+			Package string
+			Test    string
+			Elapsed float64
+		}
+		if json.Unmarshal(line, &ev) == nil && ev.Test == "" && ev.Package != "" && ev.Elapsed > 0 && (ev.Action == "pass" || ev.Action == "fail") {
+			r.timings.Set(ev.Package, time.Duration(ev.Elapsed*float64(time.Second)))
+		}
+		return
+	}
+
+	// Package results, like "ok  \texample.com/pkg\t0.123s\tcoverage: 50.0% of statements".
+	fields := strings.Split(string(line), "\t")
+	if len(fields) < 3 {
+		return
+	}
+	status := strings.TrimSpace(fields[0])
+	if status != "ok" && status != "FAIL" {
+		return
+	}
+	if secs, ok := strings.CutSuffix(fields[2], "s"); ok {
+		if f, err := strconv.ParseFloat(secs, 64); err == nil {
+			r.timings.Set(fields[1], time.Duration(f*float64(time.Second)))
+		}
+	}
+}
@@ -8,13 +8,16 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
 
 	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/run/infra"
 	"encr.dev/cli/daemon/secret"
+	"encr.dev/cli/daemon/sqldb"
 	"encr.dev/internal/optracker"
+	"encr.dev/pkg/appfile"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/builder/builderimpl"
 	"encr.dev/pkg/cueutil"
@@ -31,6 +34,10 @@ type TestParams struct {
 
 	// Stdout and Stderr are where "go test" output should be written.
 	Stdout, Stderr io.Writer
+
+	// CoverageSummary, if set, is the file to write the per-service
+	// coverage summary to, as JSON, when running with -coverprofile.
+	CoverageSummary string
 }
 
 // Test runs the tests.
@@ -43,17 +50,59 @@ func (mgr *Manager) Test(ctx context.Context, params TestParams) (err error) {
 	defer fns.CloseIgnore(bld)
 
 	spec, err := mgr.testSpec(ctx, bld, expSet, params.TestSpecParams)
-	if err != nil {
+	if errors.Is(err, errEmptyShard) {
+		shard, _ := params.Shard.Get()
+		_, _ = fmt.Fprintf(params.Stdout, "no test packages to run in shard %s\n", shard)
+		return nil
+	} else if err != nil {
 		return err
 	}
 
+	// Record the duration of each package's tests, for balancing test shards.
+	stdout := params.Stdout
+	timings, timingsErr := loadTestTimings(params.App)
+	if timingsErr != nil {
+		log.Warn().Err(timingsErr).Msg("unable to load test timings")
+	} else {
+		stdout = &timingRecorder{w: params.Stdout, timings: timings}
+	}
+
 	workingDir := paths.RootedFSPath(params.App.Root(), params.WorkingDir)
-	return bld.RunTests(ctx, builder.RunTestsParams{
+	err = bld.RunTests(ctx, builder.RunTestsParams{
 		Spec:       spec,
 		WorkingDir: workingDir,
-		Stdout:     params.Stdout,
+		Stdout:     stdout,
 		Stderr:     params.Stderr,
 	})
+
+	// The test binaries have exited, so drop the databases they cloned.
+	if cluster, ok := mgr.testCluster(params); ok {
+		if dropErr := cluster.DropExitedPackageDBs(ctx); dropErr != nil {
+			log.Warn().Err(dropErr).Msg("unable to drop test databases")
+		}
+	}
+
+	if timings != nil {
+		if saveErr := timings.save(params.App); saveErr != nil {
+			log.Warn().Err(saveErr).Msg("unable to save test timings")
+		}
+	}
+
+	// Report coverage even if tests failed, as the profile is still written.
+	if profile := coverProfileArg(params.Args); profile != "" {
+		if covErr := processCoverage(params, workingDir, profile, params.CoverageSummary, params.Stdout); covErr != nil && err == nil {
+			err = covErr
+		}
+	}
+	return err
+}
+
+// testCluster returns the database cluster used by the tests, if it's running.
+func (mgr *Manager) testCluster(params TestParams) (*sqldb.Cluster, bool) {
+	if mgr.ClusterMgr == nil {
+		return nil, false
+	}
+	return mgr.ClusterMgr.Get(sqldb.GetClusterID(params.App, sqldb.Test, params.NS))
 }
 
 // TestSpecParams are the parameters for computing a test spec.
@@ -81,6 +130,10 @@ type TestSpecParams struct {
 	// CodegenDebug, if true, specifies to keep the output
 	// around for codegen debugging purposes.
 	CodegenDebug bool
+
+	// Shard, if set, specifies to only test the packages in the given shard,
+	// replacing the package patterns in Args with the packages in the shard.
+	Shard option.Option[Shard]
 }
 
 type TestSpecResponse struct {
@@ -111,13 +164,18 @@ func (mgr *Manager) TestSpec(ctx context.Context, params TestSpecParams) (*TestS
 
 // testSpec returns how to run the tests.
 func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *experiments.Set, params *TestSpecParams) (*builder.TestSpecResult, error) {
-	var secrets map[string]string
+	environ, err := prepareNode(ctx, params.App, "", params.Environ)
+	if err != nil {
+		return nil, err
+	}
+	params.Environ = environ
+
+	var secretData *secret.Data
 	if params.Secrets != nil {
-		secretData, err := params.Secrets.Get(ctx, expSet)
+		secretData, err = params.Secrets.Get(ctx, expSet)
 		if err != nil {
 			return nil, err
 		}
-		secrets = secretData.Values
 	}
 
 	vcsRevision := vcs.GetRevision(params.App.Root())
@@ -148,7 +206,33 @@ func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *expe
 		return nil, errors.Wrap(err, "cache metadata")
 	}
 
-	rm := infra.NewResourceManager(params.App, mgr.ClusterMgr, params.NS, nil, mgr.DBProxyPort, true)
+	args := params.Args
+	if shard, ok := params.Shard.Get(); ok {
+		if params.App.Lang() != appfile.LangGo {
+			return nil, errors.New("test sharding is only supported for Go applications")
+		}
+		timings, err := loadTestTimings(params.App)
+		if err != nil {
+			return nil, err
+		}
+		appRoot := paths.RootedFSPath(params.App.Root(), ".")
+		workingDir := paths.RootedFSPath(params.App.Root(), params.WorkingDir)
+		args, err = shardTestArgs(parse.Meta, appRoot, workingDir, args, shard, timings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var secrets map[string]string
+	if secretData != nil {
+		secretData, err = mgr.Secret.ApplyGenerated(params.App, parse.Meta, secretData)
+		if err != nil {
+			return nil, err
+		}
+		secrets = secretData.Values
+	}
+
+	rm := infra.NewResourceManager(params.App, mgr.ClusterMgr, mgr.ExternalRedis, params.NS, nil, mgr.DBProxyPort, true)
 
 	jobs := optracker.NewAsyncBuildJobs(ctx, params.App.PlatformOrLocalID(), nil)
 	rm.StartRequiredServices(jobs, parse.Meta)
@@ -182,20 +266,21 @@ func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *expe
 
 	authKey := genAuthKey()
 	configGen := &RuntimeConfigGenerator{
-		app:            params.App,
-		infraManager:   rm,
-		md:             parse.Meta,
-		AppID:          option.Some(params.App.PlatformOrLocalID()),
-		EnvID:          option.Some("test"),
-		TraceEndpoint:  option.Some(fmt.Sprintf("http://localhost:%d/trace", mgr.RuntimePort)),
-		AuthKey:        authKey,
-		Gateways:       gateways,
-		DefinedSecrets: secrets,
-		SvcConfigs:     cfg.Configs,
-		EnvName:        option.Some("test"),
-		EnvType:        option.Some(runtimev1.Environment_TYPE_TEST),
-		DeployID:       option.Some(fmt.Sprintf("clitest_%s", xid.New().String())),
-		IncludeMetaEnv: bld.NeedsMeta(),
+		app:              params.App,
+		infraManager:     rm,
+		md:               parse.Meta,
+		AppID:            option.Some(params.App.PlatformOrLocalID()),
+		EnvID:            option.Some("test"),
+		TraceEndpoint:    option.Some(fmt.Sprintf("http://localhost:%d/trace", mgr.RuntimePort)),
+		AuditLogEndpoint: option.Some(fmt.Sprintf("http://localhost:%d/audit", mgr.RuntimePort)),
+		AuthKey:          authKey,
+		Gateways:         gateways,
+		DefinedSecrets:   secrets,
+		SvcConfigs:       cfg.Configs,
+		EnvName:          option.Some("test"),
+		EnvType:          option.Some(runtimev1.Environment_TYPE_TEST),
+		DeployID:         option.Some(fmt.Sprintf("clitest_%s", xid.New().String())),
+		IncludeMetaEnv:   bld.NeedsMeta(),
 	}
 
 	env, err := configGen.ForTests(bld.UseNewRuntimeConfig())
@@ -214,6 +299,6 @@ func (mgr *Manager) testSpec(ctx context.Context, bld builder.Impl, expSet *expe
 			WorkingDir:  params.WorkingDir,
 		},
 		Env:  append(params.Environ, env...),
-		Args: params.Args,
+		Args: args,
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/secret"
 	"encr.dev/pkg/watcher"
 )
 
@@ -56,7 +57,10 @@ func IgnoreEvents(events []watcher.Event) bool {
 
 func ignoreEvent(ev watcher.Event) bool {
 	filename := filepath.Base(ev.Path)
-	if strings.HasPrefix(strings.ToLower(filename), "encore.gen.") {
+	if filename == secret.LocalEnvFile {
+		// Reload the local secret values
+		return false
+	} else if strings.HasPrefix(strings.ToLower(filename), "encore.gen.") {
 		// Ignore generated code
 		return true
 	}
@@ -0,0 +1,109 @@
+package secret
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LocalEnvFile is the name of the file in the app root holding default
+// secret values for local development, in the dotenv format:
+//
+//	# Comments and blank lines are ignored.
+//	StripeKey=sk_test_...
+//	Greeting="hello, world"
+//
+// Values set on the Encore Platform take precedence over its values.
+const LocalEnvFile = ".secrets.local.env"
+
+// applyLocalDefaults returns a new Data object where the secrets with no
+// value in src are given the value from flagValues or the local env file
+// in appRoot, in that order of precedence.
+//
+// If there are no defaults src is returned directly.
+// The original src data object is never modified.
+func applyLocalDefaults(appRoot string, flagValues map[string]string, src *Data) (*Data, error) {
+	defaults, err := readLocalEnvFile(filepath.Join(appRoot, LocalEnvFile))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range flagValues {
+		defaults[k] = v
+	}
+	if len(defaults) == 0 {
+		return src, nil
+	}
+
+	updated := &Data{
+		Synced: src.Synced,
+		Values: defaults,
+	}
+	for k, v := range src.Values {
+		updated.Values[k] = v
+	}
+	return updated, nil
+}
+
+// readLocalEnvFile reads the secret values in the env file at path, if any.
+func readLocalEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]string), nil
+	} else if err != nil {
+		return nil, err
+	}
+	values, err := parseEnvFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %v", LocalEnvFile, err)
+	}
+	return values, nil
+}
+
+// parseEnvFile parses the KEY=VALUE lines of a dotenv file.
+// Lines may be prefixed with "export". Unquoted values end at a " #" comment,
+// double-quoted values support Go escape sequences like \n,
+// and single-quoted values are used as-is.
+func parseEnvFile(data []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, val, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		val = strings.TrimSpace(val)
+		switch {
+		case strings.HasPrefix(val, `"`):
+			unquoted, err := strconv.Unquote(val)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted value for %s", lineNum, key)
+			}
+			val = unquoted
+		case strings.HasPrefix(val, "'"):
+			if len(val) < 2 || !strings.HasSuffix(val, "'") {
+				return nil, fmt.Errorf("line %d: invalid quoted value for %s", lineNum, key)
+			}
+			val = val[1 : len(val)-1]
+		default:
+			if idx := strings.Index(val, " #"); idx >= 0 {
+				val = strings.TrimSpace(val[:idx])
+			}
+		}
+		values[key] = val
+	}
+	return values, sc.Err()
+}
@@ -0,0 +1,61 @@
+package secret
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	c := qt.New(t)
+	values, err := parseEnvFile([]byte(`# Local secrets.
+
+StripeKey=sk_test_default
+export Exported = exported # a comment
+Quoted="a \"quoted\"\nvalue"
+Single='as-is \n # not a comment'
+Empty=
+`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(values, qt.DeepEquals, map[string]string{
+		"StripeKey": "sk_test_default",
+		"Exported":  "exported",
+		"Quoted":    "a \"quoted\"\nvalue",
+		"Single":    `as-is \n # not a comment`,
+		"Empty":     "",
+	})
+
+	_, err = parseEnvFile([]byte("StripeKey=ok\nnot a pair\n"))
+	c.Assert(err, qt.ErrorMatches, `line 2: expected KEY=VALUE`)
+
+	_, err = parseEnvFile([]byte(`Key="unterminated`))
+	c.Assert(err, qt.ErrorMatches, `line 1: invalid quoted value for Key`)
+}
+
+func TestLocalDefaults(t *testing.T) {
+	c := qt.New(t)
+	appRoot := c.TempDir()
+
+	// Without an env file or flags the source data is used directly.
+	src := &Data{Values: map[string]string{"Remote": "remote"}}
+	data, err := applyLocalDefaults(appRoot, nil, src)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data, qt.Equals, src)
+
+	err = os.WriteFile(filepath.Join(appRoot, LocalEnvFile), []byte("FromFile=file\nFromFlag=file\nRemote=file\n"), 0600)
+	c.Assert(err, qt.IsNil)
+
+	// The precedence is env file < flag < platform value.
+	data, err = applyLocalDefaults(appRoot, map[string]string{"FromFlag": "flag", "Remote": "flag"}, src)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data.Values, qt.DeepEquals, map[string]string{
+		"FromFile": "file",
+		"FromFlag": "flag",
+		"Remote":   "remote",
+	})
+
+	// The source data is never modified.
+	c.Assert(src.Values, qt.DeepEquals, map[string]string{"Remote": "remote"})
+}
@@ -0,0 +1,188 @@
+package secret
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/uuid"
+
+	"encr.dev/cli/daemon/apps"
+	"encr.dev/pkg/xos"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// generatedValue is a generated secret value, persisted so that
+// it stays the same across runs.
+type generatedValue struct {
+	Shape string // the meta.GeneratedSecret_Shape it was generated for
+	Value string
+}
+
+// ApplyGenerated returns a new Data object where the secrets in md marked
+// encore:"generate" that have no value in src are given a generated value.
+// Values that are set, whether synced or overridden locally, take precedence.
+//
+// The generated values are persisted per app, so they stay the same across
+// runs. They're only ever used for local development and tests.
+//
+// If no values need to be generated src is returned directly.
+// The original src data object is never modified.
+func (mgr *Manager) ApplyGenerated(app *apps.Instance, md *meta.Data, src *Data) (*Data, error) {
+	var missing []*meta.GeneratedSecret
+	lists := make(map[string]bool)
+	for _, pkg := range md.Pkgs {
+		for _, s := range pkg.GeneratedSecrets {
+			if _, ok := src.Values[s.Key]; !ok {
+				missing = append(missing, s)
+			}
+		}
+		for _, key := range pkg.ListSecrets {
+			lists[key] = true
+		}
+	}
+	if len(missing) == 0 {
+		return src, nil
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	path, err := mgr.generatedPath(app.PlatformOrLocalID())
+	if err != nil {
+		return nil, err
+	}
+	generated, err := readGenerated(path)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &Data{
+		Synced: src.Synced,
+		Values: make(map[string]string, len(src.Values)+len(missing)),
+	}
+	for k, v := range src.Values {
+		updated.Values[k] = v
+	}
+
+	changed := false
+	for _, s := range missing {
+		shape := s.Shape.String()
+		if g, ok := generated[s.Key]; !ok || g.Shape != shape {
+			val, err := GenerateValue(s.Shape)
+			if err != nil {
+				return nil, fmt.Errorf("generate secret %s: %v", s.Key, err)
+			}
+			generated[s.Key] = generatedValue{Shape: shape, Value: val}
+			changed = true
+		}
+		val := generated[s.Key].Value
+		if lists[s.Key] {
+			// The values of []string secrets are stored as JSON lists.
+			data, err := json.Marshal([]string{val})
+			if err != nil {
+				return nil, err
+			}
+			val = string(data)
+		}
+		updated.Values[s.Key] = val
+	}
+
+	if changed {
+		if err := writeGenerated(path, generated); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// alnumChars are the characters of generated alphanumeric values.
+const alnumChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// GenerateValue generates a random secret value of the given shape.
+func GenerateValue(shape meta.GeneratedSecret_Shape) (string, error) {
+	if shape == meta.GeneratedSecret_UUID {
+		u, err := uuid.NewV4()
+		if err != nil {
+			return "", err
+		}
+		return u.String(), nil
+	}
+
+	if shape == meta.GeneratedSecret_ALNUM {
+		val := make([]byte, 32)
+		for i := range val {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alnumChars))))
+			if err != nil {
+				return "", err
+			}
+			val[i] = alnumChars[n.Int64()]
+		}
+		return string(val), nil
+	}
+
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	switch shape {
+	case meta.GeneratedSecret_HEX:
+		return hex.EncodeToString(data), nil
+	case meta.GeneratedSecret_BASE64:
+		return base64.StdEncoding.EncodeToString(data), nil
+	case meta.GeneratedSecret_BYTES:
+		// The values of []byte secrets are stored base64-encoded,
+		// and decoded by the runtime.
+		return base64.StdEncoding.EncodeToString(data), nil
+	default:
+		return "", fmt.Errorf("unknown shape %v", shape)
+	}
+}
+
+// generatedPath returns the file path to where the given app's
+// generated secret values are stored on disk.
+func (mgr *Manager) generatedPath(appID string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "encore", "secrets", "generated", appID+".json"), nil
+}
+
+// readGenerated reads the generated secret values stored at path, if any.
+func readGenerated(path string) (map[string]generatedValue, error) {
+	generated := make(map[string]generatedValue)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return generated, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read generated secrets: %v", err)
+	}
+	if err := json.Unmarshal(data, &generated); err != nil {
+		return nil, fmt.Errorf("read generated secrets: %v", err)
+	}
+	return generated, nil
+}
+
+// writeGenerated writes the generated secret values to path,
+// readable only for the current user.
+func writeGenerated(path string, generated map[string]generatedValue) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("write generated secrets: %v", err)
+	}
+	out, err := json.Marshal(generated)
+	if err != nil {
+		return err
+	}
+	if err := xos.WriteFile(path, out, 0600); err != nil {
+		return fmt.Errorf("write generated secrets: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package secret
+
+import (
+	"encoding/base64"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/cli/daemon/apps"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestApplyGenerated(t *testing.T) {
+	c := qt.New(t)
+	c.Setenv("XDG_CACHE_HOME", c.TempDir())
+	c.Setenv("HOME", c.TempDir())
+
+	mgr := New()
+	app := apps.NewInstance(c.TempDir(), "app", "")
+	md := &meta.Data{Pkgs: []*meta.Package{{
+		Secrets:     []string{"APIToken", "APITokens", "SessionKey", "SigningKey", "StripeKey"},
+		ListSecrets: []string{"APITokens"},
+		GeneratedSecrets: []*meta.GeneratedSecret{
+			{Key: "SessionKey", Shape: meta.GeneratedSecret_ALNUM},
+			{Key: "SigningKey", Shape: meta.GeneratedSecret_BYTES},
+			{Key: "APIToken", Shape: meta.GeneratedSecret_UUID},
+			{Key: "APITokens", Shape: meta.GeneratedSecret_HEX},
+		},
+	}}}
+
+	src := &Data{Values: map[string]string{"StripeKey": "sk_test", "APIToken": "set"}}
+	data, err := mgr.ApplyGenerated(app, md, src)
+	c.Assert(err, qt.IsNil)
+	c.Assert(src.Values, qt.HasLen, 2, qt.Commentf("src must not be modified"))
+
+	// Values that are set take precedence.
+	c.Assert(data.Values["StripeKey"], qt.Equals, "sk_test")
+	c.Assert(data.Values["APIToken"], qt.Equals, "set")
+
+	c.Assert(data.Values["SessionKey"], qt.Matches, `[a-zA-Z0-9]{32}`)
+	raw, err := base64.StdEncoding.DecodeString(data.Values["SigningKey"])
+	c.Assert(err, qt.IsNil)
+	c.Assert(raw, qt.HasLen, 32)
+	c.Assert(data.Values["APITokens"], qt.Matches, `\["[0-9a-f]{64}"\]`)
+
+	// The generated values are stable across runs.
+	again, err := New().ApplyGenerated(app, md, &Data{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(again.Values["SessionKey"], qt.Equals, data.Values["SessionKey"])
+	c.Assert(again.Values["SigningKey"], qt.Equals, data.Values["SigningKey"])
+	c.Assert(again.Values["APIToken"], qt.Matches, `[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+	// Changing the shape generates a new value.
+	md.Pkgs[0].GeneratedSecrets[0].Shape = meta.GeneratedSecret_HEX
+	changed, err := mgr.ApplyGenerated(app, md, &Data{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(changed.Values["SessionKey"], qt.Matches, `[0-9a-f]{64}`)
+
+	// Nothing is generated when all values are set.
+	full := &Data{Values: changed.Values}
+	res, err := mgr.ApplyGenerated(app, md, full)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res, qt.Equals, full)
+}
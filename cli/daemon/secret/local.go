@@ -0,0 +1,94 @@
+package secret
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+
+	"encr.dev/cli/daemon/namespace"
+	"encr.dev/pkg/xos"
+)
+
+// LocalOverridesFile is the name of the file in the app root
+// that overrides secret values when running locally.
+const LocalOverridesFile = ".secrets.local.cue"
+
+// namespaceOverridesDef is the definition in the local overrides file
+// holding the overrides of each infrastructure namespace, like so:
+//
+//	#namespace: "feature-x": {
+//		StripeKey: "sk_test_..."
+//	}
+const namespaceOverridesDef = "#namespace"
+
+// SetLocalOverride sets the local override of the secret key to value in the
+// app at appRoot. If ns is non-empty the override only applies when running
+// in that namespace. Other overrides and comments in the file are preserved.
+func SetLocalOverride(appRoot string, ns namespace.Name, key, value string) error {
+	path := filepath.Join(appRoot, LocalOverridesFile)
+	src, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	f, err := parser.ParseFile(LocalOverridesFile, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("parse local secrets: %v", err)
+	}
+
+	decls := &f.Decls
+	if ns != "" {
+		decls = &structField(&f.Decls, namespaceOverridesDef).Elts
+		decls = &structField(decls, string(ns)).Elts
+	}
+	if field := findField(*decls, key); field != nil {
+		field.Value = ast.NewString(value)
+	} else {
+		*decls = append(*decls, &ast.Field{Label: newLabel(key), Value: ast.NewString(value)})
+	}
+
+	out, err := format.Node(f)
+	if err != nil {
+		return fmt.Errorf("format local secrets: %v", err)
+	}
+	return xos.WriteFile(path, out, 0600)
+}
+
+// structField returns the struct value of the field with the given name
+// among decls, adding the field if there's no such field.
+func structField(decls *[]ast.Decl, name string) *ast.StructLit {
+	if field := findField(*decls, name); field != nil {
+		if st, ok := field.Value.(*ast.StructLit); ok {
+			return st
+		}
+	}
+	st := &ast.StructLit{}
+	*decls = append(*decls, &ast.Field{Label: newLabel(name), Value: st})
+	return st
+}
+
+// findField returns the last field with the given name among decls, if any.
+func findField(decls []ast.Decl, name string) *ast.Field {
+	var found *ast.Field
+	for _, d := range decls {
+		if field, ok := d.(*ast.Field); ok {
+			if label, _, err := ast.LabelName(field.Label); err == nil && label == name {
+				found = field
+			}
+		}
+	}
+	return found
+}
+
+// newLabel returns a field label for name, quoted unless it's an identifier.
+func newLabel(name string) ast.Label {
+	if ast.IsValidIdent(name) {
+		return ast.NewIdent(name)
+	}
+	return ast.NewString(name)
+}
@@ -0,0 +1,58 @@
+package secret
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestLocalOverrides(t *testing.T) {
+	c := qt.New(t)
+	appRoot := c.TempDir()
+	err := os.WriteFile(filepath.Join(appRoot, LocalOverridesFile), []byte(`// Local secrets.
+
+StripeKey: "sk_test_default"
+Other:     "other"
+
+#namespace: "feature-x": {
+	Other: "feature-x-other"
+}
+`), 0600)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(SetLocalOverride(appRoot, "", "StripeKey", "sk_test_updated"), qt.IsNil)
+	c.Assert(SetLocalOverride(appRoot, "feature-x", "StripeKey", "sk_test_feature_x"), qt.IsNil)
+	c.Assert(SetLocalOverride(appRoot, "feature-y", "multi-word", "a \"quoted\"\nvalue"), qt.IsNil)
+
+	src := &Data{Values: map[string]string{"StripeKey": "sk_live", "Remote": "remote"}}
+	data, err := applyLocalOverrides(appRoot, "", src)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data.Values, qt.DeepEquals, map[string]string{
+		"StripeKey": "sk_test_updated",
+		"Other":     "other",
+		"Remote":    "remote",
+	})
+
+	data, err = applyLocalOverrides(appRoot, "feature-x", src)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data.Values, qt.DeepEquals, map[string]string{
+		"StripeKey": "sk_test_feature_x",
+		"Other":     "feature-x-other",
+		"Remote":    "remote",
+	})
+
+	data, err = applyLocalOverrides(appRoot, "feature-y", src)
+	c.Assert(err, qt.IsNil)
+	c.Assert(data.Values["multi-word"], qt.Equals, "a \"quoted\"\nvalue")
+
+	// The source data is never modified.
+	c.Assert(src.Values, qt.DeepEquals, map[string]string{"StripeKey": "sk_live", "Remote": "remote"})
+
+	// Comments are preserved.
+	out, err := os.ReadFile(filepath.Join(appRoot, LocalOverridesFile))
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.HasPrefix(string(out), "// Local secrets.\n\n"), qt.IsTrue)
+}
@@ -22,7 +22,9 @@ import (
 
 	"encore.dev/appruntime/exported/experiments"
 	"encr.dev/cli/daemon/apps"
+	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/internal/platform"
+	"encr.dev/internal/conf"
 	"encr.dev/pkg/xos"
 )
 
@@ -50,26 +52,49 @@ type Data struct {
 }
 
 type LoadResult struct {
-	mgr *Manager
-	app *apps.Instance
+	mgr     *Manager
+	app     *apps.Instance
+	ns      namespace.Name // the namespace whose local overrides to apply, if any
+	offline bool           // whether to only use cached secrets
+
+	flagValues map[string]string // secret values given as command-line flags
 
 	once    syncutil.Once
 	ch      <-chan singleflight.Result
 	initial singleflight.Result
 }
 
-// Load loads the secrets for appSlug.
+// Load loads the secrets for appSlug, running in the namespace ns.
 // If appSlug is empty, (*LoadResult).Get resolves to empty secret data.
-func (mgr *Manager) Load(app *apps.Instance) *LoadResult {
-	mgr.pollOnce.Do(mgr.startPolling)
+// If ns is nil only the app-wide local overrides are applied.
+func (mgr *Manager) Load(app *apps.Instance, ns *namespace.Namespace) *LoadResult {
+	var nsName namespace.Name
+	if ns != nil {
+		nsName = ns.Name
+	}
 
 	// Ignore cases when the app isn't linked.
 	if app.PlatformID() == "" {
-		return &LoadResult{mgr: mgr, app: app}
+		return &LoadResult{mgr: mgr, app: app, ns: nsName}
+	}
+
+	// In offline mode, use the cached secrets without syncing.
+	if conf.Offline() {
+		return &LoadResult{mgr: mgr, app: app, ns: nsName, offline: true}
 	}
 
+	mgr.pollOnce.Do(mgr.startPolling)
+
 	ch := mgr.fetch(app.PlatformID(), false)
-	return &LoadResult{mgr: mgr, app: app, ch: ch}
+	return &LoadResult{mgr: mgr, app: app, ns: nsName, ch: ch}
+}
+
+// WithFlagValues sets the secret values given as command-line flags,
+// and returns lr. They take precedence over the values in LocalEnvFile,
+// but not over the values set on the Encore Platform.
+func (lr *LoadResult) WithFlagValues(values map[string]string) *LoadResult {
+	lr.flagValues = values
+	return lr
 }
 
 // Get returns the result of the prefetch.
@@ -78,9 +103,12 @@ func (mgr *Manager) Load(app *apps.Instance) *LoadResult {
 // more recent data that has been subsequently cached.
 func (lr *LoadResult) Get(ctx context.Context, expSet *experiments.Set) (data *Data, err error) {
 	defer func() {
+		// Return new data objects so we don't write the local values to the cache.
+		if err == nil {
+			data, err = applyLocalDefaults(lr.app.Root(), lr.flagValues, data)
+		}
 		if err == nil {
-			// Return a new data object so we don't write the overrides to the cache.
-			data, err = applyLocalOverrides(lr.app, data)
+			data, err = applyLocalOverrides(lr.app.Root(), lr.ns, data)
 		}
 	}()
 
@@ -88,6 +116,14 @@ func (lr *LoadResult) Get(ctx context.Context, expSet *experiments.Set) (data *D
 		return &Data{}, nil
 	}
 
+	if lr.offline {
+		if cached, ok := lr.mgr.loadFromCache(lr.app.PlatformID()); ok {
+			return cached, nil
+		}
+		// No secrets have been cached; only the local overrides are available.
+		return &Data{}, nil
+	}
+
 	// Fetch the initial result the first time.
 	err = lr.once.Do(func() error {
 		select {
@@ -128,6 +164,19 @@ func (lr *LoadResult) Get(ctx context.Context, expSet *experiments.Set) (data *D
 	}
 }
 
+// Cached reports when the secrets for app were last synced,
+// and whether there are any cached secrets at all.
+func (mgr *Manager) Cached(app *apps.Instance) (synced time.Time, ok bool) {
+	if app.PlatformID() == "" {
+		return time.Time{}, false
+	}
+	data, ok := mgr.loadFromCache(app.PlatformID())
+	if !ok {
+		return time.Time{}, false
+	}
+	return data.Synced, true
+}
+
 // UpdateKey updates the cached secret key to the given value.
 func (mgr *Manager) UpdateKey(appSlug, key, value string) {
 	mgr.mu.Lock()
@@ -277,14 +326,14 @@ func (mgr *Manager) secretsPath(appSlug string) (string, error) {
 	return filepath.Join(dir, "encore", "secrets", appSlug+".json"), nil
 }
 
-// applyLocalOverrides parses the local secrets override file, if any,
-// and returns a new Data object with the overrides applied.
+// applyLocalOverrides parses the local secrets override file in appRoot, if any,
+// and returns a new Data object with the overrides applied. The overrides
+// for the namespace ns take precedence over the app-wide overrides.
 //
 // If there are no overrides src is returned directly.
 // The original src data object is never modified.
-func applyLocalOverrides(app *apps.Instance, src *Data) (*Data, error) {
-	const name = ".secrets.local.cue"
-	data, err := os.ReadFile(filepath.Join(app.Root(), name))
+func applyLocalOverrides(appRoot string, ns namespace.Name, src *Data) (*Data, error) {
+	data, err := os.ReadFile(filepath.Join(appRoot, LocalOverridesFile))
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
 			return src, nil
@@ -314,17 +363,33 @@ func applyLocalOverrides(app *apps.Instance, src *Data) (*Data, error) {
 		return nil, fmt.Errorf("parse local secrets: %v", err)
 	}
 
-	it, err := secrets.Fields(cue.Hidden(false), cue.Concrete(true))
+	if err := addOverrides(updated.Values, secrets); err != nil {
+		return nil, err
+	}
+	if ns != "" {
+		nsSecrets := secrets.LookupPath(cue.MakePath(cue.Def(namespaceOverridesDef), cue.Str(string(ns))))
+		if nsSecrets.Exists() {
+			if err := addOverrides(updated.Values, nsSecrets); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return updated, nil
+}
+
+// addOverrides adds the string fields of the struct v to values.
+func addOverrides(values map[string]string, v cue.Value) error {
+	it, err := v.Fields(cue.Hidden(false), cue.Concrete(true))
 	if err != nil {
-		return nil, fmt.Errorf("parse local secrets: %v", err)
+		return fmt.Errorf("parse local secrets: %v", err)
 	}
 	for it.Next() {
-		key := it.Selector().String()
+		key := it.Label()
 		val, err := it.Value().String()
 		if err != nil {
-			return nil, fmt.Errorf("parse local secrets: secret key %s is not a string", key)
+			return fmt.Errorf("parse local secrets: secret key %s is not a string", key)
 		}
-		updated.Values[key] = val
+		values[key] = val
 	}
-	return updated, nil
+	return nil
 }
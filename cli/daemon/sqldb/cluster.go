@@ -218,13 +218,7 @@ func (c *Cluster) initDBs(md *meta.Data, reinit bool) {
 func (c *Cluster) initDB(encoreName string) *DB {
 	driverName := encoreName
 	if !c.driver.Meta().ClusterIsolation {
-		driverName += fmt.Sprintf("-%s-%s", c.ID.NS.App.PlatformOrLocalID(), c.ID.Type)
-
-		// Add the namespace id, as long as it's not the default namespace
-		// (for backwards compatibility).
-		if c.ID.NS.Name != "default" {
-			driverName += "-" + string(c.ID.NS.ID)
-		}
+		driverName += c.ID.DatabaseSuffix()
 	}
 
 	dbCtx, cancel := context.WithCancel(c.Ctx)
@@ -323,6 +317,27 @@ func (c *Cluster) Recreate(ctx context.Context, appRoot string, databaseNames []
 	return err
 }
 
+// DropExitedPackageDBs drops the databases cloned by test binaries that have exited,
+// once a test run completes.
+func (c *Cluster) DropExitedPackageDBs(ctx context.Context) error {
+	c.mu.Lock()
+	dbs := make([]*DB, 0, len(c.dbs))
+	for _, db := range c.dbs {
+		dbs = append(dbs, db)
+	}
+	c.mu.Unlock()
+
+	for _, db := range dbs {
+		if _, ok := db.TemplateCloudName().Get(); !ok {
+			continue
+		}
+		if err := db.dropPackageDBs(ctx, true); err != nil {
+			return errors.Wrapf(err, "drop test databases of %s", db.EncoreName)
+		}
+	}
+	return nil
+}
+
 // Status reports the cluster's status.
 func (c *Cluster) Status(ctx context.Context) (*ClusterStatus, error) {
 	if st := c.cachedStatus.Load(); st != nil {
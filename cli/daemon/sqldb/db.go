@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"io/fs"
+	"strings"
 	"sync"
 	"time"
 
@@ -80,6 +81,16 @@ func (db *DB) Setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabas
 		}
 	}()
 
+	// When recreating a database backed by a template, reuse the template from a previous
+	// run if the migrations haven't changed since, as running them can be slow.
+	if recreate && db.template {
+		if reused, err := db.recreateFromTemplate(ctx, appRoot, dbMeta); err != nil {
+			return err
+		} else if reused {
+			return nil
+		}
+	}
+
 	if recreate {
 		if err := db.drop(ctx); err != nil {
 			return err
@@ -132,11 +143,133 @@ func (db *DB) Setup(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabas
 		if err := db.ensureRoles(ctx, db.ApplicationCloudName(), db.Cluster.Roles...); err != nil {
 			return fmt.Errorf("ensure db roles %s: %v", db.ApplicationCloudName(), err)
 		}
+
+		// Record which migrations the template has, so later runs can reuse it.
+		if db.migrated {
+			if fingerprint, err := migrationsFingerprint(appRoot, dbMeta); err == nil {
+				if err := db.setTemplateFingerprint(ctx, tmplName, fingerprint); err != nil {
+					db.log.Warn().Err(err).Msg("unable to record template database migrations")
+				}
+			}
+		}
 	}
 
 	return nil
 }
 
+// templateCommentPrefix prefixes the comment on a template database
+// recording the fingerprint of the migrations applied to it.
+const templateCommentPrefix = "encore:migrations="
+
+// packageDBInfix separates the application database name from the unique suffix
+// of the databases cloned from the template database by each test binary.
+// It must be kept in sync with the runtime.
+const packageDBInfix = "_pkg_"
+
+// packageDBAppNamePrefix prefixes the application_name of the connection each test binary
+// holds while it runs, followed by the unique suffix of its database.
+// It must be kept in sync with the runtime.
+const packageDBAppNamePrefix = "encore-test-db:"
+
+// recreateFromTemplate recreates the application database by cloning the existing template
+// database, if its migrations are the same as the current ones. It reports whether it did.
+func (db *DB) recreateFromTemplate(ctx context.Context, appRoot string, dbMeta *meta.SQLDatabase) (reused bool, err error) {
+	tmplName, ok := db.TemplateCloudName().Get()
+	if !ok {
+		return false, nil
+	}
+	want, err := migrationsFingerprint(appRoot, dbMeta)
+	if err != nil {
+		// Set up the database from scratch, which reports the error if it persists.
+		return false, nil
+	}
+	if got, err := db.templateFingerprint(ctx, tmplName); err != nil || got != want {
+		return false, nil
+	}
+
+	db.log.Debug().Msg("reusing template database with up-to-date migrations")
+	if err := db.doDrop(ctx, db.ApplicationCloudName()); err != nil {
+		return false, errors.Wrapf(err, "drop database %s", db.ApplicationCloudName())
+	}
+	if err := db.dropPackageDBs(ctx, false); err != nil {
+		return false, err
+	}
+	if err := db.doCreate(ctx, db.ApplicationCloudName(), option.Some(tmplName)); err != nil {
+		return false, errors.Wrapf(err, "create db %s: %v", db.ApplicationCloudName(), err)
+	}
+	if err := db.ensureRoles(ctx, db.ApplicationCloudName(), db.Cluster.Roles...); err != nil {
+		return false, fmt.Errorf("ensure db roles %s: %v", db.ApplicationCloudName(), err)
+	}
+	db.migrated = true
+	return true, nil
+}
+
+// templateFingerprint returns the fingerprint of the migrations applied to the template
+// database, or "" if it doesn't exist or the migrations weren't recorded.
+func (db *DB) templateFingerprint(ctx context.Context, tmplName string) (string, error) {
+	adm, err := db.connectSuperuser(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = adm.Close(context.Background()) }()
+
+	var comment *string
+	err = adm.QueryRow(ctx, "SELECT shobj_description(oid, 'pg_database') FROM pg_database WHERE datname = $1", tmplName).Scan(&comment)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && comment == nil) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	fingerprint, _ := strings.CutPrefix(*comment, templateCommentPrefix)
+	return fingerprint, nil
+}
+
+// setTemplateFingerprint records the fingerprint of the migrations applied to the template database.
+func (db *DB) setTemplateFingerprint(ctx context.Context, tmplName, fingerprint string) error {
+	adm, err := db.connectSuperuser(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = adm.Close(context.Background()) }()
+
+	// COMMENT doesn't support query params, but the fingerprint is hex encoded.
+	_, err = adm.Exec(ctx, fmt.Sprintf("COMMENT ON DATABASE %s IS '%s%s'",
+		(pgx.Identifier{tmplName}).Sanitize(), templateCommentPrefix, fingerprint))
+	return err
+}
+
+// dropPackageDBs drops the databases cloned from the template database by test binaries.
+// If exitedOnly is true, only the databases of test binaries that have exited are dropped.
+func (db *DB) dropPackageDBs(ctx context.Context, exitedOnly bool) error {
+	adm, err := db.connectSuperuser(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = adm.Close(context.Background()) }()
+
+	query := "SELECT datname FROM pg_database WHERE starts_with(datname, $1)"
+	args := []any{db.ApplicationCloudName() + packageDBInfix}
+	if exitedOnly {
+		query += " AND NOT EXISTS (SELECT 1 FROM pg_stat_activity WHERE application_name = $2 || substr(datname, length($1) + 1))"
+		args = append(args, packageDBAppNamePrefix)
+	}
+	rows, err := adm.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := db.doDrop(ctx, name); err != nil {
+			return errors.Wrapf(err, "drop database %s", name)
+		}
+	}
+	return nil
+}
+
 func (db *DB) doCreate(ctx context.Context, cloudName string, template option.Option[string]) error {
 	adm, err := db.connectSuperuser(ctx)
 	if err != nil {
@@ -351,6 +484,9 @@ func (db *DB) drop(ctx context.Context) error {
 		if err := db.doDrop(ctx, name); err != nil {
 			return errors.Wrapf(err, "drop database %s", name)
 		}
+		if err := db.dropPackageDBs(ctx, false); err != nil {
+			return err
+		}
 	}
 	return nil
 }
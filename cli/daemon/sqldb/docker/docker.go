@@ -1,9 +1,7 @@
 package docker
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -17,10 +15,17 @@ import (
 
 	"encr.dev/cli/daemon/namespace"
 	"encr.dev/cli/daemon/sqldb"
+	"encr.dev/cli/internal/containers"
 	"encr.dev/pkg/idents"
 )
 
-type Driver struct{}
+// Driver runs database clusters as containers, using Docker
+// or any other supported container runtime.
+type Driver struct {
+	// Runtime is the container runtime to use.
+	// If nil the runtime is detected automatically.
+	Runtime containers.Runtime
+}
 
 var _ sqldb.Driver = (*Driver)(nil)
 
@@ -32,16 +37,21 @@ const (
 )
 
 func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log zerolog.Logger) (status *sqldb.ClusterStatus, err error) {
-	// Ensure the docker image exists first.
+	rt, err := d.runtime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the image exists first.
 	{
 		checkExistsCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		if ok, err := ImageExists(checkExistsCtx); err != nil {
+		if ok, err := rt.ImageExists(checkExistsCtx, Image); err != nil {
 			return nil, errors.Wrap(err, "check docker image")
 		} else if !ok {
 			log.Debug().Msg("PostgreSQL image does not exist, pulling")
 			pullOp := p.Tracker.Add("Pulling PostgreSQL docker image", time.Now())
-			if err := PullImage(context.Background()); err != nil {
+			if err := rt.PullImage(context.Background(), Image, os.Stdout); err != nil {
 				log.Error().Err(err).Msg("failed to pull PostgreSQL image")
 				p.Tracker.Fail(pullOp, err)
 				return nil, errors.Wrap(err, "pull docker image")
@@ -122,43 +132,38 @@ func (d *Driver) CreateCluster(ctx context.Context, p *sqldb.CreateParams, log z
 	case sqldb.Stopped:
 		log.Debug().Msg("cluster stopped, restarting")
 
-		if out, err := exec.CommandContext(ctx, "docker", "start", existingContainerName).CombinedOutput(); err != nil {
-			return nil, errors.Wrapf(err, "could not start sqldb container: %s", string(out))
+		if err := rt.StartContainer(ctx, existingContainerName); err != nil {
+			return nil, errors.Wrap(err, "could not start sqldb container")
 		}
 		return waitForPort()
 
 	case sqldb.NotFound:
 		log.Debug().Msg("cluster not found, creating")
-		args := []string{
-			"run",
-			"-d",
-			"-p", "5432",
-			"--shm-size=1gb",
-			"-e", "POSTGRES_USER=" + DefaultSuperuserUsername,
-			"-e", "POSTGRES_PASSWORD=" + DefaultSuperuserPassword,
-			"-e", "POSTGRES_DB=" + DefaultRootDatabase,
-			"-e", "PGDATA=" + defaultDataDir,
-			"--name", cnames[0],
+		params := containers.RunParams{
+			Name:    cnames[0],
+			Image:   Image,
+			Ports:   []string{"5432"},
+			ShmSize: "1gb",
+			Env: []string{
+				"POSTGRES_USER=" + DefaultSuperuserUsername,
+				"POSTGRES_PASSWORD=" + DefaultSuperuserPassword,
+				"POSTGRES_DB=" + DefaultRootDatabase,
+				"PGDATA=" + defaultDataDir,
+			},
 		}
 		if p.Memfs {
-			args = append(args,
-				"--mount", "type=tmpfs,destination="+defaultDataDir,
-				Image,
-				"-c", "fsync=off",
-			)
+			params.TmpfsMounts = []string{defaultDataDir}
+			params.Cmd = []string{"-c", "fsync=off"}
 		} else {
 			volumeName := clusterVolumeNames(p.ClusterID.NS)[0] // guaranteed to be non-empty
-			if err := d.createVolumeIfNeeded(ctx, volumeName); err != nil {
+			if err := rt.CreateVolume(ctx, volumeName); err != nil {
 				return nil, errors.Wrap(err, "create data volume")
 			}
-			args = append(args,
-				"-v", fmt.Sprintf("%s:%s", volumeName, defaultDataDir),
-				Image)
+			params.Volumes = []string{fmt.Sprintf("%s:%s", volumeName, defaultDataDir)}
 		}
 
-		cmd := exec.CommandContext(ctx, "docker", args...)
-		if out, err := cmd.CombinedOutput(); err != nil {
-			return nil, errors.Wrapf(err, "could not start sql database as docker container: %s", out)
+		if err := rt.RunContainer(ctx, params); err != nil {
+			return nil, errors.Wrapf(err, "could not start sql database as %s container", rt.Name())
 		}
 
 		log.Debug().Msg("cluster created")
@@ -175,155 +180,119 @@ func (d *Driver) ClusterStatus(ctx context.Context, id sqldb.ClusterID) (*sqldb.
 }
 
 func (d *Driver) CheckRequirements(ctx context.Context) error {
-	if _, err := exec.LookPath("docker"); err != nil {
-		return errors.New("This application requires docker to run since it uses an SQL database. Install docker first.")
-	} else if !isDockerRunning(ctx) {
-		return errors.New("The docker daemon is not running. Start it first.")
+	rt, err := d.runtime(ctx)
+	if err != nil {
+		return err
+	} else if _, err := exec.LookPath(rt.Binary()); err != nil {
+		return errors.Newf("This application requires %s to run since it uses an SQL database. Install %s first, "+
+			"or set %s to use another container runtime.", rt.Name(), rt.Name(), containers.EnvVar)
+	} else if err := rt.CheckRequirements(ctx); err != nil {
+		return errors.Newf("The %s daemon is not running. Start it first.", rt.Name())
 	}
 	return nil
 }
 
+// runtime returns the container runtime to use.
+func (d *Driver) runtime(ctx context.Context) (containers.Runtime, error) {
+	if d.Runtime != nil {
+		return d.Runtime, nil
+	}
+	return containers.Detect(ctx)
+}
+
 // clusterStatus reports both the standard ClusterStatus but also the container name we actually resolved to.
 func (d *Driver) clusterStatus(ctx context.Context, id sqldb.ClusterID) (status *sqldb.ClusterStatus, containerName string, err error) {
-	var output []byte
+	rt, err := d.runtime(ctx)
+	if err != nil {
+		return nil, "", err
+	}
 
 	// Try the candidate container names in order.
-	cnames := containerNames(id)
-	for _, cname := range cnames {
-		var err error
-		out, err := exec.CommandContext(ctx, "docker", "container", "inspect", cname).CombinedOutput()
-		if errors.Is(err, exec.ErrNotFound) {
-			return nil, "", errors.New("docker not found: is it installed and in your PATH?")
+	var c *containers.Container
+	for _, cname := range containerNames(id) {
+		c, err = rt.InspectContainer(ctx, cname)
+		if errors.Is(err, containers.ErrNotFound) {
+			continue
 		} else if err != nil {
-			// Docker returns a non-zero exit code if the container does not exist.
-			// Try to tell this apart from an error by parsing the output.
-			if bytes.Contains(out, []byte("No such container")) {
-				continue
-			}
-			// Podman has slightly different output when a container is not found.
-			if bytes.Contains(out, []byte("no such container")) {
-				continue
-			}
-			return nil, "", errors.Wrapf(err, "docker container inspect failed: %s", out)
-		} else {
-			// Found our container; use it.
-			output, containerName = out, cname
-			break
+			return nil, "", err
 		}
+		// Found our container; use it.
+		containerName = cname
+		break
 	}
-	if output == nil {
+	if c == nil {
 		return &sqldb.ClusterStatus{Status: sqldb.NotFound}, containerName, nil
 	}
 
-	var resp []struct {
-		Name  string
-		State struct {
-			Running bool
-		}
-		Config struct {
-			Env []string
-		}
-		NetworkSettings struct {
-			Ports map[string][]struct {
-				HostIP   string
-				HostPort string
-			}
-		}
+	status = &sqldb.ClusterStatus{Status: sqldb.Stopped, Config: &sqldb.ConnConfig{
+		// Defaults if we don't find anything else configured.
+		Superuser: sqldb.Role{
+			Type:     sqldb.RoleSuperuser,
+			Username: DefaultSuperuserUsername,
+			Password: DefaultSuperuserPassword,
+		},
+		RootDatabase: DefaultRootDatabase,
+	}}
+	if c.Running {
+		status.Status = sqldb.Running
 	}
-	if err := json.Unmarshal(output, &resp); err != nil {
-		return nil, "", errors.Wrap(err, "parse `docker container inspect` response")
+	if ports := c.Ports["5432/tcp"]; len(ports) > 0 {
+		status.Config.Host = ports[0].HostIP + ":" + ports[0].HostPort
 	}
-	for _, c := range resp {
-		// Docker prefixes `/` to the container name, Podman doesn't.
-		if c.Name == "/"+containerName || c.Name == containerName {
-			status := &sqldb.ClusterStatus{Status: sqldb.Stopped, Config: &sqldb.ConnConfig{
-				// Defaults if we don't find anything else configured.
-				Superuser: sqldb.Role{
-					Type:     sqldb.RoleSuperuser,
-					Username: DefaultSuperuserUsername,
-					Password: DefaultSuperuserPassword,
-				},
-				RootDatabase: DefaultRootDatabase,
-			}}
-			if c.State.Running {
-				status.Status = sqldb.Running
-			}
-			ports := c.NetworkSettings.Ports["5432/tcp"]
-			if len(ports) > 0 {
-				hostIP := ports[0].HostIP
-
-				// Podman can keep HostIP empty or 0.0.0.0.
-				// https://github.com/containers/podman/issues/17780
-				if hostIP == "" || hostIP == "0.0.0.0" {
-					hostIP = "127.0.0.1"
-				}
-
-				status.Config.Host = hostIP + ":" + ports[0].HostPort
-			}
 
-			// Read the Postgres config from the docker container's environment.
-			for _, env := range c.Config.Env {
-				if name, value, ok := strings.Cut(env, "="); ok {
-					switch name {
-					case "POSTGRES_USER":
-						status.Config.Superuser.Username = value
-					case "POSTGRES_PASSWORD":
-						status.Config.Superuser.Password = value
-					case "POSTGRES_DB":
-						status.Config.RootDatabase = value
-					}
-				}
+	// Read the Postgres config from the container's environment.
+	for _, env := range c.Env {
+		if name, value, ok := strings.Cut(env, "="); ok {
+			switch name {
+			case "POSTGRES_USER":
+				status.Config.Superuser.Username = value
+			case "POSTGRES_PASSWORD":
+				status.Config.Superuser.Password = value
+			case "POSTGRES_DB":
+				status.Config.RootDatabase = value
 			}
-
-			return status, containerName, nil
 		}
 	}
-	return &sqldb.ClusterStatus{Status: sqldb.NotFound}, containerName, nil
+	return status, containerName, nil
 }
 
 func (d *Driver) CanDestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
-	// Check that we can communicate with Docker.
-	if !isDockerRunning(ctx) {
-		return errors.New("cannot delete sql database: docker is not running")
+	// Check that we can communicate with the container runtime.
+	rt, err := d.runtime(ctx)
+	if err != nil {
+		return err
+	} else if err := rt.CheckRequirements(ctx); err != nil {
+		return errors.Newf("cannot delete sql database: %s is not running", rt.Name())
 	}
 	return nil
 }
 
 func (d *Driver) DestroyCluster(ctx context.Context, id sqldb.ClusterID) error {
-	cnames := containerNames(id)
-	for _, cname := range cnames {
-		out, err := exec.CommandContext(ctx, "docker", "rm", "-f", cname).CombinedOutput()
-		if err != nil {
-			if bytes.Contains(out, []byte("No such container")) {
-				continue
-			}
-			return errors.Wrapf(err, "could not delete cluster: %s", out)
+	rt, err := d.runtime(ctx)
+	if err != nil {
+		return err
+	}
+	for _, cname := range containerNames(id) {
+		if err := rt.RemoveContainer(ctx, cname); err != nil {
+			return errors.Wrap(err, "could not delete cluster")
 		}
 	}
 	return nil
 }
 
 func (d *Driver) DestroyNamespaceData(ctx context.Context, ns *namespace.Namespace) error {
-	candidates := clusterVolumeNames(ns)
-	for _, c := range candidates {
-		if err := exec.CommandContext(ctx, "docker", "volume", "rm", "-f", c).Run(); err != nil {
-			if strings.Contains(strings.ToLower(err.Error()), "no such volume") {
-				continue
-			}
-			return errors.Wrapf(err, "could not delete volume %s", c)
+	rt, err := d.runtime(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range clusterVolumeNames(ns) {
+		if err := rt.RemoveVolume(ctx, c); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func (d *Driver) createVolumeIfNeeded(ctx context.Context, name string) error {
-	if err := exec.CommandContext(ctx, "docker", "volume", "inspect", name).Run(); err == nil {
-		return nil
-	}
-	out, err := exec.CommandContext(ctx, "docker", "volume", "create", name).CombinedOutput()
-	return errors.Wrapf(err, "create volume %s: %s", name, out)
-}
-
 func (d *Driver) Meta() sqldb.DriverMeta {
 	return sqldb.DriverMeta{ClusterIsolation: true}
 }
@@ -358,37 +327,8 @@ func containerNames(id sqldb.ClusterID) []string {
 	return names
 }
 
-// ImageExists reports whether the docker image exists.
-func ImageExists(ctx context.Context) (ok bool, err error) {
-	out, err := exec.CommandContext(ctx, "docker", "image", "inspect", Image).CombinedOutput()
-	switch {
-	case err == nil:
-		return true, nil
-	case bytes.Contains(out, []byte("No such image")):
-		return false, nil
-	// Podman has a different error message.
-	case bytes.Contains(out, []byte("failed to find image")):
-		return false, nil
-	default:
-		return false, errors.WithStack(errors.Wrapf(err, "docker image inspect failed: %s", Image))
-	}
-}
-
-// PullImage pulls the image.
-func PullImage(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, "docker", "pull", Image)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
-}
-
 const Image = "encoredotdev/postgres:15"
 
-func isDockerRunning(ctx context.Context) bool {
-	err := exec.CommandContext(ctx, "docker", "info").Run()
-	return err == nil
-}
-
 // clusterVolumeName reports the candidate names for the docker volume.
 func clusterVolumeNames(ns *namespace.Namespace) (candidates []string) {
 	nsName := idents.Convert(string(ns.Name), idents.KebabCase)
@@ -58,6 +58,20 @@ func (id ClusterID) clusterKey() clusterKey {
 	return clusterKey(fmt.Sprintf("%s-%s", id.NS.ID, id.Type))
 }
 
+// DatabaseSuffix is the suffix added to database names to tell
+// databases apart when several clusters share the same database server,
+// which is the case for drivers without cluster isolation.
+func (id ClusterID) DatabaseSuffix() string {
+	suffix := fmt.Sprintf("-%s-%s", id.NS.App.PlatformOrLocalID(), id.Type)
+
+	// Add the namespace id, as long as it's not the default namespace
+	// (for backwards compatibility).
+	if id.NS.Name != "default" {
+		suffix += "-" + string(id.NS.ID)
+	}
+	return suffix
+}
+
 func GetClusterID(app *apps.Instance, typ ClusterType, ns *namespace.Namespace) ClusterID {
 	return ClusterID{ns, typ}
 }
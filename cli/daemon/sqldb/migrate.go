@@ -2,6 +2,8 @@ package sqldb
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -68,6 +70,23 @@ func (src *src) ReadDown(version uint) (r io.ReadCloser, identifier string, err
 	return nil, "", os.ErrNotExist
 }
 
+// migrationsFingerprint returns a fingerprint of the database's migrations,
+// which changes whenever a migration is added, removed or modified.
+func migrationsFingerprint(appRoot string, dbMeta *meta.SQLDatabase) (string, error) {
+	h := sha256.New()
+	if dbMeta.MigrationRelPath != nil {
+		for _, m := range dbMeta.Migrations {
+			data, err := os.ReadFile(filepath.Join(appRoot, *dbMeta.MigrationRelPath, m.Filename))
+			if err != nil {
+				return "", err
+			}
+			_, _ = fmt.Fprintf(h, "%d %s %d\n", m.Number, m.Filename, len(data))
+			_, _ = h.Write(data)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func (src *src) verIdx(version uint, offset int) int {
 	idx := slices.IndexFunc(src.migrations, func(m *meta.DBMigration) bool {
 		return m.Number == uint64(version)
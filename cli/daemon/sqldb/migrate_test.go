@@ -0,0 +1,55 @@
+package sqldb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMigrationsFingerprint(t *testing.T) {
+	c := qt.New(t)
+	appRoot := c.TempDir()
+	relPath := "migrations"
+	c.Assert(os.Mkdir(filepath.Join(appRoot, relPath), 0755), qt.IsNil)
+
+	write := func(name, contents string) {
+		err := os.WriteFile(filepath.Join(appRoot, relPath, name), []byte(contents), 0644)
+		c.Assert(err, qt.IsNil)
+	}
+	write("1_create.up.sql", "CREATE TABLE foo (id INT);")
+	write("2_alter.up.sql", "ALTER TABLE foo ADD COLUMN name TEXT;")
+
+	dbMeta := &meta.SQLDatabase{
+		Name:             "foo",
+		MigrationRelPath: &relPath,
+		Migrations: []*meta.DBMigration{
+			{Filename: "1_create.up.sql", Number: 1},
+		},
+	}
+	fingerprint := func() string {
+		fp, err := migrationsFingerprint(appRoot, dbMeta)
+		c.Assert(err, qt.IsNil)
+		return fp
+	}
+
+	first := fingerprint()
+	c.Assert(fingerprint(), qt.Equals, first)
+
+	// Adding a migration changes the fingerprint.
+	dbMeta.Migrations = append(dbMeta.Migrations, &meta.DBMigration{Filename: "2_alter.up.sql", Number: 2})
+	second := fingerprint()
+	c.Assert(second, qt.Not(qt.Equals), first)
+
+	// So does modifying one.
+	write("2_alter.up.sql", "ALTER TABLE foo ADD COLUMN email TEXT;")
+	c.Assert(fingerprint(), qt.Not(qt.Equals), second)
+
+	// A missing migration file is an error.
+	dbMeta.Migrations = append(dbMeta.Migrations, &meta.DBMigration{Filename: "3_missing.up.sql", Number: 3})
+	_, err := migrationsFingerprint(appRoot, dbMeta)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
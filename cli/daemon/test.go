@@ -13,11 +13,16 @@ import (
 	"encr.dev/cli/daemon/run"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/fns"
+	"encr.dev/pkg/option"
 	daemonpb "encr.dev/proto/encore/daemon"
 )
 
 // Test runs tests.
 func (s *Server) Test(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServer) error {
+	if req.Integration {
+		return s.testIntegration(req, stream)
+	}
+
 	ctx := stream.Context()
 	slog := &streamLog{stream: stream, buffered: false}
 	stderr := slog.Stderr(false)
@@ -45,7 +50,17 @@ func (s *Server) Test(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServ
 		return nil
 	}
 
-	secrets := s.sm.Load(app)
+	secrets := s.sm.Load(app, ns)
+
+	var shard option.Option[run.Shard]
+	if req.Shard != nil {
+		sh, err := run.ParseShard(req.GetShard())
+		if err != nil {
+			sendErr(err)
+			return nil
+		}
+		shard = option.Some(sh)
+	}
 
 	testCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -78,9 +93,11 @@ func (s *Server) Test(req *daemonpb.TestRequest, stream daemonpb.Daemon_TestServ
 				Args:         req.Args,
 				Secrets:      secrets,
 				CodegenDebug: req.CodegenDebug,
+				Shard:        shard,
 			},
-			Stdout: slog.Stdout(false),
-			Stderr: slog.Stderr(false),
+			Stdout:          slog.Stdout(false),
+			Stderr:          slog.Stderr(false),
+			CoverageSummary: req.GetCoverageSummary(),
 		}
 		testResults <- s.mgr.Test(testCtx, tp)
 	}()
@@ -111,7 +128,7 @@ func (s *Server) TestSpec(ctx context.Context, req *daemonpb.TestSpecRequest) (r
 		return nil, errors.Wrap(err, "unable to get namespace")
 	}
 
-	secrets := s.sm.Load(app)
+	secrets := s.sm.Load(app, ns)
 
 	defer func() {
 		if recovered := recover(); recovered != nil {
@@ -0,0 +1,185 @@
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"regexp"
+
+	"github.com/cockroachdb/errors"
+)
+
+// cliRuntime implements Runtime using a Docker-compatible CLI.
+// Docker, Podman and nerdctl all accept the same commands but differ slightly
+// in their output, which is accounted for here.
+type cliRuntime struct {
+	bin      string
+	hostAddr string
+}
+
+var _ Runtime = (*cliRuntime)(nil)
+
+func newCLIRuntime(bin, hostAddr string) *cliRuntime {
+	return &cliRuntime{bin: bin, hostAddr: hostAddr}
+}
+
+func (r *cliRuntime) Name() string        { return r.bin }
+func (r *cliRuntime) Binary() string      { return r.bin }
+func (r *cliRuntime) HostAddress() string { return r.hostAddr }
+
+func (r *cliRuntime) CheckRequirements(ctx context.Context) error {
+	if _, err := exec.LookPath(r.bin); err != nil {
+		return errors.Newf("%s is not installed or not in your PATH", r.bin)
+	} else if err := exec.CommandContext(ctx, r.bin, "info").Run(); err != nil {
+		return errors.Newf("the %s daemon is not running", r.bin)
+	}
+	return nil
+}
+
+func (r *cliRuntime) ImageExists(ctx context.Context, image string) (bool, error) {
+	out, err := exec.CommandContext(ctx, r.bin, "image", "inspect", image).CombinedOutput()
+	switch {
+	case err == nil:
+		return true, nil
+	case isNotFound(out):
+		return false, nil
+	default:
+		return false, errors.Wrapf(err, "%s image inspect failed: %s", r.bin, out)
+	}
+}
+
+func (r *cliRuntime) PullImage(ctx context.Context, image string, w io.Writer) error {
+	cmd := exec.CommandContext(ctx, r.bin, "pull", image)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return errors.Wrapf(cmd.Run(), "%s pull %s", r.bin, image)
+}
+
+func (r *cliRuntime) InspectContainer(ctx context.Context, name string) (*Container, error) {
+	out, err := exec.CommandContext(ctx, r.bin, "container", "inspect", name).CombinedOutput()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, errors.Newf("%s not found: is it installed and in your PATH?", r.bin)
+	} else if err != nil {
+		// The CLI returns a non-zero exit code if the container does not exist.
+		// Try to tell this apart from an error by parsing the output.
+		if isNotFound(out) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "%s container inspect failed: %s", r.bin, out)
+	}
+	return parseInspect(out, name)
+}
+
+func parseInspect(out []byte, name string) (*Container, error) {
+	var resp []struct {
+		Name  string
+		State struct {
+			Running bool
+		}
+		Config struct {
+			Env []string
+		}
+		NetworkSettings struct {
+			Ports map[string][]PortBinding
+		}
+	}
+	if err := json.Unmarshal(out, &resp); err != nil {
+		return nil, errors.Wrap(err, "parse container inspect response")
+	}
+	for _, c := range resp {
+		// Docker prefixes `/` to the container name, Podman doesn't.
+		if c.Name == "/"+name || c.Name == name {
+			ports := make(map[string][]PortBinding, len(c.NetworkSettings.Ports))
+			for port, bindings := range c.NetworkSettings.Ports {
+				for _, b := range bindings {
+					// Podman can keep HostIP empty or 0.0.0.0.
+					// https://github.com/containers/podman/issues/17780
+					if b.HostIP == "" || b.HostIP == "0.0.0.0" {
+						b.HostIP = "127.0.0.1"
+					}
+					ports[port] = append(ports[port], b)
+				}
+			}
+			return &Container{
+				Name:    name,
+				Running: c.State.Running,
+				Env:     c.Config.Env,
+				Ports:   ports,
+			}, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r *cliRuntime) RunContainer(ctx context.Context, p RunParams) error {
+	args := []string{"run", "-d", "--name", p.Name}
+	for _, port := range p.Ports {
+		args = append(args, "-p", port)
+	}
+	if p.ShmSize != "" {
+		args = append(args, "--shm-size="+p.ShmSize)
+	}
+	for _, env := range p.Env {
+		args = append(args, "-e", env)
+	}
+	for _, v := range p.Volumes {
+		args = append(args, "-v", v)
+	}
+	for _, path := range p.TmpfsMounts {
+		args = append(args, "--mount", "type=tmpfs,destination="+path)
+	}
+	args = append(args, p.Image)
+	args = append(args, p.Cmd...)
+
+	if out, err := exec.CommandContext(ctx, r.bin, args...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s run failed: %s", r.bin, out)
+	}
+	return nil
+}
+
+func (r *cliRuntime) StartContainer(ctx context.Context, name string) error {
+	if out, err := exec.CommandContext(ctx, r.bin, "start", name).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s start failed: %s", r.bin, out)
+	}
+	return nil
+}
+
+func (r *cliRuntime) RemoveContainer(ctx context.Context, name string) error {
+	out, err := exec.CommandContext(ctx, r.bin, "rm", "-f", name).CombinedOutput()
+	if err != nil && !isNotFound(out) {
+		return errors.Wrapf(err, "%s rm failed: %s", r.bin, out)
+	}
+	return nil
+}
+
+func (r *cliRuntime) CreateVolume(ctx context.Context, name string) error {
+	if err := exec.CommandContext(ctx, r.bin, "volume", "inspect", name).Run(); err == nil {
+		return nil
+	}
+	out, err := exec.CommandContext(ctx, r.bin, "volume", "create", name).CombinedOutput()
+	return errors.Wrapf(err, "create volume %s: %s", name, out)
+}
+
+func (r *cliRuntime) RemoveVolume(ctx context.Context, name string) error {
+	out, err := exec.CommandContext(ctx, r.bin, "volume", "rm", "-f", name).CombinedOutput()
+	if err != nil && !isNotFound(out) {
+		return errors.Wrapf(err, "could not delete volume %s: %s", name, out)
+	}
+	return nil
+}
+
+// notFoundRegexp matches the errors the CLIs report when the requested object does not exist.
+// Only the specific messages are matched, so that unrelated errors mentioning
+// "not found", like a missing binary or network, aren't mistaken for them.
+var notFoundRegexp = regexp.MustCompile(`(?im)` +
+	`no such (container|image|volume|object)\b` + // docker, podman, nerdctl
+	`|\bimage not known\b` + // podman
+	`|\bfailed to find image\b` +
+	`|\b(container|image|volume)s? "[^"]*":? not found\b`) // nerdctl, as reported by containerd
+
+// isNotFound reports whether the CLI output indicates that
+// the requested object does not exist.
+func isNotFound(out []byte) bool {
+	return notFoundRegexp.Match(out)
+}
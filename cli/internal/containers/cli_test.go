@@ -0,0 +1,54 @@
+package containers
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseInspect(t *testing.T) {
+	c := qt.New(t)
+
+	// Docker output
+	docker := []byte(`[{
+		"Name": "/sqldb-app",
+		"State": {"Running": true},
+		"Config": {"Env": ["POSTGRES_USER=postgres"]},
+		"NetworkSettings": {"Ports": {"5432/tcp": [{"HostIp": "127.0.0.1", "HostPort": "55000"}]}}
+	}]`)
+	got, err := parseInspect(docker, "sqldb-app")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got, qt.DeepEquals, &Container{
+		Name:    "sqldb-app",
+		Running: true,
+		Env:     []string{"POSTGRES_USER=postgres"},
+		Ports:   map[string][]PortBinding{"5432/tcp": {{HostIP: "127.0.0.1", HostPort: "55000"}}},
+	})
+
+	// Podman output: no slash prefix and an empty host ip.
+	podman := []byte(`[{
+		"Name": "sqldb-app",
+		"State": {"Running": false},
+		"NetworkSettings": {"Ports": {"5432/tcp": [{"HostIp": "", "HostPort": "55001"}]}}
+	}]`)
+	got, err = parseInspect(podman, "sqldb-app")
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Running, qt.IsFalse)
+	c.Assert(got.Ports["5432/tcp"], qt.DeepEquals, []PortBinding{{HostIP: "127.0.0.1", HostPort: "55001"}})
+
+	_, err = parseInspect(docker, "other")
+	c.Assert(err, qt.ErrorIs, ErrNotFound)
+}
+
+func TestIsNotFound(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(isNotFound([]byte("Error: No such container: foo")), qt.IsTrue)
+	c.Assert(isNotFound([]byte("Error: no such container foo")), qt.IsTrue)
+	c.Assert(isNotFound([]byte("Error: foo: image not known")), qt.IsTrue)
+	c.Assert(isNotFound([]byte("Error: No such object: foo")), qt.IsTrue)
+	c.Assert(isNotFound([]byte(`FATA[0000] 1 errors: image "docker.io/library/foo:latest": not found`)), qt.IsTrue)
+	c.Assert(isNotFound([]byte(`FATA[0000] volume "foo" not found`)), qt.IsTrue)
+	c.Assert(isNotFound([]byte("permission denied")), qt.IsFalse)
+	c.Assert(isNotFound([]byte("exec: \"runc\": executable file not found in $PATH")), qt.IsFalse)
+	c.Assert(isNotFound([]byte("Error response from daemon: network encore not found")), qt.IsFalse)
+}
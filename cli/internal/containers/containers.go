@@ -0,0 +1,183 @@
+// Package containers abstracts over the container runtime used to run
+// local infrastructure, such as Docker, Podman and containerd (via nerdctl).
+package containers
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrNotFound is reported when a container, image or volume does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Runtime is a container runtime.
+type Runtime interface {
+	// Name is the name of the runtime, e.g. "docker".
+	Name() string
+
+	// Binary is the CLI binary used to interact with the runtime.
+	Binary() string
+
+	// CheckRequirements checks that the runtime is installed and running.
+	CheckRequirements(ctx context.Context) error
+
+	// ImageExists reports whether the given image exists locally.
+	ImageExists(ctx context.Context, image string) (bool, error)
+
+	// PullImage pulls the given image, writing progress to w.
+	PullImage(ctx context.Context, image string, w io.Writer) error
+
+	// InspectContainer inspects the container with the given name.
+	// It reports ErrNotFound if the container does not exist.
+	InspectContainer(ctx context.Context, name string) (*Container, error)
+
+	// RunContainer creates and starts a new container in the background.
+	RunContainer(ctx context.Context, p RunParams) error
+
+	// StartContainer starts a stopped container.
+	StartContainer(ctx context.Context, name string) error
+
+	// RemoveContainer forcibly removes a container.
+	// It does not report an error if the container does not exist.
+	RemoveContainer(ctx context.Context, name string) error
+
+	// CreateVolume creates a volume if it does not already exist.
+	CreateVolume(ctx context.Context, name string) error
+
+	// RemoveVolume removes a volume.
+	// It does not report an error if the volume does not exist.
+	RemoveVolume(ctx context.Context, name string) error
+
+	// HostAddress is the hostname containers use to reach the host machine,
+	// when not using host networking.
+	HostAddress() string
+}
+
+// Container describes a container.
+type Container struct {
+	Name    string
+	Running bool
+	Env     []string
+
+	// Ports maps container ports (like "5432/tcp") to their host bindings.
+	Ports map[string][]PortBinding
+}
+
+// PortBinding describes a container port published on the host.
+type PortBinding struct {
+	HostIP   string
+	HostPort string
+}
+
+// RunParams are the parameters for creating a container.
+type RunParams struct {
+	Name  string
+	Image string
+
+	// Ports are the container ports to publish on random host ports.
+	Ports []string
+
+	// Env are environment variables, in "KEY=VALUE" format.
+	Env []string
+
+	// Volumes are volume mounts, in "name:/path" format.
+	Volumes []string
+
+	// TmpfsMounts are paths to mount as tmpfs.
+	TmpfsMounts []string
+
+	// ShmSize is the size of /dev/shm, like "1gb". If empty the default is used.
+	ShmSize string
+
+	// Cmd are the arguments to pass to the image entrypoint.
+	Cmd []string
+}
+
+// Runtime names.
+const (
+	Docker  = "docker"
+	Podman  = "podman"
+	Nerdctl = "nerdctl"
+)
+
+// EnvVar is the environment variable used to select a container runtime.
+// Valid values are "docker", "podman" and "nerdctl" (for containerd).
+// If unset the runtime is detected automatically.
+const EnvVar = "ENCORE_CONTAINER_RUNTIME"
+
+// New returns the container runtime with the given name.
+func New(name string) (Runtime, error) {
+	switch name {
+	case Docker:
+		return newCLIRuntime(Docker, "host.docker.internal"), nil
+	case Podman:
+		return newCLIRuntime(Podman, "host.containers.internal"), nil
+	case Nerdctl, "containerd":
+		return newCLIRuntime(Nerdctl, "host.docker.internal"), nil
+	default:
+		return nil, errors.Newf("unknown container runtime %q (supported: docker, podman, nerdctl)", name)
+	}
+}
+
+var (
+	detectMu sync.Mutex
+	detected Runtime // the detected runtime, once one is known to be usable
+)
+
+// Detect returns the container runtime to use.
+//
+// If ENCORE_CONTAINER_RUNTIME is set that runtime is used.
+// Otherwise the first runtime that is installed and running is used,
+// in the order Docker, Podman, nerdctl. If none are running the first one
+// that is installed is returned, so that error messages refer to it.
+//
+// A runtime that is set or found running is cached for the lifetime of the process.
+// Otherwise detection is retried on the next call, as a runtime may be started
+// later, and a cancelled ctx may have failed the check of a running one.
+func Detect(ctx context.Context) (Runtime, error) {
+	detectMu.Lock()
+	defer detectMu.Unlock()
+	if detected != nil {
+		return detected, nil
+	}
+	rt, running, err := detect(ctx)
+	if err == nil && running {
+		detected = rt
+	}
+	return rt, err
+}
+
+// detect detects the container runtime to use, and reports whether it was
+// explicitly configured or found running.
+func detect(ctx context.Context) (rt Runtime, running bool, err error) {
+	if name := os.Getenv(EnvVar); name != "" {
+		rt, err := New(name)
+		return rt, true, err
+	}
+
+	var installed Runtime
+	for _, name := range [...]string{Docker, Podman, Nerdctl} {
+		if _, err := exec.LookPath(name); err != nil {
+			continue
+		}
+		rt, _ := New(name)
+		if rt.CheckRequirements(ctx) == nil {
+			return rt, true, nil
+		} else if installed == nil {
+			installed = rt
+		}
+	}
+
+	if installed != nil {
+		return installed, false, nil
+	}
+	// Nothing is installed; default to Docker so the error messages
+	// suggest installing it.
+	rt, err = New(Docker)
+	return rt, false, err
+}
@@ -0,0 +1,62 @@
+// Package desktop provides utilities for showing desktop notifications.
+package desktop
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+
+	exec "golang.org/x/sys/execabs"
+)
+
+// ErrUnsupported is reported by Notify when there is no way
+// to show desktop notifications on the current system.
+var ErrUnsupported = errors.New("desktop notifications are not supported on this system")
+
+// Command returns the command to use to show a notification
+// with the given title and message, or nil if there is none.
+func Command(title, message string) []string {
+	switch runtime.GOOS {
+	case "darwin":
+		script := "display notification " + appleScriptString(message) + " with title " + appleScriptString(title)
+		return []string{"/usr/bin/osascript", "-e", script}
+	case "windows":
+		script := `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$xml = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $xml.GetElementsByTagName('text')
+$text.Item(0).AppendChild($xml.CreateTextNode(` + powerShellString(title) + `)) > $null
+$text.Item(1).AppendChild($xml.CreateTextNode(` + powerShellString(message) + `)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('Encore').Show([Windows.UI.Notifications.ToastNotification]::new($xml))`
+		return []string{"powershell", "-NoProfile", "-NonInteractive", "-Command", script}
+	default:
+		if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			// notify-send is only for use in a desktop environment.
+			return nil
+		}
+		return []string{"notify-send", "--app-name=Encore", title, message}
+	}
+}
+
+// Notify shows a desktop notification with the given title and message.
+func Notify(title, message string) error {
+	args := Command(title, message)
+	if args == nil {
+		return ErrUnsupported
+	} else if _, err := exec.LookPath(args[0]); err != nil {
+		return ErrUnsupported
+	}
+	return exec.Command(args[0], args[1:]...).Run()
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// powerShellString quotes s as a verbatim PowerShell string literal.
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
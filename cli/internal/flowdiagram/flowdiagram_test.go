@@ -0,0 +1,153 @@
+package flowdiagram
+
+import (
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func testMeta() *meta.Data {
+	return &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "orders", RelPath: "orders", Databases: []string{"orders"}},
+			{Name: "payments", RelPath: "payments"},
+			{Name: "email", RelPath: "email"},
+		},
+		Pkgs: []*meta.Package{
+			{RelPath: "orders", ServiceName: "orders", RpcCalls: []*meta.QualifiedName{
+				{Pkg: "payments", Name: "Charge"},
+				{Pkg: "orders", Name: "Get"}, // calls within a service are left out
+			}},
+			{RelPath: "payments", ServiceName: "payments"},
+		},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:          "order-placed",
+			Publishers:    []*meta.PubSubTopic_Publisher{{ServiceName: "orders"}},
+			Subscriptions: []*meta.PubSubTopic_Subscription{{Name: "send-receipt", ServiceName: "email"}},
+		}},
+		CronJobs: []*meta.CronJob{{
+			Id: "cleanup", Title: "Cleanup", Endpoint: &meta.QualifiedName{Pkg: "orders", Name: "Cleanup"},
+		}},
+	}
+}
+
+func edges(g *Graph) []string {
+	var out []string
+	for _, e := range g.Edges {
+		s := e.From + " -" + string(e.Kind) + "-> " + e.To
+		if e.Label != "" {
+			s += " (" + e.Label + ")"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestBuild(t *testing.T) {
+	c := qt.New(t)
+	g, err := Build(testMeta(), Options{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(g.Nodes, qt.HasLen, 6)
+	c.Assert(edges(g), qt.DeepEquals, []string{
+		"service:orders -rpc-> service:payments",
+		"service:orders -database-> database:orders",
+		"service:orders -publish-> topic:order-placed",
+		"topic:order-placed -subscribe-> service:email (send-receipt)",
+		"cron:cleanup -cron-> service:orders",
+	})
+}
+
+func TestBuild_CollapseInfra(t *testing.T) {
+	c := qt.New(t)
+	g, err := Build(testMeta(), Options{CollapseInfra: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(g.Nodes, qt.HasLen, 3)
+	c.Assert(g.Nodes[0].Details, qt.DeepEquals, []string{"database: orders", "cron: Cleanup"})
+	c.Assert(edges(g), qt.DeepEquals, []string{
+		"service:orders -rpc-> service:payments",
+		"service:orders -pubsub-> service:email (order-placed)",
+	})
+}
+
+func TestBuild_Highlight(t *testing.T) {
+	c := qt.New(t)
+	g, err := Build(testMeta(), Options{Highlight: "payments"})
+	c.Assert(err, qt.IsNil)
+
+	var highlighted, dimmed []string
+	for _, n := range g.Nodes {
+		if n.Highlighted {
+			highlighted = append(highlighted, n.ID)
+		}
+		if n.Dimmed {
+			dimmed = append(dimmed, n.ID)
+		}
+	}
+	c.Assert(highlighted, qt.DeepEquals, []string{"service:payments"})
+	c.Assert(dimmed, qt.DeepEquals, []string{"service:email", "database:orders", "topic:order-placed", "cron:cleanup"})
+	c.Assert(g.Edges[0].Highlighted, qt.IsTrue)
+	c.Assert(g.Edges[1].Dimmed, qt.IsTrue)
+
+	_, err = Build(testMeta(), Options{Highlight: "unknown"})
+	c.Assert(err, qt.ErrorMatches, `unknown service "unknown"`)
+}
+
+func TestMermaid(t *testing.T) {
+	c := qt.New(t)
+	g, err := Build(testMeta(), Options{Highlight: "payments"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(Mermaid(g), qt.Equals, `flowchart LR
+    n0["orders"]
+    n1["payments"]
+    n2["email"]
+    n3[("orders")]
+    n4>"order-placed"]
+    n5(["Cleanup"])
+    n0 ==> n1
+    n0 --> n3
+    n0 -.-> n4
+    n4 -.->|"send-receipt"| n2
+    n5 --> n0
+    classDef highlighted stroke:#6d28d9,stroke-width:3px
+    class n1 highlighted
+    classDef dimmed opacity:0.3
+    class n2,n3,n4,n5 dimmed
+    linkStyle 1,2,3,4 stroke-opacity:0.3
+`)
+}
+
+func TestSVG(t *testing.T) {
+	c := qt.New(t)
+	g, err := Build(testMeta(), Options{})
+	c.Assert(err, qt.IsNil)
+
+	// The cron job calls orders, which uses the other nodes.
+	// The email service is reached through the topic.
+	layers := layer(g)
+	c.Assert(layers, qt.DeepEquals, map[string]int{
+		"cron:cleanup":       0,
+		"service:orders":     1,
+		"service:payments":   2,
+		"database:orders":    2,
+		"topic:order-placed": 2,
+		"service:email":      3,
+	})
+
+	svg := string(SVG(g))
+	c.Assert(strings.HasPrefix(svg, "<svg "), qt.IsTrue)
+	c.Assert(strings.Count(svg, "<rect x="), qt.Equals, 6)
+	c.Assert(strings.Count(svg, `fill="none"`), qt.Equals, 5)
+	c.Assert(svg, qt.Contains, ">order-placed</text>")
+}
+
+func TestLayer_Cycle(t *testing.T) {
+	c := qt.New(t)
+	g := &Graph{
+		Nodes: []*Node{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+		Edges: []*Edge{{From: "a", To: "b"}, {From: "b", To: "c"}, {From: "c", To: "a"}},
+	}
+	c.Assert(layer(g), qt.DeepEquals, map[string]int{"a": 0, "b": 1, "c": 2})
+}
@@ -0,0 +1,250 @@
+// Package flowdiagram renders the architecture diagram of an app, like the
+// Flow view of the local development dashboard shows, for embedding in
+// design docs.
+package flowdiagram
+
+import (
+	"fmt"
+	"strings"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// NodeKind is the kind of a node.
+type NodeKind string
+
+const (
+	Service  NodeKind = "service"
+	Database NodeKind = "database"
+	Topic    NodeKind = "topic"
+	Cache    NodeKind = "cache"
+	CronJob  NodeKind = "cron"
+)
+
+// EdgeKind is the kind of an edge.
+type EdgeKind string
+
+const (
+	RPCCall   EdgeKind = "rpc"       // service -> service
+	UsesDB    EdgeKind = "database"  // service -> database
+	Publish   EdgeKind = "publish"   // service -> topic
+	Subscribe EdgeKind = "subscribe" // topic -> service
+	UsesCache EdgeKind = "cache"     // service -> cache
+	Schedules EdgeKind = "cron"      // cron job -> service
+
+	// PubSub connects a publisher to a subscriber
+	// when infrastructure nodes are collapsed.
+	PubSub EdgeKind = "pubsub"
+)
+
+// Node is a node in the diagram.
+type Node struct {
+	ID    string   `json:"id"`
+	Kind  NodeKind `json:"kind"`
+	Label string   `json:"label"`
+
+	// Details are extra lines shown in the node, like
+	// the infrastructure used by a service when collapsed.
+	Details []string `json:"details,omitempty"`
+
+	Highlighted bool `json:"highlighted,omitempty"`
+	Dimmed      bool `json:"dimmed,omitempty"`
+}
+
+// Edge is a directed edge in the diagram.
+type Edge struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Kind  EdgeKind `json:"kind"`
+	Label string   `json:"label,omitempty"`
+
+	Highlighted bool `json:"highlighted,omitempty"`
+	Dimmed      bool `json:"dimmed,omitempty"`
+}
+
+// Graph is an architecture diagram.
+type Graph struct {
+	Nodes []*Node `json:"nodes"`
+	Edges []*Edge `json:"edges"`
+}
+
+// Options configure the diagram.
+type Options struct {
+	// CollapseInfra leaves out the infrastructure nodes. The databases,
+	// caches and cron jobs of a service are listed in its node instead,
+	// and publishers are connected directly to subscribers.
+	CollapseInfra bool
+
+	// Highlight is the name of a service to highlight along with
+	// the services and infrastructure it depends on or is used by.
+	// Everything else is dimmed.
+	Highlight string
+}
+
+// Build builds the diagram of the app described by md.
+func Build(md *meta.Data, opts Options) (*Graph, error) {
+	b := &builder{
+		g:     &Graph{Nodes: []*Node{}, Edges: []*Edge{}},
+		nodes: make(map[string]*Node),
+		edges: make(map[Edge]bool),
+	}
+
+	svcByPkg := func(pkg string) string {
+		for _, svc := range md.Svcs {
+			if pkg == svc.RelPath || strings.HasPrefix(pkg, svc.RelPath+"/") {
+				return svc.Name
+			}
+		}
+		return ""
+	}
+
+	for _, svc := range md.Svcs {
+		b.node(Service, svc.Name, svc.Name)
+	}
+
+	// Calls between services.
+	for _, pkg := range md.Pkgs {
+		if pkg.ServiceName == "" {
+			continue
+		}
+		for _, call := range pkg.RpcCalls {
+			if target := svcByPkg(call.Pkg); target != "" && target != pkg.ServiceName {
+				b.edge(nodeID(Service, pkg.ServiceName), nodeID(Service, target), RPCCall, "")
+			}
+		}
+	}
+
+	for _, svc := range md.Svcs {
+		for _, db := range svc.Databases {
+			if opts.CollapseInfra {
+				b.detail(svc.Name, "database: "+db)
+			} else {
+				b.node(Database, db, db)
+				b.edge(nodeID(Service, svc.Name), nodeID(Database, db), UsesDB, "")
+			}
+		}
+	}
+
+	for _, topic := range md.PubsubTopics {
+		if opts.CollapseInfra {
+			for _, pub := range topic.Publishers {
+				for _, sub := range topic.Subscriptions {
+					b.edge(nodeID(Service, pub.ServiceName), nodeID(Service, sub.ServiceName), PubSub, topic.Name)
+				}
+			}
+			continue
+		}
+
+		b.node(Topic, topic.Name, topic.Name)
+		for _, pub := range topic.Publishers {
+			b.edge(nodeID(Service, pub.ServiceName), nodeID(Topic, topic.Name), Publish, "")
+		}
+		for _, sub := range topic.Subscriptions {
+			b.edge(nodeID(Topic, topic.Name), nodeID(Service, sub.ServiceName), Subscribe, sub.Name)
+		}
+	}
+
+	for _, cluster := range md.CacheClusters {
+		for _, ks := range cluster.Keyspaces {
+			if ks.Service == "" {
+				continue
+			}
+			if opts.CollapseInfra {
+				b.detail(ks.Service, "cache: "+cluster.Name)
+			} else {
+				b.node(Cache, cluster.Name, cluster.Name)
+				b.edge(nodeID(Service, ks.Service), nodeID(Cache, cluster.Name), UsesCache, "")
+			}
+		}
+	}
+
+	for _, job := range md.CronJobs {
+		svc := svcByPkg(job.Endpoint.GetPkg())
+		if svc == "" {
+			continue
+		}
+		if opts.CollapseInfra {
+			b.detail(svc, "cron: "+job.Title)
+		} else {
+			b.node(CronJob, job.Id, job.Title)
+			b.edge(nodeID(CronJob, job.Id), nodeID(Service, svc), Schedules, "")
+		}
+	}
+
+	if opts.Highlight != "" {
+		if err := b.highlight(opts.Highlight); err != nil {
+			return nil, err
+		}
+	}
+	return b.g, nil
+}
+
+func nodeID(kind NodeKind, name string) string {
+	return string(kind) + ":" + name
+}
+
+type builder struct {
+	g     *Graph
+	nodes map[string]*Node
+	edges map[Edge]bool
+}
+
+// node adds a node, unless it already exists.
+func (b *builder) node(kind NodeKind, name, label string) {
+	id := nodeID(kind, name)
+	if b.nodes[id] != nil {
+		return
+	}
+	n := &Node{ID: id, Kind: kind, Label: label}
+	b.nodes[id] = n
+	b.g.Nodes = append(b.g.Nodes, n)
+}
+
+// edge adds an edge between existing nodes, unless it already exists.
+func (b *builder) edge(from, to string, kind EdgeKind, label string) {
+	key := Edge{From: from, To: to, Kind: kind, Label: label}
+	if b.edges[key] || b.nodes[from] == nil || b.nodes[to] == nil {
+		return
+	}
+	b.edges[key] = true
+	e := key
+	b.g.Edges = append(b.g.Edges, &e)
+}
+
+// detail adds a detail line to a service node, unless it's already there.
+func (b *builder) detail(svc, line string) {
+	n := b.nodes[nodeID(Service, svc)]
+	if n == nil {
+		return
+	}
+	for _, d := range n.Details {
+		if d == line {
+			return
+		}
+	}
+	n.Details = append(n.Details, line)
+}
+
+// highlight highlights a service and its direct dependencies and dependents,
+// and dims everything else.
+func (b *builder) highlight(svc string) error {
+	id := nodeID(Service, svc)
+	if b.nodes[id] == nil {
+		return fmt.Errorf("unknown service %q", svc)
+	}
+
+	related := map[string]bool{id: true}
+	for _, e := range b.g.Edges {
+		if e.From == id || e.To == id {
+			e.Highlighted = true
+			related[e.From], related[e.To] = true, true
+		} else {
+			e.Dimmed = true
+		}
+	}
+	for _, n := range b.g.Nodes {
+		n.Highlighted = n.ID == id
+		n.Dimmed = !related[n.ID]
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package flowdiagram
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os/exec"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Mermaid renders the graph as a Mermaid flowchart.
+func Mermaid(g *Graph) string {
+	var buf strings.Builder
+	buf.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+
+		label := mermaidText(n.Label)
+		for _, d := range n.Details {
+			label += "<br/><small>" + mermaidText(d) + "</small>"
+		}
+		var open, close string
+		switch n.Kind {
+		case Database:
+			open, close = "[(", ")]"
+		case Topic:
+			open, close = ">", "]"
+		case Cache:
+			open, close = "{{", "}}"
+		case CronJob:
+			open, close = "([", "])"
+		default:
+			open, close = "[", "]"
+		}
+		fmt.Fprintf(&buf, "    %s%s\"%s\"%s\n", id, open, label, close)
+	}
+
+	var dimmedEdges []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		if e.Kind == PubSub || e.Kind == Publish || e.Kind == Subscribe {
+			arrow = "-.->"
+		} else if e.Highlighted {
+			arrow = "==>"
+		}
+		if e.Label != "" {
+			fmt.Fprintf(&buf, "    %s %s|\"%s\"| %s\n", ids[e.From], arrow, mermaidText(e.Label), ids[e.To])
+		} else {
+			fmt.Fprintf(&buf, "    %s %s %s\n", ids[e.From], arrow, ids[e.To])
+		}
+		if e.Dimmed {
+			dimmedEdges = append(dimmedEdges, fmt.Sprint(i))
+		}
+	}
+
+	var highlighted, dimmed []string
+	for _, n := range g.Nodes {
+		if n.Highlighted {
+			highlighted = append(highlighted, ids[n.ID])
+		} else if n.Dimmed {
+			dimmed = append(dimmed, ids[n.ID])
+		}
+	}
+	if len(highlighted) > 0 {
+		buf.WriteString("    classDef highlighted stroke:#6d28d9,stroke-width:3px\n")
+		fmt.Fprintf(&buf, "    class %s highlighted\n", strings.Join(highlighted, ","))
+	}
+	if len(dimmed) > 0 {
+		buf.WriteString("    classDef dimmed opacity:0.3\n")
+		fmt.Fprintf(&buf, "    class %s dimmed\n", strings.Join(dimmed, ","))
+	}
+	if len(dimmedEdges) > 0 {
+		fmt.Fprintf(&buf, "    linkStyle %s stroke-opacity:0.3\n", strings.Join(dimmedEdges, ","))
+	}
+	return buf.String()
+}
+
+// mermaidText escapes s for use in a quoted Mermaid label.
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+const (
+	nodeWidth     = 200
+	nodeHeight    = 40
+	detailHeight  = 16
+	columnSpacing = 100
+	rowSpacing    = 30
+	margin        = 30
+)
+
+var kindColors = map[NodeKind]struct{ fill, stroke string }{
+	Service:  {"#eef2ff", "#4f46e5"},
+	Database: {"#ecfdf5", "#059669"},
+	Topic:    {"#fff7ed", "#ea580c"},
+	Cache:    {"#fef2f2", "#dc2626"},
+	CronJob:  {"#f0f9ff", "#0284c7"},
+}
+
+type box struct {
+	x, y, w, h int
+}
+
+// SVG renders the graph as an SVG image, laid out from left to right
+// with every node placed to the right of the nodes pointing to it.
+func SVG(g *Graph) []byte {
+	layers := layer(g)
+
+	boxes := make(map[string]box, len(g.Nodes))
+	var columns [][]*Node
+	for _, n := range g.Nodes {
+		l := layers[n.ID]
+		for len(columns) <= l {
+			columns = append(columns, nil)
+		}
+		columns[l] = append(columns[l], n)
+	}
+
+	width, height := 2*margin, 2*margin
+	for i, col := range columns {
+		y := margin
+		for _, n := range col {
+			h := nodeHeight + len(n.Details)*detailHeight
+			boxes[n.ID] = box{x: margin + i*(nodeWidth+columnSpacing), y: y, w: nodeWidth, h: h}
+			y += h + rowSpacing
+		}
+		height = max(height, y-rowSpacing+margin)
+	}
+	if len(columns) > 0 {
+		width = 2*margin + len(columns)*nodeWidth + (len(columns)-1)*columnSpacing
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="system-ui, -apple-system, sans-serif">`+"\n", width, height, width, height)
+	buf.WriteString(`<defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="#64748b"/></marker></defs>` + "\n")
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+
+	for _, e := range g.Edges {
+		from, to := boxes[e.From], boxes[e.To]
+		x1, y1 := from.x+from.w, from.y+from.h/2
+		x2, y2 := to.x, to.y+to.h/2
+		var path string
+		if x2 > x1 {
+			dx := (x2 - x1) / 2
+			path = fmt.Sprintf("M %d %d C %d %d %d %d %d %d", x1, y1, x1+dx, y1, x2-dx, y2, x2, y2)
+		} else {
+			// The edge points backwards; route it below the nodes.
+			x1, y1 = from.x+from.w/2, from.y+from.h
+			x2, y2 = to.x+to.w/2, to.y+to.h
+			path = fmt.Sprintf("M %d %d C %d %d %d %d %d %d", x1, y1, x1, y1+60, x2, y2+60, x2, y2)
+		}
+
+		attrs := `stroke="#64748b" stroke-width="1.5"`
+		if e.Highlighted {
+			attrs = `stroke="#6d28d9" stroke-width="3"`
+		}
+		if e.Kind == PubSub || e.Kind == Publish || e.Kind == Subscribe {
+			attrs += ` stroke-dasharray="6 4"`
+		}
+		if e.Dimmed {
+			attrs += ` opacity="0.25"`
+		}
+		fmt.Fprintf(&buf, `<path d="%s" fill="none" %s marker-end="url(#arrow)"/>`+"\n", path, attrs)
+		if e.Label != "" {
+			fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="11" fill="#475569" text-anchor="middle"%s>%s</text>`+"\n",
+				(x1+x2)/2, (y1+y2)/2-4, dimAttr(e.Dimmed), html.EscapeString(e.Label))
+		}
+	}
+
+	for _, n := range g.Nodes {
+		b := boxes[n.ID]
+		c, ok := kindColors[n.Kind]
+		if !ok {
+			c = kindColors[Service]
+		}
+		strokeWidth := "1.5"
+		if n.Highlighted {
+			strokeWidth = "3"
+		}
+		rx := 6
+		if n.Kind != Service {
+			rx = 18
+		}
+		fmt.Fprintf(&buf, `<g%s>`+"\n", dimAttr(n.Dimmed))
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+			b.x, b.y, b.w, b.h, rx, c.fill, c.stroke, strokeWidth)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="10" fill="%s" text-anchor="middle">%s</text>`+"\n",
+			b.x+b.w/2, b.y+14, c.stroke, n.Kind)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="14" font-weight="600" fill="#0f172a" text-anchor="middle">%s</text>`+"\n",
+			b.x+b.w/2, b.y+31, html.EscapeString(n.Label))
+		for i, d := range n.Details {
+			fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="11" fill="#475569" text-anchor="middle">%s</text>`+"\n",
+				b.x+b.w/2, b.y+nodeHeight+i*detailHeight+8, html.EscapeString(d))
+		}
+		buf.WriteString("</g>\n")
+	}
+
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+func dimAttr(dimmed bool) string {
+	if dimmed {
+		return ` opacity="0.25"`
+	}
+	return ""
+}
+
+// layer assigns each node to a column, such that nodes are placed
+// to the right of the nodes pointing to them. Edges closing a cycle
+// are ignored for the purpose of layering.
+func layer(g *Graph) map[string]int {
+	out := make(map[string][]string)
+	for _, e := range g.Edges {
+		out[e.From] = append(out[e.From], e.To)
+	}
+
+	// Find the edges to keep with a depth-first search,
+	// dropping edges back to a node that's being visited.
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	forward := make(map[string][]string)
+	var order []string // reverse topological order
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		for _, to := range out[id] {
+			switch state[to] {
+			case unvisited:
+				forward[id] = append(forward[id], to)
+				visit(to)
+			case done:
+				forward[id] = append(forward[id], to)
+			}
+		}
+		state[id] = done
+		order = append(order, id)
+	}
+	for _, n := range g.Nodes {
+		if state[n.ID] == unvisited {
+			visit(n.ID)
+		}
+	}
+
+	layers := make(map[string]int, len(g.Nodes))
+	for _, n := range g.Nodes {
+		layers[n.ID] = 0
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		for _, to := range forward[id] {
+			layers[to] = max(layers[to], layers[id]+1)
+		}
+	}
+	return layers
+}
+
+// PNG converts an SVG image to PNG using one of the SVG converters
+// commonly available, as the standard library can't rasterize SVG.
+func PNG(svg []byte) ([]byte, error) {
+	converters := [][]string{
+		{"rsvg-convert", "--format=png"},
+		{"resvg", "-", "-c"},
+		{"magick", "svg:-", "png:-"},
+	}
+	for _, c := range converters {
+		path, err := exec.LookPath(c[0])
+		if err != nil {
+			continue
+		}
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(path, c[1:]...)
+		cmd.Stdin = bytes.NewReader(svg)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, errors.Wrapf(err, "%s: %s", c[0], strings.TrimSpace(stderr.String()))
+		}
+		return stdout.Bytes(), nil
+	}
+	return nil, errors.New("rendering PNG requires rsvg-convert, resvg or ImageMagick to be installed; use the SVG format instead")
+}
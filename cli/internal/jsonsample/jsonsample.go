@@ -1,4 +1,6 @@
-package daemon
+// Package jsonsample generates sample JSON payloads from schemas,
+// for pre-filling requests and messages in developer tools.
+package jsonsample
 
 import (
 	"fmt"
@@ -10,8 +12,8 @@ import (
 	schema "encr.dev/proto/encore/parser/schema/v1"
 )
 
-// genSchema generates a JSON payload to match the schema.
-func genSchema(meta *meta.Data, decl *schema.Type) []byte {
+// Generate generates a JSON payload to match the schema.
+func Generate(meta *meta.Data, decl *schema.Type) []byte {
 	if decl == nil {
 		return nil
 	}
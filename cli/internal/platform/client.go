@@ -183,6 +183,10 @@ func sendPlatformReq(ctx context.Context, method, path string, reqParams any, au
 }
 
 func doPlatformReq(req *http.Request, auth bool) (httpResp *http.Response, err error) {
+	if conf.Offline() {
+		return nil, conf.ErrOffline
+	}
+
 	// Add a very limited amount of information for diagnostics
 	req.Header.Set("User-Agent", "EncoreCLI/"+version.Version)
 	req.Header.Set("X-Encore-Version", version.Version)
@@ -224,6 +228,10 @@ func wsDial(ctx context.Context, path string, auth bool, extraHeaders map[string
 		}
 	}()
 
+	if conf.Offline() {
+		return nil, conf.ErrOffline
+	}
+
 	if auth {
 		tok, err := conf.DefaultTokenSource.Token()
 		if err != nil {
@@ -85,6 +85,9 @@ func (t *telemetry) sendOnce(event string, props ...map[string]any) {
 }
 
 func (t *telemetry) send(event string, props ...map[string]any) error {
+	if conf.Offline() {
+		return nil
+	}
 	var m struct {
 		Result bool `graphql:"telemetry(msg: $msg)"`
 	}
@@ -304,6 +304,11 @@
 			text: "Caching"
 			path: "/primitives/caching"
 			file: "primitives/caching"
+		}, {
+			kind: "basic"
+			text: "Audit Log"
+			path: "/primitives/audit-log"
+			file: "primitives/audit-log"
 		}, {
 			kind: "basic"
 			text: "Secrets"
@@ -540,6 +545,11 @@
 			text: "Metadata"
 			path: "/ts/develop/metadata"
 			file: "ts/develop/metadata"
+		}, {
+			kind: "basic"
+			text: "Middleware"
+			path: "/ts/develop/middleware"
+			file: "ts/develop/middleware"
 		}, {
 			kind: "basic"
 			text: "Testing"
@@ -73,7 +73,7 @@ func RunApp(c testing.TB, appRoot string, logger RunLogger, env []string) *RunAp
 
 	mgr := &Manager{}
 	ns := &namespace.Namespace{ID: "some-id", Name: "default"}
-	rm := infra.NewResourceManager(app, mgr.ClusterMgr, ns, nil, 0, false)
+	rm := infra.NewResourceManager(app, mgr.ClusterMgr, nil, ns, nil, 0, false)
 	run := &Run{
 		ID:              GenID(),
 		ListenAddr:      ln.Addr().String(),
@@ -102,7 +102,7 @@ func RunApp(c testing.TB, appRoot string, logger RunLogger, env []string) *RunAp
 	assertNil(err)
 
 	secrets := secret.New()
-	secretData, err := secrets.Load(app).Get(ctx, expSet)
+	secretData, err := secrets.Load(app, ns).Get(ctx, expSet)
 	assertNil(err)
 
 	p, err := run.StartProcGroup(&StartProcGroupParams{
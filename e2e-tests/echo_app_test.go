@@ -97,7 +97,7 @@ func doTestEndToEndWithApp(t *testing.T, env []string) {
 		clientgen.LangJavascript: "js/client.js",
 	} {
 		services := clientgentypes.AllServices(app.Meta)
-		client, err := clientgen.Client(lang, "slug", app.Meta, services, clientgentypes.TagSet{})
+		client, err := clientgen.Client(lang, "slug", app.Meta, services, clientgentypes.TagSet{}, clientgen.ClientOptions{})
 		if err != nil {
 			fmt.Println(err.Error())
 			c.FailNow()
@@ -573,7 +573,7 @@ func TestProcClosedOnCtxCancel(t *testing.T) {
 
 	mgr := &Manager{}
 	ns := &namespace.Namespace{ID: "some-id", Name: "default"}
-	rm := infra.NewResourceManager(app, nil, ns, nil, 0, false)
+	rm := infra.NewResourceManager(app, nil, nil, ns, nil, 0, false)
 	run := &Run{
 		ID:              GenID(),
 		App:             app,
@@ -10,6 +10,7 @@ import (
 
 	"encr.dev/internal/clientgen/clientgentypes"
 	"encr.dev/internal/clientgen/openapi"
+	"encr.dev/pkg/apiversion"
 	"encr.dev/pkg/errinsrc/srcerrors"
 	meta "encr.dev/proto/encore/parser/meta/v1"
 )
@@ -24,6 +25,8 @@ const (
 	LangJavascript Lang = "javascript"
 	LangGo         Lang = "go"
 	LangOpenAPI    Lang = "openapi"
+	LangCSharp     Lang = "csharp"
+	LangDart       Lang = "dart"
 )
 
 type generator interface {
@@ -44,11 +47,36 @@ func Detect(path string) (lang Lang, ok bool) {
 		return LangJavascript, true
 	case ".go":
 		return LangGo, true
+	case ".cs":
+		return LangCSharp, true
+	case ".dart":
+		return LangDart, true
 	default:
 		return LangUnknown, false
 	}
 }
 
+// ClientOptions are optional settings for generating a client.
+type ClientOptions struct {
+	// OpenAPIAnnotations are examples and vendor extensions to embed
+	// in OpenAPI specifications. It is ignored for other languages.
+	OpenAPIAnnotations *openapi.Annotations
+
+	// ReactQuery generates TanStack Query (React Query) hooks
+	// for calling the endpoints. It is ignored for languages other than TypeScript.
+	ReactQuery bool
+
+	// PackageName is the package name of Go clients, or the namespace of C# clients.
+	// For Dart clients it's the name of the generated file without the .dart extension,
+	// which the client uses to refer to the code generated by json_serializable.
+	// If empty a default is used. It is ignored for other languages.
+	PackageName string
+
+	// ServiceNames are the names to use for services in the output, keyed by service name.
+	// Services not in the map keep their names.
+	ServiceNames map[string]string
+}
+
 // Client generates an API client based on the given app metadata.
 // ServiceNames are the services to include in the output.
 // If it's nil, all services are included.
@@ -58,6 +86,7 @@ func Client(
 	md *meta.Data,
 	services clientgentypes.ServiceSet,
 	tags clientgentypes.TagSet,
+	opts ClientOptions,
 ) (code []byte, err error) {
 	defer func() {
 		if e := recover(); e != nil {
@@ -65,27 +94,42 @@ func Client(
 		}
 	}()
 
+	// Compute the API version before the metadata is filtered or renamed,
+	// as the version must match the app's.
+	apiVersion := apiversion.Fingerprint(md)
+	if !tags.IsEmpty() {
+		md = filterEndpoints(md, tags)
+	}
+	if len(opts.ServiceNames) > 0 {
+		md, services = renameServices(md, services, opts.ServiceNames)
+	}
+
 	var gen generator
 	switch lang {
 	case LangTypeScript:
-		gen = &typescript{generatorVersion: typescriptGenLatestVersion}
+		gen = &typescript{generatorVersion: typescriptGenLatestVersion, reactQuery: opts.ReactQuery}
 	case LangJavascript:
 		gen = &javascript{generatorVersion: javascriptGenLatestVersion}
 	case LangGo:
-		gen = &golang{generatorVersion: goGenLatestVersion}
+		gen = &golang{generatorVersion: goGenLatestVersion, packageName: opts.PackageName}
 	case LangOpenAPI:
-		gen = openapi.New(openapi.LatestVersion)
+		gen = openapi.New(openapi.LatestVersion).WithAnnotations(opts.OpenAPIAnnotations)
+	case LangCSharp:
+		gen = &csharp{generatorVersion: csharpGenLatestVersion, namespaceName: opts.PackageName}
+	case LangDart:
+		gen = &dart{generatorVersion: dartGenLatestVersion, fileName: opts.PackageName}
 	default:
 		return nil, ErrUnknownLang
 	}
 
 	var buf bytes.Buffer
 	params := clientgentypes.GenerateParams{
-		Buf:      &buf,
-		AppSlug:  appSlug,
-		Meta:     md,
-		Services: services,
-		Tags:     tags,
+		Buf:        &buf,
+		AppSlug:    appSlug,
+		Meta:       md,
+		Services:   services,
+		Tags:       tags,
+		APIVersion: apiVersion,
 	}
 
 	if err := gen.Generate(params); err != nil {
@@ -94,6 +138,26 @@ func Client(
 	return buf.Bytes(), nil
 }
 
+// Warnings reports problems with the endpoints a client generated by Client
+// with the same arguments includes, which don't prevent generating it.
+func Warnings(md *meta.Data, services clientgentypes.ServiceSet, tags clientgentypes.TagSet) []string {
+	var warnings []string
+	for _, svc := range md.Svcs {
+		if !services.Has(svc.Name) {
+			continue
+		}
+		for _, rpc := range svc.Rpcs {
+			if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) {
+				continue
+			}
+			if err := clientgentypes.CheckPagination(md, rpc); err != nil {
+				warnings = append(warnings, err.Error()+"; generating it as a regular endpoint")
+			}
+		}
+	}
+	return warnings
+}
+
 // GetLang returns the language specified by the given string, allowing for case insensitivity and common aliases.
 func GetLang(lang string) (Lang, error) {
 	switch strings.TrimSpace(strings.ToLower(lang)) {
@@ -105,6 +169,10 @@ func GetLang(lang string) (Lang, error) {
 		return LangGo, nil
 	case "openapi", "swagger", "oas":
 		return LangOpenAPI, nil
+	case "csharp", "c#", "cs", "dotnet":
+		return LangCSharp, nil
+	case "dart", "flutter":
+		return LangDart, nil
 	default:
 		return LangUnknown, ErrUnknownLang
 	}
@@ -2,6 +2,7 @@ package clientgen
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,8 +13,10 @@ import (
 
 	"encr.dev/cli/daemon/apps"
 	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/internal/clientgen/openapi"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
 	"encr.dev/v2/tsbuilder"
 	"encr.dev/v2/v2builder"
 )
@@ -72,7 +75,7 @@ func TestClientCodeGenerationFromGoApp(t *testing.T) {
 						c.Assert(ok, qt.IsTrue, qt.Commentf("Unable to detect language type for %s", file.Name()))
 
 						services := clientgentypes.AllServices(res.Meta)
-						generatedClient, err := Client(language, "app", res.Meta, services, clientgentypes.TagSet{})
+						generatedClient, err := Client(language, "app", res.Meta, services, clientgentypes.TagSet{}, ClientOptions{})
 						c.Assert(err, qt.IsNil)
 
 						golden.TestAgainst(c, "goapp/"+file.Name(), string(generatedClient))
@@ -133,7 +136,7 @@ func TestClientCodeGenerationFromTSApp(t *testing.T) {
 						c.Assert(ok, qt.IsTrue, qt.Commentf("Unable to detect language type for %s", file.Name()))
 
 						services := clientgentypes.AllServices(res.Meta)
-						generatedClient, err := Client(language, "app", res.Meta, services, clientgentypes.TagSet{})
+						generatedClient, err := Client(language, "app", res.Meta, services, clientgentypes.TagSet{}, ClientOptions{})
 						c.Assert(err, qt.IsNil)
 
 						golden.TestAgainst(c, "tsapp/"+file.Name(), string(generatedClient))
@@ -143,3 +146,145 @@ func TestClientCodeGenerationFromTSApp(t *testing.T) {
 		})
 	}
 }
+
+// parseTestApp parses the app in the txtar archive file,
+// returning its metadata.
+func parseTestApp(c *qt.C, file string) *meta.Data {
+	ar, err := txtar.ParseFile(file)
+	c.Assert(err, qt.IsNil)
+	base := c.TempDir()
+	c.Assert(txtar.Write(ar, base), qt.IsNil)
+
+	res, err := v2builder.BuilderImpl{}.Parse(context.Background(), builder.ParseParams{
+		Build:      builder.DefaultBuildInfo(),
+		App:        apps.NewInstance(base, "app", ""),
+		WorkingDir: ".",
+	})
+	c.Assert(err, qt.IsNil)
+	return res.Meta
+}
+
+func TestOpenAPIAnnotations(t *testing.T) {
+	c := qt.New(t)
+	md := parseTestApp(c, "./testdata/goapp/input_noauth.go")
+
+	_, err := openapi.ParseAnnotations([]byte(`{"extensions": {"logo": true}}`))
+	c.Assert(err, qt.ErrorMatches, `invalid extension "logo" for document: extension names must begin with "x-"`)
+
+	ann, err := openapi.ParseAnnotations([]byte(`{
+		"extensions": {"x-api-id": "dummy"},
+		"endpoints": {
+			"svc.DummyAPI": {
+				"request_example": {"Message": "hello"},
+				"extensions": {"x-rate-limit": 100}
+			}
+		}
+	}`))
+	c.Assert(err, qt.IsNil)
+
+	services := clientgentypes.AllServices(md)
+	code, err := Client(LangOpenAPI, "app", md, services, clientgentypes.TagSet{}, ClientOptions{OpenAPIAnnotations: ann})
+	c.Assert(err, qt.IsNil)
+
+	var spec struct {
+		APIID string `json:"x-api-id"`
+		Paths map[string]map[string]struct {
+			RateLimit   int `json:"x-rate-limit"`
+			RequestBody struct {
+				Content map[string]struct {
+					Example map[string]any `json:"example"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+	c.Assert(json.Unmarshal(code, &spec), qt.IsNil)
+	c.Assert(spec.APIID, qt.Equals, "dummy")
+	op := spec.Paths["/svc.DummyAPI"]["post"]
+	c.Assert(op.RateLimit, qt.Equals, 100)
+	c.Assert(op.RequestBody.Content["application/json"].Example, qt.DeepEquals, map[string]any{"Message": "hello"})
+}
+
+func TestReactQueryHooks(t *testing.T) {
+	c := qt.New(t)
+	md := parseTestApp(c, "./testdata/goapp/input.go")
+
+	services := clientgentypes.AllServices(md)
+	code, err := Client(LangTypeScript, "app", md, services, clientgentypes.TagSet{}, ClientOptions{ReactQuery: true})
+	c.Assert(err, qt.IsNil)
+	golden.TestAgainst(c, "goapp/expected_react_query.ts", string(code))
+}
+
+func TestClientNamingOverrides(t *testing.T) {
+	c := qt.New(t)
+	md := parseTestApp(c, "./testdata/goapp/input.go")
+
+	services := clientgentypes.NewServiceSet(md, []string{"products"}, nil)
+	code, err := Client(LangGo, "app", md, services, clientgentypes.TagSet{}, ClientOptions{
+		PackageName:  "catalogclient",
+		ServiceNames: map[string]string{"products": "catalog"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(code), qt.Contains, "package catalogclient\n")
+	c.Assert(string(code), qt.Contains, "Catalog CatalogClient\n")
+	c.Assert(string(code), qt.Contains, "type CatalogProduct struct")
+	c.Assert(string(code), qt.Contains, `"/products.List"`)
+	c.Assert(string(code), qt.Not(qt.Contains), "ProductsClient")
+
+	// The original metadata must not be modified.
+	for _, svc := range md.Svcs {
+		c.Assert(svc.Name, qt.Not(qt.Equals), "catalog")
+	}
+}
+
+func TestClientTagFiltering(t *testing.T) {
+	c := qt.New(t)
+	md := parseTestApp(c, "./testdata/goapp/input.go")
+
+	services := clientgentypes.AllServices(md)
+	tags := clientgentypes.NewTagSet([]string{"paginated"}, nil)
+	for _, lang := range []Lang{LangGo, LangTypeScript, LangOpenAPI} {
+		code, err := Client(lang, "app", md, services, tags, ClientOptions{})
+		c.Assert(err, qt.IsNil)
+
+		// Only the tagged endpoint and the types it uses are included.
+		c.Assert(string(code), qt.Contains, "Search", qt.Commentf("lang %s", lang))
+		c.Assert(string(code), qt.Contains, "Product", qt.Commentf("lang %s", lang))
+		c.Assert(string(code), qt.Not(qt.Contains), "CreateProduct", qt.Commentf("lang %s", lang))
+		c.Assert(string(code), qt.Not(qt.Contains), "DummyAPI", qt.Commentf("lang %s", lang))
+	}
+
+	// Services without any tagged endpoints are left out.
+	code, err := Client(LangGo, "app", md, services, tags, ClientOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(code), qt.Not(qt.Contains), "SvcClient")
+}
+
+func TestClientInvalidPagination(t *testing.T) {
+	c := qt.New(t)
+	md := parseTestApp(c, "./testdata/goapp/input.go")
+
+	// Tag an endpoint that doesn't follow the pagination convention as paginated.
+	for _, svc := range md.Svcs {
+		for _, rpc := range svc.Rpcs {
+			if svc.Name == "products" && rpc.Name == "Create" {
+				rpc.Tags = append(rpc.Tags, &meta.Selector{Type: meta.Selector_TAG, Value: clientgentypes.PaginationTag})
+			}
+		}
+	}
+
+	// The client is still generated, with the endpoint as a regular one.
+	services := clientgentypes.AllServices(md)
+	for _, lang := range []Lang{LangGo, LangTypeScript} {
+		code, err := Client(lang, "app", md, services, clientgentypes.TagSet{}, ClientOptions{})
+		c.Assert(err, qt.IsNil, qt.Commentf("lang %s", lang))
+		c.Assert(string(code), qt.Contains, "SearchAll", qt.Commentf("lang %s", lang))
+		c.Assert(string(code), qt.Not(qt.Contains), "CreateAll", qt.Commentf("lang %s", lang))
+	}
+	code, err := Client(LangOpenAPI, "app", md, services, clientgentypes.TagSet{}, ClientOptions{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(strings.Count(string(code), `"x-pagination"`), qt.Equals, 1)
+
+	c.Assert(Warnings(md, services, clientgentypes.TagSet{}), qt.DeepEquals, []string{
+		`products.Create is tagged "paginated", but the request has no "cursor" string parameter; generating it as a regular endpoint`,
+	})
+}
@@ -0,0 +1,172 @@
+// Package clientgenconfig parses client generation configuration files,
+// which describe the clients to generate for an app so that `encore gen client`
+// generates the same clients for everyone.
+//
+// A configuration file looks like:
+//
+//	env: staging
+//	targets:
+//	  - lang: typescript
+//	    output: frontend/src/client.ts
+//	    services: [products, users]
+//	    excluded_tags: [internal]
+//	    react_query: true
+//	  - lang: go
+//	    output: clients/go/client.go
+//	    package: apiclient
+//	    service_names:
+//	      products: catalog
+package clientgenconfig
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+	"sigs.k8s.io/yaml"
+
+	"encr.dev/internal/clientgen"
+)
+
+// FileName is the name of the configuration file in the app root.
+const FileName = "encore-clientgen.yaml"
+
+// Config is a client generation configuration.
+type Config struct {
+	// Env is the environment to generate the clients for, unless overridden by a target.
+	// If empty the primary environment is used.
+	Env string `json:"env,omitempty"`
+
+	// Targets are the clients to generate.
+	Targets []*Target `json:"targets"`
+}
+
+// Target describes a client to generate.
+type Target struct {
+	// Lang is the language of the client.
+	Lang string `json:"lang"`
+
+	// Output is the file to write the client to.
+	// Relative paths are relative to the configuration file.
+	Output string `json:"output"`
+
+	// Env is the environment to generate the client for, overriding Config.Env.
+	Env string `json:"env,omitempty"`
+
+	// Services are the services to include. If empty all services are included.
+	Services []string `json:"services,omitempty"`
+
+	// ExcludedServices are the services to exclude.
+	ExcludedServices []string `json:"excluded_services,omitempty"`
+
+	// Tags are the tags of the endpoints to include. If empty all endpoints are included.
+	Tags []string `json:"tags,omitempty"`
+
+	// ExcludedTags are the tags of the endpoints to exclude.
+	ExcludedTags []string `json:"excluded_tags,omitempty"`
+
+	// Package is the package name of Go clients, or the namespace of C# clients.
+	Package string `json:"package,omitempty"`
+
+	// ServiceNames are the names to use for services in the client, keyed by service name.
+	ServiceNames map[string]string `json:"service_names,omitempty"`
+
+	// OpenAPIAnnotations is the file containing annotations for OpenAPI specifications.
+	// Relative paths are relative to the configuration file.
+	OpenAPIAnnotations string `json:"openapi_annotations,omitempty"`
+
+	// ReactQuery generates TanStack Query (React Query) hooks in TypeScript clients.
+	ReactQuery bool `json:"react_query,omitempty"`
+}
+
+// EnvName reports the environment to generate the target for.
+func (c *Config) EnvName(t *Target) string {
+	if t.Env != "" {
+		return t.Env
+	}
+	return c.Env
+}
+
+// Load reads and validates the configuration file at path.
+// Relative paths in the configuration are resolved relative to the file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read client generation config")
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+
+	dir := filepath.Dir(path)
+	for _, t := range cfg.Targets {
+		if !filepath.IsAbs(t.Output) {
+			t.Output = filepath.Join(dir, t.Output)
+		}
+		if t.OpenAPIAnnotations != "" && !filepath.IsAbs(t.OpenAPIAnnotations) {
+			t.OpenAPIAnnotations = filepath.Join(dir, t.OpenAPIAnnotations)
+		}
+	}
+	return cfg, nil
+}
+
+// Parse parses and validates a configuration.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, errors.New("no targets defined")
+	}
+
+	outputs := make(map[string]bool, len(cfg.Targets))
+	for i, t := range cfg.Targets {
+		if err := t.validate(); err != nil {
+			return nil, errors.Wrapf(err, "targets[%d]", i)
+		}
+		if outputs[t.Output] {
+			return nil, errors.Newf("targets[%d]: output %q is used by multiple targets", i, t.Output)
+		}
+		outputs[t.Output] = true
+	}
+	return &cfg, nil
+}
+
+// validate validates the target, and normalizes its language.
+func (t *Target) validate() error {
+	lang, err := clientgen.GetLang(t.Lang)
+	if err != nil {
+		return errors.Newf("unknown lang %q", t.Lang)
+	}
+	t.Lang = string(lang)
+
+	if t.Output == "" {
+		return errors.New("no output specified")
+	}
+	if t.OpenAPIAnnotations != "" && lang != clientgen.LangOpenAPI {
+		return errors.New("openapi_annotations can only be used with the openapi lang")
+	}
+	if t.ReactQuery && lang != clientgen.LangTypeScript {
+		return errors.New("react_query can only be used with the typescript lang")
+	}
+	if t.Package != "" {
+		switch lang {
+		case clientgen.LangGo:
+			if !token.IsIdentifier(t.Package) {
+				return errors.Newf("invalid Go package name %q", t.Package)
+			}
+		case clientgen.LangCSharp:
+		default:
+			return errors.New("package can only be used with the go and csharp langs")
+		}
+	}
+	for svc, name := range t.ServiceNames {
+		if !token.IsIdentifier(name) {
+			return errors.Newf("invalid name %q for service %s", name, svc)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package clientgenconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestLoad(t *testing.T) {
+	c := qt.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	err := os.WriteFile(path, []byte(`
+env: staging
+targets:
+  - lang: ts
+    output: frontend/client.ts
+    services: [products]
+    excluded_tags: [internal]
+    react_query: true
+  - lang: go
+    output: /abs/client.go
+    env: local
+    package: apiclient
+    service_names:
+      products: catalog
+  - lang: openapi
+    output: openapi.json
+    openapi_annotations: annotations.json
+`), 0644)
+	c.Assert(err, qt.IsNil)
+
+	cfg, err := Load(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Targets, qt.DeepEquals, []*Target{
+		{
+			Lang:         "typescript",
+			Output:       filepath.Join(dir, "frontend/client.ts"),
+			Services:     []string{"products"},
+			ExcludedTags: []string{"internal"},
+			ReactQuery:   true,
+		},
+		{
+			Lang:         "go",
+			Output:       "/abs/client.go",
+			Env:          "local",
+			Package:      "apiclient",
+			ServiceNames: map[string]string{"products": "catalog"},
+		},
+		{
+			Lang:               "openapi",
+			Output:             filepath.Join(dir, "openapi.json"),
+			OpenAPIAnnotations: filepath.Join(dir, "annotations.json"),
+		},
+	})
+	c.Assert(cfg.EnvName(cfg.Targets[0]), qt.Equals, "staging")
+	c.Assert(cfg.EnvName(cfg.Targets[1]), qt.Equals, "local")
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "no_targets",
+			config: `env: staging`,
+			err:    `no targets defined`,
+		},
+		{
+			name:   "unknown_field",
+			config: "targets:\n  - lang: go\n    output: client.go\n    pkg: foo",
+			err:    `.*unknown field "pkg".*`,
+		},
+		{
+			name:   "unknown_lang",
+			config: "targets:\n  - lang: cobol\n    output: client.cbl",
+			err:    `targets\[0\]: unknown lang "cobol"`,
+		},
+		{
+			name:   "no_output",
+			config: "targets:\n  - lang: go",
+			err:    `targets\[0\]: no output specified`,
+		},
+		{
+			name:   "duplicate_output",
+			config: "targets:\n  - lang: go\n    output: client.go\n  - lang: go\n    output: client.go",
+			err:    `targets\[1\]: output "client.go" is used by multiple targets`,
+		},
+		{
+			name:   "react_query_go",
+			config: "targets:\n  - lang: go\n    output: client.go\n    react_query: true",
+			err:    `targets\[0\]: react_query can only be used with the typescript lang`,
+		},
+		{
+			name:   "invalid_package",
+			config: "targets:\n  - lang: go\n    output: client.go\n    package: api-client",
+			err:    `targets\[0\]: invalid Go package name "api-client"`,
+		},
+		{
+			name:   "package_typescript",
+			config: "targets:\n  - lang: ts\n    output: client.ts\n    package: api",
+			err:    `targets\[0\]: package can only be used with the go and csharp langs`,
+		},
+		{
+			name:   "invalid_service_name",
+			config: "targets:\n  - lang: go\n    output: client.go\n    service_names: {products: my-catalog}",
+			err:    `targets\[0\]: invalid name "my-catalog" for service products`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := qt.New(t)
+			_, err := Parse([]byte(test.config))
+			c.Assert(err, qt.ErrorMatches, test.err)
+		})
+	}
+}
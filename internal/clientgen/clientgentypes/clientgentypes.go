@@ -13,6 +13,9 @@ type GenerateParams struct {
 	Meta     *meta.Data
 	Services ServiceSet
 	Tags     TagSet
+
+	// APIVersion is the fingerprint of the API the client is generated for.
+	APIVersion string
 }
 
 type ServiceSet struct {
@@ -86,6 +89,11 @@ func NewTagSet(tags, excludedTags []string) TagSet {
 	return tagSet
 }
 
+// IsEmpty reports whether the tag set includes all endpoints.
+func (t TagSet) IsEmpty() bool {
+	return len(t.included) == 0 && len(t.excluded) == 0
+}
+
 func (t TagSet) IsRPCIncluded(rpc *meta.RPC) bool {
 	// First check if the RPC has any of the excluded tags.
 	for _, selector := range rpc.Tags {
@@ -0,0 +1,54 @@
+package clientgentypes
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/parser/encoding"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// FormContentType is the content type of form-encoded request bodies.
+const FormContentType = "application/x-www-form-urlencoded"
+
+// IsFormEncoded reports whether the endpoint accepts form-encoded
+// (application/x-www-form-urlencoded) request bodies, through the "form" api option.
+//
+// Generated clients send the body parameters of such endpoints form-encoded,
+// using the same encoding as query strings: each parameter is sent as a key,
+// and lists are sent as repeated keys. Only built-in types and lists of built-in
+// types can be form-encoded.
+func IsFormEncoded(rpc *meta.RPC) bool {
+	return rpc.Form
+}
+
+// FormBodyParameters reports the body parameters to send form-encoded for the given request encoding.
+// It reports nil if the endpoint doesn't accept form-encoded request bodies,
+// and an error if it does but its body parameters can't be form-encoded.
+func FormBodyParameters(rpc *meta.RPC, req *encoding.RequestEncoding) ([]*encoding.ParameterEncoding, error) {
+	if !IsFormEncoded(rpc) || req == nil || len(req.BodyParameters) == 0 {
+		return nil, nil
+	} else if rpc.Proto == meta.RPC_RAW || rpc.StreamingRequest || rpc.StreamingResponse {
+		return nil, errors.Newf("%s.%s: only typed, non-streaming endpoints can accept form-encoded requests",
+			rpc.ServiceName, rpc.Name)
+	}
+
+	for _, param := range req.BodyParameters {
+		if !IsBuiltinOrList(param.Type) {
+			return nil, errors.Newf("%s.%s has the \"form\" option, but the request field %s can't be form-encoded: "+
+				"only built-in types and lists of built-in types are supported",
+				rpc.ServiceName, rpc.Name, param.SrcName)
+		}
+	}
+	return req.BodyParameters, nil
+}
+
+// IsBuiltinOrList reports whether the type is a built-in type or a list of built-in types,
+// the types that can be encoded in query strings and form-encoded request bodies.
+func IsBuiltinOrList(typ *schema.Type) bool {
+	if list := typ.GetList(); list != nil {
+		typ = list.Elem
+	}
+	_, ok := typ.Typ.(*schema.Type_Builtin)
+	return ok
+}
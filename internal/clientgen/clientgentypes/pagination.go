@@ -0,0 +1,125 @@
+package clientgentypes
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/parser/encoding"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// PaginationTag is the tag that marks an endpoint as paginated.
+//
+// Paginated endpoints must follow the pagination convention: the request has a "cursor"
+// string parameter and optionally a "limit" parameter, and the response has a "next_cursor"
+// (or "nextCursor") string field together with a single list field holding the page's items.
+// An empty next cursor means there are no more pages.
+const PaginationTag = "paginated"
+
+// Pagination describes how to page through the results of a paginated endpoint.
+type Pagination struct {
+	Cursor     *encoding.ParameterEncoding // the request's cursor parameter
+	Limit      *encoding.ParameterEncoding // the request's limit parameter, or nil
+	NextCursor *encoding.ParameterEncoding // the response's next cursor field
+	Items      *encoding.ParameterEncoding // the response's list of items
+
+	// ItemType is the type of the items.
+	ItemType *schema.Type
+}
+
+// IsPaginated reports whether the endpoint is tagged as paginated.
+func IsPaginated(rpc *meta.RPC) bool {
+	return slices.ContainsFunc(rpc.Tags, func(s *meta.Selector) bool {
+		return s.Type == meta.Selector_TAG && s.Value == PaginationTag
+	})
+}
+
+// DescribePagination describes the pagination of an endpoint.
+// It reports nil if the endpoint is not tagged as paginated, or if it's tagged
+// but doesn't follow the pagination convention, in which case clients call it
+// like any other endpoint. CheckPagination reports why.
+func DescribePagination(md *meta.Data, rpc *meta.RPC, opts *encoding.Options) (*Pagination, error) {
+	p, err := describePagination(md, rpc, opts)
+	if errors.HasType(err, (*PaginationError)(nil)) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// CheckPagination reports a *PaginationError if the endpoint is tagged as paginated
+// but doesn't follow the pagination convention.
+func CheckPagination(md *meta.Data, rpc *meta.RPC) error {
+	_, err := describePagination(md, rpc, nil)
+	return err
+}
+
+// PaginationError describes why an endpoint tagged as paginated
+// doesn't follow the pagination convention.
+type PaginationError struct {
+	Service, Endpoint string
+	Reason            string
+}
+
+func (e *PaginationError) Error() string {
+	return fmt.Sprintf("%s.%s is tagged %q, but %s", e.Service, e.Endpoint, PaginationTag, e.Reason)
+}
+
+func describePagination(md *meta.Data, rpc *meta.RPC, opts *encoding.Options) (*Pagination, error) {
+	invalid := func(reason string) error {
+		return &PaginationError{Service: rpc.ServiceName, Endpoint: rpc.Name, Reason: reason}
+	}
+	if !IsPaginated(rpc) {
+		return nil, nil
+	} else if rpc.Proto == meta.RPC_RAW || rpc.StreamingRequest || rpc.StreamingResponse {
+		return nil, invalid("only typed, non-streaming endpoints can be paginated")
+	}
+
+	enc, err := encoding.DescribeRPC(md, rpc, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "describe rpc %s.%s", rpc.ServiceName, rpc.Name)
+	}
+
+	p := &Pagination{}
+	if req := enc.DefaultRequestEncoding; req != nil {
+		for _, params := range [][]*encoding.ParameterEncoding{req.QueryParameters, req.BodyParameters} {
+			for _, param := range params {
+				switch param.Name {
+				case "cursor":
+					p.Cursor = param
+				case "limit":
+					p.Limit = param
+				}
+			}
+		}
+	}
+	if p.Cursor == nil || !isString(p.Cursor.Type) {
+		return nil, invalid(`the request has no "cursor" string parameter`)
+	}
+
+	var items []*encoding.ParameterEncoding
+	if resp := enc.ResponseEncoding; resp != nil {
+		for _, param := range resp.BodyParameters {
+			switch {
+			case param.Name == "next_cursor" || param.Name == "nextCursor":
+				p.NextCursor = param
+			case param.Type.GetList() != nil:
+				items = append(items, param)
+			}
+		}
+	}
+	if p.NextCursor == nil || !isString(p.NextCursor.Type) {
+		return nil, invalid(`the response has no "next_cursor" string field`)
+	} else if len(items) != 1 {
+		return nil, invalid("the response doesn't have exactly one list field of items")
+	}
+	p.Items = items[0]
+	p.ItemType = p.Items.Type.GetList().Elem
+	return p, nil
+}
+
+func isString(typ *schema.Type) bool {
+	return typ.GetBuiltin() == schema.Builtin_STRING
+}
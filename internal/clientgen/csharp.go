@@ -0,0 +1,1216 @@
+package clientgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/internal/version"
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+/* The C# generator generates code that looks like this:
+public partial class TaskAddParams
+{
+	[JsonPropertyName("description")]
+	public string Description { get; set; } = default!;
+}
+
+public interface ITaskClient
+{
+	Task<TaskAddResponse> AddAsync(TaskAddParams @params, CancellationToken cancellationToken = default);
+}
+
+public sealed class TaskClient : ITaskClient
+{
+	public async Task<TaskAddResponse> AddAsync(TaskAddParams @params, CancellationToken cancellationToken = default)
+	{
+		// ...
+	}
+}
+
+*/
+
+// csGenVersion allows us to introduce breaking changes in the generated code but behind a switch
+// meaning that people with client code reliant on the old behaviour can continue to generate the
+// old code.
+type csGenVersion int
+
+const (
+	// CsInitial is the originally released C# generator
+	CsInitial csGenVersion = iota
+
+	// CsExperimental can be used to lock experimental or uncompleted features in the generated code
+	// It should always be the last item in the enum
+	CsExperimental
+)
+
+const csharpGenLatestVersion = CsExperimental - 1
+
+type csharp struct {
+	*bytes.Buffer
+	md               *meta.Data
+	appSlug          string
+	apiVersion       string
+	namespaceName    string // the namespace of the client; derived from appSlug if empty
+	typs             *typeRegistry
+	generatorVersion csGenVersion
+
+	seenHeaderResponse bool // true if we've seen a header used in a response object
+	hasAuth            bool // true if we've seen an authentication handler
+	authIsComplexType  bool // true if the auth type is a complex type
+
+	// inlined tracks the type arguments of the non-struct declarations
+	// currently being inlined, as C# has no type aliases.
+	inlined map[uint32][]*schema.Type
+
+	// topLevel are the classes generated for anonymous structs
+	// used directly as API request or response types.
+	topLevel []*csNestedClass
+}
+
+// csNestedClass is a class generated for an anonymous struct type.
+type csNestedClass struct {
+	name string
+	st   *schema.Struct
+}
+
+func (cs *csharp) Version() int {
+	return int(cs.generatorVersion)
+}
+
+func (cs *csharp) Generate(p clientgentypes.GenerateParams) (err error) {
+	defer cs.handleBailout(&err)
+
+	cs.Buffer = p.Buf
+	cs.md = p.Meta
+	cs.appSlug = p.AppSlug
+	cs.apiVersion = p.APIVersion
+	cs.typs = getNamedTypes(p.Meta, p.Services)
+	cs.inlined = make(map[uint32][]*schema.Type)
+
+	if cs.md.AuthHandler != nil {
+		cs.hasAuth = true
+		cs.authIsComplexType = cs.md.AuthHandler.Params.GetBuiltin() != schema.Builtin_STRING
+	}
+
+	cs.WriteString("// " + doNotEditHeader() + "\n\n")
+	cs.WriteString(`#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Globalization;
+using System.Linq;
+using System.Net.Http;
+using System.Text;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Threading;
+using System.Threading.Tasks;
+
+`)
+	fmt.Fprintf(cs, "namespace %s;\n", cs.namespace())
+
+	cs.writeClient(p.Services)
+
+	seenNs := make(map[string]bool)
+	for _, svc := range p.Meta.Svcs {
+		cs.writeTypes(svc.Name)
+		seenNs[svc.Name] = true
+
+		if hasPublicRPC(svc) && p.Services.Has(svc.Name) {
+			if err := cs.writeService(svc, p.Tags); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ns := range cs.typs.Namespaces() {
+		if !seenNs[ns] {
+			cs.writeTypes(ns)
+		}
+	}
+
+	if err := cs.writeBaseClient(); err != nil {
+		return err
+	}
+	cs.writeEncodingHelpers()
+	cs.writeErrorTypes()
+	return nil
+}
+
+// namespace returns the C# namespace of the generated code.
+func (cs *csharp) namespace() string {
+	if cs.namespaceName != "" {
+		return cs.namespaceName
+	}
+	name := idents.Convert(cs.appSlug, idents.PascalCase)
+	if name == "" || !unicode.IsLetter(rune(name[0])) {
+		name = "App" + name
+	}
+	return "Encore." + name
+}
+
+func (cs *csharp) writeClient(set clientgentypes.ServiceSet) {
+	w := cs.newIdentWriter(0)
+	w.WriteStringf(`
+/// <summary>
+/// Environments contains the base URLs for calling the %[1]s Encore application's API.
+/// </summary>
+public static class Environments
+{
+    /// <summary>
+    /// Local is the base URL of the locally running application.
+    /// </summary>
+    public const string Local = "http://localhost:4000";
+
+    /// <summary>
+    /// Environment returns the base URL for calling the cloud environment with the given name.
+    /// </summary>
+    public static string Environment(string name) => $"https://{name}-%[1]s.encr.app";
+
+    /// <summary>
+    /// PreviewEnv returns the base URL for calling the preview environment with the given PR number.
+    /// </summary>
+    public static string PreviewEnv(int pr) => Environment($"pr{pr}");
+
+    /// <summary>
+    /// APIVersion is the version of the API the client was generated for.
+    /// </summary>
+    public const string APIVersion = "%[2]s";
+}
+
+/// <summary>
+/// Client is an API client for the %[1]s Encore application.
+/// </summary>
+public sealed class Client
+{
+`, cs.appSlug, cs.apiVersion)
+
+	{
+		w := w.Indent()
+		for _, svc := range cs.md.Svcs {
+			if hasPublicRPC(svc) && set.Has(svc.Name) {
+				name := cs.serviceName(svc.Name)
+				w.WriteStringf("public I%sClient %s { get; }\n", name, name)
+			}
+		}
+		w.WriteString(`
+/// <summary>
+/// Creates a Client for calling the public and authenticated APIs of your Encore application.
+/// </summary>
+/// <param name="baseUrl">The base URL of the application. See <see cref="Environments"/> for options.</param>
+/// <param name="options">Options for the client.</param>
+public Client(string baseUrl, ClientOptions? options = null)
+{
+`)
+		{
+			w := w.Indent()
+			w.WriteString("var baseClient = new BaseClient(baseUrl, options ?? new ClientOptions());\n")
+			for _, svc := range cs.md.Svcs {
+				if hasPublicRPC(svc) && set.Has(svc.Name) {
+					name := cs.serviceName(svc.Name)
+					w.WriteStringf("%s = new %sClient(baseClient);\n", name, name)
+				}
+			}
+		}
+		w.WriteString("}\n")
+	}
+	w.WriteString(`}
+
+/// <summary>
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+/// </summary>
+public sealed class ClientOptions
+{
+    /// <summary>
+    /// The HTTP client used to make the API requests. By default a new HttpClient is created,
+    /// however you can provide your own to configure timeouts, proxies or message handlers
+    /// which run custom code on each API request made or response received.
+    /// </summary>
+    public HttpClient? HttpClient { get; set; }
+
+    /// <summary>
+    /// Headers which are added to each API request.
+    /// </summary>
+    public IDictionary<string, string> Headers { get; } = new Dictionary<string, string>();
+
+    /// <summary>
+    /// Whether to send <see cref="Environments.APIVersion"/> with each API request, so that the API
+    /// rejects the requests if the client is out of date. Such requests fail with an
+    /// <see cref="APIException"/> whose <see cref="APIException.IsClientOutOfDate"/> is true.
+    /// </summary>
+    public bool VerifyAPIVersion { get; set; }
+`)
+
+	if cs.hasAuth {
+		authType := strings.TrimSuffix(cs.typ(cs.md.AuthHandler.Params, "AuthData", nil).name, "?")
+		if cs.authIsComplexType {
+			w.WriteString(`
+    /// <summary>
+    /// The authentication data to be used for each API request.
+    /// </summary>
+`)
+		} else {
+			w.WriteString(`
+    /// <summary>
+    /// The auth token to be used for each API request,
+    /// sent as a bearer token in the Authorization header.
+    /// </summary>
+`)
+		}
+		w.WriteStringf(`    public %[1]s? Auth { get; set; }
+
+    /// <summary>
+    /// A function which is called before each API request and returns the authentication data to use,
+    /// for when the credentials can change during the lifetime of the client. It takes precedence over Auth.
+    /// </summary>
+    public Func<CancellationToken, Task<%[1]s?>>? AuthGenerator { get; set; }
+`, authType)
+	}
+	w.WriteString("}\n")
+}
+
+// writeTypes writes the classes for the struct declarations of the given namespace.
+// Other declarations are inlined where they're used, as C# has no type aliases.
+func (cs *csharp) writeTypes(ns string) {
+	decls := cs.typs.Decls(ns)
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].Name < decls[j].Name
+	})
+	for _, decl := range decls {
+		st := decl.Type.GetStruct()
+		if st == nil {
+			continue
+		}
+
+		typeParams := make([]string, len(decl.TypeParams))
+		for i, p := range decl.TypeParams {
+			typeParams[i] = cs.typeParamName(p.Name)
+		}
+
+		cs.WriteString("\n")
+		cs.writeClass(cs.newIdentWriter(0), cs.declName(decl), typeParams, decl.Doc, st)
+	}
+}
+
+// writeClass writes a class for the given struct, with nested classes for any anonymous structs within it.
+func (cs *csharp) writeClass(w *indentWriter, name string, typeParams []string, doc string, st *schema.Struct) {
+	cs.writeDoc(w, doc)
+	w.WriteString("public partial class " + name)
+	if len(typeParams) > 0 {
+		w.WriteString("<" + strings.Join(typeParams, ", ") + ">")
+	}
+	w.WriteString("\n{\n")
+
+	var nested []*csNestedClass
+	{
+		w := w.Indent()
+		first := true
+		for _, field := range st.Fields {
+			if encoding.IgnoreField(field) {
+				continue
+			}
+			if !first {
+				w.WriteString("\n")
+			}
+			first = false
+
+			prop := cs.propName(field.Name)
+			if prop == name {
+				prop += "Value"
+			}
+
+			typ := cs.typ(field.Typ, prop+"Type", &nested)
+			typName := typ.name
+			if field.Optional && !strings.HasSuffix(typName, "?") {
+				typName += "?"
+			}
+
+			cs.writeDoc(w, field.Doc)
+			w.WriteStringf("[JsonPropertyName(%s)]\n", cs.quote(cs.fieldWireName(field)))
+			w.WriteStringf("public %s %s { get; set; }", typName, prop)
+			if !typ.isValue && !strings.HasSuffix(typName, "?") {
+				w.WriteString(" = default!;")
+			}
+			w.WriteString("\n")
+		}
+
+		for _, n := range nested {
+			w.WriteString("\n")
+			cs.writeClass(w, n.name, nil, "", n.st)
+		}
+	}
+	w.WriteString("}\n")
+}
+
+func (cs *csharp) writeService(svc *meta.Service, tags clientgentypes.TagSet) error {
+	name := cs.serviceName(svc.Name)
+	cs.topLevel = nil
+
+	var rpcs []*meta.RPC
+	for _, rpc := range svc.Rpcs {
+		if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) {
+			continue
+		}
+
+		// streaming endpoints not supported yet
+		if rpc.StreamingRequest || rpc.StreamingResponse {
+			continue
+		}
+		rpcs = append(rpcs, rpc)
+	}
+
+	// The interface
+	w := cs.newIdentWriter(0)
+	w.WriteStringf(`
+/// <summary>
+/// I%[1]sClient provides access to call the public and authenticated APIs of the %[2]s service.
+/// It allows you to create mock implementations of the client during tests.
+/// </summary>
+public interface I%[1]sClient
+{
+`, name, svc.Name)
+	for i, rpc := range rpcs {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		cs.writeDoc(w.Indent(), rpc.GetDoc())
+		w.Indent().WriteString(cs.rpcSignature(svc, rpc) + ";\n")
+	}
+	w.WriteString("}\n")
+
+	// The implementation
+	w.WriteStringf(`
+/// <summary>
+/// %[1]sClient is the implementation of <see cref="I%[1]sClient"/>.
+/// </summary>
+public sealed class %[1]sClient : I%[1]sClient
+{
+    private readonly BaseClient baseClient;
+
+    internal %[1]sClient(BaseClient baseClient)
+    {
+        this.baseClient = baseClient;
+    }
+`, name)
+
+	for _, rpc := range rpcs {
+		w := w.Indent()
+		w.WriteString("\n/// <inheritdoc/>\n")
+		w.WriteString("public async " + cs.rpcSignature(svc, rpc) + "\n{\n")
+		if err := cs.rpcCallSite(svc, w.Indent(), rpc); err != nil {
+			return errors.Wrapf(err, "unable to write RPC call site for %s.%s", rpc.ServiceName, rpc.Name)
+		}
+		w.WriteString("}\n")
+	}
+	w.WriteString("}\n")
+
+	for i := 0; i < len(cs.topLevel); i++ {
+		n := cs.topLevel[i]
+		cs.WriteString("\n")
+		cs.writeClass(cs.newIdentWriter(0), n.name, nil, "", n.st)
+	}
+	return nil
+}
+
+// rpcSignature returns the signature of the method calling the given RPC.
+func (cs *csharp) rpcSignature(svc *meta.Service, rpc *meta.RPC) string {
+	prefix := cs.serviceName(svc.Name) + cs.propName(rpc.Name)
+
+	var b strings.Builder
+	switch {
+	case rpc.Proto == meta.RPC_RAW:
+		b.WriteString("Task<HttpResponseMessage>")
+	case rpc.ResponseSchema != nil:
+		b.WriteString("Task<" + cs.typ(rpc.ResponseSchema, prefix+"Response", nil).name + ">")
+	default:
+		b.WriteString("Task")
+	}
+	b.WriteString(" " + cs.propName(rpc.Name) + "Async(")
+
+	for _, s := range rpc.Path.Segments {
+		if s.Type == meta.PathSegment_LITERAL {
+			continue
+		}
+		if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
+			b.WriteString("IEnumerable<string>")
+		} else {
+			b.WriteString(cs.pathSegmentType(s.ValueType))
+		}
+		b.WriteString(" " + cs.nonReservedId(s.Value) + ", ")
+	}
+
+	if rpc.Proto == meta.RPC_RAW {
+		b.WriteString("HttpRequestMessage request, ")
+	} else if rpc.RequestSchema != nil {
+		b.WriteString(cs.typ(rpc.RequestSchema, prefix+"Request", nil).name + " @params, ")
+	}
+	b.WriteString("CancellationToken cancellationToken = default)")
+	return b.String()
+}
+
+func (cs *csharp) pathSegmentType(typ meta.PathSegment_ParamType) string {
+	switch typ {
+	case meta.PathSegment_STRING, meta.PathSegment_UUID:
+		return "string"
+	case meta.PathSegment_BOOL:
+		return "bool"
+	case meta.PathSegment_INT8:
+		return "sbyte"
+	case meta.PathSegment_INT16:
+		return "short"
+	case meta.PathSegment_INT32:
+		return "int"
+	case meta.PathSegment_INT64, meta.PathSegment_INT:
+		return "long"
+	case meta.PathSegment_UINT8:
+		return "byte"
+	case meta.PathSegment_UINT16:
+		return "ushort"
+	case meta.PathSegment_UINT32:
+		return "uint"
+	case meta.PathSegment_UINT64, meta.PathSegment_UINT:
+		return "ulong"
+	default:
+		cs.errorf("unhandled PathSegment type %s", typ)
+		return ""
+	}
+}
+
+// rpcPath returns a C# expression for the path of the given RPC.
+func (cs *csharp) rpcPath(rpc *meta.RPC) string {
+	var path strings.Builder
+	hasParams := false
+	for _, s := range rpc.Path.Segments {
+		path.WriteByte('/')
+		if s.Type == meta.PathSegment_LITERAL {
+			path.WriteString(strings.NewReplacer("{", "{{", "}", "}}", `"`, `\"`).Replace(s.Value))
+			continue
+		}
+
+		hasParams = true
+		id := cs.nonReservedId(s.Value)
+		switch {
+		case s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK:
+			path.WriteString("{ClientEncoding.EscapePath(" + id + ")}")
+		case s.ValueType == meta.PathSegment_STRING || s.ValueType == meta.PathSegment_UUID:
+			path.WriteString("{Uri.EscapeDataString(" + id + ")}")
+		default:
+			path.WriteString("{ClientEncoding.Format(" + id + ")}")
+		}
+	}
+
+	if hasParams {
+		return `$"` + path.String() + `"`
+	}
+	return `"` + path.String() + `"`
+}
+
+func (cs *csharp) rpcCallSite(svc *meta.Service, w *indentWriter, rpc *meta.RPC) error {
+	// Raw end points just pass through the request
+	// and need no further code generation
+	if rpc.Proto == meta.RPC_RAW {
+		w.WriteStringf(
+			"return await baseClient.SendRawAsync(%s, request, cancellationToken).ConfigureAwait(false);\n",
+			cs.rpcPath(rpc),
+		)
+		return nil
+	}
+
+	// Work out how we're going to encode and call this RPC
+	rpcEncoding, err := encoding.DescribeRPC(cs.md, rpc, nil)
+	if err != nil {
+		return errors.Wrapf(err, "rpc %s", rpc.Name)
+	}
+
+	headers := "null"
+	query := "null"
+	body := "null"
+
+	if rpc.RequestSchema != nil {
+		reqEnc := rpcEncoding.DefaultRequestEncoding
+
+		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 {
+			w.WriteString("// Convert our params into the objects we need for the request\n")
+		}
+
+		// Generate the headers
+		if len(reqEnc.HeaderParameters) > 0 {
+			headers = "headers"
+			w.WriteString("var headers = ")
+			cs.paramsDict(w, reqEnc.HeaderParameters)
+			w.WriteString(";\n\n")
+		}
+
+		// Generate the query string
+		if len(reqEnc.QueryParameters) > 0 {
+			query = "query"
+			w.WriteString("var query = ")
+			cs.paramsDict(w, reqEnc.QueryParameters)
+			w.WriteString(";\n\n")
+		}
+
+		// Generate the body
+		if len(reqEnc.BodyParameters) > 0 {
+			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+				// In the simple case we can just encode the params as the body directly
+				body = "@params"
+			} else {
+				// Else we need a new object called "body"
+				body = "body"
+				w.WriteString("// Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)\n")
+				w.WriteString("var body = ")
+				cs.paramsDict(w, reqEnc.BodyParameters)
+				w.WriteString(";\n\n")
+			}
+		}
+	}
+
+	w.WriteStringf(
+		"// Now make the actual call to the API\nusing var resp = await baseClient.CallApiAsync(%s, %s, %s, %s, %s, cancellationToken).ConfigureAwait(false);\n",
+		cs.httpMethod(rpcEncoding.DefaultMethod), cs.rpcPath(rpc), body, headers, query,
+	)
+
+	// If there's no response schema, there's nothing more to do
+	if rpc.ResponseSchema == nil {
+		return nil
+	}
+
+	respType := cs.typ(rpc.ResponseSchema, cs.serviceName(svc.Name)+cs.propName(rpc.Name)+"Response", nil).name
+	respEnc := rpcEncoding.ResponseEncoding
+
+	// If we don't need to do anything with the body, we can just return the response
+	if len(respEnc.HeaderParameters) == 0 {
+		w.WriteStringf("return await BaseClient.ReadJsonAsync<%s>(resp, cancellationToken).ConfigureAwait(false);\n", respType)
+		return nil
+	}
+
+	// Otherwise, we need to add the header fields to the response
+	w.WriteStringf("\n// Populate the return object from the JSON body and received headers\nvar rtn = await BaseClient.ReadJsonAsync<%s>(resp, cancellationToken).ConfigureAwait(false);\n", respType)
+	for _, field := range respEnc.HeaderParameters {
+		cs.seenHeaderResponse = true
+		typ := field.Type
+		if ptr := typ.GetPointer(); ptr != nil {
+			typ = ptr.Base
+		}
+		w.WriteStringf(
+			"rtn.%s = BaseClient.MustBeSet(resp, %s, v => %s);\n",
+			cs.propName(field.SrcName), cs.quote(field.WireFormat), cs.convertStringToBuiltin(typ.GetBuiltin(), "v"),
+		)
+	}
+	w.WriteString("return rtn;\n")
+	return nil
+}
+
+// paramsDict writes a dictionary initializer mapping the wire names of the given parameters to their values.
+func (cs *csharp) paramsDict(w *indentWriter, params []*encoding.ParameterEncoding) {
+	sorted := make([]*encoding.ParameterEncoding, len(params))
+	copy(sorted, params)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].WireFormat < sorted[j].WireFormat
+	})
+
+	w.WriteString("new Dictionary<string, object?>\n{\n")
+	{
+		w := w.Indent()
+		for _, p := range sorted {
+			w.WriteStringf("[%s] = @params.%s,\n", cs.quote(p.WireFormat), cs.propName(p.SrcName))
+		}
+	}
+	w.WriteString("}")
+}
+
+func (cs *csharp) httpMethod(method string) string {
+	switch method {
+	case "GET", "POST", "PUT", "DELETE", "HEAD", "OPTIONS", "PATCH", "TRACE":
+		return "HttpMethod." + method[:1] + strings.ToLower(method[1:])
+	default:
+		return "new HttpMethod(" + cs.quote(method) + ")"
+	}
+}
+
+func (cs *csharp) convertStringToBuiltin(typ schema.Builtin, val string) string {
+	switch typ {
+	case schema.Builtin_ANY, schema.Builtin_STRING, schema.Builtin_USER_ID:
+		return val
+	case schema.Builtin_BOOL:
+		return fmt.Sprintf("bool.Parse(%s)", val)
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64,
+		schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64,
+		schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return fmt.Sprintf("%s.Parse(%s, CultureInfo.InvariantCulture)", cs.builtinType(typ).name, val)
+	case schema.Builtin_BYTES:
+		return fmt.Sprintf("Convert.FromBase64String(%s)", val)
+	case schema.Builtin_TIME:
+		return fmt.Sprintf("DateTimeOffset.Parse(%s, CultureInfo.InvariantCulture)", val)
+	case schema.Builtin_JSON:
+		return fmt.Sprintf("JsonSerializer.Deserialize<JsonElement>(%s)", val)
+	case schema.Builtin_UUID:
+		return fmt.Sprintf("Guid.Parse(%s)", val)
+	default:
+		cs.errorf("unknown builtin type %v", typ)
+		return ""
+	}
+}
+
+// csType describes a C# type.
+type csType struct {
+	name    string
+	isValue bool // whether it's a value type
+}
+
+func (cs *csharp) builtinType(typ schema.Builtin) csType {
+	switch typ {
+	case schema.Builtin_ANY:
+		return csType{name: "object?"}
+	case schema.Builtin_BOOL:
+		return csType{name: "bool", isValue: true}
+	case schema.Builtin_INT8:
+		return csType{name: "sbyte", isValue: true}
+	case schema.Builtin_INT16:
+		return csType{name: "short", isValue: true}
+	case schema.Builtin_INT32:
+		return csType{name: "int", isValue: true}
+	case schema.Builtin_INT, schema.Builtin_INT64:
+		return csType{name: "long", isValue: true}
+	case schema.Builtin_UINT8:
+		return csType{name: "byte", isValue: true}
+	case schema.Builtin_UINT16:
+		return csType{name: "ushort", isValue: true}
+	case schema.Builtin_UINT32:
+		return csType{name: "uint", isValue: true}
+	case schema.Builtin_UINT, schema.Builtin_UINT64:
+		return csType{name: "ulong", isValue: true}
+	case schema.Builtin_FLOAT32:
+		return csType{name: "float", isValue: true}
+	case schema.Builtin_FLOAT64:
+		return csType{name: "double", isValue: true}
+	case schema.Builtin_STRING, schema.Builtin_USER_ID:
+		return csType{name: "string"}
+	case schema.Builtin_BYTES:
+		return csType{name: "byte[]"}
+	case schema.Builtin_TIME:
+		return csType{name: "DateTimeOffset", isValue: true}
+	case schema.Builtin_JSON:
+		return csType{name: "JsonElement", isValue: true}
+	case schema.Builtin_UUID:
+		return csType{name: "Guid", isValue: true}
+	default:
+		cs.errorf("unknown builtin type %v", typ)
+		return csType{}
+	}
+}
+
+// typ returns the C# type for the given schema type.
+//
+// Anonymous structs are generated as classes named after hint, which are added to nested.
+// If nested is nil they're generated as top level classes instead.
+func (cs *csharp) typ(typ *schema.Type, hint string, nested *[]*csNestedClass) csType {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := cs.md.Decls[t.Named.Id]
+		if decl.Type.GetStruct() == nil {
+			// C# has no type aliases, so inline the declared type.
+			if _, ok := cs.inlined[decl.Id]; ok {
+				return csType{name: "JsonElement", isValue: true}
+			}
+			cs.inlined[decl.Id] = t.Named.TypeArguments
+			defer delete(cs.inlined, decl.Id)
+			return cs.typ(decl.Type, hint, nested)
+		}
+
+		name := cs.declName(decl)
+		if len(t.Named.TypeArguments) > 0 {
+			args := make([]string, len(t.Named.TypeArguments))
+			for i, arg := range t.Named.TypeArguments {
+				args[i] = cs.typ(arg, fmt.Sprintf("%sArg%d", hint, i), nested).name
+			}
+			name += "<" + strings.Join(args, ", ") + ">"
+		}
+		return csType{name: name}
+
+	case *schema.Type_List:
+		return csType{name: "List<" + cs.typ(t.List.Elem, hint, nested).name + ">"}
+
+	case *schema.Type_Map:
+		key := strings.TrimSuffix(cs.typ(t.Map.Key, hint+"Key", nested).name, "?")
+		return csType{name: "Dictionary<" + key + ", " + cs.typ(t.Map.Value, hint, nested).name + ">"}
+
+	case *schema.Type_Builtin:
+		return cs.builtinType(t.Builtin)
+
+	case *schema.Type_Pointer:
+		base := cs.typ(t.Pointer.Base, hint, nested)
+		if !strings.HasSuffix(base.name, "?") {
+			base.name += "?"
+		}
+		return base
+
+	case *schema.Type_Literal:
+		switch t.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			return csType{name: "string"}
+		case *schema.Literal_Boolean:
+			return csType{name: "bool", isValue: true}
+		case *schema.Literal_Int:
+			return csType{name: "long", isValue: true}
+		case *schema.Literal_Float:
+			return csType{name: "double", isValue: true}
+		case *schema.Literal_Null:
+			return csType{name: "object?"}
+		default:
+			cs.errorf("unknown literal type %T", t.Literal.Value)
+		}
+
+	case *schema.Type_Union:
+		// There's no good way of representing unions in C#,
+		// so leave it to the caller to decode the raw JSON value.
+		return csType{name: "JsonElement", isValue: true}
+
+	case *schema.Type_Struct:
+		n := &csNestedClass{name: hint, st: t.Struct}
+		if nested != nil {
+			*nested = append(*nested, n)
+		} else if !slices.ContainsFunc(cs.topLevel, func(c *csNestedClass) bool { return c.name == hint }) {
+			cs.topLevel = append(cs.topLevel, n)
+		}
+		return csType{name: hint}
+
+	case *schema.Type_TypeParameter:
+		if args, ok := cs.inlined[t.TypeParameter.DeclId]; ok && int(t.TypeParameter.ParamIdx) < len(args) {
+			return cs.typ(args[t.TypeParameter.ParamIdx], hint, nested)
+		}
+		decl := cs.md.Decls[t.TypeParameter.DeclId]
+		return csType{name: cs.typeParamName(decl.TypeParams[t.TypeParameter.ParamIdx].Name)}
+
+	case *schema.Type_Config:
+		// Config type is transparent
+		return cs.typ(t.Config.Elem, hint, nested)
+	}
+
+	cs.errorf("unknown type %+v", reflect.TypeOf(typ.Typ))
+	return csType{}
+}
+
+func (cs *csharp) writeBaseClient() error {
+	userAgent := fmt.Sprintf("%s-Generated-CSharp-Client (Encore/%s)", cs.appSlug, version.Version)
+
+	w := cs.newIdentWriter(0)
+	w.WriteString(`
+/// <summary>
+/// BaseClient holds all the information we need to make requests to an Encore application.
+/// </summary>
+internal sealed class BaseClient
+{
+    internal static readonly JsonSerializerOptions JsonOptions = new(JsonSerializerDefaults.Web);
+
+    private const string UserAgent = "` + userAgent + `";
+
+    private readonly string baseUrl;
+    private readonly HttpClient httpClient;
+    private readonly ClientOptions options;
+
+    internal BaseClient(string baseUrl, ClientOptions options)
+    {
+        this.baseUrl = baseUrl.TrimEnd('/');
+        this.httpClient = options.HttpClient ?? new HttpClient();
+        this.options = options;
+    }
+
+    // CallApiAsync is used by each generated API method to actually make the request.
+    // It throws an APIException if the API returns an error.
+    internal async Task<HttpResponseMessage> CallApiAsync(HttpMethod method, string path, object? body, Dictionary<string, object?>? headers, Dictionary<string, object?>? query, CancellationToken cancellationToken)
+    {
+        using var request = new HttpRequestMessage(method, baseUrl + path);
+        if (body != null)
+        {
+            var json = JsonSerializer.Serialize(body, body.GetType(), JsonOptions);
+            request.Content = new StringContent(json, Encoding.UTF8, "application/json");
+        }
+
+        var response = await SendAsync(request, headers, query, cancellationToken).ConfigureAwait(false);
+        if (!response.IsSuccessStatusCode)
+        {
+            using (response)
+            {
+                throw await ReadErrorAsync(response, cancellationToken).ConfigureAwait(false);
+            }
+        }
+        return response;
+    }
+
+    // SendRawAsync sends a request to a raw API endpoint, returning the response as is.
+    internal Task<HttpResponseMessage> SendRawAsync(string path, HttpRequestMessage request, CancellationToken cancellationToken)
+    {
+        // Keep any query string of the request, but make sure it hits the right base URL
+        request.RequestUri = new Uri(baseUrl + path + (request.RequestUri?.IsAbsoluteUri == true ? request.RequestUri.Query : ""));
+        return SendAsync(request, null, null, cancellationToken);
+    }
+
+    // SendAsync sends the request to the Encore application, adding the headers,
+    // query string and authorization data as required.
+    private async Task<HttpResponseMessage> SendAsync(HttpRequestMessage request, Dictionary<string, object?>? headers, Dictionary<string, object?>? query, CancellationToken cancellationToken)
+    {
+        headers = headers != null ? new Dictionary<string, object?>(headers) : new Dictionary<string, object?>();
+        query = query != null ? new Dictionary<string, object?>(query) : new Dictionary<string, object?>();
+`)
+	if cs.hasAuth {
+		w.WriteString("        await AddAuthDataAsync(headers, query, cancellationToken).ConfigureAwait(false);\n")
+	}
+	w.WriteString(`
+        if (query.Count > 0)
+        {
+            var uri = request.RequestUri!.ToString();
+            request.RequestUri = new Uri(uri + (uri.Contains('?') ? "&" : "?") + ClientEncoding.EncodeQuery(query));
+        }
+
+        request.Headers.TryAddWithoutValidation("User-Agent", UserAgent);
+        if (options.VerifyAPIVersion)
+        {
+            request.Headers.TryAddWithoutValidation("X-Encore-API-Version", Environments.APIVersion);
+        }
+        foreach (var header in options.Headers)
+        {
+            request.Headers.TryAddWithoutValidation(header.Key, header.Value);
+        }
+        foreach (var header in headers)
+        {
+            foreach (var value in ClientEncoding.Values(header.Value))
+            {
+                if (!request.Headers.TryAddWithoutValidation(header.Key, value))
+                {
+                    request.Content?.Headers.TryAddWithoutValidation(header.Key, value);
+                }
+            }
+        }
+
+        return await httpClient.SendAsync(request, cancellationToken).ConfigureAwait(false);
+    }
+`)
+
+	if cs.hasAuth {
+		w.WriteString(`
+    // AddAuthDataAsync adds the authentication data to the request, if there is any.
+    private async Task AddAuthDataAsync(Dictionary<string, object?> headers, Dictionary<string, object?> query, CancellationToken cancellationToken)
+    {
+        var authData = options.AuthGenerator != null
+            ? await options.AuthGenerator(cancellationToken).ConfigureAwait(false)
+            : options.Auth;
+        if (authData == null)
+        {
+            return;
+        }
+
+`)
+		w := cs.newIdentWriter(2)
+		if cs.authIsComplexType {
+			authData, err := encoding.DescribeAuth(cs.md, cs.md.AuthHandler.Params, nil)
+			if err != nil {
+				return errors.Wrap(err, "unable to describe auth data")
+			}
+
+			for _, field := range authData.HeaderParameters {
+				w.WriteStringf("headers[%s] = authData.%s;\n", cs.quote(field.WireFormat), cs.propName(field.SrcName))
+			}
+			for _, field := range authData.QueryParameters {
+				w.WriteStringf("query[%s] = authData.%s;\n", cs.quote(field.WireFormat), cs.propName(field.SrcName))
+			}
+		} else {
+			w.WriteString("headers[\"Authorization\"] = \"Bearer \" + authData;\n")
+		}
+		w.Dedent().WriteString("}\n")
+	}
+
+	w.WriteString(`
+    // ReadJsonAsync decodes the JSON body of the response.
+    internal static async Task<T> ReadJsonAsync<T>(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        using var stream = await response.Content.ReadAsStreamAsync(cancellationToken).ConfigureAwait(false);
+        var value = await JsonSerializer.DeserializeAsync<T>(stream, JsonOptions, cancellationToken).ConfigureAwait(false);
+        return value ?? throw new APIException((int)response.StatusCode, ErrCode.DataLoss, "response body was unexpectedly null");
+    }
+`)
+
+	if cs.seenHeaderResponse {
+		w.WriteString(`
+    // MustBeSet parses the value of a response header, throwing an APIException with the DataLoss code if it's missing.
+    internal static T MustBeSet<T>(HttpResponseMessage response, string header, Func<string, T> parse)
+    {
+        if (response.Headers.TryGetValues(header, out var values) || response.Content.Headers.TryGetValues(header, out values))
+        {
+            var value = values.FirstOrDefault();
+            if (value != null)
+            {
+                return parse(value);
+            }
+        }
+        throw new APIException((int)response.StatusCode, ErrCode.DataLoss, $"Header ` + "`{header}`" + ` was unexpectedly missing");
+    }
+`)
+	}
+
+	w.WriteString(`
+    // ReadErrorAsync reads the structured error from a failed API call.
+    private static async Task<APIException> ReadErrorAsync(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        var status = (int)response.StatusCode;
+        var body = await response.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+        try
+        {
+            var error = JsonSerializer.Deserialize<APIErrorResponse>(body, JsonOptions);
+            if (error?.Code != null && error.Message != null)
+            {
+                return new APIException(status, APIException.ParseCode(error.Code), error.Message, error.Details);
+            }
+        }
+        catch (JsonException)
+        {
+            // Not a structured error, so fall back to the raw body below.
+        }
+        return new APIException(status, ErrCode.Unknown, $"request failed: status {status}: {body}");
+    }
+
+    private sealed class APIErrorResponse
+    {
+        [JsonPropertyName("code")]
+        public string? Code { get; set; }
+
+        [JsonPropertyName("message")]
+        public string? Message { get; set; }
+
+        [JsonPropertyName("details")]
+        public JsonElement? Details { get; set; }
+    }
+}
+`)
+	return nil
+}
+
+func (cs *csharp) writeEncodingHelpers() {
+	cs.WriteString(`
+/// <summary>
+/// ClientEncoding encodes values sent in paths, headers and query strings.
+/// </summary>
+internal static class ClientEncoding
+{
+    // Format formats a single value.
+    internal static string Format(object value) => value switch
+    {
+        string s => s,
+        bool b => b ? "true" : "false",
+        DateTimeOffset t => t.ToString("O", CultureInfo.InvariantCulture),
+        byte[] bytes => Convert.ToBase64String(bytes),
+        JsonElement json => json.GetRawText(),
+        IFormattable f => f.ToString(null, CultureInfo.InvariantCulture),
+        _ => value.ToString() ?? "",
+    };
+
+    // Values formats the values of a header or query string parameter,
+    // which has one value per element if it's a list.
+    internal static IEnumerable<string> Values(object? value)
+    {
+        switch (value)
+        {
+            case null:
+                yield break;
+            case string or byte[]:
+                yield return Format(value);
+                break;
+            case IEnumerable list:
+                foreach (var item in list)
+                {
+                    if (item != null)
+                    {
+                        yield return Format(item);
+                    }
+                }
+                break;
+            default:
+                yield return Format(value);
+                break;
+        }
+    }
+
+    // EscapePath escapes a list of path segments and joins them into a single path.
+    internal static string EscapePath(IEnumerable<string> segments) =>
+        string.Join("/", segments.Select(Uri.EscapeDataString));
+
+    // EncodeQuery encodes the parameters as a query string.
+    internal static string EncodeQuery(Dictionary<string, object?> query) =>
+        string.Join("&", query.SelectMany(p => Values(p.Value).Select(v => Uri.EscapeDataString(p.Key) + "=" + Uri.EscapeDataString(v))));
+}
+`)
+}
+
+func (cs *csharp) writeErrorTypes() {
+	w := cs.newIdentWriter(0)
+	w.WriteString(`
+/// <summary>
+/// APIException represents a structured error as returned from an Encore application.
+/// </summary>
+public sealed class APIException : Exception
+{
+    /// <summary>
+    /// The HTTP status code associated with the error.
+    /// </summary>
+    public int Status { get; }
+
+    /// <summary>
+    /// The Encore error code.
+    /// </summary>
+    public ErrCode Code { get; }
+
+    /// <summary>
+    /// The error details, if any.
+    /// </summary>
+    public JsonElement? Details { get; }
+
+    /// <summary>
+    /// Whether the API rejected the request because the client was generated
+    /// for a different version of the API. Regenerate the client to fix it.
+    /// </summary>
+    public bool IsClientOutOfDate =>
+        Code == ErrCode.FailedPrecondition &&
+        Details is { ValueKind: JsonValueKind.Object } details &&
+        details.TryGetProperty("client_out_of_date", out var outOfDate) &&
+        outOfDate.ValueKind == JsonValueKind.True;
+
+    public APIException(int status, ErrCode code, string message, JsonElement? details = null) : base(message)
+    {
+        Status = status;
+        Code = code;
+        Details = details;
+    }
+
+    internal static ErrCode ParseCode(string code) => code switch
+    {
+`)
+	for _, e := range errorCodes {
+		w.Indent().Indent().WriteStringf("%s => ErrCode.%s,\n", cs.quote(idents.Convert(e.Name, idents.SnakeCase)), e.Name)
+	}
+	w.WriteString(`        _ => ErrCode.Unknown,
+    };
+}
+
+/// <summary>
+/// ErrCode is the error code of an APIException.
+/// </summary>
+public enum ErrCode
+{
+`)
+	for i, e := range errorCodes {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		cs.writeDoc(w.Indent(), e.Comment)
+		w.Indent().WriteStringf("%s,\n", e.Name)
+	}
+	w.WriteString("}\n")
+}
+
+// writeDoc writes the given documentation as an XML doc comment.
+func (cs *csharp) writeDoc(w *indentWriter, doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	w.WriteString("/// <summary>\n")
+	scanner := bufio.NewScanner(strings.NewReader(doc))
+	for scanner.Scan() {
+		w.WriteString(strings.TrimRight("/// "+escape.Replace(scanner.Text()), " ") + "\n")
+	}
+	w.WriteString("/// </summary>\n")
+}
+
+type csharpBailout struct{ err error }
+
+func (cs *csharp) errorf(format string, args ...interface{}) {
+	panic(csharpBailout{fmt.Errorf(format, args...)})
+}
+
+func (cs *csharp) handleBailout(dst *error) {
+	if err := recover(); err != nil {
+		if bail, ok := err.(csharpBailout); ok {
+			*dst = bail.err
+		} else {
+			panic(err)
+		}
+	}
+}
+
+func (cs *csharp) newIdentWriter(indent int) *indentWriter {
+	return &indentWriter{
+		w:                cs.Buffer,
+		depth:            indent,
+		indent:           "    ",
+		firstWriteOnLine: true,
+	}
+}
+
+func (cs *csharp) quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func (cs *csharp) serviceName(name string) string {
+	return idents.Convert(name, idents.PascalCase)
+}
+
+func (cs *csharp) declName(decl *schema.Decl) string {
+	return idents.Convert(decl.Loc.PkgName, idents.PascalCase) + idents.Convert(decl.Name, idents.PascalCase)
+}
+
+func (cs *csharp) propName(name string) string {
+	return idents.Convert(name, idents.PascalCase)
+}
+
+// typeParamName returns the name of a type parameter, following the C# convention of prefixing them with T.
+// This also keeps them from clashing with the properties of the class.
+func (cs *csharp) typeParamName(name string) string {
+	if name == "T" || (len(name) > 1 && name[0] == 'T' && unicode.IsUpper(rune(name[1]))) {
+		return name
+	}
+	return "T" + idents.Convert(name, idents.PascalCase)
+}
+
+func (cs *csharp) fieldWireName(field *schema.Field) string {
+	if field.JsonName != "" {
+		return field.JsonName
+	}
+	return field.Name
+}
+
+// nonReservedId returns the given ID, unless we have it a reserved within the client function _or_ it's a reserved C# keyword
+func (cs *csharp) nonReservedId(id string) string {
+	switch id {
+	// our reserved keywords (or ID's we use within the generated client functions)
+	case "params", "headers", "query", "body", "resp", "rtn", "request", "cancellationToken", "baseClient":
+		return "_" + id
+
+	// C# keywords
+	case "abstract", "as", "base", "bool", "break", "byte", "case", "catch", "char", "checked", "class", "const",
+		"continue", "decimal", "default", "delegate", "do", "double", "else", "enum", "event", "explicit", "extern",
+		"false", "finally", "fixed", "float", "for", "foreach", "goto", "if", "implicit", "in", "int", "interface",
+		"internal", "is", "lock", "long", "namespace", "new", "null", "object", "operator", "out", "override",
+		"private", "protected", "public", "readonly", "ref", "return", "sbyte", "sealed", "short", "sizeof",
+		"stackalloc", "static", "string", "struct", "switch", "this", "throw", "true", "try", "typeof", "uint",
+		"ulong", "unchecked", "unsafe", "ushort", "using", "virtual", "void", "volatile", "while":
+		return "@" + id
+
+	default:
+		return id
+	}
+}
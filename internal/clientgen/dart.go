@@ -0,0 +1,1397 @@
+package clientgen
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/internal/version"
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+/* The Dart generator generates code that looks like this:
+@_model
+class TaskAddParams {
+  TaskAddParams({required this.description});
+
+  factory TaskAddParams.fromJson(Map<String, dynamic> json) => _$TaskAddParamsFromJson(json);
+
+  @JsonKey(name: 'description')
+  String description;
+
+  Map<String, dynamic> toJson() => _$TaskAddParamsToJson(this);
+}
+
+abstract interface class TaskClient {
+  Future<TaskAddResponse> add(TaskAddParams params);
+}
+
+class _TaskClient implements TaskClient {
+  @override
+  Future<TaskAddResponse> add(TaskAddParams params) async {
+    // ...
+  }
+}
+
+The models are serialized with json_serializable, so the generated code must be
+completed by running build_runner, which writes the part file they refer to.
+*/
+
+// dartGenVersion allows us to introduce breaking changes in the generated code but behind a switch
+// meaning that people with client code reliant on the old behaviour can continue to generate the
+// old code.
+type dartGenVersion int
+
+const (
+	// DartInitial is the originally released Dart generator
+	DartInitial dartGenVersion = iota
+
+	// DartExperimental can be used to lock experimental or uncompleted features in the generated code
+	// It should always be the last item in the enum
+	DartExperimental
+)
+
+const dartGenLatestVersion = DartExperimental - 1
+
+type dart struct {
+	*bytes.Buffer
+	md               *meta.Data
+	appSlug          string
+	apiVersion       string
+	fileName         string // the name of the generated file, without the .dart extension
+	typs             *typeRegistry
+	generatorVersion dartGenVersion
+
+	seenHeaderResponse bool // true if we've seen a header used in a response object
+	hasAuth            bool // true if we've seen an authentication handler
+	authIsComplexType  bool // true if the auth type is a complex type
+
+	// inlined tracks the type arguments of the non-struct declarations
+	// currently being inlined. Dart's type aliases can't be recursive,
+	// so declarations are inlined instead.
+	inlined map[uint32][]*schema.Type
+
+	// topLevel are the classes generated for anonymous structs
+	// used directly as API request or response types.
+	topLevel []*dartNestedClass
+}
+
+// dartNestedClass is a class generated for an anonymous struct type.
+type dartNestedClass struct {
+	name       string
+	typeParams []string // the type parameters of the enclosing class, if any
+	st         *schema.Struct
+}
+
+func (d *dart) Version() int {
+	return int(d.generatorVersion)
+}
+
+func (d *dart) Generate(p clientgentypes.GenerateParams) (err error) {
+	defer d.handleBailout(&err)
+
+	d.Buffer = p.Buf
+	d.md = p.Meta
+	d.appSlug = p.AppSlug
+	d.apiVersion = p.APIVersion
+	d.typs = getNamedTypes(p.Meta, p.Services)
+	d.inlined = make(map[uint32][]*schema.Type)
+
+	if d.md.AuthHandler != nil {
+		d.hasAuth = true
+		d.authIsComplexType = d.md.AuthHandler.Params.GetBuiltin() != schema.Builtin_STRING
+	}
+
+	fileName := d.fileName
+	if fileName == "" {
+		fileName = "client"
+	}
+
+	d.WriteString("// " + doNotEditHeader() + "\n\n")
+	d.WriteString(`// ignore_for_file: type=lint
+
+import 'dart:async';
+import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:http/http.dart' as http;
+import 'package:json_annotation/json_annotation.dart';
+
+`)
+	fmt.Fprintf(d, "part %s;\n", d.quote(fileName+".g.dart"))
+
+	d.writeClient(p.Services)
+
+	seenNs := make(map[string]bool)
+	for _, svc := range p.Meta.Svcs {
+		d.writeTypes(svc.Name)
+		seenNs[svc.Name] = true
+
+		if hasPublicRPC(svc) && p.Services.Has(svc.Name) {
+			if err := d.writeService(svc, p.Tags); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ns := range d.typs.Namespaces() {
+		if !seenNs[ns] {
+			d.writeTypes(ns)
+		}
+	}
+
+	if err := d.writeBaseClient(); err != nil {
+		return err
+	}
+	d.writeConverters()
+	d.writeErrorTypes()
+	return nil
+}
+
+func (d *dart) writeClient(set clientgentypes.ServiceSet) {
+	w := d.newIdentWriter(0)
+	w.WriteStringf(`
+/// Environments contains the base URLs for calling the %[1]s Encore application's API.
+class Environments {
+  Environments._();
+
+  /// The base URL of the locally running application.
+  static const local = 'http://localhost:4000';
+
+  /// Returns the base URL for calling the cloud environment with the given name.
+  static String environment(String name) => 'https://$name-%[1]s.encr.app';
+
+  /// Returns the base URL for calling the preview environment with the given PR number.
+  static String previewEnv(int pr) => environment('pr$pr');
+
+  /// The version of the API the client was generated for.
+  static const apiVersion = '%[2]s';
+}
+
+/// Client is an API client for the %[1]s Encore application.
+class Client {
+  /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+  ///
+  /// The [baseUrl] is the base URL of the application; see [Environments] for options.
+  Client(String baseUrl, {ClientOptions options = const ClientOptions()})
+      : this._(_BaseClient(baseUrl, options));
+`, d.appSlug, d.apiVersion)
+
+	var svcs []string
+	for _, svc := range d.md.Svcs {
+		if hasPublicRPC(svc) && set.Has(svc.Name) {
+			svcs = append(svcs, svc.Name)
+		}
+	}
+
+	{
+		w := w.Indent()
+		w.WriteString("\n")
+		if len(svcs) == 0 {
+			w.WriteString("Client._(_BaseClient baseClient);\n")
+		} else {
+			w.WriteString("Client._(_BaseClient baseClient)\n")
+			for i, svc := range svcs {
+				sep := ","
+				if i == len(svcs)-1 {
+					sep = ";"
+				}
+				prefix := "    : "
+				if i > 0 {
+					prefix = "      "
+				}
+				w.WriteStringf("%s%s = _%sClient(baseClient)%s\n", prefix, d.memberName(svc), d.serviceName(svc), sep)
+			}
+		}
+		if len(svcs) > 0 {
+			w.WriteString("\n")
+		}
+		for _, svc := range svcs {
+			w.WriteStringf("final %sClient %s;\n", d.serviceName(svc), d.memberName(svc))
+		}
+	}
+	w.WriteString(`}
+
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+class ClientOptions {
+  const ClientOptions({
+    this.httpClient,
+    this.headers = const {},
+    this.verifyAPIVersion = false,
+`)
+	if d.hasAuth {
+		w.WriteString("    this.auth,\n    this.authGenerator,\n")
+	}
+	w.WriteString(`  });
+
+  /// The HTTP client used to make the API requests. By default a new http.Client is created,
+  /// however you can provide your own to configure timeouts or proxies, or to run custom
+  /// code on each API request made or response received.
+  final http.Client? httpClient;
+
+  /// Headers which are added to each API request.
+  final Map<String, String> headers;
+
+  /// Whether to send [Environments.apiVersion] with each API request, so that the API
+  /// rejects the requests if the client is out of date. Such requests fail with an
+  /// [APIException] whose [APIException.isClientOutOfDate] is true.
+  final bool verifyAPIVersion;
+`)
+
+	if d.hasAuth {
+		authType := strings.TrimSuffix(d.typ(d.md.AuthHandler.Params, "AuthData", nil, nil), "?")
+		if d.authIsComplexType {
+			w.WriteString(`
+  /// The authentication data to be used for each API request.
+`)
+		} else {
+			w.WriteString(`
+  /// The auth token to be used for each API request,
+  /// sent as a bearer token in the Authorization header.
+`)
+		}
+		w.WriteStringf(`  final %[1]s? auth;
+
+  /// A function which is called before each API request and returns the authentication data to use,
+  /// for when the credentials can change during the lifetime of the client. It takes precedence over [auth].
+  final FutureOr<%[1]s?> Function()? authGenerator;
+`, authType)
+	}
+	w.WriteString("}\n")
+}
+
+// writeTypes writes the classes for the struct declarations of the given namespace.
+// Other declarations are inlined where they're used.
+func (d *dart) writeTypes(ns string) {
+	decls := d.typs.Decls(ns)
+	sort.Slice(decls, func(i, j int) bool {
+		return decls[i].Name < decls[j].Name
+	})
+	for _, decl := range decls {
+		st := decl.Type.GetStruct()
+		if st == nil {
+			continue
+		}
+
+		typeParams := make([]string, len(decl.TypeParams))
+		for i, p := range decl.TypeParams {
+			typeParams[i] = d.typeParamName(p.Name)
+		}
+
+		d.writeClass(d.newIdentWriter(0), d.declName(decl), typeParams, decl.Doc, st)
+	}
+}
+
+// writeClass writes a json_serializable class for the given struct,
+// followed by classes for any anonymous structs within it.
+func (d *dart) writeClass(w *indentWriter, name string, typeParams []string, doc string, st *schema.Struct) {
+	var nested []*dartNestedClass
+
+	type field struct {
+		name, typ, wireName string
+		doc                 string
+		optional            bool
+	}
+	var fields []field
+	for _, f := range st.Fields {
+		if encoding.IgnoreField(f) {
+			continue
+		}
+		prop := d.memberName(f.Name)
+		typ := d.typ(f.Typ, name+d.propName(f.Name), typeParams, &nested)
+		if f.Optional && !strings.HasSuffix(typ, "?") {
+			typ += "?"
+		}
+		fields = append(fields, field{
+			name:     prop,
+			typ:      typ,
+			wireName: d.fieldWireName(f),
+			doc:      f.Doc,
+			optional: f.Optional,
+		})
+	}
+
+	declName := name
+	if len(typeParams) > 0 {
+		declName += "<" + strings.Join(typeParams, ", ") + ">"
+	}
+
+	w.WriteString("\n")
+	d.writeDoc(w, doc)
+	if len(typeParams) > 0 {
+		w.WriteString("@_genericModel\n")
+	} else {
+		w.WriteString("@_model\n")
+	}
+	w.WriteString("class " + declName + " {\n")
+	{
+		w := w.Indent()
+
+		// The constructor
+		if len(fields) == 0 {
+			w.WriteString(name + "();\n")
+		} else {
+			w.WriteString(name + "({\n")
+			for _, f := range fields {
+				if strings.HasSuffix(f.typ, "?") {
+					w.Indent().WriteStringf("this.%s,\n", f.name)
+				} else {
+					w.Indent().WriteStringf("required this.%s,\n", f.name)
+				}
+			}
+			w.WriteString("});\n")
+		}
+
+		// The JSON decoding factory
+		w.WriteString("\n")
+		if len(typeParams) == 0 {
+			w.WriteStringf("factory %[1]s.fromJson(Map<String, dynamic> json) => _$%[1]sFromJson(json);\n", name)
+		} else {
+			params := []string{"Map<String, dynamic> json"}
+			args := []string{"json"}
+			for _, p := range typeParams {
+				params = append(params, fmt.Sprintf("%s Function(Object? json) fromJson%s", p, p))
+				args = append(args, "fromJson"+p)
+			}
+			w.WriteStringf("factory %s.fromJson(%s) =>\n", name, strings.Join(params, ", "))
+			w.Indent().Indent().WriteStringf("_$%sFromJson(%s);\n", name, strings.Join(args, ", "))
+		}
+
+		// The fields
+		for _, f := range fields {
+			w.WriteString("\n")
+			d.writeDoc(w, f.doc)
+			if f.optional {
+				w.WriteStringf("@JsonKey(name: %s, includeIfNull: false)\n", d.quote(f.wireName))
+			} else {
+				w.WriteStringf("@JsonKey(name: %s)\n", d.quote(f.wireName))
+			}
+			w.WriteStringf("%s %s;\n", f.typ, f.name)
+		}
+
+		// The JSON encoding method
+		w.WriteString("\n")
+		if len(typeParams) == 0 {
+			w.WriteStringf("Map<String, dynamic> toJson() => _$%sToJson(this);\n", name)
+		} else {
+			var params []string
+			args := []string{"this"}
+			for _, p := range typeParams {
+				params = append(params, fmt.Sprintf("Object? Function(%s value) toJson%s", p, p))
+				args = append(args, "toJson"+p)
+			}
+			w.WriteStringf("Map<String, dynamic> toJson(%s) =>\n", strings.Join(params, ", "))
+			w.Indent().Indent().WriteStringf("_$%sToJson(%s);\n", name, strings.Join(args, ", "))
+		}
+	}
+	w.WriteString("}\n")
+
+	for _, n := range nested {
+		d.writeClass(w, n.name, n.typeParams, "", n.st)
+	}
+}
+
+func (d *dart) writeService(svc *meta.Service, tags clientgentypes.TagSet) error {
+	name := d.serviceName(svc.Name)
+	d.topLevel = nil
+
+	var rpcs []*meta.RPC
+	for _, rpc := range svc.Rpcs {
+		if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) {
+			continue
+		}
+
+		// streaming endpoints not supported yet
+		if rpc.StreamingRequest || rpc.StreamingResponse {
+			continue
+		}
+		rpcs = append(rpcs, rpc)
+	}
+
+	// The interface
+	w := d.newIdentWriter(0)
+	w.WriteStringf(`
+/// %[1]sClient provides access to call the public and authenticated APIs of the %[2]s service.
+/// It allows you to create mock implementations of the client during tests.
+abstract interface class %[1]sClient {
+`, name, svc.Name)
+	for i, rpc := range rpcs {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		d.writeDoc(w.Indent(), rpc.GetDoc())
+		w.Indent().WriteString(d.rpcSignature(svc, rpc) + ";\n")
+	}
+	w.WriteString("}\n")
+
+	// The implementation
+	w.WriteStringf(`
+class _%[1]sClient implements %[1]sClient {
+  _%[1]sClient(this._baseClient);
+
+  final _BaseClient _baseClient;
+`, name)
+
+	for _, rpc := range rpcs {
+		w := w.Indent()
+		w.WriteString("\n@override\n")
+		w.WriteString(d.rpcSignature(svc, rpc) + " async {\n")
+		if err := d.rpcCallSite(svc, w.Indent(), rpc); err != nil {
+			return errors.Wrapf(err, "unable to write RPC call site for %s.%s", rpc.ServiceName, rpc.Name)
+		}
+		w.WriteString("}\n")
+	}
+	w.WriteString("}\n")
+
+	for i := 0; i < len(d.topLevel); i++ {
+		n := d.topLevel[i]
+		d.writeClass(d.newIdentWriter(0), n.name, nil, "", n.st)
+	}
+	return nil
+}
+
+// rpcSignature returns the signature of the method calling the given RPC.
+func (d *dart) rpcSignature(svc *meta.Service, rpc *meta.RPC) string {
+	prefix := d.serviceName(svc.Name) + d.propName(rpc.Name)
+
+	var b strings.Builder
+	switch {
+	case rpc.Proto == meta.RPC_RAW:
+		b.WriteString("Future<http.StreamedResponse>")
+	case rpc.ResponseSchema != nil:
+		b.WriteString("Future<" + d.typ(rpc.ResponseSchema, prefix+"Response", nil, nil) + ">")
+	default:
+		b.WriteString("Future<void>")
+	}
+	b.WriteString(" " + d.memberName(rpc.Name) + "(")
+
+	var params []string
+	for _, s := range rpc.Path.Segments {
+		if s.Type == meta.PathSegment_LITERAL {
+			continue
+		}
+		typ := d.pathSegmentType(s.ValueType)
+		if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
+			typ = "List<String>"
+		}
+		params = append(params, typ+" "+d.nonReservedId(s.Value))
+	}
+
+	if rpc.Proto == meta.RPC_RAW {
+		method := "required String method"
+		if len(rpc.HttpMethods) == 1 && rpc.HttpMethods[0] != "*" {
+			// Default to the only method the endpoint accepts
+			method = "String method = " + d.quote(rpc.HttpMethods[0])
+		}
+		params = append(params, fmt.Sprintf(
+			"{%s, Map<String, String>? headers, Map<String, Object?>? query, Stream<List<int>>? body}",
+			method,
+		))
+	} else if rpc.RequestSchema != nil {
+		params = append(params, d.typ(rpc.RequestSchema, prefix+"Request", nil, nil)+" params")
+	}
+	b.WriteString(strings.Join(params, ", ") + ")")
+	return b.String()
+}
+
+func (d *dart) pathSegmentType(typ meta.PathSegment_ParamType) string {
+	switch typ {
+	case meta.PathSegment_STRING, meta.PathSegment_UUID:
+		return "String"
+	case meta.PathSegment_BOOL:
+		return "bool"
+	case meta.PathSegment_INT8, meta.PathSegment_INT16, meta.PathSegment_INT32, meta.PathSegment_INT64, meta.PathSegment_INT,
+		meta.PathSegment_UINT8, meta.PathSegment_UINT16, meta.PathSegment_UINT32, meta.PathSegment_UINT64, meta.PathSegment_UINT:
+		return "int"
+	default:
+		d.errorf("unhandled PathSegment type %s", typ)
+		return ""
+	}
+}
+
+// rpcPath returns a Dart expression for the path of the given RPC.
+func (d *dart) rpcPath(rpc *meta.RPC) string {
+	var path strings.Builder
+	for _, s := range rpc.Path.Segments {
+		path.WriteByte('/')
+		if s.Type == meta.PathSegment_LITERAL {
+			path.WriteString(d.escape(s.Value))
+			continue
+		}
+
+		id := d.nonReservedId(s.Value)
+		switch {
+		case s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK:
+			path.WriteString("${" + id + ".map(Uri.encodeComponent).join('/')}")
+		case s.ValueType == meta.PathSegment_STRING || s.ValueType == meta.PathSegment_UUID:
+			path.WriteString("${Uri.encodeComponent(" + id + ")}")
+		default:
+			path.WriteString("${" + id + "}")
+		}
+	}
+	return "'" + path.String() + "'"
+}
+
+func (d *dart) rpcCallSite(svc *meta.Service, w *indentWriter, rpc *meta.RPC) error {
+	// Raw end points just pass through the request
+	// and need no further code generation
+	if rpc.Proto == meta.RPC_RAW {
+		w.WriteStringf(
+			"return _baseClient.sendRaw(method, %s, headers: headers, query: query, body: body);\n",
+			d.rpcPath(rpc),
+		)
+		return nil
+	}
+
+	// Work out how we're going to encode and call this RPC
+	rpcEncoding, err := encoding.DescribeRPC(d.md, rpc, nil)
+	if err != nil {
+		return errors.Wrapf(err, "rpc %s", rpc.Name)
+	}
+
+	var args []string
+	if rpc.RequestSchema != nil {
+		reqEnc := rpcEncoding.DefaultRequestEncoding
+		formParams, err := clientgentypes.FormBodyParameters(rpc, reqEnc)
+		if err != nil {
+			return err
+		}
+
+		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 || len(formParams) > 0 {
+			w.WriteString("// Convert our params into the objects we need for the request\n")
+		}
+
+		// Generate the headers
+		if len(reqEnc.HeaderParameters) > 0 {
+			args = append(args, "headers: headers")
+			w.WriteString("final headers = ")
+			d.paramsMap(w, reqEnc.HeaderParameters)
+			w.WriteString(";\n\n")
+		}
+
+		// Generate the query string
+		if len(reqEnc.QueryParameters) > 0 {
+			args = append(args, "query: query")
+			w.WriteString("final query = ")
+			d.paramsMap(w, reqEnc.QueryParameters)
+			w.WriteString(";\n\n")
+		}
+
+		// Generate the body
+		switch {
+		case len(formParams) > 0:
+			args = append(args, "form: form")
+			w.WriteString("final form = ")
+			d.paramsMap(w, formParams)
+			w.WriteString(";\n\n")
+
+		case len(reqEnc.BodyParameters) > 0:
+			encoded := d.encode(rpc.RequestSchema, "params")
+			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
+				// In the simple case we can just encode the params as the body directly
+				args = append(args, "body: "+encoded)
+			} else {
+				// Else we need to pick the fields which we want encoded within the body
+				args = append(args, "body: body")
+				w.WriteString("// Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)\n")
+				w.WriteString("final encoded = " + encoded + ";\n")
+				w.WriteString("final body = {\n")
+				{
+					w := w.Indent()
+					keys := make([]string, len(reqEnc.BodyParameters))
+					for i, p := range reqEnc.BodyParameters {
+						keys[i] = d.quote(p.WireFormat)
+					}
+					w.WriteStringf("for (final key in const [%s])\n", strings.Join(keys, ", "))
+					w.Indent().WriteString("if (encoded.containsKey(key)) key: encoded[key],\n")
+				}
+				w.WriteString("};\n\n")
+			}
+		}
+	}
+
+	call := fmt.Sprintf("_baseClient.callAPI(%s, %s", d.quote(rpcEncoding.DefaultMethod), d.rpcPath(rpc))
+	if len(args) > 0 {
+		call += ", " + strings.Join(args, ", ")
+	}
+	call += ")"
+
+	// If there's no response schema, there's nothing more to do
+	if rpc.ResponseSchema == nil {
+		w.WriteString("// Now make the actual call to the API\nawait " + call + ";\n")
+		return nil
+	}
+	w.WriteString("// Now make the actual call to the API\nfinal resp = await " + call + ";\n")
+
+	respEnc := rpcEncoding.ResponseEncoding
+
+	// If we don't need to do anything with the body, we can just decode the response
+	if len(respEnc.HeaderParameters) == 0 {
+		w.WriteString("return " + d.decode(rpc.ResponseSchema, "_BaseClient.readJson(resp)") + ";\n")
+		return nil
+	}
+
+	// Otherwise, we need to add the header fields to the JSON before decoding it
+	w.WriteString("\n// Populate the return object from the JSON body and received headers\n")
+	w.WriteString("final json = _BaseClient.readJson(resp) as Map<String, dynamic>;\n")
+	for _, field := range respEnc.HeaderParameters {
+		d.seenHeaderResponse = true
+		typ := field.Type
+		if ptr := typ.GetPointer(); ptr != nil {
+			typ = ptr.Base
+		}
+		w.WriteStringf(
+			"json[%s] = _BaseClient.mustBeSet(resp, %s, (v) => %s);\n",
+			d.quote(d.jsonKey(rpc.ResponseSchema, field.SrcName)), d.quote(field.WireFormat), d.headerToJSON(typ.GetBuiltin(), "v"),
+		)
+	}
+	w.WriteString("return " + d.fromJSON(rpc.ResponseSchema, "json") + ";\n")
+	return nil
+}
+
+// paramsMap writes a map literal mapping the wire names of the given parameters to their values.
+func (d *dart) paramsMap(w *indentWriter, params []*encoding.ParameterEncoding) {
+	sorted := make([]*encoding.ParameterEncoding, len(params))
+	copy(sorted, params)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].WireFormat < sorted[j].WireFormat
+	})
+
+	w.WriteString("<String, Object?>{\n")
+	{
+		w := w.Indent()
+		for _, p := range sorted {
+			w.WriteStringf("%s: params.%s,\n", d.quote(p.WireFormat), d.memberName(p.SrcName))
+		}
+	}
+	w.WriteString("}")
+}
+
+// jsonKey returns the JSON key of the field with the given name in the struct of typ.
+func (d *dart) jsonKey(typ *schema.Type, srcName string) string {
+	for {
+		switch t := typ.Typ.(type) {
+		case *schema.Type_Pointer:
+			typ = t.Pointer.Base
+			continue
+		case *schema.Type_Named:
+			typ = d.md.Decls[t.Named.Id].Type
+			continue
+		case *schema.Type_Struct:
+			for _, f := range t.Struct.Fields {
+				if f.Name == srcName {
+					return d.fieldWireName(f)
+				}
+			}
+		}
+		d.errorf("unable to find field %s", srcName)
+		return ""
+	}
+}
+
+// headerToJSON returns an expression converting the header value val
+// to the JSON representation of the given builtin.
+func (d *dart) headerToJSON(typ schema.Builtin, val string) string {
+	switch typ {
+	case schema.Builtin_STRING, schema.Builtin_USER_ID, schema.Builtin_UUID, schema.Builtin_TIME, schema.Builtin_BYTES:
+		return val
+	case schema.Builtin_BOOL:
+		return fmt.Sprintf("bool.parse(%s)", val)
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64,
+		schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64:
+		return fmt.Sprintf("int.parse(%s)", val)
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return fmt.Sprintf("double.parse(%s)", val)
+	case schema.Builtin_ANY, schema.Builtin_JSON:
+		return fmt.Sprintf("jsonDecode(%s)", val)
+	default:
+		d.errorf("unknown builtin type %v", typ)
+		return ""
+	}
+}
+
+func (d *dart) builtinType(typ schema.Builtin) string {
+	switch typ {
+	case schema.Builtin_ANY, schema.Builtin_JSON:
+		return "Object?"
+	case schema.Builtin_BOOL:
+		return "bool"
+	case schema.Builtin_INT, schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32, schema.Builtin_INT64,
+		schema.Builtin_UINT, schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32, schema.Builtin_UINT64:
+		return "int"
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return "double"
+	case schema.Builtin_STRING, schema.Builtin_USER_ID, schema.Builtin_UUID:
+		return "String"
+	case schema.Builtin_BYTES:
+		return "Uint8List"
+	case schema.Builtin_TIME:
+		return "DateTime"
+	default:
+		d.errorf("unknown builtin type %v", typ)
+		return ""
+	}
+}
+
+// typ returns the Dart type for the given schema type.
+//
+// Anonymous structs are generated as classes named after hint, which are added to nested
+// with the type parameters of the enclosing class. If nested is nil they're generated
+// after the service instead.
+func (d *dart) typ(typ *schema.Type, hint string, typeParams []string, nested *[]*dartNestedClass) string {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := d.md.Decls[t.Named.Id]
+		if decl.Type.GetStruct() == nil {
+			if _, ok := d.inlined[decl.Id]; ok {
+				return "Object?"
+			}
+			d.inlined[decl.Id] = t.Named.TypeArguments
+			defer delete(d.inlined, decl.Id)
+			return d.typ(decl.Type, hint, typeParams, nested)
+		}
+
+		name := d.declName(decl)
+		if len(t.Named.TypeArguments) > 0 {
+			args := make([]string, len(t.Named.TypeArguments))
+			for i, arg := range t.Named.TypeArguments {
+				args[i] = d.typ(arg, fmt.Sprintf("%sArg%d", hint, i), typeParams, nested)
+			}
+			name += "<" + strings.Join(args, ", ") + ">"
+		}
+		return name
+
+	case *schema.Type_List:
+		return "List<" + d.typ(t.List.Elem, hint+"Elem", typeParams, nested) + ">"
+
+	case *schema.Type_Map:
+		key := strings.TrimSuffix(d.typ(t.Map.Key, hint+"Key", typeParams, nested), "?")
+		return "Map<" + key + ", " + d.typ(t.Map.Value, hint+"Value", typeParams, nested) + ">"
+
+	case *schema.Type_Builtin:
+		return d.builtinType(t.Builtin)
+
+	case *schema.Type_Pointer:
+		base := d.typ(t.Pointer.Base, hint, typeParams, nested)
+		if !strings.HasSuffix(base, "?") {
+			base += "?"
+		}
+		return base
+
+	case *schema.Type_Literal:
+		switch t.Literal.Value.(type) {
+		case *schema.Literal_Str:
+			return "String"
+		case *schema.Literal_Boolean:
+			return "bool"
+		case *schema.Literal_Int:
+			return "int"
+		case *schema.Literal_Float:
+			return "double"
+		case *schema.Literal_Null:
+			return "Object?"
+		default:
+			d.errorf("unknown literal type %T", t.Literal.Value)
+		}
+
+	case *schema.Type_Union:
+		// There's no good way of representing unions in Dart,
+		// so leave it to the caller to handle the decoded JSON value.
+		return "Object?"
+
+	case *schema.Type_Struct:
+		name := hint
+		if len(typeParams) > 0 {
+			name += "<" + strings.Join(typeParams, ", ") + ">"
+		}
+		if nested != nil {
+			*nested = append(*nested, &dartNestedClass{name: hint, typeParams: typeParams, st: t.Struct})
+		} else if !slices.ContainsFunc(d.topLevel, func(c *dartNestedClass) bool { return c.name == hint }) {
+			d.topLevel = append(d.topLevel, &dartNestedClass{name: hint, st: t.Struct})
+		}
+		return name
+
+	case *schema.Type_TypeParameter:
+		if args, ok := d.inlined[t.TypeParameter.DeclId]; ok && int(t.TypeParameter.ParamIdx) < len(args) {
+			return d.typ(args[t.TypeParameter.ParamIdx], hint, typeParams, nested)
+		}
+		decl := d.md.Decls[t.TypeParameter.DeclId]
+		return d.typeParamName(decl.TypeParams[t.TypeParameter.ParamIdx].Name)
+
+	case *schema.Type_Config:
+		// Config type is transparent
+		return d.typ(t.Config.Elem, hint, typeParams, nested)
+	}
+
+	d.errorf("unknown type %+v", reflect.TypeOf(typ.Typ))
+	return ""
+}
+
+// decode returns an expression decoding the JSON value expr to the given type.
+// It's used for the request and response types of the API methods; the fields
+// of the generated classes are decoded by the code generated by json_serializable.
+func (d *dart) decode(typ *schema.Type, expr string) string {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := d.md.Decls[t.Named.Id]
+		if decl.Type.GetStruct() == nil {
+			if _, ok := d.inlined[decl.Id]; ok {
+				return expr
+			}
+			d.inlined[decl.Id] = t.Named.TypeArguments
+			defer delete(d.inlined, decl.Id)
+			return d.decode(decl.Type, expr)
+		}
+
+		return d.fromJSON(typ, expr+" as Map<String, dynamic>")
+
+	case *schema.Type_List:
+		return fmt.Sprintf("(%s as List<dynamic>).map((v) => %s).toList()", expr, d.decode(t.List.Elem, "v"))
+
+	case *schema.Type_Map:
+		key := "k"
+		switch d.typ(t.Map.Key, "", nil, nil) {
+		case "int":
+			key = "int.parse(k)"
+		case "double":
+			key = "double.parse(k)"
+		case "bool":
+			key = "bool.parse(k)"
+		case "DateTime":
+			key = "DateTime.parse(k)"
+		}
+		return fmt.Sprintf("(%s as Map<String, dynamic>).map((k, v) => MapEntry(%s, %s))", expr, key, d.decode(t.Map.Value, "v"))
+
+	case *schema.Type_Builtin:
+		switch t.Builtin {
+		case schema.Builtin_ANY, schema.Builtin_JSON:
+			return expr
+		case schema.Builtin_TIME:
+			return fmt.Sprintf("DateTime.parse(%s as String)", expr)
+		case schema.Builtin_BYTES:
+			return fmt.Sprintf("base64Decode(%s as String)", expr)
+		}
+		switch typ := d.builtinType(t.Builtin); typ {
+		case "int", "double":
+			return fmt.Sprintf("(%s as num).to%s()", expr, strings.ToUpper(typ[:1])+typ[1:])
+		default:
+			return expr + " as " + typ
+		}
+
+	case *schema.Type_Pointer:
+		return fmt.Sprintf("%s == null ? null : %s", expr, d.decode(t.Pointer.Base, expr))
+
+	case *schema.Type_Literal:
+		if typ := d.typ(typ, "", nil, nil); typ != "Object?" {
+			return expr + " as " + typ
+		}
+		return expr
+
+	case *schema.Type_Union:
+		return expr
+
+	case *schema.Type_Struct:
+		return d.typ(typ, "", nil, nil) + ".fromJson(" + expr + " as Map<String, dynamic>)"
+
+	case *schema.Type_TypeParameter:
+		if args, ok := d.inlined[t.TypeParameter.DeclId]; ok && int(t.TypeParameter.ParamIdx) < len(args) {
+			return d.decode(args[t.TypeParameter.ParamIdx], expr)
+		}
+		d.errorf("unbound type parameter")
+
+	case *schema.Type_Config:
+		return d.decode(t.Config.Elem, expr)
+	}
+
+	d.errorf("unknown type %+v", reflect.TypeOf(typ.Typ))
+	return ""
+}
+
+// fromJSON returns an expression decoding the JSON object expr, a Map<String, dynamic>,
+// to the given struct type.
+func (d *dart) fromJSON(typ *schema.Type, expr string) string {
+	named := typ.GetNamed()
+	if named == nil || d.md.Decls[named.Id].Type.GetStruct() == nil {
+		return d.decode(typ, expr)
+	}
+
+	args := []string{expr}
+	for _, arg := range named.TypeArguments {
+		args = append(args, "(v) => "+d.decode(arg, "v"))
+	}
+	return d.typ(typ, "", nil, nil) + ".fromJson(" + strings.Join(args, ", ") + ")"
+}
+
+// encode returns an expression encoding the value expr of the given type to JSON.
+// Like decode, it's only used for the request types of the API methods.
+func (d *dart) encode(typ *schema.Type, expr string) string {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := d.md.Decls[t.Named.Id]
+		if decl.Type.GetStruct() == nil {
+			if _, ok := d.inlined[decl.Id]; ok {
+				return expr
+			}
+			d.inlined[decl.Id] = t.Named.TypeArguments
+			defer delete(d.inlined, decl.Id)
+			return d.encode(decl.Type, expr)
+		}
+
+		args := make([]string, len(t.Named.TypeArguments))
+		for i, arg := range t.Named.TypeArguments {
+			args[i] = "(v) => " + d.encode(arg, "v")
+		}
+		return expr + ".toJson(" + strings.Join(args, ", ") + ")"
+
+	case *schema.Type_List:
+		if elem := d.encode(t.List.Elem, "v"); elem != "v" {
+			return fmt.Sprintf("%s.map((v) => %s).toList()", expr, elem)
+		}
+		return expr
+
+	case *schema.Type_Map:
+		key := "k"
+		if d.typ(t.Map.Key, "", nil, nil) != "String" {
+			key = "k.toString()"
+		}
+		return fmt.Sprintf("%s.map((k, v) => MapEntry(%s, %s))", expr, key, d.encode(t.Map.Value, "v"))
+
+	case *schema.Type_Builtin:
+		switch t.Builtin {
+		case schema.Builtin_TIME:
+			return expr + ".toUtc().toIso8601String()"
+		case schema.Builtin_BYTES:
+			return "base64Encode(" + expr + ")"
+		}
+		return expr
+
+	case *schema.Type_Pointer:
+		if base := d.encode(t.Pointer.Base, expr); base != expr {
+			return fmt.Sprintf("%s == null ? null : %s", expr, base)
+		}
+		return expr
+
+	case *schema.Type_Literal, *schema.Type_Union:
+		return expr
+
+	case *schema.Type_Struct:
+		return expr + ".toJson()"
+
+	case *schema.Type_TypeParameter:
+		if args, ok := d.inlined[t.TypeParameter.DeclId]; ok && int(t.TypeParameter.ParamIdx) < len(args) {
+			return d.encode(args[t.TypeParameter.ParamIdx], expr)
+		}
+		d.errorf("unbound type parameter")
+
+	case *schema.Type_Config:
+		return d.encode(t.Config.Elem, expr)
+	}
+
+	d.errorf("unknown type %+v", reflect.TypeOf(typ.Typ))
+	return ""
+}
+
+func (d *dart) writeBaseClient() error {
+	userAgent := fmt.Sprintf("%s-Generated-Dart-Client (Encore/%s)", d.appSlug, version.Version)
+
+	w := d.newIdentWriter(0)
+	w.WriteString(`
+/// _BaseClient holds all the information we need to make requests to an Encore application.
+class _BaseClient {
+  _BaseClient(String baseUrl, ClientOptions options)
+      : _baseUrl = baseUrl.endsWith('/') ? baseUrl.substring(0, baseUrl.length - 1) : baseUrl,
+        _httpClient = options.httpClient ?? http.Client(),
+        _options = options;
+
+  static const _userAgent = ` + d.quote(userAgent) + `;
+
+  final String _baseUrl;
+  final http.Client _httpClient;
+  final ClientOptions _options;
+
+  // callAPI is used by each generated API method to actually make the request.
+  // It throws an APIException if the API returns an error.
+  Future<http.Response> callAPI(
+    String method,
+    String path, {
+    Object? body,
+    Map<String, Object?>? form,
+    Map<String, Object?>? headers,
+    Map<String, Object?>? query,
+  }) async {
+    final request = await _newRequest((url) => http.Request(method, url), path, headers, query);
+    if (form != null) {
+      request.headers['Content-Type'] = 'application/x-www-form-urlencoded';
+      request.body = _encodeQuery(form);
+    } else if (body != null) {
+      request.headers['Content-Type'] = 'application/json';
+      request.body = jsonEncode(body);
+    }
+
+    final response = await http.Response.fromStream(await _httpClient.send(request));
+    if (response.statusCode < 200 || response.statusCode >= 300) {
+      throw _readError(response);
+    }
+    return response;
+  }
+
+  // sendRaw sends a request to a raw API endpoint, returning the response as is.
+  Future<http.StreamedResponse> sendRaw(
+    String method,
+    String path, {
+    Map<String, String>? headers,
+    Map<String, Object?>? query,
+    Stream<List<int>>? body,
+  }) async {
+    final request = await _newRequest((url) => http.StreamedRequest(method, url), path, headers, query);
+    final response = _httpClient.send(request);
+    if (body != null) {
+      body.listen(request.sink.add, onError: request.sink.addError, onDone: request.sink.close);
+    } else {
+      request.sink.close();
+    }
+    return response;
+  }
+
+  // _newRequest creates a request for the given path, adding the headers,
+  // query string and authorization data as required.
+  Future<R> _newRequest<R extends http.BaseRequest>(
+    R Function(Uri url) create,
+    String path,
+    Map<String, Object?>? headers,
+    Map<String, Object?>? query,
+  ) async {
+    headers = {...?headers};
+    query = {...?query};
+`)
+	if d.hasAuth {
+		w.WriteString("    await _addAuthData(headers, query);\n")
+	}
+	w.WriteString(`
+    var url = Uri.parse(_baseUrl + path);
+    if (query.isNotEmpty) {
+      url = url.replace(query: _encodeQuery(query));
+    }
+
+    final request = create(url);
+    request.headers['User-Agent'] = _userAgent;
+    if (_options.verifyAPIVersion) {
+      request.headers['X-Encore-API-Version'] = Environments.apiVersion;
+    }
+    request.headers.addAll(_options.headers);
+    headers.forEach((key, value) {
+      final values = _values(value);
+      if (values.isNotEmpty) {
+        request.headers[key] = values.join(', ');
+      }
+    });
+    return request;
+  }
+`)
+
+	if d.hasAuth {
+		w.WriteString(`
+  // _addAuthData adds the authentication data to the request, if there is any.
+  Future<void> _addAuthData(Map<String, Object?> headers, Map<String, Object?> query) async {
+    final authGenerator = _options.authGenerator;
+    final authData = authGenerator != null ? await authGenerator() : _options.auth;
+    if (authData == null) {
+      return;
+    }
+
+`)
+		w := d.newIdentWriter(2)
+		if d.authIsComplexType {
+			authData, err := encoding.DescribeAuth(d.md, d.md.AuthHandler.Params, nil)
+			if err != nil {
+				return errors.Wrap(err, "unable to describe auth data")
+			}
+
+			for _, field := range authData.HeaderParameters {
+				w.WriteStringf("headers[%s] = authData.%s;\n", d.quote(field.WireFormat), d.memberName(field.SrcName))
+			}
+			for _, field := range authData.QueryParameters {
+				w.WriteStringf("query[%s] = authData.%s;\n", d.quote(field.WireFormat), d.memberName(field.SrcName))
+			}
+		} else {
+			w.WriteString("headers['Authorization'] = 'Bearer $authData';\n")
+		}
+		w.Dedent().WriteString("}\n")
+	}
+
+	w.WriteString(`
+  // readJson decodes the JSON body of the response.
+  static Object? readJson(http.Response response) => jsonDecode(utf8.decode(response.bodyBytes));
+`)
+
+	if d.seenHeaderResponse {
+		w.WriteString(`
+  // mustBeSet parses the value of a response header, throwing an APIException with the dataLoss code if it's missing.
+  static T mustBeSet<T>(http.Response response, String header, T Function(String value) parse) {
+    final value = response.headers[header.toLowerCase()];
+    if (value == null) {
+      throw APIException(response.statusCode, ErrCode.dataLoss, 'Header ` + "`$header`" + ` was unexpectedly missing');
+    }
+    return parse(value);
+  }
+`)
+	}
+
+	w.WriteString(`
+  // _readError reads the structured error from a failed API call.
+  static APIException _readError(http.Response response) {
+    final status = response.statusCode;
+    final body = utf8.decode(response.bodyBytes, allowMalformed: true);
+    try {
+      final error = jsonDecode(body);
+      if (error is Map<String, dynamic> && error['code'] is String && error['message'] is String) {
+        return APIException(status, ErrCode.parse(error['code'] as String), error['message'] as String, error['details']);
+      }
+    } on FormatException {
+      // Not a structured error, so fall back to the raw body below.
+    }
+    return APIException(status, ErrCode.unknown, 'request failed: status $status: $body');
+  }
+}
+
+// _values formats the values of a header or query string parameter,
+// which has one value per element if it's a list.
+List<String> _values(Object? value) {
+  if (value == null) {
+    return const [];
+  } else if (value is Iterable<Object?> && value is! Uint8List) {
+    return [for (final v in value) if (v != null) _format(v)];
+  }
+  return [_format(value)];
+}
+
+// _format formats a single value.
+String _format(Object value) {
+  if (value is DateTime) {
+    return value.toUtc().toIso8601String();
+  } else if (value is Uint8List) {
+    return base64Encode(value);
+  } else if (value is String || value is num || value is bool) {
+    return value.toString();
+  }
+  return jsonEncode(value);
+}
+
+// _encodeQuery encodes the parameters as a query string.
+String _encodeQuery(Map<String, Object?> query) => [
+      for (final entry in query.entries)
+        for (final value in _values(entry.value))
+          '${Uri.encodeQueryComponent(entry.key)}=${Uri.encodeQueryComponent(value)}',
+    ].join('&');
+`)
+	return nil
+}
+
+// writeConverters writes the json_serializable annotations and converters used by the generated classes.
+func (d *dart) writeConverters() {
+	d.WriteString(`
+const _converters = <JsonConverter>[_DateTimeConverter(), _BytesConverter()];
+
+const _model = JsonSerializable(explicitToJson: true, converters: _converters);
+
+const _genericModel = JsonSerializable(explicitToJson: true, genericArgumentFactories: true, converters: _converters);
+
+// _DateTimeConverter encodes times in the RFC 3339 format expected by Encore.
+class _DateTimeConverter implements JsonConverter<DateTime, String> {
+  const _DateTimeConverter();
+
+  @override
+  DateTime fromJson(String json) => DateTime.parse(json);
+
+  @override
+  String toJson(DateTime object) => object.toUtc().toIso8601String();
+}
+
+// _BytesConverter encodes bytes as base64 strings.
+class _BytesConverter implements JsonConverter<Uint8List, String> {
+  const _BytesConverter();
+
+  @override
+  Uint8List fromJson(String json) => base64Decode(json);
+
+  @override
+  String toJson(Uint8List object) => base64Encode(object);
+}
+`)
+}
+
+func (d *dart) writeErrorTypes() {
+	w := d.newIdentWriter(0)
+	w.WriteString(`
+/// APIException represents a structured error as returned from an Encore application.
+class APIException implements Exception {
+  APIException(this.status, this.code, this.message, [this.details]);
+
+  /// The HTTP status code associated with the error.
+  final int status;
+
+  /// The Encore error code.
+  final ErrCode code;
+
+  /// The error message.
+  final String message;
+
+  /// The error details, if any.
+  final Object? details;
+
+  /// Whether the API rejected the request because the client was generated
+  /// for a different version of the API. Regenerate the client to fix it.
+  bool get isClientOutOfDate {
+    final details = this.details;
+    return code == ErrCode.failedPrecondition && details is Map && details['client_out_of_date'] == true;
+  }
+
+  @override
+  String toString() => 'APIException: ${code.value}: $message';
+}
+
+/// ErrCode is the error code of an APIException.
+enum ErrCode {
+`)
+	for i, e := range errorCodes {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		sep := ","
+		if i == len(errorCodes)-1 {
+			sep = ";"
+		}
+		d.writeDoc(w.Indent(), e.Comment)
+		w.Indent().WriteStringf("%s(%s)%s\n", d.memberName(e.Name), d.quote(idents.Convert(e.Name, idents.SnakeCase)), sep)
+	}
+	w.WriteString(`
+  const ErrCode(this.value);
+
+  /// The error code as sent by the Encore application.
+  final String value;
+
+  /// Returns the ErrCode with the given value, or [ErrCode.unknown] if there is none.
+  static ErrCode parse(String value) =>
+      ErrCode.values.firstWhere((c) => c.value == value, orElse: () => ErrCode.unknown);
+}
+`)
+}
+
+// writeDoc writes the given documentation as a doc comment.
+func (d *dart) writeDoc(w *indentWriter, doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(doc))
+	for scanner.Scan() {
+		w.WriteString(strings.TrimRight("/// "+scanner.Text(), " ") + "\n")
+	}
+}
+
+type dartBailout struct{ err error }
+
+func (d *dart) errorf(format string, args ...interface{}) {
+	panic(dartBailout{fmt.Errorf(format, args...)})
+}
+
+func (d *dart) handleBailout(dst *error) {
+	if err := recover(); err != nil {
+		if bail, ok := err.(dartBailout); ok {
+			*dst = bail.err
+		} else {
+			panic(err)
+		}
+	}
+}
+
+func (d *dart) newIdentWriter(indent int) *indentWriter {
+	return &indentWriter{
+		w:                d.Buffer,
+		depth:            indent,
+		indent:           "  ",
+		firstWriteOnLine: true,
+	}
+}
+
+// escape escapes s for use within a single-quoted Dart string.
+func (d *dart) escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`, `$`, `\$`, "\n", `\n`).Replace(s)
+}
+
+func (d *dart) quote(s string) string {
+	return "'" + d.escape(s) + "'"
+}
+
+func (d *dart) serviceName(name string) string {
+	return idents.Convert(name, idents.PascalCase)
+}
+
+func (d *dart) declName(decl *schema.Decl) string {
+	return idents.Convert(decl.Loc.PkgName, idents.PascalCase) + idents.Convert(decl.Name, idents.PascalCase)
+}
+
+func (d *dart) propName(name string) string {
+	return idents.Convert(name, idents.PascalCase)
+}
+
+// memberName returns the name of a field or method, following the Dart convention of lowerCamelCase.
+func (d *dart) memberName(name string) string {
+	return d.nonReservedMember(idents.Convert(name, idents.CamelCase))
+}
+
+// typeParamName returns the name of a type parameter.
+func (d *dart) typeParamName(name string) string {
+	return idents.Convert(name, idents.PascalCase)
+}
+
+func (d *dart) fieldWireName(field *schema.Field) string {
+	if field.JsonName != "" {
+		return field.JsonName
+	}
+	return field.Name
+}
+
+// nonReservedId returns the given ID, unless we have it a reserved within the client function _or_ it's a reserved Dart keyword
+func (d *dart) nonReservedId(id string) string {
+	switch id {
+	// our reserved keywords (or ID's we use within the generated client functions)
+	case "params", "headers", "query", "body", "form", "encoded", "json", "resp", "method":
+		return id + "_"
+	default:
+		return d.nonReservedMember(id)
+	}
+}
+
+// nonReservedMember returns the given ID, unless it's a reserved Dart keyword,
+// clashes with a Dart type used by the generated classes, or with the members of the classes.
+func (d *dart) nonReservedMember(id string) string {
+	switch id {
+	// Dart reserved words
+	case "assert", "await", "break", "case", "catch", "class", "const", "continue", "default", "do", "else",
+		"enum", "extends", "false", "final", "finally", "for", "if", "in", "is", "new", "null", "rethrow",
+		"return", "super", "switch", "this", "throw", "true", "try", "var", "void", "while", "with", "yield":
+		return id + "_"
+
+	// Dart types, which the field names would shadow
+	case "bool", "double", "dynamic", "int", "num":
+		return id + "_"
+
+	// Members of the generated classes
+	case "fromJson", "toJson", "hashCode", "runtimeType", "toString", "noSuchMethod":
+		return id + "_"
+
+	default:
+		return id
+	}
+}
@@ -0,0 +1,26 @@
+package clientgen
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// filterEndpoints returns a copy of md without the endpoints excluded by tags.
+//
+// Filtering the metadata up front means services left without endpoints,
+// and types only used by excluded endpoints, are left out of the output too.
+func filterEndpoints(md *meta.Data, tags clientgentypes.TagSet) *meta.Data {
+	md = proto.Clone(md).(*meta.Data)
+	for _, svc := range md.Svcs {
+		rpcs := svc.Rpcs[:0]
+		for _, rpc := range svc.Rpcs {
+			if tags.IsRPCIncluded(rpc) {
+				rpcs = append(rpcs, rpc)
+			}
+		}
+		svc.Rpcs = rpcs
+	}
+	return md
+}
@@ -41,9 +41,13 @@ type golang struct {
 	generatorVersion  goGenVersion
 	skipDocs          bool
 	skipPkgTypePrefix bool
+	packageName       string // the package name of the client; "client" if empty
+	apiVersion        string
 
 	seenSlicePath   bool
 	seenLiteralNull bool
+	seenPagination  bool
+	seenForm        bool
 }
 
 func GenTypes(md *meta.Data, typs ...*schema.Decl) ([]byte, error) {
@@ -63,12 +67,17 @@ func GenTypes(md *meta.Data, typs ...*schema.Decl) ([]byte, error) {
 
 func (g *golang) Generate(p clientgentypes.GenerateParams) (err error) {
 	g.md = p.Meta
+	g.apiVersion = p.APIVersion
 	g.enc = gocodegen.NewMarshallingCodeGenerator(gocodegen.UnknownPkgPath, "serde", true)
 
 	namedTypes := getNamedTypes(p.Meta, p.Services)
 
 	// Create a new client file
-	file := NewFile("client")
+	pkgName := g.packageName
+	if pkgName == "" {
+		pkgName = "client"
+	}
+	file := NewFile(pkgName)
 	file.HeaderComment(doNotEditHeader())
 
 	// Generate the parent Client struct
@@ -156,6 +165,10 @@ func (g *golang) generateClient(file *File, appSlug string, set clientgentypes.S
 	file.Const().Id("Local").Id("BaseURL").Op("=").Lit("http://localhost:4000")
 	file.Line()
 
+	file.Comment("APIVersion is the version of the API the client was generated for.")
+	file.Const().Id("APIVersion").Op("=").Lit(g.apiVersion)
+	file.Line()
+
 	file.Comment("Environment returns a BaseURL for calling the cloud environment with the given name.")
 	file.Func().Id("Environment").
 		Params(Id("name").String()).
@@ -252,6 +265,51 @@ Defaults to http.DefaultClient`,
 		},
 	)
 
+	// Generate the WithMiddleware function
+	g.generateOptionFunc(
+		file,
+		"Middleware",
+		`adds middleware which is called for each API request made by the client,
+such as to add tracing headers, log requests or refresh expired credentials.
+
+Middleware is called in the order given, before any middleware given using CallMiddleware.`,
+		&Statement{Id("middleware").Op("...").Id("Middleware")},
+		&Statement{
+			Id("base").Dot("middleware").Op("=").Append(Id("base").Dot("middleware"), Id("middleware").Op("...")),
+			Return(Nil()),
+		},
+	)
+
+	// Generate the WithRetries function
+	g.generateOptionFunc(
+		file,
+		"Retries",
+		`configures the client to retry failed API calls to idempotent endpoints
+using the given policy. It can be overridden for individual calls using CallRetries.
+
+By default API calls are not retried.`,
+		&Statement{Id("policy").Id("RetryPolicy")},
+		&Statement{
+			Id("base").Dot("retries").Op("=").Op("&").Id("policy"),
+			Return(Nil()),
+		},
+	)
+
+	// Generate the WithAPIVersionCheck function
+	g.generateOptionFunc(
+		file,
+		"APIVersionCheck",
+		`configures the client to send APIVersion with each request,
+so that the API rejects the requests if the client is out of date.
+
+Use IsClientOutOfDate to detect such errors.`,
+		&Statement{},
+		&Statement{
+			Id("base").Dot("verifyAPIVersion").Op("=").True(),
+			Return(Nil()),
+		},
+	)
+
 	if g.md.AuthHandler != nil {
 		typ := g.getType(g.md.AuthHandler.Params)
 		rawType := typ
@@ -356,6 +414,17 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 		interfaceMethods = append(interfaceMethods,
 			Id(rpc.Name).Add(g.rpcParams(rpc)).Add(g.rpcReturnType(rpc, false)),
 		)
+
+		pg, err := clientgentypes.DescribePagination(g.md, rpc, nil)
+		if err != nil {
+			return err
+		} else if pg != nil {
+			interfaceMethods = append(interfaceMethods,
+				Line(),
+				Commentf("%sAll iterates over the %s of all pages of %s.", rpc.Name, pg.Items.SrcName, rpc.Name),
+				Id(rpc.Name+"All").Add(g.rpcParams(rpc)).Op("*").Id("Pager").Types(g.getType(pg.ItemType)),
+			)
+		}
 	}
 	file.Type().Id(interfaceName).Interface(interfaceMethods...)
 	file.Line()
@@ -399,10 +468,112 @@ func (g *golang) generateServiceClient(file *File, service *meta.Service, tags c
 				g.rpcReturnType(rpc, true),
 			).Block(callSite...)
 		file.Line()
+
+		pg, err := clientgentypes.DescribePagination(g.md, rpc, nil)
+		if err != nil {
+			return err
+		} else if pg != nil {
+			g.generatePager(file, structName, rpc, pg)
+		}
 	}
 	return nil
 }
 
+// generatePager generates the method iterating over all pages of a paginated endpoint.
+func (g *golang) generatePager(file *File, structName string, rpc *meta.RPC, pg *clientgentypes.Pagination) {
+	g.seenPagination = true
+	itemType := g.getType(pg.ItemType)
+
+	// Call the endpoint with the same arguments as the pager method.
+	args := []Code{Id("ctx")}
+	for _, seg := range rpc.Path.GetSegments() {
+		if seg.Type != meta.PathSegment_LITERAL {
+			args = append(args, Id(g.nonReservedId(seg.Value)))
+		}
+	}
+	args = append(args, Id("params"))
+
+	var body []Code
+	if rpc.RequestSchema.GetPointer() != nil {
+		// Copy the params, so the caller's params aren't modified.
+		body = append(body,
+			Id("req").Op(":=").Op("*").Id("params"),
+			Id("params").Op("=").Op("&").Id("req"),
+		)
+	}
+	body = append(body, Return(Op("&").Id("Pager").Types(itemType).Values(Dict{
+		Id("cursor"): Id("params").Dot(pg.Cursor.SrcName),
+		Id("fetch"): Func().Params(Id("pageCursor").String()).Params(Index().Add(itemType), String(), Error()).Block(
+			Id("params").Dot(pg.Cursor.SrcName).Op("=").Id("pageCursor"),
+			List(Id("resp"), Err()).Op(":=").Id("c").Dot(rpc.Name).Call(args...),
+			If(Err().Op("!=").Nil()).Block(
+				Return(Nil(), Lit(""), Err()),
+			),
+			Return(Id("resp").Dot(pg.Items.SrcName), Id("resp").Dot(pg.NextCursor.SrcName), Nil()),
+		),
+	})))
+
+	file.Commentf("%sAll returns a Pager iterating over the %s of all pages of %s, starting at params.%s.",
+		rpc.Name, pg.Items.SrcName, rpc.Name, pg.Cursor.SrcName)
+	file.Func().
+		Params(Id("c").Op("*").Id(structName)).
+		Id(rpc.Name + "All").
+		Add(g.rpcParams(rpc)).
+		Op("*").Id("Pager").Types(itemType).
+		Block(body...)
+	file.Line()
+}
+
+// generatePagerType generates the Pager type used to iterate over paginated endpoints.
+func (g *golang) generatePagerType(file *File) {
+	pager := func() *Statement { return Op("*").Id("Pager").Types(Id("T")) }
+
+	file.Comment("Pager iterates over the items of a paginated API, fetching the pages as needed:")
+	file.Comment("")
+	file.Comment("\tpager := client.Svc.ListAll(ctx, params)")
+	file.Comment("\tfor pager.Next() {")
+	file.Comment("\t\titem := pager.Item()")
+	file.Comment("\t}")
+	file.Comment("\tif err := pager.Err(); err != nil {")
+	file.Comment("\t\t// handle the error")
+	file.Comment("\t}")
+	file.Type().Id("Pager").Types(Id("T").Any()).Struct(
+		Id("fetch").Func().Params(Id("cursor").String()).Params(Index().Id("T"), String(), Error()),
+		Id("cursor").String(),
+		Id("items").Index().Id("T"),
+		Id("item").Id("T"),
+		Id("done").Bool(),
+		Id("err").Error(),
+	)
+	file.Line()
+
+	file.Comment("Next advances to the next item, fetching the next page if needed.")
+	file.Comment("It reports false when there are no more items, or an error occurred.")
+	file.Func().Params(Id("p").Add(pager())).Id("Next").Params().Bool().Block(
+		For(Len(Id("p").Dot("items")).Op("==").Lit(0)).Block(
+			If(Id("p").Dot("done").Op("||").Id("p").Dot("err").Op("!=").Nil()).Block(
+				Return(False()),
+			),
+			List(Id("p").Dot("items"), Id("p").Dot("cursor"), Id("p").Dot("err")).Op("=").Id("p").Dot("fetch").Call(Id("p").Dot("cursor")),
+			Id("p").Dot("done").Op("=").Id("p").Dot("cursor").Op("==").Lit(""),
+		),
+		List(Id("p").Dot("item"), Id("p").Dot("items")).Op("=").List(Id("p").Dot("items").Index(Lit(0)), Id("p").Dot("items").Index(Lit(1), Empty())),
+		Return(True()),
+	)
+	file.Line()
+
+	file.Comment("Item returns the current item.")
+	file.Func().Params(Id("p").Add(pager())).Id("Item").Params().Id("T").Block(
+		Return(Id("p").Dot("item")),
+	)
+	file.Line()
+
+	file.Comment("Err returns the error that stopped the iteration, if any.")
+	file.Func().Params(Id("p").Add(pager())).Id("Err").Params().Error().Block(
+		Return(Id("p").Dot("err")),
+	)
+}
+
 func (g *golang) rpcParams(rpc *meta.RPC) Code {
 	params := []Code{
 		Id("ctx").Qual("context", "Context"),
@@ -529,16 +700,35 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 		code = append(
 			code,
 			Id("request").Op("=").Id("request").Dot("WithContext").Call(Id("ctx")),
+			If(Id("request").Dot("Header").Op("==").Nil()).Block(
+				Id("request").Dot("Header").Op("=").Make(Qual("net/http", "Header")),
+			),
 			Line(),
+		)
 
-			Comment("Check the request has the method set, as we can't guess what method is required"),
-			If(Id("request").Dot("Method").Op("==").Lit("")).Block(
-				Return(
-					Nil(),
-					Qual("errors", "New").Call(Lit("request.Method must be set")),
+		if len(rpc.HttpMethods) == 1 && rpc.HttpMethods[0] != "*" {
+			code = append(code,
+				Comment("Default to the only method the endpoint accepts"),
+				If(Id("request").Dot("Method").Op("==").Lit("")).Block(
+					Id("request").Dot("Method").Op("=").Lit(rpc.HttpMethods[0]),
 				),
-			),
-			Line(),
+				Line(),
+			)
+		} else {
+			code = append(code,
+				Comment("Check the request has the method set, as we can't guess what method is required"),
+				If(Id("request").Dot("Method").Op("==").Lit("")).Block(
+					Return(
+						Nil(),
+						Qual("errors", "New").Call(Lit("request.Method must be set")),
+					),
+				),
+				Line(),
+			)
+		}
+
+		code = append(
+			code,
 
 			Comment("Set the relative URL for the API call"),
 			List(Id("path"), Err()).Op(":=").Qual("net/url", "Parse").Call(g.createApiPath(rpc, false)),
@@ -574,15 +764,23 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
 
-		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 {
+		formParams, err := clientgentypes.FormBodyParameters(rpc, reqEnc)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 || formParams != nil {
 			code = append(code, Comment("Convert our params into the objects we need for the request"))
 		}
 
 		enc := g.enc.NewPossibleInstance("reqEncoder")
 
 		// Generate the headers
-		if len(reqEnc.HeaderParameters) > 0 {
+		if len(reqEnc.HeaderParameters) > 0 || formParams != nil {
 			values := Dict{}
+			if formParams != nil {
+				values[Lit("Content-Type")] = Index().String().Values(Lit(clientgentypes.FormContentType))
+			}
 
 			for _, field := range reqEnc.HeaderParameters {
 				slice, err := enc.ToStringSlice(
@@ -620,6 +818,25 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 			enc.Add(Id("queryString").Op(":=").Qual("net/url", "Values").Values(values), Line())
 		}
 
+		// Generate the form-encoded body
+		if formParams != nil {
+			g.seenForm = true
+			values := Dict{}
+			for _, field := range formParams {
+				slice, err := enc.ToStringSlice(
+					field.Type,
+					Id("params").Dot(field.SrcName),
+				)
+				if err != nil {
+					return nil, errors.Wrapf(err, "unable to encode form field %s", field.SrcName)
+				}
+				values[Lit(field.WireFormat)] = slice
+			}
+
+			body = Id("form")
+			enc.Add(Id("form").Op(":=").Qual("net/url", "Values").Values(values), Line())
+		}
+
 		if rpc.ResponseSchema != nil {
 			code = append(code, enc.Finalize(
 				Id("err").Op("=").Qual("fmt", "Errorf").Call(
@@ -638,7 +855,7 @@ func (g *golang) rpcCallSite(rpc *meta.RPC) (code []Code, err error) {
 		}
 
 		// Generate the body
-		if len(reqEnc.BodyParameters) > 0 {
+		if len(reqEnc.BodyParameters) > 0 && formParams == nil {
 			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = Id("params")
@@ -1070,6 +1287,8 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 			Params(Op("*").Qual("net/http", "Response"), Error()),
 	)
 
+	g.generateCallOptions(file)
+
 	// Add the base client struct
 	file.Line()
 	file.Comment("baseClient holds all the information we need to make requests to an Encore application")
@@ -1091,6 +1310,15 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 
 		grp.Id("userAgent").String().
 			Commentf("What user agent we will use in the API requests")
+
+		grp.Id("middleware").Index().Id("Middleware").
+			Comment("The middleware called for each API request")
+
+		grp.Id("retries").Op("*").Id("RetryPolicy").
+			Comment("How failed API calls are retried, or nil if they're not retried")
+
+		grp.Id("verifyAPIVersion").Bool().
+			Comment("Whether to send APIVersion with each request")
 	})
 
 	// Add the Do method for th base client
@@ -1102,14 +1330,20 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 		Params(Id("req").Op("*").Qual("net/http", "Request")).
 		Params(Op("*").Qual("net/http", "Response"), Error()).
 		BlockFunc(func(grp *Group) {
-			grp.Id("req").Dot("Header").Dot("Set").Call(
-				Lit("Content-Type"),
-				Lit("application/json"),
+			grp.Comment("Requests are JSON-encoded unless the caller specified another content type")
+			grp.If(Id("req").Dot("Header").Dot("Get").Call(Lit("Content-Type")).Op("==").Lit("")).Block(
+				Id("req").Dot("Header").Dot("Set").Call(
+					Lit("Content-Type"),
+					Lit("application/json"),
+				),
 			)
 			grp.Id("req").Dot("Header").Dot("Set").Call(
 				Lit("User-Agent"),
 				Id("b").Dot("userAgent"),
 			)
+			grp.If(Id("b").Dot("verifyAPIVersion")).Block(
+				Id("req").Dot("Header").Dot("Set").Call(Lit("X-Encore-API-Version"), Id("APIVersion")),
+			)
 			grp.Line()
 
 			if g.md.AuthHandler != nil {
@@ -1125,10 +1359,26 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 			grp.Id("req").Dot("Host").Op("=").Id("req").Dot("URL").Dot("Host")
 			grp.Line()
 
-			grp.Comment("Finally, make the request via the configured HTTP Client")
-			grp.Return(
-				Id("b").Dot("httpClient").Dot("Do").Call(Id("req")),
+			grp.Comment("Finally, make the request via the configured HTTP Client, passing it through any middleware")
+			grp.Id("send").Op(":=").Id("b").Dot("httpClient").Dot("Do")
+			grp.Id("middleware").Op(":=").Id("b").Dot("middleware")
+			grp.If(
+				List(Id("opts"), Id("ok")).Op(":=").Id("req").Dot("Context").Call().Dot("Value").Call(Id("callOptionsKey").Values()).Assert(Op("*").Id("callOptions")),
+				Id("ok"),
+			).Block(
+				Id("middleware").Op("=").Append(
+					Id("middleware").Index(Op(":").Len(Id("middleware")).Op(":").Len(Id("middleware"))),
+					Id("opts").Dot("middleware").Op("..."),
+				),
+			)
+			grp.For(Id("i").Op(":=").Len(Id("middleware")).Op("-").Lit(1), Id("i").Op(">=").Lit(0), Id("i").Op("--")).Block(
+				List(Id("mw"), Id("next")).Op(":=").List(Id("middleware").Index(Id("i")), Id("send")),
+				Id("send").Op("=").Func().
+					Params(Id("req").Op("*").Qual("net/http", "Request")).
+					Params(Op("*").Qual("net/http", "Response"), Error()).
+					Block(Return(Id("mw").Call(Id("req"), Id("next")))),
 			)
+			grp.Return(Id("send").Call(Id("req")))
 		})
 	if err != nil {
 		return
@@ -1150,43 +1400,73 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 		Params(Qual("net/http", "Header"), Error()).
 		Block(
 			Comment("Encode the API body"),
-			Var().Id("bodyReader").Qual("io", "Reader"),
-			If(Id("body").Op("!=").Nil()).Block(
-				List(Id("bodyBytes"), Err()).Op(":=").
-					Qual("encoding/json", "Marshal").
-					Call(Id("body")),
-				If(Err().Op("!=").Nil()).Block(
-					Return(Nil(), Qual("fmt", "Errorf").Call(Lit("marshal request: %w"), Err())),
-				),
+			Var().Id("bodyBytes").Index().Byte(),
+			g.encodeRequestBody(),
+			Line(),
 
-				Id("bodyReader").Op("=").Qual("bytes", "NewReader").Call(Id("bodyBytes")),
+			Comment("Work out if and how the call is retried if it fails"),
+			Id("retries").Op(":=").Id("client").Dot("retries"),
+			If(
+				List(Id("opts"), Id("ok")).Op(":=").Id("ctx").Dot("Value").Call(Id("callOptionsKey").Values()).Assert(Op("*").Id("callOptions")),
+				Id("ok").Op("&&").Id("opts").Dot("retries").Op("!=").Nil(),
+			).Block(
+				Id("retries").Op("=").Id("opts").Dot("retries"),
+			),
+			If(Op("!").Id("isIdempotent").Call(Id("method"))).Block(
+				Id("retries").Op("=").Nil(),
 			),
 			Line(),
 
-			Comment("Create the request"),
-			List(Id("req"), Err()).Op(":=").
-				Qual("net/http", "NewRequestWithContext").
-				Call(
-					Id("ctx"), Id("method"), Id("path"), Id("bodyReader"),
+			Var().Id("rawResponse").Op("*").Qual("net/http", "Response"),
+			For(Id("attempt").Op(":=").Lit(1), Empty(), Id("attempt").Op("++")).Block(
+				Comment("Create the request"),
+				Var().Id("bodyReader").Qual("io", "Reader"),
+				If(Id("body").Op("!=").Nil()).Block(
+					Id("bodyReader").Op("=").Qual("bytes", "NewReader").Call(Id("bodyBytes")),
 				),
-			If(Err().Op("!=").Nil()).Block(
-				Return(Nil(), Qual("fmt", "Errorf").Call(Lit("create request: %w"), Err())),
-			),
-			Line(),
+				List(Id("req"), Err()).Op(":=").
+					Qual("net/http", "NewRequestWithContext").
+					Call(
+						Id("ctx"), Id("method"), Id("path"), Id("bodyReader"),
+					),
+				If(Err().Op("!=").Nil()).Block(
+					Return(Nil(), Qual("fmt", "Errorf").Call(Lit("create request: %w"), Err())),
+				),
+				Line(),
 
-			Comment("Add any headers to the request"),
-			For(List(Id("header"), Id("values")).Op(":=").Range().Id("headers")).Block(
-				For(List(Id("_"), Id("value")).Op(":=").Range().Id("values")).Block(
-					Id("req").Dot("Header").Dot("Add").Call(Id("header"), Id("value")),
+				Comment("Add any headers to the request"),
+				For(List(Id("header"), Id("values")).Op(":=").Range().Id("headers")).Block(
+					For(List(Id("_"), Id("value")).Op(":=").Range().Id("values")).Block(
+						Id("req").Dot("Header").Dot("Add").Call(Id("header"), Id("value")),
+					),
 				),
-			),
-			Line(),
+				Line(),
 
-			Comment("Make the request via the base client"),
-			List(Id("rawResponse"), Err()).Op(":=").
-				Id("client").Dot("Do").Call(Id("req")),
-			If(Err().Op("!=").Nil()).Block(
-				Return(Nil(), Qual("fmt", "Errorf").Call(Lit("request failed: %w"), Err())),
+				Comment("Make the request via the base client"),
+				List(Id("rawResponse"), Err()).Op("=").
+					Id("client").Dot("Do").Call(Id("req")),
+				If(
+					Id("retries").Op("==").Nil().
+						Op("||").Id("attempt").Op(">=").Id("retries").Dot("MaxAttempts").
+						Op("||").Op("!").Id("retries").Dot("shouldRetry").Call(Id("rawResponse"), Err()),
+				).Block(
+					If(Err().Op("!=").Nil()).Block(
+						Return(Nil(), Qual("fmt", "Errorf").Call(Lit("request failed: %w"), Err())),
+					),
+					Break(),
+				),
+				Line(),
+
+				Comment("Discard the failed response and wait before trying again"),
+				If(Id("rawResponse").Op("!=").Nil()).Block(
+					Id("_").Op("=").Id("rawResponse").Dot("Body").Dot("Close").Call(),
+				),
+				If(
+					Err().Op(":=").Id("retries").Dot("wait").Call(Id("ctx"), Id("attempt")),
+					Err().Op("!=").Nil(),
+				).Block(
+					Return(Nil(), Qual("fmt", "Errorf").Call(Lit("request failed: %w"), Err())),
+				),
 			),
 			Defer().Func().Params().Block(
 				Id("_").Op("=").Id("rawResponse").Dot("Body").Dot("Close").Call(),
@@ -1245,9 +1525,193 @@ func (g *golang) generateBaseClient(file *File) (err error) {
 			),
 		)
 
+	g.generateRetryHelpers(file)
 	return nil
 }
 
+// encodeRequestBody generates the code used by callAPI to encode the request body.
+// Bodies are JSON-encoded, unless the client calls endpoints accepting form-encoded
+// bodies, in which case bodies of type url.Values are form-encoded.
+func (g *golang) encodeRequestBody() Code {
+	encodeJSON := If(Id("body").Op("!=").Nil()).Block(
+		Var().Err().Error(),
+		List(Id("bodyBytes"), Err()).Op("=").
+			Qual("encoding/json", "Marshal").
+			Call(Id("body")),
+		If(Err().Op("!=").Nil()).Block(
+			Return(Nil(), Qual("fmt", "Errorf").Call(Lit("marshal request: %w"), Err())),
+		),
+	)
+	if !g.seenForm {
+		return encodeJSON
+	}
+
+	return If(
+		List(Id("form"), Id("ok")).Op(":=").Id("body").Assert(Qual("net/url", "Values")),
+		Id("ok"),
+	).Block(
+		Id("bodyBytes").Op("=").Index().Byte().Call(Id("form").Dot("Encode").Call()),
+	).Else().Add(encodeJSON)
+}
+
+// generateCallOptions generates the types used to configure middleware and retries,
+// for all API calls or for individual ones.
+func (g *golang) generateCallOptions(file *File) {
+	file.Line()
+	file.Comment("Middleware is called for each API request made by the client, and calls next to send the request.")
+	file.Comment("It can modify the request before it's sent, or inspect and modify the response.")
+	file.Type().Id("Middleware").Func().
+		Params(
+			Id("req").Op("*").Qual("net/http", "Request"),
+			Id("next").Func().Params(Id("req").Op("*").Qual("net/http", "Request")).Params(Op("*").Qual("net/http", "Response"), Error()),
+		).
+		Params(Op("*").Qual("net/http", "Response"), Error())
+
+	file.Line()
+	file.Comment("RetryPolicy configures how failed API calls to idempotent endpoints are retried.")
+	file.Comment("Only calls using the GET, HEAD, PUT, DELETE and OPTIONS HTTP methods are retried.")
+	file.Type().Id("RetryPolicy").Struct(
+		Id("MaxAttempts").Int().Comment("The maximum number of attempts, including the first one"),
+		Id("InitialBackoff").Qual("time", "Duration").Comment("The delay before the first retry, doubled for each following retry (defaults to 100ms)"),
+		Id("MaxBackoff").Qual("time", "Duration").Comment("The maximum delay between attempts (defaults to 5s)"),
+		Line(),
+		Comment("ShouldRetry reports whether a failed attempt should be retried, given its response or error."),
+		Comment("Defaults to retrying network errors and 429, 502, 503 and 504 responses."),
+		Id("ShouldRetry").Func().Params(Id("resp").Op("*").Qual("net/http", "Response"), Err().Error()).Bool(),
+	)
+
+	file.Line()
+	file.Comment("CallOption configures individual API calls. Use WithCallOptions to apply them.")
+	file.Type().Id("CallOption").Op("=").Func().Params(Id("opts").Op("*").Id("callOptions"))
+
+	file.Line()
+	file.Comment("callOptions are the options of individual API calls")
+	file.Type().Id("callOptions").Struct(
+		Id("middleware").Index().Id("Middleware"),
+		Id("retries").Op("*").Id("RetryPolicy"),
+	)
+
+	file.Line()
+	file.Type().Id("callOptionsKey").Struct()
+
+	file.Line()
+	file.Comment("WithCallOptions returns a copy of ctx which applies the given options")
+	file.Comment("to the API calls made with it, in addition to any options already in ctx.")
+	file.Func().Id("WithCallOptions").
+		Params(Id("ctx").Qual("context", "Context"), Id("options").Op("...").Id("CallOption")).
+		Qual("context", "Context").
+		Block(
+			Id("opts").Op(":=").Op("&").Id("callOptions").Values(),
+			If(
+				List(Id("prev"), Id("ok")).Op(":=").Id("ctx").Dot("Value").Call(Id("callOptionsKey").Values()).Assert(Op("*").Id("callOptions")),
+				Id("ok"),
+			).Block(
+				Id("opts").Dot("middleware").Op("=").Append(Index().Id("Middleware").Call(Nil()), Id("prev").Dot("middleware").Op("...")),
+				Id("opts").Dot("retries").Op("=").Id("prev").Dot("retries"),
+			),
+			For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+				Id("option").Call(Id("opts")),
+			),
+			Return(Qual("context", "WithValue").Call(Id("ctx"), Id("callOptionsKey").Values(), Id("opts"))),
+		)
+
+	file.Line()
+	file.Comment("CallMiddleware adds middleware to the API calls, called after any middleware given using WithMiddleware.")
+	file.Func().Id("CallMiddleware").
+		Params(Id("middleware").Op("...").Id("Middleware")).
+		Id("CallOption").
+		Block(
+			Return(Func().Params(Id("opts").Op("*").Id("callOptions")).Block(
+				Id("opts").Dot("middleware").Op("=").Append(Id("opts").Dot("middleware"), Id("middleware").Op("...")),
+			)),
+		)
+
+	file.Line()
+	file.Comment("CallRetries sets the retry policy of the API calls, overriding any policy given using WithRetries.")
+	file.Comment("Use a policy with MaxAttempts set to 1 to disable retries.")
+	file.Func().Id("CallRetries").
+		Params(Id("policy").Id("RetryPolicy")).
+		Id("CallOption").
+		Block(
+			Return(Func().Params(Id("opts").Op("*").Id("callOptions")).Block(
+				Id("opts").Dot("retries").Op("=").Op("&").Id("policy"),
+			)),
+		)
+}
+
+// generateRetryHelpers generates the helper functions used by callAPI to retry failed calls.
+func (g *golang) generateRetryHelpers(file *File) {
+	file.Line()
+	file.Comment("isIdempotent reports whether requests with the given HTTP method are idempotent,")
+	file.Comment("and can therefore be retried safely.")
+	file.Func().Id("isIdempotent").Params(Id("method").String()).Bool().Block(
+		Switch(Id("method")).Block(
+			Case(Lit("GET"), Lit("HEAD"), Lit("PUT"), Lit("DELETE"), Lit("OPTIONS")).Block(
+				Return(True()),
+			),
+		),
+		Return(False()),
+	)
+
+	file.Line()
+	file.Comment("shouldRetry reports whether a failed attempt should be retried.")
+	file.Func().Params(Id("p").Op("*").Id("RetryPolicy")).Id("shouldRetry").
+		Params(Id("resp").Op("*").Qual("net/http", "Response"), Err().Error()).
+		Bool().
+		Block(
+			If(Id("p").Dot("ShouldRetry").Op("!=").Nil()).Block(
+				Return(Id("p").Dot("ShouldRetry").Call(Id("resp"), Err())),
+			),
+			If(Err().Op("!=").Nil()).Block(
+				Return(True()),
+			),
+			Switch(Id("resp").Dot("StatusCode")).Block(
+				Case(
+					Qual("net/http", "StatusTooManyRequests"),
+					Qual("net/http", "StatusBadGateway"),
+					Qual("net/http", "StatusServiceUnavailable"),
+					Qual("net/http", "StatusGatewayTimeout"),
+				).Block(
+					Return(True()),
+				),
+			),
+			Return(False()),
+		)
+
+	file.Line()
+	file.Comment("wait waits before retrying a call after the given number of failed attempts.")
+	file.Func().Params(Id("p").Op("*").Id("RetryPolicy")).Id("wait").
+		Params(Id("ctx").Qual("context", "Context"), Id("attempt").Int()).
+		Error().
+		Block(
+			Id("backoff").Op(":=").Id("p").Dot("InitialBackoff"),
+			If(Id("backoff").Op("<=").Lit(0)).Block(
+				Id("backoff").Op("=").Lit(100).Op("*").Qual("time", "Millisecond"),
+			),
+			Id("maxBackoff").Op(":=").Id("p").Dot("MaxBackoff"),
+			If(Id("maxBackoff").Op("<=").Lit(0)).Block(
+				Id("maxBackoff").Op("=").Lit(5).Op("*").Qual("time", "Second"),
+			),
+			For(Id("i").Op(":=").Lit(1), Id("i").Op("<").Id("attempt").Op("&&").Id("backoff").Op("<").Id("maxBackoff"), Id("i").Op("++")).Block(
+				Id("backoff").Op("*=").Lit(2),
+			),
+			If(Id("backoff").Op(">").Id("maxBackoff")).Block(
+				Id("backoff").Op("=").Id("maxBackoff"),
+			),
+			Line(),
+			Id("timer").Op(":=").Qual("time", "NewTimer").Call(Id("backoff")),
+			Defer().Id("timer").Dot("Stop").Call(),
+			Select().Block(
+				Case(Op("<-").Id("ctx").Dot("Done").Call()).Block(
+					Return(Id("ctx").Dot("Err").Call()),
+				),
+				Case(Op("<-").Id("timer").Dot("C")).Block(
+					Return(Nil()),
+				),
+			),
+		)
+}
+
 func (g *golang) writeErrorType(file *File) {
 	const ErrPrefix = "Err"
 
@@ -1263,6 +1727,18 @@ func (g *golang) writeErrorType(file *File) {
 		Return(Qual("fmt", "Sprintf").Call(Lit("%s: %s"), Id("e").Dot("Code"), Id("e").Dot("Message"))),
 	)
 	file.Line()
+
+	file.Comment("IsClientOutOfDate reports whether the API rejected the request because the client")
+	file.Comment("was generated for a different version of the API. Regenerate the client to fix it.")
+	file.Func().Id("IsClientOutOfDate").Params(Err().Error()).Bool().Block(
+		Var().Id("apiErr").Op("*").Id("APIError"),
+		If(Op("!").Qual("errors", "As").Call(Err(), Op("&").Id("apiErr")).Op("||").Id("apiErr").Dot("Code").Op("!=").Id(ErrPrefix+"FailedPrecondition")).Block(
+			Return(False()),
+		),
+		List(Id("details"), Id("_")).Op(":=").Id("apiErr").Dot("Details").Assert(Map(String()).Any()),
+		Return(Id("details").Index(Lit("client_out_of_date")).Op("==").True()),
+	)
+	file.Line()
 	file.Line()
 
 	// Create the ErrCode type and list
@@ -1347,6 +1823,11 @@ func (g *golang) writeExtraHelpers(file *File) {
 		file.Comment("null is a helper type to indicate a null value in JSON.")
 		file.Type().Id("null").Op("=").Op("*").Bool()
 	}
+
+	if g.seenPagination {
+		file.Line()
+		g.generatePagerType(file)
+	}
 }
 
 func (g *golang) addAuthData(grp *Group) (err error) {
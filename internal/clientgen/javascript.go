@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -54,6 +55,7 @@ type javascript struct {
 	*bytes.Buffer
 	md               *meta.Data
 	appSlug          string
+	apiVersion       string
 	typs             *typeRegistry
 	currDecl         *schema.Decl
 	generatorVersion jsGenVersion
@@ -74,6 +76,7 @@ func (js *javascript) Generate(p clientgentypes.GenerateParams) (err error) {
 	js.Buffer = p.Buf
 	js.md = p.Meta
 	js.appSlug = p.AppSlug
+	js.apiVersion = p.APIVersion
 	js.typs = getNamedTypes(p.Meta, p.Services)
 
 	if js.md.AuthHandler != nil {
@@ -365,13 +368,17 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
+		formParams, err := clientgentypes.FormBodyParameters(rpc, reqEnc)
+		if err != nil {
+			return err
+		}
 
-		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 {
+		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 || formParams != nil {
 			w.WriteString("// Convert our params into the objects we need for the request\n")
 		}
 
 		// Generate the headers
-		if len(reqEnc.HeaderParameters) > 0 {
+		if len(reqEnc.HeaderParameters) > 0 || formParams != nil {
 			headers = "headers"
 
 			dict := make(map[string]string)
@@ -379,6 +386,9 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 				ref := js.Dot("params", field.SrcName)
 				dict[field.WireFormat] = js.convertBuiltinToString(field.Type.GetBuiltin(), ref, field.Optional)
 			}
+			if formParams != nil {
+				dict["Content-Type"] = strconv.Quote(clientgentypes.FormContentType)
+			}
 
 			w.WriteString("const headers = makeRecord(")
 			js.Values(w, dict)
@@ -391,16 +401,7 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 
 			dict := make(map[string]string)
 			for _, field := range reqEnc.QueryParameters {
-				if list := field.Type.GetList(); list != nil {
-					dict[field.WireFormat] = js.Dot("params", field.SrcName) +
-						".map((v) => " + js.convertBuiltinToString(list.Elem.GetBuiltin(), "v", field.Optional) + ")"
-				} else {
-					dict[field.WireFormat] = js.convertBuiltinToString(
-						field.Type.GetBuiltin(),
-						js.Dot("params", field.SrcName),
-						field.Optional,
-					)
-				}
+				dict[field.WireFormat] = js.queryValue(field)
 			}
 
 			w.WriteString("const query = makeRecord(")
@@ -408,8 +409,23 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 			w.WriteString(")\n\n")
 		}
 
+		// Generate the form-encoded body, which is encoded like the query string
+		if formParams != nil {
+			body = "encodeQuery(form)"
+
+			dict := make(map[string]string)
+			for _, field := range formParams {
+				dict[field.WireFormat] = js.queryValue(field)
+			}
+
+			w.WriteString("// Construct the form-encoded body with only the fields which we want encoded within the body\n")
+			w.WriteString("const form = makeRecord(")
+			js.Values(w, dict)
+			w.WriteString(")\n\n")
+		}
+
 		// Generate the body
-		if len(reqEnc.BodyParameters) > 0 {
+		if len(reqEnc.BodyParameters) > 0 && formParams == nil {
 			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
@@ -490,11 +506,24 @@ func (js *javascript) rpcCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string
 	return nil
 }
 
+// queryValue returns the expression to encode a request parameter
+// in a query string or form-encoded body.
+func (js *javascript) queryValue(field *encoding.ParameterEncoding) string {
+	if list := field.Type.GetList(); list != nil {
+		dot := js.Dot("params", field.SrcName)
+		if field.Optional {
+			dot += "?"
+		}
+		return dot + ".map((v) => " + js.convertBuiltinToString(list.Elem.GetBuiltin(), "v", false) + ")"
+	}
+	return js.convertBuiltinToString(field.Type.GetBuiltin(), js.Dot("params", field.SrcName), field.Optional)
+}
+
 // nonReservedId returns the given ID, unless we have it a reserved within the client function _or_ it's a reserved Typescript keyword
 func (js *javascript) nonReservedId(id string) string {
 	switch id {
 	// our reserved keywords (or ID's we use within the generated client functions)
-	case "params", "headers", "query", "body", "resp", "rtn":
+	case "params", "headers", "query", "form", "body", "resp", "rtn":
 		return "_" + id
 
 	// Javascript keywords
@@ -521,6 +550,13 @@ func (js *javascript) writeClient(set clientgentypes.ServiceSet) {
  */
 export const Local = "http://localhost:4000"
 
+/**
+ * APIVersion is the version of the API the client was generated for.
+ * Set the verifyAPIVersion client option to send it with each request,
+ * so that the API rejects the requests if the client is out of date.
+ */
+export const APIVersion = "` + js.apiVersion + `"
+
 /**
  * Environment returns a BaseURL for calling the cloud environment with the given name.
  */
@@ -748,6 +784,10 @@ class BaseClient {`)
             this.headers["User-Agent"] = "` + userAgent + `";
         }
 
+        if (options.verifyAPIVersion) {
+            this.headers["X-Encore-API-Version"] = APIVersion
+        }
+
         this.requestInit = options.requestInit ?? {}
 
         // Setup what fetch function we'll be using in the base client
@@ -983,7 +1023,7 @@ function encodeQuery(parts) {
     for (const key in parts) {
         const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]])
         for (const v of val) {
-            pairs.push(` + "`" + `${key}=${encodeURIComponent(v)}` + "`" + `)
+            pairs.push(` + "`" + `${encodeURIComponent(key)}=${encodeURIComponent(v)}` + "`" + `)
         }
     }
     return pairs.join("&")
@@ -1232,6 +1272,14 @@ export function isAPIError(err) {
     return err instanceof APIError;
 }
 
+/**
+ * isClientOutOfDate reports whether the API rejected the request because the client
+ * was generated for a different version of the API. Regenerate the client to fix it.
+ */
+export function isClientOutOfDate(err) {
+    return isAPIError(err) && err.code === ErrCode.FailedPrecondition && err.details?.client_out_of_date === true
+}
+
 export const ErrCode = {
     /**
      * OK indicates the operation was successful.
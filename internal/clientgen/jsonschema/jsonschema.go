@@ -0,0 +1,314 @@
+// Package jsonschema generates JSON Schema documents for the types of an Encore app.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/parser/encoding"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Generate generates a JSON Schema document for each named type in the app metadata.
+// Generic types are generated once for each instantiation of them.
+//
+// The documents are keyed by file name, such as "pkg.Type.json",
+// and reference each other using relative $refs to those file names.
+// Properties are named by their JSON names on the wire.
+func Generate(md *meta.Data) (docs map[string][]byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if b, ok := r.(bailout); ok {
+				err = b.err
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	g := &generator{
+		md:      md,
+		schemas: make(map[string]map[string]any),
+		names:   make(map[string]string),
+		taken:   make(map[string]string),
+	}
+	for _, decl := range md.Decls {
+		if len(decl.TypeParams) > 0 {
+			// Generic types are generated when instantiated.
+			continue
+		}
+		g.namedRef(&schema.Named{Id: decl.Id})
+	}
+
+	docs = make(map[string][]byte, len(g.schemas))
+	for name, s := range g.schemas {
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshal schema %s", name)
+		}
+		docs[name+".json"] = append(data, '\n')
+	}
+	return docs, nil
+}
+
+type generator struct {
+	md      *meta.Data
+	schemas map[string]map[string]any // document name -> schema
+
+	names map[string]string // instantiation key -> document name
+	taken map[string]string // document name -> instantiation key
+}
+
+// namedRef returns a $ref to the document for the given named type,
+// generating the document if it hasn't been already.
+func (g *generator) namedRef(typ *schema.Named) map[string]any {
+	namedType := &schema.Type{Typ: &schema.Type_Named{Named: typ}}
+	key := instanceKey(namedType)
+	name, ok := g.names[key]
+	if !ok {
+		name = g.definitionName(namedType)
+		// Make sure the name is unique, in case of declarations
+		// with the same name in packages with the same name.
+		for idx, orig := 2, name; ; idx++ {
+			if _, ok := g.taken[name]; !ok {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", orig, idx)
+		}
+		g.names[key] = name
+		g.taken[name] = key
+
+		concrete, err := encoding.GetConcreteType(g.md.Decls, namedType, nil)
+		if err != nil {
+			doBailout(errors.Wrap(err, "get concrete type"))
+		}
+
+		// Reserve the document before generating the schema
+		// to avoid infinite recursion for recursive types.
+		g.schemas[name] = nil
+		s := g.schemaType(concrete)
+		decl := g.md.Decls[typ.Id]
+		s["$schema"] = Draft
+		s["$id"] = name + ".json"
+		s["title"] = name
+		if doc := strings.TrimSpace(decl.Doc); doc != "" {
+			s["description"] = doc
+		}
+		g.schemas[name] = s
+	}
+	return map[string]any{"$ref": name + ".json"}
+}
+
+func (g *generator) schemaType(typ *schema.Type) map[string]any {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		return g.namedRef(t.Named)
+
+	case *schema.Type_Struct:
+		props := make(map[string]any, len(t.Struct.Fields))
+		required := make([]string, 0, len(t.Struct.Fields))
+		for _, f := range t.Struct.Fields {
+			jsonName := f.JsonName
+			if jsonName == "-" {
+				continue
+			}
+			if jsonName == "" {
+				jsonName = f.Name
+			}
+			if !f.Optional {
+				required = append(required, jsonName)
+			}
+
+			val := g.schemaType(f.Typ)
+			if doc := strings.TrimSpace(f.Doc); doc != "" {
+				if _, isRef := val["$ref"]; isRef {
+					// Keywords next to $ref are allowed as of draft 2019-09.
+					val = map[string]any{"$ref": val["$ref"]}
+				}
+				val["description"] = doc
+			}
+			props[jsonName] = val
+		}
+		s := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			s["required"] = required
+		}
+		return s
+
+	case *schema.Type_Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.schemaType(t.Map.Value),
+		}
+
+	case *schema.Type_List:
+		return map[string]any{
+			"type":  "array",
+			"items": g.schemaType(t.List.Elem),
+		}
+
+	case *schema.Type_Pointer:
+		return g.schemaType(t.Pointer.Base)
+
+	case *schema.Type_Literal:
+		if _, isNull := t.Literal.Value.(*schema.Literal_Null); isNull {
+			return map[string]any{"type": "null"}
+		}
+		return map[string]any{"const": literalValue(t.Literal)}
+
+	case *schema.Type_Union:
+		// Represent unions of literals as enums.
+		var literals []any
+		allLiterals := true
+		for _, tt := range t.Union.Types {
+			lit, ok := tt.Typ.(*schema.Type_Literal)
+			if !ok {
+				allLiterals = false
+				break
+			}
+			if _, isNull := lit.Literal.Value.(*schema.Literal_Null); isNull {
+				literals = append(literals, nil)
+			} else {
+				literals = append(literals, literalValue(lit.Literal))
+			}
+		}
+		if allLiterals {
+			return map[string]any{"enum": literals}
+		}
+
+		anyOf := make([]any, 0, len(t.Union.Types))
+		for _, tt := range t.Union.Types {
+			anyOf = append(anyOf, g.schemaType(tt))
+		}
+		return map[string]any{"anyOf": anyOf}
+
+	case *schema.Type_TypeParameter:
+		return map[string]any{} // unknown
+
+	case *schema.Type_Config:
+		elem := g.schemaType(t.Config.Elem)
+		if t.Config.IsValuesList {
+			return map[string]any{"type": "array", "items": elem}
+		}
+		return elem
+
+	case *schema.Type_Builtin:
+		return builtinSchemaType(t.Builtin)
+
+	default:
+		doBailout(errors.Newf("unknown schema type %T", t))
+		panic("unreachable")
+	}
+}
+
+func builtinSchemaType(t schema.Builtin) map[string]any {
+	integer := func(min, max float64) map[string]any {
+		return map[string]any{"type": "integer", "minimum": min, "maximum": max}
+	}
+
+	switch t {
+	case schema.Builtin_ANY, schema.Builtin_JSON:
+		return map[string]any{}
+	case schema.Builtin_BOOL:
+		return map[string]any{"type": "boolean"}
+	case schema.Builtin_INT8:
+		return integer(math.MinInt8, math.MaxInt8)
+	case schema.Builtin_INT16:
+		return integer(math.MinInt16, math.MaxInt16)
+	case schema.Builtin_INT32:
+		return integer(math.MinInt32, math.MaxInt32)
+	case schema.Builtin_UINT8:
+		return integer(0, math.MaxUint8)
+	case schema.Builtin_UINT16:
+		return integer(0, math.MaxUint16)
+	case schema.Builtin_UINT32:
+		return integer(0, math.MaxUint32)
+	case schema.Builtin_INT64, schema.Builtin_INT:
+		return map[string]any{"type": "integer"}
+	case schema.Builtin_UINT64, schema.Builtin_UINT:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case schema.Builtin_FLOAT32, schema.Builtin_FLOAT64:
+		return map[string]any{"type": "number"}
+	case schema.Builtin_STRING, schema.Builtin_USER_ID:
+		return map[string]any{"type": "string"}
+	case schema.Builtin_BYTES:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case schema.Builtin_TIME:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case schema.Builtin_UUID:
+		return map[string]any{"type": "string", "format": "uuid"}
+	default:
+		doBailout(errors.Newf("unknown builtin type %v", t))
+		panic("unreachable")
+	}
+}
+
+func literalValue(lit *schema.Literal) any {
+	switch v := lit.Value.(type) {
+	case *schema.Literal_Str:
+		return v.Str
+	case *schema.Literal_Boolean:
+		return v.Boolean
+	case *schema.Literal_Int:
+		return v.Int
+	case *schema.Literal_Float:
+		return v.Float
+	case *schema.Literal_Null:
+		return nil
+	default:
+		doBailout(errors.Newf("unknown literal type %T", v))
+		panic("unreachable")
+	}
+}
+
+// definitionName returns the document name for a type, such as "pkg.Type"
+// or "pkg.Page_pkg.User" for instantiations of generic types.
+func (g *generator) definitionName(typ *schema.Type) string {
+	switch typ := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := g.md.Decls[typ.Named.Id]
+		name := decl.Loc.PkgName + "." + decl.Name
+		for _, typeArg := range typ.Named.TypeArguments {
+			name += "_" + g.definitionName(typeArg)
+		}
+		return name
+	case *schema.Type_List:
+		return "List_" + g.definitionName(typ.List.Elem)
+	case *schema.Type_Map:
+		return "Map_" + g.definitionName(typ.Map.Key) + "_" + g.definitionName(typ.Map.Value)
+	case *schema.Type_Pointer:
+		return g.definitionName(typ.Pointer.Base)
+	case *schema.Type_Config:
+		return g.definitionName(typ.Config.Elem)
+	case *schema.Type_Builtin:
+		return strings.ToLower(typ.Builtin.String())
+	default:
+		return "any"
+	}
+}
+
+// instanceKey returns a key uniquely identifying an instantiation of a type.
+func instanceKey(typ *schema.Type) string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(typ)
+	if err != nil {
+		doBailout(errors.Wrap(err, "marshal type"))
+	}
+	return string(data)
+}
+
+type bailout struct {
+	err error
+}
+
+func doBailout(err error) {
+	panic(bailout{err})
+}
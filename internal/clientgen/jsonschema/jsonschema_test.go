@@ -0,0 +1,89 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func builtin(b schema.Builtin) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+}
+
+func named(id uint32, args ...*schema.Type) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id, TypeArguments: args}}}
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{Decls: []*schema.Decl{
+		{
+			Id: 0, Name: "User", Doc: "User is a user.\n", Loc: &schema.Loc{PkgName: "users"},
+			Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{
+				{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_UUID)},
+				{Name: "Email", JsonName: "email", Typ: builtin(schema.Builtin_STRING), Optional: true},
+				{Name: "Secret", JsonName: "-", Typ: builtin(schema.Builtin_STRING)},
+				{Name: "Manager", Typ: &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: named(0)}}}, Doc: "The user's manager."},
+			}}}},
+		},
+		{
+			Id: 1, Name: "Page", Loc: &schema.Loc{PkgName: "users"},
+			TypeParams: []*schema.TypeParameter{{Name: "T"}},
+			Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{
+				{Name: "Items", JsonName: "items", Typ: &schema.Type{Typ: &schema.Type_List{List: &schema.List{
+					Elem: &schema.Type{Typ: &schema.Type_TypeParameter{TypeParameter: &schema.TypeParameterRef{ParamIdx: 0}}},
+				}}}},
+			}}}},
+		},
+		{
+			Id: 2, Name: "ListResponse", Loc: &schema.Loc{PkgName: "users"},
+			Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{
+				{Name: "Users", JsonName: "users", Typ: named(1, named(0))},
+			}}}},
+		},
+		{
+			Id: 3, Name: "User", Loc: &schema.Loc{PkgName: "users"},
+			Type: builtin(schema.Builtin_STRING),
+		},
+	}}
+
+	docs, err := Generate(md)
+	c.Assert(err, qt.IsNil)
+
+	var names []string
+	for name := range docs {
+		names = append(names, name)
+	}
+	c.Assert(names, qt.ContentEquals, []string{
+		"users.User.json",
+		"users.ListResponse.json",
+		"users.Page_users.User.json",
+		"users.User_2.json",
+	})
+
+	var user map[string]any
+	c.Assert(json.Unmarshal(docs["users.User.json"], &user), qt.IsNil)
+	c.Assert(user, qt.DeepEquals, map[string]any{
+		"$schema":     Draft,
+		"$id":         "users.User.json",
+		"title":       "users.User",
+		"description": "User is a user.",
+		"type":        "object",
+		"properties": map[string]any{
+			"id":      map[string]any{"type": "string", "format": "uuid"},
+			"email":   map[string]any{"type": "string"},
+			"Manager": map[string]any{"$ref": "users.User.json", "description": "The user's manager."},
+		},
+		"required": []any{"id", "Manager"},
+	})
+
+	var page map[string]any
+	c.Assert(json.Unmarshal(docs["users.Page_users.User.json"], &page), qt.IsNil)
+	c.Assert(page["properties"], qt.DeepEquals, map[string]any{
+		"items": map[string]any{"type": "array", "items": map[string]any{"$ref": "users.User.json"}},
+	})
+}
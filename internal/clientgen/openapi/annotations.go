@@ -0,0 +1,160 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Annotations are example payloads and vendor extensions
+// to embed in the generated OpenAPI specification.
+//
+// They are typically loaded from a JSON file using ParseAnnotations:
+//
+//	{
+//	  "extensions": {"x-api-id": "orders"},
+//	  "endpoints": {
+//	    "orders.Get": {
+//	      "request_example": {"id": "ord_123"},
+//	      "response_example": {"id": "ord_123", "total": 42},
+//	      "extensions": {"x-rate-limit": 100}
+//	    }
+//	  },
+//	  "types": {
+//	    "orders.Order": {"example": {"id": "ord_123", "total": 42}}
+//	  }
+//	}
+type Annotations struct {
+	// Extensions are added to the top-level document.
+	Extensions map[string]any `json:"extensions,omitempty"`
+
+	// Endpoints are keyed by "service.Endpoint".
+	Endpoints map[string]*EndpointAnnotations `json:"endpoints,omitempty"`
+
+	// Types are keyed by the schema name in the generated spec,
+	// such as "orders.Order".
+	Types map[string]*TypeAnnotations `json:"types,omitempty"`
+}
+
+// EndpointAnnotations annotate the operations of a single endpoint.
+type EndpointAnnotations struct {
+	// RequestExample is an example request, keyed by the wire names of its fields.
+	// Fields sent as path, header or query parameters become examples of those parameters,
+	// and the remaining fields become the example request body.
+	RequestExample map[string]any `json:"request_example,omitempty"`
+
+	// ResponseExample is an example of the response body.
+	ResponseExample any `json:"response_example,omitempty"`
+
+	// Extensions are added to each of the endpoint's operations.
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// TypeAnnotations annotate the schema of a named type.
+type TypeAnnotations struct {
+	Example    any            `json:"example,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// ParseAnnotations parses annotations from JSON.
+func ParseAnnotations(data []byte) (*Annotations, error) {
+	var a Annotations
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&a); err != nil {
+		return nil, errors.Wrap(err, "parse openapi annotations")
+	}
+
+	if err := validateExtensions("document", a.Extensions); err != nil {
+		return nil, err
+	}
+	for name, ep := range a.Endpoints {
+		if ep == nil {
+			continue
+		} else if !strings.Contains(name, ".") {
+			return nil, errors.Newf("invalid endpoint %q: must be of the form \"service.Endpoint\"", name)
+		} else if err := validateExtensions("endpoint "+name, ep.Extensions); err != nil {
+			return nil, err
+		}
+	}
+	for name, typ := range a.Types {
+		if typ == nil {
+			continue
+		} else if err := validateExtensions("type "+name, typ.Extensions); err != nil {
+			return nil, err
+		}
+	}
+	return &a, nil
+}
+
+func validateExtensions(where string, exts map[string]any) error {
+	for key := range exts {
+		if !strings.HasPrefix(key, "x-") {
+			return errors.Newf("invalid extension %q for %s: extension names must begin with \"x-\"", key, where)
+		}
+	}
+	return nil
+}
+
+// annotateOperation adds the endpoint annotations to an operation.
+func annotateOperation(op *openapi3.Operation, ann *EndpointAnnotations) {
+	if len(ann.Extensions) > 0 {
+		if op.Extensions == nil {
+			op.Extensions = make(map[string]any, len(ann.Extensions))
+		}
+		for k, v := range ann.Extensions {
+			op.Extensions[k] = v
+		}
+	}
+
+	if ann.RequestExample != nil {
+		body := make(map[string]any, len(ann.RequestExample))
+		for k, v := range ann.RequestExample {
+			body[k] = v
+		}
+		for _, p := range op.Parameters {
+			if v, ok := body[p.Value.Name]; ok {
+				p.Value.Example = v
+				delete(body, p.Value.Name)
+			}
+		}
+		if op.RequestBody != nil && len(body) > 0 {
+			if mt := op.RequestBody.Value.Content.Get("application/json"); mt != nil {
+				mt.Example = body
+			}
+		}
+	}
+
+	if ann.ResponseExample != nil {
+		if resp := op.Responses.Get(200); resp != nil && resp.Value != nil {
+			if mt := resp.Value.Content.Get("application/json"); mt != nil {
+				mt.Example = ann.ResponseExample
+			}
+		}
+	}
+}
+
+// annotateSchemas adds the type annotations to the named schemas in the spec.
+// Annotations for types not present in the spec are ignored.
+func (g *Generator) annotateSchemas(types map[string]*TypeAnnotations) {
+	for name, ann := range types {
+		ref := g.spec.Components.Schemas[name]
+		if ann == nil || ref == nil || ref.Value == nil {
+			continue
+		}
+		if ann.Example != nil {
+			ref.Value.Example = ann.Example
+		}
+		if len(ann.Extensions) > 0 {
+			if ref.Value.Extensions == nil {
+				ref.Value.Extensions = make(map[string]any, len(ann.Extensions))
+			}
+			for k, v := range ann.Extensions {
+				ref.Value.Extensions[k] = v
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"go/doc/comment"
+	"slices"
 	"strings"
 
 	"github.com/cockroachdb/errors"
@@ -31,6 +32,7 @@ type Generator struct {
 	ver       GenVersion
 	spec      *openapi3.T
 	md        *meta.Data
+	ann       *Annotations
 	seenDecls map[string]uint32
 }
 
@@ -41,6 +43,13 @@ func New(version GenVersion) *Generator {
 	}
 }
 
+// WithAnnotations configures the generator to embed the given
+// examples and vendor extensions in the generated specification.
+func (g *Generator) WithAnnotations(ann *Annotations) *Generator {
+	g.ann = ann
+	return g
+}
+
 func (g *Generator) Version() int {
 	return int(g.ver)
 }
@@ -58,6 +67,10 @@ func (g *Generator) Generate(p clientgentypes.GenerateParams) (err error) {
 
 	g.md = p.Meta
 	g.spec = newSpec(p.AppSlug)
+	if p.APIVersion != "" {
+		// Describe the version clients send in the X-Encore-API-Version header.
+		g.spec.Info.Extensions["x-encore-api-version"] = p.APIVersion
+	}
 
 	for _, svc := range p.Meta.Svcs {
 		if p.Services.Has(svc.Name) {
@@ -67,6 +80,13 @@ func (g *Generator) Generate(p clientgentypes.GenerateParams) (err error) {
 		}
 	}
 
+	if g.ann != nil {
+		if len(g.ann.Extensions) > 0 {
+			g.spec.Extensions = g.ann.Extensions
+		}
+		g.annotateSchemas(g.ann.Types)
+	}
+
 	out, err := g.spec.MarshalJSON()
 	if err != nil {
 		return errors.Wrap(err, "marshal openapi spec")
@@ -102,6 +122,10 @@ func (g *Generator) addRPC(rpc *meta.RPC) error {
 	if err != nil {
 		return errors.Wrapf(err, "describe rpc %s.%s", rpc.ServiceName, rpc.Name)
 	}
+	pagination, err := clientgentypes.DescribePagination(g.md, rpc, &encoding.Options{})
+	if err != nil {
+		return err
+	}
 
 	for _, reqEnc := range encodings.RequestEncoding {
 		for _, m := range reqEnc.HTTPMethods {
@@ -109,6 +133,17 @@ func (g *Generator) addRPC(rpc *meta.RPC) error {
 			if err != nil {
 				return errors.Wrapf(err, "create operation for rpc %s.%s", rpc.ServiceName, rpc.Name)
 			}
+			if pagination != nil {
+				if op.Extensions == nil {
+					op.Extensions = make(map[string]any)
+				}
+				op.Extensions["x-pagination"] = paginationExtension(pagination, reqEnc)
+			}
+			if g.ann != nil {
+				if ann := g.ann.Endpoints[rpc.ServiceName+"."+rpc.Name]; ann != nil {
+					annotateOperation(op, ann)
+				}
+			}
 			item.SetOperation(m, op)
 		}
 	}
@@ -117,6 +152,27 @@ func (g *Generator) addRPC(rpc *meta.RPC) error {
 	return nil
 }
 
+// paginationExtension describes the cursor pagination of an operation.
+func paginationExtension(pg *clientgentypes.Pagination, reqEnc *encoding.RequestEncoding) map[string]any {
+	cursorIn := "body"
+	if slices.ContainsFunc(reqEnc.QueryParameters, func(p *encoding.ParameterEncoding) bool {
+		return p.Name == pg.Cursor.Name
+	}) {
+		cursorIn = "query"
+	}
+	ext := map[string]any{
+		"type":        "cursor",
+		"cursorParam": pg.Cursor.Name,
+		"cursorIn":    cursorIn,
+		"nextCursor":  "$." + pg.NextCursor.Name,
+		"results":     "$." + pg.Items.Name,
+	}
+	if pg.Limit != nil {
+		ext["limitParam"] = pg.Limit.Name
+	}
+	return ext
+}
+
 func (g *Generator) getOrCreatePath(rpc *meta.RPC) *openapi3.PathItem {
 	path := rpcPath(rpc)
 	if existing, ok := g.spec.Paths[path]; ok {
@@ -215,6 +271,34 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				Content:     g.bodyContent(reqEnc.BodyParameters),
 			},
 		}
+
+		// Endpoints accepting form-encoded bodies accept them in addition to JSON.
+		// The default encoding of form-encoded bodies (style=form, explode=true)
+		// sends lists as repeated keys, matching how query strings are encoded.
+		formParams, err := clientgentypes.FormBodyParameters(rpc, reqEnc)
+		if err != nil {
+			return nil, err
+		} else if formParams != nil {
+			content := op.RequestBody.Value.Content
+			content[clientgentypes.FormContentType] = &openapi3.MediaType{
+				Schema: content["application/json"].Schema,
+			}
+		}
+	}
+
+	// Raw endpoints read the request body and write the response body as streams,
+	// so describe them as arbitrary binary content.
+	if rpc.Proto == meta.RPC_RAW {
+		switch method {
+		case "GET", "HEAD", "DELETE":
+		default:
+			op.RequestBody = &openapi3.RequestBodyRef{
+				Value: &openapi3.RequestBody{
+					Required: false,
+					Content:  rawContent(),
+				},
+			}
+		}
 	}
 
 	// Encode the response
@@ -248,6 +332,9 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 				resp.Content = g.bodyContent(respEnc.BodyParameters)
 			}
 		}
+		if rpc.Proto == meta.RPC_RAW {
+			resp.Content = rawContent()
+		}
 
 		op.Responses["200"] = &openapi3.ResponseRef{
 			Value: resp,
@@ -260,6 +347,15 @@ func (g *Generator) newOperationForEncoding(rpc *meta.RPC, method string, reqEnc
 	return op, nil
 }
 
+// rawContent describes the arbitrary content of raw endpoints' request and response bodies.
+func rawContent() openapi3.Content {
+	return openapi3.Content{
+		"*/*": &openapi3.MediaType{
+			Schema: openapi3.NewStringSchema().WithFormat("binary").NewRef(),
+		},
+	}
+}
+
 func rpcPath(rpc *meta.RPC) string {
 	var b strings.Builder
 	for _, seg := range rpc.Path.Segments {
@@ -0,0 +1,619 @@
+// Package protogen generates Protocol Buffers definitions of the API of an Encore app.
+package protogen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Options configure the generated definitions.
+type Options struct {
+	// Package is the prefix of the proto package names,
+	// which are of the form "<prefix>.<service>". It defaults to "encore".
+	Package string
+
+	// HTTPAnnotations adds google.api.http annotations describing
+	// the HTTP method and path of each endpoint, for use with gRPC gateways.
+	// The generated files then depend on google/api/annotations.proto.
+	HTTPAnnotations bool
+}
+
+// Generate generates a .proto file for each service with public or authenticated endpoints.
+// The files are keyed by file name, such as "users.proto".
+//
+// Each file is self-contained, and includes messages for all the types used by the service's endpoints.
+// Fields use their names on the wire as their JSON names. Fields with a `proto:"<number>"` struct tag
+// get that field number, and the others are numbered in declaration order using the remaining numbers.
+// Raw endpoints are left out, as their requests and responses have no schema.
+func Generate(md *meta.Data, opts Options) (files map[string][]byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if b, ok := r.(bailout); ok {
+				err = b.err
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	if opts.Package == "" {
+		opts.Package = "encore"
+	}
+
+	files = make(map[string][]byte)
+	for _, svc := range md.Svcs {
+		var rpcs []*meta.RPC
+		for _, rpc := range svc.Rpcs {
+			if rpc.AccessType != meta.RPC_PRIVATE {
+				rpcs = append(rpcs, rpc)
+			}
+		}
+		if len(rpcs) == 0 {
+			continue
+		}
+
+		f := &file{
+			md:      md,
+			opts:    opts,
+			svc:     svc,
+			imports: make(map[string]bool),
+			byKey:   make(map[string]string),
+			names:   make(map[string]bool),
+		}
+		files[svc.Name+".proto"] = f.generate(rpcs)
+	}
+	return files, nil
+}
+
+type file struct {
+	md   *meta.Data
+	opts Options
+	svc  *meta.Service
+
+	imports  map[string]bool
+	messages []*message
+	byKey    map[string]string // named type instantiation -> message name
+	names    map[string]bool   // message names in use
+}
+
+type message struct {
+	name   string
+	doc    string
+	fields []*field
+	nested []*message
+}
+
+type field struct {
+	name     string
+	jsonName string
+	number   int // the field number from the proto tag, or 0 if not set
+	doc      string
+	typ      string
+	repeated bool
+	optional bool
+}
+
+func (f *file) generate(rpcs []*meta.RPC) []byte {
+	var svc strings.Builder
+	fmt.Fprintf(&svc, "service %s {\n", idents.Convert(f.svc.Name, idents.PascalCase))
+	for i, rpc := range rpcs {
+		if i > 0 {
+			svc.WriteString("\n")
+		}
+		if rpc.Proto == meta.RPC_RAW {
+			fmt.Fprintf(&svc, "  // %s is a raw endpoint, and has no schema.\n", rpc.Name)
+			continue
+		}
+
+		writeDoc(&svc, "  ", rpc.GetDoc())
+		req, resp := f.requestMessage(rpc), f.responseMessage(rpc)
+		if rpc.StreamingRequest {
+			req = "stream " + req
+		}
+		if rpc.StreamingResponse {
+			resp = "stream " + resp
+		}
+		fmt.Fprintf(&svc, "  rpc %s(%s) returns (%s)", rpc.Name, req, resp)
+		if f.opts.HTTPAnnotations {
+			f.imports["google/api/annotations.proto"] = true
+			method := "post"
+			if len(rpc.HttpMethods) > 0 && rpc.HttpMethods[0] != "*" {
+				method = strings.ToLower(rpc.HttpMethods[0])
+			}
+			svc.WriteString(" {\n")
+			fmt.Fprintf(&svc, "    option (google.api.http) = {\n      %s: %q\n", method, httpPath(rpc.Path))
+			if method != "get" && method != "delete" && method != "head" {
+				svc.WriteString("      body: \"*\"\n")
+			}
+			svc.WriteString("    };\n  }\n")
+		} else {
+			svc.WriteString(";\n")
+		}
+	}
+	svc.WriteString("}\n")
+
+	var b strings.Builder
+	autoNumbered := false
+	for _, m := range f.messages {
+		autoNumbered = numberFields(m) || autoNumbered
+	}
+
+	b.WriteString("// Code generated by encore. DO NOT EDIT.\n\n")
+	if autoNumbered {
+		b.WriteString("// WARNING: Fields without a `proto:\"<number>\"` struct tag are numbered in declaration order,\n")
+		b.WriteString("// so adding, removing or reordering them changes their numbers and breaks compatibility\n")
+		b.WriteString("// with clients generated from earlier versions of this file.\n\n")
+	}
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s.%s;\n", f.opts.Package, strings.ToLower(f.svc.Name))
+
+	if len(f.imports) > 0 {
+		imports := make([]string, 0, len(f.imports))
+		for imp := range f.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		b.WriteString("\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&b, "import %q;\n", imp)
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(svc.String())
+	for _, m := range f.messages {
+		b.WriteString("\n")
+		writeMessage(&b, "", m)
+	}
+	return []byte(b.String())
+}
+
+// requestMessage returns the request message of an endpoint.
+// The path parameters are included as fields, followed by the fields of the request payload.
+func (f *file) requestMessage(rpc *meta.RPC) string {
+	var params []*meta.PathSegment
+	for _, seg := range rpc.Path.GetSegments() {
+		if seg.Type != meta.PathSegment_LITERAL {
+			params = append(params, seg)
+		}
+	}
+
+	if len(params) == 0 {
+		return f.payloadMessage(rpc.RequestSchema, rpc.Name+"Request")
+	}
+
+	m := f.newMessage(rpc.Name + "Request")
+	for _, seg := range params {
+		m.fields = append(m.fields, &field{
+			name:     idents.Convert(seg.Value, idents.SnakeCase),
+			jsonName: seg.Value,
+			typ:      pathParamType(seg.ValueType),
+		})
+	}
+	if rpc.RequestSchema != nil {
+		payload := f.concrete(derefPointers(rpc.RequestSchema))
+		st, ok := payload.Typ.(*schema.Type_Struct)
+		if !ok {
+			doBailout(errors.Newf("request of %s.%s is not a struct", rpc.ServiceName, rpc.Name))
+		}
+		f.addFields(m, st.Struct)
+	}
+	return m.name
+}
+
+func (f *file) responseMessage(rpc *meta.RPC) string {
+	return f.payloadMessage(rpc.ResponseSchema, rpc.Name+"Response")
+}
+
+// payloadMessage returns the message for a request or response payload,
+// creating a message with the given name if the payload is not a named struct.
+func (f *file) payloadMessage(typ *schema.Type, name string) string {
+	if typ == nil {
+		f.imports["google/protobuf/empty.proto"] = true
+		return "google.protobuf.Empty"
+	}
+	typ = derefPointers(typ)
+	if named, ok := typ.Typ.(*schema.Type_Named); ok {
+		if _, isStruct := f.concrete(typ).Typ.(*schema.Type_Struct); isStruct {
+			return f.namedMessage(named.Named)
+		}
+	}
+	if st, ok := typ.Typ.(*schema.Type_Struct); ok {
+		m := f.newMessage(name)
+		f.addFields(m, st.Struct)
+		return m.name
+	}
+
+	doBailout(errors.Newf("payload %s is not a struct", name))
+	panic("unreachable")
+}
+
+// newMessage adds a top-level message with a unique name based on the given name.
+func (f *file) newMessage(name string) *message {
+	for idx, orig := 2, name; f.names[name]; idx++ {
+		name = fmt.Sprintf("%s%d", orig, idx)
+	}
+	f.names[name] = true
+	m := &message{name: name}
+	f.messages = append(f.messages, m)
+	return m
+}
+
+// namedMessage returns the message for a named struct type, generating it if needed.
+func (f *file) namedMessage(named *schema.Named) string {
+	typ := &schema.Type{Typ: &schema.Type_Named{Named: named}}
+	key := f.typeName(typ, true)
+	if name, ok := f.byKey[key]; ok {
+		return name
+	}
+
+	decl := f.md.Decls[named.Id]
+	name := f.typeName(typ, false)
+	if f.names[name] {
+		// Disambiguate declarations with the same name in different packages.
+		name = idents.Convert(decl.Loc.PkgName, idents.PascalCase) + name
+	}
+	m := f.newMessage(name)
+	m.doc = decl.Doc
+	f.byKey[key] = m.name
+
+	st, ok := f.concrete(typ).Typ.(*schema.Type_Struct)
+	if !ok {
+		doBailout(errors.Newf("%s is not a struct", decl.Name))
+	}
+	f.addFields(m, st.Struct)
+	return m.name
+}
+
+func (f *file) addFields(m *message, st *schema.Struct) {
+	for _, fld := range st.Fields {
+		jsonName := fld.JsonName
+		if jsonName == "-" {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = fld.Name
+		}
+
+		number := 0
+		for _, tag := range fld.Tags {
+			if tag.Key != "proto" {
+				continue
+			}
+			n, err := strconv.Atoi(tag.Name)
+			if err != nil || !validFieldNumber(n) {
+				doBailout(errors.Newf("field %s of %s has an invalid proto field number %q", fld.Name, m.name, tag.Name))
+			}
+			number = n
+		}
+
+		nested := idents.Convert(fld.Name, idents.PascalCase)
+		typ, repeated := f.fieldType(fld.Typ, nested, m)
+		_, isPtr := fld.Typ.Typ.(*schema.Type_Pointer)
+		m.fields = append(m.fields, &field{
+			name:     idents.Convert(fld.Name, idents.SnakeCase),
+			jsonName: jsonName,
+			number:   number,
+			doc:      fld.Doc,
+			typ:      typ,
+			repeated: repeated,
+			optional: (fld.Optional || isPtr) && !repeated && !strings.HasPrefix(typ, "map<"),
+		})
+	}
+}
+
+// fieldType returns the protobuf type of a field with the given schema type,
+// and whether it's a repeated field. Anonymous structs are added as nested
+// messages of parent, with the given name.
+func (f *file) fieldType(typ *schema.Type, nestedName string, parent *message) (protoType string, repeated bool) {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		concrete := f.concrete(typ)
+		if _, ok := concrete.Typ.(*schema.Type_Struct); ok {
+			return f.namedMessage(t.Named), false
+		}
+		return f.fieldType(concrete, nestedName, parent)
+
+	case *schema.Type_Struct:
+		m := &message{name: nestedName}
+		parent.nested = append(parent.nested, m)
+		f.addFields(m, t.Struct)
+		return m.name, false
+
+	case *schema.Type_List:
+		elem, rep := f.fieldType(t.List.Elem, nestedName, parent)
+		if rep || strings.HasPrefix(elem, "map<") {
+			// Nested repeated fields can't be represented.
+			return f.valueType(), true
+		}
+		return elem, true
+
+	case *schema.Type_Map:
+		key, _ := f.fieldType(t.Map.Key, nestedName, parent)
+		switch key {
+		case "string", "bool", "int32", "int64", "uint32", "uint64":
+		default:
+			key = "string"
+		}
+		val, rep := f.fieldType(t.Map.Value, nestedName, parent)
+		if rep || strings.HasPrefix(val, "map<") {
+			val = f.valueType()
+		}
+		return fmt.Sprintf("map<%s, %s>", key, val), false
+
+	case *schema.Type_Pointer:
+		return f.fieldType(t.Pointer.Base, nestedName, parent)
+
+	case *schema.Type_Literal:
+		if _, isNull := t.Literal.Value.(*schema.Literal_Null); isNull {
+			return f.valueType(), false
+		}
+		return literalType(t.Literal), false
+
+	case *schema.Type_Union:
+		// Unions of literals of the same type are represented by that type.
+		kind := ""
+		for _, tt := range t.Union.Types {
+			lit, ok := tt.Typ.(*schema.Type_Literal)
+			if !ok {
+				return f.valueType(), false
+			}
+			if _, isNull := lit.Literal.Value.(*schema.Literal_Null); isNull {
+				continue
+			}
+			if k := literalType(lit.Literal); kind == "" {
+				kind = k
+			} else if kind != k {
+				return f.valueType(), false
+			}
+		}
+		if kind == "" {
+			return f.valueType(), false
+		}
+		return kind, false
+
+	case *schema.Type_Config:
+		elem, rep := f.fieldType(t.Config.Elem, nestedName, parent)
+		return elem, rep || t.Config.IsValuesList
+
+	case *schema.Type_TypeParameter:
+		return f.valueType(), false
+
+	case *schema.Type_Builtin:
+		return f.builtinType(t.Builtin), false
+
+	default:
+		doBailout(errors.Newf("unknown schema type %T", t))
+		panic("unreachable")
+	}
+}
+
+func (f *file) builtinType(b schema.Builtin) string {
+	switch b {
+	case schema.Builtin_BOOL:
+		return "bool"
+	case schema.Builtin_INT8, schema.Builtin_INT16, schema.Builtin_INT32:
+		return "int32"
+	case schema.Builtin_INT64, schema.Builtin_INT:
+		return "int64"
+	case schema.Builtin_UINT8, schema.Builtin_UINT16, schema.Builtin_UINT32:
+		return "uint32"
+	case schema.Builtin_UINT64, schema.Builtin_UINT:
+		return "uint64"
+	case schema.Builtin_FLOAT32:
+		return "float"
+	case schema.Builtin_FLOAT64:
+		return "double"
+	case schema.Builtin_STRING, schema.Builtin_UUID, schema.Builtin_USER_ID:
+		return "string"
+	case schema.Builtin_BYTES:
+		return "bytes"
+	case schema.Builtin_TIME:
+		f.imports["google/protobuf/timestamp.proto"] = true
+		return "google.protobuf.Timestamp"
+	case schema.Builtin_ANY, schema.Builtin_JSON:
+		return f.valueType()
+	default:
+		doBailout(errors.Newf("unknown builtin type %v", b))
+		panic("unreachable")
+	}
+}
+
+// valueType returns the type used for values that can't be represented
+// by a more specific type.
+func (f *file) valueType() string {
+	f.imports["google/protobuf/struct.proto"] = true
+	return "google.protobuf.Value"
+}
+
+func literalType(lit *schema.Literal) string {
+	switch lit.Value.(type) {
+	case *schema.Literal_Str:
+		return "string"
+	case *schema.Literal_Boolean:
+		return "bool"
+	case *schema.Literal_Int:
+		return "int64"
+	default:
+		return "double"
+	}
+}
+
+func pathParamType(typ meta.PathSegment_ParamType) string {
+	switch typ {
+	case meta.PathSegment_BOOL:
+		return "bool"
+	case meta.PathSegment_INT8, meta.PathSegment_INT16, meta.PathSegment_INT32:
+		return "int32"
+	case meta.PathSegment_INT64, meta.PathSegment_INT:
+		return "int64"
+	case meta.PathSegment_UINT8, meta.PathSegment_UINT16, meta.PathSegment_UINT32:
+		return "uint32"
+	case meta.PathSegment_UINT64, meta.PathSegment_UINT:
+		return "uint64"
+	default:
+		return "string"
+	}
+}
+
+func derefPointers(typ *schema.Type) *schema.Type {
+	for {
+		ptr, ok := typ.Typ.(*schema.Type_Pointer)
+		if !ok {
+			return typ
+		}
+		typ = ptr.Pointer.Base
+	}
+}
+
+func (f *file) concrete(typ *schema.Type) *schema.Type {
+	concrete, err := encoding.GetConcreteType(f.md.Decls, typ, nil)
+	if err != nil {
+		doBailout(errors.Wrap(err, "get concrete type"))
+	}
+	return concrete
+}
+
+// typeName returns the message name for a type, including the names of any type arguments.
+// If qualified is true the names include package names, making them unique.
+func (f *file) typeName(typ *schema.Type, qualified bool) string {
+	switch t := typ.Typ.(type) {
+	case *schema.Type_Named:
+		decl := f.md.Decls[t.Named.Id]
+		name := decl.Name
+		if qualified {
+			name = decl.Loc.PkgPath + "." + name
+		}
+		for _, arg := range t.Named.TypeArguments {
+			name += f.typeName(arg, qualified)
+		}
+		return name
+	case *schema.Type_List:
+		return "List" + f.typeName(t.List.Elem, qualified)
+	case *schema.Type_Map:
+		return "Map" + f.typeName(t.Map.Key, qualified) + f.typeName(t.Map.Value, qualified)
+	case *schema.Type_Pointer:
+		return f.typeName(t.Pointer.Base, qualified)
+	case *schema.Type_Config:
+		return f.typeName(t.Config.Elem, qualified)
+	case *schema.Type_Builtin:
+		return idents.Convert(strings.ToLower(t.Builtin.String()), idents.PascalCase)
+	default:
+		return "Any"
+	}
+}
+
+// httpPath converts an endpoint path to a google.api.http path template.
+func httpPath(path *meta.Path) string {
+	var b strings.Builder
+	for _, seg := range path.GetSegments() {
+		b.WriteString("/")
+		switch seg.Type {
+		case meta.PathSegment_LITERAL:
+			b.WriteString(seg.Value)
+		case meta.PathSegment_PARAM:
+			fmt.Fprintf(&b, "{%s}", idents.Convert(seg.Value, idents.SnakeCase))
+		default:
+			fmt.Fprintf(&b, "{%s=**}", idents.Convert(seg.Value, idents.SnakeCase))
+		}
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+// validFieldNumber reports whether n is a valid protobuf field number,
+// which excludes the numbers reserved for the protobuf implementation.
+func validFieldNumber(n int) bool {
+	return n >= 1 && n <= 1<<29-1 && (n < 19000 || n > 19999)
+}
+
+// numberFields assigns field numbers to the fields of m and its nested messages
+// that don't have one, in declaration order, skipping the numbers already in use.
+// It reports whether any field was numbered.
+func numberFields(m *message) (numbered bool) {
+	for _, n := range m.nested {
+		numbered = numberFields(n) || numbered
+	}
+
+	used := make(map[int]string)
+	for _, fld := range m.fields {
+		if fld.number == 0 {
+			continue
+		}
+		if other, ok := used[fld.number]; ok {
+			doBailout(errors.Newf("fields %s and %s of %s have the same proto field number %d", other, fld.name, m.name, fld.number))
+		}
+		used[fld.number] = fld.name
+	}
+
+	next := 1
+	for _, fld := range m.fields {
+		if fld.number != 0 {
+			continue
+		}
+		for used[next] != "" || !validFieldNumber(next) {
+			next++
+		}
+		fld.number = next
+		used[next] = fld.name
+		numbered = true
+	}
+	return numbered
+}
+
+func writeMessage(b *strings.Builder, indent string, m *message) {
+	writeDoc(b, indent, m.doc)
+	fmt.Fprintf(b, "%smessage %s {\n", indent, m.name)
+	for _, n := range m.nested {
+		writeMessage(b, indent+"  ", n)
+		b.WriteString("\n")
+	}
+	for _, fld := range m.fields {
+		writeDoc(b, indent+"  ", fld.doc)
+		b.WriteString(indent + "  ")
+		if fld.repeated {
+			b.WriteString("repeated ")
+		} else if fld.optional {
+			b.WriteString("optional ")
+		}
+		fmt.Fprintf(b, "%s %s = %d", fld.typ, fld.name, fld.number)
+		if fld.jsonName != idents.Convert(fld.name, idents.CamelCase) {
+			fmt.Fprintf(b, " [json_name = %q]", fld.jsonName)
+		}
+		b.WriteString(";\n")
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func writeDoc(b *strings.Builder, indent, doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		if line = strings.TrimRight(line, " \t"); line == "" {
+			fmt.Fprintf(b, "%s//\n", indent)
+		} else {
+			fmt.Fprintf(b, "%s// %s\n", indent, line)
+		}
+	}
+}
+
+type bailout struct {
+	err error
+}
+
+func doBailout(err error) {
+	panic(bailout{err})
+}
@@ -0,0 +1,162 @@
+package protogen
+
+import (
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func builtin(b schema.Builtin) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Builtin{Builtin: b}}
+}
+
+func named(id uint32) *schema.Type {
+	return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id}}}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func testMeta() *meta.Data {
+	return &meta.Data{
+		Decls: []*schema.Decl{
+			{
+				Id: 0, Name: "User", Doc: "User is a user.\n", Loc: &schema.Loc{PkgPath: "users", PkgName: "users"},
+				Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{
+					{Name: "ID", JsonName: "id", Typ: builtin(schema.Builtin_UUID), Tags: []*schema.Tag{{Key: "proto", Name: "2"}}},
+					{Name: "DisplayName", JsonName: "display_name", Typ: builtin(schema.Builtin_STRING), Optional: true},
+					{Name: "CreatedAt", Typ: builtin(schema.Builtin_TIME)},
+					{Name: "Roles", JsonName: "roles", Typ: &schema.Type{Typ: &schema.Type_List{List: &schema.List{Elem: named(1)}}}},
+					{Name: "Labels", JsonName: "labels", Typ: &schema.Type{Typ: &schema.Type_Map{Map: &schema.Map{
+						Key: builtin(schema.Builtin_STRING), Value: builtin(schema.Builtin_STRING),
+					}}}},
+					{Name: "Secret", JsonName: "-", Typ: builtin(schema.Builtin_STRING)},
+				}}}},
+			},
+			{
+				Id: 1, Name: "Role", Loc: &schema.Loc{PkgPath: "users", PkgName: "users"},
+				Type: &schema.Type{Typ: &schema.Type_Union{Union: &schema.Union{Types: []*schema.Type{
+					{Typ: &schema.Type_Literal{Literal: &schema.Literal{Value: &schema.Literal_Str{Str: "admin"}}}},
+					{Typ: &schema.Type_Literal{Literal: &schema.Literal{Value: &schema.Literal_Str{Str: "member"}}}},
+				}}}},
+			},
+			{
+				Id: 2, Name: "UpdateParams", Loc: &schema.Loc{PkgPath: "users", PkgName: "users"},
+				Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{
+					{Name: "DisplayName", JsonName: "display_name", Typ: builtin(schema.Builtin_STRING)},
+				}}}},
+			},
+		},
+		Svcs: []*meta.Service{{
+			Name: "users",
+			Rpcs: []*meta.RPC{
+				{
+					Name: "Get", ServiceName: "users", Doc: ptr("Get returns a user."), AccessType: meta.RPC_PUBLIC,
+					HttpMethods:    []string{"GET"},
+					Path:           &meta.Path{Segments: []*meta.PathSegment{{Value: "users"}, {Type: meta.PathSegment_PARAM, Value: "id"}}},
+					ResponseSchema: named(0),
+				},
+				{
+					Name: "Update", ServiceName: "users", AccessType: meta.RPC_AUTH,
+					HttpMethods:    []string{"PATCH"},
+					Path:           &meta.Path{Segments: []*meta.PathSegment{{Value: "users"}, {Type: meta.PathSegment_PARAM, Value: "id"}}},
+					RequestSchema:  &schema.Type{Typ: &schema.Type_Pointer{Pointer: &schema.Pointer{Base: named(2)}}},
+					ResponseSchema: named(0),
+				},
+				{
+					Name: "Webhook", ServiceName: "users", AccessType: meta.RPC_PUBLIC, Proto: meta.RPC_RAW,
+					Path: &meta.Path{Segments: []*meta.PathSegment{{Value: "webhook"}}},
+				},
+				{
+					Name: "Internal", ServiceName: "users", AccessType: meta.RPC_PRIVATE,
+					Path: &meta.Path{Segments: []*meta.PathSegment{{Value: "internal"}}},
+				},
+			},
+		}, {
+			Name: "cron",
+		}},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	files, err := Generate(testMeta(), Options{HTTPAnnotations: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 1)
+	c.Assert(string(files["users.proto"]), qt.Equals, `// Code generated by encore. DO NOT EDIT.
+
+// WARNING: Fields without a `+"`"+`proto:"<number>"`+"`"+` struct tag are numbered in declaration order,
+// so adding, removing or reordering them changes their numbers and breaks compatibility
+// with clients generated from earlier versions of this file.
+
+syntax = "proto3";
+
+package encore.users;
+
+import "google/api/annotations.proto";
+import "google/protobuf/timestamp.proto";
+
+service Users {
+  // Get returns a user.
+  rpc Get(GetRequest) returns (User) {
+    option (google.api.http) = {
+      get: "/users/{id}"
+    };
+  }
+
+  rpc Update(UpdateRequest) returns (User) {
+    option (google.api.http) = {
+      patch: "/users/{id}"
+      body: "*"
+    };
+  }
+
+  // Webhook is a raw endpoint, and has no schema.
+}
+
+message GetRequest {
+  string id = 1;
+}
+
+// User is a user.
+message User {
+  string id = 2;
+  optional string display_name = 1 [json_name = "display_name"];
+  google.protobuf.Timestamp created_at = 3 [json_name = "CreatedAt"];
+  repeated string roles = 4;
+  map<string, string> labels = 5;
+}
+
+message UpdateRequest {
+  string id = 1;
+  string display_name = 2 [json_name = "display_name"];
+}
+`)
+}
+
+func TestGenerateFieldNumbers(t *testing.T) {
+	c := qt.New(t)
+	md := testMeta()
+	md.Svcs[0].Rpcs = md.Svcs[0].Rpcs[:1] // only users.Get, without path parameters
+	md.Svcs[0].Rpcs[0].Path = &meta.Path{Segments: []*meta.PathSegment{{Value: "me"}}}
+	fields := md.Decls[0].Type.GetStruct().Fields
+	for i, fld := range fields {
+		fld.Tags = []*schema.Tag{{Key: "proto", Name: strconv.Itoa(i + 1)}}
+	}
+
+	// There's no warning when all fields are numbered explicitly.
+	files, err := Generate(md, Options{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(files["users.proto"]), qt.Not(qt.Contains), "WARNING")
+
+	fields[1].Tags[0].Name = "1"
+	_, err = Generate(md, Options{})
+	c.Assert(err, qt.ErrorMatches, "fields id and display_name of User have the same proto field number 1")
+
+	fields[1].Tags[0].Name = "19000"
+	_, err = Generate(md, Options{})
+	c.Assert(err, qt.ErrorMatches, `field DisplayName of User has an invalid proto field number "19000"`)
+}
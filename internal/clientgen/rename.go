@@ -0,0 +1,42 @@
+package clientgen
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// renameServices returns a copy of md where the services are renamed according to names,
+// together with the corresponding service set.
+//
+// The services' types are renamed along with them, as the generators namespace the types
+// by their package name. The endpoints' paths are left as is, so the generated clients
+// still call the same API.
+func renameServices(md *meta.Data, services clientgentypes.ServiceSet, names map[string]string) (*meta.Data, clientgentypes.ServiceSet) {
+	rename := func(name string) string {
+		if n, ok := names[name]; ok && n != "" {
+			return n
+		}
+		return name
+	}
+
+	md = proto.Clone(md).(*meta.Data)
+	for _, svc := range md.Svcs {
+		svc.Name = rename(svc.Name)
+		for _, rpc := range svc.Rpcs {
+			rpc.ServiceName = rename(rpc.ServiceName)
+		}
+	}
+	for _, decl := range md.Decls {
+		if decl.Loc != nil {
+			decl.Loc.PkgName = rename(decl.Loc.PkgName)
+		}
+	}
+
+	included := make([]string, 0, len(services.List()))
+	for _, svc := range services.List() {
+		included = append(included, rename(svc))
+	}
+	return md, clientgentypes.NewServiceSet(md, included, nil)
+}
@@ -0,0 +1,596 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Globalization;
+using System.Linq;
+using System.Net.Http;
+using System.Text;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Threading;
+using System.Threading.Tasks;
+
+namespace Encore.App;
+
+/// <summary>
+/// Environments contains the base URLs for calling the app Encore application's API.
+/// </summary>
+public static class Environments
+{
+    /// <summary>
+    /// Local is the base URL of the locally running application.
+    /// </summary>
+    public const string Local = "http://localhost:4000";
+
+    /// <summary>
+    /// Environment returns the base URL for calling the cloud environment with the given name.
+    /// </summary>
+    public static string Environment(string name) => $"https://{name}-app.encr.app";
+
+    /// <summary>
+    /// PreviewEnv returns the base URL for calling the preview environment with the given PR number.
+    /// </summary>
+    public static string PreviewEnv(int pr) => Environment($"pr{pr}");
+
+    /// <summary>
+    /// APIVersion is the version of the API the client was generated for.
+    /// </summary>
+    public const string APIVersion = "736d2ce94d4ed7f1";
+}
+
+/// <summary>
+/// Client is an API client for the app Encore application.
+/// </summary>
+public sealed class Client
+{
+    public ISvcClient Svc { get; }
+
+    /// <summary>
+    /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+    /// </summary>
+    /// <param name="baseUrl">The base URL of the application. See <see cref="Environments"/> for options.</param>
+    /// <param name="options">Options for the client.</param>
+    public Client(string baseUrl, ClientOptions? options = null)
+    {
+        var baseClient = new BaseClient(baseUrl, options ?? new ClientOptions());
+        Svc = new SvcClient(baseClient);
+    }
+}
+
+/// <summary>
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+/// </summary>
+public sealed class ClientOptions
+{
+    /// <summary>
+    /// The HTTP client used to make the API requests. By default a new HttpClient is created,
+    /// however you can provide your own to configure timeouts, proxies or message handlers
+    /// which run custom code on each API request made or response received.
+    /// </summary>
+    public HttpClient? HttpClient { get; set; }
+
+    /// <summary>
+    /// Headers which are added to each API request.
+    /// </summary>
+    public IDictionary<string, string> Headers { get; } = new Dictionary<string, string>();
+
+    /// <summary>
+    /// Whether to send <see cref="Environments.APIVersion"/> with each API request, so that the API
+    /// rejects the requests if the client is out of date. Such requests fail with an
+    /// <see cref="APIException"/> whose <see cref="APIException.IsClientOutOfDate"/> is true.
+    /// </summary>
+    public bool VerifyAPIVersion { get; set; }
+
+    /// <summary>
+    /// The auth token to be used for each API request,
+    /// sent as a bearer token in the Authorization header.
+    /// </summary>
+    public string? Auth { get; set; }
+
+    /// <summary>
+    /// A function which is called before each API request and returns the authentication data to use,
+    /// for when the credentials can change during the lifetime of the client. It takes precedence over Auth.
+    /// </summary>
+    public Func<CancellationToken, Task<string?>>? AuthGenerator { get; set; }
+}
+
+public partial class SvcRequest
+{
+    [JsonPropertyName("Message")]
+    public string Message { get; set; } = default!;
+}
+
+/// <summary>
+/// ISvcClient provides access to call the public and authenticated APIs of the svc service.
+/// It allows you to create mock implementations of the client during tests.
+/// </summary>
+public interface ISvcClient
+{
+    /// <summary>
+    /// DummyAPI is a dummy endpoint.
+    /// </summary>
+    Task DummyAPIAsync(SvcRequest @params, CancellationToken cancellationToken = default);
+
+    /// <summary>
+    /// Private is a basic auth endpoint.
+    /// </summary>
+    Task PrivateAsync(SvcRequest @params, CancellationToken cancellationToken = default);
+}
+
+/// <summary>
+/// SvcClient is the implementation of <see cref="ISvcClient"/>.
+/// </summary>
+public sealed class SvcClient : ISvcClient
+{
+    private readonly BaseClient baseClient;
+
+    internal SvcClient(BaseClient baseClient)
+    {
+        this.baseClient = baseClient;
+    }
+
+    /// <inheritdoc/>
+    public async Task DummyAPIAsync(SvcRequest @params, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.DummyAPI", @params, null, null, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task PrivateAsync(SvcRequest @params, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.Private", @params, null, null, cancellationToken).ConfigureAwait(false);
+    }
+}
+
+/// <summary>
+/// BaseClient holds all the information we need to make requests to an Encore application.
+/// </summary>
+internal sealed class BaseClient
+{
+    internal static readonly JsonSerializerOptions JsonOptions = new(JsonSerializerDefaults.Web);
+
+    private const string UserAgent = "app-Generated-CSharp-Client (Encore/v0.0.0-develop)";
+
+    private readonly string baseUrl;
+    private readonly HttpClient httpClient;
+    private readonly ClientOptions options;
+
+    internal BaseClient(string baseUrl, ClientOptions options)
+    {
+        this.baseUrl = baseUrl.TrimEnd('/');
+        this.httpClient = options.HttpClient ?? new HttpClient();
+        this.options = options;
+    }
+
+    // CallApiAsync is used by each generated API method to actually make the request.
+    // It throws an APIException if the API returns an error.
+    internal async Task<HttpResponseMessage> CallApiAsync(HttpMethod method, string path, object? body, Dictionary<string, object?>? headers, Dictionary<string, object?>? query, CancellationToken cancellationToken)
+    {
+        using var request = new HttpRequestMessage(method, baseUrl + path);
+        if (body != null)
+        {
+            var json = JsonSerializer.Serialize(body, body.GetType(), JsonOptions);
+            request.Content = new StringContent(json, Encoding.UTF8, "application/json");
+        }
+
+        var response = await SendAsync(request, headers, query, cancellationToken).ConfigureAwait(false);
+        if (!response.IsSuccessStatusCode)
+        {
+            using (response)
+            {
+                throw await ReadErrorAsync(response, cancellationToken).ConfigureAwait(false);
+            }
+        }
+        return response;
+    }
+
+    // SendRawAsync sends a request to a raw API endpoint, returning the response as is.
+    internal Task<HttpResponseMessage> SendRawAsync(string path, HttpRequestMessage request, CancellationToken cancellationToken)
+    {
+        // Keep any query string of the request, but make sure it hits the right base URL
+        request.RequestUri = new Uri(baseUrl + path + (request.RequestUri?.IsAbsoluteUri == true ? request.RequestUri.Query : ""));
+        return SendAsync(request, null, null, cancellationToken);
+    }
+
+    // SendAsync sends the request to the Encore application, adding the headers,
+    // query string and authorization data as required.
+    private async Task<HttpResponseMessage> SendAsync(HttpRequestMessage request, Dictionary<string, object?>? headers, Dictionary<string, object?>? query, CancellationToken cancellationToken)
+    {
+        headers = headers != null ? new Dictionary<string, object?>(headers) : new Dictionary<string, object?>();
+        query = query != null ? new Dictionary<string, object?>(query) : new Dictionary<string, object?>();
+        await AddAuthDataAsync(headers, query, cancellationToken).ConfigureAwait(false);
+
+        if (query.Count > 0)
+        {
+            var uri = request.RequestUri!.ToString();
+            request.RequestUri = new Uri(uri + (uri.Contains('?') ? "&" : "?") + ClientEncoding.EncodeQuery(query));
+        }
+
+        request.Headers.TryAddWithoutValidation("User-Agent", UserAgent);
+        if (options.VerifyAPIVersion)
+        {
+            request.Headers.TryAddWithoutValidation("X-Encore-API-Version", Environments.APIVersion);
+        }
+        foreach (var header in options.Headers)
+        {
+            request.Headers.TryAddWithoutValidation(header.Key, header.Value);
+        }
+        foreach (var header in headers)
+        {
+            foreach (var value in ClientEncoding.Values(header.Value))
+            {
+                if (!request.Headers.TryAddWithoutValidation(header.Key, value))
+                {
+                    request.Content?.Headers.TryAddWithoutValidation(header.Key, value);
+                }
+            }
+        }
+
+        return await httpClient.SendAsync(request, cancellationToken).ConfigureAwait(false);
+    }
+
+    // AddAuthDataAsync adds the authentication data to the request, if there is any.
+    private async Task AddAuthDataAsync(Dictionary<string, object?> headers, Dictionary<string, object?> query, CancellationToken cancellationToken)
+    {
+        var authData = options.AuthGenerator != null
+            ? await options.AuthGenerator(cancellationToken).ConfigureAwait(false)
+            : options.Auth;
+        if (authData == null)
+        {
+            return;
+        }
+
+        headers["Authorization"] = "Bearer " + authData;
+    }
+
+    // ReadJsonAsync decodes the JSON body of the response.
+    internal static async Task<T> ReadJsonAsync<T>(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        using var stream = await response.Content.ReadAsStreamAsync(cancellationToken).ConfigureAwait(false);
+        var value = await JsonSerializer.DeserializeAsync<T>(stream, JsonOptions, cancellationToken).ConfigureAwait(false);
+        return value ?? throw new APIException((int)response.StatusCode, ErrCode.DataLoss, "response body was unexpectedly null");
+    }
+
+    // ReadErrorAsync reads the structured error from a failed API call.
+    private static async Task<APIException> ReadErrorAsync(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        var status = (int)response.StatusCode;
+        var body = await response.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+        try
+        {
+            var error = JsonSerializer.Deserialize<APIErrorResponse>(body, JsonOptions);
+            if (error?.Code != null && error.Message != null)
+            {
+                return new APIException(status, APIException.ParseCode(error.Code), error.Message, error.Details);
+            }
+        }
+        catch (JsonException)
+        {
+            // Not a structured error, so fall back to the raw body below.
+        }
+        return new APIException(status, ErrCode.Unknown, $"request failed: status {status}: {body}");
+    }
+
+    private sealed class APIErrorResponse
+    {
+        [JsonPropertyName("code")]
+        public string? Code { get; set; }
+
+        [JsonPropertyName("message")]
+        public string? Message { get; set; }
+
+        [JsonPropertyName("details")]
+        public JsonElement? Details { get; set; }
+    }
+}
+
+/// <summary>
+/// ClientEncoding encodes values sent in paths, headers and query strings.
+/// </summary>
+internal static class ClientEncoding
+{
+    // Format formats a single value.
+    internal static string Format(object value) => value switch
+    {
+        string s => s,
+        bool b => b ? "true" : "false",
+        DateTimeOffset t => t.ToString("O", CultureInfo.InvariantCulture),
+        byte[] bytes => Convert.ToBase64String(bytes),
+        JsonElement json => json.GetRawText(),
+        IFormattable f => f.ToString(null, CultureInfo.InvariantCulture),
+        _ => value.ToString() ?? "",
+    };
+
+    // Values formats the values of a header or query string parameter,
+    // which has one value per element if it's a list.
+    internal static IEnumerable<string> Values(object? value)
+    {
+        switch (value)
+        {
+            case null:
+                yield break;
+            case string or byte[]:
+                yield return Format(value);
+                break;
+            case IEnumerable list:
+                foreach (var item in list)
+                {
+                    if (item != null)
+                    {
+                        yield return Format(item);
+                    }
+                }
+                break;
+            default:
+                yield return Format(value);
+                break;
+        }
+    }
+
+    // EscapePath escapes a list of path segments and joins them into a single path.
+    internal static string EscapePath(IEnumerable<string> segments) =>
+        string.Join("/", segments.Select(Uri.EscapeDataString));
+
+    // EncodeQuery encodes the parameters as a query string.
+    internal static string EncodeQuery(Dictionary<string, object?> query) =>
+        string.Join("&", query.SelectMany(p => Values(p.Value).Select(v => Uri.EscapeDataString(p.Key) + "=" + Uri.EscapeDataString(v))));
+}
+
+/// <summary>
+/// APIException represents a structured error as returned from an Encore application.
+/// </summary>
+public sealed class APIException : Exception
+{
+    /// <summary>
+    /// The HTTP status code associated with the error.
+    /// </summary>
+    public int Status { get; }
+
+    /// <summary>
+    /// The Encore error code.
+    /// </summary>
+    public ErrCode Code { get; }
+
+    /// <summary>
+    /// The error details, if any.
+    /// </summary>
+    public JsonElement? Details { get; }
+
+    /// <summary>
+    /// Whether the API rejected the request because the client was generated
+    /// for a different version of the API. Regenerate the client to fix it.
+    /// </summary>
+    public bool IsClientOutOfDate =>
+        Code == ErrCode.FailedPrecondition &&
+        Details is { ValueKind: JsonValueKind.Object } details &&
+        details.TryGetProperty("client_out_of_date", out var outOfDate) &&
+        outOfDate.ValueKind == JsonValueKind.True;
+
+    public APIException(int status, ErrCode code, string message, JsonElement? details = null) : base(message)
+    {
+        Status = status;
+        Code = code;
+        Details = details;
+    }
+
+    internal static ErrCode ParseCode(string code) => code switch
+    {
+        "ok" => ErrCode.OK,
+        "canceled" => ErrCode.Canceled,
+        "unknown" => ErrCode.Unknown,
+        "invalid_argument" => ErrCode.InvalidArgument,
+        "deadline_exceeded" => ErrCode.DeadlineExceeded,
+        "not_found" => ErrCode.NotFound,
+        "already_exists" => ErrCode.AlreadyExists,
+        "permission_denied" => ErrCode.PermissionDenied,
+        "resource_exhausted" => ErrCode.ResourceExhausted,
+        "failed_precondition" => ErrCode.FailedPrecondition,
+        "aborted" => ErrCode.Aborted,
+        "out_of_range" => ErrCode.OutOfRange,
+        "unimplemented" => ErrCode.Unimplemented,
+        "internal" => ErrCode.Internal,
+        "unavailable" => ErrCode.Unavailable,
+        "data_loss" => ErrCode.DataLoss,
+        "unauthenticated" => ErrCode.Unauthenticated,
+        _ => ErrCode.Unknown,
+    };
+}
+
+/// <summary>
+/// ErrCode is the error code of an APIException.
+/// </summary>
+public enum ErrCode
+{
+    /// <summary>
+    /// OK indicates the operation was successful.
+    /// </summary>
+    OK,
+
+    /// <summary>
+    /// Canceled indicates the operation was canceled (typically by the caller).
+    ///
+    /// Encore will generate this error code when cancellation is requested.
+    /// </summary>
+    Canceled,
+
+    /// <summary>
+    /// Unknown error. An example of where this error may be returned is
+    /// if a Status value received from another address space belongs to
+    /// an error-space that is not known in this address space. Also
+    /// errors raised by APIs that do not return enough error information
+    /// may be converted to this error.
+    ///
+    /// Encore will generate this error code in the above two mentioned cases.
+    /// </summary>
+    Unknown,
+
+    /// <summary>
+    /// InvalidArgument indicates client specified an invalid argument.
+    /// Note that this differs from FailedPrecondition. It indicates arguments
+    /// that are problematic regardless of the state of the system
+    /// (e.g., a malformed file name).
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    InvalidArgument,
+
+    /// <summary>
+    /// DeadlineExceeded means operation expired before completion.
+    /// For operations that change the state of the system, this error may be
+    /// returned even if the operation has completed successfully. For
+    /// example, a successful response from a server could have been delayed
+    /// long enough for the deadline to expire.
+    ///
+    /// The gRPC framework will generate this error code when the deadline is
+    /// exceeded.
+    /// </summary>
+    DeadlineExceeded,
+
+    /// <summary>
+    /// NotFound means some requested entity (e.g., file or directory) was
+    /// not found.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    NotFound,
+
+    /// <summary>
+    /// AlreadyExists means an attempt to create an entity failed because one
+    /// already exists.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    AlreadyExists,
+
+    /// <summary>
+    /// PermissionDenied indicates the caller does not have permission to
+    /// execute the specified operation. It must not be used for rejections
+    /// caused by exhausting some resource (use ResourceExhausted
+    /// instead for those errors). It must not be
+    /// used if the caller cannot be identified (use Unauthenticated
+    /// instead for those errors).
+    ///
+    /// This error code will not be generated by the gRPC core framework,
+    /// but expect authentication middleware to use it.
+    /// </summary>
+    PermissionDenied,
+
+    /// <summary>
+    /// ResourceExhausted indicates some resource has been exhausted, perhaps
+    /// a per-user quota, or perhaps the entire file system is out of space.
+    ///
+    /// This error code will be generated by the gRPC framework in
+    /// out-of-memory and server overload situations, or when a message is
+    /// larger than the configured maximum size.
+    /// </summary>
+    ResourceExhausted,
+
+    /// <summary>
+    /// FailedPrecondition indicates operation was rejected because the
+    /// system is not in a state required for the operation's execution.
+    /// For example, directory to be deleted may be non-empty, an rmdir
+    /// operation is applied to a non-directory, etc.
+    ///
+    /// A litmus test that may help a service implementor in deciding
+    /// between FailedPrecondition, Aborted, and Unavailable:
+    ///  (a) Use Unavailable if the client can retry just the failing call.
+    ///  (b) Use Aborted if the client should retry at a higher-level
+    ///      (e.g., restarting a read-modify-write sequence).
+    ///  (c) Use FailedPrecondition if the client should not retry until
+    ///      the system state has been explicitly fixed. E.g., if an "rmdir"
+    ///      fails because the directory is non-empty, FailedPrecondition
+    ///      should be returned since the client should not retry unless
+    ///      they have first fixed up the directory by deleting files from it.
+    ///  (d) Use FailedPrecondition if the client performs conditional
+    ///      REST Get/Update/Delete on a resource and the resource on the
+    ///      server does not match the condition. E.g., conflicting
+    ///      read-modify-write on the same resource.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    FailedPrecondition,
+
+    /// <summary>
+    /// Aborted indicates the operation was aborted, typically due to a
+    /// concurrency issue like sequencer check failures, transaction aborts,
+    /// etc.
+    ///
+    /// See litmus test above for deciding between FailedPrecondition,
+    /// Aborted, and Unavailable.
+    /// </summary>
+    Aborted,
+
+    /// <summary>
+    /// OutOfRange means operation was attempted past the valid range.
+    /// E.g., seeking or reading past end of file.
+    ///
+    /// Unlike InvalidArgument, this error indicates a problem that may
+    /// be fixed if the system state changes. For example, a 32-bit file
+    /// may be rotated to a 64-bit file without error.
+    ///
+    /// There is a fair bit of overlap between FailedPrecondition and
+    /// OutOfRange. We recommend using OutOfRange (the more specific
+    /// error) when it applies so that callers who are iterating through
+    /// a space can easily look for an OutOfRange error to detect when
+    /// they are done.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    OutOfRange,
+
+    /// <summary>
+    /// Unimplemented indicates operation is not implemented or not
+    /// supported/enabled in this service.
+    ///
+    /// This is not an error, but a feature not available.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    Unimplemented,
+
+    /// <summary>
+    /// Internal means some invariant expected by the underlying system has
+    /// been broken. This is not a per-message error, it is a global
+    /// conditions check.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    Internal,
+
+    /// <summary>
+    /// Unavailable indicates the service is currently unavailable.
+    /// This is most likely a transient condition, which can be corrected by
+    /// retrying with a backoff.
+    ///
+    /// See litmus test above for deciding between FailedPrecondition,
+    /// Aborted, and Unavailable.
+    /// </summary>
+    Unavailable,
+
+    /// <summary>
+    /// DataLoss indicates unrecoverable data loss or corruption.
+    ///
+    /// This error code is only defined in the gRPC library, and only for
+    /// unrecoverable data loss (i.e., data loss resulting from errors
+    /// like hard disk corruption or bandwidth exceeded).
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    DataLoss,
+
+    /// <summary>
+    /// Unauthenticated indicates the request does not have valid
+    /// authentication credentials for the operation.
+    ///
+    /// The gRPC framework will generate this error code when the
+    /// authentication metadata is invalid or a Credentials callback fails,
+    /// but also expect authentication middleware to generate it.
+    /// </summary>
+    Unauthenticated,
+}
@@ -6,10 +6,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // Client is an API client for the app Encore application.
@@ -22,6 +24,9 @@ type BaseURL string
 
 const Local BaseURL = "http://localhost:4000"
 
+// APIVersion is the version of the API the client was generated for.
+const APIVersion = "736d2ce94d4ed7f1"
+
 // Environment returns a BaseURL for calling the cloud environment with the given name.
 func Environment(name string) BaseURL {
 	return BaseURL(fmt.Sprintf("https://%s-app.encr.app", name))
@@ -71,6 +76,39 @@ func WithHTTPClient(client HTTPDoer) Option {
 	}
 }
 
+// WithMiddleware adds middleware which is called for each API request made by the client,
+// such as to add tracing headers, log requests or refresh expired credentials.
+//
+// Middleware is called in the order given, before any middleware given using CallMiddleware.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(base *baseClient) error {
+		base.middleware = append(base.middleware, middleware...)
+		return nil
+	}
+}
+
+// WithRetries configures the client to retry failed API calls to idempotent endpoints
+// using the given policy. It can be overridden for individual calls using CallRetries.
+//
+// By default API calls are not retried.
+func WithRetries(policy RetryPolicy) Option {
+	return func(base *baseClient) error {
+		base.retries = &policy
+		return nil
+	}
+}
+
+// WithAPIVersionCheck configures the client to send APIVersion with each request,
+// so that the API rejects the requests if the client is out of date.
+//
+// Use IsClientOutOfDate to detect such errors.
+func WithAPIVersionCheck() Option {
+	return func(base *baseClient) error {
+		base.verifyAPIVersion = true
+		return nil
+	}
+}
+
 // WithAuthToken allows you to set an authentication token to be used for each request.
 //
 // This token will be sent as a Bearer token in the Authorization header.
@@ -132,18 +170,83 @@ type HTTPDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// Middleware is called for each API request made by the client, and calls next to send the request.
+// It can modify the request before it's sent, or inspect and modify the response.
+type Middleware func(req *http.Request, next func(req *http.Request) (*http.Response, error)) (*http.Response, error)
+
+// RetryPolicy configures how failed API calls to idempotent endpoints are retried.
+// Only calls using the GET, HEAD, PUT, DELETE and OPTIONS HTTP methods are retried.
+type RetryPolicy struct {
+	MaxAttempts    int           // The maximum number of attempts, including the first one
+	InitialBackoff time.Duration // The delay before the first retry, doubled for each following retry (defaults to 100ms)
+	MaxBackoff     time.Duration // The maximum delay between attempts (defaults to 5s)
+
+	// ShouldRetry reports whether a failed attempt should be retried, given its response or error.
+	// Defaults to retrying network errors and 429, 502, 503 and 504 responses.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// CallOption configures individual API calls. Use WithCallOptions to apply them.
+type CallOption = func(opts *callOptions)
+
+// callOptions are the options of individual API calls
+type callOptions struct {
+	middleware []Middleware
+	retries    *RetryPolicy
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx which applies the given options
+// to the API calls made with it, in addition to any options already in ctx.
+func WithCallOptions(ctx context.Context, options ...CallOption) context.Context {
+	opts := &callOptions{}
+	if prev, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok {
+		opts.middleware = append([]Middleware(nil), prev.middleware...)
+		opts.retries = prev.retries
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, opts)
+}
+
+// CallMiddleware adds middleware to the API calls, called after any middleware given using WithMiddleware.
+func CallMiddleware(middleware ...Middleware) CallOption {
+	return func(opts *callOptions) {
+		opts.middleware = append(opts.middleware, middleware...)
+	}
+}
+
+// CallRetries sets the retry policy of the API calls, overriding any policy given using WithRetries.
+// Use a policy with MaxAttempts set to 1 to disable retries.
+func CallRetries(policy RetryPolicy) CallOption {
+	return func(opts *callOptions) {
+		opts.retries = &policy
+	}
+}
+
 // baseClient holds all the information we need to make requests to an Encore application
 type baseClient struct {
-	authGenerator func(ctx context.Context) (string, error) // The function which will add the authentication data to the requests
-	httpClient    HTTPDoer                                  // The HTTP client which will be used for all API requests
-	baseURL       *url.URL                                  // The base URL which API requests will be made against
-	userAgent     string                                    // What user agent we will use in the API requests
+	authGenerator    func(ctx context.Context) (string, error) // The function which will add the authentication data to the requests
+	httpClient       HTTPDoer                                  // The HTTP client which will be used for all API requests
+	baseURL          *url.URL                                  // The base URL which API requests will be made against
+	userAgent        string                                    // What user agent we will use in the API requests
+	middleware       []Middleware                              // The middleware called for each API request
+	retries          *RetryPolicy                              // How failed API calls are retried, or nil if they're not retried
+	verifyAPIVersion bool                                      // Whether to send APIVersion with each request
 }
 
 // Do sends the req to the Encore application adding the authorization token as required.
 func (b *baseClient) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Content-Type", "application/json")
+	// Requests are JSON-encoded unless the caller specified another content type
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("User-Agent", b.userAgent)
+	if b.verifyAPIVersion {
+		req.Header.Set("X-Encore-API-Version", APIVersion)
+	}
 
 	// If a authorization data generator is present, call it and add the returned token to the request
 	if b.authGenerator != nil {
@@ -158,39 +261,77 @@ func (b *baseClient) Do(req *http.Request) (*http.Response, error) {
 	req.URL = b.baseURL.ResolveReference(req.URL)
 	req.Host = req.URL.Host
 
-	// Finally, make the request via the configured HTTP Client
-	return b.httpClient.Do(req)
+	// Finally, make the request via the configured HTTP Client, passing it through any middleware
+	send := b.httpClient.Do
+	middleware := b.middleware
+	if opts, ok := req.Context().Value(callOptionsKey{}).(*callOptions); ok {
+		middleware = append(middleware[:len(middleware):len(middleware)], opts.middleware...)
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, next := middleware[i], send
+		send = func(req *http.Request) (*http.Response, error) {
+			return mw(req, next)
+		}
+	}
+	return send(req)
 }
 
 // callAPI is used by each generated API method to actually make request and decode the responses
 func callAPI(ctx context.Context, client *baseClient, method, path string, headers http.Header, body, resp any) (http.Header, error) {
 	// Encode the API body
-	var bodyReader io.Reader
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+		var err error
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshal request: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	// Create the request
-	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+	// Work out if and how the call is retried if it fails
+	retries := client.retries
+	if opts, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok && opts.retries != nil {
+		retries = opts.retries
+	}
+	if !isIdempotent(method) {
+		retries = nil
 	}
 
-	// Add any headers to the request
-	for header, values := range headers {
-		for _, value := range values {
-			req.Header.Add(header, value)
+	var rawResponse *http.Response
+	for attempt := 1; ; attempt++ {
+		// Create the request
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
 		}
-	}
 
-	// Make the request via the base client
-	rawResponse, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		// Add any headers to the request
+		for header, values := range headers {
+			for _, value := range values {
+				req.Header.Add(header, value)
+			}
+		}
+
+		// Make the request via the base client
+		rawResponse, err = client.Do(req)
+		if retries == nil || attempt >= retries.MaxAttempts || !retries.shouldRetry(rawResponse, err) {
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			break
+		}
+
+		// Discard the failed response and wait before trying again
+		if rawResponse != nil {
+			_ = rawResponse.Body.Close()
+		}
+		if err := retries.wait(ctx, attempt); err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
 	}
 	defer func() {
 		_ = rawResponse.Body.Close()
@@ -226,6 +367,58 @@ func callAPI(ctx context.Context, client *baseClient, method, path string, heade
 	return rawResponse.Header, nil
 }
 
+// isIdempotent reports whether requests with the given HTTP method are idempotent,
+// and can therefore be retried safely.
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a failed attempt should be retried.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// wait waits before retrying a call after the given number of failed attempts.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // APIError is the error type returned by the API
 type APIError struct {
 	Code    ErrCode `json:"code"`
@@ -237,6 +430,17 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// IsClientOutOfDate reports whether the API rejected the request because the client
+// was generated for a different version of the API. Regenerate the client to fix it.
+func IsClientOutOfDate(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrFailedPrecondition {
+		return false
+	}
+	details, _ := apiErr.Details.(map[string]any)
+	return details["client_out_of_date"] == true
+}
+
 type ErrCode int
 
 const (
@@ -10,6 +10,13 @@
  */
 export const Local = "http://localhost:4000"
 
+/**
+ * APIVersion is the version of the API the client was generated for.
+ * Set the verifyAPIVersion client option to send it with each request,
+ * so that the API rejects the requests if the client is out of date.
+ */
+export const APIVersion = "736d2ce94d4ed7f1"
+
 /**
  * Environment returns a BaseURL for calling the cloud environment with the given name.
  */
@@ -79,7 +86,7 @@ function encodeQuery(parts) {
     for (const key in parts) {
         const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]])
         for (const v of val) {
-            pairs.push(`${key}=${encodeURIComponent(v)}`)
+            pairs.push(`${encodeURIComponent(key)}=${encodeURIComponent(v)}`)
         }
     }
     return pairs.join("&")
@@ -274,6 +281,10 @@ class BaseClient {
             this.headers["User-Agent"] = "app-Generated-JS-Client (Encore/v0.0.0-develop)";
         }
 
+        if (options.verifyAPIVersion) {
+            this.headers["X-Encore-API-Version"] = APIVersion
+        }
+
         this.requestInit = options.requestInit ?? {}
 
         // Setup what fetch function we'll be using in the base client
@@ -509,6 +520,14 @@ export function isAPIError(err) {
     return err instanceof APIError;
 }
 
+/**
+ * isClientOutOfDate reports whether the API rejected the request because the client
+ * was generated for a different version of the API. Regenerate the client to fix it.
+ */
+export function isClientOutOfDate(err) {
+    return isAPIError(err) && err.code === ErrCode.FailedPrecondition && err.details?.client_out_of_date === true
+}
+
 export const ErrCode = {
     /**
      * OK indicates the operation was successful.
@@ -0,0 +1,1214 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+#nullable enable
+
+using System;
+using System.Collections;
+using System.Collections.Generic;
+using System.Globalization;
+using System.Linq;
+using System.Net.Http;
+using System.Text;
+using System.Text.Json;
+using System.Text.Json.Serialization;
+using System.Threading;
+using System.Threading.Tasks;
+
+namespace Encore.App;
+
+/// <summary>
+/// Environments contains the base URLs for calling the app Encore application's API.
+/// </summary>
+public static class Environments
+{
+    /// <summary>
+    /// Local is the base URL of the locally running application.
+    /// </summary>
+    public const string Local = "http://localhost:4000";
+
+    /// <summary>
+    /// Environment returns the base URL for calling the cloud environment with the given name.
+    /// </summary>
+    public static string Environment(string name) => $"https://{name}-app.encr.app";
+
+    /// <summary>
+    /// PreviewEnv returns the base URL for calling the preview environment with the given PR number.
+    /// </summary>
+    public static string PreviewEnv(int pr) => Environment($"pr{pr}");
+
+    /// <summary>
+    /// APIVersion is the version of the API the client was generated for.
+    /// </summary>
+    public const string APIVersion = "0a5dad7de3c5eb52";
+}
+
+/// <summary>
+/// Client is an API client for the app Encore application.
+/// </summary>
+public sealed class Client
+{
+    public IAuthenticationClient Authentication { get; }
+    public IProductsClient Products { get; }
+    public ISvcClient Svc { get; }
+
+    /// <summary>
+    /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+    /// </summary>
+    /// <param name="baseUrl">The base URL of the application. See <see cref="Environments"/> for options.</param>
+    /// <param name="options">Options for the client.</param>
+    public Client(string baseUrl, ClientOptions? options = null)
+    {
+        var baseClient = new BaseClient(baseUrl, options ?? new ClientOptions());
+        Authentication = new AuthenticationClient(baseClient);
+        Products = new ProductsClient(baseClient);
+        Svc = new SvcClient(baseClient);
+    }
+}
+
+/// <summary>
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+/// </summary>
+public sealed class ClientOptions
+{
+    /// <summary>
+    /// The HTTP client used to make the API requests. By default a new HttpClient is created,
+    /// however you can provide your own to configure timeouts, proxies or message handlers
+    /// which run custom code on each API request made or response received.
+    /// </summary>
+    public HttpClient? HttpClient { get; set; }
+
+    /// <summary>
+    /// Headers which are added to each API request.
+    /// </summary>
+    public IDictionary<string, string> Headers { get; } = new Dictionary<string, string>();
+
+    /// <summary>
+    /// Whether to send <see cref="Environments.APIVersion"/> with each API request, so that the API
+    /// rejects the requests if the client is out of date. Such requests fail with an
+    /// <see cref="APIException"/> whose <see cref="APIException.IsClientOutOfDate"/> is true.
+    /// </summary>
+    public bool VerifyAPIVersion { get; set; }
+
+    /// <summary>
+    /// The authentication data to be used for each API request.
+    /// </summary>
+    public AuthenticationAuthData? Auth { get; set; }
+
+    /// <summary>
+    /// A function which is called before each API request and returns the authentication data to use,
+    /// for when the credentials can change during the lifetime of the client. It takes precedence over Auth.
+    /// </summary>
+    public Func<CancellationToken, Task<AuthenticationAuthData?>>? AuthGenerator { get; set; }
+}
+
+public partial class AuthenticationAuthData
+{
+    [JsonPropertyName("APIKey")]
+    public string APIKey { get; set; } = default!;
+}
+
+/// <summary>
+/// BarType docs
+/// </summary>
+public partial class AuthenticationBarType
+{
+    /// <summary>
+    /// Baz docs
+    /// </summary>
+    [JsonPropertyName("Baz")]
+    public string Baz { get; set; } = default!;
+}
+
+/// <summary>
+/// FooType docs
+/// </summary>
+public partial class AuthenticationFooType
+{
+    /// <summary>
+    /// Moo docs
+    /// </summary>
+    [JsonPropertyName("Moo")]
+    public string Moo { get; set; } = default!;
+
+    /// <summary>
+    /// Bar docs
+    /// </summary>
+    [JsonPropertyName("Bar")]
+    public AuthenticationBarType Bar { get; set; } = default!;
+}
+
+public partial class AuthenticationUser
+{
+    [JsonPropertyName("id")]
+    public long ID { get; set; }
+
+    [JsonPropertyName("name")]
+    public string Name { get; set; } = default!;
+}
+
+/// <summary>
+/// IAuthenticationClient provides access to call the public and authenticated APIs of the authentication service.
+/// It allows you to create mock implementations of the client during tests.
+/// </summary>
+public interface IAuthenticationClient
+{
+    Task DocsAsync(AuthenticationFooType @params, CancellationToken cancellationToken = default);
+}
+
+/// <summary>
+/// AuthenticationClient is the implementation of <see cref="IAuthenticationClient"/>.
+/// </summary>
+public sealed class AuthenticationClient : IAuthenticationClient
+{
+    private readonly BaseClient baseClient;
+
+    internal AuthenticationClient(BaseClient baseClient)
+    {
+        this.baseClient = baseClient;
+    }
+
+    /// <inheritdoc/>
+    public async Task DocsAsync(AuthenticationFooType @params, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/authentication.Docs", @params, null, null, cancellationToken).ConfigureAwait(false);
+    }
+}
+
+public partial class ProductsCreateProductRequest
+{
+    [JsonPropertyName("IdempotencyKey")]
+    public string IdempotencyKey { get; set; } = default!;
+
+    [JsonPropertyName("name")]
+    public string Name { get; set; } = default!;
+
+    [JsonPropertyName("description")]
+    public string Description { get; set; } = default!;
+}
+
+public partial class ProductsProduct
+{
+    [JsonPropertyName("id")]
+    public Guid ID { get; set; }
+
+    [JsonPropertyName("name")]
+    public string Name { get; set; } = default!;
+
+    [JsonPropertyName("description")]
+    public string Description { get; set; } = default!;
+
+    [JsonPropertyName("created_at")]
+    public DateTimeOffset CreatedAt { get; set; }
+
+    [JsonPropertyName("created_by")]
+    public AuthenticationUser? CreatedBy { get; set; }
+}
+
+public partial class ProductsProductListing
+{
+    [JsonPropertyName("products")]
+    public List<ProductsProduct?> Products { get; set; } = default!;
+
+    [JsonPropertyName("previous")]
+    public PreviousPageType PreviousPage { get; set; } = default!;
+
+    [JsonPropertyName("next")]
+    public NextPageType NextPage { get; set; } = default!;
+
+    public partial class PreviousPageType
+    {
+        [JsonPropertyName("cursor")]
+        public string Cursor { get; set; } = default!;
+
+        [JsonPropertyName("exists")]
+        public bool Exists { get; set; }
+    }
+
+    public partial class NextPageType
+    {
+        [JsonPropertyName("cursor")]
+        public string Cursor { get; set; } = default!;
+
+        [JsonPropertyName("exists")]
+        public bool Exists { get; set; }
+    }
+}
+
+public partial class ProductsReviewRequest
+{
+    [JsonPropertyName("Notify")]
+    public bool Notify { get; set; }
+
+    [JsonPropertyName("rating")]
+    public long Rating { get; set; }
+
+    [JsonPropertyName("comment")]
+    public string Comment { get; set; } = default!;
+
+    [JsonPropertyName("tags")]
+    public List<string>? Tags { get; set; }
+}
+
+public partial class ProductsSearchParams
+{
+    [JsonPropertyName("Query")]
+    public string Query { get; set; } = default!;
+
+    [JsonPropertyName("Cursor")]
+    public string Cursor { get; set; } = default!;
+
+    [JsonPropertyName("Limit")]
+    public long Limit { get; set; }
+}
+
+public partial class ProductsSearchResult
+{
+    [JsonPropertyName("products")]
+    public List<ProductsProduct?> Products { get; set; } = default!;
+
+    [JsonPropertyName("next_cursor")]
+    public string NextCursor { get; set; } = default!;
+}
+
+/// <summary>
+/// IProductsClient provides access to call the public and authenticated APIs of the products service.
+/// It allows you to create mock implementations of the client during tests.
+/// </summary>
+public interface IProductsClient
+{
+    Task<ProductsProduct> CreateAsync(ProductsCreateProductRequest @params, CancellationToken cancellationToken = default);
+
+    Task<ProductsProductListing> ListAsync(CancellationToken cancellationToken = default);
+
+    /// <summary>
+    /// Review submits a review of a product, and can be called from HTML forms.
+    /// </summary>
+    Task ReviewAsync(string id, ProductsReviewRequest @params, CancellationToken cancellationToken = default);
+
+    /// <summary>
+    /// Search searches the products, a page at a time.
+    /// </summary>
+    Task<ProductsSearchResult> SearchAsync(ProductsSearchParams @params, CancellationToken cancellationToken = default);
+}
+
+/// <summary>
+/// ProductsClient is the implementation of <see cref="IProductsClient"/>.
+/// </summary>
+public sealed class ProductsClient : IProductsClient
+{
+    private readonly BaseClient baseClient;
+
+    internal ProductsClient(BaseClient baseClient)
+    {
+        this.baseClient = baseClient;
+    }
+
+    /// <inheritdoc/>
+    public async Task<ProductsProduct> CreateAsync(ProductsCreateProductRequest @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var headers = new Dictionary<string, object?>
+        {
+            ["idempotency-key"] = @params.IdempotencyKey,
+        };
+
+        // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+        var body = new Dictionary<string, object?>
+        {
+            ["description"] = @params.Description,
+            ["name"] = @params.Name,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/products.Create", body, headers, null, cancellationToken).ConfigureAwait(false);
+        return await BaseClient.ReadJsonAsync<ProductsProduct>(resp, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<ProductsProductListing> ListAsync(CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Get, "/products.List", null, null, null, cancellationToken).ConfigureAwait(false);
+        return await BaseClient.ReadJsonAsync<ProductsProductListing>(resp, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task ReviewAsync(string id, ProductsReviewRequest @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var query = new Dictionary<string, object?>
+        {
+            ["notify"] = @params.Notify,
+        };
+
+        // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+        var body = new Dictionary<string, object?>
+        {
+            ["comment"] = @params.Comment,
+            ["rating"] = @params.Rating,
+            ["tags"] = @params.Tags,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, $"/products/{Uri.EscapeDataString(id)}/reviews", body, null, query, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<ProductsSearchResult> SearchAsync(ProductsSearchParams @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var query = new Dictionary<string, object?>
+        {
+            ["cursor"] = @params.Cursor,
+            ["limit"] = @params.Limit,
+            ["q"] = @params.Query,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Get, "/products.Search", null, null, query, cancellationToken).ConfigureAwait(false);
+        return await BaseClient.ReadJsonAsync<ProductsSearchResult>(resp, cancellationToken).ConfigureAwait(false);
+    }
+}
+
+public partial class SvcAllInputTypes<TA>
+{
+    /// <summary>
+    /// Specify this comes from a header field
+    /// </summary>
+    [JsonPropertyName("A")]
+    public DateTimeOffset A { get; set; }
+
+    /// <summary>
+    /// Specify this comes from a query string
+    /// </summary>
+    [JsonPropertyName("B")]
+    public List<long> B { get; set; } = default!;
+
+    /// <summary>
+    /// This can come from anywhere, but if it comes from the payload in JSON it must be called Charile
+    /// </summary>
+    [JsonPropertyName("Charlies-Bool")]
+    public bool C { get; set; }
+
+    /// <summary>
+    /// This generic type complicates the whole thing 🙈
+    /// </summary>
+    [JsonPropertyName("Dave")]
+    public TA Dave { get; set; } = default!;
+}
+
+public partial class SvcGetRequest
+{
+    [JsonPropertyName("Baz")]
+    public long Baz { get; set; }
+}
+
+/// <summary>
+/// HeaderOnlyStruct contains all types we support in headers
+/// </summary>
+public partial class SvcHeaderOnlyStruct
+{
+    [JsonPropertyName("Boolean")]
+    public bool Boolean { get; set; }
+
+    [JsonPropertyName("Int")]
+    public long Int { get; set; }
+
+    [JsonPropertyName("Float")]
+    public double Float { get; set; }
+
+    [JsonPropertyName("String")]
+    public string String { get; set; } = default!;
+
+    [JsonPropertyName("Bytes")]
+    public byte[] Bytes { get; set; } = default!;
+
+    [JsonPropertyName("Time")]
+    public DateTimeOffset Time { get; set; }
+
+    [JsonPropertyName("Json")]
+    public JsonElement Json { get; set; }
+
+    [JsonPropertyName("UUID")]
+    public Guid UUID { get; set; }
+
+    [JsonPropertyName("UserID")]
+    public string UserID { get; set; } = default!;
+}
+
+public partial class SvcRecursive
+{
+    [JsonPropertyName("Optional")]
+    public SvcRecursive? Optional { get; set; }
+
+    [JsonPropertyName("Slice")]
+    public List<SvcRecursive> Slice { get; set; } = default!;
+
+    [JsonPropertyName("Map")]
+    public Dictionary<string, SvcRecursive> Map { get; set; } = default!;
+}
+
+public partial class SvcRequest
+{
+    /// <summary>
+    /// Foo is good
+    /// </summary>
+    [JsonPropertyName("Foo")]
+    public long? Foo { get; set; }
+
+    /// <summary>
+    /// Baz is better
+    /// </summary>
+    [JsonPropertyName("boo")]
+    public string Baz { get; set; } = default!;
+
+    [JsonPropertyName("QueryFoo")]
+    public bool? QueryFoo { get; set; }
+
+    [JsonPropertyName("QueryBar")]
+    public string? QueryBar { get; set; }
+
+    [JsonPropertyName("HeaderBaz")]
+    public string? HeaderBaz { get; set; }
+
+    [JsonPropertyName("HeaderInt")]
+    public long? HeaderInt { get; set; }
+
+    /// <summary>
+    /// This is a multiline
+    /// comment on the raw message!
+    /// </summary>
+    [JsonPropertyName("Raw")]
+    public JsonElement Raw { get; set; }
+}
+
+/// <summary>
+/// Tuple is a generic type which allows us to
+/// return two values of two different types
+/// </summary>
+public partial class SvcTuple<TA, TB>
+{
+    [JsonPropertyName("A")]
+    public TA A { get; set; } = default!;
+
+    [JsonPropertyName("B")]
+    public TB B { get; set; } = default!;
+}
+
+public partial class SvcWithNested
+{
+    [JsonPropertyName("Nested")]
+    public NestedType? Nested { get; set; }
+}
+
+public partial class SvcWrapper<T>
+{
+    [JsonPropertyName("Value")]
+    public T Value { get; set; } = default!;
+}
+
+/// <summary>
+/// ISvcClient provides access to call the public and authenticated APIs of the svc service.
+/// It allows you to create mock implementations of the client during tests.
+/// </summary>
+public interface ISvcClient
+{
+    /// <summary>
+    /// DummyAPI is a dummy endpoint.
+    /// </summary>
+    Task DummyAPIAsync(SvcRequest @params, CancellationToken cancellationToken = default);
+
+    Task FallbackPathAsync(string a, IEnumerable<string> b, CancellationToken cancellationToken = default);
+
+    Task GetAsync(SvcGetRequest @params, CancellationToken cancellationToken = default);
+
+    Task<SvcHeaderOnlyStruct> GetRequestWithAllInputTypesAsync(SvcAllInputTypes<long> @params, CancellationToken cancellationToken = default);
+
+    Task HeaderOnlyRequestAsync(SvcHeaderOnlyStruct @params, CancellationToken cancellationToken = default);
+
+    Task<SvcWithNested> NestedAsync(SvcWithNested @params, CancellationToken cancellationToken = default);
+
+    Task RESTPathAsync(string a, long b, CancellationToken cancellationToken = default);
+
+    Task<SvcRecursive> RecAsync(SvcRecursive @params, CancellationToken cancellationToken = default);
+
+    Task<SvcAllInputTypes<double>> RequestWithAllInputTypesAsync(SvcAllInputTypes<string> @params, CancellationToken cancellationToken = default);
+
+    /// <summary>
+    /// TupleInputOutput tests the usage of generics in the client generator
+    /// and this comment is also multiline, so multiline comments get tested as well.
+    /// </summary>
+    Task<SvcTuple<bool, long>> TupleInputOutputAsync(SvcTuple<string, SvcWrapper<SvcRequest>> @params, CancellationToken cancellationToken = default);
+
+    /// <summary>
+    /// Upload streams the request body to storage.
+    /// </summary>
+    Task<HttpResponseMessage> UploadAsync(string id, HttpRequestMessage request, CancellationToken cancellationToken = default);
+
+    Task<HttpResponseMessage> WebhookAsync(string a, IEnumerable<string> b, HttpRequestMessage request, CancellationToken cancellationToken = default);
+
+    Task Webhook2Async(string a, IEnumerable<string> b, CancellationToken cancellationToken = default);
+}
+
+/// <summary>
+/// SvcClient is the implementation of <see cref="ISvcClient"/>.
+/// </summary>
+public sealed class SvcClient : ISvcClient
+{
+    private readonly BaseClient baseClient;
+
+    internal SvcClient(BaseClient baseClient)
+    {
+        this.baseClient = baseClient;
+    }
+
+    /// <inheritdoc/>
+    public async Task DummyAPIAsync(SvcRequest @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var headers = new Dictionary<string, object?>
+        {
+            ["baz"] = @params.HeaderBaz,
+            ["int"] = @params.HeaderInt,
+        };
+
+        var query = new Dictionary<string, object?>
+        {
+            ["bar"] = @params.QueryBar,
+            ["foo"] = @params.QueryFoo,
+        };
+
+        // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+        var body = new Dictionary<string, object?>
+        {
+            ["Foo"] = @params.Foo,
+            ["Raw"] = @params.Raw,
+            ["boo"] = @params.Baz,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.DummyAPI", body, headers, query, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task FallbackPathAsync(string a, IEnumerable<string> b, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, $"/fallbackPath/{Uri.EscapeDataString(a)}/{ClientEncoding.EscapePath(b)}", null, null, null, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task GetAsync(SvcGetRequest @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var query = new Dictionary<string, object?>
+        {
+            ["boo"] = @params.Baz,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Get, "/svc.Get", null, null, query, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<SvcHeaderOnlyStruct> GetRequestWithAllInputTypesAsync(SvcAllInputTypes<long> @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var headers = new Dictionary<string, object?>
+        {
+            ["x-alice"] = @params.A,
+        };
+
+        var query = new Dictionary<string, object?>
+        {
+            ["Bob"] = @params.B,
+            ["c"] = @params.C,
+            ["dave"] = @params.Dave,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Get, "/svc.GetRequestWithAllInputTypes", null, headers, query, cancellationToken).ConfigureAwait(false);
+
+        // Populate the return object from the JSON body and received headers
+        var rtn = await BaseClient.ReadJsonAsync<SvcHeaderOnlyStruct>(resp, cancellationToken).ConfigureAwait(false);
+        rtn.Boolean = BaseClient.MustBeSet(resp, "x-boolean", v => bool.Parse(v));
+        rtn.Int = BaseClient.MustBeSet(resp, "x-int", v => long.Parse(v, CultureInfo.InvariantCulture));
+        rtn.Float = BaseClient.MustBeSet(resp, "x-float", v => double.Parse(v, CultureInfo.InvariantCulture));
+        rtn.String = BaseClient.MustBeSet(resp, "x-string", v => v);
+        rtn.Bytes = BaseClient.MustBeSet(resp, "x-bytes", v => Convert.FromBase64String(v));
+        rtn.Time = BaseClient.MustBeSet(resp, "x-time", v => DateTimeOffset.Parse(v, CultureInfo.InvariantCulture));
+        rtn.Json = BaseClient.MustBeSet(resp, "x-json", v => JsonSerializer.Deserialize<JsonElement>(v));
+        rtn.UUID = BaseClient.MustBeSet(resp, "x-uuid", v => Guid.Parse(v));
+        rtn.UserID = BaseClient.MustBeSet(resp, "x-user-id", v => v);
+        return rtn;
+    }
+
+    /// <inheritdoc/>
+    public async Task HeaderOnlyRequestAsync(SvcHeaderOnlyStruct @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var headers = new Dictionary<string, object?>
+        {
+            ["x-boolean"] = @params.Boolean,
+            ["x-bytes"] = @params.Bytes,
+            ["x-float"] = @params.Float,
+            ["x-int"] = @params.Int,
+            ["x-json"] = @params.Json,
+            ["x-string"] = @params.String,
+            ["x-time"] = @params.Time,
+            ["x-user-id"] = @params.UserID,
+            ["x-uuid"] = @params.UUID,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Get, "/svc.HeaderOnlyRequest", null, headers, null, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<SvcWithNested> NestedAsync(SvcWithNested @params, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.Nested", @params, null, null, cancellationToken).ConfigureAwait(false);
+        return await BaseClient.ReadJsonAsync<SvcWithNested>(resp, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task RESTPathAsync(string a, long b, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, $"/path/{Uri.EscapeDataString(a)}/{ClientEncoding.Format(b)}", null, null, null, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<SvcRecursive> RecAsync(SvcRecursive @params, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.Rec", @params, null, null, cancellationToken).ConfigureAwait(false);
+        return await BaseClient.ReadJsonAsync<SvcRecursive>(resp, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<SvcAllInputTypes<double>> RequestWithAllInputTypesAsync(SvcAllInputTypes<string> @params, CancellationToken cancellationToken = default)
+    {
+        // Convert our params into the objects we need for the request
+        var headers = new Dictionary<string, object?>
+        {
+            ["x-alice"] = @params.A,
+        };
+
+        var query = new Dictionary<string, object?>
+        {
+            ["Bob"] = @params.B,
+        };
+
+        // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+        var body = new Dictionary<string, object?>
+        {
+            ["Charlies-Bool"] = @params.C,
+            ["Dave"] = @params.Dave,
+        };
+
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.RequestWithAllInputTypes", body, headers, query, cancellationToken).ConfigureAwait(false);
+
+        // Populate the return object from the JSON body and received headers
+        var rtn = await BaseClient.ReadJsonAsync<SvcAllInputTypes<double>>(resp, cancellationToken).ConfigureAwait(false);
+        rtn.A = BaseClient.MustBeSet(resp, "x-alice", v => DateTimeOffset.Parse(v, CultureInfo.InvariantCulture));
+        return rtn;
+    }
+
+    /// <inheritdoc/>
+    public async Task<SvcTuple<bool, long>> TupleInputOutputAsync(SvcTuple<string, SvcWrapper<SvcRequest>> @params, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, "/svc.TupleInputOutput", @params, null, null, cancellationToken).ConfigureAwait(false);
+        return await BaseClient.ReadJsonAsync<SvcTuple<bool, long>>(resp, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<HttpResponseMessage> UploadAsync(string id, HttpRequestMessage request, CancellationToken cancellationToken = default)
+    {
+        return await baseClient.SendRawAsync($"/upload/{Uri.EscapeDataString(id)}", request, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task<HttpResponseMessage> WebhookAsync(string a, IEnumerable<string> b, HttpRequestMessage request, CancellationToken cancellationToken = default)
+    {
+        return await baseClient.SendRawAsync($"/webhook/{Uri.EscapeDataString(a)}/{ClientEncoding.EscapePath(b)}", request, cancellationToken).ConfigureAwait(false);
+    }
+
+    /// <inheritdoc/>
+    public async Task Webhook2Async(string a, IEnumerable<string> b, CancellationToken cancellationToken = default)
+    {
+        // Now make the actual call to the API
+        using var resp = await baseClient.CallApiAsync(HttpMethod.Post, $"/webhook2/{Uri.EscapeDataString(a)}/{ClientEncoding.EscapePath(b)}", null, null, null, cancellationToken).ConfigureAwait(false);
+    }
+}
+
+public partial class NestedType
+{
+    [JsonPropertyName("Message")]
+    public string Message { get; set; } = default!;
+}
+
+/// <summary>
+/// BaseClient holds all the information we need to make requests to an Encore application.
+/// </summary>
+internal sealed class BaseClient
+{
+    internal static readonly JsonSerializerOptions JsonOptions = new(JsonSerializerDefaults.Web);
+
+    private const string UserAgent = "app-Generated-CSharp-Client (Encore/v0.0.0-develop)";
+
+    private readonly string baseUrl;
+    private readonly HttpClient httpClient;
+    private readonly ClientOptions options;
+
+    internal BaseClient(string baseUrl, ClientOptions options)
+    {
+        this.baseUrl = baseUrl.TrimEnd('/');
+        this.httpClient = options.HttpClient ?? new HttpClient();
+        this.options = options;
+    }
+
+    // CallApiAsync is used by each generated API method to actually make the request.
+    // It throws an APIException if the API returns an error.
+    internal async Task<HttpResponseMessage> CallApiAsync(HttpMethod method, string path, object? body, Dictionary<string, object?>? headers, Dictionary<string, object?>? query, CancellationToken cancellationToken)
+    {
+        using var request = new HttpRequestMessage(method, baseUrl + path);
+        if (body != null)
+        {
+            var json = JsonSerializer.Serialize(body, body.GetType(), JsonOptions);
+            request.Content = new StringContent(json, Encoding.UTF8, "application/json");
+        }
+
+        var response = await SendAsync(request, headers, query, cancellationToken).ConfigureAwait(false);
+        if (!response.IsSuccessStatusCode)
+        {
+            using (response)
+            {
+                throw await ReadErrorAsync(response, cancellationToken).ConfigureAwait(false);
+            }
+        }
+        return response;
+    }
+
+    // SendRawAsync sends a request to a raw API endpoint, returning the response as is.
+    internal Task<HttpResponseMessage> SendRawAsync(string path, HttpRequestMessage request, CancellationToken cancellationToken)
+    {
+        // Keep any query string of the request, but make sure it hits the right base URL
+        request.RequestUri = new Uri(baseUrl + path + (request.RequestUri?.IsAbsoluteUri == true ? request.RequestUri.Query : ""));
+        return SendAsync(request, null, null, cancellationToken);
+    }
+
+    // SendAsync sends the request to the Encore application, adding the headers,
+    // query string and authorization data as required.
+    private async Task<HttpResponseMessage> SendAsync(HttpRequestMessage request, Dictionary<string, object?>? headers, Dictionary<string, object?>? query, CancellationToken cancellationToken)
+    {
+        headers = headers != null ? new Dictionary<string, object?>(headers) : new Dictionary<string, object?>();
+        query = query != null ? new Dictionary<string, object?>(query) : new Dictionary<string, object?>();
+        await AddAuthDataAsync(headers, query, cancellationToken).ConfigureAwait(false);
+
+        if (query.Count > 0)
+        {
+            var uri = request.RequestUri!.ToString();
+            request.RequestUri = new Uri(uri + (uri.Contains('?') ? "&" : "?") + ClientEncoding.EncodeQuery(query));
+        }
+
+        request.Headers.TryAddWithoutValidation("User-Agent", UserAgent);
+        if (options.VerifyAPIVersion)
+        {
+            request.Headers.TryAddWithoutValidation("X-Encore-API-Version", Environments.APIVersion);
+        }
+        foreach (var header in options.Headers)
+        {
+            request.Headers.TryAddWithoutValidation(header.Key, header.Value);
+        }
+        foreach (var header in headers)
+        {
+            foreach (var value in ClientEncoding.Values(header.Value))
+            {
+                if (!request.Headers.TryAddWithoutValidation(header.Key, value))
+                {
+                    request.Content?.Headers.TryAddWithoutValidation(header.Key, value);
+                }
+            }
+        }
+
+        return await httpClient.SendAsync(request, cancellationToken).ConfigureAwait(false);
+    }
+
+    // AddAuthDataAsync adds the authentication data to the request, if there is any.
+    private async Task AddAuthDataAsync(Dictionary<string, object?> headers, Dictionary<string, object?> query, CancellationToken cancellationToken)
+    {
+        var authData = options.AuthGenerator != null
+            ? await options.AuthGenerator(cancellationToken).ConfigureAwait(false)
+            : options.Auth;
+        if (authData == null)
+        {
+            return;
+        }
+
+        headers["x-api-key"] = authData.APIKey;
+    }
+
+    // ReadJsonAsync decodes the JSON body of the response.
+    internal static async Task<T> ReadJsonAsync<T>(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        using var stream = await response.Content.ReadAsStreamAsync(cancellationToken).ConfigureAwait(false);
+        var value = await JsonSerializer.DeserializeAsync<T>(stream, JsonOptions, cancellationToken).ConfigureAwait(false);
+        return value ?? throw new APIException((int)response.StatusCode, ErrCode.DataLoss, "response body was unexpectedly null");
+    }
+
+    // MustBeSet parses the value of a response header, throwing an APIException with the DataLoss code if it's missing.
+    internal static T MustBeSet<T>(HttpResponseMessage response, string header, Func<string, T> parse)
+    {
+        if (response.Headers.TryGetValues(header, out var values) || response.Content.Headers.TryGetValues(header, out values))
+        {
+            var value = values.FirstOrDefault();
+            if (value != null)
+            {
+                return parse(value);
+            }
+        }
+        throw new APIException((int)response.StatusCode, ErrCode.DataLoss, $"Header `{header}` was unexpectedly missing");
+    }
+
+    // ReadErrorAsync reads the structured error from a failed API call.
+    private static async Task<APIException> ReadErrorAsync(HttpResponseMessage response, CancellationToken cancellationToken)
+    {
+        var status = (int)response.StatusCode;
+        var body = await response.Content.ReadAsStringAsync(cancellationToken).ConfigureAwait(false);
+        try
+        {
+            var error = JsonSerializer.Deserialize<APIErrorResponse>(body, JsonOptions);
+            if (error?.Code != null && error.Message != null)
+            {
+                return new APIException(status, APIException.ParseCode(error.Code), error.Message, error.Details);
+            }
+        }
+        catch (JsonException)
+        {
+            // Not a structured error, so fall back to the raw body below.
+        }
+        return new APIException(status, ErrCode.Unknown, $"request failed: status {status}: {body}");
+    }
+
+    private sealed class APIErrorResponse
+    {
+        [JsonPropertyName("code")]
+        public string? Code { get; set; }
+
+        [JsonPropertyName("message")]
+        public string? Message { get; set; }
+
+        [JsonPropertyName("details")]
+        public JsonElement? Details { get; set; }
+    }
+}
+
+/// <summary>
+/// ClientEncoding encodes values sent in paths, headers and query strings.
+/// </summary>
+internal static class ClientEncoding
+{
+    // Format formats a single value.
+    internal static string Format(object value) => value switch
+    {
+        string s => s,
+        bool b => b ? "true" : "false",
+        DateTimeOffset t => t.ToString("O", CultureInfo.InvariantCulture),
+        byte[] bytes => Convert.ToBase64String(bytes),
+        JsonElement json => json.GetRawText(),
+        IFormattable f => f.ToString(null, CultureInfo.InvariantCulture),
+        _ => value.ToString() ?? "",
+    };
+
+    // Values formats the values of a header or query string parameter,
+    // which has one value per element if it's a list.
+    internal static IEnumerable<string> Values(object? value)
+    {
+        switch (value)
+        {
+            case null:
+                yield break;
+            case string or byte[]:
+                yield return Format(value);
+                break;
+            case IEnumerable list:
+                foreach (var item in list)
+                {
+                    if (item != null)
+                    {
+                        yield return Format(item);
+                    }
+                }
+                break;
+            default:
+                yield return Format(value);
+                break;
+        }
+    }
+
+    // EscapePath escapes a list of path segments and joins them into a single path.
+    internal static string EscapePath(IEnumerable<string> segments) =>
+        string.Join("/", segments.Select(Uri.EscapeDataString));
+
+    // EncodeQuery encodes the parameters as a query string.
+    internal static string EncodeQuery(Dictionary<string, object?> query) =>
+        string.Join("&", query.SelectMany(p => Values(p.Value).Select(v => Uri.EscapeDataString(p.Key) + "=" + Uri.EscapeDataString(v))));
+}
+
+/// <summary>
+/// APIException represents a structured error as returned from an Encore application.
+/// </summary>
+public sealed class APIException : Exception
+{
+    /// <summary>
+    /// The HTTP status code associated with the error.
+    /// </summary>
+    public int Status { get; }
+
+    /// <summary>
+    /// The Encore error code.
+    /// </summary>
+    public ErrCode Code { get; }
+
+    /// <summary>
+    /// The error details, if any.
+    /// </summary>
+    public JsonElement? Details { get; }
+
+    /// <summary>
+    /// Whether the API rejected the request because the client was generated
+    /// for a different version of the API. Regenerate the client to fix it.
+    /// </summary>
+    public bool IsClientOutOfDate =>
+        Code == ErrCode.FailedPrecondition &&
+        Details is { ValueKind: JsonValueKind.Object } details &&
+        details.TryGetProperty("client_out_of_date", out var outOfDate) &&
+        outOfDate.ValueKind == JsonValueKind.True;
+
+    public APIException(int status, ErrCode code, string message, JsonElement? details = null) : base(message)
+    {
+        Status = status;
+        Code = code;
+        Details = details;
+    }
+
+    internal static ErrCode ParseCode(string code) => code switch
+    {
+        "ok" => ErrCode.OK,
+        "canceled" => ErrCode.Canceled,
+        "unknown" => ErrCode.Unknown,
+        "invalid_argument" => ErrCode.InvalidArgument,
+        "deadline_exceeded" => ErrCode.DeadlineExceeded,
+        "not_found" => ErrCode.NotFound,
+        "already_exists" => ErrCode.AlreadyExists,
+        "permission_denied" => ErrCode.PermissionDenied,
+        "resource_exhausted" => ErrCode.ResourceExhausted,
+        "failed_precondition" => ErrCode.FailedPrecondition,
+        "aborted" => ErrCode.Aborted,
+        "out_of_range" => ErrCode.OutOfRange,
+        "unimplemented" => ErrCode.Unimplemented,
+        "internal" => ErrCode.Internal,
+        "unavailable" => ErrCode.Unavailable,
+        "data_loss" => ErrCode.DataLoss,
+        "unauthenticated" => ErrCode.Unauthenticated,
+        _ => ErrCode.Unknown,
+    };
+}
+
+/// <summary>
+/// ErrCode is the error code of an APIException.
+/// </summary>
+public enum ErrCode
+{
+    /// <summary>
+    /// OK indicates the operation was successful.
+    /// </summary>
+    OK,
+
+    /// <summary>
+    /// Canceled indicates the operation was canceled (typically by the caller).
+    ///
+    /// Encore will generate this error code when cancellation is requested.
+    /// </summary>
+    Canceled,
+
+    /// <summary>
+    /// Unknown error. An example of where this error may be returned is
+    /// if a Status value received from another address space belongs to
+    /// an error-space that is not known in this address space. Also
+    /// errors raised by APIs that do not return enough error information
+    /// may be converted to this error.
+    ///
+    /// Encore will generate this error code in the above two mentioned cases.
+    /// </summary>
+    Unknown,
+
+    /// <summary>
+    /// InvalidArgument indicates client specified an invalid argument.
+    /// Note that this differs from FailedPrecondition. It indicates arguments
+    /// that are problematic regardless of the state of the system
+    /// (e.g., a malformed file name).
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    InvalidArgument,
+
+    /// <summary>
+    /// DeadlineExceeded means operation expired before completion.
+    /// For operations that change the state of the system, this error may be
+    /// returned even if the operation has completed successfully. For
+    /// example, a successful response from a server could have been delayed
+    /// long enough for the deadline to expire.
+    ///
+    /// The gRPC framework will generate this error code when the deadline is
+    /// exceeded.
+    /// </summary>
+    DeadlineExceeded,
+
+    /// <summary>
+    /// NotFound means some requested entity (e.g., file or directory) was
+    /// not found.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    NotFound,
+
+    /// <summary>
+    /// AlreadyExists means an attempt to create an entity failed because one
+    /// already exists.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    AlreadyExists,
+
+    /// <summary>
+    /// PermissionDenied indicates the caller does not have permission to
+    /// execute the specified operation. It must not be used for rejections
+    /// caused by exhausting some resource (use ResourceExhausted
+    /// instead for those errors). It must not be
+    /// used if the caller cannot be identified (use Unauthenticated
+    /// instead for those errors).
+    ///
+    /// This error code will not be generated by the gRPC core framework,
+    /// but expect authentication middleware to use it.
+    /// </summary>
+    PermissionDenied,
+
+    /// <summary>
+    /// ResourceExhausted indicates some resource has been exhausted, perhaps
+    /// a per-user quota, or perhaps the entire file system is out of space.
+    ///
+    /// This error code will be generated by the gRPC framework in
+    /// out-of-memory and server overload situations, or when a message is
+    /// larger than the configured maximum size.
+    /// </summary>
+    ResourceExhausted,
+
+    /// <summary>
+    /// FailedPrecondition indicates operation was rejected because the
+    /// system is not in a state required for the operation's execution.
+    /// For example, directory to be deleted may be non-empty, an rmdir
+    /// operation is applied to a non-directory, etc.
+    ///
+    /// A litmus test that may help a service implementor in deciding
+    /// between FailedPrecondition, Aborted, and Unavailable:
+    ///  (a) Use Unavailable if the client can retry just the failing call.
+    ///  (b) Use Aborted if the client should retry at a higher-level
+    ///      (e.g., restarting a read-modify-write sequence).
+    ///  (c) Use FailedPrecondition if the client should not retry until
+    ///      the system state has been explicitly fixed. E.g., if an "rmdir"
+    ///      fails because the directory is non-empty, FailedPrecondition
+    ///      should be returned since the client should not retry unless
+    ///      they have first fixed up the directory by deleting files from it.
+    ///  (d) Use FailedPrecondition if the client performs conditional
+    ///      REST Get/Update/Delete on a resource and the resource on the
+    ///      server does not match the condition. E.g., conflicting
+    ///      read-modify-write on the same resource.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    FailedPrecondition,
+
+    /// <summary>
+    /// Aborted indicates the operation was aborted, typically due to a
+    /// concurrency issue like sequencer check failures, transaction aborts,
+    /// etc.
+    ///
+    /// See litmus test above for deciding between FailedPrecondition,
+    /// Aborted, and Unavailable.
+    /// </summary>
+    Aborted,
+
+    /// <summary>
+    /// OutOfRange means operation was attempted past the valid range.
+    /// E.g., seeking or reading past end of file.
+    ///
+    /// Unlike InvalidArgument, this error indicates a problem that may
+    /// be fixed if the system state changes. For example, a 32-bit file
+    /// may be rotated to a 64-bit file without error.
+    ///
+    /// There is a fair bit of overlap between FailedPrecondition and
+    /// OutOfRange. We recommend using OutOfRange (the more specific
+    /// error) when it applies so that callers who are iterating through
+    /// a space can easily look for an OutOfRange error to detect when
+    /// they are done.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    OutOfRange,
+
+    /// <summary>
+    /// Unimplemented indicates operation is not implemented or not
+    /// supported/enabled in this service.
+    ///
+    /// This is not an error, but a feature not available.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    Unimplemented,
+
+    /// <summary>
+    /// Internal means some invariant expected by the underlying system has
+    /// been broken. This is not a per-message error, it is a global
+    /// conditions check.
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    Internal,
+
+    /// <summary>
+    /// Unavailable indicates the service is currently unavailable.
+    /// This is most likely a transient condition, which can be corrected by
+    /// retrying with a backoff.
+    ///
+    /// See litmus test above for deciding between FailedPrecondition,
+    /// Aborted, and Unavailable.
+    /// </summary>
+    Unavailable,
+
+    /// <summary>
+    /// DataLoss indicates unrecoverable data loss or corruption.
+    ///
+    /// This error code is only defined in the gRPC library, and only for
+    /// unrecoverable data loss (i.e., data loss resulting from errors
+    /// like hard disk corruption or bandwidth exceeded).
+    ///
+    /// This error code will not be generated by the gRPC framework.
+    /// </summary>
+    DataLoss,
+
+    /// <summary>
+    /// Unauthenticated indicates the request does not have valid
+    /// authentication credentials for the operation.
+    ///
+    /// The gRPC framework will generate this error code when the
+    /// authentication metadata is invalid or a Credentials callback fails,
+    /// but also expect authentication middleware to generate it.
+    /// </summary>
+    Unauthenticated,
+}
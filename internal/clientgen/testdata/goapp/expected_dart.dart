@@ -0,0 +1,1219 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+// ignore_for_file: type=lint
+
+import 'dart:async';
+import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:http/http.dart' as http;
+import 'package:json_annotation/json_annotation.dart';
+
+part 'client.g.dart';
+
+/// Environments contains the base URLs for calling the app Encore application's API.
+class Environments {
+  Environments._();
+
+  /// The base URL of the locally running application.
+  static const local = 'http://localhost:4000';
+
+  /// Returns the base URL for calling the cloud environment with the given name.
+  static String environment(String name) => 'https://$name-app.encr.app';
+
+  /// Returns the base URL for calling the preview environment with the given PR number.
+  static String previewEnv(int pr) => environment('pr$pr');
+
+  /// The version of the API the client was generated for.
+  static const apiVersion = '0a5dad7de3c5eb52';
+}
+
+/// Client is an API client for the app Encore application.
+class Client {
+  /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+  ///
+  /// The [baseUrl] is the base URL of the application; see [Environments] for options.
+  Client(String baseUrl, {ClientOptions options = const ClientOptions()})
+      : this._(_BaseClient(baseUrl, options));
+
+  Client._(_BaseClient baseClient)
+      : authentication = _AuthenticationClient(baseClient),
+        products = _ProductsClient(baseClient),
+        svc = _SvcClient(baseClient);
+
+  final AuthenticationClient authentication;
+  final ProductsClient products;
+  final SvcClient svc;
+}
+
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+class ClientOptions {
+  const ClientOptions({
+    this.httpClient,
+    this.headers = const {},
+    this.verifyAPIVersion = false,
+    this.auth,
+    this.authGenerator,
+  });
+
+  /// The HTTP client used to make the API requests. By default a new http.Client is created,
+  /// however you can provide your own to configure timeouts or proxies, or to run custom
+  /// code on each API request made or response received.
+  final http.Client? httpClient;
+
+  /// Headers which are added to each API request.
+  final Map<String, String> headers;
+
+  /// Whether to send [Environments.apiVersion] with each API request, so that the API
+  /// rejects the requests if the client is out of date. Such requests fail with an
+  /// [APIException] whose [APIException.isClientOutOfDate] is true.
+  final bool verifyAPIVersion;
+
+  /// The authentication data to be used for each API request.
+  final AuthenticationAuthData? auth;
+
+  /// A function which is called before each API request and returns the authentication data to use,
+  /// for when the credentials can change during the lifetime of the client. It takes precedence over [auth].
+  final FutureOr<AuthenticationAuthData?> Function()? authGenerator;
+}
+
+@_model
+class AuthenticationAuthData {
+  AuthenticationAuthData({
+    required this.apiKey,
+  });
+
+  factory AuthenticationAuthData.fromJson(Map<String, dynamic> json) => _$AuthenticationAuthDataFromJson(json);
+
+  @JsonKey(name: 'APIKey')
+  String apiKey;
+
+  Map<String, dynamic> toJson() => _$AuthenticationAuthDataToJson(this);
+}
+
+/// BarType docs
+@_model
+class AuthenticationBarType {
+  AuthenticationBarType({
+    required this.baz,
+  });
+
+  factory AuthenticationBarType.fromJson(Map<String, dynamic> json) => _$AuthenticationBarTypeFromJson(json);
+
+  /// Baz docs
+  @JsonKey(name: 'Baz')
+  String baz;
+
+  Map<String, dynamic> toJson() => _$AuthenticationBarTypeToJson(this);
+}
+
+/// FooType docs
+@_model
+class AuthenticationFooType {
+  AuthenticationFooType({
+    required this.moo,
+    required this.bar,
+  });
+
+  factory AuthenticationFooType.fromJson(Map<String, dynamic> json) => _$AuthenticationFooTypeFromJson(json);
+
+  /// Moo docs
+  @JsonKey(name: 'Moo')
+  String moo;
+
+  /// Bar docs
+  @JsonKey(name: 'Bar')
+  AuthenticationBarType bar;
+
+  Map<String, dynamic> toJson() => _$AuthenticationFooTypeToJson(this);
+}
+
+@_model
+class AuthenticationUser {
+  AuthenticationUser({
+    required this.id,
+    required this.name,
+  });
+
+  factory AuthenticationUser.fromJson(Map<String, dynamic> json) => _$AuthenticationUserFromJson(json);
+
+  @JsonKey(name: 'id')
+  int id;
+
+  @JsonKey(name: 'name')
+  String name;
+
+  Map<String, dynamic> toJson() => _$AuthenticationUserToJson(this);
+}
+
+/// AuthenticationClient provides access to call the public and authenticated APIs of the authentication service.
+/// It allows you to create mock implementations of the client during tests.
+abstract interface class AuthenticationClient {
+  Future<void> docs(AuthenticationFooType params);
+}
+
+class _AuthenticationClient implements AuthenticationClient {
+  _AuthenticationClient(this._baseClient);
+
+  final _BaseClient _baseClient;
+
+  @override
+  Future<void> docs(AuthenticationFooType params) async {
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/authentication.Docs', body: params.toJson());
+  }
+}
+
+@_model
+class ProductsCreateProductRequest {
+  ProductsCreateProductRequest({
+    required this.idempotencyKey,
+    required this.name,
+    required this.description,
+  });
+
+  factory ProductsCreateProductRequest.fromJson(Map<String, dynamic> json) => _$ProductsCreateProductRequestFromJson(json);
+
+  @JsonKey(name: 'IdempotencyKey')
+  String idempotencyKey;
+
+  @JsonKey(name: 'name')
+  String name;
+
+  @JsonKey(name: 'description')
+  String description;
+
+  Map<String, dynamic> toJson() => _$ProductsCreateProductRequestToJson(this);
+}
+
+@_model
+class ProductsProduct {
+  ProductsProduct({
+    required this.id,
+    required this.name,
+    required this.description,
+    required this.createdAt,
+    this.createdBy,
+  });
+
+  factory ProductsProduct.fromJson(Map<String, dynamic> json) => _$ProductsProductFromJson(json);
+
+  @JsonKey(name: 'id')
+  String id;
+
+  @JsonKey(name: 'name')
+  String name;
+
+  @JsonKey(name: 'description')
+  String description;
+
+  @JsonKey(name: 'created_at')
+  DateTime createdAt;
+
+  @JsonKey(name: 'created_by')
+  AuthenticationUser? createdBy;
+
+  Map<String, dynamic> toJson() => _$ProductsProductToJson(this);
+}
+
+@_model
+class ProductsProductListing {
+  ProductsProductListing({
+    required this.products,
+    required this.previousPage,
+    required this.nextPage,
+  });
+
+  factory ProductsProductListing.fromJson(Map<String, dynamic> json) => _$ProductsProductListingFromJson(json);
+
+  @JsonKey(name: 'products')
+  List<ProductsProduct?> products;
+
+  @JsonKey(name: 'previous')
+  ProductsProductListingPreviousPage previousPage;
+
+  @JsonKey(name: 'next')
+  ProductsProductListingNextPage nextPage;
+
+  Map<String, dynamic> toJson() => _$ProductsProductListingToJson(this);
+}
+
+@_model
+class ProductsProductListingPreviousPage {
+  ProductsProductListingPreviousPage({
+    required this.cursor,
+    required this.exists,
+  });
+
+  factory ProductsProductListingPreviousPage.fromJson(Map<String, dynamic> json) => _$ProductsProductListingPreviousPageFromJson(json);
+
+  @JsonKey(name: 'cursor')
+  String cursor;
+
+  @JsonKey(name: 'exists')
+  bool exists;
+
+  Map<String, dynamic> toJson() => _$ProductsProductListingPreviousPageToJson(this);
+}
+
+@_model
+class ProductsProductListingNextPage {
+  ProductsProductListingNextPage({
+    required this.cursor,
+    required this.exists,
+  });
+
+  factory ProductsProductListingNextPage.fromJson(Map<String, dynamic> json) => _$ProductsProductListingNextPageFromJson(json);
+
+  @JsonKey(name: 'cursor')
+  String cursor;
+
+  @JsonKey(name: 'exists')
+  bool exists;
+
+  Map<String, dynamic> toJson() => _$ProductsProductListingNextPageToJson(this);
+}
+
+@_model
+class ProductsReviewRequest {
+  ProductsReviewRequest({
+    required this.notify,
+    required this.rating,
+    required this.comment,
+    this.tags,
+  });
+
+  factory ProductsReviewRequest.fromJson(Map<String, dynamic> json) => _$ProductsReviewRequestFromJson(json);
+
+  @JsonKey(name: 'Notify')
+  bool notify;
+
+  @JsonKey(name: 'rating')
+  int rating;
+
+  @JsonKey(name: 'comment')
+  String comment;
+
+  @JsonKey(name: 'tags', includeIfNull: false)
+  List<String>? tags;
+
+  Map<String, dynamic> toJson() => _$ProductsReviewRequestToJson(this);
+}
+
+@_model
+class ProductsSearchParams {
+  ProductsSearchParams({
+    required this.query,
+    required this.cursor,
+    required this.limit,
+  });
+
+  factory ProductsSearchParams.fromJson(Map<String, dynamic> json) => _$ProductsSearchParamsFromJson(json);
+
+  @JsonKey(name: 'Query')
+  String query;
+
+  @JsonKey(name: 'Cursor')
+  String cursor;
+
+  @JsonKey(name: 'Limit')
+  int limit;
+
+  Map<String, dynamic> toJson() => _$ProductsSearchParamsToJson(this);
+}
+
+@_model
+class ProductsSearchResult {
+  ProductsSearchResult({
+    required this.products,
+    required this.nextCursor,
+  });
+
+  factory ProductsSearchResult.fromJson(Map<String, dynamic> json) => _$ProductsSearchResultFromJson(json);
+
+  @JsonKey(name: 'products')
+  List<ProductsProduct?> products;
+
+  @JsonKey(name: 'next_cursor')
+  String nextCursor;
+
+  Map<String, dynamic> toJson() => _$ProductsSearchResultToJson(this);
+}
+
+/// ProductsClient provides access to call the public and authenticated APIs of the products service.
+/// It allows you to create mock implementations of the client during tests.
+abstract interface class ProductsClient {
+  Future<ProductsProduct> create(ProductsCreateProductRequest params);
+
+  Future<ProductsProductListing> list();
+
+  /// Review submits a review of a product, and can be called from HTML forms.
+  Future<void> review(String id, ProductsReviewRequest params);
+
+  /// Search searches the products, a page at a time.
+  Future<ProductsSearchResult> search(ProductsSearchParams params);
+}
+
+class _ProductsClient implements ProductsClient {
+  _ProductsClient(this._baseClient);
+
+  final _BaseClient _baseClient;
+
+  @override
+  Future<ProductsProduct> create(ProductsCreateProductRequest params) async {
+    // Convert our params into the objects we need for the request
+    final headers = <String, Object?>{
+      'idempotency-key': params.idempotencyKey,
+    };
+
+    // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+    final encoded = params.toJson();
+    final body = {
+      for (final key in const ['name', 'description'])
+        if (encoded.containsKey(key)) key: encoded[key],
+    };
+
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('POST', '/products.Create', headers: headers, body: body);
+    return ProductsProduct.fromJson(_BaseClient.readJson(resp) as Map<String, dynamic>);
+  }
+
+  @override
+  Future<ProductsProductListing> list() async {
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('GET', '/products.List');
+    return ProductsProductListing.fromJson(_BaseClient.readJson(resp) as Map<String, dynamic>);
+  }
+
+  @override
+  Future<void> review(String id, ProductsReviewRequest params) async {
+    // Convert our params into the objects we need for the request
+    final query = <String, Object?>{
+      'notify': params.notify,
+    };
+
+    final form = <String, Object?>{
+      'comment': params.comment,
+      'rating': params.rating,
+      'tags': params.tags,
+    };
+
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/products/${Uri.encodeComponent(id)}/reviews', query: query, form: form);
+  }
+
+  @override
+  Future<ProductsSearchResult> search(ProductsSearchParams params) async {
+    // Convert our params into the objects we need for the request
+    final query = <String, Object?>{
+      'cursor': params.cursor,
+      'limit': params.limit,
+      'q': params.query,
+    };
+
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('GET', '/products.Search', query: query);
+    return ProductsSearchResult.fromJson(_BaseClient.readJson(resp) as Map<String, dynamic>);
+  }
+}
+
+@_genericModel
+class SvcAllInputTypes<A> {
+  SvcAllInputTypes({
+    required this.a,
+    required this.b,
+    required this.c,
+    required this.dave,
+  });
+
+  factory SvcAllInputTypes.fromJson(Map<String, dynamic> json, A Function(Object? json) fromJsonA) =>
+      _$SvcAllInputTypesFromJson(json, fromJsonA);
+
+  /// Specify this comes from a header field
+  @JsonKey(name: 'A')
+  DateTime a;
+
+  /// Specify this comes from a query string
+  @JsonKey(name: 'B')
+  List<int> b;
+
+  /// This can come from anywhere, but if it comes from the payload in JSON it must be called Charile
+  @JsonKey(name: 'Charlies-Bool')
+  bool c;
+
+  /// This generic type complicates the whole thing 🙈
+  @JsonKey(name: 'Dave')
+  A dave;
+
+  Map<String, dynamic> toJson(Object? Function(A value) toJsonA) =>
+      _$SvcAllInputTypesToJson(this, toJsonA);
+}
+
+@_model
+class SvcGetRequest {
+  SvcGetRequest({
+    required this.baz,
+  });
+
+  factory SvcGetRequest.fromJson(Map<String, dynamic> json) => _$SvcGetRequestFromJson(json);
+
+  @JsonKey(name: 'Baz')
+  int baz;
+
+  Map<String, dynamic> toJson() => _$SvcGetRequestToJson(this);
+}
+
+/// HeaderOnlyStruct contains all types we support in headers
+@_model
+class SvcHeaderOnlyStruct {
+  SvcHeaderOnlyStruct({
+    required this.boolean,
+    required this.int_,
+    required this.float,
+    required this.string,
+    required this.bytes,
+    required this.time,
+    this.json,
+    required this.uuid,
+    required this.userID,
+  });
+
+  factory SvcHeaderOnlyStruct.fromJson(Map<String, dynamic> json) => _$SvcHeaderOnlyStructFromJson(json);
+
+  @JsonKey(name: 'Boolean')
+  bool boolean;
+
+  @JsonKey(name: 'Int')
+  int int_;
+
+  @JsonKey(name: 'Float')
+  double float;
+
+  @JsonKey(name: 'String')
+  String string;
+
+  @JsonKey(name: 'Bytes')
+  Uint8List bytes;
+
+  @JsonKey(name: 'Time')
+  DateTime time;
+
+  @JsonKey(name: 'Json')
+  Object? json;
+
+  @JsonKey(name: 'UUID')
+  String uuid;
+
+  @JsonKey(name: 'UserID')
+  String userID;
+
+  Map<String, dynamic> toJson() => _$SvcHeaderOnlyStructToJson(this);
+}
+
+@_model
+class SvcRecursive {
+  SvcRecursive({
+    this.optional,
+    required this.slice,
+    required this.map,
+  });
+
+  factory SvcRecursive.fromJson(Map<String, dynamic> json) => _$SvcRecursiveFromJson(json);
+
+  @JsonKey(name: 'Optional', includeIfNull: false)
+  SvcRecursive? optional;
+
+  @JsonKey(name: 'Slice')
+  List<SvcRecursive> slice;
+
+  @JsonKey(name: 'Map')
+  Map<String, SvcRecursive> map;
+
+  Map<String, dynamic> toJson() => _$SvcRecursiveToJson(this);
+}
+
+@_model
+class SvcRequest {
+  SvcRequest({
+    this.foo,
+    required this.baz,
+    this.queryFoo,
+    this.queryBar,
+    this.headerBaz,
+    this.headerInt,
+    this.raw,
+  });
+
+  factory SvcRequest.fromJson(Map<String, dynamic> json) => _$SvcRequestFromJson(json);
+
+  /// Foo is good
+  @JsonKey(name: 'Foo', includeIfNull: false)
+  int? foo;
+
+  /// Baz is better
+  @JsonKey(name: 'boo')
+  String baz;
+
+  @JsonKey(name: 'QueryFoo', includeIfNull: false)
+  bool? queryFoo;
+
+  @JsonKey(name: 'QueryBar', includeIfNull: false)
+  String? queryBar;
+
+  @JsonKey(name: 'HeaderBaz', includeIfNull: false)
+  String? headerBaz;
+
+  @JsonKey(name: 'HeaderInt', includeIfNull: false)
+  int? headerInt;
+
+  /// This is a multiline
+  /// comment on the raw message!
+  @JsonKey(name: 'Raw')
+  Object? raw;
+
+  Map<String, dynamic> toJson() => _$SvcRequestToJson(this);
+}
+
+/// Tuple is a generic type which allows us to
+/// return two values of two different types
+@_genericModel
+class SvcTuple<A, B> {
+  SvcTuple({
+    required this.a,
+    required this.b,
+  });
+
+  factory SvcTuple.fromJson(Map<String, dynamic> json, A Function(Object? json) fromJsonA, B Function(Object? json) fromJsonB) =>
+      _$SvcTupleFromJson(json, fromJsonA, fromJsonB);
+
+  @JsonKey(name: 'A')
+  A a;
+
+  @JsonKey(name: 'B')
+  B b;
+
+  Map<String, dynamic> toJson(Object? Function(A value) toJsonA, Object? Function(B value) toJsonB) =>
+      _$SvcTupleToJson(this, toJsonA, toJsonB);
+}
+
+@_model
+class SvcWithNested {
+  SvcWithNested({
+    this.nested,
+  });
+
+  factory SvcWithNested.fromJson(Map<String, dynamic> json) => _$SvcWithNestedFromJson(json);
+
+  @JsonKey(name: 'Nested')
+  NestedType? nested;
+
+  Map<String, dynamic> toJson() => _$SvcWithNestedToJson(this);
+}
+
+@_genericModel
+class SvcWrapper<T> {
+  SvcWrapper({
+    required this.value,
+  });
+
+  factory SvcWrapper.fromJson(Map<String, dynamic> json, T Function(Object? json) fromJsonT) =>
+      _$SvcWrapperFromJson(json, fromJsonT);
+
+  @JsonKey(name: 'Value')
+  T value;
+
+  Map<String, dynamic> toJson(Object? Function(T value) toJsonT) =>
+      _$SvcWrapperToJson(this, toJsonT);
+}
+
+/// SvcClient provides access to call the public and authenticated APIs of the svc service.
+/// It allows you to create mock implementations of the client during tests.
+abstract interface class SvcClient {
+  /// DummyAPI is a dummy endpoint.
+  Future<void> dummyAPI(SvcRequest params);
+
+  Future<void> fallbackPath(String a, List<String> b);
+
+  Future<void> get(SvcGetRequest params);
+
+  Future<SvcHeaderOnlyStruct> getRequestWithAllInputTypes(SvcAllInputTypes<int> params);
+
+  Future<void> headerOnlyRequest(SvcHeaderOnlyStruct params);
+
+  Future<SvcWithNested> nested(SvcWithNested params);
+
+  Future<void> restPath(String a, int b);
+
+  Future<SvcRecursive> rec(SvcRecursive params);
+
+  Future<SvcAllInputTypes<double>> requestWithAllInputTypes(SvcAllInputTypes<String> params);
+
+  /// TupleInputOutput tests the usage of generics in the client generator
+  /// and this comment is also multiline, so multiline comments get tested as well.
+  Future<SvcTuple<bool, int>> tupleInputOutput(SvcTuple<String, SvcWrapper<SvcRequest>> params);
+
+  /// Upload streams the request body to storage.
+  Future<http.StreamedResponse> upload(String id, {String method = 'PUT', Map<String, String>? headers, Map<String, Object?>? query, Stream<List<int>>? body});
+
+  Future<http.StreamedResponse> webhook(String a, List<String> b, {required String method, Map<String, String>? headers, Map<String, Object?>? query, Stream<List<int>>? body});
+
+  Future<void> webhook2(String a, List<String> b);
+}
+
+class _SvcClient implements SvcClient {
+  _SvcClient(this._baseClient);
+
+  final _BaseClient _baseClient;
+
+  @override
+  Future<void> dummyAPI(SvcRequest params) async {
+    // Convert our params into the objects we need for the request
+    final headers = <String, Object?>{
+      'baz': params.headerBaz,
+      'int': params.headerInt,
+    };
+
+    final query = <String, Object?>{
+      'bar': params.queryBar,
+      'foo': params.queryFoo,
+    };
+
+    // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+    final encoded = params.toJson();
+    final body = {
+      for (final key in const ['Foo', 'boo', 'Raw'])
+        if (encoded.containsKey(key)) key: encoded[key],
+    };
+
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/svc.DummyAPI', headers: headers, query: query, body: body);
+  }
+
+  @override
+  Future<void> fallbackPath(String a, List<String> b) async {
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/fallbackPath/${Uri.encodeComponent(a)}/${b.map(Uri.encodeComponent).join('/')}');
+  }
+
+  @override
+  Future<void> get(SvcGetRequest params) async {
+    // Convert our params into the objects we need for the request
+    final query = <String, Object?>{
+      'boo': params.baz,
+    };
+
+    // Now make the actual call to the API
+    await _baseClient.callAPI('GET', '/svc.Get', query: query);
+  }
+
+  @override
+  Future<SvcHeaderOnlyStruct> getRequestWithAllInputTypes(SvcAllInputTypes<int> params) async {
+    // Convert our params into the objects we need for the request
+    final headers = <String, Object?>{
+      'x-alice': params.a,
+    };
+
+    final query = <String, Object?>{
+      'Bob': params.b,
+      'c': params.c,
+      'dave': params.dave,
+    };
+
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('GET', '/svc.GetRequestWithAllInputTypes', headers: headers, query: query);
+
+    // Populate the return object from the JSON body and received headers
+    final json = _BaseClient.readJson(resp) as Map<String, dynamic>;
+    json['Boolean'] = _BaseClient.mustBeSet(resp, 'x-boolean', (v) => bool.parse(v));
+    json['Int'] = _BaseClient.mustBeSet(resp, 'x-int', (v) => int.parse(v));
+    json['Float'] = _BaseClient.mustBeSet(resp, 'x-float', (v) => double.parse(v));
+    json['String'] = _BaseClient.mustBeSet(resp, 'x-string', (v) => v);
+    json['Bytes'] = _BaseClient.mustBeSet(resp, 'x-bytes', (v) => v);
+    json['Time'] = _BaseClient.mustBeSet(resp, 'x-time', (v) => v);
+    json['Json'] = _BaseClient.mustBeSet(resp, 'x-json', (v) => jsonDecode(v));
+    json['UUID'] = _BaseClient.mustBeSet(resp, 'x-uuid', (v) => v);
+    json['UserID'] = _BaseClient.mustBeSet(resp, 'x-user-id', (v) => v);
+    return SvcHeaderOnlyStruct.fromJson(json);
+  }
+
+  @override
+  Future<void> headerOnlyRequest(SvcHeaderOnlyStruct params) async {
+    // Convert our params into the objects we need for the request
+    final headers = <String, Object?>{
+      'x-boolean': params.boolean,
+      'x-bytes': params.bytes,
+      'x-float': params.float,
+      'x-int': params.int_,
+      'x-json': params.json,
+      'x-string': params.string,
+      'x-time': params.time,
+      'x-user-id': params.userID,
+      'x-uuid': params.uuid,
+    };
+
+    // Now make the actual call to the API
+    await _baseClient.callAPI('GET', '/svc.HeaderOnlyRequest', headers: headers);
+  }
+
+  @override
+  Future<SvcWithNested> nested(SvcWithNested params) async {
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('POST', '/svc.Nested', body: params.toJson());
+    return SvcWithNested.fromJson(_BaseClient.readJson(resp) as Map<String, dynamic>);
+  }
+
+  @override
+  Future<void> restPath(String a, int b) async {
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/path/${Uri.encodeComponent(a)}/${b}');
+  }
+
+  @override
+  Future<SvcRecursive> rec(SvcRecursive params) async {
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('POST', '/svc.Rec', body: params.toJson());
+    return SvcRecursive.fromJson(_BaseClient.readJson(resp) as Map<String, dynamic>);
+  }
+
+  @override
+  Future<SvcAllInputTypes<double>> requestWithAllInputTypes(SvcAllInputTypes<String> params) async {
+    // Convert our params into the objects we need for the request
+    final headers = <String, Object?>{
+      'x-alice': params.a,
+    };
+
+    final query = <String, Object?>{
+      'Bob': params.b,
+    };
+
+    // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+    final encoded = params.toJson((v) => v);
+    final body = {
+      for (final key in const ['Charlies-Bool', 'Dave'])
+        if (encoded.containsKey(key)) key: encoded[key],
+    };
+
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('POST', '/svc.RequestWithAllInputTypes', headers: headers, query: query, body: body);
+
+    // Populate the return object from the JSON body and received headers
+    final json = _BaseClient.readJson(resp) as Map<String, dynamic>;
+    json['A'] = _BaseClient.mustBeSet(resp, 'x-alice', (v) => v);
+    return SvcAllInputTypes<double>.fromJson(json, (v) => (v as num).toDouble());
+  }
+
+  @override
+  Future<SvcTuple<bool, int>> tupleInputOutput(SvcTuple<String, SvcWrapper<SvcRequest>> params) async {
+    // Now make the actual call to the API
+    final resp = await _baseClient.callAPI('POST', '/svc.TupleInputOutput', body: params.toJson((v) => v, (v) => v.toJson((v) => v.toJson())));
+    return SvcTuple<bool, int>.fromJson(_BaseClient.readJson(resp) as Map<String, dynamic>, (v) => v as bool, (v) => (v as num).toInt());
+  }
+
+  @override
+  Future<http.StreamedResponse> upload(String id, {String method = 'PUT', Map<String, String>? headers, Map<String, Object?>? query, Stream<List<int>>? body}) async {
+    return _baseClient.sendRaw(method, '/upload/${Uri.encodeComponent(id)}', headers: headers, query: query, body: body);
+  }
+
+  @override
+  Future<http.StreamedResponse> webhook(String a, List<String> b, {required String method, Map<String, String>? headers, Map<String, Object?>? query, Stream<List<int>>? body}) async {
+    return _baseClient.sendRaw(method, '/webhook/${Uri.encodeComponent(a)}/${b.map(Uri.encodeComponent).join('/')}', headers: headers, query: query, body: body);
+  }
+
+  @override
+  Future<void> webhook2(String a, List<String> b) async {
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/webhook2/${Uri.encodeComponent(a)}/${b.map(Uri.encodeComponent).join('/')}');
+  }
+}
+
+@_model
+class NestedType {
+  NestedType({
+    required this.message,
+  });
+
+  factory NestedType.fromJson(Map<String, dynamic> json) => _$NestedTypeFromJson(json);
+
+  @JsonKey(name: 'Message')
+  String message;
+
+  Map<String, dynamic> toJson() => _$NestedTypeToJson(this);
+}
+
+/// _BaseClient holds all the information we need to make requests to an Encore application.
+class _BaseClient {
+  _BaseClient(String baseUrl, ClientOptions options)
+      : _baseUrl = baseUrl.endsWith('/') ? baseUrl.substring(0, baseUrl.length - 1) : baseUrl,
+        _httpClient = options.httpClient ?? http.Client(),
+        _options = options;
+
+  static const _userAgent = 'app-Generated-Dart-Client (Encore/v0.0.0-develop)';
+
+  final String _baseUrl;
+  final http.Client _httpClient;
+  final ClientOptions _options;
+
+  // callAPI is used by each generated API method to actually make the request.
+  // It throws an APIException if the API returns an error.
+  Future<http.Response> callAPI(
+    String method,
+    String path, {
+    Object? body,
+    Map<String, Object?>? form,
+    Map<String, Object?>? headers,
+    Map<String, Object?>? query,
+  }) async {
+    final request = await _newRequest((url) => http.Request(method, url), path, headers, query);
+    if (form != null) {
+      request.headers['Content-Type'] = 'application/x-www-form-urlencoded';
+      request.body = _encodeQuery(form);
+    } else if (body != null) {
+      request.headers['Content-Type'] = 'application/json';
+      request.body = jsonEncode(body);
+    }
+
+    final response = await http.Response.fromStream(await _httpClient.send(request));
+    if (response.statusCode < 200 || response.statusCode >= 300) {
+      throw _readError(response);
+    }
+    return response;
+  }
+
+  // sendRaw sends a request to a raw API endpoint, returning the response as is.
+  Future<http.StreamedResponse> sendRaw(
+    String method,
+    String path, {
+    Map<String, String>? headers,
+    Map<String, Object?>? query,
+    Stream<List<int>>? body,
+  }) async {
+    final request = await _newRequest((url) => http.StreamedRequest(method, url), path, headers, query);
+    final response = _httpClient.send(request);
+    if (body != null) {
+      body.listen(request.sink.add, onError: request.sink.addError, onDone: request.sink.close);
+    } else {
+      request.sink.close();
+    }
+    return response;
+  }
+
+  // _newRequest creates a request for the given path, adding the headers,
+  // query string and authorization data as required.
+  Future<R> _newRequest<R extends http.BaseRequest>(
+    R Function(Uri url) create,
+    String path,
+    Map<String, Object?>? headers,
+    Map<String, Object?>? query,
+  ) async {
+    headers = {...?headers};
+    query = {...?query};
+    await _addAuthData(headers, query);
+
+    var url = Uri.parse(_baseUrl + path);
+    if (query.isNotEmpty) {
+      url = url.replace(query: _encodeQuery(query));
+    }
+
+    final request = create(url);
+    request.headers['User-Agent'] = _userAgent;
+    if (_options.verifyAPIVersion) {
+      request.headers['X-Encore-API-Version'] = Environments.apiVersion;
+    }
+    request.headers.addAll(_options.headers);
+    headers.forEach((key, value) {
+      final values = _values(value);
+      if (values.isNotEmpty) {
+        request.headers[key] = values.join(', ');
+      }
+    });
+    return request;
+  }
+
+  // _addAuthData adds the authentication data to the request, if there is any.
+  Future<void> _addAuthData(Map<String, Object?> headers, Map<String, Object?> query) async {
+    final authGenerator = _options.authGenerator;
+    final authData = authGenerator != null ? await authGenerator() : _options.auth;
+    if (authData == null) {
+      return;
+    }
+
+    headers['x-api-key'] = authData.apiKey;
+  }
+
+  // readJson decodes the JSON body of the response.
+  static Object? readJson(http.Response response) => jsonDecode(utf8.decode(response.bodyBytes));
+
+  // mustBeSet parses the value of a response header, throwing an APIException with the dataLoss code if it's missing.
+  static T mustBeSet<T>(http.Response response, String header, T Function(String value) parse) {
+    final value = response.headers[header.toLowerCase()];
+    if (value == null) {
+      throw APIException(response.statusCode, ErrCode.dataLoss, 'Header `$header` was unexpectedly missing');
+    }
+    return parse(value);
+  }
+
+  // _readError reads the structured error from a failed API call.
+  static APIException _readError(http.Response response) {
+    final status = response.statusCode;
+    final body = utf8.decode(response.bodyBytes, allowMalformed: true);
+    try {
+      final error = jsonDecode(body);
+      if (error is Map<String, dynamic> && error['code'] is String && error['message'] is String) {
+        return APIException(status, ErrCode.parse(error['code'] as String), error['message'] as String, error['details']);
+      }
+    } on FormatException {
+      // Not a structured error, so fall back to the raw body below.
+    }
+    return APIException(status, ErrCode.unknown, 'request failed: status $status: $body');
+  }
+}
+
+// _values formats the values of a header or query string parameter,
+// which has one value per element if it's a list.
+List<String> _values(Object? value) {
+  if (value == null) {
+    return const [];
+  } else if (value is Iterable<Object?> && value is! Uint8List) {
+    return [for (final v in value) if (v != null) _format(v)];
+  }
+  return [_format(value)];
+}
+
+// _format formats a single value.
+String _format(Object value) {
+  if (value is DateTime) {
+    return value.toUtc().toIso8601String();
+  } else if (value is Uint8List) {
+    return base64Encode(value);
+  } else if (value is String || value is num || value is bool) {
+    return value.toString();
+  }
+  return jsonEncode(value);
+}
+
+// _encodeQuery encodes the parameters as a query string.
+String _encodeQuery(Map<String, Object?> query) => [
+      for (final entry in query.entries)
+        for (final value in _values(entry.value))
+          '${Uri.encodeQueryComponent(entry.key)}=${Uri.encodeQueryComponent(value)}',
+    ].join('&');
+
+const _converters = <JsonConverter>[_DateTimeConverter(), _BytesConverter()];
+
+const _model = JsonSerializable(explicitToJson: true, converters: _converters);
+
+const _genericModel = JsonSerializable(explicitToJson: true, genericArgumentFactories: true, converters: _converters);
+
+// _DateTimeConverter encodes times in the RFC 3339 format expected by Encore.
+class _DateTimeConverter implements JsonConverter<DateTime, String> {
+  const _DateTimeConverter();
+
+  @override
+  DateTime fromJson(String json) => DateTime.parse(json);
+
+  @override
+  String toJson(DateTime object) => object.toUtc().toIso8601String();
+}
+
+// _BytesConverter encodes bytes as base64 strings.
+class _BytesConverter implements JsonConverter<Uint8List, String> {
+  const _BytesConverter();
+
+  @override
+  Uint8List fromJson(String json) => base64Decode(json);
+
+  @override
+  String toJson(Uint8List object) => base64Encode(object);
+}
+
+/// APIException represents a structured error as returned from an Encore application.
+class APIException implements Exception {
+  APIException(this.status, this.code, this.message, [this.details]);
+
+  /// The HTTP status code associated with the error.
+  final int status;
+
+  /// The Encore error code.
+  final ErrCode code;
+
+  /// The error message.
+  final String message;
+
+  /// The error details, if any.
+  final Object? details;
+
+  /// Whether the API rejected the request because the client was generated
+  /// for a different version of the API. Regenerate the client to fix it.
+  bool get isClientOutOfDate {
+    final details = this.details;
+    return code == ErrCode.failedPrecondition && details is Map && details['client_out_of_date'] == true;
+  }
+
+  @override
+  String toString() => 'APIException: ${code.value}: $message';
+}
+
+/// ErrCode is the error code of an APIException.
+enum ErrCode {
+  /// OK indicates the operation was successful.
+  ok('ok'),
+
+  /// Canceled indicates the operation was canceled (typically by the caller).
+  ///
+  /// Encore will generate this error code when cancellation is requested.
+  canceled('canceled'),
+
+  /// Unknown error. An example of where this error may be returned is
+  /// if a Status value received from another address space belongs to
+  /// an error-space that is not known in this address space. Also
+  /// errors raised by APIs that do not return enough error information
+  /// may be converted to this error.
+  ///
+  /// Encore will generate this error code in the above two mentioned cases.
+  unknown('unknown'),
+
+  /// InvalidArgument indicates client specified an invalid argument.
+  /// Note that this differs from FailedPrecondition. It indicates arguments
+  /// that are problematic regardless of the state of the system
+  /// (e.g., a malformed file name).
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  invalidArgument('invalid_argument'),
+
+  /// DeadlineExceeded means operation expired before completion.
+  /// For operations that change the state of the system, this error may be
+  /// returned even if the operation has completed successfully. For
+  /// example, a successful response from a server could have been delayed
+  /// long enough for the deadline to expire.
+  ///
+  /// The gRPC framework will generate this error code when the deadline is
+  /// exceeded.
+  deadlineExceeded('deadline_exceeded'),
+
+  /// NotFound means some requested entity (e.g., file or directory) was
+  /// not found.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  notFound('not_found'),
+
+  /// AlreadyExists means an attempt to create an entity failed because one
+  /// already exists.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  alreadyExists('already_exists'),
+
+  /// PermissionDenied indicates the caller does not have permission to
+  /// execute the specified operation. It must not be used for rejections
+  /// caused by exhausting some resource (use ResourceExhausted
+  /// instead for those errors). It must not be
+  /// used if the caller cannot be identified (use Unauthenticated
+  /// instead for those errors).
+  ///
+  /// This error code will not be generated by the gRPC core framework,
+  /// but expect authentication middleware to use it.
+  permissionDenied('permission_denied'),
+
+  /// ResourceExhausted indicates some resource has been exhausted, perhaps
+  /// a per-user quota, or perhaps the entire file system is out of space.
+  ///
+  /// This error code will be generated by the gRPC framework in
+  /// out-of-memory and server overload situations, or when a message is
+  /// larger than the configured maximum size.
+  resourceExhausted('resource_exhausted'),
+
+  /// FailedPrecondition indicates operation was rejected because the
+  /// system is not in a state required for the operation's execution.
+  /// For example, directory to be deleted may be non-empty, an rmdir
+  /// operation is applied to a non-directory, etc.
+  ///
+  /// A litmus test that may help a service implementor in deciding
+  /// between FailedPrecondition, Aborted, and Unavailable:
+  ///  (a) Use Unavailable if the client can retry just the failing call.
+  ///  (b) Use Aborted if the client should retry at a higher-level
+  ///      (e.g., restarting a read-modify-write sequence).
+  ///  (c) Use FailedPrecondition if the client should not retry until
+  ///      the system state has been explicitly fixed. E.g., if an "rmdir"
+  ///      fails because the directory is non-empty, FailedPrecondition
+  ///      should be returned since the client should not retry unless
+  ///      they have first fixed up the directory by deleting files from it.
+  ///  (d) Use FailedPrecondition if the client performs conditional
+  ///      REST Get/Update/Delete on a resource and the resource on the
+  ///      server does not match the condition. E.g., conflicting
+  ///      read-modify-write on the same resource.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  failedPrecondition('failed_precondition'),
+
+  /// Aborted indicates the operation was aborted, typically due to a
+  /// concurrency issue like sequencer check failures, transaction aborts,
+  /// etc.
+  ///
+  /// See litmus test above for deciding between FailedPrecondition,
+  /// Aborted, and Unavailable.
+  aborted('aborted'),
+
+  /// OutOfRange means operation was attempted past the valid range.
+  /// E.g., seeking or reading past end of file.
+  ///
+  /// Unlike InvalidArgument, this error indicates a problem that may
+  /// be fixed if the system state changes. For example, a 32-bit file
+  /// may be rotated to a 64-bit file without error.
+  ///
+  /// There is a fair bit of overlap between FailedPrecondition and
+  /// OutOfRange. We recommend using OutOfRange (the more specific
+  /// error) when it applies so that callers who are iterating through
+  /// a space can easily look for an OutOfRange error to detect when
+  /// they are done.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  outOfRange('out_of_range'),
+
+  /// Unimplemented indicates operation is not implemented or not
+  /// supported/enabled in this service.
+  ///
+  /// This is not an error, but a feature not available.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  unimplemented('unimplemented'),
+
+  /// Internal means some invariant expected by the underlying system has
+  /// been broken. This is not a per-message error, it is a global
+  /// conditions check.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  internal('internal'),
+
+  /// Unavailable indicates the service is currently unavailable.
+  /// This is most likely a transient condition, which can be corrected by
+  /// retrying with a backoff.
+  ///
+  /// See litmus test above for deciding between FailedPrecondition,
+  /// Aborted, and Unavailable.
+  unavailable('unavailable'),
+
+  /// DataLoss indicates unrecoverable data loss or corruption.
+  ///
+  /// This error code is only defined in the gRPC library, and only for
+  /// unrecoverable data loss (i.e., data loss resulting from errors
+  /// like hard disk corruption or bandwidth exceeded).
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  dataLoss('data_loss'),
+
+  /// Unauthenticated indicates the request does not have valid
+  /// authentication credentials for the operation.
+  ///
+  /// The gRPC framework will generate this error code when the
+  /// authentication metadata is invalid or a Credentials callback fails,
+  /// but also expect authentication middleware to generate it.
+  unauthenticated('unauthenticated');
+
+  const ErrCode(this.value);
+
+  /// The error code as sent by the Encore application.
+  final String value;
+
+  /// Returns the ErrCode with the given value, or [ErrCode.unknown] if there is none.
+  static ErrCode parse(String value) =>
+      ErrCode.values.firstWhere((c) => c.value == value, orElse: () => ErrCode.unknown);
+}
@@ -29,6 +29,9 @@ type BaseURL string
 
 const Local BaseURL = "http://localhost:4000"
 
+// APIVersion is the version of the API the client was generated for.
+const APIVersion = "0a5dad7de3c5eb52"
+
 // Environment returns a BaseURL for calling the cloud environment with the given name.
 func Environment(name string) BaseURL {
 	return BaseURL(fmt.Sprintf("https://%s-app.encr.app", name))
@@ -82,6 +85,39 @@ func WithHTTPClient(client HTTPDoer) Option {
 	}
 }
 
+// WithMiddleware adds middleware which is called for each API request made by the client,
+// such as to add tracing headers, log requests or refresh expired credentials.
+//
+// Middleware is called in the order given, before any middleware given using CallMiddleware.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(base *baseClient) error {
+		base.middleware = append(base.middleware, middleware...)
+		return nil
+	}
+}
+
+// WithRetries configures the client to retry failed API calls to idempotent endpoints
+// using the given policy. It can be overridden for individual calls using CallRetries.
+//
+// By default API calls are not retried.
+func WithRetries(policy RetryPolicy) Option {
+	return func(base *baseClient) error {
+		base.retries = &policy
+		return nil
+	}
+}
+
+// WithAPIVersionCheck configures the client to send APIVersion with each request,
+// so that the API rejects the requests if the client is out of date.
+//
+// Use IsClientOutOfDate to detect such errors.
+func WithAPIVersionCheck() Option {
+	return func(base *baseClient) error {
+		base.verifyAPIVersion = true
+		return nil
+	}
+}
+
 // WithAuth allows you to set the authentication data to be used with each request
 func WithAuth(auth AuthenticationAuthData) Option {
 	return func(base *baseClient) error {
@@ -163,11 +199,38 @@ type ProductsProductListing struct {
 	} `json:"next"`
 }
 
+type ProductsReviewRequest struct {
+	Notify  bool     `query:"notify"`
+	Rating  int      `json:"rating"`
+	Comment string   `json:"comment,omitempty"`
+	Tags    []string `encore:"optional" json:"tags"`
+}
+
+type ProductsSearchParams struct {
+	Query  string `query:"q"`
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+type ProductsSearchResult struct {
+	Products   []*ProductsProduct `json:"products"`
+	NextCursor string             `json:"next_cursor"`
+}
+
 // ProductsClient Provides you access to call public and authenticated APIs on products. The concrete implementation is productsClient.
 // It is setup as an interface allowing you to use GoMock to create mock implementations during tests.
 type ProductsClient interface {
 	Create(ctx context.Context, params ProductsCreateProductRequest) (ProductsProduct, error)
 	List(ctx context.Context) (ProductsProductListing, error)
+
+	// Review submits a review of a product, and can be called from HTML forms.
+	Review(ctx context.Context, id string, params ProductsReviewRequest) error
+
+	// Search searches the products, a page at a time.
+	Search(ctx context.Context, params ProductsSearchParams) (ProductsSearchResult, error)
+
+	// SearchAll iterates over the Products of all pages of Search.
+	SearchAll(ctx context.Context, params ProductsSearchParams) *Pager[*ProductsProduct]
 }
 
 type productsClient struct {
@@ -215,6 +278,69 @@ func (c *productsClient) List(ctx context.Context) (resp ProductsProductListing,
 	return
 }
 
+// Review submits a review of a product, and can be called from HTML forms.
+func (c *productsClient) Review(ctx context.Context, id string, params ProductsReviewRequest) error {
+	// Convert our params into the objects we need for the request
+	reqEncoder := &serde{}
+
+	headers := http.Header{"Content-Type": []string{"application/x-www-form-urlencoded"}}
+
+	queryString := url.Values{"notify": {reqEncoder.FromBool(params.Notify)}}
+
+	form := url.Values{
+		"comment": {reqEncoder.FromString(params.Comment)},
+		"rating":  {reqEncoder.FromInt(params.Rating)},
+		"tags":    reqEncoder.FromStringList(params.Tags),
+	}
+
+	if reqEncoder.LastError != nil {
+		return fmt.Errorf("unable to marshal parameters: %w", reqEncoder.LastError)
+	}
+
+	_, err := callAPI(ctx, c.base, "POST", fmt.Sprintf("/products/%s/reviews?%s", url.PathEscape(id), queryString.Encode()), headers, form, nil)
+	return err
+}
+
+// Search searches the products, a page at a time.
+func (c *productsClient) Search(ctx context.Context, params ProductsSearchParams) (resp ProductsSearchResult, err error) {
+	// Convert our params into the objects we need for the request
+	reqEncoder := &serde{}
+
+	queryString := url.Values{
+		"cursor": {reqEncoder.FromString(params.Cursor)},
+		"limit":  {reqEncoder.FromInt(params.Limit)},
+		"q":      {reqEncoder.FromString(params.Query)},
+	}
+
+	if reqEncoder.LastError != nil {
+		err = fmt.Errorf("unable to marshal parameters: %w", reqEncoder.LastError)
+		return
+	}
+
+	// Now make the actual call to the API
+	_, err = callAPI(ctx, c.base, "GET", fmt.Sprintf("/products.Search?%s", queryString.Encode()), nil, nil, &resp)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// SearchAll returns a Pager iterating over the Products of all pages of Search, starting at params.Cursor.
+func (c *productsClient) SearchAll(ctx context.Context, params ProductsSearchParams) *Pager[*ProductsProduct] {
+	return &Pager[*ProductsProduct]{
+		cursor: params.Cursor,
+		fetch: func(pageCursor string) ([]*ProductsProduct, string, error) {
+			params.Cursor = pageCursor
+			resp, err := c.Search(ctx, params)
+			if err != nil {
+				return nil, "", err
+			}
+			return resp.Products, resp.NextCursor, nil
+		},
+	}
+}
+
 type SvcAllInputTypes[A any] struct {
 	A    time.Time `header:"X-Alice"`               // Specify this comes from a header field
 	B    []int     `query:"Bob"`                    // Specify this comes from a query string
@@ -294,6 +420,9 @@ type SvcClient interface {
 	// TupleInputOutput tests the usage of generics in the client generator
 	// and this comment is also multiline, so multiline comments get tested as well.
 	TupleInputOutput(ctx context.Context, params SvcTuple[string, SvcWrappedRequest]) (SvcTuple[bool, SvcFoo], error)
+
+	// Upload streams the request body to storage.
+	Upload(ctx context.Context, id string, request *http.Request) (*http.Response, error)
 	Webhook(ctx context.Context, a string, b []string, request *http.Request) (*http.Response, error)
 	Webhook2(ctx context.Context, a string, b []string) error
 }
@@ -516,8 +645,41 @@ func (c *svcClient) TupleInputOutput(ctx context.Context, params SvcTuple[string
 	return
 }
 
+// Upload streams the request body to storage.
+func (c *svcClient) Upload(ctx context.Context, id string, request *http.Request) (*http.Response, error) {
+	request = request.WithContext(ctx)
+	if request.Header == nil {
+		request.Header = make(http.Header)
+	}
+
+	// Default to the only method the endpoint accepts
+	if request.Method == "" {
+		request.Method = "PUT"
+	}
+
+	// Set the relative URL for the API call
+	path, err := url.Parse(fmt.Sprintf("/upload/%s", url.PathEscape(id)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse api url: %w", err)
+	}
+	if request.URL != nil {
+		// If the request already has a URL associated, we'll keep any fields set inside it, and just override the schema,
+		// host and path to ensure the final URL which hit the right BaseURL
+		request.URL.Scheme = path.Scheme
+		request.URL.Host = path.Host
+		request.URL.Path = path.Path
+	} else {
+		request.URL = path
+	}
+
+	return c.base.Do(request)
+}
+
 func (c *svcClient) Webhook(ctx context.Context, a string, b []string, request *http.Request) (*http.Response, error) {
 	request = request.WithContext(ctx)
+	if request.Header == nil {
+		request.Header = make(http.Header)
+	}
 
 	// Check the request has the method set, as we can't guess what method is required
 	if request.Method == "" {
@@ -558,18 +720,83 @@ type HTTPDoer interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// Middleware is called for each API request made by the client, and calls next to send the request.
+// It can modify the request before it's sent, or inspect and modify the response.
+type Middleware func(req *http.Request, next func(req *http.Request) (*http.Response, error)) (*http.Response, error)
+
+// RetryPolicy configures how failed API calls to idempotent endpoints are retried.
+// Only calls using the GET, HEAD, PUT, DELETE and OPTIONS HTTP methods are retried.
+type RetryPolicy struct {
+	MaxAttempts    int           // The maximum number of attempts, including the first one
+	InitialBackoff time.Duration // The delay before the first retry, doubled for each following retry (defaults to 100ms)
+	MaxBackoff     time.Duration // The maximum delay between attempts (defaults to 5s)
+
+	// ShouldRetry reports whether a failed attempt should be retried, given its response or error.
+	// Defaults to retrying network errors and 429, 502, 503 and 504 responses.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// CallOption configures individual API calls. Use WithCallOptions to apply them.
+type CallOption = func(opts *callOptions)
+
+// callOptions are the options of individual API calls
+type callOptions struct {
+	middleware []Middleware
+	retries    *RetryPolicy
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx which applies the given options
+// to the API calls made with it, in addition to any options already in ctx.
+func WithCallOptions(ctx context.Context, options ...CallOption) context.Context {
+	opts := &callOptions{}
+	if prev, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok {
+		opts.middleware = append([]Middleware(nil), prev.middleware...)
+		opts.retries = prev.retries
+	}
+	for _, option := range options {
+		option(opts)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, opts)
+}
+
+// CallMiddleware adds middleware to the API calls, called after any middleware given using WithMiddleware.
+func CallMiddleware(middleware ...Middleware) CallOption {
+	return func(opts *callOptions) {
+		opts.middleware = append(opts.middleware, middleware...)
+	}
+}
+
+// CallRetries sets the retry policy of the API calls, overriding any policy given using WithRetries.
+// Use a policy with MaxAttempts set to 1 to disable retries.
+func CallRetries(policy RetryPolicy) CallOption {
+	return func(opts *callOptions) {
+		opts.retries = &policy
+	}
+}
+
 // baseClient holds all the information we need to make requests to an Encore application
 type baseClient struct {
-	authGenerator func(ctx context.Context) (AuthenticationAuthData, error) // The function which will add the authentication data to the requests
-	httpClient    HTTPDoer                                                  // The HTTP client which will be used for all API requests
-	baseURL       *url.URL                                                  // The base URL which API requests will be made against
-	userAgent     string                                                    // What user agent we will use in the API requests
+	authGenerator    func(ctx context.Context) (AuthenticationAuthData, error) // The function which will add the authentication data to the requests
+	httpClient       HTTPDoer                                                  // The HTTP client which will be used for all API requests
+	baseURL          *url.URL                                                  // The base URL which API requests will be made against
+	userAgent        string                                                    // What user agent we will use in the API requests
+	middleware       []Middleware                                              // The middleware called for each API request
+	retries          *RetryPolicy                                              // How failed API calls are retried, or nil if they're not retried
+	verifyAPIVersion bool                                                      // Whether to send APIVersion with each request
 }
 
 // Do sends the req to the Encore application adding the authorization token as required.
 func (b *baseClient) Do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Content-Type", "application/json")
+	// Requests are JSON-encoded unless the caller specified another content type
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("User-Agent", b.userAgent)
+	if b.verifyAPIVersion {
+		req.Header.Set("X-Encore-API-Version", APIVersion)
+	}
 
 	// If a authorization data generator is present, call it and add the returned token to the request
 	if b.authGenerator != nil {
@@ -592,39 +819,79 @@ func (b *baseClient) Do(req *http.Request) (*http.Response, error) {
 	req.URL = b.baseURL.ResolveReference(req.URL)
 	req.Host = req.URL.Host
 
-	// Finally, make the request via the configured HTTP Client
-	return b.httpClient.Do(req)
+	// Finally, make the request via the configured HTTP Client, passing it through any middleware
+	send := b.httpClient.Do
+	middleware := b.middleware
+	if opts, ok := req.Context().Value(callOptionsKey{}).(*callOptions); ok {
+		middleware = append(middleware[:len(middleware):len(middleware)], opts.middleware...)
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		mw, next := middleware[i], send
+		send = func(req *http.Request) (*http.Response, error) {
+			return mw(req, next)
+		}
+	}
+	return send(req)
 }
 
 // callAPI is used by each generated API method to actually make request and decode the responses
 func callAPI(ctx context.Context, client *baseClient, method, path string, headers http.Header, body, resp any) (http.Header, error) {
 	// Encode the API body
-	var bodyReader io.Reader
-	if body != nil {
-		bodyBytes, err := json.Marshal(body)
+	var bodyBytes []byte
+	if form, ok := body.(url.Values); ok {
+		bodyBytes = []byte(form.Encode())
+	} else if body != nil {
+		var err error
+		bodyBytes, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshal request: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
 	}
 
-	// Create the request
-	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+	// Work out if and how the call is retried if it fails
+	retries := client.retries
+	if opts, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok && opts.retries != nil {
+		retries = opts.retries
+	}
+	if !isIdempotent(method) {
+		retries = nil
 	}
 
-	// Add any headers to the request
-	for header, values := range headers {
-		for _, value := range values {
-			req.Header.Add(header, value)
+	var rawResponse *http.Response
+	for attempt := 1; ; attempt++ {
+		// Create the request
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("create request: %w", err)
 		}
-	}
 
-	// Make the request via the base client
-	rawResponse, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		// Add any headers to the request
+		for header, values := range headers {
+			for _, value := range values {
+				req.Header.Add(header, value)
+			}
+		}
+
+		// Make the request via the base client
+		rawResponse, err = client.Do(req)
+		if retries == nil || attempt >= retries.MaxAttempts || !retries.shouldRetry(rawResponse, err) {
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			break
+		}
+
+		// Discard the failed response and wait before trying again
+		if rawResponse != nil {
+			_ = rawResponse.Body.Close()
+		}
+		if err := retries.wait(ctx, attempt); err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
 	}
 	defer func() {
 		_ = rawResponse.Body.Close()
@@ -660,6 +927,58 @@ func callAPI(ctx context.Context, client *baseClient, method, path string, heade
 	return rawResponse.Header, nil
 }
 
+// isIdempotent reports whether requests with the given HTTP method are idempotent,
+// and can therefore be retried safely.
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a failed attempt should be retried.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// wait waits before retrying a call after the given number of failed attempts.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 5 * time.Second
+	}
+	for i := 1; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // pathEscapeSlice escapes a slice of strings and then joins them into a single string
 func pathEscapeSlice(paths []string) string {
 	var escapedPaths strings.Builder
@@ -672,6 +991,48 @@ func pathEscapeSlice(paths []string) string {
 	return escapedPaths.String()
 }
 
+// Pager iterates over the items of a paginated API, fetching the pages as needed:
+//
+//	pager := client.Svc.ListAll(ctx, params)
+//	for pager.Next() {
+//		item := pager.Item()
+//	}
+//	if err := pager.Err(); err != nil {
+//		// handle the error
+//	}
+type Pager[T any] struct {
+	fetch  func(cursor string) ([]T, string, error)
+	cursor string
+	items  []T
+	item   T
+	done   bool
+	err    error
+}
+
+// Next advances to the next item, fetching the next page if needed.
+// It reports false when there are no more items, or an error occurred.
+func (p *Pager[T]) Next() bool {
+	for len(p.items) == 0 {
+		if p.done || p.err != nil {
+			return false
+		}
+		p.items, p.cursor, p.err = p.fetch(p.cursor)
+		p.done = p.cursor == ""
+	}
+	p.item, p.items = p.items[0], p.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (p *Pager[T]) Item() T {
+	return p.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
 // APIError is the error type returned by the API
 type APIError struct {
 	Code    ErrCode `json:"code"`
@@ -683,6 +1044,17 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Code, e.Message)
 }
 
+// IsClientOutOfDate reports whether the API rejected the request because the client
+// was generated for a different version of the API. Regenerate the client to fix it.
+func IsClientOutOfDate(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrFailedPrecondition {
+		return false
+	}
+	details, _ := apiErr.Details.(map[string]any)
+	return details["client_out_of_date"] == true
+}
+
 type ErrCode int
 
 const (
@@ -942,14 +1314,22 @@ func (e *serde) FromString(s string) (v string) {
 	return s
 }
 
+func (e *serde) FromBool(s bool) (v string) {
+	e.NonEmptyValues++
+	return strconv.FormatBool(s)
+}
+
 func (e *serde) FromInt(s int) (v string) {
 	e.NonEmptyValues++
 	return strconv.FormatInt(int64(s), 10)
 }
 
-func (e *serde) FromBool(s bool) (v string) {
+func (e *serde) FromStringList(s []string) (v []string) {
 	e.NonEmptyValues++
-	return strconv.FormatBool(s)
+	for _, x := range s {
+		v = append(v, e.FromString(x))
+	}
+	return v
 }
 
 func (e *serde) FromTime(s time.Time) (v string) {
@@ -10,6 +10,13 @@
  */
 export const Local = "http://localhost:4000"
 
+/**
+ * APIVersion is the version of the API the client was generated for.
+ * Set the verifyAPIVersion client option to send it with each request,
+ * so that the API rejects the requests if the client is out of date.
+ */
+export const APIVersion = "0a5dad7de3c5eb52"
+
 /**
  * Environment returns a BaseURL for calling the cloud environment with the given name.
  */
@@ -83,6 +90,45 @@ class ProductsServiceClient {
         const resp = await this.baseClient.callAPI("GET", `/products.List`)
         return await resp.json()
     }
+
+    /**
+     * Review submits a review of a product, and can be called from HTML forms.
+     */
+    async Review(id, params) {
+        // Convert our params into the objects we need for the request
+        const headers = makeRecord({
+            "Content-Type": "application/x-www-form-urlencoded",
+        })
+
+        const query = makeRecord({
+            notify: String(params.Notify),
+        })
+
+        // Construct the form-encoded body with only the fields which we want encoded within the body
+        const form = makeRecord({
+            comment: params.comment,
+            rating:  String(params.rating),
+            tags:    params.tags?.map((v) => v),
+        })
+
+        await this.baseClient.callAPI("POST", `/products/${encodeURIComponent(id)}/reviews`, encodeQuery(form), {headers, query})
+    }
+
+    /**
+     * Search searches the products, a page at a time.
+     */
+    async Search(params) {
+        // Convert our params into the objects we need for the request
+        const query = makeRecord({
+            cursor: params.Cursor,
+            limit:  String(params.Limit),
+            q:      params.Query,
+        })
+
+        // Now make the actual call to the API
+        const resp = await this.baseClient.callAPI("GET", `/products.Search`, undefined, {query})
+        return await resp.json()
+    }
 }
 
 export const products = {
@@ -229,6 +275,13 @@ class SvcServiceClient {
         return await resp.json()
     }
 
+    /**
+     * Upload streams the request body to storage.
+     */
+    async Upload(method, id, body, options) {
+        return this.baseClient.callAPI(method, `/upload/${encodeURIComponent(id)}`, body, options)
+    }
+
     async Webhook(method, a, b, body, options) {
         return this.baseClient.callAPI(method, `/webhook/${encodeURIComponent(a)}/${b.map(encodeURIComponent).join("/")}`, body, options)
     }
@@ -248,7 +301,7 @@ function encodeQuery(parts) {
     for (const key in parts) {
         const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]])
         for (const v of val) {
-            pairs.push(`${key}=${encodeURIComponent(v)}`)
+            pairs.push(`${encodeURIComponent(key)}=${encodeURIComponent(v)}`)
         }
     }
     return pairs.join("&")
@@ -457,6 +510,10 @@ class BaseClient {
             this.headers["User-Agent"] = "app-Generated-JS-Client (Encore/v0.0.0-develop)";
         }
 
+        if (options.verifyAPIVersion) {
+            this.headers["X-Encore-API-Version"] = APIVersion
+        }
+
         this.requestInit = options.requestInit ?? {}
 
         // Setup what fetch function we'll be using in the base client
@@ -693,6 +750,14 @@ export function isAPIError(err) {
     return err instanceof APIError;
 }
 
+/**
+ * isClientOutOfDate reports whether the API rejected the request because the client
+ * was generated for a different version of the API. Regenerate the client to fix it.
+ */
+export function isClientOutOfDate(err) {
+    return isAPIError(err) && err.code === ErrCode.FailedPrecondition && err.details?.client_out_of_date === true
+}
+
 export const ErrCode = {
     /**
      * OK indicates the operation was successful.
@@ -0,0 +1,455 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+// ignore_for_file: type=lint
+
+import 'dart:async';
+import 'dart:convert';
+import 'dart:typed_data';
+
+import 'package:http/http.dart' as http;
+import 'package:json_annotation/json_annotation.dart';
+
+part 'client.g.dart';
+
+/// Environments contains the base URLs for calling the app Encore application's API.
+class Environments {
+  Environments._();
+
+  /// The base URL of the locally running application.
+  static const local = 'http://localhost:4000';
+
+  /// Returns the base URL for calling the cloud environment with the given name.
+  static String environment(String name) => 'https://$name-app.encr.app';
+
+  /// Returns the base URL for calling the preview environment with the given PR number.
+  static String previewEnv(int pr) => environment('pr$pr');
+
+  /// The version of the API the client was generated for.
+  static const apiVersion = '494e11531b8ae81c';
+}
+
+/// Client is an API client for the app Encore application.
+class Client {
+  /// Creates a Client for calling the public and authenticated APIs of your Encore application.
+  ///
+  /// The [baseUrl] is the base URL of the application; see [Environments] for options.
+  Client(String baseUrl, {ClientOptions options = const ClientOptions()})
+      : this._(_BaseClient(baseUrl, options));
+
+  Client._(_BaseClient baseClient)
+      : svc = _SvcClient(baseClient);
+
+  final SvcClient svc;
+}
+
+/// ClientOptions allows you to override any default behaviour within the generated Encore client.
+class ClientOptions {
+  const ClientOptions({
+    this.httpClient,
+    this.headers = const {},
+    this.verifyAPIVersion = false,
+  });
+
+  /// The HTTP client used to make the API requests. By default a new http.Client is created,
+  /// however you can provide your own to configure timeouts or proxies, or to run custom
+  /// code on each API request made or response received.
+  final http.Client? httpClient;
+
+  /// Headers which are added to each API request.
+  final Map<String, String> headers;
+
+  /// Whether to send [Environments.apiVersion] with each API request, so that the API
+  /// rejects the requests if the client is out of date. Such requests fail with an
+  /// [APIException] whose [APIException.isClientOutOfDate] is true.
+  final bool verifyAPIVersion;
+}
+
+@_model
+class SvcRequest {
+  SvcRequest({
+    required this.message,
+  });
+
+  factory SvcRequest.fromJson(Map<String, dynamic> json) => _$SvcRequestFromJson(json);
+
+  @JsonKey(name: 'Message')
+  String message;
+
+  Map<String, dynamic> toJson() => _$SvcRequestToJson(this);
+}
+
+/// SvcClient provides access to call the public and authenticated APIs of the svc service.
+/// It allows you to create mock implementations of the client during tests.
+abstract interface class SvcClient {
+  /// DummyAPI is a dummy endpoint.
+  Future<void> dummyAPI(SvcRequest params);
+}
+
+class _SvcClient implements SvcClient {
+  _SvcClient(this._baseClient);
+
+  final _BaseClient _baseClient;
+
+  @override
+  Future<void> dummyAPI(SvcRequest params) async {
+    // Now make the actual call to the API
+    await _baseClient.callAPI('POST', '/svc.DummyAPI', body: params.toJson());
+  }
+}
+
+/// _BaseClient holds all the information we need to make requests to an Encore application.
+class _BaseClient {
+  _BaseClient(String baseUrl, ClientOptions options)
+      : _baseUrl = baseUrl.endsWith('/') ? baseUrl.substring(0, baseUrl.length - 1) : baseUrl,
+        _httpClient = options.httpClient ?? http.Client(),
+        _options = options;
+
+  static const _userAgent = 'app-Generated-Dart-Client (Encore/v0.0.0-develop)';
+
+  final String _baseUrl;
+  final http.Client _httpClient;
+  final ClientOptions _options;
+
+  // callAPI is used by each generated API method to actually make the request.
+  // It throws an APIException if the API returns an error.
+  Future<http.Response> callAPI(
+    String method,
+    String path, {
+    Object? body,
+    Map<String, Object?>? form,
+    Map<String, Object?>? headers,
+    Map<String, Object?>? query,
+  }) async {
+    final request = await _newRequest((url) => http.Request(method, url), path, headers, query);
+    if (form != null) {
+      request.headers['Content-Type'] = 'application/x-www-form-urlencoded';
+      request.body = _encodeQuery(form);
+    } else if (body != null) {
+      request.headers['Content-Type'] = 'application/json';
+      request.body = jsonEncode(body);
+    }
+
+    final response = await http.Response.fromStream(await _httpClient.send(request));
+    if (response.statusCode < 200 || response.statusCode >= 300) {
+      throw _readError(response);
+    }
+    return response;
+  }
+
+  // sendRaw sends a request to a raw API endpoint, returning the response as is.
+  Future<http.StreamedResponse> sendRaw(
+    String method,
+    String path, {
+    Map<String, String>? headers,
+    Map<String, Object?>? query,
+    Stream<List<int>>? body,
+  }) async {
+    final request = await _newRequest((url) => http.StreamedRequest(method, url), path, headers, query);
+    final response = _httpClient.send(request);
+    if (body != null) {
+      body.listen(request.sink.add, onError: request.sink.addError, onDone: request.sink.close);
+    } else {
+      request.sink.close();
+    }
+    return response;
+  }
+
+  // _newRequest creates a request for the given path, adding the headers,
+  // query string and authorization data as required.
+  Future<R> _newRequest<R extends http.BaseRequest>(
+    R Function(Uri url) create,
+    String path,
+    Map<String, Object?>? headers,
+    Map<String, Object?>? query,
+  ) async {
+    headers = {...?headers};
+    query = {...?query};
+
+    var url = Uri.parse(_baseUrl + path);
+    if (query.isNotEmpty) {
+      url = url.replace(query: _encodeQuery(query));
+    }
+
+    final request = create(url);
+    request.headers['User-Agent'] = _userAgent;
+    if (_options.verifyAPIVersion) {
+      request.headers['X-Encore-API-Version'] = Environments.apiVersion;
+    }
+    request.headers.addAll(_options.headers);
+    headers.forEach((key, value) {
+      final values = _values(value);
+      if (values.isNotEmpty) {
+        request.headers[key] = values.join(', ');
+      }
+    });
+    return request;
+  }
+
+  // readJson decodes the JSON body of the response.
+  static Object? readJson(http.Response response) => jsonDecode(utf8.decode(response.bodyBytes));
+
+  // _readError reads the structured error from a failed API call.
+  static APIException _readError(http.Response response) {
+    final status = response.statusCode;
+    final body = utf8.decode(response.bodyBytes, allowMalformed: true);
+    try {
+      final error = jsonDecode(body);
+      if (error is Map<String, dynamic> && error['code'] is String && error['message'] is String) {
+        return APIException(status, ErrCode.parse(error['code'] as String), error['message'] as String, error['details']);
+      }
+    } on FormatException {
+      // Not a structured error, so fall back to the raw body below.
+    }
+    return APIException(status, ErrCode.unknown, 'request failed: status $status: $body');
+  }
+}
+
+// _values formats the values of a header or query string parameter,
+// which has one value per element if it's a list.
+List<String> _values(Object? value) {
+  if (value == null) {
+    return const [];
+  } else if (value is Iterable<Object?> && value is! Uint8List) {
+    return [for (final v in value) if (v != null) _format(v)];
+  }
+  return [_format(value)];
+}
+
+// _format formats a single value.
+String _format(Object value) {
+  if (value is DateTime) {
+    return value.toUtc().toIso8601String();
+  } else if (value is Uint8List) {
+    return base64Encode(value);
+  } else if (value is String || value is num || value is bool) {
+    return value.toString();
+  }
+  return jsonEncode(value);
+}
+
+// _encodeQuery encodes the parameters as a query string.
+String _encodeQuery(Map<String, Object?> query) => [
+      for (final entry in query.entries)
+        for (final value in _values(entry.value))
+          '${Uri.encodeQueryComponent(entry.key)}=${Uri.encodeQueryComponent(value)}',
+    ].join('&');
+
+const _converters = <JsonConverter>[_DateTimeConverter(), _BytesConverter()];
+
+const _model = JsonSerializable(explicitToJson: true, converters: _converters);
+
+const _genericModel = JsonSerializable(explicitToJson: true, genericArgumentFactories: true, converters: _converters);
+
+// _DateTimeConverter encodes times in the RFC 3339 format expected by Encore.
+class _DateTimeConverter implements JsonConverter<DateTime, String> {
+  const _DateTimeConverter();
+
+  @override
+  DateTime fromJson(String json) => DateTime.parse(json);
+
+  @override
+  String toJson(DateTime object) => object.toUtc().toIso8601String();
+}
+
+// _BytesConverter encodes bytes as base64 strings.
+class _BytesConverter implements JsonConverter<Uint8List, String> {
+  const _BytesConverter();
+
+  @override
+  Uint8List fromJson(String json) => base64Decode(json);
+
+  @override
+  String toJson(Uint8List object) => base64Encode(object);
+}
+
+/// APIException represents a structured error as returned from an Encore application.
+class APIException implements Exception {
+  APIException(this.status, this.code, this.message, [this.details]);
+
+  /// The HTTP status code associated with the error.
+  final int status;
+
+  /// The Encore error code.
+  final ErrCode code;
+
+  /// The error message.
+  final String message;
+
+  /// The error details, if any.
+  final Object? details;
+
+  /// Whether the API rejected the request because the client was generated
+  /// for a different version of the API. Regenerate the client to fix it.
+  bool get isClientOutOfDate {
+    final details = this.details;
+    return code == ErrCode.failedPrecondition && details is Map && details['client_out_of_date'] == true;
+  }
+
+  @override
+  String toString() => 'APIException: ${code.value}: $message';
+}
+
+/// ErrCode is the error code of an APIException.
+enum ErrCode {
+  /// OK indicates the operation was successful.
+  ok('ok'),
+
+  /// Canceled indicates the operation was canceled (typically by the caller).
+  ///
+  /// Encore will generate this error code when cancellation is requested.
+  canceled('canceled'),
+
+  /// Unknown error. An example of where this error may be returned is
+  /// if a Status value received from another address space belongs to
+  /// an error-space that is not known in this address space. Also
+  /// errors raised by APIs that do not return enough error information
+  /// may be converted to this error.
+  ///
+  /// Encore will generate this error code in the above two mentioned cases.
+  unknown('unknown'),
+
+  /// InvalidArgument indicates client specified an invalid argument.
+  /// Note that this differs from FailedPrecondition. It indicates arguments
+  /// that are problematic regardless of the state of the system
+  /// (e.g., a malformed file name).
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  invalidArgument('invalid_argument'),
+
+  /// DeadlineExceeded means operation expired before completion.
+  /// For operations that change the state of the system, this error may be
+  /// returned even if the operation has completed successfully. For
+  /// example, a successful response from a server could have been delayed
+  /// long enough for the deadline to expire.
+  ///
+  /// The gRPC framework will generate this error code when the deadline is
+  /// exceeded.
+  deadlineExceeded('deadline_exceeded'),
+
+  /// NotFound means some requested entity (e.g., file or directory) was
+  /// not found.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  notFound('not_found'),
+
+  /// AlreadyExists means an attempt to create an entity failed because one
+  /// already exists.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  alreadyExists('already_exists'),
+
+  /// PermissionDenied indicates the caller does not have permission to
+  /// execute the specified operation. It must not be used for rejections
+  /// caused by exhausting some resource (use ResourceExhausted
+  /// instead for those errors). It must not be
+  /// used if the caller cannot be identified (use Unauthenticated
+  /// instead for those errors).
+  ///
+  /// This error code will not be generated by the gRPC core framework,
+  /// but expect authentication middleware to use it.
+  permissionDenied('permission_denied'),
+
+  /// ResourceExhausted indicates some resource has been exhausted, perhaps
+  /// a per-user quota, or perhaps the entire file system is out of space.
+  ///
+  /// This error code will be generated by the gRPC framework in
+  /// out-of-memory and server overload situations, or when a message is
+  /// larger than the configured maximum size.
+  resourceExhausted('resource_exhausted'),
+
+  /// FailedPrecondition indicates operation was rejected because the
+  /// system is not in a state required for the operation's execution.
+  /// For example, directory to be deleted may be non-empty, an rmdir
+  /// operation is applied to a non-directory, etc.
+  ///
+  /// A litmus test that may help a service implementor in deciding
+  /// between FailedPrecondition, Aborted, and Unavailable:
+  ///  (a) Use Unavailable if the client can retry just the failing call.
+  ///  (b) Use Aborted if the client should retry at a higher-level
+  ///      (e.g., restarting a read-modify-write sequence).
+  ///  (c) Use FailedPrecondition if the client should not retry until
+  ///      the system state has been explicitly fixed. E.g., if an "rmdir"
+  ///      fails because the directory is non-empty, FailedPrecondition
+  ///      should be returned since the client should not retry unless
+  ///      they have first fixed up the directory by deleting files from it.
+  ///  (d) Use FailedPrecondition if the client performs conditional
+  ///      REST Get/Update/Delete on a resource and the resource on the
+  ///      server does not match the condition. E.g., conflicting
+  ///      read-modify-write on the same resource.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  failedPrecondition('failed_precondition'),
+
+  /// Aborted indicates the operation was aborted, typically due to a
+  /// concurrency issue like sequencer check failures, transaction aborts,
+  /// etc.
+  ///
+  /// See litmus test above for deciding between FailedPrecondition,
+  /// Aborted, and Unavailable.
+  aborted('aborted'),
+
+  /// OutOfRange means operation was attempted past the valid range.
+  /// E.g., seeking or reading past end of file.
+  ///
+  /// Unlike InvalidArgument, this error indicates a problem that may
+  /// be fixed if the system state changes. For example, a 32-bit file
+  /// may be rotated to a 64-bit file without error.
+  ///
+  /// There is a fair bit of overlap between FailedPrecondition and
+  /// OutOfRange. We recommend using OutOfRange (the more specific
+  /// error) when it applies so that callers who are iterating through
+  /// a space can easily look for an OutOfRange error to detect when
+  /// they are done.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  outOfRange('out_of_range'),
+
+  /// Unimplemented indicates operation is not implemented or not
+  /// supported/enabled in this service.
+  ///
+  /// This is not an error, but a feature not available.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  unimplemented('unimplemented'),
+
+  /// Internal means some invariant expected by the underlying system has
+  /// been broken. This is not a per-message error, it is a global
+  /// conditions check.
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  internal('internal'),
+
+  /// Unavailable indicates the service is currently unavailable.
+  /// This is most likely a transient condition, which can be corrected by
+  /// retrying with a backoff.
+  ///
+  /// See litmus test above for deciding between FailedPrecondition,
+  /// Aborted, and Unavailable.
+  unavailable('unavailable'),
+
+  /// DataLoss indicates unrecoverable data loss or corruption.
+  ///
+  /// This error code is only defined in the gRPC library, and only for
+  /// unrecoverable data loss (i.e., data loss resulting from errors
+  /// like hard disk corruption or bandwidth exceeded).
+  ///
+  /// This error code will not be generated by the gRPC framework.
+  dataLoss('data_loss'),
+
+  /// Unauthenticated indicates the request does not have valid
+  /// authentication credentials for the operation.
+  ///
+  /// The gRPC framework will generate this error code when the
+  /// authentication metadata is invalid or a Credentials callback fails,
+  /// but also expect authentication middleware to generate it.
+  unauthenticated('unauthenticated');
+
+  const ErrCode(this.value);
+
+  /// The error code as sent by the Encore application.
+  final String value;
+
+  /// Returns the ErrCode with the given value, or [ErrCode.unknown] if there is none.
+  static ErrCode parse(String value) =>
+      ErrCode.values.firstWhere((c) => c.value == value, orElse: () => ErrCode.unknown);
+}
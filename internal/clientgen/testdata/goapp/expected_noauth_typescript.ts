@@ -12,6 +12,11 @@ export type BaseURL = string
 
 export const Local: BaseURL = "http://localhost:4000"
 
+/**
+ * APIVersion is the version of the API the client was generated for.
+ */
+export const APIVersion = "494e11531b8ae81c"
+
 /**
  * Environment returns a BaseURL for calling the cloud environment with the given name.
  */
@@ -58,6 +63,12 @@ export interface ClientOptions {
 
     /** Default RequestInit to be used for the client */
     requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+
+    /**
+     * Whether to send APIVersion with each request, so that the API rejects the requests
+     * if the client is out of date. Use isClientOutOfDate to detect such errors.
+     */
+    verifyAPIVersion?: boolean
 }
 
 export namespace svc {
@@ -88,7 +99,7 @@ function encodeQuery(parts: Record<string, string | string[]>): string {
     for (const key in parts) {
         const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]]) as string[]
         for (const v of val) {
-            pairs.push(`${key}=${encodeURIComponent(v)}`)
+            pairs.push(`${encodeURIComponent(key)}=${encodeURIComponent(v)}`)
         }
     }
     return pairs.join("&")
@@ -106,6 +117,53 @@ function makeRecord<K extends string | number | symbol, V>(record: Record<K, V |
     return record as Record<K, V>
 }
 
+/**
+ * StreamOptions configures a stream to a streaming API endpoint.
+ */
+export interface StreamOptions {
+    /**
+     * signal closes the stream when aborted.
+     */
+    signal?: AbortSignal
+
+    /**
+     * reconnect configures reopening the stream when the connection is lost
+     * before the stream is closed. If unset the stream is not reopened.
+     */
+    reconnect?: ReconnectOptions
+}
+
+/**
+ * ReconnectOptions configures how lost stream connections are reopened.
+ */
+export interface ReconnectOptions {
+    /**
+     * maxAttempts is the maximum number of attempts in a row to reopen the stream.
+     * Defaults to 5.
+     */
+    maxAttempts?: number
+
+    /**
+     * initialDelayMs is the delay before the first attempt, which is doubled
+     * for each following attempt. Defaults to 500 milliseconds.
+     */
+    initialDelayMs?: number
+
+    /**
+     * maxDelayMs is the maximum delay between attempts. Defaults to 10 seconds.
+     */
+    maxDelayMs?: number
+}
+
+/**
+ * StreamCallbacks are called as events happen on a stream.
+ */
+export interface StreamCallbacks<Response> {
+    onMessage?: (msg: Response) => void
+    onError?: (event: any) => void
+    onClose?: () => void
+}
+
 function encodeWebSocketHeaders(headers: Record<string, string>) {
     // url safe, no pad
     const base64encoded = btoa(JSON.stringify(headers))
@@ -115,26 +173,77 @@ function encodeWebSocketHeaders(headers: Record<string, string>) {
     return "encore.dev.headers." + base64encoded;
 }
 
+type WebSocketEventType = "error" | "close" | "message" | "open";
+
 class WebSocketConnection {
     public ws: WebSocket;
 
+    // done is true once the stream is closed and will not be reopened.
+    public done = false;
+
     private hasUpdateHandlers: (() => void)[] = [];
+    private handlers: { type: WebSocketEventType, handler: (event: any) => void }[] = [];
+    private attempts = 0;
+
+    constructor(private url: string, private headers?: Record<string, string>, private options?: StreamOptions) {
+        this.ws = this.connect();
+
+        const signal = options?.signal;
+        if (signal) {
+            if (signal.aborted) {
+                this.close();
+            } else {
+                signal.addEventListener("abort", () => this.close(), { once: true });
+            }
+        }
+    }
 
-    constructor(url: string, headers?: Record<string, string>) {
+    private connect(): WebSocket {
         let protocols = ["encore-ws"];
-        if (headers) {
-            protocols.push(encodeWebSocketHeaders(headers))
+        if (this.headers) {
+            protocols.push(encodeWebSocketHeaders(this.headers))
+        }
+
+        const ws = new WebSocket(this.url, protocols)
+        for (const { type, handler } of this.handlers) {
+            ws.addEventListener(type, handler);
         }
 
-        this.ws = new WebSocket(url, protocols)
+        ws.addEventListener("open", () => {
+            this.attempts = 0;
+            this.resolveHasUpdateHandlers();
+        });
 
-        this.on("error", () => {
+        ws.addEventListener("error", () => {
             this.resolveHasUpdateHandlers();
         });
 
-        this.on("close", () => {
+        ws.addEventListener("close", (event: CloseEvent) => {
+            // Reopen the stream unless it was closed normally.
+            if (this.done || event.code === 1000 || !this.reconnect()) {
+                this.done = true;
+            }
             this.resolveHasUpdateHandlers();
         });
+
+        return ws;
+    }
+
+    // reconnect schedules reopening the stream, and reports whether it will be reopened.
+    private reconnect(): boolean {
+        const opts = this.options?.reconnect;
+        if (!opts || this.attempts >= (opts.maxAttempts ?? 5)) {
+            return false;
+        }
+
+        const delay = Math.min((opts.initialDelayMs ?? 500) * 2 ** this.attempts, opts.maxDelayMs ?? 10000);
+        this.attempts++;
+        setTimeout(() => {
+            if (!this.done) {
+                this.ws = this.connect();
+            }
+        }, delay);
+        return true;
     }
 
     resolveHasUpdateHandlers() {
@@ -153,16 +262,30 @@ class WebSocketConnection {
         });
     }
 
-    on(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+    // opened waits until the socket is open, throwing an error if the stream is closed.
+    async opened() {
+        while (this.ws.readyState !== WebSocket.OPEN) {
+            if (this.done) {
+                throw new Error("stream is closed");
+            }
+            await this.hasUpdate();
+        }
+    }
+
+    on(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers.push({ type, handler });
         this.ws.addEventListener(type, handler);
     }
 
-    off(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+    off(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers = this.handlers.filter((h) => h.type !== type || h.handler !== handler);
         this.ws.removeEventListener(type, handler);
     }
 
     close() {
+        this.done = true;
         this.ws.close();
+        this.resolveHasUpdateHandlers();
     }
 }
 
@@ -170,8 +293,8 @@ export class StreamInOut<Request, Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             this.buffer.push(JSON.parse(event.data));
             this.socket.resolveHasUpdateHandlers();
@@ -183,13 +306,7 @@ export class StreamInOut<Request, Response> {
     }
 
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
+        await this.socket.opened();
         return this.socket.ws.send(JSON.stringify(msg));
     }
 
@@ -203,19 +320,35 @@ export class StreamInOut<Request, Response> {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.done) return;
                 await this.socket.hasUpdate();
             }
         }
     }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
+    }
 }
 
 export class StreamIn<Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             this.buffer.push(JSON.parse(event.data));
             this.socket.resolveHasUpdateHandlers();
@@ -236,22 +369,38 @@ export class StreamIn<Response> {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.done) return;
                 await this.socket.hasUpdate();
             }
         }
     }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
+    }
 }
 
 export class StreamOut<Request, Response> {
     public socket: WebSocketConnection;
     private responseValue: Promise<Response>;
 
-    constructor(url: string, headers?: Record<string, string>) {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
         let responseResolver: (_: any) => void;
         this.responseValue = new Promise((resolve) => responseResolver = resolve);
 
-        this.socket = new WebSocketConnection(url, headers);
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             responseResolver(JSON.parse(event.data))
         });
@@ -266,13 +415,7 @@ export class StreamOut<Request, Response> {
     }
 
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
+        await this.socket.opened();
         return this.socket.ws.send(JSON.stringify(msg));
     }
 }
@@ -309,6 +452,10 @@ class BaseClient {
             this.headers["User-Agent"] = "app-Generated-TS-Client (Encore/v0.0.0-develop)";
         }
 
+        if (options.verifyAPIVersion) {
+            this.headers["X-Encore-API-Version"] = APIVersion
+        }
+
         this.requestInit = options.requestInit ?? {};
 
         // Setup what fetch function we'll be using in the base client
@@ -324,7 +471,7 @@ class BaseClient {
     }
 
     // createStreamInOut sets up a stream to a streaming API endpoint.
-    async createStreamInOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamInOut<Request, Response>> {
+    async createStreamInOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamInOut<Request, Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -341,11 +488,11 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamInOut(this.baseURL + path + queryString, headers);
+        return new StreamInOut(this.baseURL + path + queryString, headers, options);
     }
 
     // createStreamIn sets up a stream to a streaming API endpoint.
-    async createStreamIn<Response>(path: string, params?: CallParameters): Promise<StreamIn<Response>> {
+    async createStreamIn<Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamIn<Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -362,11 +509,11 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamIn(this.baseURL + path + queryString, headers);
+        return new StreamIn(this.baseURL + path + queryString, headers, options);
     }
 
     // createStreamOut sets up a stream to a streaming API endpoint.
-    async createStreamOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamOut<Request, Response>> {
+    async createStreamOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamOut<Request, Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -383,7 +530,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamOut(this.baseURL + path + queryString, headers);
+        return new StreamOut(this.baseURL + path + queryString, headers, options);
     }
 
     // callAPI is used by each generated API method to actually make the request
@@ -526,6 +673,14 @@ export function isAPIError(err: any): err is APIError {
     return err instanceof APIError;
 }
 
+/**
+ * isClientOutOfDate reports whether the API rejected the request because the client
+ * was generated for a different version of the API. Regenerate the client to fix it.
+ */
+export function isClientOutOfDate(err: any): err is APIError {
+    return isAPIError(err) && err.code === ErrCode.FailedPrecondition && err.details?.client_out_of_date === true
+}
+
 export enum ErrCode {
     /**
      * OK indicates the operation was successful.
@@ -0,0 +1,1552 @@
+// Code generated by the Encore v0.0.0-develop client generator. DO NOT EDIT.
+
+// Disable eslint, jshint, and jslint for this file.
+/* eslint-disable */
+/* jshint ignore:start */
+/*jslint-disable*/
+
+import {
+    useMutation,
+    useQuery,
+    useQueryClient,
+    type QueryKey,
+    type UseMutationOptions,
+    type UseQueryOptions,
+} from "@tanstack/react-query"
+
+/**
+ * BaseURL is the base URL for calling the Encore application's API.
+ */
+export type BaseURL = string
+
+export const Local: BaseURL = "http://localhost:4000"
+
+/**
+ * APIVersion is the version of the API the client was generated for.
+ */
+export const APIVersion = "0a5dad7de3c5eb52"
+
+/**
+ * Environment returns a BaseURL for calling the cloud environment with the given name.
+ */
+export function Environment(name: string): BaseURL {
+    return `https://${name}-app.encr.app`
+}
+
+/**
+ * PreviewEnv returns a BaseURL for calling the preview environment with the given PR number.
+ */
+export function PreviewEnv(pr: number | string): BaseURL {
+    return Environment(`pr${pr}`)
+}
+
+/**
+ * Client is an API client for the app Encore application.
+ */
+export default class Client {
+    public readonly authentication: authentication.ServiceClient
+    public readonly products: products.ServiceClient
+    public readonly svc: svc.ServiceClient
+
+
+    /**
+     * Creates a Client for calling the public and authenticated APIs of your Encore application.
+     *
+     * @param target  The target which the client should be configured to use. See Local and Environment for options.
+     * @param options Options for the client
+     */
+    constructor(target: BaseURL, options?: ClientOptions) {
+        const base = new BaseClient(target, options ?? {})
+        this.authentication = new authentication.ServiceClient(base)
+        this.products = new products.ServiceClient(base)
+        this.svc = new svc.ServiceClient(base)
+    }
+}
+
+/**
+ * ClientOptions allows you to override any default behaviour within the generated Encore client.
+ */
+export interface ClientOptions {
+    /**
+     * By default the client will use the inbuilt fetch function for making the API requests.
+     * however you can override it with your own implementation here if you want to run custom
+     * code on each API request made or response received.
+     */
+    fetcher?: Fetcher
+
+    /** Default RequestInit to be used for the client */
+    requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+
+    /**
+     * Whether to send APIVersion with each request, so that the API rejects the requests
+     * if the client is out of date. Use isClientOutOfDate to detect such errors.
+     */
+    verifyAPIVersion?: boolean
+
+    /**
+     * Allows you to set the authentication data to be used for each
+     * request either by passing in a static object or by passing in
+     * a function which returns a new object for each request.
+     */
+    auth?: authentication.AuthData | AuthDataGenerator
+}
+
+export namespace authentication {
+    export interface AuthData {
+        APIKey: string
+    }
+
+    /**
+     * BarType docs
+     */
+    export interface BarType {
+        /**
+         * Baz docs
+         */
+        Baz: string
+    }
+
+    /**
+     * FooType docs
+     */
+    export interface FooType {
+        /**
+         * Moo docs
+         */
+        Moo: string
+
+        /**
+         * Bar docs
+         */
+        Bar: BarType
+    }
+
+    export interface User {
+        id: number
+        name: string
+    }
+
+    export class ServiceClient {
+        private baseClient: BaseClient
+
+        constructor(baseClient: BaseClient) {
+            this.baseClient = baseClient
+        }
+
+        public async Docs(params: FooType): Promise<void> {
+            await this.baseClient.callAPI("POST", `/authentication.Docs`, JSON.stringify(params))
+        }
+    }
+}
+
+export namespace products {
+    export interface CreateProductRequest {
+        IdempotencyKey: string
+        name: string
+        description: string
+    }
+
+    export interface Product {
+        id: string
+        name: string
+        description: string
+        "created_at": string
+        "created_by": authentication.User
+    }
+
+    export interface ProductListing {
+        products: Product[]
+        previous: {
+            cursor: string
+            exists: boolean
+        }
+        next: {
+            cursor: string
+            exists: boolean
+        }
+    }
+
+    export interface ReviewRequest {
+        Notify: boolean
+        rating: number
+        comment: string
+        tags?: string[]
+    }
+
+    export interface SearchParams {
+        Query: string
+        Cursor: string
+        Limit: number
+    }
+
+    export interface SearchResult {
+        products: Product[]
+        "next_cursor": string
+    }
+
+    export class ServiceClient {
+        private baseClient: BaseClient
+
+        constructor(baseClient: BaseClient) {
+            this.baseClient = baseClient
+        }
+
+        public async Create(params: CreateProductRequest): Promise<Product> {
+            // Convert our params into the objects we need for the request
+            const headers = makeRecord<string, string>({
+                "idempotency-key": params.IdempotencyKey,
+            })
+
+            // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+            const body: Record<string, any> = {
+                description: params.description,
+                name:        params.name,
+            }
+
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("POST", `/products.Create`, JSON.stringify(body), {headers})
+            return await resp.json() as Product
+        }
+
+        public async List(): Promise<ProductListing> {
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("GET", `/products.List`)
+            return await resp.json() as ProductListing
+        }
+
+        /**
+         * Review submits a review of a product, and can be called from HTML forms.
+         */
+        public async Review(id: string, params: ReviewRequest): Promise<void> {
+            // Convert our params into the objects we need for the request
+            const headers = makeRecord<string, string>({
+                "Content-Type": "application/x-www-form-urlencoded",
+            })
+
+            const query = makeRecord<string, string | string[]>({
+                notify: String(params.Notify),
+            })
+
+            // Construct the form-encoded body with only the fields which we want encoded within the body
+            const form = makeRecord<string, string | string[]>({
+                comment: params.comment,
+                rating:  String(params.rating),
+                tags:    params.tags?.map((v) => v),
+            })
+
+            await this.baseClient.callAPI("POST", `/products/${encodeURIComponent(id)}/reviews`, encodeQuery(form), {headers, query})
+        }
+
+        /**
+         * Search searches the products, a page at a time.
+         */
+        public async Search(params: SearchParams): Promise<SearchResult> {
+            // Convert our params into the objects we need for the request
+            const query = makeRecord<string, string | string[]>({
+                cursor: params.Cursor,
+                limit:  String(params.Limit),
+                q:      params.Query,
+            })
+
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("GET", `/products.Search`, undefined, {query})
+            return await resp.json() as SearchResult
+        }
+
+        /**
+         * SearchAll iterates over the products of all pages of Search, starting at params.Cursor.
+         */
+        public async *SearchAll(params: SearchParams): AsyncGenerator<Product, void, undefined> {
+            let cursor = params.Cursor
+            while (true) {
+                const page = await this.Search({ ...params, Cursor: cursor })
+                yield* page.products
+                if (!page["next_cursor"]) {
+                    return
+                }
+                cursor = page["next_cursor"]
+            }
+        }
+    }
+}
+
+export namespace svc {
+    export interface AllInputTypes<A> {
+        /**
+         * Specify this comes from a header field
+         */
+        A: string
+
+        /**
+         * Specify this comes from a query string
+         */
+        B: number[]
+
+        /**
+         * This can come from anywhere, but if it comes from the payload in JSON it must be called Charile
+         */
+        "Charlies-Bool": boolean
+
+        /**
+         * This generic type complicates the whole thing 🙈
+         */
+        Dave: A
+    }
+
+    export type Foo = number
+
+    export interface GetRequest {
+        Baz: number
+    }
+
+    /**
+     * HeaderOnlyStruct contains all types we support in headers
+     */
+    export interface HeaderOnlyStruct {
+        Boolean: boolean
+        Int: number
+        Float: number
+        String: string
+        Bytes: string
+        Time: string
+        Json: JSONValue
+        UUID: string
+        UserID: string
+    }
+
+    export interface Recursive {
+        Optional?: Recursive
+        Slice: Recursive[]
+        Map: { [key: string]: Recursive }
+    }
+
+    export interface Request {
+        /**
+         * Foo is good
+         */
+        Foo?: Foo
+
+        /**
+         * Baz is better
+         */
+        boo: string
+
+        QueryFoo?: boolean
+        QueryBar?: string
+        HeaderBaz?: string
+        HeaderInt?: number
+        /**
+         * This is a multiline
+         * comment on the raw message!
+         */
+        Raw: JSONValue
+    }
+
+    /**
+     * Tuple is a generic type which allows us to
+     * return two values of two different types
+     */
+    export interface Tuple<A, B> {
+        A: A
+        B: B
+    }
+
+    export interface WithNested {
+        Nested: nested.Type
+    }
+
+    export type WrappedRequest = Wrapper<Request>
+
+    export interface Wrapper<T> {
+        Value: T
+    }
+
+    export class ServiceClient {
+        private baseClient: BaseClient
+
+        constructor(baseClient: BaseClient) {
+            this.baseClient = baseClient
+        }
+
+        /**
+         * DummyAPI is a dummy endpoint.
+         */
+        public async DummyAPI(params: Request): Promise<void> {
+            // Convert our params into the objects we need for the request
+            const headers = makeRecord<string, string>({
+                baz: params.HeaderBaz,
+                int: params.HeaderInt === undefined ? undefined : String(params.HeaderInt),
+            })
+
+            const query = makeRecord<string, string | string[]>({
+                bar: params.QueryBar,
+                foo: params.QueryFoo === undefined ? undefined : String(params.QueryFoo),
+            })
+
+            // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+            const body: Record<string, any> = {
+                Foo: params.Foo,
+                Raw: params.Raw,
+                boo: params.boo,
+            }
+
+            await this.baseClient.callAPI("POST", `/svc.DummyAPI`, JSON.stringify(body), {headers, query})
+        }
+
+        public async FallbackPath(a: string, b: string[]): Promise<void> {
+            await this.baseClient.callAPI("POST", `/fallbackPath/${encodeURIComponent(a)}/${b.map(encodeURIComponent).join("/")}`)
+        }
+
+        public async Get(params: GetRequest): Promise<void> {
+            // Convert our params into the objects we need for the request
+            const query = makeRecord<string, string | string[]>({
+                boo: String(params.Baz),
+            })
+
+            await this.baseClient.callAPI("GET", `/svc.Get`, undefined, {query})
+        }
+
+        public async GetRequestWithAllInputTypes(params: AllInputTypes<number>): Promise<HeaderOnlyStruct> {
+            // Convert our params into the objects we need for the request
+            const headers = makeRecord<string, string>({
+                "x-alice": String(params.A),
+            })
+
+            const query = makeRecord<string, string | string[]>({
+                Bob:  params.B.map((v) => String(v)),
+                c:    String(params["Charlies-Bool"]),
+                dave: String(params.Dave),
+            })
+
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("GET", `/svc.GetRequestWithAllInputTypes`, undefined, {headers, query})
+
+            //Populate the return object from the JSON body and received headers
+            const rtn = await resp.json() as HeaderOnlyStruct
+            rtn.Boolean = mustBeSet("Header `x-boolean`", resp.headers.get("x-boolean")).toLowerCase() === "true"
+            rtn.Int = parseInt(mustBeSet("Header `x-int`", resp.headers.get("x-int")), 10)
+            rtn.Float = Number(mustBeSet("Header `x-float`", resp.headers.get("x-float")))
+            rtn.String = mustBeSet("Header `x-string`", resp.headers.get("x-string"))
+            rtn.Bytes = mustBeSet("Header `x-bytes`", resp.headers.get("x-bytes"))
+            rtn.Time = mustBeSet("Header `x-time`", resp.headers.get("x-time"))
+            rtn.Json = JSON.parse(mustBeSet("Header `x-json`", resp.headers.get("x-json")))
+            rtn.UUID = mustBeSet("Header `x-uuid`", resp.headers.get("x-uuid"))
+            rtn.UserID = mustBeSet("Header `x-user-id`", resp.headers.get("x-user-id"))
+            return rtn
+        }
+
+        public async HeaderOnlyRequest(params: HeaderOnlyStruct): Promise<void> {
+            // Convert our params into the objects we need for the request
+            const headers = makeRecord<string, string>({
+                "x-boolean": String(params.Boolean),
+                "x-bytes":   String(params.Bytes),
+                "x-float":   String(params.Float),
+                "x-int":     String(params.Int),
+                "x-json":    JSON.stringify(params.Json),
+                "x-string":  params.String,
+                "x-time":    String(params.Time),
+                "x-user-id": String(params.UserID),
+                "x-uuid":    String(params.UUID),
+            })
+
+            await this.baseClient.callAPI("GET", `/svc.HeaderOnlyRequest`, undefined, {headers})
+        }
+
+        public async Nested(params: WithNested): Promise<WithNested> {
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("POST", `/svc.Nested`, JSON.stringify(params))
+            return await resp.json() as WithNested
+        }
+
+        public async RESTPath(a: string, b: number): Promise<void> {
+            await this.baseClient.callAPI("POST", `/path/${encodeURIComponent(a)}/${encodeURIComponent(b)}`)
+        }
+
+        public async Rec(params: Recursive): Promise<Recursive> {
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("POST", `/svc.Rec`, JSON.stringify(params))
+            return await resp.json() as Recursive
+        }
+
+        public async RequestWithAllInputTypes(params: AllInputTypes<string>): Promise<AllInputTypes<number>> {
+            // Convert our params into the objects we need for the request
+            const headers = makeRecord<string, string>({
+                "x-alice": String(params.A),
+            })
+
+            const query = makeRecord<string, string | string[]>({
+                Bob: params.B.map((v) => String(v)),
+            })
+
+            // Construct the body with only the fields which we want encoded within the body (excluding query string or header fields)
+            const body: Record<string, any> = {
+                "Charlies-Bool": params["Charlies-Bool"],
+                Dave:            params.Dave,
+            }
+
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("POST", `/svc.RequestWithAllInputTypes`, JSON.stringify(body), {headers, query})
+
+            //Populate the return object from the JSON body and received headers
+            const rtn = await resp.json() as AllInputTypes<number>
+            rtn.A = mustBeSet("Header `x-alice`", resp.headers.get("x-alice"))
+            return rtn
+        }
+
+        /**
+         * TupleInputOutput tests the usage of generics in the client generator
+         * and this comment is also multiline, so multiline comments get tested as well.
+         */
+        public async TupleInputOutput(params: Tuple<string, WrappedRequest>): Promise<Tuple<boolean, Foo>> {
+            // Now make the actual call to the API
+            const resp = await this.baseClient.callAPI("POST", `/svc.TupleInputOutput`, JSON.stringify(params))
+            return await resp.json() as Tuple<boolean, Foo>
+        }
+
+        /**
+         * Upload streams the request body to storage.
+         */
+        public async Upload(method: "PUT", id: string, body?: BodyInit, options?: CallParameters): Promise<Response> {
+            return this.baseClient.callAPI(method, `/upload/${encodeURIComponent(id)}`, body, options)
+        }
+
+        public async Webhook(method: string, a: string, b: string[], body?: BodyInit, options?: CallParameters): Promise<Response> {
+            return this.baseClient.callAPI(method, `/webhook/${encodeURIComponent(a)}/${b.map(encodeURIComponent).join("/")}`, body, options)
+        }
+
+        public async Webhook2(a: string, b: string[]): Promise<void> {
+            await this.baseClient.callAPI("POST", `/webhook2/${encodeURIComponent(a)}/${b.map(encodeURIComponent).join("/")}`)
+        }
+    }
+}
+
+export namespace nested {
+    export interface Type {
+        Message: string
+    }
+}
+
+// JSONValue represents an arbitrary JSON value.
+export type JSONValue = string | number | boolean | null | JSONValue[] | {[key: string]: JSONValue}
+
+
+function encodeQuery(parts: Record<string, string | string[]>): string {
+    const pairs: string[] = []
+    for (const key in parts) {
+        const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]]) as string[]
+        for (const v of val) {
+            pairs.push(`${encodeURIComponent(key)}=${encodeURIComponent(v)}`)
+        }
+    }
+    return pairs.join("&")
+}
+
+// makeRecord takes a record and strips any undefined values from it,
+// and returns the same record with a narrower type.
+// @ts-ignore - TS ignore because makeRecord is not always used
+function makeRecord<K extends string | number | symbol, V>(record: Record<K, V | undefined>): Record<K, V> {
+    for (const key in record) {
+        if (record[key] === undefined) {
+            delete record[key]
+        }
+    }
+    return record as Record<K, V>
+}
+
+
+// mustBeSet will throw an APIError with the Data Loss code if value is null or undefined
+function mustBeSet<A>(field: string, value: A | null | undefined): A {
+    if (value === null || value === undefined) {
+        throw new APIError(
+            500,
+            {
+                code: ErrCode.DataLoss,
+                message: `${field} was unexpectedly ${value}`, // ${value} will create the string "null" or "undefined"
+            },
+        )
+    }
+    return value
+}
+
+/**
+ * StreamOptions configures a stream to a streaming API endpoint.
+ */
+export interface StreamOptions {
+    /**
+     * signal closes the stream when aborted.
+     */
+    signal?: AbortSignal
+
+    /**
+     * reconnect configures reopening the stream when the connection is lost
+     * before the stream is closed. If unset the stream is not reopened.
+     */
+    reconnect?: ReconnectOptions
+}
+
+/**
+ * ReconnectOptions configures how lost stream connections are reopened.
+ */
+export interface ReconnectOptions {
+    /**
+     * maxAttempts is the maximum number of attempts in a row to reopen the stream.
+     * Defaults to 5.
+     */
+    maxAttempts?: number
+
+    /**
+     * initialDelayMs is the delay before the first attempt, which is doubled
+     * for each following attempt. Defaults to 500 milliseconds.
+     */
+    initialDelayMs?: number
+
+    /**
+     * maxDelayMs is the maximum delay between attempts. Defaults to 10 seconds.
+     */
+    maxDelayMs?: number
+}
+
+/**
+ * StreamCallbacks are called as events happen on a stream.
+ */
+export interface StreamCallbacks<Response> {
+    onMessage?: (msg: Response) => void
+    onError?: (event: any) => void
+    onClose?: () => void
+}
+
+function encodeWebSocketHeaders(headers: Record<string, string>) {
+    // url safe, no pad
+    const base64encoded = btoa(JSON.stringify(headers))
+      .replaceAll("=", "")
+      .replaceAll("+", "-")
+      .replaceAll("/", "_");
+    return "encore.dev.headers." + base64encoded;
+}
+
+type WebSocketEventType = "error" | "close" | "message" | "open";
+
+class WebSocketConnection {
+    public ws: WebSocket;
+
+    // done is true once the stream is closed and will not be reopened.
+    public done = false;
+
+    private hasUpdateHandlers: (() => void)[] = [];
+    private handlers: { type: WebSocketEventType, handler: (event: any) => void }[] = [];
+    private attempts = 0;
+
+    constructor(private url: string, private headers?: Record<string, string>, private options?: StreamOptions) {
+        this.ws = this.connect();
+
+        const signal = options?.signal;
+        if (signal) {
+            if (signal.aborted) {
+                this.close();
+            } else {
+                signal.addEventListener("abort", () => this.close(), { once: true });
+            }
+        }
+    }
+
+    private connect(): WebSocket {
+        let protocols = ["encore-ws"];
+        if (this.headers) {
+            protocols.push(encodeWebSocketHeaders(this.headers))
+        }
+
+        const ws = new WebSocket(this.url, protocols)
+        for (const { type, handler } of this.handlers) {
+            ws.addEventListener(type, handler);
+        }
+
+        ws.addEventListener("open", () => {
+            this.attempts = 0;
+            this.resolveHasUpdateHandlers();
+        });
+
+        ws.addEventListener("error", () => {
+            this.resolveHasUpdateHandlers();
+        });
+
+        ws.addEventListener("close", (event: CloseEvent) => {
+            // Reopen the stream unless it was closed normally.
+            if (this.done || event.code === 1000 || !this.reconnect()) {
+                this.done = true;
+            }
+            this.resolveHasUpdateHandlers();
+        });
+
+        return ws;
+    }
+
+    // reconnect schedules reopening the stream, and reports whether it will be reopened.
+    private reconnect(): boolean {
+        const opts = this.options?.reconnect;
+        if (!opts || this.attempts >= (opts.maxAttempts ?? 5)) {
+            return false;
+        }
+
+        const delay = Math.min((opts.initialDelayMs ?? 500) * 2 ** this.attempts, opts.maxDelayMs ?? 10000);
+        this.attempts++;
+        setTimeout(() => {
+            if (!this.done) {
+                this.ws = this.connect();
+            }
+        }, delay);
+        return true;
+    }
+
+    resolveHasUpdateHandlers() {
+        const handlers = this.hasUpdateHandlers;
+        this.hasUpdateHandlers = [];
+
+        for (const handler of handlers) {
+            handler()
+        }
+    }
+
+    async hasUpdate() {
+        // await until a new message have been received, or the socket is closed
+        await new Promise((resolve) => {
+            this.hasUpdateHandlers.push(() => resolve(null))
+        });
+    }
+
+    // opened waits until the socket is open, throwing an error if the stream is closed.
+    async opened() {
+        while (this.ws.readyState !== WebSocket.OPEN) {
+            if (this.done) {
+                throw new Error("stream is closed");
+            }
+            await this.hasUpdate();
+        }
+    }
+
+    on(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers.push({ type, handler });
+        this.ws.addEventListener(type, handler);
+    }
+
+    off(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers = this.handlers.filter((h) => h.type !== type || h.handler !== handler);
+        this.ws.removeEventListener(type, handler);
+    }
+
+    close() {
+        this.done = true;
+        this.ws.close();
+        this.resolveHasUpdateHandlers();
+    }
+}
+
+export class StreamInOut<Request, Response> {
+    public socket: WebSocketConnection;
+    private buffer: Response[] = [];
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.on("message", (event: any) => {
+            this.buffer.push(JSON.parse(event.data));
+            this.socket.resolveHasUpdateHandlers();
+        });
+    }
+
+    close() {
+        this.socket.close();
+    }
+
+    async send(msg: Request) {
+        await this.socket.opened();
+        return this.socket.ws.send(JSON.stringify(msg));
+    }
+
+    async next(): Promise<Response | undefined> {
+        for await (const next of this) return next;
+        return undefined;
+    }
+
+    async *[Symbol.asyncIterator](): AsyncGenerator<Response, undefined, void> {
+        while (true) {
+            if (this.buffer.length > 0) {
+                yield this.buffer.shift() as Response;
+            } else {
+                if (this.socket.done) return;
+                await this.socket.hasUpdate();
+            }
+        }
+    }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
+    }
+}
+
+export class StreamIn<Response> {
+    public socket: WebSocketConnection;
+    private buffer: Response[] = [];
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.on("message", (event: any) => {
+            this.buffer.push(JSON.parse(event.data));
+            this.socket.resolveHasUpdateHandlers();
+        });
+    }
+
+    close() {
+        this.socket.close();
+    }
+
+    async next(): Promise<Response | undefined> {
+        for await (const next of this) return next;
+        return undefined;
+    }
+
+    async *[Symbol.asyncIterator](): AsyncGenerator<Response, undefined, void> {
+        while (true) {
+            if (this.buffer.length > 0) {
+                yield this.buffer.shift() as Response;
+            } else {
+                if (this.socket.done) return;
+                await this.socket.hasUpdate();
+            }
+        }
+    }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
+    }
+}
+
+export class StreamOut<Request, Response> {
+    public socket: WebSocketConnection;
+    private responseValue: Promise<Response>;
+
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        let responseResolver: (_: any) => void;
+        this.responseValue = new Promise((resolve) => responseResolver = resolve);
+
+        this.socket = new WebSocketConnection(url, headers, options);
+        this.socket.on("message", (event: any) => {
+            responseResolver(JSON.parse(event.data))
+        });
+    }
+
+    async response(): Promise<Response> {
+        return this.responseValue;
+    }
+
+    close() {
+        this.socket.close();
+    }
+
+    async send(msg: Request) {
+        await this.socket.opened();
+        return this.socket.ws.send(JSON.stringify(msg));
+    }
+}
+// CallParameters is the type of the parameters to a method call, but require headers to be a Record type
+type CallParameters = Omit<RequestInit, "method" | "body" | "headers"> & {
+    /** Headers to be sent with the request */
+    headers?: Record<string, string>
+
+    /** Query parameters to be sent with the request */
+    query?: Record<string, string | string[]>
+}
+
+// AuthDataGenerator is a function that returns a new instance of the authentication data required by this API
+export type AuthDataGenerator = () =>
+  | authentication.AuthData
+  | Promise<authentication.AuthData | undefined>
+  | undefined;
+
+// A fetcher is the prototype for the inbuilt Fetch function
+export type Fetcher = typeof fetch;
+
+const boundFetch = fetch.bind(this);
+
+class BaseClient {
+    readonly baseURL: string
+    readonly fetcher: Fetcher
+    readonly headers: Record<string, string>
+    readonly requestInit: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+    readonly authGenerator?: AuthDataGenerator
+
+    constructor(baseURL: string, options: ClientOptions) {
+        this.baseURL = baseURL
+        this.headers = {
+            "Content-Type": "application/json",
+        }
+
+        // Add User-Agent header if the script is running in the server
+        // because browsers do not allow setting User-Agent headers to requests
+        if (typeof window === "undefined") {
+            this.headers["User-Agent"] = "app-Generated-TS-Client (Encore/v0.0.0-develop)";
+        }
+
+        if (options.verifyAPIVersion) {
+            this.headers["X-Encore-API-Version"] = APIVersion
+        }
+
+        this.requestInit = options.requestInit ?? {};
+
+        // Setup what fetch function we'll be using in the base client
+        if (options.fetcher !== undefined) {
+            this.fetcher = options.fetcher
+        } else {
+            this.fetcher = boundFetch
+        }
+
+        // Setup an authentication data generator using the auth data token option
+        if (options.auth !== undefined) {
+            const auth = options.auth
+            if (typeof auth === "function") {
+                this.authGenerator = auth
+            } else {
+                this.authGenerator = () => auth
+            }
+        }
+    }
+
+    async getAuthData(): Promise<CallParameters | undefined> {
+        let authData: authentication.AuthData | undefined;
+
+        // If authorization data generator is present, call it and add the returned data to the request
+        if (this.authGenerator) {
+            const mayBePromise = this.authGenerator();
+            if (mayBePromise instanceof Promise) {
+                authData = await mayBePromise;
+            } else {
+                authData = mayBePromise;
+            }
+        }
+
+        if (authData) {
+            const data: CallParameters = {};
+
+            data.headers = makeRecord<string, string>({
+                "x-api-key": authData.APIKey,
+            });
+
+            return data;
+        }
+
+        return undefined;
+    }
+
+    // createStreamInOut sets up a stream to a streaming API endpoint.
+    async createStreamInOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamInOut<Request, Response>> {
+        let { query, headers } = params ?? {};
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                headers = {...headers, ...authData.headers};
+            }
+        }
+
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        return new StreamInOut(this.baseURL + path + queryString, headers, options);
+    }
+
+    // createStreamIn sets up a stream to a streaming API endpoint.
+    async createStreamIn<Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamIn<Response>> {
+        let { query, headers } = params ?? {};
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                headers = {...headers, ...authData.headers};
+            }
+        }
+
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        return new StreamIn(this.baseURL + path + queryString, headers, options);
+    }
+
+    // createStreamOut sets up a stream to a streaming API endpoint.
+    async createStreamOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamOut<Request, Response>> {
+        let { query, headers } = params ?? {};
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                headers = {...headers, ...authData.headers};
+            }
+        }
+
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        return new StreamOut(this.baseURL + path + queryString, headers, options);
+    }
+
+    // callAPI is used by each generated API method to actually make the request
+    public async callAPI(method: string, path: string, body?: BodyInit, params?: CallParameters): Promise<Response> {
+        let { query, headers, ...rest } = params ?? {}
+        const init = {
+            ...this.requestInit,
+            ...rest,
+            method,
+            body: body ?? null,
+        }
+
+        // Merge our headers with any predefined headers
+        init.headers = {...this.headers, ...init.headers, ...headers}
+
+
+        // Fetch auth data if there is any
+        const authData = await this.getAuthData();
+
+        // If we now have authentication data, add it to the request
+        if (authData) {
+            if (authData.query) {
+                query = {...query, ...authData.query};
+            }
+            if (authData.headers) {
+                init.headers = {...init.headers, ...authData.headers};
+            }
+        }
+
+        // Make the actual request
+        const queryString = query ? '?' + encodeQuery(query) : ''
+        const response = await this.fetcher(this.baseURL+path+queryString, init)
+
+        // handle any error responses
+        if (!response.ok) {
+            // try and get the error message from the response body
+            let body: APIErrorResponse = { code: ErrCode.Unknown, message: `request failed: status ${response.status}` }
+
+            // if we can get the structured error we should, otherwise give a best effort
+            try {
+                const text = await response.text()
+
+                try {
+                    const jsonBody = JSON.parse(text)
+                    if (isAPIErrorResponse(jsonBody)) {
+                        body = jsonBody
+                    } else {
+                        body.message += ": " + JSON.stringify(jsonBody)
+                    }
+                } catch {
+                    body.message += ": " + text
+                }
+            } catch (e) {
+                // otherwise we just append the text to the error message
+                body.message += ": " + String(e)
+            }
+
+            throw new APIError(response.status, body)
+        }
+
+        return response
+    }
+}
+
+/**
+ * APIErrorDetails represents the response from an Encore API in the case of an error
+ */
+interface APIErrorResponse {
+    code: ErrCode
+    message: string
+    details?: any
+}
+
+function isAPIErrorResponse(err: any): err is APIErrorResponse {
+    return (
+        err !== undefined && err !== null &&
+        isErrCode(err.code) &&
+        typeof(err.message) === "string" &&
+        (err.details === undefined || err.details === null || typeof(err.details) === "object")
+    )
+}
+
+function isErrCode(code: any): code is ErrCode {
+    return code !== undefined && Object.values(ErrCode).includes(code)
+}
+
+/**
+ * APIError represents a structured error as returned from an Encore application.
+ */
+export class APIError extends Error {
+    /**
+     * The HTTP status code associated with the error.
+     */
+    public readonly status: number
+
+    /**
+     * The Encore error code
+     */
+    public readonly code: ErrCode
+
+    /**
+     * The error details
+     */
+    public readonly details?: any
+
+    constructor(status: number, response: APIErrorResponse) {
+        // extending errors causes issues after you construct them, unless you apply the following fixes
+        super(response.message);
+
+        // set error name as constructor name, make it not enumerable to keep native Error behavior
+        // https://developer.mozilla.org/en-US/docs/Web/JavaScript/Reference/Operators/new.target#new.target_in_constructors
+        Object.defineProperty(this, 'name', {
+            value:        'APIError',
+            enumerable:   false,
+            configurable: true,
+        })
+
+        // fix the prototype chain
+        if ((Object as any).setPrototypeOf == undefined) {
+            (this as any).__proto__ = APIError.prototype
+        } else {
+            Object.setPrototypeOf(this, APIError.prototype);
+        }
+
+        // capture a stack trace
+        if ((Error as any).captureStackTrace !== undefined) {
+            (Error as any).captureStackTrace(this, this.constructor);
+        }
+
+        this.status = status
+        this.code = response.code
+        this.details = response.details
+    }
+}
+
+/**
+ * Typeguard allowing use of an APIError's fields'
+ */
+export function isAPIError(err: any): err is APIError {
+    return err instanceof APIError;
+}
+
+/**
+ * isClientOutOfDate reports whether the API rejected the request because the client
+ * was generated for a different version of the API. Regenerate the client to fix it.
+ */
+export function isClientOutOfDate(err: any): err is APIError {
+    return isAPIError(err) && err.code === ErrCode.FailedPrecondition && err.details?.client_out_of_date === true
+}
+
+export enum ErrCode {
+    /**
+     * OK indicates the operation was successful.
+     */
+    OK = "ok",
+
+    /**
+     * Canceled indicates the operation was canceled (typically by the caller).
+     *
+     * Encore will generate this error code when cancellation is requested.
+     */
+    Canceled = "canceled",
+
+    /**
+     * Unknown error. An example of where this error may be returned is
+     * if a Status value received from another address space belongs to
+     * an error-space that is not known in this address space. Also
+     * errors raised by APIs that do not return enough error information
+     * may be converted to this error.
+     *
+     * Encore will generate this error code in the above two mentioned cases.
+     */
+    Unknown = "unknown",
+
+    /**
+     * InvalidArgument indicates client specified an invalid argument.
+     * Note that this differs from FailedPrecondition. It indicates arguments
+     * that are problematic regardless of the state of the system
+     * (e.g., a malformed file name).
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    InvalidArgument = "invalid_argument",
+
+    /**
+     * DeadlineExceeded means operation expired before completion.
+     * For operations that change the state of the system, this error may be
+     * returned even if the operation has completed successfully. For
+     * example, a successful response from a server could have been delayed
+     * long enough for the deadline to expire.
+     *
+     * The gRPC framework will generate this error code when the deadline is
+     * exceeded.
+     */
+    DeadlineExceeded = "deadline_exceeded",
+
+    /**
+     * NotFound means some requested entity (e.g., file or directory) was
+     * not found.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    NotFound = "not_found",
+
+    /**
+     * AlreadyExists means an attempt to create an entity failed because one
+     * already exists.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    AlreadyExists = "already_exists",
+
+    /**
+     * PermissionDenied indicates the caller does not have permission to
+     * execute the specified operation. It must not be used for rejections
+     * caused by exhausting some resource (use ResourceExhausted
+     * instead for those errors). It must not be
+     * used if the caller cannot be identified (use Unauthenticated
+     * instead for those errors).
+     *
+     * This error code will not be generated by the gRPC core framework,
+     * but expect authentication middleware to use it.
+     */
+    PermissionDenied = "permission_denied",
+
+    /**
+     * ResourceExhausted indicates some resource has been exhausted, perhaps
+     * a per-user quota, or perhaps the entire file system is out of space.
+     *
+     * This error code will be generated by the gRPC framework in
+     * out-of-memory and server overload situations, or when a message is
+     * larger than the configured maximum size.
+     */
+    ResourceExhausted = "resource_exhausted",
+
+    /**
+     * FailedPrecondition indicates operation was rejected because the
+     * system is not in a state required for the operation's execution.
+     * For example, directory to be deleted may be non-empty, an rmdir
+     * operation is applied to a non-directory, etc.
+     *
+     * A litmus test that may help a service implementor in deciding
+     * between FailedPrecondition, Aborted, and Unavailable:
+     *  (a) Use Unavailable if the client can retry just the failing call.
+     *  (b) Use Aborted if the client should retry at a higher-level
+     *      (e.g., restarting a read-modify-write sequence).
+     *  (c) Use FailedPrecondition if the client should not retry until
+     *      the system state has been explicitly fixed. E.g., if an "rmdir"
+     *      fails because the directory is non-empty, FailedPrecondition
+     *      should be returned since the client should not retry unless
+     *      they have first fixed up the directory by deleting files from it.
+     *  (d) Use FailedPrecondition if the client performs conditional
+     *      REST Get/Update/Delete on a resource and the resource on the
+     *      server does not match the condition. E.g., conflicting
+     *      read-modify-write on the same resource.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    FailedPrecondition = "failed_precondition",
+
+    /**
+     * Aborted indicates the operation was aborted, typically due to a
+     * concurrency issue like sequencer check failures, transaction aborts,
+     * etc.
+     *
+     * See litmus test above for deciding between FailedPrecondition,
+     * Aborted, and Unavailable.
+     */
+    Aborted = "aborted",
+
+    /**
+     * OutOfRange means operation was attempted past the valid range.
+     * E.g., seeking or reading past end of file.
+     *
+     * Unlike InvalidArgument, this error indicates a problem that may
+     * be fixed if the system state changes. For example, a 32-bit file
+     * system will generate InvalidArgument if asked to read at an
+     * offset that is not in the range [0,2^32-1], but it will generate
+     * OutOfRange if asked to read from an offset past the current
+     * file size.
+     *
+     * There is a fair bit of overlap between FailedPrecondition and
+     * OutOfRange. We recommend using OutOfRange (the more specific
+     * error) when it applies so that callers who are iterating through
+     * a space can easily look for an OutOfRange error to detect when
+     * they are done.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    OutOfRange = "out_of_range",
+
+    /**
+     * Unimplemented indicates operation is not implemented or not
+     * supported/enabled in this service.
+     *
+     * This error code will be generated by the gRPC framework. Most
+     * commonly, you will see this error code when a method implementation
+     * is missing on the server. It can also be generated for unknown
+     * compression algorithms or a disagreement as to whether an RPC should
+     * be streaming.
+     */
+    Unimplemented = "unimplemented",
+
+    /**
+     * Internal errors. Means some invariants expected by underlying
+     * system has been broken. If you see one of these errors,
+     * something is very broken.
+     *
+     * This error code will be generated by the gRPC framework in several
+     * internal error conditions.
+     */
+    Internal = "internal",
+
+    /**
+     * Unavailable indicates the service is currently unavailable.
+     * This is a most likely a transient condition and may be corrected
+     * by retrying with a backoff. Note that it is not always safe to retry
+     * non-idempotent operations.
+     *
+     * See litmus test above for deciding between FailedPrecondition,
+     * Aborted, and Unavailable.
+     *
+     * This error code will be generated by the gRPC framework during
+     * abrupt shutdown of a server process or network connection.
+     */
+    Unavailable = "unavailable",
+
+    /**
+     * DataLoss indicates unrecoverable data loss or corruption.
+     *
+     * This error code will not be generated by the gRPC framework.
+     */
+    DataLoss = "data_loss",
+
+    /**
+     * Unauthenticated indicates the request does not have valid
+     * authentication credentials for the operation.
+     *
+     * The gRPC framework will generate this error code when the
+     * authentication metadata is invalid or a Credentials callback fails,
+     * but also expect authentication middleware to generate it.
+     */
+    Unauthenticated = "unauthenticated",
+}
+
+/**
+ * queryKeys are the TanStack Query keys of the API's queries.
+ * The keys are hierarchical, so that queryKeys.<service>.all matches all the queries of a service.
+ */
+export const queryKeys = {
+    authentication: {
+        all: ["authentication"] as const,
+    },
+    products: {
+        all: ["products"] as const,
+        List: () => ["products", "List"] as const,
+        Search: (params: products.SearchParams) => ["products", "Search", params] as const,
+    },
+    svc: {
+        all: ["svc"] as const,
+        Get: (params: svc.GetRequest) => ["svc", "Get", params] as const,
+        GetRequestWithAllInputTypes: (params: svc.AllInputTypes<number>) => ["svc", "GetRequestWithAllInputTypes", params] as const,
+        HeaderOnlyRequest: (params: svc.HeaderOnlyStruct) => ["svc", "HeaderOnlyRequest", params] as const,
+    },
+}
+
+/**
+ * QueryHookOptions are the options of the generated query hooks.
+ */
+export type QueryHookOptions<TData, TQueryKey extends QueryKey> =
+    Omit<UseQueryOptions<TData, Error, TData, TQueryKey>, "queryKey" | "queryFn">
+
+/**
+ * MutationHookOptions are the options of the generated mutation hooks.
+ */
+export type MutationHookOptions<TData, TVariables> =
+    Omit<UseMutationOptions<TData, Error, TVariables>, "mutationFn"> & {
+        /**
+         * Query keys to invalidate when the mutation succeeds, such as queryKeys.<service>.all.
+         */
+        invalidates?: QueryKey[]
+    }
+
+function useAPIMutation<TData, TVariables>(
+    mutationFn: (variables: TVariables) => Promise<TData>,
+    options?: MutationHookOptions<TData, TVariables>,
+) {
+    const queryClient = useQueryClient()
+    const { invalidates, onSuccess, ...rest } = options ?? {}
+    return useMutation<TData, Error, TVariables>({
+        ...rest,
+        mutationFn,
+        onSuccess: async (...args) => {
+            await Promise.all((invalidates ?? []).map((queryKey) => queryClient.invalidateQueries({ queryKey })))
+            return onSuccess?.(...args)
+        },
+    })
+}
+
+/**
+ * useAuthenticationDocs calls authentication.Docs as a mutation.
+ */
+export function useAuthenticationDocs(client: Client, options?: MutationHookOptions<void, authentication.FooType>) {
+    return useAPIMutation<void, authentication.FooType>((vars) => client.authentication.Docs(vars), options)
+}
+
+/**
+ * useProductsCreate calls products.Create as a mutation.
+ */
+export function useProductsCreate(client: Client, options?: MutationHookOptions<products.Product, products.CreateProductRequest>) {
+    return useAPIMutation<products.Product, products.CreateProductRequest>((vars) => client.products.Create(vars), options)
+}
+
+/**
+ * useProductsList queries products.List.
+ */
+export function useProductsList(client: Client, options?: QueryHookOptions<products.ProductListing, ReturnType<typeof queryKeys.products.List>>) {
+    return useQuery({
+        ...options,
+        queryKey: queryKeys.products.List(),
+        queryFn: () => client.products.List(),
+    })
+}
+
+/**
+ * useProductsReview calls products.Review as a mutation.
+ */
+export function useProductsReview(client: Client, options?: MutationHookOptions<void, { id: string, params: products.ReviewRequest }>) {
+    return useAPIMutation<void, { id: string, params: products.ReviewRequest }>((vars) => client.products.Review(vars.id, vars.params), options)
+}
+
+/**
+ * useProductsSearch queries products.Search.
+ */
+export function useProductsSearch(client: Client, params: products.SearchParams, options?: QueryHookOptions<products.SearchResult, ReturnType<typeof queryKeys.products.Search>>) {
+    return useQuery({
+        ...options,
+        queryKey: queryKeys.products.Search(params),
+        queryFn: () => client.products.Search(params),
+    })
+}
+
+/**
+ * useSvcDummyAPI calls svc.DummyAPI as a mutation.
+ */
+export function useSvcDummyAPI(client: Client, options?: MutationHookOptions<void, svc.Request>) {
+    return useAPIMutation<void, svc.Request>((vars) => client.svc.DummyAPI(vars), options)
+}
+
+/**
+ * useSvcFallbackPath calls svc.FallbackPath as a mutation.
+ */
+export function useSvcFallbackPath(client: Client, options?: MutationHookOptions<void, { a: string, b: string[] }>) {
+    return useAPIMutation<void, { a: string, b: string[] }>((vars) => client.svc.FallbackPath(vars.a, vars.b), options)
+}
+
+/**
+ * useSvcGet queries svc.Get.
+ */
+export function useSvcGet(client: Client, params: svc.GetRequest, options?: QueryHookOptions<null, ReturnType<typeof queryKeys.svc.Get>>) {
+    return useQuery({
+        ...options,
+        queryKey: queryKeys.svc.Get(params),
+        queryFn: () => client.svc.Get(params).then(() => null),
+    })
+}
+
+/**
+ * useSvcGetRequestWithAllInputTypes queries svc.GetRequestWithAllInputTypes.
+ */
+export function useSvcGetRequestWithAllInputTypes(client: Client, params: svc.AllInputTypes<number>, options?: QueryHookOptions<svc.HeaderOnlyStruct, ReturnType<typeof queryKeys.svc.GetRequestWithAllInputTypes>>) {
+    return useQuery({
+        ...options,
+        queryKey: queryKeys.svc.GetRequestWithAllInputTypes(params),
+        queryFn: () => client.svc.GetRequestWithAllInputTypes(params),
+    })
+}
+
+/**
+ * useSvcHeaderOnlyRequest queries svc.HeaderOnlyRequest.
+ */
+export function useSvcHeaderOnlyRequest(client: Client, params: svc.HeaderOnlyStruct, options?: QueryHookOptions<null, ReturnType<typeof queryKeys.svc.HeaderOnlyRequest>>) {
+    return useQuery({
+        ...options,
+        queryKey: queryKeys.svc.HeaderOnlyRequest(params),
+        queryFn: () => client.svc.HeaderOnlyRequest(params).then(() => null),
+    })
+}
+
+/**
+ * useSvcNested calls svc.Nested as a mutation.
+ */
+export function useSvcNested(client: Client, options?: MutationHookOptions<svc.WithNested, svc.WithNested>) {
+    return useAPIMutation<svc.WithNested, svc.WithNested>((vars) => client.svc.Nested(vars), options)
+}
+
+/**
+ * useSvcRESTPath calls svc.RESTPath as a mutation.
+ */
+export function useSvcRESTPath(client: Client, options?: MutationHookOptions<void, { a: string, b: number }>) {
+    return useAPIMutation<void, { a: string, b: number }>((vars) => client.svc.RESTPath(vars.a, vars.b), options)
+}
+
+/**
+ * useSvcRec calls svc.Rec as a mutation.
+ */
+export function useSvcRec(client: Client, options?: MutationHookOptions<svc.Recursive, svc.Recursive>) {
+    return useAPIMutation<svc.Recursive, svc.Recursive>((vars) => client.svc.Rec(vars), options)
+}
+
+/**
+ * useSvcRequestWithAllInputTypes calls svc.RequestWithAllInputTypes as a mutation.
+ */
+export function useSvcRequestWithAllInputTypes(client: Client, options?: MutationHookOptions<svc.AllInputTypes<number>, svc.AllInputTypes<string>>) {
+    return useAPIMutation<svc.AllInputTypes<number>, svc.AllInputTypes<string>>((vars) => client.svc.RequestWithAllInputTypes(vars), options)
+}
+
+/**
+ * useSvcTupleInputOutput calls svc.TupleInputOutput as a mutation.
+ */
+export function useSvcTupleInputOutput(client: Client, options?: MutationHookOptions<svc.Tuple<boolean, svc.Foo>, svc.Tuple<string, svc.WrappedRequest>>) {
+    return useAPIMutation<svc.Tuple<boolean, svc.Foo>, svc.Tuple<string, svc.WrappedRequest>>((vars) => client.svc.TupleInputOutput(vars), options)
+}
+
+/**
+ * useSvcWebhook2 calls svc.Webhook2 as a mutation.
+ */
+export function useSvcWebhook2(client: Client, options?: MutationHookOptions<void, { a: string, b: string[] }>) {
+    return useAPIMutation<void, { a: string, b: string[] }>((vars) => client.svc.Webhook2(vars.a, vars.b), options)
+}
@@ -125,6 +125,10 @@ func RESTPath(ctx context.Context, a string, b int) error {
 //encore:api public raw path=/webhook/:a/*b
 func Webhook(w http.ResponseWriter, req *http.Request) {}
 
+// Upload streams the request body to storage.
+//encore:api public raw method=PUT path=/upload/:id
+func Upload(w http.ResponseWriter, req *http.Request) {}
+
 
 //encore:api public path=/webhook2/:a/*b
 func Webhook2(ctx context.Context, a string, b string) error { return nil }
@@ -217,6 +221,36 @@ func Create(ctx context.Context, req *CreateProductRequest) (*Product, error) {
     return nil, nil
 }
 
+type SearchParams struct {
+    Query  string `query:"q"`
+    Cursor string `query:"cursor"`
+    Limit  int    `query:"limit"`
+}
+
+type SearchResult struct {
+    Products   []*Product `json:"products"`
+    NextCursor string     `json:"next_cursor"`
+}
+
+// Search searches the products, a page at a time.
+//encore:api public method=GET tag:paginated
+func Search(ctx context.Context, p *SearchParams) (*SearchResult, error) {
+    return nil, nil
+}
+
+type ReviewRequest struct {
+    Notify  bool     `query:"notify"`
+    Rating  int      `json:"rating"`
+    Comment string   `json:"comment,omitempty"`
+    Tags    []string `json:"tags" encore:"optional"`
+}
+
+// Review submits a review of a product, and can be called from HTML forms.
+//encore:api public method=POST path=/products/:id/reviews form
+func Review(ctx context.Context, id string, req *ReviewRequest) error {
+    return nil
+}
+
 -- authentication/auth.go --
 package authentication
 
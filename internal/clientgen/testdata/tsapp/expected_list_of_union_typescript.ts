@@ -90,7 +90,7 @@ function encodeQuery(parts: Record<string, string | string[]>): string {
     for (const key in parts) {
         const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]]) as string[]
         for (const v of val) {
-            pairs.push(`${key}=${encodeURIComponent(v)}`)
+            pairs.push(`${encodeURIComponent(key)}=${encodeURIComponent(v)}`)
         }
     }
     return pairs.join("&")
@@ -108,6 +108,53 @@ function makeRecord<K extends string | number | symbol, V>(record: Record<K, V |
     return record as Record<K, V>
 }
 
+/**
+ * StreamOptions configures a stream to a streaming API endpoint.
+ */
+export interface StreamOptions {
+    /**
+     * signal closes the stream when aborted.
+     */
+    signal?: AbortSignal
+
+    /**
+     * reconnect configures reopening the stream when the connection is lost
+     * before the stream is closed. If unset the stream is not reopened.
+     */
+    reconnect?: ReconnectOptions
+}
+
+/**
+ * ReconnectOptions configures how lost stream connections are reopened.
+ */
+export interface ReconnectOptions {
+    /**
+     * maxAttempts is the maximum number of attempts in a row to reopen the stream.
+     * Defaults to 5.
+     */
+    maxAttempts?: number
+
+    /**
+     * initialDelayMs is the delay before the first attempt, which is doubled
+     * for each following attempt. Defaults to 500 milliseconds.
+     */
+    initialDelayMs?: number
+
+    /**
+     * maxDelayMs is the maximum delay between attempts. Defaults to 10 seconds.
+     */
+    maxDelayMs?: number
+}
+
+/**
+ * StreamCallbacks are called as events happen on a stream.
+ */
+export interface StreamCallbacks<Response> {
+    onMessage?: (msg: Response) => void
+    onError?: (event: any) => void
+    onClose?: () => void
+}
+
 function encodeWebSocketHeaders(headers: Record<string, string>) {
     // url safe, no pad
     const base64encoded = btoa(JSON.stringify(headers))
@@ -117,26 +164,77 @@ function encodeWebSocketHeaders(headers: Record<string, string>) {
     return "encore.dev.headers." + base64encoded;
 }
 
+type WebSocketEventType = "error" | "close" | "message" | "open";
+
 class WebSocketConnection {
     public ws: WebSocket;
 
+    // done is true once the stream is closed and will not be reopened.
+    public done = false;
+
     private hasUpdateHandlers: (() => void)[] = [];
+    private handlers: { type: WebSocketEventType, handler: (event: any) => void }[] = [];
+    private attempts = 0;
+
+    constructor(private url: string, private headers?: Record<string, string>, private options?: StreamOptions) {
+        this.ws = this.connect();
+
+        const signal = options?.signal;
+        if (signal) {
+            if (signal.aborted) {
+                this.close();
+            } else {
+                signal.addEventListener("abort", () => this.close(), { once: true });
+            }
+        }
+    }
 
-    constructor(url: string, headers?: Record<string, string>) {
+    private connect(): WebSocket {
         let protocols = ["encore-ws"];
-        if (headers) {
-            protocols.push(encodeWebSocketHeaders(headers))
+        if (this.headers) {
+            protocols.push(encodeWebSocketHeaders(this.headers))
+        }
+
+        const ws = new WebSocket(this.url, protocols)
+        for (const { type, handler } of this.handlers) {
+            ws.addEventListener(type, handler);
         }
 
-        this.ws = new WebSocket(url, protocols)
+        ws.addEventListener("open", () => {
+            this.attempts = 0;
+            this.resolveHasUpdateHandlers();
+        });
 
-        this.on("error", () => {
+        ws.addEventListener("error", () => {
             this.resolveHasUpdateHandlers();
         });
 
-        this.on("close", () => {
+        ws.addEventListener("close", (event: CloseEvent) => {
+            // Reopen the stream unless it was closed normally.
+            if (this.done || event.code === 1000 || !this.reconnect()) {
+                this.done = true;
+            }
             this.resolveHasUpdateHandlers();
         });
+
+        return ws;
+    }
+
+    // reconnect schedules reopening the stream, and reports whether it will be reopened.
+    private reconnect(): boolean {
+        const opts = this.options?.reconnect;
+        if (!opts || this.attempts >= (opts.maxAttempts ?? 5)) {
+            return false;
+        }
+
+        const delay = Math.min((opts.initialDelayMs ?? 500) * 2 ** this.attempts, opts.maxDelayMs ?? 10000);
+        this.attempts++;
+        setTimeout(() => {
+            if (!this.done) {
+                this.ws = this.connect();
+            }
+        }, delay);
+        return true;
     }
 
     resolveHasUpdateHandlers() {
@@ -155,16 +253,30 @@ class WebSocketConnection {
         });
     }
 
-    on(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+    // opened waits until the socket is open, throwing an error if the stream is closed.
+    async opened() {
+        while (this.ws.readyState !== WebSocket.OPEN) {
+            if (this.done) {
+                throw new Error("stream is closed");
+            }
+            await this.hasUpdate();
+        }
+    }
+
+    on(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers.push({ type, handler });
         this.ws.addEventListener(type, handler);
     }
 
-    off(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+    off(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers = this.handlers.filter((h) => h.type !== type || h.handler !== handler);
         this.ws.removeEventListener(type, handler);
     }
 
     close() {
+        this.done = true;
         this.ws.close();
+        this.resolveHasUpdateHandlers();
     }
 }
 
@@ -172,8 +284,8 @@ export class StreamInOut<Request, Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             this.buffer.push(JSON.parse(event.data));
             this.socket.resolveHasUpdateHandlers();
@@ -185,13 +297,7 @@ export class StreamInOut<Request, Response> {
     }
 
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
+        await this.socket.opened();
         return this.socket.ws.send(JSON.stringify(msg));
     }
 
@@ -205,19 +311,35 @@ export class StreamInOut<Request, Response> {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.done) return;
                 await this.socket.hasUpdate();
             }
         }
     }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
+    }
 }
 
 export class StreamIn<Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             this.buffer.push(JSON.parse(event.data));
             this.socket.resolveHasUpdateHandlers();
@@ -238,22 +360,38 @@ export class StreamIn<Response> {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.done) return;
                 await this.socket.hasUpdate();
             }
         }
     }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
+    }
 }
 
 export class StreamOut<Request, Response> {
     public socket: WebSocketConnection;
     private responseValue: Promise<Response>;
 
-    constructor(url: string, headers?: Record<string, string>) {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
         let responseResolver: (_: any) => void;
         this.responseValue = new Promise((resolve) => responseResolver = resolve);
 
-        this.socket = new WebSocketConnection(url, headers);
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             responseResolver(JSON.parse(event.data))
         });
@@ -268,13 +406,7 @@ export class StreamOut<Request, Response> {
     }
 
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
+        await this.socket.opened();
         return this.socket.ws.send(JSON.stringify(msg));
     }
 }
@@ -326,7 +458,7 @@ class BaseClient {
     }
 
     // createStreamInOut sets up a stream to a streaming API endpoint.
-    async createStreamInOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamInOut<Request, Response>> {
+    async createStreamInOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamInOut<Request, Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -343,11 +475,11 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamInOut(this.baseURL + path + queryString, headers);
+        return new StreamInOut(this.baseURL + path + queryString, headers, options);
     }
 
     // createStreamIn sets up a stream to a streaming API endpoint.
-    async createStreamIn<Response>(path: string, params?: CallParameters): Promise<StreamIn<Response>> {
+    async createStreamIn<Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamIn<Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -364,11 +496,11 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamIn(this.baseURL + path + queryString, headers);
+        return new StreamIn(this.baseURL + path + queryString, headers, options);
     }
 
     // createStreamOut sets up a stream to a streaming API endpoint.
-    async createStreamOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamOut<Request, Response>> {
+    async createStreamOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamOut<Request, Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -385,7 +517,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamOut(this.baseURL + path + queryString, headers);
+        return new StreamOut(this.baseURL + path + queryString, headers, options);
     }
 
     // callAPI is used by each generated API method to actually make the request
@@ -55,9 +55,11 @@ type typescript struct {
 	*bytes.Buffer
 	md               *meta.Data
 	appSlug          string
+	apiVersion       string
 	typs             *typeRegistry
 	currDecl         *schema.Decl
 	generatorVersion tsGenVersion
+	reactQuery       bool // true if TanStack Query hooks should be generated
 
 	seenJSON           bool // true if a JSON type was seen
 	seenHeaderResponse bool // true if we've seen a header used in a response object
@@ -75,6 +77,7 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 	ts.Buffer = p.Buf
 	ts.md = p.Meta
 	ts.appSlug = p.AppSlug
+	ts.apiVersion = p.APIVersion
 	ts.typs = getNamedTypes(p.Meta, p.Services)
 
 	if ts.md.AuthHandler != nil {
@@ -88,6 +91,9 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 	ts.WriteString("/* eslint-disable */\n")
 	ts.WriteString("/* jshint ignore:start */\n")
 	ts.WriteString("/*jslint-disable*/\n")
+	if ts.reactQuery {
+		ts.writeReactQueryImports()
+	}
 
 	nss := ts.typs.Namespaces()
 	seenNs := make(map[string]bool)
@@ -109,6 +115,9 @@ func (ts *typescript) Generate(p clientgentypes.GenerateParams) (err error) {
 		return err
 	}
 	ts.writeCustomErrorType()
+	if ts.reactQuery {
+		ts.writeReactQueryHooks(p.Services, p.Tags)
+	}
 
 	return nil
 }
@@ -256,6 +265,7 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 				ts.writeTyp(ns, rpc.RequestSchema, 0)
 
 			}
+			nParams++
 		} else if rpc.Proto == meta.RPC_RAW {
 			if nParams > 0 {
 				ts.WriteString(", ")
@@ -263,6 +273,13 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 			ts.WriteString("body?: BodyInit, options?: CallParameters")
 		}
 
+		if isStream {
+			if nParams > 0 {
+				ts.WriteString(", ")
+			}
+			ts.WriteString("options?: StreamOptions")
+		}
+
 		var direction streamDirection
 
 		if rpc.StreamingRequest && rpc.StreamingResponse {
@@ -322,6 +339,10 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 
 		indent()
 		ts.WriteString("}\n")
+
+		if err := ts.writePaginatedMethod(ns, ts.newIdentWriter(numIndent), rpc); err != nil {
+			return err
+		}
 	}
 	numIndent--
 	indent()
@@ -329,6 +350,65 @@ func (ts *typescript) writeService(svc *meta.Service, p clientgentypes.ServiceSe
 	return nil
 }
 
+// writePaginatedMethod writes a method iterating over all pages of rpc,
+// if it's paginated.
+func (ts *typescript) writePaginatedMethod(ns string, w *indentWriter, rpc *meta.RPC) error {
+	pg, err := clientgentypes.DescribePagination(ts.md, rpc, &encoding.Options{SrcNameTag: "json"})
+	if err != nil {
+		return err
+	} else if pg == nil {
+		return nil
+	}
+
+	var params, args []string
+	for _, s := range rpc.Path.Segments {
+		if s.Type == meta.PathSegment_LITERAL {
+			continue
+		}
+		typ := "string"
+		switch s.ValueType {
+		case meta.PathSegment_BOOL:
+			typ = "boolean"
+		case meta.PathSegment_STRING, meta.PathSegment_UUID:
+		default:
+			typ = "number"
+		}
+		if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
+			typ += "[]"
+		}
+		params = append(params, ts.nonReservedId(s.Value)+": "+typ)
+		args = append(args, ts.nonReservedId(s.Value))
+	}
+	params = append(params, "params: "+ts.typString(ns, rpc.RequestSchema))
+	args = append(args, "{ ...params, "+ts.QuoteIfRequired(pg.Cursor.SrcName)+": cursor }")
+
+	nextCursor := ts.Dot("page", pg.NextCursor.SrcName)
+	w.WriteString("\n")
+	w.WriteString("/**\n")
+	w.WriteStringf(" * %s iterates over the %s of all pages of %s, starting at params.%s.\n",
+		ts.memberName(rpc.Name+"All"), pg.Items.SrcName, ts.memberName(rpc.Name), pg.Cursor.SrcName)
+	w.WriteString(" */\n")
+	w.WriteStringf("public async *%s(%s): AsyncGenerator<%s, void, undefined> {\n",
+		ts.memberName(rpc.Name+"All"), strings.Join(params, ", "), ts.typString(ns, pg.ItemType))
+	{
+		w := w.Indent()
+		w.WriteStringf("let cursor = %s\n", ts.Dot("params", pg.Cursor.SrcName))
+		w.WriteString("while (true) {\n")
+		{
+			w := w.Indent()
+			w.WriteStringf("const page = await this.%s(%s)\n", ts.memberName(rpc.Name), strings.Join(args, ", "))
+			w.WriteStringf("yield* %s\n", ts.Dot("page", pg.Items.SrcName))
+			w.WriteStringf("if (!%s) {\n", nextCursor)
+			w.Indent().WriteString("return\n")
+			w.WriteString("}\n")
+			w.WriteStringf("cursor = %s\n", nextCursor)
+		}
+		w.WriteString("}\n")
+	}
+	w.WriteString("}\n")
+	return nil
+}
+
 func (ts *typescript) streamCallSite(w *indentWriter, rpc *meta.RPC, rpcPath string, direction streamDirection) error {
 	headers := ""
 	query := ""
@@ -418,8 +498,10 @@ func (ts *typescript) streamCallSite(w *indentWriter, rpc *meta.RPC, rpcPath str
 		}
 
 		createStream += "}"
+	} else {
+		createStream += ", undefined"
 	}
-	createStream += ")"
+	createStream += ", options)"
 
 	w.WriteStringf("return await %s\n", createStream)
 	return nil
@@ -448,13 +530,17 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 
 	if rpc.RequestSchema != nil {
 		reqEnc := rpcEncoding.DefaultRequestEncoding
+		formParams, err := clientgentypes.FormBodyParameters(rpc, reqEnc)
+		if err != nil {
+			return err
+		}
 
-		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 {
+		if len(reqEnc.HeaderParameters) > 0 || len(reqEnc.QueryParameters) > 0 || formParams != nil {
 			w.WriteString("// Convert our params into the objects we need for the request\n")
 		}
 
 		// Generate the headers
-		if len(reqEnc.HeaderParameters) > 0 {
+		if len(reqEnc.HeaderParameters) > 0 || formParams != nil {
 			headers = "headers"
 
 			dict := make(map[string]string)
@@ -462,6 +548,9 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 				ref := ts.Dot("params", field.SrcName)
 				dict[field.WireFormat] = ts.convertBuiltinToString(field.Type.GetBuiltin(), ref, field.Optional)
 			}
+			if formParams != nil {
+				dict["Content-Type"] = strconv.Quote(clientgentypes.FormContentType)
+			}
 
 			w.WriteString("const headers = makeRecord<string, string>(")
 			ts.Values(w, dict)
@@ -474,20 +563,7 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 
 			dict := make(map[string]string)
 			for _, field := range reqEnc.QueryParameters {
-				if list := field.Type.GetList(); list != nil {
-					dot := ts.Dot("params", field.SrcName)
-					if field.Optional || ts.isRecursive(field.Type) {
-						dot += "?"
-					}
-					dict[field.WireFormat] = dot +
-						".map((v) => " + ts.convertBuiltinToString(list.Elem.GetBuiltin(), "v", field.Optional) + ")"
-				} else {
-					dict[field.WireFormat] = ts.convertBuiltinToString(
-						field.Type.GetBuiltin(),
-						ts.Dot("params", field.SrcName),
-						field.Optional,
-					)
-				}
+				dict[field.WireFormat] = ts.queryValue(field)
 			}
 
 			w.WriteString("const query = makeRecord<string, string | string[]>(")
@@ -495,8 +571,23 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 			w.WriteString(")\n\n")
 		}
 
+		// Generate the form-encoded body, which is encoded like the query string
+		if formParams != nil {
+			body = "encodeQuery(form)"
+
+			dict := make(map[string]string)
+			for _, field := range formParams {
+				dict[field.WireFormat] = ts.queryValue(field)
+			}
+
+			w.WriteString("// Construct the form-encoded body with only the fields which we want encoded within the body\n")
+			w.WriteString("const form = makeRecord<string, string | string[]>(")
+			ts.Values(w, dict)
+			w.WriteString(")\n\n")
+		}
+
 		// Generate the body
-		if len(reqEnc.BodyParameters) > 0 {
+		if len(reqEnc.BodyParameters) > 0 && formParams == nil {
 			if len(reqEnc.HeaderParameters) == 0 && len(reqEnc.QueryParameters) == 0 {
 				// In the simple case we can just encode the params as the body directly
 				body = "JSON.stringify(params)"
@@ -580,11 +671,24 @@ func (ts *typescript) rpcCallSite(ns string, w *indentWriter, rpc *meta.RPC, rpc
 	return nil
 }
 
+// queryValue returns the expression to encode a request parameter
+// in a query string or form-encoded body.
+func (ts *typescript) queryValue(field *encoding.ParameterEncoding) string {
+	if list := field.Type.GetList(); list != nil {
+		dot := ts.Dot("params", field.SrcName)
+		if field.Optional || ts.isRecursive(field.Type) {
+			dot += "?"
+		}
+		return dot + ".map((v) => " + ts.convertBuiltinToString(list.Elem.GetBuiltin(), "v", false) + ")"
+	}
+	return ts.convertBuiltinToString(field.Type.GetBuiltin(), ts.Dot("params", field.SrcName), field.Optional)
+}
+
 // nonReservedId returns the given ID, unless we have it a reserved within the client function _or_ it's a reserved Typescript keyword
 func (ts *typescript) nonReservedId(id string) string {
 	switch id {
 	// our reserved keywords (or ID's we use within the generated client functions)
-	case "params", "headers", "query", "body", "resp", "rtn":
+	case "params", "headers", "query", "form", "body", "resp", "rtn":
 		return "_" + id
 
 	// Typescript & Javascript keywords
@@ -663,13 +767,7 @@ func (ts *typescript) writeDeclDef(ns string, decl *schema.Decl) {
 func (ts *typescript) writeStreamClasses() {
 	send := `
     async send(msg: Request) {
-        if (this.socket.ws.readyState === WebSocket.CONNECTING) {
-            // await that the socket is opened
-            await new Promise((resolve) => {
-                this.socket.ws.addEventListener("open", resolve, { once: true });
-            });
-        }
-
+        await this.socket.opened();
         return this.socket.ws.send(JSON.stringify(msg));
     }`
 
@@ -684,13 +782,76 @@ func (ts *typescript) writeStreamClasses() {
             if (this.buffer.length > 0) {
                 yield this.buffer.shift() as Response;
             } else {
-                if (this.socket.ws.readyState === WebSocket.CLOSED) return;
+                if (this.socket.done) return;
                 await this.socket.hasUpdate();
             }
         }
+    }
+
+    /**
+     * subscribe calls the given callbacks as messages are received,
+     * as an alternative to iterating over the stream.
+     */
+    subscribe(callbacks: StreamCallbacks<Response>) {
+        if (callbacks.onError) {
+            this.socket.on("error", callbacks.onError);
+        }
+        (async () => {
+            for await (const msg of this) {
+                callbacks.onMessage?.(msg);
+            }
+            callbacks.onClose?.();
+        })();
     }`
 
 	ts.WriteString(`
+/**
+ * StreamOptions configures a stream to a streaming API endpoint.
+ */
+export interface StreamOptions {
+    /**
+     * signal closes the stream when aborted.
+     */
+    signal?: AbortSignal
+
+    /**
+     * reconnect configures reopening the stream when the connection is lost
+     * before the stream is closed. If unset the stream is not reopened.
+     */
+    reconnect?: ReconnectOptions
+}
+
+/**
+ * ReconnectOptions configures how lost stream connections are reopened.
+ */
+export interface ReconnectOptions {
+    /**
+     * maxAttempts is the maximum number of attempts in a row to reopen the stream.
+     * Defaults to 5.
+     */
+    maxAttempts?: number
+
+    /**
+     * initialDelayMs is the delay before the first attempt, which is doubled
+     * for each following attempt. Defaults to 500 milliseconds.
+     */
+    initialDelayMs?: number
+
+    /**
+     * maxDelayMs is the maximum delay between attempts. Defaults to 10 seconds.
+     */
+    maxDelayMs?: number
+}
+
+/**
+ * StreamCallbacks are called as events happen on a stream.
+ */
+export interface StreamCallbacks<Response> {
+    onMessage?: (msg: Response) => void
+    onError?: (event: any) => void
+    onClose?: () => void
+}
+
 function encodeWebSocketHeaders(headers: Record<string, string>) {
     // url safe, no pad
     const base64encoded = btoa(JSON.stringify(headers))
@@ -700,26 +861,77 @@ function encodeWebSocketHeaders(headers: Record<string, string>) {
     return "encore.dev.headers." + base64encoded;
 }
 
+type WebSocketEventType = "error" | "close" | "message" | "open";
+
 class WebSocketConnection {
     public ws: WebSocket;
 
+    // done is true once the stream is closed and will not be reopened.
+    public done = false;
+
     private hasUpdateHandlers: (() => void)[] = [];
+    private handlers: { type: WebSocketEventType, handler: (event: any) => void }[] = [];
+    private attempts = 0;
 
-    constructor(url: string, headers?: Record<string, string>) {
+    constructor(private url: string, private headers?: Record<string, string>, private options?: StreamOptions) {
+        this.ws = this.connect();
+
+        const signal = options?.signal;
+        if (signal) {
+            if (signal.aborted) {
+                this.close();
+            } else {
+                signal.addEventListener("abort", () => this.close(), { once: true });
+            }
+        }
+    }
+
+    private connect(): WebSocket {
         let protocols = ["encore-ws"];
-        if (headers) {
-            protocols.push(encodeWebSocketHeaders(headers))
+        if (this.headers) {
+            protocols.push(encodeWebSocketHeaders(this.headers))
         }
 
-        this.ws = new WebSocket(url, protocols)
+        const ws = new WebSocket(this.url, protocols)
+        for (const { type, handler } of this.handlers) {
+            ws.addEventListener(type, handler);
+        }
 
-        this.on("error", () => {
+        ws.addEventListener("open", () => {
+            this.attempts = 0;
             this.resolveHasUpdateHandlers();
         });
 
-        this.on("close", () => {
+        ws.addEventListener("error", () => {
             this.resolveHasUpdateHandlers();
         });
+
+        ws.addEventListener("close", (event: CloseEvent) => {
+            // Reopen the stream unless it was closed normally.
+            if (this.done || event.code === 1000 || !this.reconnect()) {
+                this.done = true;
+            }
+            this.resolveHasUpdateHandlers();
+        });
+
+        return ws;
+    }
+
+    // reconnect schedules reopening the stream, and reports whether it will be reopened.
+    private reconnect(): boolean {
+        const opts = this.options?.reconnect;
+        if (!opts || this.attempts >= (opts.maxAttempts ?? 5)) {
+            return false;
+        }
+
+        const delay = Math.min((opts.initialDelayMs ?? 500) * 2 ** this.attempts, opts.maxDelayMs ?? 10000);
+        this.attempts++;
+        setTimeout(() => {
+            if (!this.done) {
+                this.ws = this.connect();
+            }
+        }, delay);
+        return true;
     }
 
     resolveHasUpdateHandlers() {
@@ -738,16 +950,30 @@ class WebSocketConnection {
         });
     }
 
-    on(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+    // opened waits until the socket is open, throwing an error if the stream is closed.
+    async opened() {
+        while (this.ws.readyState !== WebSocket.OPEN) {
+            if (this.done) {
+                throw new Error("stream is closed");
+            }
+            await this.hasUpdate();
+        }
+    }
+
+    on(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers.push({ type, handler });
         this.ws.addEventListener(type, handler);
     }
 
-    off(type: "error" | "close" | "message" | "open", handler: (event: any) => void) {
+    off(type: WebSocketEventType, handler: (event: any) => void) {
+        this.handlers = this.handlers.filter((h) => h.type !== type || h.handler !== handler);
         this.ws.removeEventListener(type, handler);
     }
 
     close() {
+        this.done = true;
         this.ws.close();
+        this.resolveHasUpdateHandlers();
     }
 }
 
@@ -755,8 +981,8 @@ export class StreamInOut<Request, Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             this.buffer.push(JSON.parse(event.data));
             this.socket.resolveHasUpdateHandlers();
@@ -774,8 +1000,8 @@ export class StreamIn<Response> {
     public socket: WebSocketConnection;
     private buffer: Response[] = [];
 
-    constructor(url: string, headers?: Record<string, string>) {
-        this.socket = new WebSocketConnection(url, headers);
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             this.buffer.push(JSON.parse(event.data));
             this.socket.resolveHasUpdateHandlers();
@@ -792,11 +1018,11 @@ export class StreamOut<Request, Response> {
     public socket: WebSocketConnection;
     private responseValue: Promise<Response>;
 
-    constructor(url: string, headers?: Record<string, string>) {
+    constructor(url: string, headers?: Record<string, string>, options?: StreamOptions) {
         let responseResolver: (_: any) => void;
         this.responseValue = new Promise((resolve) => responseResolver = resolve);
 
-        this.socket = new WebSocketConnection(url, headers);
+        this.socket = new WebSocketConnection(url, headers, options);
         this.socket.on("message", (event: any) => {
             responseResolver(JSON.parse(event.data))
         });
@@ -823,6 +1049,11 @@ export type BaseURL = string
 
 export const Local: BaseURL = "http://localhost:4000"
 
+/**
+ * APIVersion is the version of the API the client was generated for.
+ */
+export const APIVersion = "` + ts.apiVersion + `"
+
 /**
  * Environment returns a BaseURL for calling the cloud environment with the given name.
  */
@@ -921,6 +1152,12 @@ export interface ClientOptions {
 
     /** Default RequestInit to be used for the client */
     requestInit?: Omit<RequestInit, "headers"> & { headers?: Record<string, string> }
+
+    /**
+     * Whether to send APIVersion with each request, so that the API rejects the requests
+     * if the client is out of date. Use isClientOutOfDate to detect such errors.
+     */
+    verifyAPIVersion?: boolean
 `)
 
 	if ts.hasAuth {
@@ -1012,6 +1249,10 @@ class BaseClient {
             this.headers["User-Agent"] = "` + userAgent + `";
         }
 
+        if (options.verifyAPIVersion) {
+            this.headers["X-Encore-API-Version"] = APIVersion
+        }
+
         this.requestInit = options.requestInit ?? {};
 
         // Setup what fetch function we'll be using in the base client
@@ -1120,7 +1361,7 @@ class BaseClient {
 
 	ts.WriteString(`
     // createStreamInOut sets up a stream to a streaming API endpoint.
-    async createStreamInOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamInOut<Request, Response>> {
+    async createStreamInOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamInOut<Request, Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -1137,11 +1378,11 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamInOut(this.baseURL + path + queryString, headers);
+        return new StreamInOut(this.baseURL + path + queryString, headers, options);
     }
 
     // createStreamIn sets up a stream to a streaming API endpoint.
-    async createStreamIn<Response>(path: string, params?: CallParameters): Promise<StreamIn<Response>> {
+    async createStreamIn<Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamIn<Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -1158,11 +1399,11 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamIn(this.baseURL + path + queryString, headers);
+        return new StreamIn(this.baseURL + path + queryString, headers, options);
     }
 
     // createStreamOut sets up a stream to a streaming API endpoint.
-    async createStreamOut<Request, Response>(path: string, params?: CallParameters): Promise<StreamOut<Request, Response>> {
+    async createStreamOut<Request, Response>(path: string, params?: CallParameters, options?: StreamOptions): Promise<StreamOut<Request, Response>> {
         let { query, headers } = params ?? {};
 
         // Fetch auth data if there is any
@@ -1179,7 +1420,7 @@ class BaseClient {
         }
 
         const queryString = query ? '?' + encodeQuery(query) : ''
-        return new StreamOut(this.baseURL + path + queryString, headers);
+        return new StreamOut(this.baseURL + path + queryString, headers, options);
     }
 
     // callAPI is used by each generated API method to actually make the request
@@ -1260,7 +1501,7 @@ function encodeQuery(parts: Record<string, string | string[]>): string {
     for (const key in parts) {
         const val = (Array.isArray(parts[key]) ?  parts[key] : [parts[key]]) as string[]
         for (const v of val) {
-            pairs.push(` + "`" + `${key}=${encodeURIComponent(v)}` + "`" + `)
+            pairs.push(` + "`" + `${encodeURIComponent(key)}=${encodeURIComponent(v)}` + "`" + `)
         }
     }
     return pairs.join("&")
@@ -1709,6 +1950,14 @@ export function isAPIError(err: any): err is APIError {
     return err instanceof APIError;
 }
 
+/**
+ * isClientOutOfDate reports whether the API rejected the request because the client
+ * was generated for a different version of the API. Regenerate the client to fix it.
+ */
+export function isClientOutOfDate(err: any): err is APIError {
+    return isAPIError(err) && err.code === ErrCode.FailedPrecondition && err.details?.client_out_of_date === true
+}
+
 export enum ErrCode {
     /**
      * OK indicates the operation was successful.
@@ -0,0 +1,247 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"encr.dev/internal/clientgen/clientgentypes"
+	"encr.dev/parser/encoding"
+	"encr.dev/pkg/idents"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// hookArg is an argument of the client method called by a hook.
+type hookArg struct {
+	name string
+	typ  string
+}
+
+// hookEndpoint describes an endpoint for which hooks are generated.
+type hookEndpoint struct {
+	rpc   *meta.RPC
+	args  []hookArg
+	resp  string
+	query bool // true if the endpoint is called using GET, and is a query
+}
+
+func (ts *typescript) writeReactQueryImports() {
+	ts.WriteString(`
+import {
+    useMutation,
+    useQuery,
+    useQueryClient,
+    type QueryKey,
+    type UseMutationOptions,
+    type UseQueryOptions,
+} from "@tanstack/react-query"
+`)
+}
+
+// writeReactQueryHooks writes TanStack Query hooks for calling the endpoints.
+// Endpoints called using GET are exposed as queries, and other endpoints as mutations.
+func (ts *typescript) writeReactQueryHooks(set clientgentypes.ServiceSet, tags clientgentypes.TagSet) {
+	type service struct {
+		name      string
+		endpoints []*hookEndpoint
+	}
+	var svcs []service
+	for _, svc := range ts.md.Svcs {
+		if !hasPublicRPC(svc) || !set.Has(svc.Name) {
+			continue
+		}
+		s := service{name: svc.Name}
+		for _, rpc := range svc.Rpcs {
+			if rpc.AccessType == meta.RPC_PRIVATE || !tags.IsRPCIncluded(rpc) ||
+				rpc.Proto == meta.RPC_RAW || rpc.StreamingRequest || rpc.StreamingResponse {
+				continue
+			}
+			s.endpoints = append(s.endpoints, ts.hookEndpoint(rpc))
+		}
+		svcs = append(svcs, s)
+	}
+
+	w := ts.newIdentWriter(0)
+	w.WriteString(`
+/**
+ * queryKeys are the TanStack Query keys of the API's queries.
+ * The keys are hierarchical, so that queryKeys.<service>.all matches all the queries of a service.
+ */
+export const queryKeys = {
+`)
+	{
+		w := w.Indent()
+		for _, s := range svcs {
+			w.WriteStringf("%s: {\n", ts.memberName(s.name))
+			{
+				w := w.Indent()
+				w.WriteStringf("all: [%s] as const,\n", ts.Quote(s.name))
+				for _, ep := range s.endpoints {
+					if !ep.query {
+						continue
+					}
+					key := []string{ts.Quote(s.name), ts.Quote(ep.rpc.Name)}
+					for _, arg := range ep.args {
+						key = append(key, arg.name)
+					}
+					w.WriteStringf("%s: (%s) => [%s] as const,\n", ts.memberName(ep.rpc.Name), hookParams(ep.args), strings.Join(key, ", "))
+				}
+			}
+			w.WriteString("},\n")
+		}
+	}
+	w.WriteString(`}
+
+/**
+ * QueryHookOptions are the options of the generated query hooks.
+ */
+export type QueryHookOptions<TData, TQueryKey extends QueryKey> =
+    Omit<UseQueryOptions<TData, Error, TData, TQueryKey>, "queryKey" | "queryFn">
+
+/**
+ * MutationHookOptions are the options of the generated mutation hooks.
+ */
+export type MutationHookOptions<TData, TVariables> =
+    Omit<UseMutationOptions<TData, Error, TVariables>, "mutationFn"> & {
+        /**
+         * Query keys to invalidate when the mutation succeeds, such as queryKeys.<service>.all.
+         */
+        invalidates?: QueryKey[]
+    }
+
+function useAPIMutation<TData, TVariables>(
+    mutationFn: (variables: TVariables) => Promise<TData>,
+    options?: MutationHookOptions<TData, TVariables>,
+) {
+    const queryClient = useQueryClient()
+    const { invalidates, onSuccess, ...rest } = options ?? {}
+    return useMutation<TData, Error, TVariables>({
+        ...rest,
+        mutationFn,
+        onSuccess: async (...args) => {
+            await Promise.all((invalidates ?? []).map((queryKey) => queryClient.invalidateQueries({ queryKey })))
+            return onSuccess?.(...args)
+        },
+    })
+}
+`)
+
+	for _, s := range svcs {
+		for _, ep := range s.endpoints {
+			ts.writeHook(w, s.name, ep)
+		}
+	}
+}
+
+func (ts *typescript) writeHook(w *indentWriter, svcName string, ep *hookEndpoint) {
+	hookName := "use" + idents.Convert(svcName, idents.PascalCase) + idents.Convert(ep.rpc.Name, idents.PascalCase)
+	method := fmt.Sprintf("client.%s.%s", ts.memberName(svcName), ts.memberName(ep.rpc.Name))
+	argNames := make([]string, len(ep.args))
+	for i, arg := range ep.args {
+		argNames[i] = arg.name
+	}
+
+	w.WriteString("\n")
+	if ep.query {
+		keyFn := fmt.Sprintf("queryKeys.%s.%s", ts.memberName(svcName), ts.memberName(ep.rpc.Name))
+		params := hookParams(ep.args)
+		if params != "" {
+			params += ", "
+		}
+		// Queries can't resolve to undefined, so endpoints without responses resolve to null.
+		resp, call := ep.resp, method+"("+strings.Join(argNames, ", ")+")"
+		if resp == "void" {
+			resp, call = "null", call+".then(() => null)"
+		}
+		w.WriteStringf(`/**
+ * %s queries %s.%s.
+ */
+export function %s(client: Client, %soptions?: QueryHookOptions<%s, ReturnType<typeof %s>>) {
+    return useQuery({
+        ...options,
+        queryKey: %s(%s),
+        queryFn: () => %s,
+    })
+}
+`, hookName, svcName, ep.rpc.Name, hookName, params, resp, keyFn,
+			keyFn, strings.Join(argNames, ", "), call)
+		return
+	}
+
+	// Mutations take the arguments as variables: a single argument is passed as is,
+	// and multiple arguments are passed as an object.
+	var vars, fn string
+	switch len(ep.args) {
+	case 0:
+		vars, fn = "void", "() => "+method+"()"
+	case 1:
+		vars, fn = ep.args[0].typ, "(vars) => "+method+"(vars)"
+	default:
+		fields := make([]string, len(ep.args))
+		callArgs := make([]string, len(ep.args))
+		for i, arg := range ep.args {
+			fields[i] = arg.name + ": " + arg.typ
+			callArgs[i] = "vars." + arg.name
+		}
+		vars = "{ " + strings.Join(fields, ", ") + " }"
+		fn = "(vars) => " + method + "(" + strings.Join(callArgs, ", ") + ")"
+	}
+	w.WriteStringf(`/**
+ * %s calls %s.%s as a mutation.
+ */
+export function %s(client: Client, options?: MutationHookOptions<%s, %s>) {
+    return useAPIMutation<%s, %s>(%s, options)
+}
+`, hookName, svcName, ep.rpc.Name, hookName, ep.resp, vars, ep.resp, vars, fn)
+}
+
+func (ts *typescript) hookEndpoint(rpc *meta.RPC) *hookEndpoint {
+	ep := &hookEndpoint{
+		rpc:   rpc,
+		resp:  "void",
+		query: encoding.DefaultClientHttpMethod(rpc) == "GET",
+	}
+	for _, s := range rpc.Path.Segments {
+		if s.Type == meta.PathSegment_LITERAL {
+			continue
+		}
+		typ := "string"
+		switch s.ValueType {
+		case meta.PathSegment_BOOL:
+			typ = "boolean"
+		case meta.PathSegment_STRING, meta.PathSegment_UUID:
+		default:
+			typ = "number"
+		}
+		if s.Type == meta.PathSegment_WILDCARD || s.Type == meta.PathSegment_FALLBACK {
+			typ += "[]"
+		}
+		ep.args = append(ep.args, hookArg{name: ts.nonReservedId(s.Value), typ: typ})
+	}
+	if rpc.RequestSchema != nil {
+		ep.args = append(ep.args, hookArg{name: "params", typ: ts.typString("", rpc.RequestSchema)})
+	}
+	if rpc.ResponseSchema != nil {
+		ep.resp = ts.typString("", rpc.ResponseSchema)
+	}
+	return ep
+}
+
+// typString returns the TypeScript type of typ, as referenced from the namespace ns.
+// If ns is empty the type is fully qualified.
+func (ts *typescript) typString(ns string, typ *schema.Type) string {
+	orig := ts.Buffer
+	defer func() { ts.Buffer = orig }()
+	ts.Buffer = &bytes.Buffer{}
+	ts.writeTyp(ns, typ, 0)
+	return ts.Buffer.String()
+}
+
+func hookParams(args []hookArg) string {
+	params := make([]string, len(args))
+	for i, arg := range args {
+		params[i] = arg.name + ": " + arg.typ
+	}
+	return strings.Join(params, ", ")
+}
@@ -228,6 +228,11 @@ func (ts *TokenSource) Token() (*oauth2.Token, error) {
 		return nil, err
 	}
 
+	// Don't attempt to refresh the token in offline mode.
+	if Offline() {
+		return baseToken, nil
+	}
+
 	// Use the built-in token source to simplify the logic of
 	// refreshing the token as necessary.
 	fetch := ts.cfg.TokenSource(context.Background(), baseToken)
@@ -54,6 +54,13 @@ func OptEncoreGoRoot() option.Option[string] {
 	return option.AsOptional(encoreGoRoot())
 }
 
+// OptEncoreRuntimesPath reports the path to the Encore runtime.
+// It can be overridden by setting ENCORE_RUNTIMES_PATH.
+// If the path can't be found, it reports None.
+func OptEncoreRuntimesPath() option.Option[string] {
+	return option.AsOptional(encoreRuntimesPath())
+}
+
 func encoreRuntimesPath() string {
 	if p := os.Getenv("ENCORE_RUNTIMES_PATH"); p != "" {
 		return p
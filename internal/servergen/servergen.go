@@ -0,0 +1,1114 @@
+// Package servergen scaffolds Encore services from OpenAPI specifications,
+// for migrating an existing API contract to Encore.
+package servergen
+
+import (
+	"context"
+	"fmt"
+	"go/format"
+	"go/token"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"encr.dev/pkg/idents"
+)
+
+// Options configure the generated code.
+type Options struct {
+	// ModulePath is the module path of the app,
+	// used to import the types shared between services.
+	ModulePath string
+
+	// DefaultService is the name of the service of the operations without tags.
+	// It defaults to "api".
+	DefaultService string
+}
+
+// sharedPkg is the package of the types used by several services.
+const sharedPkg = "apitypes"
+
+// authPkg is the package of the generated auth handler.
+const authPkg = "auth"
+
+// Load loads the OpenAPI specification at path, in either YAML or JSON format,
+// and validates it.
+func Load(ctx context.Context, path string) (*openapi3.T, error) {
+	spec, err := openapi3.NewLoader().LoadFromFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "load openapi spec")
+	}
+	if err := spec.Validate(ctx); err != nil {
+		return nil, errors.Wrap(err, "invalid openapi spec")
+	}
+	return spec, nil
+}
+
+// Generate generates services with endpoint stubs for the operations of the spec.
+// The files are keyed by their slash-separated paths relative to the app root, such as "users/users.go".
+//
+// Operations are grouped into services by their first tag. Each service gets a file with its endpoints,
+// which return errs.Unimplemented until implemented, and a file with the types they use.
+// Component schemas used by several services are generated in a shared "apitypes" package.
+// If any operation requires authentication, an auth handler stub is generated in the "auth" package.
+//
+// The warnings describe the parts of the spec that couldn't be represented faithfully,
+// such as operations with unsupported paths, which are left out.
+func Generate(spec *openapi3.T, opts Options) (files map[string][]byte, warnings []string, err error) {
+	if opts.DefaultService == "" {
+		opts.DefaultService = "api"
+	}
+	opts.DefaultService = packageName(opts.DefaultService)
+	g := &generator{
+		spec:  spec,
+		opts:  opts,
+		pkgs:  make(map[string]*pkg),
+		comps: make(map[string]*component),
+	}
+	if err := g.generate(); err != nil {
+		return nil, nil, err
+	}
+
+	files = make(map[string][]byte)
+	for _, p := range g.sortedPkgs() {
+		if len(p.endpoints) > 0 {
+			if files[p.name+"/"+p.name+".go"], err = p.endpointsFile(); err != nil {
+				return nil, nil, err
+			}
+		}
+		if len(p.decls) > 0 {
+			if files[p.name+"/types.go"], err = p.typesFile(); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if g.auth != nil {
+		if files[authPkg+"/handler.go"], err = g.authFile(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return files, g.warnings, nil
+}
+
+type generator struct {
+	spec     *openapi3.T
+	opts     Options
+	pkgs     map[string]*pkg
+	comps    map[string]*component // keyed by the name of the component schema
+	auth     *authHandler          // nil if no endpoint requires authentication
+	warnings []string
+}
+
+// pkg is a generated package.
+type pkg struct {
+	name      string
+	doc       string
+	names     map[string]bool // the identifiers declared in the package
+	endpoints []*endpoint
+	decls     []string                    // the type declarations
+	inline    map[*openapi3.Schema]string // the structs declared for inline object schemas
+	imports   map[string]bool
+}
+
+// component is a component schema of the spec.
+type component struct {
+	key    string
+	name   string // the name of the generated type
+	pkg    string // the package of the generated type
+	schema *openapi3.Schema
+	svcs   map[string]bool // the services using the component
+}
+
+type operation struct {
+	path   string
+	method string
+	item   *openapi3.PathItem
+	op     *openapi3.Operation
+	svc    string
+}
+
+type endpoint struct {
+	name       string
+	doc        string
+	method     string
+	path       string
+	access     string
+	tags       []string
+	raw        bool
+	pathParams []param
+	req        string // the request type, or "" if there is none
+	resp       string // the response type, or "" if there is none
+	imports    map[string]bool
+}
+
+type param struct {
+	name, typ string
+}
+
+type authHandler struct {
+	fields  []field // the fields of the auth params, or nil if it takes a token
+	imports map[string]bool
+}
+
+type field struct {
+	name, typ, tag, doc string
+}
+
+func (g *generator) generate() error {
+	ops := g.operations()
+
+	// Work out which services use which components, so that the components
+	// used by a single service can be generated in it, and the others in the shared package.
+	for name, ref := range g.spec.Components.Schemas {
+		if ref.Value != nil {
+			g.comps[name] = &component{key: name, schema: ref.Value, svcs: make(map[string]bool)}
+		}
+	}
+	for _, o := range ops {
+		g.walkOperation(o, func(c *component) { c.svcs[o.svc] = true })
+	}
+
+	// Declare the components first, so endpoints and other types can't take their names.
+	keys := make([]string, 0, len(g.comps))
+	for key := range g.comps {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		c := g.comps[key]
+		switch len(c.svcs) {
+		case 0:
+			// Unused, so leave it out.
+			continue
+		case 1:
+			for svc := range c.svcs {
+				c.pkg = svc
+			}
+		default:
+			c.pkg = sharedPkg
+		}
+		c.name = g.pkg(c.pkg).declare(exportedName(key))
+	}
+	for _, key := range keys {
+		if c := g.comps[key]; c.pkg != "" {
+			g.writeComponent(c)
+		}
+	}
+
+	for _, o := range ops {
+		if err := g.writeEndpoint(o); err != nil {
+			return errors.Wrapf(err, "%s %s", o.method, o.path)
+		}
+	}
+	return nil
+}
+
+// operations returns the operations of the spec, sorted by path and method.
+func (g *generator) operations() []*operation {
+	paths := make([]string, 0, len(g.spec.Paths))
+	for path := range g.spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var ops []*operation
+	for _, path := range paths {
+		item := g.spec.Paths[path]
+		for _, method := range []string{
+			http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+			http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodTrace,
+		} {
+			op := item.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			svc := g.opts.DefaultService
+			if len(op.Tags) > 0 {
+				svc = packageName(op.Tags[0])
+			}
+			ops = append(ops, &operation{path: path, method: method, item: item, op: op, svc: svc})
+		}
+	}
+	return ops
+}
+
+// walkOperation calls fn for each component used by the operation.
+func (g *generator) walkOperation(o *operation, fn func(c *component)) {
+	seen := make(map[*openapi3.Schema]bool)
+	var walk func(ref *openapi3.SchemaRef)
+	walk = func(ref *openapi3.SchemaRef) {
+		if ref == nil || ref.Value == nil || seen[ref.Value] {
+			return
+		}
+		seen[ref.Value] = true
+		if c := g.component(ref); c != nil {
+			fn(c)
+		}
+
+		s := ref.Value
+		for _, refs := range []openapi3.SchemaRefs{s.AllOf, s.OneOf, s.AnyOf} {
+			for _, r := range refs {
+				walk(r)
+			}
+		}
+		for _, r := range s.Properties {
+			walk(r)
+		}
+		walk(s.Items)
+		walk(s.AdditionalProperties.Schema)
+	}
+
+	for _, p := range g.parameters(o) {
+		walk(p.Schema)
+	}
+	if body := o.op.RequestBody; body != nil && body.Value != nil {
+		for _, mt := range body.Value.Content {
+			walk(mt.Schema)
+		}
+	}
+	if resp := successResponse(o.op); resp != nil {
+		for _, mt := range resp.Content {
+			walk(mt.Schema)
+		}
+	}
+}
+
+// component returns the component schema the ref refers to, or nil if it's not a component reference.
+func (g *generator) component(ref *openapi3.SchemaRef) *component {
+	key, ok := strings.CutPrefix(ref.Ref, "#/components/schemas/")
+	if !ok || strings.Contains(key, "/") {
+		return nil
+	}
+	return g.comps[key]
+}
+
+// parameters returns the parameters of the operation, including those of its path.
+func (g *generator) parameters(o *operation) []*openapi3.Parameter {
+	var params []*openapi3.Parameter
+	for _, ref := range o.op.Parameters {
+		if ref.Value != nil {
+			params = append(params, ref.Value)
+		}
+	}
+	for _, ref := range o.item.Parameters {
+		p := ref.Value
+		if p != nil && !slices.ContainsFunc(params, func(q *openapi3.Parameter) bool { return q.Name == p.Name && q.In == p.In }) {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+func (g *generator) writeComponent(c *component) {
+	p := g.pkg(c.pkg)
+	s := c.schema
+
+	if len(s.Enum) > 0 && s.Type == "string" {
+		var b strings.Builder
+		writeDoc(&b, s.Description, "")
+		fmt.Fprintf(&b, "type %s string\n\nconst (\n", c.name)
+		for _, v := range s.Enum {
+			str, ok := v.(string)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&b, "\t%s %s = %s\n", p.declare(c.name+exportedName(str)), c.name, strconv.Quote(str))
+		}
+		b.WriteString(")\n")
+		p.decls = append(p.decls, b.String())
+		return
+	}
+
+	if isObject(s) {
+		g.writeStruct(p, c.name, s, c.key)
+		return
+	}
+
+	var b strings.Builder
+	writeDoc(&b, s.Description, "")
+	fmt.Fprintf(&b, "type %s %s\n", c.name, g.inlineType(p, p.imports, s, c.name))
+	p.decls = append(p.decls, b.String())
+}
+
+// writeStruct declares a struct type for the object schema s.
+func (g *generator) writeStruct(p *pkg, name string, s *openapi3.Schema, what string) {
+	// Reserve the declaration's place, so it comes before the types declared for its fields.
+	idx := len(p.decls)
+	p.decls = append(p.decls, "")
+
+	fields := g.bodyFields(p, p.imports, s, name, what)
+	var b strings.Builder
+	writeDoc(&b, s.Description, "")
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	writeFields(&b, fields)
+	b.WriteString("}\n")
+	p.decls[idx] = b.String()
+}
+
+// bodyFields returns the fields of a struct for the properties of the object schema s.
+func (g *generator) bodyFields(p *pkg, imports map[string]bool, s *openapi3.Schema, hint, what string) []field {
+	props, required := g.properties(s)
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fields []field
+	taken := make(map[string]bool)
+	for _, name := range names {
+		prop := props[name]
+		f := field{
+			name: uniqueName(taken, exportedName(name)),
+			typ:  g.typeExpr(p, imports, prop, hint+exportedName(name)),
+		}
+		if prop.Value != nil && g.component(prop) == nil {
+			f.doc = prop.Value.Description
+		}
+		if required[name] {
+			f.tag = fmt.Sprintf(`json:%q`, name)
+		} else {
+			f.tag = fmt.Sprintf(`json:"%s,omitempty" encore:"optional"`, name)
+		}
+		if prop.Value != nil && prop.Value.ReadOnly && !required[name] {
+			// Read-only properties are only sent in responses.
+			f.doc = strings.TrimSpace(f.doc + "\n\nIt's read-only, and ignored in requests.")
+		}
+		fields = append(fields, f)
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		g.warnf("%s: oneOf and anyOf schemas aren't supported, so they're represented as raw JSON", what)
+	}
+	return fields
+}
+
+// properties returns the properties of the object schema s, including those of the schemas it's composed of,
+// and which properties are required.
+func (g *generator) properties(s *openapi3.Schema) (props openapi3.Schemas, required map[string]bool) {
+	props = make(openapi3.Schemas)
+	required = make(map[string]bool)
+	var collect func(s *openapi3.Schema)
+	collect = func(s *openapi3.Schema) {
+		for _, ref := range s.AllOf {
+			if ref.Value != nil {
+				collect(ref.Value)
+			}
+		}
+		for name, ref := range s.Properties {
+			props[name] = ref
+		}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+	}
+	collect(s)
+	return props, required
+}
+
+// typeExpr returns the Go type for the schema, declaring types for inline objects named after hint.
+// The imports it needs are added to imports.
+func (g *generator) typeExpr(p *pkg, imports map[string]bool, ref *openapi3.SchemaRef, hint string) string {
+	if ref == nil || ref.Value == nil {
+		imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	var typ string
+	if c := g.component(ref); c != nil && c.pkg != "" {
+		typ = c.name
+		if c.pkg != p.name {
+			imports[g.importPath(c.pkg)] = true
+			typ = c.pkg + "." + typ
+		}
+	} else {
+		typ = g.inlineType(p, imports, ref.Value, hint)
+	}
+
+	if ref.Value.Nullable && !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") &&
+		!strings.HasPrefix(typ, "*") && typ != "json.RawMessage" {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+// inlineType returns the Go type for a schema that's not a component.
+func (g *generator) inlineType(p *pkg, imports map[string]bool, s *openapi3.Schema, hint string) string {
+	switch {
+	case len(s.OneOf) > 0 || len(s.AnyOf) > 0:
+		g.warnf("%s: oneOf and anyOf schemas aren't supported, so they're represented as raw JSON", hint)
+		imports["encoding/json"] = true
+		return "json.RawMessage"
+
+	case isObject(s):
+		if len(s.Properties) == 0 && len(s.AllOf) == 0 {
+			if v := s.AdditionalProperties.Schema; v != nil {
+				return "map[string]" + g.typeExpr(p, imports, v, hint+"Value")
+			}
+			imports["encoding/json"] = true
+			return "json.RawMessage"
+		}
+		if name, ok := p.inline[s]; ok {
+			return name
+		}
+		name := p.declare(hint)
+		p.inline[s] = name
+		g.writeStruct(p, name, s, hint)
+		return name
+
+	case s.Type == "array":
+		return "[]" + g.typeExpr(p, imports, s.Items, hint+"Item")
+
+	case s.Type == "string":
+		switch s.Format {
+		case "date-time":
+			imports["time"] = true
+			return "time.Time"
+		case "uuid":
+			imports["encore.dev/types/uuid"] = true
+			return "uuid.UUID"
+		case "byte", "binary":
+			return "[]byte"
+		default:
+			return "string"
+		}
+
+	case s.Type == "integer":
+		switch s.Format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		default:
+			return "int"
+		}
+
+	case s.Type == "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+
+	case s.Type == "boolean":
+		return "bool"
+
+	default:
+		imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+}
+
+func (g *generator) writeEndpoint(o *operation) error {
+	p := g.pkg(o.svc)
+	if p.doc == "" {
+		for _, tag := range g.spec.Tags {
+			if len(o.op.Tags) > 0 && tag.Name == o.op.Tags[0] {
+				p.doc = tag.Description
+			}
+		}
+	}
+
+	name := o.op.OperationID
+	if name == "" {
+		name = strings.ToLower(o.method) + " " + o.path
+	}
+	ep := &endpoint{
+		name:    p.declare(exportedName(name)),
+		method:  o.method,
+		access:  "public",
+		imports: map[string]bool{"context": true, "encore.dev/beta/errs": true},
+	}
+	ep.doc = strings.TrimSpace(o.op.Summary + "\n\n" + o.op.Description)
+	if o.op.Deprecated {
+		ep.doc = strings.TrimSpace(ep.doc + "\n\nDeprecated: the operation is deprecated in the API specification.")
+	}
+	if g.requiresAuth(o.op) {
+		ep.access = "auth"
+	}
+
+	// Convert the path, whose parameters are of the form {name}, to Encore's :name.
+	params := g.parameters(o)
+	var segs []string
+	for _, seg := range strings.Split(strings.Trim(o.path, "/"), "/") {
+		if !strings.ContainsAny(seg, "{}") {
+			segs = append(segs, seg)
+			continue
+		}
+		paramName, ok := strings.CutPrefix(seg, "{")
+		if paramName, ok = strings.CutSuffix(paramName, "}"); !ok || strings.ContainsAny(paramName, "{}") {
+			g.warnf("%s %s: path segments partially made up of parameters aren't supported, so the operation is left out", o.method, o.path)
+			p.names[ep.name] = false
+			return nil
+		}
+
+		typ := "string"
+		idx := slices.IndexFunc(params, func(p *openapi3.Parameter) bool { return p.In == openapi3.ParameterInPath && p.Name == paramName })
+		if idx >= 0 {
+			typ = g.typeExpr(p, ep.imports, params[idx].Schema, ep.name+exportedName(paramName))
+			if !isPathParamType(typ) {
+				g.warnf("%s %s: path parameter %s must be a string, integer, boolean or UUID", o.method, o.path, paramName)
+				typ = "string"
+			}
+		}
+		id := unexportedName(paramName)
+		ep.pathParams = append(ep.pathParams, param{name: id, typ: typ})
+		segs = append(segs, ":"+id)
+	}
+	ep.path = "/" + strings.Join(segs, "/")
+
+	// Raw endpoints take care of the request and response bodies themselves,
+	// for content which isn't JSON or form-encoded.
+	body, bodyType := requestBody(o.op)
+	resp, respType := responseBody(o.op)
+	if bodyType == "raw" || respType == "raw" {
+		ep.raw = true
+		ep.imports = map[string]bool{"net/http": true, "encore.dev/beta/errs": true}
+		ep.doc = strings.TrimSpace(ep.doc + "\n\nIt's a raw endpoint, as it sends or receives content other than JSON.")
+		p.endpoints = append(p.endpoints, ep)
+		return nil
+	}
+
+	// The request
+	var fields []field
+	for _, param := range params {
+		var tag string
+		switch param.In {
+		case openapi3.ParameterInPath:
+			continue
+		case openapi3.ParameterInQuery:
+			tag = "query"
+		case openapi3.ParameterInHeader:
+			tag = "header"
+		default:
+			g.warnf("%s %s: %s parameters aren't supported, so %s is left out", o.method, o.path, param.In, param.Name)
+			continue
+		}
+
+		typ := g.typeExpr(p, p.imports, param.Schema, ep.name+exportedName(param.Name))
+		if !isParamType(typ) {
+			g.warnf("%s %s: %s parameter %s must be a built-in type or a list of them, so it's represented as a string", o.method, o.path, param.In, param.Name)
+			typ = "string"
+		}
+		f := field{name: exportedName(param.Name), typ: typ, tag: fmt.Sprintf("%s:%q", tag, param.Name), doc: param.Description}
+		if !param.Required {
+			f.tag += ` encore:"optional"`
+		}
+		fields = append(fields, f)
+	}
+
+	if body != nil {
+		if bodyType == "form" {
+			ep.tags = append(ep.tags, "form")
+		}
+		switch {
+		case len(fields) == 0 && g.component(body) != nil && isObject(body.Value):
+			// The request is just the component.
+			ep.req = "*" + g.typeExpr(p, ep.imports, body, "")
+		case isObject(body.Value):
+			fields = append(fields, g.bodyFields(p, p.imports, body.Value, ep.name+"Request", ep.name+" request")...)
+		default:
+			g.warnf("%s %s: the request body must be an object, so it's left out", o.method, o.path)
+		}
+	}
+	if ep.req == "" && len(fields) > 0 {
+		name := p.declare(ep.name + "Request")
+		var b strings.Builder
+		fmt.Fprintf(&b, "// %s is the request of %s.\ntype %s struct {\n", name, ep.name, name)
+		writeFields(&b, fields)
+		b.WriteString("}\n")
+		p.decls = append(p.decls, b.String())
+		ep.req = "*" + name
+	}
+
+	// The response
+	if resp != nil {
+		switch {
+		case g.component(resp) != nil && isObject(resp.Value):
+			ep.resp = strings.TrimPrefix(g.typeExpr(p, ep.imports, resp, ""), "*")
+		case isObject(resp.Value):
+			name := p.declare(ep.name + "Response")
+			g.writeStruct(p, name, resp.Value, ep.name+" response")
+			ep.resp = name
+		default:
+			g.warnf("%s %s: the response body must be an object, so it's left out", o.method, o.path)
+		}
+		if ep.resp != "" {
+			ep.resp = "*" + ep.resp
+		}
+	}
+
+	if bodyType == "form" && ep.req != "" {
+		ep.doc = strings.TrimSpace(ep.doc + "\n\nThe request body is form-encoded.")
+	}
+	p.endpoints = append(p.endpoints, ep)
+	return nil
+}
+
+// requiresAuth reports whether the operation requires authentication.
+func (g *generator) requiresAuth(op *openapi3.Operation) bool {
+	reqs := g.spec.Security
+	if op.Security != nil {
+		reqs = *op.Security
+	}
+	if len(reqs) == 0 {
+		return false
+	}
+
+	for _, req := range reqs {
+		if len(req) == 0 {
+			// Authentication is optional, which public endpoints support.
+			return false
+		}
+	}
+
+	if g.auth == nil {
+		g.auth = g.authHandler(reqs)
+	}
+	return true
+}
+
+// authHandler describes the auth handler for the given security requirements.
+// It takes a bearer token, unless the requirements use API keys or other HTTP authentication schemes,
+// in which case it takes the headers, query parameters and cookies they use.
+func (g *generator) authHandler(reqs openapi3.SecurityRequirements) *authHandler {
+	h := &authHandler{imports: make(map[string]bool)}
+	taken := make(map[string]bool)
+	for _, req := range reqs {
+		names := make([]string, 0, len(req))
+		for name := range req {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			ref := g.spec.Components.SecuritySchemes[name]
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			scheme := ref.Value
+			switch {
+			case scheme.Type == "apiKey" && scheme.In == "cookie":
+				h.imports["net/http"] = true
+				h.fields = append(h.fields, field{
+					name: uniqueName(taken, exportedName(scheme.Name)),
+					typ:  "*http.Cookie",
+					tag:  fmt.Sprintf("cookie:%q", scheme.Name),
+					doc:  scheme.Description,
+				})
+			case scheme.Type == "apiKey":
+				h.fields = append(h.fields, field{
+					name: uniqueName(taken, exportedName(scheme.Name)),
+					typ:  "string",
+					tag:  fmt.Sprintf("%s:%q", scheme.In, scheme.Name),
+					doc:  scheme.Description,
+				})
+			case scheme.Type == "http" && !strings.EqualFold(scheme.Scheme, "bearer"):
+				if !taken["Authorization"] {
+					h.fields = append(h.fields, field{
+						name: uniqueName(taken, "Authorization"),
+						typ:  "string",
+						tag:  `header:"Authorization"`,
+						doc:  scheme.Description,
+					})
+				}
+			}
+		}
+	}
+	return h
+}
+
+func (g *generator) authFile() ([]byte, error) {
+	imports := map[string]bool{"context": true, "encore.dev/beta/auth": true, "encore.dev/beta/errs": true}
+	for imp := range g.auth.imports {
+		imports[imp] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("// Package auth authenticates the requests to the endpoints which require authentication.\npackage auth\n\n")
+	writeImports(&b, imports)
+
+	if g.auth.fields == nil {
+		b.WriteString(`
+// AuthHandler authenticates requests using the bearer token in their Authorization header.
+//
+//encore:authhandler
+func AuthHandler(ctx context.Context, token string) (auth.UID, error) {
+	// TODO: validate the token, and return the ID of the user it belongs to.
+	return "", errs.B().Code(errs.Unauthenticated).Msg("not implemented").Err()
+}
+`)
+	} else {
+		b.WriteString(`
+// Params are the credentials of the requests.
+type Params struct {
+`)
+		writeFields(&b, g.auth.fields)
+		b.WriteString(`}
+
+// AuthHandler authenticates requests using their credentials.
+//
+//encore:authhandler
+func AuthHandler(ctx context.Context, p *Params) (auth.UID, error) {
+	// TODO: validate the credentials, and return the ID of the user they belong to.
+	return "", errs.B().Code(errs.Unauthenticated).Msg("not implemented").Err()
+}
+`)
+	}
+	return formatFile(authPkg+"/handler.go", b.String())
+}
+
+func (p *pkg) endpointsFile() ([]byte, error) {
+	imports := make(map[string]bool)
+	for _, ep := range p.endpoints {
+		for imp := range ep.imports {
+			imports[imp] = true
+		}
+	}
+
+	var b strings.Builder
+	doc := p.doc
+	if doc == "" {
+		doc = fmt.Sprintf("Package %s implements the %s service.", p.name, p.name)
+	}
+	writeDoc(&b, doc, "")
+	fmt.Fprintf(&b, "package %s\n\n", p.name)
+	writeImports(&b, imports)
+
+	for _, ep := range p.endpoints {
+		b.WriteString("\n")
+		if ep.doc != "" {
+			writeDoc(&b, ep.doc, "")
+			b.WriteString("//\n")
+		}
+		fmt.Fprintf(&b, "//encore:api %s", ep.access)
+		if ep.raw {
+			b.WriteString(" raw")
+		}
+		fmt.Fprintf(&b, " method=%s path=%s", ep.method, ep.path)
+		for _, tag := range ep.tags {
+			b.WriteString(" tag:" + tag)
+		}
+		b.WriteString("\n")
+
+		if ep.raw {
+			fmt.Fprintf(&b, "func %s(w http.ResponseWriter, req *http.Request) {\n", ep.name)
+			b.WriteString("\t// TODO: implement the endpoint.\n")
+			b.WriteString("\terrs.HTTPError(w, errs.B().Code(errs.Unimplemented).Msg(\"not implemented\").Err())\n}\n")
+			continue
+		}
+
+		args := []string{"ctx context.Context"}
+		for _, param := range ep.pathParams {
+			args = append(args, param.name+" "+param.typ)
+		}
+		if ep.req != "" {
+			args = append(args, "params "+ep.req)
+		}
+		ret, zero := "error", ""
+		if ep.resp != "" {
+			ret, zero = "("+ep.resp+", error)", "nil, "
+		}
+		fmt.Fprintf(&b, "func %s(%s) %s {\n", ep.name, strings.Join(args, ", "), ret)
+		b.WriteString("\t// TODO: implement the endpoint.\n")
+		fmt.Fprintf(&b, "\treturn %serrs.B().Code(errs.Unimplemented).Msg(\"not implemented\").Err()\n}\n", zero)
+	}
+	return formatFile(p.name+"/"+p.name+".go", b.String())
+}
+
+func (p *pkg) typesFile() ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", p.name)
+	writeImports(&b, p.imports)
+	for _, decl := range p.decls {
+		b.WriteString("\n" + decl)
+	}
+	return formatFile(p.name+"/types.go", b.String())
+}
+
+// pkg returns the package with the given name, creating it if needed.
+func (g *generator) pkg(name string) *pkg {
+	p, ok := g.pkgs[name]
+	if !ok {
+		p = &pkg{
+			name:    name,
+			names:   make(map[string]bool),
+			inline:  make(map[*openapi3.Schema]string),
+			imports: make(map[string]bool),
+		}
+		g.pkgs[name] = p
+		if name == sharedPkg {
+			p.doc = "Package apitypes contains the types used by several services."
+		}
+	}
+	return p
+}
+
+func (g *generator) sortedPkgs() []*pkg {
+	pkgs := make([]*pkg, 0, len(g.pkgs))
+	for _, p := range g.pkgs {
+		pkgs = append(pkgs, p)
+	}
+	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].name < pkgs[j].name })
+	return pkgs
+}
+
+func (g *generator) importPath(pkg string) string {
+	if g.opts.ModulePath == "" {
+		return pkg
+	}
+	return g.opts.ModulePath + "/" + pkg
+}
+
+func (g *generator) warnf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if !slices.Contains(g.warnings, msg) {
+		g.warnings = append(g.warnings, msg)
+	}
+}
+
+// declare declares an identifier in the package, adding a number to it if it's taken.
+func (p *pkg) declare(name string) string {
+	return uniqueName(p.names, name)
+}
+
+// requestBody returns the schema of the request body of the operation, and whether it's
+// JSON ("json"), form-encoded ("form") or other content ("raw").
+func requestBody(op *openapi3.Operation) (*openapi3.SchemaRef, string) {
+	if op.RequestBody == nil || op.RequestBody.Value == nil || len(op.RequestBody.Value.Content) == 0 {
+		return nil, ""
+	}
+	content := op.RequestBody.Value.Content
+	if mt := jsonMediaType(content); mt != nil {
+		return mt.Schema, "json"
+	}
+	if mt := content.Get("application/x-www-form-urlencoded"); mt != nil && mt.Schema != nil && isFormSchema(mt.Schema.Value) {
+		return mt.Schema, "form"
+	}
+	return nil, "raw"
+}
+
+// responseBody returns the schema of the body of the successful response of the operation,
+// and whether it's JSON ("json") or other content ("raw").
+func responseBody(op *openapi3.Operation) (*openapi3.SchemaRef, string) {
+	resp := successResponse(op)
+	if resp == nil || len(resp.Content) == 0 {
+		return nil, ""
+	}
+	if mt := jsonMediaType(resp.Content); mt != nil {
+		return mt.Schema, "json"
+	}
+	return nil, "raw"
+}
+
+// successResponse returns the first 2xx response of the operation, if any.
+func successResponse(op *openapi3.Operation) *openapi3.Response {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if ref := op.Responses[code]; ref != nil && ref.Value != nil {
+			return ref.Value
+		}
+	}
+	return nil
+}
+
+func jsonMediaType(content openapi3.Content) *openapi3.MediaType {
+	types := make([]string, 0, len(content))
+	for typ := range content {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		base, _, _ := strings.Cut(typ, ";")
+		if base = strings.TrimSpace(base); base == "application/json" || strings.HasSuffix(base, "+json") {
+			return content[typ]
+		}
+	}
+	return nil
+}
+
+// isFormSchema reports whether the form-encoded request body can be decoded by Encore,
+// which supports form-encoded objects with built-in and list fields.
+func isFormSchema(s *openapi3.Schema) bool {
+	if s == nil || !isObject(s) {
+		return false
+	}
+	for _, ref := range s.Properties {
+		v := ref.Value
+		if v != nil && v.Type == "array" && v.Items != nil {
+			v = v.Items.Value
+		}
+		if v == nil || isObject(v) || v.Type == "array" || v.Type == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isObject(s *openapi3.Schema) bool {
+	return s != nil && (s.Type == "object" || (s.Type == "" && (len(s.Properties) > 0 || len(s.AllOf) > 0)))
+}
+
+// isParamType reports whether typ can be used for query and header parameters.
+func isParamType(typ string) bool {
+	return isPathParamType(strings.TrimPrefix(typ, "[]")) || typ == "time.Time" || typ == "[]byte"
+}
+
+// isPathParamType reports whether typ can be used for path parameters.
+func isPathParamType(typ string) bool {
+	switch typ {
+	case "string", "bool", "int", "int32", "int64", "float32", "float64", "uuid.UUID":
+		return true
+	}
+	return false
+}
+
+func writeFields(b *strings.Builder, fields []field) {
+	for i, f := range fields {
+		if f.doc != "" {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			writeDoc(b, f.doc, "\t")
+		}
+		fmt.Fprintf(b, "\t%s %s `%s`\n", f.name, f.typ, f.tag)
+	}
+}
+
+func writeDoc(b *strings.Builder, doc, indent string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		b.WriteString(strings.TrimRight(indent+"// "+line, " ") + "\n")
+	}
+}
+
+func writeImports(b *strings.Builder, imports map[string]bool) {
+	if len(imports) == 0 {
+		return
+	}
+	var std, other []string
+	for imp := range imports {
+		if strings.Contains(strings.Split(imp, "/")[0], ".") {
+			other = append(other, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+
+	b.WriteString("import (\n")
+	for _, imp := range std {
+		fmt.Fprintf(b, "\t%q\n", imp)
+	}
+	if len(std) > 0 && len(other) > 0 {
+		b.WriteString("\n")
+	}
+	for _, imp := range other {
+		fmt.Fprintf(b, "\t%q\n", imp)
+	}
+	b.WriteString(")\n")
+}
+
+func formatFile(name, src string) ([]byte, error) {
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		return nil, errors.Wrapf(err, "format %s", name)
+	}
+	return out, nil
+}
+
+// exportedName returns an exported Go identifier for the given name.
+func exportedName(name string) string {
+	id := goName(name, true)
+	if id == "" || !unicode.IsLetter(rune(id[0])) {
+		id = "X" + id
+	}
+	return id
+}
+
+// unexportedName returns an unexported Go identifier for the given name.
+func unexportedName(name string) string {
+	id := goName(name, false)
+	if id == "" || !unicode.IsLetter(rune(id[0])) {
+		id = "x" + id
+	}
+	switch {
+	case token.IsKeyword(id), id == "ctx", id == "params":
+		id += "Param"
+	}
+	return id
+}
+
+// initialisms are the words which are written in all caps in Go identifiers.
+var initialisms = map[string]bool{
+	"api": true, "css": true, "dns": true, "html": true, "http": true, "https": true, "id": true,
+	"ip": true, "json": true, "jwt": true, "sql": true, "tls": true, "ttl": true, "ui": true,
+	"uri": true, "url": true, "utf8": true, "uuid": true, "xml": true,
+}
+
+// goName converts name, in any case, to a Go identifier in camelCase or PascalCase.
+func goName(name string, exported bool) string {
+	var b strings.Builder
+	for i, word := range strings.Split(idents.Convert(sanitize(name), idents.SnakeCase), "_") {
+		switch {
+		case word == "":
+		case i == 0 && !exported:
+			b.WriteString(word)
+		case initialisms[word]:
+			b.WriteString(strings.ToUpper(word))
+		default:
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// packageName returns a Go package name for the given tag.
+func packageName(tag string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(tag) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter(rune(name[0])) || token.IsKeyword(name) {
+		name = "svc" + name
+	}
+	return name
+}
+
+// sanitize replaces the characters which can't be part of identifiers with spaces,
+// so they separate the words of the identifier.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return ' '
+	}, name)
+}
+
+// uniqueName returns name, adding a number to it if it's already taken, and marks it as taken.
+func uniqueName(taken map[string]bool, name string) string {
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	taken[unique] = true
+	return unique
+}
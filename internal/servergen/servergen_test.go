@@ -0,0 +1,33 @@
+package servergen
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+
+	"encr.dev/pkg/golden"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	spec, err := Load(context.Background(), "testdata/petstore.yaml")
+	c.Assert(err, qt.IsNil)
+
+	files, warnings, err := Generate(spec, Options{ModulePath: "example.com/petstore"})
+	c.Assert(err, qt.IsNil)
+
+	ar := &txtar.Archive{Comment: []byte(strings.Join(warnings, "\n") + "\n")}
+	for name, data := range files {
+		ar.Files = append(ar.Files, txtar.File{Name: name, Data: data})
+	}
+	sort.Slice(ar.Files, func(i, j int) bool { return ar.Files[i].Name < ar.Files[j].Name })
+	golden.TestAgainst(t, "petstore.golden", string(txtar.Format(ar)))
+}
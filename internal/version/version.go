@@ -40,6 +40,7 @@ func ConfigHash() (string, error) {
 
 	fmt.Fprintf(h, "APIBaseURL=%s\n", conf.APIBaseURL)
 	fmt.Fprintf(h, "ConfigDir=%s\n", configDir)
+	fmt.Fprintf(h, "Offline=%t\n", conf.Offline())
 
 	digest := h.Sum(nil)
 	return base64.RawURLEncoding.EncodeToString(digest), nil
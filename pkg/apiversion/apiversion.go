@@ -0,0 +1,134 @@
+// Package apiversion computes fingerprints of an app's API,
+// used to detect generated clients that are out of date.
+package apiversion
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Header is the header generated clients use to send the API version
+// they were generated for.
+const Header = "X-Encore-API-Version"
+
+// Fingerprint computes the version of the app's API described by md.
+//
+// The fingerprint only covers what clients depend on: the public and authenticated
+// endpoints, the types they use and the auth handler's parameters.
+// Documentation, source locations and unrelated types don't affect it.
+func Fingerprint(md *meta.Data) string {
+	api := &meta.Data{}
+	for _, svc := range md.Svcs {
+		s := &meta.Service{Name: svc.Name}
+		for _, rpc := range svc.Rpcs {
+			if rpc.AccessType == meta.RPC_PRIVATE {
+				continue
+			}
+			s.Rpcs = append(s.Rpcs, &meta.RPC{
+				Name:              rpc.Name,
+				ServiceName:       rpc.ServiceName,
+				AccessType:        rpc.AccessType,
+				RequestSchema:     rpc.RequestSchema,
+				ResponseSchema:    rpc.ResponseSchema,
+				Proto:             rpc.Proto,
+				Path:              rpc.Path,
+				HttpMethods:       rpc.HttpMethods,
+				StreamingRequest:  rpc.StreamingRequest,
+				StreamingResponse: rpc.StreamingResponse,
+				HandshakeSchema:   rpc.HandshakeSchema,
+			})
+		}
+		if len(s.Rpcs) > 0 {
+			slices.SortFunc(s.Rpcs, func(a, b *meta.RPC) int { return cmp.Compare(a.Name, b.Name) })
+			api.Svcs = append(api.Svcs, s)
+		}
+	}
+	slices.SortFunc(api.Svcs, func(a, b *meta.Service) int { return cmp.Compare(a.Name, b.Name) })
+	if md.AuthHandler != nil {
+		api.AuthHandler = &meta.AuthHandler{Params: md.AuthHandler.Params}
+	}
+
+	// Find the declarations used by the API, and number them in a stable order
+	// so that unrelated declarations don't affect the fingerprint.
+	used := make(map[uint32]bool)
+	var visit func(m protoreflect.Message)
+	visit = func(m protoreflect.Message) {
+		if n, ok := m.Interface().(*schema.Named); ok && !used[n.Id] && int(n.Id) < len(md.Decls) {
+			used[n.Id] = true
+			visit(md.Decls[n.Id].ProtoReflect())
+		}
+		walk(m, visit)
+	}
+	visit(api.ProtoReflect())
+
+	var decls []*schema.Decl
+	for id := range used {
+		decls = append(decls, md.Decls[id])
+	}
+	slices.SortFunc(decls, func(a, b *schema.Decl) int {
+		return cmp.Or(cmp.Compare(a.Loc.GetPkgPath(), b.Loc.GetPkgPath()), cmp.Compare(a.Name, b.Name))
+	})
+	ids := make(map[uint32]uint32, len(decls))
+	for i, d := range decls {
+		ids[d.Id] = uint32(i)
+	}
+	for _, d := range decls {
+		d = proto.Clone(d).(*schema.Decl)
+		d.Id = ids[d.Id]
+		api.Decls = append(api.Decls, d)
+	}
+
+	// Renumber the references to the declarations and drop what doesn't affect clients.
+	api = proto.Clone(api).(*meta.Data)
+	var normalize func(m protoreflect.Message)
+	normalize = func(m protoreflect.Message) {
+		if n, ok := m.Interface().(*schema.Named); ok {
+			n.Id = ids[n.Id]
+		}
+		fields := m.Descriptor().Fields()
+		for _, name := range []protoreflect.Name{"doc", "docs", "loc"} {
+			if f := fields.ByName(name); f != nil {
+				m.Clear(f)
+			}
+		}
+		walk(m, normalize)
+	}
+	normalize(api.ProtoReflect())
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(api)
+	if err != nil {
+		// Marshalling a valid message can't fail.
+		panic(err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// walk calls fn for each message directly contained in m.
+func walk(m protoreflect.Message, fn func(protoreflect.Message)) {
+	m.Range(func(f protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case f.IsList() && f.Kind() == protoreflect.MessageKind:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				fn(list.Get(i).Message())
+			}
+		case f.IsMap() && f.MapValue().Kind() == protoreflect.MessageKind:
+			v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+				fn(v.Message())
+				return true
+			})
+		case !f.IsList() && !f.IsMap() && f.Kind() == protoreflect.MessageKind:
+			fn(v.Message())
+		}
+		return true
+	})
+}
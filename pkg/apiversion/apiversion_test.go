@@ -0,0 +1,96 @@
+package apiversion
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func testMeta() *meta.Data {
+	named := func(id uint32) *schema.Type {
+		return &schema.Type{Typ: &schema.Type_Named{Named: &schema.Named{Id: id}}}
+	}
+	return &meta.Data{
+		Decls: []*schema.Decl{
+			{
+				Id: 0, Name: "User", Doc: "User is a user.", Loc: &schema.Loc{PkgPath: "app/users", StartPos: 10},
+				Type: &schema.Type{Typ: &schema.Type_Struct{Struct: &schema.Struct{Fields: []*schema.Field{
+					{Name: "ID", JsonName: "id", Typ: &schema.Type{Typ: &schema.Type_Builtin{Builtin: schema.Builtin_STRING}}},
+				}}}},
+			},
+		},
+		Svcs: []*meta.Service{{
+			Name: "users",
+			Rpcs: []*meta.RPC{
+				{
+					Name: "Get", ServiceName: "users", AccessType: meta.RPC_PUBLIC, Doc: proto.String("Get gets a user."),
+					Path:           &meta.Path{Segments: []*meta.PathSegment{{Value: "users"}}},
+					HttpMethods:    []string{"GET"},
+					ResponseSchema: named(0),
+				},
+				{
+					Name: "Internal", ServiceName: "users", AccessType: meta.RPC_PRIVATE,
+					Path: &meta.Path{Segments: []*meta.PathSegment{{Value: "internal"}}},
+				},
+			},
+		}},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	c := qt.New(t)
+	base := Fingerprint(testMeta())
+	c.Assert(base, qt.HasLen, 16)
+	c.Assert(Fingerprint(testMeta()), qt.Equals, base)
+
+	// Changes that don't affect clients keep the fingerprint.
+	unaffected := map[string]func(md *meta.Data){
+		"docs": func(md *meta.Data) {
+			md.Decls[0].Doc = "Changed."
+			md.Svcs[0].Rpcs[0].Doc = proto.String("Changed.")
+		},
+		"locations": func(md *meta.Data) {
+			md.Decls[0].Loc.StartPos = 42
+		},
+		"private_endpoints": func(md *meta.Data) {
+			md.Svcs[0].Rpcs[1].HttpMethods = []string{"POST"}
+		},
+		"unrelated_decls": func(md *meta.Data) {
+			// Prepend an unrelated declaration, shifting the ids.
+			md.Decls = append([]*schema.Decl{{Name: "Config", Loc: &schema.Loc{PkgPath: "app/cfg"}}}, md.Decls...)
+			md.Decls[1].Id = 1
+			md.Svcs[0].Rpcs[0].ResponseSchema.GetNamed().Id = 1
+		},
+	}
+	for name, fn := range unaffected {
+		c.Run(name, func(c *qt.C) {
+			md := testMeta()
+			fn(md)
+			c.Assert(Fingerprint(md), qt.Equals, base)
+		})
+	}
+
+	// Changes that affect clients change the fingerprint.
+	affected := map[string]func(md *meta.Data){
+		"fields": func(md *meta.Data) {
+			md.Decls[0].Type.GetStruct().Fields[0].JsonName = "user_id"
+		},
+		"paths": func(md *meta.Data) {
+			md.Svcs[0].Rpcs[0].Path.Segments[0].Value = "user"
+		},
+		"access": func(md *meta.Data) {
+			md.Svcs[0].Rpcs[1].AccessType = meta.RPC_PUBLIC
+		},
+	}
+	for name, fn := range affected {
+		c.Run(name, func(c *qt.C) {
+			md := testMeta()
+			fn(md)
+			c.Assert(Fingerprint(md), qt.Not(qt.Equals), base)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/tailscale/hujson"
 
@@ -50,6 +51,14 @@ type File struct {
 	// Build contains build settings for the application.
 	Build Build `json:"build,omitempty"`
 
+	// Watch configures how the app's source code is watched
+	// for changes during local development.
+	Watch Watch `json:"watch,omitempty"`
+
+	// AuditLog configures the audit log of events
+	// the app records with encore.dev/audit.
+	AuditLog AuditLog `json:"audit_log,omitempty"`
+
 	// CgoEnabled enables building with cgo.
 	//
 	// Deprecated: Use build.cgo_enabled instead.
@@ -69,6 +78,11 @@ type Build struct {
 	// Docker configures the docker images built
 	// by Encore's CI/CD system.
 	Docker Docker `json:"docker,omitempty"`
+
+	// NodeVersion pins the version of Node.js to run TypeScript apps with
+	// locally, as a full version like "22.11.0". Encore downloads it
+	// if needed. If empty, the node binary on the PATH is used.
+	NodeVersion string `json:"node_version,omitempty"`
 }
 
 type Docker struct {
@@ -87,6 +101,73 @@ type Docker struct {
 	// ProcessPerService specifies whether each service should run in its own process. If false,
 	// all services are run in the same process.
 	ProcessPerService bool `json:"process_per_service,omitempty"`
+
+	// User specifies the user to run the application as in images built with
+	// 'encore build docker', as "user[:group]" (e.g. "65532:65532" to run as non-root).
+	// If empty it defaults to the base image's user.
+	User string `json:"user,omitempty"`
+
+	// HealthCheck, if set, adds a health check probing the application's
+	// health endpoint to images built with 'encore build docker'.
+	HealthCheck *DockerHealthCheck `json:"healthcheck,omitempty"`
+
+	// CACerts are paths, relative to the app root, to PEM-encoded CA certificates
+	// to trust in addition to the public CAs in images built with 'encore build docker'.
+	CACerts []string `json:"ca_certs,omitempty"`
+}
+
+type DockerHealthCheck struct {
+	// Interval is the time between checks, as a duration string like "30s".
+	// If empty it defaults to Docker's default.
+	Interval string `json:"interval,omitempty"`
+
+	// Timeout is the time to wait for a check to complete, as a duration string.
+	// If empty it defaults to Docker's default.
+	Timeout string `json:"timeout,omitempty"`
+
+	// StartPeriod is the time to wait for the application to start before counting
+	// failed checks, as a duration string. If empty it defaults to Docker's default.
+	StartPeriod string `json:"start_period,omitempty"`
+
+	// Retries is the number of consecutive failed checks needed to consider the
+	// container unhealthy. If zero it defaults to Docker's default.
+	Retries int `json:"retries,omitempty"`
+}
+
+// WatchMode is a strategy for watching the file system for changes.
+type WatchMode string
+
+const (
+	// WatchAuto uses file system notifications, and falls back to polling
+	// for network and virtualized file systems where notifications are
+	// known to be unreliable (like NFS, SMB, and WSL2 and Docker volume mounts).
+	WatchAuto WatchMode = "auto"
+
+	// WatchNotify uses file system notifications.
+	WatchNotify WatchMode = "notify"
+
+	// WatchPoll periodically scans the file system for changes.
+	WatchPoll WatchMode = "poll"
+)
+
+type Watch struct {
+	// Mode is the strategy for detecting changes.
+	// If empty it defaults to WatchAuto.
+	Mode WatchMode `json:"mode,omitempty"`
+
+	// PollInterval is how often to scan for changes when polling,
+	// as a duration string like "500ms" or "2s". If empty it defaults to 1s.
+	PollInterval string `json:"poll_interval,omitempty"`
+
+	// HotReload enables hot reloading TypeScript apps running on Node.js,
+	// swapping in changes to their implementation instead of restarting them.
+	HotReload bool `json:"hot_reload,omitempty"`
+}
+
+type AuditLog struct {
+	// RetentionDays is how many days audit events are kept for.
+	// If zero they're kept indefinitely.
+	RetentionDays int `json:"retention_days,omitempty"`
 }
 
 type CORS struct {
@@ -139,6 +220,38 @@ func Parse(data []byte) (*File, error) {
 		return nil, fmt.Errorf("appfile.Parse: invalid lang %q", f.Lang)
 	}
 
+	switch f.Watch.Mode {
+	case WatchAuto, WatchNotify, WatchPoll:
+	// Do nothing
+	case "":
+		f.Watch.Mode = WatchAuto
+	default:
+		return nil, fmt.Errorf("appfile.Parse: invalid watch mode %q", f.Watch.Mode)
+	}
+	if f.Watch.PollInterval != "" {
+		if d, err := time.ParseDuration(f.Watch.PollInterval); err != nil || d <= 0 {
+			return nil, fmt.Errorf("appfile.Parse: invalid watch poll_interval %q", f.Watch.PollInterval)
+		}
+	}
+
+	if hc := f.Build.Docker.HealthCheck; hc != nil {
+		for _, d := range []struct{ name, val string }{
+			{"interval", hc.Interval},
+			{"timeout", hc.Timeout},
+			{"start_period", hc.StartPeriod},
+		} {
+			if d.val == "" {
+				continue
+			}
+			if dur, err := time.ParseDuration(d.val); err != nil || dur < time.Second {
+				return nil, fmt.Errorf("appfile.Parse: invalid docker healthcheck %s %q: must be at least 1s", d.name, d.val)
+			}
+		}
+		if hc.Retries < 0 {
+			return nil, fmt.Errorf("appfile.Parse: invalid docker healthcheck retries %d", hc.Retries)
+		}
+	}
+
 	// Parse deprecated fields into the new Build struct.
 	f.Build.CgoEnabled = f.Build.CgoEnabled || f.CgoEnabled
 	if f.Build.Docker.BaseImage == "" {
@@ -196,3 +309,23 @@ func AppLang(appRoot string) (Lang, error) {
 	}
 	return f.Lang, nil
 }
+
+// NodeVersion returns the Node.js version pinned by the app located at appRoot,
+// or "" if it's not pinned.
+func NodeVersion(appRoot string) (string, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return "", err
+	}
+	return f.Build.NodeVersion, nil
+}
+
+// AuditLogRetention returns how long the app's audit events are kept for.
+// It reports 0 if they're kept indefinitely.
+func AuditLogRetention(appRoot string) (time.Duration, error) {
+	f, err := ParseFile(filepath.Join(appRoot, Name))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(f.AuditLog.RetentionDays) * 24 * time.Hour, nil
+}
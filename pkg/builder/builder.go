@@ -24,7 +24,7 @@ import (
 
 var LocalBuildTags = []string{
 	"encore_local",
-	"encore_no_gcp", "encore_no_aws", "encore_no_azure",
+	"encore_no_gcp", "encore_no_aws", "encore_no_azure", "encore_no_vault",
 	"encore_no_datadog", "encore_no_prometheus",
 }
 
@@ -37,6 +37,15 @@ const (
 	DebugModeBreak    DebugMode = "break"
 )
 
+// JSRuntime specifies the JavaScript runtime to run TypeScript applications with.
+type JSRuntime string
+
+const (
+	JSRuntimeNode JSRuntime = "node"
+	JSRuntimeBun  JSRuntime = "bun"
+	JSRuntimeDeno JSRuntime = "deno"
+)
+
 type BuildInfo struct {
 	BuildTags          []string
 	CgoEnabled         bool
@@ -60,6 +69,10 @@ type BuildInfo struct {
 	// with the local JS runtime.
 	UseLocalJSRuntime bool
 
+	// JSRuntime specifies the JavaScript runtime to run TypeScript applications with.
+	// If empty it defaults to Node.js.
+	JSRuntime JSRuntime
+
 	// Logger allows a custom logger to be used by the various phases of the builder.
 	Logger option.Option[zerolog.Logger]
 }
@@ -90,6 +103,15 @@ type ParseParams struct {
 	Experiments *experiments.Set
 	WorkingDir  string
 	ParseTests  bool
+
+	// DetectHardcodedSecrets reports string literals in the app's code
+	// that look like credentials as errors.
+	DetectHardcodedSecrets bool
+
+	// Incremental keeps the parser running after the parse, if supported,
+	// so later parses of the same app only re-parse the files that changed.
+	// The parser is stopped when the builder is closed.
+	Incremental bool
 }
 
 type ParseResult struct {
@@ -0,0 +1,450 @@
+// Package composegen generates Docker Compose configurations for self-hosting Encore apps,
+// running the app's Docker image together with the infrastructure it uses.
+package composegen
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/infraconf"
+	"encr.dev/pkg/rtconfgen"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// The names of the generated files.
+const (
+	ComposeFile     = "docker-compose.yaml"
+	InfraConfigFile = "infra-config.json"
+	PostgresInitSQL = "postgres-init.sql"
+	EnvFile         = ".env"
+)
+
+// PostgresPasswordEnv is the variable in the env file holding the password of the Postgres user.
+const PostgresPasswordEnv = "POSTGRES_PASSWORD"
+
+// The images of the infrastructure services.
+const (
+	postgresImage = "postgres:16"
+	redisImage    = "redis:7"
+	nsqImage      = "nsqio/nsq:v1.3.0"
+)
+
+const (
+	postgresUser = "encore"
+	redisHost    = "redis:6379"
+	nsqdHost     = "nsqd:4150"
+)
+
+// maxRedisDatabases is the number of databases of a Redis server with the default configuration.
+// Each cache cluster uses a database of its own.
+const maxRedisDatabases = 16
+
+// Params are the parameters for generating a Docker Compose configuration.
+type Params struct {
+	// Meta is the metadata of the app.
+	Meta *meta.Data
+
+	// AppSlug is the slug of the app, used as the name of the Compose project.
+	AppSlug string
+
+	// Lang is the language of the app. TypeScript apps get the new runtime configuration
+	// and read their secrets from it, while Go apps get the legacy runtime configuration
+	// and read their secrets from ENCORE_APP_SECRETS.
+	Lang appfile.Lang
+
+	// Image is the Docker image of the app, as built by "encore eject docker".
+	Image string
+
+	// Port is the port on the host to serve the app on.
+	Port int
+
+	// BaseURL is the URL the API gateway is reachable at.
+	// It defaults to http://localhost:<Port>.
+	BaseURL string
+
+	// CORS is the app's global CORS configuration, if any.
+	CORS *appfile.CORS
+
+	// PostgresPassword is the password of the Postgres user.
+	PostgresPassword string
+
+	// DeployedAt is the time the configuration is generated at.
+	DeployedAt time.Time
+}
+
+// Generate generates a Docker Compose configuration running the app with
+// Postgres, Redis and NSQ for the databases, caches and Pub/Sub topics it uses,
+// and the runtime configuration wiring them together.
+//
+// The files are keyed by their names, and include an env file with the Postgres password
+// and placeholders for the app's secrets, which should only be written if it doesn't already exist.
+func Generate(p Params) (files map[string][]byte, err error) {
+	if p.Port == 0 {
+		p.Port = 8080
+	}
+	if p.BaseURL == "" {
+		p.BaseURL = fmt.Sprintf("http://localhost:%d", p.Port)
+	}
+	if len(p.Meta.CacheClusters) > maxRedisDatabases {
+		return nil, errors.Newf("the app uses %d cache clusters, but at most %d are supported",
+			len(p.Meta.CacheClusters), maxRedisDatabases)
+	}
+
+	conf, err := runtimeConfig(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// Go apps use the legacy runtime configuration, while TypeScript apps use the new one.
+	var infraConfig []byte
+	var encoded string
+	if p.Lang == appfile.LangTS {
+		data, err := proto.Marshal(conf)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal runtime config")
+		}
+		encoded = base64.StdEncoding.EncodeToString(data)
+		// Indent the JSON ourselves, as protojson's output is deliberately unstable.
+		data, err = protojson.Marshal(conf)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal runtime config")
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return nil, errors.Wrap(err, "marshal runtime config")
+		}
+		infraConfig = buf.Bytes()
+	} else {
+		legacy, err := rtconfgen.ToLegacy(conf, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "generate runtime config")
+		}
+		data, err := json.Marshal(legacy)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal runtime config")
+		}
+		encoded = base64.StdEncoding.EncodeToString(data)
+		if infraConfig, err = json.MarshalIndent(legacy, "", "  "); err != nil {
+			return nil, errors.Wrap(err, "marshal runtime config")
+		}
+	}
+
+	files = map[string][]byte{
+		ComposeFile:     composeFile(p, encoded),
+		InfraConfigFile: append(infraConfig, '\n'),
+		EnvFile:         envFile(p),
+	}
+	if len(p.Meta.SqlDatabases) > 0 {
+		files[PostgresInitSQL] = postgresInit(p)
+	}
+	return files, nil
+}
+
+// runtimeConfig generates the runtime configuration of the app's container,
+// which hosts all services and gateways.
+func runtimeConfig(p Params) (*runtimev1.RuntimeConfig, error) {
+	md := p.Meta
+	b := rtconfgen.NewBuilder()
+	b.Env(&runtimev1.Environment{
+		AppId:   p.AppSlug,
+		AppSlug: p.AppSlug,
+		EnvId:   "compose",
+		EnvName: "compose",
+		EnvType: runtimev1.Environment_TYPE_PRODUCTION,
+		Cloud:   runtimev1.Environment_CLOUD_LOCAL,
+	})
+	b.EncorePlatform(&runtimev1.EncorePlatform{})
+	b.DeployID("compose")
+	b.DeployedAt(p.DeployedAt)
+
+	// The services are all hosted in the same container, so there are no internal calls to authenticate.
+	b.AuthMethods([]*runtimev1.ServiceAuth{{
+		AuthMethod: &runtimev1.ServiceAuth_Noop{Noop: &runtimev1.ServiceAuth_NoopAuth{}},
+	}})
+	b.DefaultGracefulShutdown(&runtimev1.GracefulShutdown{
+		Total:         durationpb.New(10 * time.Second),
+		ShutdownHooks: durationpb.New(4 * time.Second),
+		Handlers:      durationpb.New(2 * time.Second),
+	})
+
+	baseURL, err := url.Parse(p.BaseURL)
+	if err != nil || baseURL.Hostname() == "" {
+		return nil, errors.Newf("invalid base url %q", p.BaseURL)
+	}
+	for _, gw := range md.Gateways {
+		b.Infra.Gateway(&runtimev1.Gateway{
+			Rid:        "gateway:" + gw.EncoreName,
+			EncoreName: gw.EncoreName,
+			BaseUrl:    p.BaseURL,
+			Hostnames:  []string{baseURL.Hostname()},
+			Cors:       GatewayCORS(p.CORS),
+		})
+	}
+
+	if len(md.SqlDatabases) > 0 {
+		cluster := b.Infra.SQLCluster(&runtimev1.SQLCluster{Rid: "sql-cluster"})
+		cluster.SQLServer(&runtimev1.SQLServer{
+			Rid:  "sql-server",
+			Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+			Host: "postgres:5432",
+		})
+		b.Infra.SQLRole(&runtimev1.SQLRole{
+			Rid:      "sql-role",
+			Username: postgresUser,
+			Password: embedded(p.PostgresPassword),
+		})
+		for _, db := range md.SqlDatabases {
+			cluster.SQLDatabase(&runtimev1.SQLDatabase{
+				Rid:        "sql-database:" + db.Name,
+				EncoreName: db.Name,
+				CloudName:  db.Name,
+			}).AddConnectionPool(&runtimev1.SQLConnectionPool{RoleRid: "sql-role"})
+		}
+	}
+
+	if len(md.CacheClusters) > 0 {
+		b.Infra.RedisRole(&runtimev1.RedisRole{Rid: "redis-role"})
+		for i, cl := range md.CacheClusters {
+			cluster := b.Infra.RedisCluster(&runtimev1.RedisCluster{Rid: "redis-cluster:" + cl.Name})
+			cluster.RedisServer(&runtimev1.RedisServer{
+				Rid:  "redis-server:" + cl.Name,
+				Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+				Host: redisHost,
+			})
+			cluster.RedisDatabase(&runtimev1.RedisDatabase{
+				Rid:         "redis-database:" + cl.Name,
+				EncoreName:  cl.Name,
+				DatabaseIdx: int32(i),
+			}).AddConnectionPool(&runtimev1.RedisConnectionPool{RoleRid: "redis-role"})
+		}
+	}
+
+	if len(md.PubsubTopics) > 0 {
+		cluster := b.Infra.PubSubCluster(&runtimev1.PubSubCluster{
+			Rid: "pubsub-cluster",
+			Provider: &runtimev1.PubSubCluster_Nsq{
+				Nsq: &runtimev1.PubSubCluster_NSQ{Hosts: []string{nsqdHost}},
+			},
+		})
+		for _, topic := range md.PubsubTopics {
+			var guarantee runtimev1.PubSubTopic_DeliveryGuarantee
+			switch topic.DeliveryGuarantee {
+			case meta.PubSubTopic_AT_LEAST_ONCE:
+				guarantee = runtimev1.PubSubTopic_DELIVERY_GUARANTEE_AT_LEAST_ONCE
+			case meta.PubSubTopic_EXACTLY_ONCE:
+				guarantee = runtimev1.PubSubTopic_DELIVERY_GUARANTEE_EXACTLY_ONCE
+			default:
+				return nil, errors.Newf("unknown delivery guarantee %q", topic.DeliveryGuarantee)
+			}
+			var orderingAttr *string
+			if topic.OrderingKey != "" {
+				orderingAttr = &topic.OrderingKey
+			}
+
+			cluster.PubSubTopic(&runtimev1.PubSubTopic{
+				Rid:               "pubsub-topic:" + topic.Name,
+				EncoreName:        topic.Name,
+				CloudName:         topic.Name,
+				DeliveryGuarantee: guarantee,
+				OrderingAttr:      orderingAttr,
+			})
+			for _, sub := range topic.Subscriptions {
+				cluster.PubSubSubscription(&runtimev1.PubSubSubscription{
+					Rid:                    "pubsub-subscription:" + topic.Name + ":" + sub.Name,
+					TopicEncoreName:        topic.Name,
+					SubscriptionEncoreName: sub.Name,
+					TopicCloudName:         topic.Name,
+					SubscriptionCloudName:  sub.Name,
+				})
+			}
+		}
+	}
+
+	// TypeScript apps read their secrets from the runtime configuration,
+	// which refers to environment variables set from the env file.
+	if p.Lang == appfile.LangTS {
+		for _, name := range infraconf.SecretNames(md) {
+			b.Infra.AppSecret(&runtimev1.AppSecret{
+				Rid:        "secret:" + name,
+				EncoreName: name,
+				Data:       &runtimev1.SecretData{Source: &runtimev1.SecretData_Env{Env: secretEnv(name)}},
+			})
+		}
+	}
+
+	d := b.Deployment("compose").ServiceDiscovery(&runtimev1.ServiceDiscovery{
+		Services: make(map[string]*runtimev1.ServiceDiscovery_Location),
+	})
+	for _, gw := range md.Gateways {
+		d.HostsGateways(gw.EncoreName)
+	}
+	for _, svc := range md.Svcs {
+		d.HostsServices(svc.Name)
+	}
+	conf, err := d.BuildRuntimeConfig()
+	return conf, errors.Wrap(err, "generate runtime config")
+}
+
+// GatewayCORS returns the runtime CORS configuration of a gateway
+// for the given app CORS configuration, which may be nil.
+func GatewayCORS(cors *appfile.CORS) *runtimev1.Gateway_CORS {
+	if cors == nil {
+		cors = &appfile.CORS{}
+	}
+	withoutCreds := cors.AllowOriginsWithoutCredentials
+	if withoutCreds == nil {
+		withoutCreds = []string{"*"}
+	}
+
+	c := &runtimev1.Gateway_CORS{
+		Debug:               cors.Debug,
+		ExtraAllowedHeaders: cors.AllowHeaders,
+		ExtraExposedHeaders: cors.ExposeHeaders,
+		AllowedOriginsWithoutCredentials: &runtimev1.Gateway_CORSAllowedOrigins{
+			AllowedOrigins: withoutCreds,
+		},
+	}
+	if slices.Contains(cors.AllowOriginsWithCredentials, config.UnsafeAllOriginWithCredentials) {
+		c.AllowedOriginsWithCredentials = &runtimev1.Gateway_CORS_UnsafeAllowAllOriginsWithCredentials{
+			UnsafeAllowAllOriginsWithCredentials: true,
+		}
+	} else {
+		c.AllowedOriginsWithCredentials = &runtimev1.Gateway_CORS_AllowedOrigins{
+			AllowedOrigins: &runtimev1.Gateway_CORSAllowedOrigins{AllowedOrigins: cors.AllowOriginsWithCredentials},
+		}
+	}
+	return c
+}
+
+func composeFile(p Params, runtimeConfig string) []byte {
+	md := p.Meta
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by \"encore build compose\"; regenerate it when the app's infrastructure changes.\n")
+	fmt.Fprintf(&b, "# The runtime configuration in ENCORE_RUNTIME_CONFIG is %s, base64-encoded.\n", InfraConfigFile)
+	fmt.Fprintf(&b, "name: %s\n\nservices:\n", quote(p.AppSlug))
+
+	fmt.Fprintf(&b, "  app:\n    image: %s\n    restart: unless-stopped\n", quote(p.Image))
+	fmt.Fprintf(&b, "    ports:\n      - %s\n", quote(fmt.Sprintf("%d:8080", p.Port)))
+	b.WriteString("    environment:\n")
+	b.WriteString("      PORT: \"8080\"\n")
+	fmt.Fprintf(&b, "      ENCORE_RUNTIME_CONFIG: %s\n", quote(runtimeConfig))
+	if secrets := infraconf.SecretNames(md); len(secrets) > 0 {
+		if p.Lang == appfile.LangTS {
+			for _, name := range secrets {
+				fmt.Fprintf(&b, "      %s: %s\n", secretEnv(name), quote("${"+secretEnv(name)+"}"))
+			}
+		} else {
+			b.WriteString("      ENCORE_APP_SECRETS: \"${ENCORE_APP_SECRETS}\"\n")
+		}
+	}
+	var deps []string
+	if len(md.SqlDatabases) > 0 {
+		deps = append(deps, "      postgres:\n        condition: service_healthy\n")
+	}
+	if len(md.CacheClusters) > 0 {
+		deps = append(deps, "      redis:\n        condition: service_started\n")
+	}
+	if len(md.PubsubTopics) > 0 {
+		deps = append(deps, "      nsqd:\n        condition: service_started\n")
+	}
+	if len(deps) > 0 {
+		b.WriteString("    depends_on:\n" + strings.Join(deps, ""))
+	}
+
+	var volumes []string
+	if len(md.SqlDatabases) > 0 {
+		fmt.Fprintf(&b, "\n  postgres:\n    image: %s\n    restart: unless-stopped\n", postgresImage)
+		b.WriteString("    environment:\n")
+		fmt.Fprintf(&b, "      POSTGRES_USER: %s\n", postgresUser)
+		fmt.Fprintf(&b, "      POSTGRES_PASSWORD: \"${%s}\"\n", PostgresPasswordEnv)
+		b.WriteString("    volumes:\n")
+		b.WriteString("      - postgres-data:/var/lib/postgresql/data\n")
+		fmt.Fprintf(&b, "      - ./%s:/docker-entrypoint-initdb.d/encore.sql:ro\n", PostgresInitSQL)
+		b.WriteString("    healthcheck:\n")
+		fmt.Fprintf(&b, "      test: [\"CMD-SHELL\", \"pg_isready -U %s\"]\n", postgresUser)
+		b.WriteString("      interval: 5s\n      timeout: 5s\n      retries: 10\n")
+		volumes = append(volumes, "postgres-data")
+	}
+	if len(md.CacheClusters) > 0 {
+		fmt.Fprintf(&b, "\n  redis:\n    image: %s\n    restart: unless-stopped\n", redisImage)
+		b.WriteString("    volumes:\n      - redis-data:/data\n")
+		volumes = append(volumes, "redis-data")
+	}
+	if len(md.PubsubTopics) > 0 {
+		fmt.Fprintf(&b, "\n  nsqd:\n    image: %s\n    restart: unless-stopped\n", nsqImage)
+		b.WriteString("    command: /nsqd --data-path=/data\n")
+		b.WriteString("    volumes:\n      - nsq-data:/data\n")
+		volumes = append(volumes, "nsq-data")
+	}
+
+	if len(volumes) > 0 {
+		b.WriteString("\nvolumes:\n")
+		for _, v := range volumes {
+			fmt.Fprintf(&b, "  %s:\n", v)
+		}
+	}
+	return []byte(b.String())
+}
+
+func envFile(p Params) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# The environment of %s, generated by \"encore build compose\".\n", ComposeFile)
+	fmt.Fprintf(&b, "# It isn't overwritten when regenerating the configuration.\n")
+	if len(p.Meta.SqlDatabases) > 0 {
+		b.WriteString("\n# The password of the Postgres user. It's also embedded in the runtime configuration,\n")
+		b.WriteString("# so regenerate the configuration when changing it.\n")
+		fmt.Fprintf(&b, "%s=%s\n", PostgresPasswordEnv, p.PostgresPassword)
+	}
+	if secrets := infraconf.SecretNames(p.Meta); len(secrets) > 0 {
+		if p.Lang == appfile.LangTS {
+			b.WriteString("\n# The values of the app's secrets.\n")
+			for _, name := range secrets {
+				fmt.Fprintf(&b, "%s=\n", secretEnv(name))
+			}
+		} else {
+			b.WriteString("\n# The values of the app's secrets, as comma-separated name=value pairs with base64url-encoded values.\n")
+			fmt.Fprintf(&b, "# The app uses the secrets %s.\n", strings.Join(secrets, ", "))
+			b.WriteString("ENCORE_APP_SECRETS=\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+func postgresInit(p Params) []byte {
+	var b strings.Builder
+	b.WriteString("-- Creates the app's databases when the Postgres data volume is initialized.\n")
+	b.WriteString("-- The databases' migrations must be applied separately.\n")
+	for _, db := range p.Meta.SqlDatabases {
+		fmt.Fprintf(&b, "CREATE DATABASE %q;\n", db.Name)
+	}
+	return []byte(b.String())
+}
+
+// secretEnv returns the environment variable holding the value of the secret, for TypeScript apps.
+func secretEnv(name string) string {
+	return "ENCORE_SECRET_" + name
+}
+
+func embedded(s string) *runtimev1.SecretData {
+	return &runtimev1.SecretData{Source: &runtimev1.SecretData_Embedded{Embedded: []byte(s)}}
+}
+
+// quote quotes s as a YAML string.
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
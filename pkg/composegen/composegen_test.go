@@ -0,0 +1,51 @@
+package composegen_test
+
+import (
+	"sort"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/composegen"
+	"encr.dev/pkg/golden"
+	"encr.dev/pkg/infraconf/infraconftest"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestGenerate(t *testing.T) {
+	for _, lang := range []appfile.Lang{appfile.LangGo, appfile.LangTS} {
+		t.Run(string(lang), func(t *testing.T) {
+			c := qt.New(t)
+			files, err := composegen.Generate(infraconftest.ComposeParams(infraconftest.Meta(), lang))
+			c.Assert(err, qt.IsNil)
+
+			ar := &txtar.Archive{}
+			for name, data := range files {
+				ar.Files = append(ar.Files, txtar.File{Name: name, Data: data})
+			}
+			sort.Slice(ar.Files, func(i, j int) bool { return ar.Files[i].Name < ar.Files[j].Name })
+			golden.Test(t, string(txtar.Format(ar)))
+		})
+	}
+}
+
+func TestGenerateWithoutInfra(t *testing.T) {
+	c := qt.New(t)
+	files, err := composegen.Generate(composegen.Params{
+		Meta:    &meta.Data{Svcs: []*meta.Service{{Name: "hello"}}},
+		AppSlug: "hello",
+		Lang:    appfile.LangGo,
+		Image:   "hello:latest",
+		Port:    4000,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(files[composegen.PostgresInitSQL], qt.IsNil)
+	c.Assert(string(files[composegen.ComposeFile]), qt.Not(qt.Contains), "volumes:")
+	c.Assert(string(files[composegen.ComposeFile]), qt.Contains, `- "4000:8080"`)
+}
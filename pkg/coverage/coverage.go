@@ -0,0 +1,165 @@
+// Package coverage attributes Go coverage profiles to the services of an Encore app,
+// excluding the code generated by Encore.
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// ServiceCoverage is the statement coverage of a service, or of the whole app.
+type ServiceCoverage struct {
+	Service    string  `json:"service,omitempty"`
+	Statements int     `json:"statements"`
+	Covered    int     `json:"covered"`
+	Percent    float64 `json:"percent"`
+}
+
+// Summary is the coverage of an app, per service.
+type Summary struct {
+	// Services is the coverage of each of the app's services, sorted by name.
+	Services []ServiceCoverage `json:"services"`
+
+	// Total is the coverage of all the app's code, including code outside of services.
+	Total ServiceCoverage `json:"total"`
+}
+
+// IsGenerated reports whether the file, given by its import path
+// as in coverage profiles, contains code generated by Encore.
+func IsGenerated(file string) bool {
+	base := path.Base(file)
+	return base == "encore.gen.go" ||
+		strings.HasPrefix(base, "encore_internal__") ||
+		strings.Contains(file, "/__encore/")
+}
+
+// Process reads a Go coverage profile of the app described by md.
+// It returns the profile without the blocks in files generated by Encore,
+// and the coverage summary computed from it.
+//
+// Blocks reported several times, as happens when multiple test binaries
+// cover the same package, are counted once: as covered if any of them is.
+func Process(md *meta.Data, profile []byte) (filtered []byte, summary *Summary, err error) {
+	type block struct {
+		service string
+		stmts   int
+		covered bool
+	}
+	blocks := make(map[string]*block)
+	var order []string
+
+	var out bytes.Buffer
+	sc := bufio.NewScanner(bytes.NewReader(profile))
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := sc.Text()
+		if lineNum == 1 {
+			if !strings.HasPrefix(line, "mode: ") {
+				return nil, nil, errors.Newf("invalid coverage profile: missing mode line")
+			}
+			out.WriteString(line + "\n")
+			continue
+		} else if line == "" {
+			continue
+		}
+
+		// Lines are of the form "file:startLine.startCol,endLine.endCol numStmts count".
+		file, rest, ok := strings.Cut(line, ":")
+		fields := strings.Fields(rest)
+		if !ok || len(fields) != 3 {
+			return nil, nil, errors.Newf("invalid coverage profile: line %d: %q", lineNum, line)
+		}
+		if IsGenerated(file) {
+			continue
+		}
+		stmts, err1 := strconv.Atoi(fields[1])
+		count, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil {
+			return nil, nil, errors.Newf("invalid coverage profile: line %d: %q", lineNum, line)
+		}
+		out.WriteString(line + "\n")
+
+		key := file + ":" + fields[0]
+		b, ok := blocks[key]
+		if !ok {
+			b = &block{service: serviceFor(md, file), stmts: stmts}
+			blocks[key] = b
+			order = append(order, key)
+		}
+		b.covered = b.covered || count > 0
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, errors.Wrap(err, "read coverage profile")
+	}
+
+	perSvc := make(map[string]*ServiceCoverage)
+	for _, svc := range md.Svcs {
+		perSvc[svc.Name] = &ServiceCoverage{Service: svc.Name}
+	}
+	summary = &Summary{}
+	for _, key := range order {
+		b := blocks[key]
+		summary.Total.add(b.stmts, b.covered)
+		if cov, ok := perSvc[b.service]; ok {
+			cov.add(b.stmts, b.covered)
+		}
+	}
+
+	for _, cov := range perSvc {
+		summary.Services = append(summary.Services, *cov)
+	}
+	sort.Slice(summary.Services, func(i, j int) bool {
+		return summary.Services[i].Service < summary.Services[j].Service
+	})
+	return out.Bytes(), summary, nil
+}
+
+// WriteTable writes the summary as a table, for displaying to users.
+func (s *Summary) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SERVICE\tSTATEMENTS\tCOVERAGE")
+	for _, svc := range s.Services {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%.1f%%\n", svc.Service, svc.Statements, svc.Percent)
+	}
+	_, _ = fmt.Fprintf(tw, "total\t%d\t%.1f%%\n", s.Total.Statements, s.Total.Percent)
+	return tw.Flush()
+}
+
+func (c *ServiceCoverage) add(stmts int, covered bool) {
+	c.Statements += stmts
+	if covered {
+		c.Covered += stmts
+	}
+	if c.Statements > 0 {
+		// Round to one decimal, as it's meant for display and thresholds.
+		c.Percent = float64(c.Covered*1000/c.Statements) / 10
+	}
+}
+
+// serviceFor returns the name of the service the file belongs to,
+// or "" if it's not part of any service.
+func serviceFor(md *meta.Data, file string) string {
+	pkg := path.Dir(file)
+	var name string
+	var longest int
+	for _, svc := range md.Svcs {
+		root := md.ModulePath
+		if svc.RelPath != "." {
+			root += "/" + svc.RelPath
+		}
+		if (pkg == root || strings.HasPrefix(pkg, root+"/")) && len(root) > longest {
+			name, longest = svc.Name, len(root)
+		}
+	}
+	return name
+}
@@ -0,0 +1,69 @@
+package coverage
+
+import (
+	"bytes"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestProcess(t *testing.T) {
+	c := qt.New(t)
+	md := &meta.Data{
+		ModulePath: "encore.app",
+		Svcs: []*meta.Service{
+			{Name: "orders", RelPath: "orders"},
+			{Name: "users", RelPath: "users"},
+			{Name: "billing", RelPath: "billing"},
+		},
+	}
+
+	profile := `mode: set
+encore.app/orders/orders.go:10.2,12.3 4 1
+encore.app/orders/orders.go:14.2,15.3 2 0
+encore.app/orders/encore.gen.go:5.2,8.3 3 0
+encore.app/orders/encore_internal__api.go:5.2,8.3 3 1
+encore.app/orders/internal/db.go:3.2,4.3 2 0
+encore.app/users/users.go:3.2,6.3 4 0
+encore.app/users/users.go:3.2,6.3 4 1
+encore.app/pkg/util/util.go:1.2,2.3 1 1
+encore.app/__encore/main/main.go:1.2,9.3 10 1
+`
+	filtered, summary, err := Process(md, []byte(profile))
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(filtered), qt.Equals, `mode: set
+encore.app/orders/orders.go:10.2,12.3 4 1
+encore.app/orders/orders.go:14.2,15.3 2 0
+encore.app/orders/internal/db.go:3.2,4.3 2 0
+encore.app/users/users.go:3.2,6.3 4 0
+encore.app/users/users.go:3.2,6.3 4 1
+encore.app/pkg/util/util.go:1.2,2.3 1 1
+`)
+	c.Assert(summary, qt.DeepEquals, &Summary{
+		Services: []ServiceCoverage{
+			{Service: "billing"},
+			{Service: "orders", Statements: 8, Covered: 4, Percent: 50},
+			{Service: "users", Statements: 4, Covered: 4, Percent: 100},
+		},
+		Total: ServiceCoverage{Statements: 13, Covered: 9, Percent: 69.2},
+	})
+
+	var buf bytes.Buffer
+	c.Assert(summary.WriteTable(&buf), qt.IsNil)
+	c.Assert(buf.String(), qt.Equals, `SERVICE  STATEMENTS  COVERAGE
+billing  0           0.0%
+orders   8           50.0%
+users    4           100.0%
+total    13          69.2%
+`)
+}
+
+func TestProcessInvalid(t *testing.T) {
+	c := qt.New(t)
+	_, _, err := Process(&meta.Data{}, []byte("encore.app/a.go:1.1,2.2 1 1\n"))
+	c.Assert(err, qt.ErrorMatches, ".*missing mode line")
+	_, _, err = Process(&meta.Data{}, []byte("mode: set\nencore.app/a.go 1 1\n"))
+	c.Assert(err, qt.ErrorMatches, ".*line 2.*")
+}
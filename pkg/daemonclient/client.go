@@ -0,0 +1,311 @@
+// Package daemonclient provides a Go client for the Encore daemon.
+//
+// It wraps the daemon's gRPC API for driving Encore apps programmatically:
+// starting and stopping apps, streaming their output, running tests and
+// fetching app metadata. It's intended for building developer tooling on top
+// of Encore; the Encore CLI itself uses the same daemon API.
+//
+// The daemon is started by the Encore CLI (see "encore daemon"). Use [Connect]
+// to connect to it:
+//
+//	cl, err := daemonclient.Connect(ctx, daemonclient.Config{AutoStart: true})
+//	if err != nil {
+//		return err
+//	}
+//	defer cl.Close()
+//
+//	run, err := cl.Run(ctx, daemonclient.RunParams{AppRoot: "/path/to/app"})
+//	if err != nil {
+//		return err
+//	}
+//	defer run.Stop()
+//	code, err := run.Wait(os.Stdout, os.Stderr)
+package daemonclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"encr.dev/pkg/xos"
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// ErrNotRunning is reported by Connect when the daemon is not running
+// and Config.AutoStart is false.
+var ErrNotRunning = errors.New("daemonclient: encore daemon is not running")
+
+// SocketPath reports the path to the unix socket the daemon listens on.
+func SocketPath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine cache dir: %v", err)
+	}
+	return filepath.Join(cacheDir, "encore", "encored.sock"), nil
+}
+
+// Config configures how to connect to the daemon.
+type Config struct {
+	// SocketPath is the path to the daemon's unix socket.
+	// If empty it defaults to SocketPath().
+	SocketPath string
+
+	// AutoStart, if true, starts the daemon if it's not already running.
+	AutoStart bool
+
+	// EncoreBinary is the path to the encore binary used to start the daemon.
+	// If empty the "encore" binary in $PATH is used.
+	EncoreBinary string
+}
+
+// Client is a client for the Encore daemon.
+// It is safe for concurrent use.
+type Client struct {
+	cc *grpc.ClientConn
+	pb daemonpb.DaemonClient
+}
+
+// Connect connects to the Encore daemon.
+func Connect(ctx context.Context, cfg Config) (*Client, error) {
+	socketPath := cfg.SocketPath
+	if socketPath == "" {
+		var err error
+		if socketPath, err = SocketPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := xos.SocketStat(socketPath); err != nil {
+		if !cfg.AutoStart {
+			return nil, ErrNotRunning
+		} else if err := startDaemon(ctx, cfg.EncoreBinary, socketPath); err != nil {
+			return nil, err
+		}
+	}
+	return Dial(ctx, socketPath)
+}
+
+// Dial connects to the daemon listening on the unix socket at socketPath.
+func Dial(ctx context.Context, socketPath string) (*Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+	}
+	cc, err := grpc.DialContext(ctx, "",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.WithContextDialer(dialer))
+	if err != nil {
+		return nil, fmt.Errorf("daemonclient: dial daemon: %w", err)
+	}
+	return &Client{cc: cc, pb: daemonpb.NewDaemonClient(cc)}, nil
+}
+
+// startDaemon starts the daemon in the background and waits for it to come up.
+func startDaemon(ctx context.Context, encoreBinary, socketPath string) error {
+	if encoreBinary == "" {
+		var err error
+		if encoreBinary, err = exec.LookPath("encore"); err != nil {
+			return fmt.Errorf("daemonclient: could not find encore binary: %v", err)
+		}
+	}
+
+	// nosemgrep
+	cmd := exec.Command(encoreBinary, "daemon", "-f")
+	cmd.SysProcAttr = xos.CreateNewProcessGroup()
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("daemonclient: could not start encore daemon: %v", err)
+	}
+	go func() { _ = cmd.Wait() }()
+
+	for i := 0; i < 50; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+		if _, err := xos.SocketStat(socketPath); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("daemonclient: timed out waiting for daemon to start")
+}
+
+// Close closes the connection to the daemon.
+// It does not stop the daemon.
+func (c *Client) Close() error {
+	return c.cc.Close()
+}
+
+// API returns the underlying gRPC client, for using parts
+// of the daemon API not covered by this package.
+func (c *Client) API() daemonpb.DaemonClient {
+	return c.pb
+}
+
+// Version reports the version of the daemon.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	resp, err := c.pb.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", err
+	}
+	return resp.Version, nil
+}
+
+// MetaParams are the parameters to Client.Meta.
+type MetaParams struct {
+	// AppRoot is the absolute filesystem path to the Encore app root.
+	AppRoot string
+
+	// Environ is the environment to parse the app with,
+	// in the same format as os.Environ().
+	Environ []string
+
+	// ParseTests, if true, also parses test files.
+	ParseTests bool
+}
+
+// Meta parses the app and returns its metadata.
+func (c *Client) Meta(ctx context.Context, p MetaParams) (*meta.Data, error) {
+	resp, err := c.pb.DumpMeta(ctx, &daemonpb.DumpMetaRequest{
+		AppRoot:    p.AppRoot,
+		Environ:    p.Environ,
+		ParseTests: p.ParseTests,
+		Format:     daemonpb.DumpMetaRequest_FORMAT_PROTO,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var md meta.Data
+	if err := proto.Unmarshal(resp.Meta, &md); err != nil {
+		return nil, fmt.Errorf("daemonclient: decode metadata: %v", err)
+	}
+	return &md, nil
+}
+
+// RunParams are the parameters to Client.Run.
+type RunParams struct {
+	// AppRoot is the absolute filesystem path to the Encore app root.
+	AppRoot string
+
+	// Environ is the environment to run the app with,
+	// in the same format as os.Environ().
+	Environ []string
+
+	// ListenAddr is the address to listen on. It defaults to ":4000".
+	ListenAddr string
+
+	// AutoPort, if true, lets the daemon pick an available port
+	// if the port in ListenAddr is in use.
+	AutoPort bool
+
+	// Watch, if true, restarts the app when its source code changes.
+	Watch bool
+
+	// Namespace is the infrastructure namespace to use.
+	// If empty the active namespace is used.
+	Namespace string
+
+	// Debug, if true, builds the app for debugging.
+	Debug bool
+}
+
+// Run starts the app. The app runs until the returned command is stopped,
+// ctx is canceled, or the app exits.
+func (c *Client) Run(ctx context.Context, p RunParams) (*Command, error) {
+	if p.ListenAddr == "" {
+		p.ListenAddr = ":4000"
+	}
+	req := &daemonpb.RunRequest{
+		AppRoot:    p.AppRoot,
+		Environ:    p.Environ,
+		ListenAddr: p.ListenAddr,
+		AutoPort:   p.AutoPort,
+		Watch:      p.Watch,
+		Browser:    daemonpb.RunRequest_BROWSER_NEVER,
+	}
+	if p.Namespace != "" {
+		req.Namespace = &p.Namespace
+	}
+	if p.Debug {
+		req.DebugMode = daemonpb.RunRequest_DEBUG_ENABLED
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.pb.Run(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return newCommand(stream, cancel), nil
+}
+
+// TestParams are the parameters to Client.Test.
+type TestParams struct {
+	// AppRoot is the absolute filesystem path to the Encore app root.
+	AppRoot string
+
+	// WorkingDir is the directory to run the tests in,
+	// relative to AppRoot.
+	WorkingDir string
+
+	// Args are the arguments to pass to the test command,
+	// like "./..." and "-run=TestFoo".
+	Args []string
+
+	// Environ is the environment to run the tests with,
+	// in the same format as os.Environ().
+	Environ []string
+}
+
+// Test runs the app's tests.
+func (c *Client) Test(ctx context.Context, p TestParams) (*Command, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.pb.Test(ctx, &daemonpb.TestRequest{
+		AppRoot:    p.AppRoot,
+		WorkingDir: p.WorkingDir,
+		Args:       p.Args,
+		Environ:    p.Environ,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return newCommand(stream, cancel), nil
+}
+
+// CheckParams are the parameters to Client.Check.
+type CheckParams struct {
+	// AppRoot is the absolute filesystem path to the Encore app root.
+	AppRoot string
+
+	// Environ is the environment to check the app with,
+	// in the same format as os.Environ().
+	Environ []string
+}
+
+// Check checks the app for compilation errors.
+func (c *Client) Check(ctx context.Context, p CheckParams) (*Command, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.pb.Check(ctx, &daemonpb.CheckRequest{
+		AppRoot: p.AppRoot,
+		Environ: p.Environ,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return newCommand(stream, cancel), nil
+}
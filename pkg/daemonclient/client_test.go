@@ -0,0 +1,100 @@
+package daemonclient
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	daemonpb "encr.dev/proto/encore/daemon"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+type fakeDaemon struct {
+	daemonpb.UnimplementedDaemonServer
+	runReq *daemonpb.RunRequest
+}
+
+func (d *fakeDaemon) Version(ctx context.Context, _ *emptypb.Empty) (*daemonpb.VersionResponse, error) {
+	return &daemonpb.VersionResponse{Version: "v1.2.3"}, nil
+}
+
+func (d *fakeDaemon) DumpMeta(ctx context.Context, req *daemonpb.DumpMetaRequest) (*daemonpb.DumpMetaResponse, error) {
+	data, err := proto.Marshal(&meta.Data{ModulePath: "example.com/app", AppRevision: req.AppRoot})
+	if err != nil {
+		return nil, err
+	}
+	return &daemonpb.DumpMetaResponse{Meta: data}, nil
+}
+
+func (d *fakeDaemon) Run(req *daemonpb.RunRequest, stream daemonpb.Daemon_RunServer) error {
+	d.runReq = req
+	msgs := []*daemonpb.CommandMessage{
+		{Msg: &daemonpb.CommandMessage_Output{Output: &daemonpb.CommandOutput{Stdout: []byte("hello\n")}}},
+		{Msg: &daemonpb.CommandMessage_Output{Output: &daemonpb.CommandOutput{Stderr: []byte("oops\n")}}},
+		{Msg: &daemonpb.CommandMessage_Errors{Errors: &daemonpb.CommandDisplayErrors{}}},
+		{Msg: &daemonpb.CommandMessage_Exit{Exit: &daemonpb.CommandExit{Code: 3}}},
+	}
+	for _, msg := range msgs {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestClient(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	// Unix socket paths are limited in length, so avoid long temp dirs.
+	dir, err := os.MkdirTemp("", "encored")
+	c.Assert(err, qt.IsNil)
+	c.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "encored.sock")
+
+	ln, err := net.Listen("unix", socketPath)
+	c.Assert(err, qt.IsNil)
+	daemon := &fakeDaemon{}
+	srv := grpc.NewServer()
+	daemonpb.RegisterDaemonServer(srv, daemon)
+	go func() { _ = srv.Serve(ln) }()
+	c.Cleanup(srv.Stop)
+
+	_, err = Connect(ctx, Config{SocketPath: filepath.Join(dir, "missing.sock")})
+	c.Assert(err, qt.Equals, ErrNotRunning)
+
+	cl, err := Connect(ctx, Config{SocketPath: socketPath})
+	c.Assert(err, qt.IsNil)
+	defer func() { _ = cl.Close() }()
+
+	ver, err := cl.Version(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ver, qt.Equals, "v1.2.3")
+
+	md, err := cl.Meta(ctx, MetaParams{AppRoot: "/app"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(md.ModulePath, qt.Equals, "example.com/app")
+	c.Assert(md.AppRevision, qt.Equals, "/app")
+
+	run, err := cl.Run(ctx, RunParams{AppRoot: "/app", Namespace: "feature"})
+	c.Assert(err, qt.IsNil)
+	defer run.Stop()
+	var stdout, stderr bytes.Buffer
+	code, err := run.Wait(&stdout, &stderr)
+	c.Assert(err, qt.IsNil)
+	c.Assert(code, qt.Equals, 3)
+	c.Assert(stdout.String(), qt.Equals, "hello\n")
+	c.Assert(stderr.String(), qt.Equals, "oops\n")
+
+	c.Assert(daemon.runReq.ListenAddr, qt.Equals, ":4000")
+	c.Assert(daemon.runReq.GetNamespace(), qt.Equals, "feature")
+	c.Assert(daemon.runReq.Browser, qt.Equals, daemonpb.RunRequest_BROWSER_NEVER)
+}
@@ -0,0 +1,124 @@
+package daemonclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"encr.dev/pkg/errlist"
+	daemonpb "encr.dev/proto/encore/daemon"
+)
+
+// Event is an event produced by a running command.
+// Exactly one of its fields is set.
+type Event struct {
+	// Stdout and Stderr are chunks of output written by the command.
+	Stdout []byte
+	Stderr []byte
+
+	// Errors, if non-nil, reports compilation errors.
+	// An Errors event with an empty list reports that
+	// the previously reported errors have been resolved.
+	Errors *errlist.List
+
+	// Exit, if non-nil, reports the exit code of the command.
+	// It is the last event produced by the command.
+	Exit *int
+}
+
+// Command is a command running in the daemon,
+// like a running app or a test run.
+type Command struct {
+	stream interface {
+		Recv() (*daemonpb.CommandMessage, error)
+	}
+	cancel context.CancelFunc
+}
+
+func newCommand(stream interface {
+	Recv() (*daemonpb.CommandMessage, error)
+}, cancel context.CancelFunc) *Command {
+	return &Command{stream: stream, cancel: cancel}
+}
+
+// Stop stops the command.
+func (c *Command) Stop() {
+	c.cancel()
+}
+
+// Next returns the next event produced by the command.
+// It reports io.EOF when the command has completed.
+func (c *Command) Next() (*Event, error) {
+	for {
+		msg, err := c.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+
+		switch m := msg.Msg.(type) {
+		case *daemonpb.CommandMessage_Output:
+			return &Event{Stdout: m.Output.Stdout, Stderr: m.Output.Stderr}, nil
+
+		case *daemonpb.CommandMessage_Errors:
+			list := errlist.New(nil)
+			if len(m.Errors.Errinsrc) > 0 {
+				if err := json.Unmarshal(m.Errors.Errinsrc, list); err != nil {
+					return nil, fmt.Errorf("daemonclient: decode errors: %v", err)
+				}
+			}
+			return &Event{Errors: list}, nil
+
+		case *daemonpb.CommandMessage_Exit:
+			code := int(m.Exit.Code)
+			return &Event{Exit: &code}, nil
+		}
+		// Unknown message type; skip it.
+	}
+}
+
+// Wait waits for the command to complete, copying its output to stdout
+// and stderr, and reports its exit code. Compilation errors are written
+// to stderr. Either writer may be nil to discard the output.
+//
+// If the command completes without reporting an exit code,
+// as happens when it's stopped, Wait reports an exit code of 0.
+func (c *Command) Wait(stdout, stderr io.Writer) (exitCode int, err error) {
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+	for {
+		ev, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return 0, nil
+		} else if err != nil {
+			return 1, err
+		}
+
+		switch {
+		case ev.Stdout != nil || ev.Stderr != nil:
+			if _, err := stdout.Write(ev.Stdout); err != nil {
+				return 1, err
+			}
+			if _, err := stderr.Write(ev.Stderr); err != nil {
+				return 1, err
+			}
+		case ev.Errors != nil && ev.Errors.Len() > 0:
+			if _, err := io.WriteString(stderr, ev.Errors.Error()); err != nil {
+				return 1, err
+			}
+		case ev.Exit != nil:
+			return *ev.Exit, nil
+		}
+	}
+}
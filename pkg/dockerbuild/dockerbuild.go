@@ -45,6 +45,19 @@ type ImageBuildConfig struct {
 	// BaseImageOverride overrides the base image to use.
 	// If None it resolves the image from the spec using ResolveRemoteImage.
 	BaseImageOverride option.Option[v1.Image]
+
+	// ExtraCACerts are paths to PEM-encoded CA certificates to add to the
+	// CA certificates in the image, such as those of internal CAs.
+	// They are ignored unless AddCACerts is set.
+	ExtraCACerts []HostPath
+
+	// User, if set, specifies the user to run the entrypoint as, in the
+	// "user[:group]" format. The files added to the image are owned by root,
+	// so they can be read but not modified by other users.
+	User option.Option[string]
+
+	// HealthCheck, if set, adds a health check to the image.
+	HealthCheck option.Option[HealthCheck]
 }
 
 // BuildImage builds a docker image from the given spec.
@@ -112,6 +125,20 @@ func BuildImage(ctx context.Context, spec *ImageSpec, cfg ImageBuildConfig) (v1.
 	imgCfg.Config.Cmd = nil
 	imgCfg.Config.Env = envs.ToSlice()
 	imgCfg.Config.WorkingDir = string(spec.WorkingDir)
+	if user, ok := cfg.User.Get(); ok {
+		imgCfg.Config.User = user
+	}
+	if hc, ok := cfg.HealthCheck.Get(); ok {
+		imgCfg.Config.Healthcheck = hc.config()
+	}
+	labels := make(map[string]string)
+	for k, v := range baseCfg.Config.Labels {
+		labels[k] = v
+	}
+	for k, v := range imageLabels(spec, cfg.BuildTime) {
+		labels[k] = v
+	}
+	imgCfg.Config.Labels = labels
 	imgCfg.Author = "encore.dev"
 	imgCfg.Created = v1.Time{Time: cfg.BuildTime}
 
@@ -180,6 +207,13 @@ func buildImageFilesystem(ctx context.Context, spec *ImageSpec, cfg *ImageBuildC
 		return nil, err
 	}
 
+	// Add the health probe, if requested.
+	if hc, ok := cfg.HealthCheck.Get(); ok {
+		if err := addHealthProbe(tc, hc); err != nil {
+			return nil, err
+		}
+	}
+
 	// Add build information.
 	if err := writeBuildInfo(tc, spec.BuildInfo); err != nil {
 		return nil, err
@@ -195,7 +229,7 @@ func buildImageFilesystem(ctx context.Context, spec *ImageSpec, cfg *ImageBuildC
 		if caCertsDest == "" {
 			caCertsDest = DefaultCACertsPath
 		}
-		if err := addCACerts(ctx, tw, caCertsDest); err != nil {
+		if err := addCACerts(ctx, tc, caCertsDest, cfg.ExtraCACerts); err != nil {
 			return nil, errors.Wrap(err, "add ca certs")
 		}
 	}
@@ -300,7 +334,7 @@ func tryFetch(ctx context.Context, url string) (*http.Response, error) {
 	return resp, nil
 }
 
-func addCACerts(ctx context.Context, tw *tar.Writer, dest ImagePath) error {
+func addCACerts(ctx context.Context, tc *tarCopier, dest ImagePath, extraCerts []HostPath) error {
 	const (
 		encoreCachedRootCerts                     = "https://api.encore.dev/artifacts/build/root-certs"
 		mozillaRootStoreWebsiteTrustBitEnabledURL = "https://ccadb-public.secure.force.com/mozilla/IncludedRootsPEMTxt?TrustBitsInclude=Websites"
@@ -321,32 +355,37 @@ func addCACerts(ctx context.Context, tw *tar.Writer, dest ImagePath) error {
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// We need to populate the body of the tar file before writing the contents.
-	// Use the content length if it was provided. Otherwise, read the whole response
-	// into memory and use its length.
-	var body io.Reader = resp.Body
-	size := resp.ContentLength
-	if size < 0 {
-		// Unknown body; read the whole response into memory
-		data, err := io.ReadAll(resp.Body)
+	// We need to know the size of the file before writing its contents,
+	// so read the whole bundle into memory.
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, resp.Body); err != nil {
+		return errors.Wrap(err, "read cert data")
+	}
+	for _, path := range extraCerts {
+		data, err := os.ReadFile(path.String())
 		if err != nil {
-			return errors.Wrap(err, "read cert data")
+			return errors.Wrap(err, "read extra ca cert")
+		}
+		if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+			buf.WriteByte('\n')
 		}
-		size = int64(len(data))
-		body = bytes.NewReader(data)
+		buf.Write(data)
 	}
 
-	// Add the file
-	err = tw.WriteHeader(&tar.Header{
-		Typeflag: tar.TypeReg,
-		Name:     string(dest),
-		Size:     size,
-	})
+	err = tc.WriteFile(dest.String(), 0644, buf.Bytes())
+	return errors.Wrap(err, "write cert file")
+}
+
+func addHealthProbe(tc *tarCopier, hc HealthCheck) error {
+	fi, err := os.Stat(hc.ProbePath.String())
 	if err != nil {
-		return errors.Wrap(err, "create cert file")
+		return errors.Wrap(err, "stat health probe")
+	}
+	if err := tc.MkdirAll(defaultHealthProbePath.Dir(), 0755); err != nil {
+		return errors.Wrap(err, "create health probe dir")
 	}
-	if _, err := io.Copy(tw, body); err != nil {
-		return errors.Wrap(err, "write cert data")
+	if err := tc.CopyFile(defaultHealthProbePath, hc.ProbePath, fi, ""); err != nil {
+		return errors.Wrap(err, "copy health probe")
 	}
 	return nil
 }
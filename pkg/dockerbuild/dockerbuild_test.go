@@ -1,15 +1,22 @@
 package dockerbuild
 
 import (
+	"archive/tar"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
 	qt "github.com/frankban/quicktest"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 
 	"encr.dev/pkg/builder"
+	"encr.dev/pkg/fns"
 	"encr.dev/pkg/option"
 	"encr.dev/pkg/paths"
 	meta "encr.dev/proto/encore/parser/meta/v1"
@@ -17,7 +24,80 @@ import (
 
 func TestBuildImage(t *testing.T) {
 	c := qt.New(t)
+	spec := testImageSpec(c)
 
+	encoreBinaries := paths.FS(c.TempDir())
+	writeFiles(c, encoreBinaries, map[string]string{
+		"supervisor.bin": "supervisor",
+	})
+
+	ctx := context.Background()
+	buildTime := time.Unix(1234567890, 0)
+	img, err := BuildImage(ctx, spec, ImageBuildConfig{
+		BuildTime:      buildTime,
+		SupervisorPath: option.Some(HostPath(encoreBinaries.Join("supervisor.bin"))),
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = img.Digest()
+	c.Assert(err, qt.IsNil)
+	// Note: this digest changes depending on the machine it's being built on
+	// c.Assert(digest.String(), qt.Equals, "sha256:6e0032a1560c506901bbc1bb291d7655639d242f5ca09d5e119876830e34813d")
+}
+
+func TestBuildImageUserAndHealthCheck(t *testing.T) {
+	c := qt.New(t)
+	spec := testImageSpec(c)
+
+	ctx := context.Background()
+	probe, err := BuildHealthProbe(ctx, runtime.GOROOT(), "linux", "amd64", HostPath(c.TempDir()))
+	c.Assert(err, qt.IsNil)
+
+	encoreBinaries := paths.FS(c.TempDir())
+	writeFiles(c, encoreBinaries, map[string]string{
+		"supervisor.bin": "supervisor",
+	})
+	img, err := BuildImage(ctx, spec, ImageBuildConfig{
+		BuildTime:      time.Unix(1234567890, 0),
+		SupervisorPath: option.Some(HostPath(encoreBinaries.Join("supervisor.bin"))),
+		User:           option.Some("65532:65532"),
+		HealthCheck: option.Some(HealthCheck{
+			ProbePath: probe,
+			Interval:  10 * time.Second,
+			Retries:   3,
+		}),
+	})
+	c.Assert(err, qt.IsNil)
+
+	cfg, err := img.ConfigFile()
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Config.User, qt.Equals, "65532:65532")
+	c.Assert(cfg.Config.Healthcheck, qt.DeepEquals, &v1.HealthConfig{
+		Test:     []string{"CMD", "/encore/bin/healthcheck"},
+		Interval: 10 * time.Second,
+		Retries:  3,
+	})
+
+	// The probe is added to the image.
+	rc := mutate.Extract(img)
+	defer fns.CloseIgnore(rc)
+	tr := tar.NewReader(rc)
+	found := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, qt.IsNil)
+		if hdr.Name == "/encore/bin/healthcheck" {
+			found = true
+			c.Assert(hdr.Mode&0111, qt.Not(qt.Equals), int64(0))
+		}
+	}
+	c.Assert(found, qt.IsTrue)
+}
+
+func testImageSpec(c *qt.C) *ImageSpec {
 	artifacts := paths.FS(c.TempDir())
 	writeFiles(c, artifacts, map[string]string{
 		"entrypoint":       "echo hello",
@@ -53,24 +133,7 @@ func TestBuildImage(t *testing.T) {
 
 	spec, err := describe(cfg)
 	c.Assert(err, qt.IsNil)
-
-	encoreBinaries := paths.FS(c.TempDir())
-	writeFiles(c, encoreBinaries, map[string]string{
-		"supervisor.bin": "supervisor",
-	})
-
-	ctx := context.Background()
-	buildTime := time.Unix(1234567890, 0)
-	img, err := BuildImage(ctx, spec, ImageBuildConfig{
-		BuildTime:      buildTime,
-		SupervisorPath: option.Some(HostPath(encoreBinaries.Join("supervisor.bin"))),
-	})
-	c.Assert(err, qt.IsNil)
-
-	_, err = img.Digest()
-	c.Assert(err, qt.IsNil)
-	// Note: this digest changes depending on the machine it's being built on
-	// c.Assert(digest.String(), qt.Equals, "sha256:6e0032a1560c506901bbc1bb291d7655639d242f5ca09d5e119876830e34813d")
+	return spec
 }
 
 func writeFiles(c *qt.C, dir paths.FS, files map[string]string) {
@@ -85,3 +148,47 @@ func writeFiles(c *qt.C, dir paths.FS, files map[string]string) {
 		c.Assert(err, qt.IsNil)
 	}
 }
+
+func TestBuildImageLabels(t *testing.T) {
+	c := qt.New(t)
+	spec := testImageSpec(c)
+	spec.BuildInfo.Info = BuildInfo{
+		EncoreCompiler: "v1.2.3",
+		AppCommit:      CommitInfo{Revision: "abc123", Uncommitted: true},
+		APIHash:        "deadbeef",
+	}
+	spec.BundledServices = []string{"orders", "users"}
+	spec.BundledGateways = nil
+
+	base, err := mutate.Config(empty.Image, v1.Config{Labels: map[string]string{
+		"maintainer":    "base",
+		LabelRevision:   "base-revision",
+		"com.example.x": "y",
+	}})
+	c.Assert(err, qt.IsNil)
+
+	encoreBinaries := paths.FS(c.TempDir())
+	writeFiles(c, encoreBinaries, map[string]string{
+		"supervisor.bin": "supervisor",
+	})
+	img, err := BuildImage(context.Background(), spec, ImageBuildConfig{
+		BuildTime:         time.Unix(1234567890, 0),
+		SupervisorPath:    option.Some(HostPath(encoreBinaries.Join("supervisor.bin"))),
+		BaseImageOverride: option.Some(base),
+	})
+	c.Assert(err, qt.IsNil)
+
+	cfg, err := img.ConfigFile()
+	c.Assert(err, qt.IsNil)
+	c.Assert(cfg.Config.Labels, qt.DeepEquals, map[string]string{
+		"maintainer":                        "base",
+		"com.example.x":                     "y",
+		"org.opencontainers.image.created":  "2009-02-13T23:31:30Z",
+		"org.opencontainers.image.revision": "abc123",
+		"dev.encore.commit.uncommitted":     "true",
+		"dev.encore.version":                "v1.2.3",
+		"dev.encore.api-hash":               "deadbeef",
+		"dev.encore.services":               "orders,users",
+		"dev.encore.gateways":               "",
+	})
+}
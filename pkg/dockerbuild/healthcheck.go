@@ -0,0 +1,94 @@
+package dockerbuild
+
+import (
+	"context"
+	_ "embed" // for go:embed
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// healthProbeSource is the source code of the health probe.
+//
+//go:embed healthprobe/main.go
+var healthProbeSource []byte
+
+// defaultHealthProbePath is the path in the image where the health probe is located.
+const defaultHealthProbePath ImagePath = "/encore/bin/healthcheck"
+
+// HealthCheck configures the health check of an image,
+// which probes the health endpoint of the Encore runtime.
+type HealthCheck struct {
+	// ProbePath is the path to the health probe binary,
+	// as built by BuildHealthProbe for the image's platform.
+	ProbePath HostPath
+
+	// Interval is the time to wait between checks.
+	// If zero it defaults to Docker's default.
+	Interval time.Duration
+
+	// Timeout is the time to wait before considering a check to have failed.
+	// If zero it defaults to Docker's default.
+	Timeout time.Duration
+
+	// StartPeriod is the time to wait for the app to start before
+	// counting failed checks. If zero it defaults to Docker's default.
+	StartPeriod time.Duration
+
+	// Retries is the number of consecutive failures needed to
+	// consider the container unhealthy. If zero it defaults to Docker's default.
+	Retries int
+}
+
+func (h HealthCheck) config() *v1.HealthConfig {
+	return &v1.HealthConfig{
+		Test:        []string{"CMD", defaultHealthProbePath.String()},
+		Interval:    h.Interval,
+		Timeout:     h.Timeout,
+		StartPeriod: h.StartPeriod,
+		Retries:     h.Retries,
+	}
+}
+
+// BuildHealthProbe builds the health probe for the given platform into outputDir,
+// using the Go toolchain at goroot. The probe is statically linked, so it runs
+// on any base image, including scratch.
+func BuildHealthProbe(ctx context.Context, goroot, goos, goarch string, outputDir HostPath) (HostPath, error) {
+	srcDir, err := os.MkdirTemp("", "encore-healthprobe")
+	if err != nil {
+		return "", errors.Wrap(err, "create temp dir")
+	}
+	defer func() { _ = os.RemoveAll(srcDir) }()
+
+	files := map[string][]byte{
+		"go.mod":  []byte("module healthprobe\n\ngo 1.21\n"),
+		"main.go": healthProbeSource,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(srcDir, name), data, 0644); err != nil {
+			return "", errors.Wrapf(err, "write %s", name)
+		}
+	}
+
+	out := outputDir.Join("healthcheck-" + goos + "-" + goarch)
+	cmd := exec.CommandContext(ctx, filepath.Join(goroot, "bin", "go"), "build",
+		"-trimpath", "-ldflags=-s -w", "-o", out.String(), ".")
+	cmd.Dir = srcDir
+	cmd.Env = append(os.Environ(),
+		"GOROOT="+goroot,
+		"GOOS="+goos,
+		"GOARCH="+goarch,
+		"CGO_ENABLED=0",
+		"GOFLAGS=",
+		"GOWORK=off",
+		"GOTOOLCHAIN=local",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", errors.Wrapf(err, "build health probe: %s", output)
+	}
+	return out, nil
+}
@@ -0,0 +1,47 @@
+// Command healthprobe checks the health of an Encore application
+// running in the same container, for use as the container's health check.
+//
+// It exits with status 0 if the application reports that it's healthy,
+// and with status 1 otherwise. It has no dependencies outside the
+// standard library, as it's built into Docker images on demand.
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get("http://" + addr() + "/__encore/healthz")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "health check failed:", err)
+		os.Exit(1)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		fmt.Fprintln(os.Stderr, "health check failed:", resp.Status)
+		os.Exit(1)
+	}
+}
+
+// addr returns the address the application is listening on,
+// determined the same way as by the Encore runtime.
+func addr() string {
+	if listenAddr := os.Getenv("ENCORE_LISTEN_ADDR"); listenAddr != "" {
+		host, port, err := net.SplitHostPort(listenAddr)
+		if err == nil {
+			if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+				host = "127.0.0.1"
+			}
+			return net.JoinHostPort(host, port)
+		}
+	}
+	if port := os.Getenv("PORT"); port != "" {
+		return net.JoinHostPort("127.0.0.1", port)
+	}
+	return "127.0.0.1:8080"
+}
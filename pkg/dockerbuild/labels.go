@@ -0,0 +1,82 @@
+package dockerbuild
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+// Labels added to the images, so deployment tooling can trace what's running.
+// The OCI labels are defined by the OCI image spec's annotations.
+const (
+	LabelCreated     = "org.opencontainers.image.created"
+	LabelRevision    = "org.opencontainers.image.revision"
+	LabelVersion     = "dev.encore.version"
+	LabelUncommitted = "dev.encore.commit.uncommitted"
+	LabelAPIHash     = "dev.encore.api-hash"
+	LabelServices    = "dev.encore.services"
+	LabelGateways    = "dev.encore.gateways"
+)
+
+// imageLabels returns the labels of the image built from the spec.
+func imageLabels(spec *ImageSpec, buildTime time.Time) map[string]string {
+	info := spec.BuildInfo.Info
+	labels := map[string]string{
+		LabelCreated:  buildTime.UTC().Format(time.RFC3339),
+		LabelServices: strings.Join(spec.BundledServices, ","),
+		LabelGateways: strings.Join(spec.BundledGateways, ","),
+	}
+	if info.AppCommit.Revision != "" {
+		labels[LabelRevision] = info.AppCommit.Revision
+		labels[LabelUncommitted] = strconv.FormatBool(info.AppCommit.Uncommitted)
+	}
+	if info.EncoreCompiler != "" {
+		labels[LabelVersion] = info.EncoreCompiler
+	}
+	if info.APIHash != "" {
+		labels[LabelAPIHash] = info.APIHash
+	}
+	return labels
+}
+
+// APIHash returns a hash of the app's API: its services and their endpoints,
+// the auth handler, and the types they use. Source locations and the docs of
+// endpoints and types are ignored, so the hash only changes with the API.
+func APIHash(md *meta.Data) string {
+	api := &meta.Data{
+		Svcs:        make([]*meta.Service, 0, len(md.Svcs)),
+		Decls:       make([]*schema.Decl, 0, len(md.Decls)),
+		AuthHandler: proto.Clone(md.AuthHandler).(*meta.AuthHandler),
+	}
+	for _, svc := range md.Svcs {
+		s := &meta.Service{Name: svc.Name}
+		for _, rpc := range svc.Rpcs {
+			rpc = proto.Clone(rpc).(*meta.RPC)
+			rpc.Doc, rpc.Loc = nil, nil
+			s.Rpcs = append(s.Rpcs, rpc)
+		}
+		slices.SortFunc(s.Rpcs, func(a, b *meta.RPC) int { return strings.Compare(a.Name, b.Name) })
+		api.Svcs = append(api.Svcs, s)
+	}
+	slices.SortFunc(api.Svcs, func(a, b *meta.Service) int { return strings.Compare(a.Name, b.Name) })
+	for _, decl := range md.Decls {
+		decl = proto.Clone(decl).(*schema.Decl)
+		decl.Doc, decl.Loc = "", nil
+		api.Decls = append(api.Decls, decl)
+	}
+	if api.AuthHandler != nil {
+		api.AuthHandler.Doc, api.AuthHandler.Loc = "", nil
+	}
+
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(api)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package dockerbuild
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/proto"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestAPIHash(t *testing.T) {
+	c := qt.New(t)
+	doc := "Lists the orders."
+	md := &meta.Data{
+		Svcs: []*meta.Service{{
+			Name:    "orders",
+			RelPath: "orders",
+			Rpcs: []*meta.RPC{{
+				Name:        "List",
+				ServiceName: "orders",
+				Doc:         &doc,
+				AccessType:  meta.RPC_PUBLIC,
+				HttpMethods: []string{"GET"},
+				Loc:         &schema.Loc{Filename: "orders.go", StartPos: 10},
+			}},
+		}},
+		Decls: []*schema.Decl{{Id: 0, Name: "Order", Doc: "An order.", Loc: &schema.Loc{Filename: "orders.go"}}},
+	}
+	hash := APIHash(md)
+	c.Assert(hash, qt.HasLen, 64)
+
+	// Moving code around and changing docs doesn't change the hash.
+	moved := proto.Clone(md).(*meta.Data)
+	moved.Svcs[0].RelPath = "svc/orders"
+	moved.Svcs[0].Rpcs[0].Doc = nil
+	moved.Svcs[0].Rpcs[0].Loc.StartPos = 42
+	moved.Decls[0].Doc = ""
+	c.Assert(APIHash(moved), qt.Equals, hash)
+
+	// Changing the endpoints does.
+	changed := proto.Clone(md).(*meta.Data)
+	changed.Svcs[0].Rpcs[0].HttpMethods = []string{"POST"}
+	c.Assert(APIHash(changed), qt.Not(qt.Equals), hash)
+}
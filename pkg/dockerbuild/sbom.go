@@ -0,0 +1,279 @@
+package dockerbuild
+
+import (
+	"crypto/sha256"
+	"debug/buildinfo"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"encr.dev/pkg/builder"
+)
+
+// SBOM is a software bill of materials, in the SPDX 2.3 JSON format.
+type SBOM struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SBOMCreationInfo   `json:"creationInfo"`
+	Packages          []SBOMPackage      `json:"packages"`
+	Relationships     []SBOMRelationship `json:"relationships"`
+}
+
+type SBOMCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SBOMPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	ExternalRefs     []SBOMExternalRef `json:"externalRefs,omitempty"`
+}
+
+type SBOMExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SBOMRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// sbomPackage is a package found in the build outputs.
+type sbomPackage struct {
+	Name    string
+	Version string
+	PURL    string
+}
+
+// GenerateSBOM generates the SBOM of the app named name, listing the Go modules its
+// binaries are built from and the npm packages in its node modules. The packages
+// of the base image are not included; scanners find them in the base image itself.
+func GenerateSBOM(name string, compile *builder.CompileResult, created time.Time, tool string) (*SBOM, error) {
+	var pkgs []sbomPackage
+	seenBinaries := make(map[string]bool)
+	for _, out := range compile.Outputs {
+		switch out := out.(type) {
+		case *builder.GoBuildOutput:
+			for _, ep := range out.Entrypoints {
+				cmd := ep.Cmd.Expand(out.ArtifactDir)
+				if len(cmd.Command) == 0 || seenBinaries[cmd.Command[0]] {
+					continue
+				}
+				seenBinaries[cmd.Command[0]] = true
+				found, err := goModules(cmd.Command[0])
+				if err != nil {
+					return nil, err
+				}
+				pkgs = append(pkgs, found...)
+			}
+		case *builder.JSBuildOutput:
+			if nodeModules, ok := out.NodeModules.Get(); ok {
+				found, err := npmPackages(nodeModules.ToIO())
+				if err != nil {
+					return nil, err
+				}
+				pkgs = append(pkgs, found...)
+			}
+		}
+	}
+	slices.SortFunc(pkgs, func(a, b sbomPackage) int { return strings.Compare(a.PURL, b.PURL) })
+	pkgs = slices.CompactFunc(pkgs, func(a, b sbomPackage) bool { return a.PURL == b.PURL })
+
+	// Name the document after its contents, so the same packages give the same document.
+	h := sha256.New()
+	for _, pkg := range pkgs {
+		h.Write([]byte(pkg.PURL + "\x00"))
+	}
+	sbom := &SBOM{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: "https://encore.dev/spdx/" + url.PathEscape(name) + "-" + hex.EncodeToString(h.Sum(nil))[:16],
+		CreationInfo: SBOMCreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: " + tool},
+		},
+		Packages: []SBOMPackage{{
+			SPDXID:           "SPDXRef-App",
+			Name:             name,
+			DownloadLocation: "NOASSERTION",
+		}},
+		Relationships: []SBOMRelationship{{
+			SPDXElementID:      "SPDXRef-DOCUMENT",
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: "SPDXRef-App",
+		}},
+	}
+	for i, pkg := range pkgs {
+		id := "SPDXRef-Package-" + strconv.Itoa(i)
+		sbom.Packages = append(sbom.Packages, SBOMPackage{
+			SPDXID:           id,
+			Name:             pkg.Name,
+			VersionInfo:      pkg.Version,
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []SBOMExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  pkg.PURL,
+			}},
+		})
+		sbom.Relationships = append(sbom.Relationships, SBOMRelationship{
+			SPDXElementID:      "SPDXRef-App",
+			RelationshipType:   "DEPENDS_ON",
+			RelatedSPDXElement: id,
+		})
+	}
+	return sbom, nil
+}
+
+// goModules returns the Go modules the binary is built from, and the Go standard library.
+func goModules(binary string) ([]sbomPackage, error) {
+	info, err := buildinfo.ReadFile(binary)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read build info of %s", binary)
+	}
+
+	goVersion, _, _ := strings.Cut(strings.TrimPrefix(info.GoVersion, "go"), " ")
+	pkgs := []sbomPackage{{
+		Name:    "stdlib",
+		Version: goVersion,
+		PURL:    "pkg:golang/stdlib@" + goVersion,
+	}}
+	for _, mod := range append([]*debug.Module{&info.Main}, info.Deps...) {
+		if mod.Replace != nil {
+			mod = mod.Replace
+		}
+		if mod.Path == "" {
+			continue
+		}
+		purl := "pkg:golang/" + mod.Path
+		if mod.Version != "" && mod.Version != "(devel)" {
+			purl += "@" + mod.Version
+		}
+		pkgs = append(pkgs, sbomPackage{Name: mod.Path, Version: mod.Version, PURL: purl})
+	}
+	return pkgs, nil
+}
+
+// npmPackages returns the npm packages installed in the node modules directory,
+// as recorded by the hidden lockfile npm writes there.
+func npmPackages(nodeModules string) ([]sbomPackage, error) {
+	data, err := os.ReadFile(filepath.Join(nodeModules, ".package-lock.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "read node modules lockfile")
+	}
+
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, errors.Wrap(err, "parse node modules lockfile")
+	}
+
+	var pkgs []sbomPackage
+	for path, pkg := range lock.Packages {
+		idx := strings.LastIndex(path, "node_modules/")
+		if idx < 0 || pkg.Link || pkg.Version == "" {
+			continue
+		}
+		name := path[idx+len("node_modules/"):]
+		pkgs = append(pkgs, sbomPackage{
+			Name:    name,
+			Version: pkg.Version,
+			PURL:    "pkg:npm/" + strings.Replace(name, "@", "%40", 1) + "@" + pkg.Version,
+		})
+	}
+	return pkgs, nil
+}
+
+// Annotations identifying attestation manifests in image indexes,
+// in the format used by BuildKit.
+const (
+	attestationRefTypeAnnotation   = "vnd.docker.reference.type"
+	attestationRefDigestAnnotation = "vnd.docker.reference.digest"
+	attestationManifestType        = "attestation-manifest"
+	inTotoPredicateTypeAnnotation  = "in-toto.io/predicate-type"
+	spdxPredicateType              = "https://spdx.dev/Document"
+)
+
+// SBOMAttestation returns an attestation manifest attaching the SBOM to the image,
+// to add to the image index alongside the image.
+func SBOMAttestation(img v1.Image, sbom *SBOM) (mutate.IndexAddendum, error) {
+	digest, err := img.Digest()
+	if err != nil {
+		return mutate.IndexAddendum{}, errors.Wrap(err, "get image digest")
+	}
+
+	statement, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": spdxPredicateType,
+		"subject": []map[string]any{{
+			"name":   "_",
+			"digest": map[string]string{digest.Algorithm: digest.Hex},
+		}},
+		"predicate": sbom,
+	})
+	if err != nil {
+		return mutate.IndexAddendum{}, errors.Wrap(err, "marshal sbom statement")
+	}
+
+	att := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	att = mutate.ConfigMediaType(att, types.OCIConfigJSON)
+	att, err = mutate.Append(att, mutate.Addendum{
+		Layer:       static.NewLayer(statement, "application/vnd.in-toto+json"),
+		Annotations: map[string]string{inTotoPredicateTypeAnnotation: spdxPredicateType},
+	})
+	if err != nil {
+		return mutate.IndexAddendum{}, errors.Wrap(err, "add sbom layer")
+	}
+	cfg, err := att.ConfigFile()
+	if err != nil {
+		return mutate.IndexAddendum{}, errors.Wrap(err, "get attestation config")
+	}
+	cfg = cfg.DeepCopy()
+	cfg.OS, cfg.Architecture = "unknown", "unknown"
+	if att, err = mutate.ConfigFile(att, cfg); err != nil {
+		return mutate.IndexAddendum{}, errors.Wrap(err, "set attestation config")
+	}
+
+	return mutate.IndexAddendum{
+		Add: att,
+		Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"},
+			Annotations: map[string]string{
+				attestationRefTypeAnnotation:   attestationManifestType,
+				attestationRefDigestAnnotation: digest.String(),
+			},
+		},
+	}, nil
+}
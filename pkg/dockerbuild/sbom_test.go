@@ -0,0 +1,111 @@
+package dockerbuild
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+
+	"encr.dev/pkg/builder"
+	"encr.dev/pkg/fns"
+	"encr.dev/pkg/option"
+	"encr.dev/pkg/paths"
+)
+
+func TestGenerateSBOM(t *testing.T) {
+	c := qt.New(t)
+
+	// The test binary has Go build info, like the app's binary,
+	// though without any module dependencies.
+	exe, err := os.Executable()
+	c.Assert(err, qt.IsNil)
+	goArtifacts := paths.FS(filepath.Dir(exe))
+
+	jsArtifacts := paths.FS(c.TempDir())
+	writeFiles(c, jsArtifacts, map[string]string{
+		"node_modules/.package-lock.json": `{"packages": {
+			"": {"name": "app"},
+			"node_modules/left-pad": {"version": "1.3.0"},
+			"node_modules/@scope/pkg": {"version": "2.0.0"},
+			"node_modules/@scope/pkg/node_modules/left-pad": {"version": "1.0.0"},
+			"node_modules/local": {"link": true}
+		}}`,
+	})
+
+	sbom, err := GenerateSBOM("my-app", &builder.CompileResult{Outputs: []builder.BuildOutput{
+		&builder.GoBuildOutput{
+			ArtifactDir: goArtifacts,
+			Entrypoints: []builder.Entrypoint{
+				{Cmd: builder.CmdSpec{Command: builder.ArtifactStrings{builder.ArtifactString("${ARTIFACT_DIR}/" + filepath.Base(exe))}}},
+				{Cmd: builder.CmdSpec{Command: builder.ArtifactStrings{builder.ArtifactString("${ARTIFACT_DIR}/" + filepath.Base(exe))}}},
+			},
+		},
+		&builder.JSBuildOutput{
+			ArtifactDir: jsArtifacts,
+			NodeModules: option.Some(jsArtifacts.Join("node_modules")),
+		},
+	}}, time.Unix(1234567890, 0), "encore-v1.2.3")
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(sbom.SPDXVersion, qt.Equals, "SPDX-2.3")
+	c.Assert(sbom.CreationInfo, qt.DeepEquals, SBOMCreationInfo{
+		Created:  "2009-02-13T23:31:30Z",
+		Creators: []string{"Tool: encore-v1.2.3"},
+	})
+	purls := make(map[string]bool)
+	for _, pkg := range sbom.Packages[1:] {
+		c.Assert(pkg.ExternalRefs, qt.HasLen, 1)
+		purls[pkg.ExternalRefs[0].ReferenceLocator] = true
+	}
+	c.Assert(purls["pkg:npm/left-pad@1.3.0"], qt.IsTrue)
+	c.Assert(purls["pkg:npm/left-pad@1.0.0"], qt.IsTrue)
+	c.Assert(purls["pkg:npm/%40scope/pkg@2.0.0"], qt.IsTrue)
+	c.Assert(purls["pkg:golang/stdlib@"+strings.TrimPrefix(runtime.Version(), "go")], qt.IsTrue)
+	// Each package is listed once, even though both entrypoints run the same binary.
+	c.Assert(sbom.Packages, qt.HasLen, len(purls)+1)
+	c.Assert(sbom.Relationships, qt.HasLen, len(sbom.Packages))
+}
+
+func TestSBOMAttestation(t *testing.T) {
+	c := qt.New(t)
+	img, err := random.Image(64, 1)
+	c.Assert(err, qt.IsNil)
+	digest, err := img.Digest()
+	c.Assert(err, qt.IsNil)
+
+	sbom := &SBOM{SPDXVersion: "SPDX-2.3", Name: "my-app"}
+	add, err := SBOMAttestation(img, sbom)
+	c.Assert(err, qt.IsNil)
+	c.Assert(add.Descriptor.Platform.String(), qt.Equals, "unknown/unknown")
+	c.Assert(add.Descriptor.Annotations, qt.DeepEquals, map[string]string{
+		"vnd.docker.reference.type":   "attestation-manifest",
+		"vnd.docker.reference.digest": digest.String(),
+	})
+
+	// The attestation holds an in-toto statement with the SBOM about the image.
+	layers, err := add.Add.(v1.Image).Layers()
+	c.Assert(err, qt.IsNil)
+	c.Assert(layers, qt.HasLen, 1)
+	rc, err := layers[0].Uncompressed()
+	c.Assert(err, qt.IsNil)
+	defer fns.CloseIgnore(rc)
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+		Subject       []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+		Predicate SBOM `json:"predicate"`
+	}
+	c.Assert(json.NewDecoder(rc).Decode(&statement), qt.IsNil)
+	c.Assert(statement.PredicateType, qt.Equals, "https://spdx.dev/Document")
+	c.Assert(statement.Subject, qt.HasLen, 1)
+	c.Assert(statement.Subject[0].Digest, qt.DeepEquals, map[string]string{"sha256": digest.Hex})
+	c.Assert(statement.Predicate.Name, qt.Equals, "my-app")
+}
@@ -0,0 +1,165 @@
+package dockerbuild
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// The media type and annotation of cosign signatures.
+const (
+	simpleSigningMediaType    = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// LoadSigningKey parses a PEM-encoded private key to sign images with. Keys
+// generated by "cosign generate-key-pair" are encrypted, and decrypted with
+// password. Unencrypted PKCS #8 and EC private keys are also accepted.
+func LoadSigningKey(data, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM-encoded private key found")
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		der, decErr := decryptCosignKey(block.Bytes, password)
+		if decErr != nil {
+			return nil, decErr
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, errors.Newf("unsupported private key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "parse private key")
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Newf("unsupported private key %T", key)
+	}
+	return signer, nil
+}
+
+// decryptCosignKey decrypts a private key encrypted by cosign, with a key
+// derived from the password by scrypt and the NaCl secretbox cipher.
+func decryptCosignKey(data, password []byte) ([]byte, error) {
+	var enc struct {
+		KDF struct {
+			Name   string `json:"name"`
+			Params struct {
+				N int `json:"N"`
+				R int `json:"r"`
+				P int `json:"p"`
+			} `json:"params"`
+			Salt []byte `json:"salt"`
+		} `json:"kdf"`
+		Cipher struct {
+			Name  string `json:"name"`
+			Nonce []byte `json:"nonce"`
+		} `json:"cipher"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, errors.Wrap(err, "parse encrypted private key")
+	}
+	if enc.KDF.Name != "scrypt" || enc.Cipher.Name != "nacl/secretbox" {
+		return nil, errors.Newf("unsupported private key encryption %s with %s", enc.Cipher.Name, enc.KDF.Name)
+	}
+	if len(enc.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid private key encryption nonce")
+	}
+
+	p := enc.KDF.Params
+	derived, err := scrypt.Key(password, enc.KDF.Salt, p.N, p.R, p.P, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "derive private key encryption key")
+	}
+	var (
+		nonce [24]byte
+		key   [32]byte
+	)
+	copy(nonce[:], enc.Cipher.Nonce)
+	copy(key[:], derived)
+	der, ok := secretbox.Open(nil, enc.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.New("decrypt private key: wrong password")
+	}
+	return der, nil
+}
+
+// SignatureTag returns the tag cosign looks up the signatures
+// of the image with the given digest at, in the repository.
+func SignatureTag(repo name.Repository, digest v1.Hash) name.Tag {
+	return repo.Tag(digest.Algorithm + "-" + digest.Hex + ".sig")
+}
+
+// SignatureImage returns an image holding a cosign signature of the image
+// with the given digest in the repository, to push to its SignatureTag.
+func SignatureImage(signer crypto.Signer, repo name.Repository, digest v1.Hash) (v1.Image, error) {
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]string{"docker-reference": repo.String()},
+			"image":    map[string]string{"docker-manifest-digest": digest.String()},
+			"type":     "cosign container image signature",
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal signature payload")
+	}
+	sig, err := sign(signer, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "sign image")
+	}
+
+	img := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, types.OCIConfigJSON)
+	img, err = mutate.Append(img, mutate.Addendum{
+		Layer:       static.NewLayer(payload, simpleSigningMediaType),
+		Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "add signature layer")
+	}
+	return img, nil
+}
+
+// sign signs the payload the way cosign does for the type of the key.
+func sign(signer crypto.Signer, payload []byte) ([]byte, error) {
+	switch signer.Public().(type) {
+	case ed25519.PublicKey:
+		return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, errors.Newf("unsupported signing key %T", signer.Public())
+	}
+}
@@ -0,0 +1,121 @@
+package dockerbuild
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+
+	"encr.dev/pkg/fns"
+)
+
+// encryptCosignKey encrypts the key like "cosign generate-key-pair" does.
+func encryptCosignKey(c *qt.C, key *ecdsa.PrivateKey, password []byte) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	c.Assert(err, qt.IsNil)
+	salt := make([]byte, 32)
+	var nonce [24]byte
+	_, _ = rand.Read(salt)
+	_, _ = rand.Read(nonce[:])
+	derived, err := scrypt.Key(password, salt, 1<<10, 8, 1, 32)
+	c.Assert(err, qt.IsNil)
+	var secret [32]byte
+	copy(secret[:], derived)
+
+	data, err := json.Marshal(map[string]any{
+		"kdf": map[string]any{
+			"name":   "scrypt",
+			"params": map[string]int{"N": 1 << 10, "r": 8, "p": 1},
+			"salt":   salt,
+		},
+		"cipher":     map[string]any{"name": "nacl/secretbox", "nonce": nonce[:]},
+		"ciphertext": secretbox.Seal(nil, der, &nonce, &secret),
+	})
+	c.Assert(err, qt.IsNil)
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: data})
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	c := qt.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, qt.IsNil)
+
+	encrypted := encryptCosignKey(c, key, []byte("hunter2"))
+	signer, err := LoadSigningKey(encrypted, []byte("hunter2"))
+	c.Assert(err, qt.IsNil)
+	c.Assert(key.PublicKey.Equal(signer.Public()), qt.IsTrue)
+	_, err = LoadSigningKey(encrypted, []byte("wrong"))
+	c.Assert(err, qt.ErrorMatches, "decrypt private key: wrong password")
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	c.Assert(err, qt.IsNil)
+	signer, err = LoadSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(key.PublicKey.Equal(signer.Public()), qt.IsTrue)
+
+	_, err = LoadSigningKey([]byte("not a key"), nil)
+	c.Assert(err, qt.ErrorMatches, "no PEM-encoded private key found")
+}
+
+func TestSignatureImage(t *testing.T) {
+	c := qt.New(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	c.Assert(err, qt.IsNil)
+	img, err := random.Image(64, 1)
+	c.Assert(err, qt.IsNil)
+	digest, err := img.Digest()
+	c.Assert(err, qt.IsNil)
+	repo, err := name.NewRepository("registry.example.com/shop")
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(SignatureTag(repo, digest).String(), qt.Equals, "registry.example.com/shop:sha256-"+digest.Hex+".sig")
+
+	sigImg, err := SignatureImage(key, repo, digest)
+	c.Assert(err, qt.IsNil)
+	manifest, err := sigImg.Manifest()
+	c.Assert(err, qt.IsNil)
+	c.Assert(manifest.Layers, qt.HasLen, 1)
+	c.Assert(string(manifest.Layers[0].MediaType), qt.Equals, "application/vnd.dev.cosign.simplesigning.v1+json")
+
+	// The layer holds the payload identifying the image, signed by the annotation.
+	layers, err := sigImg.Layers()
+	c.Assert(err, qt.IsNil)
+	rc, err := layers[0].Uncompressed()
+	c.Assert(err, qt.IsNil)
+	defer fns.CloseIgnore(rc)
+	payload, err := io.ReadAll(rc)
+	c.Assert(err, qt.IsNil)
+	var simpleSigning struct {
+		Critical struct {
+			Identity struct {
+				DockerReference string `json:"docker-reference"`
+			} `json:"identity"`
+			Image struct {
+				DockerManifestDigest v1.Hash `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+	c.Assert(json.Unmarshal(payload, &simpleSigning), qt.IsNil)
+	c.Assert(simpleSigning.Critical.Identity.DockerReference, qt.Equals, "registry.example.com/shop")
+	c.Assert(simpleSigning.Critical.Image.DockerManifestDigest, qt.Equals, digest)
+	c.Assert(simpleSigning.Critical.Type, qt.Equals, "cosign container image signature")
+
+	sig, err := base64.StdEncoding.DecodeString(manifest.Layers[0].Annotations["dev.cosignproject.cosign/signature"])
+	c.Assert(err, qt.IsNil)
+	hash := sha256.Sum256(payload)
+	c.Assert(ecdsa.VerifyASN1(&key.PublicKey, hash[:], sig), qt.IsTrue)
+}
@@ -1,6 +1,8 @@
 package dockerbuild
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	pathpkg "path"
 	"path/filepath"
@@ -92,6 +94,9 @@ type BuildInfo struct {
 
 	// AppCommit describes the commit of the app.
 	AppCommit CommitInfo
+
+	// APIHash is the hash of the app's API, as computed by APIHash.
+	APIHash string
 }
 
 type CommitInfo struct {
@@ -146,6 +151,13 @@ type DescribeConfig struct {
 
 	// ProcessPerService specifies whether to run each service in a separate process.
 	ProcessPerService bool
+
+	// HostedServices and HostedGateways, if either is non-empty, restrict the image
+	// to hosting only the given services and gateways. The image then runs the
+	// single entrypoint hosting them, with a process config selecting them baked in,
+	// instead of running every entrypoint.
+	HostedServices []string
+	HostedGateways []string
 }
 
 type (
@@ -237,6 +249,16 @@ const (
 )
 
 func (b *imageSpecBuilder) Describe(cfg DescribeConfig) (*ImageSpec, error) {
+	restricted := len(cfg.HostedServices) > 0 || len(cfg.HostedGateways) > 0
+	if restricted {
+		compile, err := hostingEntrypoint(cfg.Compile, cfg.HostedServices, cfg.HostedGateways)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Compile = compile
+		cfg.ProcessPerService = false
+	}
+
 	// Allocate artifact directories for each output.
 	for _, out := range cfg.Compile.Outputs {
 		b.allocArtifactDir(out)
@@ -256,6 +278,18 @@ func (b *imageSpecBuilder) Describe(cfg DescribeConfig) (*ImageSpec, error) {
 		cmd := ep.Cmd.Expand(paths.FS(imageArtifacts.BuildArtifacts))
 		b.spec.Entrypoint = cmd.Command
 		b.spec.Env = cmd.Env
+
+		if restricted {
+			procCfg, err := json.Marshal(processConfig{
+				HostedServices:    nonNil(cfg.HostedServices),
+				HostedGateways:    nonNil(cfg.HostedGateways),
+				LocalServicePorts: map[string]int{},
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "marshal process config")
+			}
+			b.spec.Env = append(b.spec.Env, "ENCORE_PROCESS_CONFIG="+base64.StdEncoding.EncodeToString(procCfg))
+		}
 	} else {
 		config := &supervisor.Config{
 			NoopGateways: make(map[string]*noopgateway.Description),
@@ -316,7 +350,12 @@ func (b *imageSpecBuilder) Describe(cfg DescribeConfig) (*ImageSpec, error) {
 	}
 
 	// Compute bundled services and gateways.
-	{
+	if restricted {
+		b.spec.BundledServices = append(b.spec.BundledServices, cfg.HostedServices...)
+		b.spec.BundledGateways = append(b.spec.BundledGateways, cfg.HostedGateways...)
+		slices.Sort(b.spec.BundledServices)
+		slices.Sort(b.spec.BundledGateways)
+	} else {
 		for _, out := range cfg.Compile.Outputs {
 			for _, ep := range out.GetEntrypoints() {
 				b.spec.BundledServices = append(b.spec.BundledServices, ep.Services...)
@@ -411,6 +450,66 @@ func (b *imageSpecBuilder) Describe(cfg DescribeConfig) (*ImageSpec, error) {
 	return b.spec, nil
 }
 
+// processConfig is the process config read by the runtimes from ENCORE_PROCESS_CONFIG,
+// selecting the services and gateways a process hosts.
+type processConfig struct {
+	HostedServices    []string       `json:"hosted_services"`
+	HostedGateways    []string       `json:"hosted_gateways"`
+	LocalServicePorts map[string]int `json:"local_service_ports"`
+}
+
+// hostingEntrypoint returns a copy of the compile result with only the first entrypoint
+// hosting all the given services and gateways.
+func hostingEntrypoint(compile *builder.CompileResult, services, gateways []string) (*builder.CompileResult, error) {
+	hosts := func(ep builder.Entrypoint) bool {
+		for _, svc := range services {
+			if !slices.Contains(ep.Services, svc) {
+				return false
+			}
+		}
+		for _, gw := range gateways {
+			if !slices.Contains(ep.Gateways, gw) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, out := range compile.Outputs {
+		for _, ep := range out.GetEntrypoints() {
+			if !hosts(ep) {
+				continue
+			}
+
+			var single builder.BuildOutput
+			switch out := out.(type) {
+			case *builder.GoBuildOutput:
+				o := *out
+				o.Entrypoints = []builder.Entrypoint{ep}
+				single = &o
+			case *builder.JSBuildOutput:
+				o := *out
+				o.Entrypoints = []builder.Entrypoint{ep}
+				single = &o
+			default:
+				return nil, errors.Errorf("unsupported build output type %T", out)
+			}
+
+			result := *compile
+			result.Outputs = []builder.BuildOutput{single}
+			return &result, nil
+		}
+	}
+	return nil, errors.Errorf("no entrypoint hosts services %v and gateways %v", services, gateways)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
 func (b *imageSpecBuilder) addPrio(path ImagePath) {
 	if !b.seenPrioFiles[path] {
 		b.seenPrioFiles[path] = true
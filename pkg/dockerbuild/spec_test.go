@@ -1,6 +1,7 @@
 package dockerbuild
 
 import (
+	"encoding/base64"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -190,6 +191,67 @@ func TestBuild_Go_MultiProc(t *testing.T) {
 	})
 }
 
+func TestBuild_HostedService(t *testing.T) {
+	c := qt.New(t)
+	cfg := DescribeConfig{
+		Meta: &meta.Data{},
+		Compile: &builder.CompileResult{Outputs: []builder.BuildOutput{
+			&builder.GoBuildOutput{
+				ArtifactDir: "/host/artifacts",
+				Entrypoints: []builder.Entrypoint{
+					{
+						Cmd: builder.CmdSpec{
+							Command:          builder.ArtifactStrings{"${ARTIFACT_DIR}/entrypoint"},
+							PrioritizedFiles: builder.ArtifactStrings{"${ARTIFACT_DIR}/entrypoint"},
+						},
+						Services: []string{"foo"},
+					},
+					{
+						Cmd: builder.CmdSpec{
+							Command:          builder.ArtifactStrings{"${ARTIFACT_DIR}/other-entrypoint"},
+							PrioritizedFiles: builder.ArtifactStrings{"${ARTIFACT_DIR}/other-entrypoint"},
+						},
+						Services: []string{"bar", "baz"},
+						Gateways: []string{"api-gateway"},
+					},
+				},
+			},
+		}},
+		ProcessPerService: true,
+		HostedServices:    []string{"bar"},
+	}
+
+	spec, err := describe(cfg)
+	c.Assert(err, qt.IsNil)
+
+	opts := append([]cmp.Option{cmpopts.EquateEmpty()}, option.CmpOpts()...)
+	c.Assert(spec, qt.CmpEquals(opts...), &ImageSpec{
+		Entrypoint: []string{"/artifacts/0/build/other-entrypoint"},
+		Env: []string{
+			"ENCORE_PROCESS_CONFIG=" + base64.StdEncoding.EncodeToString(
+				[]byte(`{"hosted_services":["bar"],"hosted_gateways":[],"local_service_ports":{}}`)),
+		},
+		WorkingDir: "/",
+		BuildInfo:  BuildInfoSpec{InfoPath: defaultBuildInfoPath},
+		CopyData: map[ImagePath]HostPath{
+			"/artifacts/0/build": "/host/artifacts",
+		},
+		BundledServices: []string{"bar"},
+		BundleSource:    option.Option[BundleSourceSpec]{},
+		Supervisor:      option.None[SupervisorSpec](),
+		DockerBaseImage: "scratch",
+		FeatureFlags:    map[FeatureFlag]bool{},
+		StargzPrioritizedFiles: []ImagePath{
+			"/artifacts/0/build/other-entrypoint",
+		},
+	})
+
+	cfg.HostedServices = nil
+	cfg.HostedGateways = []string{"other-gateway"}
+	_, err = describe(cfg)
+	c.Assert(err, qt.ErrorMatches, `no entrypoint hosts services \[\] and gateways \[other-gateway\]`)
+}
+
 // describe is like Describe but mocks the proc id generation
 // for reproducible tests.
 func describe(cfg DescribeConfig) (*ImageSpec, error) {
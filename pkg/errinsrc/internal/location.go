@@ -16,6 +16,19 @@ const (
 	LocHelp                        // Renders in blue
 )
 
+func (t LocationType) String() string {
+	switch t {
+	case LocError:
+		return "error"
+	case LocWarning:
+		return "warning"
+	case LocHelp:
+		return "help"
+	default:
+		return "unknown"
+	}
+}
+
 type SrcLocation struct {
 	Type  LocationType `json:"type"`           // The type of this location
 	Text  string       `json:"text,omitempty"` // Optional text to render at this location
@@ -0,0 +1,88 @@
+package infraconf
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/shared/cloud"
+)
+
+// driverTags are the build tags leaving out each of the Go runtime's infrastructure
+// drivers and secrets fetchers, which bring in the SDKs of the cloud providers they talk to.
+var driverTags = []struct {
+	tag  string
+	used func(cfg *config.Runtime) bool
+}{
+	{"encore_no_aws", func(cfg *config.Runtime) bool {
+		return cfg.EnvCloud == cloud.AWS ||
+			usesPubsub(cfg, func(p *config.PubsubProvider) bool { return p.AWS != nil }) ||
+			(cfg.Metrics != nil && cfg.Metrics.CloudWatch != nil) ||
+			usesSecretSource(cfg, func(s *config.SecretSource) bool { return s.AWSSecretsManager != nil })
+	}},
+	{"encore_no_gcp", func(cfg *config.Runtime) bool {
+		return cfg.EnvCloud == cloud.GCP || cfg.EnvCloud == cloud.Encore ||
+			usesPubsub(cfg, func(p *config.PubsubProvider) bool { return p.GCP != nil }) ||
+			(cfg.Metrics != nil && (cfg.Metrics.CloudMonitoring != nil || cfg.Metrics.EncoreCloud != nil)) ||
+			(cfg.Profiling != nil && cfg.Profiling.CloudProfiler != nil)
+	}},
+	{"encore_no_azure", func(cfg *config.Runtime) bool {
+		return usesPubsub(cfg, func(p *config.PubsubProvider) bool { return p.Azure != nil })
+	}},
+	{"encore_no_encorecloud", func(cfg *config.Runtime) bool {
+		return usesPubsub(cfg, func(p *config.PubsubProvider) bool { return p.EncoreCloud != nil })
+	}},
+	{"encore_no_local", func(cfg *config.Runtime) bool {
+		return usesPubsub(cfg, func(p *config.PubsubProvider) bool { return p.NSQ != nil })
+	}},
+	{"encore_no_vault", func(cfg *config.Runtime) bool {
+		return usesSecretSource(cfg, func(s *config.SecretSource) bool { return s.Vault != nil })
+	}},
+	{"encore_no_datadog", func(cfg *config.Runtime) bool {
+		return cfg.Metrics != nil && cfg.Metrics.Datadog != nil
+	}},
+	{"encore_no_prometheus", func(cfg *config.Runtime) bool {
+		return cfg.Metrics != nil && cfg.Metrics.Prometheus != nil
+	}},
+}
+
+// DriverBuildTags returns the build tags leaving out the infrastructure drivers of
+// the Go runtime that the infra config data, in the JSON format read by the runtime,
+// doesn't use. Building the app with them shrinks its binary, as the SDKs of the
+// cloud providers the app doesn't talk to aren't linked in.
+//
+// The app can only run with infra configs using the same drivers, so the tags
+// must be derived from the config of the environment the binary is deployed to.
+func DriverBuildTags(data []byte) ([]string, error) {
+	var cfg config.Runtime
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(err, "parse infra config")
+	}
+
+	var tags []string
+	for _, d := range driverTags {
+		if !d.used(&cfg) {
+			tags = append(tags, d.tag)
+		}
+	}
+	return tags, nil
+}
+
+func usesPubsub(cfg *config.Runtime, match func(p *config.PubsubProvider) bool) bool {
+	return slices.ContainsFunc(cfg.PubsubProviders, match)
+}
+
+// usesSecretSource reports whether any of the app secrets or
+// database passwords are read from a source matching match.
+func usesSecretSource(cfg *config.Runtime, match func(s *config.SecretSource) bool) bool {
+	for _, src := range cfg.AppSecrets {
+		if src != nil && match(src) {
+			return true
+		}
+	}
+	return slices.ContainsFunc(cfg.SQLDatabases, func(db *config.SQLDatabase) bool {
+		return db.PasswordSource != nil && match(db.PasswordSource)
+	})
+}
@@ -0,0 +1,78 @@
+package infraconf
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestDriverBuildTags(t *testing.T) {
+	allTags := []string{
+		"encore_no_aws", "encore_no_gcp", "encore_no_azure", "encore_no_encorecloud",
+		"encore_no_local", "encore_no_vault", "encore_no_datadog", "encore_no_prometheus",
+	}
+	tests := []struct {
+		name string
+		conf string
+		want []string
+	}{
+		{
+			name: "no_drivers",
+			conf: `{"app_id": "shop", "env_cloud": "local"}`,
+			want: allTags,
+		},
+		{
+			name: "aws",
+			conf: `{
+				"env_cloud": "aws",
+				"pubsub_providers": [{"aws": {}}],
+				"metrics": {"aws_cloud_watch": {"Namespace": "shop"}}
+			}`,
+			want: []string{
+				"encore_no_gcp", "encore_no_azure", "encore_no_encorecloud",
+				"encore_no_local", "encore_no_vault", "encore_no_datadog", "encore_no_prometheus",
+			},
+		},
+		{
+			name: "gcp_profiler",
+			conf: `{"profiling": {"gcp_cloud_profiler": {"ProjectID": "shop"}}}`,
+			want: []string{
+				"encore_no_aws", "encore_no_azure", "encore_no_encorecloud",
+				"encore_no_local", "encore_no_vault", "encore_no_datadog", "encore_no_prometheus",
+			},
+		},
+		{
+			name: "nsq_prometheus",
+			conf: `{
+				"pubsub_providers": [{"nsq": {"host": "nsq:4150"}}],
+				"metrics": {"prometheus": {"RemoteWriteURL": "http://prometheus:9090/api/v1/write"}}
+			}`,
+			want: []string{
+				"encore_no_aws", "encore_no_gcp", "encore_no_azure",
+				"encore_no_encorecloud", "encore_no_vault", "encore_no_datadog",
+			},
+		},
+		{
+			name: "secrets_managers",
+			conf: `{
+				"app_secrets": {"StripeKey": {"vault": {"path": "secret/data/shop", "key": "stripe"}}},
+				"sql_databases": [{"password_source": {"aws_secrets_manager": {"secret_id": "shop-db"}}}]
+			}`,
+			want: []string{
+				"encore_no_gcp", "encore_no_azure", "encore_no_encorecloud",
+				"encore_no_local", "encore_no_datadog", "encore_no_prometheus",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := qt.New(t)
+			got, err := DriverBuildTags([]byte(tt.conf))
+			c.Assert(err, qt.IsNil)
+			c.Assert(got, qt.DeepEquals, tt.want)
+		})
+	}
+
+	_, err := DriverBuildTags([]byte(`{"pubsub_providers": {}}`))
+	qt.Assert(t, err, qt.ErrorMatches, "parse infra config: .*")
+}
@@ -0,0 +1,27 @@
+package infraconf_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/infraconf"
+	"encr.dev/pkg/infraconf/infraconftest"
+)
+
+// TestConvertCompose tests that the infra configs generated by "encore build compose",
+// which are valid CUE, pass the schema unchanged.
+func TestConvertCompose(t *testing.T) {
+	for _, lang := range []appfile.Lang{appfile.LangGo, appfile.LangTS} {
+		t.Run(string(lang), func(t *testing.T) {
+			c := qt.New(t)
+			want := infraconftest.ComposeInfraConfig(c, infraconftest.Meta(), lang)
+
+			got, err := infraconf.Convert("infra.cue", want, lang, nil)
+			c.Assert(err, qt.IsNil)
+			c.Assert(got, qt.JSONEquals, json.RawMessage(want))
+		})
+	}
+}
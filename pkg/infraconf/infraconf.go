@@ -0,0 +1,273 @@
+// Package infraconf loads the infra configs of self-hosted apps, which can be
+// written in JSON, YAML or CUE, and converts them to the JSON the runtime reads.
+package infraconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/ast/astutil"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/load"
+	"cuelang.org/go/cue/token"
+	"cuelang.org/go/encoding/gocode/gocodec"
+	"cuelang.org/go/encoding/yaml"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// Format is the format of an infra config.
+type Format string
+
+const (
+	JSON Format = "json"
+	YAML Format = "yaml"
+	CUE  Format = "cue"
+)
+
+// FormatOf returns the format of the infra config in filename, based on its extension.
+func FormatOf(filename string) (Format, error) {
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".cue":
+		return CUE, nil
+	default:
+		return "", errors.Newf("unsupported infra config format %q: use .json, .yaml, .yml or .cue", ext)
+	}
+}
+
+// Load reads the infra config in filename and converts it to the JSON the
+// runtime of an app written in lang reads. See Convert.
+func Load(filename string, lang appfile.Lang, tags []string) ([]byte, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "read infra config")
+	}
+	return Convert(filename, data, lang, tags)
+}
+
+// Convert converts the infra config data, read from filename, to the JSON the
+// runtime of an app written in lang reads.
+//
+// JSON configs are returned as is. YAML and CUE configs are validated against
+// the schema of the runtime configuration, which fills in the defaults of
+// settings that aren't set. Tags of the form key=value set the values of the
+// @tag attributes of CUE configs, to render a config for a given environment.
+func Convert(filename string, data []byte, lang appfile.Lang, tags []string) ([]byte, error) {
+	cfgFormat, err := FormatOf(filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) > 0 && cfgFormat != CUE {
+		return nil, errors.Newf("tags can only be used with CUE infra configs")
+	}
+
+	ctx := cuecontext.New()
+	var val cue.Value
+	switch cfgFormat {
+	case JSON:
+		return data, nil
+	case YAML:
+		f, err := yaml.Extract(filename, data)
+		if err != nil {
+			return nil, cueError("parse infra config", err)
+		}
+		val = ctx.BuildFile(f)
+	case CUE:
+		if val, err = loadCUE(ctx, filename, data, tags); err != nil {
+			return nil, err
+		}
+	}
+	if err := val.Err(); err != nil {
+		return nil, cueError("evaluate infra config", err)
+	}
+
+	if lang == appfile.LangTS {
+		return convertV2(val)
+	}
+	return convertLegacy(ctx, val)
+}
+
+// loadCUE loads the CUE infra config, injecting the tags.
+func loadCUE(ctx *cue.Context, filename string, data []byte, tags []string) (cue.Value, error) {
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return cue.Value{}, errors.Wrap(err, "resolve infra config path")
+	}
+	insts := load.Instances([]string{abs}, &load.Config{
+		Dir:     filepath.Dir(abs),
+		Tags:    tags,
+		Overlay: map[string]load.Source{abs: load.FromBytes(data)},
+	})
+	if len(insts) != 1 {
+		return cue.Value{}, errors.Newf("load infra config: expected one instance, got %d", len(insts))
+	}
+	if err := insts[0].Err; err != nil {
+		return cue.Value{}, cueError("load infra config", err)
+	}
+	return ctx.BuildInstance(insts[0]), nil
+}
+
+// convertLegacy validates the config of a Go app against the schema
+// of the legacy runtime configuration, and encodes it.
+func convertLegacy(ctx *cue.Context, val cue.Value) ([]byte, error) {
+	schema, err := legacySchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	val = schema.Unify(val)
+	if err := val.Validate(cue.Concrete(true)); err != nil {
+		return nil, cueError("invalid infra config", err)
+	}
+	data, err := val.MarshalJSON()
+	if err != nil {
+		return nil, cueError("encode infra config", err)
+	}
+	return data, nil
+}
+
+// convertV2 encodes the config of a TypeScript app, validating it
+// against the runtime configuration protobuf.
+func convertV2(val cue.Value) ([]byte, error) {
+	if err := val.Validate(cue.Concrete(true)); err != nil {
+		return nil, cueError("invalid infra config", err)
+	}
+	data, err := val.MarshalJSON()
+	if err != nil {
+		return nil, cueError("encode infra config", err)
+	}
+	if err := protojson.Unmarshal(data, &runtimev1.RuntimeConfig{}); err != nil {
+		return nil, errors.Wrap(err, "invalid infra config")
+	}
+	return data, nil
+}
+
+// legacyDefaults are the defaults of the legacy runtime configuration,
+// matching the infra config generated by "encore build compose".
+const legacyDefaults = `
+#Runtime: {
+	env_type:  *"production" | string
+	env_cloud: *"local" | string
+}
+`
+
+// schemaFilename is the file name of the schema in errors.
+const schemaFilename = "schema.cue"
+
+var (
+	legacySchemaOnce sync.Once
+	legacySchemaSrc  []byte
+	legacySchemaErr  error
+)
+
+// legacySchema returns the schema of the legacy runtime configuration.
+// It's derived from the config.Runtime struct, with all fields optional
+// like when decoding it, and closed so misspelled fields are reported.
+func legacySchema(ctx *cue.Context) (cue.Value, error) {
+	legacySchemaOnce.Do(func() {
+		var r cue.Runtime
+		typ, err := gocodec.New(&r, nil).ExtractType(&config.Runtime{})
+		if err != nil {
+			legacySchemaErr = errors.Wrap(err, "extract runtime config schema")
+			return
+		}
+		expr := astutil.Apply(typ.Syntax(), func(c astutil.Cursor) bool {
+			if f, ok := c.Node().(*ast.Field); ok {
+				f.Optional = token.Blank.Pos()
+			}
+			return true
+		}, func(c astutil.Cursor) bool {
+			switch n := c.Node().(type) {
+			case *ast.BinaryExpr:
+				// Drop the null alternative of pointers, slices and maps,
+				// which only obscures the errors of configs setting them.
+				if n.Op == token.OR && isNull(n.X) {
+					c.Replace(n.Y)
+				}
+			case *ast.Ident:
+				// Byte slices are encoded as base64 strings in JSON.
+				if n.Name == "bytes" {
+					c.Replace(ast.NewIdent("string"))
+				}
+			}
+			return true
+		})
+		src, err := format.Node(&ast.File{Decls: []ast.Decl{
+			&ast.Field{Label: ast.NewIdent("#Runtime"), Value: expr.(ast.Expr)},
+		}})
+		if err != nil {
+			legacySchemaErr = errors.Wrap(err, "format runtime config schema")
+			return
+		}
+		legacySchemaSrc = append(src, legacyDefaults...)
+	})
+	if legacySchemaErr != nil {
+		return cue.Value{}, legacySchemaErr
+	}
+
+	schema := ctx.CompileBytes(legacySchemaSrc, cue.Filename(schemaFilename))
+	if err := schema.Err(); err != nil {
+		return cue.Value{}, cueError("compile runtime config schema", err)
+	}
+	return schema.LookupPath(cue.ParsePath("#Runtime")), nil
+}
+
+// isNull reports whether expr is null, or null marked as the default.
+func isNull(expr ast.Expr) bool {
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.MUL {
+		expr = u.X
+	}
+	lit, ok := expr.(*ast.BasicLit)
+	return ok && lit.Kind == token.NULL
+}
+
+// cueError wraps a CUE error, listing each error it holds with its path
+// and its positions in the infra config.
+func cueError(msg string, err error) error {
+	var b strings.Builder
+	b.WriteString(msg + ":")
+	for _, e := range cueerrors.Errors(err) {
+		b.WriteString("\n  ")
+		path := e.Path()
+		if len(path) > 0 && path[0] == "#Runtime" {
+			path = path[1:]
+		}
+		if len(path) > 0 {
+			b.WriteString(strings.Join(path, ".") + ": ")
+		}
+		format, args := e.Msg()
+		b.WriteString(fmt.Sprintf(format, args...))
+		for _, pos := range cueerrors.Positions(e) {
+			if pos.Filename() != "" && pos.Filename() != schemaFilename {
+				b.WriteString(" (" + pos.String() + ")")
+			}
+		}
+	}
+	return errors.New(b.String())
+}
+
+// Indent returns the infra config data indented for reading.
+func Indent(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, errors.Wrap(err, "indent infra config")
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
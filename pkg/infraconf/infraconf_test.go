@@ -0,0 +1,135 @@
+package infraconf
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+func TestConvertYAML(t *testing.T) {
+	c := qt.New(t)
+	data, err := Convert("infra.yaml", []byte(`
+# The app's databases.
+app_id: shop
+sql_servers:
+  - host: db.internal:5432
+sql_databases:
+  - encore_name: orders
+    database_name: orders
+    user: shop
+    password: secret
+    max_connections: 20
+`), appfile.LangGo, nil)
+	c.Assert(err, qt.IsNil)
+
+	var conf config.Runtime
+	c.Assert(json.Unmarshal(data, &conf), qt.IsNil)
+	c.Assert(conf.AppID, qt.Equals, "shop")
+	c.Assert(conf.EnvType, qt.Equals, "production")
+	c.Assert(conf.EnvCloud, qt.Equals, "local")
+	c.Assert(conf.SQLServers, qt.HasLen, 1)
+	c.Assert(conf.SQLServers[0].Host, qt.Equals, "db.internal:5432")
+	c.Assert(conf.SQLDatabases, qt.HasLen, 1)
+	c.Assert(conf.SQLDatabases[0].MaxConnections, qt.Equals, 20)
+}
+
+func TestConvertJSON(t *testing.T) {
+	c := qt.New(t)
+	data := []byte(`{"app_id": "shop", "unknown": true}`)
+	got, err := Convert("infra.json", data, appfile.LangGo, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(got), qt.Equals, string(data))
+
+	_, err = Convert("infra.json", data, appfile.LangGo, []string{"env=prod"})
+	c.Assert(err, qt.ErrorMatches, "tags can only be used with CUE infra configs")
+}
+
+func TestConvertUnsupportedFormat(t *testing.T) {
+	c := qt.New(t)
+	_, err := Convert("infra.toml", nil, appfile.LangGo, nil)
+	c.Assert(err, qt.ErrorMatches, `unsupported infra config format ".toml".*`)
+}
+
+func TestConvertUnknownField(t *testing.T) {
+	c := qt.New(t)
+	_, err := Convert("infra.yml", []byte("sql_databases:\n  - encore_name: orders\n    usr: shop\n"), appfile.LangGo, nil)
+	c.Assert(err, qt.ErrorMatches, `(?s)invalid infra config:.*usr.*`)
+}
+
+func TestConvertInvalidType(t *testing.T) {
+	c := qt.New(t)
+	_, err := Convert("infra.yaml", []byte("sql_databases:\n  - encore_name: orders\n    max_connections: many\n"), appfile.LangGo, nil)
+	c.Assert(err, qt.ErrorMatches, `(?s)invalid infra config:.*max_connections.*`)
+}
+
+func TestConvertTS(t *testing.T) {
+	c := qt.New(t)
+	data, err := Convert("infra.yaml", []byte("environment:\n  appSlug: shop\n  envName: prod\n"), appfile.LangTS, nil)
+	c.Assert(err, qt.IsNil)
+	var conf runtimev1.RuntimeConfig
+	c.Assert(protojson.Unmarshal(data, &conf), qt.IsNil)
+	c.Assert(conf.Environment.AppSlug, qt.Equals, "shop")
+
+	_, err = Convert("infra.yaml", []byte("environment:\n  appSlugg: shop\n"), appfile.LangTS, nil)
+	c.Assert(err, qt.ErrorMatches, `(?s)invalid infra config:.*appSlugg.*`)
+}
+
+func TestLoadCUETags(t *testing.T) {
+	c := qt.New(t)
+	file := filepath.Join(c.TempDir(), "infra.cue")
+	c.Assert(os.WriteFile(file, []byte(`
+_env: "staging" | "production" @tag(env)
+_dbs: ["orders", "users"]
+
+app_id:   "shop"
+env_name: _env
+env_type: [if _env == "production" {"production"}, "development"][0]
+
+sql_servers: [{host: "db-\(_env).internal:5432"}]
+sql_databases: [for db in _dbs {
+	encore_name:     db
+	database_name:   db
+	user:            "shop"
+	max_connections: *10 | int
+}]
+`), 0644), qt.IsNil)
+
+	data, err := Load(file, appfile.LangGo, []string{"env=staging"})
+	c.Assert(err, qt.IsNil)
+	var conf config.Runtime
+	c.Assert(json.Unmarshal(data, &conf), qt.IsNil)
+	c.Assert(conf.EnvName, qt.Equals, "staging")
+	c.Assert(conf.EnvType, qt.Equals, "development")
+	c.Assert(conf.SQLServers[0].Host, qt.Equals, "db-staging.internal:5432")
+	c.Assert(conf.SQLDatabases, qt.HasLen, 2)
+	c.Assert(conf.SQLDatabases[1].EncoreName, qt.Equals, "users")
+	c.Assert(conf.SQLDatabases[1].MaxConnections, qt.Equals, 10)
+
+	data, err = Load(file, appfile.LangGo, []string{"env=production"})
+	c.Assert(err, qt.IsNil)
+	conf = config.Runtime{}
+	c.Assert(json.Unmarshal(data, &conf), qt.IsNil)
+	c.Assert(conf.EnvType, qt.Equals, "production")
+
+	// Without the tag, the environment isn't concrete.
+	_, err = Load(file, appfile.LangGo, nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+}
+
+func TestConvertBytes(t *testing.T) {
+	c := qt.New(t)
+	data, err := Convert("infra.yaml", []byte("auth_keys:\n  - kid: 1\n    data: c2VjcmV0\n"), appfile.LangGo, nil)
+	c.Assert(err, qt.IsNil)
+	var conf config.Runtime
+	c.Assert(json.Unmarshal(data, &conf), qt.IsNil)
+	c.Assert(conf.AuthKeys, qt.HasLen, 1)
+	c.Assert(string(conf.AuthKeys[0].Data), qt.Equals, "secret")
+}
@@ -0,0 +1,75 @@
+// Package infraconftest provides the app and infra config fixtures shared by
+// the tests of the packages generating and checking self-hosted infra configs.
+package infraconftest
+
+import (
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/composegen"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Meta returns the metadata of a small shop app using each kind of infrastructure:
+// a database, a cache cluster, a topic, a cron job and secrets.
+func Meta() *meta.Data {
+	return &meta.Data{
+		Svcs: []*meta.Service{
+			{Name: "orders", RelPath: "orders"},
+			{Name: "emails", RelPath: "emails", Rpcs: []*meta.RPC{{
+				Name: "SendDigest",
+				Path: &meta.Path{Segments: []*meta.PathSegment{{Value: "emails"}, {Value: "digest"}}},
+			}}},
+		},
+		Pkgs: []*meta.Package{
+			{RelPath: "orders", ServiceName: "orders", Secrets: []string{"StripeKey"}},
+			{RelPath: "emails", ServiceName: "emails", Secrets: []string{"SendGridKey", "StripeKey"}},
+		},
+		Gateways:      []*meta.Gateway{{EncoreName: "api-gateway"}},
+		SqlDatabases:  []*meta.SQLDatabase{{Name: "orders"}},
+		CacheClusters: []*meta.CacheCluster{{Name: "sessions"}},
+		PubsubTopics: []*meta.PubSubTopic{{
+			Name:              "order-placed",
+			DeliveryGuarantee: meta.PubSubTopic_AT_LEAST_ONCE,
+			OrderingKey:       "CustomerID",
+			Subscriptions: []*meta.PubSubTopic_Subscription{{
+				Name:             "send-receipt",
+				ServiceName:      "emails",
+				AckDeadline:      int64(30 * time.Second),
+				MessageRetention: int64(7 * 24 * time.Hour),
+				RetryPolicy: &meta.PubSubTopic_RetryPolicy{
+					MinBackoff: int64(10 * time.Second),
+					MaxBackoff: int64(10 * time.Minute),
+				},
+			}},
+		}},
+		CronJobs: []*meta.CronJob{{
+			Id:       "send-digest",
+			Title:    "Send the daily digest",
+			Schedule: "schedule:0 9 * * 1-5",
+			Endpoint: &meta.QualifiedName{Pkg: "emails", Name: "SendDigest"},
+		}},
+	}
+}
+
+// ComposeParams returns the parameters to generate the Docker Compose
+// configuration of the app with, as "encore build compose" does.
+func ComposeParams(md *meta.Data, lang appfile.Lang) composegen.Params {
+	return composegen.Params{
+		Meta:             md,
+		AppSlug:          "shop",
+		Lang:             lang,
+		Image:            "shop:latest",
+		PostgresPassword: "secret-password",
+		DeployedAt:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+// ComposeInfraConfig returns the infra config generated by "encore build compose".
+func ComposeInfraConfig(c *qt.C, md *meta.Data, lang appfile.Lang) []byte {
+	files, err := composegen.Generate(ComposeParams(md, lang))
+	c.Assert(err, qt.IsNil)
+	return files[composegen.InfraConfigFile]
+}
@@ -0,0 +1,19 @@
+package infraconf
+
+import (
+	"slices"
+	"sort"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// SecretNames returns the names of the secrets used by the app, sorted.
+// They're the secrets an infra config must provide values for.
+func SecretNames(md *meta.Data) []string {
+	var names []string
+	for _, pkg := range md.Pkgs {
+		names = append(names, pkg.Secrets...)
+	}
+	sort.Strings(names)
+	return slices.Compact(names)
+}
@@ -0,0 +1,271 @@
+package infravalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/infraconf"
+)
+
+// validateLegacy validates an infra config in the legacy runtime configuration format used by Go apps.
+func (v *validator) validateLegacy(data []byte) {
+	var conf config.Runtime
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&conf); err != nil {
+		// The runtime ignores unknown fields, but they're likely misspelled.
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			v.warnf(CodeUnknownField, "", "unknown field %s", field)
+			conf = config.Runtime{}
+			err = json.Unmarshal(data, &conf)
+		}
+		if err != nil {
+			v.errorf(CodeInvalidJSON, "", "%s", jsonError(data, err))
+			return
+		}
+	}
+
+	if conf.APIBaseURL != "" {
+		v.checkURL("api_base_url", conf.APIBaseURL)
+	}
+
+	// SQL databases.
+	for i, srv := range conf.SQLServers {
+		path := index("sql_servers", i)
+		v.checkHost(path+".host", srv.Host, true)
+		v.checkTLS(path, srv.ServerCACert, srv.ClientCert, srv.ClientKey)
+	}
+	var dbs []entry
+	for i, db := range conf.SQLDatabases {
+		path := index("sql_databases", i)
+		if db.EncoreName == "" {
+			v.errorf(CodeMissingSetting, path+".encore_name", "the database name is not set")
+			continue
+		}
+		dbs = append(dbs, entry{db.EncoreName, path})
+		if db.ServerID < 0 || db.ServerID >= len(conf.SQLServers) {
+			v.errorf(CodeInvalidReference, path+".server_id", "there is no SQL server %d", db.ServerID)
+		}
+		if db.DatabaseName == "" {
+			v.errorf(CodeMissingSetting, path+".database_name", "the name of the database on the server is not set")
+		}
+		if db.User == "" {
+			v.errorf(CodeMissingSetting, path+".user", "the user is not set")
+		} else if db.Password == "" && db.IAMAuth == nil && db.PasswordSource == nil {
+			v.warnf(CodeEmptyPassword, path+".password", "the password of the user %q is empty", db.User)
+		}
+		if db.PasswordSource != nil {
+			v.checkSecretSource(path+".password_source", db.PasswordSource)
+			if db.IAMAuth != nil {
+				v.errorf(CodeInvalidSetting, path+".password_source", "a password source can't be used with IAM authentication")
+			}
+		}
+		if auth := db.IAMAuth; auth != nil {
+			if (auth.AWS == nil) == (auth.GCP == nil) {
+				v.errorf(CodeInvalidSetting, path+".iam_auth", "exactly one of aws and gcp must be set")
+			} else if auth.AWS != nil && db.ServerID >= 0 && db.ServerID < len(conf.SQLServers) &&
+				strings.HasPrefix(conf.SQLServers[db.ServerID].Host, "/") {
+				v.errorf(CodeInvalidSetting, path+".iam_auth.aws", "RDS IAM authentication requires a host, not a unix socket")
+			}
+			if db.Password != "" {
+				v.warnf(CodeInvalidSetting, path+".password", "the password is ignored with IAM authentication")
+			}
+		}
+		v.checkConnections(path, db.MinConnections, db.MaxConnections)
+	}
+	v.checkResources("database", "sql_databases", CodeMissingDatabase, CodeUnknownDatabase, databaseNames(v.md), dbs)
+
+	// Caches.
+	for i, srv := range conf.RedisServers {
+		path := index("redis_servers", i)
+		v.checkHost(path+".host", srv.Host, true)
+		v.checkTLS(path, srv.ServerCACert, srv.ClientCert, srv.ClientKey)
+	}
+	var caches []entry
+	for i, db := range conf.RedisDatabases {
+		path := index("redis_databases", i)
+		if db.EncoreName == "" {
+			v.errorf(CodeMissingSetting, path+".encore_name", "the cache cluster name is not set")
+			continue
+		}
+		caches = append(caches, entry{db.EncoreName, path})
+		if db.ServerID < 0 || db.ServerID >= len(conf.RedisServers) {
+			v.errorf(CodeInvalidReference, path+".server_id", "there is no Redis server %d", db.ServerID)
+		}
+		v.checkRedisDatabase(path+".database", db.Database)
+		v.checkConnections(path, db.MinConnections, db.MaxConnections)
+	}
+	v.checkResources("cache cluster", "redis_databases", CodeMissingCache, CodeUnknownCache, cacheNames(v.md), caches)
+
+	// Pub/Sub.
+	for i, p := range conf.PubsubProviders {
+		path := index("pubsub_providers", i)
+		n := 0
+		for _, set := range []bool{p.NSQ != nil, p.GCP != nil, p.AWS != nil, p.Azure != nil, p.EncoreCloud != nil} {
+			if set {
+				n++
+			}
+		}
+		if n != 1 {
+			v.errorf(CodeInvalidSetting, path, "exactly one provider must be set, got %d", n)
+		}
+		if p.NSQ != nil {
+			v.checkHost(path+".nsq.host", p.NSQ.Host, false)
+		}
+	}
+	var topics []entry
+	for _, name := range sortedKeys(conf.PubsubTopics) {
+		topic := conf.PubsubTopics[name]
+		path := key("pubsub_topics", name)
+		topics = append(topics, entry{name, path})
+		if topic == nil {
+			v.errorf(CodeMissingSetting, path, "the topic is not configured")
+			continue
+		}
+		if topic.EncoreName != name {
+			v.errorf(CodeInvalidSetting, path+".encore_name", "the name %q doesn't match the key %q", topic.EncoreName, name)
+		}
+
+		var provider *config.PubsubProvider
+		if topic.ProviderID < 0 || topic.ProviderID >= len(conf.PubsubProviders) {
+			v.errorf(CodeInvalidReference, path+".provider_id", "there is no Pub/Sub provider %d", topic.ProviderID)
+		} else {
+			provider = conf.PubsubProviders[topic.ProviderID]
+		}
+		if topic.ProviderName == "" {
+			v.errorf(CodeMissingSetting, path+".provider_name", "the name of the topic in the provider is not set")
+		} else if provider != nil && provider.AWS != nil && !strings.HasPrefix(topic.ProviderName, "arn:") {
+			v.errorf(CodeInvalidSetting, path+".provider_name", "%q must be the ARN of an SNS topic", topic.ProviderName)
+		}
+		if provider != nil && provider.GCP != nil && (topic.GCP == nil || topic.GCP.ProjectID == "") {
+			v.errorf(CodeMissingSetting, path+".gcp.project_id", "the GCP project of the topic is not set")
+		}
+
+		var subs []entry
+		for _, subName := range sortedKeys(topic.Subscriptions) {
+			sub := topic.Subscriptions[subName]
+			subPath := key(path+".subscriptions", subName)
+			subs = append(subs, entry{subName, subPath})
+			if sub == nil {
+				v.errorf(CodeMissingSetting, subPath, "the subscription is not configured")
+				continue
+			}
+			if sub.ProviderName == "" {
+				v.errorf(CodeMissingSetting, subPath+".provider_name", "the name of the subscription in the provider is not set")
+			} else if provider != nil && provider.AWS != nil {
+				v.checkURL(subPath+".provider_name", sub.ProviderName)
+			}
+			if provider != nil && provider.GCP != nil && (sub.GCP == nil || sub.GCP.ProjectID == "") {
+				v.errorf(CodeMissingSetting, subPath+".gcp.project_id", "the GCP project of the subscription is not set")
+			}
+		}
+		v.checkResources("subscription", path+".subscriptions", CodeMissingSubscription, CodeUnknownSubscription,
+			subscriptionNames(v.md, name), subs)
+	}
+	v.checkResources("topic", "pubsub_topics", CodeMissingTopic, CodeUnknownTopic, topicNames(v.md), topics)
+
+	// Gateways and services.
+	var gateways []entry
+	for i, gw := range conf.Gateways {
+		gateways = append(gateways, entry{gw.Name, index("gateways", i)})
+	}
+	v.checkResources("gateway", "gateways", CodeMissingGateway, CodeUnknownGateway, gatewayNames(v.md), gateways)
+
+	var hosted, discovered []entry
+	if len(conf.HostedServices) == 0 && len(conf.Gateways) == 0 {
+		// All services are hosted.
+		for _, svc := range v.md.Svcs {
+			hosted = append(hosted, entry{svc.Name, "hosted_services"})
+		}
+	}
+	for i, name := range conf.HostedServices {
+		hosted = append(hosted, entry{name, index("hosted_services", i)})
+	}
+	for _, name := range sortedKeys(conf.ServiceDiscovery) {
+		path := key("service_discovery", name)
+		discovered = append(discovered, entry{name, path})
+		v.checkURL(path+".url", conf.ServiceDiscovery[name].URL)
+	}
+	v.checkServices(hosted, discovered)
+
+	// Secrets, which aren't required to be configured here
+	// as they can also be provided with ENCORE_APP_SECRETS.
+	for _, name := range sortedKeys(conf.AppSecrets) {
+		path := key("app_secrets", name)
+		if !slices.Contains(infraconf.SecretNames(v.md), name) {
+			v.warnf(CodeInvalidReference, path, "the app has no secret %q", name)
+		}
+		if src := conf.AppSecrets[name]; src == nil {
+			v.errorf(CodeMissingSetting, path, "the secret source is not set")
+		} else {
+			v.checkSecretSource(path, src)
+		}
+	}
+}
+
+// checkSecretSource checks exactly one source is set, and that it's complete.
+func (v *validator) checkSecretSource(path string, src *config.SecretSource) {
+	set := 0
+	for _, ok := range []bool{src.File != "", src.Env != "", src.Vault != nil, src.AWSSecretsManager != nil} {
+		if ok {
+			set++
+		}
+	}
+	switch {
+	case set > 1:
+		v.errorf(CodeInvalidSetting, path, "only one of file, env, vault and aws_secrets_manager can be set")
+	case set == 0:
+		v.errorf(CodeMissingSetting, path, "none of file, env, vault and aws_secrets_manager is set")
+	case src.File != "" && !filepath.IsAbs(src.File):
+		v.warnf(CodeInvalidSetting, path+".file", "%q is relative to the app's working directory", src.File)
+	case src.Vault != nil:
+		if src.Vault.Path == "" {
+			v.errorf(CodeMissingSetting, path+".vault.path", "the Vault secret path is not set")
+		}
+		if src.Vault.Key == "" {
+			v.errorf(CodeMissingSetting, path+".vault.key", "the key within the Vault secret is not set")
+		}
+	case src.AWSSecretsManager != nil && src.AWSSecretsManager.SecretID == "":
+		v.errorf(CodeMissingSetting, path+".aws_secrets_manager.secret_id", "the secret ID is not set")
+	}
+}
+
+// checkTLS checks the PEM-encoded certificates and key of a server.
+func (v *validator) checkTLS(path, serverCACert, clientCert, clientKey string) {
+	if serverCACert != "" {
+		v.checkCert(path+".server_ca_cert", serverCACert)
+	}
+	switch {
+	case clientCert != "" && clientKey == "":
+		v.errorf(CodeMissingSetting, path+".client_key", "the client certificate has no key")
+	case clientCert == "" && clientKey != "":
+		v.errorf(CodeMissingSetting, path+".client_cert", "the client key has no certificate")
+	case clientCert != "":
+		v.checkCert(path+".client_cert", clientCert)
+		v.checkKey(path+".client_key", clientKey)
+	}
+}
+
+// checkRedisDatabase checks the index of a Redis database.
+func (v *validator) checkRedisDatabase(path string, idx int) {
+	switch {
+	case idx < 0:
+		v.errorf(CodeInvalidSetting, path, "the database index is negative")
+	case idx > 15:
+		v.warnf(CodeInvalidSetting, path, "the database index %d exceeds the 16 databases of Redis's default configuration", idx)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
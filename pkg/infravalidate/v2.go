@@ -0,0 +1,254 @@
+package infravalidate
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"encr.dev/pkg/infraconf"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// validateV2 validates an infra config in the JSON encoding of the runtime configuration
+// protobuf, used by TypeScript apps.
+func (v *validator) validateV2(data []byte) {
+	var conf runtimev1.RuntimeConfig
+	if err := protojson.Unmarshal(data, &conf); err != nil {
+		// Unknown fields can't be converted to the runtime's binary configuration,
+		// but report them as such and carry on validating the rest of the config.
+		msg := err.Error()
+		if _, field, ok := strings.Cut(msg, "unknown field "); ok {
+			v.errorf(CodeUnknownField, "", "unknown field %s", field)
+			conf.Reset()
+			err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, &conf)
+		}
+		if err != nil {
+			v.errorf(CodeInvalidJSON, "", "%v", err)
+			return
+		}
+	}
+
+	res := conf.GetInfra().GetResources()
+	creds := conf.GetInfra().GetCredentials()
+
+	// Credentials.
+	var certRids, sqlRoleRids, redisRoleRids []string
+	for i, cert := range creds.GetClientCerts() {
+		path := index("infra.credentials.clientCerts", i)
+		certRids = append(certRids, cert.Rid)
+		v.checkCert(path+".cert", cert.Cert)
+		v.checkSecretData(path+".key", cert.Key, true)
+	}
+	checkCertRef := func(path string, rid *string) {
+		if rid != nil && !slices.Contains(certRids, *rid) {
+			v.errorf(CodeInvalidReference, path, "there is no client certificate %q", *rid)
+		}
+	}
+	for i, role := range creds.GetSqlRoles() {
+		path := index("infra.credentials.sqlRoles", i)
+		sqlRoleRids = append(sqlRoleRids, role.Rid)
+		if role.Username == "" {
+			v.errorf(CodeMissingSetting, path+".username", "the username is not set")
+		}
+		if role.Password == nil {
+			v.warnf(CodeEmptyPassword, path+".password", "the role %q has no password", role.Rid)
+		} else {
+			v.checkSecretData(path+".password", role.Password, false)
+		}
+		checkCertRef(path+".clientCertRid", role.ClientCertRid)
+	}
+	for i, role := range creds.GetRedisRoles() {
+		path := index("infra.credentials.redisRoles", i)
+		redisRoleRids = append(redisRoleRids, role.Rid)
+		checkCertRef(path+".clientCertRid", role.ClientCertRid)
+	}
+
+	// Gateways.
+	var gateways []entry
+	var gatewayRids []string
+	for i, gw := range res.GetGateways() {
+		path := index("infra.resources.gateways", i)
+		gateways = append(gateways, entry{gw.EncoreName, path})
+		gatewayRids = append(gatewayRids, gw.Rid)
+		v.checkURL(path+".baseUrl", gw.BaseUrl)
+	}
+	v.checkResources("gateway", "infra.resources.gateways", CodeMissingGateway, CodeUnknownGateway, gatewayNames(v.md), gateways)
+
+	// SQL databases.
+	var dbs []entry
+	for i, cluster := range res.GetSqlClusters() {
+		path := index("infra.resources.sqlClusters", i)
+		hasPrimary := false
+		for j, srv := range cluster.Servers {
+			srvPath := index(path+".servers", j)
+			v.checkHost(srvPath+".host", srv.Host, true)
+			if srv.TlsConfig.GetServerCaCert() != "" {
+				v.checkCert(srvPath+".tlsConfig.serverCaCert", srv.TlsConfig.GetServerCaCert())
+			}
+			hasPrimary = hasPrimary || srv.Kind == runtimev1.ServerKind_SERVER_KIND_PRIMARY
+		}
+		if !hasPrimary {
+			v.errorf(CodeMissingSetting, path+".servers", "the cluster has no primary server")
+		}
+		for j, db := range cluster.Databases {
+			dbPath := index(path+".databases", j)
+			dbs = append(dbs, entry{db.EncoreName, dbPath})
+			if db.CloudName == "" {
+				v.errorf(CodeMissingSetting, dbPath+".cloudName", "the name of the database on the server is not set")
+			}
+			if len(db.ConnPools) == 0 {
+				v.errorf(CodeMissingSetting, dbPath+".connPools", "the database has no connection pools")
+			}
+			for k, pool := range db.ConnPools {
+				poolPath := index(dbPath+".connPools", k)
+				if !slices.Contains(sqlRoleRids, pool.RoleRid) {
+					v.errorf(CodeInvalidReference, poolPath+".roleRid", "there is no SQL role %q", pool.RoleRid)
+				}
+				v.checkConnections(poolPath, int(pool.MinConnections), int(pool.MaxConnections))
+			}
+		}
+	}
+	v.checkResources("database", "infra.resources.sqlClusters", CodeMissingDatabase, CodeUnknownDatabase, databaseNames(v.md), dbs)
+
+	// Caches.
+	var caches []entry
+	for i, cluster := range res.GetRedisClusters() {
+		path := index("infra.resources.redisClusters", i)
+		hasPrimary := false
+		for j, srv := range cluster.Servers {
+			srvPath := index(path+".servers", j)
+			v.checkHost(srvPath+".host", srv.Host, true)
+			if srv.TlsConfig.GetServerCaCert() != "" {
+				v.checkCert(srvPath+".tlsConfig.serverCaCert", srv.TlsConfig.GetServerCaCert())
+			}
+			hasPrimary = hasPrimary || srv.Kind == runtimev1.ServerKind_SERVER_KIND_PRIMARY
+		}
+		if !hasPrimary {
+			v.errorf(CodeMissingSetting, path+".servers", "the cluster has no primary server")
+		}
+		for j, db := range cluster.Databases {
+			dbPath := index(path+".databases", j)
+			caches = append(caches, entry{db.EncoreName, dbPath})
+			v.checkRedisDatabase(dbPath+".databaseIdx", int(db.DatabaseIdx))
+			if len(db.ConnPools) == 0 {
+				v.errorf(CodeMissingSetting, dbPath+".connPools", "the cache cluster has no connection pools")
+			}
+			for k, pool := range db.ConnPools {
+				poolPath := index(dbPath+".connPools", k)
+				if !slices.Contains(redisRoleRids, pool.RoleRid) {
+					v.errorf(CodeInvalidReference, poolPath+".roleRid", "there is no Redis role %q", pool.RoleRid)
+				}
+				v.checkConnections(poolPath, int(pool.MinConnections), int(pool.MaxConnections))
+			}
+		}
+	}
+	v.checkResources("cache cluster", "infra.resources.redisClusters", CodeMissingCache, CodeUnknownCache, cacheNames(v.md), caches)
+
+	// Pub/Sub.
+	var topics []entry
+	subs := make(map[string][]entry)
+	for i, cluster := range res.GetPubsubClusters() {
+		path := index("infra.resources.pubsubClusters", i)
+		switch p := cluster.Provider.(type) {
+		case nil:
+			v.errorf(CodeMissingSetting, path, "the provider is not set")
+		case *runtimev1.PubSubCluster_Nsq:
+			if len(p.Nsq.Hosts) == 0 {
+				v.errorf(CodeMissingSetting, path+".nsq.hosts", "no NSQ hosts are set")
+			}
+			for j, host := range p.Nsq.Hosts {
+				v.checkHost(index(path+".nsq.hosts", j), host, false)
+			}
+		}
+		_, isAWS := cluster.Provider.(*runtimev1.PubSubCluster_Aws)
+		_, isGCP := cluster.Provider.(*runtimev1.PubSubCluster_Gcp)
+
+		var clusterTopics []string
+		for j, topic := range cluster.Topics {
+			topicPath := index(path+".topics", j)
+			topics = append(topics, entry{topic.EncoreName, topicPath})
+			clusterTopics = append(clusterTopics, topic.EncoreName)
+			switch {
+			case topic.CloudName == "":
+				v.errorf(CodeMissingSetting, topicPath+".cloudName", "the name of the topic in the provider is not set")
+			case isAWS && !strings.HasPrefix(topic.CloudName, "arn:"):
+				v.errorf(CodeInvalidSetting, topicPath+".cloudName", "%q must be the ARN of an SNS topic", topic.CloudName)
+			}
+			if isGCP && topic.GetGcpConfig().GetProjectId() == "" {
+				v.errorf(CodeMissingSetting, topicPath+".gcpConfig.projectId", "the GCP project of the topic is not set")
+			}
+		}
+		for j, sub := range cluster.Subscriptions {
+			subPath := index(path+".subscriptions", j)
+			subs[sub.TopicEncoreName] = append(subs[sub.TopicEncoreName], entry{sub.SubscriptionEncoreName, subPath})
+			if !slices.Contains(clusterTopics, sub.TopicEncoreName) {
+				v.errorf(CodeInvalidReference, subPath+".topicEncoreName", "the cluster has no topic %q", sub.TopicEncoreName)
+			}
+			switch {
+			case sub.SubscriptionCloudName == "":
+				v.errorf(CodeMissingSetting, subPath+".subscriptionCloudName", "the name of the subscription in the provider is not set")
+			case isAWS:
+				v.checkURL(subPath+".subscriptionCloudName", sub.SubscriptionCloudName)
+			}
+			if isGCP && sub.GetGcpConfig().GetProjectId() == "" {
+				v.errorf(CodeMissingSetting, subPath+".gcpConfig.projectId", "the GCP project of the subscription is not set")
+			}
+		}
+	}
+	v.checkResources("topic", "infra.resources.pubsubClusters", CodeMissingTopic, CodeUnknownTopic, topicNames(v.md), topics)
+	for _, t := range topics {
+		v.checkResources("subscription", t.path, CodeMissingSubscription, CodeUnknownSubscription,
+			subscriptionNames(v.md, t.name), subs[t.name])
+	}
+
+	// Secrets.
+	var secrets []string
+	for i, secret := range res.GetAppSecrets() {
+		path := index("infra.resources.appSecrets", i)
+		secrets = append(secrets, secret.EncoreName)
+		v.checkSecretData(path+".data", secret.Data, true)
+	}
+	for _, name := range infraconf.SecretNames(v.md) {
+		if !slices.Contains(secrets, name) {
+			v.errorf(CodeMissingSecret, "infra.resources.appSecrets", "the secret %q used by the app is missing", name)
+		}
+	}
+
+	// Deployment.
+	d := conf.Deployment
+	if d == nil {
+		v.errorf(CodeMissingSetting, "deployment", "the deployment is not set")
+		return
+	}
+	for i, rid := range d.HostedGateways {
+		if !slices.Contains(gatewayRids, rid) {
+			v.errorf(CodeInvalidReference, index("deployment.hostedGateways", i), "there is no gateway %q", rid)
+		}
+	}
+	var hosted, discovered []entry
+	for i, svc := range d.HostedServices {
+		hosted = append(hosted, entry{svc.Name, index("deployment.hostedServices", i)})
+	}
+	locs := d.GetServiceDiscovery().GetServices()
+	for _, name := range sortedKeys(locs) {
+		path := key("deployment.serviceDiscovery.services", name)
+		discovered = append(discovered, entry{name, path})
+		v.checkURL(path+".baseUrl", locs[name].GetBaseUrl())
+	}
+	v.checkServices(hosted, discovered)
+}
+
+// checkSecretData checks secret data has a source.
+func (v *validator) checkSecretData(path string, data *runtimev1.SecretData, required bool) {
+	switch src := data.GetSource().(type) {
+	case nil:
+		if required {
+			v.errorf(CodeMissingSetting, path, "the secret data has no source")
+		}
+	case *runtimev1.SecretData_Env:
+		if src.Env == "" {
+			v.errorf(CodeMissingSetting, path+".env", "the environment variable is not set")
+		}
+	}
+}
@@ -0,0 +1,357 @@
+// Package infravalidate validates the infra configs of self-hosted Encore apps
+// against the resources the apps declare, before they're deployed.
+package infravalidate
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"encr.dev/pkg/appfile"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+const (
+	// Error diagnostics describe problems that break the app at runtime.
+	Error Severity = "error"
+	// Warning diagnostics describe likely mistakes that don't break the app.
+	Warning Severity = "warning"
+)
+
+// The codes of the diagnostics.
+const (
+	CodeInvalidJSON         = "invalid_json"
+	CodeWrongFormat         = "wrong_format"
+	CodeUnknownField        = "unknown_field"
+	CodeMissingDatabase     = "missing_database"
+	CodeMissingCache        = "missing_cache"
+	CodeMissingTopic        = "missing_topic"
+	CodeMissingSubscription = "missing_subscription"
+	CodeMissingGateway      = "missing_gateway"
+	CodeMissingSecret       = "missing_secret"
+	CodeUnknownDatabase     = "unknown_database"
+	CodeUnknownCache        = "unknown_cache"
+	CodeUnknownTopic        = "unknown_topic"
+	CodeUnknownSubscription = "unknown_subscription"
+	CodeUnknownGateway      = "unknown_gateway"
+	CodeUnknownService      = "unknown_service"
+	CodeUnreachableService  = "unreachable_service"
+	CodeInvalidReference    = "invalid_reference"
+	CodeMissingSetting      = "missing_setting"
+	CodeInvalidSetting      = "invalid_setting"
+	CodeInvalidHost         = "invalid_host"
+	CodeInvalidURL          = "invalid_url"
+	CodeInvalidCertificate  = "invalid_certificate"
+	CodeEmptyPassword       = "empty_password"
+)
+
+// Diagnostic is a problem found in an infra config.
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+
+	// Path is the path of the offending value within the infra config,
+	// like "sql_databases[0].user". It's empty for problems with the config as a whole.
+	Path string `json:"path,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s[%s]: %s", d.Severity, d.Code, d.Message)
+	}
+	return fmt.Sprintf("%s[%s]: %s: %s", d.Severity, d.Code, d.Path, d.Message)
+}
+
+// HasErrors reports whether any of the diagnostics is an error.
+func HasErrors(diags []Diagnostic) bool {
+	return slices.ContainsFunc(diags, func(d Diagnostic) bool { return d.Severity == Error })
+}
+
+// Validate validates the infra config data against the resources declared by the app.
+//
+// The infra config is expected in the format used by the app's language,
+// as generated by "encore build compose": the legacy runtime configuration
+// for Go apps and the JSON encoding of the runtime configuration protobuf
+// for TypeScript apps.
+func Validate(md *meta.Data, lang appfile.Lang, data []byte) []Diagnostic {
+	v := &validator{md: md}
+	if v.checkFormat(lang, data) {
+		if lang == appfile.LangTS {
+			v.validateV2(data)
+		} else {
+			v.validateLegacy(data)
+		}
+	}
+	return v.diags
+}
+
+// validator collects the diagnostics of an infra config.
+type validator struct {
+	md    *meta.Data
+	diags []Diagnostic
+}
+
+func (v *validator) errorf(code, path, format string, args ...any) {
+	v.diags = append(v.diags, Diagnostic{Severity: Error, Code: code, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) warnf(code, path, format string, args ...any) {
+	v.diags = append(v.diags, Diagnostic{Severity: Warning, Code: code, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// legacyKeys and v2Keys are top-level keys identifying the formats of infra configs.
+var (
+	legacyKeys = []string{"app_id", "env_name", "sql_databases", "pubsub_topics", "redis_databases", "hosted_services"}
+	v2Keys     = []string{"environment", "infra", "deployment", "encorePlatform"}
+)
+
+// checkFormat checks the data is a JSON object in the format used by the app's language,
+// and reports whether to continue validating it.
+func (v *validator) checkFormat(lang appfile.Lang, data []byte) bool {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		v.errorf(CodeInvalidJSON, "", "%s", jsonError(data, err))
+		return false
+	}
+	has := func(keys []string) bool {
+		return slices.ContainsFunc(keys, func(k string) bool { _, ok := obj[k]; return ok })
+	}
+	switch {
+	case lang == appfile.LangTS && has(legacyKeys) && !has(v2Keys):
+		v.errorf(CodeWrongFormat, "", "the infra config is in the format of Go apps, but the app is a TypeScript app")
+		return false
+	case lang != appfile.LangTS && has(v2Keys) && !has(legacyKeys):
+		v.errorf(CodeWrongFormat, "", "the infra config is in the format of TypeScript apps, but the app is a Go app")
+		return false
+	}
+	return true
+}
+
+// jsonError describes a JSON decoding error, with its line and column.
+func jsonError(data []byte, err error) string {
+	// The offsets are those of the byte following the offending one.
+	var offset int64 = -1
+	switch err := err.(type) {
+	case *json.SyntaxError:
+		offset = err.Offset
+	case *json.UnmarshalTypeError:
+		offset = err.Offset
+	}
+	if offset < 1 {
+		return err.Error()
+	}
+	before := data[:min(int(offset)-1, len(data))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Sprintf("line %d, column %d: %v", line, col, err)
+}
+
+// entry is a resource of the infra config, at the given path.
+type entry struct {
+	name string
+	path string
+}
+
+// checkResources checks the resources of a kind in the infra config match the app's.
+// Resources the app declares that are missing from the config are errors,
+// while resources the app doesn't declare are warnings.
+func (v *validator) checkResources(kind, parentPath, missingCode, unknownCode string, declared []string, configured []entry) {
+	seen := make(map[string]bool)
+	for _, e := range configured {
+		switch {
+		case seen[e.name]:
+			v.errorf(CodeInvalidSetting, e.path, "the %s %q is configured more than once", kind, e.name)
+		case !slices.Contains(declared, e.name):
+			v.warnf(unknownCode, e.path, "the app has no %s %q", kind, e.name)
+		}
+		seen[e.name] = true
+	}
+	for _, name := range declared {
+		if !seen[name] {
+			v.errorf(missingCode, parentPath, "the %s %q used by the app is missing", kind, name)
+		}
+	}
+}
+
+// checkServices checks the hosted and discovered services of the config are services of the app,
+// and that all the app's services can be reached.
+func (v *validator) checkServices(hosted, discovered []entry) {
+	reachable := make(map[string]bool)
+	for _, e := range append(slices.Clip(hosted), discovered...) {
+		if !slices.ContainsFunc(v.md.Svcs, func(s *meta.Service) bool { return s.Name == e.name }) {
+			v.errorf(CodeUnknownService, e.path, "the app has no service %q", e.name)
+		}
+		reachable[e.name] = true
+	}
+	for _, svc := range v.md.Svcs {
+		if !reachable[svc.Name] {
+			v.warnf(CodeUnreachableService, "", "the service %q is neither hosted nor listed in the service discovery", svc.Name)
+		}
+	}
+}
+
+// topicNames returns the names of the app's topics, sorted.
+func topicNames(md *meta.Data) []string {
+	var names []string
+	for _, t := range md.PubsubTopics {
+		names = append(names, t.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// subscriptionNames returns the names of the subscriptions of the app's topic, sorted.
+func subscriptionNames(md *meta.Data, topic string) []string {
+	var names []string
+	for _, t := range md.PubsubTopics {
+		if t.Name == topic {
+			for _, s := range t.Subscriptions {
+				names = append(names, s.Name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func databaseNames(md *meta.Data) []string {
+	var names []string
+	for _, db := range md.SqlDatabases {
+		names = append(names, db.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func cacheNames(md *meta.Data) []string {
+	var names []string
+	for _, cl := range md.CacheClusters {
+		names = append(names, cl.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func gatewayNames(md *meta.Data) []string {
+	var names []string
+	for _, gw := range md.Gateways {
+		names = append(names, gw.EncoreName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkHost checks a host is in the format "hostname", "hostname:port",
+// or "/path/to/unix.socket" if sockets are allowed.
+func (v *validator) checkHost(path, host string, allowSocket bool) {
+	switch {
+	case host == "":
+		v.errorf(CodeMissingSetting, path, "the host is not set")
+		return
+	case strings.Contains(host, "://"):
+		v.errorf(CodeInvalidHost, path, "%q must be a host, not a URL", host)
+		return
+	case strings.HasPrefix(host, "/"):
+		if !allowSocket {
+			v.errorf(CodeInvalidHost, path, "%q must be a host, not a socket path", host)
+		}
+		return
+	}
+
+	hostname := host
+	if strings.Contains(host, ":") {
+		h, port, err := net.SplitHostPort(host)
+		if err != nil {
+			v.errorf(CodeInvalidHost, path, "%q must be in the format hostname:port", host)
+			return
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			v.errorf(CodeInvalidHost, path, "%q has an invalid port", host)
+			return
+		}
+		hostname = h
+	}
+	if hostname == "" || strings.ContainsAny(hostname, "/ \t@") {
+		v.errorf(CodeInvalidHost, path, "%q has an invalid hostname", host)
+	}
+}
+
+// checkURL checks rawURL is an absolute HTTP(S) URL.
+func (v *validator) checkURL(path, rawURL string) {
+	if rawURL == "" {
+		v.errorf(CodeMissingSetting, path, "the URL is not set")
+		return
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.errorf(CodeInvalidURL, path, "%q must be an absolute http or https URL", rawURL)
+	}
+}
+
+// checkCert checks data holds PEM-encoded certificates.
+func (v *validator) checkCert(path, data string) {
+	rest := []byte(data)
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		found = true
+		if block.Type != "CERTIFICATE" {
+			v.errorf(CodeInvalidCertificate, path, "unexpected PEM block %q, expected CERTIFICATE", block.Type)
+			return
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			v.errorf(CodeInvalidCertificate, path, "invalid certificate: %v", err)
+			return
+		}
+	}
+	if !found {
+		v.errorf(CodeInvalidCertificate, path, "no PEM-encoded certificate found")
+	}
+}
+
+// checkKey checks data holds a PEM-encoded private key.
+func (v *validator) checkKey(path, data string) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || !strings.Contains(block.Type, "PRIVATE KEY") {
+		v.errorf(CodeInvalidCertificate, path, "no PEM-encoded private key found")
+	}
+}
+
+// checkConnections checks the connection limits of a database.
+func (v *validator) checkConnections(path string, minConns, maxConns int) {
+	switch {
+	case minConns < 0:
+		v.errorf(CodeInvalidSetting, path, "the minimum number of connections is negative")
+	case maxConns < 0:
+		v.errorf(CodeInvalidSetting, path, "the maximum number of connections is negative")
+	case maxConns > 0 && minConns > maxConns:
+		v.errorf(CodeInvalidSetting, path, "the minimum number of connections (%d) exceeds the maximum (%d)", minConns, maxConns)
+	}
+}
+
+// index formats the path of an element of a list.
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// key formats the path of an element of a map.
+func key(path, k string) string {
+	return fmt.Sprintf("%s[%q]", path, k)
+}
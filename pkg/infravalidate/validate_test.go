@@ -0,0 +1,189 @@
+package infravalidate
+
+import (
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/infraconf/infraconftest"
+)
+
+// modify decodes the JSON data, applies fn to it and encodes it again.
+func modify(c *qt.C, data []byte, fn func(conf map[string]any)) []byte {
+	var conf map[string]any
+	c.Assert(json.Unmarshal(data, &conf), qt.IsNil)
+	fn(conf)
+	data, err := json.Marshal(conf)
+	c.Assert(err, qt.IsNil)
+	return data
+}
+
+// codes returns the code and path of each diagnostic.
+func codes(diags []Diagnostic) []string {
+	var s []string
+	for _, d := range diags {
+		s = append(s, string(d.Severity)+" "+d.Code+" "+d.Path)
+	}
+	return s
+}
+
+func TestValidateGenerated(t *testing.T) {
+	for _, lang := range []appfile.Lang{appfile.LangGo, appfile.LangTS} {
+		t.Run(string(lang), func(t *testing.T) {
+			c := qt.New(t)
+			md := infraconftest.Meta()
+			diags := Validate(md, lang, infraconftest.ComposeInfraConfig(c, md, lang))
+			c.Assert(diags, qt.HasLen, 0)
+		})
+	}
+}
+
+func TestValidateLegacy(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	data := modify(c, infraconftest.ComposeInfraConfig(c, md, appfile.LangGo), func(conf map[string]any) {
+		conf["sql_databases"] = []any{map[string]any{
+			"server_id":     3,
+			"encore_name":   "users",
+			"database_name": "users",
+			"user":          "encore",
+		}}
+		conf["sql_servers"] = []any{map[string]any{"host": "postgres://db:5432"}}
+		conf["redis_servers"] = []any{map[string]any{"host": "redis:99999", "server_ca_cert": "not a cert"}}
+		topic := conf["pubsub_topics"].(map[string]any)["order-placed"].(map[string]any)
+		topic["subscriptions"] = map[string]any{}
+		conf["service_discovery"] = map[string]any{"billing": map[string]any{"url": "billing:8080"}}
+		conf["hosted_services"] = []any{"orders"}
+		conf["sql_databse"] = true
+	})
+
+	diags := Validate(md, appfile.LangGo, data)
+	c.Assert(codes(diags), qt.DeepEquals, []string{
+		"warning unknown_field ",
+		"error invalid_host sql_servers[0].host",
+		"error invalid_reference sql_databases[0].server_id",
+		"warning empty_password sql_databases[0].password",
+		"warning unknown_database sql_databases[0]",
+		"error missing_database sql_databases",
+		"error invalid_host redis_servers[0].host",
+		"error invalid_certificate redis_servers[0].server_ca_cert",
+		"error missing_subscription pubsub_topics[\"order-placed\"].subscriptions",
+		"error invalid_url service_discovery[\"billing\"].url",
+		"error unknown_service service_discovery[\"billing\"]",
+		"warning unreachable_service ",
+	})
+	c.Assert(diags[0].Message, qt.Equals, `unknown field "sql_databse"`)
+	c.Assert(diags[5].String(), qt.Equals, `error[missing_database]: sql_databases: the database "orders" used by the app is missing`)
+	c.Assert(HasErrors(diags), qt.IsTrue)
+}
+
+func TestValidateIAMAuth(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	data := modify(c, infraconftest.ComposeInfraConfig(c, md, appfile.LangGo), func(conf map[string]any) {
+		db := conf["sql_databases"].([]any)[0].(map[string]any)
+		db["iam_auth"] = map[string]any{"gcp": map[string]any{}}
+		delete(db, "password")
+	})
+	c.Assert(Validate(md, appfile.LangGo, data), qt.HasLen, 0)
+
+	data = modify(c, data, func(conf map[string]any) {
+		conf["sql_servers"].([]any)[0].(map[string]any)["host"] = "/cloudsql/shop"
+		db := conf["sql_databases"].([]any)[0].(map[string]any)
+		db["iam_auth"] = map[string]any{"aws": map[string]any{}}
+		db["password"] = "secret"
+	})
+	c.Assert(codes(Validate(md, appfile.LangGo, data)), qt.DeepEquals, []string{
+		"error invalid_setting sql_databases[0].iam_auth.aws",
+		"warning invalid_setting sql_databases[0].password",
+	})
+}
+
+func TestValidateSecretSources(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	data := modify(c, infraconftest.ComposeInfraConfig(c, md, appfile.LangGo), func(conf map[string]any) {
+		db := conf["sql_databases"].([]any)[0].(map[string]any)
+		db["password_source"] = map[string]any{"file": "/var/run/secrets/db/password"}
+		delete(db, "password")
+		conf["app_secrets"] = map[string]any{
+			"StripeKey":   map[string]any{"env": "STRIPE_KEY"},
+			"SendGridKey": map[string]any{"vault": map[string]any{"path": "secret/data/shop", "key": "sendgrid"}},
+		}
+	})
+	c.Assert(Validate(md, appfile.LangGo, data), qt.HasLen, 0)
+
+	data = modify(c, data, func(conf map[string]any) {
+		db := conf["sql_databases"].([]any)[0].(map[string]any)
+		db["password_source"] = map[string]any{"file": "secrets/password", "env": "DB_PASSWORD"}
+		conf["app_secrets"] = map[string]any{
+			"StripeKey":   map[string]any{"file": "stripe-key"},
+			"SendgridKey": map[string]any{},
+			"SendGridKey": map[string]any{"vault": map[string]any{"path": "secret/data/shop"}},
+		}
+		conf["sql_databases"].([]any)[0].(map[string]any)["password_source"] = map[string]any{
+			"aws_secrets_manager": map[string]any{"key": "password"},
+		}
+	})
+	c.Assert(codes(Validate(md, appfile.LangGo, data)), qt.DeepEquals, []string{
+		"error missing_setting sql_databases[0].password_source.aws_secrets_manager.secret_id",
+		"error missing_setting app_secrets[\"SendGridKey\"].vault.key",
+		"warning invalid_reference app_secrets[\"SendgridKey\"]",
+		"error missing_setting app_secrets[\"SendgridKey\"]",
+		"warning invalid_setting app_secrets[\"StripeKey\"].file",
+	})
+
+	data = modify(c, data, func(conf map[string]any) {
+		conf["sql_databases"].([]any)[0].(map[string]any)["password_source"] = map[string]any{
+			"env":   "DB_PASSWORD",
+			"vault": map[string]any{"path": "secret/data/shop", "key": "db"},
+		}
+	})
+	c.Assert(codes(Validate(md, appfile.LangGo, data))[0], qt.Equals, "error invalid_setting sql_databases[0].password_source")
+}
+
+func TestValidateV2(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	data := modify(c, infraconftest.ComposeInfraConfig(c, md, appfile.LangTS), func(conf map[string]any) {
+		res := conf["infra"].(map[string]any)["resources"].(map[string]any)
+		delete(res, "redisClusters")
+		delete(res, "appSecrets")
+		cluster := res["sqlClusters"].([]any)[0].(map[string]any)
+		db := cluster["databases"].([]any)[0].(map[string]any)
+		db["connPools"] = []any{map[string]any{"roleRid": "admin", "minConnections": 10, "maxConnections": 5}}
+		ps := res["pubsubClusters"].([]any)[0].(map[string]any)
+		ps["subscriptions"].([]any)[0].(map[string]any)["topicEncoreName"] = "order-shipped"
+		gw := res["gateways"].([]any)[0].(map[string]any)
+		gw["baseUrl"] = "localhost:8080"
+	})
+
+	diags := Validate(md, appfile.LangTS, data)
+	c.Assert(codes(diags), qt.DeepEquals, []string{
+		"error invalid_url infra.resources.gateways[0].baseUrl",
+		"error invalid_reference infra.resources.sqlClusters[0].databases[0].connPools[0].roleRid",
+		"error invalid_setting infra.resources.sqlClusters[0].databases[0].connPools[0]",
+		"error missing_cache infra.resources.redisClusters",
+		"error invalid_reference infra.resources.pubsubClusters[0].subscriptions[0].topicEncoreName",
+		"error missing_subscription infra.resources.pubsubClusters[0].topics[0]",
+		"error missing_secret infra.resources.appSecrets",
+		"error missing_secret infra.resources.appSecrets",
+	})
+}
+
+func TestValidateFormat(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+
+	diags := Validate(md, appfile.LangTS, infraconftest.ComposeInfraConfig(c, md, appfile.LangGo))
+	c.Assert(codes(diags), qt.DeepEquals, []string{"error wrong_format "})
+
+	diags = Validate(md, appfile.LangGo, infraconftest.ComposeInfraConfig(c, md, appfile.LangTS))
+	c.Assert(codes(diags), qt.DeepEquals, []string{"error wrong_format "})
+
+	diags = Validate(md, appfile.LangGo, []byte("{\n  \"app_id\": \"shop\",\n  \"sql_databases\": [}\n"))
+	c.Assert(codes(diags), qt.DeepEquals, []string{"error invalid_json "})
+	c.Assert(diags[0].Message, qt.Equals, "line 3, column 21: invalid character '}' looking for beginning of value")
+}
@@ -0,0 +1,702 @@
+// Package k8sgen generates Kubernetes manifests for self-hosting Encore apps,
+// running each service and gateway of the app in a Deployment of its own.
+package k8sgen
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/infraconf"
+	"encr.dev/pkg/migrategen"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// KustomizationFile is the name of the generated kustomization,
+// which lists all the other generated files.
+const KustomizationFile = "kustomization.yaml"
+
+// port is the port the app listens on within its containers.
+const port = 8080
+
+// maxConfigMapSize is the maximum size of the data of a ConfigMap.
+const maxConfigMapSize = 1 << 20
+
+// Params are the parameters for generating Kubernetes manifests.
+type Params struct {
+	// Meta is the metadata of the app.
+	Meta *meta.Data
+
+	// AppSlug is the slug of the app, used to prefix the names of the resources.
+	AppSlug string
+
+	// Lang is the language of the app. InfraConfig and the runtime configuration given
+	// to each Deployment use the legacy format for Go apps, and the new one for TypeScript apps.
+	Lang appfile.Lang
+
+	// Image is the Docker image of the app, as built by "encore build docker".
+	Image string
+
+	// Images are the Docker images of individual services and gateways, keyed
+	// by their names, as built by "encore build docker --per-service".
+	// Services and gateways without an image of their own run Image.
+	Images map[string]string
+
+	// Namespace is the namespace to deploy the app to.
+	// If empty the namespace is left unspecified.
+	Namespace string
+
+	// InfraConfig is the runtime configuration describing the app's infrastructure,
+	// in the JSON format generated by "encore build compose" for the app's language.
+	//
+	// It's used as the base of the runtime configuration of each Deployment, which
+	// hosts a single service or gateway and discovers the others through their Services.
+	InfraConfig []byte
+
+	// Replicas is the number of replicas of each Deployment. It defaults to 1.
+	Replicas int
+
+	// CPURequest and MemoryRequest are the resources requested for each container.
+	// They default to "100m" and "128Mi".
+	CPURequest    string
+	MemoryRequest string
+
+	// Migrations are the migration files of the app's databases,
+	// keyed by the database name and then the file name.
+	Migrations map[string]map[string][]byte
+
+	// MigrationImage is the migration runner built from the output of
+	// "encore build migrations", which holds the migrations. If empty the
+	// migrations are stored in ConfigMaps and applied with migrategen.BaseImage.
+	MigrationImage string
+
+	// MigrateInInitContainers applies the migrations of each database in init
+	// containers of the Deployments of the services using it, instead of in a Job.
+	MigrateInInitContainers bool
+}
+
+// component is a service or gateway, running in a Deployment of its own.
+type component struct {
+	// Name is the name of the component's Kubernetes resources.
+	Name string
+
+	// Label is the name of the component, without the app prefix.
+	Label string
+
+	// Service is the name of the hosted service, if any.
+	Service string
+
+	// Gateway is the name of the hosted gateway, if any.
+	Gateway string
+}
+
+// Generate generates a Deployment and a Service for each service and gateway of the app,
+// a Secret holding their runtime configurations, and a migration Job for each database,
+// or init containers applying the migrations if p.MigrateInInitContainers is set.
+//
+// The files are keyed by their names, and listed in a kustomization so they can
+// be applied together with "kubectl apply -k".
+func Generate(p Params) (files map[string][]byte, err error) {
+	if p.Replicas == 0 {
+		p.Replicas = 1
+	}
+	if p.CPURequest == "" {
+		p.CPURequest = "100m"
+	}
+	if p.MemoryRequest == "" {
+		p.MemoryRequest = "128Mi"
+	}
+
+	prefix := resourceName(p.AppSlug)
+	if prefix == "" {
+		return nil, errors.New("no app slug")
+	}
+	components, err := appComponents(p.Meta, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	infra, err := parseInfraConfig(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// The runtime configurations, and the database URLs used by the migration Jobs.
+	runtimeConfigs := make(map[string]string)
+	for _, comp := range components {
+		data, err := infra.deploymentConfig(comp, components)
+		if err != nil {
+			return nil, errors.Wrapf(err, "generate runtime config of %s", comp.Name)
+		}
+		runtimeConfigs[comp.Name] = data
+	}
+	for _, db := range p.Meta.SqlDatabases {
+		dbURL, err := infra.databaseURL(db.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "database %s", db.Name)
+		}
+		runtimeConfigs[databaseURLKey(db.Name)] = dbURL
+	}
+
+	for _, comp := range components {
+		if p.image(comp) == "" {
+			return nil, errors.Newf("no image for %s", comp.Label)
+		}
+	}
+
+	migs := make(map[string]migration)
+	for _, db := range p.Meta.SqlDatabases {
+		m, err := newMigration(p, prefix, db)
+		if err != nil {
+			return nil, errors.Wrapf(err, "database %s", db.Name)
+		}
+		migs[db.Name] = m
+	}
+
+	files = make(map[string][]byte)
+	files["runtime-config.yaml"] = runtimeConfigSecret(p, prefix, runtimeConfigs)
+	for _, comp := range components {
+		kind := "service"
+		if comp.Gateway != "" {
+			kind = "gateway"
+		}
+		files[kind+"-"+comp.Label+".yaml"] = deployment(p, prefix, comp, infra.secretEnvs(), migs)
+	}
+	for _, db := range p.Meta.SqlDatabases {
+		if data := migrations(p, prefix, db, migs[db.Name]); data != nil {
+			files["migrations-"+resourceName(db.Name)+".yaml"] = data
+		}
+	}
+	files[KustomizationFile] = kustomization(p, files)
+	return files, nil
+}
+
+// appComponents returns the components of the app, sorted by name.
+func appComponents(md *meta.Data, prefix string) ([]component, error) {
+	var comps []component
+	seen := make(map[string]bool)
+	add := func(comp component) error {
+		if seen[comp.Name] {
+			return errors.Newf("the resource name %s is used by several services or gateways", comp.Name)
+		}
+		seen[comp.Name] = true
+		comps = append(comps, comp)
+		return nil
+	}
+
+	for _, gw := range md.Gateways {
+		label := resourceName(gw.EncoreName)
+		if err := add(component{Name: prefix + "-" + label, Label: label, Gateway: gw.EncoreName}); err != nil {
+			return nil, err
+		}
+	}
+	for _, svc := range md.Svcs {
+		label := resourceName(svc.Name)
+		if err := add(component{Name: prefix + "-" + label, Label: label, Service: svc.Name}); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(comps, func(i, j int) bool { return comps[i].Name < comps[j].Name })
+	return comps, nil
+}
+
+// image returns the Docker image running the component.
+func (p Params) image(comp component) string {
+	name := comp.Service
+	if comp.Gateway != "" {
+		name = comp.Gateway
+	}
+	if img := p.Images[name]; img != "" {
+		return img
+	}
+	return p.Image
+}
+
+// infraConfig is the parsed infrastructure configuration,
+// in the runtime configuration format of the app's language.
+type infraConfig struct {
+	// legacy is the configuration of Go apps.
+	legacy *config.Runtime
+
+	// v2 is the configuration of TypeScript apps.
+	v2 *runtimev1.RuntimeConfig
+}
+
+func parseInfraConfig(p Params) (*infraConfig, error) {
+	if p.Lang == appfile.LangTS {
+		var conf runtimev1.RuntimeConfig
+		if err := protojson.Unmarshal(p.InfraConfig, &conf); err != nil {
+			return nil, errors.Wrap(err, "parse infra config")
+		}
+		if conf.Deployment == nil {
+			conf.Deployment = &runtimev1.Deployment{}
+		}
+		return &infraConfig{v2: &conf}, nil
+	}
+
+	var conf config.Runtime
+	if err := json.Unmarshal(p.InfraConfig, &conf); err != nil {
+		return nil, errors.Wrap(err, "parse infra config")
+	}
+	return &infraConfig{legacy: &conf}, nil
+}
+
+// deploymentConfig returns the base64-encoded runtime configuration of the
+// component's Deployment, which discovers the other services through their Services.
+func (c *infraConfig) deploymentConfig(comp component, all []component) (string, error) {
+	if c.v2 != nil {
+		conf := proto.Clone(c.v2).(*runtimev1.RuntimeConfig)
+		d := conf.Deployment
+		d.HostedServices = nil
+		d.HostedGateways = nil
+		if comp.Service != "" {
+			d.HostedServices = []*runtimev1.HostedService{{Name: comp.Service}}
+		}
+		if comp.Gateway != "" {
+			idx := slices.IndexFunc(conf.GetInfra().GetResources().GetGateways(), func(gw *runtimev1.Gateway) bool {
+				return gw.EncoreName == comp.Gateway
+			})
+			if idx < 0 {
+				return "", errors.Newf("the infra config has no gateway %s", comp.Gateway)
+			}
+			d.HostedGateways = []string{conf.Infra.Resources.Gateways[idx].Rid}
+		}
+		d.ServiceDiscovery = &runtimev1.ServiceDiscovery{Services: make(map[string]*runtimev1.ServiceDiscovery_Location)}
+		for _, other := range all {
+			if other.Service != "" && other.Service != comp.Service {
+				d.ServiceDiscovery.Services[other.Service] = &runtimev1.ServiceDiscovery_Location{
+					BaseUrl:     serviceURL(other),
+					AuthMethods: d.AuthMethods,
+				}
+			}
+		}
+		data, err := proto.Marshal(conf)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+
+	conf := *c.legacy
+	conf.HostedServices = nil
+	conf.Gateways = nil
+	if comp.Service != "" {
+		conf.HostedServices = []string{comp.Service}
+	}
+	if comp.Gateway != "" {
+		gw := config.Gateway{Name: comp.Gateway}
+		if idx := slices.IndexFunc(c.legacy.Gateways, func(gw config.Gateway) bool {
+			return gw.Name == comp.Gateway
+		}); idx >= 0 {
+			gw = c.legacy.Gateways[idx]
+		}
+		conf.Gateways = []config.Gateway{gw}
+	}
+	auth := config.ServiceAuth{Method: "noop"}
+	if len(conf.ServiceAuth) > 0 {
+		auth = conf.ServiceAuth[0]
+	}
+	conf.ServiceDiscovery = make(map[string]config.Service)
+	for _, other := range all {
+		if other.Service != "" && other.Service != comp.Service {
+			conf.ServiceDiscovery[other.Service] = config.Service{
+				Name:        other.Service,
+				URL:         serviceURL(other),
+				Protocol:    config.Http,
+				ServiceAuth: auth,
+			}
+		}
+	}
+	data, err := json.Marshal(&conf)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// databaseURL returns the URL of the database, for running its migrations.
+func (c *infraConfig) databaseURL(name string) (string, error) {
+	var host, dbName, user, password string
+	var tls bool
+	if c.v2 != nil {
+		found := false
+		for _, cluster := range c.v2.GetInfra().GetResources().GetSqlClusters() {
+			for _, db := range cluster.Databases {
+				if db.EncoreName != name || len(db.ConnPools) == 0 || len(cluster.Servers) == 0 {
+					continue
+				}
+				idx := slices.IndexFunc(c.v2.Infra.GetCredentials().GetSqlRoles(), func(r *runtimev1.SQLRole) bool {
+					return r.Rid == db.ConnPools[0].RoleRid
+				})
+				if idx < 0 {
+					return "", errors.Newf("the infra config has no role %s", db.ConnPools[0].RoleRid)
+				}
+				role := c.v2.Infra.Credentials.SqlRoles[idx]
+				embedded, ok := role.GetPassword().GetSource().(*runtimev1.SecretData_Embedded)
+				if role.Password != nil && !ok {
+					return "", errors.Newf("the password of role %s must be embedded in the infra config to run migrations", role.Rid)
+				}
+				host, dbName, user, tls = cluster.Servers[0].Host, db.CloudName, role.Username, cluster.Servers[0].TlsConfig != nil
+				if ok {
+					password = string(embedded.Embedded)
+				}
+				found = true
+			}
+		}
+		if !found {
+			return "", errors.New("the database is missing from the infra config")
+		}
+	} else {
+		idx := slices.IndexFunc(c.legacy.SQLDatabases, func(db *config.SQLDatabase) bool {
+			return db.EncoreName == name
+		})
+		if idx < 0 {
+			return "", errors.New("the database is missing from the infra config")
+		}
+		db := c.legacy.SQLDatabases[idx]
+		if db.ServerID < 0 || db.ServerID >= len(c.legacy.SQLServers) {
+			return "", errors.Newf("invalid server id %d", db.ServerID)
+		}
+		srv := c.legacy.SQLServers[db.ServerID]
+		host, dbName, user, password, tls = srv.Host, db.DatabaseName, db.User, db.Password, srv.ServerCACert != ""
+	}
+
+	if dbName == "" {
+		dbName = name
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "5432")
+	}
+	sslMode := "disable"
+	if tls {
+		sslMode = "require"
+	}
+	u := &url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(user, password),
+		Host:     host,
+		Path:     "/" + dbName,
+		RawQuery: "sslmode=" + sslMode,
+	}
+	return u.String(), nil
+}
+
+// secretEnv is an environment variable set from the app's secrets.
+type secretEnv struct {
+	// Env is the name of the environment variable.
+	Env string
+
+	// Key is the key of the app secrets' Secret holding the value.
+	Key string
+}
+
+// secretEnvs returns the environment variables holding the values of the app's secrets.
+func (c *infraConfig) secretEnvs() []secretEnv {
+	if c.v2 == nil {
+		return []secretEnv{{Env: "ENCORE_APP_SECRETS", Key: "ENCORE_APP_SECRETS"}}
+	}
+	var envs []secretEnv
+	for _, secret := range c.v2.GetInfra().GetResources().GetAppSecrets() {
+		if env, ok := secret.GetData().GetSource().(*runtimev1.SecretData_Env); ok {
+			envs = append(envs, secretEnv{Env: env.Env, Key: secret.EncoreName})
+		}
+	}
+	sort.Slice(envs, func(i, j int) bool { return envs[i].Env < envs[j].Env })
+	return envs
+}
+
+func serviceURL(comp component) string {
+	return fmt.Sprintf("http://%s:%d", comp.Name, port)
+}
+
+// databaseURLKey is the key of the runtime config Secret holding the URL of the database.
+func databaseURLKey(name string) string {
+	return "database-url-" + resourceName(name)
+}
+
+func runtimeConfigSecret(p Params, prefix string, data map[string]string) []byte {
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("# The runtime configuration of each Deployment, base64-encoded, and the URLs of\n")
+	b.WriteString("# the databases for running their migrations. It's derived from the infra config.\n")
+	b.WriteString("apiVersion: v1\nkind: Secret\n")
+	writeMetadata(&b, p, prefix+"-runtime-config", "runtime-config")
+	b.WriteString("type: Opaque\nstringData:\n")
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "  %s: %s\n", k, quote(data[k]))
+	}
+	return []byte(b.String())
+}
+
+func deployment(p Params, prefix string, comp component, secrets []secretEnv, migs map[string]migration) []byte {
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("apiVersion: apps/v1\nkind: Deployment\n")
+	writeMetadata(&b, p, comp.Name, comp.Label)
+	b.WriteString("spec:\n")
+	fmt.Fprintf(&b, "  replicas: %d\n", p.Replicas)
+	b.WriteString("  selector:\n    matchLabels:\n")
+	writeSelector(&b, "      ", prefix, comp.Label)
+	b.WriteString("  template:\n    metadata:\n      labels:\n")
+	writeSelector(&b, "        ", prefix, comp.Label)
+	b.WriteString("    spec:\n")
+	dbs := initMigrations(p, comp)
+	if len(dbs) > 0 {
+		b.WriteString("      initContainers:\n")
+		for _, db := range dbs {
+			writeMigrateContainer(&b, p, prefix, db, migs[db.Name].Label, migs[db.Name].Label)
+		}
+	}
+	b.WriteString("      containers:\n")
+	b.WriteString("        - name: app\n")
+	fmt.Fprintf(&b, "          image: %s\n", quote(p.image(comp)))
+	fmt.Fprintf(&b, "          ports:\n            - name: http\n              containerPort: %d\n", port)
+	b.WriteString("          env:\n")
+	fmt.Fprintf(&b, "            - name: PORT\n              value: \"%d\"\n", port)
+	b.WriteString("            - name: ENCORE_RUNTIME_CONFIG\n")
+	writeSecretRef(&b, prefix+"-runtime-config", comp.Name, false)
+	if len(infraconf.SecretNames(p.Meta)) > 0 {
+		for _, s := range secrets {
+			fmt.Fprintf(&b, "            - name: %s\n", s.Env)
+			writeSecretRef(&b, prefix+"-secrets", s.Key, true)
+		}
+	}
+	b.WriteString("          resources:\n            requests:\n")
+	fmt.Fprintf(&b, "              cpu: %s\n              memory: %s\n", quote(p.CPURequest), quote(p.MemoryRequest))
+	b.WriteString("          readinessProbe:\n")
+	writeProbe(&b, 5, 10)
+	b.WriteString("          livenessProbe:\n")
+	writeProbe(&b, 15, 20)
+	if len(dbs) > 0 && p.MigrationImage == "" {
+		b.WriteString("      volumes:\n")
+		for _, db := range dbs {
+			writeMigrationVolume(&b, migs[db.Name], migs[db.Name].Label)
+		}
+	}
+
+	b.WriteString("---\napiVersion: v1\nkind: Service\n")
+	writeMetadata(&b, p, comp.Name, comp.Label)
+	b.WriteString("spec:\n  selector:\n")
+	writeSelector(&b, "    ", prefix, comp.Label)
+	fmt.Fprintf(&b, "  ports:\n    - name: http\n      port: %d\n      targetPort: http\n", port)
+	return []byte(b.String())
+}
+
+// initMigrations returns the databases whose migrations are applied
+// by init containers of the component's Deployment.
+func initMigrations(p Params, comp component) []*meta.SQLDatabase {
+	if !p.MigrateInInitContainers || comp.Service == "" {
+		return nil
+	}
+	idx := slices.IndexFunc(p.Meta.Svcs, func(svc *meta.Service) bool { return svc.Name == comp.Service })
+	if idx < 0 {
+		return nil
+	}
+	var dbs []*meta.SQLDatabase
+	for _, db := range p.Meta.SqlDatabases {
+		if slices.Contains(p.Meta.Svcs[idx].Databases, db.Name) {
+			dbs = append(dbs, db)
+		}
+	}
+	return dbs
+}
+
+// migration describes how the migrations of a database are applied.
+type migration struct {
+	// Name is the name of the migration's resources, which includes
+	// a hash of the migrations since Jobs can't be modified.
+	Name string
+
+	// Label is the name of the component, without the app prefix.
+	Label string
+
+	// Files are the names of the migration files, sorted.
+	Files []string
+}
+
+func newMigration(p Params, prefix string, db *meta.SQLDatabase) (migration, error) {
+	files := p.Migrations[db.Name]
+	names := make([]string, 0, len(files))
+	size := 0
+	for name, data := range files {
+		names = append(names, name)
+		size += len(data)
+	}
+	sort.Strings(names)
+	if size > maxConfigMapSize && p.MigrationImage == "" {
+		return migration{}, errors.Newf("the migrations are larger than the %d bytes a ConfigMap can hold; build a migration image with \"encore build migrations\"", maxConfigMapSize)
+	}
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(files[name]))
+		h.Write(files[name])
+	}
+	hash := hex.EncodeToString(h.Sum(nil))[:8]
+	label := "migrate-" + resourceName(db.Name)
+	return migration{Name: prefix + "-" + label + "-" + hash, Label: label, Files: names}, nil
+}
+
+// migrations returns the ConfigMap holding the migrations of the database, unless they're
+// in the migration image, and the Job applying them, unless init containers apply them.
+// It returns nil if there's nothing to generate.
+func migrations(p Params, prefix string, db *meta.SQLDatabase, m migration) []byte {
+	var b strings.Builder
+	writeHeader(&b)
+	if !p.MigrateInInitContainers {
+		fmt.Fprintf(&b, "# Applies the migrations of the %s database. Apply it, and wait for it to complete,\n", db.Name)
+		b.WriteString("# before deploying a new version of the app.\n")
+	} else if p.MigrationImage == "" {
+		fmt.Fprintf(&b, "# The migrations of the %s database, applied by the init containers of the services using it.\n", db.Name)
+	} else {
+		return nil
+	}
+
+	if p.MigrationImage == "" {
+		files := p.Migrations[db.Name]
+		b.WriteString("apiVersion: v1\nkind: ConfigMap\n")
+		writeMetadata(&b, p, m.Name, m.Label)
+		if len(m.Files) == 0 {
+			b.WriteString("data: {}\n")
+		} else {
+			b.WriteString("data:\n")
+			for _, name := range m.Files {
+				fmt.Fprintf(&b, "  %s: %s\n", quote(name), quote(string(files[name])))
+			}
+		}
+		if p.MigrateInInitContainers {
+			return []byte(b.String())
+		}
+		b.WriteString("---\n")
+	}
+
+	b.WriteString("apiVersion: batch/v1\nkind: Job\n")
+	writeMetadata(&b, p, m.Name, m.Label)
+	b.WriteString("spec:\n  backoffLimit: 3\n  template:\n    spec:\n")
+	b.WriteString("      restartPolicy: Never\n      containers:\n")
+	writeMigrateContainer(&b, p, prefix, db, "migrate", "migrations")
+	if p.MigrationImage == "" {
+		b.WriteString("      volumes:\n")
+		writeMigrationVolume(&b, m, "migrations")
+	}
+	return []byte(b.String())
+}
+
+// writeMigrateContainer writes the container applying the migrations of the database,
+// which are mounted from the given volume unless they're in the migration image.
+func writeMigrateContainer(b *strings.Builder, p Params, prefix string, db *meta.SQLDatabase, name, volume string) {
+	fmt.Fprintf(b, "        - name: %s\n", name)
+	if p.MigrationImage != "" {
+		env := migrategen.DatabaseURLEnv(db.Name)
+		fmt.Fprintf(b, "          image: %s\n", quote(p.MigrationImage))
+		fmt.Fprintf(b, "          args: [%s]\n", quote(db.Name))
+		fmt.Fprintf(b, "          env:\n            - name: %s\n", env)
+		writeSecretRef(b, prefix+"-runtime-config", databaseURLKey(db.Name), false)
+		return
+	}
+	fmt.Fprintf(b, "          image: %s\n", migrategen.BaseImage)
+	b.WriteString("          args: [\"-path=/migrations\", \"-database=$(DATABASE_URL)\", \"up\"]\n")
+	b.WriteString("          env:\n            - name: DATABASE_URL\n")
+	writeSecretRef(b, prefix+"-runtime-config", databaseURLKey(db.Name), false)
+	fmt.Fprintf(b, "          volumeMounts:\n            - name: %s\n              mountPath: /migrations\n", volume)
+}
+
+// writeMigrationVolume writes the volume holding the migrations of the database.
+func writeMigrationVolume(b *strings.Builder, m migration, name string) {
+	fmt.Fprintf(b, "        - name: %s\n          configMap:\n", name)
+	fmt.Fprintf(b, "            name: %s\n", m.Name)
+}
+
+func kustomization(p Params, files map[string][]byte) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("apiVersion: kustomize.config.k8s.io/v1beta1\nkind: Kustomization\n")
+	if p.Namespace != "" {
+		fmt.Fprintf(&b, "namespace: %s\n", quote(p.Namespace))
+	}
+	b.WriteString("resources:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  - %s\n", name)
+	}
+	return []byte(b.String())
+}
+
+func writeHeader(b *strings.Builder) {
+	b.WriteString("# Generated by \"encore build k8s\"; regenerate it when the app changes.\n")
+}
+
+func writeMetadata(b *strings.Builder, p Params, name, component string) {
+	fmt.Fprintf(b, "metadata:\n  name: %s\n", name)
+	if p.Namespace != "" {
+		fmt.Fprintf(b, "  namespace: %s\n", quote(p.Namespace))
+	}
+	b.WriteString("  labels:\n")
+	fmt.Fprintf(b, "    app.kubernetes.io/name: %s\n", resourceName(p.AppSlug))
+	fmt.Fprintf(b, "    app.kubernetes.io/component: %s\n", component)
+	b.WriteString("    app.kubernetes.io/managed-by: encore\n")
+}
+
+func writeSelector(b *strings.Builder, indent, prefix, component string) {
+	fmt.Fprintf(b, "%sapp.kubernetes.io/name: %s\n", indent, prefix)
+	fmt.Fprintf(b, "%sapp.kubernetes.io/component: %s\n", indent, component)
+}
+
+func writeSecretRef(b *strings.Builder, name, key string, optional bool) {
+	b.WriteString("              valueFrom:\n                secretKeyRef:\n")
+	fmt.Fprintf(b, "                  name: %s\n                  key: %s\n", name, key)
+	if optional {
+		b.WriteString("                  optional: true\n")
+	}
+}
+
+func writeProbe(b *strings.Builder, initialDelay, period int) {
+	b.WriteString("            httpGet:\n              path: /__encore/healthz\n              port: http\n")
+	fmt.Fprintf(b, "            initialDelaySeconds: %d\n            periodSeconds: %d\n", initialDelay, period)
+}
+
+// resourceName converts s into a valid Kubernetes resource name,
+// consisting of lowercase letters, digits and dashes.
+func resourceName(s string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '-'
+		}
+	}, s)
+	return strings.Trim(name, "-")
+}
+
+// quote quotes s as a YAML string.
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
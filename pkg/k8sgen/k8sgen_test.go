@@ -0,0 +1,165 @@
+package k8sgen
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/golden"
+	"encr.dev/pkg/infraconf/infraconftest"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestGenerate(t *testing.T) {
+	for _, lang := range []appfile.Lang{appfile.LangGo, appfile.LangTS} {
+		t.Run(string(lang), func(t *testing.T) {
+			c := qt.New(t)
+			md := infraconftest.Meta()
+			files, err := Generate(Params{
+				Meta:        md,
+				AppSlug:     "shop",
+				Lang:        lang,
+				Image:       "registry.example.com/shop:v1",
+				Namespace:   "shop",
+				InfraConfig: infraconftest.ComposeInfraConfig(c, md, lang),
+				Migrations: map[string]map[string][]byte{
+					"orders": {
+						"1_create_orders.up.sql": []byte("CREATE TABLE orders (id BIGSERIAL PRIMARY KEY);\n"),
+					},
+				},
+			})
+			c.Assert(err, qt.IsNil)
+
+			ar := &txtar.Archive{}
+			for name, data := range files {
+				ar.Files = append(ar.Files, txtar.File{Name: name, Data: data})
+			}
+			sort.Slice(ar.Files, func(i, j int) bool { return ar.Files[i].Name < ar.Files[j].Name })
+			golden.Test(t, string(txtar.Format(ar)))
+		})
+	}
+}
+
+func TestDeploymentConfig(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	p := Params{Meta: md, AppSlug: "shop", Lang: appfile.LangGo, InfraConfig: infraconftest.ComposeInfraConfig(c, md, appfile.LangGo)}
+	infra, err := parseInfraConfig(p)
+	c.Assert(err, qt.IsNil)
+	comps, err := appComponents(md, "shop")
+	c.Assert(err, qt.IsNil)
+
+	decode := func(data string) *config.Runtime {
+		raw, err := base64.StdEncoding.DecodeString(data)
+		c.Assert(err, qt.IsNil)
+		var rt config.Runtime
+		c.Assert(json.Unmarshal(raw, &rt), qt.IsNil)
+		return &rt
+	}
+
+	// comps are sorted: shop-api-gateway, shop-emails, shop-orders.
+	data, err := infra.deploymentConfig(comps[2], comps)
+	c.Assert(err, qt.IsNil)
+	rt := decode(data)
+	c.Assert(rt.HostedServices, qt.DeepEquals, []string{"orders"})
+	c.Assert(rt.Gateways, qt.HasLen, 0)
+	c.Assert(rt.ServiceDiscovery, qt.HasLen, 1)
+	c.Assert(rt.ServiceDiscovery["emails"].URL, qt.Equals, "http://shop-emails:8080")
+	c.Assert(rt.SQLDatabases, qt.HasLen, 1)
+
+	data, err = infra.deploymentConfig(comps[0], comps)
+	c.Assert(err, qt.IsNil)
+	rt = decode(data)
+	c.Assert(rt.HostedServices, qt.HasLen, 0)
+	c.Assert(rt.Gateways, qt.DeepEquals, []config.Gateway{{Name: "api-gateway", Host: "localhost"}})
+	c.Assert(rt.ServiceDiscovery, qt.HasLen, 2)
+}
+
+func TestGenerateMissingDatabase(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	infra := infraconftest.ComposeInfraConfig(c, &meta.Data{Svcs: md.Svcs, Gateways: md.Gateways}, appfile.LangGo)
+	_, err := Generate(Params{Meta: md, AppSlug: "shop", Lang: appfile.LangGo, InfraConfig: infra})
+	c.Assert(err, qt.ErrorMatches, `database orders: the database is missing from the infra config`)
+}
+
+func TestGenerateImages(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	p := Params{
+		Meta:        md,
+		AppSlug:     "shop",
+		Lang:        appfile.LangGo,
+		Image:       "shop:v1",
+		Images:      map[string]string{"orders": "shop-orders:v1", "api-gateway": "shop-api-gateway:v1"},
+		InfraConfig: infraconftest.ComposeInfraConfig(c, md, appfile.LangGo),
+	}
+	files, err := Generate(p)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(files["service-orders.yaml"]), qt.Contains, `image: "shop-orders:v1"`)
+	c.Assert(string(files["gateway-api-gateway.yaml"]), qt.Contains, `image: "shop-api-gateway:v1"`)
+	c.Assert(string(files["service-emails.yaml"]), qt.Contains, `image: "shop:v1"`)
+
+	p.Image = ""
+	_, err = Generate(p)
+	c.Assert(err, qt.ErrorMatches, `no image for emails`)
+}
+
+func TestGenerateMigrations(t *testing.T) {
+	c := qt.New(t)
+	md := infraconftest.Meta()
+	md.Svcs[0].Databases = []string{"orders"}
+	p := Params{
+		Meta:        md,
+		AppSlug:     "shop",
+		Lang:        appfile.LangGo,
+		Image:       "shop:v1",
+		InfraConfig: infraconftest.ComposeInfraConfig(c, md, appfile.LangGo),
+		Migrations: map[string]map[string][]byte{
+			"orders": {"1_create_orders.up.sql": []byte("CREATE TABLE orders (id BIGSERIAL PRIMARY KEY);\n")},
+		},
+	}
+
+	// With a migration image, the Job runs it without a ConfigMap.
+	p.MigrationImage = "shop-migrations:v1"
+	files, err := Generate(p)
+	c.Assert(err, qt.IsNil)
+	job := string(files["migrations-orders.yaml"])
+	c.Assert(job, qt.Contains, "kind: Job")
+	c.Assert(job, qt.Not(qt.Contains), "kind: ConfigMap")
+	c.Assert(job, qt.Contains, `image: "shop-migrations:v1"`)
+	c.Assert(job, qt.Contains, `args: ["orders"]`)
+	c.Assert(job, qt.Contains, "- name: DATABASE_URL_ORDERS")
+	c.Assert(string(files["service-orders.yaml"]), qt.Not(qt.Contains), "initContainers")
+
+	// In init containers, the services using the database apply its migrations.
+	p.MigrateInInitContainers = true
+	files, err = Generate(p)
+	c.Assert(err, qt.IsNil)
+	c.Assert(files["migrations-orders.yaml"], qt.IsNil)
+	c.Assert(string(files[KustomizationFile]), qt.Not(qt.Contains), "migrations-orders.yaml")
+	orders := string(files["service-orders.yaml"])
+	c.Assert(orders, qt.Contains, "      initContainers:\n        - name: migrate-orders\n          image: \"shop-migrations:v1\"\n")
+	c.Assert(string(files["service-emails.yaml"]), qt.Not(qt.Contains), "initContainers")
+
+	// Without a migration image, the init containers mount the migrations from a ConfigMap.
+	p.MigrationImage = ""
+	files, err = Generate(p)
+	c.Assert(err, qt.IsNil)
+	cm := string(files["migrations-orders.yaml"])
+	c.Assert(cm, qt.Contains, "kind: ConfigMap")
+	c.Assert(cm, qt.Not(qt.Contains), "kind: Job")
+	orders = string(files["service-orders.yaml"])
+	c.Assert(orders, qt.Contains, "image: migrate/migrate:v4.17.1")
+	c.Assert(orders, qt.Contains, "      volumes:\n        - name: migrate-orders\n          configMap:\n            name: shop-migrate-orders-")
+}
@@ -0,0 +1,157 @@
+// Package migrategen generates a migration runner for self-hosting Encore apps,
+// applying the migrations of the app's databases as an explicit deploy step.
+package migrategen
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// The names of the generated files, besides the migrations
+// which are written to MigrationsDir/<database>.
+const (
+	Dockerfile    = "Dockerfile"
+	Script        = "run-migrations.sh"
+	MigrationsDir = "migrations"
+)
+
+// BaseImage is the image the migration runner is built from, providing
+// golang-migrate's migrate command.
+const BaseImage = "migrate/migrate:v4.17.1"
+
+// Params are the parameters for generating a migration runner.
+type Params struct {
+	// Meta is the metadata of the app.
+	Meta *meta.Data
+
+	// Migrations are the migration files of the app's databases,
+	// keyed by the database name and then the file name.
+	Migrations map[string]map[string][]byte
+}
+
+// Generate generates the build context of the migration runner's Docker image:
+// a Dockerfile, the script applying the migrations, and the migrations themselves.
+// Databases without migrations are left out.
+//
+// The files are keyed by their slash-separated paths.
+func Generate(p Params) (files map[string][]byte, err error) {
+	files = make(map[string][]byte)
+	var dbs []string
+	for _, db := range p.Meta.SqlDatabases {
+		migrations := p.Migrations[db.Name]
+		if len(migrations) == 0 {
+			continue
+		}
+		if !validName(db.Name) {
+			return nil, errors.Newf("invalid database name %q", db.Name)
+		}
+		for name, data := range migrations {
+			if strings.ContainsAny(name, `/\`) {
+				return nil, errors.Newf("database %s: invalid migration file name %q", db.Name, name)
+			}
+			files[path.Join(MigrationsDir, db.Name, name)] = data
+		}
+		dbs = append(dbs, db.Name)
+	}
+	sort.Strings(dbs)
+
+	files[Dockerfile] = dockerfile()
+	files[Script] = script(dbs)
+	return files, nil
+}
+
+// DatabaseURLEnv is the environment variable the migration runner
+// reads the URL of the database from.
+func DatabaseURLEnv(dbName string) string {
+	return "DATABASE_URL_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, dbName)
+}
+
+// validName reports whether name can be used unquoted in the script and as a directory name.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func dockerfile() []byte {
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("# Builds the migration runner of the app: docker build -t MY-MIGRATIONS-IMAGE:TAG .\n")
+	fmt.Fprintf(&b, "FROM %s\n", BaseImage)
+	fmt.Fprintf(&b, "COPY %s /%s\n", MigrationsDir, MigrationsDir)
+	fmt.Fprintf(&b, "COPY %s /%s\n", Script, Script)
+	fmt.Fprintf(&b, "ENTRYPOINT [\"/bin/sh\", \"/%s\"]\n", Script)
+	return []byte(b.String())
+}
+
+func script(dbs []string) []byte {
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	writeHeader(&b)
+	b.WriteString("#\n")
+	b.WriteString("# Applies the migrations of the app's databases in order, stopping at the first failure.\n")
+	b.WriteString("# The URL of each database is read from an environment variable:\n#\n")
+	for _, db := range dbs {
+		fmt.Fprintf(&b, "#   %s: %s\n", db, DatabaseURLEnv(db))
+	}
+	if len(dbs) == 0 {
+		b.WriteString("#   (the app has no migrations)\n")
+	}
+	b.WriteString("#\n# Pass the names of databases as arguments to only migrate those.\n")
+	b.WriteString("# Exits with status 1 if migrating a database fails, and 2 on invalid arguments.\n")
+	b.WriteString(`set -u
+
+log() {
+	echo "encore-migrate: $*" >&2
+}
+
+migrate_db() {
+	name=$1
+	env=$2
+	url=$(printenv "$env" || true)
+	if [ -z "$url" ]; then
+		log "$name: $env is not set"
+		return 1
+	fi
+	log "$name: applying migrations"
+	if ! migrate -path "/migrations/$name" -database "$url" up; then
+		log "$name: migrations failed; if the database is left dirty, fix it and run 'migrate force VERSION'"
+		return 1
+	fi
+	log "$name: up to date"
+}
+
+`)
+	fmt.Fprintf(&b, "[ $# -gt 0 ] || set -- %s\n", strings.Join(dbs, " "))
+	b.WriteString("for name in \"$@\"; do\n\tcase \"$name\" in\n")
+	for _, db := range dbs {
+		fmt.Fprintf(&b, "\t%s) migrate_db %s %s || exit 1 ;;\n", db, db, DatabaseURLEnv(db))
+	}
+	b.WriteString("\t*) log \"unknown database $name\"; exit 2 ;;\n\tesac\ndone\n")
+	return []byte(b.String())
+}
+
+func writeHeader(b *strings.Builder) {
+	b.WriteString("# Generated by \"encore build migrations\"; regenerate it when the app changes.\n")
+}
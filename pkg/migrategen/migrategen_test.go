@@ -0,0 +1,118 @@
+package migrategen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+
+	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func testParams() Params {
+	return Params{
+		Meta: &meta.Data{SqlDatabases: []*meta.SQLDatabase{{Name: "orders"}, {Name: "user-accounts"}, {Name: "empty"}}},
+		Migrations: map[string]map[string][]byte{
+			"orders": {
+				"1_create_orders.up.sql": []byte("CREATE TABLE orders (id BIGSERIAL PRIMARY KEY);\n"),
+				"2_add_total.up.sql":     []byte("ALTER TABLE orders ADD COLUMN total BIGINT;\n"),
+			},
+			"user-accounts": {
+				"1_create_users.up.sql": []byte("CREATE TABLE users (id BIGSERIAL PRIMARY KEY);\n"),
+			},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	files, err := Generate(testParams())
+	c.Assert(err, qt.IsNil)
+
+	ar := &txtar.Archive{}
+	for name, data := range files {
+		ar.Files = append(ar.Files, txtar.File{Name: name, Data: data})
+	}
+	sort.Slice(ar.Files, func(i, j int) bool { return ar.Files[i].Name < ar.Files[j].Name })
+	golden.Test(t, string(txtar.Format(ar)))
+}
+
+func TestGenerateInvalidName(t *testing.T) {
+	c := qt.New(t)
+	_, err := Generate(Params{
+		Meta:       &meta.Data{SqlDatabases: []*meta.SQLDatabase{{Name: "orders; rm -rf /"}}},
+		Migrations: map[string]map[string][]byte{"orders; rm -rf /": {"1_x.up.sql": nil}},
+	})
+	c.Assert(err, qt.ErrorMatches, `invalid database name .*`)
+}
+
+func TestDatabaseURLEnv(t *testing.T) {
+	c := qt.New(t)
+	c.Assert(DatabaseURLEnv("orders"), qt.Equals, "DATABASE_URL_ORDERS")
+	c.Assert(DatabaseURLEnv("user-accounts"), qt.Equals, "DATABASE_URL_USER_ACCOUNTS")
+}
+
+// TestScript runs the script with a fake migrate command.
+func TestScript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the script requires a POSIX shell")
+	}
+	c := qt.New(t)
+	files, err := Generate(testParams())
+	c.Assert(err, qt.IsNil)
+	dir := c.TempDir()
+	scriptPath := filepath.Join(dir, Script)
+	c.Assert(os.WriteFile(scriptPath, files[Script], 0644), qt.IsNil)
+
+	// The fake migrate fails for the database at the URL "fail".
+	binDir := filepath.Join(dir, "bin")
+	c.Assert(os.Mkdir(binDir, 0755), qt.IsNil)
+	fake := "#!/bin/sh\necho \"migrate $*\"\n[ \"$4\" != fail ]\n"
+	c.Assert(os.WriteFile(filepath.Join(binDir, "migrate"), []byte(fake), 0755), qt.IsNil)
+
+	run := func(env []string, args ...string) (string, int) {
+		cmd := exec.Command("/bin/sh", append([]string{scriptPath}, args...)...)
+		cmd.Env = append([]string{"PATH=" + binDir + ":" + os.Getenv("PATH")}, env...)
+		out, err := cmd.CombinedOutput()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return string(out), exitErr.ExitCode()
+		}
+		c.Assert(err, qt.IsNil)
+		return string(out), 0
+	}
+
+	env := []string{"DATABASE_URL_ORDERS=postgres://orders", "DATABASE_URL_USER_ACCOUNTS=postgres://users"}
+	out, code := run(env)
+	c.Assert(code, qt.Equals, 0, qt.Commentf("%s", out))
+	c.Assert(out, qt.Contains, "migrate -path /migrations/orders -database postgres://orders up")
+	c.Assert(out, qt.Contains, "migrate -path /migrations/user-accounts -database postgres://users up")
+
+	// Only the given databases are migrated.
+	out, code = run(env, "user-accounts")
+	c.Assert(code, qt.Equals, 0)
+	c.Assert(out, qt.Not(qt.Contains), "/migrations/orders")
+
+	// Failures stop the script.
+	out, code = run([]string{"DATABASE_URL_ORDERS=fail", "DATABASE_URL_USER_ACCOUNTS=postgres://users"})
+	c.Assert(code, qt.Equals, 1)
+	c.Assert(out, qt.Contains, "orders: migrations failed")
+	c.Assert(out, qt.Not(qt.Contains), "/migrations/user-accounts")
+
+	out, code = run(nil, "orders")
+	c.Assert(code, qt.Equals, 1)
+	c.Assert(out, qt.Contains, "orders: DATABASE_URL_ORDERS is not set")
+
+	out, code = run(env, "unknown")
+	c.Assert(code, qt.Equals, 2)
+	c.Assert(out, qt.Contains, "unknown database unknown")
+}
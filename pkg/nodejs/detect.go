@@ -0,0 +1,115 @@
+package nodejs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Installation is an installation of Node.js.
+type Installation struct {
+	// Path is the path to the node binary.
+	Path string
+
+	// Version is the installed version.
+	Version Version
+}
+
+// ErrNotFound is returned by Detect when there's no node binary on the PATH.
+var ErrNotFound = errors.New("Node.js was not found on your PATH, but is needed to run Encore.ts apps.\n\n" + installHint)
+
+// Detect finds the node binary on the PATH of environ,
+// given in the same format as os.Environ(), and reports its version.
+func Detect(ctx context.Context, environ []string) (*Installation, error) {
+	path, ok := lookPath(binaryName(), getenv(environ, "PATH"))
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return inspect(ctx, path, environ)
+}
+
+// inspect reports the version of the node binary at path.
+func inspect(ctx context.Context, path string, environ []string) (*Installation, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "--version")
+	cmd.Env = environ
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "run %s --version: %s", path, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	v, err := ParseVersion(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "run %s --version", path)
+	}
+	return &Installation{Path: path, Version: v}, nil
+}
+
+// lookPath looks for the executable name in the directories of pathEnv.
+// Unlike exec.LookPath it doesn't use the PATH of the current process.
+func lookPath(name, pathEnv string) (string, bool) {
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() && (runtime.GOOS == "windows" || fi.Mode()&0111 != 0) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "node.exe"
+	}
+	return "node"
+}
+
+// getenv returns the value of the environment variable key in environ.
+// If it's set multiple times, the last value wins.
+func getenv(environ []string, key string) string {
+	for i := len(environ) - 1; i >= 0; i-- {
+		if k, v, ok := strings.Cut(environ[i], "="); ok && envKeyEqual(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// WithBinDir returns a copy of environ with dir prepended to the PATH.
+func WithBinDir(environ []string, dir string) []string {
+	path := dir
+	if prev := getenv(environ, "PATH"); prev != "" {
+		path += string(filepath.ListSeparator) + prev
+	}
+
+	env := make([]string, 0, len(environ)+1)
+	for _, kv := range environ {
+		if k, _, _ := strings.Cut(kv, "="); !envKeyEqual(k, "PATH") {
+			env = append(env, kv)
+		}
+	}
+	return append(env, "PATH="+path)
+}
+
+// envKeyEqual reports whether the environment variable names a and b are equal,
+// ignoring case on Windows where they're case-insensitive.
+func envKeyEqual(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,293 @@
+package nodejs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// defaultMirror is where Node.js releases are downloaded from,
+// unless overridden with the NODEJS_ORG_MIRROR environment variable
+// also used by other Node.js tooling.
+const defaultMirror = "https://nodejs.org/dist"
+
+// Managed returns the Node.js installation of version v managed by Encore,
+// downloading and installing it first if necessary.
+func Managed(ctx context.Context, v Version) (*Installation, error) {
+	dir, err := managedDir(v)
+	if err != nil {
+		return nil, err
+	}
+	inst := &Installation{Path: filepath.Join(BinDir(dir), binaryName()), Version: v}
+	if _, err := os.Stat(inst.Path); err == nil {
+		return inst, nil
+	}
+
+	if err := install(ctx, v, dir); err != nil {
+		return nil, errors.Wrapf(err, "install Node.js %s", v)
+	}
+	return inst, nil
+}
+
+// BinDir returns the directory containing the node binary
+// of the installation in dir.
+func BinDir(dir string) string {
+	if runtime.GOOS == "windows" {
+		return dir
+	}
+	return filepath.Join(dir, "bin")
+}
+
+// managedDir returns the directory to install version v in.
+func managedDir(v Version) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", errors.Wrap(err, "user cache dir")
+	}
+	return filepath.Join(cacheDir, "encore", "nodejs", v.String()), nil
+}
+
+// install downloads version v and extracts it to dir.
+func install(ctx context.Context, v Version, dir string) error {
+	osName, arch, ext, err := platform()
+	if err != nil {
+		return err
+	}
+
+	mirror := strings.TrimSuffix(os.Getenv("NODEJS_ORG_MIRROR"), "/")
+	if mirror == "" {
+		mirror = defaultMirror
+	}
+	baseName := fmt.Sprintf("node-%s-%s-%s", v, osName, arch)
+	releaseURL := fmt.Sprintf("%s/%s", mirror, v)
+
+	checksum, err := fetchChecksum(ctx, releaseURL+"/SHASUMS256.txt", baseName+ext)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	archive, err := os.CreateTemp(filepath.Dir(dir), baseName+"-*"+ext)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = archive.Close()
+		_ = os.Remove(archive.Name())
+	}()
+	if err := download(ctx, releaseURL+"/"+baseName+ext, archive, checksum); err != nil {
+		return err
+	}
+
+	// Extract to a temporary directory first, so an interrupted
+	// install doesn't leave a partial installation behind.
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dir), baseName+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	if ext == ".zip" {
+		err = extractZip(archive, tmpDir)
+	} else {
+		err = extractTarGz(archive, tmpDir)
+	}
+	if err != nil {
+		return errors.Wrap(err, "extract archive")
+	}
+
+	if err := os.Rename(filepath.Join(tmpDir, baseName), dir); err != nil {
+		// Another process may have installed it concurrently.
+		if _, statErr := os.Stat(filepath.Join(BinDir(dir), binaryName())); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// platform returns the names Node.js releases use for the current platform,
+// and the extension of the release archives.
+func platform() (osName, arch, ext string, err error) {
+	switch runtime.GOOS {
+	case "darwin", "linux":
+		osName, ext = runtime.GOOS, ".tar.gz"
+	case "windows":
+		osName, ext = "win", ".zip"
+	default:
+		return "", "", "", errors.Newf("managed Node.js installations are not supported on %s", runtime.GOOS)
+	}
+
+	switch runtime.GOARCH {
+	case "amd64":
+		arch = "x64"
+	case "arm64":
+		arch = "arm64"
+	default:
+		return "", "", "", errors.Newf("managed Node.js installations are not supported on %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	return osName, arch, ext, nil
+}
+
+// fetchChecksum returns the SHA-256 checksum of the file name
+// from the checksum file at url.
+func fetchChecksum(ctx context.Context, url, name string) (string, error) {
+	body, err := get(ctx, url)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = body.Close() }()
+
+	sc := bufio.NewScanner(body)
+	for sc.Scan() {
+		if sum, file, ok := strings.Cut(sc.Text(), "  "); ok && file == name {
+			return sum, nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", errors.Wrap(err, "read checksums")
+	}
+	return "", errors.Newf("no release %s found at %s", name, url)
+}
+
+// download downloads url to dst, verifying its SHA-256 checksum.
+func download(ctx context.Context, url string, dst io.Writer, checksum string) error {
+	body, err := get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = body.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(dst, h), body); err != nil {
+		return errors.Wrapf(err, "download %s", url)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != checksum {
+		return errors.Newf("download %s: checksum mismatch: got %s, want %s", url, got, checksum)
+	}
+	return nil
+}
+
+func get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "create request")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+	if resp.StatusCode != 200 {
+		_ = resp.Body.Close()
+		return nil, errors.Newf("GET %s: got non-200 response: %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// extractTarGz extracts the gzipped tarball f to dir.
+func extractTarGz(f *os.File, dir string) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		dst := extractPath(dir, hdr.Name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(dst, 0755)
+		case tar.TypeReg:
+			err = writeFile(dst, tr, hdr.FileInfo().Mode().Perm())
+		case tar.TypeSymlink:
+			if err = os.MkdirAll(filepath.Dir(dst), 0755); err == nil {
+				err = os.Symlink(hdr.Linkname, dst)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// extractZip extracts the zip archive f to dir.
+func extractZip(f *os.File, dir string) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(f, fi.Size())
+	if err != nil {
+		return err
+	}
+
+	for _, zf := range zr.File {
+		dst := extractPath(dir, zf.Name)
+		if zf.FileInfo().IsDir() {
+			if err := os.MkdirAll(dst, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		src, err := zf.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(dst, src, 0755)
+		_ = src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractPath returns the path to extract the archive entry name to,
+// making sure it's within dir.
+func extractPath(dir, name string) string {
+	p := path.Clean("/" + name)
+	return filepath.Join(dir, filepath.FromSlash(p))
+}
+
+func writeFile(dst string, src io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, src)
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+// Package nodejs detects, checks and installs the Node.js versions
+// used to run Encore.ts applications.
+package nodejs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+)
+
+// MinVersion is the oldest Node.js version the Encore.ts runtime supports.
+// Older versions lack the Node-API features the runtime's native module needs.
+var MinVersion = Version{18, 0, 0}
+
+// TestedMajors are the Node.js release lines the Encore.ts runtime
+// is tested against. Other versions newer than MinVersion are expected
+// to work, but aren't guaranteed to.
+var TestedMajors = []int{18, 20, 22, 24}
+
+// Tested reports whether v belongs to one of the release lines in TestedMajors.
+func Tested(v Version) bool {
+	return slices.Contains(TestedMajors, v.Major)
+}
+
+// VersionError is returned by Check when a Node.js version can't run an app.
+type VersionError struct {
+	// Version is the Node.js version that was checked.
+	Version Version
+
+	// Engines is the app's "engines.node" range,
+	// if the version error is due to it.
+	Engines string
+
+	// Pinned reports whether the version was pinned by the app
+	// with "build.node_version" in encore.app.
+	Pinned bool
+}
+
+func (e *VersionError) Error() string {
+	var msg string
+	if e.Engines != "" {
+		msg = fmt.Sprintf("Node.js %s does not satisfy the app's required version %q (from \"engines.node\" in package.json).",
+			e.Version, e.Engines)
+	} else {
+		msg = fmt.Sprintf("Node.js %s is not supported by Encore, which requires Node.js %s or later.",
+			e.Version, MinVersion)
+	}
+	if e.Pinned {
+		return msg + "\n\nTo fix this, change \"build.node_version\" in encore.app to a supported version."
+	}
+	return msg + "\n\n" + installHint
+}
+
+const installHint = `To fix this, either:
+  - install a supported version of Node.js, for example using a version manager like nvm or fnm, or
+  - set "build": {"node_version": "<version>"} in encore.app to have Encore download and use that version.`
+
+// Check reports whether the Node.js version v can run the app at appRoot,
+// based on the versions supported by Encore and the "engines.node"
+// field in the app's package.json, if any.
+//
+// It returns a *VersionError if not.
+func Check(v Version, appRoot string) error {
+	if v.Compare(MinVersion) < 0 {
+		return &VersionError{Version: v}
+	}
+
+	engines, err := Engines(appRoot)
+	if err != nil {
+		return err
+	} else if engines != "" {
+		rng, err := ParseRange(engines)
+		if err != nil {
+			return errors.Wrap(err, "parse \"engines.node\" in package.json")
+		} else if !rng.Contains(v) {
+			return &VersionError{Version: v, Engines: engines}
+		}
+	}
+	return nil
+}
+
+// Engines returns the "engines.node" field of the package.json
+// at appRoot, or "" if it's not set.
+func Engines(appRoot string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(appRoot, "package.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, "read package.json")
+	}
+
+	var pkg struct {
+		Engines json.RawMessage `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return "", errors.Wrap(err, "parse package.json")
+	}
+
+	// Ignore engines in the legacy array form, which npm doesn't enforce either.
+	var engines struct {
+		Node string `json:"node"`
+	}
+	if len(pkg.Engines) > 0 && json.Unmarshal(pkg.Engines, &engines) != nil {
+		return "", nil
+	}
+	return engines.Node, nil
+}
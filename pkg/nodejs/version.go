@@ -0,0 +1,271 @@
+package nodejs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Version is a Node.js release version.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a version like "v22.11.0" or "22.11.0",
+// as reported by "node --version".
+func ParseVersion(s string) (Version, error) {
+	v, n, err := parsePartial(s)
+	if err != nil {
+		return Version{}, err
+	} else if n != 3 {
+		return Version{}, errors.Newf("invalid Node.js version %q: expected a full version like \"22.11.0\"", s)
+	}
+	return v, nil
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is
+// older than, the same as or newer than other.
+func (v Version) Compare(other Version) int {
+	switch {
+	case v.Major != other.Major:
+		return cmpInt(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return cmpInt(v.Minor, other.Minor)
+	default:
+		return cmpInt(v.Patch, other.Patch)
+	}
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parsePartial parses a possibly partial version like "22", "22.x" or "22.11.0",
+// returning the version and the number of components that were given.
+// The components that weren't given are zero.
+func parsePartial(s string) (v Version, n int, err error) {
+	orig := s
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "v"), "=")
+
+	// Node.js releases have no prerelease or build metadata, so ignore it.
+	if idx := strings.IndexAny(s, "-+"); idx >= 0 {
+		s = s[:idx]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, 0, errors.Newf("invalid version %q", orig)
+	}
+	comps := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	for i, p := range parts {
+		if p == "x" || p == "X" || p == "*" {
+			break
+		}
+		num, err := strconv.Atoi(p)
+		if err != nil || num < 0 {
+			return Version{}, 0, errors.Newf("invalid version %q", orig)
+		}
+		*comps[i] = num
+		n++
+	}
+	return v, n, nil
+}
+
+// Range is a range of versions, in the syntax of npm's semver ranges
+// used by the "engines" field in package.json, like ">=18" or "^20.11 || ^22".
+type Range struct {
+	raw string
+
+	// sets are the alternatives of the range. A version is in the range
+	// if it satisfies all the comparators of any of them.
+	sets [][]comparator
+}
+
+type comparator struct {
+	op string // one of "<", "<=", ">", ">=" and "="
+	v  Version
+}
+
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.v)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp == 0
+	}
+}
+
+// never is a comparator no version matches.
+var never = comparator{"<", Version{}}
+
+// opSpaceRe matches operators separated from their version by whitespace.
+var opSpaceRe = regexp.MustCompile(`(>=|<=|>|<|=|\^|~)\s+`)
+
+// ParseRange parses an npm semver range.
+func ParseRange(s string) (Range, error) {
+	r := Range{raw: s}
+	for _, alt := range strings.Split(s, "||") {
+		alt = strings.TrimSpace(alt)
+
+		// Hyphen ranges, like "18 - 20".
+		if lo, hi, ok := strings.Cut(alt, " - "); ok {
+			set, err := hyphenRange(lo, hi)
+			if err != nil {
+				return Range{}, errors.Wrapf(err, "invalid version range %q", s)
+			}
+			r.sets = append(r.sets, set)
+			continue
+		}
+
+		var set []comparator
+		for _, f := range strings.Fields(opSpaceRe.ReplaceAllString(alt, "$1")) {
+			cs, err := parseComparator(f)
+			if err != nil {
+				return Range{}, errors.Wrapf(err, "invalid version range %q", s)
+			}
+			set = append(set, cs...)
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+// Contains reports whether v is in the range.
+func (r Range) Contains(v Version) bool {
+	for _, set := range r.sets {
+		ok := true
+		for _, c := range set {
+			if !c.matches(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Range) String() string {
+	return r.raw
+}
+
+func hyphenRange(lo, hi string) ([]comparator, error) {
+	from, _, err := parsePartial(lo)
+	if err != nil {
+		return nil, err
+	}
+	to, n, err := parsePartial(hi)
+	if err != nil {
+		return nil, err
+	}
+	return []comparator{{">=", from}, upperBound(to, n)}, nil
+}
+
+// upperBound returns the comparator for "<=v",
+// where v has n components.
+func upperBound(v Version, n int) comparator {
+	switch n {
+	case 0:
+		return comparator{">=", Version{}}
+	case 3:
+		return comparator{"<=", v}
+	default:
+		return lowerThanNext(v, n)
+	}
+}
+
+// parseComparator parses a single comparator like ">=18", "^20.11" or "22.x",
+// desugaring it into primitive comparators.
+func parseComparator(s string) ([]comparator, error) {
+	var op string
+	for _, prefix := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(s, prefix) {
+			op, s = prefix, s[len(prefix):]
+			break
+		}
+	}
+
+	v, n, err := parsePartial(s)
+	if err != nil {
+		return nil, err
+	} else if n == 0 {
+		// A wildcard, like "*" or ">=x".
+		switch op {
+		case "<", ">":
+			return []comparator{never}, nil
+		default:
+			return nil, nil
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if n == 3 {
+			return []comparator{{"=", v}}, nil
+		}
+		return []comparator{{">=", v}, lowerThanNext(v, n)}, nil
+
+	case ">=", "<":
+		return []comparator{{op, v}}, nil
+
+	case ">":
+		if n == 3 {
+			return []comparator{{">", v}}, nil
+		}
+		return []comparator{{">=", lowerThanNext(v, n).v}}, nil
+
+	case "<=":
+		return []comparator{upperBound(v, n)}, nil
+
+	case "~":
+		// Allows patch-level changes, or minor-level changes if only the major is given.
+		return []comparator{{">=", v}, lowerThanNext(v, min(n, 2))}, nil
+
+	default: // "^"
+		// Allows changes that don't modify the left-most non-zero component.
+		switch {
+		case v.Major > 0 || n == 1:
+			return []comparator{{">=", v}, lowerThanNext(v, 1)}, nil
+		case v.Minor > 0 || n == 2:
+			return []comparator{{">=", v}, lowerThanNext(v, 2)}, nil
+		default:
+			return []comparator{{">=", v}, lowerThanNext(v, 3)}, nil
+		}
+	}
+}
+
+// lowerThanNext returns the comparator for versions lower than
+// the next version after v, when considering its first n components.
+func lowerThanNext(v Version, n int) comparator {
+	switch n {
+	case 1:
+		return comparator{"<", Version{v.Major + 1, 0, 0}}
+	case 2:
+		return comparator{"<", Version{v.Major, v.Minor + 1, 0}}
+	default:
+		return comparator{"<", Version{v.Major, v.Minor, v.Patch + 1}}
+	}
+}
@@ -0,0 +1,93 @@
+package nodejs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestParseVersion(t *testing.T) {
+	c := qt.New(t)
+
+	v, err := ParseVersion("v22.11.0\n")
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, Version{22, 11, 0})
+	c.Assert(v.String(), qt.Equals, "v22.11.0")
+
+	v, err = ParseVersion("20.9.1")
+	c.Assert(err, qt.IsNil)
+	c.Assert(v, qt.Equals, Version{20, 9, 1})
+
+	for _, s := range []string{"22", "22.x", "v22.1", "abc", "1.2.3.4"} {
+		_, err := ParseVersion(s)
+		c.Assert(err, qt.IsNotNil, qt.Commentf("version %q", s))
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		rng     string
+		matches []string
+		rejects []string
+	}{
+		{"", []string{"0.0.1", "22.0.0"}, nil},
+		{"*", []string{"18.0.0"}, nil},
+		{">=18", []string{"18.0.0", "24.1.0"}, []string{"16.20.2", "17.9.9"}},
+		{">= 18.17.0", []string{"18.17.0", "20.0.0"}, []string{"18.16.9"}},
+		{">18", []string{"19.0.0"}, []string{"18.20.0"}},
+		{"<=20", []string{"20.99.0"}, []string{"21.0.0"}},
+		{"<20.5", []string{"20.4.9"}, []string{"20.5.0"}},
+		{"20", []string{"20.0.0", "20.11.1"}, []string{"21.0.0", "19.9.9"}},
+		{"20.x", []string{"20.11.1"}, []string{"22.0.0"}},
+		{"=20.11.1", []string{"20.11.1"}, []string{"20.11.2"}},
+		{"^20.11", []string{"20.11.0", "20.19.0"}, []string{"20.10.0", "21.0.0"}},
+		{"~20.11.1", []string{"20.11.5"}, []string{"20.12.0", "20.11.0"}},
+		{"~20", []string{"20.19.0"}, []string{"21.0.0"}},
+		{"^0.2.3", []string{"0.2.9"}, []string{"0.3.0"}},
+		{"^18 || ^20.9 || >=22", []string{"18.1.0", "20.9.0", "23.1.0"}, []string{"19.0.0", "20.8.0"}},
+		{">=18 <21", []string{"20.19.0"}, []string{"21.0.0", "17.0.0"}},
+		{"18 - 20", []string{"18.0.0", "20.19.0"}, []string{"21.0.0"}},
+		{"18.5.0 - 20.1.0", []string{"20.1.0"}, []string{"18.4.0", "20.1.1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.rng, func(t *testing.T) {
+			c := qt.New(t)
+			rng, err := ParseRange(test.rng)
+			c.Assert(err, qt.IsNil)
+			for _, s := range test.matches {
+				c.Assert(rng.Contains(mustParse(c, s)), qt.IsTrue, qt.Commentf("version %s", s))
+			}
+			for _, s := range test.rejects {
+				c.Assert(rng.Contains(mustParse(c, s)), qt.IsFalse, qt.Commentf("version %s", s))
+			}
+		})
+	}
+
+	_, err := ParseRange(">=eighteen")
+	qt.Assert(t, err, qt.IsNotNil)
+}
+
+func TestCheck(t *testing.T) {
+	c := qt.New(t)
+	dir := c.TempDir()
+
+	// Without a package.json only the minimum version applies.
+	c.Assert(Check(Version{18, 0, 0}, dir), qt.IsNil)
+	err := Check(Version{16, 20, 2}, dir)
+	c.Assert(err, qt.ErrorMatches, `Node.js v16.20.2 is not supported by Encore(.|\n)*`)
+
+	err = os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"engines": {"node": ">=20.11"}}`), 0644)
+	c.Assert(err, qt.IsNil)
+	c.Assert(Check(Version{22, 1, 0}, dir), qt.IsNil)
+	err = Check(Version{20, 10, 0}, dir)
+	c.Assert(err, qt.ErrorMatches, `Node.js v20.10.0 does not satisfy the app's required version ">=20.11"(.|\n)*`)
+}
+
+func mustParse(c *qt.C, s string) Version {
+	v, err := ParseVersion(s)
+	c.Assert(err, qt.IsNil)
+	return v
+}
@@ -0,0 +1,262 @@
+// Package obsgen generates the configuration wiring a self-hosted Encore app
+// into a monitoring stack: a Prometheus config receiving the app's metrics,
+// recording rules for them, and an OpenTelemetry Collector pipeline.
+package obsgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"encore.dev/appruntime/exported/config"
+	"encr.dev/pkg/appfile"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+)
+
+// The names of the generated files.
+const (
+	PrometheusFile = "prometheus.yaml"
+	RulesFile      = "encore-rules.yaml"
+	CollectorFile  = "otel-collector.yaml"
+)
+
+// Params are the parameters for generating the observability configuration.
+type Params struct {
+	// Meta is the metadata of the app.
+	Meta *meta.Data
+
+	// AppSlug names the rule group and the collector's scrape job.
+	AppSlug string
+
+	// Lang is the language the app is written in.
+	Lang appfile.Lang
+
+	// InfraConfig is the infra config of the app, whose metrics
+	// and tracing settings the configuration is generated for.
+	InfraConfig []byte
+}
+
+// The metrics the runtime exports for every app.
+const (
+	requestsTotal = "e_requests_total"
+)
+
+var systemMetrics = []string{
+	"e_sys_memory_heap_objects_bytes",
+	"e_sys_sched_goroutines",
+}
+
+// remoteWritePath is the path Prometheus receives remote writes at.
+const remoteWritePath = "/api/v1/write"
+
+// defaultCollectionInterval is the interval metrics are exported at by default.
+const defaultCollectionInterval = time.Minute
+
+// Generate generates the observability configuration of the app.
+//
+// The app pushes its metrics with Prometheus remote write, configured in the infra
+// config. When the remote write URL is a Prometheus server, its config is generated
+// along with a collector pipeline reading the app's metrics from its federation endpoint.
+// Otherwise, as when writing to a compatible store, only the recording rules are generated.
+func Generate(p Params) (files map[string][]byte, err error) {
+	if p.Lang == appfile.LangTS {
+		return nil, errors.New("metrics aren't exported by TypeScript apps yet")
+	}
+	var conf config.Runtime
+	if err := json.Unmarshal(p.InfraConfig, &conf); err != nil {
+		return nil, errors.Wrap(err, "parse infra config")
+	}
+	if conf.Metrics == nil || conf.Metrics.Prometheus == nil || conf.Metrics.Prometheus.RemoteWriteURL == "" {
+		return nil, errors.New("the infra config doesn't export metrics to Prometheus: set metrics.prometheus.RemoteWriteURL")
+	}
+	writeURL, err := url.Parse(conf.Metrics.Prometheus.RemoteWriteURL)
+	if err != nil || writeURL.Host == "" {
+		return nil, errors.Newf("invalid Prometheus remote write url %q", conf.Metrics.Prometheus.RemoteWriteURL)
+	}
+	interval := conf.Metrics.CollectionInterval
+	if interval <= 0 {
+		interval = defaultCollectionInterval
+	}
+
+	files = map[string][]byte{
+		RulesFile: rules(p, interval),
+	}
+	if strings.HasSuffix(writeURL.Path, remoteWritePath) {
+		files[PrometheusFile] = prometheusConfig(writeURL, interval)
+		files[CollectorFile] = collectorConfig(p, writeURL, interval, conf.TraceEndpoint)
+	}
+	return files, nil
+}
+
+// metric describes a metric the app exports.
+type metric struct {
+	Name    string
+	Kind    meta.Metric_MetricKind
+	Service string // the service the metric is exclusive to, if any
+	Labels  []string
+	Doc     string
+}
+
+// appMetrics returns the metrics the app exports, sorted by name.
+func appMetrics(md *meta.Data) []metric {
+	metrics := []metric{{
+		Name:   requestsTotal,
+		Kind:   meta.Metric_COUNTER,
+		Labels: []string{"service", "endpoint", "code"},
+		Doc:    "The requests handled by each endpoint, by error code.",
+	}}
+	for _, m := range md.Metrics {
+		labels := []string{"service"}
+		for _, l := range m.Labels {
+			labels = append(labels, l.Key)
+		}
+		metrics = append(metrics, metric{
+			Name:    m.Name,
+			Kind:    m.Kind,
+			Service: m.GetServiceName(),
+			Labels:  labels,
+			Doc:     m.Doc,
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+// rateWindow returns the window of the rates in the recording rules,
+// which must cover several collections to be reliable.
+func rateWindow(interval time.Duration) time.Duration {
+	return max(5*time.Minute, 4*interval)
+}
+
+func rules(p Params, interval time.Duration) []byte {
+	window := promDuration(rateWindow(interval))
+	metrics := appMetrics(p.Meta)
+
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("#\n# The metrics exported by the app, labelled with env_name and\n")
+	b.WriteString("# the service that recorded them:\n#\n")
+	for _, m := range metrics {
+		fmt.Fprintf(&b, "#   %s (%s", m.Name, strings.ToLower(m.Kind.String()))
+		if m.Service != "" {
+			fmt.Fprintf(&b, ", service %s", m.Service)
+		}
+		fmt.Fprintf(&b, "): %s\n", strings.Join(m.Labels, ", "))
+		if doc := strings.TrimSpace(m.Doc); doc != "" {
+			for _, line := range strings.Split(doc, "\n") {
+				fmt.Fprintf(&b, "#     %s\n", strings.TrimSpace(line))
+			}
+		}
+	}
+	for _, name := range systemMetrics {
+		fmt.Fprintf(&b, "#   %s (gauge, per instance rather than service)\n", name)
+	}
+
+	b.WriteString("groups:\n")
+	fmt.Fprintf(&b, "  - name: %s\n", p.AppSlug)
+	fmt.Fprintf(&b, "    interval: %s\n", promDuration(interval))
+	b.WriteString("    rules:\n")
+	by := "env_name, service, endpoint"
+	writeRule := func(record, expr string) {
+		fmt.Fprintf(&b, "      - record: %s\n", record)
+		fmt.Fprintf(&b, "        expr: %s\n", quote(expr))
+	}
+	writeRule("service_endpoint:e_requests_total:rate"+window,
+		fmt.Sprintf("sum by (%s) (rate(%s[%s]))", by, requestsTotal, window))
+	writeRule("service_endpoint:e_request_errors:rate"+window,
+		fmt.Sprintf(`sum by (%s) (rate(%s{code!="ok"}[%s]))`, by, requestsTotal, window))
+	writeRule("service_endpoint:e_request_errors:ratio_rate"+window,
+		fmt.Sprintf("service_endpoint:e_request_errors:rate%s / service_endpoint:e_requests_total:rate%s", window, window))
+	for _, m := range metrics {
+		if m.Name == requestsTotal || m.Kind != meta.Metric_COUNTER {
+			continue
+		}
+		labels := append([]string{"env_name"}, m.Labels...)
+		writeRule(strings.Join(m.Labels, "_")+":"+m.Name+":rate"+window,
+			fmt.Sprintf("sum by (%s) (rate(%s[%s]))", strings.Join(labels, ", "), m.Name, window))
+	}
+	return []byte(b.String())
+}
+
+func prometheusConfig(writeURL *url.URL, interval time.Duration) []byte {
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("#\n")
+	fmt.Fprintf(&b, "# The app pushes its metrics every %s to %s\n", promDuration(interval), writeURL.Redacted())
+	b.WriteString("# with Prometheus remote write, so run Prometheus with --web.enable-remote-write-receiver.\n")
+	b.WriteString("# There's nothing to scrape: merge the rules into your existing config.\n")
+	b.WriteString("global:\n")
+	fmt.Fprintf(&b, "  evaluation_interval: %s\n", promDuration(interval))
+	b.WriteString("rule_files:\n")
+	fmt.Fprintf(&b, "  - %s\n", RulesFile)
+	return []byte(b.String())
+}
+
+func collectorConfig(p Params, writeURL *url.URL, interval time.Duration, traceEndpoint string) []byte {
+	var names []string
+	for _, m := range appMetrics(p.Meta) {
+		names = append(names, regexp.QuoteMeta(m.Name))
+	}
+	names = append(names, systemMetrics...)
+	federatePath := strings.TrimSuffix(writeURL.Path, remoteWritePath) + "/federate"
+
+	var b strings.Builder
+	writeHeader(&b)
+	b.WriteString("#\n")
+	b.WriteString("# Reads the app's metrics from the federation endpoint of the Prometheus server\n")
+	b.WriteString("# it pushes them to, and exports them with OTLP to $OTLP_ENDPOINT.\n")
+	if traceEndpoint != "" {
+		fmt.Fprintf(&b, "# Traces are sent to %s in Encore's trace format, which the collector doesn't receive.\n", traceEndpoint)
+	}
+	b.WriteString("receivers:\n")
+	fmt.Fprintf(&b, "  prometheus/%s:\n", p.AppSlug)
+	b.WriteString("    config:\n")
+	b.WriteString("      scrape_configs:\n")
+	fmt.Fprintf(&b, "        - job_name: %s\n", p.AppSlug)
+	fmt.Fprintf(&b, "          scrape_interval: %s\n", promDuration(interval))
+	b.WriteString("          honor_labels: true\n")
+	fmt.Fprintf(&b, "          scheme: %s\n", writeURL.Scheme)
+	fmt.Fprintf(&b, "          metrics_path: %s\n", federatePath)
+	b.WriteString("          params:\n")
+	b.WriteString("            match[]:\n")
+	fmt.Fprintf(&b, "              - %s\n", quote(fmt.Sprintf(`{__name__=~"%s"}`, strings.Join(names, "|"))))
+	b.WriteString("          static_configs:\n")
+	fmt.Fprintf(&b, "            - targets: [%s]\n", quote(writeURL.Host))
+	b.WriteString("processors:\n")
+	b.WriteString("  batch: {}\n")
+	b.WriteString("exporters:\n")
+	b.WriteString("  otlp:\n")
+	b.WriteString("    endpoint: ${env:OTLP_ENDPOINT}\n")
+	b.WriteString("service:\n")
+	b.WriteString("  pipelines:\n")
+	b.WriteString("    metrics:\n")
+	fmt.Fprintf(&b, "      receivers: [prometheus/%s]\n", p.AppSlug)
+	b.WriteString("      processors: [batch]\n")
+	b.WriteString("      exporters: [otlp]\n")
+	return []byte(b.String())
+}
+
+// promDuration formats d as a Prometheus duration, in whole seconds or minutes.
+func promDuration(d time.Duration) string {
+	d = max(d.Round(time.Second), time.Second)
+	if d%time.Minute == 0 {
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// quote quotes s as a YAML string.
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+func writeHeader(b *strings.Builder) {
+	b.WriteString("# Generated by \"encore build observability\"; regenerate it when the app changes.\n")
+}
@@ -0,0 +1,138 @@
+package obsgen
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+	"sigs.k8s.io/yaml"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/golden"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	schema "encr.dev/proto/encore/parser/schema/v1"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func testMeta() *meta.Data {
+	orders := "orders"
+	return &meta.Data{
+		Svcs: []*meta.Service{{Name: "orders"}},
+		Metrics: []*meta.Metric{
+			{
+				Name:        "orders_placed",
+				ValueType:   schema.Builtin_UINT64,
+				Doc:         "The number of orders placed.\n",
+				Kind:        meta.Metric_COUNTER,
+				ServiceName: &orders,
+				Labels:      []*meta.Metric_Label{{Key: "region", Type: schema.Builtin_STRING}},
+			},
+			{
+				Name:      "queue_depth",
+				ValueType: schema.Builtin_INT64,
+				Kind:      meta.Metric_GAUGE,
+			},
+		},
+	}
+}
+
+func generate(c *qt.C, infraConfig string) string {
+	files, err := Generate(Params{
+		Meta:        testMeta(),
+		AppSlug:     "shop",
+		Lang:        appfile.LangGo,
+		InfraConfig: []byte(infraConfig),
+	})
+	c.Assert(err, qt.IsNil)
+
+	ar := &txtar.Archive{}
+	for name, data := range files {
+		ar.Files = append(ar.Files, txtar.File{Name: name, Data: data})
+	}
+	sort.Slice(ar.Files, func(i, j int) bool { return ar.Files[i].Name < ar.Files[j].Name })
+	return string(txtar.Format(ar))
+}
+
+func TestGenerate(t *testing.T) {
+	c := qt.New(t)
+	golden.Test(t, generate(c, `{
+		"trace_endpoint": "https://traces.example.com/trace",
+		"metrics": {
+			"collection_interval": 30000000000,
+			"prometheus": {"RemoteWriteURL": "http://prometheus.monitoring:9090/api/v1/write"}
+		}
+	}`))
+}
+
+func TestGenerateRemoteStore(t *testing.T) {
+	c := qt.New(t)
+	golden.Test(t, generate(c, `{
+		"metrics": {"prometheus": {"RemoteWriteURL": "https://mimir.example.com/api/v1/push"}}
+	}`))
+}
+
+func TestGenerateNoPrometheus(t *testing.T) {
+	c := qt.New(t)
+	_, err := Generate(Params{
+		Meta:        testMeta(),
+		Lang:        appfile.LangGo,
+		InfraConfig: []byte(`{"metrics": {"datadog": {"Site": "datadoghq.com"}}}`),
+	})
+	c.Assert(err, qt.ErrorMatches, "the infra config doesn't export metrics to Prometheus.*")
+}
+
+func TestGenerateTS(t *testing.T) {
+	c := qt.New(t)
+	_, err := Generate(Params{Meta: testMeta(), Lang: appfile.LangTS, InfraConfig: []byte("{}")})
+	c.Assert(err, qt.ErrorMatches, "metrics aren't exported by TypeScript apps yet")
+}
+
+func TestPromDuration(t *testing.T) {
+	c := qt.New(t)
+	for d, want := range map[string]string{
+		"1m": "1m", "90s": "90s", "2m": "2m", "500ms": "1s", "1h": "60m",
+	} {
+		dur, err := time.ParseDuration(d)
+		c.Assert(err, qt.IsNil)
+		c.Assert(promDuration(dur), qt.Equals, want, qt.Commentf("%s", d))
+	}
+}
+
+func TestGenerateValidYAML(t *testing.T) {
+	c := qt.New(t)
+	files, err := Generate(Params{
+		Meta:        testMeta(),
+		AppSlug:     "shop",
+		Lang:        appfile.LangGo,
+		InfraConfig: []byte(`{"metrics": {"prometheus": {"RemoteWriteURL": "https://prom.example.com/prom/api/v1/write"}}}`),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(files, qt.HasLen, 3)
+	for name, data := range files {
+		var v map[string]any
+		c.Assert(yaml.Unmarshal(data, &v), qt.IsNil, qt.Commentf("%s", name))
+	}
+
+	var conf struct {
+		Receivers map[string]struct {
+			Config struct {
+				ScrapeConfigs []struct {
+					Scheme      string              `json:"scheme"`
+					MetricsPath string              `json:"metrics_path"`
+					Params      map[string][]string `json:"params"`
+				} `json:"scrape_configs"`
+			} `json:"config"`
+		} `json:"receivers"`
+	}
+	c.Assert(yaml.Unmarshal(files[CollectorFile], &conf), qt.IsNil)
+	scrape := conf.Receivers["prometheus/shop"].Config.ScrapeConfigs
+	c.Assert(scrape, qt.HasLen, 1)
+	c.Assert(scrape[0].Scheme, qt.Equals, "https")
+	c.Assert(scrape[0].MetricsPath, qt.Equals, "/prom/federate")
+	c.Assert(scrape[0].Params["match[]"], qt.HasLen, 1)
+}
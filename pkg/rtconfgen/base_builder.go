@@ -109,6 +109,14 @@ func (b *Builder) LogsProviderFn(rid string, fn func() *runtimev1.LogsProvider)
 	addResFunc(&b.obs.Logs, b.rs, rid, fn)
 }
 
+func (b *Builder) AuditLogProvider(p *runtimev1.AuditLogProvider) {
+	b.AuditLogProviderFn(p.Rid, tofn(p))
+}
+
+func (b *Builder) AuditLogProviderFn(rid string, fn func() *runtimev1.AuditLogProvider) {
+	addResFunc(&b.obs.AuditLogs, b.rs, rid, fn)
+}
+
 func (b *Builder) Deployment(rid string) *Deployment {
 	if d, ok := b.deployments[rid]; ok {
 		return d
@@ -432,6 +432,17 @@ func (c *legacyConverter) Convert() (*config.Runtime, error) {
 				break
 			}
 		}
+
+		// Add the Encore audit log endpoint, if any.
+		for _, prov := range obs.AuditLogs {
+			if enc := prov.GetEncore(); enc != nil {
+				cfg.AuditLog = &config.AuditLog{
+					Endpoint:  enc.AuditEndpoint,
+					Retention: prov.Retention.AsDuration(),
+				}
+				break
+			}
+		}
 	}
 
 	if c.in.EncorePlatform != nil {
@@ -0,0 +1,276 @@
+package tfgen
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// aws generates the configuration provisioning the app's infrastructure on AWS:
+// an RDS instance for its databases, an ElastiCache cluster for each cache cluster,
+// SNS topics with SQS queues subscribed to them, and EventBridge rules for cron jobs.
+func (g *generator) aws() error {
+	md := g.md
+	g.baseConfig(runtimev1.Environment_CLOUD_AWS)
+
+	providers := []provider{{"aws", "hashicorp/aws", "~> 5.0"}}
+	if len(md.SqlDatabases) > 0 {
+		providers = append(providers, provider{"postgresql", "cyrilgdn/postgresql", "~> 1.22"})
+	}
+	if len(md.SqlDatabases) > 0 || len(g.jobs) > 0 {
+		providers = append(providers, randomProvider)
+	}
+	g.main = append(g.main, terraformBlock(providers))
+	g.main = append(g.main, newBlock(`provider "aws"`).attr("region", "var.region"))
+
+	g.variable("region", "string", "The AWS region to provision the infrastructure in.", "")
+	g.commonVariables()
+	g.locals()
+
+	if len(md.SqlDatabases) > 0 {
+		g.awsDatabases()
+	}
+	if len(md.CacheClusters) > 0 {
+		g.awsCaches()
+	}
+	if len(md.PubsubTopics) > 0 {
+		if err := g.awsPubSub(); err != nil {
+			return err
+		}
+	}
+	if len(g.jobs) > 0 {
+		if err := g.awsCrons(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) awsDatabases() {
+	g.variable("db_instance_class", "string", "The instance class of the RDS instance.", quote("db.t4g.micro"))
+	g.variable("db_subnet_group_name", "string", "The DB subnet group of the RDS instance.", "")
+	g.variable("db_security_group_ids", "list(string)", "The security groups of the RDS instance, which must allow access from the app.", "")
+
+	g.resource("random_password", "postgres").
+		attr("length", "32").
+		attr("special", "false")
+	g.resource("aws_db_instance", "postgres").
+		attr("identifier", cloudName("", "postgres")).
+		attr("engine", quote("postgres")).
+		attr("engine_version", quote("16")).
+		attr("instance_class", "var.db_instance_class").
+		attr("allocated_storage", "20").
+		attr("storage_encrypted", "true").
+		attr("username", quote(postgresUser)).
+		attr("password", "random_password.postgres.result").
+		attr("db_subnet_group_name", "var.db_subnet_group_name").
+		attr("vpc_security_group_ids", "var.db_security_group_ids").
+		attr("publicly_accessible", "false").
+		attr("final_snapshot_identifier", cloudName("", "postgres-final"))
+
+	// RDS only creates a single database, so the others are created through the Postgres provider.
+	// This requires the RDS instance to be reachable from where Terraform runs.
+	g.main = append(g.main, newBlock(`provider "postgresql"`).
+		attr("host", "aws_db_instance.postgres.address").
+		attr("port", "aws_db_instance.postgres.port").
+		attr("username", "aws_db_instance.postgres.username").
+		attr("password", "random_password.postgres.result").
+		attr("sslmode", quote("require")).
+		attr("superuser", "false"))
+
+	cluster := g.b.Infra.SQLCluster(&runtimev1.SQLCluster{Rid: "sql-cluster"})
+	cluster.SQLServer(&runtimev1.SQLServer{
+		Rid:  "sql-server",
+		Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+		Host: g.ref("aws_db_instance.postgres.endpoint"),
+	})
+	g.b.Infra.SQLRole(&runtimev1.SQLRole{
+		Rid:      "sql-role",
+		Username: postgresUser,
+		Password: g.embeddedRef("random_password.postgres.result"),
+	})
+	for _, db := range g.md.SqlDatabases {
+		g.resource("postgresql_database", label(db.Name)).
+			attr("name", quote(db.Name))
+		cluster.SQLDatabase(&runtimev1.SQLDatabase{
+			Rid:        "sql-database:" + db.Name,
+			EncoreName: db.Name,
+			CloudName:  g.ref(fmt.Sprintf("postgresql_database.%s.name", label(db.Name))),
+		}).AddConnectionPool(&runtimev1.SQLConnectionPool{RoleRid: "sql-role"})
+	}
+}
+
+func (g *generator) awsCaches() {
+	g.variable("cache_node_type", "string", "The node type of the ElastiCache clusters.", quote("cache.t4g.micro"))
+	g.variable("cache_subnet_group_name", "string", "The subnet group of the ElastiCache clusters.", "")
+	g.variable("cache_security_group_ids", "list(string)", "The security groups of the ElastiCache clusters, which must allow access from the app.", "")
+
+	g.b.Infra.RedisRole(&runtimev1.RedisRole{Rid: "redis-role"})
+	for _, cl := range g.md.CacheClusters {
+		name := label(cl.Name)
+		g.resource("aws_elasticache_cluster", name).
+			attr("cluster_id", cloudName("", cl.Name)).
+			attr("engine", quote("redis")).
+			attr("node_type", "var.cache_node_type").
+			attr("num_cache_nodes", "1").
+			attr("port", "6379").
+			attr("subnet_group_name", "var.cache_subnet_group_name").
+			attr("security_group_ids", "var.cache_security_group_ids")
+
+		cluster := g.b.Infra.RedisCluster(&runtimev1.RedisCluster{Rid: "redis-cluster:" + cl.Name})
+		cluster.RedisServer(&runtimev1.RedisServer{
+			Rid:  "redis-server:" + cl.Name,
+			Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+			Host: g.ref(fmt.Sprintf("aws_elasticache_cluster.%s.cache_nodes[0].address", name)) + ":6379",
+		})
+		cluster.RedisDatabase(&runtimev1.RedisDatabase{
+			Rid:        "redis-database:" + cl.Name,
+			EncoreName: cl.Name,
+		}).AddConnectionPool(&runtimev1.RedisConnectionPool{RoleRid: "redis-role"})
+	}
+}
+
+func (g *generator) awsPubSub() error {
+	cluster := g.b.Infra.PubSubCluster(&runtimev1.PubSubCluster{
+		Rid:      "pubsub-cluster",
+		Provider: &runtimev1.PubSubCluster_Aws{Aws: &runtimev1.PubSubCluster_AWSSqsSns{}},
+	})
+	for _, topic := range g.md.PubsubTopics {
+		// The runtime publishes with message group and deduplication ids
+		// for ordered and exactly-once topics, which require FIFO topics and queues.
+		fifo := topic.OrderingKey != "" || topic.DeliveryGuarantee == meta.PubSubTopic_EXACTLY_ONCE
+		suffix := ""
+		if fifo {
+			suffix = ".fifo"
+		}
+
+		topicName := label("topic", topic.Name)
+		t := g.resource("aws_sns_topic", topicName).
+			attr("name", cloudName(suffix, topic.Name))
+		if fifo {
+			t.attr("fifo_topic", "true")
+		}
+		rt, err := pubsubTopic(topic, g.ref(fmt.Sprintf("aws_sns_topic.%s.arn", topicName)), nil)
+		if err != nil {
+			return errors.Wrapf(err, "topic %s", topic.Name)
+		}
+		cluster.PubSubTopic(rt)
+
+		for _, sub := range topic.Subscriptions {
+			subName := label("sub", topic.Name, sub.Name)
+			q := g.resource("aws_sqs_queue", subName).
+				attr("name", cloudName(suffix, topic.Name, sub.Name)).
+				attr("visibility_timeout_seconds", fmt.Sprint(seconds(sub.AckDeadline, 0, 43200))).
+				attr("message_retention_seconds", fmt.Sprint(seconds(sub.MessageRetention, 60, 1209600)))
+			if fifo {
+				q.attr("fifo_queue", "true")
+			}
+
+			g.resource("aws_sqs_queue_policy", subName).
+				attr("queue_url", fmt.Sprintf("aws_sqs_queue.%s.id", subName)).
+				attr("policy", "jsonencode("+object(
+					"Version", quote("2012-10-17"),
+					"Statement", "["+object(
+						"Effect", quote("Allow"),
+						"Principal", object("Service", quote("sns.amazonaws.com")),
+						"Action", quote("sqs:SendMessage"),
+						"Resource", fmt.Sprintf("aws_sqs_queue.%s.arn", subName),
+						"Condition", object("ArnEquals", object(
+							quote("aws:SourceArn"), fmt.Sprintf("aws_sns_topic.%s.arn", topicName),
+						)),
+					)+"]",
+				)+")")
+
+			// The runtime expects messages in the SNS envelope, so raw message delivery is left disabled.
+			g.resource("aws_sns_topic_subscription", subName).
+				attr("topic_arn", fmt.Sprintf("aws_sns_topic.%s.arn", topicName)).
+				attr("protocol", quote("sqs")).
+				attr("endpoint", fmt.Sprintf("aws_sqs_queue.%s.arn", subName))
+
+			cluster.PubSubSubscription(&runtimev1.PubSubSubscription{
+				Rid:                    "pubsub-subscription:" + topic.Name + ":" + sub.Name,
+				TopicEncoreName:        topic.Name,
+				SubscriptionEncoreName: sub.Name,
+				TopicCloudName:         rt.CloudName,
+				SubscriptionCloudName:  g.ref(fmt.Sprintf("aws_sqs_queue.%s.url", subName)),
+			})
+		}
+	}
+	return nil
+}
+
+func (g *generator) awsCrons() error {
+	// EventBridge connections require authorization, so send a random API key.
+	// The app doesn't check it, so access to the cron target should be restricted by other means.
+	g.resource("random_password", "cron").
+		attr("length", "32").
+		attr("special", "false")
+	conn := g.resource("aws_cloudwatch_event_connection", "cron").
+		attr("name", cloudName("", "cron")).
+		attr("authorization_type", quote("API_KEY"))
+	conn.block("auth_parameters").block("api_key").
+		attr("key", quote("X-Encore-Cron-Key")).
+		attr("value", "random_password.cron.result")
+
+	g.resource("aws_iam_role", "cron").
+		attr("name", cloudName("", "cron")).
+		attr("assume_role_policy", "jsonencode("+object(
+			"Version", quote("2012-10-17"),
+			"Statement", "["+object(
+				"Effect", quote("Allow"),
+				"Principal", object("Service", quote("events.amazonaws.com")),
+				"Action", quote("sts:AssumeRole"),
+			)+"]",
+		)+")")
+
+	var destinations []string
+	for _, job := range g.jobs {
+		schedules, err := cronSchedules(job.Schedule)
+		if err != nil {
+			return errors.Wrapf(err, "cron job %s", job.ID)
+		}
+
+		name := label("cron", job.ID)
+		destinations = append(destinations, fmt.Sprintf("aws_cloudwatch_event_api_destination.%s.arn", name))
+		g.resource("aws_cloudwatch_event_api_destination", name).
+			attr("name", cloudName("", job.ID)).
+			attr("connection_arn", "aws_cloudwatch_event_connection.cron.arn").
+			attr("invocation_endpoint", `"${local.cron_target_url}`+quote(job.Path)[1:]).
+			attr("http_method", quote("POST"))
+
+		for i, sched := range schedules {
+			expr, err := awsSchedule(sched)
+			if err != nil {
+				return errors.Wrapf(err, "cron job %s", job.ID)
+			}
+			ruleName, ruleCloudName := name, cloudName("", job.ID)
+			if len(schedules) > 1 {
+				n := fmt.Sprint(i + 1)
+				ruleName, ruleCloudName = label("cron", job.ID, n), cloudName("", job.ID, n)
+			}
+			g.resource("aws_cloudwatch_event_rule", ruleName).
+				attr("name", ruleCloudName).
+				attr("description", quote(job.Title)).
+				attr("schedule_expression", quote(expr))
+			g.resource("aws_cloudwatch_event_target", ruleName).
+				attr("rule", fmt.Sprintf("aws_cloudwatch_event_rule.%s.name", ruleName)).
+				attr("arn", fmt.Sprintf("aws_cloudwatch_event_api_destination.%s.arn", name)).
+				attr("role_arn", "aws_iam_role.cron.arn")
+		}
+	}
+
+	g.resource("aws_iam_role_policy", "cron").
+		attr("role", "aws_iam_role.cron.id").
+		attr("policy", "jsonencode("+object(
+			"Version", quote("2012-10-17"),
+			"Statement", "["+object(
+				"Effect", quote("Allow"),
+				"Action", quote("events:InvokeApiDestination"),
+				"Resource", list(destinations),
+			)+"]",
+		)+")")
+	return nil
+}
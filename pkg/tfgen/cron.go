@@ -0,0 +1,145 @@
+package tfgen
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// cronSchedules returns the cron expressions running a job with the given Encore schedule.
+//
+// "every" schedules are aligned to midnight UTC, so they may need several
+// cron expressions when the interval doesn't divide an hour or a day evenly.
+func cronSchedules(schedule string) ([]string, error) {
+	kind, val, _ := strings.Cut(schedule, ":")
+	switch kind {
+	case "schedule":
+		if len(strings.Fields(val)) != 5 {
+			return nil, errors.Newf("invalid schedule %q", schedule)
+		}
+		return []string{val}, nil
+	case "every":
+	default:
+		return nil, errors.Newf("invalid schedule %q", schedule)
+	}
+
+	every, err := strconv.Atoi(val)
+	if err != nil || every <= 0 {
+		return nil, errors.Newf("invalid schedule %q", schedule)
+	}
+
+	// Group the hours of each minute of the runs of a day,
+	// and then the minutes running at the same hours.
+	hoursByMinute := make(map[int][]int)
+	for t := 0; t < 24*60; t += every {
+		hoursByMinute[t%60] = append(hoursByMinute[t%60], t/60)
+	}
+	var groups [][2][]int // minutes, hours
+	for minute := 0; minute < 60; minute++ {
+		hours, ok := hoursByMinute[minute]
+		if !ok {
+			continue
+		}
+		idx := slices.IndexFunc(groups, func(g [2][]int) bool { return slices.Equal(g[1], hours) })
+		if idx < 0 {
+			groups = append(groups, [2][]int{nil, hours})
+			idx = len(groups) - 1
+		}
+		groups[idx][0] = append(groups[idx][0], minute)
+	}
+
+	exprs := make([]string, len(groups))
+	for i, g := range groups {
+		exprs[i] = cronField(g[0], 60) + " " + cronField(g[1], 24) + " * * *"
+	}
+	return exprs, nil
+}
+
+// cronField formats the values of a cron field ranging from 0 to n-1.
+func cronField(values []int, n int) string {
+	if len(values) == n {
+		return "*"
+	}
+	if len(values) > 1 && values[0] == 0 && n%values[1] == 0 && len(values) == n/values[1] {
+		step := values[1]
+		regular := true
+		for i, v := range values {
+			regular = regular && v == i*step
+		}
+		if regular {
+			return "*/" + strconv.Itoa(step)
+		}
+	}
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = strconv.Itoa(v)
+	}
+	return strings.Join(strs, ",")
+}
+
+// awsDays are the names of the days of the week in AWS cron expressions,
+// indexed by their numbers in standard cron expressions.
+var awsDays = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+
+// awsSchedule converts a standard cron expression to an EventBridge schedule expression.
+//
+// EventBridge cron expressions have a year field, require either the day of month
+// or the day of week to be "?", and number the days of the week from 1.
+func awsSchedule(expr string) (string, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return "", errors.Newf("invalid cron expression %q", expr)
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	switch {
+	case dow == "*":
+		dow = "?"
+	case dom == "*":
+		dom = "?"
+		var err error
+		if dow, err = awsDaysOfWeek(dow); err != nil {
+			return "", errors.Wrapf(err, "invalid cron expression %q", expr)
+		}
+	default:
+		return "", errors.Newf("cron expression %q restricts both the day of month and the day of week, which is not supported on AWS", expr)
+	}
+
+	// EventBridge requires a start value for increments.
+	minute = strings.ReplaceAll(minute, "*/", "0/")
+	hour = strings.ReplaceAll(hour, "*/", "0/")
+	dom = strings.ReplaceAll(dom, "*/", "1/")
+	month = strings.ReplaceAll(month, "*/", "1/")
+
+	return "cron(" + strings.Join([]string{minute, hour, dom, month, dow, "*"}, " ") + ")", nil
+}
+
+// awsDaysOfWeek converts the numbered days of a day of week field to their names.
+func awsDaysOfWeek(field string) (string, error) {
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		days, step, hasStep := strings.Cut(item, "/")
+		if days == "*" {
+			// Days are numbered from 1 (Sunday) in increments.
+			days = "1"
+		} else {
+			parts := strings.Split(days, "-")
+			for j, p := range parts {
+				if n, err := strconv.Atoi(p); err == nil {
+					if n < 0 || n >= len(awsDays) {
+						return "", errors.Newf("invalid day of week %d", n)
+					}
+					parts[j] = awsDays[n]
+				}
+			}
+			days = strings.Join(parts, "-")
+		}
+		if hasStep {
+			days += "/" + step
+		}
+		items[i] = days
+	}
+	return strings.Join(items, ","), nil
+}
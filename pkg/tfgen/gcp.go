@@ -0,0 +1,206 @@
+package tfgen
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// gcp generates the configuration provisioning the app's infrastructure on GCP:
+// a Cloud SQL instance for its databases, a Memorystore instance for each cache cluster,
+// Pub/Sub topics and subscriptions, and Cloud Scheduler jobs for cron jobs.
+func (g *generator) gcp() error {
+	md := g.md
+	g.baseConfig(runtimev1.Environment_CLOUD_GCP)
+
+	providers := []provider{{"google", "hashicorp/google", "~> 5.0"}}
+	if len(md.SqlDatabases) > 0 {
+		providers = append(providers, randomProvider)
+	}
+	g.main = append(g.main, terraformBlock(providers))
+	g.main = append(g.main, newBlock(`provider "google"`).
+		attr("project", "var.project").
+		attr("region", "var.region"))
+
+	g.variable("project", "string", "The GCP project to provision the infrastructure in.", "")
+	g.variable("region", "string", "The GCP region to provision the infrastructure in.", "")
+	g.commonVariables()
+	if len(md.SqlDatabases) > 0 || len(md.CacheClusters) > 0 {
+		g.variable("network", "string", "The self link of the VPC network the app runs in, which must have private services access configured.", "")
+	}
+	g.locals()
+
+	if len(md.SqlDatabases) > 0 {
+		g.gcpDatabases()
+	}
+	if len(md.CacheClusters) > 0 {
+		g.gcpCaches()
+	}
+	if len(md.PubsubTopics) > 0 {
+		if err := g.gcpPubSub(); err != nil {
+			return err
+		}
+	}
+	if len(g.jobs) > 0 {
+		if err := g.gcpCrons(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *generator) gcpDatabases() {
+	g.variable("db_tier", "string", "The machine tier of the Cloud SQL instance.", quote("db-custom-1-3840"))
+
+	g.resource("random_password", "postgres").
+		attr("length", "32").
+		attr("special", "false")
+	inst := g.resource("google_sql_database_instance", "postgres").
+		attr("name", cloudName("", "postgres")).
+		attr("database_version", quote("POSTGRES_16")).
+		attr("region", "var.region").
+		attr("deletion_protection", "true")
+	settings := inst.block("settings").attr("tier", "var.db_tier")
+	settings.block("ip_configuration").
+		attr("ipv4_enabled", "false").
+		attr("private_network", "var.network")
+	g.resource("google_sql_user", "encore").
+		attr("name", quote(postgresUser)).
+		attr("instance", "google_sql_database_instance.postgres.name").
+		attr("password", "random_password.postgres.result")
+
+	cluster := g.b.Infra.SQLCluster(&runtimev1.SQLCluster{Rid: "sql-cluster"})
+	cluster.SQLServer(&runtimev1.SQLServer{
+		Rid:  "sql-server",
+		Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+		Host: g.ref("google_sql_database_instance.postgres.private_ip_address") + ":5432",
+	})
+	g.b.Infra.SQLRole(&runtimev1.SQLRole{
+		Rid:      "sql-role",
+		Username: g.ref("google_sql_user.encore.name"),
+		Password: g.embeddedRef("random_password.postgres.result"),
+	})
+	for _, db := range g.md.SqlDatabases {
+		name := label(db.Name)
+		g.resource("google_sql_database", name).
+			attr("name", quote(db.Name)).
+			attr("instance", "google_sql_database_instance.postgres.name")
+		cluster.SQLDatabase(&runtimev1.SQLDatabase{
+			Rid:        "sql-database:" + db.Name,
+			EncoreName: db.Name,
+			CloudName:  g.ref(fmt.Sprintf("google_sql_database.%s.name", name)),
+		}).AddConnectionPool(&runtimev1.SQLConnectionPool{RoleRid: "sql-role"})
+	}
+}
+
+func (g *generator) gcpCaches() {
+	g.variable("cache_memory_size_gb", "number", "The memory size of the Memorystore instances, in GiB.", "1")
+
+	g.b.Infra.RedisRole(&runtimev1.RedisRole{Rid: "redis-role"})
+	for _, cl := range g.md.CacheClusters {
+		name := label(cl.Name)
+		g.resource("google_redis_instance", name).
+			attr("name", cloudName("", cl.Name)).
+			attr("tier", quote("BASIC")).
+			attr("memory_size_gb", "var.cache_memory_size_gb").
+			attr("region", "var.region").
+			attr("redis_version", quote("REDIS_7_0")).
+			attr("authorized_network", "var.network").
+			attr("connect_mode", quote("PRIVATE_SERVICE_ACCESS"))
+
+		cluster := g.b.Infra.RedisCluster(&runtimev1.RedisCluster{Rid: "redis-cluster:" + cl.Name})
+		cluster.RedisServer(&runtimev1.RedisServer{
+			Rid:  "redis-server:" + cl.Name,
+			Kind: runtimev1.ServerKind_SERVER_KIND_PRIMARY,
+			Host: g.ref(fmt.Sprintf("google_redis_instance.%s.host", name)) + ":" +
+				g.ref(fmt.Sprintf("google_redis_instance.%s.port", name)),
+		})
+		cluster.RedisDatabase(&runtimev1.RedisDatabase{
+			Rid:        "redis-database:" + cl.Name,
+			EncoreName: cl.Name,
+		}).AddConnectionPool(&runtimev1.RedisConnectionPool{RoleRid: "redis-role"})
+	}
+}
+
+func (g *generator) gcpPubSub() error {
+	cluster := g.b.Infra.PubSubCluster(&runtimev1.PubSubCluster{
+		Rid:      "pubsub-cluster",
+		Provider: &runtimev1.PubSubCluster_Gcp{Gcp: &runtimev1.PubSubCluster_GCPPubSub{}},
+	})
+	for _, topic := range g.md.PubsubTopics {
+		topicName := label("topic", topic.Name)
+		g.resource("google_pubsub_topic", topicName).
+			attr("name", cloudName("", topic.Name))
+		rt, err := pubsubTopic(topic, g.ref(fmt.Sprintf("google_pubsub_topic.%s.name", topicName)),
+			&runtimev1.PubSubTopic_GCPConfig{ProjectId: g.ref("var.project")})
+		if err != nil {
+			return errors.Wrapf(err, "topic %s", topic.Name)
+		}
+		cluster.PubSubTopic(rt)
+
+		for _, sub := range topic.Subscriptions {
+			subName := label("sub", topic.Name, sub.Name)
+			s := g.resource("google_pubsub_subscription", subName).
+				attr("name", cloudName("", topic.Name, sub.Name)).
+				attr("topic", fmt.Sprintf("google_pubsub_topic.%s.id", topicName)).
+				attr("ack_deadline_seconds", fmt.Sprint(seconds(sub.AckDeadline, 10, 600))).
+				attr("message_retention_duration", quote(fmt.Sprintf("%ds", seconds(sub.MessageRetention, 600, 604800))))
+			if topic.OrderingKey != "" {
+				s.attr("enable_message_ordering", "true")
+			}
+			if topic.DeliveryGuarantee == meta.PubSubTopic_EXACTLY_ONCE {
+				s.attr("enable_exactly_once_delivery", "true")
+			}
+			if rp := sub.RetryPolicy; rp != nil && (rp.MinBackoff > 0 || rp.MaxBackoff > 0) {
+				retry := s.block("retry_policy")
+				if rp.MinBackoff > 0 {
+					retry.attr("minimum_backoff", quote(fmt.Sprintf("%ds", seconds(rp.MinBackoff, 0, 600))))
+				}
+				if rp.MaxBackoff > 0 {
+					retry.attr("maximum_backoff", quote(fmt.Sprintf("%ds", seconds(rp.MaxBackoff, 0, 600))))
+				}
+			}
+
+			cluster.PubSubSubscription(&runtimev1.PubSubSubscription{
+				Rid:                    "pubsub-subscription:" + topic.Name + ":" + sub.Name,
+				TopicEncoreName:        topic.Name,
+				SubscriptionEncoreName: sub.Name,
+				TopicCloudName:         rt.CloudName,
+				SubscriptionCloudName:  g.ref(fmt.Sprintf("google_pubsub_subscription.%s.name", subName)),
+				ProviderConfig: &runtimev1.PubSubSubscription_GcpConfig{
+					GcpConfig: &runtimev1.PubSubSubscription_GCPConfig{ProjectId: g.ref("var.project")},
+				},
+			})
+		}
+	}
+	return nil
+}
+
+func (g *generator) gcpCrons() error {
+	for _, job := range g.jobs {
+		schedules, err := cronSchedules(job.Schedule)
+		if err != nil {
+			return errors.Wrapf(err, "cron job %s", job.ID)
+		}
+		for i, sched := range schedules {
+			name, jobCloudName := label("cron", job.ID), cloudName("", job.ID)
+			if len(schedules) > 1 {
+				n := fmt.Sprint(i + 1)
+				name, jobCloudName = label("cron", job.ID, n), cloudName("", job.ID, n)
+			}
+			j := g.resource("google_cloud_scheduler_job", name).
+				attr("name", jobCloudName).
+				attr("description", quote(job.Title)).
+				attr("schedule", quote(sched)).
+				attr("time_zone", quote("Etc/UTC")).
+				attr("region", "var.region")
+			j.block("http_target").
+				attr("http_method", quote("POST")).
+				attr("uri", `"${local.cron_target_url}`+quote(job.Path)[1:])
+		}
+	}
+	return nil
+}
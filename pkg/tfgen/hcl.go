@@ -0,0 +1,123 @@
+package tfgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// block is a Terraform block, written in the format of "terraform fmt".
+type block struct {
+	header string
+	items  []any // *attribute or *block
+}
+
+type attribute struct {
+	name  string
+	value string // a Terraform expression, with lines after the first relative to the attribute
+}
+
+func newBlock(format string, args ...any) *block {
+	return &block{header: fmt.Sprintf(format, args...)}
+}
+
+// attr adds an attribute to the block.
+func (b *block) attr(name, value string) *block {
+	b.items = append(b.items, &attribute{name: name, value: value})
+	return b
+}
+
+// block adds a nested block to the block.
+func (b *block) block(format string, args ...any) *block {
+	nested := newBlock(format, args...)
+	b.items = append(b.items, nested)
+	return nested
+}
+
+func (b *block) write(w *strings.Builder, indent string) {
+	w.WriteString(indent + b.header + " {\n")
+	inner := indent + "  "
+	for i := 0; i < len(b.items); {
+		if nested, ok := b.items[i].(*block); ok {
+			if i > 0 {
+				w.WriteString("\n")
+			}
+			nested.write(w, inner)
+			i++
+			continue
+		}
+
+		// Align the equals signs of consecutive attributes, up to and including
+		// the first one with a multi-line value.
+		end := i
+		width := 0
+		for end < len(b.items) {
+			a, ok := b.items[end].(*attribute)
+			if !ok {
+				break
+			}
+			width = max(width, len(a.name))
+			end++
+			if strings.Contains(a.value, "\n") {
+				break
+			}
+		}
+		if i > 0 {
+			if _, ok := b.items[i-1].(*block); ok {
+				w.WriteString("\n")
+			}
+		}
+		for _, item := range b.items[i:end] {
+			a := item.(*attribute)
+			value := strings.ReplaceAll(a.value, "\n", "\n"+inner)
+			fmt.Fprintf(w, "%s%-*s = %s\n", inner, width, a.name, value)
+		}
+		i = end
+	}
+	w.WriteString(indent + "}\n")
+}
+
+// writeBlocks writes the blocks of a file, separated by blank lines.
+func writeBlocks(blocks []*block) []byte {
+	var w strings.Builder
+	for i, b := range blocks {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		b.write(&w, "")
+	}
+	return []byte(w.String())
+}
+
+// quote quotes s as a Terraform string, escaping template sequences.
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(strings.TrimSuffix(buf.String(), "\n"))
+}
+
+// object formats a Terraform object with the given attributes,
+// given as alternating names and values.
+func object(attrs ...string) string {
+	b := &block{}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		b.attr(attrs[i], attrs[i+1])
+	}
+	var w strings.Builder
+	b.write(&w, "")
+	return strings.TrimSuffix(strings.TrimPrefix(w.String(), " "), "\n")
+}
+
+// list formats a Terraform list with the given values.
+func list(values []string) string {
+	var w strings.Builder
+	w.WriteString("[\n")
+	for _, v := range values {
+		w.WriteString("  " + strings.ReplaceAll(v, "\n", "\n  ") + ",\n")
+	}
+	w.WriteString("]")
+	return w.String()
+}
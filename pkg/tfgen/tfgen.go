@@ -0,0 +1,367 @@
+// Package tfgen generates Terraform configurations provisioning the infrastructure
+// of Encore apps on AWS or GCP, for self-hosting them.
+package tfgen
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"encr.dev/cli/daemon/crons"
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/composegen"
+	"encr.dev/pkg/infraconf"
+	"encr.dev/pkg/rtconfgen"
+	meta "encr.dev/proto/encore/parser/meta/v1"
+	runtimev1 "encr.dev/proto/encore/runtime/v1"
+)
+
+// The names of the generated files.
+const (
+	MainFile      = "main.tf"
+	VariablesFile = "variables.tf"
+	OutputsFile   = "outputs.tf"
+)
+
+// InfraConfigOutput is the name of the Terraform output holding the runtime configuration
+// of the app, in the same format as the infra config generated by "encore build compose".
+const InfraConfigOutput = "infra_config"
+
+// Cloud is a cloud provider to generate Terraform for.
+type Cloud string
+
+const (
+	CloudAWS Cloud = "aws"
+	CloudGCP Cloud = "gcp"
+)
+
+// postgresUser is the name of the Postgres user the app connects as.
+const postgresUser = "encore"
+
+// Params are the parameters for generating a Terraform configuration.
+type Params struct {
+	// Meta is the metadata of the app.
+	Meta *meta.Data
+
+	// AppSlug is the slug of the app, used as the default prefix of the cloud resource names.
+	AppSlug string
+
+	// Lang is the language of the app. The runtime configuration expression uses the legacy
+	// format for Go apps, and TypeScript apps also get their secrets through it.
+	Lang appfile.Lang
+
+	// Cloud is the cloud provider to provision the infrastructure on.
+	Cloud Cloud
+
+	// CORS is the app's global CORS configuration, if any.
+	CORS *appfile.CORS
+
+	// DeployedAt is the time the configuration is generated at.
+	DeployedAt time.Time
+}
+
+// Generate generates a Terraform configuration provisioning the databases, caches,
+// Pub/Sub topics and subscriptions and cron job schedules of the app.
+//
+// The configuration outputs the runtime configuration of the app as InfraConfigOutput,
+// with the addresses and credentials of the provisioned infrastructure filled in.
+// The files are keyed by their names.
+func Generate(p Params) (files map[string][]byte, err error) {
+	if p.AppSlug == "" {
+		return nil, errors.New("no app slug")
+	}
+	jobs := crons.Jobs(p.Meta, time.Time{}, 0)
+	for _, job := range jobs {
+		if job.Path == "" {
+			return nil, errors.Newf("cron job %q has no endpoint", job.ID)
+		}
+	}
+
+	g := &generator{p: p, md: p.Meta, jobs: jobs, b: rtconfgen.NewBuilder()}
+	switch p.Cloud {
+	case CloudAWS:
+		err = g.aws()
+	case CloudGCP:
+		err = g.gcp()
+	default:
+		err = errors.Newf("unknown cloud %q", p.Cloud)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	infraConfig, err := g.infraConfig()
+	if err != nil {
+		return nil, err
+	}
+	outputs := newBlock(`output "%s"`, InfraConfigOutput)
+	outputs.attr("description", quote("The runtime configuration of the app, for use as its infra config."))
+	outputs.attr("sensitive", "true")
+	outputs.attr("value", "jsonencode("+infraConfig+")")
+
+	return map[string][]byte{
+		MainFile:      writeBlocks(g.main),
+		VariablesFile: writeBlocks(g.vars),
+		OutputsFile:   writeBlocks([]*block{outputs}),
+	}, nil
+}
+
+// generator generates the Terraform configuration and runtime configuration of an app.
+type generator struct {
+	p    Params
+	md   *meta.Data
+	jobs []*crons.Job
+	b    *rtconfgen.Builder
+
+	// main and vars are the blocks of the main and variables files.
+	main []*block
+	vars []*block
+
+	// refs are the Terraform expressions referenced by the runtime configuration,
+	// in the order of their placeholders.
+	refs []string
+}
+
+// provider is a provider required by the configuration.
+type provider struct {
+	name, source, version string
+}
+
+var randomProvider = provider{"random", "hashicorp/random", "~> 3.6"}
+
+// terraformBlock returns the terraform block requiring the given providers.
+func terraformBlock(providers []provider) *block {
+	b := newBlock("terraform")
+	req := b.block("required_providers")
+	for _, p := range providers {
+		req.attr(p.name, object("source", quote(p.source), "version", quote(p.version)))
+	}
+	return b
+}
+
+// resource adds a resource to the main file.
+func (g *generator) resource(typ, name string) *block {
+	b := newBlock(`resource "%s" "%s"`, typ, name)
+	g.main = append(g.main, b)
+	return b
+}
+
+// variable adds a variable to the variables file.
+// If def is empty the variable is required.
+func (g *generator) variable(name, typ, description, def string) {
+	b := newBlock(`variable "%s"`, name)
+	b.attr("description", quote(description))
+	b.attr("type", typ)
+	if def != "" {
+		b.attr("default", def)
+	}
+	g.vars = append(g.vars, b)
+}
+
+// commonVariables adds the variables used by both clouds.
+func (g *generator) commonVariables() {
+	g.variable("name_prefix", "string", "The prefix of the names of the cloud resources.", quote(ident(g.p.AppSlug, '-')))
+	g.variable("api_base_url", "string", "The URL the app's API gateway is reachable at.", "")
+	if len(g.jobs) > 0 {
+		g.variable("cron_target_url", "string", "The URL cron jobs call the app's endpoints at. Defaults to api_base_url.", "null")
+	}
+}
+
+// locals adds the local values used by both clouds.
+func (g *generator) locals() {
+	b := newBlock("locals")
+	b.attr("api_hostname", `regex("^[^:]+://([^/:]+)", var.api_base_url)[0]`)
+	if len(g.jobs) > 0 {
+		b.attr("cron_target_url", "trimsuffix(coalesce(var.cron_target_url, var.api_base_url), \"/\")")
+	}
+	g.main = append(g.main, b)
+}
+
+// ref returns a placeholder for the given Terraform expression,
+// which is replaced with the expression's value in the runtime configuration.
+func (g *generator) ref(expr string) string {
+	g.refs = append(g.refs, expr)
+	return placeholder(len(g.refs) - 1)
+}
+
+func placeholder(i int) string {
+	return fmt.Sprintf("__tfref%d__", i)
+}
+
+// embeddedRef returns secret data embedding the value of the given Terraform expression.
+func (g *generator) embeddedRef(expr string) *runtimev1.SecretData {
+	return &runtimev1.SecretData{Source: &runtimev1.SecretData_Embedded{Embedded: []byte(g.ref(expr))}}
+}
+
+// baseConfig configures the parts of the runtime configuration common to both clouds.
+func (g *generator) baseConfig(cloud runtimev1.Environment_Cloud) {
+	b := g.b
+	b.Env(&runtimev1.Environment{
+		AppId:   g.p.AppSlug,
+		AppSlug: g.p.AppSlug,
+		EnvId:   "terraform",
+		EnvName: "terraform",
+		EnvType: runtimev1.Environment_TYPE_PRODUCTION,
+		Cloud:   cloud,
+	})
+	b.EncorePlatform(&runtimev1.EncorePlatform{})
+	b.DeployID("terraform")
+	b.DeployedAt(g.p.DeployedAt)
+	b.AuthMethods([]*runtimev1.ServiceAuth{{
+		AuthMethod: &runtimev1.ServiceAuth_Noop{Noop: &runtimev1.ServiceAuth_NoopAuth{}},
+	}})
+	b.DefaultGracefulShutdown(&runtimev1.GracefulShutdown{
+		Total:         durationpb.New(10 * time.Second),
+		ShutdownHooks: durationpb.New(4 * time.Second),
+		Handlers:      durationpb.New(2 * time.Second),
+	})
+
+	for _, gw := range g.md.Gateways {
+		b.Infra.Gateway(&runtimev1.Gateway{
+			Rid:        "gateway:" + gw.EncoreName,
+			EncoreName: gw.EncoreName,
+			BaseUrl:    g.ref("var.api_base_url"),
+			Hostnames:  []string{g.ref("local.api_hostname")},
+			Cors:       composegen.GatewayCORS(g.p.CORS),
+		})
+	}
+
+	// TypeScript apps read their secrets from the runtime configuration,
+	// which refers to environment variables like with "encore build compose".
+	if g.p.Lang == appfile.LangTS {
+		for _, name := range infraconf.SecretNames(g.md) {
+			b.Infra.AppSecret(&runtimev1.AppSecret{
+				Rid:        "secret:" + name,
+				EncoreName: name,
+				Data:       &runtimev1.SecretData{Source: &runtimev1.SecretData_Env{Env: "ENCORE_SECRET_" + name}},
+			})
+		}
+	}
+}
+
+// pubsubTopic returns the runtime configuration of a topic with the given cloud name.
+func pubsubTopic(topic *meta.PubSubTopic, cloudName string, gcp *runtimev1.PubSubTopic_GCPConfig) (*runtimev1.PubSubTopic, error) {
+	var guarantee runtimev1.PubSubTopic_DeliveryGuarantee
+	switch topic.DeliveryGuarantee {
+	case meta.PubSubTopic_AT_LEAST_ONCE:
+		guarantee = runtimev1.PubSubTopic_DELIVERY_GUARANTEE_AT_LEAST_ONCE
+	case meta.PubSubTopic_EXACTLY_ONCE:
+		guarantee = runtimev1.PubSubTopic_DELIVERY_GUARANTEE_EXACTLY_ONCE
+	default:
+		return nil, errors.Newf("unknown delivery guarantee %q", topic.DeliveryGuarantee)
+	}
+	var orderingAttr *string
+	if topic.OrderingKey != "" {
+		orderingAttr = &topic.OrderingKey
+	}
+	t := &runtimev1.PubSubTopic{
+		Rid:               "pubsub-topic:" + topic.Name,
+		EncoreName:        topic.Name,
+		CloudName:         cloudName,
+		DeliveryGuarantee: guarantee,
+		OrderingAttr:      orderingAttr,
+	}
+	if gcp != nil {
+		t.ProviderConfig = &runtimev1.PubSubTopic_GcpConfig{GcpConfig: gcp}
+	}
+	return t, nil
+}
+
+// infraConfig generates the runtime configuration of a deployment hosting all services and gateways,
+// as a Terraform expression.
+func (g *generator) infraConfig() (string, error) {
+	d := g.b.Deployment("terraform").ServiceDiscovery(&runtimev1.ServiceDiscovery{
+		Services: make(map[string]*runtimev1.ServiceDiscovery_Location),
+	})
+	for _, gw := range g.md.Gateways {
+		d.HostsGateways(gw.EncoreName)
+	}
+	for _, svc := range g.md.Svcs {
+		d.HostsServices(svc.Name)
+	}
+	conf, err := d.BuildRuntimeConfig()
+	if err != nil {
+		return "", errors.Wrap(err, "generate runtime config")
+	}
+
+	// Go apps use the legacy runtime configuration, while TypeScript apps use the new one.
+	var data []byte
+	if g.p.Lang == appfile.LangTS {
+		// Indent the JSON ourselves, as protojson's output is deliberately unstable.
+		compact, err := protojson.Marshal(conf)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal runtime config")
+		}
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, compact, "", "  "); err != nil {
+			return "", errors.Wrap(err, "marshal runtime config")
+		}
+		data = buf.Bytes()
+	} else {
+		legacy, err := rtconfgen.ToLegacy(conf, nil)
+		if err != nil {
+			return "", errors.Wrap(err, "generate runtime config")
+		}
+		if data, err = json.MarshalIndent(legacy, "", "  "); err != nil {
+			return "", errors.Wrap(err, "marshal runtime config")
+		}
+	}
+	return g.jsonExpr(data), nil
+}
+
+// jsonExpr converts JSON data to the equivalent Terraform expression,
+// replacing the placeholders of references with template interpolations.
+//
+// JSON is valid Terraform syntax, except for strings containing template sequences,
+// which are escaped. Embedded secret data is base64-encoded by protojson,
+// so the encoded placeholders are replaced too.
+func (g *generator) jsonExpr(data []byte) string {
+	s := strings.NewReplacer("${", "$${", "%{", "%%{").Replace(string(data))
+	var oldnew []string
+	for i, expr := range g.refs {
+		ph := placeholder(i)
+		oldnew = append(oldnew,
+			ph, "${"+expr+"}",
+			base64.StdEncoding.EncodeToString([]byte(ph)), "${base64encode("+expr+")}",
+		)
+	}
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
+
+// ident converts s to a lowercase name consisting of letters, digits and sep.
+func ident(s string, sep rune) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return sep
+		}
+	}, s)
+	return strings.Trim(name, string(sep))
+}
+
+// label returns the Terraform label of a resource named after the given names.
+func label(names ...string) string {
+	return ident(strings.Join(names, "_"), '_')
+}
+
+// cloudName returns a Terraform string of the cloud name of a resource named after the given names,
+// prefixed with the name_prefix variable.
+func cloudName(suffix string, names ...string) string {
+	return `"${var.name_prefix}-` + ident(strings.Join(names, "-"), '-') + suffix + `"`
+}
+
+// seconds returns the number of whole seconds in the nanoseconds ns, clamped to [lo, hi].
+func seconds(ns int64, lo, hi int64) int64 {
+	return min(max(int64(time.Duration(ns)/time.Second), lo), hi)
+}
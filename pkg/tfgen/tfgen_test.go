@@ -0,0 +1,94 @@
+package tfgen
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/rogpeppe/go-internal/txtar"
+
+	"encr.dev/pkg/appfile"
+	"encr.dev/pkg/golden"
+	"encr.dev/pkg/infraconf/infraconftest"
+)
+
+func TestMain(m *testing.M) {
+	golden.TestMain(m)
+}
+
+func TestGenerate(t *testing.T) {
+	for _, cloud := range []Cloud{CloudAWS, CloudGCP} {
+		for _, lang := range []appfile.Lang{appfile.LangGo, appfile.LangTS} {
+			t.Run(string(cloud)+"_"+string(lang), func(t *testing.T) {
+				c := qt.New(t)
+				files, err := Generate(Params{
+					Meta:       infraconftest.Meta(),
+					AppSlug:    "shop",
+					Lang:       lang,
+					Cloud:      cloud,
+					DeployedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				})
+				c.Assert(err, qt.IsNil)
+
+				ar := &txtar.Archive{}
+				for name, data := range files {
+					ar.Files = append(ar.Files, txtar.File{Name: name, Data: data})
+				}
+				sort.Slice(ar.Files, func(i, j int) bool { return ar.Files[i].Name < ar.Files[j].Name })
+				golden.Test(t, string(txtar.Format(ar)))
+			})
+		}
+	}
+}
+
+func TestCronSchedules(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		schedule string
+		want     []string
+	}{
+		{"every:1", []string{"* * * * *"}},
+		{"every:15", []string{"*/15 * * * *"}},
+		{"every:60", []string{"0 * * * *"}},
+		{"every:180", []string{"0 */3 * * *"}},
+		{"every:1440", []string{"0 0 * * *"}},
+		{"every:90", []string{"0 */3 * * *", "30 1,4,7,10,13,16,19,22 * * *"}},
+		{"schedule:0 9 * * 1-5", []string{"0 9 * * 1-5"}},
+	}
+	for _, test := range tests {
+		got, err := cronSchedules(test.schedule)
+		c.Assert(err, qt.IsNil, qt.Commentf("schedule %q", test.schedule))
+		c.Assert(got, qt.DeepEquals, test.want, qt.Commentf("schedule %q", test.schedule))
+	}
+
+	_, err := cronSchedules("every:0")
+	c.Assert(err, qt.ErrorMatches, `invalid schedule "every:0"`)
+}
+
+func TestAWSSchedule(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		expr, want string
+	}{
+		{"*/15 * * * *", "cron(0/15 * * * ? *)"},
+		{"0 9 * * 1-5", "cron(0 9 ? * MON-FRI *)"},
+		{"0 9 * * 0,6", "cron(0 9 ? * SUN,SAT *)"},
+		{"0 0 1 * *", "cron(0 0 1 * ? *)"},
+		{"0 0 * * */2", "cron(0 0 ? * 1/2 *)"},
+	}
+	for _, test := range tests {
+		got, err := awsSchedule(test.expr)
+		c.Assert(err, qt.IsNil, qt.Commentf("expr %q", test.expr))
+		c.Assert(got, qt.Equals, test.want, qt.Commentf("expr %q", test.expr))
+	}
+
+	_, err := awsSchedule("0 0 1 * 1")
+	c.Assert(err, qt.ErrorMatches, `.*restricts both the day of month and the day of week.*`)
+}
+
+func TestGenerateUnknownCloud(t *testing.T) {
+	c := qt.New(t)
+	_, err := Generate(Params{Meta: infraconftest.Meta(), AppSlug: "shop", Cloud: "azure"})
+	c.Assert(err, qt.ErrorMatches, `unknown cloud "azure"`)
+}
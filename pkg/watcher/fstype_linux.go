@@ -0,0 +1,33 @@
+//go:build linux
+
+package watcher
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// File system magic numbers, as reported by statfs(2).
+const (
+	nfsMagic  = 0x6969
+	smbMagic  = 0x517b
+	smb2Magic = 0xfe534d42
+	cifsMagic = 0xff534d42
+	v9fsMagic = 0x01021997 // used for WSL2 mounts of Windows drives
+	fuseMagic = 0x65735546 // used for Docker Desktop and virtiofs volume mounts
+)
+
+// unreliableNotifications reports whether folder is on a file system
+// where inotify doesn't reliably report changes, typically because
+// the changes are made by another machine or by the host of a VM.
+func unreliableNotifications(folder string) bool {
+	var st unix.Statfs_t
+	if err := unix.Statfs(folder, &st); err != nil {
+		return false
+	}
+	switch uint32(st.Type) {
+	case nfsMagic, smbMagic, smb2Magic, cifsMagic, v9fsMagic, fuseMagic:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package watcher
+
+// unreliableNotifications reports whether folder is on a file system
+// where file system notifications don't reliably report changes.
+func unreliableNotifications(folder string) bool {
+	return false
+}
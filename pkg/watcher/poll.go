@@ -0,0 +1,148 @@
+package watcher
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fileState is the state of a file used to detect changes when polling.
+type fileState struct {
+	size    int64
+	modTime time.Time
+	mode    fs.FileMode
+	info    os.FileInfo
+}
+
+func (s fileState) changed(other fileState) bool {
+	return s.size != other.size || !s.modTime.Equal(other.modTime) || s.mode != other.mode
+}
+
+// snapshot is the state of the files within a polled folder, keyed by path.
+type snapshot map[string]fileState
+
+// shouldPoll reports whether folder should be polled for changes
+// rather than relying on file system notifications.
+func (w *Watcher) shouldPoll(folder string) bool {
+	switch w.mode {
+	case ModePoll:
+		return true
+	case ModeNotify:
+		return false
+	default:
+		if unreliableNotifications(folder) {
+			w.log.Info().Str("folder", folder).Msg("file system notifications are unreliable for folder, polling for changes instead")
+			return true
+		}
+		return false
+	}
+}
+
+// pollFolder starts polling folder for changes.
+func (w *Watcher) pollFolder(folder string) error {
+	folder = filepath.Clean(folder)
+	snap, err := scan(folder)
+	if err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for root := range w.pollRoots {
+		if root == folder || strings.HasPrefix(folder, root+string(filepath.Separator)) {
+			// Already polled as part of another folder.
+			return nil
+		}
+	}
+	w.pollRoots[folder] = snap
+	return nil
+}
+
+func (w *Watcher) pollForChanges() {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.poll()
+		}
+	}
+}
+
+// poll scans the polled folders and records the changes
+// since the previous scan.
+func (w *Watcher) poll() {
+	w.mutex.Lock()
+	roots := make([]string, 0, len(w.pollRoots))
+	for root := range w.pollRoots {
+		roots = append(roots, root)
+	}
+	w.mutex.Unlock()
+
+	for _, root := range roots {
+		curr, err := scan(root)
+		if err != nil {
+			w.log.Err(err).Str("folder", root).Msg("unable to scan folder for changes")
+			continue
+		}
+
+		w.mutex.Lock()
+		prev := w.pollRoots[root]
+		w.pollRoots[root] = curr
+		w.mutex.Unlock()
+
+		for path, st := range curr {
+			if old, ok := prev[path]; !ok {
+				w.recordEventInBatch(path, CREATED, st.info)
+			} else if st.changed(old) {
+				w.recordEventInBatch(path, MODIFIED, st.info)
+			}
+		}
+		for path := range prev {
+			if _, ok := curr[path]; !ok {
+				w.recordEventInBatch(path, DELETED, nil)
+			}
+		}
+	}
+}
+
+// scan walks folder and returns the state of the files within it,
+// skipping ignored folders.
+//
+// It only stats files rather than hashing their contents, which
+// keeps scans cheap even for large apps on slow file systems.
+func scan(folder string) (snapshot, error) {
+	snap := make(snapshot)
+	err := filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == folder {
+				return err
+			}
+			// The entry was likely removed while walking; skip it.
+			return nil
+		}
+		if d.IsDir() {
+			if IgnoreFolder(path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		snap[filepath.Clean(path)] = fileState{
+			size:    info.Size(),
+			modTime: info.ModTime(),
+			mode:    info.Mode(),
+			info:    info,
+		}
+		return nil
+	})
+	return snap, err
+}
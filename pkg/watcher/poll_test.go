@@ -0,0 +1,63 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestPoll(t *testing.T) {
+	c := qt.New(t)
+	root := c.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		c.Assert(os.MkdirAll(filepath.Dir(path), 0755), qt.IsNil)
+		c.Assert(os.WriteFile(path, []byte(content), 0644), qt.IsNil)
+	}
+	write("modified.go", "package foo")
+	write("deleted.go", "package foo")
+	write("node_modules/ignored.js", "")
+
+	w, err := NewWithOptions("test", Options{Mode: ModePoll, PollInterval: 10 * time.Millisecond})
+	c.Assert(err, qt.IsNil)
+	defer func() { _ = w.Close() }()
+	c.Assert(w.RecursivelyWatch(root), qt.IsNil)
+
+	write("modified.go", "package foo // changed")
+	write("sub/created.go", "package sub")
+	write("node_modules/ignored.js", "changed")
+	c.Assert(os.Remove(filepath.Join(root, "deleted.go")), qt.IsNil)
+
+	got := make(map[string]EventType)
+	deadline := time.After(5 * time.Second)
+	for len(got) < 3 {
+		events := make(chan []Event, 1)
+		go func() {
+			evs, _ := w.WaitForEvents()
+			events <- evs
+		}()
+		select {
+		case evs := <-events:
+			for _, ev := range evs {
+				rel, err := filepath.Rel(root, ev.Path)
+				c.Assert(err, qt.IsNil)
+				got[filepath.ToSlash(rel)] = ev.EventType
+			}
+		case <-deadline:
+			c.Fatalf("timed out waiting for events, got %v", got)
+		}
+	}
+
+	var paths []string
+	for path := range got {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	c.Assert(paths, qt.DeepEquals, []string{"deleted.go", "modified.go", "sub/created.go"})
+	c.Assert(got["deleted.go"], qt.Equals, DELETED)
+	c.Assert(got["sub/created.go"], qt.Equals, CREATED)
+}
@@ -15,6 +15,36 @@ import (
 	"encr.dev/pkg/eerror"
 )
 
+// Mode is a strategy for detecting file system changes.
+type Mode int
+
+const (
+	// ModeAuto uses file system notifications, except for folders on
+	// file systems where notifications are known to be unreliable
+	// (like network file systems and virtualized mounts), which are polled.
+	ModeAuto Mode = iota
+
+	// ModeNotify uses file system notifications.
+	ModeNotify
+
+	// ModePoll periodically scans watched folders for changes.
+	// It works on any file system, at the cost of latency and CPU usage.
+	ModePoll
+)
+
+// DefaultPollInterval is the default interval between scans when polling.
+const DefaultPollInterval = 1 * time.Second
+
+// Options configures a Watcher.
+type Options struct {
+	// Mode is the strategy for detecting changes.
+	Mode Mode
+
+	// PollInterval is the interval between scans when polling.
+	// If zero it defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
 type Watcher struct {
 	mutex          sync.Mutex
 	eventCond      *sync.Cond
@@ -24,36 +54,65 @@ type Watcher struct {
 	log     *zerolog.Logger
 	appRoot string
 
-	watcher     *fsnotify.Watcher
+	mode        Mode
+	watcher     *fsnotify.Watcher // nil if mode == ModePoll
 	directories map[string]struct{}
 	stop        chan struct{}
+
+	pollInterval time.Duration
+	pollRoots    map[string]snapshot // polled folders, with their latest snapshot
 }
 
+// New creates a new watcher using ModeAuto.
 func New(appID string) (*Watcher, error) {
-	fswatcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, eerror.Wrap(err, "watcher", "unable to create watcher", map[string]interface{}{"app": appID})
+	return NewWithOptions(appID, Options{})
+}
+
+// NewWithOptions creates a new watcher with the given options.
+func NewWithOptions(appID string, opts Options) (*Watcher, error) {
+	var fswatcher *fsnotify.Watcher
+	if opts.Mode != ModePoll {
+		var err error
+		fswatcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			return nil, eerror.Wrap(err, "watcher", "unable to create watcher", map[string]interface{}{"app": appID})
+		}
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
 	}
 
 	logger := log.With().Str("component", "watcher").Str("app", appID).Logger()
 	logger.Debug().Msg("File system watcher created")
 	w := &Watcher{
+		mode:           opts.Mode,
 		watcher:        fswatcher,
 		log:            &logger,
 		directories:    make(map[string]struct{}),
 		stop:           make(chan struct{}),
 		events:         nil,
 		signalDebounce: debounce.New(50 * time.Millisecond),
+		pollInterval:   opts.PollInterval,
+		pollRoots:      make(map[string]snapshot),
 	}
 
 	w.eventCond = sync.NewCond(&w.mutex)
 
-	go w.listenForChangeEvents()
+	if fswatcher != nil {
+		go w.listenForChangeEvents()
+	}
+	if opts.Mode != ModeNotify {
+		go w.pollForChanges()
+	}
 
 	return w, nil
 }
 
 func (w *Watcher) RecursivelyWatch(folder string) error {
+	if w.shouldPoll(folder) {
+		return w.pollFolder(folder)
+	}
+
 	return filepath.WalkDir(folder, func(path string, info os.DirEntry, err error) error {
 		if err != nil {
 			return eerror.Wrap(err, "watcher", "unable to walk directory", map[string]any{"path": path})
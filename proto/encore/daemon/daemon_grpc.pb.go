@@ -26,6 +26,8 @@ const (
 	Daemon_ExecScript_FullMethodName      = "/encore.daemon.Daemon/ExecScript"
 	Daemon_Check_FullMethodName           = "/encore.daemon.Daemon/Check"
 	Daemon_Export_FullMethodName          = "/encore.daemon.Daemon/Export"
+	Daemon_LanguageServer_FullMethodName  = "/encore.daemon.Daemon/LanguageServer"
+	Daemon_MCPConnect_FullMethodName      = "/encore.daemon.Daemon/MCPConnect"
 	Daemon_DBConnect_FullMethodName       = "/encore.daemon.Daemon/DBConnect"
 	Daemon_DBProxy_FullMethodName         = "/encore.daemon.Daemon/DBProxy"
 	Daemon_DBReset_FullMethodName         = "/encore.daemon.Daemon/DBReset"
@@ -39,6 +41,9 @@ const (
 	Daemon_DeleteNamespace_FullMethodName = "/encore.daemon.Daemon/DeleteNamespace"
 	Daemon_DumpMeta_FullMethodName        = "/encore.daemon.Daemon/DumpMeta"
 	Daemon_Telemetry_FullMethodName       = "/encore.daemon.Daemon/Telemetry"
+	Daemon_GC_FullMethodName              = "/encore.daemon.Daemon/GC"
+	Daemon_ListAuditLog_FullMethodName    = "/encore.daemon.Daemon/ListAuditLog"
+	Daemon_VerifyAuditLog_FullMethodName  = "/encore.daemon.Daemon/VerifyAuditLog"
 )
 
 // DaemonClient is the client API for Daemon service.
@@ -57,6 +62,12 @@ type DaemonClient interface {
 	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (Daemon_CheckClient, error)
 	// Export exports the app in various formats.
 	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (Daemon_ExportClient, error)
+	// LanguageServer serves the Encore language server for an app.
+	// The client forwards the editor's LSP byte stream to the daemon,
+	// and the daemon streams back the language server's output.
+	LanguageServer(ctx context.Context, opts ...grpc.CallOption) (Daemon_LanguageServerClient, error)
+	// MCPConnect returns the URL of the daemon's Model Context Protocol server for an app.
+	MCPConnect(ctx context.Context, in *MCPConnectRequest, opts ...grpc.CallOption) (*MCPConnectResponse, error)
 	// DBConnect starts the database and returns the DSN for connecting to it.
 	DBConnect(ctx context.Context, in *DBConnectRequest, opts ...grpc.CallOption) (*DBConnectResponse, error)
 	// DBProxy starts a local database proxy for connecting to remote databases
@@ -83,6 +94,13 @@ type DaemonClient interface {
 	DeleteNamespace(ctx context.Context, in *DeleteNamespaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DumpMeta(ctx context.Context, in *DumpMetaRequest, opts ...grpc.CallOption) (*DumpMetaResponse, error)
 	Telemetry(ctx context.Context, in *TelemetryConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GC garbage collects the data stored by the daemon according to
+	// its retention policy, and compacts the daemon database.
+	GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCResponse, error)
+	// ListAuditLog lists the entries in the audit log of an app run locally.
+	ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error)
+	// VerifyAuditLog verifies the hash chain of the audit log of an app run locally.
+	VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error)
 }
 
 type daemonClient struct {
@@ -262,6 +280,46 @@ func (x *daemonExportClient) Recv() (*CommandMessage, error) {
 	return m, nil
 }
 
+func (c *daemonClient) LanguageServer(ctx context.Context, opts ...grpc.CallOption) (Daemon_LanguageServerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[5], Daemon_LanguageServer_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &daemonLanguageServerClient{stream}
+	return x, nil
+}
+
+type Daemon_LanguageServerClient interface {
+	Send(*LanguageServerMessage) error
+	Recv() (*LanguageServerMessage, error)
+	grpc.ClientStream
+}
+
+type daemonLanguageServerClient struct {
+	grpc.ClientStream
+}
+
+func (x *daemonLanguageServerClient) Send(m *LanguageServerMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *daemonLanguageServerClient) Recv() (*LanguageServerMessage, error) {
+	m := new(LanguageServerMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *daemonClient) MCPConnect(ctx context.Context, in *MCPConnectRequest, opts ...grpc.CallOption) (*MCPConnectResponse, error) {
+	out := new(MCPConnectResponse)
+	err := c.cc.Invoke(ctx, Daemon_MCPConnect_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *daemonClient) DBConnect(ctx context.Context, in *DBConnectRequest, opts ...grpc.CallOption) (*DBConnectResponse, error) {
 	out := new(DBConnectResponse)
 	err := c.cc.Invoke(ctx, Daemon_DBConnect_FullMethodName, in, out, opts...)
@@ -272,7 +330,7 @@ func (c *daemonClient) DBConnect(ctx context.Context, in *DBConnectRequest, opts
 }
 
 func (c *daemonClient) DBProxy(ctx context.Context, in *DBProxyRequest, opts ...grpc.CallOption) (Daemon_DBProxyClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[5], Daemon_DBProxy_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[6], Daemon_DBProxy_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -304,7 +362,7 @@ func (x *daemonDBProxyClient) Recv() (*CommandMessage, error) {
 }
 
 func (c *daemonClient) DBReset(ctx context.Context, in *DBResetRequest, opts ...grpc.CallOption) (Daemon_DBResetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[6], Daemon_DBReset_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daemon_ServiceDesc.Streams[7], Daemon_DBReset_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -425,6 +483,33 @@ func (c *daemonClient) Telemetry(ctx context.Context, in *TelemetryConfig, opts
 	return out, nil
 }
 
+func (c *daemonClient) GC(ctx context.Context, in *GCRequest, opts ...grpc.CallOption) (*GCResponse, error) {
+	out := new(GCResponse)
+	err := c.cc.Invoke(ctx, Daemon_GC_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) ListAuditLog(ctx context.Context, in *ListAuditLogRequest, opts ...grpc.CallOption) (*ListAuditLogResponse, error) {
+	out := new(ListAuditLogResponse)
+	err := c.cc.Invoke(ctx, Daemon_ListAuditLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonClient) VerifyAuditLog(ctx context.Context, in *VerifyAuditLogRequest, opts ...grpc.CallOption) (*VerifyAuditLogResponse, error) {
+	out := new(VerifyAuditLogResponse)
+	err := c.cc.Invoke(ctx, Daemon_VerifyAuditLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DaemonServer is the server API for Daemon service.
 // All implementations must embed UnimplementedDaemonServer
 // for forward compatibility
@@ -441,6 +526,12 @@ type DaemonServer interface {
 	Check(*CheckRequest, Daemon_CheckServer) error
 	// Export exports the app in various formats.
 	Export(*ExportRequest, Daemon_ExportServer) error
+	// LanguageServer serves the Encore language server for an app.
+	// The client forwards the editor's LSP byte stream to the daemon,
+	// and the daemon streams back the language server's output.
+	LanguageServer(Daemon_LanguageServerServer) error
+	// MCPConnect returns the URL of the daemon's Model Context Protocol server for an app.
+	MCPConnect(context.Context, *MCPConnectRequest) (*MCPConnectResponse, error)
 	// DBConnect starts the database and returns the DSN for connecting to it.
 	DBConnect(context.Context, *DBConnectRequest) (*DBConnectResponse, error)
 	// DBProxy starts a local database proxy for connecting to remote databases
@@ -467,6 +558,13 @@ type DaemonServer interface {
 	DeleteNamespace(context.Context, *DeleteNamespaceRequest) (*emptypb.Empty, error)
 	DumpMeta(context.Context, *DumpMetaRequest) (*DumpMetaResponse, error)
 	Telemetry(context.Context, *TelemetryConfig) (*emptypb.Empty, error)
+	// GC garbage collects the data stored by the daemon according to
+	// its retention policy, and compacts the daemon database.
+	GC(context.Context, *GCRequest) (*GCResponse, error)
+	// ListAuditLog lists the entries in the audit log of an app run locally.
+	ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error)
+	// VerifyAuditLog verifies the hash chain of the audit log of an app run locally.
+	VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error)
 	mustEmbedUnimplementedDaemonServer()
 }
 
@@ -492,6 +590,12 @@ func (UnimplementedDaemonServer) Check(*CheckRequest, Daemon_CheckServer) error
 func (UnimplementedDaemonServer) Export(*ExportRequest, Daemon_ExportServer) error {
 	return status.Errorf(codes.Unimplemented, "method Export not implemented")
 }
+func (UnimplementedDaemonServer) LanguageServer(Daemon_LanguageServerServer) error {
+	return status.Errorf(codes.Unimplemented, "method LanguageServer not implemented")
+}
+func (UnimplementedDaemonServer) MCPConnect(context.Context, *MCPConnectRequest) (*MCPConnectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MCPConnect not implemented")
+}
 func (UnimplementedDaemonServer) DBConnect(context.Context, *DBConnectRequest) (*DBConnectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DBConnect not implemented")
 }
@@ -531,6 +635,15 @@ func (UnimplementedDaemonServer) DumpMeta(context.Context, *DumpMetaRequest) (*D
 func (UnimplementedDaemonServer) Telemetry(context.Context, *TelemetryConfig) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Telemetry not implemented")
 }
+func (UnimplementedDaemonServer) GC(context.Context, *GCRequest) (*GCResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GC not implemented")
+}
+func (UnimplementedDaemonServer) ListAuditLog(context.Context, *ListAuditLogRequest) (*ListAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditLog not implemented")
+}
+func (UnimplementedDaemonServer) VerifyAuditLog(context.Context, *VerifyAuditLogRequest) (*VerifyAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAuditLog not implemented")
+}
 func (UnimplementedDaemonServer) mustEmbedUnimplementedDaemonServer() {}
 
 // UnsafeDaemonServer may be embedded to opt out of forward compatibility for this service.
@@ -667,6 +780,50 @@ func (x *daemonExportServer) Send(m *CommandMessage) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Daemon_LanguageServer_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaemonServer).LanguageServer(&daemonLanguageServerServer{stream})
+}
+
+type Daemon_LanguageServerServer interface {
+	Send(*LanguageServerMessage) error
+	Recv() (*LanguageServerMessage, error)
+	grpc.ServerStream
+}
+
+type daemonLanguageServerServer struct {
+	grpc.ServerStream
+}
+
+func (x *daemonLanguageServerServer) Send(m *LanguageServerMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *daemonLanguageServerServer) Recv() (*LanguageServerMessage, error) {
+	m := new(LanguageServerMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Daemon_MCPConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MCPConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).MCPConnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_MCPConnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).MCPConnect(ctx, req.(*MCPConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Daemon_DBConnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DBConnectRequest)
 	if err := dec(in); err != nil {
@@ -907,6 +1064,60 @@ func _Daemon_Telemetry_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Daemon_GC_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).GC(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_GC_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).GC(ctx, req.(*GCRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_ListAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).ListAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_ListAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).ListAuditLog(ctx, req.(*ListAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daemon_VerifyAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServer).VerifyAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daemon_VerifyAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServer).VerifyAuditLog(ctx, req.(*VerifyAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Daemon_ServiceDesc is the grpc.ServiceDesc for Daemon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -918,6 +1129,10 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TestSpec",
 			Handler:    _Daemon_TestSpec_Handler,
 		},
+		{
+			MethodName: "MCPConnect",
+			Handler:    _Daemon_MCPConnect_Handler,
+		},
 		{
 			MethodName: "DBConnect",
 			Handler:    _Daemon_DBConnect_Handler,
@@ -962,6 +1177,18 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Telemetry",
 			Handler:    _Daemon_Telemetry_Handler,
 		},
+		{
+			MethodName: "GC",
+			Handler:    _Daemon_GC_Handler,
+		},
+		{
+			MethodName: "ListAuditLog",
+			Handler:    _Daemon_ListAuditLog_Handler,
+		},
+		{
+			MethodName: "VerifyAuditLog",
+			Handler:    _Daemon_VerifyAuditLog_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -989,6 +1216,12 @@ var Daemon_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Daemon_Export_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "LanguageServer",
+			Handler:       _Daemon_LanguageServer_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "DBProxy",
 			Handler:       _Daemon_DBProxy_Handler,
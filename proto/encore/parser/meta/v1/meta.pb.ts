@@ -57,6 +57,33 @@ export interface Package {
   /** RPCs called by the package */
   rpc_calls: QualifiedName[];
   trace_nodes: TraceNode[];
+  /** secrets whose values can be generated for local development */
+  generated_secrets: GeneratedSecret[];
+}
+
+/**
+ * GeneratedSecret is a secret whose value only needs to be random,
+ * so it can be generated for local development instead of being set.
+ */
+export interface GeneratedSecret {
+  /** the secret key */
+  key: string;
+  /** the shape of the value to generate */
+  shape: GeneratedSecret_Shape;
+}
+
+export enum GeneratedSecret_Shape {
+  /** ALNUM - 32 alphanumeric characters */
+  ALNUM = "ALNUM",
+  /** HEX - 32 random bytes, hex-encoded */
+  HEX = "HEX",
+  /** BASE64 - 32 random bytes, base64-encoded */
+  BASE64 = "BASE64",
+  /** UUID - a random (version 4) UUID */
+  UUID = "UUID",
+  /** BYTES - 32 random bytes, for []byte fields */
+  BYTES = "BYTES",
+  UNRECOGNIZED = "UNRECOGNIZED",
 }
 
 export interface Service {
@@ -0,0 +1,24 @@
+package v1
+
+import "strings"
+
+// Pattern returns the path the way it's declared, like "/users/:id".
+func (p *Path) Pattern() string {
+	var b strings.Builder
+	for _, seg := range p.GetSegments() {
+		b.WriteByte('/')
+		switch seg.Type {
+		case PathSegment_PARAM:
+			b.WriteByte(':')
+		case PathSegment_WILDCARD:
+			b.WriteByte('*')
+		case PathSegment_FALLBACK:
+			b.WriteByte('!')
+		}
+		b.WriteString(seg.Value)
+	}
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
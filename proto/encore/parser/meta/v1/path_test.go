@@ -0,0 +1,23 @@
+package v1
+
+import "testing"
+
+func TestPathPattern(t *testing.T) {
+	tests := []struct {
+		path *Path
+		want string
+	}{
+		{nil, "/"},
+		{&Path{Segments: []*PathSegment{
+			{Type: PathSegment_LITERAL, Value: "users"},
+			{Type: PathSegment_PARAM, Value: "id"},
+			{Type: PathSegment_WILDCARD, Value: "rest"},
+		}}, "/users/:id/*rest"},
+		{&Path{Segments: []*PathSegment{{Type: PathSegment_FALLBACK, Value: "fallback"}}}, "/!fallback"},
+	}
+	for _, test := range tests {
+		if got := test.path.Pattern(); got != test.want {
+			t.Errorf("got %q, want %q", got, test.want)
+		}
+	}
+}
@@ -9,13 +9,15 @@ use url::Url;
 
 use encore::runtime::v1 as pb;
 
+use crate::api::callopts::{CallOptions, CircuitBreakers};
 use crate::api::reqauth::caller::Caller;
 use crate::api::reqauth::meta::MetaKey;
 use crate::api::reqauth::{service_auth_method, svcauth};
 use crate::api::schema::{JSONPayload, ToOutgoingRequest};
 use crate::api::{schema, APIResult, Endpoint, EndpointMap};
-use crate::model::{SpanKey, TraceEventId};
+use crate::model::{LogField, LogFieldValue, SpanKey, TraceEventId};
 use crate::names::EndpointName;
+use crate::trace::protocol::LogMessageData;
 use crate::trace::Tracer;
 use crate::{api, encore, model, secrets, EncoreName, Hosted};
 
@@ -29,6 +31,7 @@ pub struct ServiceRegistry {
     tracer: Tracer,
     service_auth: HashMap<EncoreName, Arc<dyn svcauth::ServiceAuthMethod>>,
     deploy_id: String,
+    breakers: CircuitBreakers,
 }
 
 impl ServiceRegistry {
@@ -90,6 +93,7 @@ impl ServiceRegistry {
             tracer,
             service_auth,
             deploy_id,
+            breakers: CircuitBreakers::default(),
         })
     }
 
@@ -115,27 +119,135 @@ impl ServiceRegistry {
     pub async fn api_call(
         &self,
         endpoint_name: &EndpointName,
-        data: JSONPayload,
+        mut data: JSONPayload,
         source: Option<&model::Request>,
+        opts: &CallOptions,
     ) -> APIResult<JSONPayload> {
+        // Only retry calls to endpoints that are safe to call more than once.
+        let idempotent = self
+            .endpoints
+            .get(endpoint_name)
+            .is_some_and(|ep| ep.request[0].methods[0].is_idempotent());
+        let retry = opts.retry.as_ref().filter(|_| idempotent);
+        let max_attempts = retry.map_or(1, |r| r.max_attempts.max(1));
+
+        let mut attempt = 1;
+        loop {
+            let attempt_data = if attempt < max_attempts {
+                data.clone()
+            } else {
+                data.take()
+            };
+
+            let result = self
+                .api_call_attempt(endpoint_name, attempt_data, source, opts)
+                .await;
+
+            match (result, retry) {
+                (Err(AttemptError::Failed(err)), Some(retry))
+                    if attempt < max_attempts && retry.should_retry(&err) =>
+                {
+                    let backoff = retry.backoff(attempt);
+                    self.tracer.log_message(LogMessageData {
+                        source,
+                        msg: "retrying api call",
+                        level: ::log::Level::Warn,
+                        fields: Some(
+                            [
+                                LogField {
+                                    key: "endpoint",
+                                    value: LogFieldValue::String(&endpoint_name.to_string()),
+                                },
+                                LogField {
+                                    key: "attempt",
+                                    value: LogFieldValue::U64(attempt as u64),
+                                },
+                                LogField {
+                                    key: "code",
+                                    value: LogFieldValue::String(&err.code.to_string()),
+                                },
+                                LogField {
+                                    key: "backoff_ms",
+                                    value: LogFieldValue::U64(backoff.as_millis() as u64),
+                                },
+                            ]
+                            .into_iter(),
+                        ),
+                    });
+                    tokio::time::sleep(backoff).await;
+                    attempt += 1;
+                }
+                (result, _) => return result.map_err(AttemptError::into_inner),
+            }
+        }
+    }
+
+    /// Makes a single attempt at an API call, tracing it as a call of its own.
+    async fn api_call_attempt(
+        &self,
+        endpoint_name: &EndpointName,
+        data: JSONPayload,
+        source: Option<&model::Request>,
+        opts: &CallOptions,
+    ) -> Result<JSONPayload, AttemptError> {
         let call = model::APICall {
             source,
             target: endpoint_name,
         };
         let start_event_id = self.tracer.rpc_call_start(&call);
 
-        let result = self
-            .do_api_call(endpoint_name, data, source, start_event_id)
-            .await;
+        let result = match &opts.circuit_breaker {
+            Some(cfg) => match self.breakers.acquire(endpoint_name.service(), cfg) {
+                Ok(()) => {
+                    let result = self
+                        .timed_api_call(endpoint_name, data, source, start_event_id, opts)
+                        .await;
+                    self.breakers.record(endpoint_name.service(), cfg, &result);
+                    result.map_err(AttemptError::Failed)
+                }
+                Err(err) => Err(AttemptError::Rejected(err)),
+            },
+            None => self
+                .timed_api_call(endpoint_name, data, source, start_event_id, opts)
+                .await
+                .map_err(AttemptError::Failed),
+        };
 
         if let Some(start_event_id) = start_event_id {
-            self.tracer
-                .rpc_call_end(&call, start_event_id, result.as_ref().err());
+            let err = result.as_ref().err().map(AttemptError::inner);
+            self.tracer.rpc_call_end(&call, start_event_id, err);
         }
 
         result
     }
 
+    async fn timed_api_call(
+        &self,
+        endpoint_name: &EndpointName,
+        data: JSONPayload,
+        source: Option<&model::Request>,
+        start_event_id: Option<TraceEventId>,
+        opts: &CallOptions,
+    ) -> APIResult<JSONPayload> {
+        let fut = self.do_api_call(endpoint_name, data, source, start_event_id);
+        let Some(timeout) = opts.timeout else {
+            return fut.await;
+        };
+
+        match tokio::time::timeout(timeout, fut).await {
+            Ok(result) => result,
+            Err(_) => Err(api::Error {
+                code: api::ErrCode::DeadlineExceeded,
+                message: "api call timed out".into(),
+                internal_message: Some(format!(
+                    "call to endpoint {} timed out after {:?}",
+                    endpoint_name, timeout
+                )),
+                stack: None,
+            }),
+        }
+    }
+
     async fn do_api_call(
         &self,
         endpoint_name: &EndpointName,
@@ -218,6 +330,14 @@ impl ServiceRegistry {
 
         match self.http_client.execute(req).await {
             Ok(resp) => parse_api_response(resp).await,
+            // Report services that can't be reached as unavailable,
+            // so the call can be retried.
+            Err(e) if e.is_connect() => Err(api::Error {
+                code: api::ErrCode::Unavailable,
+                message: "service unavailable".into(),
+                internal_message: Some(format!("{:#?}", e)),
+                stack: None,
+            }),
             Err(e) => Err(api::Error::internal(e)),
         }
     }
@@ -407,3 +527,25 @@ where
         Ok(())
     }
 }
+
+/// The error of a single attempt at an API call.
+enum AttemptError {
+    /// The call was made and failed.
+    Failed(api::Error),
+    /// The call was rejected by the circuit breaker without being made.
+    Rejected(api::Error),
+}
+
+impl AttemptError {
+    fn inner(&self) -> &api::Error {
+        match self {
+            Self::Failed(err) | Self::Rejected(err) => err,
+        }
+    }
+
+    fn into_inner(self) -> api::Error {
+        match self {
+            Self::Failed(err) | Self::Rejected(err) => err,
+        }
+    }
+}
@@ -0,0 +1,269 @@
+//! Implements the timeouts, retries and circuit breaking of service-to-service calls.
+
+use std::collections::HashMap;
+use std::sync::Mutex;
+use std::time::{Duration, Instant};
+
+use rand::Rng;
+
+use crate::api;
+use crate::EncoreName;
+
+/// Options for an API call to another service.
+#[derive(Debug, Clone, Default)]
+pub struct CallOptions {
+    /// The maximum time each attempt may take.
+    pub timeout: Option<Duration>,
+
+    /// How to retry failed calls to idempotent endpoints.
+    pub retry: Option<RetryPolicy>,
+
+    /// Stops calling the target service after repeated failures.
+    pub circuit_breaker: Option<CircuitBreakerConfig>,
+}
+
+/// Describes how to retry a failed call.
+///
+/// Only calls to endpoints with idempotent methods are retried,
+/// and only when they fail with an `Unavailable` or `DeadlineExceeded` error.
+#[derive(Debug, Clone)]
+pub struct RetryPolicy {
+    /// The maximum number of attempts, including the first one.
+    pub max_attempts: u32,
+
+    /// The backoff before the first retry, doubling for each retry after that.
+    pub min_backoff: Duration,
+
+    /// The maximum backoff between attempts.
+    pub max_backoff: Duration,
+}
+
+impl Default for RetryPolicy {
+    fn default() -> Self {
+        Self {
+            max_attempts: 3,
+            min_backoff: Duration::from_millis(100),
+            max_backoff: Duration::from_secs(2),
+        }
+    }
+}
+
+impl RetryPolicy {
+    /// Reports whether a call that failed with err may be retried.
+    pub fn should_retry(&self, err: &api::Error) -> bool {
+        matches!(
+            err.code,
+            api::ErrCode::Unavailable | api::ErrCode::DeadlineExceeded
+        )
+    }
+
+    /// Returns the backoff before the given retry, starting at 1.
+    ///
+    /// The backoff grows exponentially and has "full jitter" applied,
+    /// so it's a random duration up to the exponential backoff.
+    pub fn backoff(&self, retry: u32) -> Duration {
+        let max = self.max_backoff_for(retry);
+        if max.is_zero() {
+            return max;
+        }
+        rand::thread_rng().gen_range(Duration::ZERO..=max)
+    }
+
+    fn max_backoff_for(&self, retry: u32) -> Duration {
+        let factor = 1u32 << retry.saturating_sub(1).min(31);
+        self.min_backoff
+            .checked_mul(factor)
+            .unwrap_or(self.max_backoff)
+            .min(self.max_backoff)
+    }
+}
+
+/// Configures a circuit breaker.
+///
+/// The breaker opens after `failure_threshold` consecutive failed calls,
+/// failing further calls immediately. After `reset_timeout` it lets
+/// a single trial call through, which closes it again if it succeeds.
+#[derive(Debug, Clone)]
+pub struct CircuitBreakerConfig {
+    pub failure_threshold: u32,
+    pub reset_timeout: Duration,
+}
+
+impl Default for CircuitBreakerConfig {
+    fn default() -> Self {
+        Self {
+            failure_threshold: 5,
+            reset_timeout: Duration::from_secs(30),
+        }
+    }
+}
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq)]
+enum BreakerState {
+    Closed { failures: u32 },
+    Open { until: Instant },
+    HalfOpen { until: Instant },
+}
+
+/// Tracks the circuit breaker state of each service.
+#[derive(Debug, Default)]
+pub struct CircuitBreakers {
+    states: Mutex<HashMap<EncoreName, BreakerState>>,
+}
+
+impl CircuitBreakers {
+    /// Reports whether a call to service may be made,
+    /// returning an `Unavailable` error if the breaker is open.
+    pub fn acquire(&self, service: &str, cfg: &CircuitBreakerConfig) -> api::APIResult<()> {
+        self.acquire_at(service, cfg, Instant::now())
+    }
+
+    /// Records the result of a call to service acquired with [`Self::acquire`].
+    pub fn record<T>(&self, service: &str, cfg: &CircuitBreakerConfig, result: &api::APIResult<T>) {
+        let failed = result.as_ref().err().is_some_and(is_failure);
+        self.record_at(service, cfg, failed, Instant::now())
+    }
+
+    fn acquire_at(
+        &self,
+        service: &str,
+        cfg: &CircuitBreakerConfig,
+        now: Instant,
+    ) -> api::APIResult<()> {
+        let mut states = self.states.lock().unwrap();
+        let state = states
+            .entry(EncoreName::from(service))
+            .or_insert(BreakerState::Closed { failures: 0 });
+
+        match *state {
+            BreakerState::Closed { .. } => Ok(()),
+
+            // Let a single trial call through once the reset timeout has passed.
+            // If the trial call never completes, let another one through
+            // after the reset timeout passes again.
+            BreakerState::Open { until } | BreakerState::HalfOpen { until } if now >= until => {
+                *state = BreakerState::HalfOpen {
+                    until: now + cfg.reset_timeout,
+                };
+                Ok(())
+            }
+
+            BreakerState::Open { .. } | BreakerState::HalfOpen { .. } => Err(api::Error {
+                code: api::ErrCode::Unavailable,
+                message: "service unavailable".into(),
+                internal_message: Some(format!("circuit breaker for service {} is open", service)),
+                stack: None,
+            }),
+        }
+    }
+
+    fn record_at(&self, service: &str, cfg: &CircuitBreakerConfig, failed: bool, now: Instant) {
+        let mut states = self.states.lock().unwrap();
+        let state = states
+            .entry(EncoreName::from(service))
+            .or_insert(BreakerState::Closed { failures: 0 });
+
+        *state = match (*state, failed) {
+            (_, false) => BreakerState::Closed { failures: 0 },
+            (BreakerState::Closed { failures }, true) if failures + 1 < cfg.failure_threshold => {
+                BreakerState::Closed {
+                    failures: failures + 1,
+                }
+            }
+            (_, true) => BreakerState::Open {
+                until: now + cfg.reset_timeout,
+            },
+        };
+    }
+}
+
+/// Reports whether err indicates the target service is failing,
+/// as opposed to the call being rejected.
+fn is_failure(err: &api::Error) -> bool {
+    matches!(
+        err.code,
+        api::ErrCode::Unavailable
+            | api::ErrCode::DeadlineExceeded
+            | api::ErrCode::Internal
+            | api::ErrCode::Unknown
+    )
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn err(code: api::ErrCode) -> api::Error {
+        api::Error {
+            code,
+            message: "".into(),
+            internal_message: None,
+            stack: None,
+        }
+    }
+
+    #[test]
+    fn test_backoff() {
+        let policy = RetryPolicy {
+            max_attempts: 10,
+            min_backoff: Duration::from_millis(100),
+            max_backoff: Duration::from_secs(1),
+        };
+        assert_eq!(policy.max_backoff_for(1), Duration::from_millis(100));
+        assert_eq!(policy.max_backoff_for(2), Duration::from_millis(200));
+        assert_eq!(policy.max_backoff_for(4), Duration::from_millis(800));
+        assert_eq!(policy.max_backoff_for(5), Duration::from_secs(1));
+        assert_eq!(policy.max_backoff_for(100), Duration::from_secs(1));
+
+        for retry in 1..10 {
+            assert!(policy.backoff(retry) <= policy.max_backoff_for(retry));
+        }
+    }
+
+    #[test]
+    fn test_should_retry() {
+        let policy = RetryPolicy::default();
+        assert!(policy.should_retry(&err(api::ErrCode::Unavailable)));
+        assert!(policy.should_retry(&err(api::ErrCode::DeadlineExceeded)));
+        assert!(!policy.should_retry(&err(api::ErrCode::Internal)));
+        assert!(!policy.should_retry(&err(api::ErrCode::InvalidArgument)));
+    }
+
+    #[test]
+    fn test_circuit_breaker() {
+        let breakers = CircuitBreakers::default();
+        let svc = "svc";
+        let cfg = CircuitBreakerConfig {
+            failure_threshold: 2,
+            reset_timeout: Duration::from_secs(10),
+        };
+        let now = Instant::now();
+
+        // Client errors don't count as failures.
+        breakers.record_at(svc, &cfg, false, now);
+        assert!(breakers.acquire_at(svc, &cfg, now).is_ok());
+
+        // The breaker opens after two consecutive failures.
+        breakers.record_at(svc, &cfg, true, now);
+        assert!(breakers.acquire_at(svc, &cfg, now).is_ok());
+        breakers.record_at(svc, &cfg, true, now);
+        let res = breakers.acquire_at(svc, &cfg, now);
+        assert_eq!(res.unwrap_err().code, api::ErrCode::Unavailable);
+
+        // After the reset timeout a single trial call is let through.
+        let later = now + Duration::from_secs(10);
+        assert!(breakers.acquire_at(svc, &cfg, later).is_ok());
+        assert!(breakers.acquire_at(svc, &cfg, later).is_err());
+
+        // A failed trial call opens the breaker again.
+        breakers.record_at(svc, &cfg, true, later);
+        assert!(breakers.acquire_at(svc, &cfg, later).is_err());
+
+        // A successful trial call closes it.
+        let later = later + Duration::from_secs(10);
+        assert!(breakers.acquire_at(svc, &cfg, later).is_ok());
+        breakers.record_at(svc, &cfg, false, later);
+        assert!(breakers.acquire_at(svc, &cfg, later).is_ok());
+        assert!(breakers.acquire_at(svc, &cfg, later).is_ok());
+    }
+}
@@ -146,6 +146,10 @@ pub struct Endpoint {
     /// The static assets to serve from this endpoint.
     /// Set only for static asset endpoints.
     pub static_assets: Option<meta::rpc::StaticAssets>,
+
+    /// The flow control settings for streaming endpoints.
+    /// If None, the defaults are used.
+    pub stream_flow_control: Option<meta::rpc::StreamFlowControl>,
 }
 
 impl Endpoint {
@@ -304,6 +308,7 @@ pub fn endpoints_from_meta(
             requires_auth: !ep.ep.allow_unauthenticated,
             body_limit: ep.ep.body_limit,
             static_assets: ep.ep.static_assets.clone(),
+            stream_flow_control: ep.ep.stream_flow_control.clone(),
         };
 
         endpoint_map.insert(
@@ -18,6 +18,7 @@ use pingora::services::Service;
 use pingora::upstreams::peer::HttpPeer;
 use pingora::{Error, ErrorSource, ErrorType, OkOrErr, OrErr};
 use tokio::sync::watch;
+use tokio_util::sync::CancellationToken;
 use url::Url;
 
 use crate::api::auth;
@@ -109,7 +110,9 @@ impl Gateway {
         self.inner.shared.auth.as_ref()
     }
 
-    pub async fn serve(self, listen_addr: &str) -> anyhow::Result<()> {
+    /// Serves the gateway until shutdown is cancelled, after which it
+    /// stops accepting connections and lets the ones in flight complete.
+    pub async fn serve(self, listen_addr: &str, shutdown: CancellationToken) -> anyhow::Result<()> {
         let conf = Arc::new(
             ServerConf::new_with_opt_override(&Opt {
                 upgrade: false,
@@ -124,7 +127,11 @@ impl Gateway {
 
         proxy.add_tcp(listen_addr);
 
-        let (_tx, rx) = watch::channel(false);
+        let (tx, rx) = watch::channel(false);
+        tokio::spawn(async move {
+            shutdown.cancelled().await;
+            let _ = tx.send(true);
+        });
         proxy
             .start_service(
                 #[cfg(unix)]
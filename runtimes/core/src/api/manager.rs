@@ -4,9 +4,11 @@ use std::sync::{Arc, Mutex};
 
 use anyhow::Context;
 use axum::response::IntoResponse;
+use tokio_util::sync::CancellationToken;
 
 use crate::api::auth::{LocalAuthHandler, RemoteAuthHandler};
 use crate::api::call::ServiceRegistry;
+use crate::api::callopts::CallOptions;
 use crate::api::gateway::Gateway;
 use crate::api::http_server::HttpServer;
 use crate::api::paths::Pather;
@@ -313,12 +315,18 @@ impl Manager {
         endpoint_name: &'a EndpointName,
         data: JSONPayload,
         source: Option<&'a model::Request>,
+        opts: &'a CallOptions,
     ) -> impl Future<Output = APIResult<JSONPayload>> + 'a {
-        self.service_registry.api_call(endpoint_name, data, source)
+        self.service_registry
+            .api_call(endpoint_name, data, source, opts)
     }
 
-    /// Starts serving the API.
-    pub fn start_serving(&self) -> tokio::task::JoinHandle<anyhow::Result<()>> {
+    /// Starts serving the API, until shutdown is cancelled.
+    /// The requests in flight then complete before it returns.
+    pub fn start_serving(
+        &self,
+        shutdown: CancellationToken,
+    ) -> tokio::task::JoinHandle<anyhow::Result<()>> {
         let api = self.api_server.as_ref().map(|srv| srv.router());
 
         async fn fallback(
@@ -351,7 +359,7 @@ impl Manager {
         self.runtime.spawn(async move {
             let gateway_parts = (gateway, gateway_listener);
             let gateway_fut = match gateway_parts {
-                (Some(gw), Some(ref ln)) => Some(gw.serve(ln)),
+                (Some(gw), Some(ref ln)) => Some(gw.serve(ln, shutdown.clone())),
                 (Some(_), None) => {
                     ::log::error!("internal encore error: misconfigured api gateway (missing listener), skipping");
                     None
@@ -370,7 +378,9 @@ impl Manager {
                         .context("unable to set nonblocking")?;
                     let axum_listener = tokio::net::TcpListener::from_std(ln)
                         .context("unable to convert listener to tokio")?;
-                    let fut = axum::serve(axum_listener, server).into_future();
+                    let fut = axum::serve(axum_listener, server)
+                        .with_graceful_shutdown(async move { shutdown.cancelled().await })
+                        .into_future();
                     Some(fut)
                 }
                 None => None,
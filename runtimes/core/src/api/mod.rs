@@ -1,5 +1,6 @@
 pub mod auth;
 pub mod call;
+pub mod callopts;
 mod cors;
 mod encore_routes;
 mod endpoint;
@@ -33,6 +33,14 @@ impl Method {
             Self::GET | Self::DELETE | Self::HEAD | Self::OPTIONS | Self::TRACE => false,
         }
     }
+
+    /// Whether requests with the method can be safely repeated.
+    pub fn is_idempotent(&self) -> bool {
+        match self {
+            Self::GET | Self::HEAD | Self::PUT | Self::DELETE | Self::OPTIONS | Self::TRACE => true,
+            Self::POST | Self::PATCH => false,
+        }
+    }
 }
 
 impl TryFrom<&str> for Method {
@@ -1,13 +1,16 @@
-use std::sync::Arc;
+use std::collections::VecDeque;
+use std::sync::{Arc, Mutex};
 
 use anyhow::{anyhow, Context};
-use axum::extract::ws::{Message, WebSocket};
+use axum::extract::ws::{close_code, CloseFrame, Message, WebSocket};
 use futures::Future;
 use tokio::sync::{
-    mpsc::{self, UnboundedReceiver, UnboundedSender},
-    watch,
+    mpsc::{self, Receiver, UnboundedSender},
+    Notify, Semaphore,
 };
 
+use crate::encore::parser::meta::v1::rpc;
+use crate::encore::parser::meta::v1::rpc::stream_flow_control::SlowConsumerPolicy;
 use crate::model::{self, Request, RequestData};
 
 use super::{schema, APIResult};
@@ -47,6 +50,12 @@ where
         .clone();
 
     let resp_schema = data.endpoint.response.clone();
+    let flow_control = data
+        .endpoint
+        .stream_flow_control
+        .as_ref()
+        .map(FlowControl::from)
+        .unwrap_or_default();
 
     let upgrade = {
         if let Some(upgrade) = data
@@ -70,7 +79,7 @@ where
         .protocols(["encore-ws"])
         .on_failed_upgrade(|err| log::debug!("websocket upgrade failed: {err}"))
         .on_upgrade(move |ws| async move {
-            let socket = Socket::new(ws, req_schema, resp_schema);
+            let socket = Socket::new(ws, req_schema, resp_schema, flow_control);
 
             let payload = match direction {
                 model::StreamDirection::InOut => StreamMessagePayload::InOut(socket),
@@ -81,7 +90,7 @@ where
                         match rx.recv().await {
                             Some(resp) => match resp {
                                 Ok(Some(resp)) => {
-                                    if sink.send(resp).is_err() {
+                                    if sink.send(resp).await.is_err() {
                                         log::debug!("sink channel closed");
                                     }
                                 }
@@ -104,11 +113,51 @@ where
         }))
 }
 
+type StreamMessage = serde_json::Map<String, serde_json::Value>;
+
+/// Flow control settings for a stream, limiting how many messages are buffered
+/// when one side sends messages faster than the other side reads them.
+#[derive(Debug, Clone, Copy)]
+pub struct FlowControl {
+    /// The maximum number of outgoing messages to buffer, or None for no limit.
+    max_buffered_messages: Option<usize>,
+
+    /// The number of buffered outgoing messages at which sending waits
+    /// for the buffer to drain, or None to never wait.
+    high_water_mark: Option<usize>,
+
+    /// What to do when the outgoing buffer is full.
+    slow_consumer: SlowConsumerPolicy,
+
+    /// The maximum number of incoming messages to buffer, or None for no limit.
+    max_buffered_incoming_messages: Option<usize>,
+}
+
+impl Default for FlowControl {
+    fn default() -> Self {
+        Self {
+            max_buffered_messages: None,
+            high_water_mark: None,
+            slow_consumer: SlowConsumerPolicy::Wait,
+            max_buffered_incoming_messages: None,
+        }
+    }
+}
+
+impl From<&rpc::StreamFlowControl> for FlowControl {
+    fn from(fc: &rpc::StreamFlowControl) -> Self {
+        Self {
+            max_buffered_messages: fc.max_buffered_messages.map(|n| n as usize),
+            high_water_mark: fc.high_water_mark.map(|n| n as usize),
+            slow_consumer: fc.slow_consumer(),
+            max_buffered_incoming_messages: fc.max_buffered_incoming_messages.map(|n| n as usize),
+        }
+    }
+}
+
 pub struct Socket {
-    outgoing_message_tx: UnboundedSender<serde_json::Map<String, serde_json::Value>>,
-    incoming_message_rx:
-        tokio::sync::Mutex<UnboundedReceiver<serde_json::Map<String, serde_json::Value>>>,
-    shutdown: watch::Sender<bool>,
+    outgoing: OutgoingHandle,
+    incoming_message_rx: tokio::sync::Mutex<Receiver<StreamMessage>>,
 }
 
 fn get_message_payload(msg: &Message) -> Option<&[u8]> {
@@ -125,32 +174,53 @@ impl Socket {
         mut websocket: WebSocket,
         incoming: Arc<schema::Request>,
         outgoing: Arc<schema::Response>,
+        flow_control: FlowControl,
     ) -> Self {
-        let (shutdown, mut shutdown_watch) = watch::channel(false);
-
-        let (outgoing_message_tx, mut outgoing_messages_rx) = mpsc::unbounded_channel();
-        let (incoming_messages_tx, incoming_message_rx) = mpsc::unbounded_channel();
+        let outgoing_messages = Arc::new(Outgoing::new(flow_control));
+        let (incoming_messages_tx, incoming_message_rx) = mpsc::channel(
+            flow_control
+                .max_buffered_incoming_messages
+                .unwrap_or(Semaphore::MAX_PERMITS),
+        );
 
         let schema = SocketSchema { incoming, outgoing };
         tokio::spawn({
+            let outgoing_messages = outgoing_messages.clone();
             async move {
+                // A slot reserved in the incoming message buffer. Reading from the
+                // websocket pauses while the buffer is full, which in turn makes
+                // the client wait to send more.
+                let mut permit = None;
+                let mut incoming_closed = false;
+
                 loop {
+                    let can_read = permit.is_some() || incoming_closed;
                     tokio::select! {
-                        msg = websocket.recv() => match msg {
+                        res = incoming_messages_tx.clone().reserve_owned(), if !can_read => {
+                            match res {
+                                Ok(p) => permit = Some(p),
+                                Err(_) => incoming_closed = true,
+                            }
+                        },
+                        msg = websocket.recv(), if can_read => match msg {
                             None => {
                                 log::trace!("websocket closed");
                                 break
                             },
                             Some(Ok(msg)) => {
-                                if let Err(e) = Socket::handle_incoming_message(
-                                    &schema,
-                                    &incoming_messages_tx,
-                                    msg,
-                                )
-                                .await
-                                {
-                                    log::warn!("failed handling incoming message: {e}");
-                                    break;
+                                let Some(msg) = schema.parse_incoming_message(&msg).await else {
+                                    continue;
+                                };
+                                match permit.take() {
+                                    Some(permit) => {
+                                        permit.send(msg);
+                                    }
+                                    None => {
+                                        log::warn!(
+                                            "failed handling incoming message: channel closed"
+                                        );
+                                        break;
+                                    }
                                 }
                             },
                             Some(Err(e)) => {
@@ -158,10 +228,18 @@ impl Socket {
                                 break;
                             }
                         },
-                        msg = outgoing_messages_rx.recv() => {
+                        msg = outgoing_messages.recv() => {
                             match msg {
                                 None => {
-                                    _ = websocket.close().await;
+                                    if outgoing_messages.overflowed() {
+                                        log::debug!("closing websocket of slow consumer");
+                                        _ = websocket.send(Message::Close(Some(CloseFrame {
+                                            code: close_code::POLICY,
+                                            reason: "slow consumer".into(),
+                                        }))).await;
+                                    } else {
+                                        _ = websocket.close().await;
+                                    }
                                     log::trace!("websocket closed");
                                     break;
                                 }
@@ -171,46 +249,40 @@ impl Socket {
                                 }
                             }
                         },
-                        _ = shutdown_watch.changed() => {
-                            // gracefully shutdown, wait for all messages to be read on out channel
-                            // before closing the websocket
-                            outgoing_messages_rx.close();
-                        }
                     }
                 }
 
+                // Fail any further sends now that the websocket is closed.
+                outgoing_messages.abort();
                 log::trace!("socket closed");
             }
         });
 
         Socket {
-            outgoing_message_tx,
+            outgoing: OutgoingHandle(outgoing_messages),
             incoming_message_rx: tokio::sync::Mutex::new(incoming_message_rx),
-            shutdown,
         }
     }
 
-    pub fn send(&self, msg: serde_json::Map<String, serde_json::Value>) -> anyhow::Result<()> {
-        self.outgoing_message_tx.send(msg)?;
-        Ok(())
+    pub async fn send(&self, msg: StreamMessage) -> anyhow::Result<()> {
+        self.outgoing.0.send(msg).await
     }
 
-    pub async fn recv(&self) -> Option<serde_json::Map<String, serde_json::Value>> {
+    pub async fn recv(&self) -> Option<StreamMessage> {
         self.incoming_message_rx.lock().await.recv().await
     }
 
     pub fn close(&self) {
-        _ = self.shutdown.send(true);
+        self.outgoing.0.close();
     }
 
     pub fn split(self) -> (Sink, Stream) {
         let Self {
-            outgoing_message_tx: tx,
+            outgoing,
             incoming_message_rx: rx,
-            shutdown,
         } = self;
 
-        let sink = Sink { tx, shutdown };
+        let sink = Sink { outgoing };
         let stream = Stream { rx };
 
         (sink, stream)
@@ -219,7 +291,7 @@ impl Socket {
     async fn handle_outgoing_message(
         schema: &SocketSchema,
         websocket: &mut WebSocket,
-        msg: serde_json::Map<String, serde_json::Value>,
+        msg: StreamMessage,
     ) {
         let msg = schema
             .to_outgoing_message(msg)
@@ -234,61 +306,202 @@ impl Socket {
             Err(e) => log::warn!("failed to send message to socket: {e}"),
         }
     }
-
-    async fn handle_incoming_message(
-        schema: &SocketSchema,
-        incoming: &UnboundedSender<serde_json::Map<String, serde_json::Value>>,
-        msg: Message,
-    ) -> anyhow::Result<()> {
-        if let Some(data) = get_message_payload(&msg) {
-            match schema.parse_incoming_message(data).await {
-                Ok(msg) => {
-                    if let Err(e) = incoming.send(msg) {
-                        return Err(anyhow!("tried to send on closed channel: {e}"));
-                    }
-                }
-                Err(e) => log::warn!("failed to parse incoming message: {e}"),
-            };
-        }
-
-        Ok(())
-    }
 }
+
 pub struct Sink {
-    tx: UnboundedSender<serde_json::Map<String, serde_json::Value>>,
-    shutdown: watch::Sender<bool>,
+    outgoing: OutgoingHandle,
 }
 
 impl Sink {
-    pub fn send(&self, msg: serde_json::Map<String, serde_json::Value>) -> anyhow::Result<()> {
-        self.tx.send(msg)?;
-        Ok(())
+    pub async fn send(&self, msg: StreamMessage) -> anyhow::Result<()> {
+        self.outgoing.0.send(msg).await
     }
 
     pub fn close(&self) {
-        _ = self.shutdown.send(true);
+        self.outgoing.0.close();
     }
 }
 
 pub struct Stream {
-    rx: tokio::sync::Mutex<UnboundedReceiver<serde_json::Map<String, serde_json::Value>>>,
+    rx: tokio::sync::Mutex<Receiver<StreamMessage>>,
 }
 impl Stream {
-    pub async fn recv(&self) -> Option<serde_json::Map<String, serde_json::Value>> {
+    pub async fn recv(&self) -> Option<StreamMessage> {
         self.rx.lock().await.recv().await
     }
 }
 
+/// The handler's handle to the outgoing messages,
+/// which closes the stream when dropped.
+struct OutgoingHandle(Arc<Outgoing>);
+
+impl Drop for OutgoingHandle {
+    fn drop(&mut self) {
+        self.0.close();
+    }
+}
+
+/// The buffer of messages waiting to be written to the websocket,
+/// which applies the flow control settings when the client reads
+/// messages slower than the handler sends them.
+struct Outgoing {
+    flow_control: FlowControl,
+    state: Mutex<OutgoingState>,
+
+    /// Notified when a message is buffered or the buffer is closed.
+    readable: Notify,
+
+    /// Notified when a message is taken from the buffer or the buffer is closed.
+    writable: Notify,
+}
+
+struct OutgoingState {
+    msgs: VecDeque<StreamMessage>,
+
+    /// Whether the buffer is closed, after which no more messages are accepted.
+    /// Messages already buffered are still written.
+    closed: bool,
+
+    /// Whether the buffer was closed because the client read messages too slowly.
+    overflowed: bool,
+}
+
+impl Outgoing {
+    fn new(flow_control: FlowControl) -> Self {
+        Self {
+            flow_control,
+            state: Mutex::new(OutgoingState {
+                msgs: VecDeque::new(),
+                closed: false,
+                overflowed: false,
+            }),
+            readable: Notify::new(),
+            writable: Notify::new(),
+        }
+    }
+
+    /// Buffers a message to be written, applying the slow consumer policy
+    /// if the buffer is full. Then it waits for the buffer to drain below
+    /// the high-water mark, if one is set.
+    async fn send(&self, msg: StreamMessage) -> anyhow::Result<()> {
+        let fc = &self.flow_control;
+        loop {
+            let notified = self.writable.notified();
+            tokio::pin!(notified);
+            notified.as_mut().enable();
+
+            {
+                let mut state = self.state.lock().expect("mutex poisoned");
+                if state.closed {
+                    anyhow::bail!("stream closed");
+                }
+
+                let full = fc
+                    .max_buffered_messages
+                    .is_some_and(|max| state.msgs.len() >= max);
+                if !full {
+                    state.msgs.push_back(msg);
+                    break;
+                }
+
+                match fc.slow_consumer {
+                    SlowConsumerPolicy::Wait => {}
+                    SlowConsumerPolicy::DropNewest => {
+                        log::debug!("outgoing message buffer full, dropping message");
+                        return Ok(());
+                    }
+                    SlowConsumerPolicy::DropOldest => {
+                        log::debug!("outgoing message buffer full, dropping oldest message");
+                        state.msgs.pop_front();
+                        state.msgs.push_back(msg);
+                        break;
+                    }
+                    SlowConsumerPolicy::Disconnect => {
+                        state.msgs.clear();
+                        state.closed = true;
+                        state.overflowed = true;
+                        drop(state);
+                        self.notify_closed();
+                        anyhow::bail!("stream closed: client is not reading messages fast enough");
+                    }
+                }
+            }
+
+            notified.await;
+        }
+        self.readable.notify_one();
+
+        let Some(high_water_mark) = fc.high_water_mark else {
+            return Ok(());
+        };
+        loop {
+            let notified = self.writable.notified();
+            tokio::pin!(notified);
+            notified.as_mut().enable();
+
+            {
+                let state = self.state.lock().expect("mutex poisoned");
+                if state.closed || state.msgs.len() < high_water_mark {
+                    return Ok(());
+                }
+            }
+
+            notified.await;
+        }
+    }
+
+    /// Takes the next message to write from the buffer.
+    /// It returns None when the buffer is closed and empty.
+    async fn recv(&self) -> Option<StreamMessage> {
+        loop {
+            let notified = self.readable.notified();
+            {
+                let mut state = self.state.lock().expect("mutex poisoned");
+                if let Some(msg) = state.msgs.pop_front() {
+                    drop(state);
+                    self.writable.notify_waiters();
+                    return Some(msg);
+                } else if state.closed {
+                    return None;
+                }
+            }
+            notified.await;
+        }
+    }
+
+    /// Closes the buffer, letting the messages already buffered be written.
+    fn close(&self) {
+        self.state.lock().expect("mutex poisoned").closed = true;
+        self.notify_closed();
+    }
+
+    /// Closes the buffer and discards the buffered messages.
+    fn abort(&self) {
+        {
+            let mut state = self.state.lock().expect("mutex poisoned");
+            state.msgs.clear();
+            state.closed = true;
+        }
+        self.notify_closed();
+    }
+
+    fn overflowed(&self) -> bool {
+        self.state.lock().expect("mutex poisoned").overflowed
+    }
+
+    fn notify_closed(&self) {
+        self.readable.notify_one();
+        self.writable.notify_waiters();
+    }
+}
+
 struct SocketSchema {
     incoming: Arc<schema::Request>,
     outgoing: Arc<schema::Response>,
 }
 
 impl SocketSchema {
-    fn to_outgoing_message(
-        &self,
-        msg: serde_json::Map<String, serde_json::Value>,
-    ) -> APIResult<Vec<u8>> {
+    fn to_outgoing_message(&self, msg: StreamMessage) -> APIResult<Vec<u8>> {
         let body_schema = self.outgoing.body.clone().ok_or_else(|| {
             super::Error::internal(anyhow!("outgoing message body can't be empty"))
         })?;
@@ -296,10 +509,20 @@ impl SocketSchema {
         body_schema.to_outgoing_payload(&Some(msg))
     }
 
-    async fn parse_incoming_message(
-        &self,
-        bytes: &[u8],
-    ) -> APIResult<serde_json::Map<String, serde_json::Value>> {
+    /// Parses an incoming websocket message, returning None for
+    /// control messages and messages that fail to parse.
+    async fn parse_incoming_message(&self, msg: &Message) -> Option<StreamMessage> {
+        let data = get_message_payload(msg)?;
+        match self.parse_incoming_payload(data).await {
+            Ok(msg) => Some(msg),
+            Err(e) => {
+                log::warn!("failed to parse incoming message: {e}");
+                None
+            }
+        }
+    }
+
+    async fn parse_incoming_payload(&self, bytes: &[u8]) -> APIResult<StreamMessage> {
         let schema::RequestBody::Typed(Some(ref body)) = self.incoming.body else {
             return Err(super::Error {
                 code: super::ErrCode::InvalidArgument,
@@ -322,3 +545,72 @@ impl SocketSchema {
         Ok(value)
     }
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn msg(n: i64) -> StreamMessage {
+        let mut m = serde_json::Map::new();
+        m.insert("n".into(), n.into());
+        m
+    }
+
+    fn outgoing(max: usize, slow_consumer: SlowConsumerPolicy) -> Outgoing {
+        Outgoing::new(FlowControl {
+            max_buffered_messages: Some(max),
+            slow_consumer,
+            ..Default::default()
+        })
+    }
+
+    async fn drain(out: &Outgoing) -> Vec<i64> {
+        out.close();
+        let mut got = vec![];
+        while let Some(m) = out.recv().await {
+            got.push(m["n"].as_i64().unwrap());
+        }
+        got
+    }
+
+    #[tokio::test]
+    async fn drops_messages_for_slow_consumers() {
+        let out = outgoing(2, SlowConsumerPolicy::DropNewest);
+        for n in 1..=4 {
+            out.send(msg(n)).await.unwrap();
+        }
+        assert_eq!(drain(&out).await, vec![1, 2]);
+
+        let out = outgoing(2, SlowConsumerPolicy::DropOldest);
+        for n in 1..=4 {
+            out.send(msg(n)).await.unwrap();
+        }
+        assert_eq!(drain(&out).await, vec![3, 4]);
+    }
+
+    #[tokio::test]
+    async fn disconnects_slow_consumers() {
+        let out = outgoing(1, SlowConsumerPolicy::Disconnect);
+        out.send(msg(1)).await.unwrap();
+        assert!(out.send(msg(2)).await.is_err());
+        assert!(out.overflowed());
+        assert_eq!(out.recv().await, None);
+    }
+
+    #[tokio::test]
+    async fn waits_for_room_in_the_buffer() {
+        let out = Arc::new(outgoing(1, SlowConsumerPolicy::Wait));
+        out.send(msg(1)).await.unwrap();
+
+        let send = tokio::spawn({
+            let out = out.clone();
+            async move { out.send(msg(2)).await }
+        });
+        tokio::task::yield_now().await;
+        assert!(!send.is_finished());
+
+        assert_eq!(out.recv().await, Some(msg(1)));
+        send.await.unwrap().unwrap();
+        assert_eq!(drain(&out).await, vec![2]);
+    }
+}
@@ -194,6 +194,11 @@ pub struct Runtime {
     sqldb: sqldb::Manager,
     api: api::Manager,
     app_meta: meta::AppMeta,
+    graceful_shutdown: runtimepb::GracefulShutdown,
+    /// Cancelled to stop serving the API.
+    shutdown: tokio_util::sync::CancellationToken,
+    /// Cancelled once the API has stopped being served.
+    stopped: tokio_util::sync::CancellationToken,
     runtime: tokio::runtime::Runtime,
 }
 
@@ -225,6 +230,7 @@ impl Runtime {
 
         let mut deployment = cfg.deployment.take().unwrap_or_default();
         let service_discovery = deployment.service_discovery.take().unwrap_or_default();
+        let graceful_shutdown = deployment.graceful_shutdown.take().unwrap_or_default();
 
         let http_client = reqwest::Client::builder()
             .build()
@@ -366,6 +372,9 @@ impl Runtime {
             sqldb,
             api,
             app_meta,
+            graceful_shutdown,
+            shutdown: tokio_util::sync::CancellationToken::new(),
+            stopped: tokio_util::sync::CancellationToken::new(),
             runtime: tokio_rt,
         })
     }
@@ -403,18 +412,31 @@ impl Runtime {
     #[inline]
     pub fn run_blocking(&self) {
         self.runtime.block_on(async move {
-            let api_handle = self.api().start_serving();
+            let api_handle = self.api().start_serving(self.shutdown.clone());
 
             if let Err(err) = api_handle.await {
                 ::log::error!("failed to start serving: {:?}", err);
             }
+            self.stopped.cancel();
         });
     }
 
+    /// Stops accepting new requests and waits for the ones in flight to complete.
+    /// It only returns once the API is no longer being served.
+    pub async fn drain(&self) {
+        self.shutdown.cancel();
+        self.stopped.cancelled().await;
+    }
+
     #[inline]
     pub fn app_meta(&self) -> &meta::AppMeta {
         &self.app_meta
     }
+
+    #[inline]
+    pub fn graceful_shutdown(&self) -> &runtimepb::GracefulShutdown {
+        &self.graceful_shutdown
+    }
 }
 
 #[derive(Debug)]
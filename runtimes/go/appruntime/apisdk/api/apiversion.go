@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"encore.dev/beta/errs"
+)
+
+// APIVersionHeader is the header generated clients use to send the version
+// of the API they were generated for, when they opt in to version checks.
+const APIVersionHeader = "X-Encore-API-Version"
+
+// ClientOutOfDate are the details of the error returned when a client
+// was generated for a different version of the API.
+type ClientOutOfDate struct {
+	ClientOutOfDate bool   `json:"client_out_of_date"` // always true
+	ClientVersion   string `json:"client_version"`
+	ServerVersion   string `json:"server_version"`
+}
+
+func (ClientOutOfDate) ErrDetails() {}
+
+// checkAPIVersion reports whether the client making the request was generated
+// for the current version of the API, writing an error response if not.
+// Requests without an API version aren't checked.
+func (s *Server) checkAPIVersion(w http.ResponseWriter, req *http.Request) bool {
+	clientVersion := req.Header.Get(APIVersionHeader)
+	if clientVersion == "" || s.static.APIVersion == "" || clientVersion == s.static.APIVersion {
+		return true
+	}
+
+	errs.HTTPError(w, errs.B().Code(errs.FailedPrecondition).
+		Msgf("client out of date: the client was generated for API version %s, but the API is at version %s",
+			clientVersion, s.static.APIVersion).
+		Details(ClientOutOfDate{
+			ClientOutOfDate: true,
+			ClientVersion:   clientVersion,
+			ServerVersion:   s.static.APIVersion,
+		}).Err())
+	return false
+}
@@ -0,0 +1,8 @@
+package api
+
+// RegisterEndpoint and RegisterGlobalMiddleware register
+// handlers and middleware with the server in external tests.
+var (
+	RegisterEndpoint         = (*Server).registerEndpoint
+	RegisterGlobalMiddleware = (*Server).registerGlobalMiddleware
+)
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"encore.dev/beta/errs"
+)
+
+// injectFault applies the fault injected into calls to the given endpoint
+// during the current test, if any. It returns the error the call should fail with,
+// or nil if the call should be made.
+func (c CallContext) injectFault(service, endpoint string) error {
+	fault, found := c.server.testingMgr.GetFault(service, endpoint)
+	if !found || fault == nil {
+		return nil
+	}
+	if fault.Probability > 0 && rand.Float64() >= fault.Probability {
+		return nil
+	}
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-c.ctx.Done():
+			code := errs.Canceled
+			if errors.Is(c.ctx.Err(), context.DeadlineExceeded) {
+				code = errs.DeadlineExceeded
+			}
+			return errs.WrapCode(c.ctx.Err(), code, "call aborted while delayed by an injected fault")
+		}
+	}
+
+	return fault.Err
+}
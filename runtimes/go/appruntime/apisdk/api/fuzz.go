@@ -0,0 +1,219 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Fuzzable is implemented by API handlers that can be fuzzed by tests.
+type Fuzzable interface {
+	Handler
+
+	// FuzzSeeds returns the seed corpus for fuzzing the API.
+	FuzzSeeds() ([][]byte, error)
+
+	// FuzzRequest returns the HTTP request for calling the API with the fuzzed input data.
+	FuzzRequest(data []byte) (*http.Request, error)
+}
+
+var _ Fuzzable = (*Desc[any, any])(nil)
+
+// fuzzMaxDepth is the maximum depth of nested types populated in seed inputs,
+// to bound the size of the seeds for recursive types.
+const fuzzMaxDepth = 5
+
+// Adversarial values to populate seed inputs with.
+var (
+	fuzzStrings = []string{
+		"", "a", " ", "\x00", "null", "-1", "é🙂\u202e", "' OR 1=1 --", "../../../etc/passwd",
+		"<script>alert(1)</script>", "%00%ff", "\\u0000\"\\", strings.Repeat("A", 4096),
+	}
+	fuzzInts   = []int64{0, 1, -1, math.MaxInt8, math.MinInt16, math.MaxInt32, math.MinInt32, math.MaxInt64, math.MinInt64}
+	fuzzUints  = []uint64{0, 1, math.MaxUint8, math.MaxUint16, math.MaxUint32, math.MaxUint64}
+	fuzzFloats = []float64{0, -1, 0.5, math.MaxFloat64, -math.MaxFloat64, math.SmallestNonzeroFloat64, 1e-300}
+
+	// fuzzMalformed are seed inputs that are not valid requests,
+	// which are sent as the raw request body.
+	fuzzMalformed = []string{"", "null", "[]", "{", `{"":}`, `"\ud800"`, "{}}", strings.Repeat("[", 1024)}
+)
+
+// FuzzSeeds returns the seed corpus for fuzzing the API, consisting of requests
+// that are structurally valid according to the API's schema but contain adversarial
+// values, followed by malformed requests.
+//
+// Each input is the JSON encoding of the request, including its path parameters.
+func (d *Desc[Req, Resp]) FuzzSeeds() ([][]byte, error) {
+	if d.Raw {
+		return nil, fmt.Errorf("cannot fuzz raw endpoint %s.%s", d.Service, d.Endpoint)
+	}
+
+	typ := reflect.TypeOf((*Req)(nil)).Elem()
+	seeds := make([][]byte, 0, len(fuzzStrings)+len(fuzzMalformed))
+	for variant := range fuzzStrings {
+		// The request type is a pointer to the generated request struct.
+		val := reflect.New(typ.Elem())
+		fillFuzzValue(val.Elem(), variant, 0)
+		data, err := jsoniter.ConfigDefault.Marshal(val.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode seed input: %v", err)
+		}
+		seeds = append(seeds, data)
+	}
+	for _, s := range fuzzMalformed {
+		seeds = append(seeds, []byte(s))
+	}
+	return seeds, nil
+}
+
+// FuzzRequest returns the HTTP request for calling the API with the fuzzed input data.
+//
+// If the data decodes as a request, the request is encoded like for
+// service-to-service calls, with its path parameters, headers and query string.
+// Otherwise the data is sent as the raw request body, with placeholder path parameters,
+// to exercise the decoding of malformed requests.
+func (d *Desc[Req, Resp]) FuzzRequest(data []byte) (*http.Request, error) {
+	if d.Raw {
+		return nil, fmt.Errorf("cannot fuzz raw endpoint %s.%s", d.Service, d.Endpoint)
+	}
+
+	// Default to POST if there are no methods available (or it's a wildcard)
+	httpMethod := "POST"
+	if len(d.Methods) > 0 && d.Methods[0] != "*" {
+		httpMethod = d.Methods[0]
+	}
+
+	var reqData Req
+	if err := jsoniter.ConfigDefault.Unmarshal(data, &reqData); err == nil && !isNil(reqData) {
+		if path, _, err := d.ReqPath(reqData); err == nil {
+			var buf bytes.Buffer
+			stream := jsoniter.ConfigDefault.BorrowStream(&buf)
+			header, query, err := d.EncodeExternalReq(reqData, stream)
+			if err2 := stream.Flush(); err == nil {
+				err = err2
+			}
+			jsoniter.ConfigDefault.ReturnStream(stream)
+			if err == nil {
+				req := newFuzzRequest(httpMethod, path, buf.Bytes())
+				req.URL.RawQuery = query.Encode()
+				req.RequestURI = req.URL.RequestURI()
+				for key, values := range header {
+					req.Header[key] = values
+				}
+				return req, nil
+			}
+		}
+	}
+
+	// Replace the path parameters with a placeholder that decodes
+	// as any builtin type, so the request is routed to the API.
+	segments := strings.Split(d.Path, "/")
+	for i, s := range segments {
+		if s != "" && strings.ContainsRune(":*!", rune(s[0])) {
+			segments[i] = "0"
+		}
+	}
+	return newFuzzRequest(httpMethod, strings.Join(segments, "/"), data), nil
+}
+
+// newFuzzRequest returns a request for the given path and body. Unlike
+// httptest.NewRequest it accepts paths that are not valid request targets.
+func newFuzzRequest(method, path string, body []byte) *http.Request {
+	req := httptest.NewRequest(method, "/", bytes.NewReader(body))
+	req.URL.Path = path
+	req.RequestURI = req.URL.RequestURI()
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// fillFuzzValue populates val with adversarial values. The variant determines
+// the values used; sibling fields are given different values in the same variant.
+func fillFuzzValue(val reflect.Value, variant, depth int) {
+	if depth > fuzzMaxDepth || !val.CanSet() {
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Bool:
+		val.SetBool(variant%2 == 1)
+	case reflect.String:
+		val.SetString(fuzzStrings[variant%len(fuzzStrings)])
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Use the next value for the variant that fits in the type.
+		for i := variant; i < variant+len(fuzzInts); i++ {
+			if n := fuzzInts[i%len(fuzzInts)]; !val.OverflowInt(n) {
+				val.SetInt(n)
+				break
+			}
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		for i := variant; i < variant+len(fuzzUints); i++ {
+			if n := fuzzUints[i%len(fuzzUints)]; !val.OverflowUint(n) {
+				val.SetUint(n)
+				break
+			}
+		}
+	case reflect.Float32, reflect.Float64:
+		for i := variant; i < variant+len(fuzzFloats); i++ {
+			if n := fuzzFloats[i%len(fuzzFloats)]; !val.OverflowFloat(n) {
+				val.SetFloat(n)
+				break
+			}
+		}
+	case reflect.Pointer:
+		// Leave some pointers nil to cover omitted values.
+		if variant%4 != 3 {
+			ptr := reflect.New(val.Type().Elem())
+			fillFuzzValue(ptr.Elem(), variant, depth+1)
+			val.Set(ptr)
+		}
+	case reflect.Struct:
+		for i := 0; i < val.NumField(); i++ {
+			if val.Type().Field(i).IsExported() {
+				fillFuzzValue(val.Field(i), variant+i, depth+1)
+			}
+		}
+	case reflect.Slice:
+		// Alternate between nil, empty and non-empty slices.
+		switch variant % 3 {
+		case 1:
+			val.Set(reflect.MakeSlice(val.Type(), 0, 0))
+		case 2:
+			s := reflect.MakeSlice(val.Type(), 3, 3)
+			for i := 0; i < s.Len(); i++ {
+				fillFuzzValue(s.Index(i), variant+i, depth+1)
+			}
+			val.Set(s)
+		}
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			fillFuzzValue(val.Index(i), variant+i, depth+1)
+		}
+	case reflect.Map:
+		if variant%3 != 0 {
+			m := reflect.MakeMap(val.Type())
+			for i := 0; i < 2; i++ {
+				k := reflect.New(val.Type().Key()).Elem()
+				v := reflect.New(val.Type().Elem()).Elem()
+				fillFuzzValue(k, variant+i, depth+1)
+				fillFuzzValue(v, variant+i, depth+1)
+				m.SetMapIndex(k, v)
+			}
+			val.Set(m)
+		}
+	}
+}
+
+// isNil reports whether v is a nil pointer.
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+	return !rv.IsValid() || (rv.Kind() == reflect.Pointer && rv.IsNil())
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type fuzzParams struct {
+	Name  string
+	Count int8
+	Tags  []string
+	Inner *fuzzParams
+}
+
+type fuzzReq struct {
+	Payload *fuzzParams
+	P0      int
+}
+
+func newFuzzDesc() *Desc[*fuzzReq, Void] {
+	return &Desc[*fuzzReq, Void]{
+		Service:  "svc",
+		Endpoint: "Fuzz",
+		Methods:  []string{"POST"},
+		Path:     "/fuzz/:id",
+		ReqPath: func(req *fuzzReq) (string, UnnamedParams, error) {
+			id := strconv.Itoa(req.P0)
+			return "/fuzz/" + id, UnnamedParams{id}, nil
+		},
+		EncodeExternalReq: func(req *fuzzReq, stream *jsoniter.Stream) (http.Header, url.Values, error) {
+			header := http.Header{"X-Name": {req.Payload.Name}}
+			stream.WriteVal(req.Payload)
+			return header, url.Values{"count": {strconv.Itoa(int(req.Payload.Count))}}, nil
+		},
+	}
+}
+
+func TestDesc_FuzzSeeds(t *testing.T) {
+	c := qt.New(t)
+	d := newFuzzDesc()
+
+	seeds, err := d.FuzzSeeds()
+	c.Assert(err, qt.IsNil)
+	c.Assert(seeds, qt.HasLen, len(fuzzStrings)+len(fuzzMalformed))
+
+	// The structured seeds must decode as requests, with differing values.
+	names := make(map[string]bool)
+	for _, seed := range seeds[:len(fuzzStrings)] {
+		var req *fuzzReq
+		c.Assert(jsoniter.ConfigDefault.Unmarshal(seed, &req), qt.IsNil, qt.Commentf("seed %s", seed))
+		c.Assert(req, qt.IsNotNil)
+		if req.Payload != nil {
+			names[req.Payload.Name] = true
+		}
+	}
+	c.Assert(len(names) > 1, qt.IsTrue)
+
+	d.Raw = true
+	_, err = d.FuzzSeeds()
+	c.Assert(err, qt.ErrorMatches, "cannot fuzz raw endpoint svc.Fuzz")
+}
+
+func TestDesc_FuzzRequest(t *testing.T) {
+	c := qt.New(t)
+	d := newFuzzDesc()
+
+	// A valid request is encoded like for API calls.
+	req, err := d.FuzzRequest([]byte(`{"P0": -5, "Payload": {"Name": "a b", "Count": 3}}`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(req.Method, qt.Equals, "POST")
+	c.Assert(req.URL.Path, qt.Equals, "/fuzz/-5")
+	c.Assert(req.URL.Query().Get("count"), qt.Equals, "3")
+	c.Assert(req.Header.Get("X-Name"), qt.Equals, "a b")
+	body, _ := io.ReadAll(req.Body)
+	c.Assert(string(body), qt.Equals, `{"Name":"a b","Count":3,"Tags":null,"Inner":null}`)
+
+	// Malformed data is sent as the request body.
+	req, err = d.FuzzRequest([]byte(`{"P0": "x"`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(req.URL.Path, qt.Equals, "/fuzz/0")
+	body, _ = io.ReadAll(req.Body)
+	c.Assert(string(body), qt.Equals, `{"P0": "x"`)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"runtime/pprof"
 	"strconv"
 	"sync"
 
@@ -151,6 +152,9 @@ func (d *Desc[Req, Resp]) Handle(c IncomingContext) {
 
 	resp, respData := d.handleIncoming(c, reqData)
 	if resp.Err != nil {
+		if opts := testRequestOptions(c.req.Context()); opts != nil && opts.HandlerError != nil {
+			opts.HandlerError(resp.Err)
+		}
 		c.server.finishRequest(resp)
 
 		// If the endpoint is raw it has already written its response;
@@ -304,7 +308,7 @@ func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(midd
 	var nextFn middleware.Next
 
 	var (
-		globalMiddleware = c.server.getGlobalMiddleware(d.GlobalMiddlewareIDs)
+		globalMiddleware = c.server.getGlobalMiddleware(c.ctx, d.GlobalMiddlewareIDs)
 		svcMiddleware    = d.ServiceMiddleware
 
 		numGlobalMiddleware  = len(globalMiddleware)
@@ -369,7 +373,17 @@ func (d *Desc[Req, Resp]) executeEndpoint(c execContext, invokeHandler func(midd
 	mwReq := middleware.NewLazyRequest(c.ctx, func() *encore.Request {
 		return c.server.encoreMgr.CurrentRequest()
 	})
-	mwResp := nextFn(mwReq)
+	var mwResp middleware.Response
+	if c.server.runtime.Profiling != nil {
+		// Label the goroutine with the endpoint while it's handling
+		// the request, so CPU profiles can be broken down by endpoint.
+		labels := pprof.Labels("service", d.Service, "endpoint", d.Endpoint)
+		pprof.Do(c.ctx, labels, func(context.Context) {
+			mwResp = nextFn(mwReq)
+		})
+	} else {
+		mwResp = nextFn(mwReq)
+	}
 
 	if mwResp.Err != nil {
 		return resp, mwResp.HTTPStatus, mwResp.Err
@@ -451,6 +465,10 @@ func (d *Desc[Req, Resp]) Call(c CallContext, req Req) (respData Resp, respErr e
 	// and if it has, we need to route the call to the mock, otherwise
 	// we'll make an internal call to the API
 	if c.server.static.Testing {
+		if err := c.injectFault(d.Service, d.Endpoint); err != nil {
+			return respData, err
+		}
+
 		if mockedAPI, found := c.server.testingMgr.GetAPIMock(d.Service, d.Endpoint); found && mockedAPI.Function != nil {
 			function, err := d.getMockFunction(mockedAPI)
 			if err != nil {
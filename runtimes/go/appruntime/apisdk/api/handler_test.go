@@ -401,6 +401,11 @@ func TestRawEndpointOverflow(t *testing.T) {
 }
 
 func testServer(t *testing.T, klock clock.Clock, mockTraces bool) (*api.Server, *mock_trace.MockLogger, *usermetrics.Registry) {
+	server, traceMock, metricsRegistry, _ := testServerWithConfig(t, klock, mockTraces, &config.Static{})
+	return server, traceMock, metricsRegistry
+}
+
+func testServerWithConfig(t *testing.T, klock clock.Clock, mockTraces bool, static *config.Static) (*api.Server, *mock_trace.MockLogger, *usermetrics.Registry, *reqtrack.RequestTracker) {
 	ctrl := gomock.NewController(t)
 
 	var tf traceprovider.Factory
@@ -411,7 +416,6 @@ func testServer(t *testing.T, klock clock.Clock, mockTraces bool) (*api.Server,
 		tf = &traceprovider.DefaultFactory{}
 	}
 
-	static := &config.Static{}
 	runtime := &config.Runtime{}
 
 	logger := zerolog.New(os.Stdout)
@@ -424,7 +428,7 @@ func testServer(t *testing.T, klock clock.Clock, mockTraces bool) (*api.Server,
 	healthMgr := health.NewCheckRegistry()
 	testingMgr := testsupport.NewManager(static, rt, logger)
 	server := api.NewServer(static, runtime, rt, nil, encoreMgr, pubsubMgr, logger, metricsRegistry, healthMgr, testingMgr, json, klock)
-	return server, traceMock, metricsRegistry
+	return server, traceMock, metricsRegistry, rt
 }
 
 func newMockAPIDesc(access api.Access) *api.Desc[*mockReq, *mockResp] {
@@ -109,6 +109,16 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 		}
 	}
 
+	// Capture the call in the test making it, if any, before
+	// the caller's request is replaced by this one.
+	if req.Type == model.RPCCall {
+		s.rt.Current().Req.CaptureOp(model.CapturedOp{
+			Kind:     model.CapturedRPCCall,
+			Service:  data.Desc.Service,
+			Endpoint: data.Desc.Endpoint,
+		})
+	}
+
 	// Begin the request, copying data over from the previous request.
 	s.rt.BeginRequest(req)
 	if curr := s.rt.Current(); curr.Trace != nil {
@@ -137,6 +147,11 @@ func (s *Server) beginRequest(ctx context.Context, p *beginRequestParams) (*mode
 	if req.TraceID != (model.TraceID{}) {
 		logCtx = logCtx.Str("trace_id", req.TraceID.String())
 	}
+	// The span id links the log lines to the span in the trace, like the
+	// TypeScript runtime's logger does (see runtimes/core/src/log/logger.rs).
+	if req.SpanID != (model.SpanID{}) {
+		logCtx = logCtx.Str("span_id", req.SpanID.String())
+	}
 
 	if req.ExtCorrelationID != "" {
 		logCtx = logCtx.Str("x_correlation_id", req.ExtCorrelationID)
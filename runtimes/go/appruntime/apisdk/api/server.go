@@ -384,18 +384,23 @@ func (s *Server) registerGlobalMiddleware(mw *Middleware) {
 	s.globalMiddleware[mw.ID] = mw
 }
 
-func (s *Server) getGlobalMiddleware(ids []string) []*Middleware {
+func (s *Server) getGlobalMiddleware(ctx context.Context, ids []string) []*Middleware {
 	// Don't add global middleware when tests are executing,
 	// as it's not possible to guarantee all global middleware
 	// have actually been imported when the tests run.
-	if s.static.Testing {
+	//
+	// For HTTP requests made by tests, add the global middleware
+	// that has been imported, to test the full HTTP stack.
+	if s.static.Testing && testRequestOptions(ctx) == nil {
 		return nil
 	}
 
 	result := make([]*Middleware, 0, len(ids))
 	for _, id := range ids {
 		mw, ok := s.globalMiddleware[id]
-		if !ok {
+		if !ok && s.static.Testing {
+			continue
+		} else if !ok {
 			panic(fmt.Sprintf("middleware %q not registered", id))
 		}
 		result = append(result, mw)
@@ -468,6 +473,9 @@ func (s *Server) handler(w http.ResponseWriter, req *http.Request) {
 	if strings.HasPrefix(path, internalPrefix+"/") {
 		router, fallbackRouter = s.encore, nil
 		path = path[len(internalPrefix):] // keep leading slash
+	} else if internalCaller == nil && !s.checkAPIVersion(w, req) {
+		// checkAPIVersion has already written the response
+		return
 	}
 
 	findRoute := func(r *httprouter.Router) (h httprouter.Handle, p httprouter.Params, handledTSR bool) {
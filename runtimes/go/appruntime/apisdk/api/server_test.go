@@ -1,12 +1,17 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
+
+	"encore.dev/appruntime/exported/config"
 )
 
 func Test_handleTrailingSlashRedirect(t *testing.T) {
@@ -82,3 +87,77 @@ func Test_determineRequestPath(t *testing.T) {
 		}
 	}
 }
+
+func Test_checkAPIVersion(t *testing.T) {
+	s := &Server{static: &config.Static{APIVersion: "v2"}}
+	tests := []struct {
+		clientVersion string
+		ok            bool
+	}{
+		{"", true},
+		{"v2", true},
+		{"v1", false},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/foo", nil)
+		if test.clientVersion != "" {
+			req.Header.Set(APIVersionHeader, test.clientVersion)
+		}
+		if ok := s.checkAPIVersion(w, req); ok != test.ok {
+			t.Errorf("version %q: got ok=%v, want %v", test.clientVersion, ok, test.ok)
+			continue
+		} else if ok {
+			continue
+		}
+
+		var resp struct {
+			Code    string          `json:"code"`
+			Details ClientOutOfDate `json:"details"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("version %q: invalid response: %v", test.clientVersion, err)
+		}
+		want := ClientOutOfDate{ClientOutOfDate: true, ClientVersion: test.clientVersion, ServerVersion: "v2"}
+		if w.Code != http.StatusBadRequest || resp.Code != "failed_precondition" || resp.Details != want {
+			t.Errorf("version %q: got status=%d code=%s details=%+v", test.clientVersion, w.Code, resp.Code, resp.Details)
+		}
+	}
+}
+
+func Test_getGlobalMiddleware(t *testing.T) {
+	a, b := &Middleware{ID: "a"}, &Middleware{ID: "b"}
+	ids := []string{"a", "missing", "b"}
+	testReq := context.WithValue(context.Background(), testRequestKey, &TestRequestOptions{})
+
+	tests := []struct {
+		name    string
+		testing bool
+		ctx     context.Context
+		want    []*Middleware
+	}{
+		// Tests calling APIs directly skip global middleware,
+		// while HTTP requests made by tests use the imported ones.
+		{"testing", true, context.Background(), nil},
+		{"test_request", true, testReq, []*Middleware{a, b}},
+	}
+	for _, test := range tests {
+		s := &Server{static: &config.Static{Testing: test.testing}}
+		s.registerGlobalMiddleware(a)
+		s.registerGlobalMiddleware(b)
+		got := s.getGlobalMiddleware(test.ctx, ids)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	// Outside of tests every middleware must be registered.
+	s := &Server{static: &config.Static{}}
+	s.registerGlobalMiddleware(a)
+	defer func() {
+		if recover() == nil {
+			t.Errorf("got no panic for unregistered middleware")
+		}
+	}()
+	s.getGlobalMiddleware(context.Background(), ids)
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// TestRequestOptions are the options for an HTTP request made by a test.
+type TestRequestOptions struct {
+	// Auth, if non-nil, is the auth information to use for the request
+	// instead of running the auth handler. An empty UID makes the
+	// request unauthenticated.
+	Auth *model.AuthInfo
+
+	// HandlerError, if non-nil, is called with the error returned by the
+	// API handler or middleware, before it's encoded to the response.
+	// It includes the full error details, like the stack of a panic.
+	HandlerError func(err error)
+}
+
+const testRequestKey ctxKey = "testreq"
+
+// ServeTestRequest serves an HTTP request made by the current test using the full HTTP stack
+// of the server, as if it had been made by an external client: the request is routed, decoded,
+// authenticated and passed through the middleware, and the response is encoded to w.
+//
+// It returns once the request has been handled. If handling the request
+// panicked outside of the API handler and middleware, like when decoding the request,
+// ServeTestRequest panics with the same value.
+func (s *Server) ServeTestRequest(w http.ResponseWriter, req *http.Request, opts *TestRequestOptions) {
+	if opts == nil {
+		opts = &TestRequestOptions{}
+	}
+	req = req.WithContext(context.WithValue(req.Context(), testRequestKey, opts))
+
+	// Handle the request in a different goroutine, like for API calls,
+	// as the handler begins and finishes requests of its own.
+	done := make(chan struct{})
+	var panicked any
+	go func() {
+		defer close(done)
+		defer func() {
+			panicked = recover()
+		}()
+		s.httpsrv.Handler.ServeHTTP(w, req)
+	}()
+	<-done
+
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// testRequestOptions returns the options of the HTTP request made by a test
+// that ctx belongs to, or nil if the request wasn't made by a test.
+func testRequestOptions(ctx context.Context) *TestRequestOptions {
+	opts, _ := ctx.Value(testRequestKey).(*TestRequestOptions)
+	return opts
+}
@@ -0,0 +1,117 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/middleware"
+)
+
+func TestServeTestRequest(t *testing.T) {
+	model.EnableTestMode(t)
+	server, _, _, rt := testServerWithConfig(t, clock.New(), false, &config.Static{Testing: true})
+
+	type call struct {
+		uid        model.UID
+		middleware bool
+	}
+	var got *call
+	var inMiddleware bool
+
+	api.RegisterGlobalMiddleware(server, &api.Middleware{
+		ID:     "global",
+		Global: true,
+		Invoke: func(req middleware.Request, next middleware.Next) middleware.Response {
+			inMiddleware = true
+			return next(req)
+		},
+	})
+
+	register := func(access api.Access, endpoint, path string) {
+		desc := newMockAPIDesc(access)
+		desc.Endpoint = endpoint
+		desc.Methods = []string{"POST"}
+		desc.RawPath = path
+		desc.PathParamNames = nil
+		// Global middleware that hasn't been imported by the test is skipped.
+		desc.GlobalMiddlewareIDs = []string{"global", "not-imported"}
+		desc.AppHandler = func(ctx context.Context, req *mockReq) (*mockResp, error) {
+			got = &call{uid: rt.Current().Req.RPCData.UserID, middleware: inMiddleware}
+			return &mockResp{Message: req.Body}, nil
+		}
+		api.RegisterEndpoint(server, desc, desc.AppHandler)
+	}
+	register(api.RequiresAuth, "private", "/private")
+	register(api.Public, "public", "/public")
+
+	tests := []struct {
+		name   string
+		path   string
+		auth   *model.AuthInfo
+		status int
+		want   *call
+	}{
+		{
+			name:   "with_auth",
+			path:   "/private",
+			auth:   &model.AuthInfo{UID: "user-1"},
+			status: http.StatusOK,
+			want:   &call{uid: "user-1", middleware: true},
+		},
+		{
+			name:   "without_auth",
+			path:   "/private",
+			auth:   &model.AuthInfo{},
+			status: http.StatusUnauthorized,
+			want:   nil,
+		},
+		{
+			name:   "without_auth_public",
+			path:   "/public",
+			auth:   &model.AuthInfo{},
+			status: http.StatusOK,
+			want:   &call{uid: "", middleware: true},
+		},
+		{
+			name:   "not_found",
+			path:   "/missing",
+			auth:   &model.AuthInfo{},
+			status: http.StatusNotFound,
+			want:   nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, inMiddleware = nil, false
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", test.path, strings.NewReader(`{"Body": "foo"}`))
+			server.ServeTestRequest(w, req, &api.TestRequestOptions{Auth: test.auth})
+
+			if w.Code != test.status {
+				t.Fatalf("got code %d, want %d: %s", w.Code, test.status, w.Body.String())
+			}
+			switch {
+			case test.want == nil && got != nil:
+				t.Errorf("handler called with %+v, want it not to be called", *got)
+			case test.want != nil && got == nil:
+				t.Errorf("handler not called, want it to be called")
+			case test.want != nil && *got != *test.want:
+				t.Errorf("handler called with %+v, want %+v", *got, *test.want)
+			}
+			if test.status == http.StatusOK {
+				if body := w.Body.String(); body != `{"Message":"foo"}` {
+					t.Errorf("got body %q, want %q", body, `{"Message":"foo"}`)
+				}
+			}
+		})
+	}
+}
@@ -9,8 +9,9 @@ import (
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/shared/shutdown"
 
-	// Initialize the metric subsystem
+	// Initialize the metric and profiling subsystems
 	_ "encore.dev/appruntime/infrasdk/metrics"
+	_ "encore.dev/appruntime/infrasdk/profiling"
 )
 
 type App struct {
@@ -41,6 +41,7 @@ func Options(cfg *config.CORS, staticAllowedHeaders, staticExposedHeaders []stri
 		"User-Agent",
 		"X-Request-ID",
 		"X-Correlation-ID",
+		"X-Encore-API-Version",
 	}
 	allowedHeaders = append(allowedHeaders, cfg.ExtraAllowedHeaders...)
 	allowedHeaders = append(allowedHeaders, staticAllowedHeaders...)
@@ -0,0 +1,151 @@
+// Package auditlog defines the audit log entries recorded by encore.dev/audit,
+// and the hash chain that makes the audit log tamper-evident.
+//
+// Each entry's hash covers its contents and the hash of the entry before it,
+// so modifying, removing or reordering entries breaks the chain from that
+// point on. Stores assign the sequence numbers and hashes as they append
+// entries, since the app may run as several instances writing to one log.
+package auditlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Entry is an entry in the audit log.
+type Entry struct {
+	// ID uniquely identifies the entry.
+	ID string `json:"id"`
+
+	// Seq is the position of the entry in the audit log, starting at 1.
+	// It's assigned by the store.
+	Seq int64 `json:"seq"`
+
+	// Time is when the event happened.
+	Time time.Time `json:"time"`
+
+	// Actor is who performed the action.
+	Actor Actor `json:"actor"`
+
+	// Action is what was done, like "invoice.update".
+	Action string `json:"action"`
+
+	// Target is what the action was performed on, like "invoice/123".
+	Target string `json:"target,omitempty"`
+
+	// Diff describes the changes the action made to the target.
+	Diff []Change `json:"diff,omitempty"`
+
+	// Metadata is additional JSON-encoded information about the event.
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+
+	// Service and Endpoint are the service and endpoint the event was recorded in,
+	// and TraceID the trace of the request, if any.
+	Service  string `json:"service,omitempty"`
+	Endpoint string `json:"endpoint,omitempty"`
+	TraceID  string `json:"trace_id,omitempty"`
+
+	// PrevHash is the hash of the previous entry in the audit log,
+	// and Hash the hash of this entry. They're assigned by the store.
+	PrevHash string `json:"prev_hash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// Actor describes who performed an action.
+type Actor struct {
+	// UserID is the id of the authenticated user.
+	// It's empty if the request wasn't authenticated.
+	UserID string `json:"user_id,omitempty"`
+
+	// AuthData is the JSON-encoded auth data of the user, if any.
+	AuthData json.RawMessage `json:"auth_data,omitempty"`
+}
+
+// Change is a change to a field of the target of an action.
+type Change struct {
+	Field string `json:"field"`
+
+	// From and To are the JSON-encoded values before and after the change.
+	// They're empty if the field was added or removed, respectively.
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+// ComputeHash computes the hash of the entry, given the hash of the previous entry.
+// It ignores the entry's current PrevHash and Hash.
+func (e *Entry) ComputeHash(prevHash string) (string, error) {
+	c := *e
+	c.PrevHash, c.Hash = prevHash, ""
+	c.Time = c.Time.UTC()
+	data, err := json.Marshal(&c)
+	if err != nil {
+		return "", fmt.Errorf("auditlog: encode entry %s: %v", e.ID, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Chain sets the entry's PrevHash and Hash, appending it after prev.
+// If prev is nil the entry is the first in the audit log.
+func (e *Entry) Chain(prev *Entry) error {
+	var prevHash string
+	e.Seq = 1
+	if prev != nil {
+		prevHash = prev.Hash
+		e.Seq = prev.Seq + 1
+	}
+	hash, err := e.ComputeHash(prevHash)
+	if err != nil {
+		return err
+	}
+	e.PrevHash, e.Hash = prevHash, hash
+	return nil
+}
+
+// VerifyError describes where an audit log's hash chain is broken.
+type VerifyError struct {
+	Seq    int64  // the sequence number of the first invalid entry
+	Reason string // why the entry is invalid
+}
+
+func (err *VerifyError) Error() string {
+	return fmt.Sprintf("audit log entry %d: %s", err.Seq, err.Reason)
+}
+
+// Checkpoint anchors the hash chain of an audit log whose oldest entries
+// have been removed by the retention policy. It's the sequence number
+// and hash of the last entry removed.
+type Checkpoint struct {
+	Seq  int64  `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+// Verify verifies the hash chain of the given entries, in sequence order.
+// The first entry must directly follow the checkpoint, or start the audit
+// log if the checkpoint is nil.
+//
+// It reports a *VerifyError if the chain is broken.
+func Verify(checkpoint *Checkpoint, entries []*Entry) error {
+	var prev Checkpoint
+	if checkpoint != nil {
+		prev = *checkpoint
+	}
+	for _, e := range entries {
+		if e.Seq != prev.Seq+1 {
+			return &VerifyError{Seq: prev.Seq + 1, Reason: "entry is missing"}
+		} else if e.PrevHash != prev.Hash {
+			return &VerifyError{Seq: e.Seq, Reason: "previous hash does not match"}
+		}
+		hash, err := e.ComputeHash(e.PrevHash)
+		if err != nil {
+			return &VerifyError{Seq: e.Seq, Reason: err.Error()}
+		} else if hash != e.Hash {
+			return &VerifyError{Seq: e.Seq, Reason: "hash does not match contents"}
+		}
+		prev = Checkpoint{Seq: e.Seq, Hash: e.Hash}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	var entries []*Entry
+	var prev *Entry
+	for i, action := range []string{"invoice.create", "invoice.update", "invoice.delete"} {
+		e := &Entry{
+			ID:       string(rune('a' + i)),
+			Time:     time.Unix(1700000000+int64(i), 0),
+			Actor:    Actor{UserID: "user1", AuthData: json.RawMessage(`{"role": "admin"}`)},
+			Action:   action,
+			Target:   "invoice/1",
+			Diff:     []Change{{Field: "amount", From: json.RawMessage(`10`), To: json.RawMessage(`20`)}},
+			Metadata: json.RawMessage(`{"reason": "typo"}`),
+		}
+		if err := e.Chain(prev); err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, e)
+		prev = e
+	}
+
+	if entries[0].Seq != 1 || entries[0].PrevHash != "" || entries[2].Seq != 3 {
+		t.Fatalf("got unexpected chain: %+v", entries)
+	}
+	if err := Verify(nil, entries); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	// Entries round-tripped through JSON still verify.
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []*Entry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(nil, decoded); err != nil {
+		t.Fatalf("verify decoded: %v", err)
+	}
+
+	// Logs pruned by retention verify against the checkpoint of the removed entries.
+	cp := &Checkpoint{Seq: entries[0].Seq, Hash: entries[0].Hash}
+	if err := Verify(cp, entries[1:]); err != nil {
+		t.Fatalf("verify pruned: %v", err)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	newChain := func() []*Entry {
+		var entries []*Entry
+		var prev *Entry
+		for i := 0; i < 3; i++ {
+			e := &Entry{ID: string(rune('a' + i)), Time: time.Unix(1700000000, 0), Action: "user.login"}
+			if err := e.Chain(prev); err != nil {
+				t.Fatal(err)
+			}
+			entries = append(entries, e)
+			prev = e
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name    string
+		tamper  func([]*Entry) []*Entry
+		wantSeq int64
+	}{
+		{"modified", func(e []*Entry) []*Entry { e[1].Target = "user/2"; return e }, 2},
+		{"removed", func(e []*Entry) []*Entry { return []*Entry{e[0], e[2]} }, 2},
+		{"reordered", func(e []*Entry) []*Entry {
+			e[1].Seq, e[2].Seq = e[2].Seq, e[1].Seq
+			return []*Entry{e[0], e[2], e[1]}
+		}, 2},
+		{"head removed", func(e []*Entry) []*Entry { return e[1:] }, 1},
+		{"rehashed", func(e []*Entry) []*Entry {
+			e[1].Target = "user/2"
+			e[1].Hash, _ = e[1].ComputeHash(e[1].PrevHash)
+			return e
+		}, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Verify(nil, test.tamper(newChain()))
+			var verr *VerifyError
+			if !errors.As(err, &verr) {
+				t.Fatalf("got err %v, want *VerifyError", err)
+			} else if verr.Seq != test.wantSeq {
+				t.Fatalf("got error at seq %d, want %d: %v", verr.Seq, test.wantSeq, err)
+			}
+		})
+	}
+}
@@ -13,6 +13,10 @@ type Static struct {
 	EncoreCompiler string
 	AppCommit      CommitInfo // The commit which this service was built from
 
+	// APIVersion is the fingerprint of the app's API. Requests from generated clients
+	// that send a different API version are rejected as out of date.
+	APIVersion string
+
 	CORSAllowHeaders  []string // Headers to be allowed by cors
 	CORSExposeHeaders []string // Headers to be exposed by cors
 	PubsubTopics      map[string]*StaticPubsubTopic
@@ -58,10 +62,18 @@ type Runtime struct {
 	RedisServers     []*RedisServer          `json:"redis_servers,omitempty"`
 	RedisDatabases   []*RedisDatabase        `json:"redis_databases,omitempty"`
 	Metrics          *Metrics                `json:"metrics,omitempty"`
+	Profiling        *Profiling              `json:"profiling,omitempty"`
+	Logging          *Logging                `json:"logging,omitempty"`
+	AuditLog         *AuditLog               `json:"audit_log,omitempty"`
 	Gateways         []Gateway               `json:"gateways,omitempty"`          // Gateways defines the gateways which should be served by the container
 	HostedServices   []string                `json:"hosted_services,omitempty"`   // List of services to be hosted within this container (zero length means all services, unless there's a gateway running)
 	ServiceDiscovery map[string]Service      `json:"service_discovery,omitempty"` // ServiceDiscovery lists where all the services are being hosted if not in this container
 
+	// AppSecrets configures where the values of the app's secrets are read from,
+	// keyed by secret name. Secrets not listed here are read from the
+	// ENCORE_APP_SECRETS environment variable.
+	AppSecrets map[string]*SecretSource `json:"app_secrets,omitempty"`
+
 	// ServiceAuth defines which authentication method can be used
 	// when talking to this runtime for internal service-to-service
 	// calls.
@@ -346,6 +358,77 @@ type SQLDatabase struct {
 	// MaxConnections is the maximum number of open connections to use
 	// for this database. If zero it defaults to 30.
 	MaxConnections int `json:"max_connections"`
+
+	// PasswordSource, if set, is where the password is read from instead
+	// of using Password. It's read each time a connection is established,
+	// so the password can be rotated without restarting the app.
+	PasswordSource *SecretSource `json:"password_source,omitempty"`
+
+	// IAMAuth, if set, authenticates as User with short-lived tokens
+	// issued by the cloud provider instead of using Password.
+	IAMAuth *SQLIAMAuth `json:"iam_auth,omitempty"`
+}
+
+// SQLIAMAuth configures IAM database authentication.
+// Exactly one of the fields must be set.
+type SQLIAMAuth struct {
+	AWS *AWSRDSIAMAuth      `json:"aws,omitempty"` // set to use AWS RDS IAM authentication
+	GCP *GCPCloudSQLIAMAuth `json:"gcp,omitempty"` // set to use GCP Cloud SQL IAM authentication
+}
+
+type AWSRDSIAMAuth struct {
+	// Region is the AWS region of the RDS instance.
+	// If empty it's determined by the AWS SDK's default configuration,
+	// such as the AWS_REGION environment variable.
+	Region string `json:"region,omitempty"`
+}
+
+// GCPCloudSQLIAMAuth currently has no specific configuration.
+// The tokens are issued for the Application Default Credentials,
+// and User must be the IAM principal's database user name.
+type GCPCloudSQLIAMAuth struct {
+}
+
+// SecretSource describes where a secret value is read from,
+// instead of embedding it in the configuration.
+// Exactly one of the fields must be set.
+type SecretSource struct {
+	// File is the path of a file holding the secret value, such as a
+	// Kubernetes Secret mounted under /var/run/secrets.
+	// The value is the file's contents as-is.
+	File string `json:"file,omitempty"`
+
+	// Env is the name of an environment variable holding the secret value.
+	Env string `json:"env,omitempty"`
+
+	// Vault reads the secret value from HashiCorp Vault, using the
+	// credentials available in the app's environment.
+	Vault *VaultSecretRef `json:"vault,omitempty"`
+
+	// AWSSecretsManager reads the secret value from AWS Secrets Manager,
+	// using the default AWS credentials and region.
+	AWSSecretsManager *AWSSecretsManagerRef `json:"aws_secrets_manager,omitempty"`
+}
+
+// VaultSecretRef refers to a value of a secret stored in HashiCorp Vault.
+type VaultSecretRef struct {
+	// Path is the Vault API path of the secret, like "secret/data/app"
+	// for the secret "app" in the KV version 2 mount "secret".
+	Path string `json:"path"`
+
+	// Key is the key of the value within the secret.
+	Key string `json:"key"`
+}
+
+// AWSSecretsManagerRef refers to a secret stored in AWS Secrets Manager.
+type AWSSecretsManagerRef struct {
+	// SecretID is the name or ARN of the secret.
+	// Secrets referenced by ARN are read from the ARN's region.
+	SecretID string `json:"secret_id"`
+
+	// Key, if set, parses the secret as a JSON object and uses the key's value.
+	// Otherwise the secret's value is used as-is.
+	Key string `json:"key,omitempty"`
 }
 
 type RedisServer struct {
@@ -438,6 +521,108 @@ type DatadogProvider struct {
 
 type LogsBasedMetricsProvider struct{}
 
+// Profiling configures continuous profiling, periodically capturing
+// profiles of the running application and exporting them to a profiler.
+type Profiling struct {
+	// Interval is how often profiles are captured.
+	// If zero it defaults to one minute.
+	Interval time.Duration `json:"interval,omitempty"`
+
+	// CPUDuration is how long the CPU is profiled for in each interval.
+	// If zero it defaults to 10 seconds.
+	CPUDuration time.Duration `json:"cpu_duration,omitempty"`
+
+	// ProfileTypes are the types of profiles to capture, out of
+	// "cpu", "heap" and "goroutine". If empty it defaults to "cpu" and "heap".
+	ProfileTypes []string `json:"profile_types,omitempty"`
+
+	Pyroscope     *PyroscopeProfilingProvider `json:"pyroscope,omitempty"`
+	Parca         *ParcaProfilingProvider     `json:"parca,omitempty"`
+	CloudProfiler *GCPCloudProfilerProvider   `json:"gcp_cloud_profiler,omitempty"`
+}
+
+// Logging configures which of the logs written by the app's services are emitted.
+// It only applies to logs written with encore.dev/rlog, and logs that aren't
+// emitted are still recorded in traces.
+type Logging struct {
+	// Level is the minimum level of logs to emit, out of "debug", "info",
+	// "warn" and "error". If empty it defaults to "debug", emitting all logs.
+	Level string `json:"level,omitempty"`
+
+	// SampleRate is the fraction of debug and info logs to emit,
+	// between 0 and 1. Warnings and errors are always emitted.
+	// If nil it defaults to 1, emitting all logs.
+	SampleRate *float64 `json:"sample_rate,omitempty"`
+
+	// Services overrides the settings for individual services, keyed by service name.
+	Services map[string]*ServiceLogging `json:"services,omitempty"`
+
+	// ReloadFile, if set, is the path to a JSON file holding logging settings
+	// that replace these ones, like a mounted Kubernetes ConfigMap.
+	// The file is checked for changes every ReloadInterval, so the settings
+	// can be changed without restarting the app. Its ReloadFile is ignored.
+	ReloadFile string `json:"reload_file,omitempty"`
+
+	// ReloadInterval is how often to check ReloadFile for changes.
+	// If zero it defaults to 10 seconds.
+	ReloadInterval time.Duration `json:"reload_interval,omitempty"`
+}
+
+// AuditLog configures where the events recorded with encore.dev/audit are stored.
+// If nil, audit events are written to the app's logs instead.
+type AuditLog struct {
+	// Endpoint is the URL of the audit log store to send events to,
+	// authenticated with the runtime's auth keys.
+	Endpoint string `json:"endpoint"`
+
+	// Retention is how long the store keeps audit events for.
+	// If zero they're kept indefinitely.
+	Retention time.Duration `json:"retention,omitempty"`
+}
+
+// ServiceLogging configures the logs of a service.
+// Unset fields default to the app-wide settings.
+type ServiceLogging struct {
+	Level      string   `json:"level,omitempty"`
+	SampleRate *float64 `json:"sample_rate,omitempty"`
+
+	// DebugEndpoints are the names of endpoints of the service
+	// whose debug logs are always emitted, regardless of the level
+	// and sample rate, for debugging them in production.
+	DebugEndpoints []string `json:"debug_endpoints,omitempty"`
+}
+
+type PyroscopeProfilingProvider struct {
+	// ServerURL is the URL of the Pyroscope server, like "http://pyroscope:4040".
+	ServerURL string
+
+	// ApplicationName is the name to report profiles under.
+	// If empty it defaults to the app slug.
+	ApplicationName string
+
+	// BasicAuthUser and BasicAuthPassword, if set, are the
+	// credentials to authenticate with, like for Grafana Cloud.
+	BasicAuthUser     string
+	BasicAuthPassword string
+
+	// TenantID, if set, is the tenant to send profiles to
+	// on multi-tenant servers.
+	TenantID string
+}
+
+type ParcaProfilingProvider struct {
+	// ServerURL is the URL of the Parca server, like "http://parca:7070".
+	ServerURL string
+
+	// BearerToken, if set, is the token to authenticate with.
+	BearerToken string
+}
+
+type GCPCloudProfilerProvider struct {
+	// ProjectID is the GCP project id to send profiles to.
+	ProjectID string
+}
+
 // Limiter represents a rate limiter that can be used for certain types of operations
 //
 // The fields are mutually exclusive, which ever is not nil is the limiter that will be used,
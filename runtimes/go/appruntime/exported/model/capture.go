@@ -0,0 +1,78 @@
+package model
+
+import "sync"
+
+// CapturedOpKind is the kind of operation captured during a test.
+type CapturedOpKind byte
+
+const (
+	CapturedRPCCall  CapturedOpKind = 0x01
+	CapturedDBQuery  CapturedOpKind = 0x02
+	CapturedHTTPCall CapturedOpKind = 0x03
+	CapturedPublish  CapturedOpKind = 0x04
+)
+
+// CapturedOp is an operation performed during a test.
+type CapturedOp struct {
+	Kind CapturedOpKind
+
+	// Caller is the service performing the operation,
+	// or "" if it was performed by the test itself.
+	Caller string
+
+	Service  string // for RPC calls
+	Endpoint string // for RPC calls
+	DB       string // for database queries
+	Query    string // for database queries
+	Method   string // for HTTP calls
+	URL      string // for HTTP calls
+	Topic    string // for published messages
+}
+
+// TestCapture records the operations performed during a test and its sub-tests.
+type TestCapture struct {
+	mu  sync.Mutex
+	ops []CapturedOp
+}
+
+// Ops returns the captured operations, in the order they were performed.
+func (c *TestCapture) Ops() []CapturedOp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CapturedOp(nil), c.ops...)
+}
+
+// Reset discards the captured operations.
+func (c *TestCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ops = nil
+}
+
+func (c *TestCapture) record(op CapturedOp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ops = append(c.ops, op)
+}
+
+// CaptureOp records the operation in the captures of the test the request
+// belongs to and its parent tests, if they're capturing operations.
+// The operation's caller is set to the service of the request.
+func (req *Request) CaptureOp(op CapturedOp) {
+	if req == nil || req.Test == nil {
+		return
+	}
+	if req.Type != Test {
+		op.Caller = req.Service()
+	}
+
+	for td := req.Test; td != nil; {
+		if c := td.Capture.Load(); c != nil {
+			c.record(op)
+		}
+		if td.Parent == nil {
+			break
+		}
+		td = td.Parent.Test
+	}
+}
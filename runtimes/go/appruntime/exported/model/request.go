@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -155,6 +156,9 @@ type TestData struct {
 	ServiceInstances   map[string]any // The service instances isolated to this test
 
 	Wait sync.WaitGroup // If we're spun up async go routines, this wait allows to the test to wait for them to end
+
+	// Capture records the operations performed during the test, if set.
+	Capture atomic.Pointer[TestCapture]
 }
 
 // TestConfig contains configuration for testing,
@@ -173,6 +177,10 @@ type TestConfig struct {
 	APIMocks         map[string]map[string]ApiMock
 	IsolatedServices *bool                // Whether to isolate services for this test
 	EndCallbacks     []func(t *testing.T) // Callbacks to run when the test ends
+
+	// Faults are the faults to inject into API calls, keyed by service and endpoint
+	// (or "" for faults injected into all of a service's endpoints).
+	Faults map[string]map[string]*InjectedFault
 }
 
 type ServiceMock struct {
@@ -186,6 +194,13 @@ type ApiMock struct {
 	RunMiddleware bool
 }
 
+// InjectedFault is a fault injected into the calls made to an API or service during a test.
+type InjectedFault struct {
+	Latency     time.Duration // How long to delay the call by
+	Err         error         // The error to return instead of making the call, if any
+	Probability float64       // The probability of a call being affected, or 0 to affect all calls
+}
+
 type Response struct {
 	// HTTPStatus is the HTTP status to respond with.
 	HTTPStatus int
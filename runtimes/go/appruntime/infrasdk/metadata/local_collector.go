@@ -0,0 +1,19 @@
+package metadata
+
+import (
+	encore "encore.dev"
+)
+
+func init() {
+	registerCollector(collectorDesc{
+		name: "local",
+		matches: func(envCloud string) bool {
+			return envCloud == encore.CloudLocal
+		},
+		collect: func() (*ContainerMetadata, error) {
+			// There's no container metadata when running locally,
+			// where metrics are only sent to the Encore daemon.
+			return &ContainerMetadata{}, nil
+		},
+	})
+}
@@ -0,0 +1,93 @@
+//go:build !encore_no_gcp
+
+package profiling
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	cloudprofiler "google.golang.org/api/cloudprofiler/v2"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "gcp_cloud_profiler",
+		matches: func(cfg *config.Profiling) bool {
+			return cfg.CloudProfiler != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			// Cloud Profiler doesn't allow underscores in label names.
+			labels := make(map[string]string, len(m.labels))
+			for k, v := range m.labels {
+				labels[strings.ReplaceAll(k, "_", "-")] = v
+			}
+			return &cloudProfilerExporter{
+				cfg:    m.runtime.Profiling.CloudProfiler,
+				target: cloudProfilerTarget(m.appName()),
+				labels: labels,
+			}
+		},
+	})
+}
+
+// cloudProfilerExporter exports profiles to Google Cloud Profiler
+// by creating them as offline profiles.
+type cloudProfilerExporter struct {
+	cfg    *config.GCPCloudProfilerProvider
+	target string
+	labels map[string]string
+
+	svcOnce sync.Once
+	svc     *cloudprofiler.Service
+	svcErr  error
+}
+
+var cloudProfilerTypes = map[ProfileType]string{
+	CPU:       "CPU",
+	Heap:      "HEAP",
+	Goroutine: "THREADS",
+}
+
+func (x *cloudProfilerExporter) Export(ctx context.Context, profiles []*Profile) error {
+	x.svcOnce.Do(func() {
+		// Create the client with a background context since it's reused across exports.
+		x.svc, x.svcErr = cloudprofiler.NewService(context.Background())
+	})
+	if x.svcErr != nil {
+		return fmt.Errorf("unable to create Cloud Profiler client: %v", x.svcErr)
+	}
+
+	parent := "projects/" + x.cfg.ProjectID
+	for _, p := range profiles {
+		prof := &cloudprofiler.Profile{
+			ProfileType: cloudProfilerTypes[p.Type],
+			Deployment: &cloudprofiler.Deployment{
+				ProjectId: x.cfg.ProjectID,
+				Target:    x.target,
+				Labels:    x.labels,
+			},
+			ProfileBytes: base64.StdEncoding.EncodeToString(p.Data),
+		}
+		if p.Duration > 0 {
+			prof.Duration = fmt.Sprintf("%.3fs", p.Duration.Seconds())
+		}
+		if _, err := x.svc.Projects.Profiles.CreateOffline(parent, prof).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("unable to send profile to Cloud Profiler: %v", err)
+		}
+	}
+	return nil
+}
+
+var invalidTargetChars = regexp.MustCompile(`[^-a-z0-9_.]+`)
+
+// cloudProfilerTarget returns the Cloud Profiler target for the app name,
+// which must only contain lowercase letters, digits, dashes, underscores and dots.
+func cloudProfilerTarget(appName string) string {
+	return strings.Trim(invalidTargetChars.ReplaceAllString(strings.ToLower(appName), "-"), "-_.")
+}
@@ -0,0 +1,101 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "parca",
+		matches: func(cfg *config.Profiling) bool {
+			return cfg.Parca != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			labels := map[string]string{"app": m.appName()}
+			for k, v := range m.labels {
+				labels[k] = v
+			}
+			return &parcaExporter{cfg: m.runtime.Profiling.Parca, labels: labels}
+		},
+	})
+}
+
+// parcaExporter exports profiles to Parca using the HTTP endpoint
+// of its profile store's WriteRaw method.
+type parcaExporter struct {
+	cfg    *config.ParcaProfilingProvider
+	labels map[string]string
+}
+
+type parcaLabel struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type parcaSeries struct {
+	Labels struct {
+		Labels []parcaLabel `json:"labels"`
+	} `json:"labels"`
+	Samples []parcaSample `json:"samples"`
+}
+
+type parcaSample struct {
+	RawProfile []byte `json:"raw_profile"` // base64-encoded by encoding/json
+}
+
+func (x *parcaExporter) Export(ctx context.Context, profiles []*Profile) error {
+	series := make([]parcaSeries, 0, len(profiles))
+	for _, p := range profiles {
+		var s parcaSeries
+		s.Labels.Labels = x.seriesLabels(p.Type)
+		s.Samples = []parcaSample{{RawProfile: p.Data}}
+		series = append(series, s)
+	}
+
+	body, err := json.Marshal(map[string]any{"normalized": true, "series": series})
+	if err != nil {
+		return fmt.Errorf("unable to marshal profiles: %v", err)
+	}
+
+	endpoint := strings.TrimSuffix(x.cfg.ServerURL, "/") + "/profiles/writeraw"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "encore")
+	if x.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+x.cfg.BearerToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send profiles to Parca: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unable to send profiles to Parca: got status %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// seriesLabels returns the labels of the series of profiles of the given type,
+// sorted by name as Parca requires.
+func (x *parcaExporter) seriesLabels(typ ProfileType) []parcaLabel {
+	labels := []parcaLabel{{Name: "__name__", Value: string(typ)}}
+	for k, v := range x.labels {
+		labels = append(labels, parcaLabel{Name: k, Value: v})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels
+}
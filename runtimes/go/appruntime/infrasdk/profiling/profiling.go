@@ -0,0 +1,233 @@
+// Package profiling implements continuous profiling, periodically capturing
+// CPU, heap and goroutine profiles of the running application and exporting
+// them to a profiler like Pyroscope, Parca or Google Cloud Profiler.
+//
+// API handlers run with the pprof labels "service" and "endpoint" while
+// profiling is enabled, so CPU profiles can be broken down by endpoint.
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/infrasdk/metadata"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// ProfileType is a type of profile.
+type ProfileType string
+
+const (
+	CPU       ProfileType = "cpu"
+	Heap      ProfileType = "heap"
+	Goroutine ProfileType = "goroutine"
+)
+
+// Profile is a captured profile.
+type Profile struct {
+	Type ProfileType
+
+	// Start is when the profile was captured, or started being captured for
+	// profiles of the CPU, and Duration how long it was captured for.
+	Start    time.Time
+	Duration time.Duration
+
+	// Data is the profile as a gzipped pprof protobuf.
+	Data []byte
+}
+
+type Manager struct {
+	ctx    context.Context
+	cancel func()
+
+	runtime    *config.Runtime
+	rootLogger zerolog.Logger
+	exp        exporter
+
+	// labels are the labels describing this instance of the app,
+	// added to all profiles.
+	labels map[string]string
+}
+
+func NewManager(static *config.Static, rtConf *config.Runtime, rootLogger zerolog.Logger) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := &Manager{
+		ctx:        ctx,
+		cancel:     cancel,
+		runtime:    rtConf,
+		rootLogger: rootLogger,
+	}
+
+	// Profiling isn't configured, return.
+	if rtConf.Profiling == nil {
+		return mgr
+	}
+
+	mgr.labels = instanceLabels(static, rtConf, rootLogger)
+	for _, desc := range providerRegistry {
+		if desc.matches(rtConf.Profiling) {
+			mgr.exp = desc.newExporter(mgr)
+			break
+		}
+	}
+	return mgr
+}
+
+// Enabled reports whether profiles are being captured.
+func (mgr *Manager) Enabled() bool {
+	return mgr.exp != nil && mgr.runtime.EnvType != "test"
+}
+
+func (mgr *Manager) Shutdown(p *shutdown.Process) error {
+	mgr.cancel()
+	return nil
+}
+
+// BeginCollection captures and exports profiles until the manager is shut down.
+func (mgr *Manager) BeginCollection() {
+	if !mgr.Enabled() {
+		return
+	}
+
+	cfg := mgr.runtime.Profiling
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	cpuDuration := cfg.CPUDuration
+	if cpuDuration <= 0 {
+		cpuDuration = 10 * time.Second
+	}
+	cpuDuration = min(cpuDuration, interval/2)
+
+	types := []ProfileType{CPU, Heap}
+	if len(cfg.ProfileTypes) > 0 {
+		types = types[:0]
+		for _, t := range cfg.ProfileTypes {
+			types = append(types, ProfileType(t))
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mgr.ctx.Done():
+			return
+		case <-ticker.C:
+			profiles := mgr.capture(types, cpuDuration)
+			if len(profiles) == 0 {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(mgr.ctx, interval/2)
+			if err := mgr.exp.Export(ctx, profiles); err != nil {
+				mgr.rootLogger.Error().Err(err).Msg("unable to export profiles")
+			} else {
+				mgr.rootLogger.Trace().Int("num_profiles", len(profiles)).Msg("successfully exported profiles")
+			}
+			cancel()
+		}
+	}
+}
+
+// capture captures the given types of profiles.
+func (mgr *Manager) capture(types []ProfileType, cpuDuration time.Duration) []*Profile {
+	var profiles []*Profile
+	for _, typ := range types {
+		p, err := mgr.captureOne(typ, cpuDuration)
+		if err != nil {
+			mgr.rootLogger.Error().Err(err).Str("type", string(typ)).Msg("unable to capture profile")
+			continue
+		} else if p != nil {
+			profiles = append(profiles, p)
+		}
+	}
+	return profiles
+}
+
+func (mgr *Manager) captureOne(typ ProfileType, cpuDuration time.Duration) (*Profile, error) {
+	var buf bytes.Buffer
+	p := &Profile{Type: typ, Start: time.Now()}
+
+	switch typ {
+	case CPU:
+		// This fails if the CPU is already being profiled,
+		// like by the app itself through net/http/pprof.
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+		select {
+		case <-time.After(cpuDuration):
+		case <-mgr.ctx.Done():
+			pprof.StopCPUProfile()
+			return nil, nil
+		}
+		pprof.StopCPUProfile()
+		p.Duration = time.Since(p.Start)
+
+	case Heap, Goroutine:
+		if err := pprof.Lookup(string(typ)).WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown profile type %q", typ)
+	}
+
+	p.Data = buf.Bytes()
+	return p, nil
+}
+
+// instanceLabels returns the labels describing this instance of the app.
+func instanceLabels(static *config.Static, rtConf *config.Runtime, rootLogger zerolog.Logger) map[string]string {
+	labels := map[string]string{"language": "go"}
+	if md, err := metadata.GetContainerMetadata(rtConf); err == nil {
+		for k, v := range md.Labels().AsMap() {
+			labels[k] = v
+		}
+	} else {
+		rootLogger.Warn().Err(err).Msg("unable to get container metadata for profiles")
+		labels["env_name"] = rtConf.EnvName
+	}
+
+	// If the instance hosts a single service, label all its profiles with it.
+	// Otherwise only the samples captured while handling requests are labeled.
+	svcs := rtConf.HostedServices
+	if len(svcs) == 0 {
+		svcs = static.BundledServices
+	}
+	if len(svcs) == 1 {
+		labels["service"] = svcs[0]
+	}
+	return labels
+}
+
+// appName returns the name of the app to report profiles under.
+func (mgr *Manager) appName() string {
+	if mgr.runtime.AppSlug != "" {
+		return mgr.runtime.AppSlug
+	}
+	return mgr.runtime.AppID
+}
+
+type exporter interface {
+	Export(context.Context, []*Profile) error
+}
+
+type providerDesc struct {
+	name        string
+	matches     func(cfg *config.Profiling) bool
+	newExporter func(m *Manager) exporter
+}
+
+var providerRegistry []providerDesc
+
+func registerProvider(desc providerDesc) {
+	providerRegistry = append(providerRegistry, desc)
+}
@@ -0,0 +1,128 @@
+package profiling
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestCapture(t *testing.T) {
+	mgr := NewManager(&config.Static{}, &config.Runtime{}, zerolog.Nop())
+	for _, typ := range []ProfileType{CPU, Heap, Goroutine} {
+		p, err := mgr.captureOne(typ, 10*time.Millisecond)
+		if err != nil {
+			t.Fatalf("capture %s profile: %v", typ, err)
+		}
+		// Profiles are gzipped.
+		if len(p.Data) < 2 || p.Data[0] != 0x1f || p.Data[1] != 0x8b {
+			t.Fatalf("capture %s profile: got invalid data", typ)
+		}
+		if typ == CPU && p.Duration < 10*time.Millisecond {
+			t.Fatalf("capture cpu profile: got duration %s", p.Duration)
+		}
+	}
+
+	if _, err := mgr.captureOne("threadcreate", 0); err == nil {
+		t.Fatal("capture unknown profile type: got nil error")
+	}
+}
+
+func TestPyroscopeExporter(t *testing.T) {
+	var gotQuery map[string]string
+	var gotProfile []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/ingest" {
+			t.Errorf("got path %q, want /ingest", req.URL.Path)
+		}
+		gotQuery = map[string]string{}
+		for k := range req.URL.Query() {
+			gotQuery[k] = req.URL.Query().Get(k)
+		}
+		f, _, err := req.FormFile("profile")
+		if err != nil {
+			t.Errorf("read profile: %v", err)
+			return
+		}
+		gotProfile, _ = io.ReadAll(f)
+	}))
+	defer srv.Close()
+
+	x := &pyroscopeExporter{
+		cfg:     &config.PyroscopeProfilingProvider{ServerURL: srv.URL + "/"},
+		appName: "my-app",
+		labels:  map[string]string{"service": "foo", "env_name": "prod"},
+	}
+	start := time.Unix(1700000000, 0)
+	err := x.Export(context.Background(), []*Profile{
+		{Type: CPU, Start: start, Duration: 10 * time.Second, Data: []byte("data")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"name":       "my-app.cpu{env_name=prod,service=foo}",
+		"from":       "1700000000",
+		"until":      "1700000010",
+		"format":     "pprof",
+		"spyName":    "gospy",
+		"sampleRate": "100",
+	}
+	for k, v := range want {
+		if gotQuery[k] != v {
+			t.Errorf("got %s=%q, want %q", k, gotQuery[k], v)
+		}
+	}
+	if string(gotProfile) != "data" {
+		t.Errorf("got profile %q, want %q", gotProfile, "data")
+	}
+}
+
+func TestParcaExporter(t *testing.T) {
+	var got struct {
+		Normalized bool          `json:"normalized"`
+		Series     []parcaSeries `json:"series"`
+	}
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/profiles/writeraw" {
+			t.Errorf("got path %q, want /profiles/writeraw", req.URL.Path)
+		}
+		gotAuth = req.Header.Get("Authorization")
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	x := &parcaExporter{
+		cfg:    &config.ParcaProfilingProvider{ServerURL: srv.URL, BearerToken: "token"},
+		labels: map[string]string{"app": "my-app"},
+	}
+	err := x.Export(context.Background(), []*Profile{{Type: Heap, Data: []byte("data")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAuth != "Bearer token" {
+		t.Errorf("got auth %q, want %q", gotAuth, "Bearer token")
+	}
+	if len(got.Series) != 1 {
+		t.Fatalf("got %d series, want 1", len(got.Series))
+	}
+	labels := got.Series[0].Labels.Labels
+	if len(labels) != 2 || labels[0] != (parcaLabel{"__name__", "heap"}) || labels[1] != (parcaLabel{"app", "my-app"}) {
+		t.Errorf("got labels %v", labels)
+	}
+	if samples := got.Series[0].Samples; len(samples) != 1 || string(samples[0].RawProfile) != "data" {
+		t.Errorf("got samples %v", samples)
+	}
+}
@@ -0,0 +1,121 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func init() {
+	registerProvider(providerDesc{
+		name: "pyroscope",
+		matches: func(cfg *config.Profiling) bool {
+			return cfg.Pyroscope != nil
+		},
+		newExporter: func(m *Manager) exporter {
+			cfg := m.runtime.Profiling.Pyroscope
+			appName := cfg.ApplicationName
+			if appName == "" {
+				appName = m.appName()
+			}
+			return &pyroscopeExporter{cfg: cfg, appName: appName, labels: m.labels}
+		},
+	})
+}
+
+// pyroscopeExporter exports profiles to Pyroscope using its ingest API.
+type pyroscopeExporter struct {
+	cfg     *config.PyroscopeProfilingProvider
+	appName string
+	labels  map[string]string
+}
+
+func (x *pyroscopeExporter) Export(ctx context.Context, profiles []*Profile) error {
+	for _, p := range profiles {
+		if err := x.upload(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *pyroscopeExporter) upload(ctx context.Context, p *Profile) error {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	_, _ = fw.Write(p.Data)
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	q.Set("name", x.appName+"."+string(p.Type)+pyroscopeLabels(x.labels))
+	q.Set("from", strconv.FormatInt(p.Start.Unix(), 10))
+	q.Set("until", strconv.FormatInt(p.Start.Add(p.Duration).Unix(), 10))
+	q.Set("format", "pprof")
+	q.Set("spyName", "gospy")
+	if p.Type == CPU {
+		q.Set("sampleRate", "100")
+	}
+
+	endpoint := strings.TrimSuffix(x.cfg.ServerURL, "/") + "/ingest?" + q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return fmt.Errorf("unable to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("User-Agent", "encore")
+	if x.cfg.BasicAuthUser != "" || x.cfg.BasicAuthPassword != "" {
+		req.SetBasicAuth(x.cfg.BasicAuthUser, x.cfg.BasicAuthPassword)
+	}
+	if x.cfg.TenantID != "" {
+		req.Header.Set("X-Scope-OrgID", x.cfg.TenantID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to send profile to Pyroscope: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unable to send profile to Pyroscope: got status %s: %s", resp.Status, msg)
+	}
+	return nil
+}
+
+// pyroscopeLabels formats labels the way Pyroscope expects them
+// in the application name, like "{env_name=prod,service=foo}".
+func pyroscopeLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k + "=" + labels[k])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
@@ -0,0 +1,22 @@
+//go:build encore_app
+
+package profiling
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// This file is named "zzz_singleton_internal.go" so that it is the last file
+// in the package, to ensure all other init functions are run before
+// we instantiate the manager.
+
+// publicapigen:drop
+var Singleton *Manager
+
+func init() {
+	Singleton = NewManager(appconf.Static, appconf.Runtime, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
+	go Singleton.BeginCollection()
+}
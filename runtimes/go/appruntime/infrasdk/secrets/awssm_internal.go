@@ -0,0 +1,139 @@
+//go:build !encore_no_aws
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+// awsSecretsManagerClient reads secrets from AWS Secrets Manager,
+// with the credentials and region of the default AWS config.
+type awsSecretsManagerClient struct {
+	httpClient *http.Client
+	loadConfig func() (aws.Config, error)
+	now        func() time.Time
+
+	// endpoint returns the Secrets Manager endpoint of the region.
+	endpoint func(region string) string
+}
+
+func newAWSSecretsManagerClient() *awsSecretsManagerClient {
+	return &awsSecretsManagerClient{
+		httpClient: http.DefaultClient,
+		loadConfig: sync.OnceValues(func() (aws.Config, error) {
+			return awsconfig.LoadDefaultConfig(context.Background())
+		}),
+		now: time.Now,
+		endpoint: func(region string) string {
+			return "https://secretsmanager." + region + ".amazonaws.com"
+		},
+	}
+}
+
+func init() {
+	registerFetcher(awsSMManager, newAWSSecretsManagerClient().fetch)
+}
+
+// fetch fetches the current value of the secret with the given name or ARN,
+// like "arn:aws:secretsmanager:eu-west-1:123456789012:secret:app-AbCdEf".
+// If a key is given the secret is parsed as a JSON object and the key's value
+// is returned, otherwise the secret's value is returned as-is.
+func (c *awsSecretsManagerClient) fetch(ctx context.Context, secretID, key string) (string, error) {
+	if secretID == "" {
+		return "", errors.New("invalid aws_secrets_manager reference: secret_id must be set")
+	}
+
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return "", fmt.Errorf("load AWS config: %w", err)
+	}
+	region := cfg.Region
+	// Secrets referenced by ARN are read from the ARN's region.
+	if parts := strings.SplitN(secretID, ":", 5); len(parts) == 5 && parts[0] == "arn" {
+		region = parts[3]
+	}
+	if region == "" {
+		return "", errors.New("the AWS region is not set")
+	}
+	creds, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("retrieve AWS credentials: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": secretID})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint(region), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), "secretsmanager", region, c.now()); err != nil {
+		return "", fmt.Errorf("sign request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Type != "" {
+			return "", fmt.Errorf("get secret %s: %s: %s", secretID, errResp.Type, errResp.Message)
+		}
+		return "", fmt.Errorf("get secret %s: unexpected status %s", secretID, resp.Status)
+	}
+
+	var secret struct {
+		SecretString *string
+		SecretBinary []byte
+	}
+	if err := json.Unmarshal(respBody, &secret); err != nil {
+		return "", fmt.Errorf("get secret %s: parse response: %w", secretID, err)
+	}
+	var val string
+	if secret.SecretString != nil {
+		val = *secret.SecretString
+	} else {
+		val = string(secret.SecretBinary)
+	}
+	if key == "" {
+		return val, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(val), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object", secretID)
+	}
+	field, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", secretID, key)
+	}
+	return stringValue(field)
+}
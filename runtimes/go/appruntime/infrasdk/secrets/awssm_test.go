@@ -0,0 +1,79 @@
+//go:build !encore_no_aws
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+func TestAWSSecretsManagerFetch(t *testing.T) {
+	var regions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			t.Errorf("unexpected target %q", r.Header.Get("X-Amz-Target"))
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+			t.Errorf("unsigned request: %q", r.Header.Get("Authorization"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req struct{ SecretId string }
+		_ = json.Unmarshal(body, &req)
+		switch {
+		case strings.HasSuffix(req.SecretId, "app-json"):
+			_, _ = fmt.Fprint(w, `{"SecretString": "{\"api_key\": \"json-key\"}"}`)
+		case req.SecretId == "app-plain":
+			_, _ = fmt.Fprint(w, `{"SecretString": "plain-value"}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"__type": "ResourceNotFoundException", "message": "not found"}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := newAWSSecretsManagerClient()
+	c.httpClient = srv.Client()
+	c.loadConfig = func() (aws.Config, error) {
+		return aws.Config{
+			Region:      "us-east-1",
+			Credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""),
+		}, nil
+	}
+	c.endpoint = func(region string) string {
+		regions = append(regions, region)
+		return srv.URL
+	}
+
+	ctx := context.Background()
+	for _, test := range []struct{ ID, Key, Want string }{
+		{"app-plain", "", "plain-value"},
+		{"arn:aws:secretsmanager:eu-west-1:123456789012:secret:app-json", "api_key", "json-key"},
+	} {
+		got, err := c.fetch(ctx, test.ID, test.Key)
+		if err != nil {
+			t.Fatalf("fetch(%q, %q): %v", test.ID, test.Key, err)
+		} else if got != test.Want {
+			t.Fatalf("fetch(%q, %q) = %q, want %q", test.ID, test.Key, got, test.Want)
+		}
+	}
+	if strings.Join(regions, ",") != "us-east-1,eu-west-1" {
+		t.Fatalf("got regions %v, want the default region and the ARN's region", regions)
+	}
+
+	_, err := c.fetch(ctx, "app-missing", "")
+	if err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Fatalf("got err %v, want ResourceNotFoundException", err)
+	}
+	if _, err := c.fetch(ctx, "app-plain", "key"); err == nil {
+		t.Fatal("fetch of key in non-JSON secret succeeded")
+	}
+}
@@ -0,0 +1,107 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// jsonReloadInterval is how often JSON secrets read from a source in the
+// runtime config's app_secrets are re-read, so rotated values are picked up.
+const jsonReloadInterval = 30 * time.Second
+
+// validator is implemented by types of JSON secrets that validate themselves.
+type validator interface {
+	Validate() error
+}
+
+// loadJSON loads the JSON secret key, parsing it into a T. If the secret has
+// no value during local development, the zero value of T is returned.
+//
+// If the secret is read from a source in app_secrets, the returned function
+// re-reads it every jsonReloadInterval, so rotated values are picked up.
+func loadJSON[T any](mgr *Manager, key string, inService string) func() T {
+	var val T
+	data := mgr.Load(key, inService)
+	if data != "" {
+		var err error
+		if val, err = parseJSON[T](data); err != nil {
+			fmt.Fprintf(os.Stderr, "encore: invalid value for secret %s: %v\n", key, err)
+			os.Exit(2)
+		}
+	}
+	if _, ok := mgr.cfg.AppSecrets[key]; !ok {
+		return func() T { return val }
+	}
+	s := &jsonSecret[T]{mgr: mgr, key: key, data: data, val: val, readAt: mgr.resolver.now()}
+	return s.get
+}
+
+// jsonSecret is a JSON secret that's re-read from its source.
+type jsonSecret[T any] struct {
+	mgr *Manager
+	key string
+
+	mu     sync.Mutex
+	data   string // the value val was parsed from
+	val    T
+	readAt time.Time
+}
+
+// get returns the secret's value, re-reading it if it was last read
+// at least jsonReloadInterval ago.
+func (s *jsonSecret[T]) get() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now := s.mgr.resolver.now(); now.Sub(s.readAt) >= jsonReloadInterval {
+		s.readAt = now
+		s.reload()
+	}
+	return s.val
+}
+
+// reload re-reads the secret. If the new value can't be read or is invalid,
+// the current value is kept and the error is logged.
+func (s *jsonSecret[T]) reload() {
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	data, err := s.mgr.reread(ctx, s.key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encore: could not re-read secret %s, using current value: %v\n", s.key, err)
+		return
+	} else if data == s.data {
+		return
+	}
+	val, err := parseJSON[T](data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encore: invalid value for secret %s, using current value: %v\n", s.key, err)
+		return
+	}
+	s.data, s.val = data, val
+}
+
+// parseJSON parses the JSON secret value into a T and validates it,
+// if T implements validator. The errors never include the value itself.
+func parseJSON[T any](data string) (T, error) {
+	var val T
+	if err := json.Unmarshal([]byte(data), &val); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return val, fmt.Errorf("not valid JSON: syntax error at offset %d", syntaxErr.Offset)
+		}
+		return val, err
+	}
+
+	// The pointer's method set includes the methods of T itself.
+	if v, ok := any(&val).(validator); ok {
+		if err := v.Validate(); err != nil {
+			return val, fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	return val, nil
+}
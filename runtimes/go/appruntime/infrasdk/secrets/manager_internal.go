@@ -1,29 +1,87 @@
 package secrets
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/shared/cfgutil"
 )
 
+// resolveTimeout is how long reading a secret from an external secrets manager may take.
+const resolveTimeout = 30 * time.Second
+
 type Manager struct {
-	cfg     *config.Runtime
-	secrets map[string]string
+	cfg      *config.Runtime
+	secrets  map[string]string
+	resolver *refResolver
 }
 
 func NewManager(cfg *config.Runtime, appSecretsEnv string) *Manager {
-	return &Manager{cfg: cfg, secrets: parse(appSecretsEnv)}
+	return &Manager{cfg: cfg, secrets: parse(appSecretsEnv), resolver: defaultResolver}
 }
 
-// Load loads a secret.
+// Load loads a secret. Secrets read from an external secrets manager
+// are fetched when they're loaded.
 func (mgr *Manager) Load(key string, inService string) string {
-	if val, ok := mgr.secrets[key]; ok {
-		return val
+	val, _ := mgr.lookup(key, inService)
+	return val
+}
+
+// LoadBytes loads a secret as bytes, for secrets holding binary data
+// like certificates and keystores. Since they're declared as []byte,
+// their values are stored base64-encoded, like "encore secret set --from-file" does.
+func (mgr *Manager) LoadBytes(key string, inService string) []byte {
+	val := mgr.Load(key, inService)
+	if val == "" {
+		return nil
+	}
+	data, err := base64.StdEncoding.DecodeString(val)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encore: invalid value for secret %s: []byte secrets must be base64-encoded\n", key)
+		os.Exit(2)
+	}
+	return data
+}
+
+// LoadValues loads the active values of a secret: the current value
+// followed, during a rotation, by the other values to accept.
+// Since the secret is declared as []string, its value is stored
+// as a JSON list of strings, like "encore secret rotate" does.
+func (mgr *Manager) LoadValues(key string, inService string) []string {
+	val, ok := mgr.lookup(key, inService)
+	if !ok {
+		return nil
+	}
+	var vals []string
+	if err := json.Unmarshal([]byte(val), &vals); err != nil || len(vals) == 0 {
+		fmt.Fprintf(os.Stderr, "encore: invalid value for secret %s: []string secrets must be a non-empty JSON list of strings\n", key)
+		os.Exit(2)
+	}
+	return vals
+}
+
+// lookup looks up the value of a secret.
+func (mgr *Manager) lookup(key string, inService string) (string, bool) {
+	if src, ok := mgr.cfg.AppSecrets[key]; ok {
+		ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+		defer cancel()
+		val, err := mgr.resolver.read(ctx, src)
+		if err == nil {
+			return val, true
+		} else if !errors.Is(err, ErrSourceNotFound) {
+			fmt.Fprintf(os.Stderr, "encore: could not read secret %s: %v\n", key, err)
+			os.Exit(2)
+		}
+	} else if val, ok := mgr.secrets[key]; ok {
+		return val, true
 	}
 
 	// For anything but local development or a gateway, a missing secret is a fatal error.
@@ -32,7 +90,13 @@ func (mgr *Manager) Load(key string, inService string) string {
 		os.Exit(2)
 	}
 
-	return ""
+	return "", false
+}
+
+// reread re-reads the current value of a secret read from a source in app_secrets.
+// Unlike Load it reports errors, so the caller can keep using the value it has.
+func (mgr *Manager) reread(ctx context.Context, key string) (string, error) {
+	return mgr.resolver.read(ctx, mgr.cfg.AppSecrets[key])
 }
 
 // parse parses secrets in "key1=base64(val1),key2=base64(val2)" format into a map.
@@ -0,0 +1,225 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestReadSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("from-file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_SECRET_SOURCE", "from-env")
+
+	tests := []struct {
+		Src      *config.SecretSource
+		Want     string
+		NotFound bool
+		Err      bool
+	}{
+		{Src: &config.SecretSource{File: path}, Want: "from-file"},
+		{Src: &config.SecretSource{Env: "TEST_SECRET_SOURCE"}, Want: "from-env"},
+		{Src: &config.SecretSource{File: path + ".missing"}, NotFound: true},
+		{Src: &config.SecretSource{Env: "TEST_SECRET_SOURCE_MISSING"}, NotFound: true},
+		{Src: &config.SecretSource{File: path, Env: "TEST_SECRET_SOURCE"}, Err: true},
+		{Src: &config.SecretSource{Env: "TEST_SECRET_SOURCE", Vault: &config.VaultSecretRef{Path: "secret/data/app", Key: "key"}}, Err: true},
+		{Src: &config.SecretSource{}, Err: true},
+	}
+	for i, test := range tests {
+		got, err := ReadSource(context.Background(), test.Src)
+		switch {
+		case test.NotFound:
+			if !errors.Is(err, ErrSourceNotFound) {
+				t.Fatalf("test %d: got err %v, want ErrSourceNotFound", i, err)
+			}
+		case test.Err:
+			if err == nil || errors.Is(err, ErrSourceNotFound) {
+				t.Fatalf("test %d: got err %v, want a config error", i, err)
+			}
+		case err != nil:
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		case got != test.Want:
+			t.Fatalf("test %d: got %q, want %q", i, got, test.Want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte("from-file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Runtime{
+		EnvCloud: "local",
+		AppSecrets: map[string]*config.SecretSource{
+			"FileKey":    {File: path},
+			"MissingKey": {File: path + ".missing"},
+		},
+	}
+	env := "FileKey=" + base64.RawURLEncoding.EncodeToString([]byte("overridden")) +
+		",EnvKey=" + base64.RawURLEncoding.EncodeToString([]byte("from-blob"))
+	mgr := NewManager(cfg, env)
+
+	for key, want := range map[string]string{
+		"FileKey":    "from-file",
+		"EnvKey":     "from-blob",
+		"MissingKey": "",
+	} {
+		if got := mgr.Load(key, "svc"); got != want {
+			t.Fatalf("Load(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadBytes(t *testing.T) {
+	enc := func(val string) string { return base64.RawURLEncoding.EncodeToString([]byte(val)) }
+	cfg := &config.Runtime{EnvCloud: "local"}
+	mgr := NewManager(cfg, "Binary="+enc("AAEC/w==")+",Prefixed="+enc("base64:AAEC/w=="))
+
+	if got := mgr.LoadBytes("Binary", "svc"); !bytes.Equal(got, []byte{0, 1, 2, 255}) {
+		t.Fatalf("LoadBytes(Binary) = %v, want [0 1 2 255]", got)
+	}
+	if got := mgr.LoadBytes("Missing", "svc"); got != nil {
+		t.Fatalf("LoadBytes(Missing) = %v, want nil", got)
+	}
+
+	// String secrets are never decoded.
+	if got := mgr.Load("Binary", "svc"); got != "AAEC/w==" {
+		t.Fatalf("Load(Binary) = %q, want the stored value", got)
+	}
+	if got := mgr.Load("Prefixed", "svc"); got != "base64:AAEC/w==" {
+		t.Fatalf("Load(Prefixed) = %q, want the stored value", got)
+	}
+}
+
+func TestLoadValues(t *testing.T) {
+	enc := func(val string) string { return base64.RawURLEncoding.EncodeToString([]byte(val)) }
+	cfg := &config.Runtime{EnvCloud: "local"}
+	env := "Single=" + enc(`["current"]`) +
+		",Rotating=" + enc(`["current","next"]`)
+	mgr := NewManager(cfg, env)
+
+	tests := []struct {
+		Key  string
+		Want []string
+	}{
+		{"Single", []string{"current"}},
+		{"Rotating", []string{"current", "next"}},
+		{"Missing", nil},
+	}
+	for _, test := range tests {
+		if got := mgr.LoadValues(test.Key, "svc"); !slices.Equal(got, test.Want) {
+			t.Fatalf("LoadValues(%q) = %q, want %q", test.Key, got, test.Want)
+		}
+	}
+
+	// Only secrets declared as []string are parsed as lists.
+	if got := mgr.Load("Rotating", "svc"); got != `["current","next"]` {
+		t.Fatalf("Load(Rotating) = %q, want the stored value", got)
+	}
+}
+
+type testCreds struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func (c testCreds) Validate() error {
+	if c.User == "" {
+		return errors.New("user is required")
+	}
+	return nil
+}
+
+func TestParseJSON(t *testing.T) {
+	got, err := parseJSON[testCreds](`{"user": "admin", "password": "hunter2"}`)
+	if err != nil {
+		t.Fatal(err)
+	} else if got != (testCreds{User: "admin", Password: "hunter2"}) {
+		t.Fatalf("got %+v", got)
+	}
+
+	tests := []struct {
+		Value   string
+		WantErr string
+	}{
+		{`{"user": "admin", "password": "hunter2"`, "not valid JSON: syntax error at offset 39"},
+		{`{"user": 5}`, "json: cannot unmarshal number into Go struct field testCreds.user of type string"},
+		{`{"password": "hunter2"}`, "validation failed: user is required"},
+	}
+	for _, test := range tests {
+		_, err := parseJSON[testCreds](test.Value)
+		if err == nil || err.Error() != test.WantErr {
+			t.Fatalf("parseJSON(%q): got err %v, want %q", test.Value, err, test.WantErr)
+		}
+		if strings.Contains(err.Error(), "hunter2") {
+			t.Fatalf("parseJSON(%q): error %q contains the secret value", test.Value, err)
+		}
+	}
+
+	// A missing secret is the zero value during local development.
+	mgr := NewManager(&config.Runtime{EnvCloud: "local"}, "")
+	if got := loadJSON[testCreds](mgr, "Missing", "svc")(); got != (testCreds{}) {
+		t.Fatalf("got %+v, want zero value", got)
+	}
+}
+
+func TestLoadJSONReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds")
+	write := func(val string) {
+		if err := os.WriteFile(path, []byte(val), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`{"user": "admin", "password": "hunter2"}`)
+
+	cfg := &config.Runtime{
+		EnvCloud:   "local",
+		AppSecrets: map[string]*config.SecretSource{"Creds": {File: path}},
+	}
+	mgr := NewManager(cfg, "Blob="+base64.RawURLEncoding.EncodeToString([]byte(`{"user": "blob"}`)))
+	now := time.Now()
+	mgr.resolver = &refResolver{now: func() time.Time { return now }, cache: make(map[secretRef]cachedRef)}
+
+	creds := loadJSON[testCreds](mgr, "Creds", "svc")
+	blob := loadJSON[testCreds](mgr, "Blob", "svc")
+	check := func(want testCreds) {
+		t.Helper()
+		if got := creds(); got != want {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	}
+	check(testCreds{User: "admin", Password: "hunter2"})
+
+	// The rotated value is picked up once the reload interval has passed.
+	write(`{"user": "admin", "password": "rotated"}`)
+	check(testCreds{User: "admin", Password: "hunter2"})
+	now = now.Add(jsonReloadInterval)
+	check(testCreds{User: "admin", Password: "rotated"})
+
+	// Invalid values and missing sources keep the current value.
+	write(`{"password": "invalid"}`)
+	now = now.Add(jsonReloadInterval)
+	check(testCreds{User: "admin", Password: "rotated"})
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(jsonReloadInterval)
+	check(testCreds{User: "admin", Password: "rotated"})
+
+	// Secrets from ENCORE_APP_SECRETS are read once.
+	if got := blob(); got != (testCreds{User: "blob"}) {
+		t.Fatalf("got %+v, want the value from the blob", got)
+	}
+}
@@ -0,0 +1,116 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// refCacheTTL is how long a secret fetched from an external secrets manager
+// is cached before it's fetched again, so rotated values are picked up when
+// it's re-read.
+const refCacheTTL = 5 * time.Minute
+
+// Secrets managers, named after their field in config.SecretSource.
+const (
+	vaultManager = "vault"
+	awsSMManager = "aws_secrets_manager"
+)
+
+// secretRef refers to a secret stored in an external secrets manager.
+type secretRef struct {
+	manager string // the secrets manager holding the secret
+	secret  string // the secret within the secrets manager
+	key     string // the key of the value within the secret, if any
+}
+
+func (ref secretRef) String() string {
+	if ref.key != "" {
+		return fmt.Sprintf("%s secret %s#%s", ref.manager, ref.secret, ref.key)
+	}
+	return fmt.Sprintf("%s secret %s", ref.manager, ref.secret)
+}
+
+// refFetcher fetches the value of the key of a secret in a secrets manager.
+type refFetcher func(ctx context.Context, secret, key string) (string, error)
+
+// refFetchers are the fetchers of the secrets managers included in the build,
+// keyed by secrets manager. The files implementing them register them, so
+// they can be left out with build tags.
+var refFetchers = make(map[string]refFetcher)
+
+func registerFetcher(manager string, fetch refFetcher) {
+	refFetchers[manager] = fetch
+}
+
+// refResolver fetches secrets stored in external secrets managers, like
+// HashiCorp Vault or AWS Secrets Manager, using the ambient credentials.
+// This way the secret material never passes through Encore's own storage.
+type refResolver struct {
+	fetchers map[string]refFetcher // keyed by secrets manager
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[secretRef]cachedRef
+}
+
+type cachedRef struct {
+	value   string
+	fetched time.Time
+}
+
+func newRefResolver() *refResolver {
+	return &refResolver{
+		fetchers: refFetchers,
+		now:      time.Now,
+		cache:    make(map[secretRef]cachedRef),
+	}
+}
+
+// defaultResolver is the resolver used for the app's secrets.
+var defaultResolver = newRefResolver()
+
+// resolve returns the value of the secret ref refers to.
+//
+// Resolved values are cached for refCacheTTL. If resolving a reference
+// again fails, the cached value is used until it succeeds.
+func (r *refResolver) resolve(ctx context.Context, ref secretRef) (string, error) {
+	fetch, ok := r.fetchers[ref.manager]
+	if !ok {
+		return "", fmt.Errorf("resolve %s: %s support was left out of the build", ref, ref.manager)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cached, haveCached := r.cache[ref]
+	if haveCached && r.now().Sub(cached.fetched) < refCacheTTL {
+		return cached.value, nil
+	}
+
+	resolved, err := fetch(ctx, ref.secret, ref.key)
+	if err != nil {
+		if haveCached {
+			fmt.Fprintf(os.Stderr, "encore: could not refresh %s, using cached value: %v\n", ref, err)
+			return cached.value, nil
+		}
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	r.cache[ref] = cachedRef{value: resolved, fetched: r.now()}
+	return resolved, nil
+}
+
+// stringValue returns the value of a key within a structured secret.
+// Values that aren't strings are returned JSON-encoded.
+func stringValue(val any) (string, error) {
+	if s, ok := val.(string); ok {
+		return s, nil
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+func TestRefResolver(t *testing.T) {
+	now := time.Now()
+	fetches := 0
+	fail := false
+	r := &refResolver{
+		fetchers: map[string]refFetcher{
+			"test": func(ctx context.Context, secret, key string) (string, error) {
+				if fail {
+					return "", errors.New("unavailable")
+				}
+				fetches++
+				return fmt.Sprintf("%s#%s-%d", secret, key, fetches), nil
+			},
+		},
+		now:   func() time.Time { return now },
+		cache: make(map[secretRef]cachedRef),
+	}
+	ctx := context.Background()
+	resolve := func(ref secretRef, want string) {
+		t.Helper()
+		got, err := r.resolve(ctx, ref)
+		if err != nil {
+			t.Fatalf("resolve(%v): %v", ref, err)
+		} else if got != want {
+			t.Fatalf("resolve(%v) = %q, want %q", ref, got, want)
+		}
+	}
+
+	// Secrets are cached until they expire.
+	a := secretRef{manager: "test", secret: "a", key: "k"}
+	resolve(a, "a#k-1")
+	resolve(a, "a#k-1")
+	resolve(secretRef{manager: "test", secret: "a", key: "other"}, "a#other-2")
+	now = now.Add(refCacheTTL)
+	resolve(a, "a#k-3")
+
+	// Failing to refresh a secret uses the cached value.
+	fail = true
+	now = now.Add(refCacheTTL)
+	resolve(a, "a#k-3")
+	if _, err := r.resolve(ctx, secretRef{manager: "test", secret: "b"}); err == nil {
+		t.Fatal("resolve of uncached failing secret succeeded")
+	}
+
+	// Secrets managers left out of the build are reported.
+	_, err := r.resolve(ctx, secretRef{manager: "missing", secret: "a"})
+	if err == nil || !strings.Contains(err.Error(), "left out of the build") {
+		t.Fatalf("got err %v, want missing secrets manager error", err)
+	}
+}
+
+func TestLoadResolvesRefs(t *testing.T) {
+	cfg := &config.Runtime{
+		EnvCloud: "local",
+		AppSecrets: map[string]*config.SecretSource{
+			"VaultKey": {Vault: &config.VaultSecretRef{Path: "secret/data/app", Key: "api_key"}},
+			"AWSKey":   {AWSSecretsManager: &config.AWSSecretsManagerRef{SecretID: "app"}},
+		},
+	}
+	// Blob=vault:secret/data/app#api_key
+	mgr := NewManager(cfg, "Blob=dmF1bHQ6c2VjcmV0L2RhdGEvYXBwI2FwaV9rZXk")
+	fetch := func(manager string) refFetcher {
+		return func(ctx context.Context, secret, key string) (string, error) {
+			return manager + ":" + secret + "#" + key, nil
+		}
+	}
+	mgr.resolver = &refResolver{
+		fetchers: map[string]refFetcher{
+			vaultManager: fetch(vaultManager),
+			awsSMManager: fetch(awsSMManager),
+		},
+		now:   time.Now,
+		cache: make(map[secretRef]cachedRef),
+	}
+
+	for key, want := range map[string]string{
+		"VaultKey": "vault:secret/data/app#api_key",
+		"AWSKey":   "aws_secrets_manager:app#",
+		// Values are never interpreted as references.
+		"Blob": "vault:secret/data/app#api_key",
+	} {
+		if got := mgr.Load(key, "svc"); got != want {
+			t.Fatalf("Load(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -15,3 +15,15 @@ var singleton = NewManager(
 func Load(key string, inService string) string {
 	return singleton.Load(key, inService)
 }
+
+func LoadBytes(key string, inService string) []byte {
+	return singleton.LoadBytes(key, inService)
+}
+
+func LoadValues(key string, inService string) []string {
+	return singleton.LoadValues(key, inService)
+}
+
+func LoadJSON[T any](key string, inService string) func() T {
+	return loadJSON[T](singleton, key, inService)
+}
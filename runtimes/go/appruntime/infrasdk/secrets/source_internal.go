@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+// ErrSourceNotFound is reported when a secret source's
+// file or environment variable doesn't exist.
+var ErrSourceNotFound = errors.New("secret source not found")
+
+// ReadSource reads a secret value from its source. The source is read on
+// every call, and secrets in external secrets managers are fetched again once
+// their cached value is older than refCacheTTL, so rotated values are picked up.
+func ReadSource(ctx context.Context, src *config.SecretSource) (string, error) {
+	return defaultResolver.read(ctx, src)
+}
+
+// read reads a secret value from its source.
+func (r *refResolver) read(ctx context.Context, src *config.SecretSource) (string, error) {
+	set := 0
+	for _, ok := range []bool{src.File != "", src.Env != "", src.Vault != nil, src.AWSSecretsManager != nil} {
+		if ok {
+			set++
+		}
+	}
+	if set == 0 {
+		return "", errors.New("none of file, env, vault and aws_secrets_manager is set")
+	} else if set > 1 {
+		return "", errors.New("only one of file, env, vault and aws_secrets_manager can be set")
+	}
+
+	switch {
+	case src.File != "":
+		data, err := os.ReadFile(src.File)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("file %s: %w", src.File, ErrSourceNotFound)
+		} else if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case src.Env != "":
+		val, ok := os.LookupEnv(src.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s: %w", src.Env, ErrSourceNotFound)
+		}
+		return val, nil
+	case src.Vault != nil:
+		return r.resolve(ctx, secretRef{manager: vaultManager, secret: src.Vault.Path, key: src.Vault.Key})
+	default:
+		ref := src.AWSSecretsManager
+		return r.resolve(ctx, secretRef{manager: awsSMManager, secret: ref.SecretID, key: ref.Key})
+	}
+}
@@ -0,0 +1,208 @@
+//go:build !encore_no_vault
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// defaultVaultAddr is the address of Vault if VAULT_ADDR is unset,
+	// matching the Vault CLI.
+	defaultVaultAddr = "https://127.0.0.1:8200"
+
+	// kubernetesTokenPath is where Kubernetes mounts the service account token.
+	kubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// errVaultForbidden is reported when Vault rejects the token.
+var errVaultForbidden = errors.New("permission denied")
+
+// vaultClient reads secrets from HashiCorp Vault's HTTP API.
+//
+// It's configured with the environment variables the Vault CLI uses:
+// VAULT_ADDR, VAULT_NAMESPACE and VAULT_TOKEN, falling back to the token
+// in ~/.vault-token written by "vault login". If VAULT_KUBERNETES_ROLE
+// is set instead it logs in with the Kubernetes auth method, using the
+// pod's service account token.
+type vaultClient struct {
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+func newVaultClient() *vaultClient {
+	return &vaultClient{httpClient: http.DefaultClient}
+}
+
+func init() {
+	registerFetcher(vaultManager, newVaultClient().fetch)
+}
+
+// fetch fetches the value of the key in the secret at the given API path, like
+// the key "api_key" of "secret/data/app" for the KV version 2 secret "app"
+// in the "secret" mount.
+func (c *vaultClient) fetch(ctx context.Context, path, key string) (string, error) {
+	if path == "" || key == "" {
+		return "", errors.New("invalid vault reference: both path and key must be set")
+	}
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	err := c.request(ctx, http.MethodGet, path, nil, &resp)
+	if errors.Is(err, errVaultForbidden) && c.usesKubernetesAuth() {
+		// The token may have expired; log in again.
+		c.mu.Lock()
+		c.token = ""
+		c.mu.Unlock()
+		err = c.request(ctx, http.MethodGet, path, nil, &resp)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	data := resp.Data
+	// KV version 2 secrets nest the data alongside its metadata.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	val, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %s", path, key)
+	}
+	return stringValue(val)
+}
+
+// request makes an authenticated request to the Vault API path,
+// decoding the JSON response into dst.
+func (c *vaultClient) request(ctx context.Context, method, path string, body, dst any) error {
+	token, err := c.getToken(ctx)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, method, path, token, body, dst)
+}
+
+func (c *vaultClient) do(ctx context.Context, method, path, token string, body, dst any) error {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		addr = defaultVaultAddr
+	}
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	url := strings.TrimSuffix(addr, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("vault %s: %w", path, errVaultForbidden)
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("vault %s: secret not found", path)
+	case resp.StatusCode >= 300:
+		var errResp struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(respBody, &errResp) == nil && len(errResp.Errors) > 0 {
+			return fmt.Errorf("vault %s: %s", path, strings.Join(errResp.Errors, "; "))
+		}
+		return fmt.Errorf("vault %s: unexpected status %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(respBody, dst); err != nil {
+		return fmt.Errorf("vault %s: parse response: %w", path, err)
+	}
+	return nil
+}
+
+func (c *vaultClient) usesKubernetesAuth() bool {
+	return os.Getenv("VAULT_TOKEN") == "" && os.Getenv("VAULT_KUBERNETES_ROLE") != ""
+}
+
+// getToken returns the token to authenticate to Vault with.
+func (c *vaultClient) getToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	if !c.usesKubernetesAuth() {
+		if home, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				return strings.TrimSpace(string(data)), nil
+			}
+		}
+		return "", errors.New("no vault credentials: set VAULT_TOKEN or VAULT_KUBERNETES_ROLE")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	jwtPath := os.Getenv("VAULT_KUBERNETES_TOKEN_PATH")
+	if jwtPath == "" {
+		jwtPath = kubernetesTokenPath
+	}
+	jwt, err := os.ReadFile(jwtPath)
+	if err != nil {
+		return "", fmt.Errorf("read kubernetes service account token: %w", err)
+	}
+	mount := os.Getenv("VAULT_KUBERNETES_MOUNT")
+	if mount == "" {
+		mount = "kubernetes"
+	}
+
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	loginBody := map[string]string{
+		"role": os.Getenv("VAULT_KUBERNETES_ROLE"),
+		"jwt":  strings.TrimSpace(string(jwt)),
+	}
+	if err := c.do(ctx, http.MethodPost, "auth/"+mount+"/login", "", loginBody, &resp); err != nil {
+		return "", fmt.Errorf("kubernetes login: %w", err)
+	}
+	c.token = resp.Auth.ClientToken
+	return c.token, nil
+}
@@ -0,0 +1,88 @@
+//go:build !encore_no_vault
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVaultFetch(t *testing.T) {
+	jwtPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(jwtPath, []byte("service-account-jwt\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	logins := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Namespace") != "team" {
+			t.Errorf("got namespace %q, want team", r.Header.Get("X-Vault-Namespace"))
+		}
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["role"] != "app" || body["jwt"] != "service-account-jwt" {
+				t.Errorf("unexpected login request %v", body)
+			}
+			logins++
+			_, _ = fmt.Fprintf(w, `{"auth": {"client_token": "k8s-token-%d"}}`, logins)
+		case "/v1/secret/data/app":
+			if r.Header.Get("X-Vault-Token") != "static-token" && r.Header.Get("X-Vault-Token") != "k8s-token-2" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"data": {"data": {"api_key": "v2-key", "port": 8080}, "metadata": {"version": 3}}}`)
+		case "/v1/kv/app":
+			_, _ = fmt.Fprint(w, `{"data": {"api_key": "v1-key"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"errors": []}`)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_NAMESPACE", "team")
+	t.Setenv("VAULT_TOKEN", "static-token")
+
+	c := newVaultClient()
+	ctx := context.Background()
+	for _, test := range []struct{ Path, Key, Want string }{
+		{"secret/data/app", "api_key", "v2-key"},
+		{"secret/data/app", "port", "8080"},
+		{"kv/app", "api_key", "v1-key"},
+	} {
+		got, err := c.fetch(ctx, test.Path, test.Key)
+		if err != nil {
+			t.Fatalf("fetch(%q, %q): %v", test.Path, test.Key, err)
+		} else if got != test.Want {
+			t.Fatalf("fetch(%q, %q) = %q, want %q", test.Path, test.Key, got, test.Want)
+		}
+	}
+	for _, test := range []struct{ Path, Key string }{
+		{"secret/data/app", ""},
+		{"secret/data/app", "missing"},
+		{"secret/data/missing", "key"},
+	} {
+		if _, err := c.fetch(ctx, test.Path, test.Key); err == nil {
+			t.Fatalf("fetch(%q, %q) succeeded, want error", test.Path, test.Key)
+		}
+	}
+
+	// With Kubernetes auth, a rejected token makes the client log in again.
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("VAULT_KUBERNETES_ROLE", "app")
+	t.Setenv("VAULT_KUBERNETES_TOKEN_PATH", jwtPath)
+	got, err := c.fetch(ctx, "secret/data/app", "api_key")
+	if err != nil {
+		t.Fatal(err)
+	} else if got != "v2-key" || logins != 2 {
+		t.Fatalf("got %q after %d logins, want v2-key after 2", got, logins)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	stdjson "encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -168,6 +170,23 @@ func (u *Unmarshaller) ReadBody(body io.Reader) (payload []byte) {
 	return payload
 }
 
+// ReadForm reads a form-encoded (application/x-www-form-urlencoded) request body.
+func (u *Unmarshaller) ReadForm(body io.Reader) url.Values {
+	payload := u.ReadBody(body)
+	form, err := url.ParseQuery(string(payload))
+	if err != nil {
+		u.setErr("could not parse form-encoded request body", "request_body", err)
+	}
+	return form
+}
+
+// IsFormEncoded reports whether the Content-Type header value denotes
+// a form-encoded (application/x-www-form-urlencoded) body.
+func IsFormEncoded(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == "application/x-www-form-urlencoded"
+}
+
 func (u *Unmarshaller) ParseJSON(field string, iter *jsoniter.Iterator, dst any) {
 	iter.ReadVal(dst)
 	u.setErr("invalid json parameter", field, iter.Error)
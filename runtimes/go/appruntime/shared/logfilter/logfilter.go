@@ -0,0 +1,210 @@
+// Package logfilter decides which logs to emit, based on the log levels
+// and sample rates configured for the app and each of its services.
+package logfilter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/shutdown"
+)
+
+// Filter decides which logs to emit.
+// The zero value, and a nil *Filter, emit all logs.
+type Filter struct {
+	ctx    context.Context
+	cancel func()
+
+	file       string
+	interval   time.Duration
+	rootLogger zerolog.Logger
+
+	rules atomic.Pointer[rules]
+}
+
+// New returns a filter for the given logging config, which may be nil.
+func New(cfg *config.Logging, rootLogger zerolog.Logger) *Filter {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &Filter{ctx: ctx, cancel: cancel, rootLogger: rootLogger}
+	if cfg == nil {
+		return f
+	}
+
+	r, err := compile(cfg)
+	if err != nil {
+		rootLogger.Error().Err(err).Msg("invalid logging config, emitting all logs")
+	} else {
+		f.rules.Store(r)
+	}
+
+	f.file = cfg.ReloadFile
+	f.interval = cfg.ReloadInterval
+	if f.interval <= 0 {
+		f.interval = 10 * time.Second
+	}
+	return f
+}
+
+// Allow reports whether to emit a log at the given level, written by svc
+// while handling a request to endpoint. Both may be empty.
+func (f *Filter) Allow(level model.LogLevel, svc, endpoint string) bool {
+	if f == nil {
+		return true
+	}
+	r := f.rules.Load()
+	if r == nil {
+		return true
+	}
+
+	lvl := r.app
+	if s, ok := r.services[svc]; ok {
+		if endpoint != "" && slices.Contains(s.debugEndpoints, endpoint) {
+			return true
+		}
+		lvl = s.levelRule
+	}
+
+	if level < lvl.minLevel {
+		return false
+	}
+	return level >= model.LevelWarn || lvl.sampleRate >= 1 || rand.Float64() < lvl.sampleRate
+}
+
+// Watch reloads the logging config from the reload file
+// whenever it changes, until the filter is shut down.
+func (f *Filter) Watch() {
+	if f.file == "" {
+		return
+	}
+
+	// Load it right away, since it replaces the config the app started with.
+	var s reloadState
+	f.reload(&s)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+			f.reload(&s)
+		}
+	}
+}
+
+func (f *Filter) Shutdown(p *shutdown.Process) error {
+	f.cancel()
+	return nil
+}
+
+// reloadState tracks what was last read from the reload file,
+// so that unchanged contents and repeated errors are only handled once.
+type reloadState struct {
+	data    []byte
+	readErr string
+}
+
+// reload reloads the logging config from the reload file,
+// if it has changed since it was last loaded.
+func (f *Filter) reload(s *reloadState) {
+	data, err := os.ReadFile(f.file)
+	if err != nil {
+		if err.Error() != s.readErr {
+			s.readErr = err.Error()
+			f.rootLogger.Error().Err(err).Str("file", f.file).Msg("unable to read logging config, keeping the current one")
+		}
+		return
+	}
+	s.readErr = ""
+	if s.data != nil && bytes.Equal(data, s.data) {
+		return
+	}
+	s.data = data
+
+	var cfg config.Logging
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		f.rootLogger.Error().Err(err).Str("file", f.file).Msg("unable to parse logging config, keeping the current one")
+		return
+	}
+	r, err := compile(&cfg)
+	if err != nil {
+		f.rootLogger.Error().Err(err).Str("file", f.file).Msg("invalid logging config, keeping the current one")
+		return
+	}
+	f.rules.Store(r)
+	f.rootLogger.Info().Str("file", f.file).Msg("reloaded logging config")
+}
+
+// rules are the compiled form of a logging config.
+type rules struct {
+	app      levelRule
+	services map[string]serviceRule
+}
+
+type levelRule struct {
+	minLevel   model.LogLevel
+	sampleRate float64
+}
+
+type serviceRule struct {
+	levelRule
+	debugEndpoints []string
+}
+
+func compile(cfg *config.Logging) (*rules, error) {
+	app, err := compileLevel(levelRule{model.LevelDebug, 1}, cfg.Level, cfg.SampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &rules{app: app, services: make(map[string]serviceRule, len(cfg.Services))}
+	for name, svc := range cfg.Services {
+		if svc == nil {
+			continue
+		}
+		lvl, err := compileLevel(app, svc.Level, svc.SampleRate)
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %v", name, err)
+		}
+		r.services[name] = serviceRule{levelRule: lvl, debugEndpoints: svc.DebugEndpoints}
+	}
+	return r, nil
+}
+
+// compileLevel compiles the level and sample rate,
+// defaulting to those of def if they're not set.
+func compileLevel(def levelRule, level string, sampleRate *float64) (levelRule, error) {
+	r := def
+	if level != "" {
+		var ok bool
+		if r.minLevel, ok = levels[level]; !ok {
+			return levelRule{}, fmt.Errorf("unknown log level %q", level)
+		}
+	}
+	if sampleRate != nil {
+		if *sampleRate < 0 || *sampleRate > 1 {
+			return levelRule{}, fmt.Errorf("sample rate %v is not between 0 and 1", *sampleRate)
+		}
+		r.sampleRate = *sampleRate
+	}
+	return r, nil
+}
+
+var levels = map[string]model.LogLevel{
+	"debug": model.LevelDebug,
+	"info":  model.LevelInfo,
+	"warn":  model.LevelWarn,
+	"error": model.LevelError,
+}
@@ -0,0 +1,101 @@
+package logfilter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestAllow(t *testing.T) {
+	zero, half := 0.0, 0.5
+	f := New(&config.Logging{
+		Level: "info",
+		Services: map[string]*config.ServiceLogging{
+			"noisy":   {Level: "warn"},
+			"sampled": {SampleRate: &zero},
+			"debug":   {Level: "debug", SampleRate: &half, DebugEndpoints: []string{"Broken"}},
+		},
+	}, zerolog.Nop())
+
+	tests := []struct {
+		level    model.LogLevel
+		svc      string
+		endpoint string
+		want     bool
+	}{
+		{model.LevelDebug, "", "", false},
+		{model.LevelInfo, "", "", true},
+		{model.LevelDebug, "other", "Foo", false},
+		{model.LevelInfo, "noisy", "", false},
+		{model.LevelWarn, "noisy", "", true},
+		{model.LevelInfo, "sampled", "", false},
+		{model.LevelDebug, "sampled", "", false},
+		{model.LevelWarn, "sampled", "", true},
+		{model.LevelError, "sampled", "", true},
+		{model.LevelTrace, "debug", "Broken", true},
+		{model.LevelDebug, "debug", "Broken", true},
+	}
+	for _, test := range tests {
+		if got := f.Allow(test.level, test.svc, test.endpoint); got != test.want {
+			t.Errorf("Allow(%v, %q, %q) = %v, want %v", test.level, test.svc, test.endpoint, got, test.want)
+		}
+	}
+
+	// Without any config, all logs are emitted.
+	var nilFilter *Filter
+	if !New(nil, zerolog.Nop()).Allow(model.LevelDebug, "", "") || !nilFilter.Allow(model.LevelDebug, "", "") {
+		t.Error("got debug log filtered out without a logging config")
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	two := 2.0
+	for _, cfg := range []*config.Logging{
+		{Level: "verbose"},
+		{SampleRate: &two},
+		{Services: map[string]*config.ServiceLogging{"foo": {Level: "loud"}}},
+	} {
+		if _, err := compile(cfg); err == nil {
+			t.Errorf("compile(%+v): got nil error", cfg)
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "logging.json")
+	f := New(&config.Logging{Level: "warn", ReloadFile: file}, zerolog.Nop())
+	var s reloadState
+
+	// A missing file keeps the initial config.
+	f.reload(&s)
+	if f.Allow(model.LevelInfo, "", "") {
+		t.Fatal("got info log emitted before reload")
+	}
+
+	write := func(data string) {
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		f.reload(&s)
+	}
+
+	write(`{"level": "info", "services": {"foo": {"level": "error"}}}`)
+	if !f.Allow(model.LevelInfo, "", "") || f.Allow(model.LevelWarn, "foo", "") {
+		t.Fatal("got reloaded config not applied")
+	}
+
+	// An invalid config keeps the current one.
+	write(`{"level": "verbose"}`)
+	if !f.Allow(model.LevelInfo, "", "") {
+		t.Fatal("got invalid config applied")
+	}
+	write(`{`)
+	if !f.Allow(model.LevelInfo, "", "") {
+		t.Fatal("got unparsable config applied")
+	}
+}
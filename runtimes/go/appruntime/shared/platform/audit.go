@@ -0,0 +1,58 @@
+package platform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"encore.dev/appruntime/exported/auditlog"
+)
+
+// RecordAuditEntry appends an entry to the audit log store,
+// and returns the entry as stored, with its sequence number and hashes.
+func (c *Client) RecordAuditEntry(ctx context.Context, e *auditlog.Entry) (*auditlog.Entry, error) {
+	cfg := c.runtime.AuditLog
+	if cfg == nil || cfg.Endpoint == "" {
+		return nil, fmt.Errorf("no audit log store configured")
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", cfg.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Encore-App-ID", c.runtime.AppID)
+	req.Header.Set("X-Encore-Env-ID", c.runtime.EnvID)
+	req.Header.Set("X-Encore-Deploy-ID", c.runtime.DeployID)
+	if cfg.Retention > 0 {
+		req.Header.Set("X-Encore-Audit-Retention", strconv.FormatInt(int64(cfg.Retention.Seconds()), 10))
+	}
+	if len(c.runtime.AuthKeys) > 0 {
+		c.addAuthKey(req)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("http %s: %s", resp.Status, body)
+	}
+
+	var stored auditlog.Entry
+	if err := json.NewDecoder(resp.Body).Decode(&stored); err != nil {
+		return nil, fmt.Errorf("decode stored audit entry: %v", err)
+	}
+	return &stored, nil
+}
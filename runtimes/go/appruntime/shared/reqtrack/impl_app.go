@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"sync/atomic"
 	_ "unsafe" // for go:linkname
+
+	model2 "encore.dev/appruntime/exported/model"
 )
 
 func newImpl() reqTrackImpl {
@@ -58,6 +60,9 @@ func exitEncoreG(e *encoreG) {}
 //go:linkname beginHTTPRoundTrip net/http.encoreBeginRoundTrip
 func beginHTTPRoundTrip(req *http.Request) (context.Context, error) {
 	g := getEncoreG()
+	if g != nil && g.req != nil && req.URL != nil {
+		g.req.data.CaptureOp(model2.CapturedOp{Kind: model2.CapturedHTTPCall, Method: req.Method, URL: req.URL.String()})
+	}
 	if g == nil || g.req == nil || !g.req.data.Traced {
 		return req.Context(), nil
 	} else if req.URL == nil {
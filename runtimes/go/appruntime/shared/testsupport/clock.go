@@ -0,0 +1,224 @@
+package testsupport
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// Clock is a fake clock controlled by a test, and shared with its sub-tests.
+//
+// The work that becomes due when the clock is advanced, like cron jobs,
+// runs synchronously in the goroutine advancing the clock, in order.
+type Clock struct {
+	mgr *Manager
+
+	mu     sync.Mutex
+	now    time.Time
+	timers []*clockTimer
+	seq    uint64
+}
+
+type clockTimer struct {
+	when time.Time
+	seq  uint64 // to fire timers due at the same time in the order they were added
+	fn   func()
+}
+
+// cronJob is a cron job, run when due on a fake clock.
+type cronJob struct {
+	id   string
+	next func(after time.Time) time.Time // returns the zero time if never due
+	run  func(ctx context.Context) error
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d, running the work that becomes due.
+func (c *Clock) Advance(d time.Duration) {
+	if d < 0 {
+		panic("et.Clock: cannot move the clock backwards")
+	}
+	c.advanceTo(c.Now().Add(d))
+}
+
+// Set moves the clock forward to t, running the work that becomes due.
+func (c *Clock) Set(t time.Time) {
+	if t.Before(c.Now()) {
+		panic("et.Clock: cannot move the clock backwards")
+	}
+	c.advanceTo(t)
+}
+
+// AfterFunc runs fn once the clock has been advanced by d.
+func (c *Clock) AfterFunc(d time.Duration, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addTimer(c.now.Add(d), fn)
+}
+
+// addTimer adds a timer. c.mu must be held.
+func (c *Clock) addTimer(when time.Time, fn func()) {
+	c.seq++
+	c.timers = append(c.timers, &clockTimer{when: when, seq: c.seq, fn: fn})
+}
+
+// advanceTo moves the clock forward to the time to, stopping at each
+// timer that becomes due to run it at the time it was due.
+func (c *Clock) advanceTo(to time.Time) {
+	for {
+		c.mu.Lock()
+		idx := -1
+		for i, t := range c.timers {
+			if t.when.After(to) {
+				continue
+			}
+			if idx < 0 || t.when.Before(c.timers[idx].when) || (t.when.Equal(c.timers[idx].when) && t.seq < c.timers[idx].seq) {
+				idx = i
+			}
+		}
+
+		var timer *clockTimer
+		next := to
+		if idx >= 0 {
+			timer = c.timers[idx]
+			c.timers = append(c.timers[:idx], c.timers[idx+1:]...)
+			next = timer.when
+		}
+		elapsed := next.Sub(c.now)
+		if elapsed > 0 {
+			c.now = next
+		}
+		c.mu.Unlock()
+
+		if elapsed > 0 {
+			c.mgr.notifyClockAdvance(c, elapsed)
+		}
+		if timer == nil {
+			return
+		}
+		timer.fn()
+	}
+}
+
+// scheduleCronJob schedules the next run of the cron job after the time after.
+func (c *Clock) scheduleCronJob(job cronJob, after time.Time) {
+	when := job.next(after)
+	if when.IsZero() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addTimer(when, func() {
+		t := c.mgr.CurrentTest()
+		if err := job.run(c.mgr.current().Ctx); err != nil {
+			t.Errorf("cron job %s failed: %v", job.id, err)
+		}
+		c.scheduleCronJob(job, when)
+	})
+}
+
+// Clock returns the fake clock of the current test or its closest parent test.
+// If none of them have one, a clock starting at the current time is created for the
+// current test, and the cron jobs are scheduled to run on it when due.
+func (mgr *Manager) Clock() *Clock {
+	if c := mgr.CurrentClock(); c != nil {
+		return c
+	}
+
+	t := mgr.CurrentTest()
+	c := &Clock{mgr: mgr, now: time.Now()}
+	mgr.clockMu.Lock()
+	if mgr.clocks == nil {
+		mgr.clocks = make(map[*testing.T]*Clock)
+	}
+	mgr.clocks[t] = c
+	jobs := mgr.cronJobs
+	mgr.clockMu.Unlock()
+
+	for _, job := range jobs {
+		c.scheduleCronJob(job, c.now)
+	}
+	return c
+}
+
+// Now returns the current time, according to the fake clock of the
+// current test if it has one, and the real time otherwise.
+func (mgr *Manager) Now() time.Time {
+	if c := mgr.CurrentClock(); c != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// CurrentClock returns the fake clock of the current test
+// or its closest parent test, or nil if there is none.
+func (mgr *Manager) CurrentClock() *Clock {
+	if mgr.static == nil || !mgr.static.Testing {
+		return nil
+	}
+	req := mgr.rt.Current().Req
+	if req == nil {
+		return nil
+	}
+	return mgr.TestClock(req.Test)
+}
+
+// TestClock returns the fake clock of the test td
+// or its closest parent test, or nil if there is none.
+func (mgr *Manager) TestClock(td *model.TestData) *Clock {
+	mgr.clockMu.Lock()
+	defer mgr.clockMu.Unlock()
+	for td != nil {
+		if c, ok := mgr.clocks[td.Current]; ok {
+			return c
+		}
+		if td.Parent == nil {
+			break
+		}
+		td = td.Parent.Test
+	}
+	return nil
+}
+
+// RegisterCronJob registers a cron job to run when it becomes due on
+// a test's fake clock. The next function returns when the job is next
+// due after the given time, or the zero time if it's never due.
+func (mgr *Manager) RegisterCronJob(id string, next func(after time.Time) time.Time, run func(ctx context.Context) error) {
+	mgr.clockMu.Lock()
+	defer mgr.clockMu.Unlock()
+	mgr.cronJobs = append(mgr.cronJobs, cronJob{id: id, next: next, run: run})
+}
+
+// OnClockAdvance registers fn to be called with the clock and the
+// elapsed time whenever a test's fake clock is advanced.
+func (mgr *Manager) OnClockAdvance(fn func(c *Clock, elapsed time.Duration)) {
+	mgr.clockMu.Lock()
+	defer mgr.clockMu.Unlock()
+	mgr.clockHooks = append(mgr.clockHooks, fn)
+}
+
+func (mgr *Manager) notifyClockAdvance(c *Clock, elapsed time.Duration) {
+	mgr.clockMu.Lock()
+	hooks := mgr.clockHooks
+	mgr.clockMu.Unlock()
+	for _, fn := range hooks {
+		fn(c, elapsed)
+	}
+}
+
+// endClock removes the fake clock of the test t, if any.
+func (mgr *Manager) endClock(t *testing.T) {
+	mgr.clockMu.Lock()
+	defer mgr.clockMu.Unlock()
+	delete(mgr.clocks, t)
+}
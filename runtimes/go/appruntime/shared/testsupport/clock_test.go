@@ -0,0 +1,53 @@
+package testsupport
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClockAdvance(t *testing.T) {
+	mgr := &Manager{}
+	start := time.Date(2023, 5, 10, 12, 0, 0, 0, time.UTC)
+	c := &Clock{mgr: mgr, now: start}
+
+	var elapsed []time.Duration
+	mgr.OnClockAdvance(func(_ *Clock, d time.Duration) { elapsed = append(elapsed, d) })
+
+	var fired []string
+	record := func(name string) func() {
+		return func() { fired = append(fired, name+"@"+c.Now().Sub(start).String()) }
+	}
+	c.AfterFunc(2*time.Minute, record("b"))
+	c.AfterFunc(time.Minute, func() {
+		record("a")()
+		// Timers added while advancing fire during the same advance when due.
+		c.AfterFunc(30*time.Second, record("c"))
+	})
+	c.AfterFunc(2*time.Minute, record("d"))
+	c.AfterFunc(time.Hour, record("e"))
+
+	c.Advance(5 * time.Minute)
+
+	if want := []string{"a@1m0s", "c@1m30s", "b@2m0s", "d@2m0s"}; !reflect.DeepEqual(fired, want) {
+		t.Errorf("got fired %v, want %v", fired, want)
+	}
+	if want := []time.Duration{time.Minute, 30 * time.Second, 30 * time.Second, 3 * time.Minute}; !reflect.DeepEqual(elapsed, want) {
+		t.Errorf("got elapsed %v, want %v", elapsed, want)
+	}
+	if got, want := c.Now(), start.Add(5*time.Minute); !got.Equal(want) {
+		t.Errorf("got now %v, want %v", got, want)
+	}
+
+	c.Set(start.Add(time.Hour))
+	if want := "e@1h0m0s"; fired[len(fired)-1] != want {
+		t.Errorf("got last fired %v, want %v", fired[len(fired)-1], want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected moving the clock backwards to panic")
+		}
+	}()
+	c.Set(start)
+}
@@ -17,6 +17,7 @@ func newTestConfig(parent *model.TestConfig) *model.TestConfig {
 		Parent:       parent,
 		ServiceMocks: make(map[string]model.ServiceMock),
 		APIMocks:     make(map[string]map[string]model.ApiMock),
+		Faults:       make(map[string]map[string]*model.InjectedFault),
 	}
 }
 
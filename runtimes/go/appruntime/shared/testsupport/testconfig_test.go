@@ -0,0 +1,47 @@
+package testsupport
+
+import (
+	"testing"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestLookupFault(t *testing.T) {
+	svcFault := &model.InjectedFault{Latency: time.Second}
+	apiFault := &model.InjectedFault{Latency: time.Minute}
+
+	parent := newTestConfig(nil)
+	parent.Faults["svc"] = map[string]*model.InjectedFault{"": svcFault, "get": apiFault}
+	child := newTestConfig(parent)
+
+	tests := []struct {
+		name      string
+		cfg       *model.TestConfig
+		service   string
+		api       string
+		want      *model.InjectedFault
+		wantFound bool
+	}{
+		{"api", child, "svc", "Get", apiFault, true},
+		{"service", child, "Svc", "List", svcFault, true},
+		{"other_service", child, "other", "Get", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := lookupFault(tt.cfg, tt.service, tt.api)
+			if got != tt.want || found != tt.wantFound {
+				t.Errorf("got (%v, %v), want (%v, %v)", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+
+	// A nil fault in a sub-test removes the parent test's faults.
+	child.Faults["svc"] = map[string]*model.InjectedFault{"": nil}
+	if got, found := lookupFault(child, "svc", "list"); got != nil || !found {
+		t.Errorf("got (%v, %v), want (nil, true)", got, found)
+	}
+	if got, _ := lookupFault(child, "svc", "get"); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
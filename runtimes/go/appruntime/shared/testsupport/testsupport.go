@@ -32,6 +32,11 @@ type Manager struct {
 	testServiceOnce sync.Once
 	testService     string
 	testServiceNum  uint16
+
+	clockMu    sync.Mutex
+	clocks     map[*testing.T]*Clock // the fake clocks of the tests using one
+	cronJobs   []cronJob
+	clockHooks []func(c *Clock, elapsed time.Duration)
 }
 
 func NewManager(static *config.Static, rt *reqtrack.RequestTracker, rootLogger zerolog.Logger) *Manager {
@@ -185,6 +190,7 @@ func (mgr *Manager) EndTest(t *testing.T) {
 		})
 	}
 
+	mgr.endClock(t)
 	mgr.rt.FinishRequest(true)
 }
 
@@ -338,9 +344,57 @@ func (mgr *Manager) GetAPIMock(service string, api string) (model.ApiMock, bool)
 	})
 }
 
+// SetFault sets the fault to inject into calls to an API for the current test.
+// If api is "", the fault is injected into calls to all the service's APIs.
+// A nil fault removes any fault injected by a parent test.
+func (mgr *Manager) SetFault(service string, api string, fault *model.InjectedFault) {
+	service = strings.TrimSpace(strings.ToLower(service))
+	api = strings.TrimSpace(strings.ToLower(api))
+
+	cfg := mgr.currentConfig()
+	cfg.Mu.Lock()
+	defer cfg.Mu.Unlock()
+
+	if cfg.Faults[service] == nil {
+		cfg.Faults[service] = make(map[string]*model.InjectedFault)
+	}
+	cfg.Faults[service][api] = fault
+}
+
+// GetFault returns the fault to inject into calls to an API for the current test
+// or any parent tests - returning the lowest level fault available, preferring
+// faults injected into the API over faults injected into the whole service.
+func (mgr *Manager) GetFault(service string, api string) (*model.InjectedFault, bool) {
+	return lookupFault(mgr.currentConfig(), service, api)
+}
+
+func lookupFault(config *TestConfig, service string, api string) (*model.InjectedFault, bool) {
+	service = strings.TrimSpace(strings.ToLower(service))
+	api = strings.TrimSpace(strings.ToLower(api))
+
+	return walkConfig(config, func(cfg *TestConfig) (value *model.InjectedFault, found bool) {
+		faults := cfg.Faults[service]
+		if faults == nil {
+			return
+		}
+		if value, found = faults[api]; !found {
+			value, found = faults[""]
+		}
+		return
+	})
+}
+
 func (mgr *Manager) AddEndCallback(fn func(t *testing.T)) {
 	cfg := mgr.currentConfig()
 	cfg.Mu.Lock()
 	defer cfg.Mu.Unlock()
 	cfg.EndCallbacks = append(cfg.EndCallbacks, fn)
 }
+
+// Capture returns the capture of the operations performed during the current test
+// and its sub-tests, starting to capture them if the test isn't already.
+func (mgr *Manager) Capture() *model.TestCapture {
+	td := mgr.current()
+	td.Capture.CompareAndSwap(nil, &model.TestCapture{})
+	return td.Capture.Load()
+}
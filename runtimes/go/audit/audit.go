@@ -0,0 +1,186 @@
+// Package audit records structured, tamper-evident audit events,
+// describing who did what to which resource.
+//
+// Events are recorded with the authenticated user of the current request
+// as the actor, and are appended to a hash-chained audit log so that
+// modifications to the log can be detected.
+//
+// When running locally the audit log is stored by Encore, and can be
+// queried with "encore audit list" and in the local development dashboard.
+//
+// For more information see https://encore.dev/docs/primitives/audit-log.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/auditlog"
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/platform"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+// Event describes an action to record in the audit log.
+type Event struct {
+	// Action is what was done, like "invoice.update". It must be set.
+	Action string
+
+	// Target is what the action was performed on, like "invoice/123".
+	Target string
+
+	// Diff describes the changes the action made to the target.
+	// Use Changes to compute it from the target before and after the action.
+	Diff []Change
+
+	// Metadata is additional information about the event.
+	// It must be encodable as JSON.
+	Metadata any
+}
+
+// Entry is an event as recorded in the audit log.
+type Entry = auditlog.Entry
+
+// Actor describes who performed an action.
+type Actor = auditlog.Actor
+
+// Change is a change to a field of the target of an action.
+type Change = auditlog.Change
+
+//publicapigen:drop
+type Manager struct {
+	rt         *config.Runtime
+	reqs       *reqtrack.RequestTracker
+	platform   *platform.Client
+	rootLogger zerolog.Logger
+}
+
+//publicapigen:drop
+func NewManager(rt *config.Runtime, reqs *reqtrack.RequestTracker, platform *platform.Client, rootLogger zerolog.Logger) *Manager {
+	return &Manager{rt: rt, reqs: reqs, platform: platform, rootLogger: rootLogger}
+}
+
+func (mgr *Manager) Record(ctx context.Context, ev Event) (*Entry, error) {
+	if ev.Action == "" {
+		return nil, errors.New("audit: event action must be set")
+	}
+
+	e := &Entry{
+		ID:     xid.New().String(),
+		Time:   time.Now().UTC(),
+		Action: ev.Action,
+		Target: ev.Target,
+		Diff:   ev.Diff,
+	}
+	if ev.Metadata != nil {
+		data, err := json.Marshal(ev.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("audit: encode metadata: %v", err)
+		}
+		e.Metadata = data
+	}
+	if err := mgr.setRequestInfo(e); err != nil {
+		return nil, err
+	}
+
+	// Without an audit log store, write the events to the logs.
+	if mgr.rt.AuditLog == nil || mgr.rt.AuditLog.Endpoint == "" {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("audit: encode entry: %v", err)
+		}
+		mgr.rootLogger.Info().RawJSON("audit_entry", data).Msg("audit event")
+		return e, nil
+	}
+
+	stored, err := mgr.platform.RecordAuditEntry(ctx, e)
+	if err != nil {
+		return nil, fmt.Errorf("audit: record event: %v", err)
+	}
+	return stored, nil
+}
+
+// setRequestInfo sets the actor, service, endpoint and trace of the entry
+// from the current request, if any.
+func (mgr *Manager) setRequestInfo(e *Entry) error {
+	curr := mgr.reqs.Current()
+	if curr.Req == nil {
+		return nil
+	}
+
+	var (
+		uid      model.UID
+		authData any
+	)
+	if data := curr.Req.RPCData; data != nil {
+		uid, authData = data.UserID, data.AuthData
+		e.Endpoint = data.Desc.Endpoint
+	} else if test := curr.Req.Test; test != nil {
+		uid, authData = test.UserID, test.AuthData
+	}
+	e.Service = curr.Req.Service()
+	e.TraceID = curr.Req.TraceID.String()
+
+	e.Actor.UserID = string(uid)
+	if authData != nil {
+		data, err := json.Marshal(authData)
+		if err != nil {
+			return fmt.Errorf("audit: encode auth data: %v", err)
+		}
+		e.Actor.AuthData = data
+	}
+	return nil
+}
+
+// Changes computes the changes between before and after, two values
+// encoded as JSON objects, like two versions of a struct. Fields are compared
+// by their JSON encoding. Either value may be nil, for resources being
+// created or deleted.
+func Changes(before, after any) ([]Change, error) {
+	from, err := fields(before)
+	if err != nil {
+		return nil, fmt.Errorf("audit: before: %v", err)
+	}
+	to, err := fields(after)
+	if err != nil {
+		return nil, fmt.Errorf("audit: after: %v", err)
+	}
+
+	var changes []Change
+	for name, val := range from {
+		if newVal, ok := to[name]; !ok || string(newVal) != string(val) {
+			changes = append(changes, Change{Field: name, From: val, To: newVal})
+		}
+	}
+	for name, val := range to {
+		if _, ok := from[name]; !ok {
+			changes = append(changes, Change{Field: name, To: val})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// fields returns the JSON-encoded fields of v, which must be encoded as an object.
+func fields(v any) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%T is not encoded as a JSON object", v)
+	}
+	return fields, nil
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	type invoice struct {
+		Amount   int      `json:"amount"`
+		Currency string   `json:"currency"`
+		Notes    string   `json:"notes,omitempty"`
+		Tags     []string `json:"tags"`
+	}
+
+	before := &invoice{Amount: 10, Currency: "USD", Notes: "draft", Tags: []string{"a"}}
+	after := &invoice{Amount: 20, Currency: "USD", Tags: []string{"a"}}
+	changes, err := Changes(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(changes)
+	want := `[{"field":"amount","from":10,"to":20},{"field":"notes","from":"draft"}]`
+	if string(got) != want {
+		t.Errorf("got changes %s, want %s", got, want)
+	}
+
+	// Creating a resource.
+	changes, err = Changes(nil, &invoice{Amount: 5, Currency: "EUR"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ = json.Marshal(changes)
+	want = `[{"field":"amount","to":5},{"field":"currency","to":"EUR"},{"field":"tags","to":null}]`
+	if string(got) != want {
+		t.Errorf("got changes %s, want %s", got, want)
+	}
+
+	if _, err := Changes("foo", after); err == nil {
+		t.Error("got nil error for non-object value")
+	}
+}
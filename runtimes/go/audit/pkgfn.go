@@ -0,0 +1,25 @@
+//go:build encore_app
+
+package audit
+
+import (
+	"context"
+
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/platform"
+	"encore.dev/appruntime/shared/reqtrack"
+)
+
+//publicapigen:drop
+var Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, platform.Singleton, logging.RootLogger)
+
+// Record records an event in the audit log, with the authenticated user
+// of the current request as the actor. It returns once the event has been
+// stored, with the entry as recorded in the audit log.
+//
+// If no audit log store is configured, like in self-hosted environments
+// without one, the event is written to the app's logs instead.
+func Record(ctx context.Context, ev Event) (*Entry, error) {
+	return Singleton.Record(ctx, ev)
+}
@@ -0,0 +1,173 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// registerJob registers a cron job when it's defined,
+// to run it when it becomes due on a test's fake clock.
+var registerJob = func(job *Job) {}
+
+// next returns when the job is next due after the time after,
+// or the zero time if it never is. Cron jobs are scheduled in UTC.
+func (j *Job) next(after time.Time) time.Time {
+	if j.Every > 0 {
+		// The interval divides 24 hours, so the runs are aligned to midnight UTC.
+		every := time.Duration(j.Every) * time.Second
+		return after.UTC().Truncate(every).Add(every)
+	}
+
+	sched, err := parseSchedule(j.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+	return sched.next(after)
+}
+
+// run runs the job's endpoint.
+func (j *Job) run(ctx context.Context) error {
+	fn := reflect.ValueOf(j.Endpoint)
+	if fn.Kind() != reflect.Func {
+		return fmt.Errorf("invalid endpoint %T", j.Endpoint)
+	}
+	out := fn.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[len(out)-1].Interface().(error); err != nil {
+		return err
+	}
+	return nil
+}
+
+// schedule is a parsed cron expression,
+// holding the values each field matches.
+type schedule struct {
+	minute, hour, dom, month, dow [64]bool
+
+	// domAny and dowAny report whether the day-of-month and day-of-week fields are "*",
+	// as when both are restricted a day matches if either of them matches.
+	domAny, dowAny bool
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dowNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// parseSchedule parses a standard cron expression with the fields
+// minute, hour, day of month, month and day of week.
+func parseSchedule(expr string) (*schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	s := &schedule{
+		domAny: fields[2] == "*" || fields[2] == "?",
+		dowAny: fields[4] == "*" || fields[4] == "?",
+	}
+	for _, f := range []struct {
+		expr     string
+		set      *[64]bool
+		min, max int
+		names    map[string]int
+	}{
+		{fields[0], &s.minute, 0, 59, nil},
+		{fields[1], &s.hour, 0, 23, nil},
+		{fields[2], &s.dom, 1, 31, nil},
+		{fields[3], &s.month, 1, 12, monthNames},
+		{fields[4], &s.dow, 0, 7, dowNames},
+	} {
+		if err := parseField(f.expr, f.set, f.min, f.max, f.names); err != nil {
+			return nil, err
+		}
+	}
+	// Sunday is both 0 and 7.
+	s.dow[0] = s.dow[0] || s.dow[7]
+	return s, nil
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into set.
+func parseField(expr string, set *[64]bool, min, max int, names map[string]int) error {
+	value := func(s string) (int, error) {
+		if n, ok := names[strings.ToLower(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < min || n > max {
+			return 0, fmt.Errorf("invalid value %q", s)
+		}
+		return n, nil
+	}
+
+	for _, part := range strings.Split(expr, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		lo, hi := min, max
+		if rng != "*" && rng != "?" {
+			from, to, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = value(from); err != nil {
+				return err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = value(to); err != nil {
+					return err
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		for n := lo; n <= hi; n += step {
+			set[n] = true
+		}
+	}
+	return nil
+}
+
+// next returns the first time after the time after that matches the schedule,
+// or the zero time if there is none within the next five years.
+func (s *schedule) next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.month[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.hour[t.Hour()]:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *schedule) matchesDay(t time.Time) bool {
+	dom, dow := s.dom[t.Day()], s.dow[t.Weekday()]
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
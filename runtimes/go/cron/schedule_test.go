@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJobNext(t *testing.T) {
+	at := func(s string) time.Time {
+		t.Helper()
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	tests := []struct {
+		name  string
+		job   Job
+		after string
+		want  string
+	}{
+		{"every", Job{Every: 2 * Hour}, "2023-05-10T13:45:00Z", "2023-05-10T14:00:00Z"},
+		{"every_aligned", Job{Every: 2 * Hour}, "2023-05-10T14:00:00Z", "2023-05-10T16:00:00Z"},
+		{"every_utc", Job{Every: Hour}, "2023-05-10T13:45:00+02:00", "2023-05-10T12:00:00Z"},
+		{"minute", Job{Schedule: "30 * * * *"}, "2023-05-10T13:45:00Z", "2023-05-10T14:30:00Z"},
+		{"step", Job{Schedule: "*/15 9-17 * * *"}, "2023-05-10T17:50:00Z", "2023-05-11T09:00:00Z"},
+		{"list", Job{Schedule: "0 4,16 * * *"}, "2023-05-10T05:00:00Z", "2023-05-10T16:00:00Z"},
+		{"weekday_names", Job{Schedule: "0 9 * * MON-FRI"}, "2023-05-12T10:00:00Z", "2023-05-15T09:00:00Z"},
+		{"sunday_7", Job{Schedule: "0 0 * * 7"}, "2023-05-10T00:00:00Z", "2023-05-14T00:00:00Z"},
+		{"month", Job{Schedule: "0 0 1 jan,jul ?"}, "2023-05-10T00:00:00Z", "2023-07-01T00:00:00Z"},
+		{"dom_or_dow", Job{Schedule: "0 0 20 * 1"}, "2023-05-10T00:00:00Z", "2023-05-15T00:00:00Z"},
+		{"leap_day", Job{Schedule: "0 12 29 2 *"}, "2023-05-10T00:00:00Z", "2024-02-29T12:00:00Z"},
+		{"never", Job{Schedule: "0 0 31 2 *"}, "2023-05-10T00:00:00Z", ""},
+		{"invalid", Job{Schedule: "0 25 * * *"}, "2023-05-10T00:00:00Z", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.job.next(at(tt.after))
+			if tt.want == "" {
+				if !got.IsZero() {
+					t.Errorf("got %v, want never", got)
+				}
+				return
+			}
+			if want := at(tt.want); !got.Equal(want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
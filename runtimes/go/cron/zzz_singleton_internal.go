@@ -0,0 +1,17 @@
+//go:build encore_app
+
+package cron
+
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+func init() {
+	// Run the cron jobs when they become due on a test's fake clock.
+	if appconf.Static.Testing {
+		registerJob = func(job *Job) {
+			testsupport.Singleton.RegisterCronJob(job.ID, job.next, job.run)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package et
+
+import "time"
+
+// ClockHelpers provides functions for controlling the fake clock of a test.
+//
+// Advancing the clock runs the work that becomes due synchronously, before
+// Advance or Set returns and in the order it became due: cron jobs run at
+// their scheduled times, and messages delivered with TopicHelpers.DeliverTo
+// whose subscription returned an error are retried according to the
+// subscription's retry policy.
+type ClockHelpers interface {
+	// Now returns the clock's current time.
+	Now() time.Time
+
+	// Advance moves the clock forward by d.
+	// It panics if d is negative.
+	Advance(d time.Duration)
+
+	// Set moves the clock forward to t.
+	// It panics if t is before the clock's current time.
+	Set(t time.Time)
+}
@@ -0,0 +1,340 @@
+// Package etload drives load at an application's APIs and checks the resulting
+// latencies and error rates against service level objectives (SLOs), so performance
+// tests can live next to the code they test.
+//
+// The load is described by a set of operations, each of which typically makes one
+// request using the generated client for the application:
+//
+//	client, _ := myapp.New(myapp.Local)
+//	res, err := etload.Run(ctx, etload.Config{Rate: 200, Duration: 30 * time.Second},
+//		etload.Op{Name: "GetOrder", Weight: 9, Fn: func(ctx context.Context) error {
+//			_, err := client.Orders.GetOrder(ctx, "order-1")
+//			return err
+//		}},
+//		etload.Op{Name: "CreateOrder", Weight: 1, Fn: func(ctx context.Context) error {
+//			_, err := client.Orders.CreateOrder(ctx, myapp.OrdersCreateOrderParams{Item: "book"})
+//			return err
+//		}},
+//	)
+//	res.Assert(t, etload.SLO{P99: 200 * time.Millisecond, MaxErrorRate: 0.01})
+//
+// It can be used from tests as well as from scripts run with "encore exec".
+package etload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Op is an operation to run under load, such as a request to an API.
+type Op struct {
+	// Name is the name of the operation in the results.
+	Name string
+
+	// Weight is the relative frequency of the operation, compared to the
+	// other operations. If zero, it defaults to 1.
+	Weight int
+
+	// Fn performs the operation. A non-nil error counts as a failed operation.
+	// The context is canceled when the load test is stopped.
+	Fn func(ctx context.Context) error
+}
+
+// Config configures a load test.
+type Config struct {
+	// Rate is the number of operations to start per second.
+	// If zero, the operations are run back-to-back by each worker,
+	// as fast as the application can handle them.
+	Rate float64
+
+	// Concurrency is the maximum number of operations in flight at once.
+	// If zero, it defaults to 10.
+	Concurrency int
+
+	// Duration is how long to run the load test for, excluding the warmup.
+	// If zero, the load test runs until Requests operations have been run.
+	Duration time.Duration
+
+	// Requests is the number of operations to run, excluding the warmup.
+	// If zero, the load test runs for Duration.
+	Requests int
+
+	// Warmup is how long to run the load before starting to record the results,
+	// to let caches and connection pools warm up.
+	Warmup time.Duration
+}
+
+// Result is the result of a load test.
+type Result struct {
+	// Ops are the results of each operation, in the order given to Run.
+	Ops []*OpResult
+
+	// Total is the combined result of all operations.
+	Total *OpResult
+
+	// Elapsed is how long the load test ran for, excluding the warmup.
+	Elapsed time.Duration
+}
+
+// OpResult is the result of an operation in a load test.
+type OpResult struct {
+	Name string
+
+	// Latency is the histogram of the latencies of the operations, including failed ones.
+	//
+	// When running at a fixed Rate, latencies are measured from when the operation was
+	// scheduled to start, so that delays caused by the application falling behind the
+	// requested rate are included in the results.
+	Latency Histogram
+
+	// Errors is the number of operations that failed.
+	Errors uint64
+
+	// Elapsed is how long the load test ran for, excluding the warmup.
+	Elapsed time.Duration
+}
+
+// Count reports the number of operations run.
+func (r *OpResult) Count() uint64 { return r.Latency.Count() }
+
+// ErrorRate reports the fraction of operations that failed.
+func (r *OpResult) ErrorRate() float64 {
+	if r.Count() == 0 {
+		return 0
+	}
+	return float64(r.Errors) / float64(r.Count())
+}
+
+// Throughput reports the number of operations run per second.
+func (r *OpResult) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Count()) / r.Elapsed.Seconds()
+}
+
+// Run runs a load test with the given operations, and returns the results once it
+// has completed or ctx is canceled. Operations are picked at random according to their weights.
+func Run(ctx context.Context, cfg Config, ops ...Op) (*Result, error) {
+	if len(ops) == 0 {
+		return nil, errors.New("etload: no operations given")
+	} else if cfg.Duration <= 0 && cfg.Requests <= 0 {
+		return nil, errors.New("etload: either Duration or Requests must be set")
+	} else if cfg.Rate < 0 || cfg.Concurrency < 0 || cfg.Requests < 0 || cfg.Warmup < 0 {
+		return nil, errors.New("etload: invalid negative configuration")
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = 10
+	}
+
+	var totalWeight int
+	for i, op := range ops {
+		if op.Fn == nil {
+			return nil, fmt.Errorf("etload: operation %q has no Fn", op.Name)
+		} else if op.Weight < 0 {
+			return nil, fmt.Errorf("etload: operation %q has a negative weight", op.Name)
+		} else if op.Weight == 0 {
+			ops[i].Weight = 1
+		}
+		totalWeight += ops[i].Weight
+	}
+
+	l := &loadTest{
+		cfg:     cfg,
+		ops:     ops,
+		weight:  totalWeight,
+		results: make([]*OpResult, len(ops)),
+	}
+	for i, op := range ops {
+		l.results[i] = &OpResult{Name: op.Name}
+	}
+	return l.run(ctx), nil
+}
+
+type loadTest struct {
+	cfg    Config
+	ops    []Op
+	weight int
+
+	begin time.Time // when the load test began, including the warmup
+
+	mu        sync.Mutex
+	results   []*OpResult
+	recording bool      // whether the warmup is over
+	started   time.Time // when recording started
+	remaining int       // operations remaining to run, if cfg.Requests is set
+}
+
+func (l *loadTest) run(ctx context.Context) *Result {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	l.remaining = l.cfg.Requests
+	l.begin = time.Now()
+	if l.cfg.Warmup == 0 {
+		l.recording, l.started = true, l.begin
+	}
+
+	// Stop the load test once the warmup and duration have passed.
+	if l.cfg.Duration > 0 {
+		timer := time.AfterFunc(l.cfg.Warmup+l.cfg.Duration, cancel)
+		defer timer.Stop()
+	}
+
+	// Schedule the operations to run. Each scheduled operation is sent
+	// with the time it was scheduled to start.
+	schedule := make(chan time.Time)
+	go func() {
+		defer close(schedule)
+		if l.cfg.Rate == 0 {
+			for {
+				select {
+				case schedule <- time.Time{}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		interval := time.Duration(float64(time.Second) / l.cfg.Rate)
+		next := time.Now()
+		for {
+			if d := time.Until(next); d > 0 {
+				select {
+				case <-time.After(d):
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case schedule <- next:
+			case <-ctx.Done():
+				return
+			}
+			next = next.Add(interval)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < l.cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for scheduled := range schedule {
+				if !l.runOne(ctx, cancel, rnd, scheduled) {
+					return
+				}
+			}
+		}(l.begin.UnixNano() + int64(i))
+	}
+	wg.Wait()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res := &Result{Ops: l.results, Total: &OpResult{Name: "total"}}
+	if l.recording {
+		res.Elapsed = time.Since(l.started)
+	}
+	for _, r := range l.results {
+		r.Elapsed = res.Elapsed
+		res.Total.Latency.Merge(&r.Latency)
+		res.Total.Errors += r.Errors
+	}
+	res.Total.Elapsed = res.Elapsed
+	return res
+}
+
+// runOne runs a randomly picked operation, recording its result once the warmup is over.
+// It reports whether to keep running operations.
+func (l *loadTest) runOne(ctx context.Context, stop func(), rnd *rand.Rand, scheduled time.Time) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	l.mu.Lock()
+	if !l.recording && time.Since(l.begin) >= l.cfg.Warmup {
+		l.recording, l.started = true, time.Now()
+	}
+	if l.recording && l.cfg.Requests > 0 {
+		if l.remaining == 0 {
+			l.mu.Unlock()
+			stop()
+			return false
+		}
+		l.remaining--
+	}
+	recording := l.recording
+	l.mu.Unlock()
+
+	idx := l.pick(rnd)
+	start := time.Now()
+	if !scheduled.IsZero() {
+		start = scheduled
+	}
+	err := l.ops[idx].Fn(ctx)
+	latency := time.Since(start)
+
+	// Don't count operations failing because the load test was stopped.
+	if err != nil && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return false
+	}
+
+	if recording {
+		l.mu.Lock()
+		r := l.results[idx]
+		r.Latency.Record(latency)
+		if err != nil {
+			r.Errors++
+		}
+		l.mu.Unlock()
+	}
+	return true
+}
+
+// pick picks an operation at random according to the weights.
+func (l *loadTest) pick(rnd *rand.Rand) int {
+	n := rnd.Intn(l.weight)
+	for i, op := range l.ops {
+		if n < op.Weight {
+			return i
+		}
+		n -= op.Weight
+	}
+	return len(l.ops) - 1
+}
+
+// WriteTable writes a table of the results to w.
+func (r *Result) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "OPERATION\tCOUNT\tERRORS\tRPS\tMEAN\tP50\tP90\tP99\tMAX\t")
+	rows := slices.Clone(r.Ops)
+	if len(rows) > 1 {
+		rows = append(rows, r.Total)
+	}
+	for _, op := range rows {
+		lat := &op.Latency
+		fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%.1f\t%s\t%s\t%s\t%s\t%s\t\n",
+			op.Name, op.Count(), op.ErrorRate()*100, op.Throughput(),
+			roundDur(lat.Mean()), roundDur(lat.Quantile(0.5)), roundDur(lat.Quantile(0.9)),
+			roundDur(lat.Quantile(0.99)), roundDur(lat.Max()))
+	}
+	return tw.Flush()
+}
+
+// roundDur rounds d to three significant digits, for display.
+func roundDur(d time.Duration) time.Duration {
+	for unit := time.Duration(1); unit < time.Hour; unit *= 10 {
+		if d < 1000*unit {
+			return d.Round(unit)
+		}
+	}
+	return d
+}
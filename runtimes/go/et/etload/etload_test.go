@@ -0,0 +1,135 @@
+package etload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestHistogram(t *testing.T) {
+	c := qt.New(t)
+
+	var h Histogram
+	c.Assert(h.Quantile(0.5), qt.Equals, time.Duration(0))
+	for i := 1; i <= 1000; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+	c.Assert(h.Count(), qt.Equals, uint64(1000))
+	c.Assert(h.Min(), qt.Equals, time.Millisecond)
+	c.Assert(h.Max(), qt.Equals, time.Second)
+	c.Assert(h.Mean(), qt.Equals, 500500*time.Microsecond)
+
+	// Quantiles are accurate to within the bucket precision.
+	for _, tc := range []struct {
+		q    float64
+		want time.Duration
+	}{{0.5, 500 * time.Millisecond}, {0.9, 900 * time.Millisecond}, {0.99, 990 * time.Millisecond}, {1, time.Second}} {
+		got := h.Quantile(tc.q)
+		c.Assert(got >= tc.want && float64(got) <= float64(tc.want)*(1+1.0/subBuckets), qt.IsTrue,
+			qt.Commentf("quantile %v: got %v, want %v", tc.q, got, tc.want))
+	}
+
+	var h2 Histogram
+	h2.Record(2 * time.Second)
+	h.Merge(&h2)
+	c.Assert(h.Count(), qt.Equals, uint64(1001))
+	c.Assert(h.Max(), qt.Equals, 2*time.Second)
+	c.Assert(h.Min(), qt.Equals, time.Millisecond)
+}
+
+func TestBucketBounds(t *testing.T) {
+	c := qt.New(t)
+	for _, v := range []uint64{0, 1, 63, 64, 127, 128, 129, 1000, 123456789, 1<<62 + 12345} {
+		idx := bucketIndex(v)
+		c.Assert(bucketUpperBound(idx) >= v, qt.IsTrue, qt.Commentf("value %d", v))
+		if idx > 0 {
+			c.Assert(bucketUpperBound(idx-1) < v, qt.IsTrue, qt.Commentf("value %d", v))
+		}
+	}
+}
+
+func TestRun_Requests(t *testing.T) {
+	c := qt.New(t)
+
+	var fast, slow atomic.Int64
+	res, err := Run(context.Background(), Config{Requests: 200, Concurrency: 4},
+		Op{Name: "fast", Weight: 3, Fn: func(ctx context.Context) error {
+			fast.Add(1)
+			return nil
+		}},
+		Op{Name: "failing", Fn: func(ctx context.Context) error {
+			slow.Add(1)
+			return errors.New("boom")
+		}},
+	)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Total.Count(), qt.Equals, uint64(200))
+	c.Assert(fast.Load()+slow.Load(), qt.Equals, int64(200))
+	c.Assert(res.Ops[0].Count(), qt.Equals, uint64(fast.Load()))
+	c.Assert(res.Ops[1].Errors, qt.Equals, uint64(slow.Load()))
+	c.Assert(res.Ops[0].Count() > res.Ops[1].Count(), qt.IsTrue)
+	c.Assert(res.Total.ErrorRate() > 0, qt.IsTrue)
+
+	var buf strings.Builder
+	c.Assert(res.WriteTable(&buf), qt.IsNil)
+	c.Assert(buf.String(), qt.Contains, "OPERATION")
+	c.Assert(buf.String(), qt.Contains, "failing")
+	c.Assert(buf.String(), qt.Contains, "total")
+
+	ft := &fakeT{}
+	res.Assert(ft, SLO{MaxErrorRate: 0.99})
+	c.Assert(ft.errors, qt.HasLen, 0)
+	res.Assert(ft, SLO{MaxErrorRate: 0.001})
+	c.Assert(ft.errors, qt.HasLen, 1)
+	c.Assert(ft.errors[0], qt.Matches, `total: error rate .* exceeds 0.10%`)
+}
+
+func TestRun_RateAndDuration(t *testing.T) {
+	c := qt.New(t)
+
+	res, err := Run(context.Background(), Config{Rate: 200, Duration: 250 * time.Millisecond, Warmup: 50 * time.Millisecond},
+		Op{Name: "sleep", Fn: func(ctx context.Context) error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}},
+	)
+	c.Assert(err, qt.IsNil)
+
+	// Allow for scheduling delays on slow machines.
+	n := res.Total.Count()
+	c.Assert(n >= 25 && n <= 60, qt.IsTrue, qt.Commentf("got %d operations", n))
+	c.Assert(res.Elapsed >= 200*time.Millisecond, qt.IsTrue, qt.Commentf("elapsed %v", res.Elapsed))
+	c.Assert(res.Total.Latency.Min() >= time.Millisecond, qt.IsTrue)
+
+	c.Assert(res.Check(SLO{P99: time.Minute}), qt.IsNil)
+	c.Assert(res.Check(SLO{P50: time.Nanosecond, MinThroughput: 1e6}), qt.ErrorMatches,
+		`total: p50 latency .* exceeds 1ns\ntotal: throughput .* is below 1000000.0/s`)
+}
+
+func TestRun_Invalid(t *testing.T) {
+	c := qt.New(t)
+	noop := Op{Name: "noop", Fn: func(ctx context.Context) error { return nil }}
+
+	_, err := Run(context.Background(), Config{Requests: 1})
+	c.Assert(err, qt.ErrorMatches, "etload: no operations given")
+	_, err = Run(context.Background(), Config{}, noop)
+	c.Assert(err, qt.ErrorMatches, "etload: either Duration or Requests must be set")
+	_, err = Run(context.Background(), Config{Requests: 1}, Op{Name: "nil"})
+	c.Assert(err, qt.ErrorMatches, `etload: operation "nil" has no Fn`)
+}
@@ -0,0 +1,124 @@
+package etload
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// subBuckets is the number of linear sub-buckets per power of two,
+// which bounds the relative error of the recorded values to 1/subBuckets.
+const subBuckets = 64
+
+// Histogram is a latency histogram with logarithmically sized buckets,
+// recording durations with a relative error of less than 2%.
+//
+// The zero value is ready to use. A Histogram is not safe for concurrent use.
+type Histogram struct {
+	counts []uint64
+	count  uint64
+	sum    time.Duration
+	min    time.Duration
+	max    time.Duration
+}
+
+// Record records a duration. Negative durations are recorded as zero.
+func (h *Histogram) Record(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	idx := bucketIndex(uint64(d))
+	if idx >= len(h.counts) {
+		h.counts = append(h.counts, make([]uint64, idx+1-len(h.counts))...)
+	}
+	h.counts[idx]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// Merge adds the durations recorded by other to h.
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+	if len(other.counts) > len(h.counts) {
+		h.counts = append(h.counts, make([]uint64, len(other.counts)-len(h.counts))...)
+	}
+	for i, n := range other.counts {
+		h.counts[i] += n
+	}
+	if h.count == 0 || other.min < h.min {
+		h.min = other.min
+	}
+	h.max = max(h.max, other.max)
+	h.count += other.count
+	h.sum += other.sum
+}
+
+// Count reports the number of recorded durations.
+func (h *Histogram) Count() uint64 { return h.count }
+
+// Min reports the smallest recorded duration.
+func (h *Histogram) Min() time.Duration { return h.min }
+
+// Max reports the largest recorded duration.
+func (h *Histogram) Max() time.Duration { return h.max }
+
+// Mean reports the mean of the recorded durations.
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Quantile reports the duration below which the fraction q of
+// the recorded durations fall, for q between 0 and 1.
+// For example, Quantile(0.99) reports the 99th percentile.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(q * float64(h.count)))
+	if target == 0 {
+		return h.min
+	}
+
+	var seen uint64
+	for i, n := range h.counts {
+		seen += n
+		if seen >= target {
+			// Report the upper bound of the bucket, within the recorded range.
+			return min(max(time.Duration(bucketUpperBound(i)), h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+// bucketIndex returns the index of the bucket for v.
+// Values below subBuckets have a bucket each; larger values are bucketed
+// by their power of two and the next log2(subBuckets) bits.
+func bucketIndex(v uint64) int {
+	if v < subBuckets {
+		return int(v)
+	}
+	exp := bits.Len64(v) - bits.Len64(subBuckets) // the number of low bits dropped
+	sub := v >> exp                                // in [subBuckets, 2*subBuckets)
+	return exp*subBuckets + int(sub)
+}
+
+// bucketUpperBound returns the largest value in the bucket with the given index.
+func bucketUpperBound(idx int) uint64 {
+	if idx < 2*subBuckets {
+		return uint64(idx)
+	}
+	exp := idx/subBuckets - 1
+	sub := uint64(idx%subBuckets + subBuckets)
+	return (sub+1)<<exp - 1
+}
@@ -0,0 +1,76 @@
+package etload
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TestingT is the subset of testing.TB used by Assert.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// SLO are service level objectives to check the results of a load test against.
+// Objectives with a zero value are not checked.
+type SLO struct {
+	// P50, P90, P99 and Max are the maximum allowed latencies
+	// at the given percentiles.
+	P50, P90, P99, Max time.Duration
+
+	// MaxErrorRate is the maximum allowed fraction of failed operations,
+	// between 0 and 1.
+	MaxErrorRate float64
+
+	// MinThroughput is the minimum required number of operations per second.
+	MinThroughput float64
+}
+
+// Check checks the combined result of all operations against the SLO,
+// returning an error describing the objectives that were not met.
+func (r *Result) Check(slo SLO) error {
+	return r.Total.Check(slo)
+}
+
+// Assert checks the combined result of all operations against the SLO,
+// reporting the objectives that were not met as test errors.
+func (r *Result) Assert(t TestingT, slo SLO) {
+	t.Helper()
+	if err := r.Check(slo); err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+// Check checks the result of the operation against the SLO,
+// returning an error describing the objectives that were not met.
+func (r *OpResult) Check(slo SLO) error {
+	var errs []error
+	if r.Count() == 0 {
+		return fmt.Errorf("%s: no operations were run", r.Name)
+	}
+
+	latencies := []struct {
+		name string
+		max  time.Duration
+		got  time.Duration
+	}{
+		{"p50", slo.P50, r.Latency.Quantile(0.5)},
+		{"p90", slo.P90, r.Latency.Quantile(0.9)},
+		{"p99", slo.P99, r.Latency.Quantile(0.99)},
+		{"max", slo.Max, r.Latency.Max()},
+	}
+	for _, l := range latencies {
+		if l.max > 0 && l.got > l.max {
+			errs = append(errs, fmt.Errorf("%s: %s latency %v exceeds %v", r.Name, l.name, roundDur(l.got), l.max))
+		}
+	}
+
+	if slo.MaxErrorRate > 0 && r.ErrorRate() > slo.MaxErrorRate {
+		errs = append(errs, fmt.Errorf("%s: error rate %.2f%% exceeds %.2f%%", r.Name, r.ErrorRate()*100, slo.MaxErrorRate*100))
+	}
+	if slo.MinThroughput > 0 && r.Throughput() < slo.MinThroughput {
+		errs = append(errs, fmt.Errorf("%s: throughput %.1f/s is below %.1f/s", r.Name, r.Throughput(), slo.MinThroughput))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,137 @@
+// Package etmock provides the call recording and expectation helpers
+// used by the service mocks Encore generates.
+//
+// When running tests, each service with API endpoints has a generated Mock type,
+// which implements the service's Interface and can be passed to et.MockService:
+//
+//	m := &svcb.Mock{
+//		GetUserFunc: func(ctx context.Context, p *svcb.GetUserParams) (*svcb.User, error) {
+//			return &svcb.User{Name: "Alice"}, nil
+//		},
+//	}
+//	et.MockService[svcb.Interface]("svcb", m)
+//
+//	SomeFuncInThisPackageWhichUltimatelyCallsServiceB()
+//	m.Calls.AssertCalledTimes(t, "GetUser", 1)
+package etmock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"encore.dev/beta/errs"
+)
+
+// TestingT is the subset of testing.TB used by the expectation helpers.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Call is a call made to an endpoint of a mock.
+type Call struct {
+	Endpoint string // the name of the endpoint called
+	Args     []any  // the arguments of the call, excluding the context
+}
+
+// Recorder records the calls made to the endpoints of a mock.
+// The zero value is ready to use, and it is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record records a call to the given endpoint.
+// It is called by the generated mocks, and should not be needed otherwise.
+func (r *Recorder) Record(endpoint string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Endpoint: endpoint, Args: args})
+}
+
+// All returns all the recorded calls, in the order they were made.
+func (r *Recorder) All() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Of returns the recorded calls to the given endpoint, in the order they were made.
+func (r *Recorder) Of(endpoint string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Endpoint == endpoint {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Count reports the number of recorded calls to the given endpoint.
+func (r *Recorder) Count(endpoint string) int {
+	return len(r.Of(endpoint))
+}
+
+// Reset discards the recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled fails the test if the endpoint has not been called.
+func (r *Recorder) AssertCalled(t TestingT, endpoint string) {
+	t.Helper()
+	if r.Count(endpoint) == 0 {
+		t.Errorf("etmock: expected a call to %s, got none", endpoint)
+	}
+}
+
+// AssertNotCalled fails the test if the endpoint has been called.
+func (r *Recorder) AssertNotCalled(t TestingT, endpoint string) {
+	t.Helper()
+	if n := r.Count(endpoint); n > 0 {
+		t.Errorf("etmock: expected no calls to %s, got %d", endpoint, n)
+	}
+}
+
+// AssertCalledTimes fails the test if the endpoint has not been called exactly n times.
+func (r *Recorder) AssertCalledTimes(t TestingT, endpoint string, n int) {
+	t.Helper()
+	if got := r.Count(endpoint); got != n {
+		t.Errorf("etmock: expected %d calls to %s, got %d", n, endpoint, got)
+	}
+}
+
+// AssertCalledWith fails the test if the endpoint has not been called with
+// the given arguments, excluding the context. Arguments are compared with reflect.DeepEqual.
+func (r *Recorder) AssertCalledWith(t TestingT, endpoint string, args ...any) {
+	t.Helper()
+	calls := r.Of(endpoint)
+	for _, c := range calls {
+		if reflect.DeepEqual(c.Args, args) {
+			return
+		}
+	}
+	t.Errorf("etmock: expected a call to %s with arguments %s, got %d calls to it", endpoint, formatArgs(args), len(calls))
+}
+
+// NotMocked returns the error returned by a generated mock
+// when an endpoint is called without a mock implementation.
+func NotMocked(service, endpoint string) error {
+	return errs.B().Code(errs.Unimplemented).Msgf("endpoint %s.%s is not mocked", service, endpoint).Err()
+}
+
+func formatArgs(args []any) string {
+	s := "("
+	for i, arg := range args {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%+v", arg)
+	}
+	return s + ")"
+}
@@ -0,0 +1,60 @@
+package etmock
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/beta/errs"
+)
+
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	c := qt.New(t)
+
+	var r Recorder
+	r.Record("Get", "a")
+	r.Record("List")
+	r.Record("Get", "b")
+
+	c.Assert(r.All(), qt.HasLen, 3)
+	c.Assert(r.Of("Get"), qt.DeepEquals, []Call{
+		{Endpoint: "Get", Args: []any{"a"}},
+		{Endpoint: "Get", Args: []any{"b"}},
+	})
+	c.Assert(r.Count("List"), qt.Equals, 1)
+	c.Assert(r.Count("Delete"), qt.Equals, 0)
+
+	ft := &fakeT{}
+	r.AssertCalled(ft, "Get")
+	r.AssertNotCalled(ft, "Delete")
+	r.AssertCalledTimes(ft, "Get", 2)
+	r.AssertCalledWith(ft, "Get", "b")
+	c.Assert(ft.errors, qt.HasLen, 0)
+
+	r.AssertCalled(ft, "Delete")
+	r.AssertNotCalled(ft, "List")
+	r.AssertCalledTimes(ft, "Get", 1)
+	r.AssertCalledWith(ft, "Get", "c")
+	c.Assert(ft.errors, qt.HasLen, 4)
+
+	r.Reset()
+	c.Assert(r.All(), qt.HasLen, 0)
+}
+
+func TestNotMocked(t *testing.T) {
+	c := qt.New(t)
+	err := NotMocked("svc", "Get")
+	c.Assert(errs.Code(err), qt.Equals, errs.Unimplemented)
+	c.Assert(err, qt.ErrorMatches, ".*endpoint svc.Get is not mocked")
+}
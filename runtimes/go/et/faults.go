@@ -0,0 +1,82 @@
+//go:build encore_app
+
+package et
+
+import (
+	"fmt"
+	"time"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+// Fault is a fault that can be injected into API calls using InjectFault.
+type Fault func(*model.InjectedFault)
+
+// Latency is a Fault that delays calls by d before making them.
+//
+// If the caller's context is canceled or its deadline is exceeded while the call
+// is delayed, the call fails with an errs.Canceled or errs.DeadlineExceeded error.
+func Latency(d time.Duration) Fault {
+	return func(f *model.InjectedFault) {
+		f.Latency = d
+	}
+}
+
+// Failure is a Fault that makes calls return err instead of being made.
+// It can be combined with Latency to make calls fail after a delay.
+func Failure(err error) Fault {
+	return func(f *model.InjectedFault) {
+		f.Err = err
+	}
+}
+
+// Probability is a Fault that makes the other faults affect only
+// the given fraction of calls, between 0 and 1.
+func Probability(p float64) Fault {
+	return func(f *model.InjectedFault) {
+		f.Probability = p
+	}
+}
+
+// InjectFault injects faults into the calls made to an API or service; Any calls made
+// to the target during this test or any of its sub-tests will be affected by the faults.
+//
+// The target is either an endpoint, in the same way as for [MockEndpoint], or the name
+// of a service, in which case the faults affect calls to all of the service's APIs.
+// Faults injected into an endpoint take precedence over faults injected into its service.
+//
+// For example, to verify the caller handles a slow or failing dependency:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+//	defer cancel()
+//	et.InjectFault(products.GetPrice, et.Latency(500*time.Millisecond))
+//	_, err := cart.Checkout(ctx, params) // the call to products.GetPrice times out
+//
+//	et.InjectFault("products", et.Failure(errs.B().Code(errs.Unavailable).Err()), et.Probability(0.5))
+//
+// Faults are applied before routing the call to any mock.
+// Calling InjectFault with no faults removes the faults injected into the target.
+func InjectFault(target any, faults ...Fault) {
+	var service, endpoint string
+	if name, ok := target.(string); ok {
+		if !Singleton.server.ServiceExists(name) {
+			panic(fmt.Sprintf("cannot inject fault into service %s: service does not exist", name))
+		}
+		service = name
+	} else {
+		handler := Singleton.server.HandlerForFunc(target)
+		if handler == nil {
+			panic(fmt.Sprintf("the function %T does not appear to be labelled as an Encore API.", target))
+		}
+		service, endpoint = handler.ServiceName(), handler.EndpointName()
+	}
+
+	var fault *model.InjectedFault
+	if len(faults) > 0 {
+		fault = &model.InjectedFault{}
+		for _, f := range faults {
+			f(fault)
+		}
+	}
+	Singleton.testMgr.SetFault(service, endpoint, fault)
+}
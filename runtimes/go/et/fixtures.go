@@ -0,0 +1,304 @@
+package et
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"gopkg.in/yaml.v3"
+
+	"encore.dev/storage/sqldb"
+)
+
+func (mgr *Manager) LoadFixtures(ctx context.Context, db *sqldb.Database, paths ...string) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("et: load fixtures: %v", err)
+	}
+
+	for _, path := range paths {
+		if err := loadFixtureFile(ctx, tx, path); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("et: load fixtures %s: %v", path, err)
+		}
+	}
+	inserted, err := insertedFixtureRows(ctx, tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("et: load fixtures: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("et: load fixtures: %v", err)
+	}
+
+	// Databases created by NewTestDatabase are dropped at the end of the test anyway.
+	if _, isTestDB := mgr.testDBs.Load(db); len(inserted) > 0 && !isTestDB {
+		mgr.testMgr.AddEndCallback(func(t *testing.T) {
+			if err := deleteFixtureRows(context.Background(), db, inserted); err != nil {
+				t.Errorf("et: unable to remove fixtures: %v", err)
+			}
+		})
+	}
+	return nil
+}
+
+// fixtureTable is a table and the rows to insert into it, from a YAML fixture file.
+type fixtureTable struct {
+	name string
+	rows []fixtureValues
+}
+
+// fixtureValues are the column values of a row, in the order they're given.
+type fixtureValues struct {
+	cols []string
+	vals []any
+}
+
+// fixtureRows are the rows of a table inserted by fixtures.
+type fixtureRows struct {
+	table string   // sanitized
+	key   string   // the expression identifying a row, for the table aliased as r
+	vals  []string // the values of key for the inserted rows
+}
+
+// loadFixtureFile loads the fixture file at path within tx.
+func loadFixtureFile(ctx context.Context, tx *sqldb.Tx, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	switch ext := filepath.Ext(path); ext {
+	case ".sql":
+		_, err := tx.Exec(ctx, string(data))
+		return err
+	case ".yaml", ".yml":
+		tables, err := parseYAMLFixtures(data)
+		if err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if err := insertFixtureTable(ctx, tx, table); err != nil {
+				return fmt.Errorf("table %s: %v", table.name, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported fixture file extension %q (expected .yaml, .yml or .sql)", ext)
+	}
+}
+
+// parseYAMLFixtures parses a YAML fixture file, which maps table names to the rows to insert:
+//
+//	users:
+//	  - id: 1
+//	    email: jane@example.com
+//
+// The tables and columns are returned in the order they're given, so that
+// rows referenced by foreign keys can be inserted first.
+func parseYAMLFixtures(data []byte) ([]fixtureTable, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of table names to rows", root.Line)
+	}
+
+	var tables []fixtureTable
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if value.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("line %d: expected a list of rows for table %s", value.Line, key.Value)
+		}
+
+		table := fixtureTable{name: key.Value}
+		for _, rowNode := range value.Content {
+			if rowNode.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("line %d: expected a mapping of column names to values", rowNode.Line)
+			}
+
+			var row fixtureValues
+			for j := 0; j < len(rowNode.Content); j += 2 {
+				col, valNode := rowNode.Content[j], rowNode.Content[j+1]
+				var val any
+				if err := valNode.Decode(&val); err != nil {
+					return nil, fmt.Errorf("line %d: %v", valNode.Line, err)
+				}
+
+				// Store lists and mappings as JSON, for json and jsonb columns.
+				switch val.(type) {
+				case map[string]any, []any:
+					data, err := json.Marshal(val)
+					if err != nil {
+						return nil, fmt.Errorf("line %d: %v", valNode.Line, err)
+					}
+					val = string(data)
+				}
+				row.cols = append(row.cols, col.Value)
+				row.vals = append(row.vals, val)
+			}
+			table.rows = append(table.rows, row)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// insertFixtureTable inserts the rows of the table.
+func insertFixtureTable(ctx context.Context, tx *sqldb.Tx, table fixtureTable) error {
+	tableName := pgx.Identifier(strings.Split(table.name, ".")).Sanitize()
+	for _, row := range table.rows {
+		cols := make([]string, len(row.cols))
+		params := make([]string, len(row.cols))
+		for i, col := range row.cols {
+			cols[i] = pgx.Identifier{col}.Sanitize()
+			params[i] = fmt.Sprintf("$%d", i+1)
+		}
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(cols, ", "), strings.Join(params, ", "))
+		if _, err := tx.Exec(ctx, query, row.vals...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertedFixtureRows returns the rows inserted by the fixtures loaded within tx,
+// from both YAML and SQL fixtures, which are the rows tx has written.
+// The rows are identified by their primary key, or by all of their
+// columns if the table has no primary key.
+func insertedFixtureRows(ctx context.Context, tx *sqldb.Tx) ([]fixtureRows, error) {
+	var xid string
+	if err := tx.QueryRow(ctx, "SELECT (txid_current() % 4294967296)::text").Scan(&xid); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT c.oid::regclass::text, COALESCE((
+			SELECT array_agg(quote_ident(a.attname) ORDER BY k.i)
+			FROM pg_index i, unnest(i.indkey) WITH ORDINALITY k(attnum, i)
+			JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = k.attnum
+			WHERE i.indrelid = c.oid AND i.indisprimary
+		), '{}')
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'r' AND n.nspname <> 'information_schema' AND n.nspname NOT LIKE 'pg\_%'`)
+	if err != nil {
+		return nil, err
+	}
+	var tables []fixtureRows
+	for rows.Next() {
+		var table fixtureRows
+		var pkCols []string
+		if err := rows.Scan(&table.table, &pkCols); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		table.key = "r::text"
+		if len(pkCols) > 0 {
+			table.key = "ROW(" + strings.Join(pkCols, ", ") + ")::text"
+		}
+		tables = append(tables, table)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var inserted []fixtureRows
+	for _, table := range tables {
+		query := fmt.Sprintf("SELECT array_agg(%s) FROM %s AS r WHERE r.xmin = $1::xid", table.key, table.table)
+		if err := tx.QueryRow(ctx, query, xid).Scan(&table.vals); err != nil {
+			return nil, err
+		}
+		if len(table.vals) > 0 {
+			inserted = append(inserted, table)
+		}
+	}
+	return inserted, nil
+}
+
+// deleteFixtureRows deletes the rows inserted by fixtures, along with the rows
+// referencing them through foreign keys, like those inserted by the test.
+func deleteFixtureRows(ctx context.Context, db *sqldb.Database, tables []fixtureRows) (err error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	for _, table := range tables {
+		cond := fmt.Sprintf("%s = ANY($1)", table.key)
+		if err := deleteCascade(ctx, tx, table.table, cond, table.vals, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// deleteCascade deletes the rows of the table matching cond, for the table aliased as r,
+// after first deleting the rows referencing them through foreign keys.
+// The path holds the tables being deleted from by the callers, to stop at reference cycles.
+func deleteCascade(ctx context.Context, tx *sqldb.Tx, table, cond string, vals []string, path []string) error {
+	path = append(path, table)
+	rows, err := tx.Query(ctx, `
+		SELECT c.conrelid::regclass::text,
+			(SELECT array_agg(quote_ident(a.attname) ORDER BY k.i)
+				FROM unnest(c.conkey) WITH ORDINALITY k(attnum, i)
+				JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum),
+			(SELECT array_agg(quote_ident(a.attname) ORDER BY k.i)
+				FROM unnest(c.confkey) WITH ORDINALITY k(attnum, i)
+				JOIN pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum)
+		FROM pg_constraint c
+		WHERE c.contype = 'f' AND c.confrelid = $1::regclass`, table)
+	if err != nil {
+		return err
+	}
+	type foreignKey struct {
+		table      string
+		cols, refs []string
+	}
+	var fks []foreignKey
+	for rows.Next() {
+		var fk foreignKey
+		if err := rows.Scan(&fk.table, &fk.cols, &fk.refs); err != nil {
+			rows.Close()
+			return err
+		}
+		fks = append(fks, fk)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, fk := range fks {
+		if slices.Contains(path, fk.table) {
+			continue
+		}
+		refCond := fmt.Sprintf("(%s) IN (SELECT %s FROM %s AS r WHERE %s)",
+			strings.Join(fk.cols, ", "), strings.Join(fk.refs, ", "), table, cond)
+		if err := deleteCascade(ctx, tx, fk.table, refCond, vals, path); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s AS r WHERE %s", table, cond), vals)
+	return err
+}
@@ -0,0 +1,43 @@
+package et
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseYAMLFixtures(t *testing.T) {
+	c := qt.New(t)
+	tables, err := parseYAMLFixtures([]byte(`
+users:
+  - id: 1
+    email: jane@example.com
+    settings: {theme: dark}
+  - email: john@example.com
+    id: 2
+    deleted_at: null
+billing.orders:
+  - user_id: 1
+    tags: [new, gift]
+`))
+	c.Assert(err, qt.IsNil)
+	c.Assert(tables, qt.CmpEquals(cmp.AllowUnexported(fixtureTable{}, fixtureValues{})), []fixtureTable{
+		{name: "users", rows: []fixtureValues{
+			{cols: []string{"id", "email", "settings"}, vals: []any{1, "jane@example.com", `{"theme":"dark"}`}},
+			{cols: []string{"email", "id", "deleted_at"}, vals: []any{"john@example.com", 2, nil}},
+		}},
+		{name: "billing.orders", rows: []fixtureValues{
+			{cols: []string{"user_id", "tags"}, vals: []any{1, `["new","gift"]`}},
+		}},
+	})
+
+	_, err = parseYAMLFixtures([]byte("- id: 1\n"))
+	c.Assert(err, qt.ErrorMatches, `line 1: expected a mapping of table names to rows`)
+
+	_, err = parseYAMLFixtures([]byte("users:\n  id: 1\n"))
+	c.Assert(err, qt.ErrorMatches, `line 2: expected a list of rows for table users`)
+
+	_, err = parseYAMLFixtures([]byte("users:\n  - 1\n"))
+	c.Assert(err, qt.ErrorMatches, `line 2: expected a mapping of column names to values`)
+}
@@ -0,0 +1,105 @@
+//go:build encore_app
+
+package et
+
+import (
+	"flag"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/stack"
+	"encore.dev/beta/errs"
+)
+
+// FuzzEndpoint fuzzes an endpoint through the full HTTP stack, failing if
+// decoding, validating or handling any request panics. It's equivalent to HTTP().Fuzz(f, endpoint).
+//
+// Encore generates a fuzz target calling FuzzEndpoint for each public endpoint and each
+// endpoint requiring auth, named FuzzEncore followed by the endpoint name. To fuzz an endpoint:
+//
+//	encore test -fuzz=FuzzEncoreCreateOrder ./orders
+//
+// To fuzz an endpoint requiring auth past the auth check, define your own fuzz target
+// with a client that authenticates its requests:
+//
+//	func FuzzCreateOrder(f *testing.F) {
+//		et.HTTP().WithAuth("user-1", &authData).Fuzz(f, CreateOrder)
+//	}
+func FuzzEndpoint(f *testing.F, endpoint any) {
+	f.Helper()
+	HTTP().Fuzz(f, endpoint)
+}
+
+// Fuzz fuzzes an endpoint with requests made by the client, failing if decoding,
+// validating or handling any request panics. See FuzzEndpoint for details.
+//
+// The seed corpus is derived from the endpoint's request schema and consists of
+// requests that are structurally valid but contain adversarial values, such as empty
+// and very long strings, extreme numbers and nil and empty lists, as well as malformed
+// requests. Fuzzed inputs that don't decode as a request are sent as the request body.
+//
+// Since the requests are handled by the endpoint, and may have side effects, the endpoint
+// is only fuzzed when running with -fuzz, or when tests are selected explicitly with -run
+// to run the seed corpus and the failing inputs in testdata/fuzz.
+func (c *HTTPClient) Fuzz(f *testing.F, endpoint any) {
+	f.Helper()
+	handler := Singleton.server.HandlerForFunc(endpoint)
+	if handler == nil {
+		f.Fatalf("et.Fuzz: the function %T does not appear to be labelled as an Encore API.", endpoint)
+	}
+	target, ok := handler.(api.Fuzzable)
+	if !ok {
+		f.Fatalf("et.Fuzz: endpoint %s.%s cannot be fuzzed", handler.ServiceName(), handler.EndpointName())
+	}
+	if !testFlagSet("test.fuzz") && !testFlagSet("test.run") {
+		f.Skip("skipping fuzz target; run with -fuzz to fuzz the endpoint")
+	}
+
+	seeds, err := target.FuzzSeeds()
+	if err != nil {
+		f.Fatalf("et.Fuzz: %v", err)
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req, err := target.FuzzRequest(data)
+		if err != nil {
+			t.Fatalf("et.Fuzz: %v", err)
+		}
+		for key, values := range c.header {
+			req.Header[key] = values
+		}
+
+		var panicErr error
+		opts := &api.TestRequestOptions{
+			Auth: c.auth,
+			HandlerError: func(err error) {
+				if _, ok := errs.Meta(err)["panic_stack"]; ok {
+					panicErr = err
+				}
+			},
+		}
+		Singleton.server.ServeTestRequest(httptest.NewRecorder(), req, opts)
+
+		if panicErr != nil {
+			var b strings.Builder
+			if st, ok := errs.Meta(panicErr)["panic_stack"].(stack.Stack); ok {
+				for _, fr := range stack.Format(st) {
+					fmt.Fprintf(&b, "\n%s\n\t%s:%d", fr.Func, fr.File, fr.Line)
+				}
+			}
+			t.Fatalf("%s %s: %s%s", req.Method, req.URL, errs.Convert(panicErr).(*errs.Error).ErrorMessage(), b.String())
+		}
+	})
+}
+
+// testFlagSet reports whether the given testing flag is set to a non-empty value.
+func testFlagSet(name string) bool {
+	fl := flag.Lookup(name)
+	return fl != nil && fl.Value.String() != ""
+}
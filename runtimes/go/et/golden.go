@@ -0,0 +1,182 @@
+package et
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// GoldenOption configures how Golden compares responses.
+type GoldenOption func(*goldenOptions)
+
+//publicapigen:keep
+type goldenOptions struct {
+	ignore [][]string
+}
+
+// IgnoreFields is a GoldenOption that ignores the values of the given fields,
+// such as timestamps and generated IDs, which are replaced by "<ignored>"
+// both in the golden file and when comparing against it.
+//
+// Fields are given as dot-separated paths of JSON keys, where "*"
+// matches any key or array element, like "created_at" or "items.*.id".
+func IgnoreFields(paths ...string) GoldenOption {
+	return func(o *goldenOptions) {
+		for _, p := range paths {
+			o.ignore = append(o.ignore, strings.Split(p, "."))
+		}
+	}
+}
+
+// Golden compares the serialized response against the golden file
+// testdata/<name>.golden.json, failing the test if they differ.
+// If name is "", the name of the test is used.
+//
+// The response is either an *http.Response, such as returned by HTTP, in which case
+// its status code and body are compared, or the response of an endpoint, which is
+// compared as JSON.
+//
+// When running "encore test -update", the golden file is written instead of compared
+// against, which is also how it's created for the first time. Golden files should be
+// checked in, so that changes to the API's responses show up in review.
+//
+// For example:
+//
+//	resp, err := orders.Get(ctx, "order-1")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	et.Golden(t, "", resp, et.IgnoreFields("created_at"))
+func Golden(t testing.TB, name string, resp any, opts ...GoldenOption) {
+	t.Helper()
+	var o goldenOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	got, err := goldenJSON(resp, o.ignore)
+	if err != nil {
+		t.Fatalf("et.Golden: %v", err)
+	}
+
+	if name == "" {
+		name = strings.ReplaceAll(t.Name(), "/", "__")
+	}
+	path := filepath.Join("testdata", name+".golden.json")
+
+	if goldenUpdate() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("et.Golden: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("et.Golden: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("et.Golden: golden file %s does not exist; run with -update to create it", path)
+	} else if err != nil {
+		t.Fatalf("et.Golden: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("et.Golden: response differs from %s (-want +got):\n%s", path, diff)
+	}
+}
+
+// goldenUpdate reports whether golden files should be updated, which is requested
+// by "encore test -update", or by an -update flag defined by the test package.
+func goldenUpdate() bool {
+	if v, _ := strconv.ParseBool(os.Getenv("ENCORE_GOLDEN_UPDATE")); v {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		v, _ := strconv.ParseBool(f.Value.String())
+		return v
+	}
+	return false
+}
+
+// goldenJSON serializes the response as indented JSON,
+// with the ignored fields replaced.
+func goldenJSON(resp any, ignore [][]string) ([]byte, error) {
+	var data []byte
+	if httpResp, ok := resp.(*http.Response); ok {
+		body, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			return nil, err
+		}
+		// Restore the body so the caller can still read it.
+		httpResp.Body = io.NopCloser(bytes.NewReader(body))
+
+		var bodyVal any = string(body)
+		if json.Valid(body) {
+			bodyVal = json.RawMessage(body)
+		}
+		data, err = json.Marshal(map[string]any{"status": httpResp.StatusCode, "body": bodyVal})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if data, err = json.Marshal(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	// Decode numbers as json.Number to not lose precision when re-encoding them.
+	var val any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	for _, path := range ignore {
+		if httpResp, ok := resp.(*http.Response); ok && httpResp != nil {
+			// Fields are given relative to the response body.
+			path = append([]string{"body"}, path...)
+		}
+		val = ignoreField(val, path)
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(val); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// ignoreField replaces the values at the given path with "<ignored>".
+func ignoreField(val any, path []string) any {
+	if len(path) == 0 {
+		return "<ignored>"
+	}
+	switch v := val.(type) {
+	case map[string]any:
+		for key, elem := range v {
+			if path[0] == "*" || path[0] == key {
+				v[key] = ignoreField(elem, path[1:])
+			}
+		}
+	case []any:
+		for i, elem := range v {
+			if path[0] == "*" || path[0] == strconv.Itoa(i) {
+				v[i] = ignoreField(elem, path[1:])
+			}
+		}
+	}
+	return val
+}
@@ -0,0 +1,60 @@
+package et
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestGoldenJSON(t *testing.T) {
+	c := qt.New(t)
+
+	type item struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	type order struct {
+		ID        string `json:"id"`
+		CreatedAt string `json:"created_at"`
+		Items     []item `json:"items"`
+	}
+	resp := &order{ID: "o1", CreatedAt: "2024-01-01T00:00:00Z", Items: []item{{ID: "i1", Name: "a"}, {ID: "i2", Name: "b"}}}
+
+	got, err := goldenJSON(resp, [][]string{{"created_at"}, {"items", "*", "id"}, {"missing", "field"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(got), qt.Equals, `{
+  "created_at": "<ignored>",
+  "id": "o1",
+  "items": [
+    {
+      "id": "<ignored>",
+      "name": "a"
+    },
+    {
+      "id": "<ignored>",
+      "name": "b"
+    }
+  ]
+}
+`)
+
+	httpResp := &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(`{"id":"o1","created_at":"now"}`))}
+	got, err = goldenJSON(httpResp, [][]string{{"created_at"}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(got), qt.Equals, `{
+  "body": {
+    "created_at": "<ignored>",
+    "id": "o1"
+  },
+  "status": 200
+}
+`)
+
+	// The body can still be read after comparing it.
+	body, err := io.ReadAll(httpResp.Body)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(body), qt.Equals, `{"id":"o1","created_at":"now"}`)
+}
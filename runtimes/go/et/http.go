@@ -0,0 +1,117 @@
+//go:build encore_app
+
+package et
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"encore.dev/appruntime/apisdk/api"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/beta/auth"
+)
+
+// HTTP returns an HTTPClient for making HTTP requests to the application's APIs
+// during the current test.
+//
+// Unlike calling an API function directly, requests made with the HTTPClient go
+// through the full HTTP stack, as if they had been made by an external client:
+// the request is routed and decoded, the auth handler is run, the middleware
+// (including global middleware) is invoked, and the response is encoded.
+// Only public APIs and APIs requiring auth can be called, like for external clients.
+//
+// By default the auth handler is run using the authentication data in the request,
+// such as the Authorization header. Use WithAuth or WithoutAuth to skip it.
+//
+// For example:
+//
+//	resp := et.HTTP().WithAuth("user-1", &authData).Post("/orders", &CreateOrderParams{...})
+//	if resp.StatusCode != http.StatusOK {
+//		t.Fatalf("got status %d", resp.StatusCode)
+//	}
+func HTTP() *HTTPClient {
+	return &HTTPClient{header: make(http.Header)}
+}
+
+// HTTPClient makes HTTP requests to the application's APIs during tests.
+// See HTTP for details.
+//
+// The With methods return a modified copy of the client,
+// leaving the original client unchanged.
+type HTTPClient struct {
+	auth   *model.AuthInfo
+	header http.Header
+}
+
+// WithAuth returns a client that authenticates its requests as the given user,
+// with the given auth data, without running the auth handler.
+//
+// If the application's auth handler returns custom auth data, data must be of
+// the same type as the auth handler returns, and may not be nil.
+func (c *HTTPClient) WithAuth(uid auth.UID, data any) *HTTPClient {
+	if err := api.CheckAuthData(uid, data); err != nil {
+		panic(fmt.Errorf("et.HTTP: %v", err))
+	}
+	c2 := c.clone()
+	c2.auth = &model.AuthInfo{UID: uid, UserData: data}
+	return c2
+}
+
+// WithoutAuth returns a client whose requests are unauthenticated,
+// without running the auth handler.
+func (c *HTTPClient) WithoutAuth() *HTTPClient {
+	c2 := c.clone()
+	c2.auth = &model.AuthInfo{}
+	return c2
+}
+
+// WithHeader returns a client that sets the given header on its requests.
+func (c *HTTPClient) WithHeader(key, value string) *HTTPClient {
+	c2 := c.clone()
+	c2.header.Set(key, value)
+	return c2
+}
+
+// Get makes a GET request to the given path, like "/orders/123?expand=true".
+func (c *HTTPClient) Get(path string) *http.Response {
+	return c.Do(httptest.NewRequest(http.MethodGet, path, nil))
+}
+
+// Post makes a POST request to the given path, with the body encoded as JSON.
+// If body is nil, the request has no body.
+func (c *HTTPClient) Post(path string, body any) *http.Response {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			Singleton.testMgr.CurrentTest().Fatalf("et.HTTP: unable to encode request body: %v", err)
+		}
+		r = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, r)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.Do(req)
+}
+
+// Do makes the given request, such as one created with httptest.NewRequest,
+// and returns the response once the request has been handled.
+// The headers set with WithHeader are added to the request.
+func (c *HTTPClient) Do(req *http.Request) *http.Response {
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+
+	w := httptest.NewRecorder()
+	Singleton.server.ServeTestRequest(w, req, &api.TestRequestOptions{Auth: c.auth})
+	return w.Result()
+}
+
+func (c *HTTPClient) clone() *HTTPClient {
+	return &HTTPClient{auth: c.auth, header: c.header.Clone()}
+}
@@ -1,6 +1,8 @@
 package et
 
 import (
+	"sync"
+
 	"encore.dev/appruntime/apisdk/api"
 	"encore.dev/appruntime/exported/config"
 	"encore.dev/appruntime/shared/reqtrack"
@@ -15,9 +17,11 @@ type Manager struct {
 	testMgr *testsupport.Manager
 	server  *api.Server
 	db      *sqldb.Manager
+
+	testDBs sync.Map // *sqldb.Database -> struct{}, the databases created by NewTestDatabase
 }
 
 //publicapigen:drop
 func NewManager(static *config.Static, rt *reqtrack.RequestTracker, testMgr *testsupport.Manager, server *api.Server, db *sqldb.Manager) *Manager {
-	return &Manager{static, rt, testMgr, server, db}
+	return &Manager{static: static, rt: rt, testMgr: testMgr, server: server, db: db}
 }
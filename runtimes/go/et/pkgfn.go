@@ -4,6 +4,7 @@ package et
 
 import (
 	"context"
+	"testing"
 
 	"encore.dev/beta/auth"
 	"encore.dev/storage/sqldb"
@@ -43,6 +44,64 @@ func EnableServiceInstanceIsolation() {
 	Singleton.testMgr.SetIsolatedServices(true)
 }
 
+// LoadFixtures loads the fixture files at the given paths into db, in order.
+// The paths are relative to the directory of the test's package, like for os.ReadFile.
+//
+// Fixture files with the extension ".sql" are executed as SQL statements.
+// Fixture files with the extension ".yaml" or ".yml" map table names to the rows to insert
+// into them, with each row mapping column names to values. Lists and mappings are stored
+// as JSON, for json and jsonb columns. For example:
+//
+//	users:
+//	  - id: 1
+//	    email: jane@example.com
+//	    settings: {theme: dark}
+//	orders:
+//	  - user_id: 1
+//	    total: 100
+//
+// The tables and rows are inserted in the order given, so rows referenced by
+// foreign keys must come first.
+//
+// All fixture files are loaded in a single transaction, so if loading any of them
+// fails none of the fixtures are loaded. At the end of the current test, the rows written
+// by the fixtures are deleted again, by their primary key, or by all of their columns if
+// the table has no primary key, along with the rows referencing them through foreign keys.
+// Rows deleted by SQL fixtures are not restored. For full isolation, use LoadFixtures
+// with a database created by NewTestDatabase, which is dropped at the end of the test.
+func LoadFixtures(ctx context.Context, db *sqldb.Database, paths ...string) error {
+	return Singleton.LoadFixtures(ctx, db, paths...)
+}
+
+// Trace starts capturing the operations performed during the current test
+// and its sub-tests, such as the API calls made, the database queries run,
+// the outgoing HTTP requests made and the Pub/Sub messages published,
+// and returns a TestTrace for making assertions about them.
+//
+// Only operations performed after Trace is first called are captured.
+// Calling Trace again during the same test returns the same captured operations.
+//
+// For example:
+//
+//	tr := et.Trace(t)
+//	_, err := orders.Create(ctx, &orders.CreateParams{...})
+//	tr.AssertCalled("inventory", "Reserve")
+//	tr.AssertNoHTTPCalls()
+func Trace(t *testing.T) *TestTrace {
+	return Singleton.Trace(t)
+}
+
+// Clock returns the fake clock of the current test, creating it if needed.
+// The clock starts at the current time, and is shared with the test's sub-tests.
+//
+// Once a test has a fake clock, Encore uses it instead of the real time for
+// the current time inside the runtime, such as when computing cache expiry
+// times, and runs the cron jobs as they become due when the clock is advanced.
+// Cron jobs never run on their own in tests.
+func Clock() ClockHelpers {
+	return Singleton.testMgr.Clock()
+}
+
 //publicapigen:keep
 type stringLiteral string
 
@@ -1,12 +1,16 @@
 package et
 
 import (
+	"fmt"
+	"reflect"
+	"testing"
+
 	"encore.dev/pubsub"
 )
 
 // Topic returns a TopicHelper for the given topic.
 func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
-	return pubsub.GetTestTopicInstance(topic).(TopicHelpers[T])
+	return &topicHelpers[T]{inst: pubsub.GetTestTopicInstance(topic).(testTopicInstance[T])}
 }
 
 // TopicHelpers provides functions for interacting with the backing topic implementation
@@ -17,4 +21,122 @@ func Topic[T any](topic *pubsub.Topic[T]) TopicHelpers[T] {
 type TopicHelpers[T any] interface {
 	// PublishedMessages returns a slice of all messages published during this test on this topic.
 	PublishedMessages() []T
+
+	// Published returns all messages published during this test on this topic,
+	// in the order they were published, along with their IDs and attributes.
+	Published() []PublishedMessage[T]
+
+	// DeliverTo delivers the messages published on this topic during the rest of this test
+	// to the given subscriptions. Each message is processed by the subscriptions, in the order given,
+	// before the call to Publish returns, so the test can assert on their effects right away.
+	// An error returned by a subscription fails the test, unless the test has a fake clock
+	// (see Clock), in which case the message is retried according to the subscription's
+	// retry policy as the clock is advanced, and only fails the test once the retries are exhausted.
+	//
+	// By default, published messages aren't delivered to any subscriptions during tests.
+	// Calling DeliverTo with no subscriptions restores the default.
+	DeliverTo(subscriptions ...string)
+
+	// AssertPublishedCount fails the test if the number of messages
+	// published during this test on this topic isn't want.
+	AssertPublishedCount(want int)
+
+	// AssertPublished fails the test unless the messages published during this test
+	// on this topic are equal to want, in the same order.
+	AssertPublished(want ...T)
+
+	// AssertAnyPublished fails the test unless at least one of the messages published
+	// during this test on this topic matches, such as by its contents or attributes.
+	AssertAnyPublished(match func(msg PublishedMessage[T]) bool)
+}
+
+// PublishedMessage is a message published to a topic during a test.
+type PublishedMessage[T any] struct {
+	// ID is the message ID returned by Publish.
+	ID string
+
+	// Message is the published message.
+	Message T
+
+	// OrderingKey is the value of the topic's ordering attribute,
+	// or "" if the topic has no OrderingAttribute.
+	OrderingKey string
+
+	// Attributes are the message's attributes, from the
+	// fields tagged with `pubsub-attr`, keyed by attribute name.
+	Attributes map[string]string
+}
+
+// testTopicInstance is the test instance of a topic, as implemented by the pubsub package.
+type testTopicInstance[T any] interface {
+	PublishedMessages() []T
+	EachPublished(fn func(id string, msg T, orderingKey string, attrs map[string]string))
+	DeliverTo(subscriptions []string)
+	Test() *testing.T
+}
+
+type topicHelpers[T any] struct {
+	inst testTopicInstance[T]
+}
+
+func (h *topicHelpers[T]) PublishedMessages() []T {
+	return h.inst.PublishedMessages()
+}
+
+func (h *topicHelpers[T]) Published() []PublishedMessage[T] {
+	var msgs []PublishedMessage[T]
+	h.inst.EachPublished(func(id string, msg T, orderingKey string, attrs map[string]string) {
+		msgs = append(msgs, PublishedMessage[T]{ID: id, Message: msg, OrderingKey: orderingKey, Attributes: attrs})
+	})
+	return msgs
+}
+
+func (h *topicHelpers[T]) DeliverTo(subscriptions ...string) {
+	h.inst.DeliverTo(subscriptions)
+}
+
+func (h *topicHelpers[T]) AssertPublishedCount(want int) {
+	t := h.inst.Test()
+	t.Helper()
+	if got := len(h.inst.PublishedMessages()); got != want {
+		t.Errorf("got %d published messages, want %d", got, want)
+	}
+}
+
+func (h *topicHelpers[T]) AssertPublished(want ...T) {
+	t := h.inst.Test()
+	t.Helper()
+	got := h.inst.PublishedMessages()
+	if len(got) != len(want) {
+		t.Errorf("got %d published messages, want %d:\n%s", len(got), len(want), formatMessages(got))
+		return
+	}
+	for i := range got {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("published message %d:\ngot  %+v\nwant %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func (h *topicHelpers[T]) AssertAnyPublished(match func(msg PublishedMessage[T]) bool) {
+	t := h.inst.Test()
+	t.Helper()
+	for _, msg := range h.Published() {
+		if match(msg) {
+			return
+		}
+	}
+	t.Errorf("no matching message was published:\n%s", formatMessages(h.inst.PublishedMessages()))
+}
+
+// formatMessages formats the messages for a test failure.
+func formatMessages[T any](msgs []T) string {
+	if len(msgs) == 0 {
+		return "\t(none)"
+	}
+	var s string
+	for i, msg := range msgs {
+		s += fmt.Sprintf("\t%d: %+v\n", i, msg)
+	}
+	return s
 }
@@ -2,10 +2,16 @@ package et
 
 import (
 	"context"
+	"testing"
 
 	"encore.dev/storage/sqldb"
 )
 
 func (mgr *Manager) NewTestDatabase(ctx context.Context, name string) (*sqldb.Database, error) {
-	return mgr.db.NewTestDatabase(ctx, name)
+	db, err := mgr.db.NewTestDatabase(ctx, name)
+	if err == nil {
+		mgr.testDBs.Store(db, struct{}{})
+		mgr.testMgr.AddEndCallback(func(*testing.T) { mgr.testDBs.Delete(db) })
+	}
+	return db, err
 }
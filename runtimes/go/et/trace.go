@@ -0,0 +1,139 @@
+package et
+
+import (
+	"testing"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func (mgr *Manager) Trace(t *testing.T) *TestTrace {
+	return &TestTrace{t: t, capture: mgr.testMgr.Capture()}
+}
+
+// TestTrace holds the operations performed during a test and its sub-tests,
+// for making assertions about the structure of the requests the test executes.
+// See Trace for details.
+type TestTrace struct {
+	t       *testing.T
+	capture *model.TestCapture
+}
+
+// RPCCall is an API call captured by a TestTrace.
+type RPCCall struct {
+	Caller   string // the calling service, or "" if called by the test
+	Service  string
+	Endpoint string
+}
+
+// DBQuery is a database query captured by a TestTrace.
+type DBQuery struct {
+	Caller string // the querying service, or "" if queried by the test
+	DB     string
+	Query  string
+}
+
+// HTTPCall is an outgoing HTTP request captured by a TestTrace.
+type HTTPCall struct {
+	Caller string // the calling service, or "" if called by the test
+	Method string
+	URL    string
+}
+
+// Publish is a Pub/Sub message publish captured by a TestTrace.
+type Publish struct {
+	Caller string // the publishing service, or "" if published by the test
+	Topic  string
+}
+
+// Calls returns the API calls made, in the order they were made.
+func (tr *TestTrace) Calls() []RPCCall {
+	var calls []RPCCall
+	for _, op := range tr.ops(model.CapturedRPCCall) {
+		calls = append(calls, RPCCall{Caller: op.Caller, Service: op.Service, Endpoint: op.Endpoint})
+	}
+	return calls
+}
+
+// Queries returns the database queries run, in the order they were run.
+func (tr *TestTrace) Queries() []DBQuery {
+	var queries []DBQuery
+	for _, op := range tr.ops(model.CapturedDBQuery) {
+		queries = append(queries, DBQuery{Caller: op.Caller, DB: op.DB, Query: op.Query})
+	}
+	return queries
+}
+
+// HTTPCalls returns the outgoing HTTP requests made, in the order they were made.
+func (tr *TestTrace) HTTPCalls() []HTTPCall {
+	var calls []HTTPCall
+	for _, op := range tr.ops(model.CapturedHTTPCall) {
+		calls = append(calls, HTTPCall{Caller: op.Caller, Method: op.Method, URL: op.URL})
+	}
+	return calls
+}
+
+// Publishes returns the Pub/Sub messages published, in the order they were published.
+func (tr *TestTrace) Publishes() []Publish {
+	var msgs []Publish
+	for _, op := range tr.ops(model.CapturedPublish) {
+		msgs = append(msgs, Publish{Caller: op.Caller, Topic: op.Topic})
+	}
+	return msgs
+}
+
+// Reset discards the operations captured so far.
+func (tr *TestTrace) Reset() {
+	tr.capture.Reset()
+}
+
+// AssertCalled fails the test if the API service.endpoint has not been called.
+// If endpoint is "", any call to an API of the service matches.
+func (tr *TestTrace) AssertCalled(service, endpoint string) {
+	tr.t.Helper()
+	if !tr.called(service, endpoint) {
+		tr.t.Errorf("et.Trace: expected a call to %s, got none", callName(service, endpoint))
+	}
+}
+
+// AssertNotCalled fails the test if the API service.endpoint has been called.
+// If endpoint is "", any call to an API of the service matches.
+func (tr *TestTrace) AssertNotCalled(service, endpoint string) {
+	tr.t.Helper()
+	if tr.called(service, endpoint) {
+		tr.t.Errorf("et.Trace: expected no calls to %s", callName(service, endpoint))
+	}
+}
+
+// AssertNoHTTPCalls fails the test if any outgoing HTTP requests have been made.
+func (tr *TestTrace) AssertNoHTTPCalls() {
+	tr.t.Helper()
+	for _, call := range tr.HTTPCalls() {
+		tr.t.Errorf("et.Trace: unexpected HTTP call: %s %s", call.Method, call.URL)
+	}
+}
+
+func (tr *TestTrace) called(service, endpoint string) bool {
+	for _, call := range tr.Calls() {
+		if call.Service == service && (endpoint == "" || call.Endpoint == endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tr *TestTrace) ops(kind model.CapturedOpKind) []model.CapturedOp {
+	var ops []model.CapturedOp
+	for _, op := range tr.capture.Ops() {
+		if op.Kind == kind {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func callName(service, endpoint string) string {
+	if endpoint == "" {
+		return service
+	}
+	return service + "." + endpoint
+}
@@ -0,0 +1,47 @@
+package et
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"encore.dev/appruntime/exported/model"
+)
+
+func TestTestTrace(t *testing.T) {
+	c := qt.New(t)
+
+	parent := &model.Request{Type: model.Test, Test: &model.TestData{}}
+	capture := &model.TestCapture{}
+	parent.Test.Capture.Store(capture)
+
+	// Operations performed by requests of sub-tests are captured by the parent test.
+	sub := &model.Request{Type: model.Test, Test: &model.TestData{Parent: parent}}
+	svc := &model.Request{
+		Type:    model.RPCCall,
+		Test:    sub.Test,
+		RPCData: &model.RPCData{Desc: &model.RPCDesc{Service: "orders", Endpoint: "Create"}},
+	}
+
+	sub.CaptureOp(model.CapturedOp{Kind: model.CapturedRPCCall, Service: "orders", Endpoint: "Create"})
+	svc.CaptureOp(model.CapturedOp{Kind: model.CapturedRPCCall, Service: "inventory", Endpoint: "Reserve"})
+	svc.CaptureOp(model.CapturedOp{Kind: model.CapturedDBQuery, DB: "orders", Query: "SELECT 1"})
+	svc.CaptureOp(model.CapturedOp{Kind: model.CapturedPublish, Topic: "order-created"})
+
+	tr := &TestTrace{t: t, capture: capture}
+	c.Assert(tr.Calls(), qt.DeepEquals, []RPCCall{
+		{Caller: "", Service: "orders", Endpoint: "Create"},
+		{Caller: "orders", Service: "inventory", Endpoint: "Reserve"},
+	})
+	c.Assert(tr.Queries(), qt.DeepEquals, []DBQuery{{Caller: "orders", DB: "orders", Query: "SELECT 1"}})
+	c.Assert(tr.Publishes(), qt.DeepEquals, []Publish{{Caller: "orders", Topic: "order-created"}})
+	c.Assert(tr.HTTPCalls(), qt.HasLen, 0)
+
+	c.Assert(tr.called("inventory", "Reserve"), qt.IsTrue)
+	c.Assert(tr.called("inventory", ""), qt.IsTrue)
+	c.Assert(tr.called("inventory", "Release"), qt.IsFalse)
+	c.Assert(tr.called("billing", ""), qt.IsFalse)
+
+	tr.Reset()
+	c.Assert(tr.Calls(), qt.HasLen, 0)
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -28,6 +29,9 @@ type TestTopic[T any] struct {
 	m           sync.RWMutex
 	instances   map[*testing.T]*testInstance[T]
 	subscribers map[string]types.RawSubscriptionCallback
+
+	// retryPolicies are the retry policies of the subscribers, by subscription name.
+	retryPolicies map[string]*types.RetryPolicy
 }
 
 func NewTopic[T any](ts *testsupport.Manager, name string) types.TopicImplementation {
@@ -36,6 +40,8 @@ func NewTopic[T any](ts *testsupport.Manager, name string) types.TopicImplementa
 		name:        name,
 		instances:   make(map[*testing.T]*testInstance[T]),
 		subscribers: make(map[string]types.RawSubscriptionCallback),
+
+		retryPolicies: make(map[string]*types.RetryPolicy),
 	}
 }
 
@@ -55,15 +61,34 @@ func (t *TestTopic[T]) PublishMessage(ctx context.Context, orderingKey string, a
 
 	instance := t.TestInstance(test)
 
-	msgID, err := instance.publishMessage(unmarshalled)
+	msgID, err := instance.publishMessage(unmarshalled, orderingKey, attrs)
 	if err != nil {
 		return "", err
 	}
 
+	// Deliver the message to the subscriptions selected by the test before returning,
+	// so the test can make assertions about their effects once the publish call is done.
+	if names := instance.deliveredTo(); len(names) > 0 {
+		published := t.ts.Now()
+		clock := t.ts.CurrentClock()
+		for _, name := range names {
+			t.m.RLock()
+			sub, ok := t.subscribers[name]
+			retryPolicy := t.retryPolicies[name]
+			t.m.RUnlock()
+			if !ok {
+				test.Errorf("cannot deliver message %s to subscription %s: no such subscription on topic %s", msgID, name, t.name)
+				continue
+			}
+
+			t.deliver(test, clock, name, sub, retryPolicy, msgID, published, 1, attrs, data)
+		}
+	}
+
 	// If subscriptions are enabled for this test, then trigger those subscribers asynchronously
 	// allowing the publishing code to continue as it would in a real system
 	if instance.subscriptionsEnabled {
-		published := time.Now()
+		published := t.ts.Now()
 
 		for name, sub := range t.subscribers {
 			name := name
@@ -80,11 +105,40 @@ func (t *TestTopic[T]) PublishMessage(ctx context.Context, orderingKey string, a
 	return msgID, nil
 }
 
+// deliver delivers a message to the subscription synchronously.
+//
+// If the subscription returns an error and the test has a fake clock, the message
+// is redelivered according to the subscription's retry policy as the clock is advanced.
+// Otherwise, or once the retries are exhausted, the error fails the test.
+func (t *TestTopic[T]) deliver(test *testing.T, clock *testsupport.Clock, name string, sub types.RawSubscriptionCallback, retryPolicy *types.RetryPolicy, msgID string, published time.Time, attempt int, attrs map[string]string, data []byte) {
+	var err error
+	done := make(chan struct{})
+	t.ts.RunAsyncCodeInTest(test, func(ctx context.Context) {
+		defer close(done)
+		err = sub(ctx, msgID, published, attempt, attrs, data)
+	})
+	<-done
+	if err == nil {
+		return
+	}
+
+	if clock != nil && retryPolicy != nil {
+		if retry, delay := utils.GetDelay(retryPolicy.MaxRetries, retryPolicy.MinBackoff, retryPolicy.MaxBackoff, uint16(attempt)); retry {
+			clock.AfterFunc(delay, func() {
+				t.deliver(test, clock, name, sub, retryPolicy, msgID, published, attempt+1, attrs, data)
+			})
+			return
+		}
+	}
+	test.Errorf("an error was returned while processing subscription %s for message %s: %s", name, msgID, err)
+}
+
 // Subscribe will register a new subscriber for the pub sub topic. By default these will not be called during tests
 func (t *TestTopic[T]) Subscribe(logger *zerolog.Logger, maxConcurrency int, ackDeadline time.Duration, retryPolicy *types.RetryPolicy, implCfg *config.PubsubSubscription, f types.RawSubscriptionCallback) {
 	t.m.Lock()
 	defer t.m.Unlock()
 	t.subscribers[implCfg.EncoreName] = f
+	t.retryPolicies[implCfg.EncoreName] = retryPolicy
 }
 
 // TestInstance returns this tests specific instance of the topic and creates it if it does not exist
@@ -111,25 +165,44 @@ func (t *TestTopic[T]) TestInstance(test *testing.T) *testInstance[T] {
 // testInstance represents a topic, as it is seen from a test
 // This struct implements test.TestTopic[T] to allow the testing package to interface with it
 type testInstance[T any] struct {
-	topicName            string     // The topic name
-	t                    *testing.T // The test we're running against
-	msgID                int32      // The last message ID we sent (updated atomically)
-	m                    sync.Mutex // Mutex for the published messages
-	messages             []T        // What messages have been published
-	subscriptionsEnabled bool       // If subscriptions are enabled for this test
+	topicName            string             // The topic name
+	t                    *testing.T         // The test we're running against
+	msgID                int32              // The last message ID we sent (updated atomically)
+	m                    sync.Mutex         // Mutex for the published messages
+	messages             []T                // What messages have been published
+	published            []publishedMessage // The metadata of the published messages, by index
+	subscriptionsEnabled bool               // If subscriptions are enabled for this test
+	deliverTo            []string           // The subscriptions to deliver messages to synchronously
+}
+
+// publishedMessage is the metadata of a published message.
+type publishedMessage struct {
+	id          string
+	orderingKey string
+	attrs       map[string]string
 }
 
 // publishMessage records the message which was sent, and generates a deterministic message ID
 // which is guaranteed to be unique across all tests
-func (t *testInstance[T]) publishMessage(unmarshalled T) (id string, err error) {
+func (t *testInstance[T]) publishMessage(unmarshalled T, orderingKey string, attrs map[string]string) (id string, err error) {
 	msgID := atomic.AddInt32(&t.msgID, 1)
 
+	// we use "/" as the separator to mirror the behaviour of tests and sub tests
+	id = fmt.Sprintf("%s/%s/%d", t.t.Name(), t.topicName, msgID)
+
+	// Only record the attributes of the message itself, not the ones Encore adds for tracing.
+	userAttrs := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		if !strings.HasPrefix(k, "encore_") {
+			userAttrs[k] = v
+		}
+	}
+
 	t.m.Lock()
 	defer t.m.Unlock()
 	t.messages = append(t.messages, unmarshalled)
-
-	// we use "/" as the separator to mirror the behaviour of tests and sub tests
-	return fmt.Sprintf("%s/%s/%d", t.t.Name(), t.topicName, msgID), nil
+	t.published = append(t.published, publishedMessage{id: id, orderingKey: orderingKey, attrs: userAttrs})
+	return id, nil
 }
 
 func (t *testInstance[T]) PublishedMessages() []T {
@@ -137,3 +210,33 @@ func (t *testInstance[T]) PublishedMessages() []T {
 	defer t.m.Unlock()
 	return t.messages
 }
+
+// EachPublished calls fn for each message published during the test, in the order they were published.
+func (t *testInstance[T]) EachPublished(fn func(id string, msg T, orderingKey string, attrs map[string]string)) {
+	t.m.Lock()
+	messages, published := t.messages, t.published
+	t.m.Unlock()
+	for i, msg := range messages {
+		p := published[i]
+		fn(p.id, msg, p.orderingKey, p.attrs)
+	}
+}
+
+// DeliverTo delivers the messages published for the rest of the test
+// to the given subscriptions, synchronously before publishing returns.
+func (t *testInstance[T]) DeliverTo(subscriptions []string) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.deliverTo = subscriptions
+}
+
+// Test returns the test the instance belongs to.
+func (t *testInstance[T]) Test() *testing.T {
+	return t.t
+}
+
+func (t *testInstance[T]) deliveredTo() []string {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.deliverTo
+}
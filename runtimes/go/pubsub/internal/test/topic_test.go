@@ -0,0 +1,33 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPublishedMessages(t *testing.T) {
+	inst := &testInstance[string]{topicName: "orders", t: t}
+
+	_, _ = inst.publishMessage("first", "", map[string]string{"region": "eu", "encore_parent_trace_id": "abc"})
+	_, _ = inst.publishMessage("second", "customer-1", nil)
+
+	type published struct {
+		id, msg, orderingKey string
+		attrs                map[string]string
+	}
+	var got []published
+	inst.EachPublished(func(id string, msg string, orderingKey string, attrs map[string]string) {
+		got = append(got, published{id, msg, orderingKey, attrs})
+	})
+
+	want := []published{
+		{t.Name() + "/orders/1", "first", "", map[string]string{"region": "eu"}},
+		{t.Name() + "/orders/2", "second", "customer-1", map[string]string{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if msgs := inst.PublishedMessages(); !reflect.DeepEqual(msgs, []string{"first", "second"}) {
+		t.Errorf("got messages %v", msgs)
+	}
+}
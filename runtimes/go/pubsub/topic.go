@@ -157,6 +157,7 @@ func (t *Topic[T]) Publish(ctx context.Context, msg T) (id string, err error) {
 
 	// Start the trace span
 	curr := t.mgr.rt.Current()
+	curr.Req.CaptureOp(model.CapturedOp{Kind: model.CapturedPublish, Topic: t.runtimeCfg.EncoreName})
 	var startEventID trace2.EventID
 	if curr.Req != nil && curr.Trace != nil {
 		startEventID = curr.Trace.PubsubPublishStart(trace2.PubsubPublishStartParams{
@@ -2,10 +2,23 @@
 
 package rlog
 
-import "encore.dev/appruntime/shared/reqtrack"
+import (
+	"encore.dev/appruntime/shared/appconf"
+	"encore.dev/appruntime/shared/logfilter"
+	"encore.dev/appruntime/shared/logging"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/shutdown"
+)
 
 //publicapigen:drop
-var Singleton = NewManager(reqtrack.Singleton)
+var Singleton = NewManager(reqtrack.Singleton, newFilter())
+
+func newFilter() *logfilter.Filter {
+	f := logfilter.New(appconf.Runtime.Logging, logging.RootLogger)
+	shutdown.Singleton.RegisterShutdownHandler(f.Shutdown)
+	go f.Watch()
+	return f
+}
 
 // Debug logs a debug-level message.
 // The variadic key-value pairs are treated as they are in With.
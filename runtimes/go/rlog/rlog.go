@@ -13,6 +13,7 @@ import (
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/shared/logfilter"
 	"encore.dev/appruntime/shared/reqtrack"
 	"encore.dev/types/uuid"
 )
@@ -26,12 +27,13 @@ const InternalKeyPrefix = "encore_"
 
 //publicapigen:drop
 type Manager struct {
-	rt *reqtrack.RequestTracker
+	rt     *reqtrack.RequestTracker
+	filter *logfilter.Filter // nil means all logs are emitted
 }
 
 //publicapigen:drop
-func NewManager(rt *reqtrack.RequestTracker) *Manager {
-	return &Manager{rt}
+func NewManager(rt *reqtrack.RequestTracker, filter *logfilter.Filter) *Manager {
+	return &Manager{rt, filter}
 }
 
 // Ctx holds additional logging context for use with the Infoc and family
@@ -132,6 +134,20 @@ func (l *Manager) doLog(level model.LogLevel, ev *zerolog.Event, msg string, ctx
 	curr := l.rt.Current()
 	numFields := len(ctxFields)/2 + len(logFields)/2
 
+	// Logs filtered out by the logging config are still recorded in the trace.
+	if l.filter != nil {
+		var svc, endpoint string
+		if curr.Req != nil {
+			svc = curr.Req.Service()
+			if curr.Req.Type == model.RPCCall {
+				endpoint = curr.Req.RPCData.Desc.Endpoint
+			}
+		}
+		if !l.filter.Allow(level, svc, endpoint) {
+			ev.Discard()
+		}
+	}
+
 	if curr.Req != nil && curr.Trace != nil {
 		traced = true
 		tp = trace2.LogMessageParams{
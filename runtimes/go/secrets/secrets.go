@@ -0,0 +1,36 @@
+// Package secrets provides typed secrets, whose values Encore parses
+// and validates when the application starts.
+//
+// For more information about secrets see https://encore.dev/docs/primitives/secrets.
+package secrets
+
+// JSON is a secret holding a JSON document, which Encore parses into a T
+// when the application starts. The application fails to start with an error
+// describing the problem if the secret's value doesn't match T, or if T has
+// a Validate() error method that reports the value as invalid.
+//
+// Declare it as a package-level variable without a value, and Encore will
+// load it for you. The variable's name is the name of the secret:
+//
+//	import "encore.dev/secrets"
+//
+//	type DBCreds struct {
+//		User     string `json:"user"`
+//		Password string `json:"password"`
+//	}
+//
+//	var dbCreds secrets.JSON[DBCreds]
+//
+//	func connect() {
+//		creds := dbCreds()
+//		// ...
+//	}
+//
+// T must be a named struct type.
+//
+// It is a function to allow for the secret to be loaded when the
+// application starts, like the values of the config package. Secrets read
+// from a file or environment variable in the runtime config are re-read
+// periodically, so call it each time the value is needed to pick up
+// rotated values.
+type JSON[T any] func() T
@@ -140,7 +140,7 @@ func (s *StringKeyspace[K]) GetRange(ctx context.Context, key K, from, to int64)
 		return "", err
 	}
 
-	res, err := s.client.redis.GetRange(ctx, k, from, to).Result()
+	res, err := s.client.redis().GetRange(ctx, k, from, to).Result()
 	err = toErr(err, op, k)
 	return res, err
 }
@@ -183,7 +183,7 @@ func (s *StringKeyspace[K]) Len(ctx context.Context, key K) (length int64, err e
 		return 0, err
 	}
 
-	res, err := s.client.redis.StrLen(ctx, k).Result()
+	res, err := s.client.redis().StrLen(ctx, k).Result()
 	err = toErr(err, op, k)
 	return res, err
 }
@@ -481,7 +481,7 @@ func (s *basicKeyspace[K, V]) Get(ctx context.Context, key K) (val V, err error)
 		return val, err
 	}
 
-	res, err := s.redis.Get(ctx, k).Result()
+	res, err := s.redis().Get(ctx, k).Result()
 	if err == nil {
 		val, err = s.fromRedis(res)
 	}
@@ -524,7 +524,7 @@ func (s *basicKeyspace[K, V]) GetAndDelete(ctx context.Context, key K) (val V, e
 	}
 
 	// When deleting we don't need to deal with expiry
-	res, err := s.redis.GetDel(ctx, k).Result()
+	res, err := s.redis().GetDel(ctx, k).Result()
 	if err == nil {
 		val, err = s.fromRedis(res)
 	}
@@ -546,7 +546,7 @@ func (s *client[K, V]) Delete(ctx context.Context, keys ...K) (deleted int, err
 	}
 
 	// When deleting we don't need to deal with expiry
-	res, err := s.redis.Del(ctx, ks...).Result()
+	res, err := s.redis().Del(ctx, ks...).Result()
 	err = toErr(err, op, firstKey)
 	return int(res), err
 }
@@ -598,7 +598,7 @@ func (s *basicKeyspace[K, V]) set(ctx context.Context, key K, val V, flag setFla
 		args = append(args, "get")
 	}
 
-	now := time.Now()
+	now := s.now()
 	exp := s.expiry(now)
 	switch exp {
 	case neverExpire:
@@ -623,14 +623,14 @@ func (s *basicKeyspace[K, V]) set(ctx context.Context, key K, val V, flag setFla
 
 	if get {
 		cmd := redis.NewStringCmd(ctx, args...)
-		_ = s.redis.Process(ctx, cmd)
+		_ = s.redis().Process(ctx, cmd)
 		res, err := cmd.Result()
 		err = toErr(err, op, k)
 		return res, k, err
 	}
 
 	cmd := redis.NewStatusCmd(ctx, args...)
-	_ = s.redis.Process(ctx, cmd)
+	_ = s.redis().Process(ctx, cmd)
 	return "", k, toErr(cmd.Err(), op, k)
 }
 
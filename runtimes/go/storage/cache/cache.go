@@ -226,10 +226,10 @@ func fnMap[A, B any](src []A, fn func(A) B) []B {
 func do[K, V, Res any](cl *client[K, V], ctx context.Context, key string, fn func(cmdable) Res) Res {
 	exp := cl.expiryCmd(ctx, key)
 	if exp == nil {
-		return fn(cl.redis)
+		return fn(cl.redis())
 	}
 
-	pipe := cl.redis.TxPipeline()
+	pipe := cl.redis().TxPipeline()
 	res := fn(pipe)
 	_ = pipe.Process(ctx, exp)
 	_, _ = pipe.Exec(ctx)
@@ -248,11 +248,11 @@ func do2[K, V, Res any](
 
 	// If we don't have any expiry commands, process the command directly.
 	if expA == nil && expB == nil {
-		return fn(cl.redis)
+		return fn(cl.redis())
 	}
 
 	// Otherwise use a pipeline.
-	pipe := cl.redis.TxPipeline()
+	pipe := cl.redis().TxPipeline()
 	res := fn(pipe)
 	if expA != nil {
 		_ = pipe.Process(ctx, expA)
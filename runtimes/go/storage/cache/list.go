@@ -174,7 +174,7 @@ func (l *ListKeyspace[K, V]) Len(ctx context.Context, key K) (length int64, err
 		return 0, err
 	}
 
-	res, err := l.redis.LLen(ctx, k).Result()
+	res, err := l.redis().LLen(ctx, k).Result()
 	err = toErr(err, op, k)
 	return res, err
 }
@@ -239,7 +239,7 @@ func (l *ListKeyspace[K, V]) Get(ctx context.Context, key K, idx int64) (val V,
 	if err != nil {
 		return val, err
 	}
-	res, err := l.redis.LIndex(ctx, k, idx).Result()
+	res, err := l.redis().LIndex(ctx, k, idx).Result()
 
 	if err == nil {
 		val, err = l.fromRedis(res)
@@ -275,7 +275,7 @@ func (l *ListKeyspace[K, V]) getRange(ctx context.Context, key K, from, to int64
 	if err != nil {
 		return nil, err
 	}
-	res, err := l.redis.LRange(ctx, k, from, to).Result()
+	res, err := l.redis().LRange(ctx, k, from, to).Result()
 	if err != nil {
 		return nil, toErr(err, op, k)
 	}
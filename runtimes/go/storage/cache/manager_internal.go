@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
@@ -37,12 +38,22 @@ type Manager struct {
 	initTestSrv syncutil.Once
 	testSrv     *miniredis.Miniredis
 
+	testSrvMu sync.Mutex
+	testSrvs  map[*testing.T]*testServer // the servers of the running tests
+
 	clientMu sync.RWMutex
 	clients  map[string]*redis.Client
 }
 
+// testServer is the cache server of a single test.
+type testServer struct {
+	srv  *miniredis.Miniredis
+	cl   *redis.Client
+	test *model.TestData
+}
+
 func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager, json jsoniter.API) *Manager {
-	return &Manager{
+	mgr := &Manager{
 		static:  static,
 		runtime: runtime,
 		rt:      rt,
@@ -50,6 +61,10 @@ func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.Req
 		json:    json,
 		clients: make(map[string]*redis.Client),
 	}
+	if static.Testing {
+		ts.OnClockAdvance(mgr.fastForwardTestServers)
+	}
+	return mgr
 }
 
 func (mgr *Manager) getClient(clusterName string) *redis.Client {
@@ -152,9 +167,13 @@ func (mgr *Manager) newMiniredisClient() (*redis.Client, error) {
 		return nil, err
 	}
 
+	return newMiniredisClient(mgr.testSrv)
+}
+
+func newMiniredisClient(srv *miniredis.Miniredis) (*redis.Client, error) {
 	opts := &redis.Options{
 		Network:      "tcp",
-		Addr:         mgr.testSrv.Addr(),
+		Addr:         srv.Addr(),
 		DB:           0,
 		MinIdleConns: 1,
 		PoolSize:     runtime.GOMAXPROCS(0) * 10,
@@ -162,11 +181,65 @@ func (mgr *Manager) newMiniredisClient() (*redis.Client, error) {
 	cl := redis.NewClient(opts)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	err = cl.Ping(ctx).Err()
+	err := cl.Ping(ctx).Err()
 	cancel()
 	return cl, err
 }
 
+// testClient returns the client of the current test's cache server,
+// starting the server if necessary, or nil if no test is running.
+//
+// Each test gets a server of its own so that advancing a test's
+// fake clock only expires the keys of the tests using that clock.
+func (mgr *Manager) testClient() *redis.Client {
+	req := mgr.rt.Current().Req
+	if req == nil || req.Test == nil {
+		return nil
+	}
+	t := req.Test.Current
+
+	mgr.testSrvMu.Lock()
+	defer mgr.testSrvMu.Unlock()
+	if s, ok := mgr.testSrvs[t]; ok {
+		return s.cl
+	}
+
+	srv, err := miniredis.Run()
+	if err != nil {
+		panic(fmt.Sprintf("cache: unable to start redis mock: %v", err))
+	}
+	cl, err := newMiniredisClient(srv)
+	if err != nil {
+		srv.Close()
+		panic(fmt.Sprintf("cache: unable to start redis mock: %v", err))
+	}
+
+	if mgr.testSrvs == nil {
+		mgr.testSrvs = make(map[*testing.T]*testServer)
+	}
+	mgr.testSrvs[t] = &testServer{srv: srv, cl: cl, test: req.Test}
+	t.Cleanup(func() {
+		mgr.testSrvMu.Lock()
+		delete(mgr.testSrvs, t)
+		mgr.testSrvMu.Unlock()
+		_ = cl.Close()
+		srv.Close()
+	})
+	return cl
+}
+
+// fastForwardTestServers expires the cached keys of the tests
+// using the fake clock c, as it's advanced by elapsed.
+func (mgr *Manager) fastForwardTestServers(c *testsupport.Clock, elapsed time.Duration) {
+	mgr.testSrvMu.Lock()
+	defer mgr.testSrvMu.Unlock()
+	for _, s := range mgr.testSrvs {
+		if mgr.ts.TestClock(s.test) == c {
+			s.srv.FastForward(elapsed)
+		}
+	}
+}
+
 func (mgr *Manager) Shutdown(p *shutdown.Process) error {
 	// The redis client does not have the concept of graceful shutdown,
 	// so wait for user code to shut down first.
@@ -186,7 +259,15 @@ func newClient[K, V any](cluster *Cluster, cfg KeyspaceConfig,
 	toRedis func(V) (any, error),
 ) *client[K, V] {
 	keyMapper := cfg.EncoreInternal_KeyMapper.(func(K) string)
+	now, relExpiry := time.Now, false
+	var testClient func() *redis.Client
 	if mgr := cluster.mgr; mgr.static.Testing {
+		testClient = mgr.testClient
+
+		// Compute expiry times using the test's fake clock, if any.
+		// As the cache server doesn't know its time, use relative expiry times.
+		now, relExpiry = mgr.ts.Now, true
+
 		// If we're running tests, map keys to a test-specific key.
 		orig := keyMapper
 		keyMapper = func(k K) string {
@@ -207,24 +288,40 @@ func newClient[K, V any](cluster *Cluster, cfg KeyspaceConfig,
 	}
 
 	return &client[K, V]{
-		rt:        cluster.mgr.rt,
-		redis:     cluster.cl,
-		cfg:       cfg,
-		expiry:    defaultExpiry,
-		keyMapper: keyMapper,
-		toRedis:   toRedis,
-		fromRedis: fromRedis,
+		rt:         cluster.mgr.rt,
+		cl:         cluster.cl,
+		testClient: testClient,
+		cfg:        cfg,
+		expiry:     defaultExpiry,
+		now:        now,
+		relExpiry:  relExpiry,
+		keyMapper:  keyMapper,
+		toRedis:    toRedis,
+		fromRedis:  fromRedis,
 	}
 }
 
 type client[K, V any] struct {
-	rt        *reqtrack.RequestTracker
-	redis     *redis.Client
-	cfg       KeyspaceConfig
-	expiry    ExpiryFunc
-	keyMapper func(K) string
-	toRedis   func(V) (any, error)
-	fromRedis func(string) (V, error)
+	rt         *reqtrack.RequestTracker
+	cl         *redis.Client
+	testClient func() *redis.Client // returns the current test's client, if any
+	cfg        KeyspaceConfig
+	expiry     ExpiryFunc
+	now        func() time.Time
+	relExpiry  bool // whether to set expiry times relative to now
+	keyMapper  func(K) string
+	toRedis    func(V) (any, error)
+	fromRedis  func(string) (V, error)
+}
+
+// redis returns the client to use for the cache server.
+func (s *client[K, V]) redis() *redis.Client {
+	if s.testClient != nil {
+		if cl := s.testClient(); cl != nil {
+			return cl
+		}
+	}
+	return s.cl
 }
 
 func (c *client[K, V]) with(opts []WriteOption) *client[K, V] {
@@ -294,12 +391,14 @@ func (s *client[K, V]) valOrNil(res string, err error) (*V, error) {
 }
 
 func (s *client[K, V]) expiryCmd(ctx context.Context, key string) *redis.BoolCmd {
-	now := time.Now()
+	now := s.now()
 	expTime := s.expiry(now)
 	if expTime == keepTTL {
 		return nil
 	} else if expTime == neverExpire {
 		return redis.NewBoolCmd(ctx, "persist", key)
+	} else if s.relExpiry {
+		return redis.NewBoolCmd(ctx, "pexpire", key, max(expTime.Sub(now).Milliseconds(), 1))
 	}
 
 	expMs := expTime.UnixNano() / int64(time.Millisecond)
@@ -307,7 +406,7 @@ func (s *client[K, V]) expiryCmd(ctx context.Context, key string) *redis.BoolCmd
 }
 
 func (s *client[K, V]) expiryDur() time.Duration {
-	now := time.Now()
+	now := s.now()
 	expTime := s.expiry(now)
 
 	var exp time.Duration
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/rs/zerolog"
+
+	"encore.dev/appruntime/exported/config"
+	"encore.dev/appruntime/exported/model"
+	"encore.dev/appruntime/shared/reqtrack"
+	"encore.dev/appruntime/shared/testsupport"
+)
+
+func TestTestServerExpiry(t *testing.T) {
+	static := &config.Static{Testing: true}
+	rt := reqtrack.New(zerolog.Nop(), nil, nil)
+	ts := testsupport.NewManager(static, rt, zerolog.Nop())
+	mgr := NewManager(static, &config.Runtime{}, rt, ts, jsoniter.ConfigCompatibleWithStandardLibrary)
+	ctx := context.Background()
+
+	// beginTest begins the request of the test t, with the given parent test request.
+	beginTest := func(t *testing.T, parent *model.Request) *model.Request {
+		req := &model.Request{Type: model.Test, Test: &model.TestData{Current: t, Parent: parent}}
+		rt.BeginRequest(req)
+		t.Cleanup(func() { rt.FinishRequest(false) })
+		return req
+	}
+
+	t.Run("real_clock", func(t *testing.T) {
+		req := beginTest(t, nil)
+		cl := mgr.testClient()
+		if err := cl.Set(ctx, "key", "val", time.Minute).Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Advancing the fake clock of a sub-test doesn't expire the keys of this test.
+		t.Run("fake_clock", func(t *testing.T) {
+			beginTest(t, req)
+			cl := mgr.testClient()
+			if err := cl.Set(ctx, "key", "val", time.Minute).Err(); err != nil {
+				t.Fatal(err)
+			}
+			ts.Clock().Advance(2 * time.Minute)
+			if err := cl.Get(ctx, "key").Err(); err != redis.Nil {
+				t.Errorf("got err %v after advancing the clock, want redis.Nil", err)
+			}
+		})
+
+		if got, err := cl.Get(ctx, "key").Result(); err != nil || got != "val" {
+			t.Errorf("got %q, %v, want the key of the test using the real clock to remain", got, err)
+		}
+	})
+}
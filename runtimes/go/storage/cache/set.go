@@ -161,7 +161,7 @@ func (s *SetKeyspace[K, V]) Contains(ctx context.Context, key K, val V) (contain
 		return false, err
 	}
 
-	res, err := s.redis.SIsMember(ctx, k, val).Result()
+	res, err := s.redis().SIsMember(ctx, k, val).Result()
 	err = toErr(err, op, k)
 	return res, err
 }
@@ -179,7 +179,7 @@ func (s *SetKeyspace[K, V]) Len(ctx context.Context, key K) (length int64, err e
 		return 0, err
 	}
 
-	res, err := s.redis.SCard(ctx, k).Result()
+	res, err := s.redis().SCard(ctx, k).Result()
 	err = toErr(err, op, k)
 	return res, err
 }
@@ -224,7 +224,7 @@ func (s *SetKeyspace[K, V]) ItemsMap(ctx context.Context, key K) (values map[V]s
 }
 
 func (s *SetKeyspace[K, V]) items(ctx context.Context, op, key string) ([]string, error) {
-	res, err := s.redis.SMembers(ctx, key).Result()
+	res, err := s.redis().SMembers(ctx, key).Result()
 	err = toErr(err, op, key)
 	return res, err
 }
@@ -270,7 +270,7 @@ func (s *SetKeyspace[K, V]) diff(ctx context.Context, op string, keys []K) (vals
 		firstKey = ks[0]
 	}
 
-	vals, err = s.redis.SDiff(ctx, ks...).Result()
+	vals, err = s.redis().SDiff(ctx, ks...).Result()
 	return vals, firstKey, toErr(err, op, firstKey)
 }
 
@@ -341,7 +341,7 @@ func (s *SetKeyspace[K, V]) intersect(ctx context.Context, op string, keys []K)
 		firstKey = ks[0]
 	}
 
-	res, err := s.redis.SInter(ctx, ks...).Result()
+	res, err := s.redis().SInter(ctx, ks...).Result()
 	err = toErr(err, op, firstKey)
 	return res, firstKey, err
 }
@@ -411,7 +411,7 @@ func (s *SetKeyspace[K, V]) union(ctx context.Context, op string, keys []K) (val
 		firstKey = ks[0]
 	}
 
-	res, err := s.redis.SUnion(ctx, ks...).Result()
+	res, err := s.redis().SUnion(ctx, ks...).Result()
 	err = toErr(err, op, firstKey)
 	return res, firstKey, err
 }
@@ -452,7 +452,7 @@ func (s *SetKeyspace[K, V]) SampleOne(ctx context.Context, key K) (val V, err er
 		return val, err
 	}
 
-	res, err := s.redis.SRandMember(ctx, k).Result()
+	res, err := s.redis().SRandMember(ctx, k).Result()
 	if err == nil {
 		val, err = s.fromRedis(res)
 	}
@@ -481,7 +481,7 @@ func (s *SetKeyspace[K, V]) Sample(ctx context.Context, key K, count int) (value
 		return nil, nil
 	}
 
-	res, err := s.redis.SRandMemberN(ctx, k, int64(count)).Result()
+	res, err := s.redis().SRandMemberN(ctx, k, int64(count)).Result()
 	if err == nil {
 		values, err = s.fromRedisMulti(res)
 	}
@@ -510,7 +510,7 @@ func (s *SetKeyspace[K, V]) SampleWithReplacement(ctx context.Context, key K, co
 		return nil, nil
 	}
 
-	res, err := s.redis.SRandMemberN(ctx, k, -int64(count)).Result()
+	res, err := s.redis().SRandMemberN(ctx, k, -int64(count)).Result()
 	if err == nil {
 		values, err = s.fromRedisMulti(res)
 	}
@@ -19,6 +19,7 @@ import (
 	"encore.dev/appruntime/exported/model"
 	"encore.dev/appruntime/exported/stack"
 	"encore.dev/appruntime/exported/trace2"
+	"encore.dev/appruntime/infrasdk/secrets"
 	"encore.dev/storage/sqldb/internal/stdlibdriver"
 )
 
@@ -29,6 +30,10 @@ type Database struct {
 
 	noopDB bool // true if this is a dummy database that does nothing and returns errors for all operations
 
+	// packageDB is true if this is the database of a test binary,
+	// cloned from the template database when first used.
+	packageDB bool
+
 	initOnce sync.Once
 	pool     *pgxpool.Pool
 	connStr  string
@@ -46,12 +51,17 @@ func (db *Database) init() {
 
 	db.initOnce.Do(func() {
 		if db.pool == nil {
-			pool, found := db.mgr.getPool(db.origName, db.name)
+			dbName := db.name
+			if db.packageDB {
+				dbName = db.mgr.createPackageDB(db.origName)
+			}
+			pool, found := db.mgr.getPool(db.origName, dbName)
 			db.pool, db.noopDB = pool, !found
 		}
 
 		if !db.noopDB {
-			db.connStr = stdlibdriver.RegisterConnConfig(db.pool.Config().ConnConfig)
+			cfg := db.pool.Config()
+			db.connStr = stdlibdriver.RegisterConnConfig(cfg.ConnConfig, cfg.BeforeConnect)
 		}
 	})
 }
@@ -110,7 +120,11 @@ func dbConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string
 	if dbName == "" {
 		dbName = db.DatabaseName
 	}
-	uri := fmt.Sprintf("user=%s password=%s dbname=%s", db.User, db.Password, dbName)
+	// With IAM authentication or a password source the password is set before each connection.
+	uri := fmt.Sprintf("user=%s dbname=%s", db.User, dbName)
+	if db.IAMAuth == nil && db.PasswordSource == nil {
+		uri += " password=" + db.Password
+	}
 
 	// Handle different ways of expressing the host
 	if strings.HasPrefix(srv.Host, "/") {
@@ -123,6 +137,8 @@ func dbConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string
 
 	if srv.ServerCACert != "" {
 		uri += " sslmode=verify-ca"
+	} else if db.IAMAuth != nil {
+		uri += " sslmode=require" // never send tokens in plaintext
 	} else {
 		uri += " sslmode=prefer"
 	}
@@ -157,6 +173,27 @@ func dbConf(srv *config.SQLServer, db *config.SQLDatabase, dbNameOverride string
 		cfg.ConnConfig.TLSConfig.Certificates = []tls.Certificate{cert}
 	}
 
+	switch {
+	case db.IAMAuth != nil && db.PasswordSource != nil:
+		return nil, fmt.Errorf("only one of IAM authentication and a password source can be set")
+	case db.IAMAuth != nil:
+		src, err := newIAMTokenSource(srv, db)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IAM authentication config: %v", err)
+		}
+		cfg.BeforeConnect = src.beforeConnect
+	case db.PasswordSource != nil:
+		src := db.PasswordSource
+		cfg.BeforeConnect = func(ctx context.Context, cfg *pgx.ConnConfig) error {
+			password, err := secrets.ReadSource(ctx, src)
+			if err != nil {
+				return fmt.Errorf("sqldb: read password: %w", err)
+			}
+			cfg.Password = password
+			return nil
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -296,7 +333,7 @@ func (db *Database) Begin(ctx context.Context) (*Tx, error) {
 	}
 
 	db.init()
-	tx, err := db.pool.Begin(markTraced(ctx))
+	tx, err := db.pool.Begin(markNoCapture(markTraced(ctx)))
 	err = convertErr(err)
 	if err != nil {
 		return nil, err
@@ -331,6 +368,7 @@ func Driver[T SupportedDrivers](db *Database) T {
 		return zero
 	}
 
+	db.init()
 	return any(db.pool).(T)
 }
 
@@ -0,0 +1,182 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"encore.dev/appruntime/exported/config"
+)
+
+const (
+	// iamTokenRefreshMargin is how long before a token expires it's replaced,
+	// so connections are never attempted with a token about to expire.
+	iamTokenRefreshMargin = 5 * time.Minute
+
+	// rdsTokenLifetime is how long RDS IAM authentication tokens are valid.
+	rdsTokenLifetime = 15 * time.Minute
+
+	// cloudSQLLoginScope is the OAuth2 scope for logging in to Cloud SQL
+	// with IAM database authentication.
+	cloudSQLLoginScope = "https://www.googleapis.com/auth/sqlservice.login"
+
+	// emptyPayloadHash is the SHA-256 hash of an empty payload.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// iamTokenFetcher fetches a new IAM authentication token along with its expiry.
+type iamTokenFetcher func(ctx context.Context) (token string, expiry time.Time, err error)
+
+// iamTokenSource caches an IAM authentication token, and fetches
+// a new one when the cached token is about to expire.
+type iamTokenSource struct {
+	fetch iamTokenFetcher
+	now   func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// newIAMTokenSource returns a token source for authenticating to the database
+// according to its IAM authentication config.
+func newIAMTokenSource(srv *config.SQLServer, db *config.SQLDatabase) (*iamTokenSource, error) {
+	src := &iamTokenSource{now: time.Now}
+	auth := db.IAMAuth
+	switch {
+	case auth.AWS != nil && auth.GCP != nil:
+		return nil, errors.New("only one IAM authentication provider can be set")
+	case auth.AWS != nil:
+		if strings.HasPrefix(srv.Host, "/") {
+			return nil, errors.New("RDS IAM authentication requires a host, not a unix socket")
+		}
+		endpoint := srv.Host
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			endpoint = net.JoinHostPort(endpoint, "5432")
+		}
+		src.fetch = rdsTokenFetcher(endpoint, auth.AWS.Region, db.User, src.now)
+	case auth.GCP != nil:
+		src.fetch = cloudSQLTokenFetcher()
+	default:
+		return nil, errors.New("no IAM authentication provider set")
+	}
+	return src, nil
+}
+
+// Token returns a valid token, fetching a new one if needed.
+func (s *iamTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Before(s.expiry.Add(-iamTokenRefreshMargin)) {
+		return s.token, nil
+	}
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token, s.expiry = token, expiry
+	return token, nil
+}
+
+// beforeConnect uses the current token as the password of a new connection.
+func (s *iamTokenSource) beforeConnect(ctx context.Context, cfg *pgx.ConnConfig) error {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("sqldb: get IAM authentication token: %w", err)
+	}
+	cfg.Password = token
+	return nil
+}
+
+// rdsTokenFetcher returns a fetcher of RDS IAM authentication tokens
+// for the given user at the given endpoint ("host:port").
+func rdsTokenFetcher(endpoint, region, user string, now func() time.Time) iamTokenFetcher {
+	loadConfig := sync.OnceValues(func() (aws.Config, error) {
+		var opts []func(*awsconfig.LoadOptions) error
+		if region != "" {
+			opts = append(opts, awsconfig.WithRegion(region))
+		}
+		return awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	})
+
+	return func(ctx context.Context) (string, time.Time, error) {
+		cfg, err := loadConfig()
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("load AWS config: %w", err)
+		} else if cfg.Region == "" {
+			return "", time.Time{}, errors.New("the AWS region is not set")
+		}
+		creds, err := cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("retrieve AWS credentials: %w", err)
+		}
+
+		signedAt := now()
+		token, err := buildRDSAuthToken(ctx, endpoint, cfg.Region, user, creds, signedAt)
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		return token, signedAt.Add(rdsTokenLifetime), nil
+	}
+}
+
+// buildRDSAuthToken builds an RDS IAM authentication token: a presigned URL
+// for the "connect" action, without its scheme, as built by the AWS SDK's
+// feature/rds/auth package.
+func buildRDSAuthToken(ctx context.Context, endpoint, region, user string, creds aws.Credentials, signedAt time.Time) (string, error) {
+	u := url.URL{Scheme: "https", Host: endpoint, Path: "/"}
+	u.RawQuery = url.Values{
+		"Action":        {"connect"},
+		"DBUser":        {user},
+		"X-Amz-Expires": {strconv.Itoa(int(rdsTokenLifetime.Seconds()))},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	signed, _, err := v4.NewSigner().PresignHTTP(ctx, creds, req, emptyPayloadHash, "rds-db", region, signedAt)
+	if err != nil {
+		return "", fmt.Errorf("sign RDS auth token: %w", err)
+	}
+	return strings.TrimPrefix(signed, "https://"), nil
+}
+
+// cloudSQLTokenFetcher returns a fetcher of OAuth2 access tokens for logging in
+// to Cloud SQL, issued for the Application Default Credentials.
+func cloudSQLTokenFetcher() iamTokenFetcher {
+	findTokenSource := sync.OnceValues(func() (oauth2.TokenSource, error) {
+		creds, err := google.FindDefaultCredentials(context.Background(), cloudSQLLoginScope)
+		if err != nil {
+			return nil, err
+		}
+		return creds.TokenSource, nil
+	})
+
+	return func(ctx context.Context) (string, time.Time, error) {
+		src, err := findTokenSource()
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("find GCP credentials: %w", err)
+		}
+		tok, err := src.Token()
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("get GCP access token: %w", err)
+		}
+		return tok.AccessToken, tok.Expiry, nil
+	}
+}
@@ -29,7 +29,7 @@ var pgxDriver *Driver
 
 func init() {
 	pgxDriver = &Driver{
-		configs: make(map[string]*pgx.ConnConfig),
+		configs: make(map[string]registeredConfig),
 	}
 
 	databaseSQLResultFormats = pgx.QueryResultFormatsByOID{
@@ -57,10 +57,16 @@ func GetDefaultDriver() driver.Driver {
 
 type Driver struct {
 	configMutex sync.Mutex
-	configs     map[string]*pgx.ConnConfig
+	configs     map[string]registeredConfig
 	sequence    int
 }
 
+// registeredConfig is a ConnConfig registered with RegisterConnConfig.
+type registeredConfig struct {
+	connConfig    *pgx.ConnConfig
+	beforeConnect func(context.Context, *pgx.ConnConfig) error
+}
+
 func (d *Driver) Open(name string) (driver.Conn, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second) // Ensure eventual timeout
 	defer cancel()
@@ -76,11 +82,11 @@ func (d *Driver) OpenConnector(name string) (driver.Connector, error) {
 	return &driverConnector{driver: d, name: name}, nil
 }
 
-func (d *Driver) registerConnConfig(c *pgx.ConnConfig) string {
+func (d *Driver) registerConnConfig(c *pgx.ConnConfig, beforeConnect func(context.Context, *pgx.ConnConfig) error) string {
 	d.configMutex.Lock()
 	connStr := fmt.Sprintf("registeredConnConfig%d", d.sequence)
 	d.sequence++
-	d.configs[connStr] = c
+	d.configs[connStr] = registeredConfig{connConfig: c, beforeConnect: beforeConnect}
 	d.configMutex.Unlock()
 	return connStr
 }
@@ -97,12 +103,11 @@ type driverConnector struct {
 }
 
 func (dc *driverConnector) Connect(ctx context.Context) (driver.Conn, error) {
-	var connConfig *pgx.ConnConfig
-
 	dc.driver.configMutex.Lock()
-	connConfig = dc.driver.configs[dc.name]
+	registered := dc.driver.configs[dc.name]
 	dc.driver.configMutex.Unlock()
 
+	connConfig := registered.connConfig
 	if connConfig == nil {
 		var err error
 		connConfig, err = pgx.ParseConfig(dc.name)
@@ -111,6 +116,14 @@ func (dc *driverConnector) Connect(ctx context.Context) (driver.Conn, error) {
 		}
 	}
 
+	// Let the hook modify a copy of the config, like pgxpool does.
+	if registered.beforeConnect != nil {
+		connConfig = connConfig.Copy()
+		if err := registered.beforeConnect(ctx, connConfig); err != nil {
+			return nil, err
+		}
+	}
+
 	conn, err := pgx.ConnectConfig(ctx, connConfig)
 	if err != nil {
 		return nil, err
@@ -131,8 +144,9 @@ func (dc *driverConnector) Driver() driver.Driver {
 }
 
 // RegisterConnConfig registers a ConnConfig and returns the connection string to use with Open.
-func RegisterConnConfig(c *pgx.ConnConfig) string {
-	return pgxDriver.registerConnConfig(c)
+// If beforeConnect is non-nil it's called with a copy of the config before each connection is established.
+func RegisterConnConfig(c *pgx.ConnConfig, beforeConnect func(context.Context, *pgx.ConnConfig) error) string {
+	return pgxDriver.registerConnConfig(c, beforeConnect)
 }
 
 // UnregisterConnConfig removes the ConnConfig registration for connStr.
@@ -14,6 +14,7 @@ import (
 
 // Manager manages database connections.
 type Manager struct {
+	static  *config.Static
 	runtime *config.Runtime
 	rt      *reqtrack.RequestTracker
 	ts      *testsupport.Manager
@@ -22,8 +23,9 @@ type Manager struct {
 	dbs map[string]*Database
 }
 
-func NewManager(runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager) *Manager {
+func NewManager(static *config.Static, runtime *config.Runtime, rt *reqtrack.RequestTracker, ts *testsupport.Manager) *Manager {
 	return &Manager{
+		static:  static,
 		runtime: runtime,
 		rt:      rt,
 		ts:      ts,
@@ -58,6 +60,19 @@ func (mgr *Manager) GetDB(dbName string) *Database {
 	if db, ok := mgr.dbs[dbName]; ok {
 		return db
 	}
+	if mgr.static.Testing {
+		// Each test binary uses its own database, created when first used.
+		db = &Database{
+			name:      dbName,
+			origName:  dbName,
+			mgr:       mgr,
+			noopDB:    mgr.dbConfig(dbName) == nil,
+			packageDB: true,
+		}
+		mgr.dbs[dbName] = db
+		return db
+	}
+
 	pool, found := mgr.getPool(dbName, "")
 	db = &Database{
 		name:     dbName,
@@ -70,16 +85,20 @@ func (mgr *Manager) GetDB(dbName string) *Database {
 	return db
 }
 
-// getPool returns a database connection pool for the given database name.
-// Each time it's called it returns a new pool.
-func (mgr *Manager) getPool(encoreName, dbNameOverride string) (pool *pgxpool.Pool, found bool) {
-	var db *config.SQLDatabase
+// dbConfig returns the config of the database with the given name, or nil if there is none.
+func (mgr *Manager) dbConfig(encoreName string) *config.SQLDatabase {
 	for _, d := range mgr.runtime.SQLDatabases {
 		if d.EncoreName == encoreName {
-			db = d
-			break
+			return d
 		}
 	}
+	return nil
+}
+
+// getPool returns a database connection pool for the given database name.
+// Each time it's called it returns a new pool.
+func (mgr *Manager) getPool(encoreName, dbNameOverride string) (pool *pgxpool.Pool, found bool) {
+	db := mgr.dbConfig(encoreName)
 	if db == nil {
 		return nil, false
 	}
@@ -90,7 +109,7 @@ func (mgr *Manager) getPool(encoreName, dbNameOverride string) (pool *pgxpool.Po
 		panic("sqldb: " + err.Error())
 	}
 
-	cfg.ConnConfig.Tracer = &pgxTracer{mgr: mgr}
+	cfg.ConnConfig.Tracer = &pgxTracer{mgr: mgr, db: encoreName}
 	pool, err = pgxpool.NewWithConfig(context.Background(), cfg)
 	if err != nil {
 		panic("sqldb: setup db: " + err.Error())
@@ -12,6 +12,7 @@ import (
 
 type pgxTracer struct {
 	mgr *Manager
+	db  string // the encore name of the database
 }
 
 type ctxKey string
@@ -22,12 +23,21 @@ const (
 	// pgxAlreadyTracedKey is a context key that indicates
 	// that the query is already traced through the sqldb integration.
 	pgxAlreadyTracedKey ctxKey = "pgx_query"
+
+	// pgxNoCaptureKey is a context key that indicates that the query
+	// is not run on behalf of the application, like transaction control
+	// statements, and should not be captured by tests.
+	pgxNoCaptureKey ctxKey = "pgx_no_capture"
 )
 
 func markTraced(ctx context.Context) context.Context {
 	return context.WithValue(ctx, pgxAlreadyTracedKey, true)
 }
 
+func markNoCapture(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pgxNoCaptureKey, true)
+}
+
 type queryValue struct {
 	trace       trace2.Logger
 	eventParams trace2.EventParams
@@ -35,11 +45,15 @@ type queryValue struct {
 }
 
 func (t *pgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	curr := t.mgr.rt.Current()
+	if ctx.Value(pgxNoCaptureKey) == nil {
+		curr.Req.CaptureOp(model.CapturedOp{Kind: model.CapturedDBQuery, DB: t.db, Query: data.SQL})
+	}
+
 	if ctx.Value(pgxAlreadyTracedKey) != nil {
 		return ctx
 	}
 
-	curr := t.mgr.rt.Current()
 	if curr.Req != nil && curr.Trace != nil {
 		eventParams := trace2.EventParams{
 			TraceID: curr.Req.TraceID,
@@ -47,7 +47,7 @@ func (tx *Tx) Commit() error { return tx.commit() }
 func (tx *Tx) Rollback() error { return tx.rollback() }
 
 func (tx *Tx) commit() error {
-	err := tx.std.Commit(markTraced(context.Background()))
+	err := tx.std.Commit(markNoCapture(markTraced(context.Background())))
 	err = convertErr(err)
 
 	if curr := tx.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
@@ -69,7 +69,7 @@ func (tx *Tx) commit() error {
 }
 
 func (tx *Tx) rollback() error {
-	err := tx.std.Rollback(markTraced(context.Background()))
+	err := tx.std.Rollback(markNoCapture(markTraced(context.Background())))
 	err = convertErr(err)
 
 	if curr := tx.mgr.rt.Current(); curr.Req != nil && curr.Trace != nil {
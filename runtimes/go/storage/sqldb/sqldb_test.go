@@ -1,11 +1,19 @@
 package sqldb
 
 import (
+	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 	_ "unsafe" // for go:linkname
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5"
+
 	"encore.dev/appruntime/exported/config"
 )
 
@@ -91,3 +99,143 @@ func TestDBConf(t *testing.T) {
 		}
 	}
 }
+
+func TestDBConfIAMAuth(t *testing.T) {
+	srv := &config.SQLServer{Host: "db.example.com"}
+	db := &config.SQLDatabase{
+		EncoreName:   "ignore",
+		DatabaseName: "dbname",
+		User:         "user",
+		IAMAuth:      &config.SQLIAMAuth{AWS: &config.AWSRDSIAMAuth{Region: "us-east-1"}},
+	}
+	cfg, err := dbConf(srv, db, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if cfg.BeforeConnect == nil {
+		t.Fatalf("got no BeforeConnect hook")
+	} else if cfg.ConnConfig.Password != "" {
+		t.Fatalf("got password %q, want none", cfg.ConnConfig.Password)
+	} else if cfg.ConnConfig.TLSConfig == nil {
+		t.Fatalf("got a connection without TLS, want TLS required")
+	}
+	for _, fallback := range cfg.ConnConfig.Fallbacks {
+		if fallback.TLSConfig == nil {
+			t.Fatalf("got a fallback without TLS, want TLS required")
+		}
+	}
+
+	for _, auth := range []*config.SQLIAMAuth{
+		{},
+		{AWS: &config.AWSRDSIAMAuth{}, GCP: &config.GCPCloudSQLIAMAuth{}},
+	} {
+		db.IAMAuth = auth
+		if _, err := dbConf(srv, db, ""); err == nil {
+			t.Fatalf("got no error for IAM auth config %+v", auth)
+		}
+	}
+}
+
+func TestDBConfPasswordSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	srv := &config.SQLServer{Host: "hostname"}
+	db := &config.SQLDatabase{
+		EncoreName:     "ignore",
+		DatabaseName:   "dbname",
+		User:           "user",
+		Password:       "ignored",
+		PasswordSource: &config.SecretSource{File: path},
+	}
+	cfg, err := dbConf(srv, db, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if cfg.ConnConfig.Password != "" {
+		t.Fatalf("got password %q, want none", cfg.ConnConfig.Password)
+	}
+
+	// The password is re-read for each connection, picking up rotated passwords.
+	for _, password := range []string{"first", "second"} {
+		if err := os.WriteFile(path, []byte(password), 0600); err != nil {
+			t.Fatal(err)
+		}
+		connCfg := cfg.ConnConfig.Copy()
+		if err := cfg.BeforeConnect(context.Background(), connCfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if connCfg.Password != password {
+			t.Fatalf("got password %q, want %q", connCfg.Password, password)
+		}
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.BeforeConnect(context.Background(), cfg.ConnConfig.Copy()); err == nil {
+		t.Fatalf("got no error for a missing password file")
+	}
+}
+
+func TestIAMTokenSource(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	fetches := 0
+	src := &iamTokenSource{
+		now: func() time.Time { return now },
+		fetch: func(ctx context.Context) (string, time.Time, error) {
+			fetches++
+			return fmt.Sprintf("token-%d", fetches), now.Add(15 * time.Minute), nil
+		},
+	}
+
+	tests := []struct {
+		Elapsed time.Duration
+		Token   string
+	}{
+		{0, "token-1"},
+		{9 * time.Minute, "token-1"},
+		{10 * time.Minute, "token-2"}, // within the refresh margin of the expiry
+		{19 * time.Minute, "token-2"},
+		{20 * time.Minute, "token-3"},
+	}
+	start := now
+	for i, test := range tests {
+		now = start.Add(test.Elapsed)
+		cfg := &pgx.ConnConfig{}
+		if err := src.beforeConnect(context.Background(), cfg); err != nil {
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		} else if cfg.Password != test.Token {
+			t.Fatalf("test %d: got password %q, want %q", i, cfg.Password, test.Token)
+		}
+	}
+}
+
+func TestBuildRDSAuthToken(t *testing.T) {
+	creds := aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}
+	signedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	token, err := buildRDSAuthToken(context.Background(), "db.example.com:5432", "eu-west-1", "app user", creds, signedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	host, query, ok := strings.Cut(token, "/?")
+	if !ok || host != "db.example.com:5432" {
+		t.Fatalf("got token %q, want it to start with the endpoint", token)
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatalf("invalid token query: %v", err)
+	}
+	want := map[string]string{
+		"Action":           "connect",
+		"DBUser":           "app user",
+		"X-Amz-Algorithm":  "AWS4-HMAC-SHA256",
+		"X-Amz-Credential": "AKIDEXAMPLE/20240102/eu-west-1/rds-db/aws4_request",
+		"X-Amz-Date":       "20240102T030405Z",
+		"X-Amz-Expires":    "900",
+	}
+	for key, val := range want {
+		if got := values.Get(key); got != val {
+			t.Fatalf("got %s=%q, want %q", key, got, val)
+		}
+	}
+	if values.Get("X-Amz-Signature") == "" {
+		t.Fatalf("got token %q without a signature", token)
+	}
+}
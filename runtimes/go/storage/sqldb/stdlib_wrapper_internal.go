@@ -201,7 +201,7 @@ func (i *interceptor) ConnBegin(tx driver.Tx) (driver.Tx, error) {
 }
 
 func (i *interceptor) ConnBeginTx(ctx context.Context, conn driver.ConnBeginTx, opts driver.TxOptions) (driver.Tx, error) {
-	tx, err := conn.BeginTx(markTraced(ctx), opts)
+	tx, err := conn.BeginTx(markNoCapture(markTraced(ctx)), opts)
 	if err != nil {
 		return nil, err
 	}
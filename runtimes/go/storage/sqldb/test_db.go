@@ -39,3 +39,58 @@ func (mgr *Manager) NewTestDatabase(ctx context.Context, name string) (*Database
 	})
 	return clone, nil
 }
+
+// packageDBInfix separates the database name from the unique suffix of the databases
+// created for each test binary. The Encore daemon drops the databases with this infix
+// when it recreates the test databases, so it must be kept in sync with the daemon.
+const packageDBInfix = "_pkg_"
+
+// packageDBAppNamePrefix prefixes the application_name of the connection each test binary
+// holds for as long as it runs, followed by the unique suffix of its database. Once a test
+// run completes, the Encore daemon drops the databases without such a connection, as their
+// test binary has exited. It must be kept in sync with the daemon.
+const packageDBAppNamePrefix = "encore-test-db:"
+
+// createPackageDB creates the database used by the current test binary, cloning the
+// template database that has had all migrations applied to it. This isolates the tests
+// of different packages, which run in parallel, from one another.
+//
+// It returns the name of the created database, or "" if it couldn't be created,
+// in which case the tests use the database shared by all test binaries.
+func (mgr *Manager) createPackageDB(encoreName string) (dbName string) {
+	cfg := mgr.dbConfig(encoreName)
+	pool, found := mgr.getPool(encoreName, "")
+	if cfg == nil || !found {
+		return ""
+	}
+
+	fail := func(err error) string {
+		mgr.rt.Logger().Warn().Err(err).Str("db", encoreName).Msg("sqldb: unable to create test database, using shared database")
+		pool.Close()
+		return ""
+	}
+
+	// The connection is never released, so it's closed when the test binary exits.
+	// It's tagged before the database is created so it's never dropped while in use.
+	ctx := markNoCapture(context.Background())
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return fail(err)
+	}
+
+	suffix := xid.New().String()
+	dbName = cfg.DatabaseName + packageDBInfix + suffix
+	templateName := cfg.DatabaseName + "_template"
+	if _, err := conn.Exec(ctx, "SELECT set_config('application_name', $1, false)", packageDBAppNamePrefix+suffix); err != nil {
+		conn.Release()
+		return fail(err)
+	}
+	if _, err := conn.Exec(ctx, fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s",
+		pgx.Identifier{dbName}.Sanitize(),
+		pgx.Identifier{templateName}.Sanitize(),
+	)); err != nil {
+		conn.Release()
+		return fail(err)
+	}
+	return dbName
+}
@@ -18,6 +18,6 @@ import (
 var Singleton *Manager
 
 func init() {
-	Singleton = NewManager(appconf.Runtime, reqtrack.Singleton, testsupport.Singleton)
+	Singleton = NewManager(appconf.Static, appconf.Runtime, reqtrack.Singleton, testsupport.Singleton)
 	shutdown.Singleton.RegisterShutdownHandler(Singleton.Shutdown)
 }
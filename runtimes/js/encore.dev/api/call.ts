@@ -0,0 +1,97 @@
+import type { DurationString } from "../internal/types/mod";
+
+/**
+ * Options for calling an endpoint of another service.
+ *
+ * They're passed as the last argument when calling the endpoint,
+ * or set for all calls made through a client created with `withOptions`:
+ *
+ *     import { users } from "~encore/clients";
+ *
+ *     const client = users.withOptions({ timeout: "2s" });
+ *     const user = await client.get({ id }, { retry: { maxAttempts: 5 } });
+ */
+export interface CallOptions {
+  /**
+   * The maximum time each attempt at the call may take,
+   * after which it fails with a `DeadlineExceeded` error.
+   *
+   * Defaults to no timeout if not specified.
+   */
+  timeout?: DurationString;
+
+  /**
+   * Retries calls that fail because the service is unavailable
+   * or the attempt timed out.
+   *
+   * Only calls to endpoints with idempotent HTTP methods
+   * (GET, HEAD, PUT, DELETE and OPTIONS) are retried,
+   * since other calls may have had an effect even though they failed.
+   *
+   * Defaults to no retries if not specified.
+   */
+  retry?: RetryPolicy;
+
+  /**
+   * Fails calls to the service immediately with an `Unavailable` error
+   * after repeated failures, giving it time to recover.
+   *
+   * The breaker is shared by all calls to the service
+   * made with a circuit breaker configured.
+   */
+  circuitBreaker?: CircuitBreakerOptions;
+}
+
+/** Describes how to retry a failed call. */
+export interface RetryPolicy {
+  /**
+   * The maximum number of attempts, including the first one.
+   *
+   * Defaults to 3 if not specified.
+   */
+  maxAttempts?: number;
+
+  /**
+   * The maximum backoff before the first retry, doubling for
+   * each retry after that. The actual backoff is a random
+   * duration up to this limit, to spread out the retries.
+   *
+   * Defaults to 100ms if not specified.
+   */
+  minBackoff?: DurationString;
+
+  /**
+   * The maximum backoff between attempts.
+   *
+   * Defaults to 2s if not specified.
+   */
+  maxBackoff?: DurationString;
+}
+
+/** Configures the circuit breaker of a service. */
+export interface CircuitBreakerOptions {
+  /**
+   * The number of consecutive failed calls after which the breaker opens.
+   *
+   * Defaults to 5 if not specified.
+   */
+  failureThreshold?: number;
+
+  /**
+   * How long the breaker stays open before letting a trial call through,
+   * which closes the breaker if it succeeds.
+   *
+   * Defaults to 30s if not specified.
+   */
+  resetTimeout?: DurationString;
+}
+
+/**
+ * The type of the client function of an endpoint with the handler type F,
+ * which takes call options as an optional last argument.
+ */
+export type WithCallOptions<F> = F extends () => Promise<infer Resp>
+  ? (opts?: CallOptions) => Promise<Resp>
+  : F extends (params: infer Params) => Promise<infer Resp>
+    ? (params: Params, opts?: CallOptions) => Promise<Resp>
+    : F;
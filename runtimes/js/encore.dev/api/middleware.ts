@@ -0,0 +1,137 @@
+import type { RequestMeta } from "../req_meta";
+
+/** Describes the endpoint a request is for. */
+export interface EndpointInfo {
+  /** The name of the service the endpoint belongs to. */
+  service: string;
+
+  /** The name of the endpoint. */
+  name: string;
+
+  /** The tags of the endpoint. */
+  tags: string[];
+
+  /** Whether the endpoint is publicly accessible. */
+  expose: boolean;
+
+  /** Whether the endpoint requires authentication. */
+  auth: boolean;
+}
+
+/** The request passed through the middleware chain. */
+export interface MiddlewareRequest<Payload = any> {
+  /** The endpoint being called. */
+  readonly endpoint: EndpointInfo;
+
+  /**
+   * The request payload, after it's been validated.
+   * Middleware can pass on a different payload by calling
+   * `next` with a modified request.
+   */
+  payload: Payload;
+
+  /** Information about the request, like its path and headers. */
+  readonly requestMeta: RequestMeta | undefined;
+
+  /** The auth data of the request, or null if it's unauthenticated. */
+  readonly authData: unknown;
+}
+
+/** The response passed back through the middleware chain. */
+export interface MiddlewareResponse<Payload = any> {
+  /** The response payload, as returned by the endpoint. */
+  payload: Payload;
+}
+
+/**
+ * Calls the next middleware in the chain,
+ * or the endpoint itself for the last middleware.
+ *
+ * It throws the error thrown by the endpoint, if any.
+ */
+export type Next<Req = any, Resp = any> = (
+  req: MiddlewareRequest<Req>
+) => Promise<MiddlewareResponse<Resp>>;
+
+export type MiddlewareFn<Req = any, Resp = any> = (
+  req: MiddlewareRequest<Req>,
+  next: Next<Req, Resp>
+) => Promise<MiddlewareResponse<Resp>>;
+
+/**
+ * Selects the endpoints a middleware applies to.
+ *
+ * An endpoint is selected if it matches every field that's set.
+ * For fields taking a list, the endpoint must match one of the values.
+ */
+export interface MiddlewareTarget {
+  /** Endpoints with one of the tags. */
+  tags?: string[];
+
+  /** Endpoints of one of the services. Only useful for global middleware. */
+  services?: string[];
+
+  /**
+   * Endpoints matching one of the patterns, written as "service.endpoint".
+   * Use `*` to match any service or endpoint name, as in "users.*".
+   */
+  endpoints?: string[];
+
+  /** Endpoints that are (or aren't) publicly accessible. */
+  expose?: boolean;
+
+  /** Endpoints that do (or don't) require authentication. */
+  auth?: boolean;
+}
+
+export interface MiddlewareOptions {
+  /**
+   * Whether the middleware applies to the endpoints of all services,
+   * rather than only to the service it's added to.
+   *
+   * Defaults to false if not specified.
+   */
+  global?: boolean;
+
+  /**
+   * The endpoints the middleware applies to.
+   *
+   * Defaults to all endpoints if not specified.
+   */
+  target?: MiddlewareTarget;
+}
+
+/** A middleware, created with `middleware`. */
+export class Middleware {
+  public readonly options: MiddlewareOptions;
+  public readonly fn: MiddlewareFn;
+
+  constructor(options: MiddlewareOptions, fn: MiddlewareFn) {
+    this.options = options;
+    this.fn = fn;
+  }
+}
+
+/**
+ * Creates a middleware, which runs before and after the endpoints it
+ * applies to. Add it to a service with the `middlewares` option of
+ * the service definition.
+ *
+ * Middleware run in order: global middleware first, then the
+ * service's own middleware, each in the order they're listed.
+ */
+export function middleware<Req = any, Resp = any>(
+  fn: MiddlewareFn<Req, Resp>
+): Middleware;
+export function middleware<Req = any, Resp = any>(
+  options: MiddlewareOptions,
+  fn: MiddlewareFn<Req, Resp>
+): Middleware;
+export function middleware(
+  a: MiddlewareOptions | MiddlewareFn,
+  b?: MiddlewareFn
+): Middleware {
+  return typeof a === "function"
+    ? new Middleware({}, a)
+    : new Middleware(a, b!);
+}
@@ -1,7 +1,13 @@
 /* eslint-disable */
 
 import type { IncomingMessage, ServerResponse } from "http";
+import { setMethodOptions } from "../internal/api/decorators";
+import { setEndpointOptions } from "../internal/api/middleware";
+import { setEndpointSchema } from "../internal/api/schema";
+import { markWorkerHandler } from "../internal/workers/mod";
+import type { EndpointSchema, StandardSchemaV1 } from "./schema";
 export { RawRequest, RawResponse } from "../internal/api/node_http";
+export type { EndpointSchema, StandardSchemaV1 } from "./schema";
 
 export type Method =
   | "GET"
@@ -58,6 +64,11 @@ export interface APIOptions {
    */
   auth?: boolean;
 
+  /**
+   * Tags to group the endpoint by, which middleware can target.
+   */
+  tags?: string[];
+
   /**
    * The maximum body size, in bytes. If the request body exceeds this value,
    * Encore stops request processing and returns an error.
@@ -66,6 +77,28 @@ export interface APIOptions {
    * If set to `null`, the body size is unlimited.
    **/
   bodyLimit?: number | null;
+
+  /**
+   * Whether to run the endpoint on a pool of worker threads instead of
+   * the main thread, so CPU-intensive work doesn't block other requests.
+   *
+   * The request and response are passed between threads using the
+   * structured clone algorithm, and the endpoint has no access to
+   * the current request through `currentRequest()`.
+   * Not supported for raw endpoints.
+   *
+   * Defaults to false if not specified.
+   */
+  worker?: boolean;
+
+  /**
+   * Schemas to validate the request and response with,
+   * using a Standard Schema library like Zod.
+   *
+   * When set, the request and response types are derived from the schemas
+   * instead of the handler's signature. Not supported for raw endpoints.
+   */
+  schema?: EndpointSchema;
 }
 
 export interface StreamOptions {
@@ -95,12 +128,88 @@ export interface StreamOptions {
    * Defaults to false if not specified.
    */
   auth?: boolean;
+
+  /**
+   * Limits how many messages are buffered when one side of the stream
+   * sends messages faster than the other side reads them,
+   * so a slow client can't make the buffers grow without bound.
+   *
+   * Defaults to no limits if not specified.
+   */
+  flowControl?: StreamFlowControl;
+}
+
+export interface StreamFlowControl {
+  /**
+   * The maximum number of outgoing messages to buffer for a client
+   * that doesn't read them as fast as they're sent.
+   * When the buffer is full the `slowConsumer` policy applies.
+   *
+   * Defaults to no limit if not specified.
+   */
+  maxBufferedMessages?: number;
+
+  /**
+   * The number of buffered outgoing messages at which `send` waits
+   * for the client to catch up before resolving, so a handler awaiting
+   * `send` produces messages at the pace the client reads them.
+   * Must not be greater than `maxBufferedMessages`.
+   *
+   * If not specified, `send` doesn't wait for the buffer to drain.
+   */
+  highWaterMark?: number;
+
+  /**
+   * What to do when the outgoing buffer is full:
+   * - "wait": `send` waits until there's room in the buffer.
+   * - "drop-newest": the message being sent is dropped.
+   * - "drop-oldest": the oldest buffered message is dropped.
+   * - "disconnect": the stream is closed, and `send` throws.
+   *
+   * Defaults to "wait" if not specified.
+   */
+  slowConsumer?: "wait" | "drop-newest" | "drop-oldest" | "disconnect";
+
+  /**
+   * The maximum number of incoming messages to buffer before
+   * the handler reads them. When the buffer is full Encore stops
+   * reading from the connection until the handler catches up,
+   * which makes the client wait before sending more.
+   *
+   * Defaults to no limit if not specified.
+   */
+  maxBufferedIncomingMessages?: number;
 }
 
 type HandlerFn<Params, Response> = Params extends void
   ? () => Promise<Response>
   : (params: Params) => Promise<Response>;
 
+export function api<
+  Req extends StandardSchemaV1,
+  Resp extends StandardSchemaV1
+>(
+  options: APIOptions & { schema: { request: Req; response: Resp } },
+  fn: (
+    params: StandardSchemaV1.InferOutput<Req>
+  ) =>
+    | Promise<StandardSchemaV1.InferInput<Resp>>
+    | StandardSchemaV1.InferInput<Resp>
+): HandlerFn<
+  StandardSchemaV1.InferInput<Req>,
+  StandardSchemaV1.InferOutput<Resp>
+>;
+
+export function api<
+  Req extends StandardSchemaV1,
+  Response extends object | void = void
+>(
+  options: APIOptions & { schema: { request: Req; response?: undefined } },
+  fn: (
+    params: StandardSchemaV1.InferOutput<Req>
+  ) => Promise<Response> | Response
+): HandlerFn<StandardSchemaV1.InferInput<Req>, Response>;
+
 export function api<
   Params extends object | void = void,
   Response extends object | void = void
@@ -117,6 +226,13 @@ export function api<
   fn: (params: Params) => Response
 ): HandlerFn<Params, Response>;
 export function api(options: APIOptions, fn: any): typeof fn {
+  setEndpointOptions(fn, options);
+  if (options.worker) {
+    markWorkerHandler(fn);
+  }
+  if (options.schema) {
+    setEndpointSchema(fn, options.schema);
+  }
   return fn;
 }
 
@@ -126,6 +242,54 @@ api.raw = function raw(options: APIOptions, fn: RawHandler) {
   return fn;
 };
 
+/** Options for endpoints defined with the method decorators, like `api.get`. */
+export type EndpointDecoratorOptions = Omit<APIOptions, "method" | "path">;
+
+/**
+ * A decorator defining an API endpoint from a method of an exported class.
+ *
+ * It supports both standard decorators and TypeScript's experimental
+ * decorators (`experimentalDecorators` in tsconfig.json).
+ */
+export type EndpointDecorator = (
+  target: any,
+  context: any,
+  descriptor?: PropertyDescriptor
+) => void;
+
+function methodDecorator(method: Method) {
+  return (
+    path: string,
+    options?: EndpointDecoratorOptions
+  ): EndpointDecorator =>
+    (target, _context, descriptor) => {
+      // Standard decorators receive the method itself,
+      // experimental decorators the prototype and the method's descriptor.
+      const fn = descriptor ? descriptor.value : target;
+      setMethodOptions(fn, { ...options, method, path });
+    };
+}
+
+/**
+ * Method decorators defining an API endpoint from a class method,
+ * as an alternative to `api()`:
+ *
+ *   export class Users {
+ *     @api.get("/users/:id", { expose: true })
+ *     async getUser({ id }: { id: string }): Promise<User> { ... }
+ *   }
+ *
+ * The endpoint is named after the method, and the class must be exported
+ * and constructible without arguments.
+ */
+api.get = methodDecorator("GET");
+api.post = methodDecorator("POST");
+api.put = methodDecorator("PUT");
+api.patch = methodDecorator("PATCH");
+api.delete = methodDecorator("DELETE");
+api.head = methodDecorator("HEAD");
+api.options = methodDecorator("OPTIONS");
+
 export interface StreamIn<Request> extends AsyncIterable<Request> {
   recv: () => Promise<Request>;
 }
@@ -235,5 +399,22 @@ api.static = function staticAssets(options: StaticOptions) {
   return new StaticAssets(options);
 };
 
+export type {
+  CallOptions,
+  CircuitBreakerOptions,
+  RetryPolicy,
+  WithCallOptions
+} from "./call";
 export { APIError, ErrCode } from "./error";
 export { Gateway, type GatewayConfig } from "./gateway";
+export {
+  middleware,
+  Middleware,
+  type EndpointInfo,
+  type MiddlewareFn,
+  type MiddlewareOptions,
+  type MiddlewareRequest,
+  type MiddlewareResponse,
+  type MiddlewareTarget,
+  type Next
+} from "./middleware";
@@ -0,0 +1,73 @@
+/**
+ * A schema implementing the Standard Schema interface,
+ * like the schemas of Zod, Valibot and ArkType.
+ *
+ * See https://standardschema.dev.
+ */
+export interface StandardSchemaV1<Input = unknown, Output = Input> {
+  readonly "~standard": StandardSchemaV1.Props<Input, Output>;
+}
+
+export declare namespace StandardSchemaV1 {
+  export interface Props<Input = unknown, Output = Input> {
+    readonly version: 1;
+    readonly vendor: string;
+    readonly validate: (
+      value: unknown
+    ) => Result<Output> | Promise<Result<Output>>;
+    readonly types?: Types<Input, Output> | undefined;
+  }
+
+  export type Result<Output> = SuccessResult<Output> | FailureResult;
+
+  export interface SuccessResult<Output> {
+    readonly value: Output;
+    readonly issues?: undefined;
+  }
+
+  export interface FailureResult {
+    readonly issues: ReadonlyArray<Issue>;
+  }
+
+  export interface Issue {
+    readonly message: string;
+    readonly path?: ReadonlyArray<PropertyKey | PathSegment> | undefined;
+  }
+
+  export interface PathSegment {
+    readonly key: PropertyKey;
+  }
+
+  export interface Types<Input = unknown, Output = Input> {
+    readonly input: Input;
+    readonly output: Output;
+  }
+
+  export type InferInput<Schema extends StandardSchemaV1> = NonNullable<
+    Schema["~standard"]["types"]
+  >["input"];
+
+  export type InferOutput<Schema extends StandardSchemaV1> = NonNullable<
+    Schema["~standard"]["types"]
+  >["output"];
+}
+
+/**
+ * The schemas to validate an endpoint's request and response with.
+ *
+ * Encore derives the endpoint's request and response types from them,
+ * when they are Zod schemas.
+ */
+export interface EndpointSchema {
+  /**
+   * The schema to validate the request with.
+   * Requests that fail validation are rejected with an InvalidArgument error.
+   */
+  request?: StandardSchemaV1;
+
+  /**
+   * The schema to validate the response with.
+   * Responses that fail validation are reported as an Internal error.
+   */
+  response?: StandardSchemaV1;
+}
@@ -0,0 +1,25 @@
+import { registerShutdownHook } from "./internal/lifecycle/mod";
+import type {
+  LifecycleHook,
+  LifecycleHookOptions
+} from "./internal/lifecycle/mod";
+
+export type { LifecycleHook, LifecycleHookOptions };
+
+/**
+ * Hooks into the lifecycle of the running application.
+ */
+export const app = {
+  /**
+   * Registers a function to run when the application shuts down gracefully,
+   * like when it receives a SIGTERM signal or is stopped by `encore run`.
+   * Use it to drain connections and flush buffered data.
+   *
+   * Shutdown hooks run one at a time in the reverse order of registration,
+   * and must complete within the graceful shutdown period, after which
+   * the process exits regardless.
+   */
+  onShutdown(fn: LifecycleHook, opts?: LifecycleHookOptions): void {
+    registerShutdownHook(fn, opts);
+  }
+};
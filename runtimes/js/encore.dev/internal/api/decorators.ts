@@ -0,0 +1,50 @@
+import type { APIOptions } from "../../api/mod";
+import { markWorkerHandler } from "../workers/mod";
+import { setEndpointOptions } from "./middleware";
+import { setEndpointSchema } from "./schema";
+
+// The options of the endpoints defined by decorated methods, keyed by method.
+const methodOptions = new WeakMap<Function, APIOptions>();
+
+/** Records that method defines an endpoint with the given options. */
+export function setMethodOptions(method: Function, options: APIOptions) {
+  methodOptions.set(method, options);
+}
+
+// The controller instances, keyed by class.
+const instances = new WeakMap<Function, object>();
+
+/**
+ * Returns the handler of the endpoint defined by the decorated method name
+ * of the controller class cls.
+ *
+ * The endpoints of a controller share a single instance, created with
+ * no constructor arguments the first time one of them is needed.
+ */
+export function controllerEndpoint(
+  cls: new () => object,
+  name: string
+): (...args: any[]) => unknown {
+  const method = cls.prototype[name];
+  const options =
+    typeof method === "function" ? methodOptions.get(method) : undefined;
+  if (!options) {
+    throw new Error(`${cls.name}.${name} is not an API endpoint`);
+  }
+
+  let instance = instances.get(cls);
+  if (!instance) {
+    instance = new cls();
+    instances.set(cls, instance);
+  }
+
+  const handler = (...args: any[]) => method.apply(instance, args);
+  setEndpointOptions(handler, options);
+  if (options.worker) {
+    markWorkerHandler(handler);
+  }
+  if (options.schema) {
+    setEndpointSchema(handler, options.schema);
+  }
+  return handler;
+}
@@ -0,0 +1,131 @@
+import type {
+  EndpointInfo,
+  Middleware,
+  MiddlewareRequest,
+  MiddlewareResponse,
+  MiddlewareTarget
+} from "../../api/middleware";
+import { currentRequest } from "../../req_meta";
+import { getAuthData } from "../auth/mod";
+
+export interface EndpointOptions {
+  tags?: string[];
+  expose?: boolean;
+  auth?: boolean;
+}
+
+// The options of the endpoints, keyed by handler function.
+const endpointOptions = new WeakMap<Function, EndpointOptions>();
+
+/** Records the options fn's endpoint was defined with. */
+export function setEndpointOptions(fn: Function, options: EndpointOptions) {
+  endpointOptions.set(fn, options);
+}
+
+/** Describes the endpoint implemented by fn. */
+export function endpointInfo(
+  service: string,
+  name: string,
+  fn: Function
+): EndpointInfo {
+  const opts = endpointOptions.get(fn) ?? {};
+  return {
+    service,
+    name,
+    tags: opts.tags ?? [],
+    expose: opts.expose ?? false,
+    auth: opts.auth ?? false
+  };
+}
+
+// The middleware added to each service, keyed by service name.
+// Registering a service's middleware again replaces them,
+// as happens when the application is hot reloaded.
+const serviceMiddleware = new Map<string, Middleware[]>();
+
+/** Registers the middleware added to service. */
+export function registerServiceMiddleware(
+  service: string,
+  middlewares: Middleware[]
+) {
+  serviceMiddleware.set(service, middlewares);
+}
+
+/**
+ * Returns the middleware that apply to ep, in the order they run:
+ * the global middleware, ordered by the name of the service they're added
+ * to, and then ep's service's own middleware, in the order they're listed.
+ */
+export function middlewareFor(ep: EndpointInfo): Middleware[] {
+  const global: Middleware[] = [];
+  const services = [...serviceMiddleware.keys()].sort();
+  for (const svc of services) {
+    for (const mw of serviceMiddleware.get(svc)!) {
+      if (mw.options.global) {
+        global.push(mw);
+      }
+    }
+  }
+  const local = (serviceMiddleware.get(ep.service) ?? []).filter(
+    (mw) => !mw.options.global
+  );
+
+  return [...global, ...local].filter((mw) =>
+    targets(mw.options.target, ep)
+  );
+}
+
+function targets(target: MiddlewareTarget | undefined, ep: EndpointInfo) {
+  if (!target) {
+    return true;
+  }
+  const { tags, services, endpoints, expose, auth } = target;
+  return (
+    (tags === undefined || tags.some((t) => ep.tags.includes(t))) &&
+    (services === undefined || services.includes(ep.service)) &&
+    (endpoints === undefined || endpoints.some((p) => matches(p, ep))) &&
+    (expose === undefined || expose === ep.expose) &&
+    (auth === undefined || auth === ep.auth)
+  );
+}
+
+function matches(pattern: string, ep: EndpointInfo): boolean {
+  const [svc, name] = pattern.split(".", 2);
+  return (
+    (svc === "*" || svc === ep.service) &&
+    (name === undefined || name === "*" || name === ep.name)
+  );
+}
+
+/**
+ * Wraps handler, which takes the request payload and returns the response,
+ * to run the given middleware around it.
+ */
+export function withMiddleware(
+  ep: EndpointInfo,
+  middlewares: Middleware[],
+  handler: (payload: any) => unknown
+): (payload: any) => Promise<unknown> {
+  const call = (
+    i: number,
+    req: MiddlewareRequest
+  ): Promise<MiddlewareResponse> => {
+    if (i === middlewares.length) {
+      return Promise.resolve(handler(req.payload)).then((payload) => ({
+        payload
+      }));
+    }
+    return middlewares[i].fn(req, (req) => call(i + 1, req));
+  };
+
+  return async (payload) => {
+    const req: MiddlewareRequest = {
+      endpoint: ep,
+      payload,
+      requestMeta: currentRequest(),
+      authData: getAuthData()
+    };
+    const resp = await call(0, req);
+    return resp.payload;
+  };
+}
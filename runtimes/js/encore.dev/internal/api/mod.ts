@@ -1,14 +1,23 @@
 import * as runtime from "../runtime/mod";
 import { getCurrentRequest } from "../reqtrack/mod";
+import type { CallOptions } from "../../api/call";
 import { APIError, ErrCode } from "../../api/error";
+import { DurationString, parseDuration } from "../types/mod";
 
 export async function apiCall(
   service: string,
   endpoint: string,
   data: any,
+  opts?: CallOptions,
 ): Promise<any> {
   const source = getCurrentRequest();
-  const resp = await runtime.RT.apiCall(service, endpoint, data, source);
+  const resp = await runtime.RT.apiCall(
+    service,
+    endpoint,
+    data,
+    source,
+    opts && callOpts(opts),
+  );
 
   // Convert any call error to our APIError type.
   // We do this here because NAPI doesn't have great support
@@ -19,3 +28,33 @@ export async function apiCall(
 
   return resp;
 }
+
+/**
+ * Merges the options of a call with the default options of the client
+ * it's made through, with the call's options taking precedence.
+ */
+export function mergeCallOptions(
+  defaults?: CallOptions,
+  opts?: CallOptions,
+): CallOptions | undefined {
+  if (!defaults || !opts) {
+    return opts ?? defaults;
+  }
+  return { ...defaults, ...opts };
+}
+
+function callOpts(opts: CallOptions): runtime.CallOpts {
+  const ms = (d?: DurationString) => (d ? parseDuration(d) : undefined);
+  return {
+    timeoutMs: ms(opts.timeout),
+    retry: opts.retry && {
+      maxAttempts: opts.retry.maxAttempts,
+      minBackoffMs: ms(opts.retry.minBackoff),
+      maxBackoffMs: ms(opts.retry.maxBackoff),
+    },
+    circuitBreaker: opts.circuitBreaker && {
+      failureThreshold: opts.circuitBreaker.failureThreshold,
+      resetTimeoutMs: ms(opts.circuitBreaker.resetTimeout),
+    },
+  };
+}
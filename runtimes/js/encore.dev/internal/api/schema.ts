@@ -0,0 +1,58 @@
+import { APIError } from "../../api/error";
+import type { EndpointSchema, StandardSchemaV1 } from "../../api/schema";
+
+// The schemas of the endpoints that declare them, keyed by handler function.
+const schemas = new WeakMap<Function, EndpointSchema>();
+
+/** Sets the schemas to validate fn's request and response with. */
+export function setEndpointSchema(fn: Function, schema: EndpointSchema) {
+  schemas.set(fn, schema);
+}
+
+/** Returns the schemas to validate fn's request and response with, if any. */
+export function getEndpointSchema(fn: Function): EndpointSchema | undefined {
+  return schemas.get(fn);
+}
+
+/**
+ * Validates the request payload against the schema,
+ * returning the validated value.
+ */
+export async function validateRequest(
+  schema: StandardSchemaV1,
+  payload: unknown
+): Promise<unknown> {
+  const result = await schema["~standard"].validate(payload);
+  if (result.issues) {
+    throw APIError.invalidArgument(
+      `invalid request: ${formatIssues(result.issues)}`
+    );
+  }
+  return result.value;
+}
+
+/**
+ * Validates the response against the schema,
+ * returning the validated value.
+ */
+export async function validateResponse(
+  schema: StandardSchemaV1,
+  resp: unknown
+): Promise<unknown> {
+  const result = await schema["~standard"].validate(resp);
+  if (result.issues) {
+    throw APIError.internal(`invalid response: ${formatIssues(result.issues)}`);
+  }
+  return result.value;
+}
+
+function formatIssues(issues: ReadonlyArray<StandardSchemaV1.Issue>): string {
+  return issues
+    .map((issue) => {
+      const path = issue.path
+        ?.map((seg) => String(typeof seg === "object" ? seg.key : seg))
+        .join(".");
+      return path ? `${path}: ${issue.message}` : issue.message;
+    })
+    .join("; ");
+}
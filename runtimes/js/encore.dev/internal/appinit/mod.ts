@@ -1,12 +1,57 @@
+import { pathToFileURL } from "node:url";
 import { Gateway } from "../../api/gateway";
 import { RawRequest, RawResponse } from "../api/node_http";
+import { listenForHotReloads, swappable } from "../hmr/mod";
+import { handleShutdownSignals, runStartHooks } from "../lifecycle/mod";
+import {
+  endpointInfo,
+  middlewareFor,
+  withMiddleware
+} from "../api/middleware";
+import {
+  getEndpointSchema,
+  validateRequest,
+  validateResponse
+} from "../api/schema";
 import { setCurrentRequest } from "../reqtrack/mod";
 import * as runtime from "../runtime/mod";
+import { installSourceMapSupport } from "../sourcemaps/mod";
+import {
+  endpointKey,
+  isPoolWorker,
+  isWorkerHandler,
+  registerWorkerHandler,
+  restartWorkerPool,
+  runOnWorker,
+  serveWorkerTasks,
+  startWorkerPool
+} from "../workers/mod";
 
 export type Handler = runtime.ApiRoute;
 
+installSourceMapSupport();
+
 export function registerHandlers(handlers: Handler[]) {
-  runtime.RT.registerHandlers(handlers.map((h) => transformHandler(h)));
+  if (isPoolWorker) {
+    // Worker pool threads only run the handlers dispatched by the main thread.
+    for (const h of handlers) {
+      if (isWorkerHandler(h.handler)) {
+        registerWorkerHandler(endpointKey(h.service, h.name), h.handler);
+      }
+    }
+    return;
+  }
+
+  // The runtime calls the handlers through swappable functions,
+  // so hot reloading the application swaps in the new implementations.
+  const routes: Handler[] = [];
+  for (const h of handlers.map((h) => transformHandler(h))) {
+    const handler = swappable(endpointKey(h.service, h.name), h.handler);
+    if (handler) {
+      routes.push({ ...h, handler });
+    }
+  }
+  runtime.RT.registerHandlers(routes);
 }
 
 export function registerTestHandler(handler: Handler) {
@@ -18,7 +63,40 @@ export function registerGateways(gateways: Gateway[]) {
   // It intentionally doesn't need to do anything.
 }
 
-export async function run() {
+let running = false;
+
+// The start hooks run by the latest hot reload, which it waits for.
+let reloaded: Promise<void> = Promise.resolve();
+
+// entrypoint is the URL of the application entrypoint,
+// which the worker pool threads run and hot reloads import.
+// services are the services the entrypoint hosts, or undefined for all.
+export async function run(entrypoint?: string, services?: string[]) {
+  if (isPoolWorker) {
+    await runStartHooks(services);
+    return serveWorkerTasks();
+  }
+  if (running) {
+    // The application is being hot reloaded into the running process,
+    // so only the start hooks of the new code need to run.
+    // The hot reload reports their failure.
+    reloaded = runStartHooks(services);
+    reloaded.catch(() => {});
+    return;
+  }
+  running = true;
+
+  await runStartHooks(services);
+  handleShutdownSignals();
+
+  const url = entrypoint
+    ? new URL(entrypoint)
+    : pathToFileURL(process.argv[1]);
+  startWorkerPool(url);
+  listenForHotReloads(url, async () => {
+    await reloaded;
+    restartWorkerPool();
+  });
   return runtime.RT.runForever();
 }
 
@@ -51,7 +129,7 @@ class IterableSocket {
     this.socket = socket;
   }
 
-  send(msg: Record<string, any>): void {
+  send(msg: Record<string, any>): Promise<void> {
     return this.socket.send(msg);
   }
   recv(): Promise<Record<string, any>> {
@@ -114,12 +192,37 @@ function transformHandler(h: Handler): Handler {
       }
     };
   }
+  let invoke = (payload: any): unknown =>
+    payload !== null ? h.handler(payload) : h.handler();
+  if (isWorkerHandler(h.handler)) {
+    const key = endpointKey(h.service, h.name);
+    registerWorkerHandler(key, h.handler);
+    invoke = (payload) => runOnWorker(key, payload);
+  }
+
+  const ep = endpointInfo(h.service, h.name, h.handler);
+  const middlewares = middlewareFor(ep);
+  if (middlewares.length > 0) {
+    invoke = withMiddleware(ep, middlewares, invoke);
+  }
+
+  const schema = getEndpointSchema(h.handler);
+  if (schema) {
+    const inner = invoke;
+    invoke = async (payload) => {
+      if (schema.request) {
+        payload = await validateRequest(schema.request, payload);
+      }
+      const resp = await inner(payload);
+      return schema.response ? validateResponse(schema.response, resp) : resp;
+    };
+  }
+
   return {
     ...h,
     handler: (req: runtime.Request) => {
       setCurrentRequest(req);
-      const payload = req.payload();
-      return payload !== null ? h.handler(payload) : h.handler();
+      return invoke(req.payload());
     }
   };
 }
@@ -1 +1 @@
-export {apiCall} from "../api/mod";
\ No newline at end of file
+export {apiCall, mergeCallOptions} from "../api/mod";
\ No newline at end of file
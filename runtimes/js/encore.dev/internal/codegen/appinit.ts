@@ -1 +1 @@
-export {registerGateways, registerHandlers, registerTestHandler, run, type Handler} from "../appinit/mod";
\ No newline at end of file
+export {registerGateways, registerHandlers, registerTestHandler, run, type Handler} from "../appinit/mod";export { controllerEndpoint } from "../api/decorators";
@@ -0,0 +1,123 @@
+import fs from "node:fs";
+import { createRequire } from "node:module";
+import net from "node:net";
+import readline from "node:readline";
+import { fileURLToPath } from "node:url";
+
+// The implementations of the swappable functions, keyed by name.
+const current = new Map<string, (...args: any[]) => any>();
+
+/**
+ * Sets fn as the current implementation of the function named key.
+ *
+ * The first time key is seen it returns a function that forwards
+ * to the current implementation, which the caller should register with
+ * the runtime. When the application is hot reloaded the same key is seen
+ * again, and it returns undefined since the function registered
+ * with the runtime already forwards to the new implementation.
+ */
+export function swappable<F extends (...args: any[]) => any>(
+  key: string,
+  fn: F
+): F | undefined {
+  const seen = current.has(key);
+  current.set(key, fn);
+  if (seen) {
+    return undefined;
+  }
+  return ((...args: any[]) => current.get(key)!(...args)) as F;
+}
+
+// The number of times the application has been hot reloaded.
+let reloads = 0;
+
+// The environment variable with the file descriptors to receive
+// hot reload commands on and to reply to, as "<read fd>,<write fd>".
+const hmrFdsEnv = "ENCORE_HMR_FDS";
+
+interface Command {
+  type: "reload";
+}
+
+interface Reply {
+  ok: boolean;
+  error?: string;
+}
+
+/**
+ * Listens for hot reload commands from the Encore daemon,
+ * when running in development with hot reloading enabled,
+ * which apps opt in to with the hot_reload watch setting in encore.app.
+ *
+ * On each command the entrypoint is imported again, under a new URL so it's
+ * evaluated anew, which registers the new implementations of the handlers
+ * with the functions already registered with the runtime. Infrastructure
+ * resources like database pools are kept by the runtime and reused.
+ * onReload is called after each reload, which fails if it throws.
+ *
+ * Evaluating the modules anew runs their top-level code again, so anything
+ * they create there, like clients and timers, is created once more while the
+ * previous instances are left running. This is why it's opt-in.
+ *
+ * Each reload keeps the previous module graph in memory,
+ * which is acceptable since it's only used in development.
+ */
+export function listenForHotReloads(
+  entrypoint: URL,
+  onReload: () => void | Promise<void>
+) {
+  const fds = process.env[hmrFdsEnv];
+  if (!fds) {
+    return;
+  }
+  const [readFd, writeFd] = fds.split(",").map((fd) => parseInt(fd, 10));
+  if (isNaN(readFd) || isNaN(writeFd)) {
+    throw new Error(`invalid ${hmrFdsEnv} ${JSON.stringify(fds)}`);
+  }
+
+  const input = new net.Socket({ fd: readFd, readable: true });
+  // Don't keep the process alive just to listen for reloads.
+  input.unref();
+  const reply = (r: Reply) => fs.writeSync(writeFd, JSON.stringify(r) + "\n");
+
+  // Handle one command at a time, in order.
+  let pending = Promise.resolve();
+  readline.createInterface({ input }).on("line", (line) => {
+    pending = pending.then(async () => {
+      let cmd: Command;
+      try {
+        cmd = JSON.parse(line);
+      } catch (err) {
+        reply({ ok: false, error: `invalid command: ${err}` });
+        return;
+      }
+      if (cmd.type !== "reload") {
+        reply({ ok: false, error: `unknown command ${cmd.type}` });
+        return;
+      }
+
+      try {
+        reloads++;
+        if (entrypoint.pathname.endsWith(".cjs")) {
+          // CommonJS modules are cached by file name rather than by URL,
+          // so evict the entrypoint from the cache to evaluate it anew.
+          const require = createRequire(entrypoint);
+          const file = fileURLToPath(entrypoint);
+          delete require.cache[file];
+          require(file);
+        } else {
+          const url = new URL(entrypoint);
+          url.searchParams.set("hmr", String(reloads));
+          await import(url.href);
+        }
+        await onReload();
+        reply({ ok: true });
+      } catch (err) {
+        reply({
+          ok: false,
+          error: err instanceof Error ? err.stack ?? err.message : String(err)
+        });
+      }
+    });
+  });
+}
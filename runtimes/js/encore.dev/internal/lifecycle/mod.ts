@@ -0,0 +1,192 @@
+import log from "../../log/mod";
+import * as runtime from "../runtime/mod";
+import { DurationString, parseDuration } from "../types/mod";
+
+/**
+ * A function run when the application starts or shuts down.
+ *
+ * The signal is aborted when the hook runs out of time,
+ * after which the application stops waiting for it.
+ */
+export type LifecycleHook = (signal: AbortSignal) => void | Promise<void>;
+
+/** Options for a lifecycle hook. */
+export interface LifecycleHookOptions {
+  /**
+   * The maximum time the hook may take.
+   *
+   * Defaults to 30 seconds for start hooks. Shutdown hooks are
+   * always bounded by the time remaining of the graceful shutdown.
+   */
+  timeout?: DurationString;
+}
+
+interface Hook {
+  service?: string;
+  desc: string;
+  fn: LifecycleHook;
+  timeoutMs?: number;
+}
+
+const defaultStartTimeoutMs = 30_000;
+
+// The start hooks that haven't run yet, in registration order.
+const startHooks: Hook[] = [];
+
+// The shutdown hooks, in registration order.
+const shutdownHooks: Hook[] = [];
+
+/** Registers a hook to run when the service starts. */
+export function registerStartHook(
+  service: string,
+  fn: LifecycleHook,
+  opts?: LifecycleHookOptions
+) {
+  startHooks.push({
+    service,
+    desc: `start hook of service ${service}`,
+    fn,
+    timeoutMs: opts?.timeout ? parseDuration(opts.timeout) : undefined
+  });
+}
+
+/** Registers a hook to run when the application shuts down. */
+export function registerShutdownHook(
+  fn: LifecycleHook,
+  opts?: LifecycleHookOptions
+) {
+  shutdownHooks.push({
+    desc: "shutdown hook",
+    fn,
+    timeoutMs: opts?.timeout ? parseDuration(opts.timeout) : undefined
+  });
+  if (handlingSignals) {
+    installSignalHandlers();
+  }
+}
+
+/**
+ * Runs the start hooks registered since the last call, in registration order.
+ * It throws if a hook fails or runs out of time, which prevents the
+ * application from starting.
+ *
+ * If services is given, only the start hooks of those services run,
+ * since the process doesn't host the others.
+ */
+export async function runStartHooks(services?: string[]) {
+  for (const hook of startHooks.splice(0)) {
+    if (services && !services.includes(hook.service!)) {
+      continue;
+    }
+    await runHook(hook, hook.timeoutMs ?? defaultStartTimeoutMs);
+  }
+}
+
+// The graceful shutdown timings used unless configured otherwise,
+// matching the defaults of the Go runtime.
+const defaultShutdownTotalMs = 5_000;
+const defaultShutdownHooksMarginMs = 1_000;
+
+let shuttingDown = false;
+let handlingSignals = false;
+let installed = false;
+
+/**
+ * Gracefully shuts down the application when it receives
+ * a SIGTERM or SIGINT signal, like when it's stopped by
+ * the orchestrator or by `encore run`.
+ *
+ * The signals are only handled once a shutdown hook is registered,
+ * including by a later hot reload. Until then they keep their
+ * default behavior of terminating the process.
+ */
+export function handleShutdownSignals() {
+  handlingSignals = true;
+  if (shutdownHooks.length > 0) {
+    installSignalHandlers();
+  }
+}
+
+function installSignalHandlers() {
+  if (installed) {
+    return;
+  }
+  installed = true;
+  for (const signal of ["SIGTERM", "SIGINT"] as const) {
+    process.once(signal, () => void shutdown(signal));
+  }
+}
+
+/**
+ * Stops serving requests, waiting for the ones in flight to complete,
+ * and then runs the shutdown hooks in reverse registration order, so hooks
+ * registered later, which may depend on those registered earlier,
+ * run first. Then it exits the process.
+ *
+ * Draining and the hooks must complete before the shutdown hooks deadline,
+ * which is the configured margin before the total graceful shutdown time
+ * runs out. The process exits when the total time has run out regardless.
+ */
+async function shutdown(signal: NodeJS.Signals) {
+  if (shuttingDown) {
+    return;
+  }
+  shuttingDown = true;
+
+  const timings = runtime.RT.gracefulShutdown();
+  const totalMs = timings.totalMs ?? defaultShutdownTotalMs;
+  const marginMs = timings.shutdownHooksMs ?? defaultShutdownHooksMarginMs;
+  setTimeout(() => process.exit(0), totalMs).unref();
+
+  const deadline = Date.now() + Math.max(0, totalMs - marginMs);
+
+  let timer: NodeJS.Timeout | undefined;
+  await Promise.race([
+    runtime.RT.drain(),
+    new Promise<void>((resolve) => {
+      timer = setTimeout(resolve, Math.max(0, deadline - Date.now()));
+    })
+  ]);
+  clearTimeout(timer);
+
+  for (const hook of shutdownHooks.slice().reverse()) {
+    const remaining = deadline - Date.now();
+    if (remaining <= 0) {
+      log.warn("graceful shutdown deadline exceeded, skipping shutdown hooks");
+      break;
+    }
+
+    try {
+      await runHook(hook, Math.min(hook.timeoutMs ?? remaining, remaining));
+    } catch (err) {
+      log.error(err, "shutdown hook failed", { signal });
+    }
+  }
+  process.exit(0);
+}
+
+async function runHook(hook: Hook, timeoutMs: number) {
+  const ctrl = new AbortController();
+  let timer: NodeJS.Timeout | undefined;
+  const timeout = new Promise<never>((_, reject) => {
+    timer = setTimeout(() => {
+      const err = new Error(`${hook.desc} timed out after ${timeoutMs}ms`);
+      ctrl.abort(err);
+      reject(err);
+    }, timeoutMs);
+  });
+
+  try {
+    await Promise.race([
+      Promise.resolve().then(() => hook.fn(ctrl.signal)),
+      timeout
+    ]);
+  } catch (err) {
+    if (ctrl.signal.aborted) {
+      throw err;
+    }
+    throw new Error(`${hook.desc} failed: ${err}`, { cause: err });
+  } finally {
+    clearTimeout(timer);
+  }
+}
@@ -0,0 +1,90 @@
+import fs from "node:fs";
+import { SourceMap } from "node:module";
+import { fileURLToPath, pathToFileURL } from "node:url";
+
+/**
+ * Maps stack frames back to the original source through the source maps
+ * written next to the bundled code.
+ *
+ * JavaScript runtimes only apply source maps that are linked from the bundle,
+ * so this is only needed when they're emitted as external source maps,
+ * as indicated by the ENCORE_SOURCE_MAPS environment variable.
+ */
+export function installSourceMapSupport() {
+  if (process.env.ENCORE_SOURCE_MAPS !== "external") {
+    return;
+  }
+
+  const maps = new Map<string, LoadedSourceMap | null>();
+  const lookup = (file: string): LoadedSourceMap | null => {
+    let map = maps.get(file);
+    if (map === undefined) {
+      map = loadSourceMap(file);
+      maps.set(file, map);
+    }
+    return map;
+  };
+
+  Error.prepareStackTrace = (err, frames) => {
+    const header = Error.prototype.toString.call(err);
+    return (
+      header +
+      frames.map((frame) => `\n    at ${formatFrame(frame, lookup)}`).join("")
+    );
+  };
+}
+
+interface LoadedSourceMap {
+  map: SourceMap;
+  // The URL of the source map, which its sources are relative to.
+  url: URL;
+}
+
+function loadSourceMap(file: string): LoadedSourceMap | null {
+  try {
+    // File URLs can have a query string, like when the code is hot reloaded.
+    const path = file.startsWith("file://") ? fileURLToPath(file) : file;
+    if (!path.endsWith(".mjs") && !path.endsWith(".js")) {
+      return null;
+    }
+    const payload = JSON.parse(fs.readFileSync(`${path}.map`, "utf8"));
+    return {
+      map: new SourceMap(payload),
+      url: pathToFileURL(`${path}.map`)
+    };
+  } catch {
+    // The file isn't part of the bundle, like code in node_modules.
+    return null;
+  }
+}
+
+function formatFrame(
+  frame: NodeJS.CallSite,
+  lookup: (file: string) => LoadedSourceMap | null
+): string {
+  let fn = frame.getFunctionName();
+  const typeName = frame.getTypeName();
+  if (fn && typeName && !frame.isToplevel() && !frame.isConstructor()) {
+    fn = `${typeName}.${fn}`;
+  }
+  let file = frame.getFileName() ?? "<anonymous>";
+  let line = frame.getLineNumber();
+  let column = frame.getColumnNumber();
+
+  const loaded = line !== null && column !== null ? lookup(file) : null;
+  if (loaded && line !== null && column !== null) {
+    // Source map entries are zero-based, while stack frames are one-based.
+    const entry = loaded.map.findEntry(line - 1, column - 1);
+    if (entry.originalSource) {
+      file = fileURLToPath(new URL(entry.originalSource, loaded.url));
+      line = entry.originalLine + 1;
+      column = entry.originalColumn + 1;
+    }
+  }
+
+  let location = file;
+  if (line !== null) {
+    location += column !== null ? `:${line}:${column}` : `:${line}`;
+  }
+  return fn ? `${fn} (${location})` : location;
+}
@@ -8,3 +8,27 @@ export type DurationString =
   | durationComponent
   | `${durationComponent}${durationComponent}`
   | `${durationComponent} ${durationComponent}`;
+
+const durationUnits: Record<string, number> = {
+  ns: 1e-6,
+  us: 1e-3,
+  µs: 1e-3,
+  ms: 1,
+  s: 1_000,
+  m: 60_000,
+  h: 3_600_000
+};
+
+/** Parses a duration string, returning the duration in milliseconds. */
+export function parseDuration(d: DurationString): number {
+  const re = /(\d+(?:\.\d+)?)\s*(ns|us|µs|ms|s|m|h)\s*/gy;
+  let ms = 0;
+  let match: RegExpExecArray | null;
+  while ((match = re.exec(d)) !== null) {
+    ms += parseFloat(match[1]) * durationUnits[match[2]];
+    if (re.lastIndex === d.length) {
+      return ms;
+    }
+  }
+  throw new Error(`invalid duration ${JSON.stringify(d)}`);
+}
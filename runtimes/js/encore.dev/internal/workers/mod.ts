@@ -0,0 +1,330 @@
+import os from "node:os";
+import {
+  isMainThread,
+  parentPort,
+  Worker,
+  workerData
+} from "node:worker_threads";
+import { APIError, ErrCode } from "../../api/error";
+
+// Marker set in the workerData of worker pool threads.
+const poolMarker = "__encoreWorkerPool";
+
+/**
+ * Whether the current thread is a worker pool thread,
+ * as opposed to the main thread or a thread started by the application.
+ */
+export const isPoolWorker = !isMainThread && workerData?.[poolMarker] === true;
+
+type WorkerHandler = (payload?: any) => unknown;
+
+// Handler functions that should run on the worker pool.
+const marked = new WeakSet<Function>();
+
+// The worker pool handlers that have been registered, keyed by task key.
+const handlers = new Map<string, WorkerHandler>();
+
+/** Marks fn to run on the worker pool. */
+export function markWorkerHandler(fn: Function) {
+  marked.add(fn);
+}
+
+/** Reports whether fn has been marked to run on the worker pool. */
+export function isWorkerHandler(fn: Function): boolean {
+  return marked.has(fn);
+}
+
+/** The task key for an API endpoint. */
+export function endpointKey(service: string, name: string): string {
+  return `endpoint:${service}.${name}`;
+}
+
+/** The task key for a Pub/Sub subscription. */
+export function subscriptionKey(topic: string, subscription: string): string {
+  return `subscription:${topic}/${subscription}`;
+}
+
+/**
+ * Registers a handler to run on the worker pool.
+ *
+ * Every thread registers the same handlers, since every thread
+ * runs the same entrypoint.
+ */
+export function registerWorkerHandler(key: string, handler: WorkerHandler) {
+  handlers.set(key, handler);
+}
+
+/**
+ * Runs the handler registered for key with the given payload on the worker pool.
+ *
+ * The payload and the handler's result are passed between threads
+ * using the structured clone algorithm. If the worker pool isn't running,
+ * like when running tests, the handler runs on the current thread.
+ */
+export function runOnWorker(key: string, payload: unknown): Promise<unknown> {
+  if (pool) {
+    return pool.run(key, payload);
+  }
+  return invoke(key, payload);
+}
+
+let pool: WorkerPool | undefined;
+
+/**
+ * Starts the worker pool if any worker pool handlers have been registered.
+ *
+ * The entrypoint is the URL of the application entrypoint,
+ * which each worker runs to register the handlers.
+ */
+export function startWorkerPool(entrypoint: URL) {
+  if (pool || handlers.size === 0 || !isMainThread) {
+    return;
+  }
+  pool = new WorkerPool(entrypoint, poolSize());
+}
+
+/**
+ * Replaces the worker pool with a new one running the latest application code,
+ * after the application has been hot reloaded.
+ *
+ * The previous workers finish the tasks they've been given before exiting.
+ */
+export function restartWorkerPool() {
+  const prev = pool;
+  if (!prev) {
+    return;
+  }
+  pool = new WorkerPool(prev.entrypoint, poolSize());
+  prev.close();
+}
+
+/**
+ * Serves the tasks sent by the main thread.
+ * It must only be called on a worker pool thread.
+ */
+export function serveWorkerTasks() {
+  if (!isPoolWorker || !parentPort) {
+    throw new Error("serveWorkerTasks called outside of a worker pool thread");
+  }
+  const port = parentPort;
+
+  port.on("message", async (task: Task) => {
+    let reply: TaskResult;
+    try {
+      reply = { id: task.id, result: await invoke(task.key, task.payload) };
+    } catch (err) {
+      reply = { id: task.id, error: serializeError(err) };
+    }
+
+    try {
+      port.postMessage(reply);
+    } catch (err) {
+      // The result couldn't be cloned.
+      port.postMessage({
+        id: task.id,
+        error: serializeError(
+          APIError.internal(
+            `unable to pass the result of ${task.key} between threads: ${err}`
+          )
+        )
+      });
+    }
+  });
+  port.postMessage({ ready: true });
+}
+
+async function invoke(key: string, payload: unknown): Promise<unknown> {
+  const handler = handlers.get(key);
+  if (!handler) {
+    throw APIError.internal(`no worker pool handler registered for ${key}`);
+  }
+  return payload !== null && payload !== undefined
+    ? handler(payload)
+    : handler();
+}
+
+// The environment variable to configure the worker pool size with.
+const poolSizeEnv = "ENCORE_WORKER_POOL_SIZE";
+
+function poolSize(): number {
+  const configured = process.env[poolSizeEnv];
+  if (configured) {
+    const size = parseInt(configured, 10);
+    if (isNaN(size) || size < 1) {
+      throw new Error(`invalid ${poolSizeEnv} ${JSON.stringify(configured)}`);
+    }
+    return size;
+  }
+
+  // Leave one core for the main thread.
+  return Math.max(1, os.availableParallelism() - 1);
+}
+
+interface Task {
+  id: number;
+  key: string;
+  payload: unknown;
+}
+
+type TaskResult =
+  | { id: number; result: unknown }
+  | { id: number; error: SerializedError };
+
+interface PendingTask extends Task {
+  resolve: (result: unknown) => void;
+  reject: (err: Error) => void;
+}
+
+interface PoolWorker {
+  worker: Worker;
+  ready: boolean;
+  current?: PendingTask;
+}
+
+class WorkerPool {
+  private readonly workers: PoolWorker[] = [];
+  private readonly queue: PendingTask[] = [];
+  private nextID = 0;
+  private closed = false;
+
+  constructor(
+    readonly entrypoint: URL,
+    size: number
+  ) {
+    for (let i = 0; i < size; i++) {
+      this.spawn();
+    }
+  }
+
+  run(key: string, payload: unknown): Promise<unknown> {
+    if (this.closed || this.workers.length === 0) {
+      return Promise.reject(APIError.internal("no worker threads available"));
+    }
+    return new Promise((resolve, reject) => {
+      this.queue.push({ id: this.nextID++, key, payload, resolve, reject });
+      this.schedule();
+    });
+  }
+
+  /**
+   * Closes the pool once the queued tasks have completed,
+   * without accepting new tasks.
+   */
+  close() {
+    this.closed = true;
+    this.schedule();
+  }
+
+  private spawn() {
+    const w: PoolWorker = {
+      worker: new Worker(this.entrypoint, {
+        workerData: { [poolMarker]: true }
+      }),
+      ready: false
+    };
+    // Don't keep the process alive just for the worker pool.
+    w.worker.unref();
+
+    w.worker.on("message", (msg: { ready: true } | TaskResult) => {
+      if ("ready" in msg) {
+        w.ready = true;
+      } else if (w.current?.id === msg.id) {
+        const task = w.current;
+        w.current = undefined;
+        if ("error" in msg) {
+          task.reject(deserializeError(msg.error));
+        } else {
+          task.resolve(msg.result);
+        }
+      }
+      this.schedule();
+    });
+
+    w.worker.on("error", (err) => {
+      w.current?.reject(
+        APIError.internal(`worker thread failed: ${err.message}`, err)
+      );
+      w.current = undefined;
+    });
+
+    w.worker.on("exit", () => {
+      w.current?.reject(APIError.internal("worker thread exited unexpectedly"));
+      w.current = undefined;
+
+      const idx = this.workers.indexOf(w);
+      if (idx !== -1) {
+        this.workers.splice(idx, 1);
+      }
+
+      // A worker that exits before it's ready failed to load the entrypoint,
+      // which its replacement would too.
+      if (w.ready && !this.closed) {
+        this.spawn();
+      } else if (this.workers.length === 0) {
+        for (const task of this.queue.splice(0)) {
+          task.reject(APIError.internal("no worker threads available"));
+        }
+      }
+    });
+
+    this.workers.push(w);
+  }
+
+  private schedule() {
+    for (const w of this.workers) {
+      if (this.queue.length === 0) {
+        break;
+      }
+      if (!w.ready || w.current) {
+        continue;
+      }
+
+      const task = this.queue.shift()!;
+      try {
+        w.worker.postMessage({
+          id: task.id,
+          key: task.key,
+          payload: task.payload
+        });
+        w.current = task;
+      } catch (err) {
+        task.reject(
+          APIError.invalidArgument(
+            `unable to pass the payload of ${task.key} between threads: ${err}`
+          )
+        );
+      }
+    }
+
+    if (this.closed && this.queue.length === 0) {
+      for (const w of this.workers) {
+        if (!w.current) {
+          void w.worker.terminate();
+        }
+      }
+    }
+  }
+}
+
+interface SerializedError {
+  message: string;
+  stack?: string;
+  code?: ErrCode;
+}
+
+function serializeError(err: unknown): SerializedError {
+  if (err instanceof APIError) {
+    return { message: err.message, stack: err.stack, code: err.code };
+  } else if (err instanceof Error) {
+    return { message: err.message, stack: err.stack };
+  }
+  return { message: String(err) };
+}
+
+function deserializeError(s: SerializedError): Error {
+  const err = s.code ? new APIError(s.code, s.message) : new Error(s.message);
+  if (s.stack) {
+    err.stack = s.stack;
+  }
+  return err;
+}
@@ -1,3 +1,6 @@
+export { app } from "./app";
+export type { LifecycleHook, LifecycleHookOptions } from "./app";
+
 export { appMeta } from "./app_meta";
 export type {
   AppMeta,
@@ -1,21 +1,44 @@
+import { swappable } from "../internal/hmr/mod";
 import { setCurrentRequest } from "../internal/reqtrack/mod";
 import { DurationString } from "../internal/types/mod";
 import { Topic } from "./topic";
 import * as runtime from "../internal/runtime/mod";
+import {
+  isPoolWorker,
+  registerWorkerHandler,
+  runOnWorker,
+  subscriptionKey
+} from "../internal/workers/mod";
 
 export class Subscription<Msg extends object> {
   private readonly topic: Topic<Msg>;
   private readonly name: string;
-  private readonly impl: runtime.PubSubSubscription;
+  private readonly impl?: runtime.PubSubSubscription;
 
   constructor(topic: Topic<Msg>, name: string, cfg: SubscriptionConfig<Msg>) {
     this.topic = topic;
     this.name = name;
 
-    const handler = (msg: runtime.Request) => {
+    const key = subscriptionKey(topic.name, name);
+    if (cfg.worker) {
+      registerWorkerHandler(key, cfg.handler);
+    }
+    if (isPoolWorker) {
+      // Messages are received by the main thread.
+      return;
+    }
+
+    const handler = swappable(key, (msg: runtime.Request) => {
       setCurrentRequest(msg);
-      return cfg.handler(msg.payload() as Msg);
-    };
+      return cfg.worker
+        ? runOnWorker(key, msg.payload())
+        : cfg.handler(msg.payload() as Msg);
+    });
+    if (!handler) {
+      // The application is being hot reloaded, and the subscription
+      // now calls the new handler.
+      return;
+    }
 
     this.impl = runtime.RT.pubsubSubscription({
       topicName: topic.name,
@@ -28,7 +51,7 @@ export class Subscription<Msg extends object> {
 
   private startSubscribing() {
     const that = this;
-    this.impl.subscribe().finally(() => {
+    this.impl!.subscribe().finally(() => {
       setTimeout(() => that.startSubscribing(), 1000);
     });
   }
@@ -95,6 +118,16 @@ export interface SubscriptionConfig<Msg> {
    * the subscriber returns an error
    */
   retryPolicy?: RetryPolicy;
+
+  /**
+   * Whether to process messages on a pool of worker threads instead of
+   * the main thread, so CPU-intensive work doesn't block other requests.
+   *
+   * Messages are passed to the handler using the structured clone algorithm.
+   *
+   * Defaults to false if not specified.
+   */
+  worker?: boolean;
 }
 
 /**
@@ -1,3 +1,11 @@
+import type { Middleware } from "../api/middleware";
+import { registerServiceMiddleware } from "../internal/api/middleware";
+import { registerStartHook } from "../internal/lifecycle/mod";
+import type {
+  LifecycleHook,
+  LifecycleHookOptions
+} from "../internal/lifecycle/mod";
+
 /**
  * Defines an Encore backend service.
  *
@@ -14,7 +22,27 @@ export class Service {
   constructor(name: string, cfg?: ServiceConfig) {
     this.name = name;
     this.cfg = cfg ?? {};
+    registerServiceMiddleware(name, this.cfg.middlewares ?? []);
+  }
+
+  /**
+   * Registers a function to run when the service starts,
+   * before it begins handling requests. Use it to open connections
+   * and warm caches.
+   *
+   * Start hooks run one at a time in the order they're registered.
+   * If a hook throws or runs out of time the service fails to start.
+   */
+  onStart(fn: LifecycleHook, opts?: LifecycleHookOptions): void {
+    registerStartHook(this.name, fn, opts);
   }
 }
 
-export interface ServiceConfig {}
+export interface ServiceConfig {
+  /**
+   * The middleware to run for the service's endpoints, in order.
+   * Middleware created with `global: true` run for the
+   * endpoints of all services instead.
+   */
+  middlewares?: Middleware[];
+}
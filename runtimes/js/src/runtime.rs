@@ -5,6 +5,7 @@ use crate::meta;
 use crate::pubsub::{PubSubSubscription, PubSubSubscriptionConfig, PubSubTopic};
 use crate::secret::Secret;
 use crate::sqldb::SQLDatabase;
+use encore_runtime_core::api::callopts::{CallOptions, CircuitBreakerConfig, RetryPolicy};
 use encore_runtime_core::api::schema::JSONPayload;
 use encore_runtime_core::pubsub::SubName;
 use encore_runtime_core::EncoreName;
@@ -175,10 +176,16 @@ impl Runtime {
         endpoint: String,
         data: JSONPayload,
         source: Option<&Request>,
+        opts: Option<CallOpts>,
     ) -> Either<JSONPayload, APICallError> {
         let endpoint = encore_runtime_core::EndpointName::new(service, endpoint);
         let source = source.map(|s| s.inner.as_ref());
-        let res = self.runtime.api().call(&endpoint, data, source).await;
+        let opts = opts.map(CallOpts::into_options).unwrap_or_default();
+        let res = self
+            .runtime
+            .api()
+            .call(&endpoint, data, source, &opts)
+            .await;
 
         match res {
             Ok(data) => Either::A(data),
@@ -206,6 +213,87 @@ impl Runtime {
         let md = self.runtime.app_meta();
         md.clone().into()
     }
+
+    /// Stops accepting new requests and waits for the ones in flight to complete,
+    /// for gracefully shutting down the application.
+    #[napi]
+    pub async fn drain(&self) {
+        let runtime = self.runtime.clone();
+        runtime.drain().await;
+    }
+
+    /// Returns the graceful shutdown timings configured for the deployment.
+    #[napi]
+    pub fn graceful_shutdown(&self) -> GracefulShutdownTimings {
+        let cfg = self.runtime.graceful_shutdown();
+        GracefulShutdownTimings {
+            total_ms: cfg.total.as_ref().map(duration_ms),
+            shutdown_hooks_ms: cfg.shutdown_hooks.as_ref().map(duration_ms),
+        }
+    }
+}
+
+#[napi(object)]
+pub struct GracefulShutdownTimings {
+    /// How long the shutdown may take in total before the process exits.
+    pub total_ms: Option<f64>,
+    /// How long before the total runs out that the shutdown hooks are aborted.
+    pub shutdown_hooks_ms: Option<f64>,
+}
+
+fn duration_ms(d: &prost_types::Duration) -> f64 {
+    d.seconds as f64 * 1000.0 + d.nanos as f64 / 1_000_000.0
+}
+
+/// Options for an API call to another service.
+#[napi(object)]
+pub struct CallOpts {
+    /// The maximum time each attempt may take.
+    pub timeout_ms: Option<f64>,
+    pub retry: Option<RetryOpts>,
+    pub circuit_breaker: Option<CircuitBreakerOpts>,
+}
+
+#[napi(object)]
+pub struct RetryOpts {
+    pub max_attempts: Option<u32>,
+    pub min_backoff_ms: Option<f64>,
+    pub max_backoff_ms: Option<f64>,
+}
+
+#[napi(object)]
+pub struct CircuitBreakerOpts {
+    pub failure_threshold: Option<u32>,
+    pub reset_timeout_ms: Option<f64>,
+}
+
+impl CallOpts {
+    fn into_options(self) -> CallOptions {
+        CallOptions {
+            timeout: self.timeout_ms.map(ms_duration),
+            retry: self.retry.map(|r| {
+                let default = RetryPolicy::default();
+                RetryPolicy {
+                    max_attempts: r.max_attempts.unwrap_or(default.max_attempts),
+                    min_backoff: r.min_backoff_ms.map_or(default.min_backoff, ms_duration),
+                    max_backoff: r.max_backoff_ms.map_or(default.max_backoff, ms_duration),
+                }
+            }),
+            circuit_breaker: self.circuit_breaker.map(|cb| {
+                let default = CircuitBreakerConfig::default();
+                CircuitBreakerConfig {
+                    failure_threshold: cb.failure_threshold.unwrap_or(default.failure_threshold),
+                    reset_timeout: cb
+                        .reset_timeout_ms
+                        .map_or(default.reset_timeout, ms_duration),
+                }
+            }),
+        }
+    }
+}
+
+fn ms_duration(ms: f64) -> std::time::Duration {
+    std::time::Duration::from_secs_f64(ms.max(0.0) / 1000.0)
 }
 
 #[napi]
@@ -74,9 +74,10 @@ impl Socket {
 
     #[napi]
     #[allow(dead_code)]
-    pub fn send(&self, msg: serde_json::Map<String, serde_json::Value>) -> napi::Result<()> {
+    pub async fn send(&self, msg: serde_json::Map<String, serde_json::Value>) -> napi::Result<()> {
         self.inner
             .send(msg)
+            .await
             .map_err(|e| napi::Error::new(napi::Status::Unknown, e))
     }
 
@@ -110,9 +111,10 @@ impl Sink {
 
     #[napi]
     #[allow(dead_code)]
-    pub fn send(&self, msg: serde_json::Map<String, serde_json::Value>) -> napi::Result<()> {
+    pub async fn send(&self, msg: serde_json::Map<String, serde_json::Value>) -> napi::Result<()> {
         self.inner
             .send(msg)
+            .await
             .map_err(|e| napi::Error::new(napi::Status::Unknown, e))
     }
 
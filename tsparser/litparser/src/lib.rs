@@ -82,6 +82,25 @@ where
     }
 }
 
+impl<T> LitParser for Vec<T>
+where
+    T: LitParser,
+{
+    fn parse_lit(input: &ast::Expr) -> Result<Vec<T>> {
+        match input {
+            ast::Expr::Array(arr) => arr
+                .elems
+                .iter()
+                .map(|elem| match elem {
+                    Some(ast::ExprOrSpread { spread: None, expr }) => T::parse_lit(expr),
+                    _ => anyhow::bail!("expected array element, got {:?}", elem),
+                })
+                .collect(),
+            _ => anyhow::bail!("expected array literal, got {:?}", input),
+        }
+    }
+}
+
 impl LitParser for std::time::Duration {
     fn parse_lit(input: &ast::Expr) -> Result<Self> {
         match input {
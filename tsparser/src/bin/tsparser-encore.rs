@@ -11,7 +11,7 @@ use swc_common::errors::{Emitter, EmitterWriter, Handler, HANDLER};
 use swc_common::{Globals, SourceMap, SourceMapper, GLOBALS};
 use tracing_subscriber::fmt::format::FmtSpan;
 
-use encore_tsparser::builder::{Builder, DebugMode, PlainError};
+use encore_tsparser::builder::{Builder, DebugMode, JSRuntime, PlainError};
 use encore_tsparser::parser::parser::ParseContext;
 use encore_tsparser::{app, builder};
 
@@ -94,9 +94,11 @@ fn main() -> Result<()> {
 
                     Command::Parse(input) => {
                         log::debug!("got parse input {:?}", input);
-                        if parse.is_some() {
-                            anyhow::bail!("already parsed!");
-                        }
+
+                        // Parsing again, like when the app is reloaded, only re-parses
+                        // what changed since the last parse.
+                        parse = None;
+                        errors.lock().unwrap().clear();
 
                         let app = builder::App {
                             root: input.app_root.clone(),
@@ -150,6 +152,7 @@ fn main() -> Result<()> {
                                 desc: parse,
                                 use_local_runtime: input.use_local_runtime,
                                 debug: input.debug,
+                                js_runtime: input.js_runtime,
                             };
 
                             log::info!("starting compile");
@@ -313,6 +316,8 @@ struct CompileInput {
     runtime_version: String,
     use_local_runtime: bool,
     debug: DebugMode,
+    #[serde(default)]
+    js_runtime: JSRuntime,
 }
 
 #[derive(Deserialize, Debug)]
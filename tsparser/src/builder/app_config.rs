@@ -0,0 +1,40 @@
+use std::path::Path;
+
+use anyhow::{Context, Result};
+use serde::Deserialize;
+
+use crate::builder::jsruntime::DenoConfig;
+use crate::builder::transpiler::BundleOptions;
+
+#[derive(Deserialize, Default)]
+struct PackageJson {
+    #[serde(default)]
+    encore: EncoreConfig,
+}
+
+/// The Encore configuration in the "encore" section of the app's package.json.
+#[derive(Deserialize, Default, Debug)]
+pub struct EncoreConfig {
+    /// Configuration for running the app with Deno.
+    #[serde(default)]
+    pub deno: DenoConfig,
+
+    /// Configuration for bundling the app.
+    #[serde(default)]
+    pub bundle: BundleOptions,
+}
+
+/// Reads the Encore configuration from the package.json of the app at app_root.
+/// It returns the default configuration if there's no package.json.
+pub fn read_encore_config(app_root: &Path) -> Result<EncoreConfig> {
+    let package_json_path = app_root.join("package.json");
+    match std::fs::read_to_string(&package_json_path) {
+        Ok(contents) => serde_json::from_str::<PackageJson>(&contents)
+            .map(|pkg| pkg.encore)
+            .with_context(|| format!("failed to parse {}", package_json_path.display())),
+        Err(err) if err.kind() == std::io::ErrorKind::NotFound => Ok(EncoreConfig::default()),
+        Err(err) => {
+            Err(err).with_context(|| format!("failed to read {}", package_json_path.display()))
+        }
+    }
+}
@@ -15,6 +15,7 @@ use crate::parser::parser::ParseContext;
 use crate::parser::resourceparser::bind::BindKind::Create;
 use crate::parser::resources::apis::api::Methods;
 use crate::parser::resources::Resource;
+use crate::parser::types::{Basic, Type};
 use crate::parser::{FilePath, Range};
 
 use super::{App, Builder};
@@ -104,6 +105,20 @@ impl Builder<'_> {
             }
         };
 
+        // The service definitions of all services, relative to the app root.
+        // Each service's entrypoint imports all of them, so global middleware
+        // added to one service apply wherever the endpoints they target run.
+        let mut service_def_paths = Vec::new();
+        for svc in &params.desc.parse.services {
+            let svc_rel_path = params.app.rel_path_string(&svc.root)?;
+            for b in &svc.binds {
+                if let (Resource::Service(_), Some(range)) = (&b.resource, b.range) {
+                    let rel_path = get_svc_rel_path(&svc.root, range, true);
+                    service_def_paths.push(Path::new(&svc_rel_path).join(rel_path));
+                }
+            }
+        }
+
         // Generate the files for each service.
         for svc in &params.desc.parse.services {
             let mut endpoints = Vec::new();
@@ -154,9 +169,18 @@ impl Builder<'_> {
 
             // Service Main
             {
+                let mut service_ctx = Vec::new();
                 let mut endpoint_ctx = Vec::new();
                 let mut subscription_ctx = Vec::new();
 
+                // Import the service definitions, which register their
+                // lifecycle hooks and middleware.
+                for path in &service_def_paths {
+                    service_ctx.push(json!({
+                        "import_path": Path::new("../../../../../").join(path),
+                    }));
+                }
+
                 for rpc in &endpoints {
                     let rel_path = get_svc_rel_path(&svc.root, rpc.range, true);
                     let import_path = Path::new("../../../../../")
@@ -165,6 +189,7 @@ impl Builder<'_> {
 
                     endpoint_ctx.push(json!({
                         "name": rpc.name,
+                        "controller": rpc.controller,
                         "raw": rpc.raw,
                         "streaming": rpc.streaming_request || rpc.streaming_response,
                         "import_path": import_path,
@@ -186,6 +211,7 @@ impl Builder<'_> {
 
                 let ctx = &json!({
                     "name": svc.name,
+                    "services": service_ctx,
                     "endpoints": endpoint_ctx,
                     "subscriptions": subscription_ctx,
                 });
@@ -240,7 +266,13 @@ impl Builder<'_> {
                 // let node_modules_to_svc = node_modules_to_app_root.join(&svc_rel_path);
 
                 for rpc in &endpoints {
-                    let _has_req = rpc.encoding.raw_req_schema.is_some();
+                    // Whether the client function takes request params,
+                    // before the call options.
+                    let has_req = rpc
+                        .encoding
+                        .raw_req_schema
+                        .as_ref()
+                        .is_some_and(|t| !matches!(t, Type::Basic(Basic::Void)));
                     let _has_resp = rpc.encoding.raw_resp_schema.is_some();
 
                     let rel_path = get_svc_rel_path(&svc.root, rpc.range, true);
@@ -251,9 +283,11 @@ impl Builder<'_> {
 
                     endpoint_ctx.push(json!({
                         "name": rpc.name,
+                        "controller": rpc.controller,
                         "raw": rpc.raw,
                         "streaming": rpc.streaming_request || rpc.streaming_response,
                         "import_path": import_path,
+                        "has_req": has_req,
                     }));
                 }
 
@@ -320,6 +354,7 @@ impl Builder<'_> {
 
         // Combined Main
         {
+            let mut service_ctx = Vec::new();
             let mut endpoint_ctx = Vec::new();
             let mut gateway_ctx = Vec::new();
             let mut subscription_ctx = Vec::new();
@@ -328,6 +363,7 @@ impl Builder<'_> {
                 let mut endpoints = Vec::new();
                 let mut gateways = Vec::new();
                 let mut subscriptions = Vec::new();
+                let mut service_defs = Vec::new();
                 for b in &svc.binds {
                     match &b.resource {
                         Resource::APIEndpoint(ep) => {
@@ -346,6 +382,9 @@ impl Builder<'_> {
                         Resource::PubSubSubscription(sub) => {
                             subscriptions.push(sub);
                         }
+                        Resource::Service(_) => {
+                            service_defs.extend(b.range);
+                        }
                         _ => {}
                     }
                 }
@@ -353,6 +392,16 @@ impl Builder<'_> {
                 let svc_rel_path = params.app.rel_path_string(&svc.root)?;
                 let _gen_root = params.app.root.join("encore.gen");
 
+                // Service definitions, which register their lifecycle hooks and middleware.
+                for range in &service_defs {
+                    let rel_path = get_svc_rel_path(&svc.root, *range, true);
+                    let import_path = Path::new("../../../../").join(&svc_rel_path).join(rel_path);
+
+                    service_ctx.push(json!({
+                        "import_path": import_path,
+                    }));
+                }
+
                 // Service Main
                 for rpc in &endpoints {
                     let rel_path = get_svc_rel_path(&svc.root, rpc.range, true);
@@ -360,6 +409,7 @@ impl Builder<'_> {
 
                     endpoint_ctx.push(json!({
                         "name": rpc.name,
+                        "controller": rpc.controller,
                         "raw": rpc.raw,
                         "streaming": rpc.streaming_request || rpc.streaming_response,
                         "service_name": svc.name,
@@ -394,6 +444,7 @@ impl Builder<'_> {
             }
 
             let ctx = &json!({
+                "services": service_ctx,
                 "endpoints": endpoint_ctx,
                 "gateways": gateway_ctx,
                 "subscriptions": subscription_ctx,
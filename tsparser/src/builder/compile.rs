@@ -5,13 +5,14 @@ use crate::app::AppDesc;
 use anyhow::{Context, Result};
 use serde::Serialize;
 
+use crate::builder::app_config::read_encore_config;
 use crate::builder::codegen::CodegenParams;
 use crate::builder::transpiler::{
     EsbuildCompiler, ExternalPackages, Input, InputKind, OutputTranspiler, TranspileParams,
 };
 use crate::parser::parser::ParseContext;
 
-use super::{App, Builder, DebugMode};
+use super::{App, Builder, DebugMode, JSRuntime, PlainError};
 
 #[derive(Debug)]
 pub struct CompileParams<'a> {
@@ -23,6 +24,7 @@ pub struct CompileParams<'a> {
     pub desc: &'a AppDesc,
     pub use_local_runtime: bool,
     pub debug: DebugMode,
+    pub js_runtime: JSRuntime,
 }
 
 #[derive(Serialize, Debug)]
@@ -58,6 +60,11 @@ pub struct CmdSpec {
 
 impl Builder<'_> {
     pub fn compile(&self, params: &CompileParams) -> Result<CompileResult> {
+        params
+            .js_runtime
+            .check_compat()
+            .context("check javascript runtime")?;
+
         self.generate_code(&CodegenParams {
             js_runtime_root: params.js_runtime_root,
             app: params.app,
@@ -74,9 +81,40 @@ impl Builder<'_> {
             .find_node_modules_dir(params.app.root.as_path())
             .ok_or_else(|| anyhow::anyhow!("could not find node_modules directory"))?;
 
+        let runtime_args = params
+            .js_runtime
+            .args(params.app.root.as_path())
+            .context("javascript runtime arguments")?;
+        let bundle_opts = read_encore_config(params.app.root.as_path())
+            .context("read bundle options")?
+            .bundle;
+        if !bundle_opts.bundle_packages && !bundle_opts.external.is_empty() {
+            anyhow::bail!(PlainError(
+                "encore.bundle.external in package.json requires bundlePackages to be enabled"
+                    .to_string()
+            ));
+        }
+        if bundle_opts.bundle_packages && !bundle_opts.no_external.is_empty() {
+            anyhow::bail!(PlainError(
+                "encore.bundle.noExternal in package.json requires bundlePackages to be disabled"
+                    .to_string()
+            ));
+        }
+        let external: Vec<&str> = bundle_opts.external.iter().map(String::as_str).collect();
+        let no_external: Vec<&str> = bundle_opts.no_external.iter().map(String::as_str).collect();
         let transpiler = EsbuildCompiler {
             node_modules_dir: node_modules.as_path(),
-            external: ExternalPackages::All,
+            external: match (bundle_opts.bundle_packages, external.is_empty()) {
+                (false, _) if no_external.is_empty() => ExternalPackages::All,
+                (false, _) => ExternalPackages::AllExcept(&no_external),
+                (true, true) => ExternalPackages::None,
+                (true, false) => ExternalPackages::Some(&external),
+            },
+            runtime: params.js_runtime,
+            runtime_args: &runtime_args,
+            sourcemap: bundle_opts.sourcemap,
+            format: bundle_opts.format,
+            minify: bundle_opts.minify,
         };
 
         let inputs = {
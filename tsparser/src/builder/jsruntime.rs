@@ -0,0 +1,268 @@
+use std::collections::{BTreeMap, HashMap};
+use std::path::Path;
+
+use anyhow::{Context, Result};
+use serde::Deserialize;
+
+use crate::builder::app_config::read_encore_config;
+use crate::builder::PlainError;
+
+/// The JavaScript runtime to run the application with.
+#[derive(Deserialize, Debug, Copy, Clone, Default, PartialEq, Eq)]
+#[serde(rename_all = "lowercase")]
+pub enum JSRuntime {
+    #[default]
+    Node,
+    Bun,
+    Deno,
+}
+
+/// The minimum supported Bun version.
+/// Earlier versions lack the Node-API support the Encore runtime's native module relies on.
+const MIN_BUN_VERSION: Version = Version(1, 1, 0);
+
+/// The minimum supported Deno version.
+/// Earlier versions don't resolve bare specifiers from node_modules by default.
+const MIN_DENO_VERSION: Version = Version(2, 0, 0);
+
+/// The Deno permissions granted to every app, keyed by permission name.
+/// An empty list grants the permission without restrictions.
+///
+/// All of the app's declared infrastructure, like the API server, databases,
+/// Pub/Sub and secrets, is accessed by the Encore runtime's native module, outside of
+/// Deno's sandbox. The app itself therefore only needs to read the environment
+/// variables used to locate the native module, and to load it.
+const DENO_PERMISSIONS: &[(&str, &[&str])] = &[
+    ("env", &["NODE_ENV", "ENCORE_RUNTIME_LIB"]),
+    ("ffi", &[]),
+    ("read", &[]),
+];
+
+impl JSRuntime {
+    /// The command to invoke the runtime.
+    pub fn command(self) -> &'static str {
+        match self {
+            JSRuntime::Node => "node",
+            JSRuntime::Bun => "bun",
+            JSRuntime::Deno => "deno",
+        }
+    }
+
+    /// The arguments to pass to the runtime before the entrypoint, for the app at app_root.
+    pub fn args(self, app_root: &Path) -> Result<Vec<String>> {
+        Ok(match self {
+            JSRuntime::Node => vec![
+                "--enable-source-maps".to_string(),
+                "--preserve-symlinks".to_string(),
+            ],
+            // Bun applies source maps by default.
+            JSRuntime::Bun => vec!["--preserve-symlinks".to_string()],
+            JSRuntime::Deno => {
+                let mut args = vec!["run".to_string()];
+                args.extend(deno_permissions(app_root).context("resolve deno permissions")?);
+                args
+            }
+        })
+    }
+
+    /// Checks that the runtime is installed and compatible with Encore.
+    pub fn check_compat(self) -> Result<()> {
+        let (min_version, install_url, upgrade_cmd) = match self {
+            // Node.js compatibility is handled by the package manager.
+            JSRuntime::Node => return Ok(()),
+            JSRuntime::Bun => (MIN_BUN_VERSION, "https://bun.sh", "bun upgrade"),
+            JSRuntime::Deno => (MIN_DENO_VERSION, "https://deno.com", "deno upgrade"),
+        };
+
+        let name = self.command();
+        let output = match std::process::Command::new(name).arg("--version").output() {
+            Ok(output) => output,
+            Err(err) if err.kind() == std::io::ErrorKind::NotFound => {
+                anyhow::bail!(PlainError(format!(
+                    "{} is not installed: see {} for installation instructions",
+                    name, install_url,
+                )));
+            }
+            Err(err) => {
+                return Err(err).with_context(|| format!("failed to run {} --version", name))
+            }
+        };
+        if !output.status.success() {
+            anyhow::bail!(
+                "{} --version failed: {}",
+                name,
+                String::from_utf8_lossy(&output.stderr)
+            );
+        }
+        check_version(
+            name,
+            &String::from_utf8_lossy(&output.stdout),
+            min_version,
+            upgrade_cmd,
+        )
+    }
+}
+
+fn check_version(name: &str, output: &str, min_version: Version, upgrade_cmd: &str) -> Result<()> {
+    // Deno reports its version as "deno 2.0.0 (stable, release, ...)", followed by
+    // the versions of its components, whereas Bun only reports the version itself.
+    let first_line = output.lines().next().unwrap_or_default();
+    let version = first_line
+        .strip_prefix(name)
+        .unwrap_or(first_line)
+        .split_whitespace()
+        .next()
+        .unwrap_or_default();
+    let parsed = Version::parse(version)
+        .with_context(|| format!("unable to parse {} version {:?}", name, first_line))?;
+    if parsed < min_version {
+        anyhow::bail!(PlainError(format!(
+            "{} {} is not supported: Encore requires {} {} or later, upgrade with '{}'",
+            name, version, name, min_version, upgrade_cmd,
+        )));
+    }
+    Ok(())
+}
+
+/// Configuration for running the app with Deno,
+/// in the "encore.deno" section of the app's package.json.
+#[derive(Deserialize, Default, Debug)]
+pub struct DenoConfig {
+    /// Additional permissions to grant, keyed by permission name,
+    /// like {"net": ["api.example.com"]}. An empty list grants
+    /// the permission without restrictions.
+    #[serde(default)]
+    allow: HashMap<String, Vec<String>>,
+}
+
+/// Computes the Deno permission flags for the app, combining the permissions
+/// the Encore runtime requires with those configured in the app's package.json.
+fn deno_permissions(app_root: &Path) -> Result<Vec<String>> {
+    let config = read_encore_config(app_root)?;
+
+    let mut perms: BTreeMap<String, Vec<String>> = DENO_PERMISSIONS
+        .iter()
+        .map(|(name, values)| {
+            (
+                name.to_string(),
+                values.iter().map(|v| v.to_string()).collect(),
+            )
+        })
+        .collect();
+    for (name, values) in config.deno.allow {
+        if !name.chars().all(|c| c.is_ascii_lowercase() || c == '-') {
+            anyhow::bail!(PlainError(format!(
+                "invalid deno permission {:?} in package.json",
+                name
+            )));
+        }
+        match perms.get_mut(&name) {
+            // The permission is already unrestricted.
+            Some(existing) if existing.is_empty() => {}
+            Some(existing) if values.is_empty() => existing.clear(),
+            Some(existing) => existing.extend(values),
+            None => {
+                perms.insert(name, values);
+            }
+        }
+    }
+
+    Ok(perms
+        .into_iter()
+        .map(|(name, mut values)| {
+            if values.is_empty() {
+                format!("--allow-{}", name)
+            } else {
+                values.sort();
+                values.dedup();
+                format!("--allow-{}={}", name, values.join(","))
+            }
+        })
+        .collect())
+}
+
+#[derive(Debug, Copy, Clone, PartialEq, Eq, PartialOrd, Ord)]
+struct Version(u64, u64, u64);
+
+impl Version {
+    /// Parses a version like "1.1.8", "v1.2" or "1.1.9-canary.1".
+    fn parse(s: &str) -> Option<Self> {
+        let s = s.strip_prefix('v').unwrap_or(s);
+        let s = s.split(['-', '+']).next()?;
+        let mut parts = s.split('.').map(|p| p.parse::<u64>().ok());
+        let major = parts.next()??;
+        let minor = parts.next().unwrap_or(Some(0))?;
+        let patch = parts.next().unwrap_or(Some(0))?;
+        Some(Version(major, minor, patch))
+    }
+}
+
+impl std::fmt::Display for Version {
+    fn fmt(&self, f: &mut std::fmt::Formatter<'_>) -> std::fmt::Result {
+        write!(f, "{}.{}.{}", self.0, self.1, self.2)
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_parse_version() {
+        assert_eq!(Version::parse("1.1.8"), Some(Version(1, 1, 8)));
+        assert_eq!(Version::parse("v1.2"), Some(Version(1, 2, 0)));
+        assert_eq!(Version::parse("1.1.9-canary.1"), Some(Version(1, 1, 9)));
+        assert_eq!(Version::parse("bun"), None);
+    }
+
+    #[test]
+    fn test_check_version() {
+        assert!(check_version("bun", "1.1.0\n", MIN_BUN_VERSION, "bun upgrade").is_ok());
+        assert!(check_version("bun", "1.2.3", MIN_BUN_VERSION, "bun upgrade").is_ok());
+        assert!(check_version("bun", "1.0.36", MIN_BUN_VERSION, "bun upgrade").is_err());
+        assert!(check_version("bun", "garbage", MIN_BUN_VERSION, "bun upgrade").is_err());
+
+        let deno = "deno 2.0.2 (stable, release, aarch64-apple-darwin)\nv8 12.9.202.13-rusty\ntypescript 5.6.2\n";
+        assert!(check_version("deno", deno, MIN_DENO_VERSION, "deno upgrade").is_ok());
+        let deno = "deno 1.46.3 (stable, release, aarch64-apple-darwin)\n";
+        assert!(check_version("deno", deno, MIN_DENO_VERSION, "deno upgrade").is_err());
+    }
+
+    #[test]
+    fn test_deno_permissions() {
+        let dir = tempdir::TempDir::new("deno_permissions").unwrap();
+        assert_eq!(
+            deno_permissions(dir.path()).unwrap(),
+            vec![
+                "--allow-env=ENCORE_RUNTIME_LIB,NODE_ENV",
+                "--allow-ffi",
+                "--allow-read",
+            ],
+        );
+
+        std::fs::write(
+            dir.path().join("package.json"),
+            r#"{"encore": {"deno": {"allow": {"net": ["api.example.com"], "env": ["API_KEY"], "read": ["/tmp"]}}}}"#,
+        )
+        .unwrap();
+        assert_eq!(
+            deno_permissions(dir.path()).unwrap(),
+            vec![
+                "--allow-env=API_KEY,ENCORE_RUNTIME_LIB,NODE_ENV",
+                "--allow-ffi",
+                "--allow-net=api.example.com",
+                "--allow-read",
+            ],
+        );
+
+        std::fs::write(
+            dir.path().join("package.json"),
+            r#"{"encore": {"deno": {"allow": {"env": []}}}}"#,
+        )
+        .unwrap();
+        assert_eq!(
+            deno_permissions(dir.path()).unwrap(),
+            vec!["--allow-env", "--allow-ffi", "--allow-read"],
+        );
+    }
+}
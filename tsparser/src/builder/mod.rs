@@ -7,12 +7,15 @@ use serde::{Deserialize, Serialize};
 
 pub use codegen::{CodegenParams, CodegenResult};
 pub use compile::CompileParams;
+pub use jsruntime::JSRuntime;
 pub use parse::{ParseError, ParseParams};
 pub use prepare::PrepareParams;
 pub use test::TestParams;
 
+mod app_config;
 mod codegen;
 mod compile;
+mod jsruntime;
 mod package_mgmt;
 mod parse;
 mod prepare;
@@ -15,6 +15,9 @@ struct PackageJson {
 
     #[serde(default)]
     dependencies: HashMap<String, String>,
+
+    #[serde(default)]
+    workspaces: Option<serde_json::Value>,
 }
 
 fn parse_package_json(package_json_path: &Path) -> Result<PackageJson> {
@@ -41,6 +44,33 @@ fn find_workspace_package_manager(mut dir: PathBuf) -> Result<Option<String>> {
     Ok(None)
 }
 
+/// Finds the root of the workspace (monorepo) containing dir, if any.
+/// The root is marked by a package.json with a "workspaces" field
+/// (npm, yarn and bun) or by a pnpm-workspace.yaml file (pnpm).
+fn find_workspace_root(dir: &Path) -> Option<PathBuf> {
+    dir.ancestors().skip(1).find_map(|dir| {
+        if dir.join("pnpm-workspace.yaml").exists() {
+            return Some(dir.to_path_buf());
+        }
+        let package_json_path = dir.join("package.json");
+        if !package_json_path.exists() {
+            return None;
+        }
+        parse_package_json(&package_json_path)
+            .ok()
+            .and_then(|pkg| pkg.workspaces)
+            .map(|_| dir.to_path_buf())
+    })
+}
+
+/// Reports whether the dependencies of the package in dir have been installed.
+/// Package managers install the dependencies of workspace packages into
+/// the node_modules folder of the workspace root.
+fn has_node_modules(dir: &Path) -> bool {
+    dir.join("node_modules").exists()
+        || find_workspace_root(dir).is_some_and(|root| root.join("node_modules").exists())
+}
+
 pub(super) fn resolve_package_manager(package_dir: &Path) -> Result<Box<dyn PackageManager>> {
     let package_json_path = package_dir.join("package.json");
     let package_json = parse_package_json(&package_json_path)?;
@@ -50,6 +80,14 @@ pub(super) fn resolve_package_manager(package_dir: &Path) -> Result<Box<dyn Pack
         _ => find_workspace_package_manager(package_dir.to_path_buf())?,
     };
 
+    // Bun doesn't require the packageManager field, so fall back to looking for its lockfile.
+    let package_manager = package_manager.or_else(|| {
+        ["bun.lockb", "bun.lock"]
+            .iter()
+            .any(|f| package_dir.join(f).exists())
+            .then(|| "bun".to_string())
+    });
+
     let package_manager = package_manager.as_deref().unwrap_or("npm");
     let package_manager = match package_manager.split_once('@') {
         Some((name, _)) => name,
@@ -69,6 +107,10 @@ pub(super) fn resolve_package_manager(package_dir: &Path) -> Result<Box<dyn Pack
             pkg_json: package_json,
             dir: package_dir.to_path_buf(),
         })),
+        "bun" => Ok(Box::new(BunPackageManager {
+            pkg_json: package_json,
+            dir: package_dir.to_path_buf(),
+        })),
         _ => Err(anyhow::anyhow!(
             "unsupported package manager: {}",
             package_manager
@@ -93,7 +135,7 @@ struct NpmPackageManager {
 impl PackageManager for NpmPackageManager {
     fn setup_deps(&self, encore_dev_path: Option<&Path>) -> Result<()> {
         // If we don't have a node_modules folder, install everything.
-        if !self.dir.join("node_modules").exists() {
+        if !has_node_modules(&self.dir) {
             cmd!("npm", "install")
                 .dir(&self.dir)
                 .stdout_to_stderr()
@@ -150,7 +192,7 @@ impl PackageManager for YarnPackageManager {
             .context("unable to update .yarnrc.yml to set nodeLinker")?;
 
         // If we don't have a node_modules folder, install everything.
-        if !self.dir.join("node_modules").exists() {
+        if !has_node_modules(&self.dir) {
             cmd!("yarn", "install")
                 .dir(&self.dir)
                 .stdout_to_stderr()
@@ -229,7 +271,7 @@ struct PnpmPackageManager {
 impl PackageManager for PnpmPackageManager {
     fn setup_deps(&self, encore_dev_path: Option<&Path>) -> Result<()> {
         // If we don't have a node_modules folder, install everything.
-        if !self.dir.join("node_modules").exists() {
+        if !has_node_modules(&self.dir) {
             cmd!("pnpm", "install")
                 .dir(&self.dir)
                 .stdout_to_stderr()
@@ -275,6 +317,55 @@ impl PackageManager for PnpmPackageManager {
     }
 }
 
+struct BunPackageManager {
+    pkg_json: PackageJson,
+    dir: PathBuf,
+}
+
+impl PackageManager for BunPackageManager {
+    fn setup_deps(&self, encore_dev_path: Option<&Path>) -> Result<()> {
+        // If we don't have a node_modules folder, install everything.
+        if !has_node_modules(&self.dir) {
+            cmd!("bun", "install")
+                .dir(&self.dir)
+                .stdout_to_stderr()
+                .run()
+                .context("bun install failed")?;
+        }
+
+        // If we don't have an `encore.dev` dependency, install it.
+        if !self.pkg_json.dependencies.contains_key("encore.dev") {
+            cmd!("bun", "add", "encore.dev@latest")
+                .dir(&self.dir)
+                .stdout_to_stderr()
+                .run()
+                .context("bun add failed")?;
+        }
+
+        // If we have a local encore.dev path, symlink it.
+        if let Some(encore_dev_path) = encore_dev_path {
+            symlink_encore_dev(&self.dir, encore_dev_path)
+                .context("unable to symlink local encore.dev")?;
+        }
+
+        Ok(())
+    }
+
+    fn run_tests(&self) -> Result<CmdSpec> {
+        Ok(CmdSpec {
+            // Use 'bun run test' rather than 'bun test', to run the
+            // "test" script instead of Bun's built-in test runner.
+            command: vec!["bun".to_string(), "run".to_string(), "test".to_string()],
+            env: vec![],
+            prioritized_files: vec![],
+        })
+    }
+
+    fn mgr_name(&self) -> &'static str {
+        "bun"
+    }
+}
+
 fn symlink_encore_dev(app_root: &Path, encore_dev_path: &Path) -> Result<()> {
     let node_modules = app_root.join("node_modules");
     let node_mod_dst = node_modules.join("encore.dev");
@@ -29,6 +29,21 @@ impl Display for ParseError {
 impl Builder<'_> {
     pub fn parse(&self, params: &ParseParams) -> Result<AppDesc> {
         let pc = params.pc;
+        pc.invalidate_changed();
+
+        let num_errs = pc.errs.err_count();
+        let result = self.parse_app(pc);
+        let succeeded = result.is_ok() && pc.errs.err_count() == num_errs;
+        pc.set_parse_result(succeeded);
+
+        let desc = result?;
+        if !succeeded {
+            anyhow::bail!(ParseError);
+        }
+        Ok(desc)
+    }
+
+    fn parse_app(&self, pc: &ParseContext) -> Result<AppDesc> {
         let pass1 = PassOneParser::new(
             pc.file_set.clone(),
             pc.type_checker.clone(),
@@ -37,11 +52,6 @@ impl Builder<'_> {
         let parser = Parser::new(pc, pass1);
 
         let result = parser.parse()?;
-        let desc = validate_and_describe(pc, result)?;
-
-        if pc.errs.has_errors() {
-            anyhow::bail!(ParseError);
-        }
-        Ok(desc)
+        validate_and_describe(pc, result)
     }
 }
@@ -2,12 +2,15 @@ use std::ffi::OsStr;
 use std::path::{Path, PathBuf};
 
 use crate::builder::compile::{CmdSpec, Entrypoint};
-use crate::builder::{DebugMode, PlainError};
+use crate::builder::{DebugMode, JSRuntime, PlainError};
 use anyhow::{Context, Result};
+use serde::Deserialize;
 
 #[allow(dead_code)]
 pub enum ExternalPackages<'a> {
     All,
+    /// All packages are external except the given ones, which are bundled.
+    AllExcept(&'a [&'a str]),
     Some(&'a [&'a str]),
     None,
 }
@@ -19,6 +22,97 @@ pub enum InputKind {
     Gateway(String),
 }
 
+/// Options for bundling the app,
+/// in the "encore.bundle" section of the app's package.json.
+#[derive(Deserialize, Debug, Default)]
+#[serde(rename_all = "camelCase")]
+pub struct BundleOptions {
+    /// How to emit source maps.
+    #[serde(default)]
+    pub sourcemap: SourceMapMode,
+
+    /// The module format of the bundled code.
+    #[serde(default)]
+    pub format: OutputFormat,
+
+    /// Whether to minify the bundled code.
+    #[serde(default)]
+    pub minify: bool,
+
+    /// Whether to bundle npm packages into the output,
+    /// instead of loading them from node_modules at runtime.
+    #[serde(default)]
+    pub bundle_packages: bool,
+
+    /// Packages to keep external when bundling npm packages,
+    /// like packages with native addons.
+    #[serde(default)]
+    pub external: Vec<String>,
+
+    /// Packages to bundle when npm packages are loaded from node_modules,
+    /// like ESM-only packages that can't be loaded from CommonJS.
+    #[serde(default)]
+    pub no_external: Vec<String>,
+}
+
+/// How to emit source maps for the bundled code.
+#[derive(Deserialize, Debug, Copy, Clone, Default, PartialEq, Eq)]
+#[serde(rename_all = "lowercase")]
+pub enum SourceMapMode {
+    /// Source maps are written next to the bundle and linked from it.
+    #[default]
+    Linked,
+    /// Source maps are written next to the bundle without being linked from it.
+    /// The Encore runtime applies them to stack traces itself.
+    External,
+    /// Source maps are embedded in the bundle.
+    Inline,
+    /// No source maps are emitted.
+    None,
+}
+
+impl SourceMapMode {
+    fn as_str(self) -> &'static str {
+        match self {
+            SourceMapMode::Linked => "linked",
+            SourceMapMode::External => "external",
+            SourceMapMode::Inline => "inline",
+            SourceMapMode::None => "none",
+        }
+    }
+}
+
+/// The module format of the bundled code.
+#[derive(Deserialize, Debug, Copy, Clone, Default, PartialEq, Eq)]
+#[serde(rename_all = "lowercase")]
+pub enum OutputFormat {
+    /// ES modules, written as .mjs files.
+    #[default]
+    Esm,
+    /// CommonJS modules, written as .cjs files.
+    Cjs,
+    /// Both ES modules and CommonJS modules. The ES modules are run.
+    Both,
+}
+
+impl OutputFormat {
+    fn as_str(self) -> &'static str {
+        match self {
+            OutputFormat::Esm => "esm",
+            OutputFormat::Cjs => "cjs",
+            OutputFormat::Both => "both",
+        }
+    }
+
+    /// The file extension of the bundled entrypoints to run.
+    fn entrypoint_ext(self) -> &'static str {
+        match self {
+            OutputFormat::Cjs => "cjs",
+            OutputFormat::Esm | OutputFormat::Both => "mjs",
+        }
+    }
+}
+
 pub struct Input {
     // What kind of input is it.
     pub kind: InputKind,
@@ -59,6 +153,16 @@ pub(super) trait OutputTranspiler {
 pub struct EsbuildCompiler<'a> {
     pub node_modules_dir: &'a Path,
     pub external: ExternalPackages<'a>,
+    /// The runtime to run the bundled entrypoints with.
+    pub runtime: JSRuntime,
+    /// The arguments to pass to the runtime before the entrypoint.
+    pub runtime_args: &'a [String],
+    /// How to emit source maps.
+    pub sourcemap: SourceMapMode,
+    /// The module format of the bundled code.
+    pub format: OutputFormat,
+    /// Whether to minify the bundled code.
+    pub minify: bool,
 }
 
 impl OutputTranspiler for EsbuildCompiler<'_> {
@@ -75,14 +179,40 @@ impl OutputTranspiler for EsbuildCompiler<'_> {
                 .arg("--engine=node:21")
                 // .arg("--format=esm")
                 // .arg("--platform=node")
-                // .arg("--sourcemap")
                 // .arg("--out-extension:.js=.mjs")
                 // .arg("--entry-names=[dir]/[name]")
+                .arg(format!("--sourcemap={}", self.sourcemap.as_str()))
+                .arg(format!("--format={}", self.format.as_str()))
                 .arg(format!(
                     "--outdir={}",
                     p.artifact_dir.join(name_prefix).to_string_lossy(),
                 ));
 
+            if self.minify {
+                cmd.arg("--minify");
+            }
+
+            match self.external {
+                ExternalPackages::All => {
+                    cmd.arg("--packages=external");
+                }
+                ExternalPackages::AllExcept(pkgs) => {
+                    cmd.arg("--packages=external");
+                    for pkg in pkgs {
+                        cmd.arg(format!("--no-external={}", pkg));
+                    }
+                }
+                ExternalPackages::Some(pkgs) => {
+                    cmd.arg("--packages=bundle");
+                    for pkg in pkgs {
+                        cmd.arg(format!("--external={}", pkg));
+                    }
+                }
+                ExternalPackages::None => {
+                    cmd.arg("--packages=bundle");
+                }
+            }
+
             for input in &inputs {
                 cmd.arg(&input.entrypoint);
             }
@@ -101,18 +231,16 @@ impl OutputTranspiler for EsbuildCompiler<'_> {
                 let entrypoint_path = {
                     let (file_stem, dir) = file_stem_and_dir(&i.entrypoint)?;
                     format!(
-                        "$ARTIFACT_DIR/{}/{}/{}.mjs",
+                        "$ARTIFACT_DIR/{}/{}/{}.{}",
                         name_prefix,
                         dir.to_string_lossy(),
                         file_stem.to_string_lossy(),
+                        self.format.entrypoint_ext(),
                     )
                 };
 
-                let mut command = vec![
-                    "node".to_string(),
-                    "--enable-source-maps".into(),
-                    "--preserve-symlinks".into(),
-                ];
+                let mut command = vec![self.runtime.command().to_string()];
+                command.extend(self.runtime_args.iter().cloned());
 
                 match p.debug {
                     DebugMode::Disabled => {}
@@ -128,10 +256,17 @@ impl OutputTranspiler for EsbuildCompiler<'_> {
                     InputKind::Gateway(name) => (vec![], vec![name]),
                     InputKind::Combined(gateways, services) => (services, gateways),
                 };
+                // The runtime can't find source maps that aren't linked from the bundle,
+                // so have the Encore runtime apply them instead.
+                let env = match self.sourcemap {
+                    SourceMapMode::External => vec!["ENCORE_SOURCE_MAPS=external".to_string()],
+                    _ => vec![],
+                };
+
                 entrypoints.push(Entrypoint {
                     cmd: CmdSpec {
                         command,
-                        env: vec![],
+                        env,
                         prioritized_files: vec![entrypoint_path],
                     },
                     services,
@@ -197,6 +332,9 @@ impl OutputTranspiler for BunBuildCompiler<'_> {
                 ExternalPackages::All => {
                     cmd.arg("--packages=external");
                 }
+                ExternalPackages::AllExcept(_) => {
+                    anyhow::bail!("bun build doesn't support bundling specific packages");
+                }
                 ExternalPackages::Some(pkgs) => {
                     for pkg in pkgs {
                         cmd.arg(format!("--external:{}", pkg));
@@ -9,6 +9,7 @@ use crate::encore::parser::meta::v1;
 use crate::legacymeta::schema::{loc_from_range, SchemaBuilder};
 use crate::parser::parser::{ParseContext, ParseResult, Service};
 use crate::parser::resourceparser::bind::{Bind, BindKind};
+use crate::parser::resources::apis::api::{SlowConsumerPolicy, StreamFlowControl};
 use crate::parser::resources::apis::{authhandler, gateway};
 use crate::parser::resources::infra::cron::CronJobSchedule;
 use crate::parser::resources::infra::{cron, pubsub_subscription, pubsub_topic, sqldb};
@@ -64,6 +65,8 @@ impl<'a> MetaBuilder<'a> {
 
                 doc: "".into(),      // TODO
                 trace_nodes: vec![], // TODO?
+                generated_secrets: vec![],
+                list_secrets: vec![],
             });
 
             svc_index.insert(svc.name.clone(), self.data.svcs.len());
@@ -154,7 +157,14 @@ impl<'a> MetaBuilder<'a> {
                         } as i32,
                         path: Some(ep.encoding.path.to_meta()),
                         http_methods: ep.encoding.methods.to_vec(),
-                        tags: vec![],
+                        tags: ep
+                            .tags
+                            .iter()
+                            .map(|tag| v1::Selector {
+                                r#type: v1::selector::Type::Tag as i32,
+                                value: tag.clone(),
+                            })
+                            .collect(),
                         sensitive: false,
                         loc: Some(loc_from_range(self.app_root, &self.pc.file_set, ep.range)?),
                         allow_unauthenticated: !ep.require_auth,
@@ -172,6 +182,9 @@ impl<'a> MetaBuilder<'a> {
                         streaming_request: ep.streaming_request,
                         streaming_response: ep.streaming_response,
                         static_assets,
+                        stream_flow_control: ep.flow_control.as_ref().map(stream_flow_control),
+                        // Form-encoded request bodies are only supported for Go apps.
+                        form: false,
                     };
 
                     let service_idx = svc_index
@@ -583,6 +596,22 @@ impl respath::Path {
     }
 }
 
+fn stream_flow_control(fc: &StreamFlowControl) -> v1::rpc::StreamFlowControl {
+    use v1::rpc::stream_flow_control::SlowConsumerPolicy as Policy;
+    let slow_consumer = match fc.slow_consumer {
+        SlowConsumerPolicy::Wait => Policy::Wait,
+        SlowConsumerPolicy::DropNewest => Policy::DropNewest,
+        SlowConsumerPolicy::DropOldest => Policy::DropOldest,
+        SlowConsumerPolicy::Disconnect => Policy::Disconnect,
+    };
+    v1::rpc::StreamFlowControl {
+        max_buffered_messages: fc.max_buffered_messages,
+        high_water_mark: fc.high_water_mark,
+        slow_consumer: slow_consumer as i32,
+        max_buffered_incoming_messages: fc.max_buffered_incoming_messages,
+    }
+}
+
 fn new_meta() -> v1::Data {
     v1::Data {
         module_path: "app".to_string(),
@@ -68,6 +68,10 @@ pub struct SourceFile {
 }
 
 impl SourceFile {
+    pub fn src(&self) -> &str {
+        self.file.src.as_str()
+    }
+
     pub fn name(&self) -> FilePath {
         match self.file.name {
             swc_common::FileName::Real(ref p) => FilePath::Real(p.to_owned()),
@@ -1,5 +1,5 @@
-use std::cell::{OnceCell, RefCell};
-use std::collections::HashMap;
+use std::cell::{Cell, OnceCell, RefCell};
+use std::collections::{HashMap, HashSet};
 use std::ffi::OsStr;
 use std::io;
 use std::path::Path;
@@ -32,6 +32,10 @@ pub struct ModuleLoader {
     file_set: Lrc<FileSet>,
     resolver: Box<dyn Resolve>,
     by_path: RefCell<HashMap<FilePath, Lrc<Module>>>,
+    next_id: Cell<usize>,
+
+    /// The modules importing each module, by path.
+    dependents: RefCell<HashMap<FilePath, HashSet<FilePath>>>,
 
     // The universe module, if it's been loaded.
     universe: OnceCell<Lrc<Module>>,
@@ -87,6 +91,8 @@ impl ModuleLoader {
             file_set,
             resolver,
             by_path: RefCell::new(HashMap::new()),
+            next_id: Cell::new(1),
+            dependents: RefCell::new(HashMap::new()),
             universe: OnceCell::new(),
             encore_app_clients: OnceCell::new(),
             encore_auth: OnceCell::new(),
@@ -138,6 +144,12 @@ impl ModuleLoader {
             }
         };
 
+        self.dependents
+            .borrow_mut()
+            .entry(target_file_path.clone())
+            .or_default()
+            .insert(module.file_path.clone());
+
         if let Some(module) = self.by_path.borrow().get(&target_file_path) {
             return Ok(Some(module.clone()));
         }
@@ -161,6 +173,47 @@ impl ModuleLoader {
         }
     }
 
+    /// Drops the modules whose files have changed since they were loaded,
+    /// or all modules loaded from files if `all` is set,
+    /// so they're parsed again the next time they're loaded.
+    ///
+    /// It returns the ids of the dropped modules and of the modules
+    /// importing them, directly or indirectly.
+    pub fn drop_changed(&self, all: bool) -> HashSet<ModuleId> {
+        let mut by_path = self.by_path.borrow_mut();
+        let changed = by_path
+            .iter()
+            .filter(|(path, module)| match path {
+                // Modules like the universe have no file on disk.
+                FilePath::Real(buf) if buf.is_absolute() => {
+                    all || std::fs::read_to_string(buf).map_or(true, |src| !module.has_src(&src))
+                }
+                _ => false,
+            })
+            .map(|(path, _)| path.clone())
+            .collect::<Vec<_>>();
+
+        let dependents = self.dependents.borrow();
+        let mut affected = HashSet::new();
+        let mut seen = changed.iter().cloned().collect::<HashSet<_>>();
+        let mut queue = changed.clone();
+        while let Some(path) = queue.pop() {
+            if let Some(module) = by_path.get(&path) {
+                affected.insert(module.id);
+            }
+            for dependent in dependents.get(&path).into_iter().flatten() {
+                if seen.insert(dependent.clone()) {
+                    queue.push(dependent.clone());
+                }
+            }
+        }
+
+        for path in &changed {
+            by_path.remove(path);
+        }
+        affected
+    }
+
     /// Load a file from the filesystem into the module loader.
     pub fn load_fs_file(
         &self,
@@ -241,12 +294,13 @@ impl ModuleLoader {
         let (ast, comments) = self.parse_file(file.clone())?;
 
         let mut mods = self.by_path.borrow_mut();
-        let id = ModuleId(mods.len() + 1);
+        let id = ModuleId(self.next_id.get());
+        self.next_id.set(id.0 + 1);
 
         let module = Module::new(
             self.file_set.clone(),
             id,
-            file.name(),
+            file,
             module_path,
             ast,
             Some(comments),
@@ -281,7 +335,8 @@ impl ModuleLoader {
             e.into_diagnostic(&self.errs).emit();
         }
 
-        let ast = parser.parse_module().map_err(Error::ParseError)?;
+        let mut ast = parser.parse_module().map_err(Error::ParseError)?;
+        normalize_npm_specifiers(&mut ast);
 
         // Resolve identifiers.
         let mut resolver = swc_ecma_transforms_base::resolver(Mark::new(), Mark::new(), true);
@@ -293,6 +348,7 @@ impl ModuleLoader {
 
 pub struct Module {
     file_set: Lrc<FileSet>,
+    file: Lrc<SourceFile>,
     pub id: ModuleId,
     pub ast: swc_ecma_ast::Module,
     pub file_path: FilePath,
@@ -315,7 +371,7 @@ impl Module {
     fn new(
         file_set: Lrc<FileSet>,
         id: ModuleId,
-        file_path: FilePath,
+        file: Lrc<SourceFile>,
         module_path: Option<String>,
         ast: ast::Module,
         comments: Option<Box<dyn Comments>>,
@@ -323,9 +379,10 @@ impl Module {
         let comments: Box<dyn Comments> = comments.unwrap_or_else(|| Box::new(NoopComments {}));
         Lrc::new(Self {
             file_set,
+            file_path: file.name(),
+            file,
             id,
             ast,
-            file_path,
             module_path,
             comments,
             cached_imports: OnceCell::new(),
@@ -340,6 +397,11 @@ impl Module {
     pub fn preceding_comments(&self, pos: Pos) -> Option<String> {
         self.file_set.preceding_comments(&self.comments, pos)
     }
+
+    /// Reports whether the module was parsed from the source src.
+    pub fn has_src(&self, src: &str) -> bool {
+        self.file.src() == src
+    }
 }
 
 /// imports_from_mod returns the import declarations in the given module.
@@ -353,6 +415,92 @@ fn imports_from_mod(ast: &ast::Module) -> Vec<ast::ImportDecl> {
         .collect()
 }
 
+/// Rewrites Deno-style "npm:" import specifiers, like "npm:encore.dev@1.40/api",
+/// to the bare specifiers they refer to, like "encore.dev/api", so they are resolved
+/// from node_modules and recognized as imports of the package.
+fn normalize_npm_specifiers(ast: &mut ast::Module) {
+    for it in ast.body.iter_mut() {
+        let src = match it {
+            ast::ModuleItem::ModuleDecl(ast::ModuleDecl::Import(imp)) => &mut imp.src,
+            ast::ModuleItem::ModuleDecl(ast::ModuleDecl::ExportAll(exp)) => &mut exp.src,
+            ast::ModuleItem::ModuleDecl(ast::ModuleDecl::ExportNamed(exp)) => match exp.src {
+                Some(ref mut src) => src,
+                None => continue,
+            },
+            _ => continue,
+        };
+        if let Some(bare) = bare_npm_specifier(&src.value) {
+            src.value = bare.into();
+            src.raw = None;
+        }
+    }
+}
+
+/// Returns the bare specifier for an "npm:" specifier, without the version,
+/// or None if it's not an "npm:" specifier.
+fn bare_npm_specifier(specifier: &str) -> Option<String> {
+    let rest = specifier.strip_prefix("npm:")?;
+    let rest = rest.strip_prefix('/').unwrap_or(rest);
+
+    // Scoped package names contain a slash, as in "@scope/name".
+    let (scope, rest) = match rest.strip_prefix('@') {
+        Some(scoped) => {
+            let (scope, rest) = scoped.split_once('/')?;
+            (Some(scope), rest)
+        }
+        None => (None, rest),
+    };
+    let (name, subpath) = match rest.split_once('/') {
+        Some((name, subpath)) => (name, Some(subpath)),
+        None => (rest, None),
+    };
+    let name = name.split_once('@').map_or(name, |(name, _version)| name);
+    if name.is_empty() {
+        return None;
+    }
+
+    let mut bare = String::with_capacity(specifier.len());
+    if let Some(scope) = scope {
+        bare.push('@');
+        bare.push_str(scope);
+        bare.push('/');
+    }
+    bare.push_str(name);
+    if let Some(subpath) = subpath {
+        bare.push('/');
+        bare.push_str(subpath);
+    }
+    Some(bare)
+}
+
+#[cfg(test)]
+mod tests {
+    use super::bare_npm_specifier;
+
+    #[test]
+    fn test_bare_npm_specifier() {
+        let cases = [
+            ("npm:encore.dev/api", Some("encore.dev/api")),
+            ("npm:encore.dev@1.40.0/api", Some("encore.dev/api")),
+            ("npm:encore.dev@^1", Some("encore.dev")),
+            ("npm:/encore.dev/api", Some("encore.dev/api")),
+            ("npm:@scope/pkg@2/sub/path", Some("@scope/pkg/sub/path")),
+            ("npm:@scope/pkg", Some("@scope/pkg")),
+            ("npm:@scope", None),
+            ("encore.dev/api", None),
+            ("jsr:@std/path", None),
+        ];
+        for (specifier, want) in cases {
+            assert_eq!(
+                bare_npm_specifier(specifier).as_deref(),
+                want,
+                "specifier {}",
+                specifier
+            );
+        }
+    }
+}
+
 #[cfg(test)]
 impl ModuleLoader {
     /// Injects a new file into the module loader.
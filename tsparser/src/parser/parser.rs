@@ -1,3 +1,4 @@
+use std::cell::{Cell, RefCell};
 use std::collections::HashMap;
 use std::ffi::OsStr;
 use std::fmt::Formatter;
@@ -12,7 +13,7 @@ use swc_ecma_loader::resolvers::node::NodeModulesResolver;
 use swc_ecma_loader::TargetEnv;
 use walkdir::WalkDir;
 
-use crate::parser::module_loader::ModuleLoader;
+use crate::parser::module_loader::{Module, ModuleId, ModuleLoader};
 use crate::parser::resourceparser::bind::{Bind, BindKind};
 use crate::parser::resourceparser::PassOneParser;
 use crate::parser::resources::apis::service_client::ServiceClient;
@@ -42,6 +43,20 @@ pub struct ParseContext {
 
     /// The error handler to emit errors to.
     pub errs: Lrc<Handler>,
+
+    /// The outcome of the last parse, if any.
+    last_parse: Cell<Option<bool>>,
+
+    /// The resources parsed from each module in earlier parses.
+    cached_resources: RefCell<HashMap<ModuleId, CachedResources>>,
+}
+
+/// The resources parsed from a module, which are reused
+/// as long as neither the module nor its imports change.
+struct CachedResources {
+    service_name: Option<String>,
+    resources: Vec<Resource>,
+    binds: Vec<UnresolvedBind>,
 }
 
 impl std::fmt::Debug for ParseContext {
@@ -104,8 +119,33 @@ impl ParseContext {
             type_checker,
             file_set,
             errs,
+            last_parse: Cell::new(None),
+            cached_resources: RefCell::new(HashMap::new()),
         })
     }
+
+    /// Prepares for parsing the app again after an earlier parse.
+    ///
+    /// After a successful parse only the modules whose files have changed are
+    /// parsed again, and only the modules importing them have their types and
+    /// resources resolved again. After a failed parse everything is parsed again,
+    /// since errors are only reported when the module causing them is parsed.
+    pub fn invalidate_changed(&self) {
+        let Some(succeeded) = self.last_parse.get() else {
+            return;
+        };
+
+        let affected = self.loader.drop_changed(!succeeded);
+        self.type_checker.invalidate_modules(&affected);
+        self.cached_resources
+            .borrow_mut()
+            .retain(|id, _| !affected.contains(id));
+    }
+
+    /// Records the outcome of a parse.
+    pub fn set_parse_result(&self, succeeded: bool) {
+        self.last_parse.set(Some(succeeded));
+    }
 }
 
 pub struct Parser<'a> {
@@ -211,7 +251,7 @@ impl<'a> Parser<'a> {
                 };
                 let module_span = module.ast.span();
                 let service_name = curr_service.as_ref().map(|(_, name)| name.as_str());
-                let (resources, binds) = self.pass1.parse(module, service_name)?;
+                let (resources, binds) = self.parse_resources(module, service_name)?;
 
                 // Is this a service file? If so, make sure there was a service defined.
                 if is_service(&entry) {
@@ -276,6 +316,49 @@ impl<'a> Parser<'a> {
         Ok(result)
     }
 
+    /// Parses the resources of a module, reusing those of an earlier parse
+    /// if neither the module nor its imports have changed since.
+    fn parse_resources(
+        &mut self,
+        module: Lrc<Module>,
+        service_name: Option<&str>,
+    ) -> Result<(Vec<Resource>, Vec<UnresolvedBind>)> {
+        if let Some(cached) = self.pc.cached_resources.borrow().get(&module.id) {
+            if cached.service_name.as_deref() == service_name {
+                let binds = cached
+                    .binds
+                    .iter()
+                    .map(|b| self.pass1.reuse_bind(b))
+                    .collect();
+                return Ok((cached.resources.clone(), binds));
+            }
+        }
+
+        let num_errs = self.pc.errs.err_count();
+        let (resources, binds) = self.pass1.parse(module.clone(), service_name)?;
+
+        // Don't reuse resources depending on other files than the app's modules,
+        // like database migrations and static assets, which may change independently.
+        let reusable = self.pc.errs.err_count() == num_errs
+            && resources.iter().all(|r| match r {
+                Resource::SQLDatabase(_) => false,
+                Resource::APIEndpoint(ep) => ep.static_assets.is_none(),
+                _ => true,
+            });
+        if reusable {
+            self.pc.cached_resources.borrow_mut().insert(
+                module.id,
+                CachedResources {
+                    service_name: service_name.map(str::to_owned),
+                    resources: resources.clone(),
+                    binds: binds.clone(),
+                },
+            );
+        }
+
+        Ok((resources, binds))
+    }
+
     fn inject_generated_service_clients(
         &mut self,
         services: &[DiscoveredService],
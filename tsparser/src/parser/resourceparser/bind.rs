@@ -17,7 +17,7 @@ impl From<u32> for Id {
     }
 }
 
-#[derive(Debug)]
+#[derive(Debug, Clone)]
 pub enum ResourceOrPath {
     Resource(Resource),
     Path(ResourcePath),
@@ -30,7 +30,7 @@ pub struct PassOneParser<'a> {
     next_id: u32,
 }
 
-#[derive(Debug)]
+#[derive(Debug, Clone)]
 pub struct UnresolvedBind {
     pub id: bind::Id,
     pub range: Option<Range>,
@@ -73,6 +73,14 @@ impl<'a> PassOneParser<'a> {
         self.next_id.into()
     }
 
+    /// Returns a copy of a bind from an earlier parse, with a new id.
+    pub fn reuse_bind(&mut self, bind: &UnresolvedBind) -> UnresolvedBind {
+        UnresolvedBind {
+            id: self.alloc_bind_id(),
+            ..bind.clone()
+        }
+    }
+
     pub fn parse(
         &mut self,
         module: Lrc<Module>,
@@ -4,6 +4,7 @@ use std::str::FromStr;
 use anyhow::{anyhow, bail, Context, Result};
 use swc_common::errors::HANDLER;
 use swc_common::sync::Lrc;
+use swc_common::Spanned;
 use swc_ecma_ast::TsTypeParamInstantiation;
 use swc_ecma_ast::{self as ast, FnExpr};
 
@@ -17,11 +18,13 @@ use crate::parser::resourceparser::resource_parser::ResourceParser;
 use crate::parser::resources::apis::encoding::{
     describe_endpoint, describe_static_assets, describe_stream_endpoint, EndpointEncoding,
 };
+use crate::parser::resources::apis::zod;
 use crate::parser::resources::parseutil::{
     extract_bind_name, iter_references, ReferenceParser, TrackedNames,
 };
 use crate::parser::resources::Resource;
 use crate::parser::respath::Path;
+use crate::parser::types::{Type, TypeChecker};
 use crate::parser::usageparser::{ResolveUsageData, Usage};
 use crate::parser::{FilePath, Range};
 
@@ -34,6 +37,12 @@ pub struct Endpoint {
     pub expose: bool,
     pub raw: bool,
     pub require_auth: bool,
+    pub tags: Vec<String>,
+
+    /// The exported class the endpoint is a decorated method of,
+    /// for endpoints defined with the method decorators like `@api.get`.
+    /// None for endpoints defined with `api()`.
+    pub controller: Option<String>,
 
     /// Body limit in bytes.
     /// None means no limit.
@@ -43,6 +52,10 @@ pub struct Endpoint {
     pub streaming_response: bool,
     pub static_assets: Option<StaticAssets>,
 
+    /// Flow control settings for streaming endpoints.
+    /// None means the defaults.
+    pub flow_control: Option<StreamFlowControl>,
+
     pub encoding: EndpointEncoding,
 }
 
@@ -156,6 +169,31 @@ pub struct StaticAssets {
     pub not_found: Option<PathBuf>,
 }
 
+/// Flow control settings for a streaming endpoint, limiting how many messages
+/// are buffered when one side sends faster than the other side reads.
+#[derive(Debug, Clone)]
+pub struct StreamFlowControl {
+    /// The maximum number of outgoing messages to buffer.
+    pub max_buffered_messages: Option<u32>,
+
+    /// The number of buffered outgoing messages at which sending waits.
+    pub high_water_mark: Option<u32>,
+
+    /// What to do when the outgoing buffer is full.
+    pub slow_consumer: SlowConsumerPolicy,
+
+    /// The maximum number of incoming messages to buffer.
+    pub max_buffered_incoming_messages: Option<u32>,
+}
+
+#[derive(Debug, Clone, Copy)]
+pub enum SlowConsumerPolicy {
+    Wait,
+    DropNewest,
+    DropOldest,
+    Disconnect,
+}
+
 pub const ENDPOINT_PARSER: ResourceParser = ResourceParser {
     name: "endpoint",
     interesting_pkgs: &[PkgPath("encore.dev/api")],
@@ -200,9 +238,10 @@ pub const ENDPOINT_PARSER: ResourceParser = ResourceParser {
                 }
             };
 
-            let object = pass
-                .type_checker
-                .resolve_obj(pass.module.clone(), &ast::Expr::Ident(r.bind_name.clone()));
+            let object = r.bind_name.as_ref().and_then(|bind_name| {
+                pass.type_checker
+                    .resolve_obj(pass.module.clone(), &ast::Expr::Ident(bind_name.clone()))
+            });
 
             let methods = r.config.method.unwrap_or(Methods::Some(vec![Method::Post]));
 
@@ -212,15 +251,42 @@ pub const ENDPOINT_PARSER: ResourceParser = ResourceParser {
             let mut streaming_response = false;
             let mut static_assets = None;
 
+            let flow_control = match r.config.flowControl.as_ref().map(|fc| fc.parse()) {
+                Some(Ok(_)) if !matches!(r.kind, EndpointKind::TypedStream { .. }) => {
+                    HANDLER.with(|h| {
+                        h.span_err(
+                            r.range,
+                            "flowControl is only supported for streaming endpoints",
+                        )
+                    });
+                    continue;
+                }
+                Some(Err(err)) => {
+                    HANDLER.with(|h| h.span_err(r.range, &err.to_string()));
+                    continue;
+                }
+                Some(Ok(fc)) => Some(fc),
+                None => None,
+            };
+
             let encoding = match r.kind {
                 EndpointKind::Typed { request, response } => {
-                    let request = match request {
-                        None => None,
-                        Some(t) => Some(pass.type_checker.resolve_type(module.clone(), &t)),
+                    let schema = r.config.schema.as_ref();
+                    let Ok(request) = resolve_endpoint_type(
+                        pass.type_checker,
+                        &module,
+                        schema.and_then(|s| s.request.as_ref()),
+                        request,
+                    ) else {
+                        continue;
                     };
-                    let response = match response {
-                        None => None,
-                        Some(t) => Some(pass.type_checker.resolve_type(module.clone(), &t)),
+                    let Ok(response) = resolve_endpoint_type(
+                        pass.type_checker,
+                        &module,
+                        schema.and_then(|s| s.response.as_ref()),
+                        response,
+                    ) else {
+                        continue;
                     };
 
                     match describe_endpoint(
@@ -361,10 +427,13 @@ pub const ENDPOINT_PARSER: ResourceParser = ResourceParser {
                 doc: r.doc_comment,
                 expose: r.config.expose.unwrap_or(false),
                 require_auth: r.config.auth.unwrap_or(false),
+                tags: r.config.tags.unwrap_or_default(),
+                controller: r.controller,
                 raw,
                 streaming_request,
                 streaming_response,
                 static_assets,
+                flow_control,
                 body_limit,
                 encoding,
             }));
@@ -375,7 +444,7 @@ pub const ENDPOINT_PARSER: ResourceParser = ResourceParser {
                 resource: ResourceOrPath::Resource(resource),
                 object,
                 kind: BindKind::Create,
-                ident: Some(r.bind_name),
+                ident: r.bind_name,
             });
         }
         Ok(())
@@ -414,7 +483,8 @@ struct APIEndpointLiteral {
     pub range: Range,
     pub doc_comment: Option<String>,
     pub endpoint_name: String,
-    pub bind_name: ast::Ident,
+    pub bind_name: Option<ast::Ident>,
+    pub controller: Option<String>,
     pub config: EndpointConfig,
     pub kind: EndpointKind,
 }
@@ -458,31 +528,96 @@ enum EndpointKind {
     Raw,
 }
 
-#[derive(LitParser, Debug)]
+#[derive(LitParser, Debug, Default)]
 #[allow(non_snake_case)]
 struct EndpointConfig {
     method: Option<Methods>,
     path: Option<String>,
     expose: Option<bool>,
     auth: Option<bool>,
+    tags: Option<Vec<String>>,
     bodyLimit: Option<Nullable<u64>>,
+    worker: Option<bool>,
+    schema: Option<EndpointSchema>,
+
+    // For streaming endpoints.
+    flowControl: Option<FlowControlConfig>,
 
     // For static assets.
     dir: Option<LocalRelPath>,
     notFound: Option<LocalRelPath>,
 }
 
+#[derive(LitParser, Debug)]
+#[allow(non_snake_case)]
+struct FlowControlConfig {
+    maxBufferedMessages: Option<u32>,
+    highWaterMark: Option<u32>,
+    slowConsumer: Option<String>,
+    maxBufferedIncomingMessages: Option<u32>,
+}
+
+impl FlowControlConfig {
+    fn parse(&self) -> Result<StreamFlowControl> {
+        let slow_consumer = match self.slowConsumer.as_deref() {
+            None | Some("wait") => SlowConsumerPolicy::Wait,
+            Some("drop-newest") => SlowConsumerPolicy::DropNewest,
+            Some("drop-oldest") => SlowConsumerPolicy::DropOldest,
+            Some("disconnect") => SlowConsumerPolicy::Disconnect,
+            Some(other) => anyhow::bail!("invalid slowConsumer policy: {}", other),
+        };
+
+        let sizes = [
+            ("maxBufferedMessages", self.maxBufferedMessages),
+            ("highWaterMark", self.highWaterMark),
+            (
+                "maxBufferedIncomingMessages",
+                self.maxBufferedIncomingMessages,
+            ),
+        ];
+        for (name, size) in sizes {
+            if size == Some(0) {
+                anyhow::bail!("{} must be greater than zero", name);
+            }
+        }
+        if let (Some(hwm), Some(max)) = (self.highWaterMark, self.maxBufferedMessages) {
+            if hwm > max {
+                anyhow::bail!("highWaterMark must not be greater than maxBufferedMessages");
+            }
+        }
+
+        Ok(StreamFlowControl {
+            max_buffered_messages: self.maxBufferedMessages,
+            high_water_mark: self.highWaterMark,
+            slow_consumer,
+            max_buffered_incoming_messages: self.maxBufferedIncomingMessages,
+        })
+    }
+}
+
+/// Zod schemas describing the request and response.
+#[derive(LitParser, Debug)]
+struct EndpointSchema {
+    request: Option<ast::Expr>,
+    response: Option<ast::Expr>,
+}
+
 impl ReferenceParser for APIEndpointLiteral {
     fn parse_resource_reference(
         module: &Module,
         path: &swc_ecma_visit::AstNodePath,
     ) -> Result<Option<Self>> {
-        for node in path.iter().rev() {
+        for (idx, node) in path.iter().rev().enumerate() {
             if let swc_ecma_visit::AstParentNodeRef::CallExpr(
                 expr,
                 swc_ecma_visit::fields::CallExprField::Callee,
             ) = node
             {
+                let idx = path.len() - idx - 1;
+                if is_decorator(path, idx) {
+                    return parse_decorated_method(module, path, idx, expr).map(Some);
+                }
+
                 let doc_comment = module.preceding_comments(expr.span.lo.into());
                 let Some(bind_name) = extract_bind_name(path)? else {
                     anyhow::bail!("API Endpoints must be bound to a variable")
@@ -504,12 +639,19 @@ impl ReferenceParser for APIEndpointLiteral {
                         let Some(_) = &expr.args.get(1) else {
                             anyhow::bail!("API Endpoint must have a handler function")
                         };
+                        if config.worker == Some(true) {
+                            anyhow::bail!("raw endpoints cannot run on the worker pool")
+                        }
+                        if config.schema.is_some() {
+                            anyhow::bail!("raw endpoints cannot have a schema")
+                        }
 
                         Self {
                             range: expr.span.into(),
                             doc_comment,
                             endpoint_name: bind_name.sym.to_string(),
-                            bind_name,
+                            bind_name: Some(bind_name),
+                            controller: None,
                             config,
                             kind: EndpointKind::Raw,
                         }
@@ -559,7 +701,8 @@ impl ReferenceParser for APIEndpointLiteral {
                             range: expr.span.into(),
                             doc_comment,
                             endpoint_name: bind_name.sym.to_string(),
-                            bind_name,
+                            bind_name: Some(bind_name),
+                            controller: None,
                             config,
                             kind: EndpointKind::TypedStream {
                                 handshake: handshake.cloned(),
@@ -619,7 +762,8 @@ impl ReferenceParser for APIEndpointLiteral {
                             range: expr.span.into(),
                             doc_comment,
                             endpoint_name: bind_name.sym.to_string(),
-                            bind_name,
+                            bind_name: Some(bind_name),
+                            controller: None,
                             config,
                             kind: EndpointKind::TypedStream {
                                 handshake: handshake.cloned(),
@@ -666,7 +810,8 @@ impl ReferenceParser for APIEndpointLiteral {
                             range: expr.span.into(),
                             doc_comment,
                             endpoint_name: bind_name.sym.to_string(),
-                            bind_name,
+                            bind_name: Some(bind_name),
+                            controller: None,
                             config,
                             kind: EndpointKind::TypedStream {
                                 handshake: handshake.cloned(),
@@ -691,7 +836,8 @@ impl ReferenceParser for APIEndpointLiteral {
                             range: expr.span.into(),
                             doc_comment,
                             endpoint_name: bind_name.sym.to_string(),
-                            bind_name,
+                            bind_name: Some(bind_name),
+                            controller: None,
                             config,
                             kind: EndpointKind::StaticAssets { dir, not_found },
                         }
@@ -714,7 +860,8 @@ impl ReferenceParser for APIEndpointLiteral {
                             range: expr.span.into(),
                             doc_comment,
                             endpoint_name: bind_name.sym.to_string(),
-                            bind_name,
+                            bind_name: Some(bind_name),
+                            controller: None,
                             config,
                             kind: EndpointKind::Typed {
                                 request: req.cloned(),
@@ -730,6 +877,157 @@ impl ReferenceParser for APIEndpointLiteral {
     }
 }
 
+/// Reports whether the call expression at path[idx] is a decorator.
+fn is_decorator(path: &swc_ecma_visit::AstNodePath, idx: usize) -> bool {
+    (0..idx)
+        .rev()
+        .filter_map(|i| path.get(i))
+        .find(|node| !matches!(node, swc_ecma_visit::AstParentNodeRef::Expr(..)))
+        .is_some_and(|node| matches!(node, swc_ecma_visit::AstParentNodeRef::Decorator(..)))
+}
+
+/// Parses an endpoint defined by a method decorated with one of
+/// the method decorators, like `@api.get("/users/:id")`, where expr
+/// at path[idx] is the decorator call.
+fn parse_decorated_method(
+    module: &Module,
+    path: &swc_ecma_visit::AstNodePath,
+    idx: usize,
+    expr: &ast::CallExpr,
+) -> Result<APIEndpointLiteral> {
+    let method = match &expr.callee {
+        ast::Callee::Expr(callee) => match callee.as_ref() {
+            ast::Expr::Member(ast::MemberExpr {
+                prop: ast::MemberProp::Ident(prop),
+                ..
+            }) => decorator_method(prop.sym.as_ref()),
+            _ => None,
+        },
+        _ => None,
+    };
+    let Some(method) = method else {
+        anyhow::bail!("invalid API decorator, expected one of the method decorators like @api.get")
+    };
+
+    // The decorator must be applied to a method: Decorator -> Function -> ClassMethod.
+    let mut ancestors = (0..idx)
+        .rev()
+        .filter_map(|i| path.get(i))
+        .skip_while(|node| !matches!(node, swc_ecma_visit::AstParentNodeRef::Decorator(..)))
+        .skip(1);
+    let (
+        Some(swc_ecma_visit::AstParentNodeRef::Function(..)),
+        Some(swc_ecma_visit::AstParentNodeRef::ClassMethod(class_method, ..)),
+    ) = (ancestors.next(), ancestors.next())
+    else {
+        anyhow::bail!("API decorators can only be applied to class methods")
+    };
+    let class_name = ancestors.find_map(|node| match node {
+        swc_ecma_visit::AstParentNodeRef::ClassDecl(decl, ..) => Some(decl.ident.sym.to_string()),
+        _ => None,
+    });
+    let Some(class_name) = class_name.filter(|name| is_exported_class(module, name)) else {
+        anyhow::bail!("API decorators can only be applied to methods of exported classes")
+    };
+
+    if class_method.is_static {
+        anyhow::bail!("API decorators cannot be applied to static methods")
+    }
+    if !matches!(class_method.kind, ast::MethodKind::Method) {
+        anyhow::bail!("API decorators cannot be applied to getters or setters")
+    }
+    let ast::PropName::Ident(method_name) = &class_method.key else {
+        anyhow::bail!("API decorators can only be applied to methods with a plain name")
+    };
+
+    let Some(path_arg) = expr.args.first() else {
+        anyhow::bail!("API decorator must have a path")
+    };
+    let mut config = expr
+        .args
+        .get(1)
+        .map(|arg| EndpointConfig::parse_lit(arg.expr.as_ref()))
+        .transpose()?
+        .unwrap_or_default();
+    if config.method.is_some() || config.path.is_some() {
+        anyhow::bail!("the method and path of decorated endpoints are set by the decorator")
+    }
+    config.method = Some(Methods::Some(vec![method]));
+    config.path = Some(String::parse_lit(path_arg.expr.as_ref())?);
+
+    let function = &class_method.function;
+    let (req, resp) = parse_function_signature(function)?;
+
+    // Doc comments are placed before the decorators.
+    let start = function
+        .decorators
+        .first()
+        .map_or(class_method.span.lo, |d| d.span.lo);
+
+    Ok(APIEndpointLiteral {
+        range: class_method.span.into(),
+        doc_comment: module.preceding_comments(start.into()),
+        endpoint_name: method_name.sym.to_string(),
+        bind_name: None,
+        controller: Some(class_name),
+        config,
+        kind: EndpointKind::Typed {
+            request: req.cloned(),
+            response: resp.cloned(),
+        },
+    })
+}
+
+/// Returns the HTTP method of the method decorator with the given name.
+fn decorator_method(name: &str) -> Option<Method> {
+    Some(match name {
+        "get" => Method::Get,
+        "post" => Method::Post,
+        "put" => Method::Put,
+        "patch" => Method::Patch,
+        "delete" => Method::Delete,
+        "head" => Method::Head,
+        "options" => Method::Options,
+        _ => return None,
+    })
+}
+
+/// Reports whether the module exports a class declaration with the given name.
+fn is_exported_class(module: &Module, name: &str) -> bool {
+    module.ast.body.iter().any(|item| {
+        matches!(
+            item,
+            ast::ModuleItem::ModuleDecl(ast::ModuleDecl::ExportDecl(ast::ExportDecl {
+                decl: ast::Decl::Class(class),
+                ..
+            })) if class.ident.sym.as_ref() == name
+        )
+    })
+}
+
+/// Resolves an endpoint's request or response type.
+/// A type derived from the schema takes precedence over the type in the handler signature,
+/// which is used for schemas the type can't be derived from, like non-Zod schemas.
+/// Errors are reported to the handler.
+fn resolve_endpoint_type(
+    type_checker: &TypeChecker,
+    module: &Lrc<Module>,
+    schema: Option<&ast::Expr>,
+    typ: Option<ast::TsType>,
+) -> std::result::Result<Option<Type>, ()> {
+    if let Some(expr) = schema {
+        match (zod::schema_type(type_checker, module.clone(), expr), &typ) {
+            (Ok(typ), _) => return Ok(Some(typ)),
+            (Err(_), Some(_)) => {}
+            (Err(err), None) => {
+                HANDLER.with(|handler| handler.span_err(expr.span(), &format!("{:#}", err)));
+                return Err(());
+            }
+        }
+    }
+    Ok(typ.map(|typ| type_checker.resolve_type(module.clone(), &typ)))
+}
+
 fn parse_stream_endpoint_signature(expr: &ast::Expr) -> Result<(bool, Option<&ast::TsType>)> {
     let (has_handshake_param, type_params, return_type) = match expr {
         ast::Expr::Fn(FnExpr { function, .. }) => (
@@ -757,20 +1055,32 @@ fn parse_stream_endpoint_signature(expr: &ast::Expr) -> Result<(bool, Option<&as
 fn parse_endpoint_signature(
     expr: &ast::Expr,
 ) -> Result<(Option<&ast::TsType>, Option<&ast::TsType>)> {
-    let (req_param, type_params, return_type) = match expr {
-        ast::Expr::Fn(func) => (
-            func.function.params.first().map(|p| &p.pat),
-            func.function.type_params.as_deref(),
-            func.function.return_type.as_deref(),
-        ),
-        ast::Expr::Arrow(arrow) => (
+    match expr {
+        ast::Expr::Fn(func) => parse_function_signature(&func.function),
+        ast::Expr::Arrow(arrow) => signature_types(
             arrow.params.first(),
             arrow.type_params.as_deref(),
             arrow.return_type.as_deref(),
         ),
-        _ => return Ok((None, None)),
-    };
+        _ => Ok((None, None)),
+    }
+}
+
+fn parse_function_signature(
+    function: &ast::Function,
+) -> Result<(Option<&ast::TsType>, Option<&ast::TsType>)> {
+    signature_types(
+        function.params.first().map(|p| &p.pat),
+        function.type_params.as_deref(),
+        function.return_type.as_deref(),
+    )
+}
 
+fn signature_types<'a>(
+    req_param: Option<&'a ast::Pat>,
+    type_params: Option<&'a ast::TsTypeParamDecl>,
+    return_type: Option<&'a ast::TsTypeAnn>,
+) -> Result<(Option<&'a ast::TsType>, Option<&'a ast::TsType>)> {
     if type_params.is_some() {
         anyhow::bail!("endpoint handler cannot have type parameters");
     }
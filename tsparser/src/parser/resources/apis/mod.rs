@@ -4,3 +4,4 @@ pub mod encoding;
 pub mod gateway;
 pub mod service;
 pub mod service_client;
+mod zod;
@@ -20,7 +20,11 @@ pub struct Service {
 }
 
 #[derive(LitParser, Default)]
-struct DecodedServiceConfig {}
+struct DecodedServiceConfig {
+    // The middleware are only used at runtime.
+    #[allow(dead_code)]
+    middlewares: Option<ast::Expr>,
+}
 
 pub static SERVICE_PARSER: ResourceParser = ResourceParser {
     name: "service",
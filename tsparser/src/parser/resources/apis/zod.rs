@@ -0,0 +1,390 @@
+use anyhow::{bail, Context, Result};
+use swc_common::sync::Lrc;
+use swc_common::Spanned;
+use swc_ecma_ast as ast;
+
+use crate::parser::module_loader::Module;
+use crate::parser::types::{
+    Basic, FieldName, Interface, InterfaceField, Literal, ObjectKind, Type, TypeChecker,
+};
+
+/// The maximum number of schema variables to follow when resolving a schema,
+/// to guard against cyclic definitions.
+const MAX_DEPTH: usize = 32;
+
+/// Derives the type described by a Zod schema expression,
+/// like `z.object({ name: z.string() })`.
+///
+/// Schemas may reference other schemas declared as variables,
+/// in the same module or imported from other modules.
+pub fn schema_type(
+    type_checker: &TypeChecker,
+    module: Lrc<Module>,
+    expr: &ast::Expr,
+) -> Result<Type> {
+    let resolver = SchemaResolver { type_checker };
+    Ok(resolver.schema(&module, expr, 0)?.typ)
+}
+
+struct SchemaResolver<'a> {
+    type_checker: &'a TypeChecker,
+}
+
+/// A resolved schema.
+struct Schema {
+    typ: Type,
+    /// Whether the value may be omitted, when used as an object field.
+    optional: bool,
+}
+
+impl From<Type> for Schema {
+    fn from(typ: Type) -> Self {
+        Schema {
+            typ,
+            optional: false,
+        }
+    }
+}
+
+impl SchemaResolver<'_> {
+    fn schema(&self, module: &Lrc<Module>, expr: &ast::Expr, depth: usize) -> Result<Schema> {
+        match expr {
+            ast::Expr::Paren(paren) => self.schema(module, &paren.expr, depth),
+            ast::Expr::Ident(ident) => self.schema_var(module, ident, depth),
+            ast::Expr::Call(call) => self.schema_call(module, call, depth),
+            _ => bail!("unsupported schema expression"),
+        }
+    }
+
+    /// Resolves a schema declared as a variable.
+    fn schema_var(&self, module: &Lrc<Module>, ident: &ast::Ident, depth: usize) -> Result<Schema> {
+        if depth >= MAX_DEPTH {
+            bail!("schema {} is too deeply nested", ident.sym);
+        }
+
+        let obj = self
+            .type_checker
+            .resolve_obj(module.clone(), &ast::Expr::Ident(ident.clone()))
+            .with_context(|| format!("unable to resolve schema {}", ident.sym))?;
+        let ObjectKind::Var(var) = &obj.kind else {
+            bail!("{} is not a schema variable", ident.sym);
+        };
+        let Some(init) = &var.expr else {
+            bail!("schema {} must be initialized", ident.sym);
+        };
+        let obj_module = self
+            .type_checker
+            .module(obj.module_id)
+            .with_context(|| format!("unable to resolve the module of schema {}", ident.sym))?;
+        self.schema(&obj_module, init, depth + 1)
+    }
+
+    fn schema_call(
+        &self,
+        module: &Lrc<Module>,
+        call: &ast::CallExpr,
+        depth: usize,
+    ) -> Result<Schema> {
+        let ast::Callee::Expr(callee) = &call.callee else {
+            bail!("unsupported schema expression");
+        };
+        let ast::Expr::Member(member) = callee.as_ref() else {
+            bail!("unsupported schema expression");
+        };
+        let ast::MemberProp::Ident(method) = &member.prop else {
+            bail!("unsupported schema expression");
+        };
+        let method = method.sym.as_ref();
+
+        // z.string(), z.coerce.number(), ...
+        if self.is_zod_namespace(module, &member.obj) {
+            return self.constructor(module, method, call, depth);
+        }
+
+        // z.string().optional(), ...
+        let base = self.schema(module, &member.obj, depth)?;
+        self.modifier(module, base, method, call, depth)
+    }
+
+    /// Reports whether expr refers to the Zod namespace, like `z` or `z.coerce`.
+    fn is_zod_namespace(&self, module: &Lrc<Module>, expr: &ast::Expr) -> bool {
+        match expr {
+            ast::Expr::Ident(ident) => module.imports().iter().any(|imp| {
+                is_zod_import(&imp.src.value)
+                    && imp.specifiers.iter().any(|spec| match spec {
+                        ast::ImportSpecifier::Named(named) => named.local.sym == ident.sym,
+                        ast::ImportSpecifier::Default(def) => def.local.sym == ident.sym,
+                        ast::ImportSpecifier::Namespace(ns) => ns.local.sym == ident.sym,
+                    })
+            }),
+            ast::Expr::Member(member) => {
+                matches!(&member.prop, ast::MemberProp::Ident(prop) if prop.sym.as_ref() == "coerce")
+                    && self.is_zod_namespace(module, &member.obj)
+            }
+            _ => false,
+        }
+    }
+
+    /// Resolves a schema created with a function in the Zod namespace.
+    fn constructor(
+        &self,
+        module: &Lrc<Module>,
+        method: &str,
+        call: &ast::CallExpr,
+        depth: usize,
+    ) -> Result<Schema> {
+        let typ = match method {
+            "string" => Type::Basic(Basic::String),
+            "number" => Type::Basic(Basic::Number),
+            "boolean" => Type::Basic(Basic::Boolean),
+            "bigint" => Type::Basic(Basic::BigInt),
+            "any" => Type::Basic(Basic::Any),
+            "unknown" => Type::Basic(Basic::Unknown),
+            "null" => Type::Basic(Basic::Null),
+            "undefined" => Type::Basic(Basic::Undefined),
+            "literal" => Type::Literal(literal(arg(call, 0)?)?),
+            "enum" => {
+                let values = array_elems(arg(call, 0)?)?
+                    .into_iter()
+                    .map(|e| literal(e).map(Type::Literal))
+                    .collect::<Result<Vec<_>>>()?;
+                Type::Union(values)
+            }
+            "object" | "strictObject" | "looseObject" => {
+                Type::Interface(self.object(module, arg(call, 0)?, depth)?)
+            }
+            "array" => Type::Array(Box::new(self.schema(module, arg(call, 0)?, depth)?.typ)),
+            "tuple" => Type::Tuple(
+                array_elems(arg(call, 0)?)?
+                    .into_iter()
+                    .map(|e| self.schema(module, e, depth).map(|s| s.typ))
+                    .collect::<Result<Vec<_>>>()?,
+            ),
+            "union" => Type::Union(
+                array_elems(arg(call, 0)?)?
+                    .into_iter()
+                    .map(|e| self.schema(module, e, depth).map(|s| s.typ))
+                    .collect::<Result<Vec<_>>>()?,
+            ),
+            "discriminatedUnion" => Type::Union(
+                array_elems(arg(call, 1)?)?
+                    .into_iter()
+                    .map(|e| self.schema(module, e, depth).map(|s| s.typ))
+                    .collect::<Result<Vec<_>>>()?,
+            ),
+            "record" => {
+                // z.record(valueSchema) or z.record(keySchema, valueSchema).
+                let (key, value) = match call.args.len() {
+                    1 => (Type::Basic(Basic::String), arg(call, 0)?),
+                    _ => (
+                        self.schema(module, arg(call, 0)?, depth)?.typ,
+                        arg(call, 1)?,
+                    ),
+                };
+                Type::Interface(Interface {
+                    fields: vec![],
+                    index: Some((
+                        Box::new(key),
+                        Box::new(self.schema(module, value, depth)?.typ),
+                    )),
+                    call: None,
+                })
+            }
+            "optional" => {
+                let mut schema = self.schema(module, arg(call, 0)?, depth)?;
+                schema.optional = true;
+                return Ok(schema);
+            }
+            "nullable" => nullable(self.schema(module, arg(call, 0)?, depth)?.typ),
+            _ => bail!("unsupported schema type z.{}", method),
+        };
+        Ok(typ.into())
+    }
+
+    /// Resolves a schema method call on base, like `.optional()`.
+    fn modifier(
+        &self,
+        module: &Lrc<Module>,
+        mut base: Schema,
+        method: &str,
+        call: &ast::CallExpr,
+        depth: usize,
+    ) -> Result<Schema> {
+        match method {
+            // Defaults are applied when validating the request,
+            // so the field may be omitted.
+            "optional" | "default" | "catch" => base.optional = true,
+            "nullable" => base.typ = nullable(base.typ),
+            "nullish" => {
+                base.typ = nullable(base.typ);
+                base.optional = true;
+            }
+            "array" => base = Type::Array(Box::new(base.typ)).into(),
+            "or" => {
+                let other = self.schema(module, arg(call, 0)?, depth)?;
+                base = Type::Union(vec![base.typ, other.typ]).into();
+            }
+            "extend" | "merge" | "partial" | "required" | "pick" | "omit" | "strict"
+            | "passthrough" | "strip" => {
+                let Type::Interface(mut iface) = base.typ else {
+                    bail!(".{}() is only supported on object schemas", method);
+                };
+                match method {
+                    "extend" => {
+                        let ext = self.object(module, arg(call, 0)?, depth)?;
+                        merge_fields(&mut iface, ext);
+                    }
+                    "merge" => {
+                        let other = self.schema(module, arg(call, 0)?, depth)?;
+                        let Type::Interface(other) = other.typ else {
+                            bail!(".merge() requires an object schema");
+                        };
+                        merge_fields(&mut iface, other);
+                    }
+                    "partial" | "required" => {
+                        for f in &mut iface.fields {
+                            f.optional = method == "partial";
+                        }
+                    }
+                    "pick" | "omit" => {
+                        let keys = object_keys(arg(call, 0)?)?;
+                        iface.fields.retain(|f| match &f.name {
+                            FieldName::String(name) => keys.contains(name) == (method == "pick"),
+                            FieldName::Symbol(_) => method == "omit",
+                        });
+                    }
+                    _ => {}
+                }
+                base.typ = Type::Interface(iface);
+            }
+            // These change the type of the validated value,
+            // so it can't be derived from the schema.
+            "transform" | "pipe" | "brand" | "readonly" | "promise" => {
+                bail!(".{}() is not supported in endpoint schemas", method)
+            }
+            // Refinements like .min(), .email() and .refine()
+            // validate the value without changing its type.
+            _ => {}
+        }
+        Ok(base)
+    }
+
+    fn object(&self, module: &Lrc<Module>, expr: &ast::Expr, depth: usize) -> Result<Interface> {
+        let ast::Expr::Object(lit) = expr else {
+            bail!("object schema must be defined with an object literal");
+        };
+
+        let mut fields = Vec::with_capacity(lit.props.len());
+        for prop in &lit.props {
+            let ast::PropOrSpread::Prop(prop) = prop else {
+                bail!("spread is not supported in object schemas");
+            };
+            let (name, schema) = match prop.as_ref() {
+                ast::Prop::KeyValue(kv) => {
+                    let name = match &kv.key {
+                        ast::PropName::Ident(id) => id.sym.to_string(),
+                        ast::PropName::Str(str) => str.value.to_string(),
+                        _ => bail!("unsupported object schema key"),
+                    };
+                    (name, self.schema(module, &kv.value, depth)?)
+                }
+                ast::Prop::Shorthand(id) => {
+                    (id.sym.to_string(), self.schema_var(module, id, depth)?)
+                }
+                _ => bail!("unsupported object schema property"),
+            };
+            fields.push(InterfaceField {
+                range: prop.span().into(),
+                name: FieldName::String(name),
+                optional: schema.optional,
+                typ: schema.typ,
+            });
+        }
+
+        Ok(Interface {
+            fields,
+            index: None,
+            call: None,
+        })
+    }
+}
+
+fn is_zod_import(src: &str) -> bool {
+    matches!(
+        src,
+        "zod" | "zod/v4" | "zod/v3" | "zod/mini" | "zod/v4-mini"
+    )
+}
+
+fn nullable(typ: Type) -> Type {
+    Type::Union(vec![typ, Type::Basic(Basic::Null)])
+}
+
+/// Adds the fields of other to iface, replacing any existing fields with the same name.
+fn merge_fields(iface: &mut Interface, other: Interface) {
+    for field in other.fields {
+        iface.fields.retain(|f| !same_name(&f.name, &field.name));
+        iface.fields.push(field);
+    }
+}
+
+fn same_name(a: &FieldName, b: &FieldName) -> bool {
+    match (a, b) {
+        (FieldName::String(a), FieldName::String(b)) => a == b,
+        _ => false,
+    }
+}
+
+fn arg(call: &ast::CallExpr, idx: usize) -> Result<&ast::Expr> {
+    match call.args.get(idx) {
+        Some(arg) if arg.spread.is_none() => Ok(&arg.expr),
+        Some(_) => bail!("spread arguments are not supported in schemas"),
+        None => bail!("missing schema argument"),
+    }
+}
+
+fn array_elems(expr: &ast::Expr) -> Result<Vec<&ast::Expr>> {
+    let ast::Expr::Array(arr) = expr else {
+        bail!("expected an array literal");
+    };
+    arr.elems
+        .iter()
+        .map(|elem| match elem {
+            Some(ast::ExprOrSpread { spread: None, expr }) => Ok(expr.as_ref()),
+            _ => bail!("unsupported array element"),
+        })
+        .collect()
+}
+
+fn literal(expr: &ast::Expr) -> Result<Literal> {
+    Ok(match expr {
+        ast::Expr::Lit(ast::Lit::Str(str)) => Literal::String(str.value.to_string()),
+        ast::Expr::Lit(ast::Lit::Num(num)) => Literal::Number(num.value),
+        ast::Expr::Lit(ast::Lit::Bool(b)) => Literal::Boolean(b.value),
+        _ => bail!("schema literals must be strings, numbers or booleans"),
+    })
+}
+
+/// Returns the keys of an object literal like `{ name: true }`.
+fn object_keys(expr: &ast::Expr) -> Result<Vec<String>> {
+    let ast::Expr::Object(lit) = expr else {
+        bail!("expected an object literal");
+    };
+    lit.props
+        .iter()
+        .map(|prop| match prop {
+            ast::PropOrSpread::Prop(prop) => match prop.as_ref() {
+                ast::Prop::KeyValue(ast::KeyValueProp {
+                    key: ast::PropName::Ident(id),
+                    ..
+                }) => Ok(id.sym.to_string()),
+                ast::Prop::KeyValue(ast::KeyValueProp {
+                    key: ast::PropName::Str(str),
+                    ..
+                }) => Ok(str.value.to_string()),
+                _ => bail!("unsupported object key"),
+            },
+            ast::PropOrSpread::Spread(_) => bail!("spread is not supported"),
+        })
+        .collect()
+}
@@ -43,6 +43,8 @@ struct DecodedSubscriptionConfig {
     ackDeadline: Option<std::time::Duration>,
     messageRetention: Option<std::time::Duration>,
     retryPolicy: Option<DecodedRetryPolicy>,
+    #[allow(dead_code)]
+    worker: Option<bool>,
 }
 
 #[allow(non_snake_case)]
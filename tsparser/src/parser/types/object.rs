@@ -1,5 +1,5 @@
 use std::cell::{Cell, OnceCell, RefCell};
-use std::collections::HashMap;
+use std::collections::{HashMap, HashSet};
 use std::fmt::Debug;
 use std::hash::Hash;
 use std::rc::Rc;
@@ -547,6 +547,12 @@ impl ResolveState {
         })
     }
 
+    /// Drops the type information of the given modules,
+    /// so it's resolved again the next time it's needed.
+    pub(super) fn drop_modules(&self, ids: &HashSet<ModuleId>) {
+        self.modules.borrow_mut().retain(|id, _| !ids.contains(id));
+    }
+
     pub(super) fn lookup_module(&self, id: ModuleId) -> Option<Rc<Module>> {
         self.modules.borrow().get(&id).cloned()
     }
@@ -1,5 +1,6 @@
 use std::borrow::Cow;
 use std::borrow::Cow::{Borrowed, Owned};
+use std::collections::HashSet;
 use std::fmt::Debug;
 use std::ops::Deref;
 use std::rc::Rc;
@@ -71,6 +72,17 @@ impl TypeChecker {
         ctx.resolve_obj(expr)
     }
 
+    /// Drops the type information of the given modules, for when they
+    /// or the modules they import have changed.
+    pub fn invalidate_modules(&self, ids: &HashSet<ModuleId>) {
+        self.ctx.drop_modules(ids);
+    }
+
+    /// Returns the module with the given id, if it has been initialized.
+    pub fn module(&self, id: ModuleId) -> Option<Lrc<module_loader::Module>> {
+        self.ctx.lookup_module(id).map(|m| m.base.clone())
+    }
+
     pub fn resolve_obj_type(&self, obj: &Object) -> Type {
         let ctx = Ctx::new(&self.ctx, obj.module_id);
         ctx.obj_type(obj)
@@ -509,7 +509,10 @@ export const Bar = 5;
                 expose: true,
                 raw: false,
                 require_auth: false,
+                tags: vec![],
+                controller: None,
                 body_limit: None,
+                flow_control: None,
                 encoding: EndpointEncoding {
                     default_method: Method::Post,
                     methods: Methods::Some(vec![Method::Post]),
@@ -594,7 +597,10 @@ export const Bar = 5;
                 expose: true,
                 raw: false,
                 require_auth: false,
+                tags: vec![],
+                controller: None,
                 body_limit: None,
+                flow_control: None,
                 encoding: EndpointEncoding {
                     default_method: Method::Post,
                     methods: Methods::Some(vec![Method::Post]),
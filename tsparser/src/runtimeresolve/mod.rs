@@ -1,6 +1,7 @@
 mod exports;
 mod node;
 mod tsconfig;
+mod workspace;
 
 pub use node::EncoreRuntimeResolver;
 pub use tsconfig::TsConfigPathResolver;
@@ -14,21 +14,38 @@ use swc_ecma_loader::resolve::Resolve;
 
 use crate::runtimeresolve::exports::Exports;
 use crate::runtimeresolve::tsconfig::TsConfigPathResolver;
+use crate::runtimeresolve::workspace;
 
 static PACKAGE: &str = "package.json";
 
 #[derive(Deserialize)]
 struct PackageJson {
-    #[allow(dead_code)]
     #[serde(default)]
     main: Option<String>,
-    #[allow(dead_code)]
     #[serde(default)]
     module: Option<String>,
     #[serde(default)]
+    types: Option<String>,
+    #[serde(default)]
+    typings: Option<String>,
+    #[serde(default)]
     exports: Option<Exports>,
 }
 
+fn read_package_json(pkg_dir: &Path) -> Result<PackageJson, Error> {
+    let package_json_path = pkg_dir.join(PACKAGE);
+    if !package_json_path.is_file() {
+        bail!("package.json not found: {}", package_json_path.display());
+    }
+
+    let file = File::open(&package_json_path)?;
+    let reader = BufReader::new(file);
+    serde_json::from_reader(reader).context(format!(
+        "failed to deserialize {}",
+        package_json_path.display()
+    ))
+}
+
 #[derive(Debug)]
 pub struct EncoreRuntimeResolver<R> {
     inner: R,
@@ -58,31 +75,85 @@ impl<R> EncoreRuntimeResolver<R> {
 
     /// Resolve a path from the "exports" directive in the package.json file, if present.
     fn resolve_export(&self, pkg_dir: &Path, rel_target: &str) -> Result<Option<PathBuf>, Error> {
-        let package_json_path = pkg_dir.join(PACKAGE);
-        if !package_json_path.is_file() {
-            bail!("package.json not found: {}", package_json_path.display());
-        }
-
-        let file = File::open(&package_json_path)?;
-        let reader = BufReader::new(file);
-        let pkg: PackageJson = serde_json::from_reader(reader).context(format!(
-            "failed to deserialize {}",
-            package_json_path.display()
-        ))?;
-
+        let pkg = read_package_json(pkg_dir)?;
         let Some(exports) = &pkg.exports else {
-            bail!("no exports field in {}", package_json_path.display());
+            bail!("no exports field in {}", pkg_dir.join(PACKAGE).display());
         };
+        Ok(self.resolve_exports(pkg_dir, exports, rel_target))
+    }
 
+    fn resolve_exports(
+        &self,
+        pkg_dir: &Path,
+        exports: &Exports,
+        rel_target: &str,
+    ) -> Option<PathBuf> {
         let mut conditions =
             HashSet::from_iter(self.extra_export_conditions.iter().map(|s| s.as_str()));
         conditions.extend(DEFAULT_CONDITIONS);
 
         // The result is relative to the package directory, whereas we want to return an absolute path.
-        let result = exports
+        exports
             .resolve_import_path(rel_target, &conditions)
-            .map(|p| p.to_path_buf());
-        Ok(result.map(|p| pkg_dir.join(p)))
+            .map(|p| pkg_dir.join(p))
+    }
+
+    /// Resolves an import of a workspace package to the package's source files, e.g.
+    /// "@acme/shared/utils" to "/repo/packages/shared/src/utils.ts".
+    ///
+    /// Resolving workspace packages to their real location rather than through their
+    /// symlink in node_modules lets their modules be shared with imports using relative
+    /// paths, and resolving the "types" entrypoint lets workspace packages be used
+    /// without being built first.
+    fn resolve_workspace_import(
+        &self,
+        base: &FileName,
+        target: &str,
+    ) -> Result<Option<FileName>, Error> {
+        let FileName::Real(base_path) = base else {
+            return Ok(None);
+        };
+        let Some(Component::Normal(_)) = Path::new(target).components().next() else {
+            return Ok(None);
+        };
+        if target.contains(':') {
+            // A "node:" or "npm:" specifier.
+            return Ok(None);
+        }
+
+        let (pkg_name, pkg_path) = self.pkg_name_from_target(target);
+        let Some(pkg_dir) = base_path
+            .parent()
+            .and_then(|dir| workspace::find_workspace_package(dir, pkg_name))
+        else {
+            return Ok(None);
+        };
+
+        let pkg = match read_package_json(&pkg_dir) {
+            Ok(pkg) => Some(pkg),
+            Err(_) if !pkg_dir.join(PACKAGE).exists() => None,
+            Err(err) => return Err(err),
+        };
+        let entry = match pkg {
+            Some(PackageJson {
+                exports: Some(exports),
+                ..
+            }) => self
+                .resolve_exports(&pkg_dir, &exports, pkg_path)
+                .with_context(|| format!("{} is not exported by {}", target, pkg_dir.display()))?,
+            _ if !pkg_path.is_empty() => pkg_dir.join(pkg_path),
+            Some(pkg) => match pkg.types.or(pkg.typings).or(pkg.module).or(pkg.main) {
+                Some(entry) => pkg_dir.join(entry),
+                None => pkg_dir,
+            },
+            None => pkg_dir,
+        };
+
+        let entry = entry.clean();
+        let entry = entry
+            .to_str()
+            .with_context(|| format!("invalid path {}", entry.display()))?;
+        self.inner.resolve(base, entry).map(Some)
     }
 
     /// Resolve the package name from a target import path, e.g.:
@@ -139,8 +210,12 @@ where
             }
         }
 
-        match self.resolve_encore_module(target)? {
-            Some(buf) => Ok(FileName::Real(buf.clean())),
+        if let Some(buf) = self.resolve_encore_module(target)? {
+            return Ok(FileName::Real(buf.clean()));
+        }
+
+        match self.resolve_workspace_import(base, target)? {
+            Some(file_name) => Ok(file_name),
             None => self.inner.resolve(base, target),
         }
     }
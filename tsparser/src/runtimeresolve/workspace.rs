@@ -0,0 +1,90 @@
+use std::io;
+use std::path::{Path, PathBuf};
+
+use clean_path::Clean;
+
+/// Finds the directory of the workspace package named pkg_name,
+/// as seen from an import in from_dir.
+///
+/// Package managers link workspace packages into node_modules with a symlink
+/// to the package's source directory, which is how they're told apart from
+/// installed packages. Like Node.js, the closest node_modules directory containing
+/// the package wins. It returns None if that isn't a workspace package.
+pub fn find_workspace_package(from_dir: &Path, pkg_name: &str) -> Option<PathBuf> {
+    for dir in from_dir.ancestors() {
+        let link = dir.join("node_modules").join(pkg_name);
+        if link.symlink_metadata().is_err() {
+            continue;
+        }
+
+        // The package manager may link installed packages too, like pnpm does
+        // from its store, so only consider links to outside node_modules.
+        let target = read_link(&link).ok().flatten()?;
+        let target = link.parent()?.join(target).clean();
+        let in_node_modules = target.components().any(|c| c.as_os_str() == "node_modules");
+        return (!in_node_modules && target.is_dir()).then_some(target);
+    }
+    None
+}
+
+#[cfg(not(windows))]
+fn read_link(src: &Path) -> io::Result<Option<PathBuf>> {
+    if src.symlink_metadata()?.is_symlink() {
+        return std::fs::read_link(src).map(Some);
+    }
+    Ok(None)
+}
+
+#[cfg(windows)]
+fn read_link(src: &Path) -> io::Result<Option<PathBuf>> {
+    if src.symlink_metadata()?.is_symlink() {
+        return std::fs::read_link(src).map(Some);
+    }
+
+    // npm links workspace packages with junctions on Windows.
+    if junction::exists(src)? {
+        return junction::get_target(src).map(Some);
+    }
+    Ok(None)
+}
+
+#[cfg(all(test, not(windows)))]
+mod tests {
+    use super::*;
+    use std::fs;
+
+    #[test]
+    fn finds_linked_packages() {
+        let tmp = tempdir::TempDir::new("workspace").unwrap();
+        let root = tmp.path();
+
+        fs::create_dir_all(root.join("packages/shared/src")).unwrap();
+        fs::create_dir_all(root.join("apps/backend/svc")).unwrap();
+        fs::create_dir_all(root.join("node_modules/@acme")).unwrap();
+        fs::create_dir_all(root.join("node_modules/.pnpm/left-pad@1.0.0/node_modules/left-pad"))
+            .unwrap();
+        fs::create_dir_all(root.join("node_modules/lodash")).unwrap();
+
+        // Workspace packages are linked relative to the link's directory.
+        symlink::symlink_dir(
+            "../../packages/shared",
+            root.join("node_modules/@acme/shared"),
+        )
+        .unwrap();
+        // Installed packages are linked into the package manager's store.
+        symlink::symlink_dir(
+            ".pnpm/left-pad@1.0.0/node_modules/left-pad",
+            root.join("node_modules/left-pad"),
+        )
+        .unwrap();
+
+        let from = root.join("apps/backend/svc");
+        assert_eq!(
+            find_workspace_package(&from, "@acme/shared"),
+            Some(root.join("packages/shared"))
+        );
+        assert_eq!(find_workspace_package(&from, "left-pad"), None);
+        assert_eq!(find_workspace_package(&from, "lodash"), None);
+        assert_eq!(find_workspace_package(&from, "missing"), None);
+    }
+}
@@ -71,3 +71,90 @@ fn parse_txtar(app_root: &Path) -> Result<app::AppDesc> {
         })
     })
 }
+
+#[test]
+fn test_reparse() {
+    let tmp_dir = TempDir::new("reparse").unwrap();
+    let app_root = tmp_dir.path();
+    let write = |name: &str, src: &str| {
+        let path = app_root.join(name);
+        fs::create_dir_all(path.parent().unwrap()).unwrap();
+        fs::write(path, src).unwrap();
+    };
+
+    write(
+        "package.json",
+        r#"{"name": "reparse", "dependencies": {"encore.dev": "^1.35.0"}}"#,
+    );
+    write(
+        "svc/encore.service.ts",
+        "import { Service } from \"encore.dev/service\";\nexport default new Service(\"svc\");\n",
+    );
+    write("svc/types.ts", "export interface Params { id: string; }\n");
+    let api = "import { api } from \"encore.dev/api\";\nimport { Params } from \"./types\";\n\
+        export const get = api({}, async (p: Params): Promise<void> => {});\n";
+    write("svc/api.ts", api);
+
+    let globals = Globals::new();
+    let cm: Rc<SourceMap> = Default::default();
+    let errs = Rc::new(Handler::with_tty_emitter(
+        swc_common::errors::ColorConfig::Auto,
+        true,
+        false,
+        Some(cm.clone()),
+    ));
+
+    GLOBALS.set(&globals, || {
+        HANDLER.set(&errs, || {
+            let builder = Builder::new().unwrap();
+            let pc = ParseContext::new(
+                app_root.to_path_buf(),
+                js_runtime_path(),
+                cm.clone(),
+                errs.clone(),
+            )
+            .unwrap();
+            let app = builder::App {
+                root: app_root.to_path_buf(),
+                platform_id: None,
+                local_id: "test".to_string(),
+            };
+            let pp = builder::ParseParams {
+                app: &app,
+                pc: &pc,
+                working_dir: app_root,
+                parse_tests: false,
+            };
+            let rpcs = || -> Result<Vec<String>> {
+                let desc = builder.parse(&pp)?;
+                Ok(desc.meta.svcs[0]
+                    .rpcs
+                    .iter()
+                    .map(|rpc| rpc.name.clone())
+                    .collect())
+            };
+
+            assert_eq!(rpcs().unwrap(), vec!["get"]);
+
+            // Changed modules are parsed again.
+            write(
+                "svc/api.ts",
+                &format!(
+                    "{}export const list = api({{}}, async (): Promise<void> => {{}});\n",
+                    api
+                ),
+            );
+            assert_eq!(rpcs().unwrap(), vec!["get", "list"]);
+
+            // So are the modules importing them.
+            write("svc/types.ts", "export interface Other { id: string; }\n");
+            assert!(rpcs().is_err());
+
+            // Parsing again after a failed parse reports the errors again.
+            assert!(rpcs().is_err());
+
+            write("svc/types.ts", "export interface Params { id: string; }\n");
+            assert_eq!(rpcs().unwrap(), vec!["get", "list"]);
+        })
+    });
+}
@@ -94,6 +94,7 @@ func (b *builder) Build() *meta.Data {
 					HttpMethods:    ep.HTTPMethods,
 					Tags:           ep.Tags.ToProto(),
 					Sensitive:      ep.Sensitive,
+					Form:           ep.Form,
 					Expose:         make(map[string]*meta.RPC_ExposeOptions),
 				}
 				if ep.Raw {
@@ -388,6 +389,26 @@ func (b *builder) Build() *meta.Data {
 			}
 			pkg.Secrets = append(pkg.Secrets, r.Keys...)
 			sort.Strings(pkg.Secrets)
+			for _, key := range r.Keys {
+				if r.Kinds[key] == secrets.ValuesKey {
+					pkg.ListSecrets = append(pkg.ListSecrets, key)
+				}
+				if shape, ok := r.Generated[key]; ok {
+					pkg.GeneratedSecrets = append(pkg.GeneratedSecrets, &meta.GeneratedSecret{
+						Key:   key,
+						Shape: generatedSecretShape(shape),
+					})
+				}
+			}
+
+		case *secrets.JSONSecret:
+			pkg, ok := pkgByPath[r.Package().ImportPath]
+			if !ok {
+				b.errs.Addf(r.ASTExpr().Pos(), "could not find package %q", r.Package().ImportPath)
+				continue
+			}
+			pkg.Secrets = append(pkg.Secrets, r.Key)
+			sort.Strings(pkg.Secrets)
 
 		case *middleware.Middleware:
 			mw := &meta.Middleware{
@@ -564,6 +585,21 @@ func transformMigration(res sqldb.MigrationFile) *meta.DBMigration {
 	}
 }
 
+func generatedSecretShape(shape secrets.GeneratedShape) meta.GeneratedSecret_Shape {
+	switch shape {
+	case secrets.HexShape:
+		return meta.GeneratedSecret_HEX
+	case secrets.Base64Shape:
+		return meta.GeneratedSecret_BASE64
+	case secrets.UUIDShape:
+		return meta.GeneratedSecret_UUID
+	case secrets.BytesShape:
+		return meta.GeneratedSecret_BYTES
+	default:
+		return meta.GeneratedSecret_ALNUM
+	}
+}
+
 func (b *builder) keyspacePath(path *resourcepaths.Path) *meta.Path {
 	res := &meta.Path{
 		Type: meta.Path_CACHE_KEYSPACE,
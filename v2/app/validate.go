@@ -44,7 +44,7 @@ func (d *Desc) validate(pc *parsectx.Context, result *parser.Result) {
 		case *authhandler.AuthHandler:
 			// AuthHandlers are also allowed to be declared outside of service code as it's shared code between all services
 			continue
-		case *secrets.Secrets:
+		case *secrets.Secrets, *secrets.JSONSecret:
 			// Secrets are allowed anywhere
 			continue
 		case *sqldb.Database:
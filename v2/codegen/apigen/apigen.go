@@ -10,6 +10,7 @@ import (
 	"encr.dev/v2/codegen"
 	"encr.dev/v2/codegen/apigen/authhandlergen"
 	"encr.dev/v2/codegen/apigen/endpointgen"
+	"encr.dev/v2/codegen/apigen/fuzzgen"
 	"encr.dev/v2/codegen/apigen/maingen"
 	"encr.dev/v2/codegen/apigen/middlewaregen"
 	"encr.dev/v2/codegen/apigen/servicestructgen"
@@ -30,6 +31,10 @@ type Params struct {
 	AppRevision     string
 	AppUncommitted  bool
 
+	// APIVersion is the fingerprint of the app's API, checked against
+	// the API version sent by generated clients.
+	APIVersion string
+
 	Test option.Option[codegen.TestConfig]
 
 	ExecScriptMainPkg option.Option[paths.Pkg]
@@ -47,6 +52,7 @@ func Process(p Params) *config.Static {
 		CompilerVersion: p.CompilerVersion,
 		AppRevision:     p.AppRevision,
 		AppUncommitted:  p.AppUncommitted,
+		APIVersion:      p.APIVersion,
 
 		APIHandlers:    make(map[*api.Endpoint]*codegen.VarDecl),
 		Middleware:     make(map[*middleware.Middleware]*codegen.VarDecl),
@@ -81,6 +87,13 @@ func Process(p Params) *config.Static {
 
 			// Generate user-facing code with the implementation in place.
 			userfacinggen.Gen(p.Gen, svc, svcStruct)
+
+			// Generate the service mock and fuzz targets for the endpoints
+			// in the packages being tested.
+			if test, ok := p.Test.Get(); ok {
+				userfacinggen.GenMock(p.Gen, svc)
+				fuzzgen.Gen(p.Gen, svc, test)
+			}
 		}
 
 		gp.AuthHandler = option.Map(fw.AuthHandler, func(ah *authhandler.AuthHandler) *codegen.VarDecl {
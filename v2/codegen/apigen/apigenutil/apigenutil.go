@@ -49,6 +49,23 @@ func DecodeQuery(g *Group, urlValuesExpr, paramExpr *Statement, dec *genutil.Typ
 	g.Line()
 }
 
+// DecodeForm is like DecodeQuery but for form-encoded request bodies.
+func DecodeForm(g *Group, ioReaderExpr, paramExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
+	if len(params) == 0 {
+		return
+	}
+	g.Comment("Decode form-encoded request body")
+	g.Id("form").Op(":=").Add(dec.ReadForm(ioReaderExpr))
+
+	for _, f := range params {
+		singleValExpr := Id("form").Dot("Get").Call(Lit(f.WireName))
+		listValExpr := Id("form").Index(Lit(f.WireName))
+		decodeExpr := dec.UnmarshalSingleOrList(f.Type, f.WireName, singleValExpr, listValExpr, false)
+		g.Add(paramExpr.Clone()).Dot(f.SrcName).Op("=").Add(decodeExpr)
+	}
+	g.Line()
+}
+
 // DecodeCookie is like DecodeHeaders but for cookies.
 func DecodeCookie(errs *perr.List, g *Group, httpReqExpr, paramExpr *Statement, dec *genutil.TypeUnmarshaller, params []*apienc.ParameterEncoding) {
 	if len(params) == 0 {
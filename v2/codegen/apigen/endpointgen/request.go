@@ -248,7 +248,36 @@ func (d *requestDesc) renderRequestDecoding(g *Group, dec *genutil.TypeUnmarshal
 func (d *requestDesc) decodeRequestParameters(g *Group, dec *genutil.TypeUnmarshaller, req *apienc.RequestEncoding) {
 	apigenutil.DecodeHeaders(g, d.httpReqExpr().Dot("Header"), Id("params"), dec, req.HeaderParameters)
 	apigenutil.DecodeQuery(g, d.httpReqExpr().Dot("URL").Dot("Query").Call(), Id("params"), dec, req.QueryParameters)
-	apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	if len(req.BodyParameters) == 0 || !d.acceptsForm(req) {
+		apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+		return
+	}
+
+	// Endpoints with the "form" option accept form-encoded request bodies in addition to JSON.
+	contentType := d.httpReqExpr().Dot("Header").Dot("Get").Call(Lit("Content-Type"))
+	g.If(Qual("encore.dev/appruntime/shared/etype", "IsFormEncoded").Call(contentType)).BlockFunc(func(g *Group) {
+		apigenutil.DecodeForm(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	}).Else().BlockFunc(func(g *Group) {
+		apigenutil.DecodeBody(g, d.httpReqExpr().Dot("Body"), Id("params"), dec, req.BodyParameters)
+	})
+}
+
+// acceptsForm reports whether the endpoint accepts form-encoded request bodies.
+// It reports an error if the endpoint has the "form" option but its body parameters
+// can't be form-encoded.
+func (d *requestDesc) acceptsForm(req *apienc.RequestEncoding) bool {
+	if !d.ep.Form {
+		return false
+	}
+	for _, f := range req.BodyParameters {
+		if _, _, ok := schemautil.IsBuiltinOrList(f.Type); !ok {
+			d.gu.Errs.Addf(f.Type.ASTExpr().Pos(),
+				"endpoint %s has the \"form\" option, but request field %s of type %s can't be form-encoded: "+
+					"only built-in types and slices of built-in types are supported", d.ep.Name, f.SrcName, f.Type)
+			return false
+		}
+	}
+	return true
 }
 
 // Clone returns the function literal to clone the request.
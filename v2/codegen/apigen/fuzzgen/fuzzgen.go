@@ -0,0 +1,54 @@
+package fuzzgen
+
+import (
+	. "github.com/dave/jennifer/jen"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/app"
+	"encr.dev/v2/codegen"
+	"encr.dev/v2/parser/apis/api"
+)
+
+// Gen generates fuzz targets for the service's endpoints that are callable
+// by external clients, for the packages being tested.
+//
+// The fuzz targets are generated into a test file in the package defining
+// the endpoints, named FuzzEncore followed by the endpoint name.
+func Gen(gen *codegen.Generator, svc *app.Service, test codegen.TestConfig) {
+	fw, ok := svc.Framework.Get()
+	if !ok {
+		return
+	}
+
+	tested := make(map[paths.Pkg]bool, len(test.Packages))
+	for _, pkg := range test.Packages {
+		tested[pkg.ImportPath] = true
+	}
+
+	files := make(map[paths.Pkg]*codegen.File)
+	for _, ep := range fw.Endpoints {
+		// Raw endpoints have no schema to derive requests from,
+		// and private endpoints can't be called over HTTP.
+		if ep.Raw || ep.Access == api.Private {
+			continue
+		}
+
+		pkg := ep.File.Pkg
+		if !tested[pkg.ImportPath] {
+			continue
+		}
+
+		f, ok := files[pkg.ImportPath]
+		if !ok {
+			f = gen.InjectFile(pkg.ImportPath, pkg.Name, pkg.FSPath, "encore_internal__fuzz_test.go", "fuzz")
+			f.Jen.HeaderComment("Code generated by encore. DO NOT EDIT.")
+			files[pkg.ImportPath] = f
+		}
+
+		name := "FuzzEncore" + ep.Name
+		f.Jen.Commentf("%s fuzzes the %s endpoint. Run it with \"encore test -fuzz=%s\".", name, ep.Name, name)
+		f.Jen.Func().Id(name).Params(Id("f").Op("*").Qual("testing", "F")).Block(
+			Qual("encore.dev/et", "FuzzEndpoint").Call(Id("f"), Id(ep.Name)),
+		)
+	}
+}
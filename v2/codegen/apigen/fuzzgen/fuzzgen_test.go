@@ -0,0 +1,22 @@
+package fuzzgen
+
+import (
+	"testing"
+
+	"encr.dev/v2/app"
+	"encr.dev/v2/codegen"
+	"encr.dev/v2/codegen/internal/codegentest"
+	"encr.dev/v2/internals/pkginfo"
+)
+
+func TestCodegen(t *testing.T) {
+	fn := func(gen *codegen.Generator, desc *app.Desc) {
+		svc := desc.Services[0]
+		var pkgs []*pkginfo.Package
+		if fw, ok := svc.Framework.Get(); ok {
+			pkgs = append(pkgs, fw.RootPkg)
+		}
+		Gen(gen, svc, codegen.TestConfig{Packages: pkgs})
+	}
+	codegentest.Run(t, fn)
+}
@@ -41,6 +41,7 @@ func GenAppConfig(p GenParams, test option.Option[testParams]) *config.Static {
 			Revision:    p.AppRevision,
 			Uncommitted: p.AppUncommitted,
 		},
+		APIVersion:         p.APIVersion,
 		CORSAllowHeaders:   allowHeaders,
 		CORSExposeHeaders:  exposeHeaders,
 		PubsubTopics:       pubsubTopics(p.Gen, p.Desc),
@@ -29,6 +29,8 @@ type GenParams struct {
 	// AppUncommitted tracks whether there were uncommitted changes in the app
 	// at the time of build.
 	AppUncommitted bool
+	// APIVersion is the fingerprint of the app's API to embed in the generated code.
+	APIVersion string
 
 	APIHandlers    map[*api.Endpoint]*codegen.VarDecl
 	AuthHandler    option.Option[*codegen.VarDecl]
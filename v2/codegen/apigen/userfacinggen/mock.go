@@ -0,0 +1,178 @@
+package userfacinggen
+
+import (
+	"go/ast"
+	"slices"
+
+	. "github.com/dave/jennifer/jen"
+
+	"encr.dev/pkg/namealloc"
+	"encr.dev/v2/app"
+	"encr.dev/v2/codegen"
+	"encr.dev/v2/codegen/internal/genutil"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/parser/apis/api"
+)
+
+// GenMock generates the Mock type of the service, which implements the service's
+// Interface for use with et.MockService.
+//
+// It's only generated for test builds, into a file of its own next to encore.gen.go,
+// so it never ends up in the app's binaries. It's not generated if the service
+// declares anything named Mock itself, including in its test files.
+func GenMock(gen *codegen.Generator, svc *app.Service) {
+	fw, ok := svc.Framework.Get()
+	if !ok || !slices.ContainsFunc(fw.Endpoints, func(ep *api.Endpoint) bool { return !ep.Raw }) {
+		return
+	}
+	pkg := fw.RootPkg
+	if declaresName(pkg, "Mock") {
+		return
+	}
+
+	f := gen.InjectFile(pkg.ImportPath, pkg.Name, pkg.FSPath, "encore_internal__mock.go", "mock")
+	f.Jen.HeaderComment("Code generated by encore. DO NOT EDIT.")
+	genMock(gen.Util, f, svc.Name, fw.Endpoints)
+}
+
+// declaresName reports whether the package declares name at the package level,
+// in any of its files including its test files, but not its external test package.
+func declaresName(pkg *pkginfo.Package, name string) bool {
+	for _, f := range pkg.Files {
+		if f.AST().Name.Name != pkg.Name {
+			continue
+		}
+		for _, d := range f.AST().Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil && d.Name.Name == name {
+					return true
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if spec.Name.Name == name {
+							return true
+						}
+					case *ast.ValueSpec:
+						for _, n := range spec.Names {
+							if n.Name == name {
+								return true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// genMock generates the Mock type, which implements the service's Interface
+// by delegating to user-provided functions and records the calls made to it.
+func genMock(gu *genutil.Helper, f *codegen.File, svcName string, endpoints []*api.Endpoint) {
+	const etmock = "encore.dev/et/etmock"
+
+	methods := make(map[string]bool)
+	for _, ep := range endpoints {
+		if !ep.Raw {
+			methods[ep.Name] = true
+		}
+	}
+
+	// The recorder field can't share its name with an endpoint method.
+	callsField := "Calls"
+	if methods[callsField] {
+		callsField = "MockCalls"
+	}
+
+	// Nor can the function fields, as with endpoints named Foo and FooFunc.
+	suffix := "Func"
+	for _, s := range []string{"Func", "Fn", "MockFunc"} {
+		suffix = s
+		clash := false
+		for name := range methods {
+			if methods[name+s] || name+s == callsField {
+				clash = true
+				break
+			}
+		}
+		if !clash {
+			break
+		}
+	}
+
+	f.Jen.Comment("Mock is a mock implementation of Interface, for use with et.MockService.")
+	f.Jen.Comment("")
+	f.Jen.Comment("Each endpoint delegates to the function field of the same name with a " + suffix + " suffix.")
+	f.Jen.Comment("Endpoints whose function is nil return an errs.Unimplemented error.")
+	f.Jen.Comment("All calls are recorded in " + callsField + ", for making assertions about them.")
+	f.Jen.Type().Id("Mock").StructFunc(func(g *Group) {
+		g.Id(callsField).Qual(etmock, "Recorder")
+		g.Line()
+		for _, ep := range endpoints {
+			if ep.Raw {
+				continue
+			}
+			var names namealloc.Allocator
+			params, _, _ := mockParams(gu, ep, &names)
+			g.Id(ep.Name + suffix).Func().Params(params...).Add(endpointResults(gu, ep))
+		}
+	})
+	f.Jen.Line()
+
+	f.Jen.Var().Id("_").Id("Interface").Op("=").Parens(Op("*").Id("Mock")).Parens(Nil())
+	f.Jen.Line()
+
+	for _, ep := range endpoints {
+		if ep.Raw {
+			continue
+		}
+
+		var names namealloc.Allocator
+		recv := names.Get("m")
+		params, ctxName, args := mockParams(gu, ep, &names)
+		fn := ep.Name + suffix
+
+		f.Jen.Func().Params(Id(recv).Op("*").Id("Mock")).Id(ep.Name).Params(params...).Add(endpointResults(gu, ep)).BlockFunc(func(g *Group) {
+			g.Id(recv).Dot(callsField).Dot("Record").CallFunc(func(g *Group) {
+				g.Lit(ep.Name)
+				for _, arg := range args {
+					g.Id(arg)
+				}
+			})
+			g.If(Id(recv).Dot(fn).Op("==").Nil()).Block(ReturnFunc(func(g *Group) {
+				if ep.Response != nil {
+					g.Add(gu.Zero(ep.Response))
+				}
+				g.Qual(etmock, "NotMocked").Call(Lit(svcName), Lit(ep.Name))
+			}))
+			g.Return(Id(recv).Dot(fn).CallFunc(func(g *Group) {
+				g.Id(ctxName)
+				for _, arg := range args {
+					g.Id(arg)
+				}
+			}))
+		})
+		f.Jen.Line()
+	}
+}
+
+// mockParams returns the parameters of the Go function implementing the non-raw endpoint,
+// along with the names of the context parameter and the remaining parameters.
+func mockParams(gu *genutil.Helper, ep *api.Endpoint, names *namealloc.Allocator) (params []Code, ctxName string, args []string) {
+	ctxName = names.Get("ctx")
+	params = append(params, Id(ctxName).Qual("context", "Context"))
+	for _, p := range ep.Path.Params() {
+		name := names.Get(p.Value)
+		params = append(params, Id(name).Add(gu.Builtin(p.Pos(), p.ValueType)))
+		args = append(args, name)
+	}
+	if req := ep.Request; req != nil {
+		name := names.Get("p")
+		params = append(params, Id(name).Add(gu.Type(req)))
+		args = append(args, name)
+	}
+	return params, ctxName, args
+}
@@ -171,15 +171,19 @@ func getEndpointPrototype(gu *genutil.Helper, grp *Group, ep *api.Endpoint, with
 				s.Params(Error())
 			}
 		} else {
-			if ep.Raw {
-				s.Params(Op("*").Qual("net/http", "Response"), Error())
-			} else if resp := ep.Response; resp != nil {
-				s.Params(gu.Type(resp), Error())
-			} else {
-				s.Error()
-			}
+			s.Add(endpointResults(gu, ep))
 		}
 	})
 
 	return stmt, pathParamNames, alloc, ctxName, paramName
 }
+
+// endpointResults returns the results of the Go function implementing the endpoint.
+func endpointResults(gu *genutil.Helper, ep *api.Endpoint) Code {
+	if ep.Raw {
+		return Params(Op("*").Qual("net/http", "Response"), Error())
+	} else if resp := ep.Response; resp != nil {
+		return Params(gu.Type(resp), Error())
+	}
+	return Error()
+}
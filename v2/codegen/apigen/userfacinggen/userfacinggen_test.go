@@ -21,6 +21,7 @@ func TestCodegen(t *testing.T) {
 			}
 		}
 		Gen(gen, svc, svcStruct)
+		GenMock(gen, svc)
 	}
 	codegentest.Run(t, fn)
 }
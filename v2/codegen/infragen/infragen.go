@@ -38,6 +38,8 @@ func Process(gg *codegen.Generator, appDesc *app.Desc) {
 			pkg = r.File.Pkg
 		case *secrets.Secrets:
 			pkg = r.File.Pkg
+		case *secrets.JSONSecret:
+			pkg = r.File.Pkg
 		case *pubsub.Subscription:
 			pkg = r.File.Pkg
 		case *config.Load:
@@ -68,9 +70,7 @@ func Process(gg *codegen.Generator, appDesc *app.Desc) {
 			}))
 		case resource.Secrets:
 			svc, _ := appDesc.ServiceForPath(pkg.FSPath)
-			secretsgen.Gen(gg, option.AsOptional(svc), pkg, fns.Map(resources, func(r resource.Resource) *secrets.Secrets {
-				return r.(*secrets.Secrets)
-			}))
+			secretsgen.Gen(gg, option.AsOptional(svc), pkg, resources)
 		case resource.ConfigLoad:
 			svc, ok := appDesc.ServiceForPath(pkg.FSPath)
 			if !ok {
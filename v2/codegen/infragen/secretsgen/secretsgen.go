@@ -10,39 +10,67 @@ import (
 	"encr.dev/v2/codegen"
 	"encr.dev/v2/internals/pkginfo"
 	"encr.dev/v2/parser/infra/secrets"
+	"encr.dev/v2/parser/resource"
 )
 
-func Gen(gen *codegen.Generator, svc option.Option[*app.Service], pkg *pkginfo.Package, secrets []*secrets.Secrets) {
+// Gen generates the code to load the secrets of a package,
+// declared by secrets structs and secrets.JSON variables.
+func Gen(gen *codegen.Generator, svc option.Option[*app.Service], pkg *pkginfo.Package, resources []resource.Resource) {
 	addedImport := make(map[*pkginfo.File]bool)
-	for _, secret := range secrets {
-		file := secret.File
-		rw := gen.Rewrite(file)
-
-		if !addedImport[file] {
-			// Add an import of the runtime package to be able to load secrets.
-			insertPos := file.AST().Name.End()
-			ln := gen.FS.Position(insertPos)
-
-			rw.Insert(insertPos, []byte(fmt.Sprintf("\nimport __encore_secrets %s;/*line :%d:%d*/",
-				strconv.Quote("encore.dev/appruntime/infrasdk/secrets"),
-				ln.Line, ln.Column)))
-			addedImport[secret.File] = true
+	addImport := func(file *pkginfo.File) {
+		if addedImport[file] {
+			return
 		}
+		// Add an import of the runtime package to be able to load secrets.
+		insertPos := file.AST().Name.End()
+		ln := gen.FS.Position(insertPos)
 
-		getName := func(svc *app.Service) string { return svc.Name }
-		svcName := strconv.Quote(option.Map(svc, getName).GetOrElse(""))
+		gen.Rewrite(file).Insert(insertPos, []byte(fmt.Sprintf("\nimport __encore_secrets %s;/*line :%d:%d*/",
+			strconv.Quote("encore.dev/appruntime/infrasdk/secrets"),
+			ln.Line, ln.Column)))
+		addedImport[file] = true
+	}
 
-		// Rewrite the value spec to load the secrets.
-		spec := secret.Spec
-		var buf bytes.Buffer
-		buf.WriteString("{\n")
-		for _, key := range secret.Keys {
-			fmt.Fprintf(&buf, "\t%s: __encore_secrets.Load(%s, %s),\n", key, strconv.Quote(key), svcName)
-		}
-		ep := gen.FS.Position(spec.End())
-		fmt.Fprintf(&buf, "}/*line :%d:%d*/", ep.Line, ep.Column)
-		rw.Insert(spec.Type.Pos(), []byte("= "))
-		rw.Insert(spec.End(), buf.Bytes())
+	getName := func(svc *app.Service) string { return svc.Name }
+	svcName := strconv.Quote(option.Map(svc, getName).GetOrElse(""))
+
+	for _, r := range resources {
+		switch secret := r.(type) {
+		case *secrets.Secrets:
+			addImport(secret.File)
+			rw := gen.Rewrite(secret.File)
 
+			// Rewrite the value spec to load the secrets.
+			spec := secret.Spec
+			var buf bytes.Buffer
+			buf.WriteString("{\n")
+			for _, key := range secret.Keys {
+				load := "Load"
+				switch secret.Kinds[key] {
+				case secrets.BytesKey:
+					load = "LoadBytes"
+				case secrets.ValuesKey:
+					load = "LoadValues"
+				}
+				fmt.Fprintf(&buf, "\t%s: __encore_secrets.%s(%s, %s),\n", key, load, strconv.Quote(key), svcName)
+			}
+			ep := gen.FS.Position(spec.End())
+			fmt.Fprintf(&buf, "}/*line :%d:%d*/", ep.Line, ep.Column)
+			rw.Insert(spec.Type.Pos(), []byte("= "))
+			rw.Insert(spec.End(), buf.Bytes())
+
+		case *secrets.JSONSecret:
+			addImport(secret.File)
+			rw := gen.Rewrite(secret.File)
+
+			// Give the variable a value that loads the secret,
+			// reusing the source of the type argument.
+			tok := secret.File.Token()
+			index := secret.TypeExpr.Index
+			typeArg := secret.File.Contents()[tok.Offset(index.Pos()):tok.Offset(index.End())]
+			ep := gen.FS.Position(secret.Spec.End())
+			rw.Insert(secret.Spec.End(), []byte(fmt.Sprintf(" = __encore_secrets.LoadJSON[%s](%s, %s)/*line :%d:%d*/",
+				typeArg, strconv.Quote(secret.Key), svcName, ep.Line, ep.Column)))
+		}
 	}
 }
@@ -39,7 +39,8 @@ func Run(t *testing.T, fn func(*codegen.Generator, *app.Desc)) {
 	tests := readTestCases(c, "testdata")
 	for _, test := range tests {
 		c.Run(test.name, func(c *qt.C) {
-			tc := testutil.NewContext(c, false, test.input)
+			// Parse test files too, so cases can cover code generated for test builds.
+			tc := testutil.NewContext(c, true, test.input)
 			tc.FailTestOnErrors()
 
 			// Create a go.mod file in the main module directory if it doesn't already exist.
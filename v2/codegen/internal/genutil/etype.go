@@ -108,6 +108,11 @@ func (u *TypeUnmarshaller) ReadBody(bodyExpr *Statement) *Statement {
 	return u.unmarshallerExpr.Clone().Dot("ReadBody").Call(bodyExpr.Clone())
 }
 
+// ReadForm returns an expression to read a form-encoded request body into a url.Values.
+func (u *TypeUnmarshaller) ReadForm(bodyExpr *Statement) *Statement {
+	return u.unmarshallerExpr.Clone().Dot("ReadForm").Call(bodyExpr.Clone())
+}
+
 // ParseJSON returns an expression to parse json.
 // It uses the iterator accessed through the given iteratorExpr to parse JSON into the given dstExpr.
 // The dstExpr must be a pointer value.
@@ -46,6 +46,10 @@ type Context struct {
 	// ParseTests controls whether to parse test files.
 	ParseTests bool
 
+	// DetectHardcodedSecrets controls whether to report string literals
+	// that look like credentials as errors.
+	DetectHardcodedSecrets bool
+
 	// Errs contains encountered errors.
 	Errs *perr.List
 
@@ -52,6 +52,10 @@ type Endpoint struct {
 	// meaning all request/response information will be redacted in traces.
 	Sensitive bool
 
+	// Form indicates whether the endpoint accepts form-encoded request bodies
+	// in addition to JSON.
+	Form bool
+
 	reqEncOnce  sync.Once
 	reqEncoding []*apienc.RequestEncoding
 
@@ -309,7 +313,7 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 
 	accessOptions := []string{"public", "private", "auth"}
 	ok := directive.Validate(errs, dir, directive.ValidateSpec{
-		AllowedOptions: append([]string{"raw", "sensitive"}, accessOptions...),
+		AllowedOptions: append([]string{"raw", "sensitive", "form"}, accessOptions...),
 		AllowedFields:  []string{"path", "method"},
 
 		ValidateOption: func(errs *perr.List, opt directive.Field) (ok bool) {
@@ -329,6 +333,8 @@ func validateDirective(errs *perr.List, dir *directive.Directive) (*Endpoint, bo
 				rawTag = opt
 			case "sensitive":
 				endpoint.Sensitive = true
+			case "form":
+				endpoint.Form = true
 			}
 
 			return true
@@ -119,6 +119,24 @@ func Raw(w http.ResponseWriter, req *http.Request) {}
 				HTTPMethods: []string{"*"},
 			},
 		},
+		{
+			name: "form",
+			def: `
+//encore:api public method=POST form
+func Foo(ctx context.Context) error {}
+`,
+			want: &Endpoint{
+				Name:        "Foo",
+				Doc:         "",
+				Access:      Public,
+				AccessField: option.Some(directive.Field{Value: "public"}),
+				Form:        true,
+				Path: &resourcepaths.Path{Segments: []resourcepaths.Segment{
+					{Type: resourcepaths.Literal, Value: "foo.Foo", ValueType: schema.String},
+				}},
+				HTTPMethods: []string{"POST"},
+			},
+		},
 	}
 
 	// testArchive renders the txtar archive to use for a given test.
@@ -34,6 +34,32 @@ var (
 
 	errSecretsMustBeString = errRange.New(
 		"Invalid secrets struct",
-		"Secrets must be of type string.",
+		"Secrets must be of type string, []byte or []string.",
+	)
+
+	errInvalidGenerateTag = errRange.New(
+		"Invalid secrets struct",
+		"The encore:\"generate\" tag takes an optional shape of the value to generate: one of alnum, hex, base64 or uuid.",
+	)
+
+	errJSONSecretsDeclaredTogether = errRange.New(
+		"Invalid secrets.JSON variable",
+		"Each secrets.JSON variable must be declared separately, as its name is the name of the secret.",
+	)
+
+	errJSONSecretGivenValue = errRange.New(
+		"Invalid secrets.JSON variable",
+		"A secrets.JSON variable must not be given a value. Encore will ensure that the secret is loaded at runtime.",
+	)
+
+	errJSONSecretType = errRange.New(
+		"Invalid secrets.JSON variable",
+		"The type argument of secrets.JSON must be a named struct type.",
+	)
+
+	errHardcodedSecret = errRange.New(
+		"Hardcoded secret",
+		"String literals must not contain credentials, as they end up in version control. Store them as secrets instead.",
+		errors.PrependDetails("If the value isn't actually a credential, add a //encore:nosecret comment to the line or the line above it."),
 	)
 )
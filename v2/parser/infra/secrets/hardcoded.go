@@ -0,0 +1,160 @@
+package secrets
+
+import (
+	"cmp"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"encr.dev/pkg/errors"
+	"encr.dev/v2/internals/perr"
+	"encr.dev/v2/internals/pkginfo"
+)
+
+// noSecretDirective suppresses the hardcoded secret detection for string
+// literals on the same line as the comment, or on the line below it.
+const noSecretDirective = "encore:nosecret"
+
+// credentialPatterns are the patterns of string literals that look like
+// well-known kinds of credentials.
+var credentialPatterns = []struct {
+	desc string
+	re   *regexp.Regexp
+}{
+	{"an AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"an AWS secret access key", regexp.MustCompile(`(?i)aws_?secret_?access_?key\s*[=:]\s*["']?[A-Za-z0-9/+]{40}\b`)},
+	{"a private key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY-----`)},
+	{"a JSON Web Token", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"a connection string with a password", regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^\s/:@]+:[^\s/@$%{}]+@`)},
+	{"a Stripe secret key", regexp.MustCompile(`\b[rs]k_live_[0-9a-zA-Z]{20,}`)},
+	{"a GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}`)},
+	{"a Slack token", regexp.MustCompile(`\bxox[abprs]-[0-9A-Za-z-]{10,}`)},
+}
+
+// credentialName matches the names of variables and fields that hold
+// credentials, like "jwtSecret" or "DBPassword".
+var credentialName = regexp.MustCompile(`(?i)(passw(or)?d|secret|api_?key|access_?key|private_?key|signing_?key|auth_?token|access_?token)$`)
+
+// envVarName matches values that look like the name of an environment
+// variable rather than a credential, like "STRIPE_SECRET_KEY".
+var envVarName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// DetectHardcoded reports string literals in the package's non-test files
+// that look like credentials, which should be stored as secrets instead.
+func DetectHardcoded(errs *perr.List, pkg *pkginfo.Package) {
+	for _, f := range pkg.Files {
+		if f.TestFile {
+			continue
+		}
+		for _, finding := range hardcodedCredentials(f) {
+			errs.Add(errHardcodedSecret.AtGoNode(finding.lit, errors.AsError("looks like "+finding.desc)))
+		}
+	}
+}
+
+type credentialFinding struct {
+	lit  *ast.BasicLit
+	desc string
+}
+
+// hardcodedCredentials returns the string literals in the file
+// that look like credentials, ordered by position.
+func hardcodedCredentials(f *pkginfo.File) []credentialFinding {
+	file := f.AST()
+	tok := f.Token()
+
+	suppressed := make(map[int]bool) // line -> suppressed
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(c.Text, "//")), noSecretDirective) {
+				line := tok.Line(c.Pos())
+				suppressed[line] = true
+				suppressed[line+1] = true
+			}
+		}
+	}
+
+	findings := make(map[*ast.BasicLit]string)
+	add := func(lit *ast.BasicLit, desc string) {
+		if _, ok := findings[lit]; !ok && !suppressed[tok.Line(lit.Pos())] {
+			findings[lit] = desc
+		}
+	}
+
+	// checkNamed checks a literal assigned to a variable or field
+	// whose name suggests it holds a credential.
+	checkNamed := func(name *ast.Ident, val ast.Expr) {
+		lit, ok := val.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING || name == nil || !credentialName.MatchString(name.Name) {
+			return
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil || len(s) < 8 || strings.ContainsAny(s, " \t\n") || envVarName.MatchString(s) {
+			return
+		}
+		add(lit, "a credential assigned to "+name.Name)
+	}
+
+	// Report literals matching the credential patterns first,
+	// as they're more specific than the names they're assigned to.
+	ast.Inspect(file, func(node ast.Node) bool {
+		if lit, ok := node.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if s, err := strconv.Unquote(lit.Value); err == nil {
+				for _, p := range credentialPatterns {
+					if p.re.MatchString(s) {
+						add(lit, p.desc)
+						break
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.ValueSpec:
+			if len(node.Names) == len(node.Values) {
+				for i, name := range node.Names {
+					checkNamed(name, node.Values[i])
+				}
+			}
+
+		case *ast.AssignStmt:
+			if len(node.Lhs) == len(node.Rhs) {
+				for i, lhs := range node.Lhs {
+					checkNamed(assignedName(lhs), node.Rhs[i])
+				}
+			}
+
+		case *ast.KeyValueExpr:
+			if key, ok := node.Key.(*ast.Ident); ok {
+				checkNamed(key, node.Value)
+			}
+		}
+		return true
+	})
+
+	result := make([]credentialFinding, 0, len(findings))
+	for lit, desc := range findings {
+		result = append(result, credentialFinding{lit: lit, desc: desc})
+	}
+	slices.SortFunc(result, func(a, b credentialFinding) int {
+		return cmp.Compare(a.lit.Pos(), b.lit.Pos())
+	})
+	return result
+}
+
+// assignedName returns the name of the variable or field being assigned to.
+func assignedName(expr ast.Expr) *ast.Ident {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr
+	case *ast.SelectorExpr:
+		return expr.Sel
+	}
+	return nil
+}
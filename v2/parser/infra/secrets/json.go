@@ -0,0 +1,98 @@
+package secrets
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+
+	"encr.dev/pkg/paths"
+	"encr.dev/v2/internals/pkginfo"
+	"encr.dev/v2/internals/schema"
+	"encr.dev/v2/internals/schema/schemautil"
+	"encr.dev/v2/parser/resource"
+	"encr.dev/v2/parser/resource/resourceparser"
+)
+
+// JSONSecret represents a package-level secrets.JSON[T] variable,
+// a secret holding a JSON document parsed into a T.
+type JSONSecret struct {
+	File  *pkginfo.File // Where the variable is declared
+	Ident *ast.Ident    // The identifier of the variable
+	Key   string        // The secret key to load, the variable's name
+
+	// Type is the type the secret is parsed into.
+	// It's guaranteed to be a named struct type.
+	Type schema.Type
+
+	// TypeExpr is the secrets.JSON[T] type expression.
+	TypeExpr *ast.IndexExpr
+
+	// Spec is the value spec that defines the variable.
+	Spec *ast.ValueSpec
+}
+
+func (*JSONSecret) Kind() resource.Kind         { return resource.Secrets }
+func (s *JSONSecret) Package() *pkginfo.Package { return s.File.Pkg }
+func (s *JSONSecret) ASTExpr() ast.Expr         { return s.TypeExpr }
+func (s *JSONSecret) Pos() token.Pos            { return s.Ident.Pos() }
+func (s *JSONSecret) End() token.Pos            { return s.TypeExpr.End() }
+func (s *JSONSecret) SortKey() string {
+	return fmt.Sprintf("%s:%s:%d", s.File.Pkg.ImportPath, s.File.Name, s.Ident.Pos())
+}
+
+var jsonName = pkginfo.QualifiedName{PkgPath: "encore.dev/secrets", Name: "JSON"}
+
+var JSONSecretParser = &resourceparser.Parser{
+	Name:               "JSONSecret",
+	InterestingImports: []paths.Pkg{"encore.dev/secrets"},
+
+	Run: func(p *resourceparser.Pass) {
+		decls := p.Pkg.Names().PkgDecls
+		names := make([]string, 0, len(decls))
+		for name, decl := range decls {
+			if decl.Type == token.VAR {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+
+		seen := make(map[*ast.ValueSpec]bool)
+		for _, name := range names {
+			decl := decls[name]
+			spec := decl.Spec.(*ast.ValueSpec)
+			typ, ok := spec.Type.(*ast.IndexExpr)
+			if !ok || seen[spec] {
+				continue
+			} else if ref, ok := decl.File.Names().ResolvePkgLevelRef(typ); !ok || ref != jsonName {
+				continue
+			}
+			seen[spec] = true
+
+			if len(spec.Names) != 1 {
+				p.Errs.Add(errJSONSecretsDeclaredTogether.AtGoNode(spec))
+				continue
+			} else if len(spec.Values) != 0 {
+				p.Errs.Add(errJSONSecretGivenValue.AtGoNode(spec.Values[0]))
+				continue
+			}
+
+			t := p.SchemaParser.ParseType(decl.File, typ.Index)
+			if ref, ok := schemautil.ResolveNamedStruct(t, false); !ok || ref.Pointers > 0 {
+				p.Errs.Add(errJSONSecretType.AtGoNode(typ.Index))
+				continue
+			}
+
+			res := &JSONSecret{
+				File:     decl.File,
+				Ident:    spec.Names[0],
+				Key:      spec.Names[0].Name,
+				Type:     t,
+				TypeExpr: typ,
+				Spec:     spec,
+			}
+			p.RegisterResource(res)
+			p.AddNamedBind(res.File, res.Ident, res)
+		}
+	},
+}
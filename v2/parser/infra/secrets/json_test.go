@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseJSONSecret(t *testing.T) {
+	tests := []resourcetest.Case[*JSONSecret]{
+		{
+			Name: "basic",
+			Code: `
+type DBCreds struct {
+	User string
+}
+
+var dbCreds secrets.JSON[DBCreds]
+`,
+			Want: &JSONSecret{
+				Key: "dbCreds",
+			},
+		},
+		{
+			Name: "given_value",
+			Code: `
+type DBCreds struct {
+	User string
+}
+
+var dbCreds secrets.JSON[DBCreds] = nil
+`,
+			WantErrs: []string{`.*must not be given a value.*`},
+		},
+		{
+			Name: "declared_together",
+			Code: `
+type DBCreds struct {
+	User string
+}
+
+var a, b secrets.JSON[DBCreds]
+`,
+			WantErrs: []string{`.*must be declared separately.*`},
+		},
+		{
+			Name: "pointer_type",
+			Code: `
+type DBCreds struct {
+	User string
+}
+
+var dbCreds secrets.JSON[*DBCreds]
+`,
+			WantErrs: []string{`.*must be a named struct type.*`},
+		},
+	}
+
+	resourcetest.Run(t, JSONSecretParser, tests, cmpopts.IgnoreFields(JSONSecret{}, "Ident", "Type", "TypeExpr", "Spec"))
+}
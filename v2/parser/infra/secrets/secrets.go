@@ -22,10 +22,58 @@ type Secrets struct {
 	Ident *ast.Ident    // The identifier of the secrets struct
 	Keys  []string      // Secret keys to load
 
+	// Kinds are the kinds of the keys' fields, keyed by secret key.
+	// Keys of string fields are omitted.
+	Kinds map[string]KeyKind
+
+	// Generated are the shapes of the values to generate for local development,
+	// keyed by secret key, for the keys whose fields are tagged encore:"generate".
+	Generated map[string]GeneratedShape
+
 	// Spec is the value spec that defines the 'secrets' variable.
 	Spec *ast.ValueSpec
 }
 
+// KeyKind describes how a secret key's value is loaded into its field.
+type KeyKind int
+
+const (
+	// StringKey fields are of type string, holding the secret value.
+	StringKey KeyKind = iota
+	// BytesKey fields are of type []byte, holding the raw bytes of the
+	// secret value, which is stored base64-encoded.
+	BytesKey
+	// ValuesKey fields are of type []string, holding the secret's active values:
+	// the current value followed by any other values accepted during a rotation.
+	// The secret value is stored as a JSON list of strings.
+	ValuesKey
+)
+
+// GeneratedShape is the shape of a generated secret value.
+type GeneratedShape int
+
+const (
+	// AlnumShape is 32 alphanumeric characters. It's the default shape.
+	AlnumShape GeneratedShape = iota
+	// HexShape is 32 random bytes, hex-encoded.
+	HexShape
+	// Base64Shape is 32 random bytes, base64-encoded.
+	Base64Shape
+	// UUIDShape is a random (version 4) UUID.
+	UUIDShape
+	// BytesShape is 32 random bytes. It's the only shape of []byte fields.
+	BytesShape
+)
+
+// generatedShapes are the shapes that can be given
+// as an option to the encore:"generate" tag.
+var generatedShapes = map[string]GeneratedShape{
+	"alnum":  AlnumShape,
+	"hex":    HexShape,
+	"base64": Base64Shape,
+	"uuid":   UUIDShape,
+}
+
 type SecretKey struct {
 	Name string
 }
@@ -81,14 +129,64 @@ var SecretsParser = &resourceparser.Parser{
 				p.Errs.Add(errAnonymousFields.AtGoNode(f.AST))
 				continue
 			}
-			if !schemautil.IsBuiltinKind(f.Type, schema.String) {
+			var kind KeyKind
+			switch builtin, isList, ok := schemautil.IsBuiltinOrList(f.Type); {
+			case ok && !isList && builtin == schema.String:
+				kind = StringKey
+			case ok && !isList && builtin == schema.Bytes:
+				kind = BytesKey
+			case ok && isList && builtin == schema.String:
+				kind = ValuesKey
+			default:
 				p.Errs.Add(errSecretsMustBeString.AtGoNode(f.AST.Type, errors.AsError(fmt.Sprintf("got %s", literals.PrettyPrint(f.Type.ASTExpr())))))
 				continue
 			}
-			res.Keys = append(res.Keys, f.Name.MustGet())
+			key := f.Name.MustGet()
+			res.Keys = append(res.Keys, key)
+			if kind != StringKey {
+				if res.Kinds == nil {
+					res.Kinds = make(map[string]KeyKind)
+				}
+				res.Kinds[key] = kind
+			}
+			if shape, ok := parseGenerateTag(p, f, kind); ok {
+				if res.Generated == nil {
+					res.Generated = make(map[string]GeneratedShape)
+				}
+				res.Generated[key] = shape
+			}
 		}
 
 		p.RegisterResource(res)
 		p.AddNamedBind(res.File, res.Ident, res)
 	},
 }
+
+// parseGenerateTag parses the encore:"generate" tag of the field, if any,
+// returning the shape of the value to generate and whether it's tagged.
+func parseGenerateTag(p *resourceparser.Pass, f schema.StructField, kind KeyKind) (GeneratedShape, bool) {
+	tag, err := f.Tag.Get("encore")
+	if err != nil || tag.Name != "generate" {
+		return 0, false
+	}
+
+	if len(tag.Options) > 1 {
+		p.Errs.Add(errInvalidGenerateTag.AtGoNode(f.AST.Tag, errors.AsError("only one shape can be given")))
+		return 0, false
+	} else if kind == BytesKey {
+		if len(tag.Options) > 0 {
+			p.Errs.Add(errInvalidGenerateTag.AtGoNode(f.AST.Tag, errors.AsError("[]byte fields are always generated as random bytes")))
+			return 0, false
+		}
+		return BytesShape, true
+	} else if len(tag.Options) == 0 {
+		return AlnumShape, true
+	}
+
+	shape, ok := generatedShapes[tag.Options[0]]
+	if !ok {
+		p.Errs.Add(errInvalidGenerateTag.AtGoNode(f.AST.Tag, errors.AsError(fmt.Sprintf("unknown shape %q", tag.Options[0]))))
+		return 0, false
+	}
+	return shape, true
+}
@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"encr.dev/v2/parser/resource/resourcetest"
+)
+
+func TestParseGeneratedSecrets(t *testing.T) {
+	tests := []resourcetest.Case[*Secrets]{
+		{
+			Name: "generated",
+			Code: `
+var secrets struct {
+	StripeKey     string
+	SessionKey    string   ` + "`encore:\"generate\"`" + `
+	WebhookSecret string   ` + "`encore:\"generate,hex\"`" + `
+	SigningKey    []byte   ` + "`encore:\"generate\"`" + `
+	APITokens     []string ` + "`encore:\"generate,uuid\"`" + `
+}
+`,
+			Want: &Secrets{
+				Keys:  []string{"StripeKey", "SessionKey", "WebhookSecret", "SigningKey", "APITokens"},
+				Kinds: map[string]KeyKind{"SigningKey": BytesKey, "APITokens": ValuesKey},
+				Generated: map[string]GeneratedShape{
+					"SessionKey":    AlnumShape,
+					"WebhookSecret": HexShape,
+					"SigningKey":    BytesShape,
+					"APITokens":     UUIDShape,
+				},
+			},
+		},
+		{
+			Name: "unknown_shape",
+			Code: `
+var secrets struct {
+	SessionKey string ` + "`encore:\"generate,words\"`" + `
+}
+`,
+			WantErrs: []string{`.*unknown shape "words".*`},
+		},
+		{
+			Name: "bytes_with_shape",
+			Code: `
+var secrets struct {
+	SigningKey []byte ` + "`encore:\"generate,hex\"`" + `
+}
+`,
+			WantErrs: []string{`.*always generated as random bytes.*`},
+		},
+	}
+
+	// The parser always runs, so it has no imports for the test to add.
+	parser := *SecretsParser
+	parser.InterestingImports = nil
+	resourcetest.Run(t, &parser, tests, cmpopts.IgnoreFields(Secrets{}, "AST", "Ident", "Spec"))
+}
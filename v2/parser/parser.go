@@ -83,6 +83,10 @@ func (p *Parser) Parse() *Result {
 			p.Run(pass)
 		}
 
+		if p.c.DetectHardcodedSecrets {
+			secrets.DetectHardcoded(p.c.Errs, pkg)
+		}
+
 		mu.Lock()
 		pkgs = append(pkgs, pkg)
 		resources = append(resources, pass.Resources()...)
@@ -145,6 +149,7 @@ var allParsers = []*resourceparser.Parser{
 	pubsub.TopicParser,
 	pubsub.SubscriptionParser,
 	secrets.SecretsParser,
+	secrets.JSONSecretParser,
 	sqldb.DatabaseParser,
 	sqldb.MigrationParser,
 	sqldb.NamedParser,
@@ -10,6 +10,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -34,6 +36,9 @@ func New() *BuilderImpl {
 type BuilderImpl struct {
 	mu   sync.Mutex
 	cmds map[*runningCmd]bool
+
+	// parser is the parser kept running between incremental parses, if any.
+	parser *parserProc
 }
 
 type parseInput struct {
@@ -44,9 +49,7 @@ type parseInput struct {
 }
 
 type data struct {
-	cmd    *exec.Cmd
-	stdin  io.Writer
-	stdout io.Reader
+	proc *parserProc
 }
 
 func getTSParserPath() (string, error) {
@@ -84,13 +87,61 @@ func (i *BuilderImpl) Close() error {
 }
 
 func (i *BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.ParseResult, error) {
-	exe, err := getTSParserPath()
+	runtimesDir := p.Build.EncoreRuntimes.GetOrElseF(func() paths.FS { return paths.FS(env.EncoreRuntimesPath()) })
+	jsRuntimePath := jsRuntimeRoot(runtimesDir)
+
+	var proc *parserProc
+	if p.Incremental {
+		proc = i.reuseParser(p, jsRuntimePath)
+	}
+	if proc == nil {
+		// An incremental parser outlives the parse, until the builder is closed.
+		procCtx := ctx
+		if p.Incremental {
+			procCtx = context.Background()
+		}
+
+		var err error
+		proc, err = i.startParser(procCtx, p, jsRuntimePath)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.Incremental {
+			i.mu.Lock()
+			prev := i.parser
+			i.parser = proc
+			i.mu.Unlock()
+			if prev != nil {
+				_ = prev.rc.cmd.Cancel()
+			}
+		}
+	}
+
+	parseResp, err := proc.request(ctx, "parse", parseInput{
+		AppRoot:    p.App.Root(),
+		PlatformID: p.App.PlatformID(),
+		LocalID:    p.App.LocalID(),
+		ParseTests: p.ParseTests,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	runtimesDir := p.Build.EncoreRuntimes.GetOrElseF(func() paths.FS { return paths.FS(env.EncoreRuntimesPath()) })
-	jsRuntimePath := jsRuntimeRoot(runtimesDir)
+	var md metav1.Data
+	if err := proto.Unmarshal(parseResp, &md); err != nil {
+		return nil, fmt.Errorf("unable to parse app: %s", err)
+	}
+
+	return &builder.ParseResult{Meta: &md, Data: &data{proc: proc}}, nil
+}
+
+// startParser starts a parser for the app and prepares it for parsing.
+func (i *BuilderImpl) startParser(ctx context.Context, p builder.ParseParams, jsRuntimePath paths.FS) (*parserProc, error) {
+	exe, err := getTSParserPath()
+	if err != nil {
+		return nil, err
+	}
 
 	cmd := exec.CommandContext(ctx, exe)
 	cmd.Dir = filepath.Join(p.App.Root(), p.WorkingDir)
@@ -138,83 +189,96 @@ func (i *BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builde
 		i.mu.Unlock()
 	}()
 
-	{
-		input, _ := json.Marshal(prepareInput{
-			AppRoot:         paths.FS(p.App.Root()),
-			JSRuntimeRoot:   jsRuntimePath,
-			RuntimeVersion:  version.Version,
-			UseLocalRuntime: p.Build.UseLocalJSRuntime,
-		})
-		_, _ = stdin.Write([]byte("prepare\n"))
-		if _, err := stdin.Write(input); err != nil {
-			return nil, fmt.Errorf("unable to write to stdin: %s", err)
-		}
-
-		isSuccess, parseResp, err := readResp(stdout)
-		if err != nil {
-			return nil, fmt.Errorf("unable to read response: %s", err)
-		} else if !isSuccess {
-			return nil, errors.New(string(parseResp))
-		}
+	proc := &parserProc{
+		rc:      rc,
+		stdin:   stdin,
+		stdout:  stdout,
+		key:     parserKey(p, jsRuntimePath),
+		configs: configState(p.App.Root()),
 	}
 
-	input, _ := json.Marshal(parseInput{
-		AppRoot:    p.App.Root(),
-		PlatformID: p.App.PlatformID(),
-		LocalID:    p.App.LocalID(),
-		ParseTests: p.ParseTests,
+	_, err = proc.request(ctx, "prepare", prepareInput{
+		AppRoot:         paths.FS(p.App.Root()),
+		JSRuntimeRoot:   jsRuntimePath,
+		RuntimeVersion:  version.Version,
+		UseLocalRuntime: p.Build.UseLocalJSRuntime,
 	})
-	_, _ = stdin.Write([]byte("parse\n"))
-	if _, err := stdin.Write(input); err != nil {
-		return nil, fmt.Errorf("unable to write to stdin: %s", err)
-	}
-
-	isSuccess, parseResp, err := readResp(stdout)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response: %s", err)
-	} else if !isSuccess {
-		return nil, errors.New(string(parseResp))
+		_ = cmd.Cancel()
+		return nil, err
 	}
+	return proc, nil
+}
 
-	var md metav1.Data
-	if err := proto.Unmarshal(parseResp, &md); err != nil {
-		return nil, fmt.Errorf("unable to parse app: %s", err)
-	}
+// reuseParser returns the parser kept running by an earlier incremental parse,
+// if it's still running and was started for the same app and options.
+//
+// The parser only re-parses the source files that changed since the
+// earlier parse, so it's not reused if the app's configuration changed.
+func (i *BuilderImpl) reuseParser(p builder.ParseParams, jsRuntimePath paths.FS) *parserProc {
+	i.mu.Lock()
+	proc := i.parser
+	i.mu.Unlock()
 
-	data := &data{
-		cmd:    cmd,
-		stdin:  stdin,
-		stdout: stdout,
+	if proc == nil || !proc.running() {
+		return nil
+	} else if proc.key != parserKey(p, jsRuntimePath) || proc.configs != configState(p.App.Root()) {
+		return nil
 	}
+	return proc
+}
+
+// parserKey identifies the app and options a parser is started with.
+func parserKey(p builder.ParseParams, jsRuntimePath paths.FS) string {
+	return strings.Join(append([]string{
+		p.App.Root(),
+		p.WorkingDir,
+		jsRuntimePath.ToIO(),
+		p.Build.Revision,
+		strconv.FormatBool(p.Build.UseLocalJSRuntime),
+	}, p.Build.Environ...), "\x00")
+}
 
-	return &builder.ParseResult{Meta: &md, Data: data}, nil
+// configFiles are the files other than the source files that affect how the app is parsed.
+var configFiles = []string{
+	"package.json",
+	"tsconfig.json",
+	"package-lock.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"bun.lock",
+	"bun.lockb",
+}
+
+// configState describes the state of the app's config files.
+func configState(appRoot string) string {
+	var b strings.Builder
+	for _, name := range configFiles {
+		if fi, err := os.Stat(filepath.Join(appRoot, name)); err == nil {
+			fmt.Fprintf(&b, "%s:%d:%d;", name, fi.Size(), fi.ModTime().UnixNano())
+		}
+	}
+	return b.String()
 }
 
 type compileInput struct {
 	RuntimeVersion  string            `json:"runtime_version"`
 	UseLocalRuntime bool              `json:"use_local_runtime"`
 	Debug           builder.DebugMode `json:"debug"`
+	JSRuntime       builder.JSRuntime `json:"js_runtime,omitempty"`
 }
 
 func (i *BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*builder.CompileResult, error) {
 	data := p.Parse.Data.(*data)
 
-	input, _ := json.Marshal(compileInput{
+	compileResp, err := data.proc.request(ctx, "compile", compileInput{
 		RuntimeVersion:  version.Version,
 		UseLocalRuntime: p.Build.UseLocalJSRuntime,
 		Debug:           p.Build.DebugMode,
+		JSRuntime:       p.Build.JSRuntime,
 	})
-
-	_, _ = data.stdin.Write([]byte("compile\n"))
-	if _, err := data.stdin.Write(input); err != nil {
-		return nil, fmt.Errorf("unable to write to stdin: %s", err)
-	}
-
-	isSuccess, compileResp, err := readResp(data.stdout)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response: %s", err)
-	} else if !isSuccess {
-		return nil, errors.New(string(compileResp))
+		return nil, err
 	}
 
 	var res struct {
@@ -267,21 +331,12 @@ func (i *BuilderImpl) RunTests(ctx context.Context, p builder.RunTestsParams) er
 func (i *BuilderImpl) TestSpec(ctx context.Context, p builder.TestSpecParams) (*builder.TestSpecResult, error) {
 	data := p.Compile.Parse.Data.(*data)
 
-	input, _ := json.Marshal(testInput{
+	testResp, err := data.proc.request(ctx, "test", testInput{
 		RuntimeVersion:  version.Version,
 		UseLocalRuntime: p.Compile.Build.UseLocalJSRuntime,
 	})
-
-	_, _ = data.stdin.Write([]byte("test\n"))
-	if _, err := data.stdin.Write(input); err != nil {
-		return nil, fmt.Errorf("unable to write to stdin: %s", err)
-	}
-
-	isSuccess, testResp, err := readResp(data.stdout)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read response: %s", err)
-	} else if !isSuccess {
-		return nil, errors.New(string(testResp))
+		return nil, err
 	}
 
 	var res struct {
@@ -316,20 +371,8 @@ func (i *BuilderImpl) TestSpec(ctx context.Context, p builder.TestSpecParams) (*
 func (i *BuilderImpl) GenUserFacing(ctx context.Context, p builder.GenUserFacingParams) error {
 	data := p.Parse.Data.(*data)
 
-	input, _ := json.Marshal(genUserFacingInput{})
-
-	_, _ = data.stdin.Write([]byte("gen-user-facing\n"))
-	if _, err := data.stdin.Write(input); err != nil {
-		return fmt.Errorf("unable to write to stdin: %s", err)
-	}
-
-	isSuccess, genUserFacingRep, err := readResp(data.stdout)
-	if err != nil {
-		return fmt.Errorf("unable to read response: %s", err)
-	} else if !isSuccess {
-		return errors.New(string(genUserFacingRep))
-	}
-	return nil
+	_, err := data.proc.request(ctx, "gen-user-facing", genUserFacingInput{})
+	return err
 }
 
 type genUserFacingInput struct {
@@ -342,6 +385,55 @@ type prepareInput struct {
 	UseLocalRuntime bool     `json:"use_local_runtime"`
 }
 
+// parserProc is a running parser.
+type parserProc struct {
+	rc     *runningCmd
+	stdin  io.Writer
+	stdout io.Reader
+
+	// key identifies the app and options the parser was started with,
+	// and configs the state of the app's config files at the time.
+	key     string
+	configs string
+
+	// mu serializes the requests to the parser.
+	mu sync.Mutex
+}
+
+// request sends a command to the parser and returns its response.
+// If ctx is canceled before the response is read, the parser is stopped.
+func (p *parserProc) request(ctx context.Context, command string, input any) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() { _ = p.rc.cmd.Cancel() })
+	defer stop()
+
+	data, _ := json.Marshal(input)
+	_, _ = p.stdin.Write([]byte(command + "\n"))
+	if _, err := p.stdin.Write(data); err != nil {
+		return nil, fmt.Errorf("unable to write to stdin: %s", err)
+	}
+
+	isSuccess, resp, err := readResp(p.stdout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response: %s", err)
+	} else if !isSuccess {
+		return nil, errors.New(string(resp))
+	}
+	return resp, nil
+}
+
+// running reports whether the parser is still running.
+func (p *parserProc) running() bool {
+	select {
+	case <-p.rc.done:
+		return false
+	default:
+		return true
+	}
+}
+
 func readResp(reader io.Reader) (isSuccess bool, data []byte, err error) {
 	var respLen uint32
 	if err := binary.Read(reader, binary.LittleEndian, &respLen); err != nil {
@@ -20,6 +20,7 @@ import (
 	"encr.dev/internal/env"
 	"encr.dev/internal/etrace"
 	"encr.dev/internal/version"
+	"encr.dev/pkg/apiversion"
 	"encr.dev/pkg/builder"
 	"encr.dev/pkg/cueutil"
 	"encr.dev/pkg/fns"
@@ -82,6 +83,8 @@ func (BuilderImpl) Parse(ctx context.Context, p builder.ParseParams) (*builder.P
 			FS:            fset,
 			ParseTests:    p.ParseTests,
 			Errs:          errs,
+
+			DetectHardcodedSecrets: p.DetectHardcodedSecrets,
 		}
 
 		parser := parser.NewParser(pc)
@@ -136,6 +139,7 @@ func (BuilderImpl) Compile(ctx context.Context, p builder.CompileParams) (*build
 			CompilerVersion:   p.EncoreVersion.GetOrElse(fmt.Sprintf("EncoreCLI/%s", version.Version)),
 			AppRevision:       p.Build.Revision,
 			AppUncommitted:    p.Build.UncommittedChanges,
+			APIVersion:        apiversion.Fingerprint(p.Parse.Meta),
 			ExecScriptMainPkg: p.Build.MainPkg,
 		})
 
@@ -312,6 +316,7 @@ func (i BuilderImpl) generateTestSpec(ctx context.Context, p builder.TestSpecPar
 				CompilerVersion: p.Compile.EncoreVersion.GetOrElse(fmt.Sprintf("EncoreCLI/%s", version.Version)),
 				AppRevision:     p.Compile.Build.Revision,
 				AppUncommitted:  p.Compile.Build.UncommittedChanges,
+				APIVersion:      apiversion.Fingerprint(p.Compile.Parse.Meta),
 				Test:            option.Some(testCfg),
 			})
 		})